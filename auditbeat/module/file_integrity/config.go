@@ -22,6 +22,7 @@ import (
 	"math"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
 	"strings"
 
@@ -72,6 +73,25 @@ const (
 	XXH64       HashType = "xxh64"
 )
 
+// Backend identifies the mechanism used to receive file change notifications.
+type Backend string
+
+// Unpack unpacks a string to a Backend for config parsing.
+func (b *Backend) Unpack(v string) error {
+	*b = Backend(strings.ToLower(v))
+	return nil
+}
+
+// Enum of backends.
+const (
+	// BackendFSNotify uses the notification API of the operating system,
+	// inotify on Linux.
+	BackendFSNotify Backend = "fsnotify"
+	// BackendFanotify uses fanotify (Linux only), which reports the process
+	// that made each change.
+	BackendFanotify Backend = "fanotify"
+)
+
 // Config contains the configuration parameters for the file integrity
 // metricset.
 type Config struct {
@@ -86,6 +106,7 @@ type Config struct {
 	Recursive           bool            `config:"recursive"` // Recursive enables recursive monitoring of directories.
 	ExcludeFiles        []match.Matcher `config:"exclude_files"`
 	IncludeFiles        []match.Matcher `config:"include_files"`
+	Backend             Backend         `config:"backend"`
 }
 
 // Validate validates the config data and return an error explaining all the
@@ -160,6 +181,16 @@ nextHash:
 	if err != nil {
 		errs = append(errs, fmt.Errorf("invalid scan_rate_per_sec value: %w", err))
 	}
+
+	switch c.Backend {
+	case BackendFSNotify:
+	case BackendFanotify:
+		if runtime.GOOS != "linux" {
+			errs = append(errs, fmt.Errorf("backend '%v' is only supported on Linux", c.Backend))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid backend value '%v'", c.Backend))
+	}
 	return errs.Err()
 }
 
@@ -208,4 +239,5 @@ var defaultConfig = Config{
 	MaxFileSizeBytes: 100 * 1024 * 1024,
 	ScanAtStart:      true,
 	ScanRatePerSec:   "50 MiB",
+	Backend:          BackendFSNotify,
 }
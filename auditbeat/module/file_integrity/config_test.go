@@ -22,6 +22,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp/syntax"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/joeshaw/multierror"
@@ -135,6 +137,31 @@ func TestConfigInvalidMaxFileSize(t *testing.T) {
 	t.Fatal("expected error")
 }
 
+func TestConfigBackend(t *testing.T) {
+	for backend, valid := range map[string]bool{
+		"fsnotify": true,
+		"FANOTIFY": runtime.GOOS == "linux",
+		"ebpf":     false,
+	} {
+		config, err := conf.NewConfigFrom(map[string]interface{}{
+			"paths":   []string{"/usr/bin"},
+			"backend": backend,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := defaultConfig
+		err = config.Unpack(&c)
+		if valid {
+			assert.NoError(t, err, backend)
+			assert.EqualValues(t, strings.ToLower(backend), c.Backend)
+		} else {
+			assert.Error(t, err, backend)
+		}
+	}
+}
+
 func TestConfigEvalSymlinks(t *testing.T) {
 	dir := setupTestDir(t)
 	defer os.RemoveAll(dir)
@@ -118,6 +118,7 @@ type Event struct {
 	Action        Action              `json:"action"`                // Action (like created, updated).
 	Hashes        map[HashType]Digest `json:"hash,omitempty"`        // File hashes.
 	ParserResults mapstr.M            `json:"file,omitempty"`        // Results from runnimg file parsers.
+	Process       *Process            `json:"process,omitempty"`     // Process that made the change (fanotify only).
 
 	// Metadata
 	rtt        time.Duration // Time taken to collect the info.
@@ -143,6 +144,19 @@ type Metadata struct {
 	Origin []string    `json:"origin"` // External origin info for the file (MacOS only)
 }
 
+// Process contains information about the process that made a change. Only the
+// PID is certain to be known, the process may have exited before the rest of
+// its information was collected.
+type Process struct {
+	PID        int    `json:"pid"`
+	Name       string `json:"name,omitempty"`
+	Executable string `json:"executable,omitempty"`
+	UID        string `json:"uid,omitempty"` // Effective user ID.
+	User       string `json:"user,omitempty"`
+	GID        string `json:"gid,omitempty"` // Effective group ID.
+	Group      string `json:"group,omitempty"`
+}
+
 // NewEventFromFileInfo creates a new Event based on data from a os.FileInfo
 // object that has already been created. Any errors that occur are included in
 // the returned Event.
@@ -332,6 +346,38 @@ func buildMetricbeatEvent(e *Event, existedBefore bool) mb.Event {
 		file[k] = v
 	}
 
+	if p := e.Process; p != nil {
+		process := mapstr.M{
+			"pid": p.PID,
+		}
+		if p.Name != "" {
+			process["name"] = p.Name
+		}
+		if p.Executable != "" {
+			process["executable"] = p.Executable
+		}
+		out.MetricSetFields["process"] = process
+
+		if p.UID != "" {
+			user := mapstr.M{
+				"id": p.UID,
+			}
+			if p.User != "" {
+				user["name"] = p.User
+			}
+			if p.GID != "" {
+				group := mapstr.M{
+					"id": p.GID,
+				}
+				if p.Group != "" {
+					group["name"] = p.Group
+				}
+				user["group"] = group
+			}
+			out.MetricSetFields["user"] = user
+		}
+	}
+
 	out.MetricSetFields.Put("event.kind", "event")              //nolint:errcheck // Will not error.
 	out.MetricSetFields.Put("event.category", []string{"file"}) //nolint:errcheck // Will not error.
 	if e.Action > 0 {
@@ -0,0 +1,460 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package file_integrity
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/joeshaw/multierror"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// fanotifyMask contains the events reported by the fanotify backend.
+// FAN_ONDIR and FAN_EVENT_ON_CHILD extend them to directories and to the
+// entries of the marked directories.
+const fanotifyMask = unix.FAN_CREATE | unix.FAN_DELETE | unix.FAN_DELETE_SELF |
+	unix.FAN_MOVED_FROM | unix.FAN_MOVED_TO | unix.FAN_MODIFY | unix.FAN_ATTRIB |
+	unix.FAN_ONDIR | unix.FAN_EVENT_ON_CHILD
+
+// fanotifyEventInfoHeader is struct fanotify_event_info_header.
+type fanotifyEventInfoHeader struct {
+	InfoType uint8
+	Pad      uint8
+	Len      uint16
+}
+
+// fanotifyEventInfoFID is struct fanotify_event_info_fid without its header,
+// up to the file handle bytes. The entry name follows the file handle in
+// FAN_EVENT_INFO_TYPE_DFID_NAME records.
+type fanotifyEventInfoFID struct {
+	Fsid        unix.Fsid
+	HandleBytes uint32
+	HandleType  int32
+}
+
+const (
+	sizeofEventInfoHeader = int(unsafe.Sizeof(fanotifyEventInfoHeader{}))
+	sizeofEventInfoFID    = int(unsafe.Sizeof(fanotifyEventInfoFID{}))
+)
+
+type fanotifyReader struct {
+	config  Config
+	log     *logp.Logger
+	parsers []FileParser
+
+	fd       int
+	fanotify *os.File
+	eventC   chan Event
+
+	// mountFDs holds a file descriptor for each marked file system, needed
+	// to open the file handles reported by fanotify.
+	mountFDs map[unix.Fsid]int
+	// dirs holds the paths of the marked directories by file handle, used
+	// for the handles that can't be opened anymore, as those of deleted
+	// directories.
+	dirs map[string]string
+}
+
+func newFanotifyReader(c Config) (EventProducer, error) {
+	return &fanotifyReader{
+		config:   c,
+		log:      logp.NewLogger(moduleName),
+		parsers:  FileParsers(c),
+		mountFDs: map[unix.Fsid]int{},
+		dirs:     map[string]string{},
+	}, nil
+}
+
+func (r *fanotifyReader) Start(done <-chan struct{}) (<-chan Event, error) {
+	// FAN_REPORT_DFID_NAME reports the directory and entry name of the
+	// changed files. It requires Linux 5.9.
+	fd, err := unix.FanotifyInit(
+		unix.FAN_CLASS_NOTIF|unix.FAN_CLOEXEC|unix.FAN_NONBLOCK|unix.FAN_REPORT_DFID_NAME,
+		unix.O_RDONLY|unix.O_LARGEFILE|unix.O_CLOEXEC)
+	if err != nil {
+		switch {
+		case errors.Is(err, unix.EPERM):
+			return nil, fmt.Errorf("fanotify backend requires the CAP_SYS_ADMIN capability: %w", err)
+		case errors.Is(err, unix.EINVAL):
+			return nil, fmt.Errorf("fanotify backend requires Linux 5.9 or newer: %w", err)
+		default:
+			return nil, fmt.Errorf("failed to initialize fanotify: %w", err)
+		}
+	}
+	r.fd = fd
+	r.fanotify = os.NewFile(uintptr(fd), "fanotify")
+
+	// Events that happen while the marks are being added are queued by
+	// the kernel.
+	for _, p := range r.config.Paths {
+		if _, err := r.addPath(p, r.config.Recursive); err != nil {
+			r.log.Warnw("Failed to add watch", "file_path", p, "error", err)
+		}
+	}
+
+	r.eventC = make(chan Event, 1)
+	go r.consumeEvents(done)
+
+	r.log.Infow("Started fanotify watcher",
+		"file_path", r.config.Paths,
+		"recursive", r.config.Recursive)
+	return r.eventC, nil
+}
+
+// addPath marks path, and all the directories under it when recursive is set.
+// It returns the paths found under path while marking it.
+func (r *fanotifyReader) addPath(path string, recursive bool) ([]string, error) {
+	if !recursive {
+		return nil, r.mark(path)
+	}
+
+	var paths []string
+	var errs multierror.Errors
+	err := filepath.Walk(path, func(p string, info os.FileInfo, fnErr error) error {
+		if r.config.IsExcludedPath(p) {
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if fnErr != nil {
+			errs = append(errs, fmt.Errorf("error walking path '%s': %w", p, fnErr))
+			// If FileInfo is not nil, the directory entry can be processed
+			// even if there was some error
+			if info == nil {
+				return nil
+			}
+		}
+		if p != path {
+			paths = append(paths, p)
+		}
+		if info.IsDir() || p == path {
+			if err := r.mark(p); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to walk path '%s': %w", path, err))
+	}
+	return paths, errs.Err()
+}
+
+// mark adds a fanotify mark to path, and keeps what's needed to resolve the
+// file handles reported by its events.
+func (r *fanotifyReader) mark(path string) error {
+	if err := unix.FanotifyMark(r.fd, unix.FAN_MARK_ADD, fanotifyMask, unix.AT_FDCWD, path); err != nil {
+		return fmt.Errorf("failed to add fanotify mark to '%s': %w", path, err)
+	}
+
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return fmt.Errorf("failed to get file system of '%s': %w", path, err)
+	}
+	if _, found := r.mountFDs[stat.Fsid]; !found {
+		fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("failed to open '%s': %w", path, err)
+		}
+		r.mountFDs[stat.Fsid] = fd
+	}
+
+	// Events are reported with the handle of the directory containing the
+	// changed entry, or of the marked directory itself.
+	dir := path
+	if info, err := os.Lstat(path); err == nil && !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+	handle, _, err := unix.NameToHandleAt(unix.AT_FDCWD, dir, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get file handle of '%s': %w", dir, err)
+	}
+	r.dirs[fileHandleKey(stat.Fsid, handle)] = dir
+
+	r.log.Debugw("Added fanotify mark", "file_path", path)
+	return nil
+}
+
+func (r *fanotifyReader) consumeEvents(done <-chan struct{}) {
+	defer close(r.eventC)
+	defer r.close()
+
+	go func() {
+		<-done
+		// Unblock the pending read.
+		r.fanotify.SetReadDeadline(time.Now()) //nolint:errcheck // The reader is closing.
+	}()
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.fanotify.Read(buf)
+		if err != nil {
+			select {
+			case <-done:
+				r.log.Debug("fanotify reader terminated")
+				return
+			default:
+			}
+			r.log.Errorw("Failed to read fanotify events", "error", err)
+			return
+		}
+
+		for _, ev := range r.parseEvents(buf[:n]) {
+			select {
+			case r.eventC <- ev:
+			case <-done:
+				r.log.Debug("fanotify reader terminated")
+				return
+			}
+		}
+	}
+}
+
+func (r *fanotifyReader) close() {
+	r.fanotify.Close()
+	for _, fd := range r.mountFDs {
+		unix.Close(fd)
+	}
+}
+
+// parseEvents parses the events read from fanotify.
+func (r *fanotifyReader) parseEvents(buf []byte) []Event {
+	var events []Event
+	for len(buf) >= unix.FAN_EVENT_METADATA_LEN {
+		meta := (*unix.FanotifyEventMetadata)(unsafe.Pointer(&buf[0]))
+		if meta.Vers != unix.FANOTIFY_METADATA_VERSION {
+			r.log.Errorw("Unexpected fanotify metadata version", "version", meta.Vers)
+			break
+		}
+		if meta.Event_len < unix.FAN_EVENT_METADATA_LEN || int(meta.Event_len) > len(buf) ||
+			meta.Metadata_len > uint16(meta.Event_len) {
+			r.log.Errorw("Invalid fanotify event length", "length", meta.Event_len)
+			break
+		}
+		info := buf[meta.Metadata_len:meta.Event_len]
+		buf = buf[meta.Event_len:]
+
+		if meta.Fd >= 0 {
+			// Not expected when reporting file handles.
+			unix.Close(int(meta.Fd))
+		}
+		if meta.Mask&unix.FAN_Q_OVERFLOW != 0 {
+			r.log.Warn("fanotify event queue overflow, some file changes were not reported")
+			continue
+		}
+
+		path, ok := r.eventPath(info)
+		if !ok {
+			continue
+		}
+		events = append(events, r.newEvents(path, meta.Mask, int(meta.Pid))...)
+	}
+	return events
+}
+
+// eventPath returns the path of the entry changed in an event, from the
+// information records that follow its metadata.
+func (r *fanotifyReader) eventPath(info []byte) (string, bool) {
+	for len(info) >= sizeofEventInfoHeader {
+		hdr := (*fanotifyEventInfoHeader)(unsafe.Pointer(&info[0]))
+		if int(hdr.Len) < sizeofEventInfoHeader || int(hdr.Len) > len(info) {
+			break
+		}
+		record := info[sizeofEventInfoHeader:hdr.Len]
+		info = info[hdr.Len:]
+
+		if hdr.InfoType != unix.FAN_EVENT_INFO_TYPE_DFID_NAME && hdr.InfoType != unix.FAN_EVENT_INFO_TYPE_DFID {
+			continue
+		}
+		if len(record) < sizeofEventInfoFID {
+			break
+		}
+		fid := (*fanotifyEventInfoFID)(unsafe.Pointer(&record[0]))
+		end := sizeofEventInfoFID + int(fid.HandleBytes)
+		if end > len(record) {
+			break
+		}
+		handle := unix.NewFileHandle(fid.HandleType, record[sizeofEventInfoFID:end])
+
+		var name string
+		if hdr.InfoType == unix.FAN_EVENT_INFO_TYPE_DFID_NAME {
+			name = string(bytes.TrimRight(record[end:], "\x00"))
+		}
+
+		dir, found := r.resolve(fid.Fsid, handle)
+		if !found {
+			r.log.Debugw("Failed to resolve the directory of a fanotify event", "file_name", name)
+			return "", false
+		}
+		if name == "" || name == "." {
+			return dir, true
+		}
+		return filepath.Join(dir, name), true
+	}
+	r.log.Debug("fanotify event without directory information")
+	return "", false
+}
+
+// resolve returns the path of a directory file handle.
+func (r *fanotifyReader) resolve(fsid unix.Fsid, handle unix.FileHandle) (string, bool) {
+	if mountFD, found := r.mountFDs[fsid]; found {
+		if fd, err := unix.OpenByHandleAt(mountFD, handle, unix.O_PATH|unix.O_CLOEXEC); err == nil {
+			path, err := os.Readlink("/proc/self/fd/" + strconv.Itoa(fd))
+			unix.Close(fd)
+			if err == nil && !strings.HasSuffix(path, " (deleted)") {
+				return path, true
+			}
+		}
+	}
+	path, found := r.dirs[fileHandleKey(fsid, handle)]
+	return path, found
+}
+
+// newEvents returns the events for a change to path. When recursive is set,
+// directories created or moved in are marked and events are returned for their
+// entries too.
+func (r *fanotifyReader) newEvents(path string, mask uint64, pid int) []Event {
+	if r.config.IsExcludedPath(path) || !r.config.IsIncludedPath(path) {
+		return nil
+	}
+	r.log.Debugw("Received fanotify event",
+		"file_path", path,
+		"event_flags", fmt.Sprintf("%#x", mask),
+		"process.pid", pid)
+
+	// Collect the process information first, as it can exit anytime.
+	process := processInfo(pid)
+
+	paths := []string{path}
+	if r.config.Recursive && mask&unix.FAN_ONDIR != 0 && mask&(unix.FAN_CREATE|unix.FAN_MOVED_TO) != 0 {
+		found, err := r.addPath(path, true)
+		if err != nil {
+			r.log.Warnw("Failed to add watch", "file_path", path, "error", err)
+		}
+		paths = append(paths, found...)
+	}
+
+	events := make([]Event, 0, len(paths))
+	action := fanotifyMaskToAction(mask)
+	for _, p := range paths {
+		if r.config.IsExcludedPath(p) || !r.config.IsIncludedPath(p) {
+			continue
+		}
+		start := time.Now()
+		e := NewEvent(p, action, SourceFSNotify,
+			r.config.MaxFileSizeBytes, r.config.HashTypes, r.parsers)
+		e.Process = process
+		e.rtt = time.Since(start)
+		events = append(events, e)
+
+		// The entries of a new directory are created.
+		action = Created
+	}
+	return events
+}
+
+func fanotifyMaskToAction(mask uint64) Action {
+	var action Action
+	if mask&(unix.FAN_CREATE|unix.FAN_MOVED_TO) != 0 {
+		action |= Created
+	}
+	if mask&(unix.FAN_DELETE|unix.FAN_DELETE_SELF) != 0 {
+		action |= Deleted
+	}
+	if mask&unix.FAN_MOVED_FROM != 0 {
+		action |= Moved
+	}
+	if mask&unix.FAN_MODIFY != 0 {
+		action |= Updated
+	}
+	if mask&unix.FAN_ATTRIB != 0 {
+		action |= AttributesModified
+	}
+	return action
+}
+
+func fileHandleKey(fsid unix.Fsid, handle unix.FileHandle) string {
+	return fmt.Sprintf("%x:%x:%x:%x", fsid.Val[0], fsid.Val[1], handle.Type(), handle.Bytes())
+}
+
+// processInfo returns the information available about the process with the
+// given PID.
+func processInfo(pid int) *Process {
+	if pid <= 0 {
+		return nil
+	}
+
+	process := &Process{PID: pid}
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+	if comm, err := os.ReadFile(filepath.Join(procDir, "comm")); err == nil {
+		process.Name = strings.TrimSuffix(string(comm), "\n")
+	}
+	if exe, err := os.Readlink(filepath.Join(procDir, "exe")); err == nil {
+		process.Executable = strings.TrimSuffix(exe, " (deleted)")
+	}
+
+	status, err := os.Open(filepath.Join(procDir, "status"))
+	if err != nil {
+		return process
+	}
+	defer status.Close()
+
+	// The Uid and Gid lines list the real, effective, saved set and file
+	// system IDs.
+	scanner := bufio.NewScanner(status)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		ids := strings.Fields(value)
+		if len(ids) < 2 {
+			continue
+		}
+		switch key {
+		case "Uid":
+			process.UID = ids[1]
+			if u, err := user.LookupId(process.UID); err == nil {
+				process.User = u.Username
+			}
+		case "Gid":
+			process.GID = ids[1]
+			if g, err := user.LookupGroupId(process.GID); err == nil {
+				process.Group = g.Name
+			}
+		}
+	}
+	return process
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package file_integrity
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+)
+
+func TestFanotifyEventReader(t *testing.T) {
+	dir := t.TempDir()
+	dir, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+
+	config := defaultConfig
+	config.Paths = []string{dir}
+	config.Recursive = true
+	config.Backend = BackendFanotify
+	config.ExcludeFiles = []match.Matcher{match.MustCompile(`\.swp$`)}
+	r, err := NewEventReader(config)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+	events, err := r.Start(done)
+	if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EINVAL) {
+		t.Skipf("fanotify backend not available: %v", err)
+	}
+	require.NoError(t, err)
+
+	// findEvent reads events until one for path with the given action
+	// is found.
+	findEvent := func(t *testing.T, path string, action Action) Event {
+		for {
+			e := readTimeout(t, events)
+			if e.Path == path && e.Action&action != 0 {
+				return e
+			}
+		}
+	}
+
+	txt := filepath.Join(dir, "test.txt")
+	mustRun(t, "created by this process", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(txt, []byte("hello"), 0o640))
+
+		e := findEvent(t, txt, Created)
+		if assert.NotNil(t, e.Process) {
+			assert.Equal(t, os.Getpid(), e.Process.PID)
+			assert.NotEmpty(t, e.Process.Name)
+			assert.NotEmpty(t, e.Process.Executable)
+			assert.Equal(t, strconv.Itoa(os.Geteuid()), e.Process.UID)
+			assert.Equal(t, strconv.Itoa(os.Getegid()), e.Process.GID)
+		}
+		assert.NotNil(t, e.Info)
+		assert.NotEmpty(t, e.Hashes)
+	})
+
+	mustRun(t, "updated by another process", func(t *testing.T) {
+		cmd := exec.Command("sh", "-c", "echo world >> "+txt)
+		require.NoError(t, cmd.Run())
+
+		e := findEvent(t, txt, Updated)
+		if assert.NotNil(t, e.Process) {
+			assert.Equal(t, cmd.Process.Pid, e.Process.PID)
+		}
+
+		fields := buildMetricbeatEvent(&e, true).MetricSetFields
+		pid, _ := fields.GetValue("process.pid")
+		assert.Equal(t, cmd.Process.Pid, pid)
+	})
+
+	subdir := filepath.Join(dir, "subdir")
+	nested := filepath.Join(subdir, "nested.txt")
+	mustRun(t, "recursive", func(t *testing.T) {
+		require.NoError(t, os.Mkdir(subdir, 0o750))
+		findEvent(t, subdir, Created)
+
+		require.NoError(t, os.WriteFile(nested, []byte("hello"), 0o640))
+		findEvent(t, nested, Created)
+	})
+
+	moved := filepath.Join(dir, "moved.txt")
+	mustRun(t, "moved", func(t *testing.T) {
+		rename(t, nested, moved)
+		findEvent(t, nested, Moved)
+		findEvent(t, moved, Created)
+	})
+
+	mustRun(t, "attributes modified", func(t *testing.T) {
+		require.NoError(t, os.Chmod(moved, 0o600))
+		findEvent(t, moved, AttributesModified)
+	})
+
+	mustRun(t, "deleted", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(subdir))
+		e := findEvent(t, subdir, Deleted)
+		assert.Nil(t, e.Info)
+	})
+
+	mustRun(t, "excluded", func(t *testing.T) {
+		swp := filepath.Join(dir, "test.txt.swp")
+		require.NoError(t, os.WriteFile(swp, []byte("hello"), 0o640))
+		require.NoError(t, os.Remove(moved))
+
+		for {
+			e := readTimeout(t, events)
+			assert.NotEqual(t, swp, e.Path)
+			if e.Path == moved && e.Action&Deleted != 0 {
+				break
+			}
+		}
+	})
+}
+
+func TestFanotifyMaskToAction(t *testing.T) {
+	assert.EqualValues(t, Created, fanotifyMaskToAction(unix.FAN_CREATE|unix.FAN_ONDIR))
+	assert.EqualValues(t, Created, fanotifyMaskToAction(unix.FAN_MOVED_TO))
+	assert.EqualValues(t, Moved, fanotifyMaskToAction(unix.FAN_MOVED_FROM))
+	assert.EqualValues(t, Deleted, fanotifyMaskToAction(unix.FAN_DELETE_SELF))
+	assert.EqualValues(t, Created|Updated|AttributesModified,
+		fanotifyMaskToAction(unix.FAN_CREATE|unix.FAN_MODIFY|unix.FAN_ATTRIB))
+}
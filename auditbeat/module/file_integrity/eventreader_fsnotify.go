@@ -42,8 +42,13 @@ type reader struct {
 	parsers []FileParser
 }
 
-// NewEventReader creates a new EventProducer backed by fsnotify.
+// NewEventReader creates a new EventProducer backed by fsnotify, or by fanotify
+// if it's the configured backend.
 func NewEventReader(c Config) (EventProducer, error) {
+	if c.Backend == BackendFanotify {
+		return newFanotifyReader(c)
+	}
+
 	return &reader{
 		config:  c,
 		log:     logp.NewLogger(moduleName),
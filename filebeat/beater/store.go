@@ -23,8 +23,11 @@ import (
 	"github.com/elastic/beats/v7/filebeat/config"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend/bbolt"
 	"github.com/elastic/beats/v7/libbeat/statestore/backend/memlog"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/elastic-agent-libs/paths"
 )
 
@@ -35,16 +38,27 @@ type filebeatStore struct {
 }
 
 func openStateStore(info beat.Info, logger *logp.Logger, cfg config.Registry) (*filebeatStore, error) {
-	memlog, err := memlog.New(logger, memlog.Settings{
-		Root:     paths.Resolve(paths.Data, cfg.Path),
-		FileMode: cfg.Permissions,
-	})
+	var registry backend.Registry
+	var err error
+	switch cfg.Type {
+	case "bbolt":
+		registry, err = bbolt.New(logger, bbolt.Settings{
+			Root:     paths.Resolve(paths.Data, cfg.Path),
+			FileMode: cfg.Permissions,
+			Metrics:  storeMetrics(),
+		})
+	default:
+		registry, err = memlog.New(logger, memlog.Settings{
+			Root:     paths.Resolve(paths.Data, cfg.Path),
+			FileMode: cfg.Permissions,
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	return &filebeatStore{
-		registry:      statestore.NewRegistry(memlog),
+		registry:      statestore.NewRegistry(registry),
 		storeName:     info.Beat,
 		cleanInterval: cfg.CleanInterval,
 	}, nil
@@ -61,3 +75,16 @@ func (s *filebeatStore) Access() (*statestore.Store, error) {
 func (s *filebeatStore) CleanupInterval() time.Duration {
 	return s.cleanInterval
 }
+
+// storeMetrics returns the registry of the state store metrics, reported
+// under registrar.store.
+func storeMetrics() *monitoring.Registry {
+	registrar := monitoring.Default.GetRegistry("registrar")
+	if registrar == nil {
+		registrar = monitoring.Default.NewRegistry("registrar")
+	}
+	if reg := registrar.GetRegistry("store"); reg != nil {
+		return reg
+	}
+	return registrar.NewRegistry("store")
+}
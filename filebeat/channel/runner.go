@@ -58,6 +58,7 @@ type commonInputConfig struct {
 	// Output meta data settings
 	Pipeline string                   `config:"pipeline"` // ES Ingest pipeline name
 	Index    fmtstr.EventFormatString `config:"index"`    // ES output index pattern
+	Priority int                      `config:"priority"` // memory queue priority lane
 }
 
 func (f *onCreateFactory) CheckConfig(cfg *conf.C) error {
@@ -82,6 +83,7 @@ func (f *onCreateFactory) Create(pipeline beat.PipelineConnector, cfg *conf.C) (
 //  - *_ fileset_name* (hiddrn setting):
 //  - *pipeline*: Configure the ES Ingest Node pipeline name to be used for events from this input
 //  - *index*: Configure the index name for events to be collected from this input
+//  - *priority*: Configure the memory queue priority lane for events from this input
 //  - *type*: implicit event type
 //  - *service.type*: implicit event type
 func RunnerFactoryWithCommonInputSettings(info beat.Info, f cfgfile.RunnerFactory) cfgfile.RunnerFactory {
@@ -152,6 +154,9 @@ func newCommonConfigEditor(
 		fields := clientCfg.Processing.Fields.Clone()
 
 		setOptional(meta, "pipeline", config.Pipeline)
+		if config.Priority > 0 {
+			meta.Put("priority", config.Priority)
+		}
 		setOptional(fields, "fileset.name", config.Fileset)
 		setOptional(fields, "service.type", serviceType)
 		setOptional(fields, "input.type", config.Type)
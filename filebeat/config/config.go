@@ -51,6 +51,7 @@ type Config struct {
 }
 
 type Registry struct {
+	Type          string        `config:"type"`
 	Path          string        `config:"path"`
 	Permissions   os.FileMode   `config:"file_permissions"`
 	FlushTimeout  time.Duration `config:"flush"`
@@ -58,8 +59,19 @@ type Registry struct {
 	MigrateFile   string        `config:"migrate_file"`
 }
 
+// Validate checks the registry backend type is supported.
+func (r *Registry) Validate() error {
+	switch r.Type {
+	case "memlog", "bbolt":
+		return nil
+	default:
+		return fmt.Errorf("unsupported registry type '%v', must be one of: memlog, bbolt", r.Type)
+	}
+}
+
 var DefaultConfig = Config{
 	Registry: Registry{
+		Type:          "memlog",
 		Path:          "registry",
 		Permissions:   0o600,
 		MigrateFile:   "",
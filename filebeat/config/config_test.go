@@ -193,3 +193,16 @@ func TestEnabledInputs(t *testing.T) {
 		}
 	})
 }
+
+func TestRegistryType(t *testing.T) {
+	for _, typ := range []string{"memlog", "bbolt"} {
+		config := DefaultConfig
+		err := conf.MustNewConfigFrom(map[string]interface{}{"registry.type": typ}).Unpack(&config)
+		assert.NoError(t, err)
+		assert.Equal(t, typ, config.Registry.Type)
+	}
+
+	config := DefaultConfig
+	err := conf.MustNewConfigFrom(map[string]interface{}{"registry.type": "sqlite"}).Unpack(&config)
+	assert.Error(t, err)
+}
@@ -66,6 +66,7 @@ type readerConfig struct {
 	Backoff        backoffConfig           `config:"backoff"`
 	BufferSize     int                     `config:"buffer_size"`
 	Encoding       string                  `config:"encoding"`
+	GzipFiles      bool                    `config:"gzip_files"`
 	ExcludeLines   []match.Matcher         `config:"exclude_lines"`
 	IncludeLines   []match.Matcher         `config:"include_lines"`
 	LineTerminator readfile.LineTerminator `config:"line_terminator"`
@@ -125,6 +126,7 @@ func defaultReaderConfig() readerConfig {
 			Max:  10 * time.Second,
 		},
 		BufferSize:     16 * humanize.KiByte,
+		GzipFiles:      false,
 		LineTerminator: readfile.AutoLineTerminator,
 		MaxBytes:       10 * humanize.MiByte,
 		Tail:           false,
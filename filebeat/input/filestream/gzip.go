@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// gzipMagic are the leading bytes of every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isGzipFile reports whether the file starts with the gzip magic bytes.
+// The file offset is not modified.
+func isGzipFile(f *os.File) (bool, error) {
+	magic := make([]byte, len(gzipMagic))
+	_, err := f.ReadAt(magic, 0)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	return bytes.Equal(magic, gzipMagic), nil
+}
+
+// gzipHead returns the first n decompressed bytes of a gzip file, so the
+// encoding can be detected from them. The file offset is not modified.
+func gzipHead(f *os.File, n int64) (*bytes.Reader, error) {
+	gz, err := gzip.NewReader(io.NewSectionReader(f, 0, math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	head, err := io.ReadAll(io.LimitReader(gz, n))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return bytes.NewReader(head), nil
+}
+
+// gzipReader decompresses a gzip file on the fly. Offsets into gzip files
+// refer to the decompressed stream, thus resuming from an offset requires
+// decompressing and discarding everything before it.
+type gzipReader struct {
+	gz  *gzip.Reader
+	src io.ReadCloser
+}
+
+func newGzipReader(src io.ReadCloser, offset int64) (*gzipReader, error) {
+	gz, err := gzip.NewReader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gzip header: %w", err)
+	}
+
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, gz, offset); err != nil {
+			return nil, fmt.Errorf("failed to skip to offset %d of the decompressed stream: %w", offset, err)
+		}
+	}
+
+	return &gzipReader{gz: gz, src: src}, nil
+}
+
+func (r *gzipReader) Read(buf []byte) (int, error) {
+	return r.gz.Read(buf)
+}
+
+func (r *gzipReader) Close() error {
+	r.gz.Close()
+	return r.src.Close()
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsGzipFile(t *testing.T) {
+	testCases := map[string]struct {
+		content  []byte
+		expected bool
+	}{
+		"empty file": {
+			content:  []byte{},
+			expected: false,
+		},
+		"plain file": {
+			content:  []byte("first log line\n"),
+			expected: false,
+		},
+		"gzip file": {
+			content:  gzipBytes(t, []byte("first log line\n")),
+			expected: true,
+		},
+	}
+
+	for name, test := range testCases {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			f := writeTempFile(t, test.content)
+
+			compressed, err := isGzipFile(f)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, compressed)
+
+			// detecting the compression must not move the file offset
+			offset, err := f.Seek(0, io.SeekCurrent)
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), offset)
+		})
+	}
+}
+
+func TestGzipReader(t *testing.T) {
+	content := []byte("first log line\nsecond log line\n")
+
+	testCases := map[string]struct {
+		offset      int64
+		expected    []byte
+		expectedErr bool
+	}{
+		"from the beginning": {
+			offset:   0,
+			expected: content,
+		},
+		"from offset in the decompressed stream": {
+			offset:   15,
+			expected: []byte("second log line\n"),
+		},
+		"from the end": {
+			offset:   int64(len(content)),
+			expected: []byte{},
+		},
+		"offset beyond the end": {
+			offset:      int64(len(content)) + 1,
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range testCases {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			f := writeTempFile(t, gzipBytes(t, content))
+
+			r, err := newGzipReader(f, test.offset)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer r.Close()
+
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, data)
+		})
+	}
+}
+
+func TestGzipHead(t *testing.T) {
+	f := writeTempFile(t, gzipBytes(t, []byte("first log line\n")))
+
+	head, err := gzipHead(f, 4)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(head)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("firs"), data)
+
+	offset, err := f.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+}
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	w := gzip.NewWriter(buf)
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func writeTempFile(t *testing.T, content []byte) *os.File {
+	path := filepath.Join(t.TempDir(), "test.log")
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
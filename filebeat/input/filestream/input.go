@@ -168,7 +168,7 @@ func initState(log *logp.Logger, c loginp.Cursor, s fileSource) state {
 }
 
 func (inp *filestream) open(log *logp.Logger, canceler input.Canceler, fs fileSource, offset int64) (reader.Reader, error) {
-	f, err := inp.openFile(log, fs.newPath, offset)
+	f, compressed, err := inp.openFile(log, fs.newPath, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -176,9 +176,9 @@ func (inp *filestream) open(log *logp.Logger, canceler input.Canceler, fs fileSo
 	log.Debug("newLogFileReader with config.MaxBytes:", inp.readerConfig.MaxBytes)
 
 	// if the file is archived, it means that it is not going to be updated in the future
-	// thus, when EOF is reached, it can be closed
+	// thus, when EOF is reached, it can be closed. The same applies to compressed files.
 	closerCfg := inp.closerConfig
-	if fs.archived && !inp.closerConfig.Reader.OnEOF {
+	if (fs.archived || compressed) && !inp.closerConfig.Reader.OnEOF {
 		closerCfg = closerConfig{
 			Reader: readerCloserConfig{
 				OnEOF:         true,
@@ -195,7 +195,16 @@ func (inp *filestream) open(log *logp.Logger, canceler input.Canceler, fs fileSo
 		return nil, err
 	}
 
-	dbgReader, err := debug.AppendReaders(logReader)
+	var src io.ReadCloser = logReader
+	if compressed {
+		src, err = newGzipReader(logReader, offset)
+		if err != nil {
+			logReader.Close()
+			return nil, fmt.Errorf("failed to decompress %s: %w", fs.newPath, err)
+		}
+	}
+
+	dbgReader, err := debug.AppendReaders(src)
 	if err != nil {
 		f.Close()
 		return nil, err
@@ -233,56 +242,75 @@ func (inp *filestream) open(log *logp.Logger, canceler input.Canceler, fs fileSo
 // openFile opens a file and checks for the encoding. In case the encoding cannot be detected
 // or the file cannot be opened because for example of failing read permissions, an error
 // is returned and the harvester is closed. The file will be picked up again the next time
-// the file system is scanned
-func (inp *filestream) openFile(log *logp.Logger, path string, offset int64) (*os.File, error) {
+// the file system is scanned. If gzip_files is enabled, the returned flag reports whether
+// the file is gzip compressed and must be decompressed while reading.
+func (inp *filestream) openFile(log *logp.Logger, path string, offset int64) (*os.File, bool, error) {
 	fi, err := os.Stat(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to stat source file %s: %w", path, err)
+		return nil, false, fmt.Errorf("failed to stat source file %s: %w", path, err)
 	}
 
 	// it must be checked if the file is not a named pipe before we try to open it
 	// if it is a named pipe os.OpenFile fails, so there is no need to try opening it.
 	if fi.Mode()&os.ModeNamedPipe != 0 {
-		return nil, fmt.Errorf("failed to open file %s, named pipes are not supported", fi.Name())
+		return nil, false, fmt.Errorf("failed to open file %s, named pipes are not supported", fi.Name())
 	}
 
 	ok := false
 	f, err := file.ReadOpen(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed opening %s: %w", path, err)
+		return nil, false, fmt.Errorf("failed opening %s: %w", path, err)
 	}
 	defer cleanup.IfNot(&ok, cleanup.IgnoreError(f.Close))
 
 	fi, err = f.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("failed to stat source file %s: %w", path, err)
+		return nil, false, fmt.Errorf("failed to stat source file %s: %w", path, err)
 	}
 
 	err = checkFileBeforeOpening(fi)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	if fi.Size() < offset {
-		log.Infof("File was truncated. Reading file from offset 0. Path=%s", path)
-		offset = 0
+	compressed := false
+	if inp.readerConfig.GzipFiles {
+		compressed, err = isGzipFile(f)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to detect compression of %s: %w", path, err)
+		}
 	}
-	err = inp.initFileOffset(f, offset)
-	if err != nil {
-		return nil, err
+
+	// the offset of compressed files refers to the decompressed stream,
+	// it is applied by the gzip reader
+	var encodingSrc io.Reader = f
+	if compressed {
+		encodingSrc, err = gzipHead(f, 4)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+	} else {
+		if fi.Size() < offset {
+			log.Infof("File was truncated. Reading file from offset 0. Path=%s", path)
+			offset = 0
+		}
+		err = inp.initFileOffset(f, offset)
+		if err != nil {
+			return nil, false, err
+		}
 	}
 
-	inp.encoding, err = inp.encodingFactory(f)
+	inp.encoding, err = inp.encodingFactory(encodingSrc)
 	if err != nil {
 		f.Close()
 		if errors.Is(err, transform.ErrShortSrc) {
-			return nil, fmt.Errorf("initialising encoding for '%v' failed due to file being too short", f)
+			return nil, false, fmt.Errorf("initialising encoding for '%v' failed due to file being too short", f)
 		}
-		return nil, fmt.Errorf("initialising encoding for '%v' failed: %w", f, err)
+		return nil, false, fmt.Errorf("initialising encoding for '%v' failed: %w", f, err)
 	}
 	ok = true
 
-	return f, nil
+	return f, compressed, nil
 }
 
 func checkFileBeforeOpening(fi os.FileInfo) error {
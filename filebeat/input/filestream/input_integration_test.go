@@ -22,6 +22,7 @@ package filestream
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"os"
@@ -335,6 +336,38 @@ func TestFilestreamUTF16BOMs(t *testing.T) {
 	}
 }
 
+func TestFilestreamGzipFile(t *testing.T) {
+	env := newInputTestingEnvironment(t)
+
+	testlogName := "test.log.1.gz"
+	inp := env.mustCreateInput(map[string]interface{}{
+		"id":                                "fake-ID",
+		"paths":                             []string{env.abspath(testlogName)},
+		"prospector.scanner.check_interval": "1ms",
+		"gzip_files":                        true,
+	})
+
+	testlines := []byte("first log line\nsecond log line\n")
+	buf := bytes.NewBuffer(nil)
+	writer := gzip.NewWriter(buf)
+	writer.Write(testlines)
+	writer.Close()
+
+	env.mustWriteLinesToFile(testlogName, buf.Bytes())
+
+	ctx, cancelInput := context.WithCancel(context.Background())
+	env.startInput(ctx, inp)
+
+	env.waitUntilEventCount(2)
+	env.requireEventsReceived([]string{"first log line", "second log line"})
+
+	cancelInput()
+	env.waitUntilInputStops()
+
+	// the offset refers to the decompressed stream
+	env.requireOffsetInRegistry(testlogName, "fake-ID", len(testlines))
+}
+
 // test_close_timeout from test_harvester.py
 func TestFilestreamCloseTimeout(t *testing.T) {
 	env := newInputTestingEnvironment(t)
@@ -67,6 +67,12 @@ type config struct {
 	// Identifiers stores the syslog identifiers to watch.
 	Identifiers []string `config:"syslog_identifiers"`
 
+	// Priorities stores the syslog priorities to watch.
+	Priorities []journalfield.Priority `config:"priorities"`
+
+	// Routes split the entries into streams that are annotated differently.
+	Routes []routeConfig `config:"routes"`
+
 	// SaveRemoteHostname defines if the original source of the entry needs to be saved.
 	SaveRemoteHostname bool `config:"save_remote_hostname"`
 
@@ -74,6 +80,31 @@ type config struct {
 	Parsers parser.Config `config:",inline"`
 }
 
+// routeConfig selects a subset of the entries read by the input, and
+// annotates them so they can be processed separately.
+type routeConfig struct {
+	// Matches store the key value pairs to match entries.
+	Matches journalfield.IncludeMatches `config:"include_matches"`
+
+	// Units stores the units to match.
+	Units []string `config:"units"`
+
+	// Transports stores the transports to match.
+	Transports []string `config:"transports"`
+
+	// Identifiers stores the syslog identifiers to match.
+	Identifiers []string `config:"syslog_identifiers"`
+
+	// Priorities stores the syslog priorities to match.
+	Priorities []journalfield.Priority `config:"priorities"`
+
+	// Tags are added to the entries of the route.
+	Tags []string `config:"tags"`
+
+	// Dataset is set as the event.dataset of the entries of the route.
+	Dataset string `config:"dataset"`
+}
+
 // bwcIncludeMatches is a wrapper that accepts include_matches configuration
 // from 7.x to allow old config to remain compatible.
 type bwcIncludeMatches journalfield.IncludeMatches
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/filebeat/input/journald/pkg/journalfield"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
@@ -63,3 +64,38 @@ include_matches:
 		verify(t, yaml)
 	})
 }
+
+func TestConfigRoutes(t *testing.T) {
+	const yaml = `
+priorities: [err, 4]
+routes:
+- syslog_identifiers: [sshd]
+  priorities: ["0", crit]
+  tags: [ssh]
+  dataset: journald.ssh
+- include_matches.match: [systemd.unit=nginx.service]
+  dataset: journald.nginx
+`
+	c, err := conf.NewConfigWithYAML([]byte(yaml), "source")
+	require.NoError(t, err)
+
+	config := defaultConfig()
+	require.NoError(t, c.Unpack(&config))
+
+	assert.Equal(t, []journalfield.Priority{3, 4}, config.Priorities)
+	require.Len(t, config.Routes, 2)
+	assert.Equal(t, []string{"sshd"}, config.Routes[0].Identifiers)
+	assert.Equal(t, []journalfield.Priority{0, 2}, config.Routes[0].Priorities)
+	assert.Equal(t, []string{"ssh"}, config.Routes[0].Tags)
+	assert.Equal(t, "journald.ssh", config.Routes[0].Dataset)
+	assert.Equal(t, "_SYSTEMD_UNIT=nginx.service", config.Routes[1].Matches.Matches[0].String())
+	assert.Equal(t, "journald.nginx", config.Routes[1].Dataset)
+
+	t.Run("invalid priority", func(t *testing.T) {
+		c, err := conf.NewConfigWithYAML([]byte("priorities: [8]"), "source")
+		require.NoError(t, err)
+
+		config := defaultConfig()
+		assert.Error(t, c.Unpack(&config))
+	})
+}
@@ -21,6 +21,7 @@
 package journald
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/coreos/go-systemd/v22/sdjournal"
@@ -36,6 +37,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 type journald struct {
@@ -47,10 +49,19 @@ type journald struct {
 	Units              []string
 	Transports         []string
 	Identifiers        []string
+	Priorities         []journalfield.Priority
+	Routes             []route
 	SaveRemoteHostname bool
 	Parsers            parser.Config
 }
 
+// route annotates the journal entries matching its filter.
+type route struct {
+	filter  journalfield.Filter
+	tags    []string
+	dataset string
+}
+
 type checkpoint struct {
 	Version            int
 	Position           string
@@ -102,6 +113,15 @@ func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
 		sources[i] = pathSource(p)
 	}
 
+	routes := make([]route, len(config.Routes))
+	for i, rc := range config.Routes {
+		filter, err := journalfield.NewFilter(rc.Matches, rc.Units, rc.Transports, rc.Identifiers, rc.Priorities)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid filter for route %d: %w", i, err)
+		}
+		routes[i] = route{filter: filter, tags: rc.Tags, dataset: rc.Dataset}
+	}
+
 	return sources, &journald{
 		Backoff:            config.Backoff,
 		MaxBackoff:         config.MaxBackoff,
@@ -111,6 +131,8 @@ func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
 		Units:              config.Units,
 		Transports:         config.Transports,
 		Identifiers:        config.Identifiers,
+		Priorities:         config.Priorities,
+		Routes:             routes,
 		SaveRemoteHostname: config.SaveRemoteHostname,
 		Parsers:            config.Parsers,
 	}, nil
@@ -151,6 +173,7 @@ func (inp *journald) Run(
 			converter:          journalfield.NewConverter(ctx.Logger, nil),
 			canceler:           ctx.Cancelation,
 			saveRemoteHostname: inp.SaveRemoteHostname,
+			routes:             inp.Routes,
 		})
 
 	for {
@@ -169,7 +192,8 @@ func (inp *journald) Run(
 func (inp *journald) open(log *logp.Logger, canceler input.Canceler, src cursor.Source) (*journalread.Reader, error) {
 	backoff := backoff.NewExpBackoff(canceler.Done(), inp.Backoff, inp.MaxBackoff)
 	reader, err := journalread.Open(log, src.Name(), backoff,
-		withFilters(inp.Matches), withUnits(inp.Units), withTransports(inp.Transports), withSyslogIdentifiers(inp.Identifiers))
+		withFilters(inp.Matches), withUnits(inp.Units), withTransports(inp.Transports), withSyslogIdentifiers(inp.Identifiers),
+		withPriorities(inp.Priorities))
 	if err != nil {
 		return nil, sderr.Wrap(err, "failed to create reader for %{path} journal", src.Name())
 	}
@@ -221,6 +245,12 @@ func withSyslogIdentifiers(identifiers []string) func(*sdjournal.Journal) error
 	}
 }
 
+func withPriorities(priorities []journalfield.Priority) func(*sdjournal.Journal) error {
+	return func(j *sdjournal.Journal) error {
+		return journalfield.ApplyPriorityMatcher(j, priorities)
+	}
+}
+
 // seekBy tries to find the last known position in the journal, so we can continue collecting
 // from the last known position.
 // The checkpoint is ignored if the user has configured the input to always
@@ -237,15 +267,17 @@ func seekBy(log *logp.Logger, cp checkpoint, seek, defaultSeek journalread.SeekM
 	return mode, cp.Position
 }
 
-// readerAdapter wraps journalread.Reader and adds two functionalities:
+// readerAdapter wraps journalread.Reader and adds three functionalities:
 // - Allows it to behave like a reader.Reader
 // - Translates the fields names from the journald format to something
 //   more human friendly
+// - Annotates the entries according to the first matching route
 type readerAdapter struct {
 	r                  *journalread.Reader
 	canceler           input.Canceler
 	converter          *journalfield.Converter
 	saveRemoteHostname bool
+	routes             []route
 }
 
 func (r *readerAdapter) Close() error {
@@ -253,9 +285,19 @@ func (r *readerAdapter) Close() error {
 }
 
 func (r *readerAdapter) Next() (reader.Message, error) {
-	data, err := r.r.Next(r.canceler)
-	if err != nil {
-		return reader.Message{}, err
+	var data *sdjournal.JournalEntry
+	var selected *route
+	for {
+		var err error
+		data, err = r.r.Next(r.canceler)
+		if err != nil {
+			return reader.Message{}, err
+		}
+
+		var ok bool
+		if selected, ok = r.route(data.Fields); ok {
+			break
+		}
 	}
 
 	created := time.Now()
@@ -276,6 +318,15 @@ func (r *readerAdapter) Next() (reader.Message, error) {
 		}
 	}
 
+	if selected != nil {
+		if len(selected.tags) > 0 {
+			mapstr.AddTags(fields, selected.tags)
+		}
+		if selected.dataset != "" {
+			fields.Put("event.dataset", selected.dataset)
+		}
+	}
+
 	m := reader.Message{
 		Ts:      time.UnixMicro(int64(data.RealtimeTimestamp)),
 		Content: content,
@@ -291,3 +342,19 @@ func (r *readerAdapter) Next() (reader.Message, error) {
 
 	return m, nil
 }
+
+// route returns the first route matching the fields of a journal entry. If no
+// routes are configured all entries are accepted without a route, otherwise
+// entries matching none of the routes are skipped.
+func (r *readerAdapter) route(fields map[string]string) (*route, bool) {
+	if len(r.routes) == 0 {
+		return nil, true
+	}
+
+	for i := range r.routes {
+		if r.routes[i].filter.Match(fields) {
+			return &r.routes[i], true
+		}
+	}
+	return nil, false
+}
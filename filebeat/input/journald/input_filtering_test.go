@@ -23,6 +23,7 @@ package journald
 import (
 	"context"
 	"path"
+	"reflect"
 	"testing"
 
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -216,3 +217,55 @@ func TestInputIncludeMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestInputRoutes(t *testing.T) {
+	env := newInputTestingEnvironment(t)
+	inp := env.mustCreateInput(mapstr.M{
+		"paths": []string{path.Join("testdata", "input-multiline-parser.journal")},
+		"routes": []mapstr.M{
+			{
+				"syslog_identifiers": []string{"sudo"},
+				"tags":               []string{"auth"},
+				"dataset":            "journald.auth",
+			},
+			{
+				"include_matches.match": []string{"syslog.identifier=systemd"},
+				"dataset":               "journald.systemd",
+			},
+		},
+	})
+
+	ctx, cancelInput := context.WithCancel(context.Background())
+	env.startInput(ctx, inp)
+	defer cancelInput()
+
+	env.waitUntilEventCount(2)
+
+	expected := []struct {
+		message string
+		dataset string
+		tags    interface{}
+	}{
+		{
+			message: "pam_unix(sudo:session): session closed for user root",
+			dataset: "journald.auth",
+			tags:    []string{"auth"},
+		},
+		{
+			message: "Started Outputs some log lines.",
+			dataset: "journald.systemd",
+		},
+	}
+
+	for idx, event := range env.pipeline.clients[0].GetEvents() {
+		if got := event.Fields["message"]; got != expected[idx].message {
+			t.Fatalf("expecting event message %q, got %q", expected[idx].message, got)
+		}
+		if got, _ := event.Fields.GetValue("event.dataset"); got != expected[idx].dataset {
+			t.Errorf("expecting event dataset %q, got %q", expected[idx].dataset, got)
+		}
+		if got, _ := event.Fields.GetValue("tags"); expected[idx].tags != nil && !reflect.DeepEqual(got, expected[idx].tags) {
+			t.Errorf("expecting event tags %v, got %v", expected[idx].tags, got)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package journalfield
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Priority is a syslog priority level. It can be configured by its number or by
+// its name, as accepted by journalctl.
+type Priority int
+
+var priorityNames = map[string]Priority{
+	"emerg":   0,
+	"alert":   1,
+	"crit":    2,
+	"err":     3,
+	"warning": 4,
+	"notice":  5,
+	"info":    6,
+	"debug":   7,
+}
+
+// Unpack initializes the Priority from its number or name.
+func (p *Priority) Unpack(value interface{}) error {
+	var level int64
+	switch v := value.(type) {
+	case int64:
+		level = v
+	case uint64:
+		level = int64(v)
+	case string:
+		if named, ok := priorityNames[v]; ok {
+			*p = named
+			return nil
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid priority '%s'", v)
+		}
+		level = n
+	default:
+		return fmt.Errorf("invalid priority '%v'", value)
+	}
+
+	if level < 0 || level > 7 {
+		return fmt.Errorf("priority %d out of range, must be between 0 and 7", level)
+	}
+	*p = Priority(level)
+	return nil
+}
+
+func priorityMatchers(priorities []Priority) []Matcher {
+	matchers := make([]Matcher, len(priorities))
+	for i, priority := range priorities {
+		matchers[i] = MustBuildMatcher("syslog.priority=" + strconv.Itoa(int(priority)))
+	}
+	return matchers
+}
+
+// Filter selects journal entries after reading them, with the same conditions
+// the journal reader can be configured with. A Filter without conditions
+// matches all entries.
+type Filter struct {
+	matches IncludeMatches
+
+	// conditions must all hold for an entry to match. A condition holds if
+	// the entry matches all the matchers of one of its groups.
+	conditions [][][]Matcher
+}
+
+// NewFilter creates a Filter matching the entries that satisfy the include
+// matches, and that belong to one of the units, transports, syslog
+// identifiers and priorities if they are set.
+func NewFilter(matches IncludeMatches, units, transports, identifiers []string, priorities []Priority) (Filter, error) {
+	f := Filter{matches: matches}
+
+	if len(units) > 0 {
+		var condition [][]Matcher
+		for _, unit := range units {
+			groups, err := unitMatchers(unit)
+			if err != nil {
+				return Filter{}, err
+			}
+			condition = append(condition, groups...)
+		}
+		f.conditions = append(f.conditions, condition)
+	}
+
+	if len(transports) > 0 {
+		var condition [][]Matcher
+		for _, transport := range transports {
+			m, err := BuildMatcher("_TRANSPORT=" + transport)
+			if err != nil {
+				return Filter{}, err
+			}
+			condition = append(condition, []Matcher{m})
+		}
+		f.conditions = append(f.conditions, condition)
+	}
+
+	if len(identifiers) > 0 {
+		var condition [][]Matcher
+		for _, identifier := range identifiers {
+			m, err := BuildMatcher("syslog.identifier=" + identifier)
+			if err != nil {
+				return Filter{}, err
+			}
+			condition = append(condition, []Matcher{m})
+		}
+		f.conditions = append(f.conditions, condition)
+	}
+
+	if len(priorities) > 0 {
+		var condition [][]Matcher
+		for _, m := range priorityMatchers(priorities) {
+			condition = append(condition, []Matcher{m})
+		}
+		f.conditions = append(f.conditions, condition)
+	}
+
+	return f, nil
+}
+
+// Match returns true if the fields of a journal entry satisfy all the
+// conditions of the filter.
+func (f Filter) Match(fields map[string]string) bool {
+	if !f.matches.match(fields) {
+		return false
+	}
+
+	for _, condition := range f.conditions {
+		found := false
+		for _, group := range condition {
+			if matchAll(group, fields) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// match evaluates the include matches like the journal does: matches on the
+// same field are connected with a disjunction and matches on different fields
+// with a conjunction. All the `and` groups and one of the `or` groups must
+// match as well.
+func (m IncludeMatches) match(fields map[string]string) bool {
+	byField := map[string][]Matcher{}
+	for _, matcher := range m.Matches {
+		key := matcher.field()
+		byField[key] = append(byField[key], matcher)
+	}
+	for _, matchers := range byField {
+		if !matchAny(matchers, fields) {
+			return false
+		}
+	}
+
+	for _, and := range m.AND {
+		if !and.match(fields) {
+			return false
+		}
+	}
+
+	if len(m.OR) == 0 {
+		return true
+	}
+	for _, or := range m.OR {
+		if or.match(fields) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAll(matchers []Matcher, fields map[string]string) bool {
+	for _, m := range matchers {
+		if !m.Matches(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(matchers []Matcher, fields map[string]string) bool {
+	for _, m := range matchers {
+		if m.Matches(fields) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux && cgo
+// +build linux,cgo
+
+package journalfield
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatch(t *testing.T) {
+	sshd := map[string]string{
+		"_SYSTEMD_UNIT":     "sshd.service",
+		"SYSLOG_IDENTIFIER": "sshd",
+		"PRIORITY":          "3",
+		"_TRANSPORT":        "syslog",
+	}
+	coredump := map[string]string{
+		"MESSAGE_ID":    "fc2e22bc6ee647b6b90729ab34a250b1",
+		"_UID":          "0",
+		"COREDUMP_UNIT": "sshd.service",
+		"PRIORITY":      "2",
+	}
+
+	cases := map[string]struct {
+		matches     IncludeMatches
+		units       []string
+		transports  []string
+		identifiers []string
+		priorities  []Priority
+		entry       map[string]string
+		expected    bool
+	}{
+		"no conditions": {
+			entry:    sshd,
+			expected: true,
+		},
+		"matching unit": {
+			units:    []string{"nginx.service", "sshd.service"},
+			entry:    sshd,
+			expected: true,
+		},
+		"coredump of unit": {
+			units:    []string{"sshd.service"},
+			entry:    coredump,
+			expected: true,
+		},
+		"other unit": {
+			units:    []string{"nginx.service"},
+			entry:    sshd,
+			expected: false,
+		},
+		"matching identifier and priority": {
+			identifiers: []string{"sshd"},
+			priorities:  []Priority{2, 3},
+			entry:       sshd,
+			expected:    true,
+		},
+		"matching identifier, other priority": {
+			identifiers: []string{"sshd"},
+			priorities:  []Priority{6},
+			entry:       sshd,
+			expected:    false,
+		},
+		"other transport": {
+			transports: []string{"kernel"},
+			entry:      sshd,
+			expected:   false,
+		},
+		"same field matches are a disjunction": {
+			matches: IncludeMatches{Matches: []Matcher{
+				MustBuildMatcher("systemd.unit=nginx.service"),
+				MustBuildMatcher("systemd.unit=sshd.service"),
+			}},
+			entry:    sshd,
+			expected: true,
+		},
+		"different field matches are a conjunction": {
+			matches: IncludeMatches{Matches: []Matcher{
+				MustBuildMatcher("systemd.unit=sshd.service"),
+				MustBuildMatcher("syslog.identifier=sudo"),
+			}},
+			entry:    sshd,
+			expected: false,
+		},
+		"or groups": {
+			matches: IncludeMatches{OR: []IncludeMatches{
+				{Matches: []Matcher{MustBuildMatcher("syslog.identifier=sudo")}},
+				{Matches: []Matcher{MustBuildMatcher("syslog.identifier=sshd")}},
+			}},
+			entry:    sshd,
+			expected: true,
+		},
+		"and groups": {
+			matches: IncludeMatches{AND: []IncludeMatches{
+				{Matches: []Matcher{MustBuildMatcher("syslog.identifier=sshd")}},
+				{Matches: []Matcher{MustBuildMatcher("syslog.priority=6")}},
+			}},
+			entry:    sshd,
+			expected: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			filter, err := NewFilter(c.matches, c.units, c.transports, c.identifiers, c.priorities)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, filter.Match(c.entry))
+		})
+	}
+}
+
+func TestPriorityUnpack(t *testing.T) {
+	cases := map[string]struct {
+		value    interface{}
+		expected Priority
+		wantErr  bool
+	}{
+		"name":         {value: "warning", expected: 4},
+		"number":       {value: int64(3), expected: 3},
+		"numeric text": {value: "7", expected: 7},
+		"unknown name": {value: "fatal", wantErr: true},
+		"out of range": {value: uint64(8), wantErr: true},
+		"negative":     {value: int64(-1), wantErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var p Priority
+			err := p.Unpack(c.value)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, p)
+		})
+	}
+}
@@ -95,6 +95,18 @@ func MustBuildMatcher(in string) Matcher {
 	return m
 }
 
+// Matches returns true if the fields of a journal entry satisfy the field match.
+func (m Matcher) Matches(fields map[string]string) bool {
+	key, value, _ := strings.Cut(m.str, "=")
+	v, ok := fields[key]
+	return ok && v == value
+}
+
+func (m Matcher) field() string {
+	key, _, _ := strings.Cut(m.str, "=")
+	return key
+}
+
 // IsValid returns true if the matcher was initialized correctly.
 func (m Matcher) IsValid() bool { return m.str != "" }
 
@@ -146,49 +158,9 @@ func ApplyMatchersOr(j journal, matchers []Matcher) error {
 // https://github.com/systemd/systemd/blob/641e2124de6047e6010cd2925ea22fba29b25309/src/shared/logs-show.c#L1409-L1455
 func ApplyUnitMatchers(j journal, units []string) error {
 	for _, unit := range units {
-		systemdUnit, err := BuildMatcher("systemd.unit=" + unit)
-		if err != nil {
-			return fmt.Errorf("failed to build matcher for _SYSTEMD_UNIT: %+w", err)
-		}
-		coredumpUnit, err := BuildMatcher("journald.coredump.unit=" + unit)
-		if err != nil {
-			return fmt.Errorf("failed to build matcher for COREDUMP_UNIT: %+w", err)
-		}
-		journaldUnit, err := BuildMatcher("journald.unit=" + unit)
+		matchers, err := unitMatchers(unit)
 		if err != nil {
-			return fmt.Errorf("failed to build matcher for UNIT: %+w", err)
-		}
-		journaldObjectUnit, err := BuildMatcher("journald.object.systemd.unit=" + unit)
-		if err != nil {
-			return fmt.Errorf("failed to build matcher for OBJECT_SYSTEMD_UNIT: %+w", err)
-		}
-
-		matchers := [][]Matcher{
-			// match for the messages of the service
-			{
-				systemdUnit,
-			},
-			// match for the coredumps of the service
-			{
-				coreDumpMsgID,
-				journaldUID,
-				coredumpUnit,
-			},
-			// match for messages about the service with PID value of 1
-			{
-				journaldPID,
-				journaldUnit,
-			},
-			// match for messages about the service from authorized daemons
-			{
-				journaldUID,
-				journaldObjectUnit,
-			},
-		}
-		if strings.HasSuffix(unit, ".slice") {
-			if sliceMatcher, err := BuildMatcher("systemd.slice=" + unit); err != nil {
-				matchers = append(matchers, []Matcher{sliceMatcher})
-			}
+			return err
 		}
 
 		for _, m := range matchers {
@@ -202,6 +174,57 @@ func ApplyUnitMatchers(j journal, units []string) error {
 	return nil
 }
 
+// unitMatchers returns the groups of matchers selecting the entries of a unit.
+// An entry belongs to the unit if it matches all the matchers of one of the groups.
+func unitMatchers(unit string) ([][]Matcher, error) {
+	systemdUnit, err := BuildMatcher("systemd.unit=" + unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build matcher for _SYSTEMD_UNIT: %+w", err)
+	}
+	coredumpUnit, err := BuildMatcher("journald.coredump.unit=" + unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build matcher for COREDUMP_UNIT: %+w", err)
+	}
+	journaldUnit, err := BuildMatcher("journald.unit=" + unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build matcher for UNIT: %+w", err)
+	}
+	journaldObjectUnit, err := BuildMatcher("journald.object.systemd.unit=" + unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build matcher for OBJECT_SYSTEMD_UNIT: %+w", err)
+	}
+
+	matchers := [][]Matcher{
+		// match for the messages of the service
+		{
+			systemdUnit,
+		},
+		// match for the coredumps of the service
+		{
+			coreDumpMsgID,
+			journaldUID,
+			coredumpUnit,
+		},
+		// match for messages about the service with PID value of 1
+		{
+			journaldPID,
+			journaldUnit,
+		},
+		// match for messages about the service from authorized daemons
+		{
+			journaldUID,
+			journaldObjectUnit,
+		},
+	}
+	if strings.HasSuffix(unit, ".slice") {
+		if sliceMatcher, err := BuildMatcher("systemd.slice=" + unit); err == nil {
+			matchers = append(matchers, []Matcher{sliceMatcher})
+		}
+	}
+
+	return matchers, nil
+}
+
 // ApplyTransportMatcher adds matchers for the configured transports.
 func ApplyTransportMatcher(j journal, transports []string) error {
 	if len(transports) == 0 {
@@ -232,6 +255,11 @@ func ApplySyslogIdentifierMatcher(j journal, identifiers []string) error {
 	return ApplyMatchersOr(j, identifierMatchers)
 }
 
+// ApplyPriorityMatcher adds syslog priority filtering to the journal reader.
+func ApplyPriorityMatcher(j journal, priorities []Priority) error {
+	return ApplyMatchersOr(j, priorityMatchers(priorities))
+}
+
 // ApplyIncludeMatches adds advanced filtering to journals.
 func ApplyIncludeMatches(j journal, m IncludeMatches) error {
 	for _, or := range m.OR {
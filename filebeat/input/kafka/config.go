@@ -196,11 +196,18 @@ func newSaramaConfig(config kafkaInputConfig) (*sarama.Config, error) {
 			Realm:              config.Kerberos.Realm,
 			DisablePAFXFAST:    !config.Kerberos.EnableFAST,
 		}
+	} else if config.Sasl.IsOAuthBearer() {
+		k.Net.SASL.Enable = true
+		if err := config.Sasl.ConfigureSarama(k); err != nil {
+			return nil, err
+		}
 	} else if config.Username != "" {
 		k.Net.SASL.Enable = true
 		k.Net.SASL.User = config.Username
 		k.Net.SASL.Password = config.Password
-		config.Sasl.ConfigureSarama(k)
+		if err := config.Sasl.ConfigureSarama(k); err != nil {
+			return nil, err
+		}
 	}
 
 	// configure client ID
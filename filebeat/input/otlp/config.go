@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	GRPC           endpointConfig          `config:"grpc"`
+	HTTP           endpointConfig          `config:"http"`
+	TLS            *tlscommon.ServerConfig `config:"ssl"`
+	MaxMessageSize cfgtype.ByteSize        `config:"max_message_size" validate:"positive,nonzero"`
+	ReadTimeout    time.Duration           `config:"read_timeout" validate:"positive"`
+	WriteTimeout   time.Duration           `config:"write_timeout" validate:"positive"`
+}
+
+// endpointConfig is the configuration of one of the protocols of the
+// receiver.
+type endpointConfig struct {
+	Enabled bool   `config:"enabled"`
+	Host    string `config:"host"`
+}
+
+func defaultConfig() config {
+	return config{
+		GRPC: endpointConfig{
+			Enabled: true,
+			Host:    "localhost:4317",
+		},
+		HTTP: endpointConfig{
+			Enabled: true,
+			Host:    "localhost:4318",
+		},
+		MaxMessageSize: 4 * humanize.MiByte,
+		ReadTimeout:    30 * time.Second,
+		WriteTimeout:   30 * time.Second,
+	}
+}
+
+func (c *config) Validate() error {
+	if !c.GRPC.Enabled && !c.HTTP.Enabled {
+		return errors.New("at least one of grpc or http must be enabled")
+	}
+	if c.GRPC.Enabled && c.GRPC.Host == "" {
+		return errors.New("grpc.host must be set")
+	}
+	if c.HTTP.Enabled && c.HTTP.Host == "" {
+		return errors.New("http.host must be set")
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// resourceFields maps the resource attributes of the OpenTelemetry semantic
+// conventions to their ECS fields.
+var resourceFields = map[string]string{
+	"service.name":            "service.name",
+	"service.version":         "service.version",
+	"service.instance.id":     "service.node.name",
+	"deployment.environment":  "service.environment",
+	"host.name":               "host.name",
+	"host.id":                 "host.id",
+	"host.arch":               "host.architecture",
+	"os.type":                 "host.os.type",
+	"os.description":          "host.os.full",
+	"os.version":              "host.os.version",
+	"process.pid":             "process.pid",
+	"process.executable.name": "process.name",
+	"process.executable.path": "process.executable",
+	"process.command_line":    "process.command_line",
+	"container.id":            "container.id",
+	"container.name":          "container.name",
+	"container.image.name":    "container.image.name",
+	"k8s.namespace.name":      "kubernetes.namespace",
+	"k8s.pod.name":            "kubernetes.pod.name",
+	"k8s.pod.uid":             "kubernetes.pod.uid",
+	"k8s.node.name":           "kubernetes.node.name",
+	"k8s.deployment.name":     "kubernetes.deployment.name",
+	"cloud.provider":          "cloud.provider",
+	"cloud.region":            "cloud.region",
+	"cloud.availability_zone": "cloud.availability_zone",
+	"cloud.account.id":        "cloud.account.id",
+}
+
+// attributeFields maps the log record attributes of the OpenTelemetry
+// semantic conventions to their ECS fields.
+var attributeFields = map[string]string{
+	"exception.type":       "error.type",
+	"exception.message":    "error.message",
+	"exception.stacktrace": "error.stack_trace",
+	"code.function":        "log.origin.function",
+	"code.filepath":        "log.origin.file.name",
+	"code.lineno":          "log.origin.file.line",
+	"thread.name":          "process.thread.name",
+	"thread.id":            "process.thread.id",
+	"log.file.path":        "log.file.path",
+}
+
+// toEvents converts the log records of an export request into events. The
+// attributes without ECS equivalent are kept under otlp.resource.attributes
+// and otlp.attributes, with their original names.
+func toEvents(req *collogspb.ExportLogsServiceRequest, now time.Time) []beat.Event {
+	var events []beat.Event
+	for _, resourceLogs := range req.GetResourceLogs() {
+		resource := mapstr.M{}
+		unmapped := mapAttributes(resource, resourceLogs.GetResource().GetAttributes(), resourceFields)
+
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			scope := scopeLogs.GetScope()
+			for _, record := range scopeLogs.GetLogRecords() {
+				fields := resource.Clone()
+				if len(unmapped) > 0 {
+					fields.Put("otlp.resource.attributes", cloneMap(unmapped))
+				}
+				if scope.GetName() != "" {
+					fields.Put("otlp.scope.name", scope.GetName())
+				}
+				if scope.GetVersion() != "" {
+					fields.Put("otlp.scope.version", scope.GetVersion())
+				}
+				events = append(events, toEvent(fields, record, now))
+			}
+		}
+	}
+	return events
+}
+
+func toEvent(fields mapstr.M, record *logspb.LogRecord, now time.Time) beat.Event {
+	if attributes := mapAttributes(fields, record.GetAttributes(), attributeFields); len(attributes) > 0 {
+		fields.Put("otlp.attributes", attributes)
+	}
+
+	if body := record.GetBody(); body != nil {
+		if s, ok := body.GetValue().(*commonpb.AnyValue_StringValue); ok {
+			fields["message"] = s.StringValue
+		} else if message, err := json.Marshal(fromAnyValue(body)); err == nil {
+			fields["message"] = string(message)
+		}
+	}
+
+	level := record.GetSeverityText()
+	if level == "" {
+		level = severityText(record.GetSeverityNumber())
+	}
+	if level != "" {
+		fields.Put("log.level", level)
+	}
+	if number := record.GetSeverityNumber(); number != logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED {
+		fields.Put("event.severity", int(number))
+	}
+
+	if traceID := record.GetTraceId(); len(traceID) > 0 {
+		fields.Put("trace.id", hex.EncodeToString(traceID))
+	}
+	if spanID := record.GetSpanId(); len(spanID) > 0 {
+		fields.Put("span.id", hex.EncodeToString(spanID))
+	}
+
+	timestamp := now
+	if t := record.GetTimeUnixNano(); t != 0 {
+		timestamp = time.Unix(0, int64(t))
+	} else if t := record.GetObservedTimeUnixNano(); t != 0 {
+		timestamp = time.Unix(0, int64(t))
+	}
+	if t := record.GetObservedTimeUnixNano(); t != 0 {
+		fields.Put("event.created", time.Unix(0, int64(t)).UTC())
+	}
+
+	return beat.Event{
+		Timestamp: timestamp.UTC(),
+		Fields:    fields,
+	}
+}
+
+// mapAttributes puts the attributes with an ECS equivalent in fields, and
+// returns the rest by their original names.
+func mapAttributes(fields mapstr.M, attributes []*commonpb.KeyValue, mapping map[string]string) map[string]interface{} {
+	unmapped := map[string]interface{}{}
+	for _, kv := range attributes {
+		value := fromAnyValue(kv.GetValue())
+		if value == nil {
+			continue
+		}
+		if field, ok := mapping[kv.GetKey()]; ok {
+			fields.Put(field, value)
+		} else {
+			unmapped[kv.GetKey()] = value
+		}
+	}
+	return unmapped
+}
+
+// fromAnyValue converts an OTLP value into a field value. It returns nil for
+// empty values.
+func fromAnyValue(value *commonpb.AnyValue) interface{} {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return v.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return v.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return v.BytesValue
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(v.ArrayValue.GetValues()))
+		for _, value := range v.ArrayValue.GetValues() {
+			if value := fromAnyValue(value); value != nil {
+				values = append(values, value)
+			}
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		values := make(map[string]interface{}, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			if value := fromAnyValue(kv.GetValue()); value != nil {
+				values[kv.GetKey()] = value
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// severityText returns the short name of the severity, as used in
+// log.level.
+func severityText(number logspb.SeverityNumber) string {
+	if number == logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED {
+		return ""
+	}
+	// Names are like SEVERITY_NUMBER_WARN2, the number is the position of
+	// the severity in its range.
+	name := strings.TrimPrefix(number.String(), "SEVERITY_NUMBER_")
+	return strings.ToLower(strings.TrimRight(name, "234"))
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	clone := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
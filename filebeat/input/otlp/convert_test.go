@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestToEvents(t *testing.T) {
+	timestamp := time.Date(2022, 10, 11, 12, 13, 14, 0, time.UTC)
+	now := time.Date(2022, 10, 11, 12, 13, 15, 0, time.UTC)
+
+	req := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+				stringKV("service.name", "checkout"),
+				stringKV("host.name", "web-1"),
+				intKV("process.pid", 42),
+				stringKV("telemetry.sdk.language", "go"),
+			}},
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope: &commonpb.InstrumentationScope{Name: "checkout/logger", Version: "1.0.0"},
+				LogRecords: []*logspb.LogRecord{
+					{
+						TimeUnixNano:         uint64(timestamp.UnixNano()),
+						ObservedTimeUnixNano: uint64(now.UnixNano()),
+						SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+						SeverityText:         "ERROR",
+						Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "payment failed"}},
+						Attributes: []*commonpb.KeyValue{
+							stringKV("exception.type", "TimeoutError"),
+							stringKV("order.id", "1234"),
+						},
+						TraceId: []byte{0x5b, 0x8e, 0xfb, 0xf3, 0x4e, 0x9b, 0x3f, 0x5b, 0x7a, 0x1f, 0x8c, 0x2b, 0x4d, 0x6e, 0x8f, 0x9a},
+						SpanId:  []byte{0x05, 0x1a, 0x81, 0x45, 0x5f, 0x2b, 0x3c, 0x4d},
+					},
+					{
+						SeverityNumber: logspb.SeverityNumber_SEVERITY_NUMBER_WARN2,
+						Body: &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{
+							Values: []*commonpb.KeyValue{stringKV("user", "alice"), intKV("attempts", 3)},
+						}}},
+					},
+				},
+			}},
+		}},
+	}
+
+	events := toEvents(req, now)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, timestamp, events[0].Timestamp)
+	assert.Equal(t, mapstr.M{
+		"message": "payment failed",
+		"service": mapstr.M{"name": "checkout"},
+		"host":    mapstr.M{"name": "web-1"},
+		"process": mapstr.M{"pid": int64(42)},
+		"log":     mapstr.M{"level": "ERROR"},
+		"error":   mapstr.M{"type": "TimeoutError"},
+		"event": mapstr.M{
+			"severity": 17,
+			"created":  now,
+		},
+		"trace": mapstr.M{"id": "5b8efbf34e9b3f5b7a1f8c2b4d6e8f9a"},
+		"span":  mapstr.M{"id": "051a81455f2b3c4d"},
+		"otlp": mapstr.M{
+			"resource":   mapstr.M{"attributes": map[string]interface{}{"telemetry.sdk.language": "go"}},
+			"scope":      mapstr.M{"name": "checkout/logger", "version": "1.0.0"},
+			"attributes": map[string]interface{}{"order.id": "1234"},
+		},
+	}, events[0].Fields)
+
+	// Without timestamps the current time is used, non-string bodies are
+	// encoded in JSON, and the level is derived from the severity number.
+	assert.Equal(t, now, events[1].Timestamp)
+	assert.Equal(t, `{"attempts":3,"user":"alice"}`, events[1].Fields["message"])
+	level, _ := events[1].Fields.GetValue("log.level")
+	assert.Equal(t, "warn", level)
+	service, _ := events[1].Fields.GetValue("service.name")
+	assert.Equal(t, "checkout", service)
+}
+
+func TestSeverityText(t *testing.T) {
+	assert.Equal(t, "", severityText(logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED))
+	assert.Equal(t, "trace", severityText(logspb.SeverityNumber_SEVERITY_NUMBER_TRACE))
+	assert.Equal(t, "info", severityText(logspb.SeverityNumber_SEVERITY_NUMBER_INFO4))
+	assert.Equal(t, "fatal", severityText(logspb.SeverityNumber_SEVERITY_NUMBER_FATAL3))
+}
+
+func stringKV(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func intKV(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}}}
+}
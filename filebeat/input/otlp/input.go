@@ -0,0 +1,305 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // Register the gzip compressor.
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	stateless "github.com/elastic/beats/v7/filebeat/input/v2/input-stateless"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+	"github.com/elastic/go-concert/ctxtool"
+)
+
+const (
+	inputName = "otlp"
+
+	logsPath = "/v1/logs"
+
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+)
+
+func Plugin() v2.Plugin {
+	return v2.Plugin{
+		Name:       inputName,
+		Stability:  feature.Experimental,
+		Deprecated: false,
+		Info:       "OTLP logs receiver",
+		Manager:    stateless.NewInputManager(configure),
+	}
+}
+
+func configure(cfg *conf.C) (stateless.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSServerConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &otlpInput{config: config, tls: tlsConfig}, nil
+}
+
+type otlpInput struct {
+	config config
+	tls    *tlscommon.TLSConfig
+}
+
+func (in *otlpInput) Name() string { return inputName }
+
+func (in *otlpInput) Test(_ v2.TestContext) error {
+	grpcListener, httpListener, err := in.listen()
+	if err != nil {
+		return err
+	}
+	closeListeners(grpcListener, httpListener)
+	return nil
+}
+
+func (in *otlpInput) Run(ctx v2.Context, publisher stateless.Publisher) error {
+	log := ctx.Logger
+	log.Info("Starting OTLP input")
+	defer log.Info("OTLP input stopped")
+
+	grpcListener, httpListener, err := in.listen()
+	if err != nil {
+		return err
+	}
+	return in.serve(ctxtool.FromCanceller(ctx.Cancelation), log, grpcListener, httpListener, publisher)
+}
+
+// listen opens the listeners of the enabled protocols, the other ones are nil.
+func (in *otlpInput) listen() (grpcListener, httpListener net.Listener, err error) {
+	if in.config.GRPC.Enabled {
+		grpcListener, err = net.Listen("tcp", in.config.GRPC.Host)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to listen for OTLP/gRPC on %s: %w", in.config.GRPC.Host, err)
+		}
+	}
+	if in.config.HTTP.Enabled {
+		httpListener, err = net.Listen("tcp", in.config.HTTP.Host)
+		if err != nil {
+			closeListeners(grpcListener)
+			return nil, nil, fmt.Errorf("failed to listen for OTLP/HTTP on %s: %w", in.config.HTTP.Host, err)
+		}
+	}
+	return grpcListener, httpListener, nil
+}
+
+func closeListeners(listeners ...net.Listener) {
+	for _, l := range listeners {
+		if l != nil {
+			l.Close()
+		}
+	}
+}
+
+// serve receives the export requests in the given listeners until the context
+// is cancelled. Nil listeners are ignored.
+func (in *otlpInput) serve(parent context.Context, log *logp.Logger, grpcListener, httpListener net.Listener, publisher stateless.Publisher) error {
+	receiver := &receiver{publisher: publisher, log: log}
+
+	// The servers are stopped when ctx is done, on cancellation or when one
+	// of them fails.
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errC := make(chan error, 2)
+
+	if grpcListener != nil {
+		var opts []grpc.ServerOption
+		opts = append(opts, grpc.MaxRecvMsgSize(int(in.config.MaxMessageSize)))
+		if in.tls != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(in.tls.BuildServerConfig(""))))
+		}
+		server := grpc.NewServer(opts...)
+		collogspb.RegisterLogsServiceServer(server, receiver)
+
+		log.Infof("OTLP/gRPC receiver listening on %s", grpcListener.Addr())
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errC <- server.Serve(grpcListener)
+		}()
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			server.GracefulStop()
+		}()
+	}
+
+	if httpListener != nil {
+		if in.tls != nil {
+			httpListener = tls.NewListener(httpListener, in.tls.BuildServerConfig(""))
+		}
+		mux := http.NewServeMux()
+		mux.Handle(logsPath, &httpHandler{receiver: receiver, maxMessageSize: int64(in.config.MaxMessageSize)})
+		server := &http.Server{
+			Handler:      mux,
+			ReadTimeout:  in.config.ReadTimeout,
+			WriteTimeout: in.config.WriteTimeout,
+		}
+
+		log.Infof("OTLP/HTTP receiver listening on %s", httpListener.Addr())
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := server.Serve(httpListener); !errors.Is(err, http.ErrServerClosed) {
+				errC <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errC:
+	}
+	cancel()
+	wg.Wait()
+	if parent.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// receiver publishes the log records of the export requests.
+type receiver struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	publisher stateless.Publisher
+	log       *logp.Logger
+}
+
+func (r *receiver) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	events := toEvents(req, time.Now())
+	for _, event := range events {
+		r.publisher.Publish(event)
+	}
+	r.log.Debugf("Published %d log records", len(events))
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+// httpHandler receives OTLP/HTTP export requests, in binary or JSON
+// protobuf encoding.
+type httpHandler struct {
+	receiver       *receiver
+	maxMessageSize int64
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+	var unmarshal func([]byte, proto.Message) error
+	var marshal func(proto.Message) ([]byte, error)
+	switch contentType {
+	case protobufContentType:
+		unmarshal, marshal = proto.Unmarshal, proto.Marshal
+	case jsonContentType:
+		unmarshal, marshal = protojson.Unmarshal, protojson.Marshal
+	default:
+		http.Error(w, "unsupported content type "+contentType, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, h.maxMessageSize)
+	switch r.Header.Get("Content-Encoding") {
+	case "":
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			http.Error(w, "invalid gzip body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, h.maxMessageSize+1)
+	default:
+		http.Error(w, "unsupported content encoding", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, "failed to read request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if int64(len(data)) > h.maxMessageSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var req collogspb.ExportLogsServiceRequest
+	if err := unmarshal(data, &req); err != nil {
+		http.Error(w, "invalid export request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.receiver.Export(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err = marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
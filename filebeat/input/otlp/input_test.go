@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type publisher struct {
+	mu     sync.Mutex
+	events []beat.Event
+}
+
+func (p *publisher) Publish(event beat.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *publisher) messages() []interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var messages []interface{}
+	for _, event := range p.events {
+		messages = append(messages, event.Fields["message"])
+	}
+	return messages
+}
+
+func TestConfigValidate(t *testing.T) {
+	_, err := configure(conf.MustNewConfigFrom(mapstr.M{
+		"grpc.enabled": false,
+		"http.enabled": false,
+	}))
+	assert.Error(t, err)
+
+	_, err = configure(conf.MustNewConfigFrom(mapstr.M{
+		"grpc.enabled": false,
+		"http.host":    "localhost:0",
+	}))
+	assert.NoError(t, err)
+}
+
+func TestReceiver(t *testing.T) {
+	input := &otlpInput{config: defaultConfig()}
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pub := &publisher{}
+	done := make(chan error)
+	go func() {
+		done <- input.serve(ctx, logp.NewLogger("otlp"), grpcListener, httpListener, pub)
+	}()
+
+	t.Run("grpc", func(t *testing.T) {
+		conn, err := grpc.Dial(grpcListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		client := collogspb.NewLogsServiceClient(conn)
+		_, err = client.Export(ctx, exportRequest("from grpc"))
+		require.NoError(t, err)
+		assert.Contains(t, pub.messages(), "from grpc")
+	})
+
+	url := "http://" + httpListener.Addr().String() + logsPath
+
+	t.Run("http protobuf", func(t *testing.T) {
+		body, err := proto.Marshal(exportRequest("from http protobuf"))
+		require.NoError(t, err)
+
+		resp, err := http.Post(url, protobufContentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, protobufContentType, resp.Header.Get("Content-Type"))
+		assert.Contains(t, pub.messages(), "from http protobuf")
+	})
+
+	t.Run("http json gzip", func(t *testing.T) {
+		body, err := protojson.Marshal(exportRequest("from http json"))
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err = gz.Write(body)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+
+		req, err := http.NewRequest(http.MethodPost, url, &buf)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", jsonContentType)
+		req.Header.Set("Content-Encoding", "gzip")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, pub.messages(), "from http json")
+	})
+
+	t.Run("http invalid requests", func(t *testing.T) {
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+		resp, err = http.Post(url, "text/plain", bytes.NewReader([]byte("hello")))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+		resp, err = http.Post(url, protobufContentType, bytes.NewReader([]byte("invalid")))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the receiver to stop")
+	}
+}
+
+func exportRequest(message string) *collogspb.ExportLogsServiceRequest {
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			ScopeLogs: []*logspb.ScopeLogs{{
+				LogRecords: []*logspb.LogRecord{{
+					Body: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: message}},
+				}},
+			}},
+		}},
+	}
+}
@@ -18,6 +18,9 @@
 package syslog
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +35,7 @@ import (
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
 // Parser is generated from a ragel state machine using the following command:
@@ -276,6 +280,10 @@ func createEvent(ev *event, metadata inputsource.NetworkMetadata, timezone *time
 		f["event.sequence"] = ev.Sequence()
 	}
 
+	if len(ev.data) > 0 {
+		addStructuredDataFields(f, ev.data, ev.Sequence() == -1)
+	}
+
 	return newBeatEvent(ev.Timestamp(timezone), metadata, f)
 }
 
@@ -314,9 +322,68 @@ func newBeatEvent(timestamp time.Time, metadata inputsource.NetworkMetadata, fie
 	if metadata.RemoteAddr != nil {
 		event.Fields.Put("log.source.address", metadata.RemoteAddr.String())
 	}
+	if metadata.TLS != nil {
+		event.Fields["tls"] = tlsFields(metadata.TLS)
+	}
 	return event
 }
 
+// addStructuredDataFields maps the parameters of the structured data elements
+// registered in RFC5424 section 7 to ECS fields.
+func addStructuredDataFields(f mapstr.M, data EventData, setSequence bool) {
+	if origin, ok := data["origin"]; ok {
+		if ip := origin["ip"]; ip != "" {
+			f.Put("host.ip", []string{ip})
+		}
+	}
+
+	if meta, ok := data["meta"]; ok && setSequence {
+		if seq, err := strconv.Atoi(meta["sequenceId"]); err == nil {
+			event, _ := f["event"].(mapstr.M)
+			event["sequence"] = seq
+		}
+	}
+}
+
+// tlsFields returns the ECS fields describing the TLS connection an event has
+// been received on, including the certificate the client authenticated with.
+func tlsFields(md *inputsource.TLSMetadata) mapstr.M {
+	fields := mapstr.M{
+		"established": true,
+	}
+
+	var version tlscommon.TLSVersion
+	if err := version.Unpack(md.TLSVersion); err == nil {
+		if details := version.Details(); details != nil {
+			fields["version"] = details.Version
+			fields["version_protocol"] = details.Protocol
+		}
+	}
+	if md.CipherSuite != "" {
+		fields["cipher"] = md.CipherSuite
+	}
+
+	client := mapstr.M{}
+	if md.ServerName != "" {
+		client["server_name"] = md.ServerName
+	}
+	if cert := md.ClientCertificate; cert != nil {
+		sum := sha256.Sum256(cert.Raw)
+		client["subject"] = cert.Subject.String()
+		client["issuer"] = cert.Issuer.String()
+		client["not_before"] = cert.NotBefore
+		client["not_after"] = cert.NotAfter
+		client["hash"] = mapstr.M{
+			"sha256": strings.ToUpper(hex.EncodeToString(sum[:])),
+		}
+	}
+	if len(client) > 0 {
+		fields["client"] = client
+	}
+
+	return fields
+}
+
 func mapValueToName(v int, m mapper) (string, error) {
 	if v < 0 || v >= len(m) {
 		return "", errors.Errorf("value out of bound: %d", v)
@@ -18,6 +18,8 @@
 package syslog
 
 import (
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net"
 	"testing"
 	"time"
@@ -322,6 +324,43 @@ func TestParseAndCreateEvent5424(t *testing.T) {
 				},
 			},
 		},
+		"registered structured data": {
+			data: []byte(`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [origin ip="192.0.2.1" software="relay"][meta sequenceId="7"] relayed entry`),
+			expected: mapstr.M{
+				"event":    mapstr.M{"severity": 5, "sequence": 7},
+				"hostname": "mymachine.example.com",
+				"host": mapstr.M{
+					"ip": []string{"192.0.2.1"},
+				},
+				"log": mapstr.M{
+					"source": mapstr.M{
+						"address": "127.0.0.1",
+					},
+				},
+				"process": mapstr.M{
+					"name":      "evntslog",
+					"entity_id": "-",
+				},
+				"message": "relayed entry",
+				"syslog": mapstr.M{
+					"facility":       20,
+					"facility_label": "local4",
+					"priority":       165,
+					"severity_label": "Notice",
+					"msgid":          "ID47",
+					"version":        1,
+					"data": EventData{
+						"origin": {
+							"ip":       "192.0.2.1",
+							"software": "relay",
+						},
+						"meta": {
+							"sequenceId": "7",
+						},
+					},
+				},
+			},
+		},
 
 		"invalid data": {
 			data: []byte("<34>Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8"),
@@ -348,3 +387,42 @@ func TestParseAndCreateEvent5424(t *testing.T) {
 		})
 	}
 }
+
+func TestTLSFields(t *testing.T) {
+	cert := &x509.Certificate{
+		Raw:       []byte("certificate"),
+		Subject:   pkix.Name{CommonName: "relay.example.com", Organization: []string{"Example"}},
+		Issuer:    pkix.Name{CommonName: "Example CA"},
+		NotBefore: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	metadata := dummyMetadata()
+	metadata.TLS = &inputsource.TLSMetadata{
+		TLSVersion:        "TLSv1.3",
+		CipherSuite:       "AES-128-GCM-SHA256",
+		ServerName:        "syslog.example.com",
+		ClientCertificate: cert,
+	}
+
+	event := parseAndCreateEvent5424([]byte(RfcDoc65Example3), metadata, time.Local, logp.NewLogger("syslog"))
+
+	tls, err := event.Fields.GetValue("tls")
+	assert.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"established":      true,
+		"version":          "1.3",
+		"version_protocol": "tls",
+		"cipher":           "AES-128-GCM-SHA256",
+		"client": mapstr.M{
+			"server_name": "syslog.example.com",
+			"subject":     "CN=relay.example.com,O=Example",
+			"issuer":      "CN=Example CA",
+			"not_before":  cert.NotBefore,
+			"not_after":   cert.NotAfter,
+			"hash": mapstr.M{
+				"sha256": "03D66DD08835C1CA3F128CCEACD1F31AC94163096B20F445AE84285BC0832D72",
+			},
+		},
+	}, tls)
+}
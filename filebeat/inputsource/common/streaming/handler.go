@@ -20,7 +20,9 @@ package streaming
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"net"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -41,6 +43,12 @@ type MetadataFunc func(net.Conn) inputsource.NetworkMetadata
 func SplitHandlerFactory(family inputsource.Family, logger *logp.Logger, metadataCallback MetadataFunc, callback inputsource.NetworkFunc, splitFunc bufio.SplitFunc) HandlerFactory {
 	return func(config ListenerConfig) ConnectionHandler {
 		return ConnectionHandler(func(ctx context.Context, conn net.Conn) error {
+			// complete the TLS handshake first, so the metadata contains the
+			// negotiated connection details and the client certificate.
+			if err := handshake(ctx, conn, config.Timeout); err != nil {
+				return errors.Wrap(err, string(family)+" split_client TLS handshake failed")
+			}
+
 			metadata := metadataCallback(conn)
 			maxMessageSize := uint64(config.MaxMessageSize)
 
@@ -89,3 +97,17 @@ func SplitHandlerFactory(family inputsource.Family, logger *logp.Logger, metadat
 		})
 	}
 }
+
+func handshake(ctx context.Context, conn net.Conn, timeout time.Duration) error {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return nil
+	}
+
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return tlsConn.HandshakeContext(ctx)
+	}
+	return tlsConn.HandshakeContext(ctx)
+}
@@ -20,9 +20,19 @@ package streaming
 import (
 	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
 	"strconv"
 )
 
+var (
+	// ErrInvalidOctetCount is returned when the length of an octet counted frame is malformed.
+	ErrInvalidOctetCount = errors.New("invalid octet count")
+
+	// ErrIncompleteFrame is returned when the stream ends in the middle of an octet counted frame.
+	ErrIncompleteFrame = errors.New("incomplete octet counted frame")
+)
+
 // FactoryDelimiter return a function to split line using a custom delimiter supporting multibytes
 // delimiter, the delimiter is stripped from the returned value.
 func FactoryDelimiter(delimiter []byte) bufio.SplitFunc {
@@ -63,16 +73,34 @@ func FactoryRFC6587Framing(delimiter []byte) bufio.SplitFunc {
 		}
 		// It can be assumed that octet-counting framing is
 		// used if a syslog frame starts with a digit RFC6587
-		if bytes.ContainsAny(data[0:1], "0123456789") {
-			if i := bytes.IndexByte(data, ' '); i > 0 {
-				length, err := strconv.Atoi(string(data[0:i]))
-				if err != nil {
-					return 0, nil, err
+		if isDigit(data[0]) {
+			i := 0
+			for i < len(data) && isDigit(data[i]) {
+				i++
+			}
+			if i == len(data) {
+				if eof {
+					return 0, nil, ErrIncompleteFrame
 				}
+				// request more data
+				return 0, nil, nil
+			}
+			// MSG-LEN = NONZERO-DIGIT *DIGIT, followed by a space
+			if data[0] == '0' || data[i] != ' ' {
+				return 0, nil, fmt.Errorf("%w: %q", ErrInvalidOctetCount, data[0:i+1])
+			}
+			length, err := strconv.Atoi(string(data[0:i]))
+			if err != nil {
+				return 0, nil, fmt.Errorf("%w: %v", ErrInvalidOctetCount, err)
+			}
+			// Compare against the remaining data instead of computing the
+			// frame end first, a length close to MaxInt would overflow.
+			if length <= len(data)-i-1 {
 				end := length + i + 1
-				if len(data) >= end {
-					return end, data[i+1 : end], nil
-				}
+				return end, data[i+1 : end], nil
+			}
+			if eof {
+				return 0, nil, ErrIncompleteFrame
 			}
 			// request more data
 			return 0, nil, nil
@@ -87,3 +115,7 @@ func FactoryRFC6587Framing(delimiter []byte) bufio.SplitFunc {
 		return 0, nil, nil
 	}
 }
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -178,3 +178,62 @@ func TestOctetCounting(t *testing.T) {
 		})
 	}
 }
+
+func TestOctetCountingErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "leading zero",
+			input:    "13 <9> message 0013 <6> msg 1",
+			expected: []string{"<9> message 0"},
+			err:      ErrInvalidOctetCount,
+		},
+		{
+			name:     "missing space",
+			input:    "13<9> message 0",
+			expected: nil,
+			err:      ErrInvalidOctetCount,
+		},
+		{
+			name:     "truncated frame",
+			input:    "13 <9> message 013 <6> msg",
+			expected: []string{"<9> message 0"},
+			err:      ErrIncompleteFrame,
+		},
+		{
+			name:     "truncated octet count",
+			input:    "13 <9> message 013",
+			expected: []string{"<9> message 0"},
+			err:      ErrIncompleteFrame,
+		},
+		{
+			name:     "max octet count",
+			input:    "9223372036854775807 hello",
+			expected: nil,
+			err:      ErrIncompleteFrame,
+		},
+		{
+			name:     "octet count out of range",
+			input:    "99999999999999999999 hello",
+			expected: nil,
+			err:      ErrInvalidOctetCount,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := strings.NewReader(test.input)
+			scanner := bufio.NewScanner(buf)
+			scanner.Split(FactoryRFC6587Framing([]byte("\n")))
+			var elements []string
+			for scanner.Scan() {
+				elements = append(elements, scanner.Text())
+			}
+			assert.EqualValues(t, test.expected, elements)
+			assert.ErrorIs(t, scanner.Err(), test.err)
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package inputsource
 
 import (
+	"crypto/x509"
 	"net"
 )
 
@@ -40,6 +41,10 @@ type TLSMetadata struct {
 	CipherSuite      string
 	ServerName       string
 	PeerCertificates []string
+
+	// ClientCertificate is the leaf certificate the client authenticated
+	// with, nil if the client did not present a certificate.
+	ClientCertificate *x509.Certificate
 }
 
 // NetworkFunc defines callback executed when a new event is received from a network source.
@@ -19,7 +19,15 @@ package tcp
 
 import (
 	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"net"
 	"strings"
@@ -260,6 +268,152 @@ func TestReceiveEventsAndMetadata(t *testing.T) {
 	}
 }
 
+func TestReceiveEventsOverMutualTLS(t *testing.T) {
+	caCert, caKey, caPEM, _ := generateCertificate(t, "ca", nil, nil)
+	_, _, serverPEM, serverKeyPEM := generateCertificate(t, "localhost", caCert, caKey)
+	clientCert, _, clientPEM, clientKeyPEM := generateCertificate(t, "client", caCert, caKey)
+
+	ch := make(chan *info, 2)
+	to := func(message []byte, mt inputsource.NetworkMetadata) {
+		ch <- &info{message: string(message), mt: mt}
+	}
+
+	cfg, err := conf.NewConfigFrom(map[string]interface{}{
+		"host":                        "localhost:0",
+		"ssl.certificate":             serverPEM,
+		"ssl.key":                     serverKeyPEM,
+		"ssl.certificate_authorities": []string{caPEM},
+		"ssl.client_authentication":   "required",
+		"ssl.verification_mode":       "certificate",
+		"ssl.supported_protocols":     []string{"TLSv1.3"},
+	})
+	require.NoError(t, err)
+	config := defaultConfig
+	require.NoError(t, cfg.Unpack(&config))
+
+	splitFunc, err := streaming.SplitFunc(streaming.FramingRFC6587, []byte("\n"))
+	require.NoError(t, err)
+
+	factory := streaming.SplitHandlerFactory(inputsource.FamilyTCP, logp.NewLogger("test"), MetadataCallback, to, splitFunc)
+	server, err := New(&config, factory)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	clientTLSCert, err := tls.X509KeyPair([]byte(clientPEM), []byte(clientKeyPEM))
+	require.NoError(t, err)
+
+	conn, err := tls.Dial("tcp", server.Listener.Listener.Addr().String(), &tls.Config{
+		RootCAs:      pool,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{clientTLSCert},
+	})
+	require.NoError(t, err)
+	fmt.Fprint(conn, "13 <9> message 010 <6> msg \n1")
+	conn.Close()
+
+	for _, expected := range []string{"<9> message 0", "<6> msg \n1"} {
+		select {
+		case e := <-ch:
+			assert.Equal(t, expected, e.message)
+			require.NotNil(t, e.mt.TLS)
+			assert.Equal(t, "TLSv1.3", e.mt.TLS.TLSVersion)
+			assert.Equal(t, "localhost", e.mt.TLS.ServerName)
+			require.NotNil(t, e.mt.TLS.ClientCertificate)
+			assert.Equal(t, clientCert.Raw, e.mt.TLS.ClientCertificate.Raw)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for events")
+		}
+	}
+}
+
+func TestRejectClientWithoutCertificate(t *testing.T) {
+	caCert, caKey, caPEM, _ := generateCertificate(t, "ca", nil, nil)
+	_, _, serverPEM, serverKeyPEM := generateCertificate(t, "localhost", caCert, caKey)
+
+	ch := make(chan *info, 1)
+	to := func(message []byte, mt inputsource.NetworkMetadata) {
+		ch <- &info{message: string(message), mt: mt}
+	}
+
+	cfg, err := conf.NewConfigFrom(map[string]interface{}{
+		"host":                        "localhost:0",
+		"ssl.certificate":             serverPEM,
+		"ssl.key":                     serverKeyPEM,
+		"ssl.certificate_authorities": []string{caPEM},
+		"ssl.client_authentication":   "required",
+	})
+	require.NoError(t, err)
+	config := defaultConfig
+	require.NoError(t, cfg.Unpack(&config))
+
+	splitFunc, err := streaming.SplitFunc(streaming.FramingRFC6587, []byte("\n"))
+	require.NoError(t, err)
+
+	factory := streaming.SplitHandlerFactory(inputsource.FamilyTCP, logp.NewLogger("test"), MetadataCallback, to, splitFunc)
+	server, err := New(&config, factory)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	conn, err := tls.Dial("tcp", server.Listener.Listener.Addr().String(), &tls.Config{
+		RootCAs:    pool,
+		ServerName: "localhost",
+	})
+	if err == nil {
+		// with TLS 1.3 the client learns about the rejected handshake on its first read
+		fmt.Fprint(conn, "13 <9> message 0")
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	require.Error(t, err)
+
+	select {
+	case e := <-ch:
+		t.Fatalf("unexpected event received: %q", e.message)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// generateCertificate creates a certificate signed by parent, or a self-signed
+// CA certificate when parent is nil.
+func generateCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{commonName},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(cryptorand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, key, string(certPEM), string(keyPEM)
+}
+
 func TestReceiveNewEventsConcurrently(t *testing.T) {
 	workers := 4
 	eventsCount := 100
@@ -30,6 +30,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 
 	// Import packages that need to register themselves.
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/grpc"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/http"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/icmp"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/tcp"
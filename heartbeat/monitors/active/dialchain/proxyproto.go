@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dialchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/transport"
+)
+
+// ProxyProtocolConfig configures the HAProxy PROXY protocol header sent on
+// new connections. The header is disabled if Version is 0.
+type ProxyProtocolConfig struct {
+	Version int `config:"version"`
+}
+
+// Enabled returns true if a PROXY protocol header must be sent.
+func (c ProxyProtocolConfig) Enabled() bool {
+	return c.Version != 0
+}
+
+// Validate validates of the ProxyProtocolConfig object is valid or not
+func (c *ProxyProtocolConfig) Validate() error {
+	switch c.Version {
+	case 0, 1, 2:
+		return nil
+	}
+	return fmt.Errorf("PROXY protocol version %d not supported, please use one of 1, 2", c.Version)
+}
+
+// proxyProtocolV2Signature starts every PROXY protocol v2 header.
+var proxyProtocolV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// ProxyProtocolLayer configures a layer sending a PROXY protocol header right
+// after the connection is established, before any other data such as a TLS
+// handshake. The header announces the local address of the connection as
+// source and dstAddr as destination. If dstAddr is empty the remote address
+// of the connection is used instead, if dstAddr is not an IP address the
+// addresses are announced as unknown.
+func ProxyProtocolLayer(config ProxyProtocolConfig, dstAddr string) Layer {
+	return func(event *beat.Event, next transport.Dialer) (transport.Dialer, error) {
+		return WithProxyProtocol(next, config, dstAddr), nil
+	}
+}
+
+// WithProxyProtocol wraps dialer to send a PROXY protocol header on the new
+// connections. See ProxyProtocolLayer.
+func WithProxyProtocol(dialer transport.Dialer, config ProxyProtocolConfig, dstAddr string) transport.Dialer {
+	return afterDial(dialer, func(conn net.Conn) (net.Conn, error) {
+		var dst net.Addr = conn.RemoteAddr()
+		if dstAddr != "" {
+			dst = parseTCPAddr(dstAddr)
+		}
+
+		header := makeProxyProtocolHeader(config.Version, conn.LocalAddr(), dst)
+		if _, err := conn.Write(header); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to send PROXY protocol header: %w", err)
+		}
+		return conn, nil
+	})
+}
+
+// parseTCPAddr returns the TCP address of an ip:port string, or nil if it is
+// not one.
+func parseTCPAddr(addr string) net.Addr {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil
+	}
+	ip := net.ParseIP(host)
+	portNum, err := strconv.ParseUint(port, 10, 16)
+	if ip == nil || err != nil {
+		return nil
+	}
+	return &net.TCPAddr{IP: ip, Port: int(portNum)}
+}
+
+// makeProxyProtocolHeader builds the PROXY protocol header of the given
+// version for a connection from src to dst. The connection is announced as
+// unknown if the addresses are not TCP addresses of the same family.
+func makeProxyProtocolHeader(version int, src, dst net.Addr) []byte {
+	srcTCP, _ := src.(*net.TCPAddr)
+	dstTCP, _ := dst.(*net.TCPAddr)
+
+	var srcIP, dstIP net.IP
+	if srcTCP != nil && dstTCP != nil {
+		srcIP, dstIP = srcTCP.IP.To4(), dstTCP.IP.To4()
+		if srcIP == nil || dstIP == nil {
+			srcIP, dstIP = srcTCP.IP.To16(), dstTCP.IP.To16()
+			if srcTCP.IP.To4() != nil || dstTCP.IP.To4() != nil {
+				// mixed address families
+				srcIP, dstIP = nil, nil
+			}
+		}
+	}
+
+	if version == 1 {
+		if srcIP == nil || dstIP == nil {
+			return []byte("PROXY UNKNOWN\r\n")
+		}
+		proto := "TCP4"
+		if len(srcIP) == net.IPv6len {
+			proto = "TCP6"
+		}
+		return []byte(fmt.Sprintf("PROXY %s %s %s %d %d\r\n", proto, srcIP, dstIP, srcTCP.Port, dstTCP.Port))
+	}
+
+	var buf bytes.Buffer
+	buf.Write(proxyProtocolV2Signature)
+	// version 2, PROXY command
+	buf.WriteByte(0x21)
+	switch {
+	case srcIP == nil || dstIP == nil:
+		// AF_UNSPEC, the receiver uses the addresses of the connection
+		buf.WriteByte(0x00)
+		_ = binary.Write(&buf, binary.BigEndian, uint16(0))
+		return buf.Bytes()
+	case len(srcIP) == net.IPv4len:
+		// AF_INET, STREAM
+		buf.WriteByte(0x11)
+	default:
+		// AF_INET6, STREAM
+		buf.WriteByte(0x21)
+	}
+	_ = binary.Write(&buf, binary.BigEndian, uint16(2*len(srcIP)+4))
+	buf.Write(srcIP)
+	buf.Write(dstIP)
+	_ = binary.Write(&buf, binary.BigEndian, uint16(srcTCP.Port))
+	_ = binary.Write(&buf, binary.BigEndian, uint16(dstTCP.Port))
+	return buf.Bytes()
+}
@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// execCheck calls the health service of the server listening on addr for the
+// given service, and adds the RPC status, the serving status, the TLS details
+// of the connection and the round trip time to the event.
+func execCheck(
+	event *beat.Event,
+	addr string,
+	u *url.URL,
+	service string,
+	config *config,
+	tlsConfig *tlscommon.TLSConfig,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	method := strings.ToLower(config.Check.Request.Method)
+	grpcFields := mapstr.M{"method": method}
+	if service != "" {
+		grpcFields["service"] = service
+	}
+
+	var creds credentials.TransportCredentials = insecure.NewCredentials()
+	var rec *tlsRecorder
+	if u.Scheme == "grpcs" {
+		rec = newTLSRecorder(credentials.NewTLS(tlsConfig.BuildModuleClientConfig(u.Hostname())))
+		creds = rec
+	}
+
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUserAgent(userAgent),
+	)
+	if err != nil {
+		return reason.IOFailed(err)
+	}
+	defer conn.Close()
+
+	if len(config.Check.Request.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(config.Check.Request.Metadata))
+	}
+
+	resp, err := callHealth(ctx, healthpb.NewHealthClient(conn), method, service)
+	end := time.Now()
+
+	if rec != nil {
+		rec.addFields(event)
+	}
+
+	code := status.Code(err)
+	grpcFields["status_code"] = int(code)
+	if err == nil {
+		grpcFields["health"] = mapstr.M{"status": resp.GetStatus().String()}
+	}
+	grpcFields["rtt"] = mapstr.M{"total": look.RTT(end.Sub(start))}
+	eventext.MergeEventFields(event, mapstr.M{"grpc": grpcFields})
+
+	if err != nil {
+		debugf("health %s of service '%s' failed with: %v", method, service, err)
+		switch code {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			// the server could not be reached
+			return reason.IOFailed(err)
+		}
+		return reason.ValidateFailed(err)
+	}
+
+	servingStatus := resp.GetStatus().String()
+	for _, s := range config.Check.Response.Status {
+		if strings.EqualFold(s, servingStatus) {
+			return nil
+		}
+	}
+	return reason.ValidateFailed(fmt.Errorf("serving status of service '%s' is %s, expected one of %v",
+		service, servingStatus, config.Check.Response.Status))
+}
+
+// callHealth calls the Check or Watch method of the health service. Watch
+// returns the first status sent by the server, which is its current status.
+func callHealth(
+	ctx context.Context,
+	client healthpb.HealthClient,
+	method string,
+	service string,
+) (*healthpb.HealthCheckResponse, error) {
+	req := &healthpb.HealthCheckRequest{Service: service}
+	if method == methodCheck {
+		return client.Check(ctx, req)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.Watch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return stream.Recv()
+}
+
+// tlsRecorder wraps TLS transport credentials to record the state, duration
+// and error of the handshakes done by the connection of a check.
+type tlsRecorder struct {
+	credentials.TransportCredentials
+
+	*handshakeRecord
+}
+
+type handshakeRecord struct {
+	mu       sync.Mutex
+	state    *tls.ConnectionState
+	duration time.Duration
+	err      error
+}
+
+func newTLSRecorder(creds credentials.TransportCredentials) *tlsRecorder {
+	return &tlsRecorder{TransportCredentials: creds, handshakeRecord: &handshakeRecord{}}
+}
+
+func (r *tlsRecorder) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	start := time.Now()
+	conn, authInfo, err := r.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	duration := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.duration = duration
+	r.err = err
+	r.state = nil
+	if info, ok := authInfo.(credentials.TLSInfo); ok {
+		r.state = &info.State
+	}
+	return conn, authInfo, err
+}
+
+func (r *tlsRecorder) Clone() credentials.TransportCredentials {
+	return &tlsRecorder{TransportCredentials: r.TransportCredentials.Clone(), handshakeRecord: r.handshakeRecord}
+}
+
+// addFields adds the TLS details of the last handshake to the event. If the
+// server certificate is invalid its details are added too.
+func (r *tlsRecorder) addFields(event *beat.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state != nil {
+		tlsFields := mapstr.M{}
+		tlsmeta.AddTLSMetadata(tlsFields, *r.state, r.duration)
+		eventext.MergeEventFields(event, tlsFields)
+		return
+	}
+
+	var certErr x509.CertificateInvalidError
+	if errors.As(r.err, &certErr) {
+		tlsFields := tlsmeta.CertFields(certErr.Cert, nil)
+		eventext.MergeEventFields(event, mapstr.M{"tls": tlsFields})
+	}
+}
@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	// hosts to check, as host:port or grpc(s)://host:port
+	Hosts []string `config:"hosts" validate:"required"`
+
+	// names of the services to check, the empty string checks the overall
+	// health of the server
+	Services []string `config:"services"`
+
+	Mode monitors.IPSettings `config:",inline"`
+
+	// configure tls
+	TLS *tlscommon.Config `config:"ssl"`
+
+	Timeout time.Duration `config:"timeout"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	Request  requestParameters  `config:"request"`
+	Response responseParameters `config:"response"`
+}
+
+type requestParameters struct {
+	// health service method to call, check or watch
+	Method string `config:"method"`
+	// metadata sent with the request
+	Metadata map[string]string `config:"metadata"`
+}
+
+type responseParameters struct {
+	// accepted serving statuses
+	Status []string `config:"status"`
+}
+
+const (
+	methodCheck = "check"
+	methodWatch = "watch"
+)
+
+func defaultConfig() config {
+	return config{
+		Services: []string{""},
+		Mode:     monitors.DefaultIPSettings,
+		Timeout:  16 * time.Second,
+		Check: checkConfig{
+			Request: requestParameters{
+				Method: methodCheck,
+			},
+			Response: responseParameters{
+				Status: []string{healthpb.HealthCheckResponse_SERVING.String()},
+			},
+		},
+	}
+}
+
+// Validate validates of the requestParameters object is valid or not
+func (r *requestParameters) Validate() error {
+	switch strings.ToLower(r.Method) {
+	case methodCheck, methodWatch:
+	default:
+		return fmt.Errorf("health method '%v' not supported, please use one of 'check', 'watch'", r.Method)
+	}
+
+	return nil
+}
+
+// Validate validates of the responseParameters object is valid or not
+func (r *responseParameters) Validate() error {
+	if len(r.Status) == 0 {
+		return fmt.Errorf("at least one serving status must be accepted")
+	}
+	for _, s := range r.Status {
+		if _, ok := healthpb.HealthCheckResponse_ServingStatus_value[strings.ToUpper(s)]; !ok {
+			return fmt.Errorf("unknown serving status '%v'", s)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates of the config object is valid or not
+func (c *config) Validate() error {
+	if len(c.Services) == 0 {
+		c.Services = []string{""}
+	}
+
+	for _, host := range c.Hosts {
+		if _, err := parseHost(host, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseHost parses a configured host into a grpc or grpcs URL. Hosts without
+// scheme use grpcs if TLS is enabled.
+func parseHost(host string, tlsEnabled bool) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		scheme := "grpc"
+		if tlsEnabled {
+			scheme = "grpcs"
+		}
+		host = scheme + "://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host '%v': %w", host, err)
+	}
+	if u.Scheme != "grpc" && u.Scheme != "grpcs" {
+		return nil, fmt.Errorf("invalid scheme '%v' in host '%v', please use one of 'grpc', 'grpcs'", u.Scheme, host)
+	}
+	if _, port, err := net.SplitHostPort(u.Host); err != nil || port == "" {
+		return nil, fmt.Errorf("host '%v' has no port", host)
+	}
+
+	return u, nil
+}
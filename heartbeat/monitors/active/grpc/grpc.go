@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/version"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+	"github.com/elastic/elastic-agent-libs/useragent"
+)
+
+func init() {
+	plugin.Register("grpc", create)
+}
+
+var debugf = logp.MakeDebug("grpc")
+
+var userAgent = useragent.UserAgent("Heartbeat", version.GetDefaultVersion(), version.Commit(), version.BuildTime().String())
+
+func create(
+	name string,
+	cfg *conf.C,
+) (p plugin.Plugin, err error) {
+	return createWithResolver(cfg, monitors.NewStdResolver())
+}
+
+// Custom resolver is useful for tests against hostnames locally where we don't want to depend on any
+// hostnames existing in test environments
+func createWithResolver(
+	cfg *conf.C,
+	resolver monitors.Resolver,
+) (p plugin.Plugin, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	var js []jobs.Job
+	for _, host := range config.Hosts {
+		u, err := parseHost(host, tlsConfig != nil)
+		if err != nil {
+			return plugin.Plugin{}, err
+		}
+
+		// Create one job for every service, they share the URL of the host.
+		for _, service := range config.Services {
+			job, err := makeCheckJob(u, service, &config, tlsConfig, resolver)
+			if err != nil {
+				return plugin.Plugin{}, err
+			}
+			js = append(js, wrappers.WithURLField(u, job))
+		}
+	}
+
+	return plugin.Plugin{Jobs: js, Endpoints: len(js)}, nil
+}
+
+// makeCheckJob makes a job checking the health of a single service of a host.
+// The host is resolved first, then the health service is called on one or all
+// of its IPs depending on config.Mode.
+func makeCheckJob(
+	u *url.URL,
+	service string,
+	config *config,
+	tlsConfig *tlscommon.TLSConfig,
+	resolver monitors.Resolver,
+) (jobs.Job, error) {
+	job, err := monitors.MakeByHostJob(
+		u.Hostname(),
+		config.Mode,
+		resolver,
+		monitors.MakePingIPFactory(
+			func(event *beat.Event, ip *net.IPAddr) error {
+				// use address from resolved IP, the TLS server name is
+				// still taken from the URL.
+				addr := net.JoinHostPort(ip.String(), u.Port())
+
+				return execCheck(event, addr, u, service, config, tlsConfig)
+			}))
+	if err != nil {
+		return nil, fmt.Errorf("could not create job for host '%v': %w", u.Host, err)
+	}
+	return job, nil
+}
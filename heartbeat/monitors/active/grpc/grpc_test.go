@@ -0,0 +1,377 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-lookslike"
+	"github.com/elastic/go-lookslike/isdef"
+	"github.com/elastic/go-lookslike/testslike"
+	"github.com/elastic/go-lookslike/validator"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/hbtestllext"
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+func TestCheckServing(t *testing.T) {
+	port, _ := startHealthServer(t, nil)
+
+	event := testGRPCCheck(t, mapstr.M{"hosts": "127.0.0.1:" + strconv.Itoa(int(port))})
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			grpcChecks("", "check", codes.OK, "SERVING"),
+		)),
+		event.Fields,
+	)
+}
+
+func TestCheckServices(t *testing.T) {
+	_, srv := startHealthServer(t, nil)
+	srv.SetServingStatus("orders.v1.Orders", healthpb.HealthCheckResponse_SERVING)
+	srv.SetServingStatus("billing.v1.Billing", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	p, err := create("grpc", mustConfig(t, mapstr.M{
+		"hosts":    "127.0.0.1:1",
+		"services": []string{"orders.v1.Orders", "billing.v1.Billing"},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 2, p.Endpoints)
+	require.Len(t, p.Jobs, 2)
+}
+
+func TestCheckNotServing(t *testing.T) {
+	port, srv := startHealthServer(t, nil)
+	srv.SetServingStatus("billing.v1.Billing", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	for _, method := range []string{"check", "watch"} {
+		t.Run(method, func(t *testing.T) {
+			event := testGRPCCheck(t, mapstr.M{
+				"hosts":                "127.0.0.1:" + strconv.Itoa(int(port)),
+				"services":             "billing.v1.Billing",
+				"check.request.method": method,
+			})
+
+			testslike.Test(
+				t,
+				lookslike.Strict(lookslike.Compose(
+					hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+					hbtest.SummaryChecks(0, 1),
+					hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+					grpcChecks("billing.v1.Billing", method, codes.OK, "NOT_SERVING"),
+					hbtest.ErrorChecks("serving status of service 'billing.v1.Billing' is NOT_SERVING", "validate"),
+				)),
+				event.Fields,
+			)
+		})
+	}
+}
+
+func TestCheckAcceptedStatus(t *testing.T) {
+	port, srv := startHealthServer(t, nil)
+	srv.SetServingStatus("billing.v1.Billing", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	event := testGRPCCheck(t, mapstr.M{
+		"hosts":                 "127.0.0.1:" + strconv.Itoa(int(port)),
+		"services":              "billing.v1.Billing",
+		"check.response.status": []string{"serving", "not_serving"},
+	})
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			grpcChecks("billing.v1.Billing", "check", codes.OK, "NOT_SERVING"),
+		)),
+		event.Fields,
+	)
+}
+
+func TestWatchServing(t *testing.T) {
+	port, srv := startHealthServer(t, nil)
+	srv.SetServingStatus("orders.v1.Orders", healthpb.HealthCheckResponse_SERVING)
+
+	event := testGRPCCheck(t, mapstr.M{
+		"hosts":                "grpc://127.0.0.1:" + strconv.Itoa(int(port)),
+		"services":             "orders.v1.Orders",
+		"check.request.method": "watch",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			grpcChecks("orders.v1.Orders", "watch", codes.OK, "SERVING"),
+		)),
+		event.Fields,
+	)
+}
+
+func TestCheckUnknownService(t *testing.T) {
+	port, _ := startHealthServer(t, nil)
+
+	event := testGRPCCheck(t, mapstr.M{
+		"hosts":    "127.0.0.1:" + strconv.Itoa(int(port)),
+		"services": "unknown.v1.Unknown",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			hbtest.SummaryChecks(0, 1),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			grpcChecks("unknown.v1.Unknown", "check", codes.NotFound, ""),
+			hbtest.ErrorChecks("unknown service", "validate"),
+		)),
+		event.Fields,
+	)
+}
+
+func TestCheckMetadata(t *testing.T) {
+	// reject requests without the expected token
+	auth := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("authorization"); len(v) != 1 || v[0] != "Bearer secret" {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+		return handler(ctx, req)
+	}
+	port, _ := startHealthServer(t, nil, grpc.UnaryInterceptor(auth))
+	host := "127.0.0.1:" + strconv.Itoa(int(port))
+
+	event := testGRPCCheck(t, mapstr.M{"hosts": host})
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			grpcChecks("", "check", codes.Unauthenticated, ""),
+			hbtest.ErrorChecks("missing token", "validate"),
+		),
+		event.Fields,
+	)
+
+	event = testGRPCCheck(t, mapstr.M{
+		"hosts":                  host,
+		"check.request.metadata": mapstr.M{"authorization": "Bearer secret"},
+	})
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			grpcChecks("", "check", codes.OK, "SERVING"),
+		),
+		event.Fields,
+	)
+}
+
+func TestCheckTLS(t *testing.T) {
+	tlsCert, cert := testCertificate(t)
+	port, _ := startHealthServer(t, &tlsCert)
+
+	certFile := hbtest.CertToTempFile(t, cert)
+	require.NoError(t, certFile.Close())
+	defer os.Remove(certFile.Name())
+
+	event := testGRPCCheck(t, mapstr.M{
+		"hosts": "127.0.0.1:" + strconv.Itoa(int(port)),
+		"ssl":   mapstr.M{"certificate_authorities": certFile.Name()},
+	})
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpcs", "127.0.0.1", port),
+			hbtest.TLSChecks(0, 0, cert),
+			// gRPC negotiates HTTP/2 with ALPN
+			lookslike.MustCompile(map[string]interface{}{"tls.next_protocol": "h2"}),
+			grpcChecks("", "check", codes.OK, "SERVING"),
+		)),
+		event.Fields,
+	)
+}
+
+func TestCheckExpiredCert(t *testing.T) {
+	certFile := "../fixtures/expired.cert"
+	tlsCert, err := tls.LoadX509KeyPair(certFile, "../fixtures/expired.key")
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	require.NoError(t, err)
+	port, _ := startHealthServer(t, &tlsCert)
+
+	event := testGRPCCheck(t, mapstr.M{
+		"hosts": "grpcs://localhost:" + strconv.Itoa(int(port)),
+		"ssl":   mapstr.M{"certificate_authorities": certFile},
+	})
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			hbtest.ResolveChecks("127.0.0.1"),
+			hbtest.SummaryChecks(0, 1),
+			hbtest.ExpiredCertChecks(cert),
+			grpcChecks("", "check", codes.Unavailable, ""),
+		),
+		event.Fields,
+	)
+}
+
+func TestConnectionRefused(t *testing.T) {
+	// find a port that nothing is listening on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := uint16(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, l.Close())
+
+	event := testGRPCCheck(t, mapstr.M{"hosts": "127.0.0.1:" + strconv.Itoa(int(port))})
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			hbtest.SummaryChecks(0, 1),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			grpcChecks("", "check", codes.Unavailable, ""),
+			hbtest.ErrorChecks("connection refused", "io"),
+		)),
+		event.Fields,
+	)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"no hosts":        {},
+		"no port":         {"hosts": "localhost"},
+		"invalid scheme":  {"hosts": "http://localhost:80"},
+		"invalid method":  {"hosts": "localhost:80", "check.request.method": "list"},
+		"invalid status":  {"hosts": "localhost:80", "check.response.status": "UP"},
+		"invalid timeout": {"hosts": "localhost:80", "timeout": "forever"},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := create("grpc", mustConfig(t, cfg))
+			require.Error(t, err)
+		})
+	}
+}
+
+func grpcChecks(service, method string, code codes.Code, servingStatus string) validator.Validator {
+	fields := map[string]interface{}{
+		"method":       method,
+		"status_code":  int(code),
+		"rtt.total.us": hbtestllext.IsInt64,
+	}
+	if service != "" {
+		fields["service"] = service
+	}
+	if servingStatus != "" {
+		fields["health.status"] = servingStatus
+	} else {
+		fields["health"] = isdef.KeyMissing
+	}
+	return lookslike.MustCompile(map[string]interface{}{"grpc": fields})
+}
+
+func testGRPCCheck(t *testing.T, configMap mapstr.M) *beat.Event {
+	configMap["timeout"] = "1s"
+	p, err := create("grpc", mustConfig(t, configMap))
+	require.NoError(t, err)
+	require.Equal(t, 1, p.Endpoints)
+
+	sched := schedule.MustParse("@every 1s")
+	job := wrappers.WrapCommon(p.Jobs, stdfields.StdMonitorFields{ID: "test", Type: "grpc", Schedule: sched, Timeout: 1}, nil)[0]
+
+	event := &beat.Event{}
+	_, err = job(event)
+	require.NoError(t, err)
+
+	return event
+}
+
+func mustConfig(t *testing.T, configMap mapstr.M) *conf.C {
+	config, err := conf.NewConfigFrom(configMap)
+	require.NoError(t, err)
+	return config
+}
+
+// startHealthServer starts a gRPC server with a health service on a random
+// port of 127.0.0.1. The overall health of the server is SERVING.
+func startHealthServer(t *testing.T, cert *tls.Certificate, opts ...grpc.ServerOption) (uint16, *health.Server) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	if cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewServerTLSFromCert(cert)))
+	}
+	srv := grpc.NewServer(opts...)
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(srv.Stop)
+
+	return uint16(l.Addr().(*net.TCPAddr).Port), healthSrv
+}
+
+// testCertificate returns the certificate of the httptest TLS servers, valid
+// for 127.0.0.1.
+func testCertificate(t *testing.T) (tls.Certificate, *x509.Certificate) {
+	server := httptest.NewTLSServer(nil)
+	server.Close()
+
+	tlsCert := server.TLS.Certificates[0]
+	cert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	require.NoError(t, err)
+	return tlsCert, cert
+}
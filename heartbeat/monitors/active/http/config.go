@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
 	"github.com/elastic/beats/v7/libbeat/conditions"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
@@ -44,6 +45,9 @@ type Config struct {
 	Check checkConfig `config:"check"`
 
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+
+	// send a HAProxy PROXY protocol header
+	ProxyProtocol dialchain.ProxyProtocolConfig `config:"proxy_protocol"`
 }
 
 type responseConfig struct {
@@ -169,6 +173,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("hosts is a mandatory parameter")
 	}
 
+	if c.ProxyProtocol.Enabled() && c.Transport.Proxy.URL != nil && !c.Transport.Proxy.Disable {
+		return fmt.Errorf("proxy_protocol can not be used together with proxy_url")
+	}
+
 	if len(c.URLs) != 0 {
 		c.Hosts = append(c.Hosts, c.URLs...)
 	}
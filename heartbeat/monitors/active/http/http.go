@@ -26,8 +26,10 @@ import (
 	"github.com/elastic/beats/v7/libbeat/version"
 	conf "github.com/elastic/elastic-agent-libs/config"
 
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/elastic-agent-libs/transport"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 	"github.com/elastic/elastic-agent-libs/useragent"
@@ -123,12 +125,19 @@ func create(
 }
 
 func newRoundTripper(config *Config) (http.RoundTripper, error) {
-	return config.Transport.RoundTripper(
+	opts := []httpcommon.TransportOption{
 		httpcommon.WithAPMHTTPInstrumentation(),
 		httpcommon.WithoutProxyEnvironmentVariables(),
 		httpcommon.WithKeepaliveSettings{
 			Disable: true,
 		},
 		httpcommon.WithHeaderRoundTripper(map[string]string{"User-Agent": userAgent}),
-	)
+	}
+	if config.ProxyProtocol.Enabled() {
+		// Connections are never established via a proxy here, announce the address
+		// each connection is made to.
+		dialer := transport.NetDialer(config.Transport.Timeout)
+		opts = append(opts, httpcommon.WithBaseDialer(dialchain.WithProxyProtocol(dialer, config.ProxyProtocol, "")))
+	}
+	return config.Transport.RoundTripper(opts...)
 }
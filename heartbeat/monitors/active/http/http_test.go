@@ -18,10 +18,12 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -34,10 +36,12 @@ import (
 	"path"
 	"reflect"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/armon/go-socks5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -809,6 +813,137 @@ func httpConnectTunnel(writer http.ResponseWriter, request *http.Request) {
 	wg.Wait()
 }
 
+func TestSocks5Proxy(t *testing.T) {
+	server := httptest.NewTLSServer(hbtest.HelloWorldHandler(http.StatusOK))
+	proxy, err := socks5.New(&socks5.Config{})
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	//nolint:errcheck // the server stops with an error once the listener is closed
+	go proxy.Serve(listener)
+
+	runHTTPSServerCheck(t, server, map[string]interface{}{
+		"proxy_url": "socks5://" + listener.Addr().String(),
+	})
+}
+
+func TestProxyProtocol(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		version      int
+		maxRedirects int
+		prefix       string
+	}{
+		{
+			name:    "version 1",
+			version: 1,
+			prefix:  "PROXY TCP4 127.0.0.1 127.0.0.1 ",
+		},
+		{
+			name:    "version 2",
+			version: 2,
+			prefix:  "\r\n\r\n\x00\r\nQUIT\n\x21\x11\x00\x0c\x7f\x00\x00\x01\x7f\x00\x00\x01",
+		},
+		{
+			name:         "version 1 with redirects",
+			version:      1,
+			maxRedirects: 1,
+			prefix:       "PROXY TCP4 127.0.0.1 127.0.0.1 ",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			ppListener := &proxyProtocolListener{Listener: listener, headers: make(chan string, 1)}
+
+			server := httptest.NewUnstartedServer(hbtest.HelloWorldHandler(http.StatusOK))
+			server.Listener = ppListener
+			server.Start()
+			defer server.Close()
+
+			event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+				"proxy_protocol.version": scenario.version,
+				"max_redirects":          scenario.maxRedirects,
+			})
+
+			testslike.Test(
+				t,
+				lookslike.Compose(
+					lookslike.MustCompile(map[string]interface{}{
+						"monitor.status": "up",
+					}),
+					hbtest.SummaryChecks(1, 0),
+				),
+				event.Fields,
+			)
+
+			header := <-ppListener.headers
+			require.True(t, strings.HasPrefix(header, scenario.prefix), "unexpected PROXY protocol header %q", header)
+		})
+	}
+}
+
+func TestProxyProtocolWithProxyURL(t *testing.T) {
+	config, err := conf.NewConfigFrom(map[string]interface{}{
+		"hosts":                  "http://localhost:8080",
+		"proxy_url":              "socks5://localhost:1080",
+		"proxy_protocol.version": 1,
+	})
+	require.NoError(t, err)
+
+	_, err = create("http", config)
+	require.Error(t, err)
+}
+
+// proxyProtocolListener strips the PROXY protocol header of accepted connections,
+// the raw headers are sent to the headers channel.
+type proxyProtocolListener struct {
+	net.Listener
+	headers chan string
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	var header []byte
+	if prefix, err := r.Peek(1); err == nil && prefix[0] == '\r' {
+		header = make([]byte, 16)
+		if _, err = io.ReadFull(r, header); err == nil {
+			addrs := make([]byte, binary.BigEndian.Uint16(header[14:]))
+			_, err = io.ReadFull(r, addrs)
+			header = append(header, addrs...)
+		}
+	} else {
+		header, err = r.ReadBytes('\n')
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	select {
+	case l.headers <- string(header):
+	default:
+	}
+	return &bufferedConn{Conn: conn, r: r}, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
 // helper that compresses some content as gzip
 func gzipBuffer(t *testing.T, toZip string) *bytes.Buffer {
 	var gzipBuffer bytes.Buffer
@@ -129,6 +129,10 @@ func createPingFactory(
 			Net: dialchain.MakeConstAddrDialer(addr, dialchain.TCPDialer(timeout)),
 		}
 
+		if config.ProxyProtocol.Enabled() {
+			d.AddLayer(dialchain.ProxyProtocolLayer(config.ProxyProtocol, addr))
+		}
+
 		if isTLS {
 			d.AddLayer(dialchain.TLSLayer(tls, timeout))
 		}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
 	"github.com/elastic/elastic-agent-libs/transport"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
@@ -35,6 +36,9 @@ type config struct {
 
 	Socks5 transport.ProxyConfig `config:",inline"`
 
+	// send a HAProxy PROXY protocol header
+	ProxyProtocol dialchain.ProxyProtocolConfig `config:"proxy_protocol"`
+
 	// configure tls
 	TLS *tlscommon.Config `config:"ssl"`
 
@@ -0,0 +1,205 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-lookslike"
+	"github.com/elastic/go-lookslike/testslike"
+)
+
+func TestProxyProtocolJob(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		version int
+		socks5  bool
+	}{
+		{
+			name:    "version 1",
+			version: 1,
+		},
+		{
+			name:    "version 2",
+			version: 2,
+		},
+		{
+			name:    "version 1 via socks5",
+			version: 1,
+			socks5:  true,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			port, ip, headers, closeServer, err := startProxyProtocolEchoServer(t)
+			require.NoError(t, err)
+			defer closeServer() //nolint:errcheck // intentional discard
+
+			configMap := mapstr.M{
+				"hosts":                  ip,
+				"ports":                  port,
+				"timeout":                "1s",
+				"proxy_protocol.version": scenario.version,
+				"check.receive":          "echo123",
+				"check.send":             "echo123",
+			}
+			if scenario.socks5 {
+				_, proxyPort, proxyIp, closeProxy, err := startSocks5Server(t)
+				require.NoError(t, err)
+				defer closeProxy() //nolint:errcheck // intentional discard
+
+				proxyURL := &url.URL{Scheme: "socks5", Host: net.JoinHostPort(proxyIp, fmt.Sprint(proxyPort))}
+				configMap["proxy_url"] = proxyURL.String()
+			}
+			event := testTCPConfigCheck(t, configMap)
+
+			testslike.Test(
+				t,
+				lookslike.Compose(
+					hbtest.BaseChecks(ip, "up", "tcp"),
+					hbtest.SummaryChecks(1, 0),
+				),
+				event.Fields,
+			)
+
+			header := <-headers
+			require.Equal(t, scenario.version, header.version)
+			require.Equal(t, ip, header.dstIP)
+			require.Equal(t, int(port), header.dstPort)
+			require.NotEmpty(t, header.srcIP)
+		})
+	}
+}
+
+type proxyProtocolHeader struct {
+	version          int
+	srcIP, dstIP     string
+	srcPort, dstPort int
+}
+
+// startProxyProtocolEchoServer starts an echo server expecting a PROXY protocol
+// header on its connection. The parsed header is sent to headers.
+func startProxyProtocolEchoServer(t *testing.T) (port uint16, ip string, headers <-chan proxyProtocolHeader, close func() error, err error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, "", nil, nil, err
+	}
+
+	headerCh := make(chan proxyProtocolHeader, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		header, err := readProxyProtocolHeader(r)
+		require.NoError(t, err)
+		headerCh <- header
+
+		buf := make([]byte, 1024)
+		rlen, err := r.Read(buf)
+		require.NoError(t, err)
+		_, err = conn.Write(buf[:rlen])
+		require.NoError(t, err)
+	}()
+
+	ip, portStr, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		listener.Close()
+		return 0, "", nil, nil, err
+	}
+	portUint64, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		listener.Close()
+		return 0, "", nil, nil, err
+	}
+
+	return uint16(portUint64), ip, headerCh, listener.Close, nil
+}
+
+func readProxyProtocolHeader(r *bufio.Reader) (proxyProtocolHeader, error) {
+	signature := []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	prefix, err := r.Peek(len(signature))
+	if err != nil {
+		return proxyProtocolHeader{}, err
+	}
+
+	if !bytes.Equal(prefix, signature) {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return proxyProtocolHeader{}, err
+		}
+		h := proxyProtocolHeader{version: 1}
+		var proto string
+		_, err = fmt.Sscanf(line, "PROXY %s %s %s %d %d\r\n", &proto, &h.srcIP, &h.dstIP, &h.srcPort, &h.dstPort)
+		return h, err
+	}
+
+	var fixed [16]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return proxyProtocolHeader{}, err
+	}
+	if fixed[12] != 0x21 {
+		return proxyProtocolHeader{}, fmt.Errorf("unexpected version and command 0x%x", fixed[12])
+	}
+	if fixed[13] != 0x11 {
+		return proxyProtocolHeader{}, fmt.Errorf("unexpected address family 0x%x", fixed[13])
+	}
+	addrs := make([]byte, binary.BigEndian.Uint16(fixed[14:]))
+	if _, err := io.ReadFull(r, addrs); err != nil {
+		return proxyProtocolHeader{}, err
+	}
+	if len(addrs) != 12 {
+		return proxyProtocolHeader{}, fmt.Errorf("unexpected addresses length %d", len(addrs))
+	}
+	return proxyProtocolHeader{
+		version: 2,
+		srcIP:   net.IP(addrs[0:4]).String(),
+		dstIP:   net.IP(addrs[4:8]).String(),
+		srcPort: int(binary.BigEndian.Uint16(addrs[8:10])),
+		dstPort: int(binary.BigEndian.Uint16(addrs[10:12])),
+	}, nil
+}
+
+func TestProxyProtocolConfigValidate(t *testing.T) {
+	for _, version := range []int{0, 1, 2} {
+		config := defaultConfig()
+		config.ProxyProtocol.Version = version
+		require.NoError(t, config.ProxyProtocol.Validate())
+	}
+
+	config := defaultConfig()
+	config.ProxyProtocol.Version = 3
+	require.Error(t, config.ProxyProtocol.Validate())
+}
@@ -202,6 +202,12 @@ func (jf *jobFactory) dial(event *beat.Event, dialAddr string, canonicalURL *url
 	// hostname we want the server to resolve for us.
 	dc.AddLayer(dialchain.ConstAddrLayer(dialAddr))
 
+	// The PROXY protocol header must be the first data sent on the connection, so
+	// it goes right after connecting, before any TLS handshake.
+	if jf.config.ProxyProtocol.Enabled() {
+		dc.AddLayer(dialchain.ProxyProtocolLayer(jf.config.ProxyProtocol, dialAddr))
+	}
+
 	// If we're using TLS we need to add a fake layer so that the TLS layer knows the hostname we're connecting to
 	// So, the canonical URL is fixed via a ConstAddrLayer to override the TLS layer's x509 logic so it doesn't
 	// try and directly match the IP from the prior ConstAddrLayer to the cert.
@@ -75,6 +75,7 @@ type publishSettings struct {
 	Index      fmtstr.EventFormatString    `config:"index"`    // ES output index pattern
 	DataStream *add_data_stream.DataStream `config:"data_stream"`
 	DataSet    string                      `config:"dataset"`
+	Priority   int                         `config:"priority"` // memory queue priority lane
 }
 
 type FactoryParams struct {
@@ -222,6 +223,9 @@ func newCommonPublishConfigs(info beat.Info, beatLocation *config.LocationWithID
 		if settings.Pipeline != "" {
 			_, _ = meta.Put("pipeline", settings.Pipeline)
 		}
+		if settings.Priority > 0 {
+			_, _ = meta.Put("priority", settings.Priority)
+		}
 
 		procs := processors.NewList(nil)
 
@@ -0,0 +1,163 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package control provides the routes of the HTTP endpoint used to reload
+// the configuration, to enable and disable the runners of the
+// configurations loaded dynamically, and to collect profiles and
+// diagnostics.
+package control
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/multierr"
+
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type handlerAttacher interface {
+	AttachHandler(route string, h http.Handler) (err error)
+}
+
+// Config is the configuration of the control API.
+type Config struct {
+	Enabled *bool  `config:"enabled"`
+	Token   string `config:"token"`
+}
+
+// IsEnabled returns true if the control config is non-nil and either
+// 'enabled' is not set or it is set to true.
+func (c *Config) IsEnabled() bool {
+	return c != nil && (c.Enabled == nil || *c.Enabled)
+}
+
+// Validate checks that a token is set when the control API is enabled.
+func (c *Config) Validate() error {
+	if c.IsEnabled() && c.Token == "" {
+		return errors.New("a token is required to enable the control API")
+	}
+	return nil
+}
+
+// HttpAttach attaches the /control HTTP handlers to the given mux. All of
+// them require the configured token.
+func HttpAttach(cfg *Config, server handlerAttacher, diagnostics Diagnostics) error {
+	if !cfg.IsEnabled() {
+		return nil
+	}
+
+	auth := func(method string, h http.HandlerFunc) http.Handler {
+		return makeControlHandler(cfg.Token, method, h)
+	}
+	const path = "/control"
+	return multierr.Combine(
+		server.AttachHandler(path+"/reload", auth(http.MethodPost, reloadHandler)),
+		server.AttachHandler(path+"/runners", auth(http.MethodGet, runnersHandler)),
+		server.AttachHandler(path+"/runners/{id}", auth(http.MethodGet, runnerHandler)),
+		server.AttachHandler(path+"/runners/{id}/enable", auth(http.MethodPost, runnerActionHandler(cfgfile.EnableRunner))),
+		server.AttachHandler(path+"/runners/{id}/disable", auth(http.MethodPost, runnerActionHandler(cfgfile.DisableRunner))),
+		server.AttachHandler(path+"/profile/cpu", auth(http.MethodGet, cpuProfileHandler)),
+		server.AttachHandler(path+"/profile/{name}", auth(http.MethodGet, profileHandler)),
+		server.AttachHandler(path+"/diagnostics", auth(http.MethodGet, diagnostics.diagnosticsHandler)),
+	)
+}
+
+func makeControlHandler(token, method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !validToken(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeControlError(w, r, http.StatusUnauthorized, errors.New("invalid or missing token"))
+			return
+		}
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeControlError(w, r, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		h(w, r)
+	}
+}
+
+func validToken(r *http.Request, token string) bool {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, prefix)), []byte(token)) == 1
+}
+
+func reloadHandler(w http.ResponseWriter, r *http.Request) {
+	if err := cfgfile.ReloadConfigs(); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, cfgfile.ErrNoReloader) {
+			status = http.StatusConflict
+		}
+		writeControlError(w, r, status, err)
+		return
+	}
+	writeControlResponse(w, r, http.StatusOK, mapstr.M{"reloaded": true})
+}
+
+func runnersHandler(w http.ResponseWriter, r *http.Request) {
+	writeControlResponse(w, r, http.StatusOK, mapstr.M{"runners": cfgfile.RunnerStates()})
+}
+
+func runnerHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	for _, state := range cfgfile.RunnerStates() {
+		if state.ID == id {
+			writeControlResponse(w, r, http.StatusOK, mapstr.M{"runner": state})
+			return
+		}
+	}
+	writeControlError(w, r, http.StatusNotFound, cfgfile.ErrRunnerNotFound)
+}
+
+func runnerActionHandler(action func(id string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := action(mux.Vars(r)["id"]); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, cfgfile.ErrRunnerNotFound) {
+				status = http.StatusNotFound
+			}
+			writeControlError(w, r, status, err)
+			return
+		}
+		runnerHandler(w, r)
+	}
+}
+
+func writeControlResponse(w http.ResponseWriter, r *http.Request, status int, data mapstr.M) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if _, ok := r.URL.Query()["pretty"]; ok {
+		fmt.Fprint(w, data.StringToPrint())
+	} else {
+		fmt.Fprint(w, data.String())
+	}
+}
+
+func writeControlError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	writeControlResponse(w, r, status, mapstr.M{"error": err.Error()})
+}
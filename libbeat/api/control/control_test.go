@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package control
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/common/reload"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testToken = "4f9c2e7d1a"
+
+type router struct {
+	*mux.Router
+}
+
+func (r router) AttachHandler(route string, h http.Handler) error {
+	return r.Handle(route, h).GetError()
+}
+
+type testRunner struct{}
+
+func (testRunner) Start()         {}
+func (testRunner) Stop()          {}
+func (testRunner) String() string { return "test" }
+
+type testFactory struct{}
+
+func (testFactory) Create(beat.PipelineConnector, *conf.C) (cfgfile.Runner, error) {
+	return testRunner{}, nil
+}
+
+func (testFactory) CheckConfig(*conf.C) error { return nil }
+
+func TestConfigValidate(t *testing.T) {
+	var config *Config
+	err := conf.MustNewConfigFrom(mapstr.M{"enabled": true}).Unpack(&config)
+	assert.Error(t, err)
+
+	config = nil
+	err = conf.MustNewConfigFrom(mapstr.M{"enabled": false}).Unpack(&config)
+	assert.NoError(t, err)
+	assert.False(t, config.IsEnabled())
+
+	config = nil
+	err = conf.MustNewConfigFrom(mapstr.M{"token": testToken}).Unpack(&config)
+	assert.NoError(t, err)
+	assert.True(t, config.IsEnabled())
+}
+
+func TestHttpAttachDisabled(t *testing.T) {
+	r := router{mux.NewRouter()}
+	require.NoError(t, HttpAttach(nil, r, Diagnostics{}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, newRequest(http.MethodGet, "/control/runners", testToken))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestControlAPI(t *testing.T) {
+	r := router{mux.NewRouter()}
+	require.NoError(t, HttpAttach(&Config{Token: testToken}, r, Diagnostics{}))
+
+	list := cfgfile.NewRunnerList("", testFactory{}, nil)
+	defer list.Stop()
+	c := conf.MustNewConfigFrom(mapstr.M{"module": "control_test"})
+	require.NoError(t, list.Reload([]*reload.ConfigWithMeta{{Config: c}}))
+	id := list.States()[0].ID
+
+	do := func(method, path, token string) (int, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(method, path, token))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	t.Run("token is required", func(t *testing.T) {
+		code, body := do(http.MethodGet, "/control/runners", "")
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Contains(t, body, "error")
+
+		code, _ = do(http.MethodGet, "/control/runners", "invalid")
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("method is checked", func(t *testing.T) {
+		code, _ := do(http.MethodGet, "/control/runners/"+id+"/disable", testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, code)
+	})
+
+	t.Run("runners are listed", func(t *testing.T) {
+		code, body := do(http.MethodGet, "/control/runners", testToken)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body["runners"], map[string]interface{}{
+			"id":     id,
+			"name":   "control_test",
+			"status": cfgfile.RunnerRunning,
+		})
+	})
+
+	t.Run("runners are disabled and enabled", func(t *testing.T) {
+		code, body := do(http.MethodPost, "/control/runners/"+id+"/disable", testToken)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, cfgfile.RunnerDisabled, body["runner"].(map[string]interface{})["status"])
+
+		code, body = do(http.MethodGet, "/control/runners/"+id, testToken)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, cfgfile.RunnerDisabled, body["runner"].(map[string]interface{})["status"])
+
+		code, body = do(http.MethodPost, "/control/runners/"+id+"/enable", testToken)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, cfgfile.RunnerRunning, body["runner"].(map[string]interface{})["status"])
+	})
+
+	t.Run("unknown runners are not found", func(t *testing.T) {
+		code, _ := do(http.MethodGet, "/control/runners/1234", testToken)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _ = do(http.MethodPost, "/control/runners/1234/enable", testToken)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("reload without reloaders", func(t *testing.T) {
+		code, _ := do(http.MethodPost, "/control/reload", testToken)
+		assert.Equal(t, http.StatusConflict, code)
+	})
+}
+
+func newRequest(method, path, token string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
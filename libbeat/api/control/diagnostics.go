@@ -0,0 +1,340 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package control
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+const (
+	// maxLogsSize is the maximum size of the logs included in the bundle,
+	// the most recent ones are included first.
+	maxLogsSize = 20 * 1024 * 1024
+
+	redactedValue = "xxxxx"
+)
+
+// redactedKeys are the setting names whose values are redacted in the
+// bundle, in addition to the ones masked in the logs. They are matched against
+// the whole name, so settings like keys_under_root are kept.
+var redactedKeys = []string{
+	"key",
+	"keys",
+	"previous_keys",
+	"certificate",
+}
+
+// redactedKeyParts are the parts of the setting names whose values are
+// redacted in the bundle.
+var redactedKeyParts = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"api_key",
+	"credentials",
+	"access_key",
+	"authorization",
+}
+
+// Diagnostics contains the information of the Beat included in the
+// diagnostics bundle.
+type Diagnostics struct {
+	Info    beat.Info
+	Config  *config.C // Configuration of the Beat, redacted in the bundle.
+	Logging *config.C // Logging configuration, used to find the log files.
+}
+
+type diagnosticsFile struct {
+	name    string
+	content func() ([]byte, error)
+}
+
+// diagnosticsHandler writes a gzipped tarball with the redacted
+// configuration, the monitoring metrics, the state of the runners, the
+// goroutines and heap profiles, and the most recent logs of the Beat.
+func (d Diagnostics) diagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	name := fmt.Sprintf("%s-diagnostics-%s", d.Info.Beat, now.Format("20060102T150405Z"))
+
+	files := []diagnosticsFile{
+		{"beat.json", d.beatInfo},
+		{"config.json", d.redactedConfig},
+		{"stats.json", namespaceSnapshot("stats")},
+		{"state.json", namespaceSnapshot("state")},
+		{"runners.json", func() ([]byte, error) { return json.MarshalIndent(cfgfile.RunnerStates(), "", "  ") }},
+		{"goroutine.txt", profileSnapshot("goroutine", 2)},
+		{"heap.pprof", profileSnapshot("heap", 0)},
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar.gz"`, name))
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		content, err := f.content()
+		if err != nil {
+			content = []byte(fmt.Sprintf("error: %v\n", err))
+		}
+		if err := writeTarFile(tw, name+"/"+f.name, now, content); err != nil {
+			return
+		}
+	}
+	for _, log := range d.recentLogs() {
+		if err := writeTarFile(tw, name+"/logs/"+filepath.Base(log.path), now, log.content); err != nil {
+			return
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, modTime time.Time, content []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(content)
+	return err
+}
+
+func (d Diagnostics) beatInfo() ([]byte, error) {
+	return json.MarshalIndent(mapstr.M{
+		"beat":         d.Info.Beat,
+		"name":         d.Info.Name,
+		"hostname":     d.Info.Hostname,
+		"version":      d.Info.Version,
+		"uuid":         d.Info.ID.String(),
+		"ephemeral_id": d.Info.EphemeralID.String(),
+		"start_time":   d.Info.StartTime,
+	}, "", "  ")
+}
+
+func (d Diagnostics) redactedConfig() ([]byte, error) {
+	if d.Config == nil {
+		return []byte("{}"), nil
+	}
+
+	var content map[string]interface{}
+	if err := d.Config.Unpack(&content); err != nil {
+		return nil, err
+	}
+	config.ApplyLoggingMask(content)
+	redact(content)
+	return json.MarshalIndent(content, "", "  ")
+}
+
+// redact replaces the values of the settings named as any of the redacted
+// keys, or whose names contain any of the redacted key parts, and the values
+// of the headers. It also removes the user information of URLs, as in the
+// hosts or the proxy_url of the outputs. It returns the redacted value.
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			switch {
+			case isRedactedKey(k):
+				v[k] = redactedValue
+			case settingName(k) == "headers":
+				v[k] = redactHeaders(value)
+			default:
+				v[k] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	case string:
+		return redactURL(v)
+	}
+	return v
+}
+
+// redactHeaders replaces the values of a headers map, their names are kept.
+func redactHeaders(v interface{}) interface{} {
+	headers, ok := v.(map[string]interface{})
+	if !ok {
+		return redact(v)
+	}
+	for k := range headers {
+		headers[k] = redactedValue
+	}
+	return headers
+}
+
+// redactURL removes the user information of s if it is a URL.
+func redactURL(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
+
+// settingName returns the lowercased last segment of key, which names the
+// setting if the key contains dots.
+func settingName(key string) string {
+	key = strings.ToLower(key)
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		key = key[i+1:]
+	}
+	return key
+}
+
+func isRedactedKey(key string) bool {
+	key = settingName(key)
+	for _, redacted := range redactedKeys {
+		if key == redacted {
+			return true
+		}
+	}
+	for _, redacted := range redactedKeyParts {
+		if strings.Contains(key, redacted) {
+			return true
+		}
+	}
+	return false
+}
+
+func namespaceSnapshot(name string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		data := monitoring.CollectStructSnapshot(
+			monitoring.GetNamespace(name).GetRegistry(),
+			monitoring.Full,
+			false,
+		)
+		return json.MarshalIndent(data, "", "  ")
+	}
+}
+
+func profileSnapshot(name string, debug int) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		var buf bytes.Buffer
+		if err := pprof.Lookup(name).WriteTo(&buf, debug); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+type logFile struct {
+	path    string
+	modTime time.Time
+	content []byte
+}
+
+// recentLogs returns the content of the most recent log files, up to
+// maxLogsSize. Only the end of the oldest file included may be returned.
+func (d Diagnostics) recentLogs() []logFile {
+	logConfig := struct {
+		Files struct {
+			Path string `config:"path"`
+			Name string `config:"name"`
+		} `config:"files"`
+	}{}
+	if d.Logging != nil {
+		if err := d.Logging.Unpack(&logConfig); err != nil {
+			return nil
+		}
+	}
+	name := logConfig.Files.Name
+	if name == "" {
+		name = d.Info.Beat
+	}
+
+	dir := paths.Resolve(paths.Logs, logConfig.Files.Path)
+	matches, err := filepath.Glob(filepath.Join(dir, name+"*"))
+	if err != nil {
+		return nil
+	}
+
+	var logs []logFile
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			logs = append(logs, logFile{path: path, modTime: info.ModTime()})
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].modTime.After(logs[j].modTime) })
+
+	remaining := int64(maxLogsSize)
+	var result []logFile
+	for _, log := range logs {
+		if remaining <= 0 {
+			break
+		}
+		content, err := readTail(log.path, remaining)
+		if err != nil {
+			continue
+		}
+		log.content = content
+		remaining -= int64(len(content))
+		result = append(result, log)
+	}
+	return result
+}
+
+// readTail reads at most the last size bytes of a file.
+func readTail(path string, size int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if offset := info.Size() - size; offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(io.LimitReader(f, size))
+}
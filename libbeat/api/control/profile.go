@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package control
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultProfileDuration = 30 * time.Second
+	maxProfileDuration     = 5 * time.Minute
+)
+
+// cpuProfileHandler streams a CPU profile collected during the duration
+// given in the query, or 30 seconds by default.
+func cpuProfileHandler(w http.ResponseWriter, r *http.Request) {
+	duration, err := profileDuration(r)
+	if err != nil {
+		writeControlError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="cpu.pprof"`)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		// Only one CPU profile can be collected at a time.
+		w.Header().Del("Content-Disposition")
+		writeControlError(w, r, http.StatusConflict, err)
+		return
+	}
+
+	select {
+	case <-time.After(duration):
+	case <-r.Context().Done():
+	}
+	pprof.StopCPUProfile()
+}
+
+// profileHandler writes a snapshot of the runtime profile in the route,
+// like heap or goroutine.
+func profileHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		writeControlError(w, r, http.StatusNotFound, fmt.Errorf("unknown profile %q", name))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pprof"`, name))
+	_ = profile.WriteTo(w, 0)
+}
+
+func profileDuration(r *http.Request) (time.Duration, error) {
+	value := r.URL.Query().Get("duration")
+	if value == "" {
+		return defaultProfileDuration, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %w", err)
+	}
+	if duration <= 0 || duration > maxProfileDuration {
+		return 0, errors.New("duration must be positive and at most " + maxProfileDuration.String())
+	}
+	return duration, nil
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package control
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	r := router{mux.NewRouter()}
+	require.NoError(t, HttpAttach(&Config{Token: testToken}, r, Diagnostics{}))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(http.MethodGet, path, testToken))
+		return rec
+	}
+
+	t.Run("cpu", func(t *testing.T) {
+		rec := get("/control/profile/cpu?duration=100ms")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Body.Bytes())
+		assert.Equal(t, `attachment; filename="cpu.pprof"`, rec.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("invalid cpu duration", func(t *testing.T) {
+		for _, duration := range []string{"foo", "-1s", "1h"} {
+			rec := get("/control/profile/cpu?duration=" + duration)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, duration)
+		}
+	})
+
+	t.Run("heap", func(t *testing.T) {
+		rec := get("/control/profile/heap")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Body.Bytes())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		rec := get("/control/profile/unknown")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("token is required", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(http.MethodGet, "/control/profile/heap", ""))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
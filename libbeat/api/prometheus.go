@@ -0,0 +1,191 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// prometheusContentType is the content type of the Prometheus text
+// exposition format.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// datasetLabels are the string metrics of the dataset registries that are
+// exposed as labels of their numeric metrics.
+var datasetLabels = []string{"id", "input", "module", "metricset", "host"}
+
+// labelValueEscaper escapes label values as required by the exposition format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricFamily contains the samples of a metric with the same name.
+type metricFamily struct {
+	name    string
+	samples []metricSample
+}
+
+type metricSample struct {
+	labels string
+	value  float64
+}
+
+// makePrometheusHandler returns a handler that exposes the numeric metrics of
+// the stats and dataset namespaces in the Prometheus text exposition format.
+func makePrometheusHandler(stats, dataset *monitoring.Namespace) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", prometheusContentType)
+
+		families := map[string]*metricFamily{}
+		collectStats(families, stats.GetRegistry())
+		collectDataset(families, dataset.GetRegistry())
+		writePrometheus(w, families)
+	}
+}
+
+// collectStats adds the metrics in the registry, named after their path in
+// the registry.
+func collectStats(families map[string]*metricFamily, reg *monitoring.Registry) {
+	data := monitoring.CollectStructSnapshot(reg, monitoring.Full, false)
+	flattenMetrics(data, "", func(name string, value float64) {
+		addSample(families, name, "", value)
+	})
+}
+
+// collectDataset adds the metrics of each one of the registries in the
+// dataset namespace, prefixed with dataset and labeled with the values of
+// the datasetLabels of the registry.
+func collectDataset(families map[string]*metricFamily, reg *monitoring.Registry) {
+	data := monitoring.CollectStructSnapshot(reg, monitoring.Full, false)
+	for id, v := range data {
+		metrics, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		values := map[string]string{"id": id}
+		for _, label := range datasetLabels {
+			if s, ok := metrics[label].(string); ok {
+				values[label] = s
+			}
+		}
+		labels := formatLabels(values)
+
+		flattenMetrics(metrics, "dataset", func(name string, value float64) {
+			addSample(families, name, labels, value)
+		})
+	}
+}
+
+// flattenMetrics calls add for each numeric or boolean value in data, with
+// the path of the value as name. Strings are ignored.
+func flattenMetrics(data map[string]interface{}, prefix string, add func(string, float64)) {
+	for k, v := range data {
+		name := k
+		if prefix != "" {
+			name = prefix + "." + k
+		}
+
+		switch v := v.(type) {
+		case map[string]interface{}:
+			flattenMetrics(v, name, add)
+		case int64:
+			add(name, float64(v))
+		case uint64:
+			add(name, float64(v))
+		case float64:
+			add(name, v)
+		case bool:
+			if v {
+				add(name, 1)
+			} else {
+				add(name, 0)
+			}
+		}
+	}
+}
+
+func addSample(families map[string]*metricFamily, name, labels string, value float64) {
+	name = metricName(name)
+	family, found := families[name]
+	if !found {
+		family = &metricFamily{name: name}
+		families[name] = family
+	}
+	family.samples = append(family.samples, metricSample{labels: labels, value: value})
+}
+
+// metricName converts the path of a metric in a registry to a valid
+// Prometheus metric name.
+func metricName(path string) string {
+	name := []byte(path)
+	for i, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == ':') {
+			name[i] = '_'
+		}
+	}
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		return "_" + string(name)
+	}
+	return string(name)
+}
+
+func formatLabels(values map[string]string) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelValueEscaper.Replace(values[name]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// writePrometheus writes the metric families sorted by name. Metrics whose
+// name end in _total are exposed as counters, and the rest as gauges.
+func writePrometheus(w io.Writer, families map[string]*metricFamily) {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		family := families[name]
+		metricType := "gauge"
+		if strings.HasSuffix(name, "_total") {
+			metricType = "counter"
+		}
+		fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+
+		sort.Slice(family.samples, func(i, j int) bool {
+			return family.samples[i].labels < family.samples[j].labels
+		})
+		for _, sample := range family.samples {
+			fmt.Fprintf(w, "%s%s %s\n", name, sample.labels, strconv.FormatFloat(sample.value, 'g', -1, 64))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	stats := monitoring.NewRegistry()
+	monitoring.NewInt(stats, "libbeat.pipeline.events.total").Set(42)
+	monitoring.NewUint(stats, "libbeat.pipeline.queue.filled.events").Set(3)
+	monitoring.NewFloat(stats, "system.load.1").Set(0.5)
+	monitoring.NewString(stats, "libbeat.output.type").Set("elasticsearch")
+
+	dataset := monitoring.NewRegistry()
+	for _, id := range []string{"b", "a"} {
+		reg := dataset.NewRegistry(id)
+		monitoring.NewString(reg, "id").Set(id)
+		monitoring.NewString(reg, "input").Set("filestream")
+		monitoring.NewString(reg, "starttime").Set("2022-09-05T10:00:00.000Z")
+		monitoring.NewUint(reg, "events_processed_total").Set(10)
+		monitoring.NewBool(reg, "running").Set(true)
+	}
+
+	statsNS := monitoring.GetNamespace("test_prometheus_stats")
+	statsNS.SetRegistry(stats)
+	datasetNS := monitoring.GetNamespace("test_prometheus_dataset")
+	datasetNS.SetRegistry(dataset)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	makePrometheusHandler(statsNS, datasetNS)(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, prometheusContentType, res.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	expected := `# TYPE dataset_events_processed_total counter
+dataset_events_processed_total{id="a",input="filestream"} 10
+dataset_events_processed_total{id="b",input="filestream"} 10
+# TYPE dataset_running gauge
+dataset_running{id="a",input="filestream"} 1
+dataset_running{id="b",input="filestream"} 1
+# TYPE libbeat_pipeline_events_total counter
+libbeat_pipeline_events_total 42
+# TYPE libbeat_pipeline_queue_filled_events gauge
+libbeat_pipeline_queue_filled_events 3
+# TYPE system_load_1 gauge
+system_load_1 0.5
+`
+	assert.Equal(t, expected, string(body))
+}
+
+func TestMetricName(t *testing.T) {
+	tests := map[string]string{
+		"libbeat.pipeline.events.total": "libbeat_pipeline_events_total",
+		"dataset.fetch-duration.p99":    "dataset_fetch_duration_p99",
+		"1m.load":                       "_1m_load",
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, metricName(path), path)
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	labels := formatLabels(map[string]string{
+		"input": "aws-s3",
+		"id":    "my \"input\"\n",
+	})
+	assert.Equal(t, `{id="my \"input\"\n",input="aws-s3"}`, labels)
+}
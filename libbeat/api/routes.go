@@ -44,6 +44,7 @@ func NewWithDefaultRoutes(log *logp.Logger, config *config.C, ns lookupFunc) (*S
 		api.AttachHandler("/state", makeAPIHandler(ns("state"))),
 		api.AttachHandler("/stats", makeAPIHandler(ns("stats"))),
 		api.AttachHandler("/dataset", makeAPIHandler(ns("dataset"))),
+		api.AttachHandler("/metrics", makePrometheusHandler(ns("stats"), ns("dataset"))),
 	)
 	if err != nil {
 		return nil, err
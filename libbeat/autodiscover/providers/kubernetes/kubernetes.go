@@ -34,6 +34,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/autodiscover"
 	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/beats/v7/libbeat/common/k8swatcher"
 	"github.com/elastic/elastic-agent-autodiscover/bus"
 	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
 	"github.com/elastic/elastic-agent-autodiscover/kubernetes/k8skeystore"
@@ -74,6 +75,12 @@ type Provider struct {
 	appenders    autodiscover.Appenders
 	logger       *logp.Logger
 	eventManager EventManager
+
+	// leaderTemplates are launched only when elected as leader, leaderManager
+	// runs the leader election when there are leader templates in a provider
+	// that is not unique.
+	leaderTemplates template.Mapper
+	leaderManager   EventManager
 }
 
 // eventerManager implements start/stop methods for autodiscover provider with resource eventer
@@ -110,14 +117,25 @@ func AutodiscoverBuilder(
 		return nil, errWrap(err)
 	}
 
-	client, err := kubernetes.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
+	client, err := k8swatcher.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
 	if err != nil {
 		return nil, errWrap(err)
 	}
 
 	k8sKeystoreProvider := k8skeystore.NewKubernetesKeystoresRegistry(logger, client)
 
-	mapper, err := template.NewConfigMapper(config.Templates, keystore, k8sKeystoreProvider)
+	// Templates of unique providers are all launched by the leader, other
+	// providers can have unique templates launched by the leader only.
+	templates, leaderTemplates := config.Templates.SplitUnique()
+	if config.Unique {
+		templates, leaderTemplates = nil, config.Templates
+	}
+
+	mapper, err := template.NewConfigMapper(templates, keystore, k8sKeystoreProvider)
+	if err != nil {
+		return nil, errWrap(err)
+	}
+	leaderMapper, err := template.NewConfigMapper(leaderTemplates, keystore, k8sKeystoreProvider)
 	if err != nil {
 		return nil, errWrap(err)
 	}
@@ -133,18 +151,22 @@ func AutodiscoverBuilder(
 	}
 
 	p := &Provider{
-		config:    config,
-		bus:       bus,
-		templates: mapper,
-		builders:  builders,
-		appenders: appenders,
-		logger:    logger,
+		config:          config,
+		bus:             bus,
+		templates:       mapper,
+		builders:        builders,
+		appenders:       appenders,
+		logger:          logger,
+		leaderTemplates: leaderMapper,
 	}
 
 	if p.config.Unique {
 		p.eventManager, err = NewLeaderElectionManager(uuid, config, client, p.startLeading, p.stopLeading, logger)
 	} else {
 		p.eventManager, err = NewEventerManager(uuid, c, config, client, p.publish)
+		if err == nil && len(leaderMapper.ConditionMaps) > 0 {
+			p.leaderManager, err = NewLeaderElectionManager(uuid, config, client, p.startLeading, p.stopLeading, logger)
+		}
 	}
 
 	if err != nil {
@@ -157,10 +179,16 @@ func AutodiscoverBuilder(
 // Start for Runner interface.
 func (p *Provider) Start() {
 	p.eventManager.Start()
+	if p.leaderManager != nil {
+		p.leaderManager.Start()
+	}
 }
 
 // Stop signals the stop channel to force the watch loop routine to stop.
 func (p *Provider) Stop() {
+	if p.leaderManager != nil {
+		p.leaderManager.Stop()
+	}
 	p.eventManager.Stop()
 }
 
@@ -212,7 +240,7 @@ func (p *Provider) startLeading(uuid string, eventID string) {
 		"id":       eventID,
 		"unique":   "true",
 	}
-	if config := p.templates.GetConfig(event); config != nil {
+	if config := p.leaderTemplates.GetConfig(event); config != nil {
 		event["config"] = config
 	}
 	p.bus.Publish(event)
@@ -225,7 +253,7 @@ func (p *Provider) stopLeading(uuid string, eventID string) {
 		"id":       eventID,
 		"unique":   "true",
 	}
-	if config := p.templates.GetConfig(event); config != nil {
+	if config := p.leaderTemplates.GetConfig(event); config != nil {
 		event["config"] = config
 	}
 	p.bus.Publish(event)
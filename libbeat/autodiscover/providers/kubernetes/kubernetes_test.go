@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin || windows
+// +build linux darwin windows
+
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/autodiscover"
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestLeaderTemplates(t *testing.T) {
+	cfg, err := conf.NewConfigWithYAML([]byte(`
+- config:
+  - module: kubernetes
+    metricsets: [pod]
+- unique: true
+  config:
+  - module: kubernetes
+    metricsets: [state_pod]
+`), "")
+	require.NoError(t, err)
+	var settings template.MapperSettings
+	require.NoError(t, cfg.Unpack(&settings))
+
+	templates, leaderTemplates := settings.SplitUnique()
+	mapper, err := template.NewConfigMapper(templates, nil, nil)
+	require.NoError(t, err)
+	leaderMapper, err := template.NewConfigMapper(leaderTemplates, nil, nil)
+	require.NoError(t, err)
+
+	b := bus.New(logp.NewLogger("bus"), "test")
+	listener := b.Subscribe()
+	defer listener.Stop()
+
+	appenders, err := autodiscover.NewAppenders(nil)
+	require.NoError(t, err)
+	p := &Provider{
+		config:          defaultConfig(),
+		bus:             b,
+		templates:       mapper,
+		leaderTemplates: leaderMapper,
+		appenders:       appenders,
+		logger:          logp.NewLogger("kubernetes"),
+	}
+
+	metricsets := func(event bus.Event) []string {
+		var result []string
+		configs, _ := event["config"].([]*conf.C)
+		for _, c := range configs {
+			var module struct {
+				Metricsets []string `config:"metricsets"`
+			}
+			require.NoError(t, c.Unpack(&module))
+			result = append(result, module.Metricsets...)
+		}
+		return result
+	}
+	next := func() bus.Event {
+		select {
+		case event := <-listener.Events():
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+			return nil
+		}
+	}
+
+	// Only the templates that are not unique are launched for resources.
+	p.publish([]bus.Event{{"start": true, "id": "pod-1", "kubernetes": mapstr.M{"pod": mapstr.M{"name": "a"}}}})
+	assert.Equal(t, []string{"pod"}, metricsets(next()))
+
+	// The unique templates are launched by the leader.
+	p.startLeading("uuid", "leader-1")
+	event := next()
+	assert.Equal(t, true, event["start"])
+	assert.Equal(t, []string{"state_pod"}, metricsets(event))
+
+	p.stopLeading("uuid", "leader-2")
+	event = next()
+	assert.Equal(t, true, event["stop"])
+	assert.Equal(t, []string{"state_pod"}, metricsets(event))
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package podman
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/elastic-agent-autodiscover/docker"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// Config for podman autodiscover provider
+type Config struct {
+	Host           string                  `config:"host"`
+	TLS            *docker.TLSConfig       `config:"ssl"`
+	Prefix         string                  `config:"prefix"`
+	Hints          *config.C               `config:"hints"`
+	Builders       []*config.C             `config:"builders"`
+	Appenders      []*config.C             `config:"appenders"`
+	Templates      template.MapperSettings `config:"templates"`
+	Dedot          bool                    `config:"labels.dedot"`
+	CleanupTimeout time.Duration           `config:"cleanup_timeout" validate:"positive"`
+}
+
+// Public variable, so specific beats (as Filebeat) can set a different cleanup timeout if they need it.
+var DefaultCleanupTimeout time.Duration = 0
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:           defaultHost(os.Getuid(), os.Getenv("XDG_RUNTIME_DIR")),
+		Prefix:         "co.elastic",
+		Dedot:          true,
+		CleanupTimeout: DefaultCleanupTimeout,
+	}
+}
+
+// defaultHost returns the socket of the Podman service of the user: the
+// system socket for root, and the socket of the rootless service otherwise.
+func defaultHost(uid int, runtimeDir string) string {
+	if uid == 0 {
+		return "unix:///run/podman/podman.sock"
+	}
+	if runtimeDir == "" {
+		runtimeDir = "/run/user/" + strconv.Itoa(uid)
+	}
+	return fmt.Sprintf("unix://%s/podman/podman.sock", runtimeDir)
+}
+
+// Validate ensures correctness of config
+func (c *Config) Validate() error {
+	// Make sure that prefix doesn't ends with a '.'
+	if len(c.Prefix) > 1 && c.Prefix[len(c.Prefix)-1] == '.' {
+		c.Prefix = c.Prefix[:len(c.Prefix)-1]
+	}
+	return nil
+}
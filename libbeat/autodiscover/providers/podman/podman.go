@@ -0,0 +1,376 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package podman
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/libbeat/autodiscover"
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/beats/v7/libbeat/common"
+
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	"github.com/elastic/elastic-agent-autodiscover/docker"
+	"github.com/elastic/elastic-agent-autodiscover/utils"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/safemapstr"
+)
+
+func init() {
+	_ = autodiscover.Registry.AddProvider("podman", AutodiscoverBuilder)
+}
+
+// Provider implements autodiscover provider for podman containers. The
+// containers are watched with the Docker compatible API of the Podman
+// service, that must be enabled with the podman.socket systemd unit.
+type Provider struct {
+	config        *Config
+	bus           bus.Bus
+	uuid          uuid.UUID
+	builders      autodiscover.Builders
+	appenders     autodiscover.Appenders
+	watcher       docker.Watcher
+	templates     template.Mapper
+	stop          chan interface{}
+	startListener bus.Listener
+	stopListener  bus.Listener
+	stoppers      map[string]*time.Timer
+	stopTrigger   chan *containerMetadata
+	logger        *logp.Logger
+}
+
+// AutodiscoverBuilder builds and returns an autodiscover provider
+func AutodiscoverBuilder(
+	beatName string,
+	bus bus.Bus,
+	uuid uuid.UUID,
+	c *config.C,
+	keystore keystore.Keystore,
+) (autodiscover.Provider, error) {
+	logger := logp.NewLogger("podman")
+
+	errWrap := func(err error) error {
+		return fmt.Errorf("error setting up podman autodiscover provider: %w", err)
+	}
+
+	config := defaultConfig()
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, errWrap(err)
+	}
+
+	watcher, err := docker.NewWatcher(logger, config.Host, config.TLS, false)
+	if err != nil {
+		return nil, errWrap(err)
+	}
+
+	mapper, err := template.NewConfigMapper(config.Templates, keystore, nil)
+	if err != nil {
+		return nil, errWrap(err)
+	}
+	if len(mapper.ConditionMaps) == 0 && !config.Hints.Enabled() {
+		return nil, errWrap(fmt.Errorf("no configs or hints defined for autodiscover provider"))
+	}
+
+	builders, err := autodiscover.NewBuilders(config.Builders, config.Hints, nil)
+	if err != nil {
+		return nil, errWrap(err)
+	}
+
+	appenders, err := autodiscover.NewAppenders(config.Appenders)
+	if err != nil {
+		return nil, errWrap(err)
+	}
+
+	start := watcher.ListenStart()
+	stop := watcher.ListenStop()
+
+	if err := watcher.Start(); err != nil {
+		return nil, errWrap(fmt.Errorf("failed to connect to the podman service at %v: %w", config.Host, err))
+	}
+
+	return &Provider{
+		config:        config,
+		bus:           bus,
+		uuid:          uuid,
+		builders:      builders,
+		appenders:     appenders,
+		templates:     mapper,
+		watcher:       watcher,
+		stop:          make(chan interface{}),
+		startListener: start,
+		stopListener:  stop,
+		stoppers:      make(map[string]*time.Timer),
+		stopTrigger:   make(chan *containerMetadata),
+		logger:        logger,
+	}, nil
+}
+
+// Start the autodiscover process
+func (p *Provider) Start() {
+	go func() {
+		for {
+			select {
+			case <-p.stop:
+				p.startListener.Stop()
+				p.stopListener.Stop()
+				p.watcher.Stop()
+
+				// Stop all timers before closing the channel
+				for _, stopper := range p.stoppers {
+					stopper.Stop()
+				}
+				close(p.stopTrigger)
+				return
+
+			case event := <-p.startListener.Events():
+				p.startContainer(event)
+
+			case event := <-p.stopListener.Events():
+				p.scheduleStopContainer(event)
+
+			case target := <-p.stopTrigger:
+				p.stopContainer(target.container, target.metadata)
+			}
+		}
+	}()
+}
+
+type containerMetadata struct {
+	container *docker.Container
+	metadata  *podmanMetadata
+}
+
+type podmanMetadata struct {
+	// ECS-based selectors
+	Container mapstr.M
+
+	// Metadata used to enrich events, like ECS-based selectors but can
+	// have modifications like dedotting
+	Metadata mapstr.M
+}
+
+func (p *Provider) generateMeta(event bus.Event) (*docker.Container, *podmanMetadata) {
+	container, ok := event["container"].(*docker.Container)
+	if !ok {
+		p.logger.Error(errors.New("couldn't get a container from watcher event"))
+		return nil, nil
+	}
+
+	// Don't dedot selectors, dedot only metadata used for events enrichment
+	labelMap := mapstr.M{}
+	metaLabelMap := mapstr.M{}
+	for k, v := range container.Labels {
+		err := safemapstr.Put(labelMap, k, v)
+		if err != nil {
+			p.logger.Debugf("error adding k:v (%v:%v): %v", k, v, err)
+		}
+		if p.config.Dedot {
+			label := common.DeDot(k)
+			_, err := metaLabelMap.Put(label, v)
+			if err != nil {
+				p.logger.Debugf("error adding value (%v): %v", v, err)
+			}
+		} else {
+			err := safemapstr.Put(metaLabelMap, k, v)
+			if err != nil {
+				p.logger.Debugf("error adding k:v (%v:%v): %v", k, v, err)
+			}
+		}
+	}
+
+	meta := &podmanMetadata{
+		Container: mapstr.M{
+			"id":   container.ID,
+			"name": container.Name,
+			"image": mapstr.M{
+				"name": container.Image,
+			},
+			"labels": labelMap,
+		},
+		Metadata: mapstr.M{
+			"container": mapstr.M{
+				"id":   container.ID,
+				"name": container.Name,
+				"image": mapstr.M{
+					"name": container.Image,
+				},
+				"runtime": "podman",
+				"labels":  metaLabelMap,
+			},
+		},
+	}
+
+	return container, meta
+}
+
+func (p *Provider) startContainer(event bus.Event) {
+	container, meta := p.generateMeta(event)
+	if container == nil || meta == nil {
+		return
+	}
+
+	if stopper, ok := p.stoppers[container.ID]; ok {
+		p.logger.Debugf("Container %s is restarting, aborting pending stop", container.ID)
+		stopper.Stop()
+		delete(p.stoppers, container.ID)
+		return
+	}
+
+	p.emitContainer(container, meta, "start")
+}
+
+func (p *Provider) scheduleStopContainer(event bus.Event) {
+	container, meta := p.generateMeta(event)
+	if container == nil || meta == nil {
+		return
+	}
+
+	if p.config.CleanupTimeout <= 0 {
+		p.stopContainer(container, meta)
+		return
+	}
+
+	stopper := time.AfterFunc(p.config.CleanupTimeout, func() {
+		p.stopTrigger <- &containerMetadata{
+			container: container,
+			metadata:  meta,
+		}
+	})
+	p.stoppers[container.ID] = stopper
+}
+
+func (p *Provider) stopContainer(container *docker.Container, meta *podmanMetadata) {
+	delete(p.stoppers, container.ID)
+
+	p.emitContainer(container, meta, "stop")
+}
+
+func (p *Provider) emitContainer(container *docker.Container, meta *podmanMetadata, flag string) {
+	var host string
+	if len(container.IPAddresses) > 0 {
+		host = container.IPAddresses[0]
+	}
+
+	// Without ports, a single event is emitted for the container, otherwise
+	// one event per port, so there are no overlapping configurations with
+	// and without ports.
+	if len(container.Ports) == 0 {
+		p.publish([]bus.Event{{
+			"provider":  p.uuid,
+			"id":        container.ID,
+			flag:        true,
+			"host":      host,
+			"container": meta.Container,
+			"meta":      meta.Metadata,
+		}})
+		return
+	}
+
+	ports := mapstr.M{}
+	for _, port := range container.Ports {
+		ports[strconv.FormatUint(uint64(port.PrivatePort), 10)] = port.PublicPort
+	}
+	events := make([]bus.Event, 0, len(container.Ports))
+	for _, port := range container.Ports {
+		events = append(events, bus.Event{
+			"provider":  p.uuid,
+			"id":        container.ID,
+			flag:        true,
+			"host":      host,
+			"port":      port.PrivatePort,
+			"ports":     ports,
+			"container": meta.Container,
+			"meta":      meta.Metadata,
+		})
+	}
+	p.publish(events)
+}
+
+func (p *Provider) publish(events []bus.Event) {
+	configs := make([]*config.C, 0)
+	for _, event := range events {
+		// Try to match a config
+		if config := p.templates.GetConfig(event); config != nil {
+			configs = append(configs, config...)
+		} else {
+			// If there isn't a default template then attempt to use builders
+			e := p.generateHints(event)
+			if config := p.builders.GetConfig(e); config != nil {
+				configs = append(configs, config...)
+			}
+		}
+	}
+
+	// Since all the events belong to the same event ID pick on and add in all the configs
+	event := bus.Event(mapstr.M(events[0]).Clone())
+	// Remove the port to avoid ambiguity during debugging
+	delete(event, "port")
+	delete(event, "ports")
+	event["config"] = configs
+
+	// Call all appenders to append any extra configuration
+	p.appenders.Append(event)
+	p.bus.Publish(event)
+}
+
+func (p *Provider) generateHints(event bus.Event) bus.Event {
+	// Try to build a config with enabled builders. Send a provider agnostic payload.
+	// Builders are Beat specific.
+	e := bus.Event{}
+	containerMeta, _ := event["container"].(mapstr.M)
+	if containerMeta != nil {
+		e["container"] = containerMeta
+	}
+
+	if host, ok := event["host"]; ok {
+		e["host"] = host
+	}
+	if port, ok := event["port"]; ok {
+		e["port"] = port
+	}
+	if ports, ok := event["ports"]; ok {
+		e["ports"] = ports
+	}
+	if labels, err := containerMeta.GetValue("labels"); err == nil {
+		if labels, ok := labels.(mapstr.M); ok {
+			e["hints"] = utils.GenerateHints(labels, "", p.config.Prefix)
+		}
+	}
+	return e
+}
+
+// Stop the autodiscover process
+func (p *Provider) Stop() {
+	close(p.stop)
+}
+
+func (p *Provider) String() string {
+	return "podman"
+}
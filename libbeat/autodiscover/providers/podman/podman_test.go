@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package podman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	"github.com/elastic/elastic-agent-autodiscover/docker"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestDefaultHost(t *testing.T) {
+	assert.Equal(t, "unix:///run/podman/podman.sock", defaultHost(0, "/run/user/0"))
+	assert.Equal(t, "unix:///run/user/1000/podman/podman.sock", defaultHost(1000, "/run/user/1000"))
+	assert.Equal(t, "unix:///run/user/1001/podman/podman.sock", defaultHost(1001, ""))
+	assert.Equal(t, "unix:///tmp/xdg/podman/podman.sock", defaultHost(1000, "/tmp/xdg"))
+}
+
+func TestGenerateHints(t *testing.T) {
+	tests := []struct {
+		event  bus.Event
+		result bus.Event
+	}{
+		// Empty events should return empty hints
+		{
+			event:  bus.Event{},
+			result: bus.Event{},
+		},
+		// Container meta must be present in the hints
+		{
+			event: bus.Event{
+				"container": mapstr.M{
+					"id":   "abc",
+					"name": "foobar",
+				},
+				"host": "10.88.0.2",
+				"port": 80,
+			},
+			result: bus.Event{
+				"container": mapstr.M{
+					"id":   "abc",
+					"name": "foobar",
+				},
+				"host": "10.88.0.2",
+				"port": 80,
+			},
+		},
+		// Labels with the prefix are converted to hints
+		{
+			event: bus.Event{
+				"container": mapstr.M{
+					"id": "abc",
+					"labels": mapstr.M{
+						"do":         mapstr.M{"not": mapstr.M{"include": "true"}},
+						"co.elastic": mapstr.M{"logs/disable": "true"},
+					},
+				},
+			},
+			result: bus.Event{
+				"container": mapstr.M{
+					"id": "abc",
+					"labels": mapstr.M{
+						"do":         mapstr.M{"not": mapstr.M{"include": "true"}},
+						"co.elastic": mapstr.M{"logs/disable": "true"},
+					},
+				},
+				"hints": mapstr.M{
+					"logs": mapstr.M{
+						"disable": "true",
+					},
+				},
+			},
+		},
+	}
+
+	p := Provider{
+		config: defaultConfig(),
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.result, p.generateHints(test.event))
+	}
+}
+
+func TestGenerateMeta(t *testing.T) {
+	event := bus.Event{
+		"container": &docker.Container{
+			ID:    "abc",
+			Name:  "foobar",
+			Image: "docker.io/library/nginx:latest",
+			Labels: map[string]string{
+				"io.podman.pod": "web",
+			},
+		},
+	}
+
+	for _, dedot := range []bool{true, false} {
+		cfg := defaultConfig()
+		cfg.Dedot = dedot
+		p := Provider{
+			config: cfg,
+			logger: logp.NewLogger("podman"),
+		}
+
+		metaLabels := mapstr.M{"io": mapstr.M{"podman": mapstr.M{"pod": "web"}}}
+		if dedot {
+			metaLabels = mapstr.M{"io_podman_pod": "web"}
+		}
+
+		_, meta := p.generateMeta(event)
+		assert.Equal(t, &podmanMetadata{
+			Container: mapstr.M{
+				"id":     "abc",
+				"name":   "foobar",
+				"image":  mapstr.M{"name": "docker.io/library/nginx:latest"},
+				"labels": mapstr.M{"io": mapstr.M{"podman": mapstr.M{"pod": "web"}}},
+			},
+			Metadata: mapstr.M{
+				"container": mapstr.M{
+					"id":      "abc",
+					"name":    "foobar",
+					"image":   mapstr.M{"name": "docker.io/library/nginx:latest"},
+					"runtime": "podman",
+					"labels":  metaLabels,
+				},
+			},
+		}, meta)
+	}
+}
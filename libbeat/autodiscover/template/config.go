@@ -51,6 +51,22 @@ type ConditionMap struct {
 type MapperSettings []*struct {
 	ConditionConfig *conditions.Config `config:"condition"`
 	Configs         []*conf.C          `config:"config"`
+
+	// Unique templates are launched by a single instance of the provider,
+	// for the providers supporting it, like the leader in a Kubernetes cluster.
+	Unique bool `config:"unique"`
+}
+
+// SplitUnique returns the unique templates and the other ones separately.
+func (s MapperSettings) SplitUnique() (templates MapperSettings, unique MapperSettings) {
+	for _, c := range s {
+		if c != nil && c.Unique {
+			unique = append(unique, c)
+		} else {
+			templates = append(templates, c)
+		}
+	}
+	return templates, unique
 }
 
 // NewConfigMapper builds a template Mapper from given settings
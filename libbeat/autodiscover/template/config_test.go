@@ -338,3 +338,34 @@ func getTemporaryKeystoreFile() string {
 	}
 	return filepath.Join(path, "keystore")
 }
+
+func TestSplitUnique(t *testing.T) {
+	config, err := conf.NewConfigWithYAML([]byte(`
+- condition.equals:
+    kubernetes.namespace: default
+  config:
+  - module: nginx
+- unique: true
+  config:
+  - module: kubernetes
+    metricsets: [state_pod]
+`), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mappings MapperSettings
+	if err := config.Unpack(&mappings); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, unique := mappings.SplitUnique()
+	if assert.Len(t, templates, 1) {
+		assert.False(t, templates[0].Unique)
+		assert.NotNil(t, templates[0].ConditionConfig)
+	}
+	if assert.Len(t, unique, 1) {
+		assert.True(t, unique[0].Unique)
+		assert.Nil(t, unique[0].ConditionConfig)
+	}
+}
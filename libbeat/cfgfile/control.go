@@ -0,0 +1,281 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgfile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// Status values of a RunnerState.
+const (
+	RunnerRunning  = "running"
+	RunnerDisabled = "disabled"
+	RunnerFailed   = "failed"
+)
+
+var (
+	// ErrRunnerNotFound is returned when no runner list has a configuration
+	// with the given ID.
+	ErrRunnerNotFound = errors.New("runner not found")
+
+	// ErrNoReloader is returned by ReloadConfigs when there are no config
+	// files loaded from a path.
+	ErrNoReloader = errors.New("no configuration is loaded from files")
+
+	errReloaderStopped = errors.New("config reloader stopped")
+
+	listsMu sync.Mutex
+	lists   = map[*RunnerList]struct{}{}
+
+	reloadersMu sync.Mutex
+	reloaders   = map[*Reloader]struct{}{}
+)
+
+// LastErrorReporter is implemented by runners that keep the last error they
+// found while running, so it is reported in their state.
+type LastErrorReporter interface {
+	LastError() error
+}
+
+// RunnerState is the state of the runner of a configuration in a list.
+type RunnerState struct {
+	// ID identifies the configuration, it changes when the configuration
+	// changes.
+	ID string `json:"id"`
+
+	// Name is the id, module or type of the configuration.
+	Name string `json:"name,omitempty"`
+
+	Status    string `json:"status"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// RunnerStates returns the state of the configurations of all the running
+// lists, sorted by ID.
+func RunnerStates() []RunnerState {
+	states := []RunnerState{}
+	for _, r := range runnerLists() {
+		states = append(states, r.States()...)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return states
+}
+
+// EnableRunner starts again the runner of the configuration with the given
+// ID, in the list that contains it.
+func EnableRunner(id string) error {
+	return withRunnerList(id, (*RunnerList).Enable)
+}
+
+// DisableRunner stops the runner of the configuration with the given ID, in
+// the list that contains it. It is not started on reloads until enabled.
+func DisableRunner(id string) error {
+	return withRunnerList(id, (*RunnerList).Disable)
+}
+
+func withRunnerList(id string, fn func(*RunnerList, uint64) error) error {
+	hash, err := strconv.ParseUint(id, 16, 64)
+	if err != nil {
+		return ErrRunnerNotFound
+	}
+	for _, r := range runnerLists() {
+		if err := fn(r, hash); !errors.Is(err, ErrRunnerNotFound) {
+			return err
+		}
+	}
+	return ErrRunnerNotFound
+}
+
+func registerList(r *RunnerList) {
+	listsMu.Lock()
+	defer listsMu.Unlock()
+	lists[r] = struct{}{}
+}
+
+func unregisterList(r *RunnerList) {
+	listsMu.Lock()
+	defer listsMu.Unlock()
+	delete(lists, r)
+}
+
+func runnerLists() []*RunnerList {
+	listsMu.Lock()
+	defer listsMu.Unlock()
+	result := make([]*RunnerList, 0, len(lists))
+	for r := range lists {
+		result = append(result, r)
+	}
+	return result
+}
+
+// States returns the state of the configurations of the last reload.
+func (r *RunnerList) States() []RunnerState {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	states := make([]RunnerState, 0, len(r.configs))
+	for hash, c := range r.configs {
+		state := RunnerState{
+			ID:   formatID(hash),
+			Name: configName(c.Config),
+		}
+		if runner, ok := r.runners[hash]; ok {
+			state.Status = RunnerRunning
+			if reporter, ok := runner.(LastErrorReporter); ok {
+				if err := reporter.LastError(); err != nil {
+					state.LastError = err.Error()
+				}
+			}
+		} else if r.disabled[hash] {
+			state.Status = RunnerDisabled
+		} else {
+			state.Status = RunnerFailed
+			if err := r.failed[hash]; err != nil {
+				state.LastError = err.Error()
+			}
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// Enable starts the runner of a disabled configuration.
+func (r *RunnerList) Enable(hash uint64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c, ok := r.configs[hash]
+	if !ok {
+		return ErrRunnerNotFound
+	}
+	if !r.disabled[hash] {
+		return nil
+	}
+	delete(r.disabled, hash)
+
+	runner, err := createRunner(r.factory, r.pipeline, c)
+	if err != nil {
+		r.failed[hash] = err
+		return fmt.Errorf("error creating runner from config: %w", err)
+	}
+	delete(r.failed, hash)
+
+	r.logger.Infof("Starting enabled runner: %s", runner)
+	r.runners[hash] = runner
+	runner.Start()
+	moduleStarts.Add(1)
+	moduleRunning.Set(int64(len(r.runners)))
+	return nil
+}
+
+// Disable stops the runner of a configuration, and keeps it stopped on the
+// next reloads while the configuration doesn't change.
+func (r *RunnerList) Disable(hash uint64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.configs[hash]; !ok {
+		return ErrRunnerNotFound
+	}
+	r.disabled[hash] = true
+	delete(r.failed, hash)
+
+	if runner, ok := r.runners[hash]; ok {
+		r.logger.Infof("Stopping disabled runner: %s", runner)
+		delete(r.runners, hash)
+		runner.Stop()
+		moduleStops.Add(1)
+		moduleRunning.Set(int64(len(r.runners)))
+	}
+	return nil
+}
+
+func formatID(hash uint64) string {
+	return strconv.FormatUint(hash, 16)
+}
+
+// configName returns the first of the id, module or type settings found in
+// the configuration.
+func configName(c *config.C) string {
+	for _, field := range []string{"id", "module", "type"} {
+		if name, err := c.String(field, -1); err == nil && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// ReloadConfigs makes all the running reloaders scan their config files and
+// reload the changes immediately. It returns when all of them are done.
+func ReloadConfigs() error {
+	reloadersMu.Lock()
+	list := make([]*Reloader, 0, len(reloaders))
+	for rl := range reloaders {
+		list = append(list, rl)
+	}
+	reloadersMu.Unlock()
+
+	if len(list) == 0 {
+		return ErrNoReloader
+	}
+
+	var errs multierror.Errors
+	for _, rl := range list {
+		if err := rl.reloadNow(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.Err()
+}
+
+func registerReloader(rl *Reloader) {
+	reloadersMu.Lock()
+	defer reloadersMu.Unlock()
+	reloaders[rl] = struct{}{}
+}
+
+func unregisterReloader(rl *Reloader) {
+	reloadersMu.Lock()
+	defer reloadersMu.Unlock()
+	delete(reloaders, rl)
+}
+
+// reloadNow asks Run to reload the configuration files and waits for it.
+func (rl *Reloader) reloadNow() error {
+	result := make(chan error, 1)
+	select {
+	case rl.reloadC <- result:
+	case <-rl.done:
+		return errReloaderStopped
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-rl.done:
+		return errReloaderStopped
+	}
+}
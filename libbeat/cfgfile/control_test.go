@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/reload"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type errorRunner struct {
+	runner
+	err error
+}
+
+func (r *errorRunner) LastError() error {
+	return r.err
+}
+
+func TestRunnerListStates(t *testing.T) {
+	factory := &runnerFactory{
+		CreateRunner: func(_ beat.PipelineConnector, c *conf.C) (Runner, error) {
+			id, _ := c.Int("id", -1)
+			if id == 103 {
+				return &errorRunner{err: errors.New("connection refused")}, nil
+			}
+			return &runner{id: id}, nil
+		},
+	}
+	list := NewRunnerList("", factory, nil)
+	defer list.Stop()
+
+	err := list.Reload([]*reload.ConfigWithMeta{
+		createConfig(101),
+		createConfig(-102),
+		createConfig(103),
+	})
+	assert.Error(t, err)
+
+	states := statesByName(list.States())
+	require.Len(t, states, 3)
+	assert.Equal(t, RunnerRunning, states["101"].Status)
+	assert.Empty(t, states["101"].LastError)
+	assert.Equal(t, RunnerFailed, states["-102"].Status)
+	assert.Equal(t, "Invalid config", states["-102"].LastError)
+	assert.Equal(t, RunnerRunning, states["103"].Status)
+	assert.Equal(t, "connection refused", states["103"].LastError)
+}
+
+func TestRunnerListEnableDisable(t *testing.T) {
+	factory := &runnerFactory{}
+	list := NewRunnerList("", factory, nil)
+	defer list.Stop()
+
+	configs := []*reload.ConfigWithMeta{createConfig(111), createConfig(112)}
+	require.NoError(t, list.Reload(configs))
+	id := statesByName(list.States())["111"].ID
+
+	require.NoError(t, DisableRunner(id))
+	assert.True(t, factory.runners[0].(*runner).stopped)
+	assert.Len(t, list.copyRunnerList(), 1)
+	assert.Equal(t, RunnerDisabled, statesByName(list.States())["111"].Status)
+
+	// Disabled configurations are not started on reloads.
+	require.NoError(t, list.Reload(configs))
+	assert.Len(t, list.copyRunnerList(), 1)
+	assert.Len(t, factory.runners, 2)
+
+	require.NoError(t, EnableRunner(id))
+	assert.Len(t, list.copyRunnerList(), 2)
+	assert.Len(t, factory.runners, 3)
+	assert.True(t, factory.runners[2].(*runner).started)
+	assert.Equal(t, RunnerRunning, statesByName(list.States())["111"].Status)
+
+	// Enabling a running configuration does nothing.
+	require.NoError(t, EnableRunner(id))
+	assert.Len(t, factory.runners, 3)
+
+	// Disabled configurations are forgotten when they are removed.
+	require.NoError(t, DisableRunner(id))
+	require.NoError(t, list.Reload(configs[1:]))
+	require.NoError(t, list.Reload(configs))
+	assert.Len(t, list.copyRunnerList(), 2)
+
+	assert.ErrorIs(t, EnableRunner("1234"), ErrRunnerNotFound)
+	assert.ErrorIs(t, DisableRunner("invalid"), ErrRunnerNotFound)
+}
+
+func TestRunnerListStopUnregisters(t *testing.T) {
+	list := NewRunnerList("", &runnerFactory{}, nil)
+	require.NoError(t, list.Reload([]*reload.ConfigWithMeta{createConfig(121)}))
+	id := statesByName(list.States())["121"].ID
+	assert.Contains(t, RunnerStates(), RunnerState{ID: id, Name: "121", Status: RunnerRunning})
+
+	list.Stop()
+	assert.NotContains(t, RunnerStates(), RunnerState{ID: id, Name: "121", Status: RunnerRunning})
+	assert.ErrorIs(t, DisableRunner(id), ErrRunnerNotFound)
+}
+
+func TestReloadConfigs(t *testing.T) {
+	assert.ErrorIs(t, ReloadConfigs(), ErrNoReloader)
+
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "first.yml"), 131)
+
+	// Reload is disabled, files should only be loaded again on request.
+	reloader := NewReloader(nil, conf.MustNewConfigFrom(mapstr.M{
+		"path": filepath.Join(dir, "*.yml"),
+	}))
+	factory := &runnerFactory{}
+	go reloader.Run(factory)
+	defer reloader.Stop()
+
+	require.Eventually(t, func() bool {
+		return ReloadConfigs() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, factory.runners, 1)
+
+	writeConfigFile(t, filepath.Join(dir, "second.yml"), 132)
+	require.NoError(t, ReloadConfigs())
+	assert.Len(t, factory.runners, 2)
+}
+
+func writeConfigFile(t *testing.T, path string, id int) {
+	t.Helper()
+	content := []byte("- id: " + strconv.Itoa(id) + "\n")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+}
+
+func statesByName(states []RunnerState) map[string]RunnerState {
+	byName := make(map[string]RunnerState, len(states))
+	for _, state := range states {
+		byName[state.Name] = state
+	}
+	return byName
+}
@@ -39,16 +39,28 @@ type RunnerList struct {
 	factory  RunnerFactory
 	pipeline beat.PipelineConnector
 	logger   *logp.Logger
+
+	// configs contains the configurations of the last reload, the runners
+	// of the ones in disabled are not started, and failed contains the
+	// errors of the ones whose runner could not be created.
+	configs  map[uint64]*reload.ConfigWithMeta
+	disabled map[uint64]bool
+	failed   map[uint64]error
 }
 
 // NewRunnerList builds and returns a RunnerList
 func NewRunnerList(name string, factory RunnerFactory, pipeline beat.PipelineConnector) *RunnerList {
-	return &RunnerList{
+	r := &RunnerList{
 		runners:  map[uint64]Runner{},
 		factory:  factory,
 		pipeline: pipeline,
 		logger:   logp.NewLogger(name),
+		configs:  map[uint64]*reload.ConfigWithMeta{},
+		disabled: map[uint64]bool{},
+		failed:   map[uint64]error{},
 	}
+	registerList(r)
+	return r
 }
 
 // Reload the list of runners to match the given state
@@ -60,6 +72,7 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 
 	startList := map[uint64]*reload.ConfigWithMeta{}
 	stopList := r.copyRunnerList()
+	desired := map[uint64]*reload.ConfigWithMeta{}
 
 	r.logger.Debugf("Starting reload procedure, current runners: %d", len(stopList))
 
@@ -72,6 +85,11 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 			continue
 		}
 
+		desired[hash] = config
+		if r.disabled[hash] {
+			continue
+		}
+
 		if _, ok := r.runners[hash]; ok {
 			delete(stopList, hash)
 		} else {
@@ -98,10 +116,20 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 	// Wait for all runners to stop before starting new ones
 	wg.Wait()
 
+	// Forget the disabled and failed configurations that are gone
+	r.configs = desired
+	for hash := range r.disabled {
+		if _, ok := desired[hash]; !ok {
+			delete(r.disabled, hash)
+		}
+	}
+	r.failed = map[uint64]error{}
+
 	// Start new runners
 	for hash, config := range startList {
 		runner, err := createRunner(r.factory, r.pipeline, config)
 		if err != nil {
+			r.failed[hash] = err
 			if _, ok := err.(*common.ErrInputNotFinished); ok {
 				// error is related to state, we should not log at error level
 				r.logger.Debugf("Error creating runner from config: %s", err)
@@ -129,6 +157,8 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 
 // Stop all runners
 func (r *RunnerList) Stop() {
+	unregisterList(r)
+
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
@@ -101,6 +101,7 @@ type Reloader struct {
 	config   DynamicConfig
 	path     string
 	done     chan struct{}
+	reloadC  chan chan<- error
 	wg       sync.WaitGroup
 }
 
@@ -119,6 +120,7 @@ func NewReloader(pipeline beat.PipelineConnector, cfg *config.C) *Reloader {
 		config:   config,
 		path:     path,
 		done:     make(chan struct{}),
+		reloadC:  make(chan chan<- error),
 	}
 }
 
@@ -178,56 +180,71 @@ func (rl *Reloader) Run(runnerFactory RunnerFactory) {
 		rl.config.Reload.Period = 0
 	}
 
+	registerReloader(rl)
+	defer unregisterReloader(rl)
+
 	// If forceReload is set, the configuration should be reloaded
 	// even if there are no changes. It is set on the first iteration,
-	// and whenever an attempted reload fails. It is unset whenever
-	// a reload succeeds.
+	// whenever an attempted reload fails, and when a reload is requested.
+	// It is unset whenever a reload succeeds.
 	forceReload := true
 
+	// Path loading is enabled but not reloading. Loads files only once and
+	// then only when a reload is requested.
+	scanned := false
+
 	for {
+		var tick <-chan time.Time
+		if rl.config.Reload.Enabled || !scanned {
+			tick = time.After(rl.config.Reload.Period)
+		}
+
+		var reloaded chan<- error
 		select {
 		case <-rl.done:
 			logp.Info("Dynamic config reloader stopped")
 			return
 
-		case <-time.After(rl.config.Reload.Period):
-			debugf("Scan for new config files")
-			configScans.Add(1)
-
-			files, updated, err := gw.Scan()
-			if err != nil {
-				// In most cases of error, updated == false, so will continue
-				// to next iteration below
-				logp.Err("Error fetching new config files: %v", err)
-			}
-
-			// if there are no changes, skip this reload unless forceReload is set.
-			if !updated && !forceReload {
-				continue
-			}
-			configReloads.Add(1)
-
-			// Load all config objects
-			configs, _ := rl.loadConfigs(files)
-
-			debugf("Number of module configs found: %v", len(configs))
-
-			err = list.Reload(configs)
-			// Force reload on the next iteration if and only if this one failed.
-			// (Any errors are already logged by list.Reload, so we don't need to
-			// propagate the details further.)
-			forceReload = err != nil
+		case reloaded = <-rl.reloadC:
+			forceReload = true
+
+		case <-tick:
+		}
+
+		debugf("Scan for new config files")
+		configScans.Add(1)
+
+		files, updated, err := gw.Scan()
+		if err != nil {
+			// In most cases of error, updated == false, so will continue
+			// to next iteration below
+			logp.Err("Error fetching new config files: %v", err)
+		}
+
+		// if there are no changes, skip this reload unless forceReload is set.
+		if !updated && !forceReload {
+			continue
+		}
+		configReloads.Add(1)
+
+		// Load all config objects
+		configs, _ := rl.loadConfigs(files)
+
+		debugf("Number of module configs found: %v", len(configs))
+
+		err = list.Reload(configs)
+		// Force reload on the next iteration if and only if this one failed.
+		// (Any errors are already logged by list.Reload, so we don't need to
+		// propagate the details further.)
+		forceReload = err != nil
+		if reloaded != nil {
+			reloaded <- err
 		}
 
-		// Path loading is enabled but not reloading. Loads files only once and then stops.
-		if !rl.config.Reload.Enabled {
+		if !rl.config.Reload.Enabled && !scanned {
 			logp.Info("Loading of config files completed.")
-			select {
-			case <-rl.done:
-				logp.Info("Dynamic config reloader stopped")
-				return
-			}
 		}
+		scanned = true
 	}
 }
 
@@ -40,6 +40,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/elastic/beats/v7/libbeat/api"
+	"github.com/elastic/beats/v7/libbeat/api/control"
 	"github.com/elastic/beats/v7/libbeat/asset"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
@@ -63,6 +64,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/pprof"
 	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
+	"github.com/elastic/beats/v7/libbeat/secrets"
 	"github.com/elastic/beats/v7/libbeat/version"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/file"
@@ -112,6 +114,7 @@ type beatConfig struct {
 	// beat internal components configurations
 	HTTP            *config.C              `config:"http"`
 	HTTPPprof       *pprof.Config          `config:"http.pprof"`
+	HTTPControl     *control.Config        `config:"http.control"`
 	BufferConfig    *config.C              `config:"http.buffer"`
 	Path            paths.Path             `config:"path"`
 	Logging         *config.C              `config:"logging"`
@@ -426,6 +429,10 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 				return fmt.Errorf("failed to attach http handlers for pprof: %w", err)
 			}
 		}
+		diagnostics := control.Diagnostics{Info: b.Info, Config: b.RawConfig, Logging: b.Config.Logging}
+		if err := control.HttpAttach(b.Config.HTTPControl, b.API, diagnostics); err != nil {
+			return fmt.Errorf("failed to attach http handlers for the control API: %w", err)
+		}
 	}
 
 	// Do not load seccomp for osquerybeat, it was disabled before V2 in the configuration file
@@ -1166,7 +1173,11 @@ func obfuscateConfigOpts() []ucfg.Option {
 func LoadKeystore(cfg *config.C, name string) (keystore.Keystore, error) {
 	keystoreCfg, _ := cfg.Child("keystore", -1)
 	defaultPathConfig := paths.Resolve(paths.Data, fmt.Sprintf("%s.keystore", name))
-	return keystore.Factory(keystoreCfg, defaultPathConfig, common.IsStrictPerms())
+	store, err := keystore.Factory(keystoreCfg, defaultPathConfig, common.IsStrictPerms())
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewKeystore(keystoreCfg, store)
 }
 
 func InitKibanaConfig(beatConfig beatConfig) *config.C {
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package k8swatcher
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
+)
+
+type clientKey struct {
+	kubeConfig string
+	options    kubernetes.KubeClientOptions
+}
+
+var clients = struct {
+	sync.Mutex
+	clients map[clientKey]k8sclient.Interface
+}{
+	clients: map[clientKey]k8sclient.Interface{},
+}
+
+// GetKubernetesClient is a replacement of kubernetes.GetKubernetesClient
+// that returns the same client for the same configuration, so that the
+// watchers created with it can share their informers. The requests sent by
+// the client are reported in the metrics.
+func GetKubernetesClient(kubeConfig string, options kubernetes.KubeClientOptions) (k8sclient.Interface, error) {
+	if kubeConfig == "" {
+		kubeConfig = kubernetes.GetKubeConfigEnvironmentVariable()
+	}
+	key := clientKey{kubeConfig: kubeConfig, options: options}
+
+	clients.Lock()
+	defer clients.Unlock()
+
+	if client, found := clients.clients[key]; found {
+		return client, nil
+	}
+
+	cfg, err := kubernetes.BuildConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build kube config due to error: %w", err)
+	}
+	cfg.QPS = options.QPS
+	cfg.Burst = options.Burst
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &countingRoundTripper{next: rt}
+	})
+
+	client, err := k8sclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build kubernetes clientset: %w", err)
+	}
+	clients.clients[key] = client
+	return client, nil
+}
+
+// countingRoundTripper counts the requests sent to the API server.
+type countingRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case req.Method == http.MethodGet && req.URL.Query().Get("watch") == "true":
+		requestMetrics.watch.Inc()
+	case req.Method == http.MethodGet:
+		requestMetrics.get.Inc()
+	default:
+		requestMetrics.other.Inc()
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		requestMetrics.errors.Inc()
+	}
+	return resp, err
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package k8swatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
+)
+
+func TestGetKubernetesClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"PodList","apiVersion":"v1","items":[]}`)
+	}))
+	defer server.Close()
+
+	kubeConfig := filepath.Join(t.TempDir(), "kubeconfig")
+	err := os.WriteFile(kubeConfig, []byte(fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`, server.URL)), 0o600)
+	require.NoError(t, err)
+
+	client, err := GetKubernetesClient(kubeConfig, kubernetes.KubeClientOptions{QPS: 5, Burst: 10})
+	require.NoError(t, err)
+
+	same, err := GetKubernetesClient(kubeConfig, kubernetes.KubeClientOptions{QPS: 5, Burst: 10})
+	require.NoError(t, err)
+	assert.Same(t, client, same, "the client must be shared for the same configuration")
+
+	other, err := GetKubernetesClient(kubeConfig, kubernetes.KubeClientOptions{QPS: 10, Burst: 20})
+	require.NoError(t, err)
+	assert.NotSame(t, client, other)
+
+	before := requestMetrics.get.Get()
+	_, err = client.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, before+1, requestMetrics.get.Get())
+}
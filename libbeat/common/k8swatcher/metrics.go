@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package k8swatcher
+
+import (
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// Metrics of the shared informers and of the requests sent to the API
+// server, reported under kubernetes_metadata.
+var (
+	metricsRegistry = monitoring.Default.NewRegistry("kubernetes_metadata")
+
+	requestMetrics = struct {
+		get, watch, other, errors *monitoring.Int
+	}{
+		get:    monitoring.NewInt(metricsRegistry, "requests.get"),
+		watch:  monitoring.NewInt(metricsRegistry, "requests.watch"),
+		other:  monitoring.NewInt(metricsRegistry, "requests.other"),
+		errors: monitoring.NewInt(metricsRegistry, "requests.errors"),
+	}
+)
+
+func init() {
+	monitoring.NewFunc(metricsRegistry, "informers", reportInformers, monitoring.Report)
+}
+
+// informerStats are the statistics of the informers of a resource type.
+type informerStats struct {
+	informers int
+	watchers  int
+	objects   int
+}
+
+// collectInformerStats returns the statistics of the informers in use by
+// resource type.
+func collectInformerStats() map[string]informerStats {
+	registry.Lock()
+	defer registry.Unlock()
+
+	stats := map[string]informerStats{}
+	for key, s := range registry.informers {
+		resource := strings.ToLower(key.resource[strings.LastIndex(key.resource, ".")+1:])
+		st := stats[resource]
+		st.informers++
+		st.watchers += s.refs
+		st.objects += len(s.informer.GetStore().ListKeys())
+		stats[resource] = st
+	}
+	return stats
+}
+
+func reportInformers(_ monitoring.Mode, V monitoring.Visitor) {
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	for resource, st := range collectInformerStats() {
+		monitoring.ReportNamespace(V, resource, func() {
+			monitoring.ReportInt(V, "informers", int64(st.informers))
+			monitoring.ReportInt(V, "watchers", int64(st.watchers))
+			monitoring.ReportInt(V, "objects", int64(st.objects))
+		})
+	}
+}
@@ -0,0 +1,362 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package k8swatcher shares Kubernetes informers between all the watchers of
+// a beat. Watchers created with the same client and watch options use a
+// single informer, so the resources are listed and watched only once from
+// the API server, no matter how many processors and autodiscover providers
+// are configured. Each watcher keeps its own work queue and event handler,
+// the informer has a single handler that dispatches its events to the
+// watchers in use.
+//
+// Informers are reference counted, an informer is stopped when all its
+// watchers have been stopped.
+package k8swatcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	stateAdd    = "add"
+	stateUpdate = "update"
+	stateDelete = "delete"
+)
+
+var accessor = meta.NewAccessor()
+
+// informerKey identifies the informers that can be shared.
+type informerKey struct {
+	client      k8sclient.Interface
+	resource    string
+	node        string
+	namespace   string
+	syncTimeout time.Duration
+}
+
+// sharedInformer is an informer shared by several watchers.
+type sharedInformer struct {
+	key      informerKey
+	informer cache.SharedInformer
+	refs     int  // Number of watchers using the informer.
+	running  bool // The informer has been started.
+	done     chan struct{}
+
+	// Watchers receiving the events of the informer. Handlers can't be
+	// removed from informers, so a single handler is added that dispatches
+	// the events to the watchers in this set.
+	mu       sync.RWMutex
+	watchers map[*watcher]struct{}
+}
+
+// registry holds the informers in use.
+var registry = struct {
+	sync.Mutex
+	informers map[informerKey]*sharedInformer
+}{
+	informers: map[informerKey]*sharedInformer{},
+}
+
+// acquire returns the informer for the given resource and options, creating
+// it if it does not exist. The reference count of the informer is increased.
+func acquire(client k8sclient.Interface, resource kubernetes.Resource, opts kubernetes.WatchOptions) (*sharedInformer, error) {
+	key := informerKey{
+		client:      client,
+		resource:    fmt.Sprintf("%T", resource),
+		node:        opts.Node,
+		namespace:   opts.Namespace,
+		syncTimeout: opts.SyncTimeout,
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	s, found := registry.informers[key]
+	if !found {
+		informer, _, err := kubernetes.NewInformer(client, resource, opts, nil)
+		if err != nil {
+			return nil, err
+		}
+		s = &sharedInformer{
+			key:      key,
+			informer: informer,
+			done:     make(chan struct{}),
+			watchers: map[*watcher]struct{}{},
+		}
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(o interface{}) {
+				s.dispatch(func(w *watcher) { w.onAdd(o) })
+			},
+			DeleteFunc: func(o interface{}) {
+				s.dispatch(func(w *watcher) { w.onDelete(o) })
+			},
+			UpdateFunc: func(o, n interface{}) {
+				s.dispatch(func(w *watcher) { w.onUpdate(o, n) })
+			},
+		})
+		registry.informers[key] = s
+	}
+	s.refs++
+	return s, nil
+}
+
+// dispatch calls f for every watcher of the informer.
+func (s *sharedInformer) dispatch(f func(w *watcher)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for w := range s.watchers {
+		f(w)
+	}
+}
+
+// addWatcher adds a watcher to the ones receiving the events of the
+// informer. The objects already in the store are enqueued as added, as the
+// informer only notifies them to the handlers added before it was started.
+func (s *sharedInformer) addWatcher(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchers[w] = struct{}{}
+	for _, o := range s.informer.GetStore().List() {
+		w.enqueue(o, stateAdd)
+	}
+}
+
+// removeWatcher stops dispatching the events of the informer to a watcher.
+func (s *sharedInformer) removeWatcher(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers, w)
+}
+
+// run starts the informer if it is not running yet.
+func (s *sharedInformer) run() {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if !s.running {
+		s.running = true
+		go s.informer.Run(s.done)
+	}
+}
+
+// release decreases the reference count of the informer, and stops it when
+// it is not used anymore.
+func (s *sharedInformer) release() {
+	registry.Lock()
+	defer registry.Unlock()
+
+	s.refs--
+	if s.refs > 0 {
+		return
+	}
+	close(s.done)
+	delete(registry.informers, s.key)
+}
+
+type item struct {
+	object    interface{}
+	objectRaw interface{}
+	state     string
+}
+
+// watcher implements kubernetes.Watcher on top of a shared informer.
+type watcher struct {
+	client   k8sclient.Interface
+	shared   *sharedInformer
+	opts     kubernetes.WatchOptions
+	queue    workqueue.Interface
+	ctx      context.Context
+	stop     context.CancelFunc
+	stopOnce sync.Once
+	handler  kubernetes.ResourceEventHandler
+	logger   *logp.Logger
+}
+
+// NewNamedWatcher is a replacement of kubernetes.NewNamedWatcher that shares
+// the informer with the other watchers of the same client, resource and
+// watch options. Watchers with custom indexers don't share their informer.
+func NewNamedWatcher(name string, client k8sclient.Interface, resource kubernetes.Resource, opts kubernetes.WatchOptions, indexers cache.Indexers) (kubernetes.Watcher, error) {
+	if indexers != nil {
+		return kubernetes.NewNamedWatcher(name, client, resource, opts, indexers)
+	}
+
+	shared, err := acquire(client, resource, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IsUpdated == nil {
+		opts.IsUpdated = func(o, n interface{}) bool {
+			old, _ := accessor.ResourceVersion(o.(runtime.Object))
+			new, _ := accessor.ResourceVersion(n.(runtime.Object))
+
+			// Only enqueue changes that have a different resource versions to avoid processing resyncs.
+			return old != new
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	w := &watcher{
+		client:  client,
+		shared:  shared,
+		opts:    opts,
+		queue:   workqueue.NewNamed(name),
+		ctx:     ctx,
+		stop:    cancel,
+		handler: kubernetes.NoOpEventHandlerFuncs{},
+		logger:  logp.NewLogger("kubernetes"),
+	}
+	shared.addWatcher(w)
+
+	return w, nil
+}
+
+func (w *watcher) onAdd(o interface{}) {
+	w.enqueue(o, stateAdd)
+}
+
+func (w *watcher) onDelete(o interface{}) {
+	w.enqueue(o, stateDelete)
+}
+
+func (w *watcher) onUpdate(o, n interface{}) {
+	if w.opts.IsUpdated(o, n) {
+		w.enqueue(n, stateUpdate)
+	} else if w.opts.HonorReSyncs {
+		// Resyncs are enqueued as add events, see kubernetes.NewNamedWatcher.
+		w.enqueue(n, stateAdd)
+	}
+}
+
+// AddEventHandler sets the handler that processes the events of the watcher.
+func (w *watcher) AddEventHandler(h kubernetes.ResourceEventHandler) {
+	w.handler = h
+}
+
+// Store returns the store of the shared informer.
+func (w *watcher) Store() cache.Store {
+	return w.shared.informer.GetStore()
+}
+
+// Client returns the kubernetes client object used by the watcher.
+func (w *watcher) Client() k8sclient.Interface {
+	return w.client
+}
+
+// Start starts the shared informer if needed, and waits for its cache to be
+// synced before processing events.
+func (w *watcher) Start() error {
+	w.shared.run()
+
+	if !cache.WaitForCacheSync(w.ctx.Done(), w.shared.informer.HasSynced) {
+		return fmt.Errorf("kubernetes informer unable to sync cache")
+	}
+
+	w.logger.Debugf("cache sync done")
+
+	// Wrap the process function with wait.Until so that if the controller crashes, it starts up again after a second.
+	go wait.Until(func() {
+		for w.process() {
+		}
+	}, time.Second*1, w.ctx.Done())
+
+	return nil
+}
+
+// Stop stops processing events, and releases the shared informer.
+func (w *watcher) Stop() {
+	w.stopOnce.Do(func() {
+		w.shared.removeWatcher(w)
+		w.queue.ShutDown()
+		w.stop()
+		w.shared.release()
+	})
+}
+
+// enqueue takes the most recent object that was received, figures out the namespace/name of the object
+// and adds it to the work queue for processing.
+func (w *watcher) enqueue(obj interface{}, state string) {
+	// DeletionHandlingMetaNamespaceKeyFunc that we get a key only if the resource's state is not Unknown.
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		w.logger.Debugf("Enqueued DeletedFinalStateUnknown contained object: %+v", deleted.Obj)
+		obj = deleted.Obj
+	}
+	w.queue.Add(&item{key, obj, state})
+}
+
+// process gets the top of the work queue and processes the object that is received.
+func (w *watcher) process() bool {
+	obj, quit := w.queue.Get()
+	if quit {
+		return false
+	}
+	defer w.queue.Done(obj)
+
+	entry, ok := obj.(*item)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("expected *item in workqueue but got %#v", obj))
+		return true
+	}
+
+	key, ok := entry.object.(string)
+	if !ok {
+		return false
+	}
+
+	o, exists, err := w.Store().GetByKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("getting object %#v from cache: %w", obj, err))
+		return true
+	}
+	if !exists {
+		if entry.state == stateDelete {
+			w.logger.Debugf("Object %+v was not found in the store, deleting anyway!", key)
+			// delete anyway in order to clean states
+			w.handler.OnDelete(entry.objectRaw)
+		}
+		return true
+	}
+
+	switch entry.state {
+	case stateAdd:
+		w.handler.OnAdd(o)
+	case stateUpdate:
+		w.handler.OnUpdate(o)
+	case stateDelete:
+		w.handler.OnDelete(o)
+	}
+
+	return true
+}
@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package k8swatcher
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// podNames collects the names of the pods added to a watcher.
+type podNames struct {
+	sync.Mutex
+	names []string
+}
+
+func (p *podNames) handler() kubernetes.ResourceEventHandler {
+	return kubernetes.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			p.Lock()
+			defer p.Unlock()
+			p.names = append(p.names, obj.(*kubernetes.Pod).Name)
+		},
+	}
+}
+
+// get returns the sorted names of the pods.
+func (p *podNames) get() []string {
+	p.Lock()
+	defer p.Unlock()
+	names := append([]string(nil), p.names...)
+	sort.Strings(names)
+	return names
+}
+
+func newPod(name string) *kubernetes.Pod {
+	return &kubernetes.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "1"},
+	}
+}
+
+func TestSharedWatcher(t *testing.T) {
+	client := k8sfake.NewSimpleClientset(newPod("existing"))
+	opts := kubernetes.WatchOptions{SyncTimeout: time.Minute}
+
+	w1, err := NewNamedWatcher("test1", client, &kubernetes.Pod{}, opts, nil)
+	require.NoError(t, err)
+	w2, err := NewNamedWatcher("test2", client, &kubernetes.Pod{}, opts, nil)
+	require.NoError(t, err)
+	w3, err := NewNamedWatcher("test3", client, &kubernetes.Pod{}, kubernetes.WatchOptions{Namespace: "other"}, nil)
+	require.NoError(t, err)
+	defer w3.Stop()
+
+	assert.Same(t, w1.(*watcher).shared, w2.(*watcher).shared, "watchers with the same options must share the informer")
+	assert.NotSame(t, w1.(*watcher).shared, w3.(*watcher).shared, "watchers with different options must not share the informer")
+
+	var pods1, pods2 podNames
+	w1.AddEventHandler(pods1.handler())
+	w2.AddEventHandler(pods2.handler())
+	require.NoError(t, w1.Start())
+	require.NoError(t, w2.Start())
+
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), newPod("new"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	expected := []string{"existing", "new"}
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(expected, pods1.get()) && assert.ObjectsAreEqual(expected, pods2.get())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stats := collectInformerStats()["pod"]
+	assert.Equal(t, 2, stats.informers)
+	assert.Equal(t, 3, stats.watchers)
+	assert.Equal(t, 2, stats.objects)
+
+	// A watcher created once the informer is running gets the objects
+	// already in the store.
+	w5, err := NewNamedWatcher("test5", client, &kubernetes.Pod{}, opts, nil)
+	require.NoError(t, err)
+	var pods5 podNames
+	w5.AddEventHandler(pods5.handler())
+	require.NoError(t, w5.Start())
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(expected, pods5.get())
+	}, 5*time.Second, 10*time.Millisecond)
+	w5.Stop()
+
+	shared := w1.(*watcher).shared
+	w1.Stop()
+	w1.Stop()
+	assert.Equal(t, 1, shared.refs)
+	shared.mu.RLock()
+	assert.Len(t, shared.watchers, 1, "stopped watchers must not receive events")
+	shared.mu.RUnlock()
+	select {
+	case <-shared.done:
+		t.Fatal("informer must keep running while it is in use")
+	default:
+	}
+
+	w2.Stop()
+	<-shared.done
+	registry.Lock()
+	_, found := registry.informers[shared.key]
+	registry.Unlock()
+	assert.False(t, found, "unused informer must be removed")
+
+	// A new watcher gets a new informer once the previous one is stopped.
+	w4, err := NewNamedWatcher("test4", client, &kubernetes.Pod{}, opts, nil)
+	require.NoError(t, err)
+	defer w4.Stop()
+	assert.NotSame(t, shared, w4.(*watcher).shared)
+}
+
+func TestInformerMetrics(t *testing.T) {
+	client := k8sfake.NewSimpleClientset(newPod("a"), newPod("b"))
+
+	w, err := NewNamedWatcher("test", client, &kubernetes.Pod{}, kubernetes.WatchOptions{Node: "node1"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Start())
+	defer w.Stop()
+
+	snapshot := monitoring.CollectStructSnapshot(metricsRegistry, monitoring.Full, false)
+	informers, ok := snapshot["informers"].(map[string]interface{})
+	require.True(t, ok, "informers metrics not found in %v", snapshot)
+	pod, ok := informers["pod"].(map[string]interface{})
+	require.True(t, ok, "pod informer metrics not found in %v", informers)
+	assert.Equal(t, int64(1), pod["informers"])
+	assert.Equal(t, int64(1), pod["watchers"])
+	assert.Equal(t, int64(2), pod["objects"])
+}
@@ -0,0 +1,217 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// TokenProviderFactory creates a sarama.AccessTokenProvider from the
+// `sasl.oauthbearer` settings.
+type TokenProviderFactory func(cfg *config.C) (sarama.AccessTokenProvider, error)
+
+var (
+	tokenProvidersMu sync.RWMutex
+	tokenProviders   = map[string]TokenProviderFactory{}
+)
+
+// RegisterTokenProvider registers a named OAUTHBEARER token provider. It
+// panics if a provider with the same name has already been registered.
+func RegisterTokenProvider(name string, factory TokenProviderFactory) {
+	tokenProvidersMu.Lock()
+	defer tokenProvidersMu.Unlock()
+
+	if _, exists := tokenProviders[name]; exists {
+		panic(fmt.Sprintf("kafka OAUTHBEARER token provider '%s' is already registered", name))
+	}
+	tokenProviders[name] = factory
+}
+
+func lookupTokenProvider(name string) (TokenProviderFactory, bool) {
+	tokenProvidersMu.RLock()
+	defer tokenProvidersMu.RUnlock()
+	factory, ok := tokenProviders[name]
+	return factory, ok
+}
+
+func init() {
+	RegisterTokenProvider("static", newStaticTokenProvider)
+	RegisterTokenProvider("file", newFileTokenProvider)
+	RegisterTokenProvider("client_credentials", newClientCredentialsTokenProvider)
+}
+
+type oauthBearerConfig struct {
+	Provider     string            `config:"provider"`
+	Token        string            `config:"token"`
+	TokenFile    string            `config:"token_file"`
+	TokenURL     string            `config:"token_url"`
+	ClientID     string            `config:"client_id"`
+	ClientSecret string            `config:"client_secret"`
+	Scopes       []string          `config:"scopes"`
+	Extensions   map[string]string `config:"extensions"`
+}
+
+// providerName returns the configured provider, inferring it from the
+// provider specific options when it is not explicitly set.
+func (c *oauthBearerConfig) providerName() string {
+	switch {
+	case c.Provider != "":
+		return c.Provider
+	case c.Token != "":
+		return "static"
+	case c.TokenFile != "":
+		return "file"
+	case c.TokenURL != "":
+		return "client_credentials"
+	}
+	return ""
+}
+
+func newTokenProvider(cfg *config.C) (sarama.AccessTokenProvider, error) {
+	if cfg == nil {
+		return nil, errors.New("sasl.oauthbearer settings are required when using the OAUTHBEARER mechanism")
+	}
+
+	var c oauthBearerConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+
+	name := c.providerName()
+	if name == "" {
+		return nil, errors.New("no OAUTHBEARER token provider configured, set one of sasl.oauthbearer.provider, token, token_file or token_url")
+	}
+	factory, ok := lookupTokenProvider(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown OAUTHBEARER token provider '%s'", name)
+	}
+	return factory(cfg)
+}
+
+type staticTokenProvider struct {
+	token *sarama.AccessToken
+}
+
+func newStaticTokenProvider(cfg *config.C) (sarama.AccessTokenProvider, error) {
+	var c oauthBearerConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	if c.Token == "" {
+		return nil, errors.New("sasl.oauthbearer.token is required by the static token provider")
+	}
+	return &staticTokenProvider{
+		token: &sarama.AccessToken{Token: c.Token, Extensions: c.Extensions},
+	}, nil
+}
+
+func (p *staticTokenProvider) Token() (*sarama.AccessToken, error) {
+	return p.token, nil
+}
+
+// fileTokenProvider reads the token from a file, reloading it whenever the
+// file is modified so externally refreshed tokens are picked up.
+type fileTokenProvider struct {
+	path       string
+	extensions map[string]string
+
+	mu      sync.Mutex
+	modTime time.Time
+	token   string
+}
+
+func newFileTokenProvider(cfg *config.C) (sarama.AccessTokenProvider, error) {
+	var c oauthBearerConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	if c.TokenFile == "" {
+		return nil, errors.New("sasl.oauthbearer.token_file is required by the file token provider")
+	}
+	return &fileTokenProvider{path: c.TokenFile, extensions: c.Extensions}, nil
+}
+
+func (p *fileTokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat OAUTHBEARER token file: %w", err)
+	}
+	if p.token == "" || !info.ModTime().Equal(p.modTime) {
+		content, err := os.ReadFile(p.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OAUTHBEARER token file: %w", err)
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return nil, fmt.Errorf("OAUTHBEARER token file %s is empty", p.path)
+		}
+		p.token = token
+		p.modTime = info.ModTime()
+	}
+	return &sarama.AccessToken{Token: p.token, Extensions: p.extensions}, nil
+}
+
+// clientCredentialsTokenProvider fetches tokens using the OAuth2 client
+// credentials flow. Tokens are cached until they expire.
+type clientCredentialsTokenProvider struct {
+	source     oauth2.TokenSource
+	extensions map[string]string
+}
+
+func newClientCredentialsTokenProvider(cfg *config.C) (sarama.AccessTokenProvider, error) {
+	var c oauthBearerConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	if c.TokenURL == "" || c.ClientID == "" || c.ClientSecret == "" {
+		return nil, errors.New("sasl.oauthbearer.token_url, client_id and client_secret are required by the client_credentials token provider")
+	}
+	creds := clientcredentials.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     c.TokenURL,
+		Scopes:       c.Scopes,
+	}
+	return &clientCredentialsTokenProvider{
+		source:     creds.TokenSource(context.Background()),
+		extensions: c.Extensions,
+	}, nil
+}
+
+func (p *clientCredentialsTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OAUTHBEARER token: %w", err)
+	}
+	return &sarama.AccessToken{Token: token.AccessToken, Extensions: p.extensions}, nil
+}
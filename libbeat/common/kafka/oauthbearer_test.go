@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestOAuthBearerStaticToken(t *testing.T) {
+	sasl := SaslConfig{
+		SaslMechanism: "oauthbearer",
+		OAuthBearer: config.MustNewConfigFrom(map[string]interface{}{
+			"token":      "secret",
+			"extensions": map[string]interface{}{"logicalCluster": "lkc-1"},
+		}),
+	}
+	require.NoError(t, sasl.Validate())
+	assert.True(t, sasl.IsOAuthBearer())
+
+	cfg := sarama.NewConfig()
+	require.NoError(t, sasl.ConfigureSarama(cfg))
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), cfg.Net.SASL.Mechanism)
+	assert.True(t, cfg.Net.SASL.Handshake)
+
+	token, err := cfg.Net.SASL.TokenProvider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "secret", token.Token)
+	assert.Equal(t, map[string]string{"logicalCluster": "lkc-1"}, token.Extensions)
+}
+
+func TestOAuthBearerFileToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	provider, err := newTokenProvider(config.MustNewConfigFrom(map[string]interface{}{
+		"token_file": path,
+	}))
+	require.NoError(t, err)
+
+	token, err := provider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "first", token.Token)
+
+	// Rotated tokens are picked up once the file is modified.
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	token, err = provider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "second", token.Token)
+}
+
+func TestOAuthBearerClientCredentials(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "kafka", r.Form.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"fetched","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	provider, err := newTokenProvider(config.MustNewConfigFrom(map[string]interface{}{
+		"token_url":     server.URL,
+		"client_id":     "beats",
+		"client_secret": "changeme",
+		"scopes":        []string{"kafka"},
+	}))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		token, err := provider.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "fetched", token.Token)
+	}
+	assert.Equal(t, 1, requests, "token should be cached until it expires")
+}
+
+func TestOAuthBearerInvalidConfig(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"no settings":        nil,
+		"no provider":        {},
+		"unknown provider":   {"provider": "unknown"},
+		"static no token":    {"provider": "static"},
+		"file no token_file": {"provider": "file"},
+		"client_credentials no secret": {
+			"token_url": "http://localhost",
+			"client_id": "beats",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sasl := SaslConfig{SaslMechanism: "OAUTHBEARER"}
+			if test != nil {
+				sasl.OAuthBearer = config.MustNewConfigFrom(test)
+			}
+			assert.Error(t, sasl.Validate())
+		})
+	}
+}
@@ -22,23 +22,33 @@ import (
 	"strings"
 
 	"github.com/Shopify/sarama"
+
+	"github.com/elastic/elastic-agent-libs/config"
 )
 
 type SaslConfig struct {
-	SaslMechanism string `config:"mechanism"`
+	SaslMechanism string    `config:"mechanism"`
+	OAuthBearer   *config.C `config:"oauthbearer"`
 }
 
 const (
 	saslTypePlaintext   = sarama.SASLTypePlaintext
 	saslTypeSCRAMSHA256 = sarama.SASLTypeSCRAMSHA256
 	saslTypeSCRAMSHA512 = sarama.SASLTypeSCRAMSHA512
+	saslTypeOAuthBearer = sarama.SASLTypeOAuth
 )
 
-func (c *SaslConfig) ConfigureSarama(config *sarama.Config) {
+// IsOAuthBearer returns true if the OAUTHBEARER mechanism is configured. This
+// mechanism doesn't use a username and password.
+func (c *SaslConfig) IsOAuthBearer() bool {
+	return strings.ToUpper(c.SaslMechanism) == saslTypeOAuthBearer
+}
+
+func (c *SaslConfig) ConfigureSarama(config *sarama.Config) error {
 	switch strings.ToUpper(c.SaslMechanism) { // try not to force users to use all upper case
 	case "":
 		// SASL is not enabled
-		return
+		return nil
 	case saslTypePlaintext:
 		config.Net.SASL.Mechanism = sarama.SASLMechanism(sarama.SASLTypePlaintext)
 	case saslTypeSCRAMSHA256:
@@ -53,17 +63,30 @@ func (c *SaslConfig) ConfigureSarama(config *sarama.Config) {
 		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
 			return &XDGSCRAMClient{HashGeneratorFcn: SHA512}
 		}
+	case saslTypeOAuthBearer:
+		provider, err := newTokenProvider(c.OAuthBearer)
+		if err != nil {
+			return err
+		}
+		config.Net.SASL.Handshake = true
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(sarama.SASLTypeOAuth)
+		config.Net.SASL.TokenProvider = provider
 	default:
 		// This should never happen because `SaslMechanism` is checked on `Validate()`, keeping a panic to detect it earlier if it happens.
-		panic(fmt.Sprintf("not valid SASL mechanism '%v', only supported with PLAIN|SCRAM-SHA-512|SCRAM-SHA-256", c.SaslMechanism))
+		panic(fmt.Sprintf("not valid SASL mechanism '%v', only supported with PLAIN|SCRAM-SHA-512|SCRAM-SHA-256|OAUTHBEARER", c.SaslMechanism))
 	}
+	return nil
 }
 
 func (c *SaslConfig) Validate() error {
 	switch strings.ToUpper(c.SaslMechanism) { // try not to force users to use all upper case
 	case "", saslTypePlaintext, saslTypeSCRAMSHA256, saslTypeSCRAMSHA512:
+	case saslTypeOAuthBearer:
+		if _, err := newTokenProvider(c.OAuthBearer); err != nil {
+			return err
+		}
 	default:
-		return fmt.Errorf("not valid SASL mechanism '%v', only supported with PLAIN|SCRAM-SHA-512|SCRAM-SHA-256", c.SaslMechanism)
+		return fmt.Errorf("not valid SASL mechanism '%v', only supported with PLAIN|SCRAM-SHA-512|SCRAM-SHA-256|OAUTHBEARER", c.SaslMechanism)
 	}
 	return nil
 }
@@ -56,8 +56,16 @@ var (
 		"2.3": sarama.V2_3_0_0,
 		"2.4": sarama.V2_4_0_0,
 		"2.5": sarama.V2_5_0_0,
-		"2.6": sarama.V2_6_0_0,
-		"2":   sarama.V2_6_0_0,
+		"2.6": sarama.V2_6_2_0,
+		"2.7": sarama.V2_7_1_0,
+		"2.8": sarama.V2_8_2_0,
+		"2":   sarama.V2_8_2_0,
+
+		"3.0": sarama.V3_0_2_0,
+		"3.1": sarama.V3_1_2_0,
+		"3.2": sarama.V3_2_3_0,
+		"3.3": sarama.V3_3_1_0,
+		"3":   sarama.V3_3_1_0,
 	}
 )
 
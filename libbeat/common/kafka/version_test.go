@@ -31,6 +31,8 @@ func TestVersionGet(t *testing.T) {
 		"2.0.1": sarama.V2_0_1_0,
 		"2.0":   sarama.V2_0_1_0,
 		"2.5":   sarama.V2_5_0_0,
+		"2.8":   sarama.V2_8_2_0,
+		"3":     sarama.V3_3_1_0,
 	}
 	invalid := []Version{
 		"1.1.2",
@@ -62,7 +64,7 @@ func TestSaramaUpdate(t *testing.T) {
 	// If any of these versions are considered valid by our parsing code,
 	// it means someone updated sarama without updating the parsing code
 	// for the new version. Gently remind them.
-	flagVersions := []Version{"2.8.1", "2.9.0"}
+	flagVersions := []Version{"3.3.2", "3.4.0"}
 	for _, v := range flagVersions {
 		if _, ok := v.Get(); ok {
 			t.Fatalf(
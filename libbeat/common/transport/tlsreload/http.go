@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsreload
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// RoundTripper creates an HTTP round tripper like settings.RoundTripper,
+// that is created again with the same options when the TLS configuration
+// is reloaded. Requests in flight are completed with the previous one.
+func RoundTripper(settings httpcommon.HTTPTransportSettings, opts ...httpcommon.TransportOption) (http.RoundTripper, error) {
+	tls, err := LoadTLSConfig(settings.TLS)
+	if err != nil {
+		return nil, err
+	}
+	rt, err := settings.RoundTripper(opts...)
+	if err != nil || tls == nil || len(tls.files) == 0 {
+		return rt, err
+	}
+
+	return &reloadingRoundTripper{
+		settings: settings,
+		opts:     opts,
+		tls:      tls,
+		log:      logp.NewLogger("tls"),
+		current:  rt,
+	}, nil
+}
+
+// Client creates an HTTP client like settings.Client, with a round tripper
+// that is created again when the TLS configuration is reloaded.
+func Client(settings httpcommon.HTTPTransportSettings, opts ...httpcommon.TransportOption) (*http.Client, error) {
+	rt, err := RoundTripper(settings, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: rt, Timeout: settings.Timeout}, nil
+}
+
+type reloadingRoundTripper struct {
+	settings httpcommon.HTTPTransportSettings
+	opts     []httpcommon.TransportOption
+	tls      *TLSConfig
+	log      *logp.Logger
+
+	mu         sync.Mutex
+	current    http.RoundTripper
+	generation uint64
+}
+
+func (rt *reloadingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.roundTripper().RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of the current round
+// tripper, so http.Client.CloseIdleConnections keeps working.
+func (rt *reloadingRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(rt.roundTripper())
+}
+
+func (rt *reloadingRoundTripper) roundTripper() http.RoundTripper {
+	_, generation := rt.tls.get()
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if generation == rt.generation {
+		return rt.current
+	}
+
+	rt.generation = generation
+	next, err := rt.settings.RoundTripper(rt.opts...)
+	if err != nil {
+		rt.log.Errorf("Failed to create HTTP transport with the reloaded TLS configuration: %v", err)
+		return rt.current
+	}
+	closeIdleConnections(rt.current)
+	rt.current = next
+	return rt.current
+}
+
+func closeIdleConnections(rt http.RoundTripper) {
+	if c, ok := rt.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package tlsreload reloads the TLS configurations when the files of their
+// certificates, keys or certificate authorities are modified, so rotated
+// certificates are used without restarting the Beat.
+package tlsreload
+
+import (
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// checkInterval is the minimum time between two checks of the files.
+const checkInterval = 10 * time.Second
+
+// TLSConfig is a TLS configuration that is loaded again from its settings
+// when any of the files it uses is modified. If the configuration cannot be
+// loaded again, the previous one is kept.
+type TLSConfig struct {
+	config   *tlscommon.Config
+	files    []string
+	interval time.Duration
+	log      *logp.Logger
+	now      func() time.Time
+
+	mu         sync.Mutex
+	current    *tlscommon.TLSConfig
+	states     map[string]fileState
+	checked    time.Time
+	generation uint64 // Incremented on each reload.
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// LoadTLSConfig loads the TLS configuration as tlscommon.LoadTLSConfig does.
+// It returns nil if TLS is not enabled.
+func LoadTLSConfig(config *tlscommon.Config) (*TLSConfig, error) {
+	current, err := tlscommon.LoadTLSConfig(config)
+	if err != nil || current == nil {
+		return nil, err
+	}
+
+	c := &TLSConfig{
+		config:   config,
+		files:    configFiles(config),
+		interval: checkInterval,
+		log:      logp.NewLogger("tls"),
+		now:      time.Now,
+		current:  current,
+	}
+	c.states = c.statFiles()
+	c.checked = c.now()
+	return c, nil
+}
+
+// configFiles returns the files of the certificate, key and certificate
+// authorities, ignoring the ones configured as inline PEM.
+func configFiles(config *tlscommon.Config) []string {
+	var files []string
+	for _, f := range append([]string{config.Certificate.Certificate, config.Certificate.Key}, config.CAs...) {
+		if f != "" && !tlscommon.IsPEMString(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// Get returns the current TLS configuration, loading it again first if any
+// of its files has been modified. It returns nil if c is nil.
+func (c *TLSConfig) Get() *tlscommon.TLSConfig {
+	if c == nil {
+		return nil
+	}
+	current, _ := c.get()
+	return current
+}
+
+func (c *TLSConfig) get() (*tlscommon.TLSConfig, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.files) == 0 || now.Sub(c.checked) < c.interval {
+		return c.current, c.generation
+	}
+	c.checked = now
+
+	states := c.statFiles()
+	if statesEqual(states, c.states) {
+		return c.current, c.generation
+	}
+	c.states = states
+
+	reloaded, err := tlscommon.LoadTLSConfig(c.config)
+	if err != nil {
+		c.log.Errorf("Failed to reload TLS configuration, the previous one is kept: %v", err)
+		return c.current, c.generation
+	}
+	c.log.Infof("TLS configuration reloaded after a change in %v", c.files)
+	c.current = reloaded
+	c.generation++
+	return c.current, c.generation
+}
+
+func (c *TLSConfig) statFiles() map[string]fileState {
+	states := make(map[string]fileState, len(c.files))
+	for _, f := range c.files {
+		// Missing files are kept with the zero state, so they are detected
+		// once they are created again.
+		if info, err := os.Stat(f); err == nil {
+			states[f] = fileState{modTime: info.ModTime(), size: info.Size()}
+		} else {
+			states[f] = fileState{}
+		}
+	}
+	return states
+}
+
+func statesEqual(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for f, state := range a {
+		if other, ok := b[f]; !ok || !state.modTime.Equal(other.modTime) || state.size != other.size {
+			return false
+		}
+	}
+	return true
+}
+
+// Dialer returns a TLS dialer that uses the current TLS configuration for
+// each new connection. It returns forward if c is nil.
+func (c *TLSConfig) Dialer(forward transport.Dialer, timeout time.Duration) transport.Dialer {
+	if c == nil {
+		return forward
+	}
+	return transport.DialerFunc(func(network, address string) (net.Conn, error) {
+		return transport.TLSDialer(forward, c.Get(), timeout).Dial(network, address)
+	})
+}
+
+// MakeDialer creates a dialer like transport.MakeDialer, whose TLS
+// connections use the current configuration of tls instead of c.TLS. TLS is
+// still disabled if c.TLS is nil, and c.TLS is used as is if tls is nil.
+func MakeDialer(c transport.Config, tls *TLSConfig) (transport.Dialer, error) {
+	if c.TLS == nil || tls == nil {
+		return transport.MakeDialer(c)
+	}
+
+	c.TLS = nil
+	dialer, err := transport.MakeDialer(c)
+	if err != nil {
+		return nil, err
+	}
+	return tls.Dialer(dialer, c.Timeout), nil
+}
@@ -0,0 +1,185 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func TestLoadTLSConfigDisabled(t *testing.T) {
+	tls, err := LoadTLSConfig(nil)
+	require.NoError(t, err)
+	assert.Nil(t, tls)
+	assert.Nil(t, tls.Get())
+
+	enabled := false
+	tls, err = LoadTLSConfig(&tlscommon.Config{Enabled: &enabled})
+	require.NoError(t, err)
+	assert.Nil(t, tls)
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeCertificate(t, certFile, keyFile, "first")
+
+	tls, err := LoadTLSConfig(&tlscommon.Config{
+		Certificate: tlscommon.CertificateConfig{Certificate: certFile, Key: keyFile},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{certFile, keyFile}, tls.files)
+
+	now := time.Now()
+	tls.now = func() time.Time { return now }
+
+	t.Run("unchanged files are not reloaded", func(t *testing.T) {
+		now = now.Add(checkInterval)
+		_, generation := tls.get()
+		assert.Equal(t, uint64(0), generation)
+		assert.Equal(t, "first", commonName(t, tls.Get()))
+	})
+
+	t.Run("changes are detected after the check interval", func(t *testing.T) {
+		writeCertificate(t, certFile, keyFile, "second")
+		touch(t, now.Add(time.Minute), certFile, keyFile)
+
+		now = now.Add(checkInterval / 2)
+		assert.Equal(t, "first", commonName(t, tls.Get()))
+
+		now = now.Add(checkInterval / 2)
+		current, generation := tls.get()
+		assert.Equal(t, uint64(1), generation)
+		assert.Equal(t, "second", commonName(t, current))
+	})
+
+	t.Run("invalid files keep the previous configuration", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0o600))
+		touch(t, now.Add(2*time.Minute), keyFile)
+
+		now = now.Add(checkInterval)
+		current, generation := tls.get()
+		assert.Equal(t, uint64(1), generation)
+		assert.Equal(t, "second", commonName(t, current))
+	})
+}
+
+func TestConfigFilesIgnoresInlinePEM(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeCertificate(t, certFile, keyFile, "inline")
+	cert, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+
+	files := configFiles(&tlscommon.Config{
+		Certificate: tlscommon.CertificateConfig{Certificate: certFile, Key: keyFile},
+		CAs:         []string{string(cert)},
+	})
+	assert.Equal(t, []string{certFile, keyFile}, files)
+}
+
+func TestRoundTripperReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeCertificate(t, certFile, keyFile, "first")
+
+	settings := httpcommon.DefaultHTTPTransportSettings()
+	settings.TLS = &tlscommon.Config{
+		Certificate: tlscommon.CertificateConfig{Certificate: certFile, Key: keyFile},
+	}
+	rt, err := RoundTripper(settings)
+	require.NoError(t, err)
+	reloading, ok := rt.(*reloadingRoundTripper)
+	require.True(t, ok)
+
+	now := time.Now()
+	reloading.tls.now = func() time.Time { return now }
+
+	first := reloading.roundTripper()
+	assert.Same(t, first, reloading.roundTripper())
+
+	writeCertificate(t, certFile, keyFile, "second")
+	touch(t, now.Add(time.Minute), certFile, keyFile)
+	now = now.Add(checkInterval)
+
+	second := reloading.roundTripper()
+	assert.NotSame(t, first, second)
+	assert.Same(t, second, reloading.roundTripper())
+}
+
+func TestRoundTripperWithoutFiles(t *testing.T) {
+	rt, err := RoundTripper(httpcommon.DefaultHTTPTransportSettings())
+	require.NoError(t, err)
+	assert.IsType(t, &http.Transport{}, rt)
+}
+
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
+
+// touch sets the modification time of the files, so changes are detected
+// even on file systems with a coarse time resolution.
+func touch(t *testing.T, modTime time.Time, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		require.NoError(t, os.Chtimes(f, modTime, modTime))
+	}
+}
+
+func commonName(t *testing.T, config *tlscommon.TLSConfig) string {
+	t.Helper()
+	require.Len(t, config.Certificates, 1)
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return cert.Subject.CommonName
+}
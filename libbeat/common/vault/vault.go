@@ -0,0 +1,237 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package vault implements a minimal client to read secrets from HashiCorp
+// Vault. Both versions of the KV secrets engine are supported. Requests are
+// authenticated with a static token, a token file that is read again on
+// each request, or AppRole, in which case the client logs in again when the
+// token expires or is revoked.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSecretNotFound is returned when the secret doesn't exist in Vault.
+var ErrSecretNotFound = errors.New("vault secret not found")
+
+// Config is the configuration of the connection to Vault.
+type Config struct {
+	Address   string        `config:"address"`
+	Token     string        `config:"token"`
+	TokenFile string        `config:"token_file"`
+	Namespace string        `config:"namespace"`
+	AppRole   AppRoleConfig `config:"auth.approle"`
+}
+
+// AppRoleConfig configures the authentication with the AppRole method.
+type AppRoleConfig struct {
+	Mount    string `config:"mount"`
+	RoleID   string `config:"role_id"`
+	SecretID string `config:"secret_id"`
+}
+
+// Secret is a secret read from Vault.
+type Secret struct {
+	// Data contains the fields of the secret, without the metadata added by
+	// the KV version 2 engine.
+	Data map[string]interface{}
+
+	// LeaseDuration is the lease of the secret, zero if it has none.
+	LeaseDuration time.Duration
+}
+
+// Client reads secrets from Vault.
+type Client struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu           sync.Mutex
+	token        string
+	tokenExpires time.Time // Zero if the token doesn't expire.
+}
+
+// NewClient returns a client that sends its requests to Vault with the
+// given HTTP client.
+func NewClient(config Config, client *http.Client) *Client {
+	if config.AppRole.Mount == "" {
+		config.AppRole.Mount = "approle"
+	}
+	return &Client{
+		config: config,
+		client: client,
+		now:    time.Now,
+		token:  config.Token,
+	}
+}
+
+// response is the common structure of the responses of the Vault API.
+type response struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// Read reads the secret at the given path.
+func (c *Client) Read(ctx context.Context, path string) (*Secret, error) {
+	path = strings.Trim(path, "/")
+	for attempt := 0; ; attempt++ {
+		token, err := c.getToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		status, resp, err := c.do(ctx, http.MethodGet, path, token, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case status == http.StatusOK:
+			return newSecret(resp), nil
+		case status == http.StatusNotFound:
+			return nil, ErrSecretNotFound
+		case status == http.StatusForbidden && attempt == 0 && c.canLogin():
+			// The token may have been revoked, login again.
+			c.resetToken(token)
+			continue
+		}
+		return nil, fmt.Errorf("failed to read secret '%s' with status %d: %s", path, status, strings.Join(resp.Errors, ", "))
+	}
+}
+
+func newSecret(resp *response) *Secret {
+	data := resp.Data
+	// KV version 2 wraps the fields of the secret with its metadata.
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	return &Secret{
+		Data:          data,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+	}
+}
+
+func (c *Client) canLogin() bool {
+	return c.config.AppRole.RoleID != ""
+}
+
+// getToken returns the token to authenticate the requests, logging in with
+// AppRole when there is no valid token. The token file is read on each
+// request, so tokens renewed by an external agent are used.
+func (c *Client) getToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.canLogin() {
+		if c.config.TokenFile != "" {
+			token, err := os.ReadFile(c.config.TokenFile)
+			if err != nil {
+				return "", fmt.Errorf("failed to read vault token: %w", err)
+			}
+			return strings.TrimSpace(string(token)), nil
+		}
+		return c.token, nil
+	}
+	if c.token != "" && (c.tokenExpires.IsZero() || c.now().Before(c.tokenExpires)) {
+		return c.token, nil
+	}
+
+	mount := strings.Trim(c.config.AppRole.Mount, "/")
+	body := map[string]string{
+		"role_id":   c.config.AppRole.RoleID,
+		"secret_id": c.config.AppRole.SecretID,
+	}
+	status, resp, err := c.do(ctx, http.MethodPost, "auth/"+mount+"/login", "", body)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK || resp.Auth == nil {
+		return "", fmt.Errorf("vault AppRole login failed with status %d: %s", status, strings.Join(resp.Errors, ", "))
+	}
+
+	c.token = resp.Auth.ClientToken
+	c.tokenExpires = time.Time{}
+	if lease := time.Duration(resp.Auth.LeaseDuration) * time.Second; lease > 0 {
+		// Login again a bit before the token expires.
+		c.tokenExpires = c.now().Add(lease * 9 / 10)
+	}
+	return c.token, nil
+}
+
+func (c *Client) resetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+// do sends a request to the Vault API. The body of responses with an error
+// status is only decoded on a best effort basis, proxies in front of Vault
+// may not reply with JSON.
+func (c *Client) do(ctx context.Context, method, path, token string, body interface{}) (int, *response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	url := strings.TrimRight(c.config.Address, "/") + "/v1/" + path
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.config.Namespace)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var vaultResp response
+	err = json.NewDecoder(resp.Body).Decode(&vaultResp)
+	if err != nil && !errors.Is(err, io.EOF) && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return resp.StatusCode, &vaultResp, nil
+}
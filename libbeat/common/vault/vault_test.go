@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.root" {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/beats":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     map[string]interface{}{"password": "changeme"},
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			})
+		case "/v1/kv/beats":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"lease_duration": 60,
+				"data":           map[string]interface{}{"password": "v1", "data": map[string]interface{}{}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{}})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Address: server.URL, Token: "s.root"}, server.Client())
+
+	t.Run("kv version 2", func(t *testing.T) {
+		secret, err := client.Read(context.Background(), "/secret/data/beats")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"password": "changeme"}, secret.Data)
+		assert.Zero(t, secret.LeaseDuration)
+	})
+
+	t.Run("kv version 1", func(t *testing.T) {
+		secret, err := client.Read(context.Background(), "kv/beats")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", secret.Data["password"])
+		assert.Contains(t, secret.Data, "data")
+		assert.Equal(t, time.Minute, secret.LeaseDuration)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Read(context.Background(), "kv/other")
+		assert.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("error without json body", func(t *testing.T) {
+		client := NewClient(Config{Address: server.URL, Token: "s.wrong"}, server.Client())
+		_, err := client.Read(context.Background(), "kv/beats")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+		assert.NotContains(t, err.Error(), "s.wrong")
+	})
+}
+
+func TestClientAppRoleTokenExpiration(t *testing.T) {
+	var logins int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/custom/login":
+			logins++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"auth": map[string]interface{}{"client_token": "s.login", "lease_duration": 100},
+			})
+		case "/v1/kv/beats":
+			assert.Equal(t, "s.login", r.Header.Get("X-Vault-Token"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"password": "v1"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	client := NewClient(Config{
+		Address: server.URL,
+		AppRole: AppRoleConfig{Mount: "custom", RoleID: "role", SecretID: "secret"},
+	}, server.Client())
+	client.now = func() time.Time { return now }
+
+	_, err := client.Read(context.Background(), "kv/beats")
+	require.NoError(t, err)
+	_, err = client.Read(context.Background(), "kv/beats")
+	require.NoError(t, err)
+	assert.Equal(t, 1, logins)
+
+	// The token is renewed before its lease ends.
+	now = now.Add(95 * time.Second)
+	_, err = client.Read(context.Background(), "kv/beats")
+	require.NoError(t, err)
+	assert.Equal(t, 2, logins)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/productorigin"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlsreload"
 	"github.com/elastic/beats/v7/libbeat/version"
 	cfg "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -131,7 +132,7 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 		s.Headers[productorigin.Header] = productorigin.Beats
 	}
 
-	httpClient, err := s.Transport.Client(
+	httpClient, err := tlsreload.Client(s.Transport,
 		httpcommon.WithLogger(logger),
 		httpcommon.WithIOStats(s.Observer),
 		httpcommon.WithKeepaliveSettings{IdleConnTimeout: s.IdleConnTimeout},
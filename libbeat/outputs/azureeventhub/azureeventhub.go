@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+const logSelector = "azure_eventhub"
+
+func init() {
+	outputs.RegisterType("azure_eventhub", makeEventHub)
+}
+
+func makeEventHub(
+	_ outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	cfgwarn.Beta("The azure_eventhub output is beta.")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	enc, err := codec.CreateEncoder(beat, config.Codec)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	userAgent := beat.Beat + "/" + beat.Version
+	client := newClient(observer, beat.IndexPrefix, enc, &config, func() (hubAPI, error) {
+		return newHub(&config, userAgent)
+	})
+	clients := []outputs.NetworkClient{
+		outputs.WithBackoff(client, config.Backoff.Init, config.Backoff.Max),
+	}
+	return outputs.SuccessNet(false, config.BulkMaxSize, config.MaxRetries, clients)
+}
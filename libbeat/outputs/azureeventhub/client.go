@@ -0,0 +1,282 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"context"
+	"errors"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// hubAPI is the subset of the Event Hubs client used by the output.
+type hubAPI interface {
+	SendBatch(ctx context.Context, iterator eventhub.BatchIterator, opts ...eventhub.BatchOption) error
+	GetRuntimeInformation(ctx context.Context) (*eventhub.HubRuntimeInformation, error)
+	Close(ctx context.Context) error
+}
+
+type client struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	index    string
+	codec    codec.Codec
+	config   *eventHubConfig
+
+	newHub func() (hubAPI, error)
+	hub    hubAPI
+}
+
+// message is an event encoded for Event Hubs.
+type message struct {
+	event publisher.Event
+	data  *eventhub.Event
+}
+
+// messageBatch is a batch of messages sharing the same partition key, that
+// is sent in a single request.
+type messageBatch struct {
+	batch  *eventhub.EventBatch
+	events []publisher.Event
+}
+
+func newClient(
+	observer outputs.Observer,
+	index string,
+	writer codec.Codec,
+	config *eventHubConfig,
+	newHub func() (hubAPI, error),
+) *client {
+	return &client{
+		log:      logp.NewLogger(logSelector),
+		observer: observer,
+		index:    index,
+		codec:    writer,
+		config:   config,
+		newHub:   newHub,
+	}
+}
+
+func (c *client) Connect() error {
+	if c.hub != nil {
+		return nil
+	}
+	hub, err := c.newHub()
+	if err != nil {
+		return err
+	}
+	c.hub = hub
+	return nil
+}
+
+func (c *client) Close() error {
+	if c.hub == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+	err := c.hub.Close(ctx)
+	c.hub = nil
+	return err
+}
+
+func (c *client) String() string {
+	return "azure_eventhub(" + c.config.EventHub + ")"
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("Azure Event Hubs: "+c.config.EventHub, func(d testing.Driver) {
+		err := c.Connect()
+		d.Fatal("connect", err)
+		defer c.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+		_, err = c.hub.GetRuntimeInformation(ctx)
+		d.Error("get runtime information", err)
+	})
+}
+
+// Publish sends the events in batches of messages with the same partition
+// key. Batches are sent in order, and if one fails, only the events not sent
+// yet are retried.
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	if c.hub == nil {
+		c.observer.Failed(len(events))
+		batch.Retry()
+		return errors.New("output not connected")
+	}
+
+	batches := c.batches(c.encodeEvents(events))
+
+	acked := 0
+	for i, b := range batches {
+		if err := c.send(ctx, b.batch); err != nil {
+			c.observer.WriteError(err)
+
+			var failed []publisher.Event
+			for _, b := range batches[i:] {
+				failed = append(failed, b.events...)
+			}
+			c.observer.Acked(acked)
+			c.observer.Failed(len(failed))
+			batch.RetryEvents(failed)
+			return err
+		}
+		c.observer.WriteBytes(b.batch.Size())
+		acked += len(b.events)
+	}
+
+	c.observer.Acked(acked)
+	batch.ACK()
+	return nil
+}
+
+func (c *client) send(ctx context.Context, batch *eventhub.EventBatch) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+	return c.hub.SendBatch(ctx, &singleBatchIterator{batch: batch})
+}
+
+// encodeEvents encodes the events and obtains their partition keys, events
+// that cannot be encoded are dropped.
+func (c *client) encodeEvents(events []publisher.Event) []message {
+	messages := make([]message, 0, len(events))
+	for i := range events {
+		event := &events[i].Content
+
+		serialized, err := c.codec.Encode(c.index, event)
+		if err != nil {
+			c.log.Errorf("Dropping event, failed to encode it: %v", err)
+			c.observer.Dropped(1)
+			continue
+		}
+
+		data := eventhub.NewEvent(append([]byte(nil), serialized...))
+		if key := c.partitionKey(event); key != "" {
+			data.PartitionKey = &key
+		}
+		messages = append(messages, message{event: events[i], data: data})
+	}
+	return messages
+}
+
+func (c *client) partitionKey(event *beat.Event) string {
+	if c.config.PartitionKey == nil {
+		return ""
+	}
+	key, err := c.config.PartitionKey.Run(event)
+	if err != nil {
+		c.log.Debugf("Failed to get partition key, sending event without it: %v", err)
+		return ""
+	}
+	return key
+}
+
+// batches groups the messages by partition key, keeping the order of the
+// events of each partition key, and splits them in batches that fit in a
+// request. Messages that don't fit in an empty batch are dropped.
+func (c *client) batches(messages []message) []messageBatch {
+	var keys []string
+	groups := map[string][]message{}
+	for _, m := range messages {
+		key := eventhub.KeyOfNoPartitionKey
+		if m.data.PartitionKey != nil {
+			key = *m.data.PartitionKey
+		}
+		if _, found := groups[key]; !found {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], m)
+	}
+
+	var batches []messageBatch
+	for _, key := range keys {
+		var current *messageBatch
+		for _, m := range groups[key] {
+			if current != nil {
+				ok, err := current.batch.Add(m.data)
+				if err != nil {
+					c.log.Errorf("Dropping event, failed to add it to a batch: %v", err)
+					c.observer.Dropped(1)
+					continue
+				}
+				if ok {
+					current.events = append(current.events, m.event)
+					continue
+				}
+			}
+
+			next := c.newBatch(m.data.PartitionKey)
+			ok, err := next.batch.Add(m.data)
+			if err != nil || !ok {
+				if err == nil {
+					err = eventhub.ErrMessageIsTooBig
+				}
+				c.log.Errorf("Dropping event: %v", err)
+				c.observer.Dropped(1)
+				continue
+			}
+			next.events = append(next.events, m.event)
+
+			if current != nil {
+				batches = append(batches, *current)
+			}
+			current = next
+		}
+		if current != nil {
+			batches = append(batches, *current)
+		}
+	}
+	return batches
+}
+
+func (c *client) newBatch(partitionKey *string) *messageBatch {
+	batch := eventhub.NewEventBatch(uuid.Must(uuid.NewV4()).String(), &eventhub.BatchOptions{
+		MaxSize: eventhub.MaxMessageSizeInBytes(c.config.MaxBatchBytes),
+	})
+	batch.PartitionKey = partitionKey
+	return &messageBatch{batch: batch}
+}
+
+// singleBatchIterator is an eventhub.BatchIterator for a batch that has
+// already been built.
+type singleBatchIterator struct {
+	batch *eventhub.EventBatch
+}
+
+func (it *singleBatchIterator) Done() bool {
+	return it.batch == nil
+}
+
+func (it *singleBatchIterator) Next(_ string, _ *eventhub.BatchOptions) (*eventhub.EventBatch, error) {
+	batch := it.batch
+	it.batch = nil
+	return batch, nil
+}
@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// fakeHub records the batches sent, it fails the call number failAt.
+type fakeHub struct {
+	batches []*eventhub.EventBatch
+	calls   int
+	failAt  int
+}
+
+func (h *fakeHub) SendBatch(_ context.Context, iterator eventhub.BatchIterator, _ ...eventhub.BatchOption) error {
+	h.calls++
+	if h.calls == h.failAt {
+		return errors.New("send failed")
+	}
+	for !iterator.Done() {
+		batch, err := iterator.Next("", nil)
+		if err != nil {
+			return err
+		}
+		h.batches = append(h.batches, batch)
+	}
+	return nil
+}
+
+func (h *fakeHub) GetRuntimeInformation(context.Context) (*eventhub.HubRuntimeInformation, error) {
+	return &eventhub.HubRuntimeInformation{}, nil
+}
+
+func (h *fakeHub) Close(context.Context) error {
+	return nil
+}
+
+func newTestClient(t *testing.T, hub *fakeHub, configure func(*eventHubConfig)) *client {
+	t.Helper()
+
+	config := defaultConfig()
+	config.EventHub = "beats"
+	if configure != nil {
+		configure(&config)
+	}
+
+	c := newClient(outputs.NewNilObserver(), "testbeat", json.New("1.2.3", json.Config{}), &config, func() (hubAPI, error) {
+		return hub, nil
+	})
+	require.NoError(t, c.Connect())
+	return c
+}
+
+func testEvents(n int) []beat.Event {
+	events := make([]beat.Event, n)
+	for i := range events {
+		events[i] = beat.Event{
+			Timestamp: time.Now(),
+			Fields: mapstr.M{
+				"message": fmt.Sprintf("event %d", i),
+				"host":    mapstr.M{"name": fmt.Sprintf("host-%d", i%2)},
+			},
+		}
+	}
+	return events
+}
+
+func TestPublish(t *testing.T) {
+	hub := &fakeHub{}
+	c := newTestClient(t, hub, nil)
+
+	batch := outest.NewBatch(testEvents(10)...)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	require.Len(t, hub.batches, 1)
+	assert.Nil(t, hub.batches[0].PartitionKey)
+}
+
+func TestPublishPartitionKey(t *testing.T) {
+	hub := &fakeHub{}
+	c := newTestClient(t, hub, func(config *eventHubConfig) {
+		config.PartitionKey = fmtstr.MustCompileEvent("%{[host.name]}")
+	})
+
+	batch := outest.NewBatch(testEvents(10)...)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, hub.batches, 2)
+	require.NotNil(t, hub.batches[0].PartitionKey)
+	require.NotNil(t, hub.batches[1].PartitionKey)
+	assert.Equal(t, "host-0", *hub.batches[0].PartitionKey)
+	assert.Equal(t, "host-1", *hub.batches[1].PartitionKey)
+}
+
+func TestBatches(t *testing.T) {
+	hub := &fakeHub{}
+	c := newTestClient(t, hub, func(config *eventHubConfig) {
+		config.PartitionKey = fmtstr.MustCompileEvent("%{[host.name]}")
+		config.MaxBatchBytes = 1000
+	})
+
+	events := outest.NewBatch(testEvents(20)...).Events()
+	batches := c.batches(c.encodeEvents(events))
+
+	// Events are grouped by partition key, keeping their order.
+	count := 0
+	for _, b := range batches {
+		assert.NotEmpty(t, b.events)
+		assert.LessOrEqual(t, b.batch.Size(), 1000)
+		for _, e := range b.events {
+			host, _ := e.Content.Fields.GetValue("host.name")
+			assert.Equal(t, *b.batch.PartitionKey, host)
+		}
+		count += len(b.events)
+	}
+	assert.Equal(t, 20, count)
+	assert.Greater(t, len(batches), 2)
+}
+
+func TestBatchesDropsTooBigEvents(t *testing.T) {
+	hub := &fakeHub{}
+	c := newTestClient(t, hub, func(config *eventHubConfig) {
+		config.MaxBatchBytes = 1000
+	})
+
+	events := testEvents(3)
+	events[1].Fields["message"] = strings.Repeat("a", 2000)
+	batches := c.batches(c.encodeEvents(outest.NewBatch(events...).Events()))
+
+	require.Len(t, batches, 1)
+	assert.Len(t, batches[0].events, 2)
+}
+
+func TestPublishRetriesNotSentEvents(t *testing.T) {
+	hub := &fakeHub{failAt: 2}
+	c := newTestClient(t, hub, func(config *eventHubConfig) {
+		config.MaxBatchBytes = 1000
+	})
+
+	batch := outest.NewBatch(testEvents(20)...)
+	batches := c.batches(c.encodeEvents(batch.Events()))
+	require.Greater(t, len(batches), 2)
+
+	err := c.Publish(context.Background(), batch)
+	assert.Error(t, err)
+
+	// Only the events of the first batch were sent.
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 20-len(batches[0].events))
+	assert.Equal(t, "event "+fmt.Sprint(len(batches[0].events)), batch.Signals[0].Events[0].Content.Fields["message"])
+}
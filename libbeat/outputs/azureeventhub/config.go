@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+)
+
+type backoffConfig struct {
+	Init time.Duration `config:"init"`
+	Max  time.Duration `config:"max"`
+}
+
+type eventHubConfig struct {
+	// ConnectionString is a shared access signature connection string of
+	// the namespace or of the event hub.
+	ConnectionString string `config:"connection_string"`
+
+	// Namespace is the name of the Event Hubs namespace, used with Azure
+	// Active Directory authentication.
+	Namespace    string `config:"namespace"`
+	TenantID     string `config:"tenant_id"`
+	ClientID     string `config:"client_id"`
+	ClientSecret string `config:"client_secret"`
+
+	EventHub string `config:"eventhub" validate:"required"`
+
+	// by default the azure public environment is used, to override, users can provide a specific resource manager endpoint
+	OverrideEnvironment string `config:"resource_manager_endpoint"`
+	WebSocket           bool   `config:"websocket"`
+
+	PartitionKey  *fmtstr.EventFormatString `config:"partition_key"`
+	MaxBatchBytes int                       `config:"max_batch_bytes" validate:"min=1"`
+
+	BulkMaxSize int           `config:"bulk_max_size" validate:"min=1"`
+	MaxRetries  int           `config:"max_retries"   validate:"min=-1"`
+	Backoff     backoffConfig `config:"backoff"`
+	Timeout     time.Duration `config:"timeout"       validate:"min=1"`
+	Codec       codec.Config  `config:"codec"`
+}
+
+func defaultConfig() eventHubConfig {
+	return eventHubConfig{
+		MaxBatchBytes: int(eventhub.DefaultMaxMessageSizeInBytes),
+		BulkMaxSize:   2048,
+		MaxRetries:    3,
+		Backoff: backoffConfig{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+		Timeout: 30 * time.Second,
+	}
+}
+
+func (c *eventHubConfig) Validate() error {
+	switch {
+	case c.ConnectionString == "" && c.Namespace == "":
+		return errors.New("either connection_string or namespace must be configured")
+	case c.ConnectionString != "" && c.Namespace != "":
+		return errors.New("connection_string and namespace cannot be used together")
+	case c.ConnectionString != "" && (c.TenantID != "" || c.ClientID != "" || c.ClientSecret != ""):
+		return errors.New("tenant_id, client_id and client_secret can only be used with namespace")
+	case c.ClientSecret != "" && (c.TenantID == "" || c.ClientID == ""):
+		return errors.New("tenant_id and client_id are required when client_secret is set")
+	}
+
+	if c.MaxBatchBytes > int(eventhub.DefaultMaxMessageSizeInBytes) {
+		return fmt.Errorf("max_batch_bytes cannot be greater than %d", eventhub.DefaultMaxMessageSizeInBytes)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	const connStr = "Endpoint=sb://beats.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=secret"
+
+	cases := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"eventhub is required": {
+			config: map[string]interface{}{"connection_string": connStr},
+			valid:  false,
+		},
+		"connection string or namespace is required": {
+			config: map[string]interface{}{"eventhub": "beats"},
+			valid:  false,
+		},
+		"connection string": {
+			config: map[string]interface{}{"eventhub": "beats", "connection_string": connStr},
+			valid:  true,
+		},
+		"namespace with managed identity": {
+			config: map[string]interface{}{"eventhub": "beats", "namespace": "beats"},
+			valid:  true,
+		},
+		"namespace with service principal": {
+			config: map[string]interface{}{"eventhub": "beats", "namespace": "beats", "tenant_id": "tenant", "client_id": "client", "client_secret": "secret"},
+			valid:  true,
+		},
+		"client secret without tenant": {
+			config: map[string]interface{}{"eventhub": "beats", "namespace": "beats", "client_id": "client", "client_secret": "secret"},
+			valid:  false,
+		},
+		"connection string and namespace": {
+			config: map[string]interface{}{"eventhub": "beats", "namespace": "beats", "connection_string": connStr},
+			valid:  false,
+		},
+		"connection string with client secret": {
+			config: map[string]interface{}{"eventhub": "beats", "connection_string": connStr, "client_secret": "secret"},
+			valid:  false,
+		},
+		"batch too big": {
+			config: map[string]interface{}{"eventhub": "beats", "connection_string": connStr, "max_batch_bytes": 2000000},
+			valid:  false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(c.config)
+			eventHubConfig := defaultConfig()
+			err := cfg.Unpack(&eventHubConfig)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-amqp-common-go/v3/aad"
+	"github.com/Azure/azure-amqp-common-go/v3/conn"
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+var environments = map[string]azure.Environment{
+	azure.ChinaCloud.ResourceManagerEndpoint:        azure.ChinaCloud,
+	azure.GermanCloud.ResourceManagerEndpoint:       azure.GermanCloud,
+	azure.PublicCloud.ResourceManagerEndpoint:       azure.PublicCloud,
+	azure.USGovernmentCloud.ResourceManagerEndpoint: azure.USGovernmentCloud,
+}
+
+// newHub creates the Event Hubs client. When a namespace is configured, it
+// authenticates with Azure Active Directory, using a service principal if a
+// client secret is configured, or a managed identity otherwise.
+func newHub(config *eventHubConfig, userAgent string) (*eventhub.Hub, error) {
+	opts := []eventhub.HubOption{eventhub.HubWithUserAgent(userAgent)}
+	if config.WebSocket {
+		opts = append(opts, eventhub.HubWithWebSocketConnection())
+	}
+
+	if config.ConnectionString != "" {
+		connStr, err := hubConnectionString(config.ConnectionString, config.EventHub)
+		if err != nil {
+			return nil, err
+		}
+		return eventhub.NewHubFromConnectionString(connStr, opts...)
+	}
+
+	env, err := getAzureEnvironment(config.OverrideEnvironment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource_manager_endpoint: %w", err)
+	}
+
+	provider, err := aad.NewJWTProvider(
+		aad.JWTProviderWithAzureEnvironment(&env),
+		func(c *aad.TokenProviderConfiguration) error {
+			c.TenantID = config.TenantID
+			c.ClientID = config.ClientID
+			c.ClientSecret = config.ClientSecret
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Azure Active Directory token: %w", err)
+	}
+
+	// Accept fully qualified namespaces, the host is built from the name of
+	// the namespace and the environment.
+	namespace := strings.SplitN(config.Namespace, ".", 2)[0]
+	opts = append(opts, eventhub.HubWithEnvironment(env))
+	return eventhub.NewHub(namespace, config.EventHub, provider, opts...)
+}
+
+// hubConnectionString returns a connection string for the event hub, it
+// accepts connection strings of the namespace or of the event hub.
+func hubConnectionString(connStr, hub string) (string, error) {
+	connStr = strings.TrimSuffix(connStr, ";")
+	parsed, err := conn.ParsedConnectionFromStr(connStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid connection_string: %w", err)
+	}
+	if parsed.HubName == "" {
+		return connStr + ";EntityPath=" + hub, nil
+	}
+	if parsed.HubName != hub {
+		return "", fmt.Errorf("connection_string is for event hub %q, but eventhub is %q", parsed.HubName, hub)
+	}
+	return connStr, nil
+}
+
+func getAzureEnvironment(overrideResManager string) (azure.Environment, error) {
+	// if no override is set then the azure public cloud is used
+	if overrideResManager == "" {
+		return azure.PublicCloud, nil
+	}
+	if env, ok := environments[overrideResManager]; ok {
+		return env, nil
+	}
+	// can retrieve hybrid env from the resource manager endpoint
+	return azure.EnvironmentFromURL(overrideResManager)
+}
@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureeventhub
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubConnectionString(t *testing.T) {
+	const namespaceConnStr = "Endpoint=sb://beats.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=secret"
+
+	connStr, err := hubConnectionString(namespaceConnStr, "logs")
+	require.NoError(t, err)
+	assert.Equal(t, namespaceConnStr+";EntityPath=logs", connStr)
+
+	connStr, err = hubConnectionString(namespaceConnStr+";", "logs")
+	require.NoError(t, err)
+	assert.Equal(t, namespaceConnStr+";EntityPath=logs", connStr)
+
+	connStr, err = hubConnectionString(namespaceConnStr+";EntityPath=logs", "logs")
+	require.NoError(t, err)
+	assert.Equal(t, namespaceConnStr+";EntityPath=logs", connStr)
+
+	_, err = hubConnectionString(namespaceConnStr+";EntityPath=metrics", "logs")
+	assert.Error(t, err)
+
+	_, err = hubConnectionString("invalid", "logs")
+	assert.Error(t, err)
+}
+
+func TestGetAzureEnvironment(t *testing.T) {
+	env, err := getAzureEnvironment("")
+	require.NoError(t, err)
+	assert.Equal(t, azure.PublicCloud, env)
+
+	env, err = getAzureEnvironment(azure.USGovernmentCloud.ResourceManagerEndpoint)
+	require.NoError(t, err)
+	assert.Equal(t, azure.USGovernmentCloud, env)
+}
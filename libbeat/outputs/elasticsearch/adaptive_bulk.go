@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// adaptiveBulkConfig configures the adaptive sizing of bulk requests.
+type adaptiveBulkConfig struct {
+	Enabled bool `config:"enabled"`
+	MinSize int  `config:"min_size" validate:"min=1"`
+}
+
+func defaultAdaptiveBulkConfig() adaptiveBulkConfig {
+	return adaptiveBulkConfig{
+		Enabled: false,
+		MinSize: 1,
+	}
+}
+
+// bulkSizer adapts the number of events sent in a bulk request to the
+// feedback of Elasticsearch. The size grows while requests succeed and is
+// halved when Elasticsearch responds with 413 (Request Entity Too Large) or
+// 429 (Too Many Requests). The sizer belongs to a client, the learned size is
+// kept when the client reconnects.
+type bulkSizer struct {
+	min  int
+	max  int // Upper bound of the size, 0 if unbounded.
+	size int // Current size, 0 until the first request is sent.
+}
+
+// newBulkSizer returns a bulkSizer or nil if adaptive sizing is disabled.
+// maxSize is the configured bulk_max_size.
+func newBulkSizer(config adaptiveBulkConfig, maxSize int) *bulkSizer {
+	if !config.Enabled {
+		return nil
+	}
+	if maxSize < 0 {
+		maxSize = 0
+	}
+	if maxSize > 0 && config.MinSize > maxSize {
+		config.MinSize = maxSize
+	}
+	return &bulkSizer{
+		min:  config.MinSize,
+		max:  maxSize,
+		size: maxSize,
+	}
+}
+
+// next returns the number of events of the next bulk request given the
+// number of events pending.
+func (s *bulkSizer) next(pending int) int {
+	if s.size == 0 {
+		// Unbounded, start with the batches created by the queue.
+		s.size = pending
+		if s.size < s.min {
+			s.size = s.min
+		}
+	}
+	if pending < s.size {
+		return pending
+	}
+	return s.size
+}
+
+// atMin returns true if n events can not be split any further.
+func (s *bulkSizer) atMin(n int) bool {
+	return n <= s.min
+}
+
+// onSuccess records that a bulk request of n events was accepted without
+// throttling. The size grows by 10% if the request was full.
+func (s *bulkSizer) onSuccess(n int) {
+	if s == nil {
+		return
+	}
+	if n < s.size {
+		return
+	}
+	growth := s.size / 10
+	if growth < 1 {
+		growth = 1
+	}
+	s.size += growth
+	if s.max > 0 && s.size > s.max {
+		s.size = s.max
+	}
+}
+
+// onBackpressure records that a bulk request of n events was rejected or
+// throttled. The size is halved.
+func (s *bulkSizer) onBackpressure(n int) {
+	if s == nil {
+		return
+	}
+	if n < s.size {
+		s.size = n
+	}
+	s.size /= 2
+	if s.size < s.min {
+		s.size = s.min
+	}
+}
+
+// onResponse updates the size from the response to a bulk request of n events.
+func (s *bulkSizer) onResponse(n, status int, stats bulkResultStats) {
+	switch {
+	case status == http.StatusRequestEntityTooLarge, status == http.StatusTooManyRequests, stats.tooMany > 0:
+		s.onBackpressure(n)
+	case status == http.StatusOK && stats.fails == 0:
+		s.onSuccess(n)
+	}
+}
+
+// publishAdaptive sends the events in as many bulk requests as needed
+// according to the current size. Requests rejected as too large are split
+// until they are accepted or they can't be split any further, in which case
+// their events are dropped. Publishing stops on the first error, the failed
+// and unsent events are returned to be retried.
+func (client *Client) publishAdaptive(ctx context.Context, data []publisher.Event) ([]publisher.Event, error) {
+	var rest []publisher.Event
+	for len(data) > 0 {
+		n := client.bulkSizer.next(len(data))
+		failed, err := client.publishEvents(ctx, data[:n])
+		switch {
+		case errors.Is(err, errPayloadTooLarge):
+			if client.bulkSizer.atMin(len(failed)) {
+				client.log.Errorf("Dropping %d events: %v", len(failed), err)
+				if st := client.observer; st != nil {
+					st.Dropped(len(failed))
+				}
+				data = data[n:]
+				continue
+			}
+			// Retry the encoded events with the reduced size.
+			data = append(failed, data[n:]...)
+		case err != nil:
+			rest = append(rest, failed...)
+			rest = append(rest, data[n:]...)
+			return rest, err
+		default:
+			data = data[n:]
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,218 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestBulkSizer(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newBulkSizer(defaultAdaptiveBulkConfig(), 50))
+	})
+
+	t.Run("grows on full requests up to the max", func(t *testing.T) {
+		s := newBulkSizer(adaptiveBulkConfig{Enabled: true, MinSize: 1}, 50)
+		s.onBackpressure(50)
+		assert.Equal(t, 25, s.next(100))
+
+		s.onSuccess(10)
+		assert.Equal(t, 25, s.next(100), "partial requests must not grow the size")
+
+		s.onSuccess(25)
+		assert.Equal(t, 27, s.next(100))
+
+		for i := 0; i < 20; i++ {
+			s.onSuccess(s.next(100))
+		}
+		assert.Equal(t, 50, s.next(100))
+	})
+
+	t.Run("shrinks down to the min", func(t *testing.T) {
+		s := newBulkSizer(adaptiveBulkConfig{Enabled: true, MinSize: 5}, 50)
+		for i := 0; i < 10; i++ {
+			s.onBackpressure(s.next(100))
+		}
+		assert.Equal(t, 5, s.next(100))
+		assert.True(t, s.atMin(5))
+	})
+
+	t.Run("unbounded starts with the pending events", func(t *testing.T) {
+		s := newBulkSizer(adaptiveBulkConfig{Enabled: true, MinSize: 1}, -1)
+		assert.Equal(t, 200, s.next(200))
+		s.onSuccess(200)
+		assert.Equal(t, 220, s.size)
+	})
+
+	t.Run("response feedback", func(t *testing.T) {
+		s := newBulkSizer(adaptiveBulkConfig{Enabled: true, MinSize: 1}, 100)
+		s.onResponse(100, http.StatusTooManyRequests, bulkResultStats{})
+		assert.Equal(t, 50, s.size)
+		s.onResponse(50, http.StatusOK, bulkResultStats{tooMany: 1})
+		assert.Equal(t, 25, s.size)
+		s.onResponse(25, http.StatusOK, bulkResultStats{fails: 1})
+		assert.Equal(t, 25, s.size)
+		s.onResponse(25, http.StatusOK, bulkResultStats{})
+		assert.Equal(t, 27, s.size)
+	})
+}
+
+func TestPublishAdaptive(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	makeEvents := func(n int) []publisher.Event {
+		events := make([]publisher.Event, n)
+		for i := range events {
+			events[i] = publisher.Event{Content: beat.Event{Fields: mapstr.M{"field": i}}}
+		}
+		return events
+	}
+
+	// newESMock returns a server answering bulk requests with the status
+	// returned by respond for the number of events of the request.
+	newESMock := func(respond func(n int) int) (*httptest.Server, *[]int) {
+		var requests []int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/_bulk") {
+				w.Write([]byte(`{"version":{"number":"8.0.0"}}`))
+				return
+			}
+			lines := 0
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				lines++
+			}
+			n := lines / 2
+			requests = append(requests, n)
+
+			status := respond(n)
+			w.WriteHeader(status)
+			if status != http.StatusOK {
+				return
+			}
+			items := make([]string, n)
+			for i := range items {
+				items[i] = `{"index":{"status":201}}`
+			}
+			fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+		}))
+		return server, &requests
+	}
+
+	newClient := func(t *testing.T, url string, minSize, maxSize int) *Client {
+		client, err := NewClient(
+			ClientSettings{
+				ConnectionSettings: eslegclient.ConnectionSettings{
+					URL: url,
+				},
+				Index:        testIndexSelector{},
+				AdaptiveBulk: adaptiveBulkConfig{Enabled: true, MinSize: minSize},
+				BulkMaxSize:  maxSize,
+			},
+			nil,
+		)
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("splits requests that are too large", func(t *testing.T) {
+		esMock, requests := newESMock(func(n int) int {
+			if n > 2 {
+				return http.StatusRequestEntityTooLarge
+			}
+			return http.StatusOK
+		})
+		defer esMock.Close()
+
+		client := newClient(t, esMock.URL, 1, 4)
+		batch := &batchMock{events: makeEvents(5)}
+
+		err := client.Publish(ctx, batch)
+		require.NoError(t, err)
+		assert.True(t, batch.ack, "batch should be acknowledged")
+		assert.Equal(t, []int{4, 2, 3, 1, 2}, *requests)
+
+		// The learned size is kept for the next batches.
+		*requests = nil
+		batch = &batchMock{events: makeEvents(2)}
+		require.NoError(t, client.Publish(ctx, batch))
+		assert.Equal(t, []int{2}, *requests)
+	})
+
+	t.Run("drops events that can't be split", func(t *testing.T) {
+		esMock, requests := newESMock(func(int) int {
+			return http.StatusRequestEntityTooLarge
+		})
+		defer esMock.Close()
+
+		client := newClient(t, esMock.URL, 2, 4)
+		batch := &batchMock{events: makeEvents(4)}
+
+		err := client.Publish(ctx, batch)
+		require.NoError(t, err)
+		assert.True(t, batch.ack)
+		assert.False(t, batch.drop)
+		assert.Equal(t, []int{4, 2, 2}, *requests)
+	})
+
+	t.Run("retries unsent events when throttled", func(t *testing.T) {
+		throttled := false
+		esMock, requests := newESMock(func(n int) int {
+			if !throttled {
+				throttled = true
+				return http.StatusTooManyRequests
+			}
+			return http.StatusOK
+		})
+		defer esMock.Close()
+
+		client := newClient(t, esMock.URL, 1, 4)
+		batch := &batchMock{events: makeEvents(6)}
+
+		err := client.Publish(ctx, batch)
+		assert.Error(t, err)
+		assert.False(t, batch.ack)
+		assert.Len(t, batch.retryEvents, 6, "all events should be in retry")
+		assert.Equal(t, []int{4}, *requests)
+
+		*requests = nil
+		batch = &batchMock{events: batch.retryEvents}
+		require.NoError(t, client.Publish(ctx, batch))
+		assert.True(t, batch.ack)
+		assert.Equal(t, []int{2, 3, 1}, *requests)
+	})
+}
@@ -34,23 +34,30 @@ var (
 	nameItems  = []byte("items")
 	nameStatus = []byte("status")
 	nameError  = []byte("error")
+	nameErrors = []byte("errors")
 )
 
-// bulkReadToItems reads the bulk response up to (but not including) items
-func bulkReadToItems(reader *jsonReader) error {
+// bulkResponseFilterPath filters the bulk responses to the fields read by
+// the output.
+const bulkResponseFilterPath = "errors,items.*.status,items.*.error"
+
+// bulkReadToItems reads the bulk response up to (but not including) items.
+// It returns false if the response reports that none of the items failed.
+func bulkReadToItems(reader *jsonReader) (bool, error) {
 	if err := reader.ExpectDict(); err != nil {
-		return errExpectedObject
+		return true, errExpectedObject
 	}
 
 	// find 'items' field in response
+	hasErrors := true
 	for {
 		kind, name, err := reader.nextFieldName()
 		if err != nil {
-			return err
+			return true, err
 		}
 
 		if kind == dictEnd {
-			return errExpectedItemsArray
+			return true, errExpectedItemsArray
 		}
 
 		// found items array -> continue
@@ -58,15 +65,24 @@ func bulkReadToItems(reader *jsonReader) error {
 			break
 		}
 
+		if bytes.Equal(name, nameErrors) {
+			kind, _, err := reader.step()
+			if err != nil {
+				return true, err
+			}
+			hasErrors = kind != falseValue
+			continue
+		}
+
 		reader.ignoreNext()
 	}
 
 	// check items field is an array
 	if err := reader.ExpectArray(); err != nil {
-		return errExpectedItemsArray
+		return true, errExpectedItemsArray
 	}
 
-	return nil
+	return hasErrors, nil
 }
 
 // bulkReadItemStatus reads the status and error fields from the bulk item
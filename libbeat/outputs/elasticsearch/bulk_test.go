@@ -39,8 +39,9 @@ func TestBulkReadToItems(t *testing.T) {
 
 	reader := newJSONReader(response)
 
-	err := bulkReadToItems(reader)
+	hasErrors, err := bulkReadToItems(reader)
 	assert.NoError(t, err)
+	assert.False(t, hasErrors)
 
 	for status := 200; status <= 400; status += 100 {
 		err = reader.ExpectDict()
@@ -55,6 +55,17 @@ type Client struct {
 	observer           outputs.Observer
 	NonIndexableAction string
 
+	// bulkParams are the parameters added to bulk requests.
+	bulkParams map[string]string
+
+	// dataStreams routes events to the data stream named after their
+	// data_stream fields, nil if routing is disabled.
+	dataStreams *dataStreamRouter
+
+	// bulkSizer adapts the size of bulk requests, nil if adaptive sizing
+	// is disabled.
+	bulkSizer *bulkSizer
+
 	log *logp.Logger
 }
 
@@ -65,6 +76,19 @@ type ClientSettings struct {
 	Pipeline           *outil.Selector
 	Observer           outputs.Observer
 	NonIndexableAction string
+
+	// BulkResponseFiltering reduces the size of bulk responses to the
+	// fields needed to check the status of the items.
+	BulkResponseFiltering bool
+
+	// DataStreamRouting configures the routing of events to the data stream
+	// named after their data_stream fields.
+	DataStreamRouting dataStreamRoutingConfig
+
+	// AdaptiveBulk configures the adaptive sizing of bulk requests, bounded
+	// by BulkMaxSize.
+	AdaptiveBulk adaptiveBulkConfig
+	BulkMaxSize  int
 }
 
 type bulkResultStats struct {
@@ -138,9 +162,14 @@ func NewClient(
 		pipeline:           pipeline,
 		observer:           s.Observer,
 		NonIndexableAction: s.NonIndexableAction,
+		dataStreams:        newDataStreamRouter(s.DataStreamRouting),
+		bulkSizer:          newBulkSizer(s.AdaptiveBulk, s.BulkMaxSize),
 
 		log: logp.NewLogger("elasticsearch"),
 	}
+	if s.BulkResponseFiltering {
+		client.bulkParams = map[string]string{"filter_path": bulkResponseFilterPath}
+	}
 
 	return client, nil
 }
@@ -174,10 +203,12 @@ func (client *Client) Clone() *Client {
 
 	c, _ := NewClient(
 		ClientSettings{
-			ConnectionSettings: connection,
-			Index:              client.index,
-			Pipeline:           client.pipeline,
-			NonIndexableAction: client.NonIndexableAction,
+			ConnectionSettings:    connection,
+			Index:                 client.index,
+			Pipeline:              client.pipeline,
+			NonIndexableAction:    client.NonIndexableAction,
+			BulkResponseFiltering: client.bulkParams != nil,
+			DataStreamRouting:     client.dataStreams.config(),
 		},
 		nil, // XXX: do not pass connection callback?
 	)
@@ -186,7 +217,14 @@ func (client *Client) Clone() *Client {
 
 func (client *Client) Publish(ctx context.Context, batch publisher.Batch) error {
 	events := batch.Events()
-	rest, err := client.publishEvents(ctx, events)
+
+	var rest []publisher.Event
+	var err error
+	if client.bulkSizer != nil {
+		rest, err = client.publishAdaptive(ctx, events)
+	} else {
+		rest, err = client.publishEvents(ctx, events)
+	}
 
 	switch {
 	case err == errPayloadTooLarge:
@@ -230,9 +268,10 @@ func (client *Client) publishEvents(ctx context.Context, data []publisher.Event)
 		return nil, nil
 	}
 
-	status, result, sendErr := client.conn.Bulk(ctx, "", "", nil, bulkItems)
+	status, result, sendErr := client.conn.Bulk(ctx, "", "", client.bulkParams, bulkItems)
 
 	if sendErr != nil {
+		client.bulkSizer.onResponse(len(data), status, bulkResultStats{})
 		if status == http.StatusRequestEntityTooLarge {
 			sendErr = errPayloadTooLarge
 		}
@@ -257,6 +296,7 @@ func (client *Client) publishEvents(ctx context.Context, data []publisher.Event)
 	} else {
 		failedEvents, stats = client.bulkCollectPublishFails(result, data)
 	}
+	client.bulkSizer.onResponse(len(data), status, stats)
 
 	failed := len(failedEvents)
 	span.Context.SetLabel("events_failed", failed)
@@ -316,14 +356,24 @@ func (client *Client) createEventBulkMeta(version version.V, event *beat.Event)
 		return nil, err
 	}
 
-	index, err := client.index.Select(event)
-	if err != nil {
-		err := fmt.Errorf("failed to select event index: %v", err)
-		return nil, err
+	var index string
+	routed := client.dataStreams.routes(event)
+	if routed {
+		index = client.dataStreams.route(event)
+	} else {
+		index, err = client.index.Select(event)
+		if err != nil {
+			err := fmt.Errorf("failed to select event index: %v", err)
+			return nil, err
+		}
 	}
 
 	id, _ := events.GetMetaStringValue(*event, events.FieldMetaID)
 	opType := events.GetOpType(*event)
+	if routed {
+		// data streams only accept the create op type
+		opType = events.OpTypeCreate
+	}
 
 	meta := eslegclient.BulkMeta{
 		Index:    index,
@@ -339,7 +389,7 @@ func (client *Client) createEventBulkMeta(version version.V, event *beat.Event)
 			return nil, fmt.Errorf("%s %s requires _id", events.FieldMetaOpType, events.OpTypeDelete)
 		}
 	}
-	if id != "" || version.Major > 7 || (version.Major == 7 && version.Minor >= 5) {
+	if routed || id != "" || version.Major > 7 || (version.Major == 7 && version.Minor >= 5) {
 		if opType == events.OpTypeIndex {
 			return eslegclient.BulkIndexAction{Index: meta}, nil
 		}
@@ -373,10 +423,15 @@ func (client *Client) getPipeline(event *beat.Event) (string, error) {
 // the event will be dropped.
 func (client *Client) bulkCollectPublishFails(result eslegclient.BulkResult, data []publisher.Event) ([]publisher.Event, bulkResultStats) {
 	reader := newJSONReader(result)
-	if err := bulkReadToItems(reader); err != nil {
+	hasErrors, err := bulkReadToItems(reader)
+	if err != nil {
 		client.log.Errorf("failed to parse bulk response: %v", err.Error())
 		return nil, bulkResultStats{}
 	}
+	if !hasErrors {
+		// all items succeeded, there is no need to read their status
+		return nil, bulkResultStats{acked: len(data)}
+	}
 
 	count := len(data)
 	failed := data[:0]
@@ -196,6 +196,54 @@ func TestCollectPublishFailMiddle(t *testing.T) {
 	assert.Equal(t, bulkResultStats{acked: 2, fails: 1, tooMany: 1}, stats)
 }
 
+func TestCollectPublishFailFilteredResponse(t *testing.T) {
+	client, err := NewClient(
+		ClientSettings{
+			NonIndexableAction: "drop",
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+
+	// response filtered with bulkResponseFilterPath
+	response := []byte(`
+    {"errors": true, "items": [
+      {"create": {"status": 201}},
+      {"create": {"status": 429, "error": {"type": "es_rejected_execution_exception"}}},
+      {"create": {"status": 400, "error": {"type": "mapper_parsing_exception"}}}
+    ]}
+  `)
+
+	event := publisher.Event{Content: beat.Event{Fields: mapstr.M{"field": 1}}}
+	eventFail := publisher.Event{Content: beat.Event{Fields: mapstr.M{"field": 2}}}
+	eventDrop := publisher.Event{Content: beat.Event{Fields: mapstr.M{"field": 3}}}
+	events := []publisher.Event{event, eventFail, eventDrop}
+
+	res, stats := client.bulkCollectPublishFails(response, events)
+	assert.Equal(t, []publisher.Event{eventFail}, res)
+	assert.Equal(t, bulkResultStats{acked: 1, fails: 1, tooMany: 1, nonIndexable: 1}, stats)
+}
+
+func TestCollectPublishFailNoErrors(t *testing.T) {
+	client, err := NewClient(
+		ClientSettings{
+			NonIndexableAction: "drop",
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+
+	// the items are not read when the response reports no errors
+	response := []byte(`{"took": 3, "errors": false, "items": [{"create": {"status": 201}}]}`)
+
+	event := publisher.Event{Content: beat.Event{Fields: mapstr.M{"field": 1}}}
+	events := []publisher.Event{event, event, event}
+
+	res, stats := client.bulkCollectPublishFails(response, events)
+	assert.Empty(t, res)
+	assert.Equal(t, bulkResultStats{acked: 3}, stats)
+}
+
 func TestCollectPublishFailDeadLetterQueue(t *testing.T) {
 	client, err := NewClient(
 		ClientSettings{
@@ -507,6 +555,49 @@ func TestClientWithHeaders(t *testing.T) {
 	assert.Equal(t, 2, requestCount)
 }
 
+func TestClientWithBulkResponseFiltering(t *testing.T) {
+	for _, filtering := range []bool{false, true} {
+		t.Run(fmt.Sprintf("filtering=%v", filtering), func(t *testing.T) {
+			var filterPath string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var response string
+				if r.URL.Path == "/" {
+					response = `{ "version": { "number": "7.6.0" } }`
+				} else {
+					filterPath = r.URL.Query().Get("filter_path")
+					response = `{"errors":false,"items":[{"index":{"status":201}}]}`
+				}
+				fmt.Fprintln(w, response)
+			}))
+			defer ts.Close()
+
+			client, err := NewClient(ClientSettings{
+				ConnectionSettings: eslegclient.ConnectionSettings{
+					URL: ts.URL,
+				},
+				Index:                 outil.MakeSelector(outil.ConstSelectorExpr("test", outil.SelectorLowerCase)),
+				BulkResponseFiltering: filtering,
+			}, nil)
+			require.NoError(t, err)
+			require.NoError(t, client.Connect())
+
+			event := beat.Event{Fields: mapstr.M{
+				"@timestamp": common.Time(time.Now()),
+				"message":    "Test message from libbeat",
+			}}
+			batch := outest.NewBatch(event, event)
+			require.NoError(t, client.Publish(context.Background(), batch))
+
+			if filtering {
+				assert.Equal(t, bulkResponseFilterPath, filterPath)
+			} else {
+				assert.Empty(t, filterPath)
+			}
+			assert.Equal(t, filtering, client.Clone().bulkParams != nil)
+		})
+	}
+}
+
 func TestBulkEncodeEvents(t *testing.T) {
 	cases := map[string]struct {
 		version string
@@ -44,6 +44,10 @@ type elasticsearchConfig struct {
 	NonIndexablePolicy *config.Namespace `config:"non_indexable_policy"`
 	AllowOlderVersion  bool              `config:"allow_older_versions"`
 
+	BulkResponseFiltering bool                    `config:"bulk_response_filtering"`
+	DataStreamRouting     dataStreamRoutingConfig `config:"data_stream_routing"`
+	AdaptiveBulk          adaptiveBulkConfig      `config:"adaptive_bulk"`
+
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 }
 
@@ -73,7 +77,9 @@ var (
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
 		},
-		Transport: httpcommon.DefaultHTTPTransportSettings(),
+		DataStreamRouting: defaultDataStreamRoutingConfig(),
+		AdaptiveBulk:      defaultAdaptiveBulkConfig(),
+		Transport:         httpcommon.DefaultHTTPTransportSettings(),
 	}
 )
 
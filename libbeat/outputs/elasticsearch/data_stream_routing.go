@@ -0,0 +1,175 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+)
+
+const (
+	dataStreamTypeField      = "data_stream.type"
+	dataStreamDatasetField   = "data_stream.dataset"
+	dataStreamNamespaceField = "data_stream.namespace"
+
+	// maxDataStreamPartLength is the maximum length of the dataset and
+	// namespace of a data stream name.
+	maxDataStreamPartLength = 100
+)
+
+// invalidDataStreamChars are the characters not allowed in any part of a
+// data stream name.
+const invalidDataStreamChars = `\/*?"<>| ,#:`
+
+// dataStreamRoutingConfig configures the routing of events to the data
+// stream named after their data_stream fields.
+type dataStreamRoutingConfig struct {
+	Enabled   bool   `config:"enabled"`
+	Type      string `config:"type"`
+	Dataset   string `config:"dataset"`
+	Namespace string `config:"namespace"`
+}
+
+func defaultDataStreamRoutingConfig() dataStreamRoutingConfig {
+	return dataStreamRoutingConfig{
+		Enabled:   false,
+		Type:      "logs",
+		Dataset:   "generic",
+		Namespace: "default",
+	}
+}
+
+func (c *dataStreamRoutingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if !validDataStreamPart(c.Type) {
+		return fmt.Errorf("invalid data stream type '%s'", c.Type)
+	}
+	if !validDataStreamPart(c.Dataset) {
+		return fmt.Errorf("invalid data stream dataset '%s'", c.Dataset)
+	}
+	if !validDataStreamPart(c.Namespace) {
+		return fmt.Errorf("invalid data stream namespace '%s'", c.Namespace)
+	}
+	return nil
+}
+
+// dataStreamRouter selects the data stream of an event from its
+// data_stream.type, data_stream.dataset and data_stream.namespace fields.
+// Missing or invalid values are replaced by the configured defaults.
+type dataStreamRouter struct {
+	typ       string
+	dataset   string
+	namespace string
+}
+
+func newDataStreamRouter(config dataStreamRoutingConfig) *dataStreamRouter {
+	if !config.Enabled {
+		return nil
+	}
+	return &dataStreamRouter{
+		typ:       config.Type,
+		dataset:   config.Dataset,
+		namespace: config.Namespace,
+	}
+}
+
+// config returns the configuration the router was created from.
+func (r *dataStreamRouter) config() dataStreamRoutingConfig {
+	if r == nil {
+		return defaultDataStreamRoutingConfig()
+	}
+	return dataStreamRoutingConfig{
+		Enabled:   true,
+		Type:      r.typ,
+		Dataset:   r.dataset,
+		Namespace: r.namespace,
+	}
+}
+
+// routes reports whether the event is sent to the data stream named after its
+// fields. Events with an index set in their metadata, dead lettered events
+// and deletes are sent to the index returned by the index selector instead.
+func (r *dataStreamRouter) routes(event *beat.Event) bool {
+	if r == nil {
+		return false
+	}
+	if events.GetOpType(*event) == events.OpTypeDelete {
+		return false
+	}
+	if len(event.Meta) == 0 {
+		return true
+	}
+	if _, err := events.GetMetaStringValue(*event, events.FieldMetaIndex); err == nil {
+		return false
+	}
+	if _, err := events.GetMetaStringValue(*event, events.FieldMetaRawIndex); err == nil {
+		return false
+	}
+	if deadLettered, _ := event.Meta.HasKey(dead_letter_marker_field); deadLettered {
+		return false
+	}
+	return true
+}
+
+// route returns the name of the data stream for the event. The data_stream
+// fields of the event are updated with the values used in the name, as
+// Elasticsearch requires them to match the data stream.
+func (r *dataStreamRouter) route(event *beat.Event) string {
+	typ := r.resolve(event, dataStreamTypeField, r.typ)
+	dataset := r.resolve(event, dataStreamDatasetField, r.dataset)
+	namespace := r.resolve(event, dataStreamNamespaceField, r.namespace)
+	return typ + "-" + dataset + "-" + namespace
+}
+
+func (r *dataStreamRouter) resolve(event *beat.Event, field, fallback string) string {
+	if v, err := event.GetValue(field); err == nil {
+		if s, ok := v.(string); ok && validDataStreamPart(s) {
+			return s
+		}
+	}
+	// the fields can't be updated if data_stream is not an object, in which
+	// case the event will be rejected by Elasticsearch like any other mapping
+	// conflict.
+	_, _ = event.PutValue(field, fallback)
+	return fallback
+}
+
+// validDataStreamPart checks a part of a data stream name against the naming
+// restrictions of Elasticsearch. The '-' separator is not allowed inside the
+// parts so that the name can be split back.
+func validDataStreamPart(s string) bool {
+	if s == "" || len(s) > maxDataStreamPartLength {
+		return false
+	}
+	if s != strings.ToLower(s) {
+		return false
+	}
+	if strings.ContainsAny(s, invalidDataStreamChars+"-") {
+		return false
+	}
+	switch s[0] {
+	case '_', '+', '.':
+		return false
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	e "github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	libversion "github.com/elastic/elastic-agent-libs/version"
+)
+
+func TestDataStreamRoutingConfig(t *testing.T) {
+	cases := map[string]struct {
+		config mapstr.M
+		err    bool
+	}{
+		"disabled with invalid defaults": {
+			config: mapstr.M{"data_stream_routing.namespace": "Invalid"},
+		},
+		"enabled with defaults": {
+			config: mapstr.M{"data_stream_routing.enabled": true},
+		},
+		"enabled with custom defaults": {
+			config: mapstr.M{
+				"data_stream_routing.enabled":   true,
+				"data_stream_routing.type":      "metrics",
+				"data_stream_routing.dataset":   "system.cpu",
+				"data_stream_routing.namespace": "production",
+			},
+		},
+		"uppercase namespace": {
+			config: mapstr.M{
+				"data_stream_routing.enabled":   true,
+				"data_stream_routing.namespace": "Production",
+			},
+			err: true,
+		},
+		"dash in dataset": {
+			config: mapstr.M{
+				"data_stream_routing.enabled": true,
+				"data_stream_routing.dataset": "system-cpu",
+			},
+			err: true,
+		},
+		"empty type": {
+			config: mapstr.M{
+				"data_stream_routing.enabled": true,
+				"data_stream_routing.type":    "",
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range cases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			_, err := readConfig(conf.MustNewConfigFrom(test.config))
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDataStreamRouterRoute(t *testing.T) {
+	router := newDataStreamRouter(dataStreamRoutingConfig{
+		Enabled:   true,
+		Type:      "logs",
+		Dataset:   "generic",
+		Namespace: "default",
+	})
+
+	cases := map[string]struct {
+		fields     mapstr.M
+		dataStream string
+	}{
+		"no data_stream fields": {
+			fields:     mapstr.M{"message": "test"},
+			dataStream: "logs-generic-default",
+		},
+		"all data_stream fields": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"type":      "metrics",
+				"dataset":   "system.cpu",
+				"namespace": "production",
+			}},
+			dataStream: "metrics-system.cpu-production",
+		},
+		"dotted data_stream fields": {
+			fields: mapstr.M{
+				"data_stream.type":    "traces",
+				"data_stream.dataset": "apm",
+			},
+			dataStream: "traces-apm-default",
+		},
+		"invalid values": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"type":      "logs",
+				"dataset":   "nginx-access",
+				"namespace": "Production",
+			}},
+			dataStream: "logs-generic-default",
+		},
+		"non string values": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"dataset": 42,
+			}},
+			dataStream: "logs-generic-default",
+		},
+		"too long value": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"namespace": strings.Repeat("a", maxDataStreamPartLength+1),
+			}},
+			dataStream: "logs-generic-default",
+		},
+	}
+
+	for name, test := range cases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			event := &beat.Event{Fields: test.fields}
+			require.True(t, router.routes(event))
+			assert.Equal(t, test.dataStream, router.route(event))
+
+			// the event fields match the data stream
+			parts := strings.Split(test.dataStream, "-")
+			for i, field := range []string{dataStreamTypeField, dataStreamDatasetField, dataStreamNamespaceField} {
+				v, err := event.GetValue(field)
+				require.NoError(t, err)
+				assert.Equal(t, parts[i], v)
+			}
+		})
+	}
+}
+
+func TestDataStreamRouterRoutes(t *testing.T) {
+	router := newDataStreamRouter(dataStreamRoutingConfig{
+		Enabled:   true,
+		Type:      "logs",
+		Dataset:   "generic",
+		Namespace: "default",
+	})
+
+	cases := map[string]struct {
+		meta   mapstr.M
+		routes bool
+	}{
+		"no metadata": {
+			routes: true,
+		},
+		"id": {
+			meta:   mapstr.M{e.FieldMetaID: "1"},
+			routes: true,
+		},
+		"index": {
+			meta:   mapstr.M{e.FieldMetaIndex: "custom"},
+			routes: false,
+		},
+		"raw_index": {
+			meta:   mapstr.M{e.FieldMetaRawIndex: "custom"},
+			routes: false,
+		},
+		"dead lettered": {
+			meta:   mapstr.M{dead_letter_marker_field: true},
+			routes: false,
+		},
+		"delete": {
+			meta:   mapstr.M{e.FieldMetaID: "1", e.FieldMetaOpType: e.OpTypeDelete},
+			routes: false,
+		},
+	}
+
+	for name, test := range cases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			event := &beat.Event{Meta: test.meta, Fields: mapstr.M{}}
+			assert.Equal(t, test.routes, router.routes(event))
+		})
+	}
+
+	var disabled *dataStreamRouter
+	assert.False(t, disabled.routes(&beat.Event{Fields: mapstr.M{}}))
+}
+
+func TestBulkEncodeEventsWithDataStreamRouting(t *testing.T) {
+	client, err := NewClient(
+		ClientSettings{
+			Index: outil.MakeSelector(outil.ConstSelectorExpr("test", outil.SelectorLowerCase)),
+			DataStreamRouting: dataStreamRoutingConfig{
+				Enabled:   true,
+				Type:      "logs",
+				Dataset:   "generic",
+				Namespace: "default",
+			},
+		},
+		nil,
+	)
+	require.NoError(t, err)
+
+	data := []publisher.Event{
+		{Content: beat.Event{
+			Meta:   mapstr.M{e.FieldMetaOpType: e.OpTypeIndex},
+			Fields: mapstr.M{"data_stream": mapstr.M{"dataset": "nginx.access"}},
+		}},
+		{Content: beat.Event{
+			Meta:   mapstr.M{e.FieldMetaIndex: "custom"},
+			Fields: mapstr.M{"message": "test"},
+		}},
+	}
+
+	encoded, bulkItems := client.bulkEncodePublishRequest(*libversion.MustNew("8.0.0"), data)
+	require.Len(t, encoded, 2)
+	require.Len(t, bulkItems, 4)
+
+	// routed events are always created, whatever their op_type
+	routed, ok := bulkItems[0].(eslegclient.BulkCreateAction)
+	require.True(t, ok, "expected create action, got %T", bulkItems[0])
+	assert.Equal(t, "logs-nginx.access-default", routed.Create.Index)
+
+	custom, ok := bulkItems[2].(eslegclient.BulkCreateAction)
+	require.True(t, ok, "expected create action, got %T", bulkItems[2])
+	assert.Equal(t, "test", custom.Create.Index)
+}
@@ -106,10 +106,14 @@ func makeES(
 				EscapeHTML:       config.EscapeHTML,
 				Transport:        config.Transport,
 			},
-			Index:              index,
-			Pipeline:           pipeline,
-			Observer:           observer,
-			NonIndexableAction: policy.action(),
+			Index:                 index,
+			Pipeline:              pipeline,
+			Observer:              observer,
+			NonIndexableAction:    policy.action(),
+			BulkResponseFiltering: config.BulkResponseFiltering,
+			DataStreamRouting:     config.DataStreamRouting,
+			AdaptiveBulk:          config.AdaptiveBulk,
+			BulkMaxSize:           config.BulkMaxSize,
 		}, &connectCallbackRegistry)
 		if err != nil {
 			return outputs.Fail(err)
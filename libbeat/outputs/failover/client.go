@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// client publishes the batches of a worker to the active output, using one
+// client per configured output.
+type client struct {
+	clients   []outputs.NetworkClient
+	connected []bool
+	health    *health
+}
+
+func newClient(clients []outputs.NetworkClient, health *health) *client {
+	return &client{
+		clients:   clients,
+		connected: make([]bool, len(clients)),
+		health:    health,
+	}
+}
+
+func (c *client) Connect() error {
+	active := c.health.activeOutput()
+	if err := c.connect(active); err != nil {
+		c.health.failure(active, false)
+		return err
+	}
+	return nil
+}
+
+func (c *client) connect(output int) error {
+	if c.connected[output] {
+		return nil
+	}
+	if err := c.clients[output].Connect(); err != nil {
+		return err
+	}
+	c.connected[output] = true
+	return nil
+}
+
+func (c *client) Close() error {
+	var errs []string
+	for i, client := range c.clients {
+		c.connected[i] = false
+		if err := client.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	output, probe := c.health.next()
+
+	err := c.publish(ctx, output, batch)
+	if err != nil {
+		c.health.failure(output, probe)
+		if probe {
+			// The batch has been returned to the pipeline, and the active
+			// output can still be used.
+			return nil
+		}
+		return err
+	}
+
+	c.health.success(output, probe)
+	return nil
+}
+
+func (c *client) publish(ctx context.Context, output int, batch publisher.Batch) error {
+	if err := c.connect(output); err != nil {
+		batch.Cancelled()
+		return err
+	}
+
+	// The output clients signal the batch themselves, and are closed by their
+	// backoff wrapper when publishing fails.
+	if err := c.clients[output].Publish(ctx, batch); err != nil {
+		c.connected[output] = false
+		return err
+	}
+	return nil
+}
+
+func (c *client) Test(d testing.Driver) {
+	for i, client := range c.clients {
+		t, ok := client.(testing.Testable)
+		d.Run(fmt.Sprintf("Output %d", i), func(d testing.Driver) {
+			if !ok {
+				d.Fatal("output", errors.New("client doesn't support testing"))
+			}
+			t.Test(d)
+		})
+	}
+}
+
+func (c *client) String() string {
+	names := make([]string, len(c.clients))
+	for i, client := range c.clients {
+		names[i] = client.String()
+	}
+	return "failover(" + strings.Join(names, ",") + ")"
+}
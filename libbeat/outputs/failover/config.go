@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+type failoverConfig struct {
+	Outputs          []config.Namespace `config:"outputs" validate:"required"`
+	FailureThreshold int                `config:"failure_threshold"`
+	ProbeInterval    time.Duration      `config:"probe_interval"`
+}
+
+func defaultConfig() failoverConfig {
+	return failoverConfig{
+		FailureThreshold: 3,
+		ProbeInterval:    30 * time.Second,
+	}
+}
+
+func (c *failoverConfig) Validate() error {
+	if len(c.Outputs) < 2 {
+		return errors.New("at least two outputs must be configured")
+	}
+	for i, out := range c.Outputs {
+		if !out.IsSet() {
+			return fmt.Errorf("output %d is not configured", i)
+		}
+		if out.Name() == "failover" {
+			return fmt.Errorf("output %d: failover outputs can't be nested", i)
+		}
+	}
+	if c.FailureThreshold < 1 {
+		return errors.New("failure_threshold must be at least 1")
+	}
+	if c.ProbeInterval <= 0 {
+		return errors.New("probe_interval must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func init() {
+	outputs.RegisterType("failover", makeFailover)
+}
+
+// makeFailover creates the clients of the configured outputs, and combines
+// them into one client per worker, publishing to the active output.
+//
+// The clients of the outputs are paired by position, so the number of
+// workers is the lowest number of clients of the outputs. The batch size and
+// retries are the ones of the first output.
+func makeFailover(
+	im outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	cfgwarn.Beta("The failover output is beta.")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	groups := make([]outputs.Group, len(config.Outputs))
+	names := make([]string, len(config.Outputs))
+	workers := 0
+	for i, out := range config.Outputs {
+		group, err := outputs.Load(im, beat, observer, out.Name(), out.Config())
+		if err != nil {
+			return outputs.Fail(fmt.Errorf("failed to load output %d (%s): %w", i, out.Name(), err))
+		}
+		if len(group.Clients) == 0 {
+			return outputs.Fail(fmt.Errorf("output %d (%s) has no clients", i, out.Name()))
+		}
+		if i == 0 || len(group.Clients) < workers {
+			workers = len(group.Clients)
+		}
+		groups[i] = group
+		names[i] = out.Name()
+	}
+
+	health := newHealth(names, config.FailureThreshold, config.ProbeInterval)
+	clients := make([]outputs.Client, workers)
+	for w := range clients {
+		outputClients := make([]outputs.NetworkClient, len(groups))
+		for i, group := range groups {
+			client, ok := group.Clients[w].(outputs.NetworkClient)
+			if !ok {
+				return outputs.Fail(fmt.Errorf("output %d (%s) doesn't support failover", i, names[i]))
+			}
+			outputClients[i] = client
+		}
+		clients[w] = newClient(outputClients, health)
+	}
+
+	return outputs.Success(groups[0].BatchSize, groups[0].Retry, clients...)
+}
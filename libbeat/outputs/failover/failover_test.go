@@ -0,0 +1,285 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+type mockClient struct {
+	name       string
+	connectErr error
+	publishErr error
+
+	connects  int
+	published int
+}
+
+func (m *mockClient) Connect() error {
+	m.connects++
+	return m.connectErr
+}
+
+func (m *mockClient) Close() error { return nil }
+
+func (m *mockClient) Publish(_ context.Context, batch publisher.Batch) error {
+	if m.publishErr != nil {
+		batch.Retry()
+		return m.publishErr
+	}
+	m.published++
+	batch.ACK()
+	return nil
+}
+
+func (m *mockClient) String() string { return m.name }
+
+type mockOutputConfig struct {
+	Workers   int `config:"workers"`
+	BatchSize int `config:"batch_size"`
+}
+
+func init() {
+	outputs.RegisterType("failover_test", func(
+		_ outputs.IndexManager,
+		_ beat.Info,
+		_ outputs.Observer,
+		cfg *config.C,
+	) (outputs.Group, error) {
+		config := mockOutputConfig{Workers: 1}
+		if err := cfg.Unpack(&config); err != nil {
+			return outputs.Fail(err)
+		}
+		clients := make([]outputs.NetworkClient, config.Workers)
+		for i := range clients {
+			clients[i] = &mockClient{name: fmt.Sprintf("mock-%d", i)}
+		}
+		return outputs.SuccessNet(true, config.BatchSize, 3, clients)
+	})
+}
+
+func newTestHealth(outputs int, now *time.Time) *health {
+	names := make([]string, outputs)
+	for i := range names {
+		names[i] = fmt.Sprintf("output-%d", i)
+	}
+	h := newHealth(names, 2, time.Minute)
+	h.now = func() time.Time { return *now }
+	return h
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		err    bool
+	}{
+		"two outputs": {
+			config: map[string]interface{}{
+				"outputs": []map[string]interface{}{
+					{"failover_test": map[string]interface{}{}},
+					{"failover_test": map[string]interface{}{}},
+				},
+			},
+		},
+		"no outputs": {
+			config: map[string]interface{}{},
+			err:    true,
+		},
+		"single output": {
+			config: map[string]interface{}{
+				"outputs": []map[string]interface{}{
+					{"failover_test": map[string]interface{}{}},
+				},
+			},
+			err: true,
+		},
+		"nested failover": {
+			config: map[string]interface{}{
+				"outputs": []map[string]interface{}{
+					{"failover_test": map[string]interface{}{}},
+					{"failover": map[string]interface{}{}},
+				},
+			},
+			err: true,
+		},
+		"invalid threshold": {
+			config: map[string]interface{}{
+				"outputs": []map[string]interface{}{
+					{"failover_test": map[string]interface{}{}},
+					{"failover_test": map[string]interface{}{}},
+				},
+				"failure_threshold": 0,
+			},
+			err: true,
+		},
+		"invalid probe interval": {
+			config: map[string]interface{}{
+				"outputs": []map[string]interface{}{
+					{"failover_test": map[string]interface{}{}},
+					{"failover_test": map[string]interface{}{}},
+				},
+				"probe_interval": "-1s",
+			},
+			err: true,
+		},
+	}
+
+	for name, test := range cases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig()
+			err := config.MustNewConfigFrom(test.config).Unpack(&c)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMakeFailover(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"outputs": []map[string]interface{}{
+			{"failover_test": map[string]interface{}{"workers": 3, "batch_size": 100}},
+			{"failover_test": map[string]interface{}{"workers": 2, "batch_size": 50}},
+		},
+	})
+
+	group, err := makeFailover(nil, beat.Info{}, outputs.NewNilObserver(), cfg)
+	require.NoError(t, err)
+
+	assert.Len(t, group.Clients, 2)
+	assert.Equal(t, 100, group.BatchSize)
+	assert.Equal(t, 3, group.Retry)
+	assert.Equal(t, "failover(mock-0,mock-0)", group.Clients[0].String())
+	assert.Equal(t, "failover(mock-1,mock-1)", group.Clients[1].String())
+}
+
+func TestHealthFailover(t *testing.T) {
+	now := time.Now()
+	h := newTestHealth(3, &now)
+
+	h.failure(0, false)
+	h.success(0, false)
+	h.failure(0, false)
+	assert.Equal(t, 0, h.activeOutput(), "failures must be consecutive")
+
+	h.failure(0, false)
+	assert.Equal(t, 1, h.activeOutput())
+
+	// late results of the previous output are ignored
+	h.failure(0, false)
+	h.success(0, false)
+	assert.Equal(t, 1, h.activeOutput())
+
+	h.failure(1, false)
+	h.failure(1, false)
+	assert.Equal(t, 2, h.activeOutput())
+
+	h.failure(2, false)
+	h.failure(2, false)
+	assert.Equal(t, 0, h.activeOutput(), "the outputs are used in a cycle")
+}
+
+func TestHealthFailback(t *testing.T) {
+	now := time.Now()
+	h := newTestHealth(2, &now)
+
+	output, probe := h.next()
+	assert.Equal(t, 0, output)
+	assert.False(t, probe)
+
+	h.failure(0, false)
+	h.failure(0, false)
+
+	output, probe = h.next()
+	assert.Equal(t, 1, output)
+	assert.False(t, probe, "no probe before the probe interval")
+
+	now = now.Add(time.Minute)
+	output, probe = h.next()
+	assert.Equal(t, 0, output)
+	assert.True(t, probe)
+
+	output, probe = h.next()
+	assert.Equal(t, 1, output)
+	assert.False(t, probe, "one probe per interval")
+
+	h.failure(0, true)
+	assert.Equal(t, 1, h.activeOutput())
+
+	now = now.Add(time.Minute)
+	output, probe = h.next()
+	require.True(t, probe)
+	h.success(output, probe)
+	assert.Equal(t, 0, h.activeOutput())
+}
+
+func TestClientPublish(t *testing.T) {
+	now := time.Now()
+	h := newTestHealth(2, &now)
+	primary := &mockClient{name: "primary"}
+	standby := &mockClient{name: "standby"}
+	c := newClient([]outputs.NetworkClient{primary, standby}, h)
+
+	require.NoError(t, c.Connect())
+
+	batch := outest.NewBatch(beat.Event{})
+	require.NoError(t, c.Publish(context.Background(), batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, 1, primary.published)
+
+	primary.publishErr = errors.New("unavailable")
+	primary.connectErr = errors.New("unavailable")
+	require.Error(t, c.Publish(context.Background(), outest.NewBatch(beat.Event{})))
+	require.Error(t, c.Connect())
+	assert.Equal(t, 1, h.activeOutput())
+
+	require.NoError(t, c.Connect())
+	require.NoError(t, c.Publish(context.Background(), outest.NewBatch(beat.Event{})))
+	assert.Equal(t, 1, standby.published)
+
+	// a failed probe returns the batch without interrupting the standby output
+	now = now.Add(time.Minute)
+	batch = outest.NewBatch(beat.Event{})
+	require.NoError(t, c.Publish(context.Background(), batch))
+	assert.Equal(t, outest.BatchCancelled, batch.Signals[0].Tag)
+	assert.Equal(t, 1, h.activeOutput())
+
+	// a successful probe fails back to the primary output
+	primary.publishErr = nil
+	primary.connectErr = nil
+	now = now.Add(time.Minute)
+	require.NoError(t, c.Publish(context.Background(), outest.NewBatch(beat.Event{})))
+	assert.Equal(t, 0, h.activeOutput())
+	assert.Equal(t, 2, primary.published)
+}
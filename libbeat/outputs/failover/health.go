@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failover
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// health tracks the output the events are published to. It is shared by the
+// clients of all the workers of a failover output.
+//
+// The outputs are ordered by priority. When the active output fails
+// threshold times in a row, the next output becomes active. While an output
+// other than the first one is active, a batch is published to the first
+// output every probe interval. If it succeeds, the first output becomes
+// active again.
+type health struct {
+	log   *logp.Logger
+	names []string
+
+	threshold     int
+	probeInterval time.Duration
+
+	mu        sync.Mutex
+	active    int
+	failures  int
+	lastProbe time.Time
+
+	now func() time.Time
+}
+
+func newHealth(names []string, threshold int, probeInterval time.Duration) *health {
+	return &health{
+		log:           logp.NewLogger("failover"),
+		names:         names,
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		now:           time.Now,
+	}
+}
+
+// activeOutput returns the index of the active output.
+func (h *health) activeOutput() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.active
+}
+
+// next returns the index of the output the next batch is published to, and
+// whether the batch is probing the first output.
+func (h *health) next() (int, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.active == 0 {
+		return 0, false
+	}
+	if now := h.now(); now.Sub(h.lastProbe) >= h.probeInterval {
+		h.lastProbe = now
+		return 0, true
+	}
+	return h.active, false
+}
+
+// success records a successful publish to an output.
+func (h *health) success(output int, probe bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case probe && h.active != 0:
+		h.log.Infof("Output %d (%s) is healthy again, failing back from output %d (%s)",
+			output, h.names[output], h.active, h.names[h.active])
+		h.active = output
+		h.failures = 0
+	case output == h.active:
+		h.failures = 0
+	}
+}
+
+// failure records a failed connection or publish to an output. Failures of
+// probes and of outputs that are not active anymore are ignored.
+func (h *health) failure(output int, probe bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if probe {
+		h.log.Debugf("Output %d (%s) is still unhealthy", output, h.names[output])
+		return
+	}
+	if output != h.active {
+		return
+	}
+
+	h.failures++
+	if h.failures < h.threshold {
+		return
+	}
+
+	next := (h.active + 1) % len(h.names)
+	h.log.Warnf("Output %d (%s) failed %d times in a row, failing over to output %d (%s)",
+		h.active, h.names[h.active], h.failures, next, h.names[next])
+	h.active = next
+	h.failures = 0
+	h.lastProbe = h.now()
+}
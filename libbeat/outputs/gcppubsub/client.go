@@ -0,0 +1,258 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gcppubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/version"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+	"github.com/elastic/elastic-agent-libs/useragent"
+)
+
+type client struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	beatName string
+	index    string
+	codec    codec.Codec
+	config   pubsubConfig
+
+	mux    sync.Mutex
+	client *pubsub.Client
+	topic  *pubsub.Topic
+}
+
+func newClient(
+	observer outputs.Observer,
+	beatName string,
+	index string,
+	writer codec.Codec,
+	config pubsubConfig,
+) *client {
+	return &client{
+		log:      logp.NewLogger(logSelector),
+		observer: observer,
+		beatName: beatName,
+		index:    index,
+		codec:    writer,
+		config:   config,
+	}
+}
+
+func (c *client) Connect() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.log.Debugf("connect: project %s, topic %s", c.config.ProjectID, c.config.Topic)
+
+	client, err := c.newPubsubClient(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to create pubsub client: %w", err)
+	}
+
+	topic := client.Topic(c.config.Topic)
+	topic.EnableMessageOrdering = c.config.OrderingKey != nil
+	topic.PublishSettings.DelayThreshold = c.config.Batching.DelayThreshold
+	topic.PublishSettings.CountThreshold = c.config.Batching.CountThreshold
+	topic.PublishSettings.ByteThreshold = c.config.Batching.ByteThreshold
+	topic.PublishSettings.Timeout = c.config.Timeout
+
+	c.client = client
+	c.topic = topic
+	return nil
+}
+
+func (c *client) newPubsubClient(ctx context.Context) (*pubsub.Client, error) {
+	opts := []option.ClientOption{option.WithUserAgent(useragent.UserAgent(c.beatName, version.GetDefaultVersion(), version.Commit(), version.BuildTime().String()))}
+
+	if c.config.AlternativeHost != "" {
+		// This will be typically set because we want to point the output to a testing pubsub emulator.
+		conn, err := grpc.Dial(c.config.AlternativeHost, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to alternative host %q: %w", c.config.AlternativeHost, err)
+		}
+		opts = append(opts, option.WithGRPCConn(conn), option.WithTelemetryDisabled())
+	}
+
+	// Application Default Credentials (ADC), that include Workload Identity,
+	// are used if no credentials are configured.
+	if c.config.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(c.config.CredentialsFile))
+	} else if len(c.config.CredentialsJSON) > 0 {
+		opts = append(opts, option.WithCredentialsJSON(c.config.CredentialsJSON))
+	}
+
+	return pubsub.NewClient(ctx, c.config.ProjectID, opts...)
+}
+
+func (c *client) Close() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.log.Debug("closed pubsub client")
+
+	// client was not created before the close() was called.
+	if c.client == nil {
+		return nil
+	}
+
+	c.topic.Stop()
+	err := c.client.Close()
+	c.client = nil
+	c.topic = nil
+	return err
+}
+
+func (c *client) String() string {
+	return "gcp_pubsub(" + c.config.ProjectID + "/" + c.config.Topic + ")"
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("Pub/Sub: "+c.config.ProjectID+"/"+c.config.Topic, func(d testing.Driver) {
+		client, err := c.newPubsubClient(context.Background())
+		d.Fatal("create client", err)
+		defer client.Close()
+
+		exists, err := client.Topic(c.config.Topic).Exists(context.Background())
+		d.Fatal("check topic", err)
+		if !exists {
+			d.Fatal("check topic", errors.New("topic does not exist"))
+		}
+	})
+}
+
+type pendingMessage struct {
+	event       publisher.Event
+	orderingKey string
+	result      *pubsub.PublishResult
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	pending := make([]pendingMessage, 0, len(events))
+	for i := range events {
+		msg, err := c.getEventMessage(&events[i].Content)
+		if err != nil {
+			c.log.Errorf("Dropping event: %+v", err)
+			c.observer.Dropped(1)
+			continue
+		}
+
+		pending = append(pending, pendingMessage{
+			event:       events[i],
+			orderingKey: msg.OrderingKey,
+			result:      c.topic.Publish(ctx, msg),
+		})
+		c.observer.WriteBytes(len(msg.Data))
+	}
+
+	var (
+		failed   []publisher.Event
+		firstErr error
+		dropped  int
+	)
+	for _, p := range pending {
+		_, err := p.result.Get(ctx)
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, pubsub.ErrOversizedMessage) {
+			c.log.Errorf("Dropping too large message: %v", err)
+			dropped++
+			continue
+		}
+
+		if p.orderingKey != "" {
+			// Publishing is paused for the ordering key after an error,
+			// resume it so the events can be retried.
+			c.topic.ResumePublish(p.orderingKey)
+		}
+		failed = append(failed, p.event)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	c.observer.Dropped(dropped)
+	c.observer.Acked(len(pending) - len(failed) - dropped)
+	if len(failed) > 0 {
+		c.observer.WriteError(firstErr)
+		c.observer.Failed(len(failed))
+		batch.RetryEvents(failed)
+		return fmt.Errorf("failed to publish %d messages: %w", len(failed), firstErr)
+	}
+
+	batch.ACK()
+	return nil
+}
+
+func (c *client) getEventMessage(event *beat.Event) (*pubsub.Message, error) {
+	serializedEvent, err := c.codec.Encode(c.index, event)
+	if err != nil {
+		if c.log.IsDebug() {
+			c.log.Debugf("failed event: %v", event)
+		}
+		return nil, err
+	}
+
+	buf := make([]byte, len(serializedEvent))
+	copy(buf, serializedEvent)
+	msg := &pubsub.Message{Data: buf}
+
+	if c.config.OrderingKey != nil {
+		msg.OrderingKey = formatOrEmpty(c.config.OrderingKey, event)
+	}
+
+	if len(c.config.Attributes) > 0 {
+		msg.Attributes = make(map[string]string, len(c.config.Attributes))
+		for name, format := range c.config.Attributes {
+			if value := formatOrEmpty(format, event); value != "" {
+				msg.Attributes[name] = value
+			}
+		}
+	}
+
+	return msg, nil
+}
+
+// formatOrEmpty formats the string for the event, or returns an empty string
+// if any of the fields it uses is missing.
+func formatOrEmpty(format *fmtstr.EventFormatString, event *beat.Event) string {
+	value, err := format.Run(event)
+	if err != nil {
+		return ""
+	}
+	return value
+}
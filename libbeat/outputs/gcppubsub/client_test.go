@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gcppubsub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	testProjectID = "test-project"
+	testTopic     = "test-topic"
+)
+
+func testServer(t *testing.T, createTopic bool) *pstest.Server {
+	t.Helper()
+
+	srv := pstest.NewServer()
+	t.Cleanup(func() { srv.Close() })
+
+	if createTopic {
+		conn, err := grpc.Dial(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		client, err := pubsub.NewClient(context.Background(), testProjectID, option.WithGRPCConn(conn))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.CreateTopic(context.Background(), testTopic)
+		require.NoError(t, err)
+	}
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *pstest.Server, configure func(*pubsubConfig)) *client {
+	t.Helper()
+
+	config := defaultConfig()
+	config.ProjectID = testProjectID
+	config.Topic = testTopic
+	config.AlternativeHost = srv.Addr
+	config.Batching.DelayThreshold = time.Millisecond
+	if configure != nil {
+		configure(&config)
+	}
+
+	c := newClient(outputs.NewNilObserver(), "testbeat", "test", json.New("1.2.3", json.Config{}), config)
+	require.NoError(t, c.Connect())
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func testBatch(n int) *outest.Batch {
+	events := make([]beat.Event, n)
+	for i := range events {
+		events[i] = beat.Event{
+			Timestamp: time.Now(),
+			Fields: mapstr.M{
+				"message": fmt.Sprintf("event %d", i),
+				"host":    mapstr.M{"name": fmt.Sprintf("host-%d", i%2)},
+			},
+		}
+	}
+	return outest.NewBatch(events...)
+}
+
+func TestPublish(t *testing.T) {
+	srv := testServer(t, true)
+	c := newTestClient(t, srv, nil)
+
+	batch := testBatch(10)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	msgs := srv.Messages()
+	require.Len(t, msgs, 10)
+	for _, msg := range msgs {
+		assert.Contains(t, string(msg.Data), `"message":"event `)
+		assert.Empty(t, msg.OrderingKey)
+		assert.Empty(t, msg.Attributes)
+	}
+}
+
+func TestPublishOrderingKeyAndAttributes(t *testing.T) {
+	srv := testServer(t, true)
+	c := newTestClient(t, srv, func(config *pubsubConfig) {
+		config.OrderingKey = fmtstr.MustCompileEvent("%{[host.name]}")
+		config.Attributes = map[string]*fmtstr.EventFormatString{
+			"host":    fmtstr.MustCompileEvent("%{[host.name]}"),
+			"missing": fmtstr.MustCompileEvent("%{[not.exists]}"),
+		}
+	})
+
+	batch := testBatch(4)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	msgs := srv.Messages()
+	require.Len(t, msgs, 4)
+	for _, msg := range msgs {
+		assert.Contains(t, []string{"host-0", "host-1"}, msg.OrderingKey)
+		assert.Equal(t, map[string]string{"host": msg.OrderingKey}, msg.Attributes)
+	}
+}
+
+func TestPublishFailed(t *testing.T) {
+	// Publishing fails because the topic doesn't exist.
+	srv := testServer(t, false)
+	c := newTestClient(t, srv, func(config *pubsubConfig) {
+		config.OrderingKey = fmtstr.MustCompileEvent("%{[host.name]}")
+	})
+
+	batch := testBatch(3)
+	err := c.Publish(context.Background(), batch)
+	assert.Error(t, err)
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 3)
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gcppubsub
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+)
+
+type backoffConfig struct {
+	Init time.Duration `config:"init"`
+	Max  time.Duration `config:"max"`
+}
+
+type batchingConfig struct {
+	DelayThreshold time.Duration `config:"delay_threshold" validate:"min=0"`
+	CountThreshold int           `config:"count_threshold" validate:"min=1"`
+	ByteThreshold  int           `config:"byte_threshold"  validate:"min=1"`
+}
+
+type pubsubConfig struct {
+	// Google Cloud project name.
+	ProjectID string `config:"project_id" validate:"required"`
+
+	// Google Cloud Pub/Sub topic name.
+	Topic string `config:"topic" validate:"required"`
+
+	// OrderingKey is formatted for each event, messages with the same
+	// ordering key are delivered in order.
+	OrderingKey *fmtstr.EventFormatString `config:"ordering_key"`
+
+	// Attributes are formatted for each event and added to its message.
+	Attributes map[string]*fmtstr.EventFormatString `config:"attributes"`
+
+	// JSON file containing authentication credentials and key.
+	CredentialsFile string `config:"credentials_file"`
+
+	// JSON blob containing authentication credentials and key.
+	CredentialsJSON common.JSONBlob `config:"credentials_json"`
+
+	// Overrides the default Pub/Sub service address and disables TLS. For testing.
+	AlternativeHost string `config:"alternative_host"`
+
+	Batching    batchingConfig `config:"batching"`
+	BulkMaxSize int            `config:"bulk_max_size"`
+	MaxRetries  int            `config:"max_retries"   validate:"min=-1"`
+	Backoff     backoffConfig  `config:"backoff"`
+	Timeout     time.Duration  `config:"timeout"       validate:"min=1"`
+	Codec       codec.Config   `config:"codec"`
+}
+
+func defaultConfig() pubsubConfig {
+	return pubsubConfig{
+		Batching: batchingConfig{
+			DelayThreshold: pubsub.DefaultPublishSettings.DelayThreshold,
+			CountThreshold: pubsub.DefaultPublishSettings.CountThreshold,
+			ByteThreshold:  pubsub.DefaultPublishSettings.ByteThreshold,
+		},
+		BulkMaxSize: 2048,
+		MaxRetries:  3,
+		Backoff: backoffConfig{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+		Timeout: 60 * time.Second,
+	}
+}
+
+func (c *pubsubConfig) Validate() error {
+	if c.CredentialsFile != "" {
+		if _, err := os.Stat(c.CredentialsFile); os.IsNotExist(err) {
+			return fmt.Errorf("credentials_file is configured, but the file %q cannot be found", c.CredentialsFile)
+		}
+	}
+	if c.Batching.CountThreshold > pubsub.MaxPublishRequestCount {
+		return fmt.Errorf("batching.count_threshold can not be bigger than %d", pubsub.MaxPublishRequestCount)
+	}
+	if c.Batching.ByteThreshold > pubsub.MaxPublishRequestBytes {
+		return fmt.Errorf("batching.byte_threshold can not be bigger than %d", int(pubsub.MaxPublishRequestBytes))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gcppubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"project and topic": {
+			config: map[string]interface{}{"project_id": "project", "topic": "topic"},
+			valid:  true,
+		},
+		"missing topic": {
+			config: map[string]interface{}{"project_id": "project"},
+			valid:  false,
+		},
+		"missing credentials file": {
+			config: map[string]interface{}{"project_id": "project", "topic": "topic", "credentials_file": "notexists.json"},
+			valid:  false,
+		},
+		"too many messages per request": {
+			config: map[string]interface{}{"project_id": "project", "topic": "topic", "batching.count_threshold": 2000},
+			valid:  false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(c.config)
+			pubsubConfig := defaultConfig()
+			err := cfg.Unpack(&pubsubConfig)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
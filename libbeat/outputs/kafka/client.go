@@ -65,6 +65,12 @@ type msgRef struct {
 	failed []publisher.Event
 	batch  publisher.Batch
 
+	// sent and produced are only used by transactional producers, sent
+	// holds the events handed to the producer, and produced is closed once
+	// all of them have been acknowledged or have failed.
+	sent     []publisher.Event
+	produced chan struct{}
+
 	err error
 }
 
@@ -119,6 +125,14 @@ func (c *client) Connect() error {
 
 	c.log.Debugf("connect: %v", c.hosts)
 
+	if c.producer != nil {
+		// the previous producer failed in a transaction and can't be used
+		// anymore.
+		c.producer.AsyncClose()
+		c.wg.Wait()
+		c.producer = nil
+	}
+
 	// try to connect
 	producer, err := sarama.NewAsyncProducer(c.hosts, &c.config)
 	if err != nil {
@@ -164,6 +178,16 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		batch:  batch,
 	}
 
+	if !c.producer.IsTransactional() {
+		c.produce(events, ref)
+		return nil
+	}
+	return c.publishTxn(events, ref)
+}
+
+// produce hands the events to the producer, the result of the batch is
+// reported once all the messages are acknowledged or have failed.
+func (c *client) produce(events []publisher.Event, ref *msgRef) {
 	ch := c.producer.Input()
 	for i := range events {
 		d := &events[i]
@@ -175,11 +199,48 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 			continue
 		}
 
+		if ref.produced != nil {
+			ref.sent = append(ref.sent, *d)
+		}
 		msg.ref = ref
 		msg.initProducerMessage()
 		ch <- &msg.msg
 	}
+}
+
+// publishTxn produces the events of a batch in a transaction. The batch is
+// acknowledged once the transaction is committed. If any of the messages
+// fails, the transaction is aborted and all of them are retried, because
+// consumers reading committed messages don't see any message of an aborted
+// transaction.
+func (c *client) publishTxn(events []publisher.Event, ref *msgRef) error {
+	ref.produced = make(chan struct{})
+	if err := c.producer.BeginTxn(); err != nil {
+		ref.batch.Retry()
+		c.observer.Failed(len(events))
+		return fmt.Errorf("failed to begin kafka transaction: %w", err)
+	}
+
+	c.produce(events, ref)
+	<-ref.produced
+
+	if ref.err == nil {
+		err := c.producer.CommitTxn()
+		if err == nil {
+			ref.report()
+			return nil
+		}
+		ref.err = fmt.Errorf("failed to commit kafka transaction: %w", err)
+	}
 
+	ref.failed = ref.sent
+	abortErr := c.producer.AbortTxn()
+	ref.report()
+	if abortErr != nil {
+		// the producer is in a fatal state, a new producer is created on
+		// reconnection.
+		return fmt.Errorf("failed to abort kafka transaction: %w", abortErr)
+	}
 	return nil
 }
 
@@ -389,6 +450,17 @@ func (r *msgRef) dec() {
 		return
 	}
 
+	if r.produced != nil {
+		// the client reports the result of the batch once its transaction
+		// is committed or aborted.
+		close(r.produced)
+		return
+	}
+	r.report()
+}
+
+// report acknowledges the batch, or retries its failed events.
+func (r *msgRef) report() {
 	r.client.log.Debug("finished kafka batch")
 	stats := r.client.observer
 
@@ -411,7 +483,7 @@ func (r *msgRef) dec() {
 }
 
 func (c *client) Test(d testing.Driver) {
-	if c.config.Net.TLS.Enable == true {
+	if c.config.Net.Proxy.Enable {
 		d.Warn("TLS", "Kafka output doesn't support TLS testing")
 	}
 
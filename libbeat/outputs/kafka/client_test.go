@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// newTransactionalClient returns a client producing to a mock transactional
+// producer.
+func newTransactionalClient(t *testing.T) (*client, *mocks.AsyncProducer) {
+	t.Helper()
+
+	cfg, err := readConfig(config.MustNewConfigFrom(mapstr.M{
+		"hosts":            []string{"localhost:9092"},
+		"transactional_id": "beats-test",
+	}))
+	require.NoError(t, err)
+	libCfg, err := newSaramaConfig(logp.L(), cfg)
+	require.NoError(t, err)
+
+	topic := outil.MakeSelector(outil.ConstSelectorExpr("test", outil.SelectorKeepCase))
+	c, err := newKafkaClient(outputs.NewNilObserver(), cfg.Hosts, "testbeat", nil, topic, nil, json.New("1.2.3", json.Config{}), libCfg)
+	require.NoError(t, err)
+
+	producer := mocks.NewAsyncProducer(t, libCfg)
+	c.producer = producer
+	c.wg.Add(2)
+	go c.successWorker(producer.Successes())
+	go c.errorWorker(producer.Errors())
+	t.Cleanup(func() { c.Close() })
+	return c, producer
+}
+
+func testBatch(n int) *outest.Batch {
+	events := make([]beat.Event, n)
+	for i := range events {
+		events[i] = beat.Event{Timestamp: time.Now(), Fields: mapstr.M{"n": i}}
+	}
+	return outest.NewBatch(events...)
+}
+
+func TestPublishTransactionCommitted(t *testing.T) {
+	c, producer := newTransactionalClient(t)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndSucceed()
+
+	batch := testBatch(2)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, sarama.ProducerTxnFlagReady, producer.TxnStatus())
+}
+
+func TestPublishTransactionAborted(t *testing.T) {
+	c, producer := newTransactionalClient(t)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(sarama.ErrNotLeaderForPartition)
+	producer.ExpectInputAndSucceed()
+
+	batch := testBatch(3)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	// The messages acknowledged before the abort are retried too, they
+	// aren't visible to consumers reading committed messages.
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 3)
+	assert.Equal(t, sarama.ProducerTxnFlagReady, producer.TxnStatus())
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/common/kafka"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlsreload"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -76,6 +78,8 @@ type kafkaConfig struct {
 	Codec              codec.Config              `config:"codec"`
 	Sasl               kafka.SaslConfig          `config:"sasl"`
 	EnableFAST         bool                      `config:"enable_krb5_fast"`
+	Idempotent         bool                      `config:"idempotent"`
+	TransactionalID    string                    `config:"transactional_id"`
 }
 
 type metaConfig struct {
@@ -174,6 +178,18 @@ func (c *kafkaConfig) Validate() error {
 			return fmt.Errorf("compression_level must be between 0 and 9")
 		}
 	}
+
+	if c.Idempotent || c.TransactionalID != "" {
+		// The idempotent producer needs the acknowledgement of all the in-sync
+		// replicas to guarantee that retries don't introduce duplicates.
+		// Transactions are always produced by an idempotent producer.
+		if c.RequiredACKs != nil && sarama.RequiredAcks(*c.RequiredACKs) != sarama.WaitForAll {
+			return errors.New("idempotent and transactional_id require required_acks to be -1")
+		}
+		if version, ok := c.Version.Get(); ok && !version.IsAtLeast(sarama.V0_11_0_0) {
+			return errors.New("idempotent and transactional_id require version 0.11 or newer")
+		}
+	}
 	return nil
 }
 
@@ -194,14 +210,17 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 	k.Producer.Timeout = config.BrokerTimeout
 	k.Producer.CompressionLevel = config.CompressionLevel
 
-	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	tls, err := tlsreload.LoadTLSConfig(config.TLS)
 	if err != nil {
 		return nil, err
 	}
 
 	if tls != nil {
-		k.Net.TLS.Enable = true
-		k.Net.TLS.Config = tls.BuildModuleClientConfig("")
+		// TLS connections are established by our own dialer rather than by
+		// sarama, so the certificates are reloaded when their files change.
+		netDialer := &net.Dialer{Timeout: timeout, KeepAlive: config.KeepAlive}
+		k.Net.Proxy.Enable = true
+		k.Net.Proxy.Dialer = tls.Dialer(netDialer, timeout)
 	}
 
 	switch {
@@ -228,11 +247,19 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 			DisablePAFXFAST:    !enableFAST,
 		}
 
+	case config.Sasl.IsOAuthBearer():
+		k.Net.SASL.Enable = true
+		if err := config.Sasl.ConfigureSarama(k); err != nil {
+			return nil, err
+		}
+
 	case config.Username != "":
 		k.Net.SASL.Enable = true
 		k.Net.SASL.User = config.Username
 		k.Net.SASL.Password = config.Password
-		config.Sasl.ConfigureSarama(k)
+		if err := config.Sasl.ConfigureSarama(k); err != nil {
+			return nil, err
+		}
 	}
 
 	// configure metadata update properties
@@ -269,6 +296,21 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 	// configure per broker go channel buffering
 	k.ChannelBufferSize = config.ChanBufferSize
 
+	if config.Idempotent || config.TransactionalID != "" {
+		// messages are written once per producer session, the broker discards
+		// duplicates caused by retries with the sequence numbers of the
+		// messages, this requires a single request in flight per broker to
+		// keep their order.
+		k.Producer.Idempotent = true
+		k.Producer.RequiredAcks = sarama.WaitForAll
+		k.Net.MaxOpenRequests = 1
+	}
+	if config.TransactionalID != "" {
+		// the transactional ID is kept across restarts, so the broker fences
+		// the previous producer and aborts its pending transaction.
+		k.Producer.Transaction.ID = config.TransactionalID
+	}
+
 	// configure bulk size
 	k.Producer.Flush.MaxMessages = config.BulkMaxSize
 	if config.BulkFlushFrequency > 0 {
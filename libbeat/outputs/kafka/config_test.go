@@ -23,6 +23,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/internal/testutil"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -61,6 +63,31 @@ func TestConfigAcceptValid(t *testing.T) {
 				"realm":        "ELASTIC",
 			},
 		},
+		"SCRAM-SHA-512": mapstr.M{
+			"username": "elastic",
+			"password": "changeme",
+			"sasl": mapstr.M{
+				"mechanism": "SCRAM-SHA-512",
+			},
+		},
+		"OAUTHBEARER with static token": mapstr.M{
+			"sasl": mapstr.M{
+				"mechanism": "OAUTHBEARER",
+				"oauthbearer": mapstr.M{
+					"token": "secret",
+				},
+			},
+		},
+		"idempotent": mapstr.M{
+			"idempotent": true,
+		},
+		"idempotent with all acks": mapstr.M{
+			"idempotent":    true,
+			"required_acks": -1,
+		},
+		"transactional_id": mapstr.M{
+			"transactional_id": "beats",
+		},
 	}
 
 	for name, test := range tests {
@@ -89,6 +116,31 @@ func TestConfigInvalid(t *testing.T) {
 				"realm":        "ELASTIC",
 			},
 		},
+		"OAUTHBEARER without token provider": mapstr.M{
+			"sasl": mapstr.M{
+				"mechanism": "OAUTHBEARER",
+			},
+		},
+		"OAUTHBEARER with unknown token provider": mapstr.M{
+			"sasl": mapstr.M{
+				"mechanism": "OAUTHBEARER",
+				"oauthbearer": mapstr.M{
+					"provider": "unknown",
+				},
+			},
+		},
+		"idempotent with local acks": mapstr.M{
+			"idempotent":    true,
+			"required_acks": 1,
+		},
+		"idempotent with old version": mapstr.M{
+			"idempotent": true,
+			"version":    "0.10.2",
+		},
+		"transactional_id with local acks": mapstr.M{
+			"transactional_id": "beats",
+			"required_acks":    1,
+		},
 	}
 
 	for name, test := range tests {
@@ -104,6 +156,56 @@ func TestConfigInvalid(t *testing.T) {
 	}
 }
 
+func TestConfigIdempotent(t *testing.T) {
+	c := config.MustNewConfigFrom(mapstr.M{
+		"hosts":      []string{"localhost"},
+		"idempotent": true,
+	})
+	cfg, err := readConfig(c)
+	if err != nil {
+		t.Fatalf("Can not create test configuration: %v", err)
+	}
+	k, err := newSaramaConfig(logp.L(), cfg)
+	if err != nil {
+		t.Fatalf("Failure creating sarama config: %v", err)
+	}
+
+	if !k.Producer.Idempotent {
+		t.Errorf("Idempotent producer not enabled")
+	}
+	if k.Producer.RequiredAcks != sarama.WaitForAll {
+		t.Errorf("Expected required acks %v, found %v", sarama.WaitForAll, k.Producer.RequiredAcks)
+	}
+	if k.Net.MaxOpenRequests != 1 {
+		t.Errorf("Expected 1 max open requests, found %v", k.Net.MaxOpenRequests)
+	}
+	if k.Producer.Transaction.ID != "" {
+		t.Errorf("Expected no transactional ID, found %v", k.Producer.Transaction.ID)
+	}
+}
+
+func TestConfigTransactionalID(t *testing.T) {
+	c := config.MustNewConfigFrom(mapstr.M{
+		"hosts":            []string{"localhost"},
+		"transactional_id": "beats",
+	})
+	cfg, err := readConfig(c)
+	if err != nil {
+		t.Fatalf("Can not create test configuration: %v", err)
+	}
+	k, err := newSaramaConfig(logp.L(), cfg)
+	if err != nil {
+		t.Fatalf("Failure creating sarama config: %v", err)
+	}
+
+	if k.Producer.Transaction.ID != "beats" {
+		t.Errorf("Expected transactional ID beats, found %v", k.Producer.Transaction.ID)
+	}
+	if !k.Producer.Idempotent {
+		t.Errorf("Idempotent producer not enabled")
+	}
+}
+
 func TestBackoffFunc(t *testing.T) {
 	testutil.SeedPRNG(t)
 	tests := map[int]backoffConfig{
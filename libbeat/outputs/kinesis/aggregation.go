@@ -0,0 +1,280 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"math/big"
+	"sort"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/awslabs/kinesis-aggregation/go/v2/records"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// kplMagic is the header of records in the KPL aggregation format. It is
+// followed by the protobuf encoded aggregated record, and its MD5 digest.
+var kplMagic = []byte{0xf3, 0x89, 0x9a, 0xc2}
+
+// kplOverhead is the size added to aggregated records by the magic header
+// and the digest.
+const kplOverhead = 4 + md5.Size
+
+// userRecord is an encoded event and the partition key it is published with.
+type userRecord struct {
+	partitionKey string
+	data         []byte
+	event        publisher.Event
+}
+
+// record is a Kinesis record, it contains multiple user records if aggregated.
+type record struct {
+	entry  types.PutRecordsRequestEntry
+	events []publisher.Event
+}
+
+func newRecord(r userRecord) record {
+	return record{
+		entry: types.PutRecordsRequestEntry{
+			Data:         r.data,
+			PartitionKey: awssdk.String(r.partitionKey),
+		},
+		events: []publisher.Event{r.event},
+	}
+}
+
+func (r *record) size() int {
+	return len(r.entry.Data) + len(*r.entry.PartitionKey)
+}
+
+// shard is the hash key range of an open shard of the stream.
+type shard struct {
+	startingHashKey *big.Int
+	endingHashKey   *big.Int
+}
+
+// shardMap contains the open shards of a stream, sorted by their hash key ranges.
+type shardMap []shard
+
+// listShards obtains the open shards of a stream.
+func listShards(ctx context.Context, api kinesisAPI, stream string) (shardMap, error) {
+	var shards shardMap
+	input := &kinesis.ListShardsInput{StreamName: awssdk.String(stream)}
+	for {
+		output, err := api.ListShards(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range output.Shards {
+			if s.SequenceNumberRange != nil && s.SequenceNumberRange.EndingSequenceNumber != nil {
+				// Closed shard, records are not written to it anymore.
+				continue
+			}
+			if s.HashKeyRange == nil {
+				continue
+			}
+			start, ok := new(big.Int).SetString(awssdk.ToString(s.HashKeyRange.StartingHashKey), 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid starting hash key in shard %s", awssdk.ToString(s.ShardId))
+			}
+			end, ok := new(big.Int).SetString(awssdk.ToString(s.HashKeyRange.EndingHashKey), 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid ending hash key in shard %s", awssdk.ToString(s.ShardId))
+			}
+			shards = append(shards, shard{startingHashKey: start, endingHashKey: end})
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input = &kinesis.ListShardsInput{NextToken: output.NextToken}
+	}
+
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].startingHashKey.Cmp(shards[j].startingHashKey) < 0
+	})
+	return shards, nil
+}
+
+// lookup returns the index of the shard records with the given partition key
+// are written to, or -1 if there is no shard for it.
+func (m shardMap) lookup(partitionKey string) int {
+	sum := md5.Sum([]byte(partitionKey))
+	hashKey := new(big.Int).SetBytes(sum[:])
+	i := sort.Search(len(m), func(i int) bool {
+		return m[i].endingHashKey.Cmp(hashKey) >= 0
+	})
+	if i == len(m) || m[i].startingHashKey.Cmp(hashKey) > 0 {
+		return -1
+	}
+	return i
+}
+
+// aggregate groups the user records by the shard they are written to, into
+// records in the KPL aggregation format of at most maxSize bytes.
+func aggregate(userRecords []userRecord, shards shardMap, maxSize int) ([]record, error) {
+	var result []record
+	aggregators := map[int]*aggregator{}
+	var order []int
+	for _, r := range userRecords {
+		i := shards.lookup(r.partitionKey)
+		agg, found := aggregators[i]
+		if !found {
+			var explicitHashKey string
+			if i >= 0 {
+				explicitHashKey = shards[i].startingHashKey.String()
+			}
+			agg = newAggregator(explicitHashKey)
+			aggregators[i] = agg
+			order = append(order, i)
+		}
+
+		if !agg.fits(r, maxSize) {
+			aggregated, err := agg.flush()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, aggregated)
+		}
+		agg.add(r)
+	}
+
+	for _, i := range order {
+		agg := aggregators[i]
+		if agg.empty() {
+			continue
+		}
+		aggregated, err := agg.flush()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, aggregated)
+	}
+	return result, nil
+}
+
+// aggregator builds a record in the KPL aggregation format.
+type aggregator struct {
+	explicitHashKey string
+
+	aggregated    records.AggregatedRecord
+	partitionKeys map[string]uint64
+	userRecords   []userRecord
+
+	// size is the size of the encoded aggregated record.
+	size int
+}
+
+func newAggregator(explicitHashKey string) *aggregator {
+	return &aggregator{
+		explicitHashKey: explicitHashKey,
+		partitionKeys:   map[string]uint64{},
+	}
+}
+
+func (a *aggregator) empty() bool {
+	return len(a.userRecords) == 0
+}
+
+// fits checks if the user record can be added without exceeding the maximum
+// size. A user record always fits in an empty aggregator.
+func (a *aggregator) fits(r userRecord, maxSize int) bool {
+	if a.empty() {
+		return true
+	}
+	size := kplOverhead + a.size + a.sizeOf(r)
+	return size+len(a.userRecords[0].partitionKey) <= maxSize
+}
+
+// sizeOf calculates the size that adding the user record adds to the encoded
+// aggregated record.
+func (a *aggregator) sizeOf(r userRecord) int {
+	size := 0
+	index, found := a.partitionKeys[r.partitionKey]
+	if !found {
+		index = uint64(len(a.partitionKeys))
+		size += protowire.SizeTag(1) + protowire.SizeBytes(len(r.partitionKey))
+	}
+	recordSize := protowire.SizeTag(1) + protowire.SizeVarint(index) +
+		protowire.SizeTag(3) + protowire.SizeBytes(len(r.data))
+	return size + protowire.SizeTag(3) + protowire.SizeBytes(recordSize)
+}
+
+func (a *aggregator) add(r userRecord) {
+	a.size += a.sizeOf(r)
+
+	index, found := a.partitionKeys[r.partitionKey]
+	if !found {
+		index = uint64(len(a.partitionKeys))
+		a.partitionKeys[r.partitionKey] = index
+		a.aggregated.PartitionKeyTable = append(a.aggregated.PartitionKeyTable, r.partitionKey)
+	}
+	a.aggregated.Records = append(a.aggregated.Records, &records.Record{
+		PartitionKeyIndex: proto.Uint64(index),
+		Data:              r.data,
+	})
+	a.userRecords = append(a.userRecords, r)
+}
+
+// flush returns the aggregated record and resets the aggregator. A single user
+// record is returned as is, without aggregation.
+func (a *aggregator) flush() (record, error) {
+	defer a.reset()
+
+	if len(a.userRecords) == 1 {
+		return newRecord(a.userRecords[0]), nil
+	}
+
+	encoded, err := proto.Marshal(&a.aggregated)
+	if err != nil {
+		return record{}, fmt.Errorf("failed to encode aggregated record: %w", err)
+	}
+	digest := md5.Sum(encoded)
+
+	data := make([]byte, 0, len(kplMagic)+len(encoded)+len(digest))
+	data = append(data, kplMagic...)
+	data = append(data, encoded...)
+	data = append(data, digest[:]...)
+
+	events := make([]publisher.Event, len(a.userRecords))
+	for i, r := range a.userRecords {
+		events[i] = r.event
+	}
+
+	entry := types.PutRecordsRequestEntry{
+		Data:         data,
+		PartitionKey: awssdk.String(a.userRecords[0].partitionKey),
+	}
+	if a.explicitHashKey != "" {
+		entry.ExplicitHashKey = awssdk.String(a.explicitHashKey)
+	}
+	return record{entry: entry, events: events}, nil
+}
+
+func (a *aggregator) reset() {
+	a.aggregated = records.AggregatedRecord{}
+	a.partitionKeys = map[string]uint64{}
+	a.userRecords = nil
+	a.size = 0
+}
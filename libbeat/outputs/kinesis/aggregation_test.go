@@ -0,0 +1,221 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/awslabs/kinesis-aggregation/go/v2/deaggregator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// twoShards splits the hash key space in two shards.
+func twoShards() shardMap {
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	half := new(big.Int).Rsh(max, 1)
+	return shardMap{
+		{startingHashKey: big.NewInt(0), endingHashKey: half},
+		{startingHashKey: new(big.Int).Add(half, big.NewInt(1)), endingHashKey: max},
+	}
+}
+
+func testUserRecords(n int) []userRecord {
+	records := make([]userRecord, n)
+	for i := range records {
+		records[i] = userRecord{
+			partitionKey: fmt.Sprintf("key-%d", i),
+			data:         []byte(fmt.Sprintf(`{"message":"event %d"}`, i)),
+			event:        publisher.Event{},
+		}
+	}
+	return records
+}
+
+func TestShardMapLookup(t *testing.T) {
+	shards := twoShards()
+	for _, r := range testUserRecords(100) {
+		i := shards.lookup(r.partitionKey)
+		require.GreaterOrEqual(t, i, 0)
+		require.Less(t, i, len(shards))
+	}
+
+	assert.Equal(t, -1, shardMap{}.lookup("key"))
+}
+
+func TestAggregate(t *testing.T) {
+	shards := twoShards()
+	userRecords := testUserRecords(100)
+
+	records, err := aggregate(userRecords, shards, maxRecordSize)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "one aggregated record per shard expected")
+
+	var deaggregated []types.Record
+	total := 0
+	for _, r := range records {
+		require.NotNil(t, r.entry.ExplicitHashKey)
+		shardKeys := map[int]bool{}
+		result, err := deaggregator.DeaggregateRecords([]types.Record{{
+			Data:         r.entry.Data,
+			PartitionKey: r.entry.PartitionKey,
+		}})
+		require.NoError(t, err)
+		for _, ur := range result {
+			shardKeys[shards.lookup(awssdk.ToString(ur.PartitionKey))] = true
+		}
+		assert.Len(t, shardKeys, 1, "all user records must belong to the same shard")
+		assert.Len(t, result, len(r.events))
+		total += len(r.events)
+		deaggregated = append(deaggregated, result...)
+	}
+	assert.Equal(t, len(userRecords), total)
+
+	expected := map[string]string{}
+	for _, r := range userRecords {
+		expected[r.partitionKey] = string(r.data)
+	}
+	found := map[string]string{}
+	for _, r := range deaggregated {
+		found[awssdk.ToString(r.PartitionKey)] = string(r.Data)
+	}
+	assert.Equal(t, expected, found)
+}
+
+func TestAggregateMaxSize(t *testing.T) {
+	userRecords := testUserRecords(100)
+	maxSize := 512
+
+	records, err := aggregate(userRecords, nil, maxSize)
+	require.NoError(t, err)
+	require.Greater(t, len(records), 1)
+
+	total := 0
+	for _, r := range records {
+		assert.LessOrEqual(t, r.size(), maxSize)
+		total += len(r.events)
+	}
+	assert.Equal(t, len(userRecords), total)
+}
+
+func TestAggregateSingleRecord(t *testing.T) {
+	userRecords := testUserRecords(1)
+
+	records, err := aggregate(userRecords, twoShards(), maxRecordSize)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, userRecords[0].data, records[0].entry.Data, "single records must not be aggregated")
+	assert.Equal(t, userRecords[0].partitionKey, awssdk.ToString(records[0].entry.PartitionKey))
+	assert.Nil(t, records[0].entry.ExplicitHashKey)
+}
+
+func TestListShards(t *testing.T) {
+	api := &fakeKinesisAPI{
+		shards: [][]types.Shard{
+			{
+				testShard("shardId-000000000002", "170141183460469231731687303715884105728", "340282366920938463463374607431768211455", false),
+				testShard("shardId-000000000000", "0", "340282366920938463463374607431768211455", true),
+			},
+			{
+				testShard("shardId-000000000001", "0", "170141183460469231731687303715884105727", false),
+			},
+		},
+	}
+
+	shards, err := listShards(context.Background(), api, "beats")
+	require.NoError(t, err)
+	require.Len(t, shards, 2, "closed shards must be ignored")
+	assert.Equal(t, "0", shards[0].startingHashKey.String())
+	assert.Equal(t, "170141183460469231731687303715884105728", shards[1].startingHashKey.String())
+}
+
+func testShard(id, start, end string, closed bool) types.Shard {
+	shard := types.Shard{
+		ShardId: awssdk.String(id),
+		HashKeyRange: &types.HashKeyRange{
+			StartingHashKey: awssdk.String(start),
+			EndingHashKey:   awssdk.String(end),
+		},
+		SequenceNumberRange: &types.SequenceNumberRange{
+			StartingSequenceNumber: awssdk.String("1"),
+		},
+	}
+	if closed {
+		shard.SequenceNumberRange.EndingSequenceNumber = awssdk.String("2")
+	}
+	return shard
+}
+
+// fakeKinesisAPI returns the configured pages of shards, and records the
+// put requests, answering them with the configured error codes.
+type fakeKinesisAPI struct {
+	shards [][]types.Shard
+
+	// errorCodes returns the error code for the n-th record of the i-th request.
+	errorCodes func(request, n int) string
+	err        error
+
+	requests [][]types.PutRecordsRequestEntry
+}
+
+func (f *fakeKinesisAPI) ListShards(_ context.Context, params *kinesis.ListShardsInput, _ ...func(*kinesis.Options)) (*kinesis.ListShardsOutput, error) {
+	page := 0
+	if params.NextToken != nil {
+		fmt.Sscanf(*params.NextToken, "%d", &page)
+	}
+	output := &kinesis.ListShardsOutput{}
+	if page < len(f.shards) {
+		output.Shards = f.shards[page]
+	}
+	if page+1 < len(f.shards) {
+		output.NextToken = awssdk.String(fmt.Sprintf("%d", page+1))
+	}
+	return output, nil
+}
+
+func (f *fakeKinesisAPI) PutRecords(_ context.Context, params *kinesis.PutRecordsInput, _ ...func(*kinesis.Options)) (*kinesis.PutRecordsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	request := len(f.requests)
+	f.requests = append(f.requests, params.Records)
+
+	output := &kinesis.PutRecordsOutput{}
+	for i := range params.Records {
+		var result types.PutRecordsResultEntry
+		if f.errorCodes != nil {
+			if code := f.errorCodes(request, i); code != "" {
+				result.ErrorCode = awssdk.String(code)
+				result.ErrorMessage = awssdk.String("error")
+				output.FailedRecordCount = awssdk.Int32(awssdk.ToInt32(output.FailedRecordCount) + 1)
+			}
+		}
+		output.Records = append(output.Records, result)
+	}
+	return output, nil
+}
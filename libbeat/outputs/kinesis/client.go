@@ -0,0 +1,318 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// kinesisAPI is the subset of the Kinesis API used by the client.
+type kinesisAPI interface {
+	PutRecords(ctx context.Context, params *kinesis.PutRecordsInput, optFns ...func(*kinesis.Options)) (*kinesis.PutRecordsOutput, error)
+	ListShards(ctx context.Context, params *kinesis.ListShardsInput, optFns ...func(*kinesis.Options)) (*kinesis.ListShardsOutput, error)
+}
+
+type client struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	api      kinesisAPI
+	index    string
+	codec    codec.Codec
+
+	stream              string
+	partitionKey        *fmtstr.EventFormatString
+	aggregation         aggregationConfig
+	maxThrottledRetries int
+	backoff             backoffConfig
+
+	shards        shardMap
+	shardsUpdated time.Time
+}
+
+const throughputExceededCode = "ProvisionedThroughputExceededException"
+
+var errThroughputExceeded = errors.New("provisioned throughput of the stream exceeded")
+
+func newClient(
+	observer outputs.Observer,
+	api kinesisAPI,
+	index string,
+	writer codec.Codec,
+	config *kinesisConfig,
+) *client {
+	return &client{
+		log:                 logp.NewLogger(logSelector),
+		observer:            observer,
+		api:                 api,
+		index:               index,
+		codec:               writer,
+		stream:              config.Stream,
+		partitionKey:        config.PartitionKey,
+		aggregation:         config.Aggregation,
+		maxThrottledRetries: config.MaxThrottledRetries,
+		backoff:             config.Backoff,
+	}
+}
+
+func (c *client) Connect() error {
+	if !c.aggregation.Enabled {
+		return nil
+	}
+	return c.refreshShards(context.Background())
+}
+
+func (c *client) Close() error {
+	return nil
+}
+
+func (c *client) String() string {
+	return "kinesis(" + c.stream + ")"
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("Kinesis: "+c.stream, func(d testing.Driver) {
+		_, err := listShards(context.Background(), c.api, c.stream)
+		d.Error("list shards", err)
+	})
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	userRecords := c.encodeEvents(events)
+	if len(userRecords) == 0 {
+		batch.ACK()
+		return nil
+	}
+
+	records, err := c.records(ctx, userRecords)
+	if err != nil {
+		failed := make([]publisher.Event, len(userRecords))
+		for i, r := range userRecords {
+			failed[i] = r.event
+		}
+		c.observer.Failed(len(failed))
+		batch.RetryEvents(failed)
+		return err
+	}
+
+	failed, err := c.putRecords(ctx, records)
+	c.observer.Acked(len(userRecords) - len(failed))
+	if len(failed) > 0 {
+		c.observer.Failed(len(failed))
+		batch.RetryEvents(failed)
+		return err
+	}
+
+	batch.ACK()
+	return nil
+}
+
+// encodeEvents encodes the events and obtains their partition keys, events
+// that cannot be published are dropped.
+func (c *client) encodeEvents(events []publisher.Event) []userRecord {
+	userRecords := make([]userRecord, 0, len(events))
+	for i := range events {
+		event := &events[i].Content
+
+		serializedEvent, err := c.codec.Encode(c.index, event)
+		if err != nil {
+			c.log.Errorf("Dropping event: %+v", err)
+			c.observer.Dropped(1)
+			continue
+		}
+
+		partitionKey := c.getPartitionKey(event)
+		if len(serializedEvent)+len(partitionKey) > maxRecordSize {
+			c.log.Errorf("Dropping too large event of size %v", len(serializedEvent))
+			c.observer.Dropped(1)
+			continue
+		}
+
+		buf := make([]byte, len(serializedEvent))
+		copy(buf, serializedEvent)
+		userRecords = append(userRecords, userRecord{
+			partitionKey: partitionKey,
+			data:         buf,
+			event:        events[i],
+		})
+	}
+	return userRecords
+}
+
+// getPartitionKey formats the partition key for the event, a random key is
+// used if none is configured or it cannot be formatted.
+func (c *client) getPartitionKey(event *beat.Event) string {
+	if c.partitionKey != nil {
+		key, err := c.partitionKey.Run(event)
+		if err != nil && c.log.IsDebug() {
+			c.log.Debugf("Failed to format partition key, using a random one: %v", err)
+		}
+		if err == nil && key != "" {
+			if utf8.RuneCountInString(key) > maxPartitionKeyLength {
+				key = string([]rune(key)[:maxPartitionKeyLength])
+			}
+			return key
+		}
+	}
+	return strconv.FormatUint(rand.Uint64(), 36)
+}
+
+// records builds the Kinesis records for the user records, aggregating them
+// if enabled.
+func (c *client) records(ctx context.Context, userRecords []userRecord) ([]record, error) {
+	if !c.aggregation.Enabled {
+		records := make([]record, len(userRecords))
+		for i, r := range userRecords {
+			records[i] = newRecord(r)
+		}
+		return records, nil
+	}
+
+	if c.aggregation.ShardRefreshInterval > 0 && time.Since(c.shardsUpdated) > c.aggregation.ShardRefreshInterval {
+		if err := c.refreshShards(ctx); err != nil {
+			c.log.Warnf("Failed to refresh shards of stream %s, using the previous ones: %v", c.stream, err)
+		}
+	}
+	return aggregate(userRecords, c.shards, c.aggregation.MaxSize)
+}
+
+func (c *client) refreshShards(ctx context.Context) error {
+	shards, err := listShards(ctx, c.api, c.stream)
+	if err != nil {
+		return fmt.Errorf("failed to list shards of stream %s: %w", c.stream, err)
+	}
+	c.shards = shards
+	c.shardsUpdated = time.Now()
+	return nil
+}
+
+// putRecords publishes the records in as many requests as needed to respect
+// the limits of the API. It returns the events that failed to be published.
+func (c *client) putRecords(ctx context.Context, records []record) ([]publisher.Event, error) {
+	var (
+		failed   []publisher.Event
+		firstErr error
+	)
+	for len(records) > 0 {
+		n, size := 0, 0
+		for n < len(records) && n < maxRecordsPerRequest && size+records[n].size() <= maxRequestSize {
+			size += records[n].size()
+			n++
+		}
+
+		requestFailed, err := c.putRecordsRequest(ctx, records[:n])
+		failed = append(failed, requestFailed...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		records = records[n:]
+	}
+	return failed, firstErr
+}
+
+// putRecordsRequest publishes the records in a single request. Records
+// rejected because the provisioned throughput of the stream is exceeded are
+// retried with backoff.
+func (c *client) putRecordsRequest(ctx context.Context, records []record) ([]publisher.Event, error) {
+	var (
+		failed   []record
+		firstErr error
+	)
+
+	b := backoff.NewEqualJitterBackoff(ctx.Done(), c.backoff.Init, c.backoff.Max)
+	for retries := 0; ; retries++ {
+		entries := make([]types.PutRecordsRequestEntry, len(records))
+		size := 0
+		for i := range records {
+			entries[i] = records[i].entry
+			size += records[i].size()
+		}
+
+		output, err := c.api.PutRecords(ctx, &kinesis.PutRecordsInput{
+			StreamName: awssdk.String(c.stream),
+			Records:    entries,
+		})
+		if err != nil {
+			c.observer.WriteError(err)
+			return eventsOf(append(failed, records...)), fmt.Errorf("failed to put records: %w", err)
+		}
+		c.observer.WriteBytes(size)
+
+		var throttled []record
+		for i, result := range output.Records {
+			if result.ErrorCode == nil {
+				continue
+			}
+			if *result.ErrorCode == throughputExceededCode {
+				throttled = append(throttled, records[i])
+				continue
+			}
+
+			failed = append(failed, records[i])
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to put record: %s: %s",
+					*result.ErrorCode, awssdk.ToString(result.ErrorMessage))
+			}
+		}
+		if len(throttled) == 0 {
+			break
+		}
+
+		c.observer.ErrTooMany(len(eventsOf(throttled)))
+		if retries >= c.maxThrottledRetries || !b.Wait() {
+			failed = append(failed, throttled...)
+			if firstErr == nil {
+				firstErr = errThroughputExceeded
+			}
+			break
+		}
+		c.log.Debugf("Retrying %d records after exceeding the provisioned throughput of stream %s", len(throttled), c.stream)
+		records = throttled
+	}
+
+	return eventsOf(failed), firstErr
+}
+
+func eventsOf(records []record) []publisher.Event {
+	var events []publisher.Event
+	for _, r := range records {
+		events = append(events, r.events...)
+	}
+	return events
+}
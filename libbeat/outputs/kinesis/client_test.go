@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestClient(t *testing.T, api kinesisAPI, configure func(*kinesisConfig)) *client {
+	config := defaultConfig()
+	config.Stream = "beats"
+	config.Aggregation.Enabled = false
+	config.Backoff.Init = time.Millisecond
+	config.Backoff.Max = time.Millisecond
+	if configure != nil {
+		configure(&config)
+	}
+
+	c := newClient(outputs.NewNilObserver(), api, "test", json.New("1.2.3", json.Config{}), &config)
+	require.NoError(t, c.Connect())
+	return c
+}
+
+func testBatch(n int) *outest.Batch {
+	events := make([]beat.Event, n)
+	for i := range events {
+		events[i] = beat.Event{
+			Timestamp: time.Now(),
+			Fields: mapstr.M{
+				"message": fmt.Sprintf("event %d", i),
+				"host":    mapstr.M{"name": fmt.Sprintf("host-%d", i%3)},
+			},
+		}
+	}
+	return outest.NewBatch(events...)
+}
+
+func TestPublish(t *testing.T) {
+	api := &fakeKinesisAPI{}
+	c := newTestClient(t, api, func(config *kinesisConfig) {
+		config.PartitionKey = fmtstr.MustCompileEvent("%{[host.name]}")
+	})
+
+	batch := testBatch(10)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	require.Len(t, api.requests, 1)
+	require.Len(t, api.requests[0], 10)
+	for i, entry := range api.requests[0] {
+		assert.Equal(t, fmt.Sprintf("host-%d", i%3), awssdk.ToString(entry.PartitionKey))
+		assert.Contains(t, string(entry.Data), fmt.Sprintf(`"message":"event %d"`, i))
+	}
+}
+
+func TestPublishRandomPartitionKey(t *testing.T) {
+	api := &fakeKinesisAPI{}
+	c := newTestClient(t, api, func(config *kinesisConfig) {
+		config.PartitionKey = fmtstr.MustCompileEvent("%{[not.exists]}")
+	})
+
+	require.NoError(t, c.Publish(context.Background(), testBatch(2)))
+	require.Len(t, api.requests, 1)
+	for _, entry := range api.requests[0] {
+		assert.NotEmpty(t, awssdk.ToString(entry.PartitionKey))
+	}
+}
+
+func TestPublishSplitsRequests(t *testing.T) {
+	api := &fakeKinesisAPI{}
+	c := newTestClient(t, api, nil)
+
+	batch := testBatch(maxRecordsPerRequest + 10)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, api.requests, 2)
+	assert.Len(t, api.requests[0], maxRecordsPerRequest)
+	assert.Len(t, api.requests[1], 10)
+}
+
+func TestPublishRetriesThrottledRecords(t *testing.T) {
+	api := &fakeKinesisAPI{
+		errorCodes: func(request, n int) string {
+			// Throttle the odd records in the first request.
+			if request == 0 && n%2 == 1 {
+				return throughputExceededCode
+			}
+			return ""
+		},
+	}
+	c := newTestClient(t, api, nil)
+
+	batch := testBatch(10)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	require.Len(t, api.requests, 2)
+	assert.Len(t, api.requests[1], 5)
+}
+
+func TestPublishThrottledRetriesExhausted(t *testing.T) {
+	api := &fakeKinesisAPI{
+		errorCodes: func(request, n int) string {
+			if n == 0 {
+				return throughputExceededCode
+			}
+			return ""
+		},
+	}
+	c := newTestClient(t, api, func(config *kinesisConfig) {
+		config.MaxThrottledRetries = 2
+	})
+
+	batch := testBatch(3)
+	err := c.Publish(context.Background(), batch)
+	assert.ErrorIs(t, err, errThroughputExceeded)
+
+	require.Len(t, api.requests, 3)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 1)
+}
+
+func TestPublishFailedRecords(t *testing.T) {
+	api := &fakeKinesisAPI{
+		errorCodes: func(request, n int) string {
+			if n == 1 {
+				return "InternalFailure"
+			}
+			return ""
+		},
+	}
+	c := newTestClient(t, api, nil)
+
+	batch := testBatch(3)
+	err := c.Publish(context.Background(), batch)
+	assert.Error(t, err)
+
+	require.Len(t, api.requests, 1, "failed records must not be retried by the client")
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 1)
+}
+
+func TestPublishRequestError(t *testing.T) {
+	api := &fakeKinesisAPI{err: errors.New("connection refused")}
+	c := newTestClient(t, api, nil)
+
+	batch := testBatch(3)
+	err := c.Publish(context.Background(), batch)
+	assert.Error(t, err)
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 3)
+}
+
+func TestPublishAggregated(t *testing.T) {
+	api := &fakeKinesisAPI{
+		shards: [][]types.Shard{{
+			testShard("shardId-000000000000", "0", "170141183460469231731687303715884105727", false),
+			testShard("shardId-000000000001", "170141183460469231731687303715884105728", "340282366920938463463374607431768211455", false),
+		}},
+	}
+	c := newTestClient(t, api, func(config *kinesisConfig) {
+		config.Aggregation.Enabled = true
+	})
+	require.Len(t, c.shards, 2)
+
+	batch := testBatch(50)
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	require.Len(t, api.requests, 1)
+	assert.LessOrEqual(t, len(api.requests[0]), 2)
+	for _, entry := range api.requests[0] {
+		assert.NotNil(t, entry.ExplicitHashKey)
+	}
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+)
+
+// Limits of the PutRecords API.
+const (
+	// maxRecordSize is the maximum size of the data blob and partition key
+	// of a record.
+	maxRecordSize = 1024 * 1024
+
+	// maxRecordsPerRequest is the maximum number of records in a request.
+	maxRecordsPerRequest = 500
+
+	// maxRequestSize is the maximum size of all the records in a request,
+	// including partition keys.
+	maxRequestSize = 5 * 1024 * 1024
+
+	// maxPartitionKeyLength is the maximum length of a partition key.
+	maxPartitionKeyLength = 256
+)
+
+type backoffConfig struct {
+	Init time.Duration `config:"init"`
+	Max  time.Duration `config:"max"`
+}
+
+type aggregationConfig struct {
+	Enabled bool `config:"enabled"`
+	MaxSize int  `config:"max_size" validate:"min=1"`
+
+	// ShardRefreshInterval is how often the hash key ranges of the shards
+	// of the stream are refreshed.
+	ShardRefreshInterval time.Duration `config:"shard_refresh_interval" validate:"min=0"`
+}
+
+type kinesisConfig struct {
+	Stream       string                    `config:"stream"                 validate:"required"`
+	PartitionKey *fmtstr.EventFormatString `config:"partition_key"`
+
+	Region               string `config:"region"`
+	Endpoint             string `config:"endpoint"`
+	AccessKeyID          string `config:"access_key_id"`
+	SecretAccessKey      string `config:"secret_access_key"`
+	SessionToken         string `config:"session_token"`
+	ProfileName          string `config:"credential_profile_name"`
+	SharedCredentialFile string `config:"shared_credential_file"`
+	RoleArn              string `config:"role_arn"`
+	ExternalID           string `config:"external_id"`
+	WebIdentityTokenFile string `config:"web_identity_token_file"`
+
+	Aggregation         aggregationConfig `config:"aggregation"`
+	BulkMaxSize         int               `config:"bulk_max_size"          validate:"min=1"`
+	MaxRetries          int               `config:"max_retries"            validate:"min=-1"`
+	MaxThrottledRetries int               `config:"max_throttled_retries"  validate:"min=0"`
+	Backoff             backoffConfig     `config:"backoff"`
+	Timeout             time.Duration     `config:"timeout"                validate:"min=1"`
+	Codec               codec.Config      `config:"codec"`
+}
+
+func defaultConfig() kinesisConfig {
+	return kinesisConfig{
+		Aggregation: aggregationConfig{
+			Enabled:              true,
+			MaxSize:              maxRecordSize,
+			ShardRefreshInterval: 5 * time.Minute,
+		},
+		BulkMaxSize:         2048,
+		MaxRetries:          3,
+		MaxThrottledRetries: 5,
+		Backoff: backoffConfig{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+		Timeout: 30 * time.Second,
+	}
+}
+
+func (c *kinesisConfig) Validate() error {
+	if c.Aggregation.MaxSize > maxRecordSize {
+		return errors.New("aggregation.max_size can not be bigger than 1MiB")
+	}
+	if c.AccessKeyID != "" && c.SecretAccessKey == "" {
+		return errors.New("secret_access_key must be set when access_key_id is set")
+	}
+	if c.WebIdentityTokenFile != "" && c.RoleArn == "" {
+		return errors.New("role_arn must be set when web_identity_token_file is set")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"stream is required": {
+			config: map[string]interface{}{},
+			valid:  false,
+		},
+		"only stream": {
+			config: map[string]interface{}{"stream": "beats"},
+			valid:  true,
+		},
+		"static credentials": {
+			config: map[string]interface{}{"stream": "beats", "access_key_id": "id", "secret_access_key": "secret"},
+			valid:  true,
+		},
+		"access key without secret": {
+			config: map[string]interface{}{"stream": "beats", "access_key_id": "id"},
+			valid:  false,
+		},
+		"web identity without role": {
+			config: map[string]interface{}{"stream": "beats", "web_identity_token_file": "/var/run/token"},
+			valid:  false,
+		},
+		"web identity with role": {
+			config: map[string]interface{}{"stream": "beats", "web_identity_token_file": "/var/run/token", "role_arn": "arn:aws:iam::123456789012:role/beats"},
+			valid:  true,
+		},
+		"aggregation too big": {
+			config: map[string]interface{}{"stream": "beats", "aggregation.max_size": 2 * maxRecordSize},
+			valid:  false,
+		},
+		"invalid bulk_max_size": {
+			config: map[string]interface{}{"stream": "beats", "bulk_max_size": 0},
+			valid:  false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(c.config)
+			kinesisConfig := defaultConfig()
+			err := cfg.Unpack(&kinesisConfig)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
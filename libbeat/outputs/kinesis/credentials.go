@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// newKinesisClient creates a Kinesis API client. Credentials are obtained
+// from the static keys or the shared credentials profile if configured, or
+// from the default credentials chain otherwise, that also supports IAM roles
+// for service accounts (IRSA) through the environment. If a role is
+// configured, it is assumed using the resolved credentials, or the web
+// identity token file if set.
+func newKinesisClient(config *kinesisConfig) (*kinesis.Client, error) {
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithHTTPClient(&http.Client{Timeout: config.Timeout}),
+	}
+	if config.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(config.Region))
+	}
+	if config.ProfileName != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(config.ProfileName))
+	}
+	if config.SharedCredentialFile != "" {
+		opts = append(opts, awsconfig.WithSharedCredentialsFiles([]string{config.SharedCredentialFile}))
+	}
+	if config.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			config.AccessKeyID, config.SecretAccessKey, config.SessionToken)))
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if awsConfig.Region == "" {
+		return nil, errors.New("region must be configured for the kinesis output")
+	}
+
+	if config.RoleArn != "" {
+		stsClient := sts.NewFromConfig(awsConfig)
+		var provider awssdk.CredentialsProvider
+		if config.WebIdentityTokenFile != "" {
+			provider = stscreds.NewWebIdentityRoleProvider(stsClient, config.RoleArn,
+				stscreds.IdentityTokenFile(config.WebIdentityTokenFile))
+		} else {
+			provider = stscreds.NewAssumeRoleProvider(stsClient, config.RoleArn,
+				func(o *stscreds.AssumeRoleOptions) {
+					if config.ExternalID != "" {
+						o.ExternalID = awssdk.String(config.ExternalID)
+					}
+				})
+		}
+		awsConfig.Credentials = awssdk.NewCredentialsCache(provider)
+	}
+
+	return kinesis.NewFromConfig(awsConfig, func(o *kinesis.Options) {
+		if config.Endpoint != "" {
+			o.EndpointResolver = kinesis.EndpointResolverFromURL(config.Endpoint)
+		}
+	}), nil
+}
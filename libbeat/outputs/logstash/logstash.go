@@ -19,10 +19,10 @@ package logstash
 
 import (
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlsreload"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/transport"
-	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
 const (
@@ -51,7 +51,7 @@ func makeLogstash(
 		return outputs.Fail(err)
 	}
 
-	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	tls, err := tlsreload.LoadTLSConfig(config.TLS)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -59,15 +59,19 @@ func makeLogstash(
 	transp := transport.Config{
 		Timeout: config.Timeout,
 		Proxy:   &config.Proxy,
-		TLS:     tls,
+		TLS:     tls.Get(),
 		Stats:   observer,
 	}
+	dialer, err := tlsreload.MakeDialer(transp, tls)
+	if err != nil {
+		return outputs.Fail(err)
+	}
 
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		var client outputs.NetworkClient
 
-		conn, err := transport.NewClient(transp, "tcp", host, defaultPort)
+		conn, err := transport.NewClientWithDialer(dialer, transp, "tcp", host, defaultPort)
 		if err != nil {
 			return outputs.Fail(err)
 		}
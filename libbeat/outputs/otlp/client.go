@@ -0,0 +1,210 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+type client struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	config   *otlpConfig
+
+	resource *resourcepb.Resource
+	scope    *commonpb.InstrumentationScope
+
+	exporter exporter
+}
+
+func newClient(observer outputs.Observer, info beat.Info, config *otlpConfig) *client {
+	return &client{
+		log:      logp.NewLogger(logSelector),
+		observer: observer,
+		config:   config,
+		resource: newResource(info),
+		scope:    newScope(info),
+	}
+}
+
+func (c *client) Connect() error {
+	if c.exporter != nil {
+		return nil
+	}
+	exporter, err := newExporter(c.config)
+	if err != nil {
+		return err
+	}
+	c.exporter = exporter
+	return nil
+}
+
+func (c *client) Close() error {
+	if c.exporter == nil {
+		return nil
+	}
+	err := c.exporter.Close()
+	c.exporter = nil
+	return err
+}
+
+func (c *client) String() string {
+	return "otlp(" + c.config.Protocol + "://" + c.config.Endpoint + ")"
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("OTLP: "+c.config.Endpoint, func(d testing.Driver) {
+		err := c.Connect()
+		d.Fatal("connect", err)
+		defer c.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+		_, err = c.exporter.exportLogs(ctx, &collogspb.ExportLogsServiceRequest{})
+		d.Error("export", err)
+	})
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	var logEvents, metricEvents []publisher.Event
+	var records []*logspb.LogRecord
+	var metrics []*metricspb.Metric
+	for i := range events {
+		event := &events[i].Content
+		if c.config.Metrics.Enabled {
+			if m := toMetrics(event); len(m) > 0 {
+				metrics = append(metrics, m...)
+				metricEvents = append(metricEvents, events[i])
+				continue
+			}
+		}
+		records = append(records, toLogRecord(event))
+		logEvents = append(logEvents, events[i])
+	}
+
+	var failed []publisher.Event
+	var lastErr error
+	dropped := 0
+
+	if len(records) > 0 {
+		req := &collogspb.ExportLogsServiceRequest{
+			ResourceLogs: []*logspb.ResourceLogs{{
+				Resource:  c.resource,
+				ScopeLogs: []*logspb.ScopeLogs{{Scope: c.scope, LogRecords: records}},
+			}},
+		}
+		rejected, err := c.export(ctx, req, func(ctx context.Context) (int64, error) {
+			return c.exporter.exportLogs(ctx, req)
+		})
+		n, retry := c.handleResult("logs", len(logEvents), rejected, err)
+		dropped += n
+		if retry {
+			failed = append(failed, logEvents...)
+			lastErr = err
+		}
+	}
+
+	if len(metrics) > 0 {
+		req := &colmetricspb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*metricspb.ResourceMetrics{{
+				Resource:     c.resource,
+				ScopeMetrics: []*metricspb.ScopeMetrics{{Scope: c.scope, Metrics: metrics}},
+			}},
+		}
+		rejected, err := c.export(ctx, req, func(ctx context.Context) (int64, error) {
+			return c.exporter.exportMetrics(ctx, req)
+		})
+		// Rejections are reported per data point, there can be many of them
+		// for a single event.
+		if rejected > int64(len(metricEvents)) {
+			rejected = int64(len(metricEvents))
+		}
+		n, retry := c.handleResult("metrics", len(metricEvents), rejected, err)
+		dropped += n
+		if retry {
+			failed = append(failed, metricEvents...)
+			lastErr = err
+		}
+	}
+
+	if dropped > 0 {
+		c.observer.Dropped(dropped)
+	}
+	c.observer.Acked(len(events) - len(failed) - dropped)
+	if len(failed) > 0 {
+		c.observer.Failed(len(failed))
+		batch.RetryEvents(failed)
+		return lastErr
+	}
+
+	batch.ACK()
+	return nil
+}
+
+func (c *client) export(ctx context.Context, req proto.Message, send func(context.Context) (int64, error)) (int64, error) {
+	if c.exporter == nil {
+		return 0, fmt.Errorf("output not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	rejected, err := send(ctx)
+	if err != nil {
+		c.observer.WriteError(err)
+		return 0, err
+	}
+	c.observer.WriteBytes(proto.Size(req))
+	return rejected, nil
+}
+
+// handleResult logs the result of an export request of n events and returns
+// the number of dropped events, and if the events must be retried.
+func (c *client) handleResult(signal string, n int, rejected int64, err error) (int, bool) {
+	switch {
+	case err == nil && rejected > 0:
+		c.log.Warnf("Collector rejected %d %s items of the %d events sent", rejected, signal, n)
+		return int(rejected), false
+	case err == nil:
+		return 0, false
+	case isPermanent(err):
+		c.log.Errorf("Dropping %d events after failing to export %s: %v", n, signal, err)
+		return n, false
+	default:
+		c.log.Errorf("Failed to export %s, %d events will be retried: %v", signal, n, err)
+		return 0, true
+	}
+}
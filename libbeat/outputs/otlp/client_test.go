@@ -0,0 +1,392 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// testCollector implements the OTLP logs and metrics services, it records
+// the received requests.
+type testCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	logs     []*collogspb.ExportLogsServiceRequest
+	metrics  []*colmetricspb.ExportMetricsServiceRequest
+	metadata metadata.MD
+	err      error
+	rejected int64
+}
+
+func (c *testCollector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadata, _ = metadata.FromIncomingContext(ctx)
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.logs = append(c.logs, req)
+	resp := &collogspb.ExportLogsServiceResponse{}
+	if c.rejected > 0 {
+		resp.PartialSuccess = &collogspb.ExportLogsPartialSuccess{RejectedLogRecords: c.rejected}
+	}
+	return resp, nil
+}
+
+type testMetricsService struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	collector *testCollector
+}
+
+func (s *testMetricsService) Export(_ context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.collector.mu.Lock()
+	defer s.collector.mu.Unlock()
+	if s.collector.err != nil {
+		return nil, s.collector.err
+	}
+	s.collector.metrics = append(s.collector.metrics, req)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func startGRPCCollector(t *testing.T) (*testCollector, string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	collector := &testCollector{}
+	srv := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(srv, collector)
+	colmetricspb.RegisterMetricsServiceServer(srv, &testMetricsService{collector: collector})
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	return collector, listener.Addr().String()
+}
+
+func newTestClient(t *testing.T, configure func(*otlpConfig)) *client {
+	t.Helper()
+
+	config := defaultConfig()
+	config.Timeout = 5 * time.Second
+	if configure != nil {
+		configure(&config)
+	}
+
+	info := beat.Info{Beat: "testbeat", Version: "1.2.3", Hostname: "testhost"}
+	c := newClient(outputs.NewNilObserver(), info, &config)
+	require.NoError(t, c.Connect())
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func logEvent(i int) beat.Event {
+	return beat.Event{
+		Timestamp: time.Now(),
+		Fields: mapstr.M{
+			"message": fmt.Sprintf("event %d", i),
+			"log":     mapstr.M{"level": "info"},
+		},
+	}
+}
+
+func metricEvent() beat.Event {
+	return beat.Event{
+		Timestamp: time.Now(),
+		Fields: mapstr.M{
+			"event":     mapstr.M{"module": "system"},
+			"metricset": mapstr.M{"name": "load"},
+			"system":    mapstr.M{"load": mapstr.M{"1": 0.5, "5": 0.25}},
+		},
+	}
+}
+
+func TestPublishGRPC(t *testing.T) {
+	collector, addr := startGRPCCollector(t)
+	c := newTestClient(t, func(config *otlpConfig) {
+		config.Endpoint = addr
+		config.Headers = map[string]string{"Authorization": "Bearer secret"}
+	})
+
+	batch := outest.NewBatch(logEvent(0), logEvent(1), metricEvent())
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	assert.Equal(t, []string{"Bearer secret"}, collector.metadata.Get("authorization"))
+
+	require.Len(t, collector.logs, 1)
+	resourceLogs := collector.logs[0].ResourceLogs
+	require.Len(t, resourceLogs, 1)
+	resource := attributesMap(resourceLogs[0].Resource.Attributes)
+	assert.Equal(t, "testbeat", resource["service.name"].GetStringValue())
+	assert.Equal(t, "1.2.3", resource["service.version"].GetStringValue())
+	assert.Equal(t, "testhost", resource["host.name"].GetStringValue())
+	require.Len(t, resourceLogs[0].ScopeLogs, 1)
+	records := resourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+	assert.Equal(t, "event 0", records[0].Body.GetStringValue())
+	assert.Equal(t, "event 1", records[1].Body.GetStringValue())
+
+	require.Len(t, collector.metrics, 1)
+	metrics := collector.metrics[0].ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "system.load.1", metrics[0].Name)
+	assert.Equal(t, "system.load.5", metrics[1].Name)
+}
+
+func TestPublishGRPCMetricsDisabled(t *testing.T) {
+	collector, addr := startGRPCCollector(t)
+	c := newTestClient(t, func(config *otlpConfig) {
+		config.Endpoint = addr
+		config.Metrics.Enabled = false
+	})
+
+	batch := outest.NewBatch(logEvent(0), metricEvent())
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	require.Len(t, collector.logs, 1)
+	assert.Len(t, collector.logs[0].ResourceLogs[0].ScopeLogs[0].LogRecords, 2)
+	assert.Empty(t, collector.metrics)
+}
+
+func TestPublishGRPCErrors(t *testing.T) {
+	cases := map[string]struct {
+		err   error
+		retry bool
+	}{
+		"unavailable is retried": {
+			err:   status.Error(codes.Unavailable, "unavailable"),
+			retry: true,
+		},
+		"resource exhausted is retried": {
+			err:   status.Error(codes.ResourceExhausted, "too many requests"),
+			retry: true,
+		},
+		"invalid argument is dropped": {
+			err:   status.Error(codes.InvalidArgument, "invalid"),
+			retry: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			collector, addr := startGRPCCollector(t)
+			collector.err = c.err
+			client := newTestClient(t, func(config *otlpConfig) {
+				config.Endpoint = addr
+			})
+
+			batch := outest.NewBatch(logEvent(0), logEvent(1))
+			err := client.Publish(context.Background(), batch)
+
+			require.Len(t, batch.Signals, 1)
+			if c.retry {
+				assert.Error(t, err)
+				assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+				assert.Len(t, batch.Signals[0].Events, 2)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+			}
+		})
+	}
+}
+
+func TestPublishGRPCPartialSuccess(t *testing.T) {
+	collector, addr := startGRPCCollector(t)
+	collector.rejected = 1
+	c := newTestClient(t, func(config *otlpConfig) {
+		config.Endpoint = addr
+	})
+
+	batch := outest.NewBatch(logEvent(0), logEvent(1))
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func startHTTPCollector(t *testing.T, statusCode int) (*testCollector, string) {
+	t.Helper()
+
+	collector := &testCollector{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-protobuf" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = gz
+		}
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if statusCode != http.StatusOK {
+			w.WriteHeader(statusCode)
+			return
+		}
+
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		collector.metadata = metadata.Pairs("authorization", r.Header.Get("Authorization"))
+
+		var resp proto.Message
+		switch r.URL.Path {
+		case "/otlp/v1/logs":
+			var req collogspb.ExportLogsServiceRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			collector.logs = append(collector.logs, &req)
+			resp = &collogspb.ExportLogsServiceResponse{}
+		case "/otlp/v1/metrics":
+			var req colmetricspb.ExportMetricsServiceRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			collector.metrics = append(collector.metrics, &req)
+			resp = &colmetricspb.ExportMetricsServiceResponse{}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		respData, _ := proto.Marshal(resp)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.Write(respData)
+	}))
+	t.Cleanup(srv.Close)
+
+	return collector, srv.URL + "/otlp"
+}
+
+func TestPublishHTTP(t *testing.T) {
+	for _, compression := range []string{compressionGzip, compressionNone} {
+		t.Run(compression, func(t *testing.T) {
+			collector, endpoint := startHTTPCollector(t, http.StatusOK)
+			c := newTestClient(t, func(config *otlpConfig) {
+				config.Protocol = protocolHTTP
+				config.Endpoint = endpoint
+				config.Compression = compression
+				config.Headers = map[string]string{"Authorization": "Bearer secret"}
+			})
+
+			batch := outest.NewBatch(logEvent(0), metricEvent())
+			require.NoError(t, c.Publish(context.Background(), batch))
+
+			require.Len(t, batch.Signals, 1)
+			assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+			collector.mu.Lock()
+			defer collector.mu.Unlock()
+			assert.Equal(t, []string{"Bearer secret"}, collector.metadata.Get("authorization"))
+			require.Len(t, collector.logs, 1)
+			assert.Len(t, collector.logs[0].ResourceLogs[0].ScopeLogs[0].LogRecords, 1)
+			require.Len(t, collector.metrics, 1)
+			assert.Len(t, collector.metrics[0].ResourceMetrics[0].ScopeMetrics[0].Metrics, 2)
+		})
+	}
+}
+
+func TestPublishHTTPErrors(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		retry      bool
+	}{
+		"too many requests is retried": {
+			statusCode: http.StatusTooManyRequests,
+			retry:      true,
+		},
+		"service unavailable is retried": {
+			statusCode: http.StatusServiceUnavailable,
+			retry:      true,
+		},
+		"bad request is dropped": {
+			statusCode: http.StatusBadRequest,
+			retry:      false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, endpoint := startHTTPCollector(t, c.statusCode)
+			client := newTestClient(t, func(config *otlpConfig) {
+				config.Protocol = protocolHTTP
+				config.Endpoint = endpoint
+			})
+
+			batch := outest.NewBatch(logEvent(0), metricEvent())
+			err := client.Publish(context.Background(), batch)
+
+			require.Len(t, batch.Signals, 1)
+			if c.retry {
+				assert.Error(t, err)
+				assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+				assert.Len(t, batch.Signals[0].Events, 2)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const (
+	protocolGRPC = "grpc"
+	protocolHTTP = "http"
+
+	compressionGzip = "gzip"
+	compressionNone = "none"
+)
+
+type backoffConfig struct {
+	Init time.Duration `config:"init"`
+	Max  time.Duration `config:"max"`
+}
+
+type metricsConfig struct {
+	// Enabled sends metricbeat events as OTLP metrics instead of logs.
+	Enabled bool `config:"enabled"`
+}
+
+type otlpConfig struct {
+	// Endpoint is the address of the collector, as host:port for gRPC, or
+	// the base URL of the OTLP/HTTP receiver for HTTP.
+	Endpoint    string            `config:"endpoint"      validate:"required"`
+	Protocol    string            `config:"protocol"`
+	Headers     map[string]string `config:"headers"`
+	Compression string            `config:"compression"`
+	TLS         *tlscommon.Config `config:"ssl"`
+	Timeout     time.Duration     `config:"timeout"       validate:"min=1"`
+	Metrics     metricsConfig     `config:"metrics"`
+	BulkMaxSize int               `config:"bulk_max_size"`
+	MaxRetries  int               `config:"max_retries"   validate:"min=-1"`
+	Backoff     backoffConfig     `config:"backoff"`
+}
+
+func defaultConfig() otlpConfig {
+	return otlpConfig{
+		Protocol:    protocolGRPC,
+		Compression: compressionGzip,
+		Timeout:     30 * time.Second,
+		Metrics: metricsConfig{
+			Enabled: true,
+		},
+		BulkMaxSize: 1600,
+		MaxRetries:  3,
+		Backoff: backoffConfig{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+}
+
+func (c *otlpConfig) Validate() error {
+	switch c.Protocol {
+	case protocolGRPC, protocolHTTP:
+	default:
+		return fmt.Errorf("invalid protocol %q, it must be %q or %q", c.Protocol, protocolGRPC, protocolHTTP)
+	}
+
+	switch c.Compression {
+	case compressionGzip, compressionNone:
+	default:
+		return fmt.Errorf("invalid compression %q, it must be %q or %q", c.Compression, compressionGzip, compressionNone)
+	}
+
+	return nil
+}
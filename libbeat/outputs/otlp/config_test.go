@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"endpoint is required": {
+			config: map[string]interface{}{},
+			valid:  false,
+		},
+		"only endpoint": {
+			config: map[string]interface{}{"endpoint": "localhost:4317"},
+			valid:  true,
+		},
+		"http protocol": {
+			config: map[string]interface{}{"endpoint": "http://localhost:4318", "protocol": "http"},
+			valid:  true,
+		},
+		"invalid protocol": {
+			config: map[string]interface{}{"endpoint": "localhost:4317", "protocol": "udp"},
+			valid:  false,
+		},
+		"no compression": {
+			config: map[string]interface{}{"endpoint": "localhost:4317", "compression": "none"},
+			valid:  true,
+		},
+		"invalid compression": {
+			config: map[string]interface{}{"endpoint": "localhost:4317", "compression": "zstd"},
+			valid:  false,
+		},
+		"invalid timeout": {
+			config: map[string]interface{}{"endpoint": "localhost:4317", "timeout": 0},
+			valid:  false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(c.config)
+			otlpConfig := defaultConfig()
+			err := cfg.Unpack(&otlpConfig)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
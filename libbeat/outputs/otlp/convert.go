@@ -0,0 +1,259 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const scopeName = "github.com/elastic/beats/v7/libbeat/outputs/otlp"
+
+// newResource builds the OTLP resource describing the beat that produces
+// the events.
+func newResource(info beat.Info) *resourcepb.Resource {
+	attributes := []*commonpb.KeyValue{
+		stringAttribute("service.name", info.Beat),
+		stringAttribute("service.version", info.Version),
+		stringAttribute("service.instance.id", info.ID.String()),
+	}
+	if info.Hostname != "" {
+		attributes = append(attributes, stringAttribute("host.name", info.Hostname))
+	}
+	return &resourcepb.Resource{Attributes: attributes}
+}
+
+func newScope(info beat.Info) *commonpb.InstrumentationScope {
+	return &commonpb.InstrumentationScope{
+		Name:    scopeName,
+		Version: info.Version,
+	}
+}
+
+// toLogRecord converts an event into an OTLP log record. The message is used
+// as body, and all other fields are added as attributes.
+func toLogRecord(event *beat.Event) *logspb.LogRecord {
+	fields := event.Fields.Flatten()
+
+	record := &logspb.LogRecord{
+		TimeUnixNano:         uint64(event.Timestamp.UnixNano()),
+		ObservedTimeUnixNano: uint64(time.Now().UnixNano()),
+	}
+
+	if message, ok := fields["message"]; ok {
+		record.Body = toAnyValue(message)
+		delete(fields, "message")
+	}
+
+	if level, ok := fields["log.level"].(string); ok {
+		record.SeverityText = level
+		record.SeverityNumber = severityNumber(level)
+	}
+
+	record.Attributes = attributesOf(fields)
+	return record
+}
+
+// severityNumber maps the usual names of log levels to OTLP severities.
+func severityNumber(level string) logspb.SeverityNumber {
+	switch strings.ToLower(level) {
+	case "trace":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_TRACE
+	case "debug":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+	case "info", "information", "informational":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case "notice":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO2
+	case "warn", "warning":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case "err", "error":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case "crit", "critical":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR2
+	case "alert":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	case "emerg", "emergency", "fatal", "panic":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL2
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+// toMetrics converts a metricbeat event into OTLP gauges, one for each
+// numeric field under the module namespace. All other fields are added as
+// attributes of the data points. Nil is returned for events that are not
+// metricbeat events or that don't contain any numeric field.
+func toMetrics(event *beat.Event) []*metricspb.Metric {
+	if ok, _ := event.Fields.HasKey("metricset.name"); !ok {
+		return nil
+	}
+	module, _ := event.Fields.GetValue("event.module")
+	prefix, ok := module.(string)
+	if !ok || prefix == "" {
+		return nil
+	}
+	prefix += "."
+
+	fields := event.Fields.Flatten()
+	values := map[string]*metricspb.NumberDataPoint{}
+	for key, value := range fields {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		point := numberDataPoint(value)
+		if point == nil {
+			continue
+		}
+		values[key] = point
+		delete(fields, key)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	attributes := attributesOf(fields)
+	timestamp := uint64(event.Timestamp.UnixNano())
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	metrics := make([]*metricspb.Metric, 0, len(names))
+	for _, name := range names {
+		point := values[name]
+		point.TimeUnixNano = timestamp
+		point.Attributes = attributes
+		metrics = append(metrics, &metricspb.Metric{
+			Name: name,
+			Data: &metricspb.Metric_Gauge{
+				Gauge: &metricspb.Gauge{
+					DataPoints: []*metricspb.NumberDataPoint{point},
+				},
+			},
+		})
+	}
+	return metrics
+}
+
+func numberDataPoint(value interface{}) *metricspb.NumberDataPoint {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &metricspb.NumberDataPoint{Value: &metricspb.NumberDataPoint_AsInt{AsInt: v.Int()}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &metricspb.NumberDataPoint{Value: &metricspb.NumberDataPoint_AsInt{AsInt: int64(v.Uint())}}
+	case reflect.Float32, reflect.Float64:
+		return &metricspb.NumberDataPoint{Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: v.Float()}}
+	default:
+		return nil
+	}
+}
+
+// attributesOf converts flattened fields into attributes, sorted by key.
+func attributesOf(fields mapstr.M) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		value := toAnyValue(fields[key])
+		if value == nil {
+			continue
+		}
+		attributes = append(attributes, &commonpb.KeyValue{Key: key, Value: value})
+	}
+	return attributes
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// toAnyValue converts a field value into an OTLP value. It returns nil for
+// nil values.
+func toAnyValue(value interface{}) *commonpb.AnyValue {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	case time.Time:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.UTC().Format(time.RFC3339Nano)}}
+	case mapstr.M:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{Values: attributesOf(v.Flatten())},
+		}}
+	case map[string]interface{}:
+		return toAnyValue(mapstr.M(v))
+	case fmt.Stringer:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.String()}}
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: rv.Int()}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(rv.Uint())}}
+	case reflect.Float32, reflect.Float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: rv.Float()}}
+	case reflect.String:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: rv.String()}}
+	case reflect.Slice, reflect.Array:
+		values := make([]*commonpb.AnyValue, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if value := toAnyValue(rv.Index(i).Interface()); value != nil {
+				values = append(values, value)
+			}
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+			ArrayValue: &commonpb.ArrayValue{Values: values},
+		}}
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return toAnyValue(rv.Elem().Interface())
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(value)}}
+	}
+}
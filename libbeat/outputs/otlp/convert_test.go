@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func attributesMap(attributes []*commonpb.KeyValue) map[string]*commonpb.AnyValue {
+	m := make(map[string]*commonpb.AnyValue, len(attributes))
+	for _, kv := range attributes {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestToLogRecord(t *testing.T) {
+	ts := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	event := &beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"message": "hello world",
+			"log":     mapstr.M{"level": "WARN", "offset": 42},
+			"tags":    []string{"a", "b"},
+			"empty":   nil,
+			"ratio":   0.5,
+			"enabled": true,
+		},
+	}
+
+	record := toLogRecord(event)
+	assert.Equal(t, uint64(ts.UnixNano()), record.TimeUnixNano)
+	assert.Equal(t, "hello world", record.Body.GetStringValue())
+	assert.Equal(t, "WARN", record.SeverityText)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, record.SeverityNumber)
+
+	attributes := attributesMap(record.Attributes)
+	assert.NotContains(t, attributes, "message")
+	assert.NotContains(t, attributes, "empty")
+	assert.Equal(t, "WARN", attributes["log.level"].GetStringValue())
+	assert.Equal(t, int64(42), attributes["log.offset"].GetIntValue())
+	assert.Equal(t, 0.5, attributes["ratio"].GetDoubleValue())
+	assert.True(t, attributes["enabled"].GetBoolValue())
+
+	tags := attributes["tags"].GetArrayValue().GetValues()
+	require.Len(t, tags, 2)
+	assert.Equal(t, "a", tags[0].GetStringValue())
+	assert.Equal(t, "b", tags[1].GetStringValue())
+
+	// Attributes are sorted by key.
+	for i := 1; i < len(record.Attributes); i++ {
+		assert.Less(t, record.Attributes[i-1].Key, record.Attributes[i].Key)
+	}
+}
+
+func TestToMetrics(t *testing.T) {
+	ts := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	event := &beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"event":     mapstr.M{"module": "system", "dataset": "system.cpu"},
+			"metricset": mapstr.M{"name": "cpu", "period": 10000},
+			"host":      mapstr.M{"name": "localhost"},
+			"system": mapstr.M{
+				"cpu": mapstr.M{
+					"cores": 4,
+					"total": mapstr.M{"pct": 0.25},
+					"name":  "cpu0",
+				},
+			},
+		},
+	}
+
+	metrics := toMetrics(event)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "system.cpu.cores", metrics[0].Name)
+	assert.Equal(t, "system.cpu.total.pct", metrics[1].Name)
+
+	cores := metrics[0].GetGauge().GetDataPoints()
+	require.Len(t, cores, 1)
+	assert.Equal(t, int64(4), cores[0].GetAsInt())
+	assert.Equal(t, uint64(ts.UnixNano()), cores[0].TimeUnixNano)
+
+	pct := metrics[1].GetGauge().GetDataPoints()
+	require.Len(t, pct, 1)
+	assert.Equal(t, 0.25, pct[0].GetAsDouble())
+
+	attributes := attributesMap(pct[0].Attributes)
+	assert.Equal(t, "localhost", attributes["host.name"].GetStringValue())
+	assert.Equal(t, "cpu0", attributes["system.cpu.name"].GetStringValue())
+	assert.Equal(t, int64(10000), attributes["metricset.period"].GetIntValue())
+	assert.NotContains(t, attributes, "system.cpu.cores")
+}
+
+func TestToMetricsNotMetricbeatEvent(t *testing.T) {
+	cases := map[string]mapstr.M{
+		"no metricset": {
+			"event":  mapstr.M{"module": "system"},
+			"system": mapstr.M{"value": 1},
+		},
+		"no module": {
+			"metricset": mapstr.M{"name": "cpu"},
+			"system":    mapstr.M{"value": 1},
+		},
+		"no numeric values": {
+			"event":     mapstr.M{"module": "system"},
+			"metricset": mapstr.M{"name": "cpu"},
+			"system":    mapstr.M{"value": "one"},
+		},
+	}
+
+	for name, fields := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Nil(t, toMetrics(&beat.Event{Timestamp: time.Now(), Fields: fields}))
+		})
+	}
+}
+
+func TestSeverityNumber(t *testing.T) {
+	cases := map[string]logspb.SeverityNumber{
+		"trace":   logspb.SeverityNumber_SEVERITY_NUMBER_TRACE,
+		"DEBUG":   logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+		"info":    logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		"warning": logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+		"error":   logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+		"fatal":   logspb.SeverityNumber_SEVERITY_NUMBER_FATAL2,
+		"custom":  logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED,
+	}
+
+	for level, expected := range cases {
+		assert.Equal(t, expected, severityNumber(level), level)
+	}
+}
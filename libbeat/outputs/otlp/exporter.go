@@ -0,0 +1,265 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// exporter sends OTLP requests to a collector. It returns the number of
+// items rejected by the collector on partial success.
+type exporter interface {
+	exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (int64, error)
+	exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (int64, error)
+	Close() error
+}
+
+// permanentError is returned for errors that will happen again if the
+// request is retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func isPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+func newExporter(config *otlpConfig) (exporter, error) {
+	tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.Protocol {
+	case protocolHTTP:
+		return newHTTPExporter(config, tlsConfig)
+	default:
+		return newGRPCExporter(config, tlsConfig)
+	}
+}
+
+type grpcExporter struct {
+	conn     *grpc.ClientConn
+	logs     collogspb.LogsServiceClient
+	metrics  colmetricspb.MetricsServiceClient
+	metadata metadata.MD
+	callOpts []grpc.CallOption
+}
+
+func newGRPCExporter(config *otlpConfig, tlsConfig *tlscommon.TLSConfig) (*grpcExporter, error) {
+	transportCredentials := insecure.NewCredentials()
+	if tlsConfig != nil {
+		host := config.Endpoint
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig.BuildModuleClientConfig(host))
+	}
+
+	conn, err := grpc.Dial(config.Endpoint, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.Endpoint, err)
+	}
+
+	var callOpts []grpc.CallOption
+	if config.Compression == compressionGzip {
+		callOpts = append(callOpts, grpc.UseCompressor(grpcgzip.Name))
+	}
+
+	return &grpcExporter{
+		conn:     conn,
+		logs:     collogspb.NewLogsServiceClient(conn),
+		metrics:  colmetricspb.NewMetricsServiceClient(conn),
+		metadata: metadata.New(config.Headers),
+		callOpts: callOpts,
+	}, nil
+}
+
+func (e *grpcExporter) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (int64, error) {
+	ctx = metadata.NewOutgoingContext(ctx, e.metadata)
+	resp, err := e.logs.Export(ctx, req, e.callOpts...)
+	if err != nil {
+		return 0, grpcError(err)
+	}
+	return resp.GetPartialSuccess().GetRejectedLogRecords(), nil
+}
+
+func (e *grpcExporter) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (int64, error) {
+	ctx = metadata.NewOutgoingContext(ctx, e.metadata)
+	resp, err := e.metrics.Export(ctx, req, e.callOpts...)
+	if err != nil {
+		return 0, grpcError(err)
+	}
+	return resp.GetPartialSuccess().GetRejectedDataPoints(), nil
+}
+
+func (e *grpcExporter) Close() error {
+	return e.conn.Close()
+}
+
+// grpcError classifies the error of a call following the OTLP specification
+// about retryable response codes.
+func grpcError(err error) error {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return err
+	default:
+		return &permanentError{err: err}
+	}
+}
+
+type httpExporter struct {
+	client      *http.Client
+	logsURL     string
+	metricsURL  string
+	headers     map[string]string
+	compression string
+}
+
+func newHTTPExporter(config *otlpConfig, tlsConfig *tlscommon.TLSConfig) (*httpExporter, error) {
+	base, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", config.Endpoint, err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %q, an http or https URL is expected", config.Endpoint)
+	}
+
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.BuildModuleClientConfig(base.Hostname())
+	}
+
+	path := strings.TrimSuffix(base.Path, "/")
+	logsURL, metricsURL := *base, *base
+	logsURL.Path = path + "/v1/logs"
+	metricsURL.Path = path + "/v1/metrics"
+
+	return &httpExporter{
+		client:      &http.Client{Transport: transport, Timeout: config.Timeout},
+		logsURL:     logsURL.String(),
+		metricsURL:  metricsURL.String(),
+		headers:     config.Headers,
+		compression: config.Compression,
+	}, nil
+}
+
+func (e *httpExporter) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (int64, error) {
+	var resp collogspb.ExportLogsServiceResponse
+	if err := e.export(ctx, e.logsURL, req, &resp); err != nil {
+		return 0, err
+	}
+	return resp.GetPartialSuccess().GetRejectedLogRecords(), nil
+}
+
+func (e *httpExporter) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (int64, error) {
+	var resp colmetricspb.ExportMetricsServiceResponse
+	if err := e.export(ctx, e.metricsURL, req, &resp); err != nil {
+		return 0, err
+	}
+	return resp.GetPartialSuccess().GetRejectedDataPoints(), nil
+}
+
+func (e *httpExporter) export(ctx context.Context, url string, req, resp proto.Message) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return &permanentError{err: fmt.Errorf("failed to encode request: %w", err)}
+	}
+
+	if e.compression == compressionGzip {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(body); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	for name, value := range e.headers {
+		httpReq.Header.Set(name, value)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	if e.compression == compressionGzip {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
+
+	httpResp, err := e.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case httpResp.StatusCode >= 200 && httpResp.StatusCode < 300:
+		if err := proto.Unmarshal(respBody, resp); err != nil {
+			// The request was accepted, ignore invalid responses.
+			return nil
+		}
+		return nil
+	case httpResp.StatusCode == http.StatusTooManyRequests,
+		httpResp.StatusCode == http.StatusBadGateway,
+		httpResp.StatusCode == http.StatusServiceUnavailable,
+		httpResp.StatusCode == http.StatusGatewayTimeout:
+		return fmt.Errorf("request to %s failed with status code %d", url, httpResp.StatusCode)
+	default:
+		return &permanentError{err: fmt.Errorf("request to %s failed with status code %d", url, httpResp.StatusCode)}
+	}
+}
+
+func (e *httpExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -53,6 +53,7 @@ type Group struct {
 	Clients   []Client
 	BatchSize int
 	Retry     int
+	RateLimit RateLimit
 }
 
 // RegisterType registers a new output type.
@@ -81,8 +82,18 @@ func Load(
 		return Group{}, fmt.Errorf("output type %v undefined", name)
 	}
 
+	rateLimit, err := readRateLimit(config)
+	if err != nil {
+		return Group{}, fmt.Errorf("invalid rate_limit settings: %w", err)
+	}
+
 	if stats == nil {
 		stats = NewNilObserver()
 	}
-	return factory(im, info, stats, config)
+	group, err := factory(im, info, stats, config)
+	if err != nil {
+		return group, err
+	}
+	group.RateLimit = rateLimit
+	return group, nil
 }
@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// RateLimit configures the maximum rate the publisher pipeline publishes
+// events with to each host of an output. Limits set to 0 are disabled.
+type RateLimit struct {
+	EventsPerSecond float64          `config:"events_per_second" validate:"min=0"`
+	BytesPerSecond  cfgtype.ByteSize `config:"bytes_per_second" validate:"min=0"`
+}
+
+// Enabled returns true if any of the limits is set.
+func (r RateLimit) Enabled() bool {
+	return r.EventsPerSecond > 0 || r.BytesPerSecond > 0
+}
+
+// readRateLimit reads the rate_limit settings common to all outputs.
+func readRateLimit(cfg *config.C) (RateLimit, error) {
+	settings := struct {
+		RateLimit RateLimit `config:"rate_limit"`
+	}{}
+	if cfg == nil {
+		return settings.RateLimit, nil
+	}
+	err := cfg.Unpack(&settings)
+	return settings.RateLimit, err
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlsreload"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/beats/v7/libbeat/outputs/outil"
@@ -102,7 +103,7 @@ func makeRedis(
 		return outputs.Fail(err)
 	}
 
-	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	tls, err := tlsreload.LoadTLSConfig(config.TLS)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -131,7 +132,7 @@ func makeRedis(
 		transp := transport.Config{
 			Timeout: config.Timeout,
 			Proxy:   &config.Proxy,
-			TLS:     tls,
+			TLS:     tls.Get(),
 			Stats:   observer,
 		}
 
@@ -146,7 +147,11 @@ func makeRedis(
 			}
 		}
 
-		conn, err := transport.NewClient(transp, "tcp", hostUrl.Host, defaultPort)
+		dialer, err := tlsreload.MakeDialer(transp, tls)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		conn, err := transport.NewClientWithDialer(dialer, transp, "tcp", hostUrl.Host, defaultPort)
 		if err != nil {
 			return outputs.Fail(err)
 		}
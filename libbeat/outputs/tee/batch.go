@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tee
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// batch is the copy of a batch of events published to one of the outputs.
+// The output clients signal it like any other batch: retried events are
+// queued again for the same output, and done is called once when the
+// events are acknowledged, dropped, or the retries are exhausted.
+type batch struct {
+	output *output
+	events []publisher.Event
+
+	// ttl is the number of remaining retries, no limit if negative.
+	ttl int
+
+	once sync.Once
+	done func()
+}
+
+func newBatch(output *output, events []publisher.Event, done func()) *batch {
+	return &batch{
+		output: output,
+		events: events,
+		ttl:    output.retry,
+		done:   done,
+	}
+}
+
+func (b *batch) Events() []publisher.Event {
+	return b.events
+}
+
+func (b *batch) ACK() {
+	b.finish()
+}
+
+func (b *batch) Drop() {
+	b.finish()
+}
+
+func (b *batch) Retry() {
+	b.retry(b.events)
+}
+
+func (b *batch) RetryEvents(events []publisher.Event) {
+	b.retry(events)
+}
+
+func (b *batch) Cancelled() {
+	b.output.requeue(b)
+}
+
+func (b *batch) retry(events []publisher.Event) {
+	if b.ttl == 0 {
+		b.output.log.Errorf("Dropping %d events after exhausting the retries", len(events))
+		b.finish()
+		return
+	}
+	if b.ttl > 0 {
+		b.ttl--
+	}
+	b.events = events
+	b.output.requeue(b)
+}
+
+func (b *batch) finish() {
+	b.once.Do(func() {
+		if b.done != nil {
+			b.done()
+		}
+	})
+}
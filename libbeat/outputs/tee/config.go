@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tee
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// Policies applied to the batches of an output when its queue is full.
+const (
+	whenFullBlock = "block"
+	whenFullDrop  = "drop"
+	whenFullSpool = "spool"
+)
+
+type teeConfig struct {
+	Outputs   []config.Namespace `config:"outputs" validate:"required"`
+	QueueSize int                `config:"queue_size"`
+	WhenFull  string             `config:"when_full"`
+
+	// Spool is the configuration of the disk queues used by the spool
+	// policy, see the disk queue settings.
+	Spool *config.C `config:"spool"`
+}
+
+func defaultConfig() teeConfig {
+	return teeConfig{
+		QueueSize: 4,
+		WhenFull:  whenFullBlock,
+	}
+}
+
+func (c *teeConfig) Validate() error {
+	if len(c.Outputs) < 2 {
+		return errors.New("at least two outputs must be configured")
+	}
+	for i, out := range c.Outputs {
+		if !out.IsSet() {
+			return fmt.Errorf("output %d is not configured", i)
+		}
+		if out.Name() == "tee" {
+			return fmt.Errorf("output %d: tee outputs can't be nested", i)
+		}
+	}
+	if c.QueueSize < 1 {
+		return errors.New("queue_size must be at least 1")
+	}
+	switch c.WhenFull {
+	case whenFullBlock, whenFullDrop:
+	case whenFullSpool:
+		if c.Spool == nil {
+			return errors.New("the spool policy requires the spool settings")
+		}
+	default:
+		return fmt.Errorf("invalid when_full policy '%s'", c.WhenFull)
+	}
+	return nil
+}
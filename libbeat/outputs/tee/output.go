@@ -0,0 +1,223 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tee
+
+import (
+	"context"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// output publishes the batches of one of the outputs of the tee. The batches
+// are buffered in a queue of its own, and published by one worker per client
+// of the output, so that a slow output doesn't slow down the others until its
+// queue is full.
+type output struct {
+	log       *logp.Logger
+	clients   []outputs.Client
+	batchSize int
+	retry     int
+	whenFull  string
+
+	queue chan *batch
+
+	// retries are the batches returned by the clients, published before the
+	// queued ones. retried is signaled when a batch is added.
+	mu      sync.Mutex
+	retries []*batch
+	retried chan struct{}
+
+	// spool buffers the batches on disk when the queue is full, if the spool
+	// policy is used.
+	spool   *spool
+	spooled chan *batch
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newOutput(
+	log *logp.Logger,
+	group outputs.Group,
+	queueSize int,
+	whenFull string,
+	spool *spool,
+) *output {
+	o := &output{
+		log:       log,
+		clients:   group.Clients,
+		batchSize: group.BatchSize,
+		retry:     group.Retry,
+		whenFull:  whenFull,
+		queue:     make(chan *batch, queueSize),
+		retried:   make(chan struct{}, 1),
+		spool:     spool,
+		done:      make(chan struct{}),
+	}
+	if spool != nil {
+		o.spooled = make(chan *batch)
+	}
+	return o
+}
+
+func (o *output) start() {
+	for _, client := range o.clients {
+		o.wg.Add(1)
+		go o.run(client)
+	}
+	if o.spool != nil {
+		o.wg.Add(1)
+		go o.readSpool()
+	}
+}
+
+// publish queues the events, applying the when_full policy if the queue is
+// full. done is called once the events have been handled by the output.
+func (o *output) publish(events []publisher.Event, done func()) {
+	b := newBatch(o, events, done)
+
+	select {
+	case o.queue <- b:
+		return
+	default:
+	}
+
+	switch o.whenFull {
+	case whenFullDrop:
+		o.log.Warnf("Queue is full, dropping %d events", len(events))
+		b.Drop()
+	case whenFullSpool:
+		o.spool.write(events, b.finish)
+	default:
+		select {
+		case o.queue <- b:
+		case <-o.done:
+		}
+	}
+}
+
+func (o *output) requeue(b *batch) {
+	o.mu.Lock()
+	o.retries = append(o.retries, b)
+	o.mu.Unlock()
+
+	select {
+	case o.retried <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the next batch to publish, or nil if the output is closed.
+func (o *output) next() *batch {
+	for {
+		o.mu.Lock()
+		if len(o.retries) > 0 {
+			b := o.retries[0]
+			o.retries = o.retries[1:]
+			o.mu.Unlock()
+			return b
+		}
+		o.mu.Unlock()
+
+		select {
+		case <-o.done:
+			return nil
+		case <-o.retried:
+		case b := <-o.queue:
+			return b
+		case b := <-o.spooled:
+			return b
+		}
+	}
+}
+
+func (o *output) run(client outputs.Client) {
+	defer o.wg.Done()
+
+	netClient, isNetwork := client.(outputs.NetworkClient)
+	connected := !isNetwork
+	for {
+		b := o.next()
+		if b == nil {
+			return
+		}
+
+		if !connected {
+			// Return the batch to the other workers while connecting.
+			b.Cancelled()
+			if err := netClient.Connect(); err != nil {
+				o.log.Errorf("Failed to connect to %v: %v", client, err)
+				continue
+			}
+			o.log.Infof("Connection to %v established", client)
+			connected = true
+			continue
+		}
+
+		if err := client.Publish(context.Background(), b); err != nil {
+			o.log.Errorf("Failed to publish events to %v: %v", client, err)
+			connected = !isNetwork
+		}
+	}
+}
+
+func (o *output) readSpool() {
+	defer o.wg.Done()
+
+	for {
+		qb, err := o.spool.queue.Get(o.batchSize)
+		if err != nil {
+			// the spool is closed
+			return
+		}
+
+		events := make([]publisher.Event, 0, qb.Count())
+		for i := 0; i < qb.Count(); i++ {
+			if event, ok := qb.Entry(i).(publisher.Event); ok {
+				events = append(events, event)
+			}
+		}
+
+		select {
+		case o.spooled <- newBatch(o, events, qb.Done):
+		case <-o.done:
+			return
+		}
+	}
+}
+
+func (o *output) close() error {
+	close(o.done)
+	if o.spool != nil {
+		if err := o.spool.close(); err != nil {
+			o.log.Errorf("Failed to close the spool: %v", err)
+		}
+	}
+	o.wg.Wait()
+
+	var err error
+	for _, client := range o.clients {
+		if cerr := client.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tee
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// spool is the disk queue of an output, buffering its batches when its
+// queue is full. The batches are done once they have been written to disk.
+type spool struct {
+	queue    queue.Queue
+	producer queue.Producer
+
+	// writeMu keeps the events of concurrent writes in the order of pending.
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending []spooledBatch
+}
+
+type spooledBatch struct {
+	count int
+	done  func()
+}
+
+func newSpool(log *logp.Logger, settings diskqueue.Settings) (*spool, error) {
+	s := &spool{}
+	settings.WriteToDiskListener = s
+	q, err := diskqueue.NewQueue(log, settings)
+	if err != nil {
+		return nil, err
+	}
+	s.queue = q
+	s.producer = q.Producer(queue.ProducerConfig{})
+	return s, nil
+}
+
+func (s *spool) write(events []publisher.Event, done func()) {
+	if len(events) == 0 {
+		done()
+		return
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	s.pending = append(s.pending, spooledBatch{count: len(events), done: done})
+	s.mu.Unlock()
+
+	for _, event := range events {
+		if _, ok := s.producer.Publish(event); !ok {
+			// the spool is closed
+			return
+		}
+	}
+}
+
+// OnACK is called by the disk queue with the number of events written to
+// disk, in the order they were published.
+func (s *spool) OnACK(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for count > 0 && len(s.pending) > 0 {
+		p := &s.pending[0]
+		n := count
+		if n > p.count {
+			n = p.count
+		}
+		p.count -= n
+		count -= n
+
+		if p.count == 0 {
+			p.done()
+			s.pending = s.pending[1:]
+		}
+	}
+}
+
+func (s *spool) close() error {
+	s.producer.Cancel()
+	return s.queue.Close()
+}
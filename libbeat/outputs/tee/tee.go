@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+func init() {
+	outputs.RegisterType("tee", makeTee)
+}
+
+// client publishes every batch to all the outputs of the tee. The batch is
+// acknowledged once all the outputs have handled their copy of it.
+type client struct {
+	outputs []*output
+	names   []string
+}
+
+func makeTee(
+	im outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	cfgwarn.Beta("The tee output is beta.")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	c := &client{}
+	batchSize := 0
+	for i, out := range config.Outputs {
+		group, err := outputs.Load(im, beat, observer, out.Name(), out.Config())
+		if err != nil {
+			c.Close()
+			return outputs.Fail(fmt.Errorf("failed to load output %d (%s): %w", i, out.Name(), err))
+		}
+
+		log := logp.NewLogger("tee").With("output", fmt.Sprintf("%d-%s", i, out.Name()))
+		var spool *spool
+		if config.WhenFull == whenFullSpool {
+			spool, err = newOutputSpool(log, config.Spool, i, out.Name())
+			if err != nil {
+				c.Close()
+				return outputs.Fail(fmt.Errorf("failed to create the spool of output %d (%s): %w", i, out.Name(), err))
+			}
+		}
+
+		if i == 0 {
+			batchSize = group.BatchSize
+		}
+		c.outputs = append(c.outputs, newOutput(log, group, config.QueueSize, config.WhenFull, spool))
+		c.names = append(c.names, out.Name())
+	}
+
+	for _, o := range c.outputs {
+		o.start()
+	}
+
+	// The batches have the size of the first output, and are retried by the
+	// outputs themselves.
+	return outputs.Success(batchSize, -1, c)
+}
+
+// newOutputSpool creates the disk queue of an output, in a directory of its
+// own under the configured path.
+func newOutputSpool(log *logp.Logger, cfg *config.C, index int, name string) (*spool, error) {
+	settings, err := diskqueue.SettingsForUserConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Path == "" {
+		settings.Path = paths.Resolve(paths.Data, "tee")
+	}
+	settings.Path = filepath.Join(settings.Path, fmt.Sprintf("%d-%s", index, name))
+	return newSpool(log, settings)
+}
+
+func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+
+	pending := int32(len(c.outputs))
+	done := func() {
+		if atomic.AddInt32(&pending, -1) == 0 {
+			batch.ACK()
+		}
+	}
+
+	for i, o := range c.outputs {
+		// The outputs can modify the events, each one gets its own copy.
+		outputEvents := events
+		if i > 0 {
+			outputEvents = cloneEvents(events)
+		}
+		o.publish(outputEvents, done)
+	}
+	return nil
+}
+
+func cloneEvents(events []publisher.Event) []publisher.Event {
+	clones := make([]publisher.Event, len(events))
+	for i, event := range events {
+		clones[i] = publisher.Event{
+			Content: *event.Content.Clone(),
+			Flags:   event.Flags,
+		}
+	}
+	return clones
+}
+
+func (c *client) Close() error {
+	var errs []string
+	for i, o := range c.outputs {
+		if err := o.close(); err != nil {
+			errs = append(errs, fmt.Sprintf("output %d (%s): %v", i, c.names[i], err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (c *client) String() string {
+	return "tee(" + strings.Join(c.names, ",") + ")"
+}
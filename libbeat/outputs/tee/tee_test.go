@@ -0,0 +1,259 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tee
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// mockClient acknowledges the batches once they are allowed by the gate, if
+// set, and retries the first failures batches.
+type mockClient struct {
+	gate     chan struct{}
+	failures int
+
+	mu        sync.Mutex
+	published []publisher.Event
+}
+
+func (m *mockClient) Close() error { return nil }
+
+func (m *mockClient) Publish(_ context.Context, batch publisher.Batch) error {
+	if m.gate != nil {
+		<-m.gate
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failures > 0 {
+		m.failures--
+		batch.Retry()
+		return nil
+	}
+	m.published = append(m.published, batch.Events()...)
+	batch.ACK()
+	return nil
+}
+
+func (m *mockClient) String() string { return "mock" }
+
+func (m *mockClient) events() []publisher.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]publisher.Event(nil), m.published...)
+}
+
+func newTestOutput(t *testing.T, client *mockClient, retry int, whenFull string, spool *spool) *output {
+	group, err := outputs.Success(10, retry, client)
+	require.NoError(t, err)
+	o := newOutput(logp.NewLogger("tee"), group, 1, whenFull, spool)
+	o.start()
+	t.Cleanup(func() { o.close() })
+	return o
+}
+
+func testEvents(n int) []publisher.Event {
+	events := make([]publisher.Event, n)
+	for i := range events {
+		events[i] = publisher.Event{Content: beat.Event{
+			Timestamp: time.Now(),
+			Fields:    mapstr.M{"message": "test", "n": i},
+		}}
+	}
+	return events
+}
+
+func TestConfigValidate(t *testing.T) {
+	outputsConfig := []map[string]interface{}{
+		{"console": map[string]interface{}{}},
+		{"console": map[string]interface{}{}},
+	}
+
+	cases := map[string]struct {
+		config map[string]interface{}
+		err    bool
+	}{
+		"defaults": {
+			config: map[string]interface{}{"outputs": outputsConfig},
+		},
+		"single output": {
+			config: map[string]interface{}{"outputs": outputsConfig[:1]},
+			err:    true,
+		},
+		"nested tee": {
+			config: map[string]interface{}{
+				"outputs": []map[string]interface{}{
+					{"console": map[string]interface{}{}},
+					{"tee": map[string]interface{}{}},
+				},
+			},
+			err: true,
+		},
+		"drop": {
+			config: map[string]interface{}{"outputs": outputsConfig, "when_full": "drop"},
+		},
+		"spool without settings": {
+			config: map[string]interface{}{"outputs": outputsConfig, "when_full": "spool"},
+			err:    true,
+		},
+		"spool": {
+			config: map[string]interface{}{
+				"outputs":          outputsConfig,
+				"when_full":        "spool",
+				"spool.max_size":   "100MB",
+				"spool.read_ahead": 10,
+			},
+		},
+		"invalid policy": {
+			config: map[string]interface{}{"outputs": outputsConfig, "when_full": "wait"},
+			err:    true,
+		},
+		"invalid queue size": {
+			config: map[string]interface{}{"outputs": outputsConfig, "queue_size": 0},
+			err:    true,
+		},
+	}
+
+	for name, test := range cases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig()
+			err := config.MustNewConfigFrom(test.config).Unpack(&c)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPublishAllOutputs(t *testing.T) {
+	first, second := &mockClient{}, &mockClient{failures: 2}
+	c := &client{
+		outputs: []*output{
+			newTestOutput(t, first, 3, whenFullBlock, nil),
+			newTestOutput(t, second, 3, whenFullBlock, nil),
+		},
+		names: []string{"first", "second"},
+	}
+
+	signals := make(chan outest.BatchSignal, 1)
+	var in []beat.Event
+	for _, event := range testEvents(5) {
+		in = append(in, event.Content)
+	}
+	batch := outest.NewBatch(in...)
+	batch.OnSignal = func(sig outest.BatchSignal) { signals <- sig }
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	select {
+	case sig := <-signals:
+		assert.Equal(t, outest.BatchACK, sig.Tag)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the batch has not been acknowledged")
+	}
+	assert.Len(t, first.events(), 5)
+	assert.Len(t, second.events(), 5)
+
+	// every output gets its own copy of the events
+	first.events()[0].Content.Fields["message"] = "modified"
+	assert.Equal(t, "test", second.events()[0].Content.Fields["message"])
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	client := &mockClient{failures: 5}
+	o := newTestOutput(t, client, 2, whenFullBlock, nil)
+
+	done := make(chan struct{})
+	o.publish(testEvents(1), func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the batch has not been dropped")
+	}
+	assert.Empty(t, client.events())
+}
+
+func TestWhenFullDrop(t *testing.T) {
+	client := &mockClient{gate: make(chan struct{})}
+	o := newTestOutput(t, client, 3, whenFullDrop, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	// the first batch is blocked in the client, the second one fills the
+	// queue and the third one is dropped.
+	o.publish(testEvents(1), wg.Done)
+	require.Eventually(t, func() bool { return len(o.queue) == 0 }, 10*time.Second, time.Millisecond)
+	o.publish(testEvents(1), wg.Done)
+	o.publish(testEvents(1), wg.Done)
+
+	close(client.gate)
+	wg.Wait()
+	assert.Len(t, client.events(), 2)
+}
+
+func TestWhenFullSpool(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"path":     t.TempDir(),
+		"max_size": "100MB",
+	})
+	spool, err := newOutputSpool(logp.NewLogger("tee"), cfg, 0, "mock")
+	require.NoError(t, err)
+
+	client := &mockClient{gate: make(chan struct{})}
+	o := newTestOutput(t, client, 3, whenFullSpool, spool)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	o.publish(testEvents(1), wg.Done)
+	require.Eventually(t, func() bool { return len(o.queue) == 0 }, 10*time.Second, time.Millisecond)
+	o.publish(testEvents(1), wg.Done)
+
+	// the spooled batch is done once written to disk, without waiting
+	// for the client
+	spooled := make(chan struct{})
+	o.publish(testEvents(3), func() {
+		close(spooled)
+		wg.Done()
+	})
+	select {
+	case <-spooled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the batch has not been spooled")
+	}
+
+	close(client.gate)
+	wg.Wait()
+	require.Eventually(t, func() bool { return len(client.events()) == 5 }, 10*time.Second, time.Millisecond)
+}
@@ -29,6 +29,7 @@ import (
 	k8sclient "k8s.io/client-go/kubernetes"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/k8swatcher"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
 	"github.com/elastic/elastic-agent-autodiscover/kubernetes/metadata"
@@ -46,6 +47,8 @@ const (
 type kubernetesAnnotator struct {
 	log                 *logp.Logger
 	watcher             kubernetes.Watcher
+	nodeWatcher         kubernetes.Watcher
+	namespaceWatcher    kubernetes.Watcher
 	indexers            *Indexers
 	matchers            *Matchers
 	cache               *cache
@@ -142,7 +145,7 @@ func newProcessorConfig(cfg *config.C, register *Register) (kubeAnnotatorConfig,
 
 func (k *kubernetesAnnotator) init(config kubeAnnotatorConfig, cfg *config.C) {
 	k.initOnce.Do(func() {
-		client, err := kubernetes.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
+		client, err := k8swatcher.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
 		if err != nil {
 			if kubernetes.IsInCluster(config.KubeConfig) {
 				k.log.Debugf("Could not create kubernetes client using in_cluster config: %+v", err)
@@ -181,7 +184,7 @@ func (k *kubernetesAnnotator) init(config kubeAnnotatorConfig, cfg *config.C) {
 			k.log.Debugf("Initializing a new Kubernetes watcher using host: %s", config.Node)
 		}
 
-		watcher, err := kubernetes.NewNamedWatcher("add_kubernetes_metadata_pod", client, &kubernetes.Pod{}, kubernetes.WatchOptions{
+		watcher, err := k8swatcher.NewNamedWatcher("add_kubernetes_metadata_pod", client, &kubernetes.Pod{}, kubernetes.WatchOptions{
 			SyncTimeout: config.SyncPeriod,
 			Node:        config.Node,
 			Namespace:   config.Namespace,
@@ -199,11 +202,11 @@ func (k *kubernetesAnnotator) init(config kubeAnnotatorConfig, cfg *config.C) {
 			Namespace:   config.Namespace,
 		}
 
-		nodeWatcher, err := kubernetes.NewNamedWatcher("add_kubernetes_metadata_node", client, &kubernetes.Node{}, options, nil)
+		nodeWatcher, err := k8swatcher.NewNamedWatcher("add_kubernetes_metadata_node", client, &kubernetes.Node{}, options, nil)
 		if err != nil {
 			k.log.Errorf("couldn't create watcher for %T due to error %+v", &kubernetes.Node{}, err)
 		}
-		namespaceWatcher, err := kubernetes.NewNamedWatcher("add_kubernetes_metadata_namespace", client, &kubernetes.Namespace{}, kubernetes.WatchOptions{
+		namespaceWatcher, err := k8swatcher.NewNamedWatcher("add_kubernetes_metadata_namespace", client, &kubernetes.Namespace{}, kubernetes.WatchOptions{
 			SyncTimeout: config.SyncPeriod,
 		}, nil)
 		if err != nil {
@@ -214,6 +217,8 @@ func (k *kubernetesAnnotator) init(config kubeAnnotatorConfig, cfg *config.C) {
 
 		k.indexers = NewIndexers(config.Indexers, metaGen)
 		k.watcher = watcher
+		k.nodeWatcher = nodeWatcher
+		k.namespaceWatcher = namespaceWatcher
 		k.kubernetesAvailable = true
 
 		watcher.AddEventHandler(kubernetes.ResourceEventHandlerFuncs{
@@ -307,6 +312,12 @@ func (k *kubernetesAnnotator) Close() error {
 	if k.watcher != nil {
 		k.watcher.Stop()
 	}
+	if k.nodeWatcher != nil {
+		k.nodeWatcher.Stop()
+	}
+	if k.namespaceWatcher != nil {
+		k.namespaceWatcher.Stop()
+	}
 	if k.cache != nil {
 		k.cache.stop()
 	}
@@ -0,0 +1,404 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/atomic"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+const (
+	processorName = "aggregate"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName, New)
+}
+
+type metrics struct {
+	Aggregated *monitoring.Int // Events absorbed into a window.
+	Emitted    *monitoring.Int // Summary and correlated events emitted.
+	Unmatched  *monitoring.Int // Start events emitted without end event.
+	Overflow   *monitoring.Int // Events passed through because max_keys is reached.
+	Pending    *monitoring.Int // Events waiting to be emitted.
+	Dropped    *monitoring.Int // Events dropped because max_pending is reached.
+}
+
+// aggregate groups the events by key in windows. As processors can only
+// return a single event, the events produced when a window ends are queued,
+// and emitted one per call in place of the next events processed. Windows
+// are only ended when an event is processed.
+type aggregate struct {
+	config     config
+	start, end conditions.Condition
+	logger     *logp.Logger
+	metrics    metrics
+	clock      clockwork.Clock
+
+	mu      sync.Mutex
+	windows map[string]*window
+	order   []*window     // Windows in expiration order.
+	pending []*beat.Event // Events of ended windows waiting to be emitted.
+}
+
+// window is the state of a key.
+type window struct {
+	key     string
+	expires time.Time
+
+	// State of the summary mode.
+	values      []interface{}
+	first, last time.Time
+	count       int
+	sums        map[string]float64
+	distinct    map[string]*distinctValues
+
+	// State of the correlate mode.
+	start *beat.Event
+}
+
+type distinctValues struct {
+	seen   map[string]struct{}
+	values []interface{}
+}
+
+// New constructs a new aggregate processor.
+func New(cfg *conf.C) (processors.Processor, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("could not unpack %v processor configuration: %w", processorName, err)
+	}
+
+	// Logging and metrics (each processor instance has a unique ID).
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger(logName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	p := &aggregate{
+		config: config,
+		logger: log,
+		metrics: metrics{
+			Aggregated: monitoring.NewInt(reg, "aggregated"),
+			Emitted:    monitoring.NewInt(reg, "emitted"),
+			Unmatched:  monitoring.NewInt(reg, "unmatched"),
+			Overflow:   monitoring.NewInt(reg, "overflow"),
+			Pending:    monitoring.NewInt(reg, "pending"),
+			Dropped:    monitoring.NewInt(reg, "dropped"),
+		},
+		clock:   clockwork.NewRealClock(),
+		windows: map[string]*window{},
+	}
+
+	if config.Mode == modeCorrelate {
+		var err error
+		if p.start, err = conditions.NewCondition(config.Start); err != nil {
+			return nil, fmt.Errorf("invalid start condition: %w", err)
+		}
+		if p.end, err = conditions.NewCondition(config.End); err != nil {
+			return nil, fmt.Errorf("invalid end condition: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// Run adds the event to the window of its key. Events without key, or not
+// matching the start or end conditions in correlate mode, are returned
+// unchanged. When the event is aggregated, the oldest pending event is
+// returned in its place, if any.
+func (p *aggregate) Run(event *beat.Event) (*beat.Event, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	p.expire(now)
+
+	var out *beat.Event
+	if p.config.Mode == modeCorrelate {
+		out = p.correlate(event, now)
+	} else {
+		out = p.summarize(event, now)
+	}
+
+	if out == nil && len(p.pending) > 0 {
+		out = p.pending[0]
+		p.pending[0] = nil
+		p.pending = p.pending[1:]
+	}
+	p.metrics.Pending.Set(int64(len(p.pending)))
+	return out, nil
+}
+
+// enqueue queues an event of an ended window. It returns false if the event
+// is dropped because the maximum number of pending events is reached.
+func (p *aggregate) enqueue(event *beat.Event) bool {
+	if len(p.pending) >= p.config.MaxPending {
+		p.metrics.Dropped.Inc()
+		p.logger.Debugf("maximum number of pending events (%d) reached, event is dropped", p.config.MaxPending)
+		return false
+	}
+	p.pending = append(p.pending, event)
+	return true
+}
+
+// expire ends the windows expired at now.
+func (p *aggregate) expire(now time.Time) {
+	for len(p.order) > 0 && !p.order[0].expires.After(now) {
+		w := p.order[0]
+		p.order[0] = nil
+		p.order = p.order[1:]
+
+		// The window was already ended if it's no longer the window of its key.
+		if p.windows[w.key] != w {
+			continue
+		}
+		delete(p.windows, w.key)
+
+		if p.config.Mode == modeCorrelate {
+			if p.enqueue(w.start) {
+				p.metrics.Unmatched.Inc()
+			}
+		} else {
+			if p.enqueue(p.summary(w)) {
+				p.metrics.Emitted.Inc()
+			}
+		}
+	}
+}
+
+// newWindow creates the window of a key, or returns nil if the maximum
+// number of keys is reached.
+func (p *aggregate) newWindow(key string, values []interface{}, now time.Time) *window {
+	if len(p.windows) >= p.config.MaxKeys {
+		p.metrics.Overflow.Inc()
+		p.logger.Debugf("maximum number of keys (%d) reached, event is not aggregated", p.config.MaxKeys)
+		return nil
+	}
+
+	w := &window{key: key, values: values, expires: now.Add(p.config.Window)}
+	p.windows[key] = w
+	p.order = append(p.order, w)
+	return w
+}
+
+func (p *aggregate) summarize(event *beat.Event, now time.Time) *beat.Event {
+	key, values, ok := p.makeKey(event)
+	if !ok {
+		return event
+	}
+
+	w := p.windows[key]
+	if w == nil {
+		if w = p.newWindow(key, values, now); w == nil {
+			return event
+		}
+		w.first = event.Timestamp
+		w.sums = map[string]float64{}
+		w.distinct = map[string]*distinctValues{}
+	}
+
+	w.count++
+	if event.Timestamp.Before(w.first) {
+		w.first = event.Timestamp
+	}
+	if event.Timestamp.After(w.last) {
+		w.last = event.Timestamp
+	}
+	for _, field := range p.config.Sum {
+		if v, err := event.GetValue(field); err == nil {
+			if f, ok := toFloat(v); ok {
+				w.sums[field] += f
+			}
+		}
+	}
+	for _, field := range p.config.Distinct {
+		v, err := event.GetValue(field)
+		if err != nil {
+			continue
+		}
+		d := w.distinct[field]
+		if d == nil {
+			d = &distinctValues{seen: map[string]struct{}{}}
+			w.distinct[field] = d
+		}
+		if len(d.values) >= p.config.MaxValues {
+			continue
+		}
+		s := fmt.Sprint(v)
+		if _, found := d.seen[s]; !found {
+			d.seen[s] = struct{}{}
+			d.values = append(d.values, v)
+		}
+	}
+
+	p.metrics.Aggregated.Inc()
+	return nil
+}
+
+// summary creates the summary event of a window.
+func (p *aggregate) summary(w *window) *beat.Event {
+	event := &beat.Event{Timestamp: w.first, Fields: mapstr.M{}}
+	for i, field := range p.config.Fields {
+		_, _ = event.PutValue(field, w.values[i])
+	}
+	_, _ = event.PutValue("event.start", w.first)
+	_, _ = event.PutValue("event.end", w.last)
+	_, _ = event.PutValue(p.config.Target+".count", w.count)
+	for field, sum := range w.sums {
+		_, _ = event.PutValue(p.config.Target+".sum."+field, sum)
+	}
+	for field, d := range w.distinct {
+		_, _ = event.PutValue(p.config.Target+".distinct."+field, d.values)
+	}
+	return event
+}
+
+func (p *aggregate) correlate(event *beat.Event, now time.Time) *beat.Event {
+	isEnd := p.end.Check(event)
+	isStart := p.start.Check(event)
+	if !isEnd && !isStart {
+		return event
+	}
+	key, values, ok := p.makeKey(event)
+	if !ok {
+		return event
+	}
+
+	w := p.windows[key]
+	if isEnd && w != nil {
+		delete(p.windows, key)
+		p.metrics.Aggregated.Inc()
+		p.metrics.Emitted.Inc()
+		return correlated(w.start, event)
+	}
+	if !isStart {
+		return event
+	}
+
+	if w != nil {
+		// A new start event replaces the previous one, which is emitted
+		// unmatched.
+		delete(p.windows, key)
+		if p.enqueue(w.start) {
+			p.metrics.Unmatched.Inc()
+		}
+	}
+	if w = p.newWindow(key, values, now); w == nil {
+		return event
+	}
+	w.start = event
+	p.metrics.Aggregated.Inc()
+	return nil
+}
+
+// correlated enriches the end event with the fields of the start event and
+// the duration between them.
+func correlated(start, end *beat.Event) *beat.Event {
+	end.Fields.DeepUpdateNoOverwrite(start.Fields)
+	_, _ = end.PutValue("event.start", start.Timestamp)
+	_, _ = end.PutValue("event.end", end.Timestamp)
+	_, _ = end.PutValue("event.duration", end.Timestamp.Sub(start.Timestamp).Nanoseconds())
+	return end
+}
+
+// makeKey returns the key of an event and the values of the key fields.
+// It returns false if any of the key fields is missing.
+func (p *aggregate) makeKey(event *beat.Event) (string, []interface{}, bool) {
+	var key strings.Builder
+	values := make([]interface{}, len(p.config.Fields))
+	for i, field := range p.config.Fields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			return "", nil, false
+		}
+		values[i] = v
+		fmt.Fprint(&key, v)
+		key.WriteByte(0)
+	}
+	return key.String(), values, true
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// Close discards the aggregations in progress and the pending events, as they
+// cannot be emitted anymore.
+func (p *aggregate) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.windows) > 0 || len(p.pending) > 0 {
+		p.logger.Warnf("Discarding %d aggregations in progress and %d pending events.", len(p.windows), len(p.pending))
+	}
+	p.windows = map[string]*window{}
+	p.order = nil
+	p.pending = nil
+	p.metrics.Pending.Set(0)
+	return nil
+}
+
+func (p *aggregate) String() string {
+	return fmt.Sprintf("%v=[mode=%v, fields=%v, window=%v]",
+		processorName, p.config.Mode, p.config.Fields, p.config.Window)
+}
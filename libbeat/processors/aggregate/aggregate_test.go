@@ -0,0 +1,248 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var t0 = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestAggregate(t *testing.T, settings mapstr.M) (*aggregate, clockwork.FakeClock) {
+	p, err := New(conf.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	clock := clockwork.NewFakeClockAt(t0)
+	p.(*aggregate).clock = clock
+	return p.(*aggregate), clock
+}
+
+func run(t *testing.T, p *aggregate, ts time.Time, fields mapstr.M) *beat.Event {
+	event, err := p.Run(&beat.Event{Timestamp: ts, Fields: fields})
+	require.NoError(t, err)
+	return event
+}
+
+func TestSummary(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{
+		"fields":   []string{"source.ip"},
+		"window":   "10s",
+		"sum":      []string{"network.bytes"},
+		"distinct": []string{"destination.port"},
+	})
+
+	flow := func(ip string, port, bytes int) mapstr.M {
+		return mapstr.M{
+			"source":      mapstr.M{"ip": ip},
+			"destination": mapstr.M{"port": port},
+			"network":     mapstr.M{"bytes": bytes},
+		}
+	}
+
+	assert.Nil(t, run(t, p, t0, flow("10.0.0.1", 80, 100)))
+	assert.Nil(t, run(t, p, t0.Add(time.Second), flow("10.0.0.1", 443, 50)))
+	assert.Nil(t, run(t, p, t0.Add(2*time.Second), flow("10.0.0.1", 80, 10)))
+	assert.Nil(t, run(t, p, t0.Add(5*time.Second), flow("10.0.0.2", 22, 1)))
+
+	// Events without key are not aggregated.
+	event := run(t, p, t0, mapstr.M{"message": "hello"})
+	assert.Equal(t, mapstr.M{"message": "hello"}, event.Fields)
+
+	// The summary is emitted in place of an event absorbed after the window end.
+	clock.Advance(10 * time.Second)
+	summary := run(t, p, t0.Add(10*time.Second), flow("10.0.0.1", 80, 1))
+	require.NotNil(t, summary)
+	assert.Equal(t, t0, summary.Timestamp)
+	assert.Equal(t, mapstr.M{
+		"source": mapstr.M{"ip": "10.0.0.1"},
+		"event":  mapstr.M{"start": t0, "end": t0.Add(2 * time.Second)},
+		"aggregate": mapstr.M{
+			"count":    3,
+			"sum":      mapstr.M{"network": mapstr.M{"bytes": 160.0}},
+			"distinct": mapstr.M{"destination": mapstr.M{"port": []interface{}{80, 443}}},
+		},
+	}, summary.Fields)
+
+	clock.Advance(5 * time.Second)
+	summary = run(t, p, t0.Add(15*time.Second), flow("10.0.0.3", 80, 1))
+	require.NotNil(t, summary)
+	v, _ := summary.GetValue("source.ip")
+	assert.Equal(t, "10.0.0.2", v)
+
+	assert.EqualValues(t, 6, p.metrics.Aggregated.Get())
+	assert.EqualValues(t, 2, p.metrics.Emitted.Get())
+}
+
+func TestSummaryMaxKeys(t *testing.T) {
+	p, _ := newTestAggregate(t, mapstr.M{"fields": []string{"host"}, "max_keys": 1})
+
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "a"}))
+	assert.NotNil(t, run(t, p, t0, mapstr.M{"host": "b"}))
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "a"}))
+	assert.EqualValues(t, 1, p.metrics.Overflow.Get())
+}
+
+func TestSummaryPending(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{"fields": []string{"host"}, "window": "10s"})
+
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "a"}))
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "b"}))
+
+	// Pending summaries are emitted in place of aggregated events.
+	clock.Advance(10 * time.Second)
+	event := run(t, p, t0.Add(10*time.Second), mapstr.M{"host": "c"})
+	assert.Equal(t, "a", event.Fields["host"])
+	assert.EqualValues(t, 1, p.metrics.Pending.Get())
+	event = run(t, p, t0.Add(10*time.Second), mapstr.M{"host": "c"})
+	assert.Equal(t, "b", event.Fields["host"])
+	assert.Nil(t, run(t, p, t0.Add(10*time.Second), mapstr.M{"host": "c"}))
+	assert.EqualValues(t, 0, p.metrics.Pending.Get())
+}
+
+func TestSummaryPendingPassthrough(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{"fields": []string{"host"}, "window": "10s", "max_keys": 2})
+
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "a"}))
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "b"}))
+
+	// Events over max_keys are returned right away.
+	event := run(t, p, t0, mapstr.M{"host": "c"})
+	assert.Equal(t, "c", event.Fields["host"])
+
+	// Events without key are never held behind the pending summaries.
+	clock.Advance(10 * time.Second)
+	for i := 0; i < 3; i++ {
+		event = run(t, p, t0.Add(10*time.Second), mapstr.M{"message": "no key"})
+		assert.Equal(t, mapstr.M{"message": "no key"}, event.Fields)
+	}
+	assert.EqualValues(t, 2, p.metrics.Pending.Get())
+
+	// Only the pending summaries are discarded on close.
+	require.NoError(t, p.Close())
+	assert.EqualValues(t, 0, p.metrics.Pending.Get())
+	assert.EqualValues(t, 2, p.metrics.Emitted.Get())
+}
+
+func TestSummaryMaxPending(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{"fields": []string{"host"}, "window": "10s", "max_pending": 1})
+
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "a"}))
+	assert.Nil(t, run(t, p, t0, mapstr.M{"host": "b"}))
+
+	clock.Advance(10 * time.Second)
+	event := run(t, p, t0.Add(10*time.Second), mapstr.M{"host": "c"})
+	assert.Equal(t, "a", event.Fields["host"])
+	assert.Nil(t, run(t, p, t0.Add(10*time.Second), mapstr.M{"host": "c"}))
+	assert.EqualValues(t, 1, p.metrics.Dropped.Get())
+	assert.EqualValues(t, 1, p.metrics.Emitted.Get())
+}
+
+func TestCorrelateMaxPending(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{
+		"mode":        "correlate",
+		"fields":      []string{"id"},
+		"window":      "10s",
+		"max_pending": 1,
+		"start":       mapstr.M{"equals": mapstr.M{"action": "start"}},
+		"end":         mapstr.M{"equals": mapstr.M{"action": "end"}},
+	})
+
+	assert.Nil(t, run(t, p, t0, mapstr.M{"id": "1", "action": "start"}))
+	assert.Nil(t, run(t, p, t0, mapstr.M{"id": "2", "action": "start"}))
+
+	// Unmatched start events that are dropped are not counted as unmatched.
+	clock.Advance(10 * time.Second)
+	event := run(t, p, t0.Add(10*time.Second), mapstr.M{"id": "3", "action": "start"})
+	assert.Equal(t, "1", event.Fields["id"])
+	assert.EqualValues(t, 1, p.metrics.Unmatched.Get())
+	assert.EqualValues(t, 1, p.metrics.Dropped.Get())
+}
+
+func TestCorrelate(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{
+		"mode":   "correlate",
+		"fields": []string{"transaction.id"},
+		"window": "1m",
+		"start":  mapstr.M{"equals": mapstr.M{"event.action": "start"}},
+		"end":    mapstr.M{"equals": mapstr.M{"event.action": "end"}},
+	})
+
+	tx := func(id, action string, extra mapstr.M) mapstr.M {
+		fields := mapstr.M{"transaction": mapstr.M{"id": id}, "event": mapstr.M{"action": action}}
+		fields.DeepUpdate(extra)
+		return fields
+	}
+
+	assert.Nil(t, run(t, p, t0, tx("1", "start", mapstr.M{"user": mapstr.M{"name": "jane"}})))
+
+	// Other events are not modified.
+	event := run(t, p, t0, mapstr.M{"event": mapstr.M{"action": "other"}})
+	assert.Equal(t, mapstr.M{"event": mapstr.M{"action": "other"}}, event.Fields)
+
+	// End events without start are not modified.
+	event = run(t, p, t0, tx("2", "end", nil))
+	assert.Equal(t, tx("2", "end", nil), event.Fields)
+
+	event = run(t, p, t0.Add(3*time.Second), tx("1", "end", mapstr.M{"http": mapstr.M{"status": 200}}))
+	assert.Equal(t, mapstr.M{
+		"transaction": mapstr.M{"id": "1"},
+		"user":        mapstr.M{"name": "jane"},
+		"http":        mapstr.M{"status": 200},
+		"event": mapstr.M{
+			"action":   "end",
+			"start":    t0,
+			"end":      t0.Add(3 * time.Second),
+			"duration": int64(3 * time.Second),
+		},
+	}, event.Fields)
+
+	// Start events without end are emitted unmatched after the window.
+	assert.Nil(t, run(t, p, t0, tx("3", "start", nil)))
+	clock.Advance(time.Minute)
+	event = run(t, p, t0.Add(time.Minute), tx("4", "start", nil))
+	require.NotNil(t, event)
+	assert.Equal(t, tx("3", "start", nil), event.Fields)
+	assert.EqualValues(t, 1, p.metrics.Unmatched.Get())
+	assert.EqualValues(t, 1, p.metrics.Emitted.Get())
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"no fields":                  {},
+		"unknown mode":               {"fields": []string{"a"}, "mode": "join"},
+		"correlate without start":    {"fields": []string{"a"}, "mode": "correlate", "end": mapstr.M{"has_fields": []string{"b"}}},
+		"summary with conditions":    {"fields": []string{"a"}, "start": mapstr.M{"has_fields": []string{"b"}}},
+		"correlate with aggregation": {"fields": []string{"a"}, "mode": "correlate", "start": mapstr.M{"has_fields": []string{"b"}}, "end": mapstr.M{"has_fields": []string{"c"}}, "sum": []string{"d"}},
+		"invalid window":             {"fields": []string{"a"}, "window": "-1s"},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(settings))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/conditions"
+)
+
+// Aggregation modes.
+const (
+	modeSummary   = "summary"
+	modeCorrelate = "correlate"
+)
+
+// config for the aggregate processor.
+type config struct {
+	Mode       string        `config:"mode"`
+	Fields     []string      `config:"fields" validate:"required"`
+	Window     time.Duration `config:"window" validate:"positive"`
+	MaxKeys    int           `config:"max_keys" validate:"min=1"`
+	Target     string        `config:"target"`
+	Sum        []string      `config:"sum"`
+	Distinct   []string      `config:"distinct"`
+	MaxValues  int           `config:"max_distinct_values" validate:"min=1"`
+	MaxPending int           `config:"max_pending" validate:"min=1"`
+
+	// Conditions matching the start and end events in correlate mode.
+	Start *conditions.Config `config:"start"`
+	End   *conditions.Config `config:"end"`
+}
+
+func defaultConfig() config {
+	return config{
+		Mode:       modeSummary,
+		Window:     time.Minute,
+		MaxKeys:    10000,
+		Target:     "aggregate",
+		MaxValues:  100,
+		MaxPending: 10000,
+	}
+}
+
+func (c *config) Validate() error {
+	c.Mode = strings.ToLower(c.Mode)
+	switch c.Mode {
+	case modeSummary:
+		if c.Start != nil || c.End != nil {
+			return errors.New("start and end conditions are only supported in correlate mode")
+		}
+	case modeCorrelate:
+		if c.Start == nil || c.End == nil {
+			return errors.New("start and end conditions are required in correlate mode")
+		}
+		if len(c.Sum) > 0 || len(c.Distinct) > 0 {
+			return errors.New("sum and distinct are only supported in summary mode")
+		}
+	default:
+		return fmt.Errorf("invalid mode '%v' (valid values are: summary, correlate)", c.Mode)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package enrich
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type lookupRecord struct {
+	doc     mapstr.M // nil if no document matched the lookup key
+	expires time.Time
+}
+
+func (r lookupRecord) IsExpired(now time.Time) bool {
+	return now.After(r.expires)
+}
+
+// lookupCache caches the documents found for each lookup key.
+type lookupCache struct {
+	sync.RWMutex
+	data    map[string]lookupRecord
+	maxSize int
+	ttl     time.Duration
+}
+
+func newLookupCache(conf cacheConfig) *lookupCache {
+	return &lookupCache{
+		data:    map[string]lookupRecord{},
+		maxSize: conf.MaxSize,
+		ttl:     conf.TTL,
+	}
+}
+
+func (c *lookupCache) set(now time.Time, key string, doc mapstr.M) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, found := c.data[key]; !found && len(c.data) >= c.maxSize {
+		c.evict()
+	}
+
+	c.data[key] = lookupRecord{
+		doc:     doc,
+		expires: now.Add(c.ttl),
+	}
+}
+
+// evict removes a single random key from the cache.
+func (c *lookupCache) evict() {
+	var key string
+	for k := range c.data {
+		key = k
+		break
+	}
+	delete(c.data, key)
+}
+
+// get returns the document cached for key, and whether the key was found.
+func (c *lookupCache) get(now time.Time, key string) (mapstr.M, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	r, found := c.data[key]
+	if found && !r.IsExpired(now) {
+		return r.doc, true
+	}
+	return nil, false
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package enrich
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// config defines the configuration options for the enrich processor. The
+// settings of the connection to Elasticsearch are the same as those of the
+// Elasticsearch output, and are read by the Elasticsearch client.
+type config struct {
+	Index         string      `config:"index" validate:"required"`        // Index the documents are looked up from.
+	Field         string      `config:"field" validate:"required"`        // Event field holding the lookup key.
+	LookupField   string      `config:"lookup_field" validate:"required"` // Document field matched against the lookup key.
+	Fields        mapstr.M    `config:"fields" validate:"required"`       // Mapping of document fields to event fields.
+	OverwriteKeys bool        `config:"overwrite_keys"`                   // Overwrite event fields that already exist.
+	IgnoreMissing bool        `config:"ignore_missing"`                   // Ignore events without lookup key.
+	TagOnFailure  []string    `config:"tag_on_failure"`                   // Tags to append when a lookup fails.
+	Cache         cacheConfig `config:"cache"`
+
+	fieldsFlat map[string]string
+}
+
+// cacheConfig defines the caching behavior of the lookup results.
+type cacheConfig struct {
+	// TTL of the lookup results in the cache, including documents not found.
+	TTL time.Duration `config:"ttl" validate:"min=1ns"`
+
+	// Max size of the cache. When the size is reached a random item is
+	// evicted from the cache.
+	MaxSize int `config:"max_size" validate:"min=1"`
+}
+
+func defaultConfig() config {
+	return config{
+		TagOnFailure: []string{"_enrich_lookup_failure"},
+		Cache: cacheConfig{
+			TTL:     5 * time.Minute,
+			MaxSize: 10000,
+		},
+	}
+}
+
+// Validate validates the data contained in the config.
+func (c *config) Validate() error {
+	// Flatten the mapping of document fields to event fields.
+	c.fieldsFlat = map[string]string{}
+	for k, v := range c.Fields.Flatten() {
+		target, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("target field for document field %v "+
+				"must be a string but got %T", k, v)
+		}
+		c.fieldsFlat[k] = target
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package enrich
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/atomic"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	processorName = "enrich"
+	logName       = "processor." + processorName
+)
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin(processorName, New)
+}
+
+type processor struct {
+	config
+	cache *lookupCache
+	log   *logp.Logger
+	stats cacheStats
+
+	// mu protects the clients, which are not safe for concurrent use.
+	mu        sync.Mutex
+	clients   []eslegclient.Connection
+	active    int
+	connected bool
+}
+
+type cacheStats struct {
+	Hit  *monitoring.Int
+	Miss *monitoring.Int
+}
+
+// New constructs a new enrich processor.
+func New(cfg *conf.C) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v configuration: %w", processorName, err)
+	}
+
+	clients, err := eslegclient.NewClients(cfg, "Libbeat")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %v Elasticsearch clients: %w", processorName, err)
+	}
+
+	// Logging and metrics (each processor instance has a unique ID).
+	var (
+		id      = int(instanceID.Inc())
+		log     = logp.NewLogger(logName).With("instance_id", id)
+		metrics = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	return &processor{
+		config:  c,
+		cache:   newLookupCache(c.Cache),
+		log:     log,
+		clients: clients,
+		stats: cacheStats{
+			Hit:  monitoring.NewInt(metrics, "cache.hits"),
+			Miss: monitoring.NewInt(metrics, "cache.misses"),
+		},
+	}, nil
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if err := p.enrich(event); err != nil {
+		p.log.Debugf("enrich processor failed: %v", err)
+		if len(p.TagOnFailure) > 0 {
+			mapstr.AddTags(event.Fields, p.TagOnFailure)
+		}
+	}
+	return event, nil
+}
+
+func (p *processor) enrich(event *beat.Event) error {
+	value, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return nil
+		}
+		return fmt.Errorf("could not get lookup key from field %v: %w", p.Field, err)
+	}
+
+	doc, err := p.lookup(value)
+	if err != nil {
+		return fmt.Errorf("lookup of %v value '%v' failed: %w", p.Field, value, err)
+	}
+	if doc == nil {
+		return nil
+	}
+
+	for source, target := range p.fieldsFlat {
+		v, err := doc.GetValue(source)
+		if err != nil {
+			continue
+		}
+		if !p.OverwriteKeys {
+			if _, err := event.GetValue(target); err == nil {
+				continue
+			}
+		}
+		if _, err := event.PutValue(target, v); err != nil {
+			return fmt.Errorf("could not set %v: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// lookup returns the document matching a lookup key, or nil if none
+// matches. A cached result is returned if it is contained in the cache,
+// otherwise Elasticsearch is queried.
+func (p *processor) lookup(value interface{}) (mapstr.M, error) {
+	now := time.Now()
+	key := fmt.Sprint(value)
+
+	if doc, found := p.cache.get(now, key); found {
+		p.stats.Hit.Inc()
+		return doc, nil
+	}
+	p.stats.Miss.Inc()
+
+	doc, err := p.search(value)
+	if err != nil {
+		return nil, err
+	}
+	p.cache.set(now, key, doc)
+	return doc, nil
+}
+
+// search queries Elasticsearch for the first document matching the lookup
+// key. Failed requests mark the client as disconnected, so the next request
+// connects to the next host.
+func (p *processor) search(value interface{}) (mapstr.M, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn := &p.clients[p.active]
+	if !p.connected {
+		if err := conn.Connect(); err != nil {
+			p.nextClient()
+			return nil, err
+		}
+		p.connected = true
+	}
+
+	source := make([]string, 0, len(p.fieldsFlat))
+	for field := range p.fieldsFlat {
+		source = append(source, field)
+	}
+	body := mapstr.M{
+		"size":    1,
+		"_source": source,
+		"query": mapstr.M{
+			"terms": mapstr.M{
+				p.LookupField: []interface{}{value},
+			},
+		},
+	}
+
+	_, result, err := conn.SearchURIWithBody(p.Index, "", nil, body)
+	if err != nil {
+		p.nextClient()
+		return nil, err
+	}
+	if len(result.Hits.Hits) == 0 {
+		return nil, nil
+	}
+
+	var hit struct {
+		Source mapstr.M `json:"_source"`
+	}
+	if err := json.Unmarshal(result.Hits.Hits[0], &hit); err != nil {
+		return nil, fmt.Errorf("failed to parse search result: %w", err)
+	}
+	if hit.Source == nil {
+		hit.Source = mapstr.M{}
+	}
+	return hit.Source, nil
+}
+
+func (p *processor) nextClient() {
+	p.connected = false
+	p.active = (p.active + 1) % len(p.clients)
+}
+
+// Close closes the connections to Elasticsearch.
+func (p *processor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range p.clients {
+		p.clients[i].Close()
+	}
+	return nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[index=%v, field=%v, lookup_field=%v, fields=%v]",
+		processorName, p.Index, p.Field, p.LookupField, p.fieldsFlat)
+}
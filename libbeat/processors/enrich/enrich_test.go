@@ -0,0 +1,236 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package enrich
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// newTestServer returns an Elasticsearch mock serving the users index.
+func newTestServer(t *testing.T, searches *int32) *httptest.Server {
+	users := map[string]mapstr.M{
+		"alice": {"user": mapstr.M{"name": "alice", "full_name": "Alice Smith", "department": "sales"}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{"version":{"number":"8.5.0"}}`))
+		case "/users/_search":
+			atomic.AddInt32(searches, 1)
+
+			var req struct {
+				Query struct {
+					Terms map[string][]string `json:"terms"`
+				} `json:"query"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &req))
+
+			hits := []mapstr.M{}
+			if doc, found := users[req.Query.Terms["user.name"][0]]; found {
+				hits = append(hits, mapstr.M{"_index": "users", "_source": doc})
+			}
+			json.NewEncoder(w).Encode(mapstr.M{
+				"hits": mapstr.M{"total": mapstr.M{"value": len(hits)}, "hits": hits},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"index_not_found_exception"}`))
+		}
+	}))
+}
+
+func newTestProcessor(t *testing.T, url string, settings mapstr.M) *processor {
+	cfg := mapstr.M{
+		"hosts":        []string{url},
+		"index":        "users",
+		"field":        "user.name",
+		"lookup_field": "user.name",
+		"fields": mapstr.M{
+			"user.full_name":  "user.full_name",
+			"user.department": "user.department",
+		},
+	}
+	cfg.DeepUpdate(settings)
+
+	p, err := New(conf.MustNewConfigFrom(cfg))
+	require.NoError(t, err)
+	t.Cleanup(func() { p.(*processor).Close() })
+	return p.(*processor)
+}
+
+func TestEnrich(t *testing.T) {
+	var searches int32
+	srv := newTestServer(t, &searches)
+	defer srv.Close()
+
+	tests := map[string]struct {
+		settings mapstr.M
+		fields   mapstr.M
+		expected mapstr.M
+	}{
+		"found": {
+			fields: mapstr.M{"user": mapstr.M{"name": "alice"}},
+			expected: mapstr.M{"user": mapstr.M{
+				"name":       "alice",
+				"full_name":  "Alice Smith",
+				"department": "sales",
+			}},
+		},
+		"not found": {
+			fields:   mapstr.M{"user": mapstr.M{"name": "bob"}},
+			expected: mapstr.M{"user": mapstr.M{"name": "bob"}},
+		},
+		"existing fields are kept": {
+			fields: mapstr.M{"user": mapstr.M{"name": "alice", "department": "marketing"}},
+			expected: mapstr.M{"user": mapstr.M{
+				"name":       "alice",
+				"full_name":  "Alice Smith",
+				"department": "marketing",
+			}},
+		},
+		"overwrite keys": {
+			settings: mapstr.M{"overwrite_keys": true},
+			fields:   mapstr.M{"user": mapstr.M{"name": "alice", "department": "marketing"}},
+			expected: mapstr.M{"user": mapstr.M{
+				"name":       "alice",
+				"full_name":  "Alice Smith",
+				"department": "sales",
+			}},
+		},
+		"missing key": {
+			fields:   mapstr.M{"message": "hello"},
+			expected: mapstr.M{"message": "hello", "tags": []string{"_enrich_lookup_failure"}},
+		},
+		"ignore missing key": {
+			settings: mapstr.M{"ignore_missing": true},
+			fields:   mapstr.M{"message": "hello"},
+			expected: mapstr.M{"message": "hello"},
+		},
+		"lookup failure": {
+			settings: mapstr.M{"index": "unknown", "tag_on_failure": []string{"lookup_failed"}},
+			fields:   mapstr.M{"user": mapstr.M{"name": "alice"}},
+			expected: mapstr.M{"user": mapstr.M{"name": "alice"}, "tags": []string{"lookup_failed"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newTestProcessor(t, srv.URL, test.settings)
+
+			event, err := p.Run(&beat.Event{Fields: test.fields})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, event.Fields)
+		})
+	}
+}
+
+func TestEnrichCache(t *testing.T) {
+	var searches int32
+	srv := newTestServer(t, &searches)
+	defer srv.Close()
+
+	p := newTestProcessor(t, srv.URL, nil)
+	for i := 0; i < 3; i++ {
+		for _, name := range []string{"alice", "bob"} {
+			_, err := p.Run(&beat.Event{Fields: mapstr.M{"user": mapstr.M{"name": name}}})
+			require.NoError(t, err)
+		}
+	}
+	assert.EqualValues(t, 2, atomic.LoadInt32(&searches))
+	assert.EqualValues(t, 4, p.stats.Hit.Get())
+	assert.EqualValues(t, 2, p.stats.Miss.Get())
+}
+
+func TestLookupCache(t *testing.T) {
+	c := newLookupCache(cacheConfig{TTL: time.Minute, MaxSize: 2})
+	now := time.Now()
+
+	c.set(now, "a", mapstr.M{"a": 1})
+	c.set(now, "b", nil)
+
+	doc, found := c.get(now, "a")
+	assert.True(t, found)
+	assert.Equal(t, mapstr.M{"a": 1}, doc)
+
+	doc, found = c.get(now, "b")
+	assert.True(t, found)
+	assert.Nil(t, doc)
+
+	_, found = c.get(now.Add(2*time.Minute), "a")
+	assert.False(t, found)
+
+	c.set(now, "c", nil)
+	assert.Len(t, c.data, 2)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		settings mapstr.M
+		err      bool
+	}{
+		"valid": {},
+		"missing index": {
+			settings: mapstr.M{"index": ""},
+			err:      true,
+		},
+		"invalid target": {
+			settings: mapstr.M{"fields": mapstr.M{"user.full_name": 1}},
+			err:      true,
+		},
+		"invalid cache size": {
+			settings: mapstr.M{"cache.max_size": 0},
+			err:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := mapstr.M{
+				"index":        "users",
+				"field":        "user.name",
+				"lookup_field": "user.name",
+				"fields":       mapstr.M{"user.full_name": "user.full_name"},
+			}
+			cfg.DeepUpdate(test.settings)
+
+			c := defaultConfig()
+			err := conf.MustNewConfigFrom(cfg).Unpack(&c)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
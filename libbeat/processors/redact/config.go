@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redact
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+)
+
+// Replacement modes.
+const (
+	modeMask = "mask"
+	modeHash = "hash"
+)
+
+// config for the redact processor.
+type config struct {
+	Fields         []string        `config:"fields" validate:"required"`
+	Patterns       []string        `config:"patterns"`
+	CustomPatterns []customPattern `config:"custom_patterns"`
+	Mode           string          `config:"mode"`
+	Mask           string          `config:"mask"`
+	Hash           hashConfig      `config:"hash"`
+	IgnoreMissing  bool            `config:"ignore_missing"`
+	FailOnError    bool            `config:"fail_on_error"`
+}
+
+type customPattern struct {
+	Name  string `config:"name" validate:"required"`
+	Regex string `config:"regex" validate:"required"`
+}
+
+type hashConfig struct {
+	Key    string     `config:"key"`
+	Method hashMethod `config:"method"`
+}
+
+type hashMethod func() hash.Hash
+
+var hashes = map[string]hashMethod{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// Unpack creates the hashMethod from the given string.
+func (m *hashMethod) Unpack(str string) error {
+	method, found := hashes[strings.ToLower(str)]
+	if !found {
+		return fmt.Errorf("invalid hash method '%v' (valid values are: sha256, sha384, sha512)", str)
+	}
+	*m = method
+	return nil
+}
+
+func defaultConfig() config {
+	return config{
+		Mode:        modeMask,
+		Mask:        "[REDACTED]",
+		Hash:        hashConfig{Method: sha256.New},
+		FailOnError: true,
+	}
+}
+
+func (c *config) Validate() error {
+	if len(c.Patterns) == 0 && len(c.CustomPatterns) == 0 {
+		return errors.New("at least one of patterns or custom_patterns must be set")
+	}
+	for _, name := range c.Patterns {
+		if _, found := builtinPatterns[name]; !found {
+			return fmt.Errorf("unknown pattern '%v' (valid values are: %v)", name, strings.Join(builtinPatternNames(), ", "))
+		}
+	}
+	for _, p := range c.CustomPatterns {
+		if _, err := regexp.Compile(p.Regex); err != nil {
+			return fmt.Errorf("invalid regex of custom pattern '%v': %w", p.Name, err)
+		}
+	}
+
+	c.Mode = strings.ToLower(c.Mode)
+	switch c.Mode {
+	case modeMask:
+	case modeHash:
+		if c.Hash.Key == "" {
+			return errors.New("hash.key is required for the hash mode")
+		}
+	default:
+		return fmt.Errorf("invalid mode '%v' (valid values are: mask, hash)", c.Mode)
+	}
+	return nil
+}
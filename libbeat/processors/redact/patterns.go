@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redact
+
+import (
+	"regexp"
+	"sort"
+)
+
+// pattern matches a kind of sensitive data.
+type pattern struct {
+	name  string
+	regex *regexp.Regexp
+
+	// valid filters out false positives of the regex, if set.
+	valid func(match string) bool
+}
+
+var builtinPatterns = map[string]pattern{
+	"credit_card": {
+		name:  "credit_card",
+		regex: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		valid: luhn,
+	},
+	"email": {
+		name:  "email",
+		regex: regexp.MustCompile(`\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`),
+	},
+	"ipv4": {
+		name:  "ipv4",
+		regex: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`),
+	},
+	"us_ssn": {
+		name:  "us_ssn",
+		regex: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+	},
+}
+
+func builtinPatternNames() []string {
+	names := make([]string, 0, len(builtinPatterns))
+	for name := range builtinPatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// luhn checks the digits of a credit card number with the Luhn algorithm,
+// ignoring the separators.
+func luhn(number string) bool {
+	var sum, digits int
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return sum%10 == 0
+}
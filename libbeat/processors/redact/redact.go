@@ -0,0 +1,220 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redact
+
+import (
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/atomic"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/libbeat/processors/checks"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+const (
+	processorName = "redact"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("fields"),
+			checks.AllowedFields("fields", "patterns", "custom_patterns", "mode", "mask", "hash",
+				"ignore_missing", "fail_on_error", "when")))
+}
+
+type metrics struct {
+	Redacted *monitoring.Int
+}
+
+type redact struct {
+	config   config
+	patterns []pattern
+	logger   *logp.Logger
+	metrics  metrics
+}
+
+// New constructs a new redact processor.
+func New(cfg *conf.C) (processors.Processor, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("could not unpack %v processor configuration: %w", processorName, err)
+	}
+
+	patterns := make([]pattern, 0, len(config.Patterns)+len(config.CustomPatterns))
+	for _, name := range config.Patterns {
+		patterns = append(patterns, builtinPatterns[name])
+	}
+	for _, p := range config.CustomPatterns {
+		patterns = append(patterns, pattern{name: p.Name, regex: regexp.MustCompile(p.Regex)})
+	}
+
+	// Logging and metrics (each processor instance has a unique ID).
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger(logName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	return &redact{
+		config:   config,
+		patterns: patterns,
+		logger:   log,
+		metrics: metrics{
+			Redacted: monitoring.NewInt(reg, "redacted"),
+		},
+	}, nil
+}
+
+// Run redacts the sensitive data matched by the patterns in the configured
+// fields.
+func (p *redact) Run(event *beat.Event) (*beat.Event, error) {
+	var backup *beat.Event
+	if p.config.FailOnError {
+		backup = event.Clone()
+	}
+
+	for _, field := range p.config.Fields {
+		if err := p.redactField(event, field); err != nil {
+			err = fmt.Errorf("failed to redact field %v: %w", field, err)
+			p.logger.Debug(err.Error())
+			if p.config.FailOnError {
+				event = backup
+				_, _ = event.PutValue("error.message", err.Error())
+				return event, err
+			}
+		}
+	}
+	return event, nil
+}
+
+func (p *redact) redactField(event *beat.Event, field string) error {
+	value, err := event.GetValue(field)
+	if err != nil {
+		if p.config.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	switch v := value.(type) {
+	case string:
+		value = p.redactString(v)
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = p.redactString(s)
+		}
+		value = redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return fmt.Errorf("unexpected type %T in array, expecting strings", elem)
+			}
+			redacted[i] = p.redactString(s)
+		}
+		value = redacted
+	default:
+		return fmt.Errorf("unexpected type %T, expecting a string or an array of strings", value)
+	}
+
+	_, err = event.PutValue(field, value)
+	return err
+}
+
+// redactString replaces all the matches of the patterns in s. All patterns
+// are matched against the original value, and overlapping matches are
+// replaced once, so replacements are never matched by other patterns.
+func (p *redact) redactString(s string) string {
+	var matches [][]int
+	for _, pat := range p.patterns {
+		for _, loc := range pat.regex.FindAllStringIndex(s, -1) {
+			if pat.valid != nil && !pat.valid(s[loc[0]:loc[1]]) {
+				continue
+			}
+			matches = append(matches, loc)
+		}
+	}
+	if len(matches) == 0 {
+		return s
+	}
+
+	// Merge the overlapping matches.
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+	merged := matches[:1]
+	for _, loc := range matches[1:] {
+		last := merged[len(merged)-1]
+		if loc[0] < last[1] {
+			if loc[1] > last[1] {
+				last[1] = loc[1]
+			}
+			continue
+		}
+		merged = append(merged, loc)
+	}
+
+	var b strings.Builder
+	end := 0
+	for _, loc := range merged {
+		b.WriteString(s[end:loc[0]])
+		b.WriteString(p.replacement(s[loc[0]:loc[1]]))
+		end = loc[1]
+	}
+	b.WriteString(s[end:])
+
+	p.metrics.Redacted.Add(int64(len(merged)))
+	return b.String()
+}
+
+// replacement returns the value replacing a match. In hash mode, the value is
+// a keyed hash of the match, so the same data always gets the same pseudonym
+// but cannot be recovered without the key.
+func (p *redact) replacement(match string) string {
+	if p.config.Mode != modeHash {
+		return p.config.Mask
+	}
+	mac := hmac.New(p.config.Hash.Method, []byte(p.config.Hash.Key))
+	mac.Write([]byte(match))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *redact) String() string {
+	names := make([]string, len(p.patterns))
+	for i, pat := range p.patterns {
+		names[i] = pat.name
+	}
+	return fmt.Sprintf("%v=[fields=%v, patterns=%v, mode=%v]",
+		processorName, p.config.Fields, names, p.config.Mode)
+}
@@ -0,0 +1,145 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestRedact(t *testing.T) {
+	tests := map[string]struct {
+		settings mapstr.M
+		input    mapstr.M
+		expected mapstr.M
+		err      bool
+	}{
+		"email": {
+			settings: mapstr.M{"patterns": []string{"email"}},
+			input:    mapstr.M{"message": "login of jane.doe@example.com failed"},
+			expected: mapstr.M{"message": "login of [REDACTED] failed"},
+		},
+		"credit card with luhn check": {
+			settings: mapstr.M{"patterns": []string{"credit_card"}},
+			input:    mapstr.M{"message": "card 4111 1111 1111 1111, order 1234567890123"},
+			expected: mapstr.M{"message": "card [REDACTED], order 1234567890123"},
+		},
+		"multiple patterns": {
+			settings: mapstr.M{"patterns": []string{"email", "ipv4", "us_ssn"}, "mask": "***"},
+			input:    mapstr.M{"message": "a@b.io from 10.0.0.1 ssn 123-45-6789"},
+			expected: mapstr.M{"message": "*** from *** ssn ***"},
+		},
+		"custom pattern": {
+			settings: mapstr.M{"custom_patterns": []mapstr.M{{"name": "employee_id", "regex": `EMP-\d{6}`}}},
+			input:    mapstr.M{"message": "EMP-123456 logged in"},
+			expected: mapstr.M{"message": "[REDACTED] logged in"},
+		},
+		"overlapping matches": {
+			settings: mapstr.M{
+				"patterns":        []string{"email"},
+				"custom_patterns": []mapstr.M{{"name": "user", "regex": `user=\S+`}},
+			},
+			input:    mapstr.M{"message": "user=jane@example.com"},
+			expected: mapstr.M{"message": "[REDACTED]"},
+		},
+		"arrays": {
+			settings: mapstr.M{"fields": []string{"user.emails"}, "patterns": []string{"email"}},
+			input:    mapstr.M{"user": mapstr.M{"emails": []interface{}{"a@example.com", "none"}}},
+			expected: mapstr.M{"user": mapstr.M{"emails": []interface{}{"[REDACTED]", "none"}}},
+		},
+		"missing field": {
+			settings: mapstr.M{"fields": []string{"message", "other"}, "patterns": []string{"email"}},
+			input:    mapstr.M{"message": "a@example.com"},
+			expected: mapstr.M{"message": "a@example.com", "error": mapstr.M{"message": "failed to redact field other: key not found"}},
+			err:      true,
+		},
+		"ignore missing field": {
+			settings: mapstr.M{"fields": []string{"message", "other"}, "patterns": []string{"email"}, "ignore_missing": true},
+			input:    mapstr.M{"message": "a@example.com"},
+			expected: mapstr.M{"message": "[REDACTED]"},
+		},
+		"not a string": {
+			settings: mapstr.M{"fields": []string{"message", "count"}, "patterns": []string{"email"}, "fail_on_error": false},
+			input:    mapstr.M{"message": "a@example.com", "count": 3},
+			expected: mapstr.M{"message": "[REDACTED]", "count": 3},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings := mapstr.M{"fields": []string{"message"}}
+			settings.DeepUpdate(test.settings)
+			p, err := New(conf.MustNewConfigFrom(settings))
+			require.NoError(t, err)
+
+			event, err := p.Run(&beat.Event{Fields: test.input})
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, event.Fields)
+		})
+	}
+}
+
+func TestRedactHash(t *testing.T) {
+	p, err := New(conf.MustNewConfigFrom(mapstr.M{
+		"fields":   []string{"message"},
+		"patterns": []string{"email"},
+		"mode":     "hash",
+		"hash.key": "secret",
+	}))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("jane@example.com"))
+	pseudonym := hex.EncodeToString(mac.Sum(nil))
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"message": "from jane@example.com to jane@example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "from "+pseudonym+" to "+pseudonym, event.Fields["message"])
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"no patterns":       {},
+		"unknown pattern":   {"patterns": []string{"phone"}},
+		"invalid regex":     {"custom_patterns": []mapstr.M{{"name": "bad", "regex": "("}}},
+		"hash without key":  {"patterns": []string{"email"}, "mode": "hash"},
+		"unknown mode":      {"patterns": []string{"email"}, "mode": "encrypt"},
+		"unknown hash type": {"patterns": []string{"email"}, "mode": "hash", "hash": mapstr.M{"key": "k", "method": "md5"}},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings["fields"] = []string{"message"}
+			_, err := New(conf.MustNewConfigFrom(settings))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sample
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sampling methods.
+const (
+	methodRandom = "random"
+	methodHash   = "hash"
+	methodRate   = "rate"
+)
+
+// config for the sample processor.
+type config struct {
+	Method      string   `config:"method"`
+	Probability float64  `config:"probability"`
+	Rate        float64  `config:"rate"`
+	Fields      []string `config:"fields"`
+}
+
+func defaultConfig() config {
+	return config{
+		Method: methodRandom,
+	}
+}
+
+func (c *config) Validate() error {
+	c.Method = strings.ToLower(c.Method)
+	switch c.Method {
+	case methodRandom, methodHash:
+		if c.Probability <= 0 || c.Probability > 1 {
+			return fmt.Errorf("probability must be in the range (0, 1] for the %v method", c.Method)
+		}
+		if c.Method == methodHash && len(c.Fields) == 0 {
+			return errors.New("fields are required for the hash method")
+		}
+	case methodRate:
+		if c.Rate <= 0 {
+			return errors.New("rate must be > 0 for the rate method")
+		}
+	default:
+		return fmt.Errorf("invalid sampling method '%v' (valid values are: random, hash, rate)", c.Method)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sample
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/atomic"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+const (
+	processorName = "sample"
+	logName       = "processor." + processorName
+
+	// sampledRateField is the field storing the number of events each
+	// published event represents, to scale aggregations of sampled events.
+	sampledRateField = "event.sampled_rate"
+
+	// rateWindow is the duration of the windows events are counted in by
+	// the rate method.
+	rateWindow = time.Second
+)
+
+func init() {
+	processors.RegisterPlugin(processorName, New)
+}
+
+type metrics struct {
+	Dropped *monitoring.Int
+}
+
+type sample struct {
+	config  config
+	logger  *logp.Logger
+	metrics metrics
+	clock   clockwork.Clock
+
+	// random returns a random number in [0, 1), to be overwritten in tests.
+	random func() float64
+
+	// State of the rate method.
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// window counts the events of a key in the current rate window.
+type window struct {
+	start time.Time
+	seen  int
+	kept  int
+
+	// sampledRate is the ratio of seen to kept events of the previous window.
+	sampledRate float64
+}
+
+// New constructs a new sample processor.
+func New(cfg *conf.C) (processors.Processor, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("could not unpack %v processor configuration: %w", processorName, err)
+	}
+
+	// Logging and metrics (each processor instance has a unique ID).
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger(logName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	return &sample{
+		config: config,
+		logger: log,
+		metrics: metrics{
+			Dropped: monitoring.NewInt(reg, "dropped"),
+		},
+		clock:   clockwork.NewRealClock(),
+		random:  rand.Float64,
+		windows: map[string]*window{},
+	}, nil
+}
+
+// Run samples the given event. If the event is sampled, it is returned with
+// the sampled rate, otherwise nil is returned.
+func (p *sample) Run(event *beat.Event) (*beat.Event, error) {
+	var (
+		keep        bool
+		sampledRate float64
+	)
+	switch p.config.Method {
+	case methodRandom:
+		keep = p.random() < p.config.Probability
+		sampledRate = 1 / p.config.Probability
+	case methodHash:
+		keep = hashRatio(p.makeKey(event)) < p.config.Probability
+		sampledRate = 1 / p.config.Probability
+	case methodRate:
+		keep, sampledRate = p.sampleRate(p.makeKey(event))
+	}
+
+	if !keep {
+		p.logger.Debugf("event [%v] dropped by sample processor", event)
+		p.metrics.Dropped.Inc()
+		return nil, nil
+	}
+
+	if _, err := event.PutValue(sampledRateField, sampledRate); err != nil {
+		return event, fmt.Errorf("could not set %v: %w", sampledRateField, err)
+	}
+	return event, nil
+}
+
+// sampleRate keeps up to rate events per second for each key. The sampled
+// rate of the kept events is estimated from the previous window of the key.
+func (p *sample) sampleRate(key string) (bool, float64) {
+	now := p.clock.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sweep(now)
+
+	w := p.windows[key]
+	if w == nil {
+		w = &window{start: now, sampledRate: 1}
+		p.windows[key] = w
+	} else if elapsed := now.Sub(w.start); elapsed >= rateWindow {
+		w.sampledRate = 1
+		if elapsed < 2*rateWindow && w.kept > 0 {
+			w.sampledRate = float64(w.seen) / float64(w.kept)
+		}
+		w.start, w.seen, w.kept = now, 0, 0
+	}
+
+	w.seen++
+	if float64(w.kept) >= p.config.Rate*rateWindow.Seconds() {
+		return false, 0
+	}
+	w.kept++
+	return true, w.sampledRate
+}
+
+// sweep removes the windows of keys without events for a while, to not keep
+// the state of all keys ever seen.
+func (p *sample) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < 10*rateWindow {
+		return
+	}
+	p.lastSweep = now
+
+	for key, w := range p.windows {
+		if now.Sub(w.start) >= 2*rateWindow {
+			delete(p.windows, key)
+		}
+	}
+}
+
+func (p *sample) makeKey(event *beat.Event) string {
+	if len(p.config.Fields) == 0 {
+		return ""
+	}
+
+	var key strings.Builder
+	for _, field := range p.config.Fields {
+		// Missing fields are part of the key as empty values.
+		if value, err := event.GetValue(field); err == nil {
+			fmt.Fprint(&key, value)
+		}
+		key.WriteByte(0)
+	}
+	return key.String()
+}
+
+// hashRatio maps a key to a number in [0, 1), consistently across processes
+// and hosts. The FNV hash is finalized with the murmur3 mixer, as the high
+// bits of FNV are poorly distributed for short keys.
+func hashRatio(key string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return float64(x>>11) / (1 << 53)
+}
+
+func (p *sample) String() string {
+	return fmt.Sprintf(
+		"%v=[method=%v, probability=%v, rate=%v, fields=%v]",
+		processorName, p.config.Method, p.config.Probability, p.config.Rate, p.config.Fields,
+	)
+}
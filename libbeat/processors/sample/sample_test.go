@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sample
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestSample(t *testing.T, settings mapstr.M) *sample {
+	p, err := New(conf.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	return p.(*sample)
+}
+
+func hostEvent(host string) *beat.Event {
+	return &beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": host}}}
+}
+
+func TestRandom(t *testing.T) {
+	p := newTestSample(t, mapstr.M{"probability": 0.25})
+
+	randoms := []float64{0.1, 0.3, 0.2499, 0.25}
+	p.random = func() float64 {
+		r := randoms[0]
+		randoms = randoms[1:]
+		return r
+	}
+
+	var kept []bool
+	for range randoms {
+		event, err := p.Run(hostEvent("a"))
+		require.NoError(t, err)
+		kept = append(kept, event != nil)
+		if event != nil {
+			rate, _ := event.GetValue(sampledRateField)
+			assert.Equal(t, 4.0, rate)
+		}
+	}
+	assert.Equal(t, []bool{true, false, true, false}, kept)
+	assert.EqualValues(t, 2, p.metrics.Dropped.Get())
+}
+
+func TestHash(t *testing.T) {
+	p := newTestSample(t, mapstr.M{"method": "hash", "probability": 0.5, "fields": []string{"host.name"}})
+
+	// Events of the same key are either all kept or all dropped.
+	kept := 0
+	for i := 0; i < 100; i++ {
+		host := fmt.Sprintf("host-%d", i)
+		first, err := p.Run(hostEvent(host))
+		require.NoError(t, err)
+		for j := 0; j < 5; j++ {
+			event, err := p.Run(hostEvent(host))
+			require.NoError(t, err)
+			assert.Equal(t, first != nil, event != nil)
+		}
+		if first != nil {
+			kept++
+			rate, _ := first.GetValue(sampledRateField)
+			assert.Equal(t, 2.0, rate)
+		}
+	}
+	assert.InDelta(t, 50, kept, 20)
+
+	// The sampling is consistent across processor instances.
+	other := newTestSample(t, mapstr.M{"method": "hash", "probability": 0.5, "fields": []string{"host.name"}})
+	for i := 0; i < 100; i++ {
+		host := fmt.Sprintf("host-%d", i)
+		a, _ := p.Run(hostEvent(host))
+		b, _ := other.Run(hostEvent(host))
+		assert.Equal(t, a != nil, b != nil)
+	}
+}
+
+func TestRate(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	p := newTestSample(t, mapstr.M{"method": "rate", "rate": 2, "fields": []string{"host.name"}})
+	p.clock = clock
+
+	run := func(host string, count int) (kept int, rates []interface{}) {
+		for i := 0; i < count; i++ {
+			event, err := p.Run(hostEvent(host))
+			require.NoError(t, err)
+			if event != nil {
+				kept++
+				rate, _ := event.GetValue(sampledRateField)
+				rates = append(rates, rate)
+			}
+		}
+		return kept, rates
+	}
+
+	kept, rates := run("a", 10)
+	assert.Equal(t, 2, kept)
+	assert.Equal(t, []interface{}{1.0, 1.0}, rates)
+
+	// Keys are limited independently.
+	kept, _ = run("b", 1)
+	assert.Equal(t, 1, kept)
+
+	// The sampled rate of the next window is estimated from the previous one.
+	clock.Advance(time.Second)
+	kept, rates = run("a", 10)
+	assert.Equal(t, 2, kept)
+	assert.Equal(t, []interface{}{5.0, 5.0}, rates)
+
+	// The estimate is reset after a window without events.
+	clock.Advance(3 * time.Second)
+	_, rates = run("a", 1)
+	assert.Equal(t, []interface{}{1.0}, rates)
+
+	// Stale keys are removed.
+	clock.Advance(20 * time.Second)
+	run("a", 1)
+	assert.Len(t, p.windows, 1)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		settings mapstr.M
+		err      bool
+	}{
+		"random":                     {settings: mapstr.M{"probability": 0.1}},
+		"random without prob":        {settings: mapstr.M{}, err: true},
+		"probability above 1":        {settings: mapstr.M{"probability": 1.5}, err: true},
+		"hash":                       {settings: mapstr.M{"method": "hash", "probability": 0.1, "fields": []string{"a"}}},
+		"hash without fields":        {settings: mapstr.M{"method": "hash", "probability": 0.1}, err: true},
+		"rate":                       {settings: mapstr.M{"method": "rate", "rate": 10}},
+		"rate without rate":          {settings: mapstr.M{"method": "rate"}, err: true},
+		"unknown method":             {settings: mapstr.M{"method": "reservoir", "probability": 0.1}, err: true},
+		"method is case insensitive": {settings: mapstr.M{"method": "RATE", "rate": 10}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(test.settings))
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wasm
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+)
+
+// Config defines the WebAssembly module to use for the processor.
+type Config struct {
+	Tag               string           `config:"tag"`                                  // Processor ID for debug.
+	File              string           `config:"file"`                                 // WebAssembly module file.
+	Timeout           time.Duration    `config:"timeout" validate:"min=0"`             // Execution timeout.
+	MaxMemory         cfgtype.ByteSize `config:"max_memory" validate:"min=0"`          // Max. linear memory of a module instance.
+	TagOnException    string           `config:"tag_on_exception"`                     // Tag to add to events when the module fails.
+	MaxCachedSessions int              `config:"max_cached_sessions" validate:"min=0"` // Max. number of cached module instances.
+}
+
+// Validate returns an error if the module file is not set.
+func (c Config) Validate() error {
+	if c.File == "" {
+		return errors.New("wasm module must be defined via 'file'")
+	}
+	if c.MaxMemory > 0 && c.MaxMemory < wasmPageSize {
+		return errors.Errorf("max_memory must be at least %d bytes", wasmPageSize)
+	}
+	return nil
+}
+
+func defaultConfig() Config {
+	return Config{
+		MaxMemory:         16 * 1024 * 1024,
+		TagOnException:    "_wasm_exception",
+		MaxCachedSessions: 4,
+	}
+}
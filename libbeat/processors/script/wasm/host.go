@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// hostModule is the name of the module with the functions the WebAssembly
+// modules can import to access the event being processed.
+const hostModule = "beat"
+
+// Return codes of the host functions.
+const (
+	resultOK       int32 = 0
+	resultNotFound int32 = -1
+	resultInvalid  int32 = -2
+)
+
+var errMemoryAccess = errors.New("wasm module accessed memory out of bounds")
+
+// callState holds the event being processed by a call to the process
+// function of a module instance.
+type callState struct {
+	event     *beat.Event
+	cancelled bool
+}
+
+type callStateKey struct{}
+
+func getCallState(ctx context.Context) *callState {
+	s, _ := ctx.Value(callStateKey{}).(*callState)
+	return s
+}
+
+// instantiateHostModule registers the host API in the runtime:
+//
+//	get(key_ptr, key_len, buf_ptr, buf_len i32) i32
+//	put(key_ptr, key_len, value_ptr, value_len i32) i32
+//	delete(key_ptr, key_len i32) i32
+//	cancel()
+//
+// Values are exchanged encoded as JSON. get returns the length of the value,
+// the value is only written when it fits in the buffer so the module can
+// retry with a larger one.
+func instantiateHostModule(ctx context.Context, r wazero.Runtime) error {
+	_, err := r.NewHostModuleBuilder(hostModule).
+		NewFunctionBuilder().WithFunc(hostGet).Export("get").
+		NewFunctionBuilder().WithFunc(hostPut).Export("put").
+		NewFunctionBuilder().WithFunc(hostDelete).Export("delete").
+		NewFunctionBuilder().WithFunc(hostCancel).Export("cancel").
+		Instantiate(ctx)
+	return err
+}
+
+func hostGet(ctx context.Context, m api.Module, keyPtr, keyLen, bufPtr, bufLen uint32) int32 {
+	s := getCallState(ctx)
+	if s == nil {
+		return resultInvalid
+	}
+
+	value, err := s.event.GetValue(readString(m, keyPtr, keyLen))
+	if err != nil {
+		return resultNotFound
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return resultInvalid
+	}
+	if uint32(len(data)) <= bufLen && !m.Memory().Write(bufPtr, data) {
+		panic(errMemoryAccess)
+	}
+	return int32(len(data))
+}
+
+func hostPut(ctx context.Context, m api.Module, keyPtr, keyLen, valuePtr, valueLen uint32) int32 {
+	s := getCallState(ctx)
+	if s == nil {
+		return resultInvalid
+	}
+
+	key := readString(m, keyPtr, keyLen)
+	var value interface{}
+	if err := json.Unmarshal(read(m, valuePtr, valueLen), &value); err != nil {
+		return resultInvalid
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		value = mapstr.M(v)
+	case string:
+		if key == "@timestamp" {
+			ts, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return resultInvalid
+			}
+			value = ts
+		}
+	}
+	if _, err := s.event.PutValue(key, value); err != nil {
+		return resultInvalid
+	}
+	return resultOK
+}
+
+func hostDelete(ctx context.Context, m api.Module, keyPtr, keyLen uint32) int32 {
+	s := getCallState(ctx)
+	if s == nil {
+		return resultInvalid
+	}
+
+	if err := s.event.Delete(readString(m, keyPtr, keyLen)); err != nil {
+		return resultNotFound
+	}
+	return resultOK
+}
+
+func hostCancel(ctx context.Context) {
+	if s := getCallState(ctx); s != nil {
+		s.cancelled = true
+	}
+}
+
+func read(m api.Module, ptr, length uint32) []byte {
+	data, ok := m.Memory().Read(ptr, length)
+	if !ok {
+		panic(errMemoryAccess)
+	}
+	return data
+}
+
+func readString(m api.Module, ptr, length uint32) string {
+	return string(read(m, ptr, length))
+}
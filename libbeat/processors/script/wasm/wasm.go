@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wasm
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+const (
+	wasmPageSize = 64 * 1024
+
+	processFunc = "process"
+)
+
+type wasmProcessor struct {
+	Config
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+	pool    chan api.Module
+}
+
+// New constructs a new WebAssembly processor.
+func New(c *config.C) (processors.Processor, error) {
+	conf := defaultConfig()
+	if err := c.Unpack(&conf); err != nil {
+		return nil, err
+	}
+
+	return NewFromConfig(conf)
+}
+
+// NewFromConfig constructs a new WebAssembly processor from the given config
+// object. It compiles the module and validates that it exports the process
+// function.
+//
+// Modules run sandboxed. They can only import the host API to access the
+// event and WASI, that is configured without access to the filesystem, the
+// environment, the network or the real clocks. Their linear memory is
+// limited to MaxMemory and, if Timeout is set, the execution of the process
+// function is interrupted when it exceeds it.
+func NewFromConfig(c Config) (processors.Processor, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := paths.Resolve(paths.Config, c.File)
+	if common.IsStrictPerms() {
+		if err := common.OwnerHasExclusiveWritePerms(path); err != nil {
+			return nil, annotateError(c.Tag, err)
+		}
+	}
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, annotateError(c.Tag, errors.Wrapf(err, "failed to read file %v", path))
+	}
+
+	ctx := context.Background()
+	runtimeConfig := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if c.MaxMemory > 0 {
+		runtimeConfig = runtimeConfig.WithMemoryLimitPages(uint32(c.MaxMemory / wasmPageSize))
+	}
+	r := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+
+	p, err := newProcessor(ctx, r, code, c)
+	if err != nil {
+		r.Close(ctx)
+		return nil, annotateError(c.Tag, err)
+	}
+	return p, nil
+}
+
+func newProcessor(ctx context.Context, r wazero.Runtime, code []byte, c Config) (*wasmProcessor, error) {
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := instantiateHostModule(ctx, r); err != nil {
+		return nil, err
+	}
+
+	module, err := r.CompileModule(ctx, code)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile wasm module")
+	}
+	def, found := module.ExportedFunctions()[processFunc]
+	if !found {
+		return nil, errors.Errorf("wasm module must export a %v function", processFunc)
+	}
+	if len(def.ParamTypes()) != 0 || len(def.ResultTypes()) != 1 || def.ResultTypes()[0] != api.ValueTypeI32 {
+		return nil, errors.Errorf("wasm %v function must have the signature () -> i32", processFunc)
+	}
+
+	p := &wasmProcessor{
+		Config:  c,
+		runtime: r,
+		module:  module,
+		pool:    make(chan api.Module, c.MaxCachedSessions),
+	}
+
+	// Instantiate the first module to validate its imports and memory.
+	m, err := p.instantiate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.put(m)
+	return p, nil
+}
+
+func (p *wasmProcessor) instantiate(ctx context.Context) (api.Module, error) {
+	// Only reactor modules are supported, the process function of commands
+	// can't be called after _start returns.
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize")
+	m, err := p.runtime.InstantiateModule(ctx, p.module, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate wasm module")
+	}
+	return m, nil
+}
+
+func (p *wasmProcessor) get(ctx context.Context) (api.Module, error) {
+	select {
+	case m := <-p.pool:
+		return m, nil
+	default:
+		return p.instantiate(ctx)
+	}
+}
+
+func (p *wasmProcessor) put(m api.Module) {
+	select {
+	case p.pool <- m:
+	default:
+		m.Close(context.Background())
+	}
+}
+
+func annotateError(id string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if id != "" {
+		return errors.Wrapf(err, "failed in processor.wasm with id=%v", id)
+	}
+	return errors.Wrap(err, "failed in processor.wasm")
+}
+
+// Run executes the processor on the given event. It calls the process
+// function exported by the module, that returns 0 on success. The event is
+// dropped if the module cancels it.
+func (p *wasmProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	out, err := p.run(event)
+	return out, annotateError(p.Tag, err)
+}
+
+func (p *wasmProcessor) run(event *beat.Event) (*beat.Event, error) {
+	ctx := context.Background()
+	m, err := p.get(ctx)
+	if err != nil {
+		return event, err
+	}
+
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	state := &callState{event: event}
+	results, err := m.ExportedFunction(processFunc).Call(context.WithValue(ctx, callStateKey{}, state))
+	if err == nil && int32(results[0]) != 0 {
+		err = errors.Errorf("%v function returned %d", processFunc, int32(results[0]))
+	}
+	if err != nil {
+		// The state of an instance that trapped or was interrupted can't be
+		// trusted, start over with a new one.
+		m.Close(context.Background())
+		if p.TagOnException != "" {
+			mapstr.AddTags(event.Fields, []string{p.TagOnException})
+		}
+		event.Fields.Put("error.message", err.Error())
+		return event, errors.Wrap(err, "failed in process function")
+	}
+	p.put(m)
+
+	if state.cancelled {
+		return nil, nil
+	}
+	return event, nil
+}
+
+// Close releases the runtime and all the module instances.
+func (p *wasmProcessor) Close() error {
+	return p.runtime.Close(context.Background())
+}
+
+func (p *wasmProcessor) String() string {
+	return "script=[type=wasm, id=" + p.Tag + ", file=" + p.File + "]"
+}
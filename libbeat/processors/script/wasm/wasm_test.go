@@ -0,0 +1,287 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wasm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Opcodes used by the test modules.
+const (
+	opLoop     = 0x03
+	opBr       = 0x0c
+	opEnd      = 0x0b
+	opCall     = 0x10
+	opDrop     = 0x1a
+	opLocalGet = 0x20
+	opLocalSet = 0x21
+	opI32Const = 0x41
+	blockEmpty = 0x40
+	typeI32    = 0x7f
+)
+
+// Indexes of the functions of the host API imported by the test modules.
+const (
+	fnGet = iota
+	fnPut
+	fnDelete
+	fnCancel
+)
+
+type testImport struct {
+	module, name string
+	typeIdx      byte
+}
+
+// testModule describes a WebAssembly module with a single function that is
+// exported as process. Data is copied to the start of the memory.
+type testModule struct {
+	imports     []testImport
+	body        []byte
+	memoryPages byte
+	data        []byte
+	funcName    string
+}
+
+var hostImports = []testImport{
+	{hostModule, "get", 0},
+	{hostModule, "put", 0},
+	{hostModule, "delete", 1},
+	{hostModule, "cancel", 2},
+}
+
+func (m testModule) encode() []byte {
+	section := func(id byte, items ...[]byte) []byte {
+		content := uleb(uint32(len(items)))
+		for _, item := range items {
+			content = append(content, item...)
+		}
+		return append(append([]byte{id}, uleb(uint32(len(content)))...), content...)
+	}
+	name := func(s string) []byte { return append(uleb(uint32(len(s))), s...) }
+
+	out := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	out = append(out, section(1,
+		[]byte{0x60, 4, typeI32, typeI32, typeI32, typeI32, 1, typeI32}, // get, put
+		[]byte{0x60, 2, typeI32, typeI32, 1, typeI32},                   // delete
+		[]byte{0x60, 0, 0},          // cancel
+		[]byte{0x60, 0, 1, typeI32}, // process
+	)...)
+	var imports [][]byte
+	for _, imp := range m.imports {
+		imports = append(imports, append(append(name(imp.module), name(imp.name)...), 0x00, imp.typeIdx))
+	}
+	out = append(out, section(2, imports...)...)
+	out = append(out, section(3, []byte{3})...)
+	out = append(out, section(5, []byte{0x00, m.memoryPages})...)
+	funcName := m.funcName
+	if funcName == "" {
+		funcName = processFunc
+	}
+	out = append(out, section(7,
+		append(name("memory"), 0x02, 0),
+		append(name(funcName), 0x00, byte(len(m.imports))),
+	)...)
+	// A single i32 local is available to the body.
+	code := append([]byte{1, 1, typeI32}, m.body...)
+	code = append(code, opEnd)
+	out = append(out, section(10, append(uleb(uint32(len(code))), code...))...)
+	out = append(out, section(11,
+		append([]byte{0x00, opI32Const, 0, opEnd}, append(uleb(uint32(len(m.data))), m.data...)...),
+	)...)
+	return out
+}
+
+func uleb(v uint32) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func i32(v int32) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append([]byte{opI32Const}, append(out, b)...)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func code(instrs ...[]byte) []byte {
+	var out []byte
+	for _, instr := range instrs {
+		out = append(out, instr...)
+	}
+	return out
+}
+
+func call(fn byte) []byte { return []byte{opCall, fn} }
+
+// transformModule copies the message field to copy and deletes drop. The
+// names of the fields are at offsets 0, 16 and 32 of the memory and values
+// are read into a buffer at offset 256.
+var transformModule = testModule{
+	imports:     hostImports,
+	memoryPages: 1,
+	data:        []byte("message\x00\x00\x00\x00\x00\x00\x00\x00\x00copy\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00drop"),
+	body: code(
+		i32(0), i32(7), i32(256), i32(256), call(fnGet), []byte{opLocalSet, 0},
+		i32(16), i32(4), i32(256), []byte{opLocalGet, 0}, call(fnPut), []byte{opDrop},
+		i32(32), i32(4), call(fnDelete), []byte{opDrop},
+		i32(0),
+	),
+}
+
+func newTestProcessor(t *testing.T, m testModule, settings map[string]interface{}) processors.Processor {
+	t.Helper()
+	p, err := newTestProcessorErr(t, m, settings)
+	require.NoError(t, err)
+	t.Cleanup(func() { processors.Close(p) })
+	return p
+}
+
+func newTestProcessorErr(t *testing.T, m testModule, settings map[string]interface{}) (processors.Processor, error) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "processor.wasm")
+	require.NoError(t, os.WriteFile(file, m.encode(), 0o600))
+
+	c := defaultConfig()
+	c.File = file
+	if timeout, ok := settings["timeout"].(time.Duration); ok {
+		c.Timeout = timeout
+	}
+	if maxMemory, ok := settings["max_memory"].(int); ok {
+		c.MaxMemory = cfgtype.ByteSize(maxMemory)
+	}
+	return NewFromConfig(c)
+}
+
+func testEvent() *beat.Event {
+	return &beat.Event{
+		Fields: mapstr.M{
+			"message": "hello",
+			"drop":    true,
+		},
+	}
+}
+
+func TestProcessorTransform(t *testing.T) {
+	p := newTestProcessor(t, transformModule, nil)
+
+	// Run more than once to reuse the cached instances.
+	for i := 0; i < 3; i++ {
+		out, err := p.Run(testEvent())
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{"message": "hello", "copy": "hello"}, out.Fields)
+	}
+}
+
+func TestProcessorCancel(t *testing.T) {
+	p := newTestProcessor(t, testModule{
+		imports:     hostImports,
+		memoryPages: 1,
+		body:        code(call(fnCancel), i32(0)),
+	}, nil)
+
+	out, err := p.Run(testEvent())
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestProcessorErrors(t *testing.T) {
+	t.Run("non zero result", func(t *testing.T) {
+		p := newTestProcessor(t, testModule{memoryPages: 1, body: i32(1)}, nil)
+
+		out, err := p.Run(testEvent())
+		require.Error(t, err)
+		require.NotNil(t, out)
+		tags, _ := out.Fields.GetValue("tags")
+		assert.Equal(t, []string{"_wasm_exception"}, tags)
+		assert.Contains(t, out.Fields["error"], "message")
+	})
+
+	t.Run("out of bounds memory access", func(t *testing.T) {
+		p := newTestProcessor(t, testModule{
+			imports:     hostImports,
+			memoryPages: 1,
+			body:        code(i32(wasmPageSize), i32(4), call(fnDelete)),
+		}, nil)
+
+		for i := 0; i < 2; i++ {
+			out, err := p.Run(testEvent())
+			require.Error(t, err)
+			require.NotNil(t, out)
+		}
+	})
+}
+
+func TestProcessorTimeout(t *testing.T) {
+	loop := testModule{
+		memoryPages: 1,
+		body:        code([]byte{opLoop, blockEmpty, opBr, 0, opEnd}, i32(0)),
+	}
+	p := newTestProcessor(t, loop, map[string]interface{}{"timeout": 100 * time.Millisecond})
+
+	start := time.Now()
+	out, err := p.Run(testEvent())
+	require.Error(t, err)
+	assert.NotNil(t, out)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestProcessorSandbox(t *testing.T) {
+	t.Run("memory limit", func(t *testing.T) {
+		_, err := newTestProcessorErr(t, testModule{memoryPages: 2, body: i32(0)},
+			map[string]interface{}{"max_memory": wasmPageSize})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown import", func(t *testing.T) {
+		_, err := newTestProcessorErr(t, testModule{
+			imports:     []testImport{{"env", "system", 2}},
+			memoryPages: 1,
+			body:        i32(0),
+		}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing process function", func(t *testing.T) {
+		_, err := newTestProcessorErr(t, testModule{memoryPages: 1, body: i32(0), funcName: "run"}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type config struct {
+	Field          string        `config:"field" validate:"required"`
+	Target         string        `config:"target_field"`
+	DictionaryPath string        `config:"dictionary_path" validate:"required"`
+	Format         string        `config:"format"`
+	Regex          bool          `config:"regex"`
+	Default        interface{}   `config:"default"`
+	IgnoreMissing  bool          `config:"ignore_missing"`
+	IgnoreFailure  bool          `config:"ignore_failure"`
+	ReloadPeriod   time.Duration `config:"reload.period" validate:"min=0"`
+}
+
+// Supported dictionary formats.
+const (
+	formatCSV  = "csv"
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+func defaultConfig() config {
+	return config{
+		ReloadPeriod: time.Minute,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Target == "" {
+		c.Target = c.Field
+	}
+
+	if c.Format == "" {
+		// Select the format from the file extension.
+		switch ext := strings.ToLower(filepath.Ext(c.DictionaryPath)); ext {
+		case ".csv":
+			c.Format = formatCSV
+		case ".yml", ".yaml":
+			c.Format = formatYAML
+		case ".json":
+			c.Format = formatJSON
+		default:
+			return fmt.Errorf("cannot detect the format of dictionary file %v, "+
+				"set format to csv, yaml or json", c.DictionaryPath)
+		}
+	}
+
+	c.Format = strings.ToLower(c.Format)
+	switch c.Format {
+	case formatCSV, formatYAML, formatJSON:
+	default:
+		return fmt.Errorf("invalid dictionary format '%v' (valid values are: csv, yaml, json)", c.Format)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+// dictionary maps values to their translation. If the keys are regular
+// expressions, the translation of the first key matching a value is used.
+type dictionary struct {
+	exact map[string]interface{}
+	regex []regexEntry
+}
+
+type regexEntry struct {
+	pattern *regexp.Regexp
+	value   interface{}
+}
+
+// loadDictionary reads a dictionary file in the given format.
+func loadDictionary(path, format string, regex bool) (*dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries yaml.MapSlice
+	switch format {
+	case formatCSV:
+		entries, err = readCSV(f)
+	default:
+		// JSON is parsed as YAML, to keep the order of the keys.
+		var data []byte
+		if data, err = io.ReadAll(f); err == nil {
+			err = yaml.Unmarshal(data, &entries)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dictionary file %v: %w", path, err)
+	}
+
+	d := &dictionary{exact: make(map[string]interface{}, len(entries))}
+	for _, entry := range entries {
+		key := fmt.Sprint(entry.Key)
+		value, ok := scalarValue(entry.Value)
+		if !ok {
+			return nil, fmt.Errorf("value of dictionary key '%v' must be a scalar, but got %T", key, entry.Value)
+		}
+
+		if regex {
+			pattern, err := regexp.Compile(key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression in dictionary key '%v': %w", key, err)
+			}
+			d.regex = append(d.regex, regexEntry{pattern: pattern, value: value})
+			continue
+		}
+		d.exact[key] = value
+	}
+	return d, nil
+}
+
+// readCSV reads the keys and values from the first two columns of a CSV file.
+func readCSV(r io.Reader) (yaml.MapSlice, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var entries yaml.MapSlice
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, yaml.MapItem{Key: record[0], Value: record[1]})
+	}
+}
+
+func scalarValue(v interface{}) (interface{}, bool) {
+	switch v.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// lookup returns the translation of value.
+func (d *dictionary) lookup(value string) (interface{}, bool) {
+	if v, found := d.exact[value]; found {
+		return v, true
+	}
+	for _, entry := range d.regex {
+		if entry.pattern.MatchString(value) {
+			return entry.value, true
+		}
+	}
+	return nil, false
+}
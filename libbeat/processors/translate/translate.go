@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/libbeat/processors/checks"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	processorName = "translate"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("field", "dictionary_path"),
+			checks.AllowedFields("field", "target_field", "dictionary_path", "format",
+				"regex", "default", "ignore_missing", "ignore_failure", "reload", "when")))
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+
+	// mu protects the dictionary and the state of the dictionary file, which
+	// are updated when the file changes.
+	mu        sync.RWMutex
+	dict      *dictionary
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+// New returns a new translate processor mapping the values of a field
+// through a dictionary file.
+func New(cfg *conf.C) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v configuration: %w", processorName, err)
+	}
+
+	p := &processor{
+		config: c,
+		log:    logp.NewLogger(logName),
+	}
+	if err := p.load(time.Now()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// load reads the dictionary file. It must be called with mu held, or before
+// the processor is used.
+func (p *processor) load(now time.Time) error {
+	info, err := os.Stat(p.DictionaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to read dictionary file: %w", err)
+	}
+
+	dict, err := loadDictionary(p.DictionaryPath, p.Format, p.Regex)
+	if err != nil {
+		return err
+	}
+
+	p.dict = dict
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	p.lastCheck = now
+	return nil
+}
+
+// dictionary returns the current dictionary, reloading the dictionary file
+// if it changed and the reload period has elapsed since the last check.
+// If the reload fails the previous dictionary is kept.
+func (p *processor) dictionary() *dictionary {
+	now := time.Now()
+
+	p.mu.RLock()
+	dict := p.dict
+	check := p.ReloadPeriod > 0 && now.Sub(p.lastCheck) >= p.ReloadPeriod
+	p.mu.RUnlock()
+	if !check {
+		return dict
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if now.Sub(p.lastCheck) < p.ReloadPeriod {
+		// Checked by another goroutine in the meantime.
+		return p.dict
+	}
+	p.lastCheck = now
+
+	info, err := os.Stat(p.DictionaryPath)
+	if err != nil {
+		p.log.Warnf("Failed to check dictionary file for changes: %v", err)
+		return p.dict
+	}
+	if info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.dict
+	}
+
+	if err := p.load(now); err != nil {
+		p.log.Warnf("Failed to reload dictionary file, keeping the previous dictionary: %v", err)
+		return p.dict
+	}
+	p.log.Infof("Reloaded dictionary file %v", p.DictionaryPath)
+	return p.dict
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	err := p.translate(event)
+	if err == nil || p.IgnoreFailure || (p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound)) {
+		return event, nil
+	}
+	return event, fmt.Errorf("failed to translate field %v: %w", p.Field, err)
+}
+
+func (p *processor) translate(event *beat.Event) error {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		return err
+	}
+
+	var value string
+	switch v := v.(type) {
+	case string:
+		value = v
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		value = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("field value of type %T cannot be translated", v)
+	}
+
+	translation, found := p.dictionary().lookup(value)
+	if !found {
+		if p.Default == nil {
+			return nil
+		}
+		translation = p.Default
+	}
+
+	_, err = event.PutValue(p.Target, translation)
+	return err
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, target_field=%v, dictionary_path=%v, regex=%v]",
+		processorName, p.Field, p.Target, p.DictionaryPath, p.Regex)
+}
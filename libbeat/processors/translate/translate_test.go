@@ -0,0 +1,170 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var dictionaries = map[string]string{
+	"codes.csv":  "200,OK\n404, Not Found\n5\\d\\d,Server Error\n",
+	"codes.yml":  "200: OK\n404: Not Found\n'5\\d\\d': Server Error\n",
+	"codes.json": `{"200": "OK", "404": "Not Found", "5\\d\\d": "Server Error"}`,
+}
+
+func writeDictionary(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func newTestProcessor(t *testing.T, settings mapstr.M) *processor {
+	cfg := mapstr.M{"field": "http.response.status_code", "target_field": "http.response.status"}
+	cfg.DeepUpdate(settings)
+
+	p, err := New(conf.MustNewConfigFrom(cfg))
+	require.NoError(t, err)
+	return p.(*processor)
+}
+
+func TestTranslate(t *testing.T) {
+	for name, content := range dictionaries {
+		path := writeDictionary(t, name, content)
+
+		t.Run(name, func(t *testing.T) {
+			tests := map[string]struct {
+				settings mapstr.M
+				value    interface{}
+				expected interface{}
+			}{
+				"exact string":      {value: "404", expected: "Not Found"},
+				"exact number":      {value: 200, expected: "OK"},
+				"no match":          {value: 503, expected: nil},
+				"default":           {settings: mapstr.M{"default": "Unknown"}, value: 503, expected: "Unknown"},
+				"regex":             {settings: mapstr.M{"regex": true}, value: 503, expected: "Server Error"},
+				"regex exact match": {settings: mapstr.M{"regex": true}, value: 200, expected: "OK"},
+			}
+
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					settings := mapstr.M{"dictionary_path": path}
+					settings.DeepUpdate(test.settings)
+					p := newTestProcessor(t, settings)
+
+					event := &beat.Event{Fields: mapstr.M{"http": mapstr.M{"response": mapstr.M{"status_code": test.value}}}}
+					event, err := p.Run(event)
+					require.NoError(t, err)
+
+					v, err := event.GetValue("http.response.status")
+					if test.expected == nil {
+						assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+						return
+					}
+					require.NoError(t, err)
+					assert.Equal(t, test.expected, v)
+				})
+			}
+		})
+	}
+}
+
+func TestTranslateInPlace(t *testing.T) {
+	path := writeDictionary(t, "levels.yml", "E: error\nW: warning\n")
+	p, err := New(conf.MustNewConfigFrom(mapstr.M{"field": "log.level", "dictionary_path": path}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{"log": mapstr.M{"level": "W"}}})
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{"log": mapstr.M{"level": "warning"}}, event.Fields)
+}
+
+func TestTranslateErrors(t *testing.T) {
+	path := writeDictionary(t, "codes.csv", dictionaries["codes.csv"])
+
+	p := newTestProcessor(t, mapstr.M{"dictionary_path": path})
+	_, err := p.Run(&beat.Event{Fields: mapstr.M{}})
+	assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+
+	_, err = p.Run(&beat.Event{Fields: mapstr.M{"http": mapstr.M{"response": mapstr.M{"status_code": []int{}}}}})
+	assert.Error(t, err)
+
+	p = newTestProcessor(t, mapstr.M{"dictionary_path": path, "ignore_missing": true})
+	_, err = p.Run(&beat.Event{Fields: mapstr.M{}})
+	assert.NoError(t, err)
+
+	p = newTestProcessor(t, mapstr.M{"dictionary_path": path, "ignore_failure": true})
+	_, err = p.Run(&beat.Event{Fields: mapstr.M{"http": mapstr.M{"response": mapstr.M{"status_code": []int{}}}}})
+	assert.NoError(t, err)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"missing dictionary": {"dictionary_path": filepath.Join(t.TempDir(), "missing.yml")},
+		"unknown format":     {"dictionary_path": writeDictionary(t, "codes.txt", "200: OK")},
+		"invalid format":     {"dictionary_path": writeDictionary(t, "codes.yml", "200: OK"), "format": "xml"},
+		"invalid csv":        {"dictionary_path": writeDictionary(t, "codes.csv", "200,OK,extra\n")},
+		"invalid yaml":       {"dictionary_path": writeDictionary(t, "codes.yml", "- 200\n- OK\n")},
+		"non scalar value":   {"dictionary_path": writeDictionary(t, "codes.yml", "200: [OK]\n")},
+		"invalid regex":      {"dictionary_path": writeDictionary(t, "codes.yml", "'5[': Server Error\n"), "regex": true},
+		"missing field":      {"dictionary_path": writeDictionary(t, "codes.yml", "200: OK"), "field": ""},
+		"negative reload":    {"dictionary_path": writeDictionary(t, "codes.yml", "200: OK"), "reload.period": "-1s"},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := mapstr.M{"field": "http.response.status_code"}
+			cfg.DeepUpdate(settings)
+			_, err := New(conf.MustNewConfigFrom(cfg))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	path := writeDictionary(t, "codes.yml", "200: OK\n")
+	p := newTestProcessor(t, mapstr.M{"dictionary_path": path, "reload.period": "1ns"})
+
+	translate := func() interface{} {
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"http": mapstr.M{"response": mapstr.M{"status_code": 200}}}})
+		require.NoError(t, err)
+		v, _ := event.GetValue("http.response.status")
+		return v
+	}
+	assert.Equal(t, "OK", translate())
+
+	require.NoError(t, os.WriteFile(path, []byte("200: Success\n"), 0o644))
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, future, future))
+	assert.Equal(t, "Success", translate())
+
+	// The previous dictionary is kept if the file becomes invalid.
+	require.NoError(t, os.WriteFile(path, []byte("200: [Success]\n"), 0o644))
+	future = future.Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, future, future))
+	assert.Equal(t, "Success", translate())
+}
@@ -19,15 +19,21 @@ package includes
 
 import (
 	// import queue types
+	_ "github.com/elastic/beats/v7/libbeat/outputs/azureeventhub"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/codec/format"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/codec/json"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/console"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/elasticsearch"
+	_ "github.com/elastic/beats/v7/libbeat/outputs/failover"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/fileout"
+	_ "github.com/elastic/beats/v7/libbeat/outputs/gcppubsub"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/kafka"
+	_ "github.com/elastic/beats/v7/libbeat/outputs/kinesis"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/logstash"
+	_ "github.com/elastic/beats/v7/libbeat/outputs/otlp"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/redis"
 	_ "github.com/elastic/beats/v7/libbeat/outputs/shipper"
+	_ "github.com/elastic/beats/v7/libbeat/outputs/tee"
 	_ "github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
 	_ "github.com/elastic/beats/v7/libbeat/publisher/queue/memqueue"
 )
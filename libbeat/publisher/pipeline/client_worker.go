@@ -26,12 +26,19 @@ import (
 	"go.elastic.co/apm/v2"
 
 	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
 )
 
 type worker struct {
 	observer outputObserver
 	qu       chan publisher.Batch
 	done     chan struct{}
+
+	// limiter limits the publishing rate to the host of the client, it is
+	// nil if the output is not rate limited. The encoder is used to
+	// estimate the size of the events, if the limiter has a bytes limit.
+	limiter *rateLimiter
+	encoder *json.Encoder
 }
 
 // clientWorker manages output client of type outputs.Client, not supporting reconnect.
@@ -50,11 +57,15 @@ type netClientWorker struct {
 	tracer *apm.Tracer
 }
 
-func makeClientWorker(observer outputObserver, qu chan publisher.Batch, client outputs.Client, logger logger, tracer *apm.Tracer) outputWorker {
+func makeClientWorker(observer outputObserver, qu chan publisher.Batch, client outputs.Client, limiter *rateLimiter, logger logger, tracer *apm.Tracer) outputWorker {
 	w := worker{
 		observer: observer,
 		qu:       qu,
 		done:     make(chan struct{}),
+		limiter:  limiter,
+	}
+	if limiter != nil && limiter.bytes != nil {
+		w.encoder = json.New(limiter.version, json.Config{})
 	}
 
 	var c interface {
@@ -81,6 +92,25 @@ func (w *worker) close() {
 	close(w.done)
 }
 
+// waitRateLimit waits until the batch can be published according to the
+// rate limit. Returns false if the worker is closed while waiting.
+func (w *worker) waitRateLimit(batch publisher.Batch) bool {
+	if w.limiter == nil {
+		return true
+	}
+
+	events := batch.Events()
+	bytes := 0
+	if w.encoder != nil {
+		for i := range events {
+			if data, err := w.encoder.Encode("", &events[i].Content); err == nil {
+				bytes += len(data)
+			}
+		}
+	}
+	return w.limiter.wait(len(events), bytes, w.done)
+}
+
 func (w *clientWorker) Close() error {
 	w.worker.close()
 	return w.client.Close()
@@ -99,6 +129,10 @@ func (w *clientWorker) run() {
 			if batch == nil {
 				continue
 			}
+			if !w.waitRateLimit(batch) {
+				batch.Cancelled()
+				return
+			}
 			if err := w.client.Publish(context.TODO(), batch); err != nil {
 				return
 			}
@@ -154,6 +188,10 @@ func (w *netClientWorker) run() {
 				continue
 			}
 
+			if !w.waitRateLimit(batch) {
+				batch.Cancelled()
+				return
+			}
 			if err := w.publishBatch(batch); err != nil {
 				connected = false
 			}
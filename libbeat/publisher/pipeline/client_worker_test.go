@@ -64,7 +64,7 @@ func TestMakeClientWorker(t *testing.T) {
 
 				client := ctor(publishFn)
 
-				worker := makeClientWorker(nilObserver, workQueue, client, logger, nil)
+				worker := makeClientWorker(nilObserver, workQueue, client, nil, logger, nil)
 				defer worker.Close()
 
 				for i := uint(0); i < numBatches; i++ {
@@ -158,7 +158,7 @@ func TestReplaceClientWorker(t *testing.T) {
 				}
 
 				client := ctor(blockingPublishFn)
-				worker := makeClientWorker(nilObserver, workQueue, client, logger, nil)
+				worker := makeClientWorker(nilObserver, workQueue, client, nil, logger, nil)
 
 				// Allow the worker to make *some* progress before we close it
 				timeout := 10 * time.Second
@@ -185,7 +185,7 @@ func TestReplaceClientWorker(t *testing.T) {
 				}
 
 				client = ctor(countingPublishFn)
-				makeClientWorker(nilObserver, workQueue, client, logger, nil)
+				makeClientWorker(nilObserver, workQueue, client, nil, logger, nil)
 				wg.Wait()
 
 				// Make sure that all events have eventually been published
@@ -231,7 +231,7 @@ func TestMakeClientTracer(t *testing.T) {
 	recorder := apmtest.NewRecordingTracer()
 	defer recorder.Close()
 
-	worker := makeClientWorker(nilObserver, workQueue, client, logger, recorder.Tracer)
+	worker := makeClientWorker(nilObserver, workQueue, client, nil, logger, recorder.Tracer)
 	defer worker.Close()
 
 	for i := 0; i < numBatches; i++ {
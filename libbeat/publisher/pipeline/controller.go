@@ -25,6 +25,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
 // outputController manages the pipelines output capabilities, like:
@@ -101,11 +102,13 @@ func (c *outputController) Set(outGrp outputs.Group) {
 
 	// create new output group with the shared work queue
 	clients := outGrp.Clients
+	limiters := makeRateLimiters(c.beat.Version, outGrp)
 	worker := make([]outputWorker, len(clients))
 	for i, client := range clients {
 		logger := logp.NewLogger("publisher_pipeline_output")
-		worker[i] = makeClientWorker(c.observer, c.workQueue, client, logger, c.monitors.Tracer)
+		worker[i] = makeClientWorker(c.observer, c.workQueue, client, limiters[client.String()], logger, c.monitors.Tracer)
 	}
+	c.reportRateLimiters(limiters)
 	grp := &outputGroup{
 		workQueue:  c.workQueue,
 		outputs:    worker,
@@ -124,6 +127,23 @@ func (c *outputController) Set(outGrp outputs.Group) {
 		})
 }
 
+// reportRateLimiters exposes the state of the rate limiters of the output
+// in the output metrics, replacing the state of the previous output.
+func (c *outputController) reportRateLimiters(limiters map[string]*rateLimiter) {
+	if c.monitors.Metrics == nil {
+		return
+	}
+	reg := c.monitors.Metrics.GetRegistry("output")
+	if reg == nil {
+		return
+	}
+
+	reg.Remove("rate_limit")
+	if len(limiters) > 0 {
+		monitoring.NewFunc(reg, "rate_limit", reportRateLimiters(limiters), monitoring.Report)
+	}
+}
+
 // Reload the output
 func (c *outputController) Reload(
 	cfg *reload.ConfigWithMeta,
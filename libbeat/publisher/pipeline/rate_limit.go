@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// rateLimiter limits the rate batches are published with to a single host.
+// It is shared by all output workers publishing to the same host.
+type rateLimiter struct {
+	host    string
+	version string // beat version used to estimate the size of events
+	events  *tokenBucket
+	bytes   *tokenBucket
+
+	// now is the time source, to be overwritten in tests.
+	now func() time.Time
+
+	throttled uint64 // number of batches delayed by the limiter (atomic)
+	waitTime  uint64 // total delay of batches in nanoseconds (atomic)
+}
+
+// tokenBucket is a token bucket refilled at rate tokens per second, holding
+// up to one second of tokens. Taking more tokens than available puts the
+// bucket in debt, which must be refilled before the next batch is published.
+// This allows batches bigger than the bucket size to be published.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(host, version string, config outputs.RateLimit) *rateLimiter {
+	now := time.Now()
+	return &rateLimiter{
+		host:    host,
+		version: version,
+		events:  newTokenBucket(config.EventsPerSecond, now),
+		bytes:   newTokenBucket(float64(config.BytesPerSecond), now),
+		now:     time.Now,
+	}
+}
+
+// makeRateLimiters creates one rate limiter per host of the output clients.
+// Returns nil if the output is not rate limited.
+func makeRateLimiters(version string, group outputs.Group) map[string]*rateLimiter {
+	if !group.RateLimit.Enabled() {
+		return nil
+	}
+
+	limiters := map[string]*rateLimiter{}
+	for _, client := range group.Clients {
+		host := client.String()
+		if limiters[host] == nil {
+			limiters[host] = newRateLimiter(host, version, group.RateLimit)
+		}
+	}
+	return limiters
+}
+
+// wait takes the tokens required to publish a batch, and waits until the
+// tokens are available. Returns false if done is closed while waiting.
+func (l *rateLimiter) wait(events, bytes int, done <-chan struct{}) bool {
+	now := l.now()
+	delay := l.events.take(float64(events), now)
+	if d := l.bytes.take(float64(bytes), now); d > delay {
+		delay = d
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	atomic.AddUint64(&l.throttled, 1)
+	atomic.AddUint64(&l.waitTime, uint64(delay))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (l *rateLimiter) report(V monitoring.Visitor) {
+	now := l.now()
+	if l.events != nil {
+		monitoring.ReportNamespace(V, "events", func() {
+			monitoring.ReportFloat(V, "limit", l.events.rate)
+			monitoring.ReportFloat(V, "tokens", l.events.available(now))
+		})
+	}
+	if l.bytes != nil {
+		monitoring.ReportNamespace(V, "bytes", func() {
+			monitoring.ReportFloat(V, "limit", l.bytes.rate)
+			monitoring.ReportFloat(V, "tokens", l.bytes.available(now))
+		})
+	}
+	monitoring.ReportInt(V, "throttled", int64(atomic.LoadUint64(&l.throttled)))
+	monitoring.ReportInt(V, "wait.ms", int64(time.Duration(atomic.LoadUint64(&l.waitTime))/time.Millisecond))
+}
+
+// reportRateLimiters returns a monitoring function reporting the state of
+// the rate limiters by host.
+func reportRateLimiters(limiters map[string]*rateLimiter) func(monitoring.Mode, monitoring.Visitor) {
+	hosts := make([]string, 0, len(limiters))
+	for host := range limiters {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	return func(_ monitoring.Mode, V monitoring.Visitor) {
+		V.OnRegistryStart()
+		defer V.OnRegistryFinished()
+
+		for _, host := range hosts {
+			limiter := limiters[host]
+			monitoring.ReportNamespace(V, host, func() {
+				limiter.report(V)
+			})
+		}
+	}
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	return &tokenBucket{rate: rate, tokens: rate, last: now}
+}
+
+// take removes n tokens from the bucket, and returns how long to wait for
+// the bucket to be refilled if there were not enough tokens.
+func (b *tokenBucket) take(n float64, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// available returns the number of tokens in the bucket, which is negative
+// while the bucket is in debt.
+func (b *tokenBucket) available(now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	return b.tokens
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+}
@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, now)
+
+	assert.Zero(t, b.take(5, now))
+	assert.Equal(t, 5.0, b.available(now))
+
+	// Taking more tokens than available puts the bucket in debt.
+	assert.Equal(t, time.Second, b.take(15, now))
+	assert.Equal(t, -10.0, b.available(now))
+
+	// The bucket is refilled at rate, up to one second of tokens.
+	now = now.Add(1500 * time.Millisecond)
+	assert.Equal(t, 5.0, b.available(now))
+	now = now.Add(time.Hour)
+	assert.Equal(t, 10.0, b.available(now))
+
+	assert.Nil(t, newTokenBucket(0, now))
+	assert.Zero(t, (*tokenBucket)(nil).take(100, now))
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := newRateLimiter("host", "8.0.0", outputs.RateLimit{EventsPerSecond: 100, BytesPerSecond: 1000})
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	done := make(chan struct{})
+	assert.True(t, l.wait(100, 100, done))
+	assert.Zero(t, l.throttled)
+
+	// The bytes limit delays the batch by 10ms.
+	assert.True(t, l.wait(0, 910, done))
+	assert.Equal(t, uint64(1), l.throttled)
+	assert.Equal(t, uint64(10*time.Millisecond), l.waitTime)
+
+	close(done)
+	assert.False(t, l.wait(1000, 0, done))
+	assert.Equal(t, uint64(2), l.throttled)
+}
+
+func TestMakeRateLimiters(t *testing.T) {
+	clients := []outputs.Client{
+		newMockClient(nil),
+		newMockClient(nil),
+		newMockNetworkClient(nil),
+	}
+
+	assert.Nil(t, makeRateLimiters("8.0.0", outputs.Group{Clients: clients}))
+
+	limiters := makeRateLimiters("8.0.0", outputs.Group{
+		Clients:   clients,
+		RateLimit: outputs.RateLimit{EventsPerSecond: 10},
+	})
+	require.Len(t, limiters, 1)
+	l := limiters["mock_client"]
+	require.NotNil(t, l)
+	assert.NotNil(t, l.events)
+	assert.Nil(t, l.bytes)
+}
+
+func TestRateLimitedWorker(t *testing.T) {
+	limiter := newRateLimiter("mock_client", "8.0.0", outputs.RateLimit{EventsPerSecond: 1, BytesPerSecond: 1})
+	published := make(chan publisher.Batch, 1)
+	client := newMockClient(func(batch publisher.Batch) error {
+		published <- batch
+		return nil
+	})
+
+	workQueue := make(chan publisher.Batch)
+	worker := makeClientWorker(nilObserver, workQueue, client, limiter, nil, nil)
+
+	event := publisher.Event{Content: beat.Event{Fields: mapstr.M{"message": "hello"}}}
+	cancelled := make(chan struct{})
+	batch := &mockBatch{
+		events:      []publisher.Event{event},
+		onCancelled: func() { close(cancelled) },
+	}
+
+	// The size of the event is more than one second worth of bytes, so the
+	// batch is delayed until the worker is closed.
+	workQueue <- batch
+	require.Eventually(t, func() bool {
+		return limiter.bytes.available(time.Now()) < 0
+	}, time.Second, time.Millisecond)
+	worker.Close()
+
+	select {
+	case <-cancelled:
+	case <-published:
+		t.Fatal("rate limited batch was published")
+	case <-time.After(time.Second):
+		t.Fatal("batch was not cancelled")
+	}
+}
+
+func TestReportRateLimiters(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	limiters := map[string]*rateLimiter{
+		"b": newRateLimiter("b", "8.0.0", outputs.RateLimit{EventsPerSecond: 10}),
+		"a": newRateLimiter("a", "8.0.0", outputs.RateLimit{BytesPerSecond: 100}),
+	}
+	monitoring.NewFunc(reg, "rate_limit", reportRateLimiters(limiters), monitoring.Report)
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, 10.0, snapshot.Floats["rate_limit.b.events.limit"])
+	assert.Equal(t, 100.0, snapshot.Floats["rate_limit.a.bytes.limit"])
+	assert.Equal(t, int64(0), snapshot.Ints["rate_limit.a.throttled"])
+	assert.NotContains(t, snapshot.Floats, "rate_limit.a.events.limit")
+}
+
+func TestLoadOutputRateLimit(t *testing.T) {
+	outputs.RegisterType("rate_limit_test", func(
+		_ outputs.IndexManager, _ beat.Info, _ outputs.Observer, _ *config.C,
+	) (outputs.Group, error) {
+		return outputs.Success(10, 0, newMockClient(nil))
+	})
+
+	cfg := config.MustNewConfigFrom(mapstr.M{
+		"rate_limit.events_per_second": 500,
+		"rate_limit.bytes_per_second":  "1MiB",
+	})
+	group, err := outputs.Load(nil, beat.Info{}, nil, "rate_limit_test", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, group.RateLimit.EventsPerSecond)
+	assert.EqualValues(t, 1024*1024, group.RateLimit.BytesPerSecond)
+
+	cfg = config.MustNewConfigFrom(mapstr.M{"rate_limit.events_per_second": -1})
+	_, err = outputs.Load(nil, beat.Info{}, nil, "rate_limit_test", cfg)
+	assert.Error(t, err)
+}
@@ -18,6 +18,7 @@
 package diskqueue
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -72,9 +73,20 @@ type Settings struct {
 	// EncryptionKey is used to encrypt data if SchemaVersion 2 is used.
 	EncryptionKey []byte
 
+	// EncryptionKeys enables the AES-GCM encryption of the segments. New
+	// segments are encrypted with the first key, the others are used to
+	// read the segments encrypted before the keys were rotated. It takes
+	// precedence over EncryptionKey.
+	EncryptionKeys [][]byte
+
 	// UseCompression enables or disables LZ4 compression
 	UseCompression bool
 
+	// ZstdCompressionLevel enables the zstd compression of the segments
+	// with the given level, from 1 (fastest) to 22 (best compression). It
+	// takes precedence over UseCompression.
+	ZstdCompressionLevel int
+
 	// UseProtobuf enables protobuf serialization instead of CBOR
 	UseProtobuf bool
 }
@@ -91,6 +103,40 @@ type userConfig struct {
 
 	RetryInterval    *time.Duration `config:"retry_interval" validate:"positive"`
 	MaxRetryInterval *time.Duration `config:"max_retry_interval" validate:"positive"`
+
+	Encryption  *encryptionConfig  `config:"encryption"`
+	Compression *compressionConfig `config:"compression"`
+}
+
+// compressionConfig enables the zstd compression of the segments.
+type compressionConfig struct {
+	Enabled bool `config:"enabled"`
+	Level   int  `config:"level" validate:"min=1, max=22"`
+}
+
+// encryptionConfig holds the base64 encoded AES keys of the segments.
+// They are usually references to keystore entries.
+type encryptionConfig struct {
+	Key          string   `config:"key" validate:"required"`
+	PreviousKeys []string `config:"previous_keys"`
+}
+
+// keys decodes the current key followed by the previous ones.
+func (c *encryptionConfig) keys() ([][]byte, error) {
+	keys := make([][]byte, 0, 1+len(c.PreviousKeys))
+	for i, encoded := range append([]string{c.Key}, c.PreviousKeys...) {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %d is not base64 encoded: %w", i, err)
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("encryption key %d must be 16, 24 or 32 bytes long, not %d", i, len(key))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
 }
 
 func (c *userConfig) Validate() error {
@@ -123,6 +169,12 @@ func (c *userConfig) Validate() error {
 			*c.MaxRetryInterval, *c.RetryInterval)
 	}
 
+	if c.Encryption != nil {
+		if _, err := c.Encryption.keys(); err != nil {
+			return fmt.Errorf("disk queue encryption: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -144,7 +196,9 @@ func DefaultSettings() Settings {
 // SettingsForUserConfig returns a Settings struct initialized with the
 // end-user-configurable settings in the given config tree.
 func SettingsForUserConfig(config *config.C) (Settings, error) {
-	userConfig := userConfig{}
+	userConfig := userConfig{
+		Compression: &compressionConfig{Level: defaultZstdCompressionLevel},
+	}
 	if err := config.Unpack(&userConfig); err != nil {
 		return Settings{}, fmt.Errorf("parsing user config: %w", err)
 	}
@@ -174,6 +228,18 @@ func SettingsForUserConfig(config *config.C) (Settings, error) {
 		settings.MaxRetryInterval = *userConfig.RetryInterval
 	}
 
+	if userConfig.Compression != nil && userConfig.Compression.Enabled {
+		settings.ZstdCompressionLevel = userConfig.Compression.Level
+	}
+
+	if userConfig.Encryption != nil {
+		keys, err := userConfig.Encryption.keys()
+		if err != nil {
+			return Settings{}, err
+		}
+		settings.EncryptionKeys = keys
+	}
+
 	return settings, nil
 }
 
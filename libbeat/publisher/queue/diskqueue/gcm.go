@@ -0,0 +1,255 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The GCM encrypted stream of a segment starts with the ID of the key it is
+// encrypted with, followed by chunks of data. Each chunk is the 4-byte length
+// of its sealed data, a random nonce, and the data sealed with the index of
+// the chunk as additional data, to detect reordered chunks.
+const (
+	// gcmKeyIDSize is the size of the key IDs, the beginning of the SHA-256
+	// hash of the keys.
+	gcmKeyIDSize = 8
+
+	// gcmMaxChunkSize is the maximum size of the plaintext of a chunk. The
+	// pending data is also sealed when the writer is synced.
+	gcmMaxChunkSize = 64 * 1024
+)
+
+var errNoMatchingKey = errors.New("none of the encryption keys matches the segment key")
+
+// gcmKeyID returns the ID written in the segments encrypted with the key.
+func gcmKeyID(key []byte) []byte {
+	sum := sha256.Sum256(key)
+	return sum[:gcmKeyIDSize]
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// GCMEncryptionReader allows reading from an AES-GCM encrypted stream.
+type GCMEncryptionReader struct {
+	src  io.ReadCloser
+	keys [][]byte
+
+	aead   cipher.AEAD
+	keyID  []byte
+	chunk  uint64
+	sealed []byte
+	plain  []byte
+	buf    []byte
+}
+
+// NewGCMEncryptionReader returns a new AES-GCM decrypter, using the key of
+// keys the stream was encrypted with.
+func NewGCMEncryptionReader(r io.ReadCloser, keys [][]byte) (*GCMEncryptionReader, error) {
+	gr := &GCMEncryptionReader{src: r, keys: keys}
+
+	keyID := make([]byte, gcmKeyIDSize)
+	if _, err := io.ReadFull(r, keyID); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if bytes.Equal(keyID, gcmKeyID(key)) {
+			aead, err := newGCM(key)
+			if err != nil {
+				return nil, err
+			}
+			gr.aead = aead
+			gr.keyID = keyID
+			return gr, nil
+		}
+	}
+	return nil, errNoMatchingKey
+}
+
+func (gr *GCMEncryptionReader) Read(buf []byte) (int, error) {
+	if len(gr.buf) == 0 {
+		if err := gr.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(buf, gr.buf)
+	gr.buf = gr.buf[n:]
+	return n, nil
+}
+
+func (gr *GCMEncryptionReader) readChunk() error {
+	var size uint32
+	if err := binary.Read(gr.src, binary.LittleEndian, &size); err != nil {
+		return err
+	}
+	nonceSize := uint32(gr.aead.NonceSize())
+	if size < nonceSize+uint32(gr.aead.Overhead()) || size > nonceSize+gcmMaxChunkSize+uint32(gr.aead.Overhead()) {
+		return fmt.Errorf("invalid encrypted chunk size %d", size)
+	}
+
+	if cap(gr.sealed) < int(size) {
+		gr.sealed = make([]byte, size)
+	}
+	gr.sealed = gr.sealed[:size]
+	if _, err := io.ReadFull(gr.src, gr.sealed); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	plain, err := gr.aead.Open(gr.plain[:0], gr.sealed[:nonceSize], gr.sealed[nonceSize:], chunkAdditionalData(gr.chunk))
+	if err != nil {
+		return fmt.Errorf("could not decrypt chunk %d: %w", gr.chunk, err)
+	}
+	gr.chunk++
+	gr.plain = plain
+	gr.buf = plain
+	return nil
+}
+
+func (gr *GCMEncryptionReader) Close() error {
+	return gr.src.Close()
+}
+
+// Reset sets up the stream again, assumes that caller has already set the
+// src to the key ID.
+func (gr *GCMEncryptionReader) Reset() error {
+	keyID := make([]byte, gcmKeyIDSize)
+	if _, err := io.ReadFull(gr.src, keyID); err != nil {
+		return err
+	}
+	if !bytes.Equal(keyID, gr.keyID) {
+		return fmt.Errorf("different key, something is wrong")
+	}
+	gr.chunk = 0
+	gr.buf = nil
+	return nil
+}
+
+// GCMEncryptionWriter allows writing to an AES-GCM encrypted stream.
+type GCMEncryptionWriter struct {
+	dst    WriteCloseSyncer
+	aead   cipher.AEAD
+	chunk  uint64
+	buf    []byte
+	sealed []byte
+}
+
+// NewGCMEncryptionWriter returns a new AES-GCM stream encryptor.
+func NewGCMEncryptionWriter(w WriteCloseSyncer, key []byte) (*GCMEncryptionWriter, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := w.Write(gcmKeyID(key))
+	if err != nil {
+		return nil, err
+	}
+	if n != gcmKeyIDSize {
+		return nil, io.ErrShortWrite
+	}
+
+	return &GCMEncryptionWriter{
+		dst:  w,
+		aead: aead,
+		buf:  make([]byte, 0, gcmMaxChunkSize),
+	}, nil
+}
+
+func (gw *GCMEncryptionWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := gcmMaxChunkSize - len(gw.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		gw.buf = append(gw.buf, p[:n]...)
+		p = p[n:]
+		written += n
+
+		if len(gw.buf) == gcmMaxChunkSize {
+			if err := gw.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// flush seals the pending data into a chunk.
+func (gw *GCMEncryptionWriter) flush() error {
+	if len(gw.buf) == 0 {
+		return nil
+	}
+
+	nonceSize := gw.aead.NonceSize()
+	size := nonceSize + len(gw.buf) + gw.aead.Overhead()
+	if cap(gw.sealed) < 4+size {
+		gw.sealed = make([]byte, 4+size)
+	}
+	sealed := gw.sealed[:4+nonceSize]
+	binary.LittleEndian.PutUint32(sealed, uint32(size))
+	if _, err := io.ReadFull(rand.Reader, sealed[4:]); err != nil {
+		return err
+	}
+	sealed = gw.aead.Seal(sealed, sealed[4:], gw.buf, chunkAdditionalData(gw.chunk))
+
+	if _, err := gw.dst.Write(sealed); err != nil {
+		return err
+	}
+	gw.chunk++
+	gw.buf = gw.buf[:0]
+	return nil
+}
+
+func (gw *GCMEncryptionWriter) Close() error {
+	if err := gw.flush(); err != nil {
+		gw.dst.Close()
+		return err
+	}
+	return gw.dst.Close()
+}
+
+func (gw *GCMEncryptionWriter) Sync() error {
+	if err := gw.flush(); err != nil {
+		return err
+	}
+	return gw.dst.Sync()
+}
+
+func chunkAdditionalData(chunk uint64) []byte {
+	var data [8]byte
+	binary.LittleEndian.PutUint64(data[:], chunk)
+	return data[:]
+}
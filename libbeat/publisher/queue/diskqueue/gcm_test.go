@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+type bufferWriteCloseSyncer struct {
+	bytes.Buffer
+}
+
+func (*bufferWriteCloseSyncer) Close() error { return nil }
+func (*bufferWriteCloseSyncer) Sync() error  { return nil }
+
+func gcmEncrypt(t *testing.T, key []byte, chunks ...[]byte) []byte {
+	var dst bufferWriteCloseSyncer
+	gw, err := NewGCMEncryptionWriter(&dst, key)
+	require.NoError(t, err)
+	for _, chunk := range chunks {
+		_, err := gw.Write(chunk)
+		require.NoError(t, err)
+		require.NoError(t, gw.Sync())
+	}
+	require.NoError(t, gw.Close())
+	return dst.Bytes()
+}
+
+func TestGCMEncryptionRoundTrip(t *testing.T) {
+	key := []byte("kkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkk")
+	tests := map[string][][]byte{
+		"8 bits":          {[]byte("a")},
+		"several syncs":   {[]byte("abc"), []byte("defg"), []byte("h")},
+		"multiple chunks": {bytes.Repeat([]byte("x"), 3*gcmMaxChunkSize+1)},
+	}
+	for name, chunks := range tests {
+		t.Run(name, func(t *testing.T) {
+			ciphertext := gcmEncrypt(t, key, chunks...)
+			plaintext := bytes.Join(chunks, nil)
+			assert.False(t, bytes.Contains(ciphertext, plaintext))
+
+			gr, err := NewGCMEncryptionReader(io.NopCloser(bytes.NewReader(ciphertext)), [][]byte{key})
+			require.NoError(t, err)
+			var dst bytes.Buffer
+			_, err = io.Copy(&dst, gr)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, dst.Bytes())
+		})
+	}
+}
+
+func TestGCMEncryptionKeyRotation(t *testing.T) {
+	oldKey := []byte("oooooooooooooooo")
+	newKey := []byte("nnnnnnnnnnnnnnnnnnnnnnnnnnnnnnnn")
+	ciphertext := gcmEncrypt(t, oldKey, []byte("written before the rotation"))
+
+	_, err := NewGCMEncryptionReader(io.NopCloser(bytes.NewReader(ciphertext)), [][]byte{newKey})
+	assert.ErrorIs(t, err, errNoMatchingKey)
+
+	gr, err := NewGCMEncryptionReader(io.NopCloser(bytes.NewReader(ciphertext)), [][]byte{newKey, oldKey})
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "written before the rotation", string(plaintext))
+}
+
+func TestGCMEncryptionTampered(t *testing.T) {
+	key := []byte("kkkkkkkkkkkkkkkk")
+
+	ciphertext := gcmEncrypt(t, key, []byte("abc"))
+	ciphertext[len(ciphertext)-1] ^= 1
+	gr, err := NewGCMEncryptionReader(io.NopCloser(bytes.NewReader(ciphertext)), [][]byte{key})
+	require.NoError(t, err)
+	_, err = io.ReadAll(gr)
+	assert.Error(t, err)
+
+	// reordered chunks are detected
+	first := gcmEncrypt(t, key, []byte("abc"), []byte("def"))
+	chunkSize := (len(first) - gcmKeyIDSize) / 2
+	reordered := append([]byte{}, first[:gcmKeyIDSize]...)
+	reordered = append(reordered, first[gcmKeyIDSize+chunkSize:]...)
+	reordered = append(reordered, first[gcmKeyIDSize:gcmKeyIDSize+chunkSize]...)
+	gr, err = NewGCMEncryptionReader(io.NopCloser(bytes.NewReader(reordered)), [][]byte{key})
+	require.NoError(t, err)
+	_, err = io.ReadAll(gr)
+	assert.Error(t, err)
+
+	// truncated chunks are detected
+	gr, err = NewGCMEncryptionReader(io.NopCloser(bytes.NewReader(first[:len(first)-1])), [][]byte{key})
+	require.NoError(t, err)
+	_, err = io.ReadAll(gr)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestEncryptionConfig(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("kkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkk"))
+	previous := base64.StdEncoding.EncodeToString([]byte("pppppppppppppppp"))
+
+	settings, err := SettingsForUserConfig(config.MustNewConfigFrom(map[string]interface{}{
+		"max_size":                 "1GB",
+		"encryption.key":           key,
+		"encryption.previous_keys": []string{previous},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{
+		[]byte("kkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkk"),
+		[]byte("pppppppppppppppp"),
+	}, settings.EncryptionKeys)
+
+	for name, key := range map[string]string{
+		"not base64":   "not base64!",
+		"invalid size": base64.StdEncoding.EncodeToString([]byte("short")),
+		"missing":      "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := SettingsForUserConfig(config.MustNewConfigFrom(map[string]interface{}{
+				"max_size":       "1GB",
+				"encryption.key": key,
+			}))
+			assert.Error(t, err)
+		})
+	}
+}
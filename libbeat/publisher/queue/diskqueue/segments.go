@@ -158,8 +158,24 @@ const (
 	ENABLE_ENCRYPTION  uint32 = 1 << iota // 0x1
 	ENABLE_COMPRESSION                    // 0x2
 	ENABLE_PROTOBUF                       // 0x4
+	ENABLE_GCM_ENCRYPTION                 // 0x8
+	ENABLE_ZSTD_COMPRESSION               // 0x10
 )
 
+// encryptionReader is a reader of an encrypted stream, that can be reset to
+// the beginning of the stream.
+type encryptionReader interface {
+	io.ReadCloser
+	Reset() error
+}
+
+// compressionReader is a reader of a compressed stream, that can be reset to
+// the beginning of the stream.
+type compressionReader interface {
+	io.ReadCloser
+	Reset() error
+}
+
 // Sort order: we store loaded segments in ascending order by their id.
 type bySegmentID []*queueSegment
 
@@ -265,6 +281,13 @@ func (segment *queueSegment) getReader(queueSettings Settings) (*segmentReader,
 			return nil, fmt.Errorf("couldn't create encryption reader: %w", err)
 		}
 	}
+	if (header.options & ENABLE_GCM_ENCRYPTION) == ENABLE_GCM_ENCRYPTION {
+		sr.er, err = NewGCMEncryptionReader(sr.src, queueSettings.EncryptionKeys)
+		if err != nil {
+			sr.src.Close()
+			return nil, fmt.Errorf("couldn't create encryption reader: %w", err)
+		}
+	}
 	if (header.options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION {
 		if sr.er != nil {
 			sr.cr = NewCompressionReader(sr.er)
@@ -272,6 +295,17 @@ func (segment *queueSegment) getReader(queueSettings Settings) (*segmentReader,
 			sr.cr = NewCompressionReader(sr.src)
 		}
 	}
+	if (header.options & ENABLE_ZSTD_COMPRESSION) == ENABLE_ZSTD_COMPRESSION {
+		var src io.ReadCloser = sr.src
+		if sr.er != nil {
+			src = sr.er
+		}
+		sr.cr, err = NewZstdCompressionReader(src)
+		if err != nil {
+			sr.src.Close()
+			return nil, fmt.Errorf("couldn't create compression reader: %w", err)
+		}
+	}
 	return sr, nil
 }
 
@@ -289,11 +323,17 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 		return nil, err
 	}
 
-	if len(queueSettings.EncryptionKey) > 0 {
+	// New segments are encrypted with the current key, the previous keys
+	// are only used to read the segments written before a key rotation.
+	if len(queueSettings.EncryptionKeys) > 0 {
+		options = options | ENABLE_GCM_ENCRYPTION
+	} else if len(queueSettings.EncryptionKey) > 0 {
 		options = options | ENABLE_ENCRYPTION
 	}
 
-	if queueSettings.UseCompression {
+	if queueSettings.ZstdCompressionLevel > 0 {
+		options = options | ENABLE_ZSTD_COMPRESSION
+	} else if queueSettings.UseCompression {
 		options = options | ENABLE_COMPRESSION
 	}
 
@@ -315,6 +355,13 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 			return nil, fmt.Errorf("couldn't create encryption writer: %w", err)
 		}
 	}
+	if (options & ENABLE_GCM_ENCRYPTION) == ENABLE_GCM_ENCRYPTION {
+		sw.ew, err = NewGCMEncryptionWriter(sw.dst, queueSettings.EncryptionKeys[0])
+		if err != nil {
+			sw.dst.Close()
+			return nil, fmt.Errorf("couldn't create encryption writer: %w", err)
+		}
+	}
 
 	if (options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION {
 		if sw.ew != nil {
@@ -324,6 +371,18 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 		}
 	}
 
+	if (options & ENABLE_ZSTD_COMPRESSION) == ENABLE_ZSTD_COMPRESSION {
+		var dst WriteCloseSyncer = sw.dst
+		if sw.ew != nil {
+			dst = sw.ew
+		}
+		sw.cw, err = NewZstdCompressionWriter(dst, queueSettings.ZstdCompressionLevel)
+		if err != nil {
+			sw.dst.Close()
+			return nil, fmt.Errorf("couldn't create compression writer: %w", err)
+		}
+	}
+
 	return sw, nil
 }
 
@@ -480,8 +539,8 @@ func (segments *diskQueueSegments) sizeOnDisk() uint64 {
 // less compressable.
 type segmentReader struct {
 	src                 io.ReadSeekCloser
-	er                  *EncryptionReader
-	cr                  *CompressionReader
+	er                  encryptionReader
+	cr                  compressionReader
 	serializationFormat SerializationFormat
 }
 
@@ -551,8 +610,8 @@ func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
 // data less compressable.
 type segmentWriter struct {
 	dst *os.File
-	ew  *EncryptionWriter
-	cw  *CompressionWriter
+	ew  WriteCloseSyncer
+	cw  WriteCloseSyncer
 }
 
 func (w *segmentWriter) Write(p []byte) (int, error) {
@@ -28,7 +28,9 @@ func TestSegmentsRoundTrip(t *testing.T) {
 	tests := map[string]struct {
 		id        segmentID
 		encrypt   bool
+		gcm       bool
 		compress  bool
+		zstd      bool
 		plaintext []byte
 	}{
 		"No Encryption or Compression": {
@@ -55,6 +57,29 @@ func TestSegmentsRoundTrip(t *testing.T) {
 			compress:  true,
 			plaintext: []byte("encryption and compression"),
 		},
+		"GCM Encryption Only": {
+			id:        4,
+			gcm:       true,
+			compress:  false,
+			plaintext: []byte("gcm encryption only"),
+		},
+		"GCM Encryption and Compression": {
+			id:        5,
+			gcm:       true,
+			compress:  true,
+			plaintext: []byte("gcm encryption and compression"),
+		},
+		"Zstd Compression Only": {
+			id:        6,
+			zstd:      true,
+			plaintext: []byte("zstd compression only"),
+		},
+		"GCM Encryption and Zstd Compression": {
+			id:        7,
+			gcm:       true,
+			zstd:      true,
+			plaintext: []byte("gcm encryption and zstd compression"),
+		},
 	}
 	dir := t.TempDir()
 	for name, tc := range tests {
@@ -64,7 +89,13 @@ func TestSegmentsRoundTrip(t *testing.T) {
 		if tc.encrypt {
 			settings.EncryptionKey = []byte("keykeykeykeykeyk")
 		}
+		if tc.gcm {
+			settings.EncryptionKeys = [][]byte{[]byte("keykeykeykeykeykkeykeykeykeykeyk")}
+		}
 		settings.UseCompression = tc.compress
+		if tc.zstd {
+			settings.ZstdCompressionLevel = defaultZstdCompressionLevel
+		}
 		qs := &queueSegment{
 			id: tc.id,
 		}
@@ -102,7 +133,9 @@ func TestSegmentReaderSeek(t *testing.T) {
 	tests := map[string]struct {
 		id         segmentID
 		encrypt    bool
+		gcm        bool
 		compress   bool
+		zstd       bool
 		plaintexts [][]byte
 	}{
 		"No Encryption or compression": {
@@ -129,6 +162,29 @@ func TestSegmentReaderSeek(t *testing.T) {
 			compress:   true,
 			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
 		},
+		"GCM Encryption Only": {
+			id:         4,
+			gcm:        true,
+			compress:   false,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+		},
+		"GCM Encryption and Compression": {
+			id:         5,
+			gcm:        true,
+			compress:   true,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+		},
+		"Zstd Compression Only": {
+			id:         6,
+			zstd:       true,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+		},
+		"GCM Encryption and Zstd Compression": {
+			id:         7,
+			gcm:        true,
+			zstd:       true,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+		},
 	}
 	dir := t.TempDir()
 	for name, tc := range tests {
@@ -137,7 +193,13 @@ func TestSegmentReaderSeek(t *testing.T) {
 		if tc.encrypt {
 			settings.EncryptionKey = []byte("keykeykeykeykeyk")
 		}
+		if tc.gcm {
+			settings.EncryptionKeys = [][]byte{[]byte("keykeykeykeykeykkeykeykeykeykeyk")}
+		}
 		settings.UseCompression = tc.compress
+		if tc.zstd {
+			settings.ZstdCompressionLevel = defaultZstdCompressionLevel
+		}
 
 		qs := &queueSegment{
 			id: tc.id,
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// defaultZstdCompressionLevel is the level used when compression is
+	// enabled without level, the default level of zstd.
+	defaultZstdCompressionLevel = 3
+)
+
+// ZstdCompressionReader allows reading a stream compressed with zstd
+type ZstdCompressionReader struct {
+	src     io.ReadCloser
+	decoder *zstd.Decoder
+}
+
+// NewZstdCompressionReader returns a new zstd frame decoder
+func NewZstdCompressionReader(r io.ReadCloser) (*ZstdCompressionReader, error) {
+	// A single goroutine decodes the stream, as the segments are read one
+	// frame at a time.
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &ZstdCompressionReader{
+		src:     r,
+		decoder: decoder,
+	}, nil
+}
+
+func (r *ZstdCompressionReader) Read(buf []byte) (int, error) {
+	return r.decoder.Read(buf)
+}
+
+func (r *ZstdCompressionReader) Close() error {
+	r.decoder.Close()
+	return r.src.Close()
+}
+
+// Reset Sets up decompression again, assumes that caller has already set
+// the src to the correct position
+func (r *ZstdCompressionReader) Reset() error {
+	return r.decoder.Reset(r.src)
+}
+
+// ZstdCompressionWriter allows writing a zstd stream
+type ZstdCompressionWriter struct {
+	dst     WriteCloseSyncer
+	encoder *zstd.Encoder
+}
+
+// NewZstdCompressionWriter returns a new zstd frame encoder compressing
+// with the given zstd level, from 1 (fastest) to 22 (best compression).
+func NewZstdCompressionWriter(w WriteCloseSyncer, level int) (*ZstdCompressionWriter, error) {
+	encoder, err := zstd.NewWriter(w,
+		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)),
+		zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &ZstdCompressionWriter{
+		dst:     w,
+		encoder: encoder,
+	}, nil
+}
+
+func (w *ZstdCompressionWriter) Write(p []byte) (int, error) {
+	return w.encoder.Write(p)
+}
+
+func (w *ZstdCompressionWriter) Close() error {
+	err := w.encoder.Close()
+	if err != nil {
+		return err
+	}
+	return w.dst.Close()
+}
+
+// Sync flushes the pending data as a complete block, so that it can be
+// read back before the segment is closed.
+func (w *ZstdCompressionWriter) Sync() error {
+	if err := w.encoder.Flush(); err != nil {
+		return err
+	}
+	return w.dst.Sync()
+}
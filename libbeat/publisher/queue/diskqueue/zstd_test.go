@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestZstdCompressionReadBeforeClose(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Path = t.TempDir()
+	settings.ZstdCompressionLevel = defaultZstdCompressionLevel
+
+	qs := &queueSegment{id: 0}
+	sw, err := qs.getWriter(settings)
+	require.NoError(t, err)
+	defer sw.Close()
+
+	// the synced data can be read while the segment is still written
+	_, err = sw.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.NoError(t, sw.Sync())
+
+	sr, err := qs.getReader(settings)
+	require.NoError(t, err)
+	defer sr.Close()
+
+	dst := make([]byte, 3)
+	_, err = io.ReadFull(sr, dst)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(dst))
+}
+
+func TestZstdCompressionRatio(t *testing.T) {
+	var plaintext bytes.Buffer
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&plaintext, `{"@timestamp":"2022-06-01T10:00:%02d.000Z","message":"GET /index.html HTTP/1.1 200 %d","host":{"name":"web-01"}}`, i%60, i)
+	}
+
+	for _, level := range []int{1, defaultZstdCompressionLevel, 19} {
+		t.Run(fmt.Sprintf("level %d", level), func(t *testing.T) {
+			settings := DefaultSettings()
+			settings.Path = t.TempDir()
+			settings.ZstdCompressionLevel = level
+
+			qs := &queueSegment{id: 0}
+			sw, err := qs.getWriter(settings)
+			require.NoError(t, err)
+			_, err = sw.Write(plaintext.Bytes())
+			require.NoError(t, err)
+			require.NoError(t, sw.Close())
+
+			info, err := os.Stat(settings.segmentPath(qs.id))
+			require.NoError(t, err)
+			assert.Less(t, info.Size(), int64(plaintext.Len()/4))
+
+			sr, err := qs.getReader(settings)
+			require.NoError(t, err)
+			defer sr.Close()
+			dst, err := io.ReadAll(sr)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext.Bytes(), dst)
+		})
+	}
+}
+
+func TestCompressionConfig(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		level  int
+		err    bool
+	}{
+		"disabled": {
+			config: map[string]interface{}{},
+		},
+		"enabled": {
+			config: map[string]interface{}{"compression.enabled": true},
+			level:  defaultZstdCompressionLevel,
+		},
+		"level": {
+			config: map[string]interface{}{"compression.enabled": true, "compression.level": 9},
+			level:  9,
+		},
+		"invalid level": {
+			config: map[string]interface{}{"compression.enabled": true, "compression.level": 23},
+			err:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.config["max_size"] = "1GB"
+			settings, err := SettingsForUserConfig(config.MustNewConfigFrom(tc.config))
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.level, settings.ZstdCompressionLevel)
+		})
+	}
+}
@@ -141,13 +141,17 @@ func create(
 		logger = logp.L()
 	}
 
-	return NewQueue(logger, Settings{
+	settings := Settings{
 		ACKListener:    ackListener,
 		Events:         config.Events,
 		FlushMinEvents: config.FlushMinEvents,
 		FlushTimeout:   config.FlushTimeout,
 		InputQueueSize: inQueueSize,
-	}), nil
+	}
+	if config.PriorityLanes > 1 {
+		return newPriorityQueue(logger, settings, config.PriorityLanes), nil
+	}
+	return NewQueue(logger, settings), nil
 }
 
 // NewQueue creates a new broker based in-memory queue holding up to sz number of events.
@@ -26,12 +26,14 @@ type config struct {
 	Events         int           `config:"events" validate:"min=32"`
 	FlushMinEvents int           `config:"flush.min_events" validate:"min=0"`
 	FlushTimeout   time.Duration `config:"flush.timeout"`
+	PriorityLanes  int           `config:"priority.lanes" validate:"min=1,max=8"`
 }
 
 var defaultConfig = config{
 	Events:         4 * 1024,
 	FlushMinEvents: 2 * 1024,
 	FlushTimeout:   1 * time.Second,
+	PriorityLanes:  1,
 }
 
 func (c *config) Validate() error {
@@ -0,0 +1,318 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package memqueue
+
+import (
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/opt"
+)
+
+// priorityMetaKey is the key in the events metadata selecting the priority
+// lane an event is stored in.
+const priorityMetaKey = "priority"
+
+// priorityQueue is a memory queue made of several lanes, each being an
+// independent broker. Consumers are served from the highest priority lane
+// that has events available, so high priority events are not delayed by
+// events waiting in lower priority lanes.
+// Entry IDs are assigned per lane and are not unique across lanes.
+type priorityQueue struct {
+	done chan struct{}
+
+	// lanes ordered from lowest (0) to highest priority.
+	lanes []*broker
+}
+
+// priorityProducer forwards events to the producer of the lane selected by
+// the event priority. Lane producers are created on first use.
+type priorityProducer struct {
+	queue  *priorityQueue
+	config queue.ProducerConfig
+
+	producers []queue.Producer
+	acks      *laneACKs
+}
+
+// laneACKs combines the ACKs of the lane producers, such that the producer
+// ACK callback is called in publishing order, even if lanes are
+// acknowledged out of order.
+type laneACKs struct {
+	mu   sync.Mutex
+	cb   func(count int)
+	runs []laneRun
+}
+
+// laneRun is a sequence of events published in a row to the same lane.
+type laneRun struct {
+	lane  int
+	count int // number of events in the run not reported to cb yet
+	acked int // number of events in the run ACKed by the lane
+}
+
+func newPriorityQueue(logger *logp.Logger, settings Settings, lanes int) *priorityQueue {
+	if logger == nil {
+		logger = logp.NewLogger("memqueue")
+	}
+
+	q := &priorityQueue{
+		done:  make(chan struct{}),
+		lanes: make([]*broker, lanes),
+	}
+	for i := range q.lanes {
+		q.lanes[i] = NewQueue(logger.With("lane", i), settings)
+	}
+	return q
+}
+
+func (q *priorityQueue) Close() error {
+	close(q.done)
+	for _, b := range q.lanes {
+		b.Close()
+	}
+	return nil
+}
+
+func (q *priorityQueue) BufferConfig() queue.BufferConfig {
+	return queue.BufferConfig{
+		MaxEvents: q.lanes[0].bufSize * len(q.lanes),
+	}
+}
+
+func (q *priorityQueue) Producer(cfg queue.ProducerConfig) queue.Producer {
+	p := &priorityProducer{
+		queue:     q,
+		config:    cfg,
+		producers: make([]queue.Producer, len(q.lanes)),
+	}
+	if cfg.ACK != nil {
+		p.acks = &laneACKs{cb: cfg.ACK}
+	}
+	return p
+}
+
+func (q *priorityQueue) Get(count int) (queue.Batch, error) {
+	responseChan := make(chan getResponse, 1)
+	req := getRequest{entryCount: count, responseChan: responseChan}
+
+	// Lanes only accept get requests if they have events available, so we
+	// first offer the request to every lane, from the highest priority one,
+	// and wait for any lane to accept it otherwise.
+	lane := -1
+	for i := len(q.lanes) - 1; i >= 0 && lane < 0; i-- {
+		select {
+		case <-q.done:
+			return nil, io.EOF
+		case q.lanes[i].getChan <- req:
+			lane = i
+		default:
+		}
+	}
+	if lane < 0 {
+		// The last case is the done channel.
+		cases := make([]reflect.SelectCase, len(q.lanes)+1)
+		for i, b := range q.lanes {
+			cases[i] = reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(b.getChan),
+				Send: reflect.ValueOf(req),
+			}
+		}
+		cases[len(q.lanes)] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(q.done),
+		}
+		chosen, _, _ := reflect.Select(cases)
+		if chosen == len(q.lanes) {
+			return nil, io.EOF
+		}
+		lane = chosen
+	}
+
+	// if request has been sent, we have to wait for a response
+	resp := <-responseChan
+	return &batch{
+		queue:    q.lanes[lane],
+		entries:  resp.entries,
+		doneChan: resp.ackChan,
+	}, nil
+}
+
+func (q *priorityQueue) Metrics() (queue.Metrics, error) {
+	var count, limit, consumed uint64
+	for _, b := range q.lanes {
+		m, err := b.Metrics()
+		if err != nil {
+			return queue.Metrics{}, err
+		}
+		count += m.EventCount.ValueOr(0)
+		limit += m.EventLimit.ValueOr(0)
+		consumed += m.UnackedConsumedEvents.ValueOr(0)
+	}
+
+	return queue.Metrics{
+		EventCount:            opt.UintWith(count),
+		EventLimit:            opt.UintWith(limit),
+		UnackedConsumedEvents: opt.UintWith(consumed),
+	}, nil
+}
+
+// eventPriority returns the lane for an event, based on the priority set in
+// the events metadata. Events without priority go to the lowest priority
+// lane, and priorities above the number of lanes go to the highest one.
+func eventPriority(event interface{}, lanes int) int {
+	e, ok := event.(publisher.Event)
+	if !ok || e.Content.Meta == nil {
+		return 0
+	}
+
+	var priority int
+	switch v := e.Content.Meta[priorityMetaKey].(type) {
+	case int:
+		priority = v
+	case int64:
+		priority = int(v)
+	case uint64:
+		priority = int(v)
+	case float64:
+		priority = int(v)
+	default:
+		return 0
+	}
+
+	if priority < 0 {
+		return 0
+	}
+	if priority >= lanes {
+		return lanes - 1
+	}
+	return priority
+}
+
+func (p *priorityProducer) Publish(event interface{}) (queue.EntryID, bool) {
+	lane := eventPriority(event, len(p.producers))
+	p.acks.add(lane)
+	id, published := p.producer(lane).Publish(event)
+	if !published {
+		p.acks.remove()
+	}
+	return id, published
+}
+
+func (p *priorityProducer) TryPublish(event interface{}) (queue.EntryID, bool) {
+	lane := eventPriority(event, len(p.producers))
+	p.acks.add(lane)
+	id, published := p.producer(lane).TryPublish(event)
+	if !published {
+		p.acks.remove()
+	}
+	return id, published
+}
+
+func (p *priorityProducer) Cancel() int {
+	removed := 0
+	for _, producer := range p.producers {
+		if producer != nil {
+			removed += producer.Cancel()
+		}
+	}
+	return removed
+}
+
+func (p *priorityProducer) producer(lane int) queue.Producer {
+	if p.producers[lane] == nil {
+		cfg := p.config
+		if p.acks != nil {
+			cfg.ACK = func(count int) { p.acks.ack(lane, count) }
+		}
+		p.producers[lane] = p.queue.lanes[lane].Producer(cfg)
+	}
+	return p.producers[lane]
+}
+
+// add records an event being published to lane.
+func (a *laneACKs) add(lane int) {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if n := len(a.runs); n > 0 && a.runs[n-1].lane == lane {
+		a.runs[n-1].count++
+		return
+	}
+	a.runs = append(a.runs, laneRun{lane: lane, count: 1})
+}
+
+// remove drops the last event recorded by add, if it failed to be published.
+func (a *laneACKs) remove() {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := len(a.runs)
+	if a.runs[n-1].count--; a.runs[n-1].count == 0 {
+		a.runs = a.runs[:n-1]
+	}
+}
+
+// ack marks the count oldest unacknowledged events of lane as ACKed, and
+// reports the events published before the oldest pending event.
+func (a *laneACKs) ack(lane, count int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.runs {
+		if count == 0 {
+			break
+		}
+		run := &a.runs[i]
+		if run.lane != lane || run.acked == run.count {
+			continue
+		}
+		n := run.count - run.acked
+		if n > count {
+			n = count
+		}
+		run.acked += n
+		count -= n
+	}
+
+	reported := 0
+	for len(a.runs) > 0 {
+		run := &a.runs[0]
+		reported += run.acked
+		run.count -= run.acked
+		run.acked = 0
+		if run.count > 0 {
+			break
+		}
+		a.runs = a.runs[1:]
+	}
+	if reported > 0 {
+		a.cb(reported)
+	}
+}
@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package memqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/queuetest"
+	c "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestPriorityProduceConsumer(t *testing.T) {
+	factory := func(_ *testing.T) queue.Queue {
+		return newPriorityQueue(nil, Settings{Events: 64}, 3)
+	}
+
+	t.Run("single", func(t *testing.T) {
+		queuetest.TestSingleProducerConsumer(t, 200, 16, factory)
+	})
+	t.Run("multi", func(t *testing.T) {
+		queuetest.TestMultiProducerConsumer(t, 200, 16, factory)
+	})
+}
+
+func TestPriorityQueueCreate(t *testing.T) {
+	cfg := c.MustNewConfigFrom(mapstr.M{"priority.lanes": 2})
+	q, err := create(nil, nil, cfg, 0)
+	require.NoError(t, err)
+	defer q.Close()
+
+	require.IsType(t, &priorityQueue{}, q)
+	assert.Equal(t, 2*defaultConfig.Events, q.BufferConfig().MaxEvents)
+}
+
+func TestPriorityQueueDrainsHighPriorityFirst(t *testing.T) {
+	q := newPriorityQueue(nil, Settings{Events: 32}, 3)
+	defer q.Close()
+
+	acked := make(chan int, 10)
+	p := q.Producer(queue.ProducerConfig{ACK: func(count int) { acked <- count }})
+
+	publish := func(priority interface{}, count int) {
+		for i := 0; i < count; i++ {
+			event := publisher.Event{Content: beat.Event{Meta: mapstr.M{}}}
+			if priority != nil {
+				event.Content.Meta[priorityMetaKey] = priority
+			}
+			_, ok := p.Publish(event)
+			require.True(t, ok)
+		}
+	}
+	publish(nil, 5)
+	publish(2, 3)
+	publish(1, 2)
+
+	// Wait for the lanes to process all publish requests.
+	require.Eventually(t, func() bool {
+		m, err := q.Metrics()
+		return err == nil && m.EventCount.ValueOr(0) == 10
+	}, time.Second, 10*time.Millisecond)
+
+	var batches []queue.Batch
+	for _, expected := range []int{3, 2, 5} {
+		b, err := q.Get(100)
+		require.NoError(t, err)
+		require.Equal(t, expected, b.Count())
+		batches = append(batches, b)
+	}
+
+	// The events of the first lane were published first, so nothing is
+	// ACKed before their batch is done.
+	batches[0].Done()
+	batches[1].Done()
+	select {
+	case n := <-acked:
+		t.Fatalf("unexpected ACK of %v events", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	batches[2].Done()
+	total := 0
+	for total < 10 {
+		select {
+		case n := <-acked:
+			total += n
+		case <-time.After(time.Second):
+			t.Fatalf("only %v events ACKed", total)
+		}
+	}
+}
+
+func TestLaneACKs(t *testing.T) {
+	var reported []int
+	a := &laneACKs{cb: func(count int) { reported = append(reported, count) }}
+
+	for _, lane := range []int{0, 0, 1, 1, 0, 2} {
+		a.add(lane)
+	}
+	a.remove()
+
+	a.ack(1, 2)
+	assert.Empty(t, reported)
+
+	a.ack(0, 1)
+	assert.Equal(t, []int{1}, reported)
+
+	a.ack(0, 2)
+	assert.Equal(t, []int{1, 4}, reported)
+	assert.Empty(t, a.runs)
+}
+
+func TestEventPriority(t *testing.T) {
+	tests := map[string]struct {
+		event    interface{}
+		expected int
+	}{
+		"no metadata":     {event: publisher.Event{}, expected: 0},
+		"not an event":    {event: "event", expected: 0},
+		"int":             {event: withPriority(1), expected: 1},
+		"int64":           {event: withPriority(int64(2)), expected: 2},
+		"uint64":          {event: withPriority(uint64(1)), expected: 1},
+		"float64":         {event: withPriority(float64(2)), expected: 2},
+		"above max":       {event: withPriority(10), expected: 2},
+		"negative":        {event: withPriority(-1), expected: 0},
+		"invalid type":    {event: withPriority("high"), expected: 0},
+		"without setting": {event: publisher.Event{Content: beat.Event{Meta: mapstr.M{}}}, expected: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, eventPriority(test.event, 3))
+		})
+	}
+}
+
+func withPriority(priority interface{}) publisher.Event {
+	return publisher.Event{Content: beat.Event{Meta: mapstr.M{priorityMetaKey: priority}}}
+}
@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// cachedKeystore is a keystore retrieving the secrets from a backend. The
+// secrets are cached up to the configured TTL, or the TTL reported by the
+// backend if it is shorter. Expired secrets are fetched again the next time
+// they are retrieved, and are still used if the backend cannot be reached.
+//
+// The keystore is also used to resolve the variables of the configuration
+// that come from the environment, so keys not found are cached too, and keys
+// that can't be fetched because of an error are reported as not found unless
+// they were found before. This way not every variable causes a request to the
+// backend, and a backend that can't be reached doesn't prevent loading the
+// configuration.
+type cachedKeystore struct {
+	backend Backend
+	timeout time.Duration
+	ttl     time.Duration
+	log     *logp.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   []byte
+	missing bool // The key doesn't exist in the backend.
+	expires time.Time
+}
+
+func newCachedKeystore(name string, backend Backend, timeout, ttl time.Duration) *cachedKeystore {
+	return &cachedKeystore{
+		backend: backend,
+		timeout: timeout,
+		ttl:     ttl,
+		log:     logp.NewLogger("keystore").With("backend", name),
+		now:     time.Now,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+func (k *cachedKeystore) Retrieve(key string) (*keystore.SecureString, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	entry, cached := k.entries[key]
+	if cached && now.Before(entry.expires) {
+		if entry.missing {
+			return nil, keystore.ErrKeyDoesntExists
+		}
+		return newSecureString(entry.value), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+	value, ttl, err := k.backend.Fetch(ctx, key)
+	switch {
+	case errors.Is(err, keystore.ErrKeyDoesntExists):
+		delete(k.entries, key)
+		if k.ttl > 0 {
+			k.entries[key] = cacheEntry{missing: true, expires: now.Add(k.ttl)}
+		}
+		return nil, keystore.ErrKeyDoesntExists
+	case err != nil && cached && !entry.missing:
+		k.log.Warnf("Failed to renew secret '%s', using the cached value: %v", key, err)
+		return newSecureString(entry.value), nil
+	case err != nil:
+		k.log.Warnf("Failed to fetch secret '%s', it is considered not found: %v", key, err)
+		return nil, keystore.ErrKeyDoesntExists
+	}
+
+	if k.ttl > 0 {
+		if ttl <= 0 || ttl > k.ttl {
+			ttl = k.ttl
+		}
+		k.entries[key] = cacheEntry{value: value, expires: now.Add(ttl)}
+	}
+	return newSecureString(value), nil
+}
+
+// GetConfig returns an empty configuration, the secrets of the backends
+// are only retrieved on demand.
+func (k *cachedKeystore) GetConfig() (*conf.C, error) {
+	return conf.NewConfig(), nil
+}
+
+func (k *cachedKeystore) IsPersisted() bool {
+	return true
+}
+
+// newSecureString returns a SecureString with a copy of value, so the
+// cached value is not modified if the returned one is cleared.
+func newSecureString(value []byte) *keystore.SecureString {
+	return keystore.NewSecureString(append([]byte(nil), value...))
+}
@@ -0,0 +1,208 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package secrets resolves the secrets referenced in the configuration from
+// external secret backends, in addition to the file keystore.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+// Backend retrieves secrets from an external secret store.
+type Backend interface {
+	// Fetch returns the value of a secret, and for how long it can be
+	// cached, zero if the backend doesn't limit it. It returns
+	// keystore.ErrKeyDoesntExists if the secret doesn't exist.
+	Fetch(ctx context.Context, key string) (value []byte, ttl time.Duration, err error)
+}
+
+// BackendFactory creates a Backend from its settings.
+type BackendFactory func(cfg *conf.C) (Backend, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendFactory{}
+)
+
+// RegisterBackend registers a named secret backend. It panics if a backend
+// with the same name has already been registered.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if _, exists := backends[name]; exists {
+		panic(fmt.Sprintf("secret backend '%s' is already registered", name))
+	}
+	backends[name] = factory
+}
+
+func lookupBackend(name string) (BackendFactory, bool) {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	factory, ok := backends[name]
+	return factory, ok
+}
+
+type config struct {
+	Backends []*conf.C `config:"backends"`
+}
+
+type backendConfig struct {
+	Type    string        `config:"type" validate:"required"`
+	Timeout time.Duration `config:"timeout" validate:"positive"`
+	Cache   struct {
+		TTL time.Duration `config:"ttl" validate:"min=0"`
+	} `config:"cache"`
+}
+
+func defaultBackendConfig() backendConfig {
+	c := backendConfig{Timeout: 30 * time.Second}
+	c.Cache.TTL = 5 * time.Minute
+	return c
+}
+
+// NewKeystore returns a keystore that looks up the secrets in store, and
+// then in the backends configured in the `backends` setting of cfg, in
+// order. It returns store if there are no backends configured.
+func NewKeystore(cfg *conf.C, store keystore.Keystore) (keystore.Keystore, error) {
+	var c config
+	if cfg != nil {
+		if err := cfg.Unpack(&c); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.Backends) == 0 {
+		return store, nil
+	}
+
+	chain := &chainKeystore{primary: store}
+	for i, backendCfg := range c.Backends {
+		bc := defaultBackendConfig()
+		if err := backendCfg.Unpack(&bc); err != nil {
+			return nil, fmt.Errorf("invalid keystore backend %d: %w", i, err)
+		}
+
+		cfgwarn.Beta("The %s keystore backend is beta.", bc.Type)
+		factory, ok := lookupBackend(bc.Type)
+		if !ok {
+			return nil, fmt.Errorf("unknown keystore backend type '%s'", bc.Type)
+		}
+		backend, err := factory(backendCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s keystore backend: %w", bc.Type, err)
+		}
+		chain.backends = append(chain.backends, newCachedKeystore(bc.Type, backend, bc.Timeout, bc.Cache.TTL))
+	}
+	return chain, nil
+}
+
+// chainKeystore retrieves the secrets from the first keystore containing
+// them. Changes and listings are delegated to the primary keystore.
+type chainKeystore struct {
+	primary  keystore.Keystore
+	backends []keystore.Keystore
+}
+
+func (k *chainKeystore) Retrieve(key string) (*keystore.SecureString, error) {
+	for _, store := range k.stores() {
+		secret, err := store.Retrieve(key)
+		if errors.Is(err, keystore.ErrKeyDoesntExists) {
+			continue
+		}
+		return secret, err
+	}
+	return nil, keystore.ErrKeyDoesntExists
+}
+
+// GetConfig returns the configuration of the primary keystore, secrets from
+// backends are only retrieved on demand.
+func (k *chainKeystore) GetConfig() (*conf.C, error) {
+	if k.primary == nil {
+		return conf.NewConfig(), nil
+	}
+	return k.primary.GetConfig()
+}
+
+func (k *chainKeystore) IsPersisted() bool {
+	return k.primary == nil || k.primary.IsPersisted()
+}
+
+func (k *chainKeystore) Store(key string, secret []byte) error {
+	w, err := k.writable()
+	if err != nil {
+		return err
+	}
+	return w.Store(key, secret)
+}
+
+func (k *chainKeystore) Delete(key string) error {
+	w, err := k.writable()
+	if err != nil {
+		return err
+	}
+	return w.Delete(key)
+}
+
+func (k *chainKeystore) Create(override bool) error {
+	w, err := k.writable()
+	if err != nil {
+		return err
+	}
+	return w.Create(override)
+}
+
+func (k *chainKeystore) Save() error {
+	w, err := k.writable()
+	if err != nil {
+		return err
+	}
+	return w.Save()
+}
+
+func (k *chainKeystore) List() ([]string, error) {
+	if k.primary == nil {
+		return nil, keystore.ErrNotListing
+	}
+	l, err := keystore.AsListingKeystore(k.primary)
+	if err != nil {
+		return nil, err
+	}
+	return l.List()
+}
+
+func (k *chainKeystore) stores() []keystore.Keystore {
+	if k.primary == nil {
+		return k.backends
+	}
+	return append([]keystore.Keystore{k.primary}, k.backends...)
+}
+
+func (k *chainKeystore) writable() (keystore.WritableKeystore, error) {
+	if k.primary == nil {
+		return nil, keystore.ErrNotWritable
+	}
+	return keystore.AsWritableKeystore(k.primary)
+}
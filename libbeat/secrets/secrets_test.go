@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+type mapBackend struct {
+	secrets map[string]string
+	ttl     time.Duration
+	err     error
+	fetches int
+}
+
+func (b *mapBackend) Fetch(_ context.Context, key string) ([]byte, time.Duration, error) {
+	b.fetches++
+	if b.err != nil {
+		return nil, 0, b.err
+	}
+	value, found := b.secrets[key]
+	if !found {
+		return nil, 0, keystore.ErrKeyDoesntExists
+	}
+	return []byte(value), b.ttl, nil
+}
+
+type mapKeystore map[string]string
+
+func (k mapKeystore) Retrieve(key string) (*keystore.SecureString, error) {
+	value, found := k[key]
+	if !found {
+		return nil, keystore.ErrKeyDoesntExists
+	}
+	return keystore.NewSecureString([]byte(value)), nil
+}
+
+func (k mapKeystore) GetConfig() (*conf.C, error) { return conf.NewConfigFrom(map[string]string(k)) }
+func (k mapKeystore) IsPersisted() bool           { return true }
+
+func retrieve(t *testing.T, store keystore.Keystore, key string) string {
+	t.Helper()
+	secret, err := store.Retrieve(key)
+	require.NoError(t, err)
+	value, err := secret.Get()
+	require.NoError(t, err)
+	return string(value)
+}
+
+func TestNewKeystore(t *testing.T) {
+	backend := &mapBackend{secrets: map[string]string{"a": "backend", "b": "backend"}}
+	RegisterBackend("test_map", func(*conf.C) (Backend, error) { return backend, nil })
+
+	primary := mapKeystore{"a": "primary"}
+
+	t.Run("without backends", func(t *testing.T) {
+		store, err := NewKeystore(conf.MustNewConfigFrom(map[string]interface{}{"path": "x"}), primary)
+		require.NoError(t, err)
+		assert.Equal(t, primary, store)
+	})
+
+	t.Run("with backends", func(t *testing.T) {
+		store, err := NewKeystore(conf.MustNewConfigFrom(map[string]interface{}{
+			"backends": []map[string]interface{}{{"type": "test_map"}},
+		}), primary)
+		require.NoError(t, err)
+
+		assert.Equal(t, "primary", retrieve(t, store, "a"))
+		assert.Equal(t, "backend", retrieve(t, store, "b"))
+		_, err = store.Retrieve("c")
+		assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+
+		// The primary keystore is not writable.
+		w, err := keystore.AsWritableKeystore(store)
+		require.NoError(t, err)
+		assert.ErrorIs(t, w.Save(), keystore.ErrNotWritable)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := NewKeystore(conf.MustNewConfigFrom(map[string]interface{}{
+			"backends": []map[string]interface{}{{"type": "unknown"}},
+		}), primary)
+		assert.Error(t, err)
+	})
+}
+
+func TestCachedKeystore(t *testing.T) {
+	now := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("cache ttl", func(t *testing.T) {
+		backend := &mapBackend{secrets: map[string]string{"a": "1"}}
+		store := newCachedKeystore("test", backend, time.Second, time.Minute)
+		store.now = clock
+
+		assert.Equal(t, "1", retrieve(t, store, "a"))
+		assert.Equal(t, "1", retrieve(t, store, "a"))
+		assert.Equal(t, 1, backend.fetches)
+
+		backend.secrets["a"] = "2"
+		now = now.Add(time.Minute)
+		assert.Equal(t, "2", retrieve(t, store, "a"))
+		assert.Equal(t, 2, backend.fetches)
+	})
+
+	t.Run("backend ttl", func(t *testing.T) {
+		backend := &mapBackend{secrets: map[string]string{"a": "1"}, ttl: time.Second}
+		store := newCachedKeystore("test", backend, time.Second, time.Minute)
+		store.now = clock
+
+		retrieve(t, store, "a")
+		now = now.Add(2 * time.Second)
+		retrieve(t, store, "a")
+		assert.Equal(t, 2, backend.fetches)
+	})
+
+	t.Run("disabled cache", func(t *testing.T) {
+		backend := &mapBackend{secrets: map[string]string{"a": "1"}}
+		store := newCachedKeystore("test", backend, time.Second, 0)
+
+		retrieve(t, store, "a")
+		retrieve(t, store, "a")
+		assert.Equal(t, 2, backend.fetches)
+	})
+
+	t.Run("stale value on errors", func(t *testing.T) {
+		backend := &mapBackend{secrets: map[string]string{"a": "1"}}
+		store := newCachedKeystore("test", backend, time.Second, time.Minute)
+		store.now = clock
+
+		retrieve(t, store, "a")
+		backend.err = errors.New("unavailable")
+		now = now.Add(time.Hour)
+		assert.Equal(t, "1", retrieve(t, store, "a"))
+
+		// Keys never found are reported as not found, so the configuration can
+		// still be loaded.
+		_, err := store.Retrieve("b")
+		assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+	})
+
+	t.Run("missing keys are cached", func(t *testing.T) {
+		backend := &mapBackend{secrets: map[string]string{}}
+		store := newCachedKeystore("test", backend, time.Second, time.Minute)
+		store.now = clock
+
+		for i := 0; i < 3; i++ {
+			_, err := store.Retrieve("HOSTNAME")
+			assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+		}
+		assert.Equal(t, 1, backend.fetches)
+
+		backend.secrets["HOSTNAME"] = "1"
+		now = now.Add(time.Minute)
+		assert.Equal(t, "1", retrieve(t, store, "HOSTNAME"))
+		assert.Equal(t, 2, backend.fetches)
+	})
+
+	t.Run("removed secret", func(t *testing.T) {
+		backend := &mapBackend{secrets: map[string]string{"a": "1"}}
+		store := newCachedKeystore("test", backend, time.Second, time.Minute)
+		store.now = clock
+
+		retrieve(t, store, "a")
+		delete(backend.secrets, "a")
+		now = now.Add(time.Hour)
+		_, err := store.Retrieve("a")
+		assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+	})
+}
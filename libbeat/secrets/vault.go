@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/vault"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+func init() {
+	RegisterBackend("vault", newVaultBackend)
+}
+
+type vaultConfig struct {
+	vault.Config `config:",inline"`
+	Path         string                           `config:"path" validate:"required"`
+	Transport    httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+func defaultVaultConfig() vaultConfig {
+	c := vaultConfig{
+		Transport: httpcommon.DefaultHTTPTransportSettings(),
+	}
+	c.Address = os.Getenv("VAULT_ADDR")
+	c.Token = os.Getenv("VAULT_TOKEN")
+	c.AppRole.Mount = "approle"
+	return c
+}
+
+func (c *vaultConfig) Validate() error {
+	if c.Address == "" {
+		return errors.New("address is required, it can also be set with the VAULT_ADDR environment variable")
+	}
+	if c.AppRole.RoleID == "" && c.Token == "" && c.TokenFile == "" {
+		return errors.New("one of token, token_file or auth.approle.role_id is required")
+	}
+	return nil
+}
+
+// vaultBackend reads the secrets from the fields of a secret in HashiCorp
+// Vault.
+type vaultBackend struct {
+	path   string
+	client *vault.Client
+}
+
+func newVaultBackend(cfg *conf.C) (Backend, error) {
+	c := defaultVaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	client, err := c.Transport.Client()
+	if err != nil {
+		return nil, err
+	}
+	return &vaultBackend{
+		path:   c.Path,
+		client: vault.NewClient(c.Config, client),
+	}, nil
+}
+
+func (b *vaultBackend) Fetch(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	secret, err := b.client.Read(ctx, b.path)
+	if errors.Is(err, vault.ErrSecretNotFound) {
+		return nil, 0, keystore.ErrKeyDoesntExists
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	field, found := secret.Data[key]
+	if !found || field == nil {
+		return nil, 0, keystore.ErrKeyDoesntExists
+	}
+	if s, ok := field.(string); ok {
+		return []byte(s), secret.LeaseDuration, nil
+	}
+	value, err := json.Marshal(field)
+	return value, secret.LeaseDuration, err
+}
@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+func TestVaultBackend(t *testing.T) {
+	var logins int
+	token := "s.initial"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "role", body["role_id"])
+			assert.Equal(t, "secret", body["secret_id"])
+			logins++
+			token = "s.login"
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"auth": map[string]interface{}{"client_token": token, "lease_duration": 3600},
+			})
+		case "/v1/secret/data/beats":
+			if r.Header.Get("X-Vault-Token") != token {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"permission denied"}})
+				return
+			}
+			assert.Equal(t, "ns1", r.Header.Get("X-Vault-Namespace"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     map[string]interface{}{"es_password": "changeme", "port": 9200},
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			})
+		case "/v1/kv/beats":
+			assert.Equal(t, token, r.Header.Get("X-Vault-Token"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"lease_duration": 60,
+				"data":           map[string]interface{}{"es_password": "v1"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{}})
+		}
+	}))
+	defer server.Close()
+
+	newBackend := func(t *testing.T, settings map[string]interface{}) Backend {
+		t.Helper()
+		settings["address"] = server.URL
+		b, err := newVaultBackend(conf.MustNewConfigFrom(settings))
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("kv version 2", func(t *testing.T) {
+		b := newBackend(t, map[string]interface{}{"token": token, "path": "secret/data/beats", "namespace": "ns1"})
+
+		value, _, err := b.Fetch(context.Background(), "es_password")
+		require.NoError(t, err)
+		assert.Equal(t, "changeme", string(value))
+
+		value, _, err = b.Fetch(context.Background(), "port")
+		require.NoError(t, err)
+		assert.Equal(t, "9200", string(value))
+
+		_, _, err = b.Fetch(context.Background(), "missing")
+		assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+	})
+
+	t.Run("kv version 1", func(t *testing.T) {
+		b := newBackend(t, map[string]interface{}{"token": token, "path": "/kv/beats"})
+
+		value, ttl, err := b.Fetch(context.Background(), "es_password")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", string(value))
+		assert.Equal(t, time.Minute, ttl)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		b := newBackend(t, map[string]interface{}{"token": token, "path": "kv/other"})
+
+		_, _, err := b.Fetch(context.Background(), "es_password")
+		assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+	})
+
+	t.Run("token file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte(token+"\n"), 0o600))
+		b := newBackend(t, map[string]interface{}{"token_file": tokenFile, "path": "kv/beats"})
+
+		value, _, err := b.Fetch(context.Background(), "es_password")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", string(value))
+	})
+
+	t.Run("approle login", func(t *testing.T) {
+		b := newBackend(t, map[string]interface{}{
+			"path":                   "secret/data/beats",
+			"namespace":              "ns1",
+			"auth.approle.role_id":   "role",
+			"auth.approle.secret_id": "secret",
+		})
+
+		_, _, err := b.Fetch(context.Background(), "es_password")
+		require.NoError(t, err)
+		_, _, err = b.Fetch(context.Background(), "es_password")
+		require.NoError(t, err)
+		assert.Equal(t, 1, logins)
+
+		// Revoked token.
+		token = "s.other"
+		value, _, err := b.Fetch(context.Background(), "es_password")
+		require.NoError(t, err)
+		assert.Equal(t, "changeme", string(value))
+		assert.Equal(t, 2, logins)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := newVaultBackend(conf.MustNewConfigFrom(map[string]interface{}{
+			"address": server.URL,
+			"path":    "secret/data/beats",
+		}))
+		assert.Error(t, err)
+	})
+}
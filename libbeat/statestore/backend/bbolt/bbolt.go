@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package bbolt implements a statestore backend storing the key value pairs
+// of each store in a bbolt database file. In contrast to memlog, the states
+// are not held in memory and updates don't require to rewrite the complete
+// store in periodic checkpoints, which keeps write latency and disk churn
+// low for stores with many keys.
+//
+// The database file of a store is named after the store, in the registry root
+// directory. If the database file does not exist yet, but a memlog store of
+// the same name exists, the memlog store is migrated when it is accessed for
+// the first time. The memlog store directory is renamed afterwards, and kept
+// as a backup.
+package bbolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// Registry configures access to bbolt based stores.
+type Registry struct {
+	log *logp.Logger
+
+	mu     sync.Mutex
+	active bool
+
+	settings Settings
+	metrics  *metrics
+}
+
+// Settings configures a new Registry.
+type Settings struct {
+	// Registry root directory. Each store is stored in a database file in
+	// the root directory.
+	Root string
+
+	// FileMode is used to configure the file mode for new files generated by the
+	// registry. File mode 0600 will be used if this field is not set.
+	FileMode os.FileMode
+
+	// Timeout to wait for the lock of a database file in use by another
+	// process. Defaults to 1s if not set.
+	Timeout time.Duration
+
+	// Compaction predicate that can trigger the compaction of a database file.
+	// If not configured, a database file of at least 10MB is compacted if
+	// more than half of it is free.
+	Compaction CompactionPredicate
+
+	// Metrics registry the compaction metrics are reported to. No metrics
+	// are reported if not set.
+	Metrics *monitoring.Registry
+}
+
+// CompactionPredicate is the type for configurable compaction checks. The
+// store is compacted when the predicate returns true. fileSize is the size
+// of the database file, and dataSize the number of bytes in use.
+type CompactionPredicate func(fileSize, dataSize uint64) bool
+
+const defaultFileMode os.FileMode = 0600
+
+const defaultTimeout = time.Second
+
+func defaultCompaction(fileSize, dataSize uint64) bool {
+	const limit = 10 * 1 << 20 // only compact files of at least 10MB by default
+	return fileSize >= limit && dataSize < fileSize/2
+}
+
+// dbFileExt is the extension of the database files.
+const dbFileExt = ".db"
+
+// New configures a bbolt Registry that can be used to open stores.
+func New(log *logp.Logger, settings Settings) (*Registry, error) {
+	if settings.FileMode == 0 {
+		settings.FileMode = defaultFileMode
+	}
+	if settings.Timeout == 0 {
+		settings.Timeout = defaultTimeout
+	}
+	if settings.Compaction == nil {
+		settings.Compaction = defaultCompaction
+	}
+
+	root, err := filepath.Abs(settings.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.Root = root
+	return &Registry{
+		log:      log,
+		active:   true,
+		settings: settings,
+		metrics:  newMetrics(settings.Metrics),
+	}, nil
+}
+
+// Access creates or opens a store. The root directory and the database file
+// are created if they do not exist. An existing memlog store with the same
+// name is migrated into the new database file.
+func (r *Registry) Access(name string) (backend.Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.active {
+		return nil, errRegClosed
+	}
+
+	logger := r.log.With("store", name)
+
+	if err := os.MkdirAll(r.settings.Root, os.ModeDir|0770); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(r.settings.Root, name+dbFileExt)
+	if err := migrateMemlog(logger, r.settings, name, path); err != nil {
+		return nil, fmt.Errorf("failed to migrate memlog store '%v': %w", name, err)
+	}
+
+	return openStore(logger, path, r.settings, r.metrics)
+}
+
+// Close closes the registry. No new store can be accessed after close.
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active = false
+	return nil
+}
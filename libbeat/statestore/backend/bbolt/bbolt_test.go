@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bbolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend/memlog"
+	"github.com/elastic/beats/v7/libbeat/statestore/internal/storecompliance"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func init() {
+	logp.DevelopmentSetup()
+}
+
+func TestCompliance_Default(t *testing.T) {
+	storecompliance.TestBackendCompliance(t, func(testPath string) (backend.Registry, error) {
+		return New(logp.NewLogger("test"), Settings{Root: testPath})
+	})
+}
+
+func TestCompliance_AlwaysCompact(t *testing.T) {
+	storecompliance.TestBackendCompliance(t, func(testPath string) (backend.Registry, error) {
+		return New(logp.NewLogger("test"), Settings{
+			Root: testPath,
+			Compaction: func(fileSize, dataSize uint64) bool {
+				return true
+			},
+		})
+	})
+}
+
+func TestMigrateMemlog(t *testing.T) {
+	root := t.TempDir()
+
+	memlogRegistry, err := memlog.New(logp.NewLogger("test"), memlog.Settings{Root: root})
+	require.NoError(t, err)
+	memlogStore, err := memlogRegistry.Access("test")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, memlogStore.Set(fmt.Sprintf("key%d", i), map[string]interface{}{"offset": i}))
+	}
+	require.NoError(t, memlogStore.Remove("key0"))
+	require.NoError(t, memlogStore.Close())
+	require.NoError(t, memlogRegistry.Close())
+
+	registry, err := New(logp.NewLogger("test"), Settings{Root: root})
+	require.NoError(t, err)
+	defer registry.Close()
+
+	store, err := registry.Access("test")
+	require.NoError(t, err)
+	defer store.Close()
+
+	assert.FileExists(t, filepath.Join(root, "test.db"))
+	assert.NoDirExists(t, filepath.Join(root, "test"))
+	assert.DirExists(t, filepath.Join(root, "test"+migratedSuffix))
+
+	count := 0
+	require.NoError(t, store.Each(func(string, backend.ValueDecoder) (bool, error) {
+		count++
+		return true, nil
+	}))
+	assert.Equal(t, 99, count)
+
+	has, err := store.Has("key0")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	var value struct {
+		Offset int `struct:"offset"`
+	}
+	require.NoError(t, store.Get("key42", &value))
+	assert.Equal(t, 42, value.Offset)
+}
+
+func TestCompaction(t *testing.T) {
+	root := t.TempDir()
+	metricsRegistry := monitoring.NewRegistry()
+
+	compact := false
+	registry, err := New(logp.NewLogger("test"), Settings{
+		Root:    root,
+		Metrics: metricsRegistry,
+		Compaction: func(fileSize, dataSize uint64) bool {
+			return compact
+		},
+	})
+	require.NoError(t, err)
+	defer registry.Close()
+
+	s, err := registry.Access("test")
+	require.NoError(t, err)
+	defer s.Close()
+
+	value := map[string]interface{}{"data": string(make([]byte, 1024))}
+	for i := 0; i < compactCheckInterval; i++ {
+		require.NoError(t, s.Set(fmt.Sprintf("key%d", i), value))
+	}
+	for i := 1; i < compactCheckInterval; i++ {
+		require.NoError(t, s.Remove(fmt.Sprintf("key%d", i)))
+	}
+
+	path := filepath.Join(root, "test.db")
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	compact = true
+	require.NoError(t, s.Remove("missing"))
+
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Less(t, after.Size(), before.Size())
+
+	has, err := s.Has("key0")
+	require.NoError(t, err)
+	assert.True(t, has, "key must be kept by the compaction")
+
+	snapshot := monitoring.CollectFlatSnapshot(metricsRegistry, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints["compaction.total"])
+	assert.Equal(t, int64(0), snapshot.Ints["compaction.fail"])
+	assert.Equal(t, uint64(after.Size()), uint64(snapshot.Ints["file.size.bytes"]))
+	assert.Greater(t, snapshot.Ints["compaction.reclaimed.bytes"], int64(0))
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bbolt
+
+import (
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// metrics reports the size of the database files and the compactions of a
+// registry's stores.
+type metrics struct {
+	fileSize *monitoring.Uint // Size of the database file, in bytes.
+	dataSize *monitoring.Uint // Bytes in use in the database file.
+
+	compactions *monitoring.Int  // Number of compactions.
+	failures    *monitoring.Int  // Number of failed compactions.
+	reclaimed   *monitoring.Uint // Bytes released by compactions.
+	duration    *monitoring.Int  // Duration of the last compaction, in milliseconds.
+}
+
+func newMetrics(reg *monitoring.Registry) *metrics {
+	if reg == nil {
+		return nil
+	}
+	return &metrics{
+		fileSize:    uintVar(reg, "file.size.bytes"),
+		dataSize:    uintVar(reg, "data.size.bytes"),
+		compactions: intVar(reg, "compaction.total"),
+		failures:    intVar(reg, "compaction.fail"),
+		reclaimed:   uintVar(reg, "compaction.reclaimed.bytes"),
+		duration:    intVar(reg, "compaction.duration.ms"),
+	}
+}
+
+// intVar returns the Int variable registered with name, or registers a new
+// one. The variables are reused when a registry is created again with the
+// same monitoring registry.
+func intVar(reg *monitoring.Registry, name string) *monitoring.Int {
+	if v, ok := reg.Get(name).(*monitoring.Int); ok {
+		return v
+	}
+	return monitoring.NewInt(reg, name)
+}
+
+func uintVar(reg *monitoring.Registry, name string) *monitoring.Uint {
+	if v, ok := reg.Get(name).(*monitoring.Uint); ok {
+		return v
+	}
+	return monitoring.NewUint(reg, name)
+}
+
+func (m *metrics) updateSizes(fileSize, dataSize uint64) {
+	if m == nil {
+		return
+	}
+	m.fileSize.Set(fileSize)
+	m.dataSize.Set(dataSize)
+}
+
+func (m *metrics) compacted(d time.Duration, before, after uint64) {
+	if m == nil {
+		return
+	}
+	m.compactions.Inc()
+	m.duration.Set(d.Milliseconds())
+	if after < before {
+		m.reclaimed.Add(before - after)
+	}
+}
+
+func (m *metrics) compactionFailed() {
+	if m == nil {
+		return
+	}
+	m.failures.Inc()
+}
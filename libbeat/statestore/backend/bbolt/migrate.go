@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bbolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend/memlog"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// migratedSuffix is appended to the directory of a memlog store once it has
+// been migrated.
+const migratedSuffix = ".migrated"
+
+// memlogMetaFile is the file that identifies a memlog store directory.
+const memlogMetaFile = "meta.json"
+
+// migrateMemlog copies the key value pairs of the memlog store name into a
+// new database file at path, if the database file does not exist yet. The
+// pairs are written into a temporary file first, that is renamed once all
+// pairs have been copied. Finally the memlog store directory is renamed, so
+// the store is not migrated again.
+func migrateMemlog(log *logp.Logger, settings Settings, name, path string) error {
+	home := filepath.Join(settings.Root, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(home, memlogMetaFile)); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	log.Infof("Migrating memlog store in %v to %v.", home, path)
+
+	memlogRegistry, err := memlog.New(log, memlog.Settings{
+		Root:     settings.Root,
+		FileMode: settings.FileMode,
+	})
+	if err != nil {
+		return err
+	}
+	defer memlogRegistry.Close()
+
+	src, err := memlogRegistry.Access(name)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".migrate"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		src.Close()
+		return err
+	}
+	count, err := copyMemlogStore(tmpPath, settings, src)
+	if closeErr := src.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(home, home+migratedSuffix); err != nil {
+		return fmt.Errorf("failed to rename migrated memlog store: %w", err)
+	}
+
+	log.Infof("Migrated %d keys from memlog store. The memlog store has been moved to %v.", count, home+migratedSuffix)
+	return nil
+}
+
+// copyMemlogStore writes all key value pairs of src in a new database file
+// at path, in a single transaction.
+func copyMemlogStore(path string, settings Settings, src backend.Store) (int, error) {
+	db, err := openDB(path, settings)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(statesBucket)
+		return src.Each(func(key string, dec backend.ValueDecoder) (bool, error) {
+			var value mapstr.M
+			if err := dec.Decode(&value); err != nil {
+				return false, fmt.Errorf("failed to decode key '%v': %w", key, err)
+			}
+			data, err := encodeValue(value)
+			if err != nil {
+				return false, fmt.Errorf("failed to encode key '%v': %w", key, err)
+			}
+			count++
+			return true, b.Put([]byte(key), data)
+		})
+	})
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	return count, err
+}
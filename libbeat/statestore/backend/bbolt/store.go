@@ -0,0 +1,327 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package bbolt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/go-structform/gotype"
+	structjson "github.com/elastic/go-structform/json"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/elastic/beats/v7/libbeat/common/transform/typeconv"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// store implements a bbolt based store. All key value pairs are stored as
+// JSON documents in a single bucket of the database file.
+//
+// The database file is checked for compaction when the store is opened, and
+// every compactCheckInterval removals. Compaction writes the key value pairs
+// into a new database file replacing the current one, which releases the
+// space of the free pages to the file system.
+type store struct {
+	log      *logp.Logger
+	path     string
+	settings Settings
+	metrics  *metrics
+
+	// lock is held exclusively while the database file is replaced by the
+	// compaction.
+	lock sync.RWMutex
+	db   *bolt.DB
+
+	removes uint64
+}
+
+// rawValue decodes a JSON document read from the database.
+type rawValue []byte
+
+var statesBucket = []byte("states")
+
+const compactCheckInterval = 1000
+
+// compactTxMaxSize is the maximum number of bytes copied in a single
+// transaction during compaction.
+const compactTxMaxSize = 64 * 1 << 20
+
+// openStore opens the database file at path. The file is created if it does
+// not exist.
+func openStore(log *logp.Logger, path string, settings Settings, metrics *metrics) (*store, error) {
+	db, err := openDB(path, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &store{
+		log:      log,
+		path:     path,
+		settings: settings,
+		metrics:  metrics,
+		db:       db,
+	}
+	if err := s.maybeCompact(); err != nil {
+		log.Errorf("Failed to compact store: %v", err)
+	}
+	return s, nil
+}
+
+func openDB(path string, settings Settings) (*bolt.DB, error) {
+	db, err := bolt.Open(path, settings.FileMode, &bolt.Options{
+		Timeout:        settings.Timeout,
+		NoFreelistSync: true,
+		FreelistType:   bolt.FreelistMapType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database file '%v': %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(statesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Close closes the database file. Access to the store after close returns
+// an error.
+func (s *store) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.db.Close()
+}
+
+// Has checks if the key is known.
+func (s *store) Has(key string) (bool, error) {
+	var found bool
+	err := s.view(func(b *bolt.Bucket) error {
+		found = b.Get([]byte(key)) != nil
+		return nil
+	})
+	return found, err
+}
+
+// Get retrieves and decodes the key-value pair into to.
+func (s *store) Get(key string, to interface{}) error {
+	return s.view(func(b *bolt.Bucket) error {
+		value := b.Get([]byte(key))
+		if value == nil {
+			return errKeyUnknown
+		}
+		return rawValue(value).Decode(to)
+	})
+}
+
+// Set inserts or overwrites a key-value pair.
+func (s *store) Set(key string, value interface{}) error {
+	data, err := encodeValue(value)
+	if err != nil {
+		return err
+	}
+
+	return s.update(func(b *bolt.Bucket) error {
+		return b.Put([]byte(key), data)
+	})
+}
+
+// Remove removes a key from the store. The operation does not check if the
+// key exists.
+func (s *store) Remove(key string) error {
+	err := s.update(func(b *bolt.Bucket) error {
+		return b.Delete([]byte(key))
+	})
+	if err != nil {
+		return err
+	}
+
+	if atomic.AddUint64(&s.removes, 1)%compactCheckInterval == 0 {
+		if err := s.maybeCompact(); err != nil {
+			s.log.Errorf("Failed to compact store: %v", err)
+		}
+	}
+	return nil
+}
+
+// Each iterates over all key-value pairs in the store.
+func (s *store) Each(fn func(string, backend.ValueDecoder) (bool, error)) error {
+	return s.view(func(b *bolt.Bucket) error {
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			cont, err := fn(string(k), rawValue(v))
+			if !cont || err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *store) view(fn func(*bolt.Bucket) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.db.View(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(statesBucket))
+	})
+}
+
+func (s *store) update(fn func(*bolt.Bucket) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(statesBucket))
+	})
+}
+
+// maybeCompact compacts the database file if the compaction predicate
+// triggers for the current file and data sizes.
+func (s *store) maybeCompact() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fileSize, dataSize, err := s.sizes()
+	if err != nil {
+		return err
+	}
+	s.metrics.updateSizes(fileSize, dataSize)
+
+	if !s.settings.Compaction(fileSize, dataSize) {
+		return nil
+	}
+
+	s.log.Infof("Compacting database file of %d bytes with %d bytes in use.", fileSize, dataSize)
+	start := time.Now()
+	if err := s.compact(); err != nil {
+		s.metrics.compactionFailed()
+		return err
+	}
+
+	newFileSize, newDataSize, err := s.sizes()
+	if err != nil {
+		return err
+	}
+	s.metrics.compacted(time.Since(start), fileSize, newFileSize)
+	s.metrics.updateSizes(newFileSize, newDataSize)
+	s.log.Infof("Compacted database file to %d bytes in %v.", newFileSize, time.Since(start))
+	return nil
+}
+
+// sizes returns the size of the database file and the number of bytes in
+// use by the database pages.
+func (s *store) sizes() (fileSize, dataSize uint64, err error) {
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var size int64
+	err = s.db.View(func(tx *bolt.Tx) error {
+		size = tx.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if free := int64(s.db.Stats().FreeAlloc); free < size {
+		size -= free
+	}
+	return uint64(fi.Size()), uint64(size), nil
+}
+
+// compact copies the database into a new file replacing the current one.
+// The caller must hold the store lock exclusively.
+func (s *store) compact() error {
+	tmpPath := s.path + ".compact"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	dst, err := bolt.Open(tmpPath, s.settings.FileMode, &bolt.Options{Timeout: s.settings.Timeout})
+	if err != nil {
+		return err
+	}
+	if err := bolt.Compact(dst, s.db, compactTxMaxSize); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := s.db.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	renameErr := os.Rename(tmpPath, s.path)
+	if renameErr != nil {
+		os.Remove(tmpPath)
+	}
+
+	// Reopen the compacted file, or the original one if it could not be replaced.
+	db, err := openDB(s.path, s.settings)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	return renameErr
+}
+
+// encodeValue encodes a value as a JSON document. The value is converted to a
+// map first, the same way the memlog store does.
+func encodeValue(value interface{}) ([]byte, error) {
+	var tmp mapstr.M
+	if err := typeconv.Convert(&tmp, value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	visitor := structjson.NewVisitor(&buf)
+	visitor.SetEscapeHTML(false)
+	folder, err := gotype.NewIterator(visitor)
+	if err != nil {
+		return nil, err
+	}
+	if err := folder.Fold(tmp); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (v rawValue) Decode(to interface{}) error {
+	var tmp map[string]interface{}
+	if err := json.Unmarshal(v, &tmp); err != nil {
+		return err
+	}
+	return typeconv.Convert(to, tmp)
+}
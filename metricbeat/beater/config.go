@@ -31,8 +31,18 @@ type Config struct {
 	ConfigModules *conf.C              `config:"config.modules"`
 	MaxStartDelay time.Duration        `config:"max_start_delay"` // Upper bound on the random startup delay for metricsets (use 0 to disable startup delay).
 	Autodiscover  *autodiscover.Config `config:"autodiscover"`
+	Plugins       PluginsConfig        `config:"plugins"`
+}
+
+// PluginsConfig contains the settings of the external metricset plugins.
+type PluginsConfig struct {
+	Enabled bool   `config:"enabled"`
+	Path    string `config:"path"` // Directory with the plugin executables, relative to the home path.
 }
 
 var defaultConfig = Config{
 	MaxStartDelay: 10 * time.Second,
+	Plugins: PluginsConfig{
+		Path: "plugins",
+	},
 }
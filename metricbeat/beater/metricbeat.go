@@ -27,6 +27,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/external"
 	"github.com/elastic/beats/v7/metricbeat/mb/module"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -74,6 +75,20 @@ func WithLightModules() Option {
 	}
 }
 
+// WithPlugins enables the metricsets served by the plugins in the plugins
+// directory. Plugins are only loaded if metricbeat.plugins.enabled is set.
+func WithPlugins() Option {
+	return func(m *Metricbeat) {
+		if !m.config.Plugins.Enabled {
+			return
+		}
+		path := paths.Resolve(paths.Home, m.config.Plugins.Path)
+		if err := external.Load(path, mb.Registry); err != nil {
+			logp.Err("Failed to load plugins: %v", err)
+		}
+	}
+}
+
 // Creator returns a beat.Creator for instantiating a new instance of the
 // Metricbeat framework with the given options.
 func Creator(options ...Option) beat.Creator {
@@ -96,6 +111,7 @@ func Creator(options ...Option) beat.Creator {
 func DefaultCreator() beat.Creator {
 	return Creator(
 		WithLightModules(),
+		WithPlugins(),
 		WithModuleOptions(
 			module.WithMetricSetInfo(),
 			module.WithServiceName(),
@@ -18,6 +18,7 @@
 package helper
 
 import (
+	"errors"
 	"time"
 
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
@@ -28,10 +29,26 @@ type Config struct {
 	ConnectTimeout  time.Duration     `config:"connect_timeout"`
 	Headers         map[string]string `config:"headers"`
 	BearerTokenFile string            `config:"bearer_token_file"`
+	BearerToken     string            `config:"bearer_token"`
+	APIKey          string            `config:"api_key"`
 
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 }
 
+// Validate checks that at most one of the token based authentication methods is configured
+func (c *Config) Validate() error {
+	configured := 0
+	for _, v := range []string{c.BearerTokenFile, c.BearerToken, c.APIKey} {
+		if v != "" {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return errors.New("only one of bearer_token_file, bearer_token and api_key can be configured")
+	}
+	return nil
+}
+
 func defaultConfig() Config {
 	transport := httpcommon.DefaultHTTPTransportSettings()
 	transport.Timeout = 10 * time.Second
@@ -20,16 +20,21 @@ package helper
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/pkg/errors"
 
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlsreload"
 	"github.com/elastic/beats/v7/libbeat/version"
 	"github.com/elastic/beats/v7/metricbeat/helper/dialer"
+	"github.com/elastic/beats/v7/metricbeat/helper/secrets"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 	"github.com/elastic/elastic-agent-libs/useragent"
@@ -47,6 +52,8 @@ type HTTP struct {
 	uri      string
 	method   string
 	body     []byte
+
+	credentials *secrets.Resolver
 }
 
 // NewHTTP creates new http helper
@@ -77,6 +84,14 @@ func NewHTTPFromConfig(config Config, hostData mb.HostData) (*HTTP, error) {
 		headers.Set("Authorization", header)
 	}
 
+	if config.BearerToken != "" {
+		headers.Set("Authorization", "Bearer "+config.BearerToken)
+	}
+
+	if config.APIKey != "" {
+		headers.Set("Authorization", getAuthHeaderFromAPIKey(config.APIKey))
+	}
+
 	// Ensure backward compatibility
 	builder := hostData.Transport
 	if builder == nil {
@@ -88,7 +103,7 @@ func NewHTTPFromConfig(config Config, hostData mb.HostData) (*HTTP, error) {
 		return nil, err
 	}
 
-	client, err := config.Transport.Client(
+	client, err := tlsreload.Client(config.Transport,
 		httpcommon.WithBaseDialer(dialer),
 		httpcommon.WithAPMHTTPInstrumentation(),
 		httpcommon.WithHeaderRoundTripper(map[string]string{"User-Agent": userAgent}),
@@ -111,19 +126,41 @@ func NewHTTPFromConfig(config Config, hostData mb.HostData) (*HTTP, error) {
 // It's important that resp.Body has to be closed if this method is used. Before using this method
 // check if one of the other Fetch* methods could be used as they ensure that the Body is properly closed.
 func (h *HTTP) FetchResponse() (*http.Response, error) {
+	return h.FetchResponseWithContext(context.Background())
+}
+
+// FetchResponseWithContext fetches a response for the http metricset, the request
+// is cancelled if the context is done before it finishes, what can happen when the
+// deadline of the fetch is reached.
+// As with FetchResponse, resp.Body has to be closed if this method is used.
+func (h *HTTP) FetchResponseWithContext(ctx context.Context) (*http.Response, error) {
 	// Create a fresh reader every time
 	var reader io.Reader
 	if h.body != nil {
 		reader = bytes.NewReader(h.body)
 	}
 
-	req, err := http.NewRequest(h.method, h.uri, reader)
+	req, err := http.NewRequestWithContext(ctx, h.method, h.uri, reader)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create HTTP request")
 	}
 	req.Header = h.headers
-	if h.hostData.User != "" || h.hostData.Password != "" {
-		req.SetBasicAuth(h.hostData.User, h.hostData.Password)
+
+	username, password := h.hostData.User, h.hostData.Password
+	if h.credentials != nil {
+		credentials := h.credentials.Credentials()
+		switch {
+		case credentials.Token != "":
+			// Basic authentication would replace the token.
+			req.Header = h.headers.Clone()
+			req.Header.Set("Authorization", "Bearer "+credentials.Token)
+			username, password = "", ""
+		case credentials.Username != "" || credentials.Password != "":
+			username, password = credentials.Username, credentials.Password
+		}
+	}
+	if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
 	}
 
 	resp, err := h.client.Do(req)
@@ -149,6 +186,13 @@ func (h *HTTP) SetHeaderDefault(key, value string) {
 	}
 }
 
+// SetCredentialsResolver sets a resolver from which the credentials used in requests
+// are obtained. Resolved credentials take precedence over the username and password
+// of the host configuration, and no basic authentication is used when a token is resolved.
+func (h *HTTP) SetCredentialsResolver(resolver *secrets.Resolver) {
+	h.credentials = resolver
+}
+
 // SetMethod sets HTTP method to use in requests
 func (h *HTTP) SetMethod(method string) {
 	h.method = method
@@ -171,19 +215,49 @@ func (h *HTTP) SetBody(body []byte) {
 
 // FetchContent makes an HTTP request to the configured url and returns the body content.
 func (h *HTTP) FetchContent() ([]byte, error) {
-	resp, err := h.FetchResponse()
+	return h.FetchContentWithContext(context.Background())
+}
+
+// FetchContentWithContext makes an HTTP request to the configured url and returns the
+// body content. The request is cancelled if the context is done before it finishes.
+func (h *HTTP) FetchContentWithContext(ctx context.Context) ([]byte, error) {
+	resp, err := h.FetchResponseWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP error %d in %s: %s", resp.StatusCode, h.name, resp.Status)
+		return nil, newHTTPStatusError(resp, h.name)
 	}
 
 	return ioutil.ReadAll(resp.Body)
 }
 
+// FetchStream makes an HTTP request to the configured url and returns the body of the
+// response, so it can be processed without reading it entirely in memory. The caller
+// is responsible for closing it.
+func (h *HTTP) FetchStream() (io.ReadCloser, error) {
+	return h.FetchStreamWithContext(context.Background())
+}
+
+// FetchStreamWithContext makes an HTTP request to the configured url and returns the
+// body of the response. The request is cancelled if the context is done before the
+// body is read. As with FetchStream, the caller is responsible for closing it.
+func (h *HTTP) FetchStreamWithContext(ctx context.Context) (io.ReadCloser, error) {
+	resp, err := h.FetchResponseWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, newHTTPStatusError(resp, h.name)
+	}
+
+	return resp.Body, nil
+}
+
 // FetchScanner returns a Scanner for the content.
 func (h *HTTP) FetchScanner() (*bufio.Scanner, error) {
 	content, err := h.FetchContent()
@@ -197,7 +271,13 @@ func (h *HTTP) FetchScanner() (*bufio.Scanner, error) {
 // FetchJSON makes an HTTP request to the configured url and returns the JSON content.
 // This only works if the JSON output needed is in map[string]interface format.
 func (h *HTTP) FetchJSON() (map[string]interface{}, error) {
-	body, err := h.FetchContent()
+	return h.FetchJSONWithContext(context.Background())
+}
+
+// FetchJSONWithContext makes an HTTP request to the configured url and returns the
+// JSON content. The request is cancelled if the context is done before it finishes.
+func (h *HTTP) FetchJSONWithContext(ctx context.Context) (map[string]interface{}, error) {
+	body, err := h.FetchContentWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +292,43 @@ func (h *HTTP) FetchJSON() (map[string]interface{}, error) {
 	return data, nil
 }
 
+// httpStatusError is returned when the response has an unexpected status code.
+// Errors for 429 and 503 status codes are considered mb.ErrTargetOverloaded,
+// and errors for 401 and 403 status codes are authentication errors.
+type httpStatusError struct {
+	code int
+	msg  string
+}
+
+func newHTTPStatusError(resp *http.Response, name string) error {
+	return &httpStatusError{
+		code: resp.StatusCode,
+		msg:  fmt.Sprintf("HTTP error %d in %s: %s", resp.StatusCode, name, resp.Status),
+	}
+}
+
+func (e *httpStatusError) Error() string { return e.msg }
+
+func (e *httpStatusError) Is(target error) bool {
+	return target == mb.ErrTargetOverloaded &&
+		(e.code == http.StatusTooManyRequests || e.code == http.StatusServiceUnavailable)
+}
+
+func (e *httpStatusError) ErrorType() string {
+	if e.code == http.StatusUnauthorized || e.code == http.StatusForbidden {
+		return mb.ErrorTypeAuth
+	}
+	return ""
+}
+
+func (e *httpStatusError) ErrorCode() string { return strconv.Itoa(e.code) }
+
+// getAuthHeaderFromAPIKey builds the authorization header for the given API key, in
+// the `id:api_key` format, as expected by the Elastic stack
+func getAuthHeaderFromAPIKey(apiKey string) string {
+	return "ApiKey " + base64.StdEncoding.EncodeToString([]byte(apiKey))
+}
+
 // getAuthHeaderFromToken reads a bearer authorizaiton token from the given file
 func getAuthHeaderFromToken(path string) (string, error) {
 	var token string
@@ -18,6 +18,8 @@
 package helper
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -32,8 +34,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/metricbeat/helper/dialer"
+	"github.com/elastic/beats/v7/metricbeat/helper/secrets"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/logp"
 )
 
 func TestGetAuthHeaderFromToken(t *testing.T) {
@@ -105,6 +109,65 @@ func TestTimeout(t *testing.T) {
 	close(c)
 }
 
+func TestOverloadedStatusCodes(t *testing.T) {
+	cases := map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusInternalServerError: false,
+		http.StatusNotFound:            false,
+	}
+
+	for code, overloaded := range cases {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(code)
+			}))
+			defer ts.Close()
+
+			hostData := mb.HostData{
+				URI:          ts.URL,
+				SanitizedURI: ts.URL,
+			}
+			h, err := NewHTTPFromConfig(defaultConfig(), hostData)
+			require.NoError(t, err)
+
+			_, err = h.FetchContent()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("HTTP error %d", code))
+			assert.Equal(t, overloaded, errors.Is(err, mb.ErrTargetOverloaded))
+		})
+	}
+}
+
+func TestStatusCodeErrorType(t *testing.T) {
+	cases := map[int]string{
+		http.StatusUnauthorized:        mb.ErrorTypeAuth,
+		http.StatusForbidden:           mb.ErrorTypeAuth,
+		http.StatusInternalServerError: mb.ErrorTypeUnknown,
+	}
+
+	for code, errorType := range cases {
+		t.Run(http.StatusText(code), func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(code)
+			}))
+			defer ts.Close()
+
+			hostData := mb.HostData{
+				URI:          ts.URL,
+				SanitizedURI: ts.URL,
+			}
+			h, err := NewHTTPFromConfig(defaultConfig(), hostData)
+			require.NoError(t, err)
+
+			_, err = h.FetchContent()
+			require.Error(t, err)
+			assert.Equal(t, errorType, mb.ErrorType(err))
+			assert.Equal(t, fmt.Sprint(code), mb.ErrorCode(err))
+		})
+	}
+}
+
 func TestConnectTimeout(t *testing.T) {
 	// This IP shouldn't exist, 192.0.2.0/24 is reserved for testing
 	uri := "http://192.0.2.42"
@@ -163,6 +226,86 @@ func TestAuthentication(t *testing.T) {
 	assert.Equal(t, http.StatusOK, response.StatusCode, "response status code")
 }
 
+func TestTokenAuthentication(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer ts.Close()
+
+	hostData := mb.HostData{
+		URI:          ts.URL,
+		SanitizedURI: ts.URL,
+	}
+
+	cases := map[string]struct {
+		config   func(*Config)
+		expected string
+	}{
+		"api key": {
+			config:   func(c *Config) { c.APIKey = "foo:bar" },
+			expected: "ApiKey Zm9vOmJhcg==",
+		},
+		"bearer token": {
+			config:   func(c *Config) { c.BearerToken = "testtoken" },
+			expected: "Bearer testtoken",
+		},
+	}
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			cfg := defaultConfig()
+			c.config(&cfg)
+			require.NoError(t, cfg.Validate())
+
+			h, err := NewHTTPFromConfig(cfg, hostData)
+			require.NoError(t, err)
+
+			content, err := h.FetchContent()
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, string(content))
+		})
+	}
+}
+
+type staticCredentials secrets.Credentials
+
+func (c staticCredentials) Credentials(context.Context) (secrets.Credentials, error) {
+	return secrets.Credentials(c), nil
+}
+
+func TestResolvedTokenOverridesBasicAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer ts.Close()
+
+	secrets.Register("test_static_token", func(secrets.Config) (secrets.Provider, error) {
+		return staticCredentials{Token: "resolved"}, nil
+	})
+	resolver, err := secrets.NewResolver(secrets.Config{Provider: "test_static_token"}, logp.NewLogger("test"))
+	require.NoError(t, err)
+
+	h, err := NewHTTPFromConfig(defaultConfig(), mb.HostData{
+		URI:          ts.URL,
+		SanitizedURI: ts.URL,
+		User:         "elastic",
+		Password:     "changeme",
+	})
+	require.NoError(t, err)
+	h.SetCredentialsResolver(resolver)
+
+	content, err := h.FetchContent()
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer resolved", string(content))
+}
+
+func TestTokenAuthenticationValidate(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.APIKey = "foo:bar"
+	cfg.BearerToken = "testtoken"
+	assert.Error(t, cfg.Validate())
+}
+
 func TestSetHeader(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.Headers = map[string]string{
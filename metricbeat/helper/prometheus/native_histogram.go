@@ -0,0 +1,351 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Native histograms are only available in the protobuf exposition format, and
+// the client_model version in use predates them, so their fields are decoded
+// from the unrecognized fields of the histogram message. Field numbers are the
+// ones defined in io.prometheus.client.Histogram.
+const (
+	histogramSampleCountFloat = 4
+	histogramSchema           = 5
+	histogramZeroThreshold    = 6
+	histogramZeroCount        = 7
+	histogramZeroCountFloat   = 8
+	histogramNegativeSpan     = 9
+	histogramNegativeDelta    = 10
+	histogramNegativeCount    = 11
+	histogramPositiveSpan     = 12
+	histogramPositiveDelta    = 13
+	histogramPositiveCount    = 14
+
+	bucketSpanOffset = 1
+	bucketSpanLength = 2
+
+	// Valid schemas for exponential native histograms
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
+)
+
+var errInvalidNativeHistogram = errors.New("invalid native histogram")
+
+type bucketSpan struct {
+	offset int32
+	length uint32
+}
+
+// nativeHistogram holds the sparse buckets of a Prometheus native histogram
+type nativeHistogram struct {
+	isNative bool
+
+	sampleCountFloat float64
+	schema           int32
+	zeroThreshold    float64
+	zeroCount        float64
+
+	negativeSpans  []bucketSpan
+	negativeDeltas []int64
+	negativeCounts []float64
+	positiveSpans  []bucketSpan
+	positiveDeltas []int64
+	positiveCounts []float64
+}
+
+// decodeNativeHistogram reads the native histogram fields of the given
+// histogram. It returns nil if the histogram has no native buckets.
+func decodeNativeHistogram(h *dto.Histogram) (*nativeHistogram, error) {
+	b := h.XXX_unrecognized
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	nh := &nativeHistogram{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errInvalidNativeHistogram
+		}
+		b = b[n:]
+
+		switch {
+		case num == histogramSampleCountFloat && typ == protowire.Fixed64Type:
+			n = consumeDouble(b, &nh.sampleCountFloat)
+		case num == histogramSchema && typ == protowire.VarintType:
+			var v int64
+			n = consumeSint(b, &v)
+			nh.schema = int32(v)
+			nh.isNative = true
+		case num == histogramZeroThreshold && typ == protowire.Fixed64Type:
+			n = consumeDouble(b, &nh.zeroThreshold)
+		case num == histogramZeroCount && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			nh.zeroCount = float64(v)
+		case num == histogramZeroCountFloat && typ == protowire.Fixed64Type:
+			n = consumeDouble(b, &nh.zeroCount)
+		case num == histogramNegativeSpan && typ == protowire.BytesType:
+			n = consumeSpan(b, &nh.negativeSpans)
+		case num == histogramPositiveSpan && typ == protowire.BytesType:
+			n = consumeSpan(b, &nh.positiveSpans)
+		case num == histogramNegativeDelta:
+			n = consumeRepeated(b, typ, func(b []byte) int {
+				var v int64
+				n := consumeSint(b, &v)
+				nh.negativeDeltas = append(nh.negativeDeltas, v)
+				return n
+			}, protowire.VarintType)
+		case num == histogramPositiveDelta:
+			n = consumeRepeated(b, typ, func(b []byte) int {
+				var v int64
+				n := consumeSint(b, &v)
+				nh.positiveDeltas = append(nh.positiveDeltas, v)
+				return n
+			}, protowire.VarintType)
+		case num == histogramNegativeCount:
+			n = consumeRepeated(b, typ, func(b []byte) int {
+				var v float64
+				n := consumeDouble(b, &v)
+				nh.negativeCounts = append(nh.negativeCounts, v)
+				return n
+			}, protowire.Fixed64Type)
+		case num == histogramPositiveCount:
+			n = consumeRepeated(b, typ, func(b []byte) int {
+				var v float64
+				n := consumeDouble(b, &v)
+				nh.positiveCounts = append(nh.positiveCounts, v)
+				return n
+			}, protowire.Fixed64Type)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, errInvalidNativeHistogram
+		}
+		b = b[n:]
+	}
+
+	// Native histograms without observations may have no schema set, they
+	// are still identified by their spans.
+	if len(nh.negativeSpans) > 0 || len(nh.positiveSpans) > 0 {
+		nh.isNative = true
+	}
+	if !nh.isNative {
+		return nil, nil
+	}
+	return nh, nil
+}
+
+func consumeDouble(b []byte, v *float64) int {
+	u, n := protowire.ConsumeFixed64(b)
+	if n >= 0 {
+		*v = math.Float64frombits(u)
+	}
+	return n
+}
+
+func consumeSint(b []byte, v *int64) int {
+	u, n := protowire.ConsumeVarint(b)
+	if n >= 0 {
+		*v = protowire.DecodeZigZag(u)
+	}
+	return n
+}
+
+// consumeRepeated reads a repeated scalar field, that can be encoded packed or
+// as individual elements.
+func consumeRepeated(b []byte, typ protowire.Type, consume func([]byte) int, elemType protowire.Type) int {
+	switch typ {
+	case elemType:
+		return consume(b)
+	case protowire.BytesType:
+		packed, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n
+		}
+		for len(packed) > 0 {
+			m := consume(packed)
+			if m < 0 {
+				return m
+			}
+			packed = packed[m:]
+		}
+		return n
+	default:
+		return -1
+	}
+}
+
+func consumeSpan(b []byte, spans *[]bucketSpan) int {
+	msg, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return n
+	}
+	var span bucketSpan
+	for len(msg) > 0 {
+		num, typ, m := protowire.ConsumeTag(msg)
+		if m < 0 {
+			return m
+		}
+		msg = msg[m:]
+		switch {
+		case num == bucketSpanOffset && typ == protowire.VarintType:
+			var v int64
+			m = consumeSint(msg, &v)
+			span.offset = int32(v)
+		case num == bucketSpanLength && typ == protowire.VarintType:
+			var v uint64
+			v, m = protowire.ConsumeVarint(msg)
+			span.length = uint32(v)
+		default:
+			m = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if m < 0 {
+			return m
+		}
+		msg = msg[m:]
+	}
+	*spans = append(*spans, span)
+	return n
+}
+
+// bucketBound returns the upper bound of the positive bucket with the given
+// index, for the given schema.
+func bucketBound(schema int32, index int) float64 {
+	if schema <= 0 {
+		return math.Ldexp(1, index<<uint(-schema))
+	}
+	return math.Exp2(float64(index) / float64(int(1)<<uint(schema)))
+}
+
+// sparseBuckets returns the index and absolute count of each populated
+// bucket described by the given spans.
+func sparseBuckets(spans []bucketSpan, deltas []int64, counts []float64) ([]int, []float64) {
+	var indexes []int
+	var values []float64
+
+	useCounts := len(counts) > 0
+	index, pos := 0, 0
+	var current int64
+	for i, span := range spans {
+		if i == 0 {
+			index = int(span.offset)
+		} else {
+			index += int(span.offset)
+		}
+		for j := uint32(0); j < span.length; j++ {
+			var count float64
+			if useCounts {
+				if pos >= len(counts) {
+					return indexes, values
+				}
+				count = counts[pos]
+			} else {
+				if pos >= len(deltas) {
+					return indexes, values
+				}
+				current += deltas[pos]
+				count = float64(current)
+			}
+			indexes = append(indexes, index)
+			values = append(values, count)
+			index++
+			pos++
+		}
+	}
+	return indexes, values
+}
+
+// classicBuckets converts the sparse buckets of the native histogram to
+// cumulative buckets with an upper bound, as classic histograms have.
+func (nh *nativeHistogram) classicBuckets(sampleCount uint64) []*dto.Bucket {
+	type bucket struct {
+		upper float64
+		count float64
+	}
+	var buckets []bucket
+
+	// Negative bucket i covers [-bound(i), -bound(i-1)), so its upper bound
+	// is -bound(i-1).
+	indexes, counts := sparseBuckets(nh.negativeSpans, nh.negativeDeltas, nh.negativeCounts)
+	for i, index := range indexes {
+		buckets = append(buckets, bucket{upper: -bucketBound(nh.schema, index-1), count: counts[i]})
+	}
+	buckets = append(buckets, bucket{upper: nh.zeroThreshold, count: nh.zeroCount})
+	indexes, counts = sparseBuckets(nh.positiveSpans, nh.positiveDeltas, nh.positiveCounts)
+	for i, index := range indexes {
+		buckets = append(buckets, bucket{upper: bucketBound(nh.schema, index), count: counts[i]})
+	}
+
+	sort.SliceStable(buckets, func(i, j int) bool { return buckets[i].upper < buckets[j].upper })
+
+	result := make([]*dto.Bucket, 0, len(buckets)+1)
+	var cumulative float64
+	for _, b := range buckets {
+		cumulative += b.count
+		result = append(result, &dto.Bucket{
+			UpperBound:      proto.Float64(b.upper),
+			CumulativeCount: proto.Uint64(uint64(math.Round(cumulative))),
+		})
+	}
+	result = append(result, &dto.Bucket{
+		UpperBound:      proto.Float64(math.Inf(1)),
+		CumulativeCount: proto.Uint64(sampleCount),
+	})
+	return result
+}
+
+// convertNativeHistograms adds classic buckets to the histograms of the given
+// family that only have native buckets, so they can be handled as any other
+// histogram. Histograms exposing both keep their classic buckets.
+func convertNativeHistograms(mf *dto.MetricFamily) error {
+	if mf.GetType() != dto.MetricType_HISTOGRAM {
+		return nil
+	}
+	for _, metric := range mf.GetMetric() {
+		h := metric.GetHistogram()
+		if h == nil {
+			continue
+		}
+		nh, err := decodeNativeHistogram(h)
+		if err != nil {
+			return err
+		}
+		if nh == nil || len(h.GetBucket()) > 0 {
+			continue
+		}
+		if nh.schema < minNativeHistogramSchema || nh.schema > maxNativeHistogramSchema {
+			continue
+		}
+
+		if h.GetSampleCount() == 0 && nh.sampleCountFloat > 0 {
+			h.SampleCount = proto.Uint64(uint64(math.Round(nh.sampleCountFloat)))
+		}
+		h.Bucket = nh.classicBuckets(h.GetSampleCount())
+	}
+	return nil
+}
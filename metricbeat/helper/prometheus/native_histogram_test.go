@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type protobufFetcher struct {
+	families []*dto.MetricFamily
+}
+
+func (f protobufFetcher) FetchResponse() (*http.Response, error) {
+	body := bytes.NewBuffer(nil)
+	encoder := expfmt.NewEncoder(body, expfmt.FmtProtoDelim)
+	for _, mf := range f.families {
+		if err := encoder.Encode(mf); err != nil {
+			return nil, err
+		}
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Header: http.Header{
+			"Content-Type": []string{string(expfmt.FmtProtoDelim)},
+		},
+		Body: ioutil.NopCloser(body),
+	}, nil
+}
+
+func appendSpan(b []byte, num protowire.Number, offset int32, length uint32) []byte {
+	var span []byte
+	span = protowire.AppendTag(span, bucketSpanOffset, protowire.VarintType)
+	span = protowire.AppendVarint(span, protowire.EncodeZigZag(int64(offset)))
+	span = protowire.AppendTag(span, bucketSpanLength, protowire.VarintType)
+	span = protowire.AppendVarint(span, uint64(length))
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, span)
+}
+
+func appendPackedSint(b []byte, num protowire.Number, values ...int64) []byte {
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, protowire.EncodeZigZag(v))
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+func histogramFamily(name string, h *dto.Histogram) *dto.MetricFamily {
+	return &dto.MetricFamily{
+		Name:   proto.String(name),
+		Type:   dto.MetricType_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{{Histogram: h}},
+	}
+}
+
+func TestNativeHistograms(t *testing.T) {
+	// Schema 0, so bucket bounds are powers of 2
+	var native []byte
+	native = protowire.AppendTag(native, histogramSchema, protowire.VarintType)
+	native = protowire.AppendVarint(native, protowire.EncodeZigZag(0))
+	native = protowire.AppendTag(native, histogramZeroThreshold, protowire.Fixed64Type)
+	native = protowire.AppendFixed64(native, math.Float64bits(0.001))
+	native = protowire.AppendTag(native, histogramZeroCount, protowire.VarintType)
+	native = protowire.AppendVarint(native, 1)
+	native = appendSpan(native, histogramNegativeSpan, 0, 1)
+	native = appendPackedSint(native, histogramNegativeDelta, 1)
+	native = appendSpan(native, histogramPositiveSpan, 0, 2)
+	native = appendSpan(native, histogramPositiveSpan, 1, 1)
+	// Deltas not packed, as individual fields
+	for _, delta := range []int64{2, -1, 2} {
+		native = protowire.AppendTag(native, histogramPositiveDelta, protowire.VarintType)
+		native = protowire.AppendVarint(native, protowire.EncodeZigZag(delta))
+	}
+
+	classic := &dto.Histogram{
+		SampleCount: proto.Uint64(3),
+		SampleSum:   proto.Float64(1.5),
+		Bucket: []*dto.Bucket{
+			{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+			{UpperBound: proto.Float64(math.Inf(1)), CumulativeCount: proto.Uint64(3)},
+		},
+	}
+	classic.XXX_unrecognized = native
+
+	p := &prometheus{protobufFetcher{families: []*dto.MetricFamily{
+		histogramFamily("native", &dto.Histogram{
+			SampleCount:      proto.Uint64(8),
+			SampleSum:        proto.Float64(25.5),
+			XXX_unrecognized: native,
+		}),
+		histogramFamily("classic_and_native", classic),
+	}}, logp.NewLogger("test")}
+
+	families, err := p.GetFamilies()
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+
+	type bucket struct {
+		upper float64
+		count uint64
+	}
+	buckets := func(h *dto.Histogram) []bucket {
+		var result []bucket
+		for _, b := range h.GetBucket() {
+			result = append(result, bucket{b.GetUpperBound(), b.GetCumulativeCount()})
+		}
+		return result
+	}
+
+	h := families[0].GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(8), h.GetSampleCount())
+	assert.Equal(t, 25.5, h.GetSampleSum())
+	assert.Equal(t, []bucket{
+		{-0.5, 1},
+		{0.001, 2},
+		{1, 4},
+		{2, 5},
+		{8, 8},
+		{math.Inf(1), 8},
+	}, buckets(h))
+
+	// Classic buckets are kept when both are exposed
+	assert.Equal(t, []bucket{
+		{1, 2},
+		{math.Inf(1), 3},
+	}, buckets(families[1].GetMetric()[0].GetHistogram()))
+}
+
+func TestNativeHistogramFloatCounts(t *testing.T) {
+	var native []byte
+	native = protowire.AppendTag(native, histogramSampleCountFloat, protowire.Fixed64Type)
+	native = protowire.AppendFixed64(native, math.Float64bits(4.5))
+	native = protowire.AppendTag(native, histogramSchema, protowire.VarintType)
+	native = protowire.AppendVarint(native, protowire.EncodeZigZag(-1))
+	native = appendSpan(native, histogramPositiveSpan, 1, 2)
+	for _, count := range []float64{1.5, 3} {
+		native = protowire.AppendTag(native, histogramPositiveCount, protowire.Fixed64Type)
+		native = protowire.AppendFixed64(native, math.Float64bits(count))
+	}
+
+	mf := histogramFamily("float", &dto.Histogram{XXX_unrecognized: native})
+	require.NoError(t, convertNativeHistograms(mf))
+
+	h := mf.GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(5), h.GetSampleCount())
+
+	// Schema -1, so bucket bounds are powers of 4
+	var bounds []float64
+	var counts []uint64
+	for _, b := range h.GetBucket() {
+		bounds = append(bounds, b.GetUpperBound())
+		counts = append(counts, b.GetCumulativeCount())
+	}
+	assert.Equal(t, []float64{0, 4, 16, math.Inf(1)}, bounds)
+	assert.Equal(t, []uint64{0, 2, 5, 5}, counts)
+}
+
+func TestBucketBound(t *testing.T) {
+	assert.Equal(t, 1.0, bucketBound(0, 0))
+	assert.Equal(t, 0.5, bucketBound(0, -1))
+	assert.Equal(t, 256.0, bucketBound(-2, 2))
+	assert.InDelta(t, 2.0, bucketBound(3, 8), 1e-12)
+	assert.InDelta(t, math.Sqrt2, bucketBound(1, 1), 1e-12)
+}
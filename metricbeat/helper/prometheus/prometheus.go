@@ -35,7 +35,13 @@ import (
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
-const acceptHeader = `text/plain;version=0.0.4;q=0.5,*/*;q=0.1`
+const (
+	acceptHeader = `text/plain;version=0.0.4;q=0.5,*/*;q=0.1`
+
+	// Native histograms are only exposed in the protobuf format, text is
+	// accepted as fallback for endpoints not supporting it.
+	nativeHistogramsAcceptHeader = `application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,` + acceptHeader
+)
 
 // Prometheus helper retrieves prometheus formatted metrics
 type Prometheus interface {
@@ -58,14 +64,39 @@ type httpfetcher interface {
 	FetchResponse() (*http.Response, error)
 }
 
+// ClientOption configures the prometheus helper
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	nativeHistograms bool
+}
+
+// WithNativeHistograms negotiates the protobuf exposition format with the
+// endpoint, so native histograms are also retrieved. Histograms with only
+// native buckets are converted to histograms with classic buckets.
+func WithNativeHistograms() ClientOption {
+	return func(o *clientOptions) {
+		o.nativeHistograms = true
+	}
+}
+
 // NewPrometheusClient creates new prometheus helper
-func NewPrometheusClient(base mb.BaseMetricSet) (Prometheus, error) {
+func NewPrometheusClient(base mb.BaseMetricSet, options ...ClientOption) (Prometheus, error) {
+	var opts clientOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
 	http, err := helper.NewHTTP(base)
 	if err != nil {
 		return nil, err
 	}
 
-	http.SetHeaderDefault("Accept", acceptHeader)
+	if opts.nativeHistograms {
+		http.SetHeaderDefault("Accept", nativeHistogramsAcceptHeader)
+	} else {
+		http.SetHeaderDefault("Accept", acceptHeader)
+	}
 	http.SetHeaderDefault("Accept-Encoding", "gzip")
 	return &prometheus{http, base.Logger()}, nil
 }
@@ -119,6 +150,9 @@ func (p *prometheus) GetFamilies() ([]*dto.MetricFamily, error) {
 			}
 			return nil, errors.Wrap(err, "decoding of metric family failed")
 		} else {
+			if err := convertNativeHistograms(mf); err != nil {
+				return nil, errors.Wrapf(err, "decoding of native histograms of %s failed", mf.GetName())
+			}
 			families = append(families, mf)
 		}
 	}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+func init() {
+	Register("command", newCommandProvider)
+}
+
+// CommandConfig configures the command secrets provider. The command must write
+// a JSON object with the `username`, `password` and/or `token` keys to its
+// standard output.
+type CommandConfig struct {
+	Path    string        `config:"path"`
+	Args    []string      `config:"args"`
+	Timeout time.Duration `config:"timeout"`
+}
+
+func defaultCommandConfig() CommandConfig {
+	return CommandConfig{
+		Timeout: 10 * time.Second,
+	}
+}
+
+type commandProvider struct {
+	config CommandConfig
+}
+
+func newCommandProvider(config Config) (Provider, error) {
+	if config.Command.Path == "" {
+		return nil, errors.New("command.path is required")
+	}
+	return &commandProvider{config: config.Command}, nil
+}
+
+func (p *commandProvider) Credentials(ctx context.Context) (Credentials, error) {
+	if p.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.Timeout)
+		defer cancel()
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.config.Path, p.config.Args...)
+	cmd.Stdout = &stdout
+
+	// Neither the output nor the standard error of the command are included in
+	// the returned errors, as they might contain secret values.
+	if err := cmd.Run(); err != nil {
+		return Credentials{}, fmt.Errorf("credentials command %s failed: %w", p.config.Path, err)
+	}
+
+	var output struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Token    string `json:"token"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse the output of credentials command %s", p.config.Path)
+	}
+
+	return Credentials{
+		Username: output.Username,
+		Password: output.Password,
+		Token:    output.Token,
+	}, nil
+}
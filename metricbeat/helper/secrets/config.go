@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config configures the secrets provider used to resolve credentials at runtime.
+type Config struct {
+	// Provider is the name of a registered provider, e.g. "vault" or "command".
+	Provider string `config:"provider" validate:"required"`
+
+	// RefreshInterval is how often credentials are resolved again from the provider.
+	// A value of zero means credentials are only resolved once, at startup.
+	RefreshInterval time.Duration `config:"refresh_interval"`
+
+	Vault   VaultConfig   `config:"vault"`
+	Command CommandConfig `config:"command"`
+}
+
+// DefaultConfig returns the default secrets provider configuration.
+func DefaultConfig() Config {
+	return Config{
+		RefreshInterval: 5 * time.Minute,
+		Vault:           defaultVaultConfig(),
+		Command:         defaultCommandConfig(),
+	}
+}
+
+// Validate validates the secrets provider configuration.
+func (c *Config) Validate() error {
+	if c.RefreshInterval < 0 {
+		return errors.New("refresh_interval must not be negative")
+	}
+	if _, found := providers[c.Provider]; !found {
+		return fmt.Errorf("unknown secrets provider '%s'", c.Provider)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package secrets resolves credentials used by metricsets to authenticate
+// against the monitored service from an external secrets provider, such as
+// HashiCorp Vault, so they can be rotated without restarting Metricbeat.
+package secrets
+
+import (
+	"context"
+	"fmt"
+)
+
+// Credentials holds the authentication settings resolved from a provider.
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// String implements fmt.Stringer. It never includes the secret values so
+// credentials can't end up in logs by accident.
+func (c Credentials) String() string {
+	return fmt.Sprintf("{username:%t password:%t token:%t}", c.Username != "", c.Password != "", c.Token != "")
+}
+
+// GoString implements fmt.GoStringer, see String.
+func (c Credentials) GoString() string {
+	return c.String()
+}
+
+// IsEmpty returns true if no credential was resolved.
+func (c Credentials) IsEmpty() bool {
+	return c.Username == "" && c.Password == "" && c.Token == ""
+}
+
+// Provider resolves credentials from an external secrets store.
+//
+// Implementations must not include secret values in returned errors.
+type Provider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// Factory creates a Provider from its configuration.
+type Factory func(config Config) (Provider, error)
+
+var providers = map[string]Factory{}
+
+// Register registers a secrets provider factory under the given name.
+func Register(name string, factory Factory) {
+	if _, exists := providers[name]; exists {
+		panic(fmt.Sprintf("secrets provider '%s' is already registered", name))
+	}
+	providers[name] = factory
+}
+
+// NewProvider creates the provider selected in the given configuration.
+func NewProvider(config Config) (Provider, error) {
+	factory, found := providers[config.Provider]
+	if !found {
+		return nil, fmt.Errorf("unknown secrets provider '%s'", config.Provider)
+	}
+	return factory(config)
+}
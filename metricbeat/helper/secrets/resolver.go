@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	// resolveTimeout bounds the time spent resolving credentials from a provider.
+	resolveTimeout = 30 * time.Second
+
+	// retryBackoff is the time to wait before retrying after the first failed
+	// refresh. It doubles on each consecutive failure, up to the refresh interval.
+	retryBackoff = 10 * time.Second
+)
+
+// Resolver caches the credentials returned by a Provider and resolves them again
+// once the refresh interval has elapsed.
+//
+// If refreshing fails, the previously resolved credentials are kept so a
+// temporarily unavailable secrets store doesn't interrupt collection. The
+// failure is logged, without any secret value, and refreshing is retried with
+// an exponential backoff. Only one caller refreshes at a time, the others get
+// the current credentials meanwhile.
+type Resolver struct {
+	provider Provider
+	interval time.Duration
+	logger   *logp.Logger
+
+	mu          sync.Mutex
+	credentials Credentials
+	nextRefresh time.Time
+	refreshing  bool
+	failures    int
+
+	now func() time.Time
+}
+
+// NewResolver creates a resolver for the configured provider and resolves the
+// credentials a first time. An error is returned if this first resolution fails.
+func NewResolver(config Config, logger *logp.Logger) (*Resolver, error) {
+	provider, err := NewProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	return newResolver(provider, config.RefreshInterval, logger)
+}
+
+func newResolver(provider Provider, interval time.Duration, logger *logp.Logger) (*Resolver, error) {
+	r := &Resolver{
+		provider: provider,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+
+	credentials, err := r.resolve()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials: %w", err)
+	}
+	r.credentials = credentials
+	r.nextRefresh = r.now().Add(interval)
+
+	return r, nil
+}
+
+// Credentials returns the current credentials, refreshing them first if the
+// refresh interval has elapsed.
+func (r *Resolver) Credentials() Credentials {
+	r.mu.Lock()
+	if r.interval <= 0 || r.refreshing || r.now().Before(r.nextRefresh) {
+		defer r.mu.Unlock()
+		return r.credentials
+	}
+	r.refreshing = true
+	r.mu.Unlock()
+
+	credentials, err := r.resolve()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshing = false
+	if err != nil {
+		r.failures++
+		backoff := r.interval
+		if r.failures < 16 && retryBackoff<<(r.failures-1) < backoff {
+			backoff = retryBackoff << (r.failures - 1)
+		}
+		r.nextRefresh = r.now().Add(backoff)
+		r.logger.Warnf("Failed to refresh credentials, using previously resolved credentials, retrying in %v: %v", backoff, err)
+		return r.credentials
+	}
+
+	r.credentials = credentials
+	r.failures = 0
+	r.nextRefresh = r.now().Add(r.interval)
+	r.logger.Debugf("Refreshed credentials %v", credentials)
+
+	return r.credentials
+}
+
+func (r *Resolver) resolve() (Credentials, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	credentials, err := r.provider.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if credentials.IsEmpty() {
+		return Credentials{}, fmt.Errorf("secrets provider returned no credentials")
+	}
+	return credentials, nil
+}
@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type fakeProvider struct {
+	calls       int
+	credentials []Credentials
+	err         error
+}
+
+func (p *fakeProvider) Credentials(_ context.Context) (Credentials, error) {
+	defer func() { p.calls++ }()
+	if p.err != nil && p.calls > 0 {
+		return Credentials{}, p.err
+	}
+	return p.credentials[p.calls%len(p.credentials)], nil
+}
+
+func TestResolverRefresh(t *testing.T) {
+	provider := &fakeProvider{credentials: []Credentials{
+		{Username: "elastic", Password: "first"},
+		{Username: "elastic", Password: "second"},
+	}}
+
+	r, err := newResolver(provider, time.Minute, logp.NewLogger("test"))
+	require.NoError(t, err)
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	assert.Equal(t, "first", r.Credentials().Password)
+	assert.Equal(t, 1, provider.calls)
+
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "second", r.Credentials().Password)
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestResolverKeepsCredentialsOnFailure(t *testing.T) {
+	provider := &fakeProvider{
+		credentials: []Credentials{{Token: "abc"}},
+		err:         errors.New("vault sealed"),
+	}
+
+	r, err := newResolver(provider, time.Minute, logp.NewLogger("test"))
+	require.NoError(t, err)
+
+	now := time.Now().Add(2 * time.Minute)
+	r.now = func() time.Time { return now }
+
+	assert.Equal(t, "abc", r.Credentials().Token)
+	assert.Equal(t, "abc", r.Credentials().Token)
+	assert.Equal(t, 2, provider.calls)
+
+	// Refreshing is retried with a backoff after a failure.
+	now = now.Add(retryBackoff)
+	assert.Equal(t, "abc", r.Credentials().Token)
+	assert.Equal(t, 3, provider.calls)
+	now = now.Add(retryBackoff)
+	assert.Equal(t, "abc", r.Credentials().Token)
+	assert.Equal(t, 3, provider.calls)
+	now = now.Add(retryBackoff)
+	assert.Equal(t, "abc", r.Credentials().Token)
+	assert.Equal(t, 4, provider.calls)
+}
+
+func TestResolverFailsWithoutInitialCredentials(t *testing.T) {
+	provider := &fakeProvider{credentials: []Credentials{{}}}
+
+	_, err := newResolver(provider, time.Minute, logp.NewLogger("test"))
+	require.Error(t, err)
+}
+
+func TestCredentialsStringRedacted(t *testing.T) {
+	c := Credentials{Username: "elastic", Password: "s3cr3t", Token: "t0k3n"}
+	for _, s := range []string{c.String(), fmt.Sprintf("%v", c), fmt.Sprintf("%#v", c), fmt.Sprintf("%+v", c)} {
+		assert.NotContains(t, s, "elastic")
+		assert.NotContains(t, s, "s3cr3t")
+		assert.NotContains(t, s, "t0k3n")
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	tests := map[string]string{
+		"kv_v1": `{"data": {"username": "elastic", "password": "changeme"}}`,
+		"kv_v2": `{"data": {"data": {"username": "elastic", "password": "changeme"}, "metadata": {"version": 3}}}`,
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Vault-Token") != "s.root" || r.URL.Path != "/v1/secret/data/es" {
+					http.Error(w, "permission denied", http.StatusForbidden)
+					return
+				}
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.Provider = "vault"
+			config.Vault.Address = server.URL
+			config.Vault.Token = "s.root"
+			config.Vault.Path = "secret/data/es"
+
+			p, err := NewProvider(config)
+			require.NoError(t, err)
+
+			credentials, err := p.Credentials(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, Credentials{Username: "elastic", Password: "changeme"}, credentials)
+		})
+	}
+}
+
+func TestVaultProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "permission denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Provider = "vault"
+	config.Vault.Address = server.URL
+	config.Vault.Token = "s.wrong"
+	config.Vault.Path = "secret/data/es"
+
+	p, err := NewProvider(config)
+	require.NoError(t, err)
+
+	_, err = p.Credentials(context.Background())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s.wrong")
+}
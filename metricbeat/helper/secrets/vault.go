@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/vault"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func init() {
+	Register("vault", newVaultProvider)
+}
+
+// VaultConfig configures the HashiCorp Vault secrets provider.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault:8200.
+	Address string `config:"address"`
+	// Token used to authenticate against Vault. Either Token or TokenFile must be set.
+	Token string `config:"token"`
+	// TokenFile is a file from which the Vault token is read on each resolution.
+	TokenFile string `config:"token_file"`
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string `config:"namespace"`
+	// Path of the secret to read, e.g. secret/data/metricbeat/elasticsearch for a KV v2 engine.
+	Path string `config:"path"`
+
+	UsernameKey string `config:"username_key"`
+	PasswordKey string `config:"password_key"`
+	TokenKey    string `config:"token_key"`
+
+	Timeout time.Duration     `config:"timeout"`
+	TLS     *tlscommon.Config `config:"ssl"`
+}
+
+func defaultVaultConfig() VaultConfig {
+	return VaultConfig{
+		UsernameKey: "username",
+		PasswordKey: "password",
+		TokenKey:    "token",
+		Timeout:     10 * time.Second,
+	}
+}
+
+type vaultProvider struct {
+	config VaultConfig
+	client *vault.Client
+}
+
+func newVaultProvider(config Config) (Provider, error) {
+	c := config.Vault
+	if c.Address == "" {
+		return nil, errors.New("vault.address is required")
+	}
+	if c.Path == "" {
+		return nil, errors.New("vault.path is required")
+	}
+	if c.Token == "" && c.TokenFile == "" {
+		return nil, errors.New("one of vault.token or vault.token_file is required")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.TLS != nil {
+		tlsConfig, err := tlscommon.LoadTLSConfig(c.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vault.ssl configuration: %w", err)
+		}
+		transport.TLSClientConfig = tlsConfig.ToConfig()
+	}
+
+	client := vault.NewClient(vault.Config{
+		Address:   c.Address,
+		Token:     c.Token,
+		TokenFile: c.TokenFile,
+		Namespace: c.Namespace,
+	}, &http.Client{Transport: transport, Timeout: c.Timeout})
+
+	return &vaultProvider{
+		config: c,
+		client: client,
+	}, nil
+}
+
+// Credentials reads the configured secret from Vault.
+func (p *vaultProvider) Credentials(ctx context.Context) (Credentials, error) {
+	secret, err := p.client.Read(ctx, p.config.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{
+		Username: stringValue(secret.Data, p.config.UsernameKey),
+		Password: stringValue(secret.Data, p.config.PasswordKey),
+		Token:    stringValue(secret.Data, p.config.TokenKey),
+	}, nil
+}
+
+func stringValue(data map[string]interface{}, key string) string {
+	if key == "" {
+		return ""
+	}
+	s, _ := data[key].(string)
+	return s
+}
@@ -53,17 +53,27 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/dropwizard"
 	_ "github.com/elastic/beats/v7/metricbeat/module/dropwizard/collector"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/allocation_explain"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ccr"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/cluster_stats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/data_stream"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/enrich"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/health_report"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ilm"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/index"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/index_recovery"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/index_summary"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ingest_pipeline"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ml_job"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/node_stats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/node_usage"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/pending_tasks"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/remote_clusters"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/searchable_snapshots"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/shard"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/slm"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/transform"
 	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
 	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy/server"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd"
@@ -85,6 +95,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/jolokia"
 	_ "github.com/elastic/beats/v7/metricbeat/module/jolokia/jmx"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kafka"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kafka/consumer_lag"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kafka/consumergroup"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kafka/partition"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kibana"
@@ -95,6 +106,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/kibana/settings"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kibana/stats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kibana/status"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kibana/task_manager"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kvm"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kvm/dommemstat"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kvm/status"
@@ -109,10 +121,12 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node_stats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/pipeline_flow"
 	_ "github.com/elastic/beats/v7/metricbeat/module/memcached"
 	_ "github.com/elastic/beats/v7/metricbeat/module/memcached/stats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/collstats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/collstats_latency"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/dbstats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/metrics"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/replstatus"
@@ -121,11 +135,13 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/munin/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/galera_status"
+	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/group_replication"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/query"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/connection"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/connections"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nats/jetstream"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/route"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/routes"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/stats"
@@ -141,6 +157,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/activity"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/bgwriter"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/database"
+	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/replication"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/statement"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus/collector"
@@ -152,7 +169,9 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/queue"
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/shovel"
+	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/stream"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis"
+	_ "github.com/elastic/beats/v7/metricbeat/module/redis/cluster"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/info"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/key"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/keyspace"
@@ -163,10 +182,12 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/entropy"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/filesystem"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/fsstat"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/gpu"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/load"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/memory"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network_summary"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/pressure"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/process"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/process_summary"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/raid"
@@ -181,8 +202,10 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/uwsgi/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/datastore"
+	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/datastorecluster"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/host"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/virtualmachine"
+	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/vsan"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows/perfmon"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows/service"
@@ -38,6 +38,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/container"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/controllermanager"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/event"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/kubelet"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/pod"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/proxy"
@@ -46,11 +47,13 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_cronjob"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_daemonset"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_deployment"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_horizontalpodautoscaler"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_job"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_persistentvolume"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_persistentvolumeclaim"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_pod"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_poddisruptionbudget"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_replicaset"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_resourcequota"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_service"
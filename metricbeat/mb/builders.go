@@ -137,6 +137,10 @@ func initMetricSets(r *Register, m Module) ([]MetricSet, error) {
 		}
 
 		metricSet, err := registration.Factory(bm)
+		if errors.Is(err, errMetricSetDisabled) {
+			logp.Info("Metricset %v/%v is disabled by its condition", bm.Module().Name(), bm.Name())
+			continue
+		}
 		if err == nil {
 			err = mustHaveModule(metricSet, bm)
 			if err == nil {
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net"
+	"strconv"
+)
+
+// Error types used to categorize the errors of the fetches.
+const (
+	ErrorTypeAuth    = "auth"
+	ErrorTypeTimeout = "timeout"
+	ErrorTypeLicense = "license"
+	ErrorTypeParse   = "parse"
+	ErrorTypeUnknown = "unknown"
+)
+
+// FetchError is an error that occurred while fetching data, categorized with
+// a type and optionally a code.
+type FetchError struct {
+	Type string
+	Code string
+	Err  error
+}
+
+// NewFetchError creates a new FetchError of the given type.
+func NewFetchError(errType string, err error) *FetchError {
+	return &FetchError{Type: errType, Err: err}
+}
+
+func (e *FetchError) Error() string     { return e.Err.Error() }
+func (e *FetchError) Unwrap() error     { return e.Err }
+func (e *FetchError) ErrorType() string { return e.Type }
+func (e *FetchError) ErrorCode() string { return e.Code }
+
+// typedError is implemented by errors that know their type.
+type typedError interface {
+	ErrorType() string
+}
+
+// codedError is implemented by errors that have a code.
+type codedError interface {
+	ErrorCode() string
+}
+
+// ErrorType returns the category of the given error. Errors can define their
+// type by implementing an ErrorType method, otherwise it is guessed for known
+// errors of the standard library. ErrorTypeUnknown is returned if the type
+// cannot be determined.
+func ErrorType(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if typed, ok := e.(typedError); ok && typed.ErrorType() != "" {
+			return typed.ErrorType()
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorTypeTimeout
+	}
+
+	var (
+		syntaxErr    *json.SyntaxError
+		unmarshalErr *json.UnmarshalTypeError
+		xmlErr       *xml.SyntaxError
+		numErr       *strconv.NumError
+	)
+	if errors.As(err, &syntaxErr) || errors.As(err, &unmarshalErr) || errors.As(err, &xmlErr) || errors.As(err, &numErr) {
+		return ErrorTypeParse
+	}
+
+	return ErrorTypeUnknown
+}
+
+// ErrorCode returns the code of the given error, if any of the errors in its
+// chain implements an ErrorCode method.
+func ErrorCode(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if coded, ok := e.(codedError); ok && coded.ErrorCode() != "" {
+			return coded.ErrorCode()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package mb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorType(t *testing.T) {
+	_, numErr := strconv.Atoi("foo")
+	syntaxErr := json.Unmarshal([]byte("{"), &struct{}{})
+
+	cases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"unknown":          {errors.New("foo"), ErrorTypeUnknown},
+		"fetch error":      {NewFetchError(ErrorTypeLicense, errors.New("foo")), ErrorTypeLicense},
+		"wrapped":          {fmt.Errorf("bar: %w", NewFetchError(ErrorTypeAuth, errors.New("foo"))), ErrorTypeAuth},
+		"wrapped with pkg": {pkgerrors.Wrap(NewFetchError(ErrorTypeAuth, errors.New("foo")), "bar"), ErrorTypeAuth},
+		"untyped fetch":    {&FetchError{Code: "500", Err: errors.New("foo")}, ErrorTypeUnknown},
+		"deadline":         {fmt.Errorf("bar: %w", context.DeadlineExceeded), ErrorTypeTimeout},
+		"net timeout":      {timeoutError{}, ErrorTypeTimeout},
+		"json syntax":      {fmt.Errorf("bar: %w", syntaxErr), ErrorTypeParse},
+		"number":           {fmt.Errorf("bar: %w", numErr), ErrorTypeParse},
+	}
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			assert.Equal(t, c.expected, ErrorType(c.err))
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "", ErrorCode(errors.New("foo")))
+	assert.Equal(t, "", ErrorCode(NewFetchError(ErrorTypeAuth, errors.New("foo"))))
+
+	err := &FetchError{Type: ErrorTypeAuth, Code: "401", Err: errors.New("foo")}
+	assert.Equal(t, "401", ErrorCode(fmt.Errorf("bar: %w", err)))
+}
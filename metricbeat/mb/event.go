@@ -102,8 +102,12 @@ func (e *Event) BeatEvent(module, metricSet string, modifiers ...EventModifier)
 	}
 
 	if e.Error != nil {
-		b.Fields["error"] = mapstr.M{
-			"message": e.Error.Error(),
+		if errorFields, ok := b.Fields["error"].(mapstr.M); ok {
+			errorFields["message"] = e.Error.Error()
+		} else {
+			b.Fields["error"] = mapstr.M{
+				"message": e.Error.Error(),
+			}
 		}
 	}
 
@@ -171,6 +171,21 @@ func TestEventConversionToBeatEvent(t *testing.T) {
 		}
 		assert.Equal(t, msg, errorMessage)
 	})
+
+	t.Run("error message with error fields", func(t *testing.T) {
+		msg := "something failed"
+		e := (&Event{
+			RootFields: mapstr.M{
+				"error": mapstr.M{"type": ErrorTypeAuth},
+			},
+			Error: errors.New(msg),
+		}).BeatEvent(module, metricSet)
+
+		assert.Equal(t, mapstr.M{
+			"message": msg,
+			"type":    ErrorTypeAuth,
+		}, e.Fields["error"])
+	})
 }
 
 func TestAddMetricSetInfo(t *testing.T) {
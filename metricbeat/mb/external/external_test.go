@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package external
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeMetricSet struct {
+	host   string
+	config struct {
+		Namespace string `config:"namespace"`
+	}
+}
+
+func newFakeMetricSet(host string, config *conf.C) (MetricSet, error) {
+	m := &fakeMetricSet{host: host}
+	if err := config.Unpack(&m.config); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *fakeMetricSet) Fetch(_ context.Context, r mb.ReporterV2) error {
+	r.Event(mb.Event{
+		Timestamp: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+		MetricSetFields: mapstr.M{
+			"host":      m.host,
+			"namespace": m.config.Namespace,
+			"requests": mapstr.M{
+				"count": int64(9007199254740993),
+				"rate":  0.5,
+			},
+			"ports": []int{80, 443},
+		},
+	})
+	r.Error(errors.New("partial failure"))
+	return nil
+}
+
+func newTestClient(t *testing.T) *client {
+	factories := map[string]Factory{"fake": newFakeMetricSet}
+	pluginClient, _ := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		pluginName: &metricSetPlugin{module: "test", factories: factories},
+	})
+	t.Cleanup(func() { pluginClient.Close() })
+
+	raw, err := pluginClient.Dispense(pluginName)
+	require.NoError(t, err)
+	return raw.(*client)
+}
+
+func TestDescribe(t *testing.T) {
+	c := newTestClient(t)
+
+	d, err := c.Describe(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, description{Module: "test", MetricSets: []string{"fake"}}, d)
+}
+
+func TestCreateUnknownMetricSet(t *testing.T) {
+	c := newTestClient(t)
+
+	err := c.Create(context.Background(), createRequest{MetricSet: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestFetchBeforeCreate(t *testing.T) {
+	c := newTestClient(t)
+
+	_, err := c.Fetch(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFetch(t *testing.T) {
+	c := newTestClient(t)
+
+	err := c.Create(context.Background(), createRequest{
+		MetricSet: "fake",
+		Host:      "localhost:1234",
+		Config:    mapstr.M{"namespace": "foo"},
+	})
+	require.NoError(t, err)
+
+	resp, err := c.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 2)
+	assert.Empty(t, resp.Error)
+
+	event := resp.Events[0].mbEvent()
+	assert.NoError(t, event.Error)
+	assert.Equal(t, time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC), event.Timestamp)
+	assert.Equal(t, mapstr.M{
+		"host":      "localhost:1234",
+		"namespace": "foo",
+		"requests": mapstr.M{
+			"count": int64(9007199254740993),
+			"rate":  0.5,
+		},
+		"ports": []interface{}{int64(80), int64(443)},
+	}, event.MetricSetFields)
+
+	event = resp.Events[1].mbEvent()
+	assert.EqualError(t, event.Error, "partial failure")
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("directory does not exist", func(t *testing.T) {
+		r := mb.NewRegister()
+		err := Load(filepath.Join(t.TempDir(), "plugins"), r)
+		assert.NoError(t, err)
+		assert.Empty(t, r.Modules())
+	})
+
+	t.Run("not executable files are ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0644)
+		require.NoError(t, err)
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0755))
+
+		r := mb.NewRegister()
+		err = Load(dir, r)
+		assert.NoError(t, err)
+		assert.Empty(t, r.Modules())
+	})
+	t.Run("plugins writable by others are not run", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("permissions are not checked on windows")
+		}
+		dir := t.TempDir()
+		marker := filepath.Join(dir, "started")
+		pluginPath := filepath.Join(dir, "plugin")
+		script := "#!/bin/sh\ntouch " + marker + "\n"
+		require.NoError(t, ioutil.WriteFile(pluginPath, []byte(script), 0755))
+		require.NoError(t, os.Chmod(pluginPath, 0775))
+
+		r := mb.NewRegister()
+		err := Load(dir, r)
+		assert.NoError(t, err)
+		assert.Empty(t, r.Modules())
+		assert.NoFileExists(t, marker)
+	})
+
+	t.Run("directory writable by others is rejected", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("permissions are not checked on windows")
+		}
+		dir := t.TempDir()
+		require.NoError(t, os.Chmod(dir, 0777))
+
+		r := mb.NewRegister()
+		err := Load(dir, r)
+		assert.Error(t, err)
+	})
+}
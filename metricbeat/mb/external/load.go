@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Load registers the metricsets served by the plugins found in the given
+// directory. Plugins that cannot be started are skipped. It is not an error
+// if the directory doesn't exist.
+//
+// When strict permissions are enabled, the directory and the plugins must be
+// owned by the current user or root and only be writable by their owner, as
+// it is required for configuration files.
+func Load(path string, r *mb.Register) error {
+	files, err := ioutil.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+	if err := checkPerms(path); err != nil {
+		return fmt.Errorf("plugins directory rejected: %w", err)
+	}
+
+	logger := logp.NewLogger("external")
+	for _, file := range files {
+		if !isExecutable(file) {
+			continue
+		}
+
+		pluginPath := filepath.Join(path, file.Name())
+		if err := checkPerms(pluginPath); err != nil {
+			logger.Errorf("Plugin %s rejected: %v", pluginPath, err)
+			continue
+		}
+		if err := register(pluginPath, r, logger); err != nil {
+			logger.Errorf("Failed to load plugin %s: %v", pluginPath, err)
+		}
+	}
+	return nil
+}
+
+// register starts the plugin to get its metricsets and registers them.
+func register(path string, r *mb.Register, logger *logp.Logger) error {
+	p, c, err := startPlugin(path, logger)
+	if err != nil {
+		return err
+	}
+	defer p.Kill()
+
+	d, err := c.Describe(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to describe plugin: %w", err)
+	}
+
+	cfgwarn.Beta("The %s module is served by an external plugin, external plugins are beta.", d.Module)
+
+	factory := newMetricSetFactory(path)
+	for _, name := range d.MetricSets {
+		if err := r.AddMetricSet(d.Module, name, factory); err != nil {
+			logger.Errorf("Failed to register metricset %s/%s from plugin %s: %v", d.Module, name, path, err)
+			continue
+		}
+		logger.Infof("Registered metricset %s/%s from plugin %s", d.Module, name, path)
+	}
+	return nil
+}
+
+// checkPerms applies the permission checks done on configuration files.
+func checkPerms(path string) error {
+	if !common.IsStrictPerms() {
+		return nil
+	}
+	return common.OwnerHasExclusiveWritePerms(path)
+}
+
+func isExecutable(file os.FileInfo) bool {
+	if !file.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Ext(file.Name()) == ".exe"
+	}
+	return file.Mode().Perm()&0111 != 0
+}
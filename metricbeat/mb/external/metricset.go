@@ -0,0 +1,211 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// metricSet is a metricset whose data is collected by a plugin.
+type metricSet struct {
+	mb.BaseMetricSet
+	path   string
+	config mapstr.M
+
+	mutex  sync.Mutex
+	plugin *plugin.Client
+	client *client
+}
+
+// newMetricSetFactory returns the factory of the metricsets served by the
+// plugin in the given path.
+func newMetricSetFactory(path string) mb.MetricSetFactory {
+	return func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		m := &metricSet{
+			BaseMetricSet: base,
+			path:          path,
+		}
+		if err := base.Module().UnpackConfig(&m.config); err != nil {
+			return nil, err
+		}
+		if err := m.start(); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+// start starts a new plugin process and creates the metricset on it.
+func (m *metricSet) start() error {
+	p, c, err := startPlugin(m.path, m.Logger())
+	if err != nil {
+		return err
+	}
+
+	req := createRequest{
+		MetricSet: m.Name(),
+		Host:      m.Host(),
+		Config:    m.config,
+	}
+	if err := c.Create(context.Background(), req); err != nil {
+		p.Kill()
+		return fmt.Errorf("failed to create metricset in plugin %s: %w", m.path, err)
+	}
+
+	m.plugin, m.client = p, c
+	return nil
+}
+
+// Fetch requests the plugin to collect the data of the metricset and reports
+// the received events. The plugin is restarted if it has exited.
+func (m *metricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.plugin.Exited() {
+		m.Logger().Warnf("Plugin %s has exited, restarting it", m.path)
+		if err := m.start(); err != nil {
+			return err
+		}
+	}
+
+	resp, err := m.client.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch from plugin %s failed: %w", m.path, err)
+	}
+
+	for _, e := range resp.Events {
+		if !reporter.Event(e.mbEvent()) {
+			return nil
+		}
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// Close stops the plugin process.
+func (m *metricSet) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.plugin.Kill()
+	return nil
+}
+
+// startPlugin starts the plugin in the given path and connects to it.
+func startPlugin(path string, logger *logp.Logger) (*plugin.Client, *client, error) {
+	p := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          plugin.PluginSet{pluginName: &metricSetPlugin{}},
+		Cmd:              exec.Command(path),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Name:        filepath.Base(path),
+			Level:       hclog.Debug,
+			Output:      logWriter{logger},
+			DisableTime: true,
+		}),
+	})
+
+	rpcClient, err := p.Client()
+	if err != nil {
+		p.Kill()
+		return nil, nil, fmt.Errorf("failed to start plugin %s: %w", path, err)
+	}
+
+	raw, err := rpcClient.Dispense(pluginName)
+	if err != nil {
+		p.Kill()
+		return nil, nil, fmt.Errorf("failed to connect to plugin %s: %w", path, err)
+	}
+
+	return p, raw.(*client), nil
+}
+
+// logWriter forwards the logs of the plugins to the logger of Metricbeat.
+type logWriter struct {
+	logger *logp.Logger
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.logger.Debug(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+// mbEvent converts the event received from a plugin to an mb.Event.
+func (e event) mbEvent() mb.Event {
+	event := mb.Event{
+		Timestamp:       e.Timestamp,
+		ID:              e.ID,
+		Namespace:       e.Namespace,
+		RootFields:      normalizeNumbers(e.RootFields),
+		ModuleFields:    normalizeNumbers(e.ModuleFields),
+		MetricSetFields: normalizeNumbers(e.MetricSetFields),
+	}
+	if e.Error != "" {
+		event.Error = errors.New(e.Error)
+	}
+	return event
+}
+
+// normalizeNumbers converts the numbers decoded as json.Number to integers
+// when possible, or to floats otherwise.
+func normalizeNumbers(m mapstr.M) mapstr.M {
+	for k, v := range m {
+		m[k] = normalizeValue(v)
+	}
+	return m
+}
+
+func normalizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		return normalizeNumbers(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeValue(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,203 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package external allows to run metricsets implemented out of process, as
+// plugins that communicate with Metricbeat over gRPC.
+//
+// A plugin is an executable placed in the plugins directory that serves one
+// module with one or more metricsets by calling Serve. Metricbeat starts each
+// plugin once on startup to register its metricsets, and then starts a new
+// plugin process for each one of the configured metricset and host
+// combinations.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// pluginName is the name the metricsets of a plugin are served with.
+const pluginName = "metricset"
+
+// Handshake is used by Metricbeat and the plugins to verify that they are
+// compatible. It must be changed on incompatible changes of the protocol.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "METRICBEAT_PLUGIN",
+	MagicCookieValue: "metricset",
+}
+
+// description contains the metricsets served by a plugin.
+type description struct {
+	Module     string   `json:"module"`
+	MetricSets []string `json:"metricsets"`
+}
+
+// createRequest contains the configuration of a new metricset instance.
+type createRequest struct {
+	MetricSet string   `json:"metricset"`
+	Host      string   `json:"host"`
+	Config    mapstr.M `json:"config"`
+}
+
+// event is the representation of an mb.Event in the protocol.
+type event struct {
+	Timestamp       time.Time `json:"timestamp"`
+	ID              string    `json:"id,omitempty"`
+	Namespace       string    `json:"namespace,omitempty"`
+	RootFields      mapstr.M  `json:"root_fields,omitempty"`
+	ModuleFields    mapstr.M  `json:"module_fields,omitempty"`
+	MetricSetFields mapstr.M  `json:"metricset_fields,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// fetchResponse contains the events reported by the metricset of a plugin
+// in a fetch.
+type fetchResponse struct {
+	Events []event `json:"events"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// metricSetRPC is the interface of the metricsets served by the plugins.
+// Messages are JSON documents wrapped in protobuf values.
+type metricSetRPC interface {
+	Describe(context.Context, *emptypb.Empty) (*wrapperspb.BytesValue, error)
+	Create(context.Context, *wrapperspb.BytesValue) (*emptypb.Empty, error)
+	Fetch(context.Context, *emptypb.Empty) (*wrapperspb.BytesValue, error)
+}
+
+var metricSetServiceDesc = grpc.ServiceDesc{
+	ServiceName: "metricbeat.external.MetricSet",
+	HandlerType: (*metricSetRPC)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(metricSetRPC).Describe(ctx, req.(*emptypb.Empty))
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/metricbeat.external.MetricSet/Describe"}, handler)
+			},
+		},
+		{
+			MethodName: "Create",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(wrapperspb.BytesValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(metricSetRPC).Create(ctx, req.(*wrapperspb.BytesValue))
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/metricbeat.external.MetricSet/Create"}, handler)
+			},
+		},
+		{
+			MethodName: "Fetch",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(metricSetRPC).Fetch(ctx, req.(*emptypb.Empty))
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/metricbeat.external.MetricSet/Fetch"}, handler)
+			},
+		},
+	},
+}
+
+// metricSetPlugin implements plugin.GRPCPlugin for the metricsets of a
+// module. Factories are only needed on the plugin side.
+type metricSetPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	module    string
+	factories map[string]Factory
+}
+
+func (p *metricSetPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	s.RegisterService(&metricSetServiceDesc, newServer(p.module, p.factories))
+	return nil
+}
+
+func (p *metricSetPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &client{conn: conn}, nil
+}
+
+// client is used by Metricbeat to call the metricsets served by a plugin.
+type client struct {
+	conn *grpc.ClientConn
+}
+
+// Describe returns the module and metricsets served by the plugin.
+func (c *client) Describe(ctx context.Context) (description, error) {
+	var d description
+	out := new(wrapperspb.BytesValue)
+	if err := c.conn.Invoke(ctx, "/metricbeat.external.MetricSet/Describe", &emptypb.Empty{}, out); err != nil {
+		return d, err
+	}
+	err := json.Unmarshal(out.Value, &d)
+	return d, err
+}
+
+// Create creates the metricset instance that is used by the following fetches.
+func (c *client) Create(ctx context.Context, req createRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return c.conn.Invoke(ctx, "/metricbeat.external.MetricSet/Create", wrapperspb.Bytes(data), new(emptypb.Empty))
+}
+
+// Fetch runs a fetch in the metricset instance and returns the reported events.
+func (c *client) Fetch(ctx context.Context) (fetchResponse, error) {
+	var resp fetchResponse
+	out := new(wrapperspb.BytesValue)
+	if err := c.conn.Invoke(ctx, "/metricbeat.external.MetricSet/Fetch", &emptypb.Empty{}, out); err != nil {
+		return resp, err
+	}
+
+	// Decode numbers as json.Number so integers are not converted to floats.
+	dec := json.NewDecoder(bytes.NewReader(out.Value))
+	dec.UseNumber()
+	err := dec.Decode(&resp)
+	return resp, err
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// MetricSet is the interface that must be implemented by the metricsets
+// served by plugins.
+type MetricSet interface {
+	// Fetch collects the data of the metricset, and reports it as events
+	// through the reporter.
+	Fetch(ctx context.Context, reporter mb.ReporterV2) error
+}
+
+// Factory creates a new instance of a metricset for the given host. The
+// configuration contains the settings of the module.
+type Factory func(host string, config *conf.C) (MetricSet, error)
+
+// Serve serves the metricsets of the given module. It must be called from the
+// main function of the plugin, and it blocks until Metricbeat stops the plugin.
+func Serve(module string, factories map[string]Factory) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: plugin.PluginSet{
+			pluginName: &metricSetPlugin{module: module, factories: factories},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
+
+// server implements the metricSetRPC interface on the plugin side.
+type server struct {
+	module    string
+	factories map[string]Factory
+
+	mutex     sync.Mutex
+	metricSet MetricSet
+}
+
+func newServer(module string, factories map[string]Factory) *server {
+	return &server{module: module, factories: factories}
+}
+
+func (s *server) Describe(_ context.Context, _ *emptypb.Empty) (*wrapperspb.BytesValue, error) {
+	d := description{Module: s.module}
+	for name := range s.factories {
+		d.MetricSets = append(d.MetricSets, name)
+	}
+	sort.Strings(d.MetricSets)
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.Bytes(data), nil
+}
+
+func (s *server) Create(_ context.Context, in *wrapperspb.BytesValue) (*emptypb.Empty, error) {
+	var req createRequest
+	if err := json.Unmarshal(in.Value, &req); err != nil {
+		return nil, fmt.Errorf("invalid create request: %w", err)
+	}
+
+	factory, found := s.factories[req.MetricSet]
+	if !found {
+		return nil, fmt.Errorf("metricset '%s/%s' is not served by this plugin", s.module, req.MetricSet)
+	}
+
+	config, err := conf.NewConfigFrom(req.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	metricSet, err := factory(req.Host, config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.metricSet = metricSet
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) Fetch(ctx context.Context, _ *emptypb.Empty) (*wrapperspb.BytesValue, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.metricSet == nil {
+		return nil, errors.New("metricset has not been created")
+	}
+
+	var r eventCollector
+	if err := s.metricSet.Fetch(ctx, &r); err != nil {
+		r.resp.Error = err.Error()
+	}
+
+	data, err := json.Marshal(r.resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode events: %w", err)
+	}
+	return wrapperspb.Bytes(data), nil
+}
+
+// eventCollector implements mb.ReporterV2 to collect the events reported
+// during a fetch.
+type eventCollector struct {
+	resp fetchResponse
+}
+
+func (r *eventCollector) Event(e mb.Event) bool {
+	ev := event{
+		Timestamp:       e.Timestamp,
+		ID:              e.ID,
+		Namespace:       e.Namespace,
+		RootFields:      e.RootFields,
+		ModuleFields:    e.ModuleFields,
+		MetricSetFields: e.MetricSetFields,
+	}
+	if e.Error != nil {
+		ev.Error = e.Error.Error()
+	}
+	r.resp.Events = append(r.resp.Events, ev)
+	return true
+}
+
+func (r *eventCollector) Error(err error) bool {
+	return r.Event(mb.Event{Error: err})
+}
@@ -18,12 +18,21 @@
 package mb
 
 import (
+	"bytes"
+	"text/template"
+
 	"github.com/pkg/errors"
 
+	"github.com/elastic/beats/v7/libbeat/conditions"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
+// errMetricSetDisabled is returned by the factory of light metric sets whose
+// condition is not met by the module configuration.
+var errMetricSetDisabled = errors.New("light metricset disabled by condition")
+
 // LightMetricSet contains the definition of a non-registered metric set
 type LightMetricSet struct {
 	Name    string
@@ -35,6 +44,14 @@ type LightMetricSet struct {
 		Defaults  interface{} `config:"defaults"`
 	} `config:"input" validate:"required"`
 	Processors processors.PluginConfig `config:"processors"`
+
+	// Condition is evaluated against the module configuration, the metric set
+	// is only enabled if it matches.
+	Condition *conditions.Config `config:"condition"`
+
+	// ProcessorsTemplate is a Go template that, rendered with the module
+	// configuration, produces a YAML list of additional processors.
+	ProcessorsTemplate string `config:"processors_template"`
 }
 
 // Registration obtains a metric set registration for this light metric set, this registration
@@ -67,7 +84,20 @@ func (m *LightMetricSet) Registration(r *Register) (MetricSetRegistration, error
 	registration.Factory = func(base BaseMetricSet) (MetricSet, error) {
 		// Override default config on base module and metricset
 		base.name = m.Name
-		baseModule, err := m.baseModule(base.module)
+		rawConfig, err := m.config(base.module.UnpackConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read configuration for light metricset '%s/%s'", m.Module, m.Name)
+		}
+
+		enabled, err := m.enabled(rawConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate condition of light metricset '%s/%s'", m.Module, m.Name)
+		}
+		if !enabled {
+			return nil, errMetricSetDisabled
+		}
+
+		baseModule, err := m.baseModule(rawConfig)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to create base module for light module '%s', using base module '%s'", m.Module, base.module.Name())
 		}
@@ -99,9 +129,9 @@ func (m *LightMetricSet) Registration(r *Register) (MetricSetRegistration, error
 	return registration, nil
 }
 
-// baseModule does the configuration overrides in the base module configuration
-// taking into account the light metric set default configurations
-func (m *LightMetricSet) baseModule(from Module) (*BaseModule, error) {
+// config obtains the configuration of the light metric set, applying the
+// user configuration over the input defaults
+func (m *LightMetricSet) config(unpack func(interface{}) error) (*conf.C, error) {
 	// Initialize config using input defaults as raw config
 	rawConfig, err := conf.NewConfigFrom(m.Input.Defaults)
 	if err != nil {
@@ -109,10 +139,83 @@ func (m *LightMetricSet) baseModule(from Module) (*BaseModule, error) {
 	}
 
 	// Copy values from user configuration
-	if err = from.UnpackConfig(rawConfig); err != nil {
+	if err = unpack(rawConfig); err != nil {
 		return nil, errors.Wrap(err, "failed to copy values from user configuration")
 	}
 
+	return rawConfig, nil
+}
+
+// enabled checks if the condition of the light metric set, if any, is met by
+// the given configuration
+func (m *LightMetricSet) enabled(rawConfig *conf.C) (bool, error) {
+	if m.Condition == nil {
+		return true, nil
+	}
+
+	condition, err := conditions.NewCondition(m.Condition)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid condition")
+	}
+
+	var values mapstr.M
+	if err := rawConfig.Unpack(&values); err != nil {
+		return false, err
+	}
+
+	return condition.Check(values), nil
+}
+
+// processors returns the configuration of the processors of the light metric
+// set, including the ones obtained by rendering the processors template with
+// the given module configuration
+func (m *LightMetricSet) processorsConfig(moduleConfig *conf.C) (processors.PluginConfig, error) {
+	if m.ProcessorsTemplate == "" {
+		return m.Processors, nil
+	}
+
+	unpack := func(interface{}) error { return nil }
+	if moduleConfig != nil {
+		unpack = moduleConfig.Unpack
+	}
+	rawConfig, err := m.config(unpack)
+	if err != nil {
+		return nil, err
+	}
+
+	var values mapstr.M
+	if err := rawConfig.Unpack(&values); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(m.Name).Option("missingkey=zero").Parse(m.ProcessorsTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid processors template")
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values); err != nil {
+		return nil, errors.Wrap(err, "failed to render processors template")
+	}
+
+	renderedConfig, err := conf.NewConfigWithYAML(rendered.Bytes(), "processors template")
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid rendered processors template")
+	}
+
+	var templated processors.PluginConfig
+	if err := renderedConfig.Unpack(&templated); err != nil {
+		return nil, errors.Wrap(err, "invalid processors in rendered template")
+	}
+
+	procs := make(processors.PluginConfig, 0, len(m.Processors)+len(templated))
+	procs = append(procs, m.Processors...)
+	return append(procs, templated...), nil
+}
+
+// baseModule creates the base module of the light metric set from the
+// resulting configuration
+func (m *LightMetricSet) baseModule(rawConfig *conf.C) (*BaseModule, error) {
 	// Create the base module
 	baseModule, err := newBaseModuleFromConfig(rawConfig)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -159,8 +160,9 @@ type lightModuleConfig struct {
 	MetricSets []string `config:"metricsets"`
 }
 
-// ProcessorsForMetricSet returns processors defined for the light metricset.
-func (s *LightModulesSource) ProcessorsForMetricSet(r *Register, moduleName string, metricSetName string) (*processors.Processors, error) {
+// ProcessorsForMetricSet returns processors defined for the light metricset,
+// templated processors are rendered using the given module configuration.
+func (s *LightModulesSource) ProcessorsForMetricSet(r *Register, moduleName string, metricSetName string, config *conf.C) (*processors.Processors, error) {
 	module, err := s.loadModule(r, moduleName)
 	if err != nil {
 		return nil, errors.Wrapf(err, "reading processors for metricset '%s' in module '%s'", metricSetName, moduleName)
@@ -169,7 +171,11 @@ func (s *LightModulesSource) ProcessorsForMetricSet(r *Register, moduleName stri
 	if !ok {
 		return nil, fmt.Errorf("unknown metricset '%s' in module '%s'", metricSetName, moduleName)
 	}
-	return processors.New(metricSet.Processors)
+	procsConfig, err := metricSet.processorsConfig(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading processors for metricset '%s' in module '%s'", metricSetName, moduleName)
+	}
+	return processors.New(procsConfig)
 }
 
 // LightModule contains the definition of a light module
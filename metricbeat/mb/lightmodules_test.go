@@ -28,6 +28,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
+	_ "github.com/elastic/beats/v7/libbeat/processors/actions"
 	_ "github.com/elastic/beats/v7/libbeat/processors/add_id"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -402,10 +404,104 @@ func TestNewModulesCallModuleFactory(t *testing.T) {
 	assert.True(t, called, "module factory must be called if registered")
 }
 
+func TestNewModuleConditionalMetricSets(t *testing.T) {
+	logp.TestingSetup()
+
+	cases := map[string]struct {
+		config             mapstr.M
+		expectedMetricSets []string
+	}{
+		"condition not met": {
+			config:             mapstr.M{"module": "conditional"},
+			expectedMetricSets: []string{"always"},
+		},
+		"condition met": {
+			config:             mapstr.M{"module": "conditional", "optional_api": true},
+			expectedMetricSets: []string{"always", "optional"},
+		},
+		"condition not met with explicit metricsets": {
+			config:             mapstr.M{"module": "conditional", "metricsets": []string{"always", "optional"}, "optional_api": false},
+			expectedMetricSets: []string{"always"},
+		},
+	}
+
+	r := NewRegister()
+	r.MustAddMetricSet("foo", "bar", newMetricSetWithOption)
+	r.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			config, err := conf.NewConfigFrom(c.config)
+			require.NoError(t, err)
+
+			_, metricSets, err := NewModule(config, r)
+			require.NoError(t, err)
+
+			var names []string
+			for _, ms := range metricSets {
+				names = append(names, ms.Name())
+			}
+			assert.ElementsMatch(t, c.expectedMetricSets, names)
+		})
+	}
+}
+
+func TestProcessorsForMetricSet_ProcessorsTemplate(t *testing.T) {
+	cases := map[string]struct {
+		config       mapstr.M
+		expectedLen  int
+		expectedTags []string
+		expected     string
+	}{
+		"without configuration": {
+			expectedLen: 2,
+			expected:    "test",
+		},
+		"with configuration": {
+			config:       mapstr.M{"option": "overriden", "optional_api": true},
+			expectedLen:  3,
+			expectedTags: []string{"optional"},
+			expected:     "overriden",
+		},
+	}
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			var config *conf.C
+			if c.config != nil {
+				var err error
+				config, err = conf.NewConfigFrom(c.config)
+				require.NoError(t, err)
+			}
+
+			r := NewRegister()
+			source := NewLightModulesSource("testdata/lightmodules")
+			procs, err := source.ProcessorsForMetricSet(r, "conditional", "always", config)
+			require.NoError(t, err)
+			require.NotNil(t, procs)
+			require.Len(t, procs.List, c.expectedLen)
+
+			event, err := procs.Run(&beat.Event{Fields: mapstr.M{}})
+			require.NoError(t, err)
+
+			option, err := event.GetValue("service.option")
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, option)
+
+			tags, _ := event.GetValue("tags")
+			if len(c.expectedTags) > 0 {
+				assert.Equal(t, c.expectedTags, tags)
+			} else {
+				assert.Nil(t, tags)
+			}
+		})
+	}
+}
+
 func TestProcessorsForMetricSet_UnknownModule(t *testing.T) {
 	r := NewRegister()
 	source := NewLightModulesSource("testdata/lightmodules")
-	procs, err := source.ProcessorsForMetricSet(r, "nonexisting", "fake")
+	procs, err := source.ProcessorsForMetricSet(r, "nonexisting", "fake", nil)
 	require.Error(t, err)
 	require.Nil(t, procs)
 }
@@ -413,7 +509,7 @@ func TestProcessorsForMetricSet_UnknownModule(t *testing.T) {
 func TestProcessorsForMetricSet_UnknownMetricSet(t *testing.T) {
 	r := NewRegister()
 	source := NewLightModulesSource("testdata/lightmodules")
-	procs, err := source.ProcessorsForMetricSet(r, "unpack", "nonexisting")
+	procs, err := source.ProcessorsForMetricSet(r, "unpack", "nonexisting", nil)
 	require.Error(t, err)
 	require.Nil(t, procs)
 }
@@ -421,7 +517,7 @@ func TestProcessorsForMetricSet_UnknownMetricSet(t *testing.T) {
 func TestProcessorsForMetricSet_ProcessorsRead(t *testing.T) {
 	r := NewRegister()
 	source := NewLightModulesSource("testdata/lightmodules")
-	procs, err := source.ProcessorsForMetricSet(r, "unpack", "withprocessors")
+	procs, err := source.ProcessorsForMetricSet(r, "unpack", "withprocessors", nil)
 	require.NoError(t, err)
 	require.NotNil(t, procs)
 	require.Len(t, procs.List, 1)
@@ -438,6 +534,7 @@ func TestProcessorsForMetricSet_ListModules(t *testing.T) {
 
 	expectedModules := []string{
 		"broken",
+		"conditional",
 		"httpextended",
 		"mixed",
 		"mixedbroken",
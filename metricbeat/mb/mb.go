@@ -60,6 +60,11 @@ const (
 	RTTKey string = "_rtt"
 )
 
+// ErrTargetOverloaded is used in errors that indicate that the monitored
+// service is overloaded, as when it responds with 429 or 503 HTTP status
+// codes. It can be checked with errors.Is.
+var ErrTargetOverloaded = errors.New("target overloaded")
+
 // Module interfaces
 
 // Module is the common interface for all Module implementations.
@@ -374,6 +379,30 @@ type ModuleConfig struct {
 	Raw         bool          `config:"raw"`
 	Query       QueryParams   `config:"query"`
 	ServiceName string        `config:"service.name"`
+
+	// MaxConcurrentFetchesPerHost limits the number of fetches of the
+	// metricsets of the module that can run at the same time against the
+	// same host. Zero means no limit.
+	MaxConcurrentFetchesPerHost int `config:"max_concurrent_fetches_per_host" validate:"min=0"`
+
+	Backoff BackoffConfig `config:"backoff"`
+
+	// ErrorEvents enables the categorization of the errors of the fetches in
+	// the error.type and error.code fields of the reported error events.
+	ErrorEvents bool `config:"error_events"`
+}
+
+// BackoffConfig contains the configuration of the adaptive backoff of the
+// fetches of a module. When enabled, the interval between fetches is increased
+// when the monitored service is overloaded or fetches take longer than the
+// period, up to MaxPeriod, and decreased back to the period when fetches are
+// healthy again.
+type BackoffConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// MaxPeriod is the maximum interval between fetches, it defaults to ten
+	// times the period.
+	MaxPeriod time.Duration `config:"max_period" validate:"min=0"`
 }
 
 func (c ModuleConfig) String() string {
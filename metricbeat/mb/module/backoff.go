@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// defaultMaxPeriodFactor is used to calculate the maximum interval between
+// fetches when no max_period is configured.
+const defaultMaxPeriodFactor = 10
+
+// degradedStatus is the value of the metricset.status field of the events
+// collected while the interval between fetches is stretched.
+const degradedStatus = "degraded"
+
+// backoff adapts the interval between the fetches of a metricset. The interval
+// is doubled when the monitored service reports that it is overloaded or when
+// a fetch takes longer than the period, and it is halved back to the period
+// after every healthy fetch.
+type backoff struct {
+	name      string
+	period    time.Duration
+	maxPeriod time.Duration
+
+	mutex      sync.Mutex
+	interval   time.Duration
+	overloaded bool
+}
+
+func newBackoff(name string, period time.Duration, config mb.BackoffConfig) *backoff {
+	maxPeriod := config.MaxPeriod
+	if maxPeriod <= 0 {
+		maxPeriod = defaultMaxPeriodFactor * period
+	}
+	if maxPeriod < period {
+		maxPeriod = period
+	}
+	return &backoff{
+		name:      name,
+		period:    period,
+		maxPeriod: maxPeriod,
+		interval:  period,
+	}
+}
+
+// Interval returns the current interval between fetches.
+func (b *backoff) Interval() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.interval
+}
+
+// Degraded returns true if the interval between fetches is stretched.
+func (b *backoff) Degraded() bool {
+	return b.Interval() > b.period
+}
+
+// markOverloaded records that the monitored service reported that it is
+// overloaded during the current fetch.
+func (b *backoff) markOverloaded() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.overloaded = true
+}
+
+// update calculates the interval until the next fetch, given the duration of
+// the last one.
+func (b *backoff) update(took time.Duration) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	previous := b.interval
+	if b.overloaded || took > b.period {
+		b.interval *= 2
+		if b.interval > b.maxPeriod {
+			b.interval = b.maxPeriod
+		}
+	} else {
+		b.interval /= 2
+		if b.interval < b.period {
+			b.interval = b.period
+		}
+	}
+	b.overloaded = false
+
+	switch {
+	case b.interval > previous:
+		logp.Info("Increasing interval between fetches of %s to %v", b.name, b.interval)
+	case b.interval < previous && b.interval == b.period:
+		logp.Info("Fetches of %s are healthy again, restoring interval to %v", b.name, b.interval)
+	}
+	return b.interval
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestBackoff(t *testing.T) {
+	period := 10 * time.Second
+	b := newBackoff("test", period, mb.BackoffConfig{Enabled: true, MaxPeriod: 35 * time.Second})
+
+	assert.Equal(t, period, b.Interval())
+	assert.False(t, b.Degraded())
+
+	// Healthy fetches keep the period.
+	assert.Equal(t, period, b.update(time.Second))
+
+	// Overloaded or slow fetches stretch the interval up to the max period.
+	b.markOverloaded()
+	assert.Equal(t, 20*time.Second, b.update(time.Second))
+	assert.True(t, b.Degraded())
+	assert.Equal(t, 35*time.Second, b.update(15*time.Second))
+	b.markOverloaded()
+	assert.Equal(t, 35*time.Second, b.update(time.Second))
+
+	// Healthy fetches decay the interval back to the period.
+	assert.Equal(t, 17500*time.Millisecond, b.update(time.Second))
+	assert.Equal(t, period, b.update(time.Second))
+	assert.False(t, b.Degraded())
+}
+
+func TestBackoffDefaultMaxPeriod(t *testing.T) {
+	period := time.Second
+	b := newBackoff("test", period, mb.BackoffConfig{Enabled: true})
+
+	for i := 0; i < 10; i++ {
+		b.markOverloaded()
+		b.update(0)
+	}
+	assert.Equal(t, defaultMaxPeriodFactor*period, b.Interval())
+}
@@ -50,7 +50,7 @@ type connectorConfig struct {
 }
 
 type metricSetRegister interface {
-	ProcessorsForMetricSet(moduleName, metricSetName string) (*processors.Processors, error)
+	ProcessorsForMetricSet(moduleName, metricSetName string, config *conf.C) (*processors.Processors, error)
 }
 
 func NewConnector(
@@ -77,8 +77,8 @@ func NewConnector(
 }
 
 // UseMetricSetProcessors appends processors defined in metricset configuration to the connector properties.
-func (c *Connector) UseMetricSetProcessors(r metricSetRegister, moduleName, metricSetName string) error {
-	metricSetProcessors, err := r.ProcessorsForMetricSet(moduleName, metricSetName)
+func (c *Connector) UseMetricSetProcessors(r metricSetRegister, moduleName, metricSetName string, config *conf.C) error {
+	metricSetProcessors, err := r.ProcessorsForMetricSet(moduleName, metricSetName, config)
 	if err != nil {
 		return errors.Wrapf(err, "reading metricset processors failed (module: %s, metricset: %s)",
 			moduleName, metricSetName)
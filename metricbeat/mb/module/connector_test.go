@@ -99,7 +99,7 @@ type fakeMetricSetRegister struct {
 	success bool
 }
 
-func (fmsr *fakeMetricSetRegister) ProcessorsForMetricSet(moduleName, metricSetName string) (*processors.Processors, error) {
+func (fmsr *fakeMetricSetRegister) ProcessorsForMetricSet(moduleName, metricSetName string, config *conf.C) (*processors.Processors, error) {
 	if !fmsr.success {
 		return nil, errors.New("failure")
 	}
@@ -113,7 +113,7 @@ func TestUseMetricSetProcessors_ReadingProcessorsFailed(t *testing.T) {
 	r := new(fakeMetricSetRegister)
 
 	var connector Connector
-	err := connector.UseMetricSetProcessors(r, "module", "metricset")
+	err := connector.UseMetricSetProcessors(r, "module", "metricset", nil)
 	require.Error(t, err)
 	require.Nil(t, connector.processors)
 }
@@ -128,7 +128,7 @@ func TestUseMetricSetProcessors_ReadingProcessorsSucceeded(t *testing.T) {
 			List: []processors.Processor{},
 		},
 	}
-	err := connector.UseMetricSetProcessors(r, "module", "metricset")
+	err := connector.UseMetricSetProcessors(r, "module", "metricset", nil)
 	require.NoError(t, err)
 	require.Len(t, connector.processors.List, 2)
 }
@@ -46,9 +46,16 @@ func (r *Factory) Create(p beat.PipelineConnector, c *conf.C) (cfgfile.Runner, e
 		return nil, err
 	}
 
+	// Limits of concurrent fetches per host are shared by all the metricsets
+	// of the module.
+	options := r.options
+	if hostLimits := newHostLimits(module.Config().MaxConcurrentFetchesPerHost, metricSets); hostLimits != nil {
+		options = append(options[:len(options):len(options)], withHostLimits(hostLimits))
+	}
+
 	var runners []Runner
 	for _, metricSet := range metricSets {
-		wrapper, err := NewWrapperForMetricSet(module, metricSet, r.options...)
+		wrapper, err := NewWrapperForMetricSet(module, metricSet, options...)
 		if err != nil {
 			return nil, err
 		}
@@ -58,7 +65,7 @@ func (r *Factory) Create(p beat.PipelineConnector, c *conf.C) (cfgfile.Runner, e
 			return nil, err
 		}
 
-		err = connector.UseMetricSetProcessors(mb.Registry, module.Name(), metricSet.Name())
+		err = connector.UseMetricSetProcessors(mb.Registry, module.Name(), metricSet.Name(), c)
 		if err != nil {
 			return nil, err
 		}
@@ -36,6 +36,14 @@ func WithMaxStartDelay(delay time.Duration) Option {
 	}
 }
 
+// withHostLimits sets the semaphores used to limit the concurrent fetches per
+// host, so they can be shared by the wrappers of the metricsets of a module.
+func withHostLimits(hostLimits map[string]chan struct{}) Option {
+	return func(w *Wrapper) {
+		w.hostLimits = hostLimits
+	}
+}
+
 // WithEventModifier attaches an EventModifier that will be executed for each
 // event generated by the MetricSets of the module. Multiple EventModifiers can
 // be added and they will be executed in the order in which they were added.
@@ -93,6 +93,11 @@ func (mr *runner) Stop() {
 	})
 }
 
+// LastError returns the last error reported by the MetricSets of the Module.
+func (mr *runner) LastError() error {
+	return mr.mod.LastError()
+}
+
 func (mr *runner) String() string {
 	return fmt.Sprintf("%s [metricsets=%d]", mr.mod.Name(), len(mr.mod.metricSets))
 }
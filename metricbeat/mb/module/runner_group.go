@@ -53,6 +53,19 @@ func (rg *runnerGroup) Stop() {
 	})
 }
 
+// LastError returns the last error of the first runner of the group that
+// has reported one.
+func (rg *runnerGroup) LastError() error {
+	for _, runner := range rg.runners {
+		if r, ok := runner.(interface{ LastError() error }); ok {
+			if err := r.LastError(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (rg *runnerGroup) String() string {
 	var entries []string
 	for _, runner := range rg.runners {
@@ -19,11 +19,14 @@ package module
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/rcrowley/go-metrics"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -31,6 +34,7 @@ import (
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
 	"github.com/elastic/elastic-agent-libs/testing"
 )
 
@@ -59,6 +63,13 @@ type Wrapper struct {
 	// Options
 	maxStartDelay  time.Duration
 	eventModifiers []mb.EventModifier
+
+	// hostLimits contains a semaphore per host to limit the concurrent
+	// fetches against it, if max_concurrent_fetches_per_host is set.
+	hostLimits map[string]chan struct{}
+
+	lastErrMu sync.Mutex
+	lastErr   error // Last error reported by any of the MetricSets.
 }
 
 // metricSetWrapper contains the MetricSet and the private data associated with
@@ -68,7 +79,10 @@ type metricSetWrapper struct {
 	module *Wrapper // Parent Module.
 	stats  *stats   // stats for this MetricSet.
 
-	periodic bool // Set to true if this metricset is a periodic fetcher
+	periodic bool     // Set to true if this metricset is a periodic fetcher
+	backoff  *backoff // Adapts the interval between fetches, nil if backoff is disabled.
+
+	fetchDuration metrics.Sample // Duration of the fetches in nanoseconds.
 }
 
 // stats bundles common metricset stats.
@@ -104,16 +118,43 @@ func createWrapper(module mb.Module, metricSets []mb.MetricSet, options ...Optio
 		applyOption(wrapper)
 	}
 
+	config := module.Config()
 	for i, metricSet := range metricSets {
-		wrapper.metricSets[i] = &metricSetWrapper{
-			MetricSet: metricSet,
-			module:    wrapper,
-			stats:     getMetricSetStats(wrapper.Name(), metricSet.Name()),
+		msw := &metricSetWrapper{
+			MetricSet:     metricSet,
+			module:        wrapper,
+			stats:         getMetricSetStats(wrapper.Name(), metricSet.Name()),
+			fetchDuration: metrics.NewUniformSample(1024),
+		}
+		if config.Backoff.Enabled {
+			name := fmt.Sprintf("%s/%s for host %s", module.Name(), metricSet.Name(), metricSet.Host())
+			msw.backoff = newBackoff(name, config.Period, config.Backoff)
 		}
+		wrapper.metricSets[i] = msw
+	}
+
+	if wrapper.hostLimits == nil {
+		wrapper.hostLimits = newHostLimits(config.MaxConcurrentFetchesPerHost, metricSets)
 	}
 	return wrapper, nil
 }
 
+// newHostLimits creates the semaphores used to limit the concurrent fetches
+// against each one of the hosts of the metricsets. It returns nil if there is
+// no limit.
+func newHostLimits(limit int, metricSets []mb.MetricSet) map[string]chan struct{} {
+	if limit <= 0 {
+		return nil
+	}
+	hostLimits := make(map[string]chan struct{})
+	for _, metricSet := range metricSets {
+		if _, found := hostLimits[metricSet.Host()]; !found {
+			hostLimits[metricSet.Host()] = make(chan struct{}, limit)
+		}
+	}
+	return hostLimits
+}
+
 // Wrapper methods
 
 // Start starts the Module's MetricSet workers which are responsible for
@@ -145,6 +186,8 @@ func (mw *Wrapper) Start(done <-chan struct{}) <-chan beat.Event {
 
 			registry.Add(metricsPath, msw.Metrics(), monitoring.Full)
 			monitoring.NewString(msw.Metrics(), "starttime").Set(common.Time(time.Now()).String())
+			_ = adapter.NewGoMetrics(msw.Metrics(), "fetch_duration", adapter.Accept).
+				Register("histogram", metrics.NewHistogram(msw.fetchDuration))
 
 			msw.run(done, out)
 		}(msw)
@@ -171,6 +214,20 @@ func (mw *Wrapper) MetricSets() []*metricSetWrapper {
 	return mw.metricSets
 }
 
+// LastError returns the last error reported by any of the MetricSets, or nil
+// if none of them has failed.
+func (mw *Wrapper) LastError() error {
+	mw.lastErrMu.Lock()
+	defer mw.lastErrMu.Unlock()
+	return mw.lastErr
+}
+
+func (mw *Wrapper) setLastError(err error) {
+	mw.lastErrMu.Lock()
+	defer mw.lastErrMu.Unlock()
+	mw.lastErr = err
+}
+
 // metricSetWrapper methods
 
 func (msw *metricSetWrapper) run(done <-chan struct{}, out chan<- beat.Event) {
@@ -221,6 +278,11 @@ func (msw *metricSetWrapper) startPeriodicFetching(ctx context.Context, reporter
 	// Indicate that it has been started as periodic fetcher
 	msw.periodic = true
 
+	if msw.backoff != nil {
+		msw.startAdaptiveFetching(ctx, reporter)
+		return
+	}
+
 	// Fetch immediately.
 	msw.fetch(ctx, reporter)
 
@@ -237,10 +299,51 @@ func (msw *metricSetWrapper) startPeriodicFetching(ctx context.Context, reporter
 	}
 }
 
+// startAdaptiveFetching continuously fetches data for the MetricSet, waiting
+// between fetches the interval calculated by the backoff. To stop the loop the
+// done channel should be closed.
+func (msw *metricSetWrapper) startAdaptiveFetching(ctx context.Context, reporter reporter) {
+	for {
+		start := time.Now()
+		msw.fetch(ctx, reporter)
+		took := time.Since(start)
+
+		t := time.NewTimer(msw.backoff.update(took) - took)
+		select {
+		case <-reporter.V2().Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
 // fetch invokes the appropriate Fetch method for the MetricSet and publishes
 // the result using the publisher client. This method will recover from panics
 // and log a stack track if one occurs.
 func (msw *metricSetWrapper) fetch(ctx context.Context, reporter reporter) {
+	if limit, found := msw.module.hostLimits[msw.Host()]; found {
+		// Wait for other fetches against the same host to finish.
+		select {
+		case limit <- struct{}{}:
+			defer func() { <-limit }()
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	// Fetches are expected to finish in the configured timeout, propagate it
+	// to the metricsets that receive a context.
+	if timeout := msw.Module().Config().Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	defer func(start time.Time) {
+		msw.fetchDuration.Update(time.Since(start).Nanoseconds())
+	}(time.Now())
+
 	switch fetcher := msw.MetricSet.(type) {
 	case mb.ReportingMetricSet:
 		reporter.StartFetchTimer()
@@ -360,6 +463,20 @@ func (r reporterV2) Event(event mb.Event) bool {
 	}
 	if r.msw.periodic {
 		event.Period = r.msw.Module().Config().Period
+
+		if b := r.msw.backoff; b != nil {
+			if errors.Is(event.Error, mb.ErrTargetOverloaded) {
+				b.markOverloaded()
+			}
+			if b.Degraded() {
+				// Report the actual period, and make the degradation visible.
+				event.Period = b.Interval()
+				if event.RootFields == nil {
+					event.RootFields = mapstr.M{}
+				}
+				event.RootFields.Put("metricset.status", degradedStatus)
+			}
+		}
 	}
 
 	if event.Timestamp.IsZero() {
@@ -378,6 +495,17 @@ func (r reporterV2) Event(event mb.Event) bool {
 		r.msw.stats.success.Add(1)
 	} else {
 		r.msw.stats.failures.Add(1)
+		r.msw.module.setLastError(fmt.Errorf("%s: %w", r.msw.Name(), event.Error))
+
+		if r.msw.Module().Config().ErrorEvents {
+			if event.RootFields == nil {
+				event.RootFields = mapstr.M{}
+			}
+			event.RootFields.Put("error.type", mb.ErrorType(event.Error))
+			if code := mb.ErrorCode(event.Error); code != "" {
+				event.RootFields.Put("error.code", code)
+			}
+		}
 	}
 
 	if event.Namespace == "" {
@@ -21,6 +21,10 @@
 package module_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -232,3 +236,198 @@ func TestNewWrapperForMetricSet(t *testing.T) {
 		assert.Fail(t, "received unexpected event")
 	}
 }
+
+// contextFetcher tracks the number of concurrent fetches
+
+type contextFetcher struct {
+	mb.BaseMetricSet
+	inFlight    *int64
+	maxInFlight *int64
+}
+
+func (ms *contextFetcher) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	current := atomic.AddInt64(ms.inFlight, 1)
+	defer atomic.AddInt64(ms.inFlight, -1)
+	for {
+		max := atomic.LoadInt64(ms.maxInFlight)
+		if current <= max || atomic.CompareAndSwapInt64(ms.maxInFlight, max, current) {
+			break
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	_, hasDeadline := ctx.Deadline()
+	r.Event(mb.Event{ModuleFields: mapstr.M{"deadline": hasDeadline}})
+	return nil
+}
+
+func TestWrapperMaxConcurrentFetchesPerHost(t *testing.T) {
+	var inFlight, maxInFlight int64
+	newContextFetcher := func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &contextFetcher{BaseMetricSet: base, inFlight: &inFlight, maxInFlight: &maxInFlight}, nil
+	}
+
+	r := mb.NewRegister()
+	metricSets := []string{"a", "b", "c"}
+	for _, name := range metricSets {
+		require.NoError(t, r.AddMetricSet(moduleName, name, newContextFetcher))
+	}
+
+	c := newConfig(t, map[string]interface{}{
+		"module":                          moduleName,
+		"metricsets":                      metricSets,
+		"hosts":                           []string{"alpha"},
+		"period":                          "1h",
+		"max_concurrent_fetches_per_host": 1,
+	})
+
+	m, err := module.NewWrapper(c, r)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	output := m.Start(done)
+	defer close(done)
+
+	for range metricSets {
+		event := <-output
+		deadline, _ := event.Fields.GetValue("fake.deadline")
+		assert.Equal(t, true, deadline)
+	}
+	assert.Equal(t, int64(1), atomic.LoadInt64(&maxInFlight))
+}
+
+// slowFetcher blocks until its fetch is cancelled
+
+type slowFetcher struct {
+	mb.BaseMetricSet
+}
+
+func (ms *slowFetcher) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Minute):
+		return errors.New("fetch was not cancelled")
+	}
+}
+
+func TestWrapperFetchTimeout(t *testing.T) {
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet(moduleName, "slow", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &slowFetcher{BaseMetricSet: base}, nil
+	}))
+
+	// The deadline is applied also without limit of concurrent fetches.
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{"slow"},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+		"timeout":    "50ms",
+	})
+
+	m, err := module.NewWrapper(c, r)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	output := m.Start(done)
+	defer close(done)
+
+	select {
+	case event := <-output:
+		errorMessage, _ := event.Fields.GetValue("error.message")
+		assert.Equal(t, context.DeadlineExceeded.Error(), errorMessage)
+	case <-time.After(10 * time.Second):
+		t.Fatal("slow fetch was not cancelled")
+	}
+}
+
+// overloadedFetcher always fails as if the monitored service was overloaded
+
+type overloadedFetcher struct {
+	mb.BaseMetricSet
+}
+
+func (ms *overloadedFetcher) Fetch(r mb.ReporterV2) error {
+	return fmt.Errorf("fetching: %w", mb.ErrTargetOverloaded)
+}
+
+func TestWrapperBackoff(t *testing.T) {
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet(moduleName, "overloaded", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &overloadedFetcher{BaseMetricSet: base}, nil
+	}))
+
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{"overloaded"},
+		"hosts":      []string{"alpha"},
+		"period":     "10ms",
+		"backoff": map[string]interface{}{
+			"enabled":    true,
+			"max_period": "40ms",
+		},
+	})
+
+	m, err := module.NewWrapper(c, r, module.WithMetricSetInfo())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	output := m.Start(done)
+	defer close(done)
+
+	// The first fetch is done with the configured period.
+	event := <-output
+	status, _ := event.Fields.GetValue("metricset.status")
+	assert.Nil(t, status)
+
+	// Following fetches are delayed.
+	expected := []time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}
+	for _, interval := range expected {
+		event := <-output
+		status, _ := event.Fields.GetValue("metricset.status")
+		assert.Equal(t, "degraded", status)
+		period, _ := event.Fields.GetValue("metricset.period")
+		assert.Equal(t, interval/time.Millisecond, period)
+	}
+}
+
+func TestWrapperErrorEvents(t *testing.T) {
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet(moduleName, "overloaded", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &overloadedFetcher{BaseMetricSet: base}, nil
+	}))
+
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("error_events: %v", enabled), func(t *testing.T) {
+			c := newConfig(t, map[string]interface{}{
+				"module":       moduleName,
+				"metricsets":   []string{"overloaded"},
+				"hosts":        []string{"alpha"},
+				"period":       "1h",
+				"error_events": enabled,
+			})
+
+			m, err := module.NewWrapper(c, r, module.WithMetricSetInfo())
+			require.NoError(t, err)
+
+			done := make(chan struct{})
+			output := m.Start(done)
+			defer close(done)
+
+			event := <-output
+			errorMessage, _ := event.Fields.GetValue("error.message")
+			assert.Equal(t, "fetching: target overloaded", errorMessage)
+			dataset, _ := event.Fields.GetValue("event.dataset")
+			assert.Equal(t, "fake.overloaded", dataset)
+
+			errorType, _ := event.Fields.GetValue("error.type")
+			if enabled {
+				assert.Equal(t, mb.ErrorTypeUnknown, errorType)
+			} else {
+				assert.Nil(t, errorType)
+			}
+		})
+	}
+}
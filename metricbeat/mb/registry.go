@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -131,7 +132,7 @@ type ModulesSource interface {
 	HasMetricSet(module, name string) bool
 	MetricSetRegistration(r *Register, module, name string) (MetricSetRegistration, error)
 	ModulesInfo(r *Register) string
-	ProcessorsForMetricSet(r *Register, module, name string) (*processors.Processors, error)
+	ProcessorsForMetricSet(r *Register, module, name string, config *conf.C) (*processors.Processors, error)
 }
 
 // NewRegister creates and returns a new Register.
@@ -380,7 +381,8 @@ func (r *Register) MetricSets(module string) []string {
 }
 
 // ProcessorsForMetricSet returns a list of processors defined in manifest of the registered metricset.
-func (r *Register) ProcessorsForMetricSet(module, name string) (*processors.Processors, error) {
+// The module configuration is used to render templated processors, it can be nil.
+func (r *Register) ProcessorsForMetricSet(module, name string, config *conf.C) (*processors.Processors, error) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
@@ -396,7 +398,7 @@ func (r *Register) ProcessorsForMetricSet(module, name string) (*processors.Proc
 	}
 
 	if source := r.secondarySource; source != nil {
-		return source.ProcessorsForMetricSet(r, module, name)
+		return source.ProcessorsForMetricSet(r, module, name, config)
 	}
 	return nil, fmt.Errorf(`metricset "%s" is not registered (module: %s)'`, name, module)
 }
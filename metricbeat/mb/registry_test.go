@@ -226,7 +226,7 @@ func TestModuleQuery(t *testing.T) {
 func TestProcessorsForMetricSet_StandardMetricSet(t *testing.T) {
 	registry := NewRegister()
 	err := registry.AddMetricSet(moduleName, metricSetName, fakeMetricSetFactory)
-	procs, err := registry.ProcessorsForMetricSet(moduleName, metricSetName)
+	procs, err := registry.ProcessorsForMetricSet(moduleName, metricSetName, nil)
 	require.NotNil(t, procs)
 	require.Empty(t, procs.List)
 	require.NoError(t, err)
@@ -234,7 +234,7 @@ func TestProcessorsForMetricSet_StandardMetricSet(t *testing.T) {
 
 func TestProcessorsForMetricSet_UndefinedSecondarySource(t *testing.T) {
 	registry := NewRegister()
-	procs, err := registry.ProcessorsForMetricSet(moduleName, metricSetName)
+	procs, err := registry.ProcessorsForMetricSet(moduleName, metricSetName, nil)
 	require.Nil(t, procs)
 	require.Error(t, err)
 }
@@ -242,7 +242,7 @@ func TestProcessorsForMetricSet_UndefinedSecondarySource(t *testing.T) {
 func TestProcessorsForMetricSet_FromSource(t *testing.T) {
 	registry := NewRegister()
 	registry.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
-	procs, err := registry.ProcessorsForMetricSet("unpack", "withprocessors")
+	procs, err := registry.ProcessorsForMetricSet("unpack", "withprocessors", nil)
 	require.NoError(t, err)
 	require.NotNil(t, procs)
 	require.Len(t, procs.List, 1)
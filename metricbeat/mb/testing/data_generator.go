@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/testing/flags"
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
@@ -143,7 +144,11 @@ func StandardizeEvent(ms mb.MetricSet, e mb.Event, modifiers ...mb.EventModifier
 
 	// Run processors if defined for the metricset, it can happen for light metricsets
 	// with processors in the manifest.
-	processors, err := mb.Registry.ProcessorsForMetricSet(ms.Module().Name(), ms.Name())
+	config := conf.NewConfig()
+	if err := ms.Module().UnpackConfig(config); err != nil {
+		panic(err)
+	}
+	processors, err := mb.Registry.ProcessorsForMetricSet(ms.Module().Name(), ms.Name(), config)
 	if err == nil && processors != nil {
 		enriched, err := processors.Run(&fullEvent)
 		if err != nil {
@@ -194,6 +194,22 @@ func NewReportingMetricSetV2WithContext(t testing.TB, config interface{}) mb.Rep
 	return reportingMetricSet
 }
 
+// NewReportingMetricSetV2WithContexts returns an array of new ReportingMetricSetV2WithContext instances.
+func NewReportingMetricSetV2WithContexts(t testing.TB, config interface{}) []mb.ReportingMetricSetV2WithContext {
+	metricSets := NewMetricSets(t, config)
+	var reportingMetricSets []mb.ReportingMetricSetV2WithContext
+	for _, metricSet := range metricSets {
+		rMS, ok := metricSet.(mb.ReportingMetricSetV2WithContext)
+		if !ok {
+			t.Fatalf("MetricSet %v does not implement ReportingMetricSetV2WithContext", metricSet.Name())
+		}
+
+		reportingMetricSets = append(reportingMetricSets, rMS)
+	}
+
+	return reportingMetricSets
+}
+
 // CapturingReporterV2 is a reporter used for testing which stores all events and errors
 type CapturingReporterV2 struct {
 	events []mb.Event
@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocation_explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "allocation_explain", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	clusterHealthPath     = "/_cluster/health"
+	allocationExplainPath = "/_cluster/allocation/explain"
+
+	catShardsPath  = "/_cat/shards"
+	catShardsQuery = "format=json&h=index,shard,prirep,state"
+
+	// Only the decisions are needed, not the details of the disk usage of every node
+	explainQuery = "include_disk_info=false"
+)
+
+// Config contains the allocation_explain specific settings of the elasticsearch module
+type Config struct {
+	// MaxShards is the maximum number of unassigned shards explained on each fetch.
+	MaxShards int `config:"allocation_explain.max_shards"`
+}
+
+// Validate checks the allocation_explain configuration
+func (c *Config) Validate() error {
+	if c.MaxShards <= 0 {
+		return errors.New("allocation_explain.max_shards must be greater than 0")
+	}
+	return nil
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	config Config
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := Config{
+		MaxShards: 10,
+	}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := elasticsearch.NewMetricSet(base, clusterHealthPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms, config: config}, nil
+}
+
+// Fetch explains why a sample of the unassigned shards of the cluster can't be
+// allocated, using the _cluster/allocation/explain API. Nothing is reported while
+// the cluster health is green.
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := getClusterStatus(content)
+	if err != nil {
+		return err
+	}
+	if status == "green" {
+		return nil
+	}
+
+	shardsContent, err := m.FetchPath(ctx, catShardsPath, catShardsQuery)
+	if err != nil {
+		return fmt.Errorf("error fetching shards: %w", err)
+	}
+
+	shards, err := sampleUnassignedShards(shardsContent, m.config.MaxShards)
+	if err != nil {
+		return err
+	}
+
+	explanations := make([]explanation, 0, len(shards))
+	for _, s := range shards {
+		content, err := m.PostPath(ctx, allocationExplainPath, explainQuery, s.explainRequest())
+		explanations = append(explanations, explanation{shard: s, content: content, err: err})
+	}
+
+	return eventsMapping(r, *info, status, explanations, m.XPackEnabled)
+}
@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package allocation_explain
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/allocation_explain.7170.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, "red", []explanation{
+		{shard: shard{Index: "metrics-000001", Shard: "0", primary: true}, content: content},
+		{shard: shard{Index: "logs-000001", Shard: "0"}, err: errors.New("HTTP error 400")},
+	}, true)
+	require.Error(t, err)
+
+	require.Len(t, reporter.GetErrors(), 0)
+	events := reporter.GetEvents()
+	require.Len(t, events, 1)
+
+	fields := events[0].MetricSetFields
+	require.Equal(t, "red", fields["cluster_status"])
+	require.Equal(t, "metrics-000001", fields["index"])
+	require.Equal(t, int64(0), fields["shard"])
+	require.Equal(t, true, fields["primary"])
+	require.Equal(t, "no", fields["can_allocate"])
+	require.Equal(t, mapstr.M{
+		"reason":                 "INDEX_CREATED",
+		"at":                     "2022-12-13T07:51:34.060Z",
+		"last_allocation_status": "no",
+	}, fields["unassigned"])
+	require.Equal(t, []string{"disk_threshold", "filter"}, fields["blocking_deciders"])
+	require.Equal(t, mapstr.M{"no": 2}, fields["node_decision_count"])
+
+	nodes := fields["node_decisions"].([]mapstr.M)
+	require.Len(t, nodes, 2)
+	require.Equal(t, "es-node-2", nodes[1]["name"])
+	require.Equal(t, "no", nodes[1]["decision"])
+	require.Len(t, nodes[1]["deciders"], 2)
+	require.Equal(t, "disk_threshold", nodes[1]["deciders"].([]mapstr.M)[1]["name"])
+}
+
+func TestSampleUnassignedShards(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/cat_shards.7170.json")
+	require.NoError(t, err)
+
+	shards, err := sampleUnassignedShards(content, 4)
+	require.NoError(t, err)
+
+	var sample []string
+	for _, s := range shards {
+		sample = append(sample, s.Index+"/"+s.Shard+"/"+s.PriRep)
+	}
+	require.Equal(t, []string{
+		"metrics-000001/0/p",
+		"logs-000001/0/r",
+		"logs-000001/2/r",
+		"logs-000001/10/r",
+	}, sample)
+
+	var request map[string]interface{}
+	require.NoError(t, json.Unmarshal(shards[0].explainRequest(), &request))
+	require.Equal(t, map[string]interface{}{"index": "metrics-000001", "shard": float64(0), "primary": true}, request)
+}
+
+func createEsMuxer(status string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+		}
+		input, _ := ioutil.ReadFile("./_meta/test/root.710.json")
+		w.Write(input)
+	}))
+	mux.Handle("/_nodes/_local/nodes", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"nodes": { "foobar": {}}}`))
+	}))
+	mux.Handle("/_cluster/state/master_node", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"master_node": "foobar"}`))
+	}))
+	mux.Handle("/_cluster/health", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"cluster_name": "elasticsearch", "status": "` + status + `"}`))
+	}))
+	mux.Handle("/_cat/shards", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/cat_shards.7170.json")
+		w.Write(input)
+	}))
+	return mux
+}
+
+func TestGreenCluster(t *testing.T) {
+	mux := createEsMuxer("green")
+	mux.Handle("/_cluster/allocation/explain", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "this should never have been called", 418)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2WithContext(ms)
+
+	require.Empty(t, errs)
+	require.Empty(t, events)
+}
+
+func TestData(t *testing.T) {
+	var explained int
+	mux := createEsMuxer("red")
+	mux.Handle("/_cluster/allocation/explain", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		explained++
+		input, _ := ioutil.ReadFile("./_meta/test/allocation_explain.7170.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := getConfig(server.URL)
+	config["allocation_explain.max_shards"] = 2
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+	require.Equal(t, 2, explained)
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"allocation_explain"},
+		"hosts":      []string{host},
+	}
+}
@@ -0,0 +1,250 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocation_explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	schema = s.Schema{
+		"index":                c.Str("index"),
+		"shard":                c.Int("shard"),
+		"primary":              c.Bool("primary"),
+		"current_state":        c.Str("current_state"),
+		"can_allocate":         c.Str("can_allocate", s.Optional),
+		"allocate_explanation": c.Str("allocate_explanation", s.Optional),
+		"unassigned": c.Dict("unassigned_info", s.Schema{
+			"reason":                 c.Str("reason"),
+			"at":                     c.Str("at"),
+			"details":                c.Str("details", s.Optional),
+			"last_allocation_status": c.Str("last_allocation_status", s.Optional),
+		}, c.DictOptional),
+	}
+
+	deciderSchema = s.Schema{
+		"name":        c.Str("decider"),
+		"decision":    c.Str("decision"),
+		"explanation": c.Str("explanation", s.Optional),
+	}
+)
+
+type shard struct {
+	Index   string `json:"index"`
+	Shard   string `json:"shard"`
+	PriRep  string `json:"prirep"`
+	State   string `json:"state"`
+	number  int
+	primary bool
+}
+
+func (s shard) explainRequest() []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"index":   s.Index,
+		"shard":   s.number,
+		"primary": s.primary,
+	})
+	return body
+}
+
+type explanation struct {
+	shard   shard
+	content []byte
+	err     error
+}
+
+type nodeDecision struct {
+	NodeID       string                   `json:"node_id"`
+	NodeName     string                   `json:"node_name"`
+	NodeDecision string                   `json:"node_decision"`
+	Deciders     []map[string]interface{} `json:"deciders"`
+}
+
+func getClusterStatus(content []byte) (string, error) {
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(content, &health); err != nil {
+		return "", fmt.Errorf("failure parsing Elasticsearch Cluster Health API response: %w", err)
+	}
+	return health.Status, nil
+}
+
+// sampleUnassignedShards returns up to max unassigned shards. Primaries are returned
+// first, as they are the ones turning the cluster health to red.
+func sampleUnassignedShards(content []byte, max int) ([]shard, error) {
+	var shards []shard
+	if err := json.Unmarshal(content, &shards); err != nil {
+		return nil, fmt.Errorf("failure parsing Elasticsearch Cat Shards API response: %w", err)
+	}
+
+	unassigned := make([]shard, 0)
+	for _, s := range shards {
+		if s.State != "UNASSIGNED" {
+			continue
+		}
+		number, err := strconv.Atoi(s.Shard)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shard number %q of index %v: %w", s.Shard, s.Index, err)
+		}
+		s.number = number
+		s.primary = s.PriRep == "p"
+		unassigned = append(unassigned, s)
+	}
+
+	sort.SliceStable(unassigned, func(i, j int) bool {
+		if unassigned[i].primary != unassigned[j].primary {
+			return unassigned[i].primary
+		}
+		if unassigned[i].Index != unassigned[j].Index {
+			return unassigned[i].Index < unassigned[j].Index
+		}
+		return unassigned[i].number < unassigned[j].number
+	})
+
+	if len(unassigned) > max {
+		unassigned = unassigned[:max]
+	}
+	return unassigned, nil
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, status string, explanations []explanation, isXpack bool) error {
+	var errs multierror.Errors
+	for _, e := range explanations {
+		if e.err != nil {
+			errs = append(errs, fmt.Errorf("error explaining allocation of shard %v of index %v: %w", e.shard.Shard, e.shard.Index, e.err))
+			continue
+		}
+
+		fields, err := explanationFields(e.content)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure parsing allocation explanation of shard %v of index %v: %w", e.shard.Shard, e.shard.Index, err))
+			continue
+		}
+		fields["cluster_status"] = status
+
+		event := mb.Event{
+			RootFields: mapstr.M{
+				"service": mapstr.M{
+					"name": elasticsearch.ModuleName,
+				},
+			},
+			ModuleFields: mapstr.M{
+				"cluster": mapstr.M{
+					"name": info.ClusterName,
+					"id":   info.ClusterID,
+				},
+			},
+			MetricSetFields: fields,
+		}
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return errs.Err()
+}
+
+func explanationFields(content []byte) (mapstr.M, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	fields, err := schema.Apply(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		NodeDecisions []nodeDecision `json:"node_allocation_decisions"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.NodeDecisions) == 0 {
+		return fields, nil
+	}
+
+	// The deciders preventing the allocation on any node, to quickly find out why a shard is stuck
+	blocking := map[string]bool{}
+	decisions := map[string]int{}
+	nodes := make([]mapstr.M, 0, len(response.NodeDecisions))
+	for _, node := range response.NodeDecisions {
+		decisions[node.NodeDecision]++
+
+		deciders := make([]mapstr.M, 0, len(node.Deciders))
+		for _, d := range node.Deciders {
+			decider, err := deciderSchema.Apply(d)
+			if err != nil {
+				return nil, err
+			}
+			if decider["decision"] == "NO" {
+				blocking[decider["name"].(string)] = true
+			}
+			deciders = append(deciders, decider)
+		}
+
+		nodeFields := mapstr.M{
+			"id":       node.NodeID,
+			"name":     node.NodeName,
+			"decision": node.NodeDecision,
+		}
+		if len(deciders) > 0 {
+			nodeFields["deciders"] = deciders
+		}
+		nodes = append(nodes, nodeFields)
+	}
+	fields["node_decisions"] = nodes
+
+	decisionCounts := mapstr.M{}
+	for decision, count := range decisions {
+		decisionCounts[decision] = count
+	}
+	fields["node_decision_count"] = decisionCounts
+
+	if len(blocking) > 0 {
+		names := make([]string, 0, len(blocking))
+		for name := range blocking {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields["blocking_deciders"] = names
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// defaultAvailabilityCacheTTL is the default time during which license and X-Pack
+// information is shared by the metricsets of a module instance.
+const defaultAvailabilityCacheTTL = time.Minute
+
+// Module is implemented by the elasticsearch module. It gives its metricsets access
+// to license and X-Pack information that is fetched once per TTL and shared by all
+// metricsets of the module instance, instead of being fetched on every Fetch.
+type Module interface {
+	mb.Module
+	GetLicense(ctx context.Context, http *helper.HTTP, resetURI string) (*License, error)
+	GetXPack(ctx context.Context, http *helper.HTTP, resetURI string) (XPack, error)
+	IsLeader(ctx context.Context, http *helper.HTTP, resetURI string) (bool, error)
+}
+
+type module struct {
+	mb.BaseModule
+	availability *availabilityCache
+	leader       *leaderElector
+}
+
+// GetLicense returns the license of the cluster behind the given URI, using the
+// module-level cache.
+func (m *module) GetLicense(ctx context.Context, http *helper.HTTP, resetURI string) (*License, error) {
+	return m.availability.getLicense(ctx, http, resetURI)
+}
+
+// GetXPack returns the X-Pack features of the cluster behind the given URI, using
+// the module-level cache.
+func (m *module) GetXPack(ctx context.Context, http *helper.HTTP, resetURI string) (XPack, error) {
+	return m.availability.getXPack(ctx, http, resetURI)
+}
+
+// IsLeader returns true if this instance is elected to collect the cluster-scoped
+// metricsets of the cluster behind the given URI. It is always true when leader
+// election is disabled.
+func (m *module) IsLeader(ctx context.Context, http *helper.HTTP, resetURI string) (bool, error) {
+	if m.leader == nil {
+		return true, nil
+	}
+	return m.leader.isLeader(ctx, http, resetURI)
+}
+
+type availabilityEntry struct {
+	license          *License
+	licenseFetchedOn time.Time
+	xpack            *XPack
+	xpackFetchedOn   time.Time
+}
+
+// availabilityCache caches license and X-Pack information per monitored host.
+type availabilityCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[string]*availabilityEntry
+}
+
+func newAvailabilityCache(ttl time.Duration) *availabilityCache {
+	return &availabilityCache{
+		ttl:     ttl,
+		entries: map[string]*availabilityEntry{},
+	}
+}
+
+func (c *availabilityCache) getLicense(ctx context.Context, http *helper.HTTP, resetURI string) (*License, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry := c.entry(resetURI)
+	if entry.license != nil && time.Since(entry.licenseFetchedOn) <= c.ttl {
+		return entry.license, nil
+	}
+
+	license, err := fetchLicense(ctx, http, resetURI)
+	if err != nil {
+		// Use the previous license while the cluster is temporarily unavailable
+		if entry.license != nil && time.Since(entry.licenseFetchedOn) <= staleAvailabilityMaxAge {
+			return entry.license, nil
+		}
+		return nil, err
+	}
+
+	entry.license = license
+	entry.licenseFetchedOn = time.Now()
+	return license, nil
+}
+
+func (c *availabilityCache) getXPack(ctx context.Context, http *helper.HTTP, resetURI string) (XPack, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry := c.entry(resetURI)
+	if entry.xpack != nil && time.Since(entry.xpackFetchedOn) <= c.ttl {
+		return *entry.xpack, nil
+	}
+
+	xpack, err := fetchXPack(ctx, http, resetURI)
+	if err != nil {
+		// Use the previous features while the cluster is temporarily unavailable
+		if entry.xpack != nil && time.Since(entry.xpackFetchedOn) <= staleAvailabilityMaxAge {
+			return *entry.xpack, nil
+		}
+		return XPack{}, err
+	}
+
+	entry.xpack = &xpack
+	entry.xpackFetchedOn = time.Now()
+	return xpack, nil
+}
+
+// entry returns the cache entry for the host of the given URI. The path is
+// ignored, as it differs for each metricset.
+func (c *availabilityCache) entry(uri string) *availabilityEntry {
+	key := hostKey(uri)
+	entry, found := c.entries[key]
+	if !found {
+		entry = &availabilityEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// hostKey returns the scheme and host of the given URI, to identify the monitored host
+// regardless of the path used by each metricset.
+func hostKey(uri string) string {
+	if u, err := url.Parse(uri); err == nil {
+		return u.Scheme + "://" + u.Host
+	}
+	return uri
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestAvailabilityCache(t *testing.T) {
+	var licenseRequests, xpackRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_license", func(w http.ResponseWriter, r *http.Request) {
+		licenseRequests++
+		w.Write([]byte(`{"license": {"type": "platinum"}}`))
+	})
+	mux.HandleFunc("/_xpack", func(w http.ResponseWriter, r *http.Request) {
+		xpackRequests++
+		w.Write([]byte(`{"features": {"ccr": {"enabled": true}}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	httpHelper, err := helper.NewHTTPFromConfig(helper.Config{}, mb.HostData{SanitizedURI: server.URL})
+	require.NoError(t, err)
+
+	cache := newAvailabilityCache(time.Minute)
+	for _, path := range []string{"/_ccr/stats", "/_enrich/_stats"} {
+		license, err := cache.getLicense(context.Background(), httpHelper, server.URL+path)
+		require.NoError(t, err)
+		require.Equal(t, "platinum", license.Type)
+
+		xpack, err := cache.getXPack(context.Background(), httpHelper, server.URL+path)
+		require.NoError(t, err)
+		require.True(t, xpack.Features.CCR.Enabled)
+	}
+
+	require.Equal(t, 1, licenseRequests)
+	require.Equal(t, 1, xpackRequests)
+
+	// Entries are fetched again once the TTL has elapsed
+	cache.ttl = 0
+	time.Sleep(time.Millisecond)
+	_, err = cache.getLicense(context.Background(), httpHelper, server.URL)
+	require.NoError(t, err)
+	require.Equal(t, 2, licenseRequests)
+}
@@ -18,7 +18,9 @@
 package ccr
 
 import (
+	"context"
 	"fmt"
+	"path"
 	"time"
 
 	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
@@ -34,27 +36,90 @@ func init() {
 }
 
 const (
-	ccrStatsPath = "/_ccr/stats"
+	ccrStatsPath      = "/_ccr/stats"
+	ccrAutoFollowPath = "/_ccr/auto_follow"
 )
 
+// Config contains the ccr specific settings of the elasticsearch module
+type Config struct {
+	// OmitZeroFields drops fields whose value equals their documented default
+	// (zero for most counters) from the emitted events.
+	OmitZeroFields bool `config:"ccr.omit_zero_fields"`
+
+	// NormalizeRolloverNames splits the rollover generation off follower index
+	// names so time series stay continuous across rollovers.
+	NormalizeRolloverNames bool `config:"ccr.normalize_rollover_names"`
+
+	// Indices restricts the follower indices for which shard events are emitted.
+	Indices IndicesConfig `config:"ccr.indices"`
+}
+
+// IndicesConfig holds the glob patterns used to select follower indices
+type IndicesConfig struct {
+	// Include lists the follower indices to report. All follower indices are
+	// reported when empty.
+	Include []string `config:"include"`
+
+	// Exclude lists the follower indices to skip, even if they are included.
+	Exclude []string `config:"exclude"`
+}
+
+// Validate checks that all the configured patterns are valid globs
+func (c IndicesConfig) Validate() error {
+	for _, pattern := range append(append([]string{}, c.Include...), c.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid ccr.indices pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if events must be emitted for the given follower index
+func (c IndicesConfig) Matches(index string) bool {
+	if len(c.Include) > 0 && !matchesAny(c.Include, index) {
+		return false
+	}
+	return !matchesAny(c.Exclude, index)
+}
+
+func matchesAny(patterns []string, index string) bool {
+	for _, pattern := range patterns {
+		// Patterns are validated when the configuration is unpacked
+		if matched, _ := path.Match(pattern, index); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // MetricSet type defines all fields of the MetricSet
 type MetricSet struct {
 	*elasticsearch.MetricSet
+	config                         Config
 	lastCCRLicenseMessageTimestamp time.Time
 }
 
 // New create a new instance of the MetricSet
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := Config{
+		OmitZeroFields:         false,
+		NormalizeRolloverNames: false,
+	}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
 	ms, err := elasticsearch.NewMetricSet(base, ccrStatsPath)
 	if err != nil {
 		return nil, err
 	}
-	return &MetricSet{MetricSet: ms}, nil
+	return &MetricSet{MetricSet: ms, config: config}, nil
 }
 
-// Fetch gathers stats for each follower shard from the _ccr/stats API
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	shouldSkip, err := m.ShouldSkipFetch()
+// Fetch gathers stats for each follower shard and the auto-follow stats from the
+// _ccr/stats API, and the auto-follow patterns from the _ccr/auto_follow API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
 	if err != nil {
 		return err
 	}
@@ -62,17 +127,20 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.GetServiceURI())
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
 	if err != nil {
 		return err
 	}
 
-	ccrUnavailableMessage, err := m.checkCCRAvailability(info.Version.Number)
+	ccrUnavailableMessage, err := m.checkCCRAvailability(ctx, info.Version.Number)
 	if err != nil {
 		return fmt.Errorf("error determining if CCR is available: %w", err)
 	}
 
 	if ccrUnavailableMessage != "" {
+		if err := m.UnavailableError(ccrUnavailableMessage); err != nil {
+			return err
+		}
 		if time.Since(m.lastCCRLicenseMessageTimestamp) > 1*time.Minute {
 			m.lastCCRLicenseMessageTimestamp = time.Now()
 			m.Logger().Warn(ccrUnavailableMessage)
@@ -80,16 +148,25 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.HTTP.FetchContentWithContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	return eventsMapping(r, *info, content, m.XPackEnabled)
+	if err := eventsMapping(r, *info, content, m.XPackEnabled, m.config); err != nil {
+		return err
+	}
+
+	autoFollowContent, err := m.FetchPath(ctx, ccrAutoFollowPath, "")
+	if err != nil {
+		return fmt.Errorf("error fetching auto-follow patterns: %w", err)
+	}
+
+	return eventsMappingAutoFollowPatterns(r, *info, autoFollowContent, m.XPackEnabled)
 }
 
-func (m *MetricSet) checkCCRAvailability(currentElasticsearchVersion *version.V) (message string, err error) {
-	license, err := elasticsearch.GetLicense(m.HTTP, m.GetServiceURI())
+func (m *MetricSet) checkCCRAvailability(ctx context.Context, currentElasticsearchVersion *version.V) (message string, err error) {
+	license, err := m.GetLicense(ctx)
 	if err != nil {
 		return "", fmt.Errorf("error determining Elasticsearch license: %w", err)
 	}
@@ -101,7 +178,7 @@ func (m *MetricSet) checkCCRAvailability(currentElasticsearchVersion *version.V)
 		return
 	}
 
-	xpack, err := elasticsearch.GetXPack(m.HTTP, m.GetServiceURI())
+	xpack, err := m.GetXPack(ctx)
 	if err != nil {
 		return "", fmt.Errorf("error determining xpack features: %w", err)
 	}
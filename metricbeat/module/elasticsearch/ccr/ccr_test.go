@@ -103,8 +103,8 @@ func TestCCRNotAvailable(t *testing.T) {
 			server := httptest.NewServer(mux)
 			defer server.Close()
 
-			ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
-			events, errs := mbtest.ReportingFetchV2Error(ms)
+			ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+			events, errs := mbtest.ReportingFetchV2WithContext(ms)
 
 			require.Empty(t, errs)
 			require.Empty(t, events)
@@ -112,6 +112,23 @@ func TestCCRNotAvailable(t *testing.T) {
 	}
 }
 
+func TestCCRServerless(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.Error(w, "this should never have been called", 418)
+			return
+		}
+		w.Write([]byte(`{"cluster_name": "serverless", "cluster_uuid": "1234", "version": {"number": "8.11.0", "build_flavor": "serverless"}}`))
+	}))
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2WithContext(ms)
+
+	require.Empty(t, errs)
+	require.Empty(t, events)
+}
+
 func getConfig(host string) map[string]interface{} {
 	return map[string]interface{}{
 		"module":     elasticsearch.ModuleName,
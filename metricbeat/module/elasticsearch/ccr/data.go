@@ -19,6 +19,9 @@ package ccr
 
 import (
 	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -125,16 +128,55 @@ var (
 	}
 )
 
+var autoFollowPatternSchema = s.Schema{
+	"active":                          c.Bool("active", s.Optional),
+	"remote_cluster":                  c.Str("remote_cluster"),
+	"leader_index_patterns":           c.Ifc("leader_index_patterns", s.Optional),
+	"leader_index_exclusion_patterns": c.Ifc("leader_index_exclusion_patterns", s.Optional),
+	"follow_index_pattern":            c.Str("follow_index_pattern", s.Optional),
+}
+
+// fieldDefaults contains the fields of a follower shard event whose default value, as
+// reported by Elasticsearch before any operation has been replicated, is not zero.
+// Every other numeric field defaults to zero.
+var fieldDefaults = map[string]interface{}{
+	"leader.max_seq_no":          int64(-1),
+	"leader.global_checkpoint":   int64(-1),
+	"follower.max_seq_no":        int64(-1),
+	"follower.global_checkpoint": int64(-1),
+}
+
+// identityFields are never omitted, even if they hold their default value, as they
+// are needed to tell follower shard events apart.
+var identityFields = map[string]bool{
+	"leader.index":          true,
+	"follower.index":        true,
+	"follower.shard.number": true,
+}
+
+// rolloverIndexRegexp matches the names of indices created by rollover, e.g. logs-000042,
+// and of data stream backing indices, e.g. .ds-logs-nginx-default-2023.01.31-000042, whose
+// creation date is stripped along with the generation.
+var rolloverIndexRegexp = regexp.MustCompile(`^(.+?)(-\d{4}\.\d{2}\.\d{2})?-(\d{6,})$`)
+
 type response struct {
 	AutoFollowStats map[string]interface{} `json:"auto_follow_stats"`
 	FollowStats     struct {
 		Indices []struct {
+			Index  string                   `json:"index"`
 			Shards []map[string]interface{} `json:"shards"`
 		} `json:"indices"`
 	} `json:"follow_stats"`
 }
 
-func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+type autoFollowPatternsResponse struct {
+	Patterns []struct {
+		Name    string                 `json:"name"`
+		Pattern map[string]interface{} `json:"pattern"`
+	} `json:"patterns"`
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool, config Config) error {
 	var data response
 	err := json.Unmarshal(content, &data)
 	if err != nil {
@@ -143,6 +185,10 @@ func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isX
 
 	var errs multierror.Errors
 	for _, followerIndex := range data.FollowStats.Indices {
+		if !config.Indices.Matches(followerIndex.Index) {
+			continue
+		}
+
 		for _, followerShard := range followerIndex.Shards {
 			event := mb.Event{}
 			event.RootFields = mapstr.M{}
@@ -157,6 +203,14 @@ func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isX
 			autoFollow, _ := autoFollowSchema.Apply(data.AutoFollowStats)
 			event.MetricSetFields["auto_follow"] = autoFollow
 
+			if config.NormalizeRolloverNames {
+				normalizeRolloverName(event.MetricSetFields)
+			}
+
+			if config.OmitZeroFields {
+				omitDefaultFields(event.MetricSetFields, "")
+			}
+
 			// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
 			// When using Agent, the index name is overwritten anyways.
 			if isXpack {
@@ -168,5 +222,186 @@ func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isX
 		}
 	}
 
+	// The coordinator stats of auto-follow are reported in their own event, along with
+	// the recent errors and the auto-followed clusters.
+	if data.AutoFollowStats != nil {
+		autoFollow, err := autoFollowSchema.Apply(data.AutoFollowStats)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "failure applying auto-follow stats schema"))
+		} else {
+			addAutoFollowDetails(autoFollow, data.AutoFollowStats)
+
+			fields := mapstr.M{"auto_follow": autoFollow}
+			if config.OmitZeroFields {
+				omitDefaultFields(fields, "")
+			}
+			if len(fields) > 0 {
+				r.Event(newEvent(info, fields, isXpack))
+			}
+		}
+	}
+
 	return errs.Err()
 }
+
+// addAutoFollowDetails adds the number of auto-followed clusters and a summary of the
+// recent auto-follow errors to the auto-follow stats.
+func addAutoFollowDetails(autoFollow mapstr.M, stats map[string]interface{}) {
+	clusters, _ := stats["auto_followed_clusters"].([]interface{})
+	recentErrors, _ := stats["recent_auto_follow_errors"].([]interface{})
+
+	var leaderIndices, reasons []string
+	for _, item := range recentErrors {
+		autoFollowError, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if leaderIndex, ok := autoFollowError["leader_index"].(string); ok && leaderIndex != "" {
+			leaderIndices = append(leaderIndices, leaderIndex)
+		}
+		if exception, ok := autoFollowError["auto_follow_exception"].(map[string]interface{}); ok {
+			if reason, ok := exception["reason"].(string); ok && reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+
+	autoFollow.Put("clusters.count", len(clusters))
+	autoFollow.Put("recent_errors.count", len(recentErrors))
+	if len(leaderIndices) > 0 {
+		autoFollow.Put("recent_errors.leader_index", leaderIndices)
+	}
+	if len(reasons) > 0 {
+		autoFollow.Put("recent_errors.reason", reasons)
+	}
+}
+
+// eventsMappingAutoFollowPatterns reports one event per auto-follow pattern, with its
+// configuration as returned by the _ccr/auto_follow API.
+func eventsMappingAutoFollowPatterns(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+	var data autoFollowPatternsResponse
+	if err := json.Unmarshal(content, &data); err != nil {
+		return errors.Wrap(err, "failure parsing Elasticsearch CCR Get Auto-Follow Pattern API response")
+	}
+
+	var errs multierror.Errors
+	for _, pattern := range data.Patterns {
+		fields, err := autoFollowPatternSchema.Apply(pattern.Pattern)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failure applying auto-follow pattern schema for pattern %s", pattern.Name))
+			continue
+		}
+		fields["name"] = pattern.Name
+
+		r.Event(newEvent(info, mapstr.M{"auto_follow_pattern": fields}, isXpack))
+	}
+
+	return errs.Err()
+}
+
+func newEvent(info elasticsearch.Info, fields mapstr.M, isXpack bool) mb.Event {
+	event := mb.Event{
+		RootFields:      mapstr.M{},
+		ModuleFields:    mapstr.M{},
+		MetricSetFields: fields,
+	}
+
+	event.RootFields.Put("service.name", elasticsearch.ModuleName)
+	event.ModuleFields.Put("cluster.name", info.ClusterName)
+	event.ModuleFields.Put("cluster.id", info.ClusterID)
+
+	// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+	// When using Agent, the index name is overwritten anyways.
+	if isXpack {
+		index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+		event.Index = index
+	}
+
+	return event
+}
+
+// omitDefaultFields removes all fields that hold their default value from the given
+// fields, along with any object left empty as a result.
+func omitDefaultFields(fields mapstr.M, prefix string) {
+	for key, value := range fields {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case mapstr.M:
+			omitDefaultFields(v, path)
+			if len(v) == 0 {
+				delete(fields, key)
+			}
+		case map[string]interface{}:
+			omitDefaultFields(v, path)
+			if len(v) == 0 {
+				delete(fields, key)
+			}
+		default:
+			if isDefaultValue(path, value) {
+				delete(fields, key)
+			}
+		}
+	}
+}
+
+func isDefaultValue(path string, value interface{}) bool {
+	if identityFields[path] {
+		return false
+	}
+
+	if defaultValue, found := fieldDefaults[path]; found {
+		return value == defaultValue
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// normalizeRolloverName adds the follower index name without its rollover generation
+// as index_base, and the generation as rollover_generation. Index names that don't
+// look like rollover indices are used as index_base unchanged.
+func normalizeRolloverName(fields mapstr.M) {
+	follower, ok := fields["follower"].(mapstr.M)
+	if !ok {
+		return
+	}
+	index, ok := follower["index"].(string)
+	if !ok || index == "" {
+		return
+	}
+
+	base, generation, found := splitRolloverName(index)
+	fields["index_base"] = base
+	if found {
+		fields["rollover_generation"] = generation
+	}
+}
+
+func splitRolloverName(index string) (base string, generation int64, found bool) {
+	matches := rolloverIndexRegexp.FindStringSubmatch(index)
+	if matches == nil {
+		return index, 0, false
+	}
+
+	base = matches[1]
+	if matches[2] != "" && !strings.HasPrefix(base, ".ds-") {
+		// Only data stream backing indices are expected to contain a date, keep
+		// it for any other index.
+		base += matches[2]
+	}
+
+	generation, err := strconv.ParseInt(matches[3], 10, 64)
+	if err != nil {
+		return index, 0, false
+	}
+	return base, generation, true
+}
@@ -28,8 +28,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/metricbeat/mb"
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
 	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 var info = elasticsearch.Info{
@@ -38,7 +40,9 @@ var info = elasticsearch.Info{
 }
 
 func TestMapper(t *testing.T) {
-	elasticsearch.TestMapperWithInfo(t, "./_meta/test/ccr_stats.*.json", eventsMapping)
+	elasticsearch.TestMapperWithInfo(t, "./_meta/test/ccr_stats.*.json", func(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+		return eventsMapping(r, info, content, isXpack, Config{})
+	})
 }
 
 func TestEmpty(t *testing.T) {
@@ -46,23 +50,216 @@ func TestEmpty(t *testing.T) {
 	require.NoError(t, err)
 
 	reporter := &mbtest.CapturingReporterV2{}
-	eventsMapping(reporter, info, input, true)
+	eventsMapping(reporter, info, input, true, Config{})
 	require.Equal(t, 0, len(reporter.GetErrors()))
 	require.Equal(t, 0, len(reporter.GetEvents()))
 }
 
+func TestOmitZeroFields(t *testing.T) {
+	input, err := ioutil.ReadFile("./_meta/test/ccr_stats.700.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, input, true, Config{OmitZeroFields: true})
+	require.NoError(t, err)
+
+	// One event for the follower shard, one for the auto-follow stats
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+	fields := events[0].MetricSetFields
+
+	// Identity fields are kept even when zero
+	shardNumber, err := fields.GetValue("follower.shard.number")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, shardNumber)
+
+	// Zero counters are dropped, along with objects left empty
+	for _, field := range []string{"requests.failed", "auto_follow.failed"} {
+		hasKey, err := fields.HasKey(field)
+		require.NoError(t, err)
+		require.False(t, hasKey, field)
+	}
+
+	// Non-zero values are kept
+	bytesRead, err := fields.GetValue("bytes_read")
+	require.NoError(t, err)
+	require.EqualValues(t, 32768, bytesRead)
+}
+
+func TestIndicesFilter(t *testing.T) {
+	input, err := ioutil.ReadFile("./_meta/test/ccr_stats.7170.json")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		indices  IndicesConfig
+		expected []string
+	}{
+		"no filter": {
+			expected: []string{"logs-000001", "logs-000002", "logs-debug-000001", "metrics-000001"},
+		},
+		"include": {
+			indices:  IndicesConfig{Include: []string{"logs-*"}},
+			expected: []string{"logs-000001", "logs-000002", "logs-debug-000001"},
+		},
+		"exclude": {
+			indices:  IndicesConfig{Exclude: []string{"logs-debug-*", "metrics-*"}},
+			expected: []string{"logs-000001", "logs-000002"},
+		},
+		"include and exclude": {
+			indices:  IndicesConfig{Include: []string{"logs-*"}, Exclude: []string{"logs-debug-*"}},
+			expected: []string{"logs-000001", "logs-000002"},
+		},
+		"no match": {
+			indices: IndicesConfig{Include: []string{"traces-*"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reporter := &mbtest.CapturingReporterV2{}
+			err := eventsMapping(reporter, info, input, true, Config{Indices: test.indices})
+			require.NoError(t, err)
+
+			var indices []string
+			for _, event := range reporter.GetEvents() {
+				index, err := event.MetricSetFields.GetValue("follower.index")
+				if err != nil {
+					// Auto-follow stats event
+					continue
+				}
+				indices = append(indices, index.(string))
+			}
+			require.Equal(t, test.expected, indices)
+		})
+	}
+}
+
+func TestIndicesConfigValidate(t *testing.T) {
+	require.NoError(t, IndicesConfig{Include: []string{"logs-*"}, Exclude: []string{"logs-[0-9]*"}}.Validate())
+	require.Error(t, IndicesConfig{Exclude: []string{"logs-["}}.Validate())
+}
+
+func TestAutoFollowStats(t *testing.T) {
+	input, err := ioutil.ReadFile("./_meta/test/ccr_stats.7100.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, input, true, Config{})
+	require.NoError(t, err)
+
+	// No follower shard, only the auto-follow stats
+	events := reporter.GetEvents()
+	require.Len(t, events, 1)
+
+	require.Equal(t, mapstr.M{
+		"auto_follow": mapstr.M{
+			"failed": mapstr.M{
+				"follow_indices":                mapstr.M{"count": int64(1)},
+				"remote_cluster_state_requests": mapstr.M{"count": int64(2)},
+			},
+			"success": mapstr.M{
+				"follow_indices": mapstr.M{"count": int64(3)},
+			},
+			"clusters": mapstr.M{"count": 1},
+			"recent_errors": mapstr.M{
+				"count":        2,
+				"leader_index": []string{"logs-000004", "logs-000005"},
+				"reason": []string{
+					"leader index [logs-000004] does not have soft deletes enabled",
+					"leader index [logs-000005] does not have soft deletes enabled",
+				},
+			},
+		},
+	}, events[0].MetricSetFields)
+}
+
+func TestAutoFollowPatterns(t *testing.T) {
+	input, err := ioutil.ReadFile("./_meta/test/auto_follow.7100.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMappingAutoFollowPatterns(reporter, info, input, true)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	require.Equal(t, mapstr.M{
+		"auto_follow_pattern": mapstr.M{
+			"name":                            "logs_pattern",
+			"active":                          true,
+			"remote_cluster":                  "remote_cluster",
+			"leader_index_patterns":           []interface{}{"logs-*"},
+			"leader_index_exclusion_patterns": []interface{}{"logs-debug-*"},
+			"follow_index_pattern":            "{{leader_index}}-follower",
+		},
+	}, events[0].MetricSetFields)
+
+	active, err := events[1].MetricSetFields.GetValue("auto_follow_pattern.active")
+	require.NoError(t, err)
+	require.Equal(t, false, active)
+}
+
 func TestData(t *testing.T) {
 	mux := createEsMuxer("7.6.0", "platinum", true)
 	mux.Handle("/_ccr/stats", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		input, _ := ioutil.ReadFile("./_meta/test/ccr_stats.700.json")
 		w.Write(input)
 	}))
+	mux.Handle("/_ccr/auto_follow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/auto_follow.7100.json")
+		w.Write(input)
+	}))
 
 	server := httptest.NewServer(mux)
 	defer server.Close()
 
-	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
-	if err := mbtest.WriteEventsReporterV2Error(ms, t, ""); err != nil {
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
 		t.Fatal("write", err)
 	}
 }
+
+func TestSplitRolloverName(t *testing.T) {
+	tests := []struct {
+		index      string
+		base       string
+		generation int64
+		found      bool
+	}{
+		{"logs-000042", "logs", 42, true},
+		{"logs-app-prod-1000001", "logs-app-prod", 1000001, true},
+		{".ds-logs-nginx-default-2023.01.31-000004", ".ds-logs-nginx-default", 4, true},
+		{"metrics-2023.01.31-000002", "metrics-2023.01.31", 2, true},
+		{"follower_index", "follower_index", 0, false},
+		{"logs-42", "logs-42", 0, false},
+		{"000001", "000001", 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.index, func(t *testing.T) {
+			base, generation, found := splitRolloverName(test.index)
+			require.Equal(t, test.base, base)
+			require.Equal(t, test.generation, generation)
+			require.Equal(t, test.found, found)
+		})
+	}
+}
+
+func TestNormalizeRolloverNames(t *testing.T) {
+	input, err := ioutil.ReadFile("./_meta/test/ccr_stats.700.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, input, true, Config{NormalizeRolloverNames: true})
+	require.NoError(t, err)
+
+	// One event for the follower shard, one for the auto-follow stats
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+	fields := events[0].MetricSetFields
+
+	// follower_index doesn't match the rollover pattern and is used unchanged
+	require.Equal(t, "follower_index", fields["index_base"])
+	require.NotContains(t, fields, "rollover_generation")
+}
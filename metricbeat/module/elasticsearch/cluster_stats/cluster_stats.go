@@ -18,6 +18,7 @@
 package cluster_stats
 
 import (
+	"context"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
 )
@@ -48,8 +49,8 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 }
 
 // Fetch methods implements the data gathering and data conversion to the right format
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	shouldSkip, err := m.ShouldSkipFetch()
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
 	if err != nil {
 		return err
 	}
@@ -57,15 +58,15 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.HTTP.FetchContentWithContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.HostData().SanitizedURI+clusterStatsPath)
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.HostData().SanitizedURI+clusterStatsPath)
 	if err != nil {
 		return err
 	}
 
-	return eventMapping(r, m.HTTP, *info, content, m.XPackEnabled)
+	return eventMapping(ctx, r, m.HTTP, *info, content, m.XPackEnabled)
 }
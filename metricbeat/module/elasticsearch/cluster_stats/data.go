@@ -18,6 +18,7 @@
 package cluster_stats
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
@@ -200,10 +201,10 @@ func apmIndicesExist(clusterState mapstr.M) (bool, error) {
 	return false, nil
 }
 
-func getClusterMetadataSettings(httpClient *helper.HTTP) (mapstr.M, error) {
+func getClusterMetadataSettings(ctx context.Context, httpClient *helper.HTTP) (mapstr.M, error) {
 	// For security reasons we only get the display_name setting
 	filterPaths := []string{"*.cluster.metadata.display_name"}
-	clusterSettings, err := elasticsearch.GetClusterSettingsWithDefaults(httpClient, httpClient.GetURI(), filterPaths)
+	clusterSettings, err := elasticsearch.GetClusterSettingsWithDefaults(ctx, httpClient, httpClient.GetURI(), filterPaths)
 	if err != nil {
 		return nil, errors.Wrap(err, "failure to get cluster settings")
 	}
@@ -216,7 +217,7 @@ func getClusterMetadataSettings(httpClient *helper.HTTP) (mapstr.M, error) {
 	return clusterSettings, nil
 }
 
-func eventMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.Info, content []byte, isXpack bool) error {
+func eventMapping(ctx context.Context, r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.Info, content []byte, isXpack bool) error {
 	var data map[string]interface{}
 	err := json.Unmarshal(content, &data)
 	if err != nil {
@@ -226,13 +227,13 @@ func eventMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.I
 	clusterStats := mapstr.M(data)
 	clusterStats.Delete("_nodes")
 
-	license, err := elasticsearch.GetLicense(httpClient, httpClient.GetURI())
+	license, err := elasticsearch.GetLicense(ctx, httpClient, httpClient.GetURI())
 	if err != nil {
 		return errors.Wrap(err, "failed to get license from Elasticsearch")
 	}
 
 	clusterStateMetrics := []string{"version", "master_node", "nodes", "routing_table"}
-	clusterState, err := elasticsearch.GetClusterState(httpClient, httpClient.GetURI(), clusterStateMetrics)
+	clusterState, err := elasticsearch.GetClusterState(ctx, httpClient, httpClient.GetURI(), clusterStateMetrics)
 	if err != nil {
 		return errors.Wrap(err, "failed to get cluster state from Elasticsearch")
 	}
@@ -262,7 +263,7 @@ func eventMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.I
 	}
 	clusterStateReduced.Put("nodes_hash", nodesHash)
 
-	usage, err := elasticsearch.GetStackUsage(httpClient, httpClient.GetURI())
+	usage, err := elasticsearch.GetStackUsage(ctx, httpClient, httpClient.GetURI())
 	if err != nil {
 		return errors.Wrap(err, "failed to get stack usage from Elasticsearch")
 	}
@@ -296,7 +297,7 @@ func eventMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.I
 	event.ModuleFields.Put("cluster.name", info.ClusterName)
 	event.ModuleFields.Put("cluster.id", info.ClusterID)
 
-	clusterSettings, err := getClusterMetadataSettings(httpClient)
+	clusterSettings, err := getClusterMetadataSettings(ctx, httpClient)
 	if err != nil {
 		return err
 	}
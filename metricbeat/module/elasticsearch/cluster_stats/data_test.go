@@ -21,6 +21,7 @@
 package cluster_stats
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -101,7 +102,9 @@ func TestMapper(t *testing.T) {
 	require.NoError(t, err)
 
 	elasticsearch.TestMapperWithHttpHelper(t, "./_meta/test/cluster_stats.*.json",
-		httpHelper, eventMapping)
+		httpHelper, func(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.Info, content []byte, isXpack bool) error {
+			return eventMapping(context.Background(), r, httpClient, info, content, isXpack)
+		})
 }
 
 func TestData(t *testing.T) {
@@ -110,8 +113,8 @@ func TestData(t *testing.T) {
 	server := httptest.NewServer(mux)
 	defer server.Close()
 
-	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
-	if err := mbtest.WriteEventsReporterV2Error(ms, t, ""); err != nil {
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
 		t.Fatal("write", err)
 	}
 }
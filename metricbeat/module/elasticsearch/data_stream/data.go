@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package data_stream
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type stats struct {
+	DataStreams []dataStream `json:"data_streams"`
+}
+
+type dataStream struct {
+	Name             string `json:"data_stream"`
+	BackingIndices   int    `json:"backing_indices"`
+	StoreSizeBytes   int64  `json:"store_size_bytes"`
+	MaximumTimestamp int64  `json:"maximum_timestamp"`
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+	var dataStreamStats stats
+	if err := json.Unmarshal(content, &dataStreamStats); err != nil {
+		return fmt.Errorf("failure parsing Data Stream Stats Elasticsearch API response: %w", err)
+	}
+
+	var errs multierror.Errors
+	for _, ds := range dataStreamStats.DataStreams {
+		if ds.Name == "" {
+			errs = append(errs, elastic.MakeErrorForMissingField("data_streams.data_stream", elastic.Elasticsearch))
+			continue
+		}
+
+		event := mb.Event{
+			ModuleFields: mapstr.M{},
+			MetricSetFields: mapstr.M{
+				"name":            ds.Name,
+				"backing_indices": ds.BackingIndices,
+				"store_size": mapstr.M{
+					"bytes": ds.StoreSizeBytes,
+				},
+				"maximum_timestamp": ds.MaximumTimestamp,
+			},
+		}
+
+		event.ModuleFields.Put("cluster.id", info.ClusterID)
+		event.ModuleFields.Put("cluster.name", info.ClusterName)
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return errs.Err()
+}
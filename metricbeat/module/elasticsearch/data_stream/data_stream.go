@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package data_stream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "data_stream", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	// Hidden data streams, such as the ones of Fleet, are only reported if they are explicitly expanded
+	dataStreamStatsPath = "/_data_stream/_stats?expand_wildcards=open,hidden"
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	lastDataStreamMessageTimestamp time.Time
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := elasticsearch.NewMetricSet(base, dataStreamStatsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch gathers stats for each data stream from the _data_stream/_stats API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	dataStreamUnavailableMessage := m.checkDataStreamAvailability(info.Version.Number)
+	if dataStreamUnavailableMessage != "" {
+		if time.Since(m.lastDataStreamMessageTimestamp) > 10*time.Minute {
+			m.lastDataStreamMessageTimestamp = time.Now()
+			m.Logger().Debug(dataStreamUnavailableMessage)
+		}
+		return nil
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching data stream stats: %w", err)
+	}
+
+	return eventsMapping(r, *info, content, m.XPackEnabled)
+}
+
+func (m *MetricSet) checkDataStreamAvailability(currentElasticsearchVersion *version.V) string {
+	isAvailable := elastic.IsFeatureAvailable(currentElasticsearchVersion, elasticsearch.DataStreamStatsAPIAvailableVersion)
+
+	if !isAvailable {
+		metricsetName := m.FullyQualifiedName()
+		return "the " + metricsetName + " is only supported with Elasticsearch >= " +
+			elasticsearch.DataStreamStatsAPIAvailableVersion.String() + ". " +
+			"You are currently running Elasticsearch " + currentElasticsearchVersion.String() + "."
+	}
+
+	return ""
+}
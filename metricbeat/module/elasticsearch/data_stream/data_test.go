@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package data_stream
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/data_stream_stats.710.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, true)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	event := events[0]
+	require.Equal(t, elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch), event.Index)
+	require.Equal(t, mapstr.M{
+		"name":            "logs-nginx.access-default",
+		"backing_indices": 3,
+		"store_size": mapstr.M{
+			"bytes": int64(3988),
+		},
+		"maximum_timestamp": int64(1607512028000),
+	}, event.MetricSetFields)
+
+	clusterID, err := event.ModuleFields.GetValue("cluster.id")
+	require.NoError(t, err)
+	require.Equal(t, "1234", clusterID)
+}
+
+func TestEmpty(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/data_stream_stats.empty.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, false)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetEvents())
+}
+
+func TestData(t *testing.T) {
+	mux := createEsMuxer("7.10.0")
+	mux.Handle("/_data_stream/_stats", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/data_stream_stats.710.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
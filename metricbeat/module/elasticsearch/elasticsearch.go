@@ -18,6 +18,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -56,7 +57,35 @@ func NewModule(base mb.BaseModule) (mb.Module, error) {
 		"node_stats",
 		"shard",
 	}
-	return elastic.NewModule(&base, xpackEnabledMetricSets, logp.NewLogger(ModuleName))
+	newBase, err := elastic.NewModule(&base, xpackEnabledMetricSets, logp.NewLogger(ModuleName))
+	if err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		AvailabilityCacheTTL time.Duration        `config:"availability_cache_ttl"`
+		LeaderElection       leaderElectionConfig `config:"leader_election"`
+	}{
+		AvailabilityCacheTTL: defaultAvailabilityCacheTTL,
+		LeaderElection:       defaultLeaderElectionConfig(),
+	}
+	if err := newBase.UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	m := &module{
+		BaseModule:   *newBase,
+		availability: newAvailabilityCache(config.AvailabilityCacheTTL),
+	}
+
+	if config.LeaderElection.Enabled {
+		m.leader, err = newLeaderElector(config.LeaderElection)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
 }
 
 var (
@@ -66,6 +95,28 @@ var (
 	// EnrichStatsAPIAvailableVersion is the version of Elasticsearch since when the Enrich stats API is available.
 	EnrichStatsAPIAvailableVersion = version.MustNew("7.5.0")
 
+	// SLMStatsAPIAvailableVersion is the version of Elasticsearch since when the SLM stats API is available.
+	SLMStatsAPIAvailableVersion = version.MustNew("7.5.0")
+
+	// ILMAPIAvailableVersion is the version of Elasticsearch since when the ILM status and explain APIs are available.
+	ILMAPIAvailableVersion = version.MustNew("6.6.0")
+
+	// DataStreamStatsAPIAvailableVersion is the version of Elasticsearch since when the data stream stats API is available.
+	DataStreamStatsAPIAvailableVersion = version.MustNew("7.9.0")
+
+	// HealthReportAPIAvailableVersion is the version of Elasticsearch since when the health report API is available.
+	HealthReportAPIAvailableVersion = version.MustNew("8.7.0")
+
+	// SearchableSnapshotsCacheStatsAPIAvailableVersion is the version of Elasticsearch since when the searchable
+	// snapshots cache stats API is available.
+	SearchableSnapshotsCacheStatsAPIAvailableVersion = version.MustNew("7.13.0")
+
+	// TransformStatsAPIAvailableVersion is the version of Elasticsearch since when the transform stats API is available.
+	TransformStatsAPIAvailableVersion = version.MustNew("7.5.0")
+
+	// ResolveClusterAPIAvailableVersion is the version of Elasticsearch since when the resolve cluster API is available.
+	ResolveClusterAPIAvailableVersion = version.MustNew("8.13.0")
+
 	// BulkStatsAvailableVersion is the version since when bulk indexing stats are available
 	BulkStatsAvailableVersion = version.MustNew("8.0.0")
 
@@ -80,6 +131,22 @@ var (
 // ModuleName is the name of this module.
 const ModuleName = "elasticsearch"
 
+const serverlessBuildFlavor = "serverless"
+
+// serverlessMetricSets are the metricsets relying only on APIs that are available in
+// Elasticsearch Serverless.
+var serverlessMetricSets = map[string]bool{
+	"data_stream": true,
+	"ml_job":      true,
+	"transform":   true,
+}
+
+// IsServerlessCompatible returns true if the given metricset can be used against
+// Elasticsearch Serverless
+func IsServerlessCompatible(metricSetName string) bool {
+	return serverlessMetricSets[metricSetName]
+}
+
 // Info construct contains the data from the Elasticsearch / endpoint
 type Info struct {
 	ClusterName string  `json:"cluster_name"`
@@ -90,7 +157,13 @@ type Info struct {
 
 // Version contains the semver formatted version of ES
 type Version struct {
-	Number *version.V `json:"number"`
+	Number      *version.V `json:"number"`
+	BuildFlavor string     `json:"build_flavor"`
+}
+
+// IsServerless returns true if the Elasticsearch / endpoint reports a serverless build
+func (i *Info) IsServerless() bool {
+	return i.Version.BuildFlavor == serverlessBuildFlavor
 }
 
 // NodeInfo struct cotains data about the node.
@@ -123,13 +196,13 @@ type licenseWrapper struct {
 }
 
 // GetClusterID fetches cluster id for given nodeID.
-func GetClusterID(http *helper.HTTP, uri string, nodeID string) (string, error) {
+func GetClusterID(ctx context.Context, http *helper.HTTP, uri string, nodeID string) (string, error) {
 	// Check if cluster id already cached. If yes, return it.
 	if clusterID, ok := clusterIDCache[nodeID]; ok {
 		return clusterID, nil
 	}
 
-	info, err := GetInfo(http, uri)
+	info, err := GetInfo(ctx, http, uri)
 	if err != nil {
 		return "", err
 	}
@@ -145,14 +218,14 @@ func GetClusterID(http *helper.HTTP, uri string, nodeID string) (string, error)
 // * Fetch current master name from cluster state /_cluster/state/master_node
 //
 // The two names are compared
-func isMaster(http *helper.HTTP, uri string) (bool, error) {
+func isMaster(ctx context.Context, http *helper.HTTP, uri string) (bool, error) {
 
-	node, err := getNodeName(http, uri)
+	node, err := getNodeName(ctx, http, uri)
 	if err != nil {
 		return false, err
 	}
 
-	master, err := getMasterName(http, uri)
+	master, err := getMasterName(ctx, http, uri)
 	if err != nil {
 		return false, err
 	}
@@ -160,8 +233,8 @@ func isMaster(http *helper.HTTP, uri string) (bool, error) {
 	return master == node, nil
 }
 
-func getNodeName(http *helper.HTTP, uri string) (string, error) {
-	content, err := fetchPath(http, uri, "/_nodes/_local/nodes", "")
+func getNodeName(ctx context.Context, http *helper.HTTP, uri string) (string, error) {
+	content, err := fetchPath(ctx, http, uri, "/_nodes/_local/nodes", "")
 	if err != nil {
 		return "", err
 	}
@@ -179,9 +252,9 @@ func getNodeName(http *helper.HTTP, uri string) (string, error) {
 	return "", fmt.Errorf("No local node found")
 }
 
-func getMasterName(http *helper.HTTP, uri string) (string, error) {
+func getMasterName(ctx context.Context, http *helper.HTTP, uri string) (string, error) {
 	// TODO: evaluate on why when run with ?local=true request does not contain master_node field
-	content, err := fetchPath(http, uri, "_cluster/state/master_node", "")
+	content, err := fetchPath(ctx, http, uri, "_cluster/state/master_node", "")
 	if err != nil {
 		return "", err
 	}
@@ -196,10 +269,14 @@ func getMasterName(http *helper.HTTP, uri string) (string, error) {
 }
 
 // GetInfo returns the data for the Elasticsearch / endpoint.
-func GetInfo(http *helper.HTTP, uri string) (*Info, error) {
-
-	content, err := fetchPath(http, uri, "/", "")
+func GetInfo(ctx context.Context, http *helper.HTTP, uri string) (*Info, error) {
+	content, err := fetchPathWithRetry(ctx, http, uri, "/", "")
 	if err != nil {
+		// Use the last known information while the cluster is temporarily unavailable
+		if info := infoCache.get(uri); info != nil {
+			logp.NewLogger(ModuleName).Warnf("Using previously fetched Elasticsearch info, fetching it failed: %v", err)
+			return info, nil
+		}
 		return nil, err
 	}
 
@@ -209,10 +286,41 @@ func GetInfo(http *helper.HTTP, uri string) (*Info, error) {
 		return nil, err
 	}
 
+	infoCache.set(uri, info)
 	return info, nil
 }
 
-func fetchPath(http *helper.HTTP, uri, path string, query string) ([]byte, error) {
+// _infoCache keeps the last known information of each host, to be used when it can't be
+// fetched again.
+type _infoCache struct {
+	sync.Mutex
+	entries map[string]infoEntry
+}
+
+type infoEntry struct {
+	info      *Info
+	fetchedOn time.Time
+}
+
+func (c *_infoCache) get(uri string) *Info {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[hostKey(uri)]
+	if !found || time.Since(entry.fetchedOn) > staleAvailabilityMaxAge {
+		return nil
+	}
+	return entry.info
+}
+
+func (c *_infoCache) set(uri string, info *Info) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries[hostKey(uri)] = infoEntry{info: info, fetchedOn: time.Now()}
+}
+
+func fetchPath(ctx context.Context, http *helper.HTTP, uri, path string, query string) ([]byte, error) {
 	defer http.SetURI(uri)
 
 	// Parses the uri to replace the path
@@ -222,13 +330,13 @@ func fetchPath(http *helper.HTTP, uri, path string, query string) ([]byte, error
 
 	// Http helper includes the HostData with username and password
 	http.SetURI(u.String())
-	return http.FetchContent()
+	return http.FetchContentWithContext(ctx)
 }
 
 // GetNodeInfo returns the node information.
-func GetNodeInfo(http *helper.HTTP, uri string, nodeID string) (*NodeInfo, error) {
+func GetNodeInfo(ctx context.Context, http *helper.HTTP, uri string, nodeID string) (*NodeInfo, error) {
 
-	content, err := fetchPath(http, uri, "/_nodes/_local/nodes", "")
+	content, err := fetchPath(ctx, http, uri, "/_nodes/_local/nodes", "")
 	if err != nil {
 		return nil, err
 	}
@@ -253,7 +361,7 @@ func GetNodeInfo(http *helper.HTTP, uri string, nodeID string) (*NodeInfo, error
 // GetLicense returns license information. Since we don't expect license information
 // to change frequently, the information is cached for 1 minute to avoid
 // hitting Elasticsearch frequently.
-func GetLicense(http *helper.HTTP, resetURI string) (*License, error) {
+func GetLicense(ctx context.Context, http *helper.HTTP, resetURI string) (*License, error) {
 	// First, check the cache
 	license := licenseCache.get()
 
@@ -263,7 +371,23 @@ func GetLicense(http *helper.HTTP, resetURI string) (*License, error) {
 	}
 
 	// License not found in cache, fetch it from Elasticsearch
-	content, err := fetchPath(http, resetURI, "_license", "")
+	license, err := fetchLicense(ctx, http, resetURI)
+	if err != nil {
+		// Use the expired license while the cluster is temporarily unavailable
+		if license := licenseCache.stale(); license != nil {
+			return license, nil
+		}
+		return nil, err
+	}
+
+	// Cache license for a minute
+	licenseCache.set(license, time.Minute)
+
+	return license, nil
+}
+
+func fetchLicense(ctx context.Context, http *helper.HTTP, resetURI string) (*License, error) {
+	content, err := fetchPathWithRetry(ctx, http, resetURI, "/_license", "")
 	if err != nil {
 		return nil, err
 	}
@@ -274,21 +398,17 @@ func GetLicense(http *helper.HTTP, resetURI string) (*License, error) {
 		return nil, err
 	}
 
-	// Cache license for a minute
-	license = &data.License
-	licenseCache.set(license, time.Minute)
-
-	return license, nil
+	return &data.License, nil
 }
 
 // GetClusterState returns cluster state information.
-func GetClusterState(http *helper.HTTP, resetURI string, metrics []string) (mapstr.M, error) {
+func GetClusterState(ctx context.Context, http *helper.HTTP, resetURI string, metrics []string) (mapstr.M, error) {
 	clusterStateURI := "_cluster/state"
 	if metrics != nil && len(metrics) > 0 {
 		clusterStateURI += "/" + strings.Join(metrics, ",")
 	}
 
-	content, err := fetchPath(http, resetURI, clusterStateURI, "")
+	content, err := fetchPath(ctx, http, resetURI, clusterStateURI, "")
 	if err != nil {
 		return nil, err
 	}
@@ -299,12 +419,12 @@ func GetClusterState(http *helper.HTTP, resetURI string, metrics []string) (maps
 }
 
 // GetClusterSettingsWithDefaults returns cluster settings.
-func GetClusterSettingsWithDefaults(http *helper.HTTP, resetURI string, filterPaths []string) (mapstr.M, error) {
-	return GetClusterSettings(http, resetURI, true, filterPaths)
+func GetClusterSettingsWithDefaults(ctx context.Context, http *helper.HTTP, resetURI string, filterPaths []string) (mapstr.M, error) {
+	return GetClusterSettings(ctx, http, resetURI, true, filterPaths)
 }
 
 // GetClusterSettings returns cluster settings
-func GetClusterSettings(http *helper.HTTP, resetURI string, includeDefaults bool, filterPaths []string) (mapstr.M, error) {
+func GetClusterSettings(ctx context.Context, http *helper.HTTP, resetURI string, includeDefaults bool, filterPaths []string) (mapstr.M, error) {
 	clusterSettingsURI := "_cluster/settings"
 	var queryParams []string
 	if includeDefaults {
@@ -318,7 +438,7 @@ func GetClusterSettings(http *helper.HTTP, resetURI string, includeDefaults bool
 
 	queryString := strings.Join(queryParams, "&")
 
-	content, err := fetchPath(http, resetURI, clusterSettingsURI, queryString)
+	content, err := fetchPath(ctx, http, resetURI, clusterSettingsURI, queryString)
 	if err != nil {
 		return nil, err
 	}
@@ -329,8 +449,8 @@ func GetClusterSettings(http *helper.HTTP, resetURI string, includeDefaults bool
 }
 
 // GetStackUsage returns stack usage information.
-func GetStackUsage(http *helper.HTTP, resetURI string) (map[string]interface{}, error) {
-	content, err := fetchPath(http, resetURI, "_xpack/usage", "")
+func GetStackUsage(ctx context.Context, http *helper.HTTP, resetURI string) (map[string]interface{}, error) {
+	content, err := fetchPath(ctx, http, resetURI, "_xpack/usage", "")
 	if err != nil {
 		return nil, err
 	}
@@ -345,12 +465,28 @@ type XPack struct {
 		CCR struct {
 			Enabled bool `json:"enabled"`
 		} `json:"CCR"`
+		SLM struct {
+			Enabled bool `json:"enabled"`
+		} `json:"slm"`
+		ILM struct {
+			Enabled bool `json:"enabled"`
+		} `json:"ilm"`
+		SearchableSnapshots struct {
+			Enabled bool `json:"enabled"`
+		} `json:"searchable_snapshots"`
+		Transform struct {
+			Enabled bool `json:"enabled"`
+		} `json:"transform"`
 	} `json:"features"`
 }
 
 // GetXPack returns information about xpack features.
-func GetXPack(http *helper.HTTP, resetURI string) (XPack, error) {
-	content, err := fetchPath(http, resetURI, "_xpack", "")
+func GetXPack(ctx context.Context, http *helper.HTTP, resetURI string) (XPack, error) {
+	return fetchXPack(ctx, http, resetURI)
+}
+
+func fetchXPack(ctx context.Context, http *helper.HTTP, resetURI string) (XPack, error) {
+	content, err := fetchPathWithRetry(ctx, http, resetURI, "/_xpack", "")
 
 	if err != nil {
 		return XPack{}, err
@@ -386,8 +522,8 @@ type IndexSettings struct {
 // GetIndicesSettings returns a map of index names to their settings.
 // Note that as of now it is optimized to fetch only the "hidden" index setting to keep the memory
 // footprint of this function call as low as possible.
-func GetIndicesSettings(http *helper.HTTP, resetURI string) (map[string]IndexSettings, error) {
-	content, err := fetchPath(http, resetURI, "*/_settings", "filter_path=*.settings.index.hidden&expand_wildcards=all")
+func GetIndicesSettings(ctx context.Context, http *helper.HTTP, resetURI string) (map[string]IndexSettings, error) {
+	content, err := fetchPath(ctx, http, resetURI, "*/_settings", "filter_path=*.settings.index.hidden&expand_wildcards=all")
 
 	if err != nil {
 		return nil, errors.Wrap(err, "could not fetch indices settings")
@@ -417,8 +553,8 @@ func GetIndicesSettings(http *helper.HTTP, resetURI string) (map[string]IndexSet
 }
 
 // IsMLockAllEnabled returns if the given Elasticsearch node has mlockall enabled
-func IsMLockAllEnabled(http *helper.HTTP, resetURI, nodeID string) (bool, error) {
-	content, err := fetchPath(http, resetURI, "_nodes/"+nodeID, "filter_path=nodes.*.process.mlockall")
+func IsMLockAllEnabled(ctx context.Context, http *helper.HTTP, resetURI, nodeID string) (bool, error) {
+	content, err := fetchPath(ctx, http, resetURI, "_nodes/"+nodeID, "filter_path=nodes.*.process.mlockall")
 	if err != nil {
 		return false, err
 	}
@@ -438,8 +574,8 @@ func IsMLockAllEnabled(http *helper.HTTP, resetURI, nodeID string) (bool, error)
 }
 
 // GetMasterNodeID returns the ID of the Elasticsearch cluster's master node
-func GetMasterNodeID(http *helper.HTTP, resetURI string) (string, error) {
-	content, err := fetchPath(http, resetURI, "_nodes/_master", "filter_path=nodes.*.name")
+func GetMasterNodeID(ctx context.Context, http *helper.HTTP, resetURI string) (string, error) {
+	content, err := fetchPath(ctx, http, resetURI, "_nodes/_master", "filter_path=nodes.*.name")
 	if err != nil {
 		return "", err
 	}
@@ -516,6 +652,9 @@ var (
 	// Global cache for license information. Assumption is that license information changes infrequently.
 	licenseCache = &_licenseCache{}
 
+	// Global cache for the information of the hosts, only used when it can't be fetched.
+	infoCache = &_infoCache{entries: map[string]infoEntry{}}
+
 	// LicenseCacheEnabled controls whether license caching is enabled or not. Intended for test use.
 	LicenseCacheEnabled = true
 )
@@ -532,8 +671,21 @@ func (c *_licenseCache) get() *License {
 	defer c.Unlock()
 
 	if time.Since(c.cachedOn) > c.ttl {
-		// We are past the TTL, so invalidate cache
-		c.license = nil
+		// We are past the TTL, the license must be fetched again
+		return nil
+	}
+
+	return c.license
+}
+
+// stale returns the cached license even if it is past its TTL, as long as it is not
+// older than staleAvailabilityMaxAge.
+func (c *_licenseCache) stale() *License {
+	c.Lock()
+	defer c.Unlock()
+
+	if time.Since(c.cachedOn) > staleAvailabilityMaxAge {
+		return nil
 	}
 
 	return c.license
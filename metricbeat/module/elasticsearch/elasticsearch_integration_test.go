@@ -39,31 +39,51 @@ import (
 	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
 	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/allocation_explain"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ccr"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/cluster_stats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/data_stream"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/enrich"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/health_report"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ilm"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/index"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/index_recovery"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/index_summary"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ingest_pipeline"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/ml_job"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/node_stats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/node_usage"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/remote_clusters"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/searchable_snapshots"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/shard"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/slm"
+	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/transform"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/version"
 )
 
 var metricSets = []string{
+	"allocation_explain",
 	"ccr",
 	"cluster_stats",
+	"data_stream",
 	"enrich",
+	"health_report",
+	"ilm",
 	"index",
 	"index_recovery",
 	"index_summary",
+	"ingest_pipeline",
 	"ml_job",
 	"node",
 	"node_stats",
+	"node_usage",
+	"remote_clusters",
+	"searchable_snapshots",
 	"shard",
+	"slm",
+	"transform",
 }
 
 func TestFetch(t *testing.T) {
@@ -78,8 +98,8 @@ func TestFetch(t *testing.T) {
 	for _, metricSet := range metricSets {
 		t.Run(metricSet, func(t *testing.T) {
 			checkSkip(t, metricSet, version)
-			f := mbtest.NewReportingMetricSetV2Error(t, getConfigForMetricset(metricSet, host))
-			events, errs := mbtest.ReportingFetchV2Error(f)
+			f := mbtest.NewReportingMetricSetV2WithContext(t, getConfigForMetricset(metricSet, host))
+			events, errs := mbtest.ReportingFetchV2WithContext(f)
 
 			require.Empty(t, errs)
 			require.NotEmpty(t, events)
@@ -100,8 +120,8 @@ func TestData(t *testing.T) {
 	for _, metricSet := range metricSets {
 		t.Run(metricSet, func(t *testing.T) {
 			checkSkip(t, metricSet, version)
-			f := mbtest.NewReportingMetricSetV2Error(t, getConfigForMetricset(metricSet, host))
-			err := mbtest.WriteEventsReporterV2Error(f, t, metricSet)
+			f := mbtest.NewReportingMetricSetV2WithContext(t, getConfigForMetricset(metricSet, host))
+			err := mbtest.WriteEventsReporterV2WithContext(f, t, metricSet)
 			require.NoError(t, err)
 		})
 	}
@@ -120,14 +140,14 @@ func TestGetAllIndices(t *testing.T) {
 
 	config := getConfig(host)
 
-	metricSets := mbtest.NewReportingMetricSetV2Errors(t, config)
+	metricSets := mbtest.NewReportingMetricSetV2WithContexts(t, config)
 	for _, metricSet := range metricSets {
 		// We only care about the index metricset for this test
 		if metricSet.Name() != "index" {
 			continue
 		}
 
-		events, errs := mbtest.ReportingFetchV2Error(metricSet)
+		events, errs := mbtest.ReportingFetchV2WithContext(metricSet)
 
 		require.Empty(t, errs)
 		require.NotEmpty(t, events)
@@ -196,6 +216,12 @@ func setupTest(t *testing.T, esHost string, esVersion *version.V) {
 
 	err = createEnrichStats(esHost)
 	require.NoError(t, err)
+
+	err = createDataStream(esHost, esVersion)
+	require.NoError(t, err)
+
+	err = createTransform(esHost, esVersion)
+	require.NoError(t, err)
 }
 
 // createIndex creates an random elasticsearch index
@@ -516,6 +542,33 @@ func ingestAndEnrichDoc(host string) error {
 	return err
 }
 
+// createDataStream creates a data stream matching the built-in metrics-*-* index template
+func createDataStream(host string, version *version.V) error {
+	if !elastic.IsFeatureAvailable(version, elasticsearch.DataStreamStatsAPIAvailableVersion) {
+		return nil
+	}
+
+	dataStreamURL := "/_data_stream/metrics-metricbeat.test-default"
+	_, _, err := httpPutJSON(host, dataStreamURL, nil)
+	return err
+}
+
+// createTransform creates a transform pivoting the source index of the enrich policy
+func createTransform(host string, version *version.V) error {
+	if !elastic.IsFeatureAvailable(version, elasticsearch.TransformStatsAPIAvailableVersion) {
+		return nil
+	}
+
+	transform, err := ioutil.ReadFile("transform/_meta/test/test_transform.json")
+	if err != nil {
+		return err
+	}
+
+	transformURL := "/_transform/users_per_state"
+	_, _, err = httpPutJSON(host, transformURL, transform)
+	return err
+}
+
 func countIndices(elasticsearchHostPort string) (int, error) {
 	return countCatItems(elasticsearchHostPort, "indices", "&expand_wildcards=open,hidden")
 }
@@ -558,6 +611,18 @@ func checkSkip(t *testing.T, metricset string, ver *version.V) {
 		checkSkipFeature("CCR", elasticsearch.CCRStatsAPIAvailableVersion)
 	case "enrich":
 		checkSkipFeature("Enrich", elasticsearch.EnrichStatsAPIAvailableVersion)
+	case "data_stream":
+		checkSkipFeature("Data stream", elasticsearch.DataStreamStatsAPIAvailableVersion)
+	case "health_report":
+		checkSkipFeature("Health report", elasticsearch.HealthReportAPIAvailableVersion)
+	case "ilm":
+		checkSkipFeature("ILM", elasticsearch.ILMAPIAvailableVersion)
+	case "searchable_snapshots":
+		checkSkipFeature("Searchable snapshots cache", elasticsearch.SearchableSnapshotsCacheStatsAPIAvailableVersion)
+	case "slm":
+		checkSkipFeature("SLM", elasticsearch.SLMStatsAPIAvailableVersion)
+	case "transform":
+		checkSkipFeature("Transform", elasticsearch.TransformStatsAPIAvailableVersion)
 	}
 }
 
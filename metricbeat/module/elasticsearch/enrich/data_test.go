@@ -96,8 +96,8 @@ func TestData(t *testing.T) {
 	server := httptest.NewServer(mux)
 	defer server.Close()
 
-	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
-	if err := mbtest.WriteEventsReporterV2Error(ms, t, ""); err != nil {
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
 		t.Fatal("write", err)
 	}
 }
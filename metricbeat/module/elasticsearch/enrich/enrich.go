@@ -18,6 +18,7 @@
 package enrich
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
@@ -54,8 +55,8 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 }
 
 // Fetch gathers stats for each enrich coordinator node
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	shouldSkip, err := m.ShouldSkipFetch()
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
 	if err != nil {
 		return err
 	}
@@ -63,7 +64,7 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.GetServiceURI())
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
 	if err != nil {
 		return err
 	}
@@ -81,7 +82,7 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.HTTP.FetchContentWithContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -32,5 +32,5 @@ func init() {
 // AssetElasticsearch returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/elasticsearch.
 func AssetElasticsearch() string {
-	return "eJzsXVuP3ThyfvevIPy0C4wF7KsfsgE2u4kDzGCQ8eYlCLRsqc45tCVRpqgz3fn1gUhdKIlXiVIfe9tPM92tr74q3orFYvED+govHxEUuOEkawCz7PYOIU54AR/R+7+qP3//DqEcmoyRmhNafUT/8g4hhGZ/g0qatwW8Q4hBAbiBj+iK3yHUAOekujYf0f+8b5ri/U/o/Y3z+v3/dr+7UcbTjFYXcv2ILrhouu8vBIq8+ShEfEAVLuEjIlUOzymDjN6BvYhfIcRf6k4Ko23d/0T9VP28uWGWN0nDMeMpJyWkpEpLUhSkGf92wMMFwepPa8xvCzslgk4y0FFwk7IxC6f1IbJ72EH0KJbj7GvacMybYHvhukwutK3yTQyzom04sETITgSPZI04yHquu99nGUugwk8FxJNpRl7LxndMiu6PDpA+xx5kFySDqoHwvswxb7d1nTnNnkCyABzkdLARpYxws2ERrH3NSInHGSCMmJCYLBFUu25TWOLOv59NXDvGeYeyAq1ovo1p92FC1uNAbYstuldt+QRs1rx9L9g4AZEqJxmse7n6re77GQPaVnz2G5NiJuXmPbnnlHDKcaGV2M/06z+II7iHn+ul9okI9opOXvBK1m0xSP1yL7XSlsxN7FWsEj+nbW1cY93qhKj05V4mk0B14V/RgjK5Aa7TtoG8Y/b0wuFgYlBS9iKkJp3URC9yxbBT6HSCJX5W+OkmkPBVUkhKb7i5xVnQOSQayEHaHVhDaBVN1BJv6uDCJJvnf50sHeZsTUzblkRyyrj0NhaQ0T0bBWj0ZkgJDcdl/c4ELWHf/+v4l++13VFhbsLQU+s/G5WlLctANbt/597cIKb1f+ZlBAOOX49zOn2S637S/Vegvcqi+2pprgnyQhlkuOFN///qghUkwQw033Vu9mBmjp+ni6c3/tz7JTsX+oZTBklD/g9Mc33Ygi/VGLklLvyBR04znWewV7wBdtQeriVU/AjJFuhBOoMLg+YmO5s5HLCfi7egyQdg18G7TY/rHJ5iZsOIVNdoPqIc0zov166br34D4cQmaEEmvsPqYmWXOC6aN0Y5L+BUhi6hI7mFZcPnwW8tsJc0w9kNen80er8XJJMgQQM7wTzHHB/LLUDMNId9a6HhZ1guUNQpc5lkFjiPHTzvD9YKnPOP8QQkGV8v4DuZ4aVSjzS76xk9ysy+YOcjcPLQ+sOoNY8t/UHOvsf0h/5HNhELGsfae87Hx9TSY41mbA6sbNJ+oo4aSZqr2TvafuLG7RMlFT+Rnac8W8A6Ihs9vLIZS++4aOFE+wTInOJ6p/YvP3Gz5TRP5Rg5j2SY2Mm/fIY8fSI8bYCfRzZMrDqtpHfIOGUnzy7eUhex4LTE9XlMQ4TO3ZPfGeHAzmMaJFU5nziH3VLQLByYZSzFLafphRYF/X1jYFCelab0kl4wKbpxK9FMR55e4e+MJQqzRCInc2TTweGSD4OSckhn5y9pvxGLSs8qyMm2abMMmubSFkdYsEf3M6H8I2DJaEGc77fYaInRXDhXCKi9cltPFOfJ6daznYwlo96rLAA02/qqzbzDHFqYMZ0GcA4s3Z5v0cmQIMkcZNnKO2WMRtNL6fW4FvQJF2l2g+yrcCP36mQGXEgu8XPawLe0ontFapBWtoyn52hXt6aj9Ai6jmIt2nYfDfMB5Luta0UbZNKWNxxXOamusecjBXo5KZkYiPX+IAoC28BByn1qL5du0aiBYd65ScutUBgLFTQZQX0YmKJhO+R3kIvsDk03r+uuFXalKcz7uh5wJXnI/o0n2oi4ki2gIaJoE+DsREIONhF62ZHk28mdorhylGlSixWJ/fIIz5AdIl3B1yY5T95Y5NlmQrZNNmd6Xqrccfg3Qvb+TjYBeortZgMOEbq3BXMQK+aZfYpqIOa9Wc5ukbuwXCPs/Tb2GqV0XNsS1ffc2NL7rqtKnm0bKprDxn3DZU0v5NjVnGG6JeA8JtfbogPG1KU+pahrrLLE7GXK1TdkTNojDfOeHIWQDFCEkpm6d7lIl49kbSOjVzF1BDb77EzobCztN7T9uEqHaENF2qVD+2c+Nhu07Y2mLFmmIwzkDNGp8QKcp/FZitU0MlU5acfnKmftHWRj3qMQxwvROrYt2cCkjbclx5sSY+6BI/Hd8idnUImcCakR70iGPCr5wnXK7clek9ygu2jht1LY80I2MhLDdcvBbXgmRijDGXKY2VY5aNH6h0e2WICuy+S1zeu3kqH3oKqucwg3KztLqntQdXWJf9u94EMSiiKN11XKzvYZzp7ptInPpvntsOwiExFv1aypMH5GDkqUCSU2gW/t82hHikco2xl+DMKBKQmhfFX4GHTjM4xAyjdDKpSawI1B0DsLL5ShBI5BMTSRK5TpDD8G4cD8qFC+KnwsukfxjEIwLIkrlKaCvoXseCdydnU+fGN/zSIuzEUhu8cxMasitwY/TNgufI0KuuPh5T9He3+5l8k1SyabJLTIkwnfGs1BHuEnA22nRxqLv9ZB9SU/EH+h7SruMMd49FYVGnzX7brSYFPLKu5QtAnFpxqFh7pDYYtFjQk/vVZ8XPU7QghZqnKEMqqBZbBlX7QmVGe+26LZOeIsh9Cn8IAdk+4LWGd1G60fFhTnKb4Dw9dlqMQObANXBfxpOWaGf462o02S1W3S87smRhzXqM107Hd4AnWLM0sv2mOrtsFXSCtc0Y0nLZ3RBIGkp5kIyMR4cuMzENfdLY622aVJv7WU47QkGYuicpJdmkRgJu0WldFsj4TtJ1Ixlu8p3RsKXPeTHaH5jlVwbpDuZ8kCO+o6PmkgCsukQ1Te5NBu02CBHVUD4X6M0Nbht528wt04GF3E52GZY8YkrTijRWrr294G6Ld+Ppi+g7IgJeE2F2ULQQFq9FVC6MkJPDI9OYWH0hujUYxm0DyOw7HZmesVEcMq3I3jN5F2UVO6r6bGU1t8jWaLby20Oq/LYQlFl6TjkwicXUF/Bl8g49pJO5TMALX5VOUK+iyBV7LwFfjDGLjjstu+y+s9r25heVPxUWw81J/eaeX4Z7J7zTwdfD6Enfvf7ja0OO56JDvL5LZHMbNkE2zlRYLdxkzqFBf7lltdQUAzkgkNuTPC7KA24BW4MUEBeW1MzHe5h1ReU9kzy64oMH3tcKa6PJBj8ue187Ad0gaLvNNRUCQbrgvfeLSzZwrPgRxdRaN/gHGoK1ClucA+gwieQofyupWuiupXePmdzirba54xGf7NnzPpcYWUxChVE9KOIJPkZomyinJcuQJTK1VbaTpkgSLL5dlE1kG4+/cLzQF9+jetnEXzx5A0b3lVmCyZrRX3RGkBuAoT96lB/AbC2OI/JL74/z/rCRQ0+zr3HfZTGEBR/1oKotVI68/r/pitSzksO4ZF5l8YbZoPQ4dnUBckE3cd0PIezfw5oeGfrc8ZC04ga6+wXnKcPi3oYlIeY2+OW/4eEJqKHNNXpOJwVfQxOwdiYYvmISwvHzu1WX1suDscBLS6wWn92tAvcJ7Ccwa17rKORKlE4xk+X93NRPtcr/GC6CGLvOb6sE6E1upoZfmNQGMqqriO+uPrqRSm+PGU1Q4LtRpFvKHhvpdlbwZDVY5w9ZSiUNG0O3AwWOtVOS2gQwyp0uUtYDEFPqAp9Eu8KGwUrR/oIs3I4bkity/X/fsFl4DopWdskDT5s5oySBbbBDH5GT+Tsi1R03WZKoP+RLwjN47SwdXs2S6fI5uztZWMspDWNuhQ/OJ7atKBs6NRtTXZrBYKYzO2YtdwQhj6nfAbkS1p52YtXxKf4SRO8oIc/WHYckD+x86zpoL1aFqpz4XR0r9fimhSQ6oM0n4rsMFv9tLsMynhJ0QqVDY/ISFxzr4Tjy7AsxuslIg9rIKI/7uQgSYZSFx26ob/3PSPP1V58zWV3vIgPcVojVW0AlDM9bACQOzliaxQukiWO+/CFkPoowf7IwZ6k7hDBcs3Q81qmBigRbBt9WadmwxaRaaWD8SFYbj6h+t77aNxbgj181VyoncsT+0TbYP+cGUA1U/oBbrR+hNikP9RH9Nbvp+JrE05k/lL96mQSERQNfFq99EFaay1Z4xjUvneVUnHiaEfxZbpYKb/546+MlcKU3bL0zDSDVK1YVR/sdP6LoE+QEGu5KkAbwKaygZbxHcw3jLX753a9zrmnY66JJofIXWotMyadN+W2ABnvevgDIqua9gg/7GpiXRLuM3j1Vlbxjna9Oduvv1O57osR2BvMo/uKN8UDuqRTja/CefZbF/3Siiq1thLYFm9lACvJECvtZ2l9SxmdmdqHEGrf+LcTW9cQ/zqqOzpoj8LYNRNBehCmSJSH2mZvY4/J+DvW41HzM81YS9p3jkm5vN+58DVuiZ252Qc8/PX9F0fKhKzePm0uC6TC20rs2OmPzucEJ5rnH2VxfIHnyMCVn/g6I00NmvFSLZ+4DlgC/FXgRBhBwHPkLXdJi2taUGyeBUXNQfbyNsV57hZ9h47Gxsjy6G+CuvwCwyU3BGysROLm6q7IDJcZVCYeq+r/ypzOmZQ8bRTaX1oG0ZJc2KrApjvhthvUinzCOO2272OwwDlOKCtKlJdkwprQzBWtFkSqXChtMNEw8KVrzGtyDKjZTifQH04Ukq0jFxxYN99CnrHIz4zfsO8n8GGSyiUNeiG7zBy6oN7IjFENGFb65XoD2miH1FnLWP62yh7fZa/SGQ1xDYdnfb6jMYyu1UbnMTQ4LGZll87mY4GgherJUD4KpUxwOuMacdovZE8XwXozVOkdWexvSfu2A1scL2HTzVRrOgRrGNS2D51ncWSwxY/c/wBMomdh9nBeb+O5CZzIcU4insWVQywANxJZqtV7AlzI9xaUMUTpiRNsxVn3qbfUxs8kPHcL0B7AhlLGId8n0MB+hs9Xqdk2mqTKEoniNFczjpiAWC+FdQCIEOL3gWyDShPF4DsW+0wANK7PmEAZlDR0ADcwIKZAchhFfACgEOLkjqgp7XY+4H9QER45sCqDjQadAnsOmw0nQuLB95DXBby7Kx7nS8V0b94ugXUtsPY7g1vXQ83BfxzmrVi7UNq+pV517x9sd1GT0oKpunnnFwoKzHv720coM2gT8djyHcTxDsthFCzBsf4psGeqVdk1r4z8IKwO7Z+58Z2p9ZjMlweZsWYYCdMm5Xetow2mAfa9cRef78r5+PQfVIUV9E9pyPfqXu9GJXKQfTTi8xe7i1imdDRsXuvI/YcO8baZuPKdXEsdP0qe6IDtoQHbo0jb4fQKbvuwzaHcfez/7yR6bcNYSRDaurZoShG9PM9AlTH92tsuKgtI/R1PpUYhLaV36z/JQwyeodZwdBXOJqNW3XuQgqLL7un35rrUyKPdBfVwRVWN9bB9ewIDDpHLkJn2rG6rPNYUBRTjyaKNKSlrWI5F64nji1Ym06810N/nc/kkNdwWm/9EjO+7VNDAkF4KpFMqx5mq1m5HmTPNN2RRrAQ2iHZ8gn0eY+bKtJ8Zi0gskhm1stu+Lo6/w6d/2vQVuAarMxw1RR02YTbZ2zzvOqZ6rk9P6ZfmqtU9HJ/jCmlk12BJ8IkNWU8xXnO1rf6PYa2BIpZM+qzgJSZWcYRI8XeaKMvrLtbcIeMeqOgP2S0LXL0BOjTr+MPKRN/1PEx3E/rScZNElJJzlOF9OOMtiyDCA3dA8Vs6N8EpL2he7FxG1oVHKOhe5JxG1olac4JuwMjl27DHdMVFZe9U78HnD2mqSCIuaPf1yV8XT8/ft7d20ljzJPGLVWH3PFqb1W2HDJ6Pmsbz8quKO+KmNcjh0ebdu8RwHmxtRhlm8x1hL2gzgqdWpnFeQDQ5yUcy0mIsgBZjekAOzygqFTmiNB9rFNhAI7xXlP0Nt7cMks0fL9GxbJZ0QL3lpZ0OL03Z+HNWXDy/6dwFtzv0tkieXOw2RncJudjreObH/PmxwTp+136MQ/geYx5XEXyhT7tCZWUxa44SczY4N8r8q0FVBboC30yRweNxdw2Cf1P+iQh9dIulEGGG94/HRRy6XxsI5qDTCGI5h4OyaP6Qic+od0ccyzTM2O+SXTHBcll/ZYdd8z6a/aQpwwyyvItWIt2/3WAlFXA4L52clTLJKoq2vlt6+ng55tarlLq14iKrAgIvwFDWKTOkuraMQFpdES7n4v/F/UIZMi6ohw9AaoxayDXnAmsZguvZ0IsCiy+P76Ao98LID2qvjnXhdzMHdw1Vfz3z+hTdaF+hchcWrs09yA0kNIaAK3mC1nljVSWV2KP9tr/A3CNOgYzR73Twe2j+1a+O0WHEj9vV6Gi1es3xS+0+hChOQZdXrNFRlX8W2Wx1iQHPNVzGcBRhw25qAQtjaadmfdX2v1trOWH8BNtOQKc3fqz1gph/TNW+9y/6gqGU9r41UNe+WJ4zDRLc8GeABDj+xQWDGW/HlSt09Vyh+0zhwTXw3d1e3Z067Pv74iuITEyLt2N0fUlzJA9/b3wVaIksnr0zhtxp54LwHO3mHQbkSHy/rhnAg8USf/eYujD8URAuHzv0cqmoxMh1IPceAn4kPXI0WRO1ZGz6c47k4gY+k93TW8o6rnELOJ/+BXuM7rCWdfRz9Dl+Ft+MTq1cmFw9+NpR17knm7nuhYTD+V9LnRPs6xyIfZk2er105NFi1vLJ8uUt85PFjq7S32ybPVG9CuIPlumck37YMlKULyTJGKp0UIAB0UAB3jDBcL9U+hZozizryE5bU2FzicxuutHy0atKS3iBXZocYzV7W/XRLG8d3mMn/GzT0GRGvDXh+H8K+CvvqTTRzK2IF76WdzxItG5xP8uDxase88X2h60YXukJnwbL2/jJc54aVp2J3dqfvn2bci8Hue3IXM2cdOQUV28a5ZktCjk7iimmzfA2lJVXrs2945ooFiafzAdtZ3kEq9XHHcev6cuyRJrfOMsRg2y1eVjP509BvjfSAGoeWk4lBYx3sY7LdDHYFdqdogs1wu5UQSOUUSaLjNu5hJ2928r/xin1J5J+CgsJ128GH4MtIjZRsAeDRBvrsvq9pCeUFCcp/h+Tf60flFYlXHDxSW9FBSvzTFSNPGIsHDVLc4ynrQNvkKyt+6b3pZupi62MxmXJvnWUo4TbQq8J2O0uFngRLJR96GvCoQC1w3kaQ2M0Nw9GDz1QYukNeXW2FEiFAnGvuMJPz+RPL4b0YozWqSudnWlas9RC1JaUnu3YcqhuR1zmfKa1W2yDkpbgtE+QWh+61aPtKY03mXow3IL5XOTEXx2Bl8g447h5eF9XsGcoPRDKaqrXfSDqnpsvsNj6Srcux9X1WVmVlozaJqWxb7Yd4yHJ1POk4yWT6SCPM0oZTmpsHgOG1d52pdwPO2oXexiB6GyXbaXHN4u+VR1VaO/gs4z8acqzqAuSIZfQedB8snt/CCjbBr257f8IPuVVD654QexuDgtNif72okCxb7moGdjRjvKe2lJDeI1b/Fu/7slesCtuH/oAP8hNn6YVA3CqP8F6n6hIqmHMFvuxjXAeEpZDsvzTaOFnK9Fd5BoDTnt7QhlhOvrO4fL+1UHNzq2olipVtKO4qcJ+htlCJ5xWRedQi3/UOK6XubBz4IPpEqlf+hb9Nt9TZ+U4qqBgF31UFHjek+XFAB959nVxw4r581vpEGkERcvPEp7y9svsYw/q5EgmNirisesB/JZ3DTBHHxkMyhoJpd2cYO3ilv2+daXAha3X0SX+R03g1DI0YXR0o9Y1FrdXrTQJ45uWHYgeMYZRw0uAYlcdcRvuNIar1saUEbLGnPyRArCX1Ddspo2phNxOQmli8oUaNcmTNOKLpMpUbp2Zev1x/8fAAD//zezSe4="
+	return "eJzsfV2P3DaW9n39CsI3b/LCETKD2Rtf7Mwg9s40kNiB3YvdxWKhZkmnqhRLokJS3V3z6xekSH3ySypWuT1rxAi6u4rPec7h4eH34Q/oM5zfICgx40XGANPstEOIF7yEN+jVu/HfX+0QyoFltGh4Qeo36F93CCE0+Q6qSN6WsEOIQgmYwRt0xDuEGHBe1Ef2Bv33K8bKV6/RqxPnzav/EZ+dCOVpRupDcXyDDrhkovyhgDJnb6SIH1CNK3iDijqH55RCRh6BnuVHCPFzI6RQ0jbqL+Oi4+LshGnOEsYx5SkvKkiLOq2KsixY/12Nh8sCj//aYH6a2SmRdBJNZ4SbVMwunDRXkU2aieheLMfZ55RxzNlqe+GmSg6krfNNDLOyZRyoMAuXRs8+J0tELeu5wdnnJMtoAjXelxBPph15KRs/4qIUX7qC9Cm2ll0WGdQMVteN0LBlEWgqAskMUMsRsBGl9HC9HqJNrta+oUWF6XkTMdkQkzlCz4djvk3hDndaXqPK1roJVZZMBMoCtCY5bMIUBZNi2Q7GdbEOUZZM6rbaA51Ur/KCjQGoqPMig7HwZVlT+QkD0tZ88olNsTBPVpwSTjgujRJVpF9+IY5gBT/VS8sWVRvBXtHJS14zzmOpvz1WM0wzcxv7MVaFn9O2sfaxfnXWqPTbY5UMAscd/4IWVMkJcJO2DHLBbH/mk7q6AjGoCD1LqYmQmphFLhgKhW5OsMLPI36akyqZjuPq0jXmLqFLS1OkJ8xOOx99P23xf0gMkFraI1BWkDqaqDmellNh8dV0c/w3yTJhannyG2nbFnk0cQbI6CObEZDGFm2UcVw1Oxt05wav/tJ/85XRHUfMbRhmakU+wWOkpRmMzR7u3BPrmXis7f8no4zVgH1pDfcb2ctmyxLxUy9vCWuCrEpRam6uAfJAKGSYcaZ+H3dYqyTYgbRQOQTbPoKZDPwCh3gmHQbQbuxaTGLzkpKJ1hiFcUIhYcU/wBbrTRx8avTcEh++5pGTbD6aiSHeAttrD8cKan4NyQ5oLZ3CgQI7dcMs+3LA5VyCBWlmFdCjHt2m13OOQDGalSxe1MeZJLPT2xx/AZiOg0aYbqH6acKJS9CMjMsLrsPKLVHT4ydKOC/hpgx9QntyM8uuj4O/t0DPaYazE6jxaHS/l36WrBKk2UnmOeb4utxWiNHMKPzeAuO3sNxKUTeJZR2zlXHsynFfW2tlzL/OSECKDx4F6Ib/wiN8p9Q8jhqFzIi46jo+I7e020X2GbsQgZpcvxkVxx+66Hsdf1B/comY0biuvad8QkzdjVijGZsDrViqArUlpsRQUw20w8Rpcg0pan5DdoHyND3ThCQiGzO8Fp6TLH3EZQs3tM8KmZpmTW7qX2HiNDnZ5eVpF5BuR3KdWE32UDxDnu4LnjLgtyO7TqwmK9p5+ggZJ/SGhl0ldbYWnFa48ZaJxnSNUE1UAqVPtBDLmjdjukqqpnozdnNBk+XALKMpbjlJD6QsydPGhcFurzQlh/SAi1K02w5N7fLtfDqZdJEnGAZmSYecTJFnq1JWPhQqwiHVC95CS0jVRCwqPacgL1vWZhkwdmjLdKpnFIoKPcyE3ZeAKsVSCji/3GK9JXpz4XxEQAsXXrnNE+V+8rA8v55hr/fiFMBYzLSaNwuzwGgpJeAcaLr9vIVQqANJpiDzWr5QRm80sxSlx7Eke1ym2Qmyz3IYuVme0skOOJMstoAZ/J7W5FKRBqSFLePp2dvVr2kvPYKuvViHtqKQjgeQXyrRjaZlkpYzjuu8qI+x49EIeh6UbAxkf38lChLbwkF+lu7bw0F0cQ1QLA6wptMvr2UxBk160BAGttWwC+QLyNnpDoObN42oBTVK3Cx45OtmwIVkffo3nmgr4kK2NCdEFG0D1JLlWLJrbHLtdbHusk7usIrbtbKKuSSq7hGeIbuK9BG+icloNBY52gzIrmBzy5HXWG7f/JmUvVlk72QDYKBYEQ041DElLzC1WBlnLlPUAKHRO4cXsiG2C0tQj9/G7qNGjuvqopTnxpauXHcseTJtqEkOG+cNhzGRZTFT0XFx0zK5GcWGNEbrD9eb+lWXtYQB1Hmxg6isSpwrH+4BzPpUX30YVIxDSEKtJqOJKJT41k4u1yyiqZMvbeeCTNrS5YY27yW5EF2o5q7D+LUQm2ltldEGxFmIC7PhnKDoG9L4LAVsbKoyul6Bq8S9hKwmaFsSmzuQyXE0htxe2JlkmtzQ5oI9mmUK5NIm2JJ6mW44e2Bp0WM2yZejomlEOglpEG9B1oL1/ny06g088xDI3nC4YTyIC7HTgpolsG5kJNCMNRfKKvwkxlqGE+R1ZlucQYvmH2qDxX4saJWu88Nrq5uy4SjgC1V1eYZws7JqjvCi1Z1wvFTh6xwoitReF0d2tkc4hXFZhJvy2RTfNB+9rRrN8jYiwaqpbd61Cm0+KLOW2AC+1ecvOeKxlu0EPwbhlUcS1vIdw8egG59hBFKhJ6TWUpO4MQgGn8Jby7ADjkFx7UGutUwn+DEIrzwftZbvGD4W3WvxjEJwzXmq9TRH6FvImq/Om3tVU4+qyx+znUnYto65LDv3WHzFBeuCHsOTMt8tPgzA9uEbVDBtD6+sb3EB/5glg00SUub61+VOcVilB9D2jkhj8a9c4D7ymviZtIt1h6+rVqUGX3W9LjTYVLOacgXxUnmEZKMIUFcntpjlmAjTa8HHl79jDSFHVo61jBqgGdQ8BqEm44F0NA2h3/QMoVWo96ShxiRs8cW5D5n8RxfPmnby90v8sCQ4T/EjUHycL5W4gV3gYwF/mLcZrxm7uiMsyZo2UfyOiRXHZOgxgczEfrvBsqbFmcOLLrFVy/AR0hrXEwdZaTRJIFE0EwmZ1Gyl8WYaX0Xb7MDS31vCcVoVGY2icpIdWCIxk0milVCVx/QEvBPCprtPf/Nxbyhxo4JdQXIb+dUGEVokM+yo/figgRiesVSvyuc7S8FNGsywo2ogsAdoZ/PbZv6pAHtj9BHXhLsp1W6tXwa1SVJzSsrU5dvBBlBTvxBMX31pfmVRFdw1RNlCUIJaxypr6MloG5ueBF1NT1NqKBHXSHY+LzF5h70HsHuazct6PlsHc0oR2azWD+P4SR67aAgpdz41XKbYt+XnnUnsFlv83kIL6y0x0iURfBKJY42JJrPMmVD4DTIOeQQyGmo1H83lCPwlWfgI/MUYWHC52L7z6z1f3MKS0Iuxsc4/faGV4+/JXmpm9elLsbP69GJDy7NkL8nOktCLceeOzWoraxJqbX/jSeoUl5d1t33+rMmnNiQb2hhRHx9afMEF6gIOP4JlsnNY3Urc/iivIamY/MFauQuCIQuuV2VqOgfiOhq8vb6NcThObbuPo8Syob7BP5xaCahnRe1W9WzgaDvpoxlqh7hKzdymHZoSVI3qRpOZQOxsKs7V0qXVfXBzFtXPcH4ik8z2hmdM9H/T50wUrpSSWKUW+TVkFrldouhoILJciWmUOskKbaoWm8dpgAlRF1kPYfHvPckB3b01yplVfwxJ05ofC+tSZs9KdeL2hJSA63Xi7hjiJ5DGlj90+PL3P5sJlCT7PB07XE5BgyL1WgoidU/rz7s5BVyWJJO3PlJ4bkpc1F4/cVB4JyBqCYfIAT2dzqitMWPFsYZcvaCDMlz/P472oGVPhn79q0N74DjQOVWbSheJxy90n78DLnnXrFom9BFmVMLQ0wk6u0qtRnZET5ghZUvIEyNj+9x0m5/jCjQ/Ca1/kdzMFOZvhQwUSlIfV8qXi+MToeip4KeiHiiZWSzfgbmwAfzHCfgJ6IhIwRDWclwGyVpKoeaThwoi1M1PHawKzv56yXCd6oYRj8XSLBmuJ23wNYLkmKCHmjy8Rg/9Mr74BT/hQjz+lRb1gTwgQtGDKibCRg4lPkP+YFZH43fRpQsNM3adcTk883U6zYKNUG2ghXLICpFYwExriEozWHPIs0WgMSQFzBa6+aotQE3x76PElvF0qME9ZKLdD6roKnz/4e279Od3/3bf1dXd+7fv/jP96eO7v96/e/uQWBUw7gN25POlMwYyvy8qmMfKBXE7pxw4LkrT2NXqNYHE3nbICO9Jy32WtRMUg03dXkVrMPZBkbzg06QjEpIR5hyqhiNO+pY26JHsTIz3YnwgWrNoITksjvq5iHpIvscV9Pw0PGooPEItAsi8japvdl5BaoQ5Er0/R6TuhlFmDcQnqW7f3Xmw5P/PyHRakL1YD5p91P0xvbyzEzwYOhCKAGcnU/AZBVXZFJ8IZZDucYnrDB4CtDNXTg2Mw8q6+euS3UBdaDIb4CQrw99ihuDzpQDO4t/dW01s6RCemUMkBuPRlZuDNux1eJhqkNSBtAwN3eNPgaze6mbOT5gjuQle7FsOuYhJlj7ZTdvuawH1HWLrQM3mda/MmDhpaRWvT+1tb8wJvdfo4b/efRLDtvcfuv7//u8fP9zf//zuwc3cPkAL6nJXMDcM3HKzMsluzjLL6M43WnPQ+IkSxn7QczgKTVko35znfZg+f+vyTE1NpVZS4DuT7UwV70zK45iVhWalC4AwZJAcShU1hyNQY0E5s0v3uH//1K+u10vGTU4nNVKzWjGnFKO1gjNExUePQNERapVfIUEf6vIsniqWI84F8oPIJiTuP+FS3FvWCKmwI3tABdNLJolR2f77g8QwM3tV/rjUxWwBNZ740x9l1/1QkiP74ccff/zxT398GJRfwAtjhCuPcJ2PVhLE5wjqnMkZPRo3BtVQluQH+xkNOSR0Mtpv4+RrklktoE5mhS2J0VYBLdJTOUvvTBCCSwrPGTR8zfBvKL5IPHWZYYfsV4uvuGBd0FO+i9xoXruZcEyZuYKBNEiXa+ufX89R1s1/PmV3ViSVanNngjCpa1N1RdIZo4q6fJ8XyKpiqHqjjNc7E8IW7a7YGJzJuL0WMCGuSUEeLGAWAl+wKTSa0t+O48DwDlKmax+dxXuJ3ewPU0B7EAs9wiN/UGOX3MqXgjgjmwKl17pxutGgwQaZG0Xogzp9ENRSOlDI0f4sh1Qjs6CMEJoXNeaE7gyo83zepi2jkNH2WmXGS3hdKnF9OKqr407H8qwChJjl7yeKQe6sEesyecwZZrde3uuh6mVs/ZnP7UxUR2E1bcRKK613oQ5qc07P8oWvJgP0H8+fxhqbNRhpm/Hi0c7JvBsXyGm8/WTgJGYfnXixYNFgcanSynMa669jw4+T6CZXtXBR6xXsabOwEh03XO0/7Dp874QMbU2GhP1Ew6wwz06b6MKz0F1saNyUuBSbQ44OlFTGEERUl2lVY+hRB6tfh/s9VE0pNly0uXOgwoUF7dqyiDGpgZ2Jf1cNOxPbLXHG1m1EDDRDFcGzlYfxiYagnjiAyS/4uajaCjEx4qszUPcNRQX0g2y95qzYmo4/DGxdz1k4SO9MWLr6d6biL7RKxy7rqFTjezFOC61j09ei/ViJlZsztXp8hoO4jhfk6Du9vAz596io1XZIb9pOn3mgc/ulWDNLWVFnkKpl3+mDAvE0E/v2r1FRo4q9RlLilL0Qjw7As9MyWlvpb2xWq4j/TcpAgwwkE7GJ5j81vZXliwlVwXxtz4IEkNYgjhc+VqDY3+pYAeJ+OsEJpSHUwC2Zb+qYg66j2n5SI8DLd4fMJrHHbF3OfhxtS98hdlxZesLstLoD0RDdqdZ0dqR4HYbPP3zlpVHStl156GBcfPtB0bFPtAx9d6QA9Wt0BtFaXyMK+ffmDSRhMhZclROZ4sw0k2cIC3kzKwmqdy34oBOSm1crrW1yVN6X5d+LYW7F1mIz/e/FltEoVkpTiu5Jt3SLVOMR73CxQ//eAf0AZXEs9iUEEzBkXd4iXsAEy5wmlPP5mT1oDIiiS2wb210Xj0ojmO6mvzeT0wY4Zx4mK55Gms/MfDZz9BhqVltkF7RXb957q3k0gvlOUKjfLTVCixaoTBbgjnJIy1Z5pJfNJ4HpsK/dqTUrmVHfdnXKYSh3PHMWDdBraWd1acJu5oFSfw3xBrQmB+pDglJgjncHTy/LX2Q86Fa/xPmIQeTOxKgsMqgZBDd6d5uF56ag5zTH3HUX0aqec2jiHpzoouI7KwqOJGbxcn3gpkoOpK3nJP0LyRrhucHZZ/kIVj/miIClDrcEI2kE0fGmjFPA1c5nH4eDvhX9dwdjn0usuPJk2D5wuYin6ehlHzGFzwem5mazx93xcVefaXBwH4U+sij8voMJ5SU7zdTaaVqJHcSZKP4GmQp5SHexWogU1sNlqcOh4rxVlapbJJAZQxnHVbMLvJTh4fv34ngCxtHDX3rkB7084qCmaUFNi+x0STN4JxEizKbF+5ituFKQNqQsMvMFsjkxG6inYfka11CYY/bZWtjExsVogDXOch0eHUDJp9EAIPblSH0RRCZuO5S2SG6LwEucBoubdKlQaXlYdR0lw+HDkLpy1dYYnnFMuW2q5K27MRJta7HvmIh0fWvRNEaXo0WEqV1QuRXBWt0810dtkFqa7yQayXQtVx5UFkXBPAiPz0weXugiGFI51ghl6IQfoeekFrrFPFvEKMrbJtk59qA1+C7Uh2zeM7uDuvjcYZAAo4yvoQ4j+NEpQH2aR+uTWAlumDAF0XsfQiusnjTVk7y9nVJoCOU7X+04OKpr4B1QNyzBsxT5W4Zwar6UXrYYuTTnB5HluCyV/mpCoft34yRNM+pVi+bPm3rTVTuVQimlaU8/sfKxzK6iMDJmC+g5vZZ3GskBqdXitv5ck6d+2Vice6Gue6bsXDWcjOcgQWemAnh/6tK8aMYW/nZmRdVgR55Kk9u4XGcCbaqKkOoKVF38u3s7KCs1CdF9TBKLE23X5/lXIWbWjBVjcfObteIGKlPdnti5PQLjr+UUpG2Ee+XQlOQsXi5JK1zjI4gf3aoxeARa8PnoOijor1DtkxKjtasI451sXSevuy3mP6DvRp99L87c/Av6ToTe/m/JzqZMXuBjTVjBpll3QqsqQJeRKylhEOJMmqCuzJQCIy1dzq39jSqA5EcNjvDhIBPv6cOpazgHtl/jEkGoxQMV0r1BT7vXS027EydH06bczRhK4W5+rKy6OW7x5Wh++vkXpDm42R4A85Z2L9J/Ob6KBepYeOxb44adCJcDRVZwQosvR1yTQWMyyW7OuSgvWozsDl2WxQGyc1YCGroD3fvjOo+yTNkf5Uir5XEBlzU9VvygcVGlMhfc/fxLP8T6+O/v39+9/9tr9On+w6+/3r3/m+j+5M/v3iZGnipS7EJjrS34abzOovluZZ+5aqKkOGtZIorjUaXK9npOrBSb0/LWrlvp1Qw1M820ATomKAgs+dmNO2Zfw5Pxc4+BA1Vwq6HWOGp4suswMD0R/qWZioASwPQJ0+pLUxUcQrhmvqfkbsBVcAjheqDkH1B/abYdixC+OZSw2Fm4Od+OhY2v5iqv8ayOYiERxra/f1MjvPv48cNHxDjM1iHnZClweraur38Rwt1tsaeiLPX9sArzIsNleZZ0C1uaBalt9w22C1TDQ39+eEHufukLbEKeWgfeA9SanD6EO1PUzNl0FN3ugh623fDs6UQYjLrLIsApbH592+U4Q7rHMQnjhl0kGj8PowsppQuG+t6Wj5hzRHQRL736Phv9BFrMugV3FWadtEBqXRtKRRu6Dr9PfSjpRNmpCA7eh/ctIXB1IkNpFgTqdu+0VYrFN9Q93goZEZlThvsT0JDslOy2xHHfLunKa5ij6LcmSi/YWt9jvdTgQ18jKpUtSJ8wmwRsO085SEiMx6Oi+Oj9uemDn5SlcqHJe6xqUV14MZFdjo+nJ4/o9oV+lUR0A1FbN2fu4hxU5gDrD35kFGYZox2+pgudijxfjMDt7cl5cNXerft63w17pxec7NRFDZck7C7v3CfzFzOML+yFvD571+efSozSbEb5qh/RMLrBRU9eGBE1mtpaTzOcneAqiqtn98XpE9+LgYEWgMciMyXHWglzKrjzLfFAmKpgbCuOxujq9GuqgxdkvOGyhEu3ACB5I8BGJrR8t2yRb0NgcBSr8OwqTrDRzAY/iuNCHGjF0qiQ0hXytLNGXGjBNn3s3tuPiywSUkaGlHerI2PmJEvlUeHIuHJIKK/oA42LrC6ZphVu4gIfimfI033BUwb8cmgNS+FAgZ2SIT2ms4dfgQjPIguKAI0GXQE96rOb3o4lAO9FvJNlZGgA8tlvBaJ+aOMi0B7MoKHdejbLXdofegf2piXZnGSt7Pv0Kqdn4Wl7Z7uNXidpNc2wwYnrCkoMbeY3VXriQgspNLnx2FSjq+DpM5DHBGEzgyAI98A2CMIzqHVgaIT5XckYAXbAdFnp25TxK5kyxu5/v6rBx1XnScr7LnM8f0wPDt3Lzqga3XNWZze1RRwB/cpzr2vMOS5oa5uN2/WL2p7JF5kT1eRrmhpHng7dZtZ9tclh3Pns/92V6W8TwgsmhGPAfVvGvJQ8Yho09vCwHAPix2NsuKg1I/UdstPFQNvKT6NI/0soZOJhmvPOV71X3ZqdI9hpuDxMox2K0ugAdkQX6hi5AZqZL9n6NiynOMrqkDuRvI5AoWWXYmyMZLq44Wp4HFP3JorUpDtbRQKTRtuItWnHeyrYfFTJI49x0mwtiSnfVrTIw77vHXN3WbuUU5wnL9V7Ehm5WuU6oQLJLFaltAo+JuKVfE9bQMUsV5ZZNuP4GFHnj1pbiWsWySmuWUmOu9BGb2vw/rjq0sQfxKzeNiuads/vTi77ezD68pgegSfSJOKWf4rznC7ffLHrMQMq8nh1eS+5yeSP9hYjv5OcCOPXESyQkTIK+i4jbSmecUZ3v/Z/JFR+SZjheyfJuIeExiSnR4WMHLq7rxEqWgHFrOhPEtJd0Ups3IoeC45R0Ypk3Ioek7SfCRMXyQ/nNO5QVOYSVy9KmDtQR4gJ7fgtELqolJ6wLlfDzqeVw5aXj/Pjn7v7ttMYc6fRklnTQ9W3Xh2sypZNxn659VZO4FvlXRBzJi29lWkv3QK43dqatTV7jTUHskdLD9Stlk6dzGzoPgljKS5Temwwh3Ib0wN29QXFYWkthvs4Q+EKHGuqQJ/Kq+t4c83M0fDjMSqWy4oOONfEzm46m8m+DRa+DRa+DRa+DRYsgwWW6p2z3IlkXslz7MHZI+m3ccy3ccy3ccxkHPMCRh66eJcBMW2KBsqihp1PXUdgvZNQSEPJ9Fh9DuEoibLirpVNszFMuCdG8etGaB7pn4Q9gsTb/coXM5xutOpy+TB0UjU6dJI20rfI12wiqKSVZ7SHoj6ihpL/Ze/6mhxHkfy7PgXPFzWKu4/QMTsbWxs3Gx1d1XuPGixhmy4ZNIDqz336i+SPJMsgkC2pavpqYh6623bmL5PMBJIkmQs48qr/sngHbRvcujSOEMKUfa3sJFcAahZHwAXJBnpy2GGHJiEXy2gH96KlQ575sFtSMxoop7oS7mkv5FnbtEPoUOdhIPiwEg58uIBxB+exVOnmEXzKDLnoHsNewQi/ckmHXVY6fJeGp/vQg3Fihf4z/6CB0sH/60TKOOJ3D5VxiB8rVjq8E8HSAT/V+Q++y2IRcgLiqfatuNJr1aosPd5ENPWd0T9bgk41+sF34ZPb4DuuVzH9J98Zkn5uey5IiaWSUBGh7D2RLMk6HAnorFoXwVdDfLNZbM6x5dBXdOF3FOB9InO3Si6GirJnXNPKPN12RRx1dKwD6AbeJRfVNbRG4/61i1sgOCLPlwkoxx2+kA9F8e49g6wj5vZ4HL5UDRVc8MzVC1VHRKjuKYX1tSYdGbAixvmg7y6G566IbTSk60YYV1A70mAhh12bLv/gRBs9Newf6QkBRr+fHy3sZQGvLq/yXnjO11HNs7Q3XMMGHmH2z3//ju7Znucz/cIvdUzyBEAOlFcBQwQ2j6ofeKWMqmA6YO2M6j8IbhAgOEuiggzx/Gnqo7ebyHDCr9eLwDh7/6H4F2e/LDAcTpb3HJFOlPRRGc01+anm5ROu6yw91R0Bdr93xBHQNutRq7RsDAMi6+2P7D90z/givOOtQgSXR1PpRRnC6Lca60d+9dMm7u2TmwK62Tt7leYLsLFI6VtdTVOcojqkHFpCRM1zAG7BKzDhXdwMIsGN1QQN91vbhTdL1XNs5FY7A3CXj7xfijGIMRky8m78Itoc0+jrEv9CcAOXVpaFG4ruMxG7m21/FbyDEyxtG9eeY71LzQZ5hclkmKL4uPUaCcUEW6mtYxiHZJcDIWNbe910XjriUCWs/W4te5mNDRZzmmkCuK5BSzY3QEwFB0c9MmRR0eNDt129yIJlGcVN4W3ZmpF1Oy69hyls1SpoC1nWLr9ZxqgtqSXmbBdXVxG575wSm0wiaFOGMNisZGPew9YgG7PWHWU25mk6Am3M9KzPzca8h91q3oH11jxt+hb6q67M2XH88XwCTjqXmqWGpFA4ckRXygA68oHmDreH0BDmG3U9ZtOU03NIxdvwu1OOje9q+HhQG85rmaVqKTaqvF5H61PWspDmk/YZ8P/v+DW0qRhCbgh++jCYvxL8lAq6+EjK1sBPaRoHn/8wwL+b4x4vaAf4jbfs8Okvn/7y6S9J/iJb8Uyfufh0mU+X+XSZoMs4sLDEO5R5yeva7I6yVJ8J+YujzOvKkZ0qVbnFHRfIDd6SDdRT808mY+YjsJdZqlghkdY/j7+lZ9yYFn7GtIZHe2+g52hdNoZJkznBwf9Oa4Lkm1TkNMEmWXlrBy3HbC8I2YpXN5JrMnTMKC/GFTdpY508RJP4Q9RjHALHy1ORJ0lpPVVBcJWvQ1rnbBeg7ehxmaXqNqRTR6ps2izEPzRWU+Pk6NYcVwVcwfyvcSGq+89gPuJ6X+xrjlUWIlWGcNyOsmxaXJYqbyX0Oby1J69fl3GkMbRnPPYy/7PlCufeEvhExEOKEA2ilKagp8AfMiQ1biSpioYIyqu4MyTKM2QBh2GDG/1rsRhwCNpOInlH1mTCs2vHItmMOFOC10VsXGOl2udUa3qaKO29jqZxzetpjktey6bNL5PSE8nolCS0OsLsUUAOOksdstBQrV5b+GdLWv/1jpm2KsgPUipSXUvL0TkQ9f9D0On7oD+VqOvWO3wsWfXy7ucVdVyZVTTQ3bUVJEsVNyRqdNK7XYO25Dwv+WlHGYFbdVxUlGEo8SwwqwrbXntyirlx7zUEpLdIuWNqxuX65yCu57ypuEOlv4PMZ+w3FVyQpqYlfgeZHedNxf0wXta7/fYj73i/k8gbD7xji+t6K5YmkG3IUO9rbkzbhvg5HvounN7sZLH5byLr+h0IuNLxPcGqFaRrpgQs7tBe8FP3V4k0S/Tl673vRtyMFlSSstK/IoAL3NmsvPGjbq8DBNHLkZh+FganXpUScSYSwgI+0IdDpLpDrWxxXb/1PwW5oduBUMML40PwAsqMsZmI8/8Y4TFS8B3M1KOPzD8WwaGPyNm38oBsgjQXF7/99vCIDBb0giUqcQ0dkHgvyx30OOive7JBuy7zO7+Q+HAQ5IA/hJADLJq1lrSVYznhwIBUCJeCS2gIRfSX9eib8t8LblRpUqJlaKgHpwN7+zNvCKtgalJYPt3ib3/4CP6BSs4UpkwijOwHCD4YUsp9/jbjBqokQhW6zZB3JOeP070miS5JOp6NoFxQ9bYQv68+co6X1M81ZKlJnigz8/xDjv7OBSKv+NTUcILfql9OuGnGt00cCPe6mtlcn+RCguvoRpnZ3GVjpoKcuCKFtSt5i3F+06SczYNRMrMI/YA9B7/UFHdRXZwBz73sT7xakP2vvW6AsB/IHZKM7vfQlqQR/PXND8yqmVTLXar/nyPRTVIAUs1LXDtEiEo3rKRCiidrTz7RpmhZd/K4PFYdsn9xOO1VHqkZe1CayZoqEGgAyw8eOmlQXBdWcn31hbdqOWsAD4VGAtYKLL+hA81RNSGVXA7bAyGVXboFjJQya6dgyX5MrD0VmkYxba23zPYTGOOGnCTFCb86/LCmgQMlFzcXkuZ3/EpP7QmxNKm4kyFVAh1GZj8iFYX9xb215IOpVasZTwADA9HfKSR08VBrGgpvCEOGjdMfDKffx5LQg2EM0Q+tZCH4l5axqASCSF4/+ye48QogAe03Ittax7NG8J1ejl7gMu24DF7NvPvgy9f7c5T+hcEQf8haYpNLgizjCXEkBWbyhQiYDU1TwR3JgyhPWJVHWLX7+4KsDxb6iGKFYPWvEGfRhxfsnSkPm6lokYjzvE+OZeWPIWGERIiJumZFXtWV4IAuEkS1gpHKLBfCthzGpzh/8l+TDUaCRITfeMsqpASsb2i/IweQUw2AHTBzYKbr+STDjTxyddPi/6Gjhzp6SN+kXmT1D4+Rksp7Nfv2dsUwoIaBRWz/DZYUd2etrhrobquzPSedGapcix9HZi/4/0IGiRIxN4bBkf5QTUsZSj/zAYdBNRuSRDz3sg01kAdR6mxiAZSC2dBJwLEs6CyBNBEEYPo0Y5oY+gx1XW1rFkN1K34B8A6ytNByEpuWk5BLgoaTtkmt/g56Iyo2HsBLEfZhhsTi8YkcFoU80+CZ1XLDIsgBeBhuZLbhwErVktgEJmUzwF3fK2bxPjfpoI02i3fF/mBRY4tmWoJO3fBWdxabiSbYA/nKTgy+CTI5P2vP/jKf1vyLycTHwNWRStgsgzISHgY325MscfBmdfHVSKbfJFdkuT3to124kBTegkCaDA59C1gzLP1otF2KdBZZmTMHw9SGsDRgtNoaFro3zxcAdvKKS4UknB/p4xSY89gFRkAOPo1KfmqwojtaU/WGmlY0XIbubEmdcC9GvZPDTpmyIPOMYkxl/Y/bllbJP3Y/kvUpiyGfGJUHu/BGNd2T8q2sCTphhg8E+qAtsgwXRBHmudd1vZJFu/JECtla2QPXxrgjhCHRsjwIa4PnFMbA7HsKL0e4wSRaxig7hAHCr6viMg29LkaBGdq14NGQNUVS8QbtCLTvN80CYXuM6xqRmh7o2V4wLIn5IWcTLaUWfxmiA9vvVXdvvZwT++ch8u7Hi3mDwk+EraaAQfKwE1tzhC0gDFvDa1rScYzdxC8mEA4eG4FpIQ3odl0ye6SuX+bQlOZChhZFICwU0mwIvmM+R98OuP70LfMhvcYJZkycs2S/r2BI9tSGtmMfnQYTppHl+pTo9UPz7/MMqAECkagUegInlall0dm+/huwOm8bKHqqwqhPvKJ7SuxTGxMx1lM8lQj/cQQLlqiQ4e14h9Ex8qoK8krKFvSzDTwqNVskyyOpWrB1xaeXBO6b6xjnr4KzjsW5DYQhCdLA01xTxew3gfrW0R/AgVkfqvyc88CTShNac9/yMAiHiKkwkTJfRv0xUQHnqyHPrDk1Sknz5upIR9E8FXB4/lwb8Znte+fUBOSRaXRdGQaT6dlSYM5YOGkghBayLcvLE/tlXMiBLgLb3ZRQMkdbg+pRkK1XmhVy38LxymwHS+lHG5g9ZqB/HJy0GfQd6E6QyFhaq/y5x/Lchwd2/yEH0bpkeACHGCuiML1o/Zh07jwD498MlwuYrZjIpIP6Cl0YXwzDhl+jk+EvAaXWor1bb4vnp7ziclkbOaBWAjMJxxJZzEMmwD46Iotkvy42JlO+FFHh/d+cRjpBcy/ThfPaD31Oe8AaSt/LI8LS3Vu4665fwpuuvGmgVF54ndgBFQTLQGLQ4w8RmN80MYfTWr5+DvcM9ySgI8G1Oube9xtv0OA/NFlkyHo0CXU1fI8OghB2h1r2xPgLu0NvpK75CyhRhADPyl5HUP7LZeZ7XUGq9eyWwvTEErT6mP4S0J17ALuoSzvTin8uWwDBcP66xOD4jyPH9LDEtNngA5GF7Q5BqhUC82A9qmtywOSguYPx6GFBQocirPzu6ZeNIHf8xlUd+nwnVq/Wo9Vf3Ayr5YYoswUPFYE3fcx9o2TM4S3XGpjdxqpT8QVmVLXEVXD0m5jYhkUJejhAZXTg5v9yssByR45iXHe6Y2FM2fZ67THifckC8ifq4FwPY7/OJ7HZuXQc0daG59jevgG4HdtjfxDUr3ImT3w2ajWyrd3YytvBhMBZQrB9V2OymD+oNRl088zJzqtrPUG1rUn1wiTHo80HaYBR6HsKcsZolUdSPumnYoqqNfLl0HbQK4BNWISenEiA/ttrwxlkD3GNTvxZH68/EzG4dO9QuL/3AGNiXYp0aYNh+wvZnqMKeYCgSkIGnaCR/4asG1QGmdVLDz6/0kF6Ct6vLWeCD3AnEXoM9Kev5wOWT+IEP5EKn5qNnKVrZ9AD1PVdpSDYJgjOjGuQAyINL49xaYq2gSt0O7jIsL5Q34EZ0syc6u08p8uvS/6sr/Hs3kZCXyGoE5KR11V84Nd+RMzdrgNcv/t0gE8H+GAO0K8Bih05Urbu3rbn5rp6WPncHR24WfFGuhWAERbDFUpFWctb2W8kwzKVRwzPsZsMe0WUvnZYYOUfwFul6y3R8kXUK9oLETb37iDdMGw1PqwozeWZQXtpmXVomr8QK8/+bwABhb3i"
 }
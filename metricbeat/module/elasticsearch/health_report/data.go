@@ -0,0 +1,202 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health_report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type healthReport struct {
+	Status     string               `json:"status"`
+	Indicators map[string]indicator `json:"indicators"`
+}
+
+type indicator struct {
+	Status    string      `json:"status"`
+	Symptom   string      `json:"symptom"`
+	Impacts   []impact    `json:"impacts"`
+	Diagnosis []diagnosis `json:"diagnosis"`
+}
+
+type impact struct {
+	ID          string   `json:"id"`
+	Severity    int      `json:"severity"`
+	ImpactAreas []string `json:"impact_areas"`
+}
+
+type diagnosis struct {
+	ID                string                     `json:"id"`
+	AffectedResources map[string]json.RawMessage `json:"affected_resources"`
+}
+
+type node struct {
+	ID   string `json:"node_id"`
+	Name string `json:"name"`
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+	var report healthReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch Health Report API response: %w", err)
+	}
+
+	names := make([]string, 0, len(report.Indicators))
+	for name := range report.Indicators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ind := report.Indicators[name]
+
+		fields := mapstr.M{
+			"cluster_status": report.Status,
+			"indicator": mapstr.M{
+				"name":    name,
+				"status":  ind.Status,
+				"symptom": ind.Symptom,
+			},
+		}
+
+		if len(ind.Impacts) > 0 {
+			fields.Put("indicator.impact", impactFields(ind.Impacts))
+		}
+
+		if len(ind.Diagnosis) > 0 {
+			ids := make([]string, 0, len(ind.Diagnosis))
+			for _, d := range ind.Diagnosis {
+				ids = append(ids, d.ID)
+			}
+			fields.Put("indicator.diagnosis.id", ids)
+
+			if resources := impactedResources(ind.Diagnosis); len(resources) > 0 {
+				fields.Put("indicator.impacted_resources", resources)
+			}
+		}
+
+		event := mb.Event{
+			RootFields:      mapstr.M{},
+			ModuleFields:    mapstr.M{},
+			MetricSetFields: fields,
+		}
+
+		event.RootFields.Put("service.name", elasticsearch.ModuleName)
+		event.ModuleFields.Put("cluster.name", info.ClusterName)
+		event.ModuleFields.Put("cluster.id", info.ClusterID)
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return nil
+}
+
+// impactFields summarizes the impacts of an indicator. Severity goes from 1,
+// the most severe, to 5, so the lowest one is reported.
+func impactFields(impacts []impact) mapstr.M {
+	ids := make([]string, 0, len(impacts))
+	areas := map[string]struct{}{}
+	severity := 0
+	for _, i := range impacts {
+		ids = append(ids, i.ID)
+		for _, area := range i.ImpactAreas {
+			areas[area] = struct{}{}
+		}
+		if severity == 0 || i.Severity < severity {
+			severity = i.Severity
+		}
+	}
+
+	return mapstr.M{
+		"id":       ids,
+		"areas":    sortedKeys(areas),
+		"severity": severity,
+	}
+}
+
+// impactedResources merges the resources affected by all the diagnoses of an
+// indicator, by resource type. Nodes are reported by name.
+func impactedResources(diagnoses []diagnosis) mapstr.M {
+	byType := map[string]map[string]struct{}{}
+	for _, d := range diagnoses {
+		for resourceType, raw := range d.AffectedResources {
+			names := resourceNames(resourceType, raw)
+			if len(names) == 0 {
+				continue
+			}
+			if byType[resourceType] == nil {
+				byType[resourceType] = map[string]struct{}{}
+			}
+			for _, name := range names {
+				byType[resourceType][name] = struct{}{}
+			}
+		}
+	}
+
+	resources := mapstr.M{}
+	for resourceType, names := range byType {
+		resources[resourceType] = sortedKeys(names)
+	}
+	return resources
+}
+
+func resourceNames(resourceType string, raw json.RawMessage) []string {
+	if resourceType == "nodes" {
+		var nodes []node
+		if err := json.Unmarshal(raw, &nodes); err != nil {
+			return nil
+		}
+		names := make([]string, 0, len(nodes))
+		for _, n := range nodes {
+			if n.Name != "" {
+				names = append(names, n.Name)
+			} else {
+				names = append(names, n.ID)
+			}
+		}
+		return names
+	}
+
+	var names []string
+	if err := json.Unmarshal(raw, &names); err != nil {
+		return nil
+	}
+	return names
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health_report
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "health_report", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	healthReportPath = "/_health_report"
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	lastHealthReportMessageTimestamp time.Time
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := elasticsearch.NewMetricSet(base, healthReportPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch gathers the status and diagnoses of each health indicator from the
+// _health_report API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	healthReportUnavailableMessage := m.checkHealthReportAvailability(info.Version.Number)
+	if healthReportUnavailableMessage != "" {
+		if time.Since(m.lastHealthReportMessageTimestamp) > 1*time.Minute {
+			m.lastHealthReportMessageTimestamp = time.Now()
+			m.Logger().Warn(healthReportUnavailableMessage)
+		}
+		return nil
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return eventsMapping(r, *info, content, m.XPackEnabled)
+}
+
+func (m *MetricSet) checkHealthReportAvailability(currentElasticsearchVersion *version.V) string {
+	isAvailable := elastic.IsFeatureAvailable(currentElasticsearchVersion, elasticsearch.HealthReportAPIAvailableVersion)
+
+	if !isAvailable {
+		metricsetName := m.FullyQualifiedName()
+		return "the " + metricsetName + " is only supported with Elasticsearch >= " +
+			elasticsearch.HealthReportAPIAvailableVersion.String() + ". " +
+			"You are currently running Elasticsearch " + currentElasticsearchVersion.String() + "."
+	}
+
+	return ""
+}
@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package health_report
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/health_report.870.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, true)
+	require.NoError(t, err)
+
+	// Indicators are reported by name order
+	events := reporter.GetEvents()
+	require.Len(t, events, 4)
+
+	disk := events[0].MetricSetFields
+	require.Equal(t, mapstr.M{
+		"cluster_status": "yellow",
+		"indicator": mapstr.M{
+			"name":    "disk",
+			"status":  "red",
+			"symptom": "2 indices are not allowed to be updated. 1 node is out of disk or running low on disk space.",
+			"impact": mapstr.M{
+				"id": []string{
+					"elasticsearch:health:disk:impact:ingest_capability_unavailable",
+					"elasticsearch:health:disk:impact:cluster_stability_at_risk",
+				},
+				"areas":    []string{"deployment_management", "ingest"},
+				"severity": 1,
+			},
+			"diagnosis": mapstr.M{
+				"id": []string{"elasticsearch:health:disk:diagnosis:add_disk_capacity_data_nodes"},
+			},
+			"impacted_resources": mapstr.M{
+				"indices": []string{"logs-1", "logs-2"},
+				"nodes":   []string{"a14cf47ef7f2"},
+			},
+		},
+	}, disk)
+
+	// A healthy indicator has neither impacts nor diagnoses
+	master := events[1].MetricSetFields
+	require.Equal(t, "master_is_stable", mustGetValue(t, master, "indicator.name"))
+	require.Equal(t, "green", mustGetValue(t, master, "indicator.status"))
+	for _, field := range []string{"indicator.impact", "indicator.diagnosis", "indicator.impacted_resources"} {
+		hasKey, _ := master.HasKey(field)
+		require.False(t, hasKey, field)
+	}
+
+	shards := events[2].MetricSetFields
+	require.Equal(t, "shards_availability", mustGetValue(t, shards, "indicator.name"))
+	require.Equal(t, 2, mustGetValue(t, shards, "indicator.impact.severity"))
+	require.Equal(t, []string{"search"}, mustGetValue(t, shards, "indicator.impact.areas"))
+
+	require.Equal(t, "slm", mustGetValue(t, events[3].MetricSetFields, "indicator.name"))
+}
+
+func TestHealthReportNotAvailable(t *testing.T) {
+	mux := createEsMuxer("8.6.2")
+	mux.Handle("/_health_report", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "this should never have been called", 418)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2WithContext(ms)
+
+	require.Empty(t, errs)
+	require.Empty(t, events)
+}
+
+func TestData(t *testing.T) {
+	mux := createEsMuxer("8.7.0")
+	mux.Handle("/_health_report", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/health_report.870.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func createEsMuxer(esVersion string) *http.ServeMux {
+	nodesLocalHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"nodes": { "foobar": {}}}`))
+	}
+	clusterStateMasterHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"master_node": "foobar"}`))
+	}
+	rootHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+		}
+
+		input, _ := ioutil.ReadFile("./_meta/test/root.870.json")
+		input = []byte(strings.Replace(string(input), "8.7.0", esVersion, -1))
+		w.Write(input)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/_nodes/_local/nodes", http.HandlerFunc(nodesLocalHandler))
+	mux.Handle("/_cluster/state/master_node", http.HandlerFunc(clusterStateMasterHandler))
+	mux.Handle("/", http.HandlerFunc(rootHandler))
+
+	return mux
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"health_report"},
+		"hosts":      []string{host},
+	}
+}
+
+func mustGetValue(t *testing.T, m mapstr.M, key string) interface{} {
+	value, err := m.GetValue(key)
+	require.NoError(t, err, key)
+	return value
+}
@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ilm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// errorStep is the step an index is moved to when a lifecycle step fails.
+const errorStep = "ERROR"
+
+var (
+	statusSchema = s.Schema{
+		"operation_mode": c.Str("operation_mode"),
+	}
+
+	errorIndexSchema = s.Schema{
+		"name":        c.Str("index"),
+		"policy":      c.Str("policy", s.Optional),
+		"phase":       c.Str("phase", s.Optional),
+		"action":      c.Str("action", s.Optional),
+		"failed_step": c.Str("failed_step", s.Optional),
+		"step_time": s.Object{
+			"ms": c.Int("step_time_millis", s.Optional),
+		},
+		"retryable":   c.Bool("is_auto_retryable_error", s.Optional),
+		"retry_count": c.Int("failed_step_retry_count", s.Optional),
+		"error": c.Dict("step_info", s.Schema{
+			"type":   c.Str("type", s.Optional),
+			"reason": c.Str("reason", s.Optional),
+		}, c.DictOptional),
+	}
+)
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, statusContent, explainContent []byte, isXpack bool) error {
+	var status map[string]interface{}
+	if err := json.Unmarshal(statusContent, &status); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch ILM Status API response: %w", err)
+	}
+
+	var explain struct {
+		Indices map[string]map[string]interface{} `json:"indices"`
+	}
+	if err := json.Unmarshal(explainContent, &explain); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch ILM Explain API response: %w", err)
+	}
+
+	var errs multierror.Errors
+
+	fields, err := statusSchema.Apply(status)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failure applying ILM status schema: %w", err))
+	} else {
+		fields.DeepUpdate(summarize(explain.Indices))
+		r.Event(newEvent(info, fields, isXpack))
+	}
+
+	var errorIndices []string
+	for name, index := range explain.Indices {
+		if isManaged(index) && index["step"] == errorStep {
+			errorIndices = append(errorIndices, name)
+		}
+	}
+	sort.Strings(errorIndices)
+
+	for _, name := range errorIndices {
+		fields, err := errorIndexSchema.Apply(explain.Indices[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure applying ILM explain schema for index %s: %w", name, err))
+			continue
+		}
+
+		r.Event(newEvent(info, mapstr.M{"index": fields}, isXpack))
+	}
+
+	return errs.Err()
+}
+
+// summarize counts the managed indices per phase and the indices whose
+// lifecycle is stuck in the error step.
+func summarize(indices map[string]map[string]interface{}) mapstr.M {
+	var managed, inError, retryable, retries int64
+	phases := mapstr.M{}
+
+	for _, index := range indices {
+		if !isManaged(index) {
+			continue
+		}
+		managed++
+
+		if phase, ok := index["phase"].(string); ok && phase != "" {
+			count, _ := phases[phase].(int64)
+			phases[phase] = count + 1
+		}
+
+		if index["step"] == errorStep {
+			inError++
+			if isRetryable, _ := index["is_auto_retryable_error"].(bool); isRetryable {
+				retryable++
+			}
+		}
+
+		if count, ok := index["failed_step_retry_count"].(float64); ok {
+			retries += int64(count)
+		}
+	}
+
+	return mapstr.M{
+		"indices": mapstr.M{
+			"managed": managed,
+			"phase":   phases,
+			"error": mapstr.M{
+				"count":     inError,
+				"retryable": retryable,
+			},
+		},
+		"step": mapstr.M{
+			"retries": retries,
+		},
+	}
+}
+
+func isManaged(index map[string]interface{}) bool {
+	managed, _ := index["managed"].(bool)
+	return managed
+}
+
+func newEvent(info elasticsearch.Info, fields mapstr.M, isXpack bool) mb.Event {
+	event := mb.Event{
+		RootFields:      mapstr.M{},
+		ModuleFields:    mapstr.M{},
+		MetricSetFields: fields,
+	}
+
+	event.RootFields.Put("service.name", elasticsearch.ModuleName)
+	event.ModuleFields.Put("cluster.name", info.ClusterName)
+	event.ModuleFields.Put("cluster.id", info.ClusterID)
+
+	// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+	// When using Agent, the index name is overwritten anyways.
+	if isXpack {
+		index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+		event.Index = index
+	}
+
+	return event
+}
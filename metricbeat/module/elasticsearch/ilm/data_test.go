@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package ilm
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	status, err := ioutil.ReadFile("./_meta/test/ilm_status.710.json")
+	require.NoError(t, err)
+	explain, err := ioutil.ReadFile("./_meta/test/ilm_explain.710.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, status, explain, true)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 3)
+
+	summary := events[0].MetricSetFields
+	for field, expected := range map[string]interface{}{
+		"operation_mode":          "RUNNING",
+		"indices.managed":         int64(4),
+		"indices.phase.hot":       int64(1),
+		"indices.phase.warm":      int64(2),
+		"indices.phase.delete":    int64(1),
+		"indices.error.count":     int64(2),
+		"indices.error.retryable": int64(1),
+		"step.retries":            int64(4),
+	} {
+		value, err := summary.GetValue(field)
+		require.NoError(t, err, field)
+		require.Equal(t, expected, value, field)
+	}
+
+	// Indices in the error step are reported by name order
+	for i, expected := range []mapstr.M{
+		{
+			"name":         ".ds-metrics-default-2020.12.01-000003",
+			"policy":       "metrics",
+			"failed_step":  "shrink",
+			"retryable":    true,
+			"retry_count":  int64(4),
+			"error.type":   "illegal_argument_exception",
+			"step_time.ms": int64(1606900940235),
+		},
+		{
+			"name":        "audit-000001",
+			"phase":       "delete",
+			"failed_step": "wait-for-shard-history-leases",
+			"retryable":   false,
+			"error.type":  "security_exception",
+		},
+	} {
+		index := events[i+1].MetricSetFields
+		for field, value := range expected {
+			actual, err := index.GetValue("index." + field)
+			require.NoError(t, err, field)
+			require.Equal(t, value, actual, field)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	status, err := ioutil.ReadFile("./_meta/test/ilm_status.710.json")
+	require.NoError(t, err)
+	explain, err := ioutil.ReadFile("./_meta/test/ilm_explain.empty.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, status, explain, true)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 1)
+
+	managed, err := events[0].MetricSetFields.GetValue("indices.managed")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, managed)
+}
+
+func TestExplainQuery(t *testing.T) {
+	require.Equal(t, "", explainQuery(version.MustNew("7.6.2")))
+	require.Equal(t, "expand_wildcards=open,hidden", explainQuery(version.MustNew("7.7.0")))
+}
+
+func TestData(t *testing.T) {
+	mux := createEsMuxer("7.10.0", "active", true)
+	mux.Handle("/_ilm/status", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/ilm_status.710.json")
+		w.Write(input)
+	}))
+	mux.Handle("/*/_ilm/explain", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/ilm_explain.710.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ilm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "ilm", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	ilmStatusPath  = "/_ilm/status"
+	ilmExplainPath = "/*/_ilm/explain"
+
+	expandWildcardsHidden = "expand_wildcards=open,hidden"
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	lastILMMessageTimestamp time.Time
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := elasticsearch.NewMetricSet(base, ilmStatusPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch gathers the index lifecycle management status from the _ilm/status API
+// and a summary of the lifecycle state of the managed indices from the
+// _ilm/explain API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	ilmUnavailableMessage, err := m.checkILMAvailability(ctx, info.Version.Number)
+	if err != nil {
+		return fmt.Errorf("error determining if ILM is available: %w", err)
+	}
+
+	if ilmUnavailableMessage != "" {
+		if err := m.UnavailableError(ilmUnavailableMessage); err != nil {
+			return err
+		}
+		if time.Since(m.lastILMMessageTimestamp) > 1*time.Minute {
+			m.lastILMMessageTimestamp = time.Now()
+			m.Logger().Warn(ilmUnavailableMessage)
+		}
+		return nil
+	}
+
+	statusContent, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	explainContent, err := m.FetchPath(ctx, ilmExplainPath, explainQuery(info.Version.Number))
+	if err != nil {
+		return err
+	}
+
+	return eventsMapping(r, *info, statusContent, explainContent, m.XPackEnabled)
+}
+
+// explainQuery returns the query string of the _ilm/explain request. Hidden
+// indices, such as data stream backing indices, are only matched by the
+// wildcard if they are explicitly expanded.
+func explainQuery(esVersion *version.V) string {
+	if !esVersion.LessThan(elasticsearch.ExpandWildcardsHiddenAvailableVersion) {
+		return expandWildcardsHidden
+	}
+	return ""
+}
+
+func (m *MetricSet) checkILMAvailability(ctx context.Context, currentElasticsearchVersion *version.V) (message string, err error) {
+	isAvailable := elastic.IsFeatureAvailable(currentElasticsearchVersion, elasticsearch.ILMAPIAvailableVersion)
+
+	if !isAvailable {
+		metricsetName := m.FullyQualifiedName()
+		message = "the " + metricsetName + " is only supported with Elasticsearch >= " +
+			elasticsearch.ILMAPIAvailableVersion.String() + ". " +
+			"You are currently running Elasticsearch " + currentElasticsearchVersion.String() + "."
+		return
+	}
+
+	license, err := m.GetLicense(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining Elasticsearch license: %w", err)
+	}
+
+	if license.Status != "" && license.Status != "active" {
+		message = "the ILM feature requires an active Elasticsearch license. " +
+			"Your " + license.Type + " license is currently " + license.Status + "."
+		return
+	}
+
+	xpack, err := m.GetXPack(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining xpack features: %w", err)
+	}
+
+	if !xpack.Features.ILM.Enabled {
+		message = "the ILM feature is not enabled on your Elasticsearch cluster."
+		return
+	}
+
+	return "", nil
+}
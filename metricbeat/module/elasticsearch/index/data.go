@@ -18,8 +18,10 @@
 package index
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/joeshaw/multierror"
 	"github.com/pkg/errors"
@@ -32,10 +34,6 @@ import (
 )
 
 // Based on https://github.com/elastic/elasticsearch/blob/master/x-pack/plugin/monitoring/src/main/java/org/elasticsearch/xpack/monitoring/collector/indices/IndexStatsMonitoringDoc.java#L127-L203
-type stats struct {
-	Indices map[string]Index `json:"indices"`
-}
-
 type Index struct {
 	UUID      string    `json:"uuid"`
 	Primaries primaries `json:"primaries"`
@@ -178,25 +176,20 @@ type bulkStats struct {
 	AvgSizeInBytes    int `json:"avg_size_in_bytes"`
 }
 
-func eventsMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.Info, content []byte, isXpack bool) error {
+func eventsMapping(ctx context.Context, r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.Info, content io.Reader, isXpack bool) error {
 	clusterStateMetrics := []string{"routing_table"}
-	clusterState, err := elasticsearch.GetClusterState(httpClient, httpClient.GetURI(), clusterStateMetrics)
+	clusterState, err := elasticsearch.GetClusterState(ctx, httpClient, httpClient.GetURI(), clusterStateMetrics)
 	if err != nil {
 		return errors.Wrap(err, "failure retrieving cluster state from Elasticsearch")
 	}
 
-	var indicesStats stats
-	if err := parseAPIResponse(content, &indicesStats); err != nil {
-		return errors.Wrap(err, "failure parsing Indices Stats Elasticsearch API response")
-	}
-
-	indicesSettings, err := elasticsearch.GetIndicesSettings(httpClient, httpClient.GetURI())
+	indicesSettings, err := elasticsearch.GetIndicesSettings(ctx, httpClient, httpClient.GetURI())
 	if err != nil {
 		return errors.Wrap(err, "failure retrieving indices settings from Elasticsearch")
 	}
 
 	var errs multierror.Errors
-	for name, idx := range indicesStats.Indices {
+	err = parseAPIResponse(content, func(name string, idx Index) {
 		event := mb.Event{
 			ModuleFields: mapstr.M{},
 		}
@@ -207,10 +200,10 @@ func eventsMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.
 			idx.Hidden = settings.Hidden
 		}
 
-		err = addClusterStateFields(&idx, clusterState)
+		err := addClusterStateFields(&idx, clusterState)
 		if err != nil {
 			errs = append(errs, errors.Wrap(err, "failure adding cluster state fields"))
-			continue
+			return
 		}
 
 		event.ModuleFields.Put("cluster.id", info.ClusterID)
@@ -221,12 +214,12 @@ func eventsMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.
 		indexBytes, err := json.Marshal(idx)
 		if err != nil {
 			errs = append(errs, errors.Wrap(err, "failure trying to convert metrics results to JSON"))
-			continue
+			return
 		}
 		var indexOutput mapstr.M
 		if err = json.Unmarshal(indexBytes, &indexOutput); err != nil {
 			errs = append(errs, errors.Wrap(err, "failure trying to convert JSON metrics back to mapstr"))
-			continue
+			return
 		}
 
 		event.MetricSetFields = indexOutput
@@ -241,13 +234,32 @@ func eventsMapping(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.
 		}
 
 		r.Event(event)
+	})
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "failure parsing Indices Stats Elasticsearch API response"))
 	}
 
 	return errs.Err()
 }
 
-func parseAPIResponse(content []byte, indicesStats *stats) error {
-	return json.Unmarshal(content, indicesStats)
+// parseAPIResponse decodes the stats of the indices one at a time while the response is
+// read, and calls fn for each of them.
+func parseAPIResponse(content io.Reader, fn func(name string, idx Index)) error {
+	dec := json.NewDecoder(content)
+	return elasticsearch.WalkJSONObject(dec, func(key string) error {
+		if key != "indices" {
+			return elasticsearch.SkipJSONValue(dec)
+		}
+
+		return elasticsearch.WalkJSONObject(dec, func(name string) error {
+			var idx Index
+			if err := dec.Decode(&idx); err != nil {
+				return errors.Wrapf(err, "failure decoding stats of index %v", name)
+			}
+			fn(name, idx)
+			return nil
+		})
+	})
 }
 
 // Fields added here are based on same fields being added by internal collection in
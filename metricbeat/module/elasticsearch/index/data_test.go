@@ -21,9 +21,13 @@
 package index
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -64,7 +68,10 @@ func TestMapper(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	elasticsearch.TestMapperWithHttpHelper(t, "../index/_meta/test/stats.*.json", httpClient, eventsMapping)
+	elasticsearch.TestMapperWithHttpHelper(t, "../index/_meta/test/stats.*.json", httpClient,
+		func(r mb.ReporterV2, httpClient *helper.HTTP, info elasticsearch.Info, content []byte, isXpack bool) error {
+			return eventsMapping(context.Background(), r, httpClient, info, bytes.NewReader(content), isXpack)
+		})
 }
 
 func TestEmpty(t *testing.T) {
@@ -77,10 +84,47 @@ func TestEmpty(t *testing.T) {
 	require.NoError(t, err)
 
 	reporter := &mbtest.CapturingReporterV2{}
-	eventsMapping(reporter, httpClient, info, input, true)
+	eventsMapping(context.Background(), reporter, httpClient, info, bytes.NewReader(input), true)
 	require.Equal(t, 0, len(reporter.GetEvents()))
 }
 
+func TestParseAPIResponse(t *testing.T) {
+	files, err := filepath.Glob("./_meta/test/stats.*.json")
+	require.NoError(t, err)
+
+	for _, f := range files {
+		t.Run(f, func(t *testing.T) {
+			input, err := ioutil.ReadFile(f)
+			require.NoError(t, err)
+
+			var expected struct {
+				Indices map[string]Index `json:"indices"`
+			}
+			require.NoError(t, json.Unmarshal(input, &expected))
+
+			indices := map[string]Index{}
+			err = parseAPIResponse(bytes.NewReader(input), func(name string, idx Index) {
+				indices[name] = idx
+			})
+			require.NoError(t, err)
+			require.Equal(t, expected.Indices, indices)
+		})
+	}
+}
+
+func TestParseAPIResponseTruncated(t *testing.T) {
+	input, err := ioutil.ReadFile("./_meta/test/stats.800.snapshot.20201118.json")
+	require.NoError(t, err)
+
+	var names []string
+	err = parseAPIResponse(bytes.NewReader(input[:len(input)/2]), func(name string, idx Index) {
+		names = append(names, name)
+	})
+	require.Error(t, err)
+	// Indices decoded before the truncation are still reported
+	require.NotEmpty(t, names)
+}
+
 func createEsMuxer(esVersion, license string, ccrEnabled bool) *http.ServeMux {
 	nodesLocalHandler := func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{"nodes": { "foobar": {}}}`))
@@ -136,8 +180,8 @@ func TestData(t *testing.T) {
 	server := httptest.NewServer(mux)
 	defer server.Close()
 
-	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
-	if err := mbtest.WriteEventsReporterV2Error(ms, t, ""); err != nil {
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
 		t.Fatal("errors writing events to data.json file", err)
 	}
 }
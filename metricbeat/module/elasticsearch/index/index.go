@@ -18,6 +18,7 @@
 package index
 
 import (
+	"context"
 	"net/url"
 	"strings"
 
@@ -62,8 +63,8 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 }
 
 // Fetch gathers stats for each index from the _stats API
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	shouldSkip, err := m.ShouldSkipFetch()
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
 	if err != nil {
 		return err
 	}
@@ -71,7 +72,7 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.HostData().SanitizedURI)
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.HostData().SanitizedURI)
 	if err != nil {
 		return errors.Wrap(err, "failed to get info from Elasticsearch")
 	}
@@ -80,12 +81,15 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return err
 	}
 
-	content, err := m.HTTP.FetchContent()
+	// The response can hold the stats of tens of thousands of indices, it is decoded
+	// while it is read instead of being buffered.
+	body, err := m.HTTP.FetchStreamWithContext(ctx)
 	if err != nil {
 		return err
 	}
+	defer body.Close()
 
-	return eventsMapping(r, m.HTTP, *info, content, m.XPackEnabled)
+	return eventsMapping(ctx, r, m.HTTP, *info, body, m.XPackEnabled)
 }
 
 func (m *MetricSet) updateServicePath(esVersion version.V) error {
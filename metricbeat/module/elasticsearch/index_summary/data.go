@@ -20,6 +20,7 @@ package index_summary
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -90,17 +91,21 @@ var bulkStatsDict = c.Dict("bulk", s.Schema{
 	},
 }, c.DictOptional)
 
-func eventMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
-	var all struct {
-		Data map[string]interface{} `json:"_all"`
-	}
+func eventMapping(r mb.ReporterV2, info elasticsearch.Info, content io.Reader, isXpack bool) error {
+	var all map[string]interface{}
 
-	err := json.Unmarshal(content, &all)
+	dec := json.NewDecoder(content)
+	err := elasticsearch.WalkJSONObject(dec, func(key string) error {
+		if key != "_all" {
+			return elasticsearch.SkipJSONValue(dec)
+		}
+		return dec.Decode(&all)
+	})
 	if err != nil {
 		return fmt.Errorf("failure parsing Elasticsearch Stats API response: %w", err)
 	}
 
-	fields, err := schema.Apply(all.Data, s.FailOnRequired)
+	fields, err := schema.Apply(all, s.FailOnRequired)
 	if err != nil {
 		return fmt.Errorf("failure applying stats schema: %w", err)
 	}
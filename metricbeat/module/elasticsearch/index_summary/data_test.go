@@ -21,6 +21,7 @@
 package index_summary
 
 import (
+	"bytes"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/metricbeat/mb"
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
 	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
 )
@@ -76,14 +78,16 @@ func TestData(t *testing.T) {
 	server := httptest.NewServer(mux)
 	defer server.Close()
 
-	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
-	if err := mbtest.WriteEventsReporterV2Error(ms, t, ""); err != nil {
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
 		t.Fatal("write", err)
 	}
 }
 
 func TestMapper(t *testing.T) {
-	elasticsearch.TestMapperWithInfo(t, "../index/_meta/test/stats.*.json", eventMapping)
+	elasticsearch.TestMapperWithInfo(t, "../index/_meta/test/stats.*.json", func(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+		return eventMapping(r, info, bytes.NewReader(content), isXpack)
+	})
 }
 
 func TestEmpty(t *testing.T) {
@@ -91,7 +95,7 @@ func TestEmpty(t *testing.T) {
 	require.NoError(t, err)
 
 	reporter := &mbtest.CapturingReporterV2{}
-	eventMapping(reporter, info, input, true)
+	eventMapping(reporter, info, bytes.NewReader(input), true)
 	require.Empty(t, reporter.GetErrors())
 	require.Equal(t, 1, len(reporter.GetEvents()))
 }
@@ -18,6 +18,7 @@
 package index_summary
 
 import (
+	"context"
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -61,8 +62,8 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 }
 
 // Fetch gathers stats for each index from the _stats API
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	shouldSkip, err := m.ShouldSkipFetch()
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
 	if err != nil {
 		return err
 	}
@@ -70,15 +71,18 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	content, err := m.HTTP.FetchContent()
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.HostData().SanitizedURI+statsPath)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to get info from Elasticsearch")
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.HostData().SanitizedURI+statsPath)
+	// Only the _all section is used, the stats of every single index are skipped while
+	// the response is read instead of being buffered.
+	body, err := m.HTTP.FetchStreamWithContext(ctx)
 	if err != nil {
-		return errors.Wrap(err, "failed to get info from Elasticsearch")
+		return err
 	}
+	defer body.Close()
 
-	return eventMapping(r, *info, content, m.XPackEnabled)
+	return eventMapping(r, *info, body, m.XPackEnabled)
 }
@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest_pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type nodesStats struct {
+	Nodes map[string]nodeStats `json:"nodes"`
+}
+
+type nodeStats struct {
+	Name   string `json:"name"`
+	Ingest struct {
+		Pipelines map[string]pipelineStats `json:"pipelines"`
+	} `json:"ingest"`
+}
+
+type pipelineStats struct {
+	ingestStats
+	// Each processor is keyed by its type, followed by its tag if it has one,
+	// e.g. `set:my-tag`.
+	Processors []map[string]processorStats `json:"processors"`
+}
+
+type processorStats struct {
+	Type  string      `json:"type"`
+	Stats ingestStats `json:"stats"`
+}
+
+type ingestStats struct {
+	Count        int64 `json:"count"`
+	TimeInMillis int64 `json:"time_in_millis"`
+	Current      int64 `json:"current"`
+	Failed       int64 `json:"failed"`
+}
+
+func (s *ingestStats) add(other ingestStats) {
+	s.Count += other.Count
+	s.TimeInMillis += other.TimeInMillis
+	s.Current += other.Current
+	s.Failed += other.Failed
+}
+
+func (s ingestStats) toMapStr() mapstr.M {
+	return mapstr.M{
+		"count":   s.Count,
+		"current": s.Current,
+		"failed":  s.Failed,
+		"time": mapstr.M{
+			"total": mapstr.M{
+				"ms": s.TimeInMillis,
+			},
+		},
+	}
+}
+
+type processor struct {
+	Type  string
+	Tag   string
+	Stats ingestStats
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, aggregateNodes bool, isXpack bool) error {
+	var stats nodesStats
+	if err := json.Unmarshal(content, &stats); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch Node Ingest Stats API response: %w", err)
+	}
+
+	if aggregateNodes {
+		reportPipelines(r, info, nil, aggregate(stats.Nodes), isXpack)
+		return nil
+	}
+
+	nodeIDs := make([]string, 0, len(stats.Nodes))
+	for nodeID := range stats.Nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	for _, nodeID := range nodeIDs {
+		node := stats.Nodes[nodeID]
+		nodeFields := mapstr.M{
+			"id":   nodeID,
+			"name": node.Name,
+		}
+		reportPipelines(r, info, nodeFields, node.Ingest.Pipelines, isXpack)
+	}
+
+	return nil
+}
+
+// aggregate sums the stats of every pipeline, and of each of its processors,
+// across the given nodes. Processors are matched by their position in the
+// pipeline, as all the nodes run the same pipeline definition.
+func aggregate(nodes map[string]nodeStats) map[string]pipelineStats {
+	pipelines := map[string]pipelineStats{}
+	for _, node := range nodes {
+		for name, pipeline := range node.Ingest.Pipelines {
+			total, found := pipelines[name]
+			if !found {
+				total.Processors = make([]map[string]processorStats, 0, len(pipeline.Processors))
+			}
+			total.add(pipeline.ingestStats)
+
+			for i, entry := range pipeline.Processors {
+				if i == len(total.Processors) {
+					total.Processors = append(total.Processors, map[string]processorStats{})
+				}
+				for key, stats := range entry {
+					sum := total.Processors[i][key]
+					sum.Type = stats.Type
+					sum.Stats.add(stats.Stats)
+					total.Processors[i][key] = sum
+				}
+			}
+
+			pipelines[name] = total
+		}
+	}
+	return pipelines
+}
+
+func reportPipelines(r mb.ReporterV2, info elasticsearch.Info, nodeFields mapstr.M, pipelines map[string]pipelineStats, isXpack bool) {
+	names := make([]string, 0, len(pipelines))
+	for name := range pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pipeline := pipelines[name]
+
+		r.Event(newEvent(info, nodeFields, mapstr.M{
+			"name":  name,
+			"total": pipeline.ingestStats.toMapStr(),
+		}, isXpack))
+
+		for i, p := range processors(pipeline) {
+			fields := p.Stats.toMapStr()
+			fields["type"] = p.Type
+			fields["order_index"] = i
+			if p.Tag != "" {
+				fields["tag"] = p.Tag
+			}
+
+			r.Event(newEvent(info, nodeFields, mapstr.M{
+				"name":      name,
+				"processor": fields,
+			}, isXpack))
+		}
+	}
+}
+
+// processors flattens the processors of a pipeline in their order of execution.
+func processors(pipeline pipelineStats) []processor {
+	var list []processor
+	for _, entry := range pipeline.Processors {
+		for key, stats := range entry {
+			p := processor{Type: stats.Type, Stats: stats.Stats}
+			if i := strings.IndexByte(key, ':'); i >= 0 {
+				p.Tag = key[i+1:]
+			}
+			if p.Type == "" {
+				p.Type = strings.SplitN(key, ":", 2)[0]
+			}
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+func newEvent(info elasticsearch.Info, nodeFields mapstr.M, fields mapstr.M, isXpack bool) mb.Event {
+	event := mb.Event{
+		RootFields:      mapstr.M{},
+		ModuleFields:    mapstr.M{},
+		MetricSetFields: fields,
+	}
+
+	event.RootFields.Put("service.name", elasticsearch.ModuleName)
+	event.ModuleFields.Put("cluster.name", info.ClusterName)
+	event.ModuleFields.Put("cluster.id", info.ClusterID)
+	if nodeFields != nil {
+		event.ModuleFields.Put("node", nodeFields)
+	}
+
+	// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+	// When using Agent, the index name is overwritten anyways.
+	if isXpack {
+		index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+		event.Index = index
+	}
+
+	return event
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ingest_pipeline
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "ingest_pipeline", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	nodeLocalIngestStatsPath = "/_nodes/_local/stats/ingest"
+	nodesAllIngestStatsPath  = "/_nodes/_all/stats/ingest"
+
+	ingestStatsQuery = "filter_path=nodes.*.name,nodes.*.ingest.pipelines"
+)
+
+// Config contains the ingest_pipeline specific settings of the elasticsearch module
+type Config struct {
+	// AggregateNodes sums the pipeline and processor stats of all the nodes of
+	// the cluster instead of reporting them per node.
+	AggregateNodes bool `config:"ingest_pipeline.aggregate_nodes"`
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	config Config
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := Config{
+		AggregateNodes: false,
+	}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := elasticsearch.NewMetricSet(base, nodeLocalIngestStatsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms, config: config}, nil
+}
+
+// Fetch gathers the stats of the ingest pipelines and their processors from the
+// ingest section of the _nodes/stats API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipServerless(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	// Stats aggregated across nodes are cluster-wide, so they are only
+	// collected from the master node like the other cluster-level metricsets.
+	if m.config.AggregateNodes {
+		shouldSkip, err = m.ShouldSkipFetch(ctx)
+		if err != nil {
+			return err
+		}
+		if shouldSkip {
+			return nil
+		}
+	}
+
+	if err := m.updateServiceURI(); err != nil {
+		return err
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	return eventsMapping(r, *info, content, m.config.AggregateNodes, m.XPackEnabled)
+}
+
+func (m *MetricSet) updateServiceURI() error {
+	u, err := getServiceURI(m.GetURI(), m.Scope, m.config.AggregateNodes)
+	if err != nil {
+		return err
+	}
+
+	m.HTTP.SetURI(u)
+	return nil
+}
+
+func getServiceURI(currURI string, scope elasticsearch.Scope, aggregateNodes bool) (string, error) {
+	u, err := url.Parse(currURI)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = nodeLocalIngestStatsPath
+	if scope == elasticsearch.ScopeCluster || aggregateNodes {
+		u.Path = nodesAllIngestStatsPath
+	}
+	u.RawQuery = ingestStatsQuery
+
+	return u.String(), nil
+}
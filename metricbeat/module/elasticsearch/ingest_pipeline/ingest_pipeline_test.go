@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package ingest_pipeline
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/ingest_stats.710.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, false, true)
+	require.NoError(t, err)
+
+	// Node 1 reports two pipelines, one with two processors, node 2 one
+	// pipeline with two processors
+	events := reporter.GetEvents()
+	require.Len(t, events, 7)
+
+	pipeline := events[0]
+	require.Equal(t, "H-jA5OKFRVSsor5K0possg", mustGetValue(t, pipeline.ModuleFields, "node.id"))
+	require.Equal(t, "es-node-1", mustGetValue(t, pipeline.ModuleFields, "node.name"))
+	require.Equal(t, "logs-nginx.access", mustGetValue(t, pipeline.MetricSetFields, "name"))
+	require.Equal(t, int64(1200), mustGetValue(t, pipeline.MetricSetFields, "total.count"))
+	require.Equal(t, int64(340), mustGetValue(t, pipeline.MetricSetFields, "total.time.total.ms"))
+
+	grok := events[1].MetricSetFields
+	require.Equal(t, mapstr.M{
+		"name": "logs-nginx.access",
+		"processor": mapstr.M{
+			"type":        "grok",
+			"tag":         "parse-message",
+			"order_index": 0,
+			"count":       int64(1200),
+			"current":     int64(1),
+			"failed":      int64(5),
+			"time": mapstr.M{
+				"total": mapstr.M{
+					"ms": int64(290),
+				},
+			},
+		},
+	}, grok)
+
+	date := events[2].MetricSetFields
+	require.Equal(t, "date", mustGetValue(t, date, "processor.type"))
+	require.Equal(t, 1, mustGetValue(t, date, "processor.order_index"))
+	hasTag, _ := date.HasKey("processor.tag")
+	require.False(t, hasTag)
+}
+
+func TestMapperAggregateNodes(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/ingest_stats.710.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, true, false)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 4)
+
+	for _, event := range events {
+		hasNode, _ := event.ModuleFields.HasKey("node")
+		require.False(t, hasNode)
+	}
+
+	pipeline := events[0].MetricSetFields
+	require.Equal(t, int64(2000), mustGetValue(t, pipeline, "total.count"))
+	require.Equal(t, int64(6), mustGetValue(t, pipeline, "total.failed"))
+	require.Equal(t, int64(500), mustGetValue(t, pipeline, "total.time.total.ms"))
+
+	grok := events[1].MetricSetFields
+	require.Equal(t, "grok", mustGetValue(t, grok, "processor.type"))
+	require.Equal(t, int64(430), mustGetValue(t, grok, "processor.time.total.ms"))
+
+	date := events[2].MetricSetFields
+	require.Equal(t, "date", mustGetValue(t, date, "processor.type"))
+	require.Equal(t, int64(1994), mustGetValue(t, date, "processor.count"))
+
+	require.Equal(t, "xpack_monitoring_7", mustGetValue(t, events[3].MetricSetFields, "name"))
+}
+
+func TestGetServiceURI(t *testing.T) {
+	tests := map[string]struct {
+		scope          elasticsearch.Scope
+		aggregateNodes bool
+		expectedURI    string
+	}{
+		"scope_node": {
+			scope:       elasticsearch.ScopeNode,
+			expectedURI: "/_nodes/_local/stats/ingest?" + ingestStatsQuery,
+		},
+		"scope_cluster": {
+			scope:       elasticsearch.ScopeCluster,
+			expectedURI: "/_nodes/_all/stats/ingest?" + ingestStatsQuery,
+		},
+		"aggregate_nodes": {
+			scope:          elasticsearch.ScopeNode,
+			aggregateNodes: true,
+			expectedURI:    "/_nodes/_all/stats/ingest?" + ingestStatsQuery,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			newURI, err := getServiceURI("/_nodes/_local/stats/ingest", test.scope, test.aggregateNodes)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedURI, newURI)
+		})
+	}
+}
+
+func TestData(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+		}
+		input, _ := ioutil.ReadFile("./_meta/test/root.710.json")
+		w.Write(input)
+	}))
+	mux.Handle("/_nodes/_local/stats/ingest", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/ingest_stats.710.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"ingest_pipeline"},
+		"hosts":      []string{server.URL},
+	}
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	if err := mbtest.WriteEventsReporterV2WithContextCond(ms, t, "", func(e mapstr.M) bool {
+		hasProcessor, _ := e.HasKey("elasticsearch.ingest_pipeline.processor")
+		return hasProcessor
+	}); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetchServerless(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.Error(w, "this should never have been called", 418)
+			return
+		}
+		w.Write([]byte(`{"cluster_name": "serverless", "cluster_uuid": "1234", "version": {"number": "8.11.0", "build_flavor": "serverless"}}`))
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"ingest_pipeline"},
+		"hosts":      []string{server.URL},
+	}
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	events, errs := mbtest.ReportingFetchV2WithContext(ms)
+
+	require.Empty(t, errs)
+	require.Empty(t, events)
+}
+
+func mustGetValue(t *testing.T, m mapstr.M, key string) interface{} {
+	value, err := m.GetValue(key)
+	require.NoError(t, err, key)
+	return value
+}
@@ -0,0 +1,231 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+)
+
+const (
+	defaultLeaderElectionIndex         = ".metricbeat-leader"
+	defaultLeaderElectionLeaseDuration = 30 * time.Second
+)
+
+// leaderElectionConfig configures the election of a single Metricbeat instance
+// collecting the cluster-scoped metricsets, when several instances monitor the same
+// cluster.
+type leaderElectionConfig struct {
+	Enabled       bool          `config:"enabled"`
+	Index         string        `config:"index"`
+	LeaseDuration time.Duration `config:"lease_duration"`
+	ID            string        `config:"id"`
+}
+
+func defaultLeaderElectionConfig() leaderElectionConfig {
+	return leaderElectionConfig{
+		Enabled:       false,
+		Index:         defaultLeaderElectionIndex,
+		LeaseDuration: defaultLeaderElectionLeaseDuration,
+	}
+}
+
+// Validate checks the leader election configuration
+func (c *leaderElectionConfig) Validate() error {
+	if c.Index == "" {
+		return errors.New("leader_election.index cannot be empty")
+	}
+	if c.LeaseDuration <= 0 {
+		return errors.New("leader_election.lease_duration must be positive")
+	}
+	return nil
+}
+
+// lease is the document claimed by the leader in the leader election index.
+type lease struct {
+	Holder    string `json:"holder"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type leaseDoc struct {
+	SeqNo       int64 `json:"_seq_no"`
+	PrimaryTerm int64 `json:"_primary_term"`
+	Source      lease `json:"_source"`
+}
+
+type leadership struct {
+	isLeader  bool
+	checkedOn time.Time
+}
+
+// leaderElector elects a leader per monitored cluster by claiming a lease document,
+// whose ID is the cluster UUID, in the leader election index of the cluster. Claims
+// rely on optimistic concurrency control, so only one instance can hold the lease.
+// The lease is renewed by its holder, other instances take it over once it expires.
+type leaderElector struct {
+	sync.Mutex
+	config leaderElectionConfig
+	id     string
+	now    func() time.Time
+
+	hosts map[string]*leadership
+}
+
+func newLeaderElector(config leaderElectionConfig) (*leaderElector, error) {
+	id := config.ID
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname for leader election ID: %w", err)
+		}
+		instance, err := uuid.NewV4()
+		if err != nil {
+			return nil, fmt.Errorf("error generating leader election ID: %w", err)
+		}
+		id = hostname + "-" + instance.String()
+	}
+
+	return &leaderElector{
+		config: config,
+		id:     id,
+		now:    time.Now,
+		hosts:  map[string]*leadership{},
+	}, nil
+}
+
+// isLeader returns true if this instance holds the lease of the cluster behind the
+// given URI. The lease is checked, and renewed, at most twice per lease duration.
+func (e *leaderElector) isLeader(ctx context.Context, http *helper.HTTP, resetURI string) (bool, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	key := hostKey(resetURI)
+	now := e.now()
+	state, found := e.hosts[key]
+	if found && now.Sub(state.checkedOn) < e.config.LeaseDuration/2 {
+		return state.isLeader, nil
+	}
+
+	isLeader, err := e.claim(ctx, http, resetURI, now)
+	if err != nil {
+		// Step down, so a healthy instance can take over once the lease expires
+		delete(e.hosts, key)
+		return false, err
+	}
+
+	e.hosts[key] = &leadership{isLeader: isLeader, checkedOn: now}
+	return isLeader, nil
+}
+
+func (e *leaderElector) claim(ctx context.Context, h *helper.HTTP, resetURI string, now time.Time) (bool, error) {
+	info, err := GetInfo(ctx, h, resetURI)
+	if err != nil {
+		return false, fmt.Errorf("error determining cluster UUID: %w", err)
+	}
+	clusterID := info.ClusterID
+
+	docPath := "/" + url.PathEscape(e.config.Index) + "/_doc/" + url.PathEscape(clusterID)
+	status, content, err := sendRequest(ctx, h, resetURI, http.MethodGet, docPath, "", nil)
+	if err != nil {
+		return false, fmt.Errorf("error fetching leader lease: %w", err)
+	}
+
+	body, err := json.Marshal(lease{
+		Holder:    e.id,
+		ExpiresAt: now.Add(e.config.LeaseDuration).UnixMilli(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var createPath, query string
+	switch status {
+	case http.StatusNotFound:
+		createPath = "/" + url.PathEscape(e.config.Index) + "/_create/" + url.PathEscape(clusterID)
+	case http.StatusOK:
+		var doc leaseDoc
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return false, fmt.Errorf("error parsing leader lease: %w", err)
+		}
+		if doc.Source.Holder != e.id && now.UnixMilli() < doc.Source.ExpiresAt {
+			return false, nil
+		}
+		createPath = docPath
+		query = fmt.Sprintf("if_seq_no=%d&if_primary_term=%d", doc.SeqNo, doc.PrimaryTerm)
+	default:
+		return false, fmt.Errorf("unexpected HTTP status %d fetching leader lease", status)
+	}
+
+	status, _, err = sendRequest(ctx, h, resetURI, http.MethodPut, createPath, query, body)
+	if err != nil {
+		return false, fmt.Errorf("error claiming leader lease: %w", err)
+	}
+
+	switch status {
+	case http.StatusOK, http.StatusCreated:
+		return true, nil
+	case http.StatusConflict:
+		// Another instance claimed the lease first
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP status %d claiming leader lease", status)
+	}
+}
+
+// sendRequest sends a request with the given method and body to the given path, and
+// returns the status code and the body of the response, whatever the status code is.
+func sendRequest(ctx context.Context, h *helper.HTTP, uri, method, path, query string, body []byte) (int, []byte, error) {
+	defer h.SetURI(uri)
+	defer h.SetMethod(http.MethodGet)
+	defer h.SetBody(nil)
+
+	u, _ := url.Parse(uri)
+	u.Path = path
+	u.RawQuery = query
+
+	h.SetURI(u.String())
+	h.SetMethod(method)
+	h.SetBody(body)
+	if body != nil {
+		h.SetHeaderDefault("Content-Type", "application/json")
+	}
+
+	resp, err := h.FetchResponseWithContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, content, nil
+}
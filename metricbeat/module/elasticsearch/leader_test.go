@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// leaseServer mimics the subset of the Elasticsearch document APIs used by the
+// leader election, with optimistic concurrency control.
+type leaseServer struct {
+	sync.Mutex
+	doc   []byte
+	seqNo int
+}
+
+func (s *leaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	switch {
+	case r.URL.Path == "/":
+		w.Write([]byte(`{"cluster_name": "es", "cluster_uuid": "1234", "version": {"number": "8.11.0"}}`))
+
+	case r.Method == http.MethodGet && r.URL.Path == "/_ccr/stats":
+		// Path of a metricset, only expected to be fetched with GET
+
+	case r.Method == http.MethodGet && r.URL.Path == "/.metricbeat-leader/_doc/1234":
+		if s.doc == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"_seq_no": ` + strconv.Itoa(s.seqNo) + `, "_primary_term": 1, "_source": ` + string(s.doc) + `}`))
+
+	case r.Method == http.MethodPut && r.URL.Path == "/.metricbeat-leader/_create/1234":
+		if s.doc != nil {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.doc, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && r.URL.Path == "/.metricbeat-leader/_doc/1234":
+		if r.URL.Query().Get("if_seq_no") != strconv.Itoa(s.seqNo) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.doc, _ = ioutil.ReadAll(r.Body)
+		s.seqNo++
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestLeaderElection(t *testing.T) {
+	server := httptest.NewServer(&leaseServer{})
+	defer server.Close()
+
+	httpHelper, err := helper.NewHTTPFromConfig(helper.Config{}, mb.HostData{SanitizedURI: server.URL})
+	require.NoError(t, err)
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	newElector := func(id string) *leaderElector {
+		config := defaultLeaderElectionConfig()
+		config.ID = id
+		e, err := newLeaderElector(config)
+		require.NoError(t, err)
+		e.now = clock
+		return e
+	}
+	first, second := newElector("first"), newElector("second")
+
+	isLeader, err := first.isLeader(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.True(t, isLeader)
+
+	isLeader, err = second.isLeader(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.False(t, isLeader)
+
+	// The leader renews the lease before it expires
+	now = now.Add(defaultLeaderElectionLeaseDuration / 2)
+	isLeader, err = first.isLeader(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.True(t, isLeader)
+
+	now = now.Add(defaultLeaderElectionLeaseDuration / 2)
+	isLeader, err = second.isLeader(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.False(t, isLeader)
+
+	// The leader stops renewing the lease, the other instance takes over once it expires
+	now = now.Add(defaultLeaderElectionLeaseDuration)
+	isLeader, err = second.isLeader(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.True(t, isLeader)
+
+	now = now.Add(defaultLeaderElectionLeaseDuration / 2)
+	isLeader, err = first.isLeader(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.False(t, isLeader)
+}
+
+func TestLeaderElectionRequestReset(t *testing.T) {
+	server := httptest.NewServer(&leaseServer{})
+	defer server.Close()
+
+	uri := server.URL + "/_ccr/stats"
+	httpHelper, err := helper.NewHTTPFromConfig(helper.Config{}, mb.HostData{SanitizedURI: server.URL})
+	require.NoError(t, err)
+	httpHelper.SetURI(uri)
+
+	e, err := newLeaderElector(defaultLeaderElectionConfig())
+	require.NoError(t, err)
+	_, err = e.isLeader(context.Background(), httpHelper, uri)
+	require.NoError(t, err)
+
+	// The helper is shared with the metricset, it must be left as it was
+	require.Equal(t, uri, httpHelper.GetURI())
+	content, err := httpHelper.FetchContent()
+	require.NoError(t, err)
+	require.Empty(t, content)
+
+	var doc lease
+	err = json.Unmarshal(server.Config.Handler.(*leaseServer).doc, &doc)
+	require.NoError(t, err)
+	require.Equal(t, e.id, doc.Holder)
+}
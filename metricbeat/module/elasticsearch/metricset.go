@@ -18,15 +18,19 @@
 package elasticsearch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/v7/libbeat/common/productorigin"
 	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/helper/secrets"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
 const (
@@ -69,8 +73,8 @@ func (h *Scope) Unpack(str string) error {
 
 type MetricSetAPI interface {
 	Module() mb.Module
-	GetMasterNodeID() (string, error)
-	IsMLockAllEnabled(string) (bool, error)
+	GetMasterNodeID(context.Context) (string, error)
+	IsMLockAllEnabled(context.Context, string) (bool, error)
 }
 
 // MetricSet can be used to build other metric sets that query RabbitMQ
@@ -81,6 +85,13 @@ type MetricSet struct {
 	*helper.HTTP
 	Scope        Scope
 	XPackEnabled bool
+
+	// Serverless is set when the monitored cluster is configured as an Elasticsearch
+	// Serverless project. It is otherwise detected from the build flavor of the cluster.
+	Serverless bool
+
+	serverlessChecked  bool
+	serverlessDetected bool
 }
 
 // NewMetricSet creates an metric set that can be used to build other metric
@@ -94,8 +105,12 @@ func NewMetricSet(base mb.BaseMetricSet, servicePath string) (*MetricSet, error)
 	http.SetHeaderDefault(productorigin.Header, productorigin.Beats)
 
 	config := struct {
-		Scope        Scope `config:"scope"`
-		XPackEnabled bool  `config:"xpack.enabled"`
+		Scope        Scope   `config:"scope"`
+		XPackEnabled bool    `config:"xpack.enabled"`
+		Secrets      *conf.C `config:"secrets"`
+		APIKey       string  `config:"api_key"`
+		BearerToken  string  `config:"bearer_token"`
+		Serverless   bool    `config:"serverless"`
 	}{
 		Scope:        ScopeNode,
 		XPackEnabled: false,
@@ -104,12 +119,27 @@ func NewMetricSet(base mb.BaseMetricSet, servicePath string) (*MetricSet, error)
 		return nil, err
 	}
 
+	if config.APIKey != "" || config.BearerToken != "" {
+		if base.HostData().User != "" || base.HostData().Password != "" {
+			return nil, errors.New("cannot set both api_key or bearer_token and username/password")
+		}
+	}
+
+	if config.Secrets != nil {
+		resolver, err := newCredentialsResolver(config.Secrets, base)
+		if err != nil {
+			return nil, err
+		}
+		http.SetCredentialsResolver(resolver)
+	}
+
 	ms := &MetricSet{
-		base,
-		servicePath,
-		http,
-		config.Scope,
-		config.XPackEnabled,
+		BaseMetricSet: base,
+		servicePath:   servicePath,
+		HTTP:          http,
+		Scope:         config.Scope,
+		XPackEnabled:  config.XPackEnabled,
+		Serverless:    config.Serverless,
 	}
 
 	ms.SetServiceURI(servicePath)
@@ -117,6 +147,70 @@ func NewMetricSet(base mb.BaseMetricSet, servicePath string) (*MetricSet, error)
 	return ms, nil
 }
 
+// newCredentialsResolver creates a resolver for the credentials configured under the
+// `secrets` setting of the module.
+func newCredentialsResolver(c *conf.C, base mb.BaseMetricSet) (*secrets.Resolver, error) {
+	secretsConfig := secrets.DefaultConfig()
+	if err := c.Unpack(&secretsConfig); err != nil {
+		return nil, errors.Wrap(err, "invalid secrets configuration")
+	}
+
+	resolver, err := secrets.NewResolver(secretsConfig, base.Logger().Named("secrets"))
+	if err != nil {
+		return nil, errors.Wrap(err, "error resolving Elasticsearch credentials")
+	}
+	return resolver, nil
+}
+
+// FetchPath fetches the given path, with the given query string, from the host of
+// this metricset.
+func (m *MetricSet) FetchPath(ctx context.Context, path, query string) ([]byte, error) {
+	return fetchPath(ctx, m.HTTP, m.GetServiceURI(), path, query)
+}
+
+// PostPath sends the given JSON body to the given path, with the given query string,
+// of the host of this metricset, and returns the content of the response.
+func (m *MetricSet) PostPath(ctx context.Context, path, query string, body []byte) ([]byte, error) {
+	status, content, err := sendRequest(ctx, m.HTTP, m.GetServiceURI(), http.MethodPost, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, httpError(status, path, content)
+	}
+
+	return content, nil
+}
+
+// UnavailableError returns the error to report when the metricset is not
+// available in the monitored cluster because of its license or features, if
+// error events are enabled in the module. Otherwise it returns nil.
+func (m *MetricSet) UnavailableError(message string) error {
+	if !m.Module().Config().ErrorEvents {
+		return nil
+	}
+	return mb.NewFetchError(mb.ErrorTypeLicense, errors.New(message))
+}
+
+// GetLicense returns the license of the monitored cluster. The license is shared
+// with the other metricsets of the module instance.
+func (m *MetricSet) GetLicense(ctx context.Context) (*License, error) {
+	if module, ok := m.Module().(Module); ok {
+		return module.GetLicense(ctx, m.HTTP, m.GetServiceURI())
+	}
+	return GetLicense(ctx, m.HTTP, m.GetServiceURI())
+}
+
+// GetXPack returns the X-Pack features of the monitored cluster. The features are
+// shared with the other metricsets of the module instance.
+func (m *MetricSet) GetXPack(ctx context.Context) (XPack, error) {
+	if module, ok := m.Module().(Module); ok {
+		return module.GetXPack(ctx, m.HTTP, m.GetServiceURI())
+	}
+	return GetXPack(ctx, m.HTTP, m.GetServiceURI())
+}
+
 // GetServiceURI returns the URI of the Elasticsearch service being monitored by this metricset
 func (m *MetricSet) GetServiceURI() string {
 	return m.HostData().SanitizedURI + m.servicePath
@@ -128,11 +222,54 @@ func (m *MetricSet) SetServiceURI(servicePath string) {
 	m.HTTP.SetURI(m.GetServiceURI())
 }
 
-func (m *MetricSet) ShouldSkipFetch() (bool, error) {
+// IsServerless returns true if the monitored cluster is an Elasticsearch Serverless project,
+// either because it is configured as such or because it reports a serverless build flavor.
+func (m *MetricSet) IsServerless(ctx context.Context) (bool, error) {
+	if m.Serverless {
+		return true, nil
+	}
+
+	if !m.serverlessChecked {
+		info, err := GetInfo(ctx, m.HTTP, m.GetServiceURI())
+		if err != nil {
+			return false, err
+		}
+		m.serverlessChecked = true
+		m.serverlessDetected = info.IsServerless()
+	}
+
+	return m.serverlessDetected, nil
+}
+
+// ShouldSkipServerless returns true if the monitored cluster is an Elasticsearch Serverless
+// project and this metricset relies on APIs that are not available in serverless.
+func (m *MetricSet) ShouldSkipServerless(ctx context.Context) (bool, error) {
+	serverless, err := m.IsServerless(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "error determining if Elasticsearch is serverless")
+	}
+
+	if serverless && !IsServerlessCompatible(m.Name()) {
+		m.Logger().Debugf("the %v metricset is not supported by Elasticsearch Serverless", m.Name())
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (m *MetricSet) ShouldSkipFetch(ctx context.Context) (bool, error) {
+	skip, err := m.ShouldSkipServerless(ctx)
+	if err != nil || skip {
+		return skip, err
+	}
+
 	// If we're talking to a set of ES nodes directly, only collect stats from the master node so
 	// we don't collect the same stats from every node and end up duplicating them.
-	if m.Scope == ScopeNode {
-		isMaster, err := isMaster(m.HTTP, m.GetServiceURI())
+	// There are no master nodes to talk to in serverless, the project is always reached
+	// through a single endpoint.
+	serverless := m.Serverless || m.serverlessDetected
+	if m.Scope == ScopeNode && !serverless {
+		isMaster, err := isMaster(ctx, m.HTTP, m.GetServiceURI())
 		if err != nil {
 			return false, errors.Wrap(err, "error determining if connected Elasticsearch node is master")
 		}
@@ -144,15 +281,29 @@ func (m *MetricSet) ShouldSkipFetch() (bool, error) {
 		}
 	}
 
+	// When several instances monitor the same cluster, only the elected one collects
+	// the cluster-scoped metricsets.
+	if module, ok := m.Module().(Module); ok {
+		isLeader, err := module.IsLeader(ctx, m.HTTP, m.GetServiceURI())
+		if err != nil {
+			return false, errors.Wrap(err, "error determining if this instance is the leader")
+		}
+
+		if !isLeader {
+			m.Logger().Debugf("not fetching %v stats, another instance is the leader", m.Name())
+			return true, nil
+		}
+	}
+
 	return false, nil
 }
 
 // GetMasterNodeID returns the ID of the Elasticsearch cluster's master node
-func (m *MetricSet) GetMasterNodeID() (string, error) {
+func (m *MetricSet) GetMasterNodeID(ctx context.Context) (string, error) {
 	http := m.HTTP
 	resetURI := m.GetServiceURI()
 
-	content, err := fetchPath(http, resetURI, "_nodes/_master", "filter_path=nodes.*.name")
+	content, err := fetchPath(ctx, http, resetURI, "_nodes/_master", "filter_path=nodes.*.name")
 	if err != nil {
 		return "", err
 	}
@@ -173,11 +324,11 @@ func (m *MetricSet) GetMasterNodeID() (string, error) {
 }
 
 // IsMLockAllEnabled returns if the given Elasticsearch node has mlockall enabled
-func (m *MetricSet) IsMLockAllEnabled(nodeID string) (bool, error) {
+func (m *MetricSet) IsMLockAllEnabled(ctx context.Context, nodeID string) (bool, error) {
 	http := m.HTTP
 	resetURI := m.GetServiceURI()
 
-	content, err := fetchPath(http, resetURI, "_nodes/"+nodeID, "filter_path=nodes.*.process.mlockall")
+	content, err := fetchPath(ctx, http, resetURI, "_nodes/"+nodeID, "filter_path=nodes.*.process.mlockall")
 	if err != nil {
 		return false, err
 	}
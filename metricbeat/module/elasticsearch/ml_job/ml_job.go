@@ -18,6 +18,7 @@
 package ml_job
 
 import (
+	"context"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
 )
@@ -51,8 +52,8 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 }
 
 // Fetch methods implements the data gathering and data conversion to the right format
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	shouldSkip, err := m.ShouldSkipFetch()
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
 	if err != nil {
 		return err
 	}
@@ -60,12 +61,12 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return nil
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.GetServiceURI())
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
 	if err != nil {
 		return err
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.HTTP.FetchContentWithContext(ctx)
 	if err != nil {
 		return err
 	}
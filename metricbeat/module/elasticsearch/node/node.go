@@ -18,6 +18,7 @@
 package node
 
 import (
+	"context"
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -63,13 +64,21 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 // Fetch methods implements the data gathering and data conversion to the right format
 // It returns the event which is then forward to the output. In case of an error, a
 // descriptive error must be returned.
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
-	content, err := m.HTTP.FetchContent()
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipServerless(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.HostData().SanitizedURI+nodeStatsPath)
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.HostData().SanitizedURI+nodeStatsPath)
 	if err != nil {
 		return errors.Wrap(err, "failed to get info from Elasticsearch")
 	}
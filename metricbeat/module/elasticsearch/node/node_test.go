@@ -21,12 +21,15 @@
 package node
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
@@ -72,11 +75,81 @@ func TestFetch(t *testing.T) {
 			}
 			reporter := &mbtest.CapturingReporterV2{}
 
-			metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
-			metricSet.Fetch(reporter)
+			metricSet := mbtest.NewReportingMetricSetV2WithContext(t, config)
+			metricSet.Fetch(context.Background(), reporter)
 
 			e := mbtest.StandardizeEvent(metricSet, reporter.GetEvents()[0])
 			t.Logf("%s/%s event: %+v", metricSet.Module().Name(), metricSet.Name(), e.Fields.StringToPrint())
 		})
 	}
 }
+
+func TestFetchWithAPIKey(t *testing.T) {
+	response, err := ioutil.ReadFile("./_meta/test/node.522.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// base64 of "id:secret"
+		if r.Header.Get("Authorization") != "ApiKey aWQ6c2VjcmV0" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.RequestURI {
+		case "/_nodes/_local":
+			w.Header().Set("Content-Type", "application/json;")
+			w.Write(response)
+
+		case "/":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("{\"cluster_name\":\"es1\",\"cluster_uuid\":\"4heb1eiady103dxu71\",\"version\":{\"number\":\"7.10.0\"}}"))
+
+		default:
+			t.FailNow()
+		}
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"node"},
+		"hosts":      []string{server.URL},
+		"api_key":    "id:secret",
+	}
+	reporter := &mbtest.CapturingReporterV2{}
+
+	metricSet := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	require.NoError(t, metricSet.Fetch(context.Background(), reporter))
+	require.Empty(t, reporter.GetErrors())
+	require.NotEmpty(t, reporter.GetEvents())
+}
+
+func TestFetchCancelledOnSlowTarget(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	config := map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"node"},
+		"hosts":      []string{server.URL},
+		"timeout":    "30s",
+	}
+	reporter := &mbtest.CapturingReporterV2{}
+	metricSet := mbtest.NewReportingMetricSetV2WithContext(t, config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := metricSet.Fetch(ctx, reporter)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -18,6 +18,7 @@
 package node_stats
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -336,14 +337,14 @@ type nodesStruct struct {
 	Nodes map[string]map[string]interface{} `json:"nodes"`
 }
 
-func eventsMapping(r mb.ReporterV2, m elasticsearch.MetricSetAPI, info elasticsearch.Info, content []byte, isXpack bool) error {
+func eventsMapping(ctx context.Context, r mb.ReporterV2, m elasticsearch.MetricSetAPI, info elasticsearch.Info, content []byte, isXpack bool) error {
 	nodeData := &nodesStruct{}
 	err := json.Unmarshal(content, nodeData)
 	if err != nil {
 		return fmt.Errorf("failure parsing Elasticsearch Node Stats API response: %w", err)
 	}
 
-	masterNodeID, err := m.GetMasterNodeID()
+	masterNodeID, err := m.GetMasterNodeID(ctx)
 	if err != nil {
 		return err
 	}
@@ -352,7 +353,7 @@ func eventsMapping(r mb.ReporterV2, m elasticsearch.MetricSetAPI, info elasticse
 	for nodeID, node := range nodeData.Nodes {
 		isMaster := nodeID == masterNodeID
 
-		mlockall, err := m.IsMLockAllEnabled(nodeID)
+		mlockall, err := m.IsMLockAllEnabled(ctx, nodeID)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("error determining if mlockall is set on Elasticsearch node: %w", err))
 			continue
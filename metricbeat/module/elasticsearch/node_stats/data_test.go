@@ -21,6 +21,7 @@
 package node_stats
 
 import (
+	"context"
 	"testing"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -29,16 +30,19 @@ import (
 
 func TestStats(t *testing.T) {
 	ms := mockMetricSet{}
-	elasticsearch.TestMapperWithMetricSetAndInfo(t, "./_meta/test/node_stats.*.json", ms, eventsMapping)
+	elasticsearch.TestMapperWithMetricSetAndInfo(t, "./_meta/test/node_stats.*.json", ms,
+		func(r mb.ReporterV2, ms elasticsearch.MetricSetAPI, info elasticsearch.Info, content []byte, isXpack bool) error {
+			return eventsMapping(context.Background(), r, ms, info, content, isXpack)
+		})
 }
 
 type mockMetricSet struct{}
 
-func (m mockMetricSet) GetMasterNodeID() (string, error) {
+func (m mockMetricSet) GetMasterNodeID(_ context.Context) (string, error) {
 	return "test_node_id", nil
 }
 
-func (m mockMetricSet) IsMLockAllEnabled(_ string) (bool, error) {
+func (m mockMetricSet) IsMLockAllEnabled(_ context.Context, _ string) (bool, error) {
 	return true, nil
 }
 
@@ -18,6 +18,7 @@
 package node_stats
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -55,22 +56,30 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 }
 
 // Fetch methods implements the data gathering and data conversion to the right format
-func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipServerless(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
 	if err := m.updateServiceURI(); err != nil {
 		return err
 	}
 
-	content, err := m.HTTP.FetchContent()
+	content, err := m.HTTP.FetchContentWithContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	info, err := elasticsearch.GetInfo(m.HTTP, m.GetServiceURI())
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
 	if err != nil {
 		return err
 	}
 
-	return eventsMapping(r, m.MetricSet, *info, content, m.XPackEnabled)
+	return eventsMapping(ctx, r, m.MetricSet, *info, content, m.XPackEnabled)
 }
 
 func (m *MetricSet) updateServiceURI() error {
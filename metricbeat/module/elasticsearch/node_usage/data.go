@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package node_usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type nodesUsage struct {
+	Nodes map[string]nodeUsage `json:"nodes"`
+}
+
+type nodeUsage struct {
+	Since       int64            `json:"since"`
+	RestActions map[string]int64 `json:"rest_actions"`
+	// Aggregations usage is keyed by aggregation type, then by the type of values
+	// the aggregation was run on, e.g. `terms.keyword`.
+	Aggregations map[string]map[string]int64 `json:"aggregations"`
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+	var usage nodesUsage
+	if err := json.Unmarshal(content, &usage); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch Nodes Usage API response: %w", err)
+	}
+
+	nodeIDs := make([]string, 0, len(usage.Nodes))
+	for nodeID := range usage.Nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	for _, nodeID := range nodeIDs {
+		node := usage.Nodes[nodeID]
+
+		event := mb.Event{
+			RootFields: mapstr.M{
+				"service": mapstr.M{
+					"name": elasticsearch.ModuleName,
+				},
+			},
+			ModuleFields: mapstr.M{
+				"cluster": mapstr.M{
+					"name": info.ClusterName,
+					"id":   info.ClusterID,
+				},
+				"node": mapstr.M{
+					"id": nodeID,
+				},
+			},
+			MetricSetFields: mapstr.M{
+				"since": node.Since,
+			},
+		}
+
+		if len(node.RestActions) > 0 {
+			restActions := mapstr.M{}
+			for action, count := range node.RestActions {
+				restActions[action] = count
+			}
+			event.MetricSetFields["rest_actions"] = restActions
+		}
+
+		if len(node.Aggregations) > 0 {
+			aggregations := mapstr.M{}
+			for aggregation, valueTypes := range node.Aggregations {
+				var total int64
+				for _, count := range valueTypes {
+					total += count
+				}
+				aggregations[aggregation] = total
+			}
+			event.MetricSetFields["aggregations"] = aggregations
+		}
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return nil
+}
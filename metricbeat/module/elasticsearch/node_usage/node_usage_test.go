@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package node_usage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/node_usage.7170.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, true)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	require.Equal(t, mapstr.M{
+		"id": "H-jA5OKFRVSsor5K0possg",
+	}, events[0].ModuleFields["node"])
+	require.Equal(t, mapstr.M{
+		"since": int64(1670912345678),
+		"rest_actions": mapstr.M{
+			"nodes_usage_action":  int64(3),
+			"search_action":       int64(1204),
+			"bulk_action":         int64(532),
+			"document_get_action": int64(18),
+		},
+		"aggregations": mapstr.M{
+			"terms":          int64(55),
+			"date_histogram": int64(40),
+		},
+	}, events[0].MetricSetFields)
+
+	// Nodes that didn't run any aggregation don't report them
+	hasAggregations, _ := events[1].MetricSetFields.HasKey("aggregations")
+	require.False(t, hasAggregations)
+}
+
+func TestEmpty(t *testing.T) {
+	reporter := &mbtest.CapturingReporterV2{}
+	err := eventsMapping(reporter, info, []byte(`{"nodes": {}}`), true)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetEvents())
+}
+
+func TestGetServiceURI(t *testing.T) {
+	tests := map[string]struct {
+		scope       elasticsearch.Scope
+		expectedURI string
+	}{
+		"scope_node": {
+			scope:       elasticsearch.ScopeNode,
+			expectedURI: "/_nodes/_local/usage",
+		},
+		"scope_cluster": {
+			scope:       elasticsearch.ScopeCluster,
+			expectedURI: "/_nodes/_all/usage",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			newURI, err := getServiceURI("/_nodes/_local/usage", test.scope)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedURI, newURI)
+		})
+	}
+}
+
+func TestData(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+		}
+		input, _ := ioutil.ReadFile("./_meta/test/root.710.json")
+		w.Write(input)
+	}))
+	mux.Handle("/_nodes/_local/usage", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/node_usage.7170.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"node_usage"},
+		"hosts":      []string{server.URL},
+	}
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
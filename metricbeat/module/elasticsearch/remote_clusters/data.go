@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_clusters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	remoteSchema = s.Schema{
+		"mode":                         c.Str("mode", s.Optional),
+		"connected":                    c.Bool("connected"),
+		"skip_unavailable":             c.Bool("skip_unavailable", s.Optional),
+		"initial_connect_timeout":      c.Str("initial_connect_timeout", s.Optional),
+		"seeds":                        c.Ifc("seeds", s.Optional),
+		"num_nodes_connected":          c.Int("num_nodes_connected", s.Optional),
+		"max_connections_per_cluster":  c.Int("max_connections_per_cluster", s.Optional),
+		"proxy_address":                c.Str("proxy_address", s.Optional),
+		"num_proxy_sockets_connected":  c.Int("num_proxy_sockets_connected", s.Optional),
+		"max_proxy_socket_connections": c.Int("max_proxy_socket_connections", s.Optional),
+	}
+
+	resolveSchema = s.Schema{
+		"connected":        c.Bool("connected"),
+		"matching_indices": c.Bool("matching_indices", s.Optional),
+		"version":          c.Str("version.number", s.Optional),
+		"error":            c.Str("error", s.Optional),
+	}
+)
+
+// resolveResult is the response of the _resolve/cluster API for one remote
+// cluster, along with the time it took.
+type resolveResult struct {
+	content []byte
+	took    time.Duration
+	err     error
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, resolved map[string]resolveResult, isXpack bool) error {
+	var remotes map[string]map[string]interface{}
+	if err := json.Unmarshal(content, &remotes); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch Remote Info API response: %w", err)
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs multierror.Errors
+	for _, name := range names {
+		fields, err := remoteSchema.Apply(remotes[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure applying remote cluster schema for %s: %w", name, err))
+			continue
+		}
+		fields["name"] = name
+
+		if result, found := resolved[name]; found {
+			resolveFields, err := resolveFields(name, result)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				fields["resolve"] = resolveFields
+			}
+		}
+
+		event := mb.Event{
+			RootFields:      mapstr.M{},
+			ModuleFields:    mapstr.M{},
+			MetricSetFields: fields,
+		}
+
+		event.RootFields.Put("service.name", elasticsearch.ModuleName)
+		event.ModuleFields.Put("cluster.name", info.ClusterName)
+		event.ModuleFields.Put("cluster.id", info.ClusterID)
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return errs.Err()
+}
+
+func resolveFields(name string, result resolveResult) (mapstr.M, error) {
+	if result.err != nil {
+		return nil, fmt.Errorf("failure resolving remote cluster %s: %w", name, result.err)
+	}
+
+	var clusters map[string]map[string]interface{}
+	if err := json.Unmarshal(result.content, &clusters); err != nil {
+		return nil, fmt.Errorf("failure parsing Elasticsearch Resolve Cluster API response for %s: %w", name, err)
+	}
+
+	cluster, found := clusters[name]
+	if !found {
+		return nil, elastic.MakeErrorForMissingField(name, elastic.Elasticsearch)
+	}
+
+	fields, err := resolveSchema.Apply(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failure applying resolve cluster schema for %s: %w", name, err)
+	}
+	fields.Put("took.ms", result.took.Milliseconds())
+
+	return fields, nil
+}
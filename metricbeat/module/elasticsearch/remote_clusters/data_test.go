@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package remote_clusters
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/remote_info.8130.json")
+	require.NoError(t, err)
+	resolveOne, err := ioutil.ReadFile("./_meta/test/resolve_cluster_one.8130.json")
+	require.NoError(t, err)
+	resolveTwo, err := ioutil.ReadFile("./_meta/test/resolve_cluster_two.8130.json")
+	require.NoError(t, err)
+
+	resolved := map[string]resolveResult{
+		"cluster_one": {content: resolveOne, took: 12 * time.Millisecond},
+		"cluster_two": {content: resolveTwo, took: 30 * time.Second},
+	}
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, resolved, true)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	require.Equal(t, mapstr.M{
+		"name":                        "cluster_one",
+		"mode":                        "sniff",
+		"connected":                   true,
+		"skip_unavailable":            false,
+		"initial_connect_timeout":     "30s",
+		"seeds":                       []interface{}{"10.0.1.5:9300", "10.0.1.6:9300"},
+		"num_nodes_connected":         int64(2),
+		"max_connections_per_cluster": int64(3),
+		"resolve": mapstr.M{
+			"connected":        true,
+			"matching_indices": true,
+			"version":          "8.13.0",
+			"took": mapstr.M{
+				"ms": int64(12),
+			},
+		},
+	}, events[0].MetricSetFields)
+
+	proxy := events[1].MetricSetFields
+	for field, expected := range map[string]interface{}{
+		"name":                         "cluster_two",
+		"mode":                         "proxy",
+		"connected":                    false,
+		"skip_unavailable":             true,
+		"proxy_address":                "cluster-two.example.com:9400",
+		"num_proxy_sockets_connected":  int64(0),
+		"max_proxy_socket_connections": int64(18),
+		"resolve.connected":            false,
+		"resolve.error":                "unable to connect to remote cluster",
+		"resolve.took.ms":              int64(30000),
+	} {
+		value, err := proxy.GetValue(field)
+		require.NoError(t, err, field)
+		require.Equal(t, expected, value, field)
+	}
+}
+
+func TestMapperWithoutResolve(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/remote_info.8130.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, nil, false)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+	for _, event := range events {
+		hasResolve, _ := event.MetricSetFields.HasKey("resolve")
+		require.False(t, hasResolve)
+	}
+}
+
+func TestMapperResolveError(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/remote_info.8130.json")
+	require.NoError(t, err)
+
+	resolved := map[string]resolveResult{
+		"cluster_one": {err: errors.New("HTTP error 500 in : 500 Internal Server Error")},
+	}
+
+	// The remote cluster is still reported when it cannot be resolved
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, resolved, false)
+	require.Error(t, err)
+	require.Len(t, reporter.GetEvents(), 2)
+}
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "remote_clusters", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	remoteInfoPath     = "/_remote/info"
+	resolveClusterPath = "/_resolve/cluster/"
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := elasticsearch.NewMetricSet(base, remoteInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch gathers the connection status of each remote cluster from the
+// _remote/info API and, on Elasticsearch versions supporting it, probes each
+// remote cluster through the _resolve/cluster API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var resolved map[string]resolveResult
+	if elastic.IsFeatureAvailable(info.Version.Number, elasticsearch.ResolveClusterAPIAvailableVersion) {
+		resolved, err = m.resolveRemotes(ctx, content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return eventsMapping(r, *info, content, resolved, m.XPackEnabled)
+}
+
+// resolveRemotes calls the _resolve/cluster API once per remote cluster, so the
+// round trip to each of them can be measured.
+func (m *MetricSet) resolveRemotes(ctx context.Context, remoteInfoContent []byte) (map[string]resolveResult, error) {
+	var remotes map[string]json.RawMessage
+	if err := json.Unmarshal(remoteInfoContent, &remotes); err != nil {
+		return nil, fmt.Errorf("failure parsing Elasticsearch Remote Info API response: %w", err)
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolved := make(map[string]resolveResult, len(names))
+	for _, name := range names {
+		start := time.Now()
+		content, err := m.FetchPath(ctx, resolveClusterPath+url.PathEscape(name)+":*", "")
+		resolved[name] = resolveResult{
+			content: content,
+			took:    time.Since(start),
+			err:     err,
+		}
+	}
+
+	return resolved, nil
+}
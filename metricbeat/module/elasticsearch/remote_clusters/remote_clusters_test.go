@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package remote_clusters
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func createEsMuxer(esVersion string, resolveCalls *int32) *http.ServeMux {
+	nodesLocalHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"nodes": { "foobar": {}}}`))
+	}
+	clusterStateMasterHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"master_node": "foobar"}`))
+	}
+	rootHandler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+		}
+
+		input, _ := ioutil.ReadFile("./_meta/test/root.8130.json")
+		input = []byte(strings.Replace(string(input), "8.13.0", esVersion, -1))
+		w.Write(input)
+	}
+	remoteInfoHandler := func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/remote_info.8130.json")
+		w.Write(input)
+	}
+	resolveHandler := func(file string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(resolveCalls, 1)
+			input, _ := ioutil.ReadFile(file)
+			w.Write(input)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/_nodes/_local/nodes", http.HandlerFunc(nodesLocalHandler))
+	mux.Handle("/_cluster/state/master_node", http.HandlerFunc(clusterStateMasterHandler))
+	mux.Handle("/", http.HandlerFunc(rootHandler))
+	mux.Handle("/_remote/info", http.HandlerFunc(remoteInfoHandler))
+	mux.Handle("/_resolve/cluster/cluster_one:*", resolveHandler("./_meta/test/resolve_cluster_one.8130.json"))
+	mux.Handle("/_resolve/cluster/cluster_two:*", resolveHandler("./_meta/test/resolve_cluster_two.8130.json"))
+
+	return mux
+}
+
+func TestFetch(t *testing.T) {
+	tests := map[string]struct {
+		esVersion            string
+		expectedResolveCalls int32
+	}{
+		"resolve_cluster_available": {
+			esVersion:            "8.13.0",
+			expectedResolveCalls: 2,
+		},
+		"resolve_cluster_unavailable": {
+			esVersion:            "8.12.2",
+			expectedResolveCalls: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resolveCalls int32
+			server := httptest.NewServer(createEsMuxer(test.esVersion, &resolveCalls))
+			defer server.Close()
+
+			ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+			events, errs := mbtest.ReportingFetchV2WithContext(ms)
+
+			require.Empty(t, errs)
+			require.Len(t, events, 2)
+			require.Equal(t, test.expectedResolveCalls, atomic.LoadInt32(&resolveCalls))
+		})
+	}
+}
+
+func TestData(t *testing.T) {
+	var resolveCalls int32
+	server := httptest.NewServer(createEsMuxer("8.13.0", &resolveCalls))
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContextCond(ms, t, "", func(e mapstr.M) bool {
+		connected, _ := e.GetValue("elasticsearch.remote_clusters.connected")
+		return connected == true
+	}); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     elasticsearch.ModuleName,
+		"metricsets": []string{"remote_clusters"},
+		"hosts":      []string{host},
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var (
+	// availabilityCheckAttempts is the number of times the information used to check the
+	// availability of features (root, license and X-Pack APIs) is requested before giving up.
+	availabilityCheckAttempts = 3
+
+	availabilityCheckInitBackoff = 250 * time.Millisecond
+	availabilityCheckMaxBackoff  = 2 * time.Second
+
+	// staleAvailabilityMaxAge is how long previously fetched information can be used when
+	// it can't be fetched again.
+	staleAvailabilityMaxAge = 10 * time.Minute
+)
+
+// fetchPathWithRetry fetches the given path like fetchPath. Requests that fail, or that
+// Elasticsearch is temporarily unable to handle, like when it is overloaded, are retried
+// with an exponential backoff.
+func fetchPathWithRetry(ctx context.Context, h *helper.HTTP, uri, path, query string) ([]byte, error) {
+	b := backoff.NewExpBackoff(ctx.Done(), availabilityCheckInitBackoff, availabilityCheckMaxBackoff)
+	for attempt := 1; ; attempt++ {
+		status, content, err := sendRequest(ctx, h, uri, http.MethodGet, path, query, nil)
+		if err == nil {
+			if status == http.StatusOK {
+				return content, nil
+			}
+
+			err = httpError(status, path, content)
+			if !isTransientStatus(status) {
+				return nil, err
+			}
+		}
+
+		if attempt >= availabilityCheckAttempts {
+			return nil, fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		if !b.Wait() {
+			// The fetch was cancelled or its deadline was reached
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// isTransientStatus returns true for the HTTP statuses returned when Elasticsearch, or a
+// proxy in front of it, is temporarily unable to handle the request.
+func isTransientStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func httpError(status int, path string, content []byte) error {
+	err := &mb.FetchError{
+		Code: strconv.Itoa(status),
+		Err:  fmt.Errorf("HTTP error %d in %s: %s", status, path, content),
+	}
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		err.Type = mb.ErrorTypeAuth
+	}
+	return err
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func withFastBackoff(t *testing.T) {
+	initBackoff, maxBackoff := availabilityCheckInitBackoff, availabilityCheckMaxBackoff
+	availabilityCheckInitBackoff, availabilityCheckMaxBackoff = time.Millisecond, time.Millisecond
+	t.Cleanup(func() {
+		availabilityCheckInitBackoff, availabilityCheckMaxBackoff = initBackoff, maxBackoff
+	})
+}
+
+func TestFetchPathWithRetry(t *testing.T) {
+	withFastBackoff(t)
+
+	tests := map[string]struct {
+		statuses         []int
+		expectedRequests int
+		expectedError    bool
+	}{
+		"success": {
+			statuses:         []int{http.StatusOK},
+			expectedRequests: 1,
+		},
+		"transient_failures": {
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedRequests: 3,
+		},
+		"persistent_failures": {
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedRequests: 3,
+			expectedError:    true,
+		},
+		"not_transient": {
+			statuses:         []int{http.StatusUnauthorized, http.StatusOK},
+			expectedRequests: 1,
+			expectedError:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := test.statuses[requests]
+				requests++
+				w.WriteHeader(status)
+				w.Write([]byte(`{"license": {"type": "platinum"}}`))
+			}))
+			defer server.Close()
+
+			httpHelper, err := helper.NewHTTPFromConfig(helper.Config{}, mb.HostData{SanitizedURI: server.URL})
+			require.NoError(t, err)
+
+			content, err := fetchPathWithRetry(context.Background(), httpHelper, server.URL+"/_ccr/stats", "/_license", "")
+			if test.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEmpty(t, content)
+			}
+			require.Equal(t, test.expectedRequests, requests)
+		})
+	}
+}
+
+func TestAvailabilityCacheStaleFallback(t *testing.T) {
+	withFastBackoff(t)
+
+	available := true
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_license", func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"license": {"type": "platinum"}}`))
+	})
+	mux.HandleFunc("/_xpack", func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"features": {"ccr": {"enabled": true}}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	httpHelper, err := helper.NewHTTPFromConfig(helper.Config{}, mb.HostData{SanitizedURI: server.URL})
+	require.NoError(t, err)
+
+	// Expire the entries immediately, so they are fetched again
+	cache := newAvailabilityCache(0)
+	_, err = cache.getLicense(context.Background(), httpHelper, server.URL)
+	require.NoError(t, err)
+	_, err = cache.getXPack(context.Background(), httpHelper, server.URL)
+	require.NoError(t, err)
+
+	available = false
+	license, err := cache.getLicense(context.Background(), httpHelper, server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "platinum", license.Type)
+
+	xpack, err := cache.getXPack(context.Background(), httpHelper, server.URL)
+	require.NoError(t, err)
+	require.True(t, xpack.Features.CCR.Enabled)
+
+	// Stale entries are not used forever
+	cache.entry(server.URL).licenseFetchedOn = time.Now().Add(-staleAvailabilityMaxAge - time.Second)
+	_, err = cache.getLicense(context.Background(), httpHelper, server.URL)
+	require.Error(t, err)
+}
+
+func TestGetInfoStaleFallback(t *testing.T) {
+	withFastBackoff(t)
+
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"cluster_name": "es", "cluster_uuid": "1234", "version": {"number": "8.11.0"}}`))
+	}))
+	defer server.Close()
+
+	httpHelper, err := helper.NewHTTPFromConfig(helper.Config{}, mb.HostData{SanitizedURI: server.URL})
+	require.NoError(t, err)
+
+	info, err := GetInfo(context.Background(), httpHelper, server.URL+"/_ccr/stats")
+	require.NoError(t, err)
+	require.Equal(t, "1234", info.ClusterID)
+
+	available = false
+	info, err = GetInfo(context.Background(), httpHelper, server.URL+"/_enrich/_stats")
+	require.NoError(t, err)
+	require.Equal(t, "1234", info.ClusterID)
+}
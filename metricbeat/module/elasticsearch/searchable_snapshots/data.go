@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package searchable_snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	sharedCacheSchema = s.Schema{
+		"reads": c.Int("reads"),
+		"bytes_read": s.Object{
+			"bytes": c.Int("bytes_read_in_bytes"),
+		},
+		"writes": c.Int("writes"),
+		"bytes_written": s.Object{
+			"bytes": c.Int("bytes_written_in_bytes"),
+		},
+		"evictions":   c.Int("evictions"),
+		"num_regions": c.Int("num_regions"),
+		"size": s.Object{
+			"bytes": c.Int("size_in_bytes"),
+		},
+		"region_size": s.Object{
+			"bytes": c.Int("region_size_in_bytes"),
+		},
+	}
+)
+
+type cacheStats struct {
+	Nodes map[string]struct {
+		SharedCache map[string]interface{} `json:"shared_cache"`
+	} `json:"nodes"`
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+	var stats cacheStats
+	if err := json.Unmarshal(content, &stats); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch Searchable Snapshots Cache Stats API response: %w", err)
+	}
+
+	nodeIDs := make([]string, 0, len(stats.Nodes))
+	for nodeID := range stats.Nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	var errs multierror.Errors
+	for _, nodeID := range nodeIDs {
+		node := stats.Nodes[nodeID]
+		if node.SharedCache == nil {
+			errs = append(errs, elastic.MakeErrorForMissingField("nodes."+nodeID+".shared_cache", elastic.Elasticsearch))
+			continue
+		}
+
+		sharedCache, err := sharedCacheSchema.Apply(node.SharedCache)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure applying shared cache schema for node %s: %w", nodeID, err))
+			continue
+		}
+
+		event := mb.Event{
+			RootFields:   mapstr.M{},
+			ModuleFields: mapstr.M{},
+			MetricSetFields: mapstr.M{
+				"shared_cache": sharedCache,
+			},
+		}
+
+		event.RootFields.Put("service.name", elasticsearch.ModuleName)
+		event.ModuleFields.Put("cluster.name", info.ClusterName)
+		event.ModuleFields.Put("cluster.id", info.ClusterID)
+		event.ModuleFields.Put("node.id", nodeID)
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return errs.Err()
+}
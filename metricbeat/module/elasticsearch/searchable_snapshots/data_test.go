@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package searchable_snapshots
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/cache_stats.7130.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, content, true)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	event := events[0]
+	nodeID, err := event.ModuleFields.GetValue("node.id")
+	require.NoError(t, err)
+	require.Equal(t, "eerC3lEnQ3GYBA1H0rnedg", nodeID)
+
+	require.Equal(t, mapstr.M{
+		"shared_cache": mapstr.M{
+			"reads":         int64(6051),
+			"bytes_read":    mapstr.M{"bytes": int64(5448829)},
+			"writes":        int64(37),
+			"bytes_written": mapstr.M{"bytes": int64(1208320)},
+			"evictions":     int64(5),
+			"num_regions":   int64(65536),
+			"size":          mapstr.M{"bytes": int64(1099511627776)},
+			"region_size":   mapstr.M{"bytes": int64(16777216)},
+		},
+	}, event.MetricSetFields)
+}
+
+func TestMapperMissingSharedCache(t *testing.T) {
+	reporter := &mbtest.CapturingReporterV2{}
+	err := eventsMapping(reporter, info, []byte(`{"nodes": {"foobar": {}}}`), false)
+	require.Error(t, err)
+	require.Empty(t, reporter.GetEvents())
+}
+
+func TestData(t *testing.T) {
+	mux := createEsMuxer("7.13.0", "enterprise", true)
+	mux.Handle("/_searchable_snapshots/cache/stats", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/cache_stats.7130.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
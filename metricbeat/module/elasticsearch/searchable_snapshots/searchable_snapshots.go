@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package searchable_snapshots
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "searchable_snapshots", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	cacheStatsPath = "/_searchable_snapshots/cache/stats"
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	lastLicenseMessageTimestamp time.Time
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := elasticsearch.NewMetricSet(base, cacheStatsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch gathers the shared cache stats of each node from the
+// _searchable_snapshots/cache/stats API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	unavailableMessage, err := m.checkSearchableSnapshotsAvailability(ctx, info.Version.Number)
+	if err != nil {
+		return fmt.Errorf("error determining if searchable snapshots are available: %w", err)
+	}
+
+	if unavailableMessage != "" {
+		if err := m.UnavailableError(unavailableMessage); err != nil {
+			return err
+		}
+		if time.Since(m.lastLicenseMessageTimestamp) > 1*time.Minute {
+			m.lastLicenseMessageTimestamp = time.Now()
+			m.Logger().Warn(unavailableMessage)
+		}
+		return nil
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return eventsMapping(r, *info, content, m.XPackEnabled)
+}
+
+func (m *MetricSet) checkSearchableSnapshotsAvailability(ctx context.Context, currentElasticsearchVersion *version.V) (message string, err error) {
+	license, err := m.GetLicense(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining Elasticsearch license: %w", err)
+	}
+
+	if !license.IsOneOf("trial", "enterprise") {
+		message = "the searchable snapshots feature is available with an enterprise Elasticsearch license. " +
+			"You currently have a " + license.Type + " license. " +
+			"Either upgrade your license or remove the searchable_snapshots metricset from your Elasticsearch module configuration."
+		return
+	}
+
+	xpack, err := m.GetXPack(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining xpack features: %w", err)
+	}
+
+	if !xpack.Features.SearchableSnapshots.Enabled {
+		message = "the searchable snapshots feature is not enabled on your Elasticsearch cluster."
+		return
+	}
+
+	isAvailable := elastic.IsFeatureAvailable(currentElasticsearchVersion, elasticsearch.SearchableSnapshotsCacheStatsAPIAvailableVersion)
+
+	if !isAvailable {
+		metricsetName := m.FullyQualifiedName()
+		message = "the " + metricsetName + " is only supported with Elasticsearch >= " +
+			elasticsearch.SearchableSnapshotsCacheStatsAPIAvailableVersion.String() + ". " +
+			"You are currently running Elasticsearch " + currentElasticsearchVersion.String() + "."
+		return
+	}
+
+	return "", nil
+}
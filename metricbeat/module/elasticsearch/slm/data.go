@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	statsSchema = s.Schema{
+		"retention": s.Object{
+			"runs":      c.Int("retention_runs"),
+			"failed":    c.Int("retention_failed"),
+			"timed_out": c.Int("retention_timed_out"),
+			"deletion_time": s.Object{
+				"ms": c.Int("retention_deletion_time_millis"),
+			},
+		},
+		"snapshots": s.Object{
+			"taken":             c.Int("total_snapshots_taken"),
+			"failed":            c.Int("total_snapshots_failed"),
+			"deleted":           c.Int("total_snapshots_deleted"),
+			"deletion_failures": c.Int("total_snapshot_deletion_failures"),
+		},
+	}
+
+	policyStatsSchema = s.Schema{
+		"taken":             c.Int("snapshots_taken"),
+		"failed":            c.Int("snapshots_failed"),
+		"deleted":           c.Int("snapshots_deleted"),
+		"deletion_failures": c.Int("snapshot_deletion_failures"),
+	}
+
+	policySchema = s.Schema{
+		"version": c.Int("version", s.Optional),
+		"modified_date": s.Object{
+			"ms": c.Int("modified_date_millis", s.Optional),
+		},
+		"next_execution": s.Object{
+			"ms": c.Int("next_execution_millis", s.Optional),
+		},
+		"schedule":   c.Str("policy.schedule", s.Optional),
+		"repository": c.Str("policy.repository", s.Optional),
+		"last_success": c.Dict("last_success", s.Schema{
+			"snapshot_name": c.Str("snapshot_name", s.Optional),
+			"time": s.Object{
+				"ms": c.Int("time", s.Optional),
+			},
+		}, c.DictOptional),
+		"last_failure": c.Dict("last_failure", s.Schema{
+			"snapshot_name": c.Str("snapshot_name", s.Optional),
+			"time": s.Object{
+				"ms": c.Int("time", s.Optional),
+			},
+			"details": c.Str("details", s.Optional),
+		}, c.DictOptional),
+	}
+)
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, statsContent, policyContent []byte, isXpack bool) error {
+	return eventsMappingAt(r, info, statsContent, policyContent, isXpack, time.Now())
+}
+
+func eventsMappingAt(r mb.ReporterV2, info elasticsearch.Info, statsContent, policyContent []byte, isXpack bool, now time.Time) error {
+	var stats map[string]interface{}
+	if err := json.Unmarshal(statsContent, &stats); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch SLM Stats API response: %w", err)
+	}
+
+	var policies map[string]map[string]interface{}
+	if err := json.Unmarshal(policyContent, &policies); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch SLM Policy API response: %w", err)
+	}
+
+	var errs multierror.Errors
+
+	fields, err := statsSchema.Apply(stats)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failure applying SLM stats schema: %w", err))
+	} else {
+		r.Event(newEvent(info, fields, isXpack))
+	}
+
+	policyStats := map[string]map[string]interface{}{}
+	if list, ok := stats["policy_stats"].([]interface{}); ok {
+		for _, item := range list {
+			stat, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, ok := stat["policy"].(string); ok {
+				policyStats[id] = stat
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(policies))
+	for id := range policies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		fields, err := policySchema.Apply(policies[id])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure applying SLM policy schema for policy %s: %w", id, err))
+			continue
+		}
+		fields["id"] = id
+
+		if stat, found := policyStats[id]; found {
+			snapshots, err := policyStatsSchema.Apply(stat)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failure applying SLM policy stats schema for policy %s: %w", id, err))
+				continue
+			}
+			fields["snapshots"] = snapshots
+		}
+
+		if lastSuccess, err := fields.GetValue("last_success.time.ms"); err == nil {
+			if ms, ok := lastSuccess.(int64); ok {
+				fields.Put("time_since_last_success.ms", now.Sub(time.Unix(0, ms*int64(time.Millisecond))).Milliseconds())
+			}
+		}
+
+		r.Event(newEvent(info, mapstr.M{"policy": fields}, isXpack))
+	}
+
+	return errs.Err()
+}
+
+func newEvent(info elasticsearch.Info, fields mapstr.M, isXpack bool) mb.Event {
+	event := mb.Event{
+		RootFields:      mapstr.M{},
+		ModuleFields:    mapstr.M{},
+		MetricSetFields: fields,
+	}
+
+	event.RootFields.Put("service.name", elasticsearch.ModuleName)
+	event.ModuleFields.Put("cluster.name", info.ClusterName)
+	event.ModuleFields.Put("cluster.id", info.ClusterID)
+
+	// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+	// When using Agent, the index name is overwritten anyways.
+	if isXpack {
+		index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+		event.Index = index
+	}
+
+	return event
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package slm
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	stats, err := ioutil.ReadFile("./_meta/test/slm_stats.750.json")
+	require.NoError(t, err)
+	policies, err := ioutil.ReadFile("./_meta/test/slm_policy.750.json")
+	require.NoError(t, err)
+
+	now := time.Unix(0, 1575945000000*int64(time.Millisecond))
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMappingAt(reporter, info, stats, policies, true, now)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 3)
+
+	summary := events[0].MetricSetFields
+	retentionRuns, err := summary.GetValue("retention.runs")
+	require.NoError(t, err)
+	require.EqualValues(t, 13, retentionRuns)
+
+	daily := events[1].MetricSetFields
+	for field, expected := range map[string]interface{}{
+		"policy.id":                         "daily-snapshots",
+		"policy.repository":                 "my_repository",
+		"policy.snapshots.failed":           int64(1),
+		"policy.snapshots.deleted":          int64(49),
+		"policy.time_since_last_success.ms": int64(3600000),
+	} {
+		value, err := daily.GetValue(field)
+		require.NoError(t, err, field)
+		require.Equal(t, expected, value, field)
+	}
+
+	// A policy that has never succeeded has no last success
+	hourly := events[2].MetricSetFields
+	for _, field := range []string{"policy.last_success", "policy.time_since_last_success"} {
+		hasKey, _ := hourly.HasKey(field)
+		require.False(t, hasKey, field)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	stats, err := ioutil.ReadFile("./_meta/test/slm_stats.empty.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, info, stats, []byte(`{}`), true)
+	require.NoError(t, err)
+	require.Len(t, reporter.GetEvents(), 1)
+}
+
+func TestData(t *testing.T) {
+	mux := createEsMuxer("7.10.0", "active", true)
+	mux.Handle("/_slm/stats", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/slm_stats.750.json")
+		w.Write(input)
+	}))
+	mux.Handle("/_slm/policy", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/slm_policy.750.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContextCond(ms, t, "", func(e mapstr.M) bool {
+		hasPolicy, _ := e.HasKey("elasticsearch.slm.policy")
+		return hasPolicy
+	}); err != nil {
+		t.Fatal("write", err)
+	}
+}
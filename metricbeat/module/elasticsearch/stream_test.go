@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkJSONObject(t *testing.T) {
+	input := `{"_shards": {"total": 2, "nested": [1, {"a": [2]}]}, "indices": {"foo": {"uuid": "1"}, "bar": {"uuid": "2"}}, "took": 3}`
+	dec := json.NewDecoder(strings.NewReader(input))
+
+	var keys, uuids []string
+	err := WalkJSONObject(dec, func(key string) error {
+		keys = append(keys, key)
+		if key != "indices" {
+			return SkipJSONValue(dec)
+		}
+
+		return WalkJSONObject(dec, func(name string) error {
+			var index struct {
+				UUID string `json:"uuid"`
+			}
+			if err := dec.Decode(&index); err != nil {
+				return err
+			}
+			uuids = append(uuids, name+":"+index.UUID)
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"_shards", "indices", "took"}, keys)
+	require.Equal(t, []string{"foo:1", "bar:2"}, uuids)
+}
+
+func TestWalkJSONObjectInvalid(t *testing.T) {
+	for _, input := range []string{`[]`, `{"foo": `, `{"foo": 1`} {
+		dec := json.NewDecoder(strings.NewReader(input))
+		err := WalkJSONObject(dec, func(key string) error {
+			return SkipJSONValue(dec)
+		})
+		require.Error(t, err, input)
+	}
+}
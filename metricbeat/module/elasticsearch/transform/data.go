@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	checkpointSchema = s.Schema{
+		"checkpoint": c.Int("checkpoint", s.Optional),
+		"timestamp": s.Object{
+			"ms": c.Int("timestamp_millis", s.Optional),
+		},
+		"time_upper_bound": s.Object{
+			"ms": c.Int("time_upper_bound_millis", s.Optional),
+		},
+	}
+
+	transformSchema = s.Schema{
+		"id":     c.Str("id"),
+		"state":  c.Str("state"),
+		"reason": c.Str("reason", s.Optional),
+		"health": c.Dict("health", s.Schema{
+			"status": c.Str("status", s.Optional),
+		}, c.DictOptional),
+		"node": c.Dict("node", s.Schema{
+			"id":   c.Str("id", s.Optional),
+			"name": c.Str("name", s.Optional),
+		}, c.DictOptional),
+		"stats": c.Dict("stats", s.Schema{
+			"pages_processed":     c.Int("pages_processed"),
+			"documents_processed": c.Int("documents_processed"),
+			"documents_indexed":   c.Int("documents_indexed"),
+			"documents_deleted":   c.Int("documents_deleted", s.Optional),
+			"trigger_count":       c.Int("trigger_count"),
+			"index": s.Object{
+				"total":    c.Int("index_total"),
+				"failures": c.Int("index_failures"),
+				"time": s.Object{
+					"ms": c.Int("index_time_in_ms"),
+				},
+			},
+			"search": s.Object{
+				"total":    c.Int("search_total"),
+				"failures": c.Int("search_failures"),
+				"time": s.Object{
+					"ms": c.Int("search_time_in_ms"),
+				},
+			},
+			"processing": s.Object{
+				"total": c.Int("processing_total"),
+				"time": s.Object{
+					"ms": c.Int("processing_time_in_ms"),
+				},
+			},
+			"checkpoint_duration": s.Object{
+				"avg": s.Object{
+					"ms": c.Float("exponential_avg_checkpoint_duration_ms", s.Optional),
+				},
+			},
+		}),
+		"checkpointing": c.Dict("checkpointing", s.Schema{
+			"last":              c.Dict("last", checkpointSchema, c.DictOptional),
+			"next":              c.Dict("next", checkpointSchema, c.DictOptional),
+			"operations_behind": c.Int("operations_behind", s.Optional),
+			"changes_last_detected_at": s.Object{
+				"ms": c.Int("changes_last_detected_at", s.Optional),
+			},
+		}, c.DictOptional),
+	}
+)
+
+type transformsStats struct {
+	Transforms []map[string]interface{} `json:"transforms"`
+}
+
+func eventsMapping(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool) error {
+	return eventsMappingAt(r, info, content, isXpack, time.Now())
+}
+
+func eventsMappingAt(r mb.ReporterV2, info elasticsearch.Info, content []byte, isXpack bool, now time.Time) error {
+	var stats transformsStats
+	if err := json.Unmarshal(content, &stats); err != nil {
+		return fmt.Errorf("failure parsing Elasticsearch Transform Stats API response: %w", err)
+	}
+
+	var errs multierror.Errors
+	for _, transform := range stats.Transforms {
+		fields, err := transformSchema.Apply(transform)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure applying transform schema: %w", err))
+			continue
+		}
+
+		// The lag is the time between now and the upper bound of the source data
+		// covered by the last checkpoint of a continuous transform.
+		if upperBound, err := fields.GetValue("checkpointing.last.time_upper_bound.ms"); err == nil {
+			if ms, ok := upperBound.(int64); ok && ms > 0 {
+				fields.Put("checkpointing.lag.ms", now.Sub(time.Unix(0, ms*int64(time.Millisecond))).Milliseconds())
+			}
+		}
+
+		event := mb.Event{
+			RootFields:      mapstr.M{},
+			ModuleFields:    mapstr.M{},
+			MetricSetFields: fields,
+		}
+
+		event.RootFields.Put("service.name", elasticsearch.ModuleName)
+		event.ModuleFields.Put("cluster.name", info.ClusterName)
+		event.ModuleFields.Put("cluster.id", info.ClusterID)
+
+		// xpack.enabled in config using standalone metricbeat writes to `.monitoring` instead of `metricbeat-*`
+		// When using Agent, the index name is overwritten anyways.
+		if isXpack {
+			index := elastic.MakeXPackMonitoringIndexName(elastic.Elasticsearch)
+			event.Index = index
+		}
+
+		r.Event(event)
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package transform
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+)
+
+var info = elasticsearch.Info{
+	ClusterID:   "1234",
+	ClusterName: "helloworld",
+}
+
+func TestMapper(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/transform_stats.7100.json")
+	require.NoError(t, err)
+
+	now := time.Unix(0, 1607514896489*int64(time.Millisecond))
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMappingAt(reporter, info, content, true, now)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	continuous := events[0].MetricSetFields
+	for field, expected := range map[string]interface{}{
+		"id":                               "ecommerce-customer-transform",
+		"state":                            "started",
+		"health.status":                    "green",
+		"node.name":                        "a14cf47ef7f2",
+		"stats.documents_processed":        int64(6027),
+		"stats.search.time.ms":             int64(242),
+		"stats.checkpoint_duration.avg.ms": 77.2,
+		"checkpointing.last.checkpoint":    int64(74),
+		"checkpointing.operations_behind":  int64(27),
+		"checkpointing.lag.ms":             int64(60000),
+		"checkpointing.changes_last_detected_at.ms": int64(1607514896489),
+	} {
+		value, err := continuous.GetValue(field)
+		require.NoError(t, err, field)
+		require.Equal(t, expected, value, field)
+	}
+
+	failed := events[1].MetricSetFields
+	for field, expected := range map[string]interface{}{
+		"id":                    "web-logs-latest",
+		"state":                 "failed",
+		"reason":                "task encountered irrecoverable failure: no such index [web-logs]",
+		"health.status":         "red",
+		"stats.search.failures": int64(1),
+	} {
+		value, err := failed.GetValue(field)
+		require.NoError(t, err, field)
+		require.Equal(t, expected, value, field)
+	}
+
+	// A transform without a completed checkpoint has no lag
+	hasLag, _ := failed.HasKey("checkpointing.lag")
+	require.False(t, hasLag)
+}
+
+func TestEmpty(t *testing.T) {
+	reporter := &mbtest.CapturingReporterV2{}
+	err := eventsMapping(reporter, info, []byte(`{"count": 0, "transforms": []}`), false)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetEvents())
+}
+
+func TestData(t *testing.T) {
+	mux := createEsMuxer("7.10.0", "active", true)
+	mux.Handle("/_transform/_stats", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("./_meta/test/transform_stats.7100.json")
+		w.Write(input)
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(server.URL))
+	if err := mbtest.WriteEventsReporterV2WithContext(ms, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
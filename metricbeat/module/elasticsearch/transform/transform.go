@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package transform
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/elasticsearch"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(elasticsearch.ModuleName, "transform", New,
+		mb.WithHostParser(elasticsearch.HostParser),
+	)
+}
+
+const (
+	transformStatsPath = "/_transform/_stats?size=10000"
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*elasticsearch.MetricSet
+	lastTransformMessageTimestamp time.Time
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := elasticsearch.NewMetricSet(base, transformStatsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch gathers stats for each transform from the _transform/_stats API
+func (m *MetricSet) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	shouldSkip, err := m.ShouldSkipFetch(ctx)
+	if err != nil {
+		return err
+	}
+	if shouldSkip {
+		return nil
+	}
+
+	info, err := elasticsearch.GetInfo(ctx, m.HTTP, m.GetServiceURI())
+	if err != nil {
+		return err
+	}
+
+	transformUnavailableMessage, err := m.checkTransformAvailability(ctx, info.Version.Number)
+	if err != nil {
+		return fmt.Errorf("error determining if transforms are available: %w", err)
+	}
+
+	if transformUnavailableMessage != "" {
+		if err := m.UnavailableError(transformUnavailableMessage); err != nil {
+			return err
+		}
+		if time.Since(m.lastTransformMessageTimestamp) > 1*time.Minute {
+			m.lastTransformMessageTimestamp = time.Now()
+			m.Logger().Warn(transformUnavailableMessage)
+		}
+		return nil
+	}
+
+	content, err := m.HTTP.FetchContentWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return eventsMapping(r, *info, content, m.XPackEnabled)
+}
+
+func (m *MetricSet) checkTransformAvailability(ctx context.Context, currentElasticsearchVersion *version.V) (message string, err error) {
+	isAvailable := elastic.IsFeatureAvailable(currentElasticsearchVersion, elasticsearch.TransformStatsAPIAvailableVersion)
+
+	if !isAvailable {
+		metricsetName := m.FullyQualifiedName()
+		message = "the " + metricsetName + " is only supported with Elasticsearch >= " +
+			elasticsearch.TransformStatsAPIAvailableVersion.String() + ". " +
+			"You are currently running Elasticsearch " + currentElasticsearchVersion.String() + "."
+		return
+	}
+
+	// Serverless projects have no license and X-Pack APIs, transforms are always available there.
+	serverless, err := m.IsServerless(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining if Elasticsearch is serverless: %w", err)
+	}
+	if serverless {
+		return "", nil
+	}
+
+	license, err := m.GetLicense(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining Elasticsearch license: %w", err)
+	}
+
+	if license.Status != "" && license.Status != "active" {
+		message = "the transform feature requires an active Elasticsearch license. " +
+			"Your " + license.Type + " license is currently " + license.Status + "."
+		return
+	}
+
+	xpack, err := m.GetXPack(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error determining xpack features: %w", err)
+	}
+
+	if !xpack.Features.Transform.Enabled {
+		message = "the transform feature is not enabled on your Elasticsearch cluster."
+		return
+	}
+
+	return "", nil
+}
@@ -18,15 +18,94 @@
 package json
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/PaesslerAG/gval"
+
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
+// fieldConfig configures a field of the events extracted with a JSONPath
+// expression.
+type fieldConfig struct {
+	Field string `config:"field" validate:"required"`
+	Path  string `config:"path" validate:"required"`
+}
+
+type extractedField struct {
+	field string
+	path  gval.Evaluable
+}
+
+// documents returns the JSON objects in the response body to be reported as
+// events. The part of the body selected by json.select is split in multiple
+// documents if it is an array and json.is_array is enabled, and then by the
+// array selected by json.split. If json.fields is set, only the fields
+// extracted from each document are kept.
+func (m *MetricSet) documents(body interface{}) ([]mapstr.M, error) {
+	var err error
+	if m.selectPath != nil {
+		body, err = m.selectPath(context.Background(), body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select json.select path in response: %w", err)
+		}
+	}
+
+	values := []interface{}{body}
+	if m.jsonIsArray {
+		array, ok := body.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("response is not an array")
+		}
+		values = array
+	}
+
+	if m.splitPath != nil {
+		var split []interface{}
+		for _, value := range values {
+			elements, err := m.splitPath(context.Background(), value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to select json.split path in response: %w", err)
+			}
+			array, ok := elements.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("json.split path doesn't select an array")
+			}
+			split = append(split, array...)
+		}
+		values = split
+	}
+
+	docs := make([]mapstr.M, 0, len(values))
+	for _, value := range values {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("json document is not an object: %v", value)
+		}
+		doc := mapstr.M(obj)
+
+		if len(m.fields) > 0 {
+			doc = mapstr.M{}
+			for _, f := range m.fields {
+				v, err := f.path(context.Background(), obj)
+				if err != nil {
+					// Documents without the field are reported without it
+					continue
+				}
+				doc.Put(f.field, v)
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
 func (m *MetricSet) processBody(response *http.Response, jsonBody interface{}) mb.Event {
 	var event mapstr.M
 
@@ -20,13 +20,15 @@ package json
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
 
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/v7/metricbeat/helper"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
-	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 // init registers the MetricSet with the central registry.
@@ -67,6 +69,11 @@ type MetricSet struct {
 	responseEnabled bool
 	jsonIsArray     bool
 	deDotEnabled    bool
+	selectPath      gval.Evaluable
+	splitPath       gval.Evaluable
+	fields          []extractedField
+	paginator       *paginator
+	uri             string
 }
 
 // New create a new instance of the MetricSet
@@ -75,13 +82,17 @@ type MetricSet struct {
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 
 	config := struct {
-		Namespace       string `config:"namespace" validate:"required"`
-		Method          string `config:"method"`
-		Body            string `config:"body"`
-		RequestEnabled  bool   `config:"request.enabled"`
-		ResponseEnabled bool   `config:"response.enabled"`
-		JSONIsArray     bool   `config:"json.is_array"`
-		DeDotEnabled    bool   `config:"dedot.enabled"`
+		Namespace       string           `config:"namespace" validate:"required"`
+		Method          string           `config:"method"`
+		Body            string           `config:"body"`
+		RequestEnabled  bool             `config:"request.enabled"`
+		ResponseEnabled bool             `config:"response.enabled"`
+		JSONIsArray     bool             `config:"json.is_array"`
+		JSONSelect      string           `config:"json.select"`
+		JSONSplit       string           `config:"json.split"`
+		JSONFields      []fieldConfig    `config:"json.fields"`
+		DeDotEnabled    bool             `config:"dedot.enabled"`
+		Pagination      paginationConfig `config:"pagination"`
 	}{
 		Method:          "GET",
 		Body:            "",
@@ -89,12 +100,35 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		ResponseEnabled: false,
 		JSONIsArray:     false,
 		DeDotEnabled:    false,
+		Pagination:      defaultPaginationConfig(),
 	}
 
 	if err := base.Module().UnpackConfig(&config); err != nil {
 		return nil, err
 	}
 
+	selectPath, err := compilePath("json.select", config.JSONSelect)
+	if err != nil {
+		return nil, err
+	}
+	splitPath, err := compilePath("json.split", config.JSONSplit)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]extractedField, 0, len(config.JSONFields))
+	for _, f := range config.JSONFields {
+		path, err := compilePath("json.fields", f.Path)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, extractedField{field: f.Field, path: path})
+	}
+
+	paginator, err := newPaginator(config.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
 	http, err := helper.NewHTTP(base)
 	if err != nil {
 		return nil, err
@@ -112,55 +146,82 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		responseEnabled: config.ResponseEnabled,
 		jsonIsArray:     config.JSONIsArray,
 		deDotEnabled:    config.DeDotEnabled,
+		selectPath:      selectPath,
+		splitPath:       splitPath,
+		fields:          fields,
+		paginator:       paginator,
+		uri:             http.GetURI(),
 	}, nil
 }
 
+func compilePath(setting, path string) (gval.Evaluable, error) {
+	if path == "" {
+		return nil, nil
+	}
+	eval, err := jsonpath.New(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s path '%s'", setting, path)
+	}
+	return eval, nil
+}
+
 // Fetch methods implements the data gathering and data conversion to the right
 // format. It publishes the event which is then forwarded to the output. In case
 // of an error set the Error field of mb.Event or simply call report.Error().
 func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
-	response, err := m.http.FetchResponse()
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err := response.Body.Close(); err != nil {
-			m.Logger().Debug("error closing http body")
+	for page := m.paginator.first(); page != nil; {
+		uri, err := m.paginator.uri(m.uri, page)
+		if err != nil {
+			return err
 		}
-	}()
+		m.http.SetURI(uri)
 
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return err
-	}
+		response, body, err := m.fetchPage()
+		if err != nil {
+			return err
+		}
 
-	if m.jsonIsArray {
-		var jsonBodyArr []mapstr.M
-		if err = json.Unmarshal(body, &jsonBodyArr); err != nil {
+		docs, err := m.documents(body)
+		if err != nil {
 			return err
 		}
 
-		for _, obj := range jsonBodyArr {
-			event := m.processBody(response, obj)
+		for _, doc := range docs {
+			event := m.processBody(response, doc)
 
 			if reported := reporter.Event(event); !reported {
 				m.Logger().Debug(errors.Errorf("error reporting event: %#v", event))
 				return nil
 			}
 		}
-	} else {
-		var jsonBody mapstr.M
-		if err = json.Unmarshal(body, &jsonBody); err != nil {
-			return err
-		}
 
-		event := m.processBody(response, jsonBody)
+		page = m.paginator.next(page, body, len(docs))
+	}
+
+	return nil
+}
 
-		if reported := reporter.Event(event); !reported {
-			m.Logger().Debug(errors.Errorf("error reporting event: %#v", event))
-			return nil
+// fetchPage requests the current URI and returns the response and its decoded
+// JSON body.
+func (m *MetricSet) fetchPage() (*http.Response, interface{}, error) {
+	response, err := m.http.FetchResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			m.Logger().Debug("error closing http body")
 		}
+	}()
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
+	var body interface{}
+	if err = json.Unmarshal(content, &body); err != nil {
+		return nil, nil, err
+	}
+	return response, body, nil
 }
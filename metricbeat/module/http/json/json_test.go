@@ -21,9 +21,17 @@
 package json
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 
 	_ "github.com/elastic/beats/v7/metricbeat/module/http"
 )
@@ -31,3 +39,135 @@ import (
 func TestData(t *testing.T) {
 	mbtest.TestDataFiles(t, "http", "json")
 }
+
+func getConfig(url string, extra map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"module":     "http",
+		"metricsets": []string{"json"},
+		"hosts":      []string{url},
+		"namespace":  "test",
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+	return config
+}
+
+func TestFetchJSONPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"nodes":[{"name":"a","stats":{"cpu":0.5}},{"name":"b","stats":{"cpu":0.25}},{"name":"c"}]}}`))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"json.select": "$.data",
+		"json.split":  "$.nodes",
+		"json.fields": []map[string]interface{}{
+			{"field": "node.name", "path": "$.name"},
+			{"field": "cpu.pct", "path": "$.stats.cpu"},
+		},
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, mapstr.M{"node": mapstr.M{"name": "a"}, "cpu": mapstr.M{"pct": 0.5}}, events[0].MetricSetFields)
+	assert.Equal(t, mapstr.M{"node": mapstr.M{"name": "b"}, "cpu": mapstr.M{"pct": 0.25}}, events[1].MetricSetFields)
+	assert.Equal(t, mapstr.M{"node": mapstr.M{"name": "c"}}, events[2].MetricSetFields)
+}
+
+func TestFetchJSONPathSplitNotArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"nodes":{"name":"a"}}`))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"json.split": "$.nodes",
+	}))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	assert.NotEmpty(t, errs)
+}
+
+func TestFetchCursorPagination(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"items":[{"id":1},{"id":2}],"next":"p2"}`,
+		"p2": `{"items":[{"id":3}],"next":"p3"}`,
+		"p3": `{"items":[{"id":4}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[r.URL.Query().Get("after")]))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"json.split":              "$.items",
+		"pagination.type":         "cursor",
+		"pagination.cursor.path":  "$.next",
+		"pagination.cursor.param": "after",
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 4)
+	for i, event := range events {
+		assert.Equal(t, float64(i+1), event.MetricSetFields["id"])
+	}
+}
+
+func TestFetchOffsetPagination(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		// 5 items in total
+		items := ""
+		for i := offset; i < offset+2 && i < 5; i++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"id":%d}`, i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[" + items + "]"))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"json.is_array":          true,
+		"pagination.type":        "offset",
+		"pagination.limit.value": 2,
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 5)
+	assert.Equal(t, 3, requests)
+	for i, event := range events {
+		assert.Equal(t, float64(i), event.MetricSetFields["id"])
+	}
+}
+
+func TestFetchPaginationMaxPages(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fmt.Sprintf(`{"items":[{"id":%d}],"next":%d}`, requests, requests)))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"json.split":             "$.items",
+		"pagination.type":        "cursor",
+		"pagination.cursor.path": "$.next",
+		"pagination.max_pages":   3,
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 3)
+	assert.Equal(t, 3, requests)
+}
@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package json
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
+)
+
+const (
+	paginationCursor = "cursor"
+	paginationOffset = "offset"
+)
+
+type paginationConfig struct {
+	Type     string `config:"type"`
+	MaxPages int    `config:"max_pages" validate:"min=1"`
+	Cursor   struct {
+		Path  string `config:"path"`
+		Param string `config:"param"`
+	} `config:"cursor"`
+	Offset struct {
+		Param string `config:"param"`
+		Start int    `config:"start"`
+	} `config:"offset"`
+	Limit struct {
+		Param string `config:"param"`
+		Value int    `config:"value" validate:"min=0"`
+	} `config:"limit"`
+}
+
+func defaultPaginationConfig() paginationConfig {
+	var c paginationConfig
+	c.MaxPages = 10
+	c.Cursor.Param = "cursor"
+	c.Offset.Param = "offset"
+	c.Limit.Param = "limit"
+	return c
+}
+
+// Validate checks the pagination configuration.
+func (c *paginationConfig) Validate() error {
+	switch c.Type {
+	case "", paginationOffset:
+	case paginationCursor:
+		if c.Cursor.Path == "" {
+			return fmt.Errorf("pagination.cursor.path is required with %s pagination", paginationCursor)
+		}
+	default:
+		return fmt.Errorf("unknown pagination type '%s', expected '%s' or '%s'", c.Type, paginationCursor, paginationOffset)
+	}
+	return nil
+}
+
+// paginator builds the URIs of the pages to request, based on the responses
+// of the previous pages.
+type paginator struct {
+	config paginationConfig
+	cursor gval.Evaluable
+}
+
+func newPaginator(config paginationConfig) (*paginator, error) {
+	p := &paginator{config: config}
+	if config.Type == paginationCursor {
+		var err error
+		p.cursor, err = jsonpath.New(config.Cursor.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pagination.cursor.path '%s': %w", config.Cursor.Path, err)
+		}
+	}
+	return p, nil
+}
+
+// page keeps the state of the pagination during a fetch.
+type page struct {
+	number int
+	cursor string
+	offset int
+}
+
+func (p *paginator) first() *page {
+	return &page{offset: p.config.Offset.Start}
+}
+
+// uri returns the URI to request the page, adding the pagination parameters
+// to the base URI.
+func (p *paginator) uri(base string, pg *page) (string, error) {
+	if p.config.Type == "" {
+		return base, nil
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	if p.config.Limit.Value > 0 {
+		q.Set(p.config.Limit.Param, strconv.Itoa(p.config.Limit.Value))
+	}
+	switch p.config.Type {
+	case paginationCursor:
+		if pg.cursor != "" {
+			q.Set(p.config.Cursor.Param, pg.cursor)
+		}
+	case paginationOffset:
+		q.Set(p.config.Offset.Param, strconv.Itoa(pg.offset))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// next returns the page following the given one, or nil if there are no more
+// pages to request. body is the decoded response of the current page and
+// count the number of documents it contained.
+func (p *paginator) next(pg *page, body interface{}, count int) *page {
+	if p.config.Type == "" || pg.number+1 >= p.config.MaxPages {
+		return nil
+	}
+	if count == 0 || (p.config.Limit.Value > 0 && count < p.config.Limit.Value) {
+		return nil
+	}
+
+	next := &page{number: pg.number + 1}
+	switch p.config.Type {
+	case paginationCursor:
+		value, err := p.cursor(context.Background(), body)
+		if err != nil || value == nil {
+			return nil
+		}
+		next.cursor = cursorString(value)
+		if next.cursor == "" || next.cursor == pg.cursor {
+			return nil
+		}
+	case paginationOffset:
+		next.offset = pg.offset + count
+	}
+	return next
+}
+
+func cursorString(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
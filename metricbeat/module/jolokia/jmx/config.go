@@ -34,12 +34,18 @@ type JMXMapping struct {
 	MBean      string
 	Attributes []Attribute
 	Target     Target
+	// ExpandWildcards adds the values of the wildcard properties of the
+	// MBean pattern to the events of each matching MBean.
+	ExpandWildcards bool `config:"expand_wildcards"`
 }
 
 type Attribute struct {
 	Attr  string
 	Field string
 	Event string
+
+	// expandWildcards is set from the mapping the attribute belongs to.
+	expandWildcards bool
 }
 
 // Target inputs the value you want to set for jolokia target block
@@ -329,6 +335,7 @@ func (pc *JolokiaHTTPGetFetcher) buildGetRequestURIs(mappings []JMXMapping) ([]s
 
 		// For every attribute we will build a response mapping
 		for _, attribute := range mapping.Attributes {
+			attribute.expandWildcards = mapping.ExpandWildcards
 			responseMapping[attributeMappingKey{mbean.Canonicalize(true), attribute.Attr}] = attribute
 		}
 
@@ -459,6 +466,7 @@ func (pc *JolokiaHTTPPostFetcher) buildRequestBodyAndMapping(mappings []JMXMappi
 		}
 
 		for _, attribute := range mapping.Attributes {
+			attribute.expandWildcards = mapping.ExpandWildcards
 			rb.Attribute = append(rb.Attribute, attribute.Attr)
 			responseMapping[attributeMappingKey{mbean, attribute.Attr}] = attribute
 		}
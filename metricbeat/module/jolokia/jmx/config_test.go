@@ -679,6 +679,29 @@ func TestBuildPOSTRequestsAndMappings(t *testing.T) {
 				},
 			},
 		},
+		{
+			mappings: []JMXMapping{
+				{
+					MBean: "Catalina:name=*,type=ThreadPool",
+					Attributes: []Attribute{
+						{
+							Attr:  "port",
+							Field: "port",
+						},
+					},
+					ExpandWildcards: true,
+				},
+			},
+			httpMethod: "POST",
+			body:       `[{"type":"read","mbean":"Catalina:name=*,type=ThreadPool","attribute":["port"],"config":{"canonicalNaming":true,"ignoreErrors":true}}]`,
+			attributeMappings: map[attributeMappingKey]Attribute{
+				attributeMappingKey{"Catalina:name=*,type=ThreadPool", "port"}: Attribute{
+					Attr:            "port",
+					Field:           "port",
+					expandWildcards: true,
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -18,6 +18,7 @@
 package jmx
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/joeshaw/multierror"
@@ -213,6 +214,11 @@ func parseResponseEntry(
 		key.mbean = responseMbeanName
 	}
 	event := selectEvent(events, key)
+	if key.mbean != "" && field.expandWildcards {
+		if err := putWildcardProperties(event, requestMbeanName, responseMbeanName); err != nil {
+			return err
+		}
+	}
 
 	// In case the attributeValue is a map the keys are dedotted
 	data := attributeValue
@@ -229,3 +235,45 @@ func parseResponseEntry(
 	_, err := event.Put(field.Field, data)
 	return err
 }
+
+// putWildcardProperties adds to the event the values that the properties with
+// wildcards in the requested MBean pattern take in the response MBean name.
+// Fields already in the event are not overwritten, so attributes mapped to the
+// same field take precedence.
+func putWildcardProperties(event mapstr.M, requestMbeanName, responseMbeanName string) error {
+	pattern, err := ParseMBeanName(requestMbeanName)
+	if err != nil {
+		return err
+	}
+	name, err := ParseMBeanName(responseMbeanName)
+	if err != nil {
+		return err
+	}
+	for key, value := range pattern.Properties {
+		if !strings.ContainsAny(value, "*?") {
+			continue
+		}
+		property, found := name.Properties[key]
+		if !found {
+			continue
+		}
+		field := common.DeDot(key)
+		if _, err := event.GetValue(field); err == nil {
+			continue
+		}
+		event.Put(field, unquoteMBeanValue(property))
+	}
+	return nil
+}
+
+// unquoteMBeanValue returns the value of a quoted MBean property without the
+// quotes and escape characters. Unquoted values are returned as they are.
+func unquoteMBeanValue(value string) string {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value[1 : len(value)-1]
+}
@@ -299,6 +299,49 @@ func TestEventMapperWithWildcard(t *testing.T) {
 	require.ElementsMatch(t, expected, events)
 }
 
+func TestEventMapperWithExpandedWildcard(t *testing.T) {
+	absPath, err := filepath.Abs("./_meta/test")
+
+	require.NotNil(t, absPath)
+	require.NoError(t, err)
+
+	jolokiaResponse, err := ioutil.ReadFile(absPath + "/jolokia_response_wildcard.json")
+
+	require.NoError(t, err)
+
+	var mapping = AttributeMapping{
+		attributeMappingKey{"Catalina:name=*,type=ThreadPool", "port"}: Attribute{
+			Attr: "port", Field: "port", expandWildcards: true},
+		attributeMappingKey{"Catalina:name=*,type=ThreadPool", "maxConnections"}: Attribute{
+			Attr: "maxConnections", Field: "max_connections", expandWildcards: true},
+	}
+
+	// Construct a new POST response event mapper
+	eventMapper := NewJolokiaHTTPRequestFetcher("POST")
+
+	// Map response to Metricbeat events
+	events, err := eventMapper.EventMapping(jolokiaResponse, mapping)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(events))
+
+	expected := []mapstr.M{
+		{
+			"mbean":           "Catalina:name=\"http-bio-8080\",type=ThreadPool",
+			"name":            "http-bio-8080",
+			"max_connections": float64(200),
+			"port":            float64(8080),
+		},
+		{
+			"mbean":           "Catalina:name=\"ajp-bio-8009\",type=ThreadPool",
+			"name":            "ajp-bio-8009",
+			"max_connections": float64(200),
+			"port":            float64(8009),
+		},
+	}
+
+	require.ElementsMatch(t, expected, events)
+}
+
 func TestEventGroupingMapperWithWildcard(t *testing.T) {
 	absPath, err := filepath.Abs("./_meta/test")
 
@@ -77,7 +77,7 @@ type MemberDescription struct {
 const noID = -1
 
 // NewBroker creates a new unconnected kafka Broker connection instance.
-func NewBroker(host string, settings BrokerSettings) *Broker {
+func NewBroker(host string, settings BrokerSettings) (*Broker, error) {
 	cfg := sarama.NewConfig()
 	cfg.Net.DialTimeout = settings.DialTimeout
 	cfg.Net.ReadTimeout = settings.ReadTimeout
@@ -88,11 +88,18 @@ func NewBroker(host string, settings BrokerSettings) *Broker {
 		cfg.Net.TLS.Enable = true
 		cfg.Net.TLS.Config = tls
 	}
-	if user := settings.Username; user != "" {
+	if settings.Sasl.IsOAuthBearer() {
+		cfg.Net.SASL.Enable = true
+		if err := settings.Sasl.ConfigureSarama(cfg); err != nil {
+			return nil, err
+		}
+	} else if user := settings.Username; user != "" {
 		cfg.Net.SASL.Enable = true
 		cfg.Net.SASL.User = user
 		cfg.Net.SASL.Password = settings.Password
-		settings.Sasl.ConfigureSarama(cfg)
+		if err := settings.Sasl.ConfigureSarama(cfg); err != nil {
+			return nil, err
+		}
 	}
 	cfg.Version, _ = settings.Version.Get()
 
@@ -102,7 +109,7 @@ func NewBroker(host string, settings BrokerSettings) *Broker {
 		client:  nil,
 		id:      noID,
 		matchID: settings.MatchID,
-	}
+	}, nil
 }
 
 // Close the broker connection
@@ -283,6 +290,16 @@ func (b *Broker) FetchGroupOffsets(group string, partitions map[string][]int32)
 	return b.broker.FetchOffset(requ)
 }
 
+// FetchAllGroupOffsets fetches the committed offsets of all the partitions
+// consumed by a group. It requires Kafka 0.10.2 or later.
+func (b *Broker) FetchAllGroupOffsets(group string) (*sarama.OffsetFetchResponse, error) {
+	requ := &sarama.OffsetFetchRequest{
+		ConsumerGroup: group,
+		Version:       2,
+	}
+	return b.broker.FetchOffset(requ)
+}
+
 // FetchPartitionOffsetFromTheLeader fetches the OffsetNewest from the leader.
 func (b *Broker) FetchPartitionOffsetFromTheLeader(topic string, partitionID int32) (int64, error) {
 	offset, err := b.client.GetOffset(topic, partitionID, sarama.OffsetNewest)
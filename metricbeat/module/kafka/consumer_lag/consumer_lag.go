@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package consumer_lag
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/kafka"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// init registers the MetricSet with the central registry.
+func init() {
+	mb.Registry.MustAddMetricSet("kafka", "consumer_lag", New)
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*kafka.MetricSet
+
+	topics common.StringSet
+	groups common.StringSet
+	ends   *endOffsetHistory
+	logger *logp.Logger
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	opts := kafka.MetricSetOptions{
+		Version: "0.10.2.0",
+	}
+
+	ms, err := kafka.NewMetricSet(base, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		Groups []string `config:"groups"`
+		Topics []string `config:"topics"`
+	}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		MetricSet: ms,
+		groups:    common.MakeStringSet(config.Groups...),
+		topics:    common.MakeStringSet(config.Topics...),
+		ends:      newEndOffsetHistory(),
+		logger:    logp.NewLogger("kafka.consumer_lag"),
+	}, nil
+}
+
+// Fetch consumer lag metrics from kafka
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	broker, err := m.Connect()
+	if err != nil {
+		return fmt.Errorf("error in connect: %w", err)
+	}
+	defer broker.Close()
+
+	brokerInfo := mapstr.M{
+		"id":      broker.ID(),
+		"address": broker.AdvertisedAddr(),
+	}
+
+	q := lagQuery{
+		client:       broker,
+		groupsFilter: filter(m.groups),
+		topicsFilter: filter(m.topics),
+		ends:         m.ends,
+		logger:       m.logger,
+	}
+	err = q.fetch(time.Now(), func(lag partitionLag) {
+		r.Event(mb.Event{
+			ModuleFields: mapstr.M{
+				"broker": brokerInfo,
+				"topic": mapstr.M{
+					"name": lag.topic,
+				},
+				"partition": mapstr.M{
+					"id":       lag.partition,
+					"topic_id": fmt.Sprintf("%d-%s", lag.partition, lag.topic),
+				},
+			},
+			MetricSetFields: lag.fields(),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error in fetch: %w", err)
+	}
+
+	return nil
+}
+
+func filter(set common.StringSet) func(string) bool {
+	if set.Count() == 0 {
+		return nil
+	}
+	return set.Has
+}
@@ -0,0 +1,209 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package consumer_lag
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type mockClient struct {
+	// group -> topic -> partition -> committed offset
+	committed map[string]map[string]map[int32]int64
+	// topic -> partition -> end offset
+	end map[string]map[int32]int64
+
+	groupErrors map[string]error
+	endQueries  map[topicPartition]int
+}
+
+func (c *mockClient) ListGroups() ([]string, error) {
+	var groups []string
+	for group := range c.committed {
+		groups = append(groups, group)
+	}
+	for group := range c.groupErrors {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (c *mockClient) FetchAllGroupOffsets(group string) (*sarama.OffsetFetchResponse, error) {
+	if err := c.groupErrors[group]; err != nil {
+		return nil, err
+	}
+
+	resp := &sarama.OffsetFetchResponse{}
+	for topic, partitions := range c.committed[group] {
+		for partition, offset := range partitions {
+			resp.AddBlock(topic, partition, &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+	}
+	return resp, nil
+}
+
+func (c *mockClient) FetchPartitionOffsetFromTheLeader(topic string, partition int32) (int64, error) {
+	if c.endQueries == nil {
+		c.endQueries = map[topicPartition]int{}
+	}
+	c.endQueries[topicPartition{topic, partition}]++
+
+	offset, found := c.end[topic][partition]
+	if !found {
+		return -1, errors.New("unknown partition")
+	}
+	return offset, nil
+}
+
+func TestFetchLag(t *testing.T) {
+	client := &mockClient{
+		committed: map[string]map[string]map[int32]int64{
+			"billing": {
+				"orders": {0: 90, 1: -1},
+			},
+			"shipping": {
+				"orders":   {0: 100, 1: 20},
+				"payments": {0: 5},
+				"deleted":  {0: 3},
+			},
+		},
+		end: map[string]map[int32]int64{
+			"orders":   {0: 100, 1: 40},
+			"payments": {0: 5},
+		},
+		groupErrors: map[string]error{
+			"broken": errors.New("coordinator not available"),
+		},
+	}
+
+	q := lagQuery{client: client, ends: newEndOffsetHistory(), logger: logp.NewLogger("test")}
+
+	var lags []partitionLag
+	err := q.fetch(time.Now(), func(lag partitionLag) { lags = append(lags, lag) })
+	assert.ErrorContains(t, err, "failed to fetch offsets of group 'broken'")
+
+	// Groups and partitions are reported in order, partitions without
+	// committed offsets or end offsets are skipped
+	require.Len(t, lags, 4)
+	assertLag(t, lags[0], "billing", "orders", 0, 10)
+	assertLag(t, lags[1], "shipping", "orders", 0, 0)
+	assertLag(t, lags[2], "shipping", "orders", 1, 20)
+	assertLag(t, lags[3], "shipping", "payments", 0, 0)
+
+	// End offsets are queried once per partition
+	assert.Equal(t, 1, client.endQueries[topicPartition{"orders", 0}])
+	assert.Equal(t, 1, client.endQueries[topicPartition{"deleted", 0}])
+}
+
+func TestFetchLagFilters(t *testing.T) {
+	client := &mockClient{
+		committed: map[string]map[string]map[int32]int64{
+			"billing":  {"orders": {0: 90}, "payments": {0: 1}},
+			"shipping": {"orders": {0: 100}},
+		},
+		end: map[string]map[int32]int64{
+			"orders":   {0: 100},
+			"payments": {0: 5},
+		},
+	}
+
+	q := lagQuery{
+		client:       client,
+		groupsFilter: func(group string) bool { return group == "billing" },
+		topicsFilter: func(topic string) bool { return topic == "payments" },
+		ends:         newEndOffsetHistory(),
+		logger:       logp.NewLogger("test"),
+	}
+
+	var lags []partitionLag
+	require.NoError(t, q.fetch(time.Now(), func(lag partitionLag) { lags = append(lags, lag) }))
+	require.Len(t, lags, 1)
+	assertLag(t, lags[0], "billing", "payments", 0, 4)
+	assert.Zero(t, client.endQueries[topicPartition{"orders", 0}])
+}
+
+func TestLagSeconds(t *testing.T) {
+	tp := topicPartition{"orders", 0}
+	start := time.Now()
+	now := start.Add(20 * time.Second)
+
+	h := newEndOffsetHistory()
+	_, ok := h.lagSeconds(tp, 10, now)
+	assert.False(t, ok, "no estimation without end offsets")
+
+	h.add(tp, 100, start)
+	_, ok = h.lagSeconds(tp, 50, now)
+	assert.False(t, ok, "no estimation before the production rate is known")
+
+	h.add(tp, 200, start.Add(10*time.Second))
+	h.add(tp, 200, start.Add(15*time.Second))
+	h.add(tp, 300, now)
+
+	cases := map[string]struct {
+		committed int64
+		seconds   float64
+	}{
+		"caught up":    {committed: 300, seconds: 0},
+		"interpolated": {committed: 150, seconds: 15},
+		"sample":       {committed: 200, seconds: 10},
+		"extrapolated": {committed: 50, seconds: 25},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			seconds, ok := h.lagSeconds(tp, c.committed, now)
+			require.True(t, ok)
+			assert.InDelta(t, c.seconds, seconds, 0.001)
+		})
+	}
+}
+
+func TestEndOffsetHistory(t *testing.T) {
+	tp := topicPartition{"orders", 0}
+	start := time.Now()
+
+	h := newEndOffsetHistory()
+	for i := 0; i < maxEndOffsetSamples+10; i++ {
+		h.add(tp, int64(i*10), start.Add(time.Duration(i)*time.Second))
+	}
+	require.Len(t, h.samples[tp], maxEndOffsetSamples)
+	assert.Equal(t, int64(100), h.samples[tp][0].offset)
+
+	// Offsets going backwards reset the history
+	h.add(tp, 5, start.Add(time.Hour))
+	require.Len(t, h.samples[tp], 1)
+
+	h.retain(map[topicPartition]int64{})
+	assert.Empty(t, h.samples)
+}
+
+func assertLag(t *testing.T, lag partitionLag, group, topic string, partition int32, messages int64) {
+	t.Helper()
+	assert.Equal(t, group, lag.group)
+	assert.Equal(t, topic, lag.topic)
+	assert.Equal(t, partition, lag.partition)
+	assert.Equal(t, messages, lag.fields()["messages"])
+}
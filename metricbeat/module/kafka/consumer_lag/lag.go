@@ -0,0 +1,262 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package consumer_lag
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// maxEndOffsetSamples is the number of end offsets kept per partition to
+// estimate the lag in seconds. With the default period of 10s, lags of up to
+// 10 minutes are interpolated, longer ones are extrapolated.
+const maxEndOffsetSamples = 60
+
+type client interface {
+	ListGroups() ([]string, error)
+	FetchAllGroupOffsets(group string) (*sarama.OffsetFetchResponse, error)
+	FetchPartitionOffsetFromTheLeader(topic string, partitionID int32) (int64, error)
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+type partitionLag struct {
+	group     string
+	topic     string
+	partition int32
+	committed int64
+	end       int64
+	metadata  string
+
+	seconds    float64
+	hasSeconds bool
+}
+
+func (l partitionLag) fields() mapstr.M {
+	messages := l.end - l.committed
+	if messages < 0 {
+		// The group committed after the end offset was read
+		messages = 0
+	}
+
+	fields := mapstr.M{
+		"group": l.group,
+		"offset": mapstr.M{
+			"committed": l.committed,
+			"end":       l.end,
+		},
+		"messages": messages,
+	}
+	if l.metadata != "" {
+		fields["meta"] = l.metadata
+	}
+	if l.hasSeconds {
+		fields["seconds"] = l.seconds
+	}
+	return fields
+}
+
+type lagQuery struct {
+	client       client
+	groupsFilter func(string) bool
+	topicsFilter func(string) bool
+	ends         *endOffsetHistory
+	logger       *logp.Logger
+}
+
+// fetch joins the committed offsets of the consumer groups managed by the
+// broker with the end offsets of their partitions. End offsets are queried
+// once per partition, even if several groups consume it.
+func (q *lagQuery) fetch(now time.Time, emit func(partitionLag)) error {
+	groups, err := q.client.ListGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list consumer groups: %w", err)
+	}
+	sort.Strings(groups)
+
+	var errs multierror.Errors
+	endOffsets := map[topicPartition]int64{}
+	failed := map[topicPartition]bool{}
+
+	for _, group := range groups {
+		if q.groupsFilter != nil && !q.groupsFilter(group) {
+			continue
+		}
+
+		resp, err := q.client.FetchAllGroupOffsets(group)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch offsets of group '%s': %w", group, err))
+			continue
+		}
+
+		for _, tp := range sortedPartitions(resp) {
+			if q.topicsFilter != nil && !q.topicsFilter(tp.topic) {
+				continue
+			}
+
+			block := resp.Blocks[tp.topic][tp.partition]
+			if block.Err != sarama.ErrNoError {
+				q.logger.Debugf("error in committed offset of group '%s' for (topic, partition): ('%s', %d): %v",
+					group, tp.topic, tp.partition, block.Err)
+				continue
+			}
+			if block.Offset < 0 {
+				// No offset committed for this partition
+				continue
+			}
+
+			end, found := endOffsets[tp]
+			if !found {
+				if failed[tp] {
+					continue
+				}
+				end, err = q.client.FetchPartitionOffsetFromTheLeader(tp.topic, tp.partition)
+				if err != nil {
+					q.logger.Errorf("failed to fetch end offset for (topic, partition): ('%s', %d): %v",
+						tp.topic, tp.partition, err)
+					failed[tp] = true
+					continue
+				}
+				endOffsets[tp] = end
+				q.ends.add(tp, end, now)
+			}
+
+			lag := partitionLag{
+				group:     group,
+				topic:     tp.topic,
+				partition: tp.partition,
+				committed: block.Offset,
+				end:       end,
+				metadata:  block.Metadata,
+			}
+			lag.seconds, lag.hasSeconds = q.ends.lagSeconds(tp, block.Offset, now)
+			emit(lag)
+		}
+	}
+
+	q.ends.retain(endOffsets)
+
+	return errs.Err()
+}
+
+func sortedPartitions(resp *sarama.OffsetFetchResponse) []topicPartition {
+	var partitions []topicPartition
+	for topic, blocks := range resp.Blocks {
+		for partition := range blocks {
+			partitions = append(partitions, topicPartition{topic, partition})
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].topic != partitions[j].topic {
+			return partitions[i].topic < partitions[j].topic
+		}
+		return partitions[i].partition < partitions[j].partition
+	})
+	return partitions
+}
+
+type offsetSample struct {
+	offset int64
+	at     time.Time
+}
+
+// endOffsetHistory keeps the recent end offsets of each partition, so the
+// time a message was produced can be estimated from its offset.
+type endOffsetHistory struct {
+	samples map[topicPartition][]offsetSample
+}
+
+func newEndOffsetHistory() *endOffsetHistory {
+	return &endOffsetHistory{samples: map[topicPartition][]offsetSample{}}
+}
+
+func (h *endOffsetHistory) add(tp topicPartition, offset int64, at time.Time) {
+	samples := h.samples[tp]
+	if n := len(samples); n > 0 {
+		last := samples[n-1]
+		if offset == last.offset {
+			// Keep the time the end offset was first seen
+			return
+		}
+		if offset < last.offset {
+			// The partition was recreated or truncated
+			samples = nil
+		}
+	}
+
+	if len(samples) == maxEndOffsetSamples {
+		samples = append(samples[:0], samples[1:]...)
+	}
+	h.samples[tp] = append(samples, offsetSample{offset: offset, at: at})
+}
+
+// lagSeconds estimates the time elapsed since the message at the committed
+// offset was produced. It interpolates between the end offsets seen around
+// the committed offset, or extrapolates with the average production rate
+// when the committed offset is older than the history.
+func (h *endOffsetHistory) lagSeconds(tp topicPartition, committed int64, now time.Time) (float64, bool) {
+	samples := h.samples[tp]
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	if committed >= last.offset {
+		return 0, true
+	}
+
+	var produced time.Time
+	i := sort.Search(len(samples), func(i int) bool { return samples[i].offset > committed })
+	if i == 0 {
+		elapsed := last.at.Sub(first.at).Seconds()
+		if elapsed <= 0 {
+			return 0, false
+		}
+		rate := float64(last.offset-first.offset) / elapsed
+		produced = first.at.Add(-time.Duration(float64(first.offset-committed) / rate * float64(time.Second)))
+	} else {
+		prev, next := samples[i-1], samples[i]
+		fraction := float64(committed-prev.offset) / float64(next.offset-prev.offset)
+		produced = prev.at.Add(time.Duration(fraction * float64(next.at.Sub(prev.at))))
+	}
+
+	seconds := now.Sub(produced).Seconds()
+	if seconds < 0 {
+		seconds = 0
+	}
+	return seconds, true
+}
+
+// retain drops the history of the partitions that are not in the given set.
+func (h *endOffsetHistory) retain(partitions map[topicPartition]int64) {
+	for tp := range h.samples {
+		if _, found := partitions[tp]; !found {
+			delete(h.samples, tp)
+		}
+	}
+}
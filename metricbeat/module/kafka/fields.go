@@ -32,5 +32,5 @@ func init() {
 // AssetKafka returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/kafka.
 func AssetKafka() string {
-	return "eJzUWk1z2zYTvvtX7OTkHMLcfXhn3iadjpumyaTpTKcXDgQsRdQgwACgbPnXdwCQFL9EEqLcNjrZFHafB/uFxVJv4AGPd/BAsgdyA2C5FXgHrz64/1/dADA0VPPSciXv4H83AAD+OygUqwTeAJhcaZtSJTO+v4OMCOOeahRIDN7B3qnNOApm7rz4G5CkwBOk+9hj6ZZqVZX1kwncvpquqp1WD6jbx1P6zuoMnx+8BninpKkK1PCTE4V7mSldECcAOTkg7BAlaCQMMq0KuK3FciKZ4HLfU2lzBNro81ReJ50Fw71098NZ73GzH6EGELNb6myLs5tJHMKYRmMmwR7w+Kj0kMg6PMIOqC03yFqIkc+sKjlN3N8jv42hZ2C/Oj1e5zkM1FrphCo2RhpYdBHGqwKnKhmjlURb7mSTnv9ikT43aoCzWRS/u3QCa95+PbDfJf9WIXAGKvMRW57QpX8QbLjMI+TgP0MHiGT+vwCaXKUg1LFboNWcmpDgodTV3/z88Y+ObFvgdmjJyrwudkjkXEZ9dAvA5sSCzbkBPKC0wI1DIxYZWLU6WRtQjd8qNDahOZESRfKtwgoTw59xjsnXHMGtaRxRawEvva46DQmUWrGKYpIRLpClJerUIFVytsY4HppYzyMIQq2n0WugRA2TmgKxTChiZ5llaGl+OS8quHOT19IaymmrNF6BXd9uS6RkVexQz5jrCjZaz2HWNNFMSsGpP40TgYShTlEgdf8PVY0YhfXQrPeu2wBfSSqQyDSWRi13DToGjXFUnpV6QCxRJ4wbqqREapdo/KnUBy8DVCh3StfKNgTrmA4+lVwv1pgTlbD+Zbi4lk1JcVzPppF4ETrmKGmMj3wO1b7dxkWofZKJyuTpRMiNs0btwa++JEDrBg9twmWyO1o0TWldguWSqoLLPTipUGXdhr3Ci0moysaxUJXdq2uz0PgXUossjkojdTUqBRpD9mhSPtuO9JxRy2yDv044XAB6BfdfgHotd0dCb3XvCrgGqrnhxvXa7T17ottuv/tO+23f66wqrwWXvKiKkFHEwmPOad6fGxiUzPTbJwNWARlfcdYERgjDWvtiG0cOqF1InNo5L9+wY5ApDQRMiZRnnNZ3sw39LlWabaFXazgRPHGZ5BpJMLZwNfeDxmo+z9w9VvWcHJvc5CkVZHYQ5IOLPPngakNpLLOE1DYsKVVFwUdXh7MbVllm0HUsXsrtt+1mIin4IeF2+A+dWeNqO68vou1F8FTWQjEND/zKC2rqsIRGTzXPKeoPZ5vPBRPJFUPC4Uj1fbt4Eij4bt2AYYDUWr/2P5dWdQZIO3Tp5/r6eQZF/3yJ2iytjFVFNw4sAUYsAWN1d0A8idyITaR3pAUE2fuC1+7+bajNlAhahZONGF+EGM8y1CgpuoP1EVEO5m61MYlkQwPPb2Zy6rp+K+PZsi8FLYe3J4bdoey8ff1FapLOMENW8Pm/MXwvkTX3MxdZLsJ8D1F3NC3JgfRUqsFcusFCFI74vguk7t/DbTCcQWsdvcA24ez1tLG6NHJlhua6lEhP1VnAAt1Rnm7fPpcWtSRi8AamBuhWodE8e0vBnVISX2xnauAlcXogXJCdwFqvaUa6e35A2ZnjR8aoxEecCY+JBF9B1n1+9YqbwjN8ETCk2TGbYC9D6JNXvExo0pnnSsDF/jy9H3IHS6zXwuTxBYz0Sxhpcga34Wry+ryn6lHqC7D4EjRP0zhfbqU5Spou0dopJcYXypXM7iXjlLiLAM8aA7ibJpdUVAxZ84KLyzeOTDtuRle54fb+ty+rdmLSBQ+/yCZsO2Jfpni2MYAr+P/HthcIB7C/ObtjbyJd2wwNL0giRxifa6mpEUb73Xc6wiDNkZHuKtcepv4Gu3QLssoSAaRQlfRVMsi6I1fp4eR9cVBBLM1Tw58xJYfFu+65QYU51+6tAi7I09pL9mrgmZekVGmWGpRs1ezo/PDDYW8dwqQarT5eTMRqjqxWVY+wthLyVeM/RCgMgeqR3L/srNg0aeww/glADGBEeiwBLv10YJXfT8ZtCvpVfiFgSiUNXs4gyG+gwFX6SPhijLWQ928/gRMAy4tY/0a/JemPOMMLE1VZP3ywHVaRPOr52Sqrl6eDeNUbjL8DAAD//1EPWgk="
+	return "eJzUms2OG7kRx+96isKexoDdvs8hQLJrBBOv14vNBghyaVBktcQMm5RJ9mjkpw/4qVarv6VZZOG5qJtV/x/JYpFd9Ad4xtMjPJPqmWwALLcCH+GHz+73DxsAhoZqfrBcyUf4ywYAwL+DWrFG4AbA7JW2JVWy4rtHqIgw7qlGgcTgI+yc24qjYObRm38ASWo8S7p/9nRwTbVqDvFJj+6lm7arrVbPqPPjPn+DPsPf37wH+FFJ09So4e8OBZ5kpXRNXOdhT14QtogSNBIGlVY1PESzPZFMcLm7cGn3CDT58yjvilaDbl/a/eHs4nHqj1AdidEutbrF2aZXhzCm0ZiOWRB7xtNRabZKj7AX1JYbZFli09W26sBp4fq7mZYekf3d+fE+hzRQa6ULqhhuJkZ0Usa7AuequFY7EG25i5WCsxuUfk1ugLNRFd+7krOF49d6DPAvyb81CJyBqnzEZvfApX/gVWZwhDX4x+AAkcz/CqLFFdyahBBjt0arOTVhgYdUF9/848u/W7Y5wW3Rkpnrut4ikRdvOgxfXAOwe2LB7rkBfEFpgRvQKIhFBlZ1zIeG+Cyq8VuDxhZ0T6REUXxrsMHC8O84RvL7HsG1SRMRvYC37hj2Rvg1wEEr1lAsKsIFsvKAujRIlWRTHJpYzxEMIfpJfg0cUEOvpwBWCUXsKFmFlu7Xc1HB3TR5L8knOG+NxjvQXY7bFJRs6i3qkeFaSdEeo/kMo0OzmOQgOPW7cSGQMNQlCqTut5kiCu0htfdTd4N8I6lAIsulGNHuHjgGjXEj8V2pZ8QD6oJxQ5WUSO0Uxn+U+uxtgArlduno7IZgvcbB1wPXOB8ltH8bFndkU1Kc5tMkizfBMSdJ56PENRTn9jYWoXZFJRqzL3tC7opBqB341msCNB7w0BZcFtuTRZNS65Qsl1TVXO7AWXlp32HvcDWEauwyCtXYnbo3hcb/IrXIlqEkq7uh1GgM2aEpuZw9GdHmNvn7hMMK0TtM/wrVe033Qulbp3eGXJJKX7jLztr5O7vntJ3f/UnP2/6gNCu91lzyuql9cAGxcNxzur+sGxiUzFwenwxYBeT6E2doptpsLpZNGb2zKT7ygprs2sc5b5/oGFRKAwFzQMorTuO32eq9SSNVmt2CFz2cAc8svawLAZcmrvR9kEbNJzH3HasuJnkhRU1eS0F2U+I1efXBlVTg2mZKKR9YSqrqmlszpZk6rKrKoIVo5fqbTzMLEXyR8Hb5z61a41zpBUk0CeexTsk0PPAtZ6gn5eSmM80z8uplJelnskuf75nMm5v3/vxwLuHcmGe7TOOJcmgviDXXn3LjXq0wuUWYXItsXi2io/m1HSEuz29PPcPkc8ZFFeo8UGNsKNdRfZIsxa6qLoXfh02KG//4spHEV5u37/i173Yu4HaCNxqZVbS/5LSb3KQaXaYGqSyc/FL04ZfH2Q/vBFzI2/1shhJXqrlevKPrwf19MpbXoZTGa3e2jPuDAcMlRU+nBHOlrtit8U7AkZhUYmHFpqMGT37OMIv6c47TwDzXBoy7VeASDhpfuGrifo/mva9zcuMJNB6UdtyNtFw4gCuxgJELCldB5FCepTq21nvvyNeXK3/Rav6CljBiyeTaOnK7bwVzselSpPbd5LI4DQ45ypluNzfPcbZ2WFYluV6xqWWZeht9AJdWtUJgi+4Q4qob2UkvwS0xQBtjVevk4XyBDwpjdfuarFd5YPdbMQKC7HwKz73/6E99QImgTTjfk5BQGa8q1OgSwBbtEbGbyOJguvWY3XcmqbczvXdP87tynb/8gSgzfDwTtq+mUuNepFBO6sXprpAZPH81hu8kslSlcpHlIszfXMTvugzZse5baqPLbSoKr3h/DFBPP8FDGDiD1jq8QFtw9i67GMTYK2PvBHLhalCwxnrbvUpbpcqlRS2JOMesn+Eo0M5CSbpvqhYn3D4ny5PtSA5cE6cvhAuyFWm/MWl33PEXlOd+FwtjVOIRR8KjZ4HPgHV/v3jHkfZqKy8GgcLh5Q2Avgo2C2jTR5XbbfqgVszn+ZbcbSxLZy3cv7zBIP3sHbv79IdQoHlXDELEC6U3oPgteO7HGOTh0t1LlFNYW6XEdVltJtmTZJwSVw7hVbpRc8dSLqloGLL0CcHlBweTL90Q3Cp4ePrnb7N6YuLN3B/bCZsvGrPZIOLgweAe8/8pnwXCBuy/J9y217NcE1D8htGbqcV5of9rtOor5OZ3f9JCLklbRrlt3PGw9HW8MQpXhLLKEgGkVo10yRKCrdtylT7NKAK1CbbElZIN/44ledlNKQ+Va83QcW+WcE1ep4RTqXG28FVkJ91QwC1d1XtWBX24BOy011+TRg6NVp9Wg1jNkUVXsZB/K5DPGv9HQOE2LV5MrEG642QtXSZpHK7/I9QSwQXLY0pwZFX48Z017+fBTQn9fG90wwibg5IG1xME+xsQuCqPhNsp8Sz59PErOANf5luotfiuOFXbvBGEa2PV2HP9OFIt5IgFyFmjnjueqpY9Rm29/w0AlGXMQA=="
 }
@@ -19,6 +19,7 @@ package kafka
 
 import (
 	"crypto/tls"
+	"fmt"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
@@ -67,9 +68,14 @@ func NewMetricSet(base mb.BaseMetricSet, options MetricSetOptions) (*MetricSet,
 		Sasl:        config.Sasl,
 	}
 
+	broker, err := NewBroker(base.Host(), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure kafka broker: %w", err)
+	}
+
 	return &MetricSet{
 		BaseMetricSet: base,
-		broker:        NewBroker(base.Host(), cfg),
+		broker:        broker,
 	}, nil
 
 }
@@ -32,5 +32,5 @@ func init() {
 // AssetKibana returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/kibana.
 func AssetKibana() string {
-	return "eJzsWs2O2zYQvu9TDHzJJSt0D3uID0WLNECLIkGRNuihKARaHFvsUqTCobzrPn1B/XhliZRkWW4WQfYQBLLmm48zw5nRkLfwgIc1PIgNU+wGwAorcQ2rX8sHqxsAjpQYkVuh1Rq+vwEAqH6ETPNC4g0ApdrYONFqK3Zr2DJJ7qlBiYxwDTsHTGitUDtaw18rIrl6DavU2nz19w3AVqDktC6xb0GxDBtGMVlmqfwBwB5yh2Z0kddP2oJtYSsyJMuy/PhLI82kYNR6mjObrmH1w1Fi1QOrmEQGKdeKMHavRhl7mohdi5cL6YNEGYUUOomC5mjxAzTwmqIMM20O0dYgxkLFm4PFWYrGoBqVudEJEkVF7tbt3suElGKWzlMsn/2aN2pqKbI8IvEvxlJkwl6i048Y+RedaJUUxqAqt4bCxG2hWUseQQqs2yAJ7mQIbVyyvcTVY+ABI2iKpGY8ustmxldXugt8fxHwfRj47jLkOw90Y0PcO8tJrfOYo2SHS/zRxfJtCIOfCyRLkdWWyXl5q0ToAPTwuaA6QGfFWKPFB/Osq5VB6ap5uKOK7dGwHV6uju13A2m/KASfqIPQ7EWCUS1wCnR20UTJyIqEkJkkjRJZkEUTecg84OFRG95PeJVIzAKZrk3C/Xk6i+av7jBqRKgRIUNrREJR691jl7FBy1rPu6sMGmiYYwiqDdcr1MPmaovqPRpe4GJUEl0oG2QitdoFRbuZaJzMEKE2cn7/nff3BnsrNfORbiG8eXMGQjceTSE9NW9+NDq8b6H4LRQnIDRSSnNcMC86uK83KW6ZkIXBrnAgcI7l6wmTomvgCXKuJOvCTpA6ceZSSaV05deZUV66H5uRxAJeLNs8YIqDKdStozLozt1UZ3aawWG7d4jW1IQiy1SC8OnTLz95lbh/l1LSw2qUCMXxaa6WDyxD0Nta2ysCoSwaxaQHtlGYaupm8tmrcljBlVnDFOXa2JhxbpD8EThdq+vq0byio9YyrpwCr/o9GhJaXajUh3LcJ4rllGq/NTdaS2Rd9Scq/kzRpmjAptio2xRCchAE7IhePfMT8OWguc50hkUmbeqDbTRKnTA5fVMcm4OukxohF687NP0U1BpvwgvMPy+nmBR0+u09h8hQLprEIZSNu5/nEPpEf/7LxM6wyqPW9NruAY/Ds9evm9rH1V4n2ff1zk3/0XWXv0BVGA8cv/QS0bNgnfEtYxoJP+qpCfzvDJlgqcrVp/2Fa9loUE6qbtqvf05G54Ks0Wdl9L74x6ouzUbJJbNbbbKLAS4lUh2OBMUvmVyEzur6WryjlTZUOcRfCKsg5BdAPfdbrG/VJcx25wuKZ+iUyW08PvS5XwTlbh7M5PNEGLL1WIktsg0aV2QTKVBZaKmAjHEEq8ukV1WNCD5oi2BTZmFj9COhIUiYAkLFISukFblEIOH+yxTqgk4QrW4OjVovl8jAiND1sha0SvB1WZRsiocS3uBtQQhIlm2koBR5GzbyN+XVSdnklDdiqd8quKa/PqvxnHh4OurM4+7rnYFPFh06NzwFIfcpxAPxPWIsgHdODzg91VgXhILq8B8TrXhXacdSKbL+zh/KCqN8Gvc55IAPx6temUEDThtJna7MMLuGkPDoAgD+cNqrBTDpvlQtcrej6kB3Fvajt5P2F2P/s+PtGMDmMJ1y8LbF/0f8PXuKQEsOlLMES0an9v+gOUb/ULOo1xMcEdy/I8uZQPdTie0yesvIwZ3XOcpfKlkCfKzTfHkcdFbSPOkSPdcKljLUc+lr7jFUde0RDbZUY7jt616rWIpZtdFVj1/Ac607BvP897GGqO7PzXRW53bDHGv8WN20OC6p5DNSOFql4/Q2xxwC79mTyIrsDALeSxRx1UvNc8a7NhK8LZFeUW9iOMUlVcPPEiv2GJNOHnBgI3VHlQF63dis0CGE3vn24PJqRBz2RBqfCyyQx95ttQCTCr6/a4OjgLOnv7+XCLX/W7+cP+i9/qDQ35y/2JGqI+anXI+josrRk8mfNQ+roygaNMAFc7EybiLt8qyU5SW15b1fo5fKAt73V5jZ3cb7Cq6/H2DSfZUJn9ewQFV/W2lpKQx/w4LnluXZc5IJnJouzflKlGWnz2PawGW4V4PxydIkvuDpjtqt2rBFYULvdk2mJ+T+CwAA///ZtsLU"
+	return "eJzsW1GP2zgOfs+vIOalL61xfejDzMPhDrsF9nBob9FtcQ+HQ1axmUQ3suQV5ZnJ/voDbWnGsSXbcZJuseikD0USfvxEUiRNKW/gHg93cC83QosVgJNO4R3c/LN542YFUCDlVlZOGn0Hf10BALQfQmmKWuEKgPbGunVu9Fbu7mArFPG7FhUKwjvYMTChc1Lv6A7+c0Okbl7Dzd656ua/K4CtRFXQXYP9BrQoMTBakxOOmg8A3KFiNGvqyr/TFewKO1kiOVGG771ICyVFwONXJdz+Dm7+9ixxMwBrmWQWqTKacM1fzUrxNBPbizcLGYJkJaUUskRNS7TEAQK8oazE0thDtrWIa6nXm4PDRYqmoILKypocibK64nXz90qplFyk8xgrK9PaPLU9iioj+TuulSylO0dnHDGLLzo3Oq+tRd1sDY05byFaon4CKbFuiyQL1k7o1g3bc1w9BZ4wgqFMGVFkb8slSiPSfeB3ZwG/SwO/PQ/5bQQ62BAf2HLKmGpdoBKHJXpSWLENYfG3GslR5owTaok2j9ADGOAXknyA0jlaYjAvujoZlK6ah3uqxANascPz1YmHXUydl6hrWczUQWgfZI6ZFzgGOrloohLkZE4obL7PclWTQ5tFyNzj4dHYYgDgRdYikem6JACinUV4+Q7DI4JHhBKdlTllne8+dxkbdGHNsVUmDTTOMQXVhRsU6nFzdUXNA9qixlVMbgmV3NS6W+HCX4uojN4lRfuZaJrMGKEucvXuL9HPA/ZWGeHGEW5vT0Dox6OtVaTmLY9Gxvseit9D8YRQ1KbAC+ZFhvvzJsWtkKq2SFEug8AJUviEed038Aw5LsmmdjOkggRb/2JJhcH+pBnlW/djGEmspuw07UW2EoHQBdhav2Eqo+7czXVmrxkct3uPqKcmNTmhc4QvX/7xY1QJW+NSSgZYQYnUBT4t1fJRlAhm67W9IpDaodVCRWCDwr0ht1Rff1WMlVyZs0JTxRMwURQWic7Uyl092lf0rLWJK1YQVf+AlqTRZyqNoQQNpEVFexO35sYYhUKPqfj3Ht0eLbg9BnWbWqoCJIF4Rm/fixOI5aClzmTDolBuH4MNGpXJhcLZGoPYwElBiON1h3bVF+iON7/F/PPtFJOajp+9lxAZy0WzOKSycf/xPPmI/vIq5c6Kdgs6W+NYAB97vOP166b2abXJlBgz8Bl6l6b/7LrLv0BVmA6cuPQloueCdSa2jHkk4qjHJoh/Z8wEl6pcQ9p/cC2bDMpZ1c3QmYn0BaqQ5KwZfDy+pL74p/YxZzFKpYTbGtud1y8DOJdIeziSFI8ZeMzIXejUWd3Es04MqhniXwirJizOgAowfOJyFbMdnTt1Xy2pvVDb9fTQ5+goaDHK8YnSbJjZ54mjtp5IIx/rcoOWi2yuJGoHHRVQigLBmaaB56SMNoOPxiG4vXCwseaR0BLkQgOhLqCslZOVQiDJ/xUaTU1HiM6EQ6POlxtkEEToCIQDo3N83Tz8uD0eGniLb2pC4MP9jZK0x6ILm0Xt5k/KVnNDa8JSP7dwob9ezYnJQGXm4emkMwPe8Ax8tujYuWH4a0GIH4WKRHxPGAvgPesB1tOOdUFqaA//MTe6oAQ9byk+u1+dkhUm+QT3MXLCh+nMEtg1GTThtBHb8z8uM8LdQUp4cgEAn1k7l/kKhOInVYcFb08f6GzhOHqgz0n7D2P/E/NmBrA5zKecvG3x9Yh/EE8ZGFUAVSLn3PY7Htv/oykw+x+FRb2e4Yjk/p1Yzgy6XxpszugdIyd3XmDks/Jq7o6bJPLJp/nmOOikpPlCKn6t4FKGeil9fvHU1rVHtNhRjem2r3+t4lLM2o2uB/xWMRJHdwyW+e+ThwCOnNMq3AuP3u2GJdb4e3vT4vmuR8NnonC8EOjd5lhC4IN4kmVdnkAgeoli3fZSy5zxvosEPzRIr2gwMZzjkiY+m+PHB1yTye/Rpe3TH1Um6PVjs0WHFPoxFVmoqxFh7Jk0fquxxmId3VYXYNLCD3dtchQQj4yRRPFLM0zw/j9r0HvdSRmjZ6cdcE1Pxq47UmViccp+HJW1sT+b/Dz9HtxHUTZqAP/dE6wQltBGXsZXPoRSzSW1y3vfozcZK+H9eIWZmSCHij+0cMP9MCdJznq8Pq2MDBny64dWS0dh+hm2S3AyTcUsNpNT6NLYV7IpO0MeaRPO79Um7TeTb6w76rZq4xad07tdk2mU3DMhQffrUmhxXHHiLh4h4HehE3QPHi6Mhv22y2IV44SbJpEJ81l5418+YRwNsNlePHfqriOL0uF+aV1XRSqXRT6YIPTZP0CxfkaHFj2MwmKmTW2gQNLWmnvJ7NK2+2nKZkHzJMPCyu3ClnVI68fYyIdvpT0i6mae2HDyYfooCCjfI/+sqJlo2FqH+Z9vwZsUZXngYWutpd5lq3k5avwG4Njtv2fJ2+WS7xZL3p4gOTJNX+zBn9HmqJ3YtTuhG1GPxt7z3HdT0wGMBhT5HiqjlNQ7yA+5wu/OGTqHrXaSg65w0fbEuQhvTuIT7fy+Hdv1k0uWJBe/VXwVejyzERah4kzt9ihtJ5lwJuEMkmYazchTWXkm1+nsHKJiMj3zYmeHzgS1X5518XwUBJDUO+WZ8bKzE0ORZa5jwc+B0uuGKj6Jks+MfhUKLf+u9C5rroK8cXuLtDeq+DVLsoxVuHE7ziQ5s9qxeX0USGqM084ax0tfcwY2UfzGfDSdZ8ey1wDh9nyEd2cj3C5AGNwzzoq6fWC+Rki8D1ogaBnEh9lGIuK7Zy/hWYtUK7feNo/OOr/OzQyqcz7uyarcLSA9O5KGzZhF7sxelutrYMMHC+yNr/q0LTp7WD/nnG+GPv9qAIsm9/WyIid+0fIeX1oL8Y2taJxysvuYUz9PIHzciRRo5QNb1pqyqTT+JxtghfNPvpLS7cBkU3uZDZZqcCe7yBPs0u8mp3LxYJYqC4VfjSMrW0o0V0KWUu++GtkhT9hg84jITOZtjMw3PF+NtNe31Mi8j7D4amwjJh7kUqkUaL6aimDRWYlFtvr/AOOPxM0="
 }
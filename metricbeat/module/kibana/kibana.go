@@ -44,15 +44,17 @@ const (
 	ClusterActionsPath = "api/monitoring_collection/cluster_actions"
 	NodeActionsPath    = "api/monitoring_collection/node_actions"
 	SettingsPath       = "api/settings"
+	TaskManagerPath    = "api/task_manager/_health"
 )
 
 var (
-	v6_4_0 = version.MustNew("6.4.0")
-	v6_5_0 = version.MustNew("6.5.0")
-	v6_7_2 = version.MustNew("6.7.2")
-	v7_0_0 = version.MustNew("7.0.0")
-	v7_0_1 = version.MustNew("7.0.1")
-	v8_2_0 = version.MustNew("8.2.0")
+	v6_4_0  = version.MustNew("6.4.0")
+	v6_5_0  = version.MustNew("6.5.0")
+	v6_7_2  = version.MustNew("6.7.2")
+	v7_0_0  = version.MustNew("7.0.0")
+	v7_0_1  = version.MustNew("7.0.1")
+	v7_11_0 = version.MustNew("7.11.0")
+	v8_2_0  = version.MustNew("8.2.0")
 
 	// StatsAPIAvailableVersion is the version of Kibana since when the stats API is available
 	StatsAPIAvailableVersion = v6_4_0
@@ -63,6 +65,9 @@ var (
 	// Version of Kibana since when the rules and task manager APIs are available
 	RulesAPIAvailableVersion   = v8_2_0
 	ActionsAPIAvailableVersion = v8_2_0
+
+	// TaskManagerAPIAvailableVersion is the version of Kibana since when the task manager health API is available
+	TaskManagerAPIAvailableVersion = v7_11_0
 )
 
 var (
@@ -136,6 +141,11 @@ func IsActionsAPIAvailable(currentKibanaVersion *version.V) bool {
 	return elastic.IsFeatureAvailable(currentKibanaVersion, ActionsAPIAvailableVersion)
 }
 
+// IsTaskManagerAPIAvailable returns whether the task manager health API is available in the given version of Kibana
+func IsTaskManagerAPIAvailable(currentKibanaVersion *version.V) bool {
+	return elastic.IsFeatureAvailable(currentKibanaVersion, TaskManagerAPIAvailableVersion)
+}
+
 // IsUsageExcludable returns whether the stats API supports the exclude_usage parameter in the
 // given version of Kibana
 func IsUsageExcludable(currentKibanaVersion *version.V) bool {
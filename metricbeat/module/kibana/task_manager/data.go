@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package task_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var (
+	percentilesSchema = s.Schema{
+		"p50": c.Float("p50", s.Optional),
+		"p90": c.Float("p90", s.Optional),
+		"p95": c.Float("p95", s.Optional),
+		"p99": c.Float("p99", s.Optional),
+	}
+
+	workloadSchema = s.Schema{
+		"count":   c.Int("count", s.Optional),
+		"overdue": c.Int("overdue", s.Optional),
+	}
+
+	resultFrequencySchema = s.Schema{
+		"success": s.Object{
+			"pct": c.Float("Success", s.Optional),
+		},
+		"retry_scheduled": s.Object{
+			"pct": c.Float("RetryScheduled", s.Optional),
+		},
+		"failed": s.Object{
+			"pct": c.Float("Failed", s.Optional),
+		},
+		"status": c.Str("status", s.Optional),
+	}
+
+	taskTypeWorkloadSchema = s.Schema{
+		"count": c.Int("count", s.Optional),
+		"status": c.Dict("status", s.Schema{
+			"idle":     c.Int("idle", s.Optional),
+			"claiming": c.Int("claiming", s.Optional),
+			"running":  c.Int("running", s.Optional),
+			"failed":   c.Int("failed", s.Optional),
+		}, c.DictOptional),
+	}
+)
+
+type section struct {
+	Status string                 `json:"status"`
+	Value  map[string]interface{} `json:"value"`
+}
+
+type response struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	LastUpdate string `json:"last_update"`
+	Stats      struct {
+		Workload section `json:"workload"`
+		Runtime  struct {
+			Status string `json:"status"`
+			Value  struct {
+				Drift       map[string]interface{}            `json:"drift"`
+				DriftByType map[string]map[string]interface{} `json:"drift_by_type"`
+				Load        map[string]interface{}            `json:"load"`
+				Execution   struct {
+					Duration        map[string]map[string]interface{} `json:"duration"`
+					ResultFrequency map[string]map[string]interface{} `json:"result_frequency_percent_as_number"`
+				} `json:"execution"`
+			} `json:"value"`
+		} `json:"runtime"`
+	} `json:"stats"`
+}
+
+func eventsMapping(r mb.ReporterV2, content []byte) error {
+	var data response
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("failure parsing Kibana Task Manager Health API response: %w", err)
+	}
+
+	rootFields := mapstr.M{}
+	if data.ID != "" {
+		rootFields.Put("service.id", data.ID)
+	}
+
+	fields := mapstr.M{
+		"status": data.Status,
+	}
+	if data.LastUpdate != "" {
+		fields.Put("last_update", data.LastUpdate)
+	}
+	if data.Stats.Runtime.Value.Drift != nil {
+		fields.Put("drift", applyPercentiles(data.Stats.Runtime.Value.Drift))
+	}
+	if data.Stats.Runtime.Value.Load != nil {
+		fields.Put("load", applyPercentiles(data.Stats.Runtime.Value.Load))
+	}
+	if data.Stats.Workload.Value != nil {
+		workload, _ := workloadSchema.Apply(data.Stats.Workload.Value)
+		workload.Put("status", data.Stats.Workload.Status)
+		fields.Put("workload", workload)
+	}
+	if data.Stats.Runtime.Status != "" {
+		fields.Put("runtime.status", data.Stats.Runtime.Status)
+	}
+
+	r.Event(mb.Event{
+		RootFields:      rootFields,
+		MetricSetFields: fields,
+	})
+
+	taskTypes := data.taskTypes()
+	for _, taskType := range taskTypes.names() {
+		task := mapstr.M{
+			"type": taskType,
+		}
+
+		if drift, ok := data.Stats.Runtime.Value.DriftByType[taskType]; ok {
+			task.Put("drift", applyPercentiles(drift))
+		}
+		if duration, ok := data.Stats.Runtime.Value.Execution.Duration[taskType]; ok {
+			task.Put("execution.duration", applyPercentiles(duration))
+		}
+		if frequency, ok := data.Stats.Runtime.Value.Execution.ResultFrequency[taskType]; ok {
+			result, _ := resultFrequencySchema.Apply(frequency)
+			task.Put("execution.result_frequency", result)
+		}
+		if workload, ok := taskTypes[taskType]; ok && workload != nil {
+			fields, _ := taskTypeWorkloadSchema.Apply(workload)
+			task.Put("workload", fields)
+		}
+
+		r.Event(mb.Event{
+			RootFields: rootFields.Clone(),
+			MetricSetFields: mapstr.M{
+				"task": task,
+			},
+		})
+	}
+
+	return nil
+}
+
+func applyPercentiles(data map[string]interface{}) mapstr.M {
+	fields, _ := percentilesSchema.Apply(data)
+	return fields
+}
+
+// taskTypeSet holds the workload of every task type seen in the health
+// report, keyed by task type. Types that only appear in the runtime
+// statistics have a nil workload.
+type taskTypeSet map[string]map[string]interface{}
+
+func (t taskTypeSet) names() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (data *response) taskTypes() taskTypeSet {
+	types := taskTypeSet{}
+
+	if workloadTypes, ok := data.Stats.Workload.Value["task_types"].(map[string]interface{}); ok {
+		for name, workload := range workloadTypes {
+			workload, _ := workload.(map[string]interface{})
+			types[name] = workload
+		}
+	}
+
+	runtime := data.Stats.Runtime.Value
+	for _, stats := range []map[string]map[string]interface{}{
+		runtime.DriftByType,
+		runtime.Execution.Duration,
+		runtime.Execution.ResultFrequency,
+	} {
+		for name := range stats {
+			if _, found := types[name]; !found {
+				types[name] = nil
+			}
+		}
+	}
+
+	return types
+}
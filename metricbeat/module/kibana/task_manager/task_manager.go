@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package task_manager
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/kibana"
+)
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet(kibana.ModuleName, "task_manager", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: "http",
+		DefaultPath:   kibana.TaskManagerPath,
+	}.Build()
+)
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*kibana.MetricSet
+	healthHTTP *helper.HTTP
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := kibana.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	healthHTTP, err := helper.NewHTTP(ms.BaseMetricSet)
+	if err != nil {
+		return nil, err
+	}
+
+	kibanaVersion, err := kibana.GetVersion(healthHTTP, kibana.TaskManagerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !kibana.IsTaskManagerAPIAvailable(kibanaVersion) {
+		const errorMsg = "the %v metricset is only supported with Kibana >= %v. You are currently running Kibana %v"
+		return nil, fmt.Errorf(errorMsg, ms.FullyQualifiedName(), kibana.TaskManagerAPIAvailableVersion, kibanaVersion)
+	}
+
+	return &MetricSet{
+		MetricSet:  ms,
+		healthHTTP: healthHTTP,
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right format
+// It returns the event which is then forward to the output. In case of an error, a
+// descriptive error must be returned.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	content, err := m.healthHTTP.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error trying to get task manager health data from Kibana: %w", err)
+	}
+
+	return eventsMapping(r, content)
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package task_manager
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/kibana"
+	"github.com/elastic/beats/v7/metricbeat/module/kibana/mtest"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const fixture = "./_meta/test/task_manager.8100.json"
+
+func TestEventsMapping(t *testing.T) {
+	content, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content)
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 4)
+
+	summary := events[0].MetricSetFields
+	require.Equal(t, "OK", summary["status"])
+	value, _ := summary.GetValue("drift.p99")
+	require.Equal(t, float64(6083), value)
+	value, _ = summary.GetValue("load.p50")
+	require.Equal(t, float64(10), value)
+	value, _ = summary.GetValue("workload.overdue")
+	require.Equal(t, int64(1), value)
+	value, _ = events[0].RootFields.GetValue("service.id")
+	require.Equal(t, "5b2de169-2785-441b-ae8c-186a1936b17d", value)
+
+	// Task types are reported in sorted order.
+	email := events[1].MetricSetFields
+	value, _ = email.GetValue("task.type")
+	require.Equal(t, "actions:.email", value)
+	value, _ = email.GetValue("task.execution.result_frequency.failed.pct")
+	require.Equal(t, float64(50), value)
+	value, _ = email.GetValue("task.workload.status.failed")
+	require.Equal(t, int64(2), value)
+	value, _ = email.GetValue("task.drift.p50")
+	require.Equal(t, float64(512), value)
+
+	threshold := events[2].MetricSetFields
+	value, _ = threshold.GetValue("task.execution.duration.p90")
+	require.Equal(t, 123.5, value)
+
+	// Task types with no runtime stats yet still report their workload.
+	telemetry := events[3].MetricSetFields
+	value, _ = telemetry.GetValue("task.type")
+	require.Equal(t, "apm-telemetry-task", value)
+	value, _ = telemetry.GetValue("task.workload.status.idle")
+	require.Equal(t, int64(1), value)
+	_, err = telemetry.GetValue("task.execution")
+	require.Error(t, err)
+}
+
+func TestEventsMappingInvalid(t *testing.T) {
+	reporter := &mbtest.CapturingReporterV2{}
+	err := eventsMapping(reporter, []byte("{"))
+	require.Error(t, err)
+	require.Empty(t, reporter.GetEvents())
+}
+
+func TestFetchUnsupportedVersion(t *testing.T) {
+	kib := newServer(t, "7.10.2")
+	defer kib.Close()
+
+	c, err := conf.NewConfigFrom(mtest.GetConfig("task_manager", kib.URL))
+	require.NoError(t, err)
+
+	_, _, err = mb.NewModule(c, mb.Registry)
+	require.ErrorContains(t, err, "only supported with Kibana >= 7.11.0")
+}
+
+func TestData(t *testing.T) {
+	kib := newServer(t, "8.10.0")
+	defer kib.Close()
+
+	ms := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("task_manager", kib.URL))
+	err := mbtest.WriteEventsReporterV2ErrorCond(ms, t, "", func(e mapstr.M) bool {
+		_, err := e.GetValue("kibana.task_manager.task.execution.duration")
+		return err == nil
+	})
+	require.NoError(t, err)
+}
+
+func newServer(t *testing.T, version string) *httptest.Server {
+	content, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/status":
+			w.Write([]byte(`{"version": {"number": "` + version + `"}}`))
+		case "/" + kibana.TaskManagerPath:
+			w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
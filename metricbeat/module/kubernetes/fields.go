@@ -32,5 +32,5 @@ func init() {
 // AssetKubernetes returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/kubernetes.
 func AssetKubernetes() string {
-	return "eJzsXU9z47aSv8+nQPmyzpbj2vPU1qtKPC8bb5KJ155JDltbGohsSYhJgAFAe/Q+/Rb+kIRIgKREUNbY0iGVsa3uXzcaQHej0fgePcL2PXosl8ApSBDvEJJEZvAeXfxS//DiHUIpiISTQhJG36N/vEMIoeYPUA6Sk0R9m0MGWMB7tMbvEBIgJaFr8R7974UQ2cUVuthIWVz8n/rdhnG5SBhdkfV7tMKZgHcIrQhkqXivGXyPKM6hBU995LZQHDgrC/sTDzz1uaUrxnOsfowwTZGQWBIhSSIQW6GCpQLlmOI1pGi5dfhcWwoVmppgBQkXRAB/Al7/xoeqB1lLgT/c3SJD0NFl9dnVafVxNdWGx+HvEoS85iBYyRPY+aMK6SNsnxlPW7/rwas+94YypMhLuw1AlMs5MYTId2AkrIgPAGmy6DLJSiGBX2mmosAJXNXa+a4X1xPwZTxYP3/6dIc6JNs8E5ZGVIXm2SHZ5UklULlQjOIPg8WgWaAOizaWlG8XvKTxYPwJcgMcyQ1UPFApQKCUb1GbURvMI6FtbhOQ/EJoqhY2S31gSPKCUaAyHvubiiTaYJpmhK5dpfSiaa+aE5Go5VSTRCtWjcyIZeIJuCAsomlYgjWKrphtCFpzO9vKRAjVJPERbjPPQW5YRHvUE9NDtCM0ExHNsJa4TbViW3CWgBBejj5D9O20Lr2kKK8FJJ3fVzRTVi6z9rrXEeTm7jMSkDCatpE1nHLIGd+qbZ2kQOX1cts4RV2+GaNrzy+NS/Qehb68g+pH9UeIUFTxtBiGID4RLkucHROhZTkEcJWKa1YAvU5Y2Vn9BqHtsP5Y5kvgasVVBNGKZFD/AePhYRQScwlpBKN5MAaDBKEJ6CXGGnfFwzsBnrFMNtHMH56ASnEtyL/ADPf1skweQV7/e1A4tvwLEp/uzS8W44fgTyWKgYAUApQSITlZltrnJzRgQ2HsosxnNdeHMlcG89zgFhq4OARsTBN2EQ1B8LgtaGDR7nDuLtxIL95mn0aP1pdRNu1AC+wjomBU+F3LQ0z6ZWw5qBEtXI95a/8CcLIxwl5Vfof+n2UTjFy5AdOVDV9UXFw7g9djVHKkKVKN6uj5MdPEaHBIVrkswmuHSUaUDhsXuwsiCCDI3PBDWFjyfZ5UPJcmqv48KnOZpSXXKZrr8iDb2mFbOX8VTWXyuTKdnCScDflXLpLpKuhioa7DYH44DswRV6F6vcmwBJpsd5acK7QhQrI1xzkymML4k5JzNR2mK/KWrjKy3shhU1LUeEkpoevIa0A1DRNJnkB/G1lG/WsCyCS9NoMQZUFokpR2aAXCUnPxssdlSuS13jqjsNf0fF7CLkMOChqkEXlWJNvMmyWLSkzotJSso92aXpSMrHbHF5LkficlxbL9iwHX4EERRB2CTkw6ejMYSuvcfUalwGvwKCIktgtFfzc4D32A+qjuCMm4j/Aw8SEGLhPPCt1mE4zVqs8IN6/63NRmp/R+wzhY5VNMg/vXDl5MmVJMCPYIyCPhGsOAdIBlDYylcF14N6kGl0hwBulilTEc+sPKlyyAJ9385UEyKP1igXBFU/3bxh6SSZxp7AhnGUuwxMsM1Pd6hc1ITuS3J20KK0IhNfDrtGWzFF6qnwQ1gsgKlVR/F9LvrtHtqvV19Tf61wJhDignQqgNVIUg6g+/KKJf9D+/CIklLMwP7LoD9mtLJjfKK1FsU8QokhssNaArJDekOo5EzyTL0LJhA1QSDtn22rtiZmw9PiU4oO9f2VrFKyu251KJnzDJsH9iTl8uQ8EXGrcqDMVwaLwdav3UwqIEFzghcjsc4lV/+Rb0Y+bZeN2opfgt6EVvOePVQtTCEE5BT/M//BEGirrNftJ20MyWoEBOjptDv2MUD5diNQZSwDrngKQNxANp94giWq7krSzabTscOGeZz+0/NZUYRQQFPnEP+DcH/Z5OcMAC0Mn7wWNknuAKW4MY8oYtilNziN3h451jcvTKpvD9w0P/BK4PTRl/JHQtIJxTfB0a+dMIigTI8Uvbac7zkChHmvNBWyrwGla4zDyZ7P3O//2iN6lTxQgFONXuEP6L8aMh0tyCuOp1hzG5ilih8zbCxnvGpK5CEVshId87gnwrnqxfT26EdQ61/TqyodXLhdxHCSM/ewJI95iJsywDbm4OTDpuuqmJ2XsIcQ6bjllWfsxy8mPXp8atS9XcgkWp6r/xeH3EOYyrff4XoxH53tIVx0LyMpElhy7x0y7BrdNH3NwWMivfzd1nfc6KRAFUqtXvXKY7AeG3U6arIOT4awQEv9bxxIsVCtflAlWsUxcK69LhkpKvCAqWbEIGvlvkFm3m9hXL7afkZpj1SluX7EjmXK+7QkvOHoGilD0rN0ZfCSyF3nOu7F6gJ79n7ffcJYpYuVZXPVrYVflVq3atI4DamQ9DPEOBVF205hS4DQzA4fiPWhTXGpV2HW6nLG6inEcst61l0wXGtlxSfzOeDHPU4sVD9xKG5K3mjmdFpoZzviGwJdER8c1v5m45eRw770rxAoW6M9iSm/P+u4Qy3s0KJRioAMHU6E53bH5mzyp23lY+C9pgof0by6mu0rX+DuNoCUCrH3eUUovsi8icPARdEUrEJopz1pGBrTQOLUvKKJgDHaL954KzNVeOmx45LOi/SSNRwqjy/bnJ0PSq4FCpcZrGWEj+rLnhNDVVFIciSqGQm6iQbNm6oXwoLA6SkyiLbgOMOsuvpr4PuJ0j6IRlGSSS+a8ZH3YHkCTaLYu5y+gzlopyV1pP5sKdnxvAmdxsoyKqqWpse0KKrZo92RvkgSO68Rjudg7N9lRHvQsAToFfE7HIsZCB6+5LxjLA7dv4Q80YNk03hqSbXSVUSKziXSIsiJrCuzbI9n3DvbO7nzbg9nixlz3rs3tkp2H9G73IYw5oDVQFT6YrTXXLwy7iOxyIXvHVQPzS7pGD9sgchy00YBe9g3CjqBguiEPCeGo25Gb9kiQH87MCc0mSMsPc3uFVOx5L9BqcehDqb0qcFx6U3XWrL0e+IlzIhWVFA+1p9r+W8akCqOTUPFDDQ/0sfIs1w7MDUiwG8DQJQtE59DcYJHyV463hN0PHWgKkTS8M8gTUo46EFduFZD4Eze6KRatNRzhn3YvuXlMaC662wnaPmQO5f9oWdTqun6MngR8y+n6Oelms2rRwKBiXpk8LEZ6x6JtAszaQWXGWo+cNSTZaOWZtIKJZGb2Q4p7XfFTuhyKMGB2LxTmpwimWePqI/WYpISwES4jeFZ6J3PTOob5x8y+h+zt/tR1w6AwI6luwRhzD7ixamoFOKfbNlAZQNS6LuEdq/2XJWpNYNcbgd7Tjn+eN4qn7j8VlrEmqmNJMAjMBnvHQbKyOHRfR2yr9YdsquQrpP+UsScRj48+U/F0C0udsZEWUt8kcIJ4MT72MQ7ZaZIQ+RgRz/6taxzkIhca23AptI4Q+sewJ0oUH41yrU8XTp5e+dQoXJL7l/HB3WzflstbTM1xxu7Mp3m5Xkx7GcRcPd8HqYTrffK0o76H6uBP28+2HAd5ufmRKzOdcINfpi/Pd8fPd8cAn/t1x7bF+69fGz7e0/H9zvqXV+cS7pXW+79KBfL7vEoJ+vr0xcHuDglTWE23t5l9fuQneQwLkSef7dWMnWt1GUjpGhErgK5yAvkPY+SkiyteU1eWlq/qan0nvqY2WSeXvcEgkesJZCejLf3zpVQ1w7qtLHK2bsXJ/tZxeSOQ6J/baDewTx1TkRMq3Z2OfXtDG6sOm81W26jNy1H4632LbW0XnC2zup6Oet3F3zSlWCbRBacM6ThebBtep9K9pEIV62NT+Z0mD2bdD1nCSK399pp5E4f1hmMEQEzRypqPx6a0xMx7tlwa7zXV8sv8OgkbuIuhtK3LEPoP2WfbepBL9u1GdYti5kTrlFKJg6Td5CHHOIVSfg3IIpxB51dH9Oe6WpzQun7zj8qZO/07mtKsD7BRbxO3TJPlNNUZWm2vdHUq020PZjsiMAmIc5YyD+8fVNWyW6prvVqe44S8R0XznNDooRz6xPR9NdoCf5OpwbiAZb8kY1UXyW1ob0BtMzO5M6rDQrYP2xes/aTeKee6ct3+DC0CczpLTFoNwrPvqq1iMJdXNjtQo6EvwA9VOBV7DYsZCCQNrdNnG4jh4wkUbTjuur9spCSfnmqOmFfMx+3OHub24BZs5HLMr4Lm/G4ravOTc362H9bm/27m/27m/27m/W/M593c793c793ebMgTn/m5vob+b2NK243HwYd9juQQT+9kIcEuTA8/9eJmBiLv5bmlyp2DdK9L1Kr/P2+BdUDFnXADgiGfCu7iOaKMB2IO2GpTGln3oJnc4zwldz+GEWS7IYRPyxw5FGrXbVw/cERYygPSI5vKxR5DxNlOvXskG0jKb9gSDk7mq6X1zjzAcM430mtNx4V6nB7F7MAalrLxLuS766bRzicUz3Cim4GxFOkmFOFx9tB2PqswiCvuDlJAX0tJVgWg1h1sVlLP25Rn1zsZxWh42S1hPp0N0TtR6sZ0TtT6A50TtOVG7n5LPidpzonZXhHOi9pyoHYPunKg9J2rPiVqPhOeHOEJynB/iCEh8fogjP+WHOESdMYk2pQugqZrLBYtiHM0uU42EZYAUg47Mys6NzLLdHn0HJAfINYdr/bJHftwEdNNbq8aBLI4JpxYeoaZGIXddfD6SPQhiWkBXW2OQYJOeiwHFVtY2MCra1RKbZKWQwJFgaIXb2TVn4awgvdSBWZMotVAmRDI21ao3IG9qdUDuSJGyCMs0FDNPlSCmjXfF2Atvcz5i6/nfteEcdoG/phflVOrgtsodPd7UuIg/024SOvF2tw0W4ZtDfgHaQvTdAK3F0YzQZe2XPmMi9f9I4DmhuP/qBeA03JvLn98fibJBqJn49bsTFEnMey4WECph3TmIOACM4RPoI+Qopv1miwtm0vh90ocv+iWX6t0na5mi8l0yvRbZoUSXNfwb/biEGt0bjsXmV8aKH3HyyFarK/RPznXzjrsyy668jOtf2+98hxh3zETxyYsMJKRXjcZuMKVM3pdUc1AxwO+///YLyTJIv9ODCuErdPqNoIbBYm6t6geDvKp9xmbrbYTV0MeJrNTUCO2/TbxPH4LBYFffIQvdvjd0Q1fH9poMN3efdWdxYViOSLYfBZJlByk6Wr9to/L5j7OGxLcX58y9u8Hub9W4vDzuZsiqq3+hXkEJZ/QvtozlbhhqUZyNKWftNxbHUAA9mYGXjuPM2cfS/DNjDJ+GBCpYRlqUavc2keQJvA5t0OgCjqwhpaP0ujahaySOTygWohQquO806hpdlrDz7qLVLFEhq49uY7n66ShP4q4nabfD9p/6oLTK1pkTVBeC2qV8D1Tt7KeVBUTDoZSud84KBi+pf4LA15nYK8qD7FPAaUZomPOQzX2wBGrWeKVj8KoIRyGpsqHK71phkjkjMeZ/+v/Z/Z9aNgw5o7t36qcUB37Q9B70de8jr4zN5lRkJMHjQ6qBDccrnWVyYOX2cIuHMTF52B9tUq9NE8JKLagA3ggShJiCILyn++00gJb6To54L3j9AWQs7ZkIck9oJT3e8Dq8hmA6JxAZ2+YTH+11XKGGYJQ5X2BPJ8wJdX+/eJEaLr7kwBGWEQdHPWiErtieq8jQFJ2UufjQYGzMrZq2NepLUUASTvMMT4RYGLvTYNL8jAXLNz/DwIrU+0RodFCGTxdQBWRMmOSf2OOcBH/gNFeI5HnsebP7PKl2/vqqklWMEGsduGs6zdZhRzvkGOFB6OBlhv3FGx8FYbQ81TlgGBb9MESZJADduCwuEs1FiFWZddFUSPZqgD5+x1AWWr9IvK+3GXp6uA6cfI8Pj3ZGdI0uls7Tz30R5Q6uKgV6VGR+ri1MnotisYax4YAESEnoet/xnNc1TxhdkXXJdRa0hqoTLu56hS4fOlt/48JxnGWQEdE+OI2lRIfDyWvRxepsOD36Y880cPNluuY0be1t8lyfD++pNm8ZUbSDFHdXrp/D1cVk7JkK01ixu1k26DxPEUdEVz1L3IMMXcL6Gl3ccEb/my0vwq4xEYuEUclZlnldugiQf3+uDvdqRujyQvISLq7QxQpnQv0P4+jiPymj8I8LvzXueUC9nzna6wyH22O1nM+jQjdfu7N5BBRZ0kfKnmnPuA94TFHRWtdpLNR6ip/m484xqyT600eTBkG/LuyWHqBLpf4rpJWvVG81H7aRktrUcG+MOqlKQqPc4TNUKGHO1xYFByFK76PQsZRnugbfWUYHazEl4vEYcD8Q8TgZLCvlgq0WCvOMUH8v5e8rhfdgnAVJj6HTu9sPB6l0jqoIp7/sfIUIyr1wG9k2FRIqEjfrqPY2MAcn2aUccn2AGayTdnRiH3YaEGJCpFx186+fkDres+XOGL1UUUJ7CG1BQjN+VWlCfTti4ji+lKAfzaaupet/LczJn80y1LoL9FyW7I5lb/apHpBZ4egm2O1X65o7DVwQIYHKJ5aVeSz3rSGLDN0mc8lZrv/ye13P9v1L18H8YeApEoE6ub5ZM+4Y3/LwPh3YV367rxCmshYnCeP6HolkzpgEvGPG8RoWSYYDTSpGcH8wRJAmUqd6OvaExtQZhOwyyTDJZzPOJMMnbKJ3f9z02KcRYTGFwY+EppBWygizsjVzC2s1E2bEfVMwWU2v+LNC6U0T8NPGOim/yCf0W/pBk0CKhJ/HjPPr7o+bdh1zdxad3DOHGybkgrS3bntMeXhGSMFTpNHtXYwswH6MbZxxWEXPjNcuWjDtvYv76t7Fnb3OeH196HWLmOimJT6qiriZ8mPtAa+4+fBeddG2K0OmFc05s7IqJhORyuZmLF1xoYZraE6pAm6nLOre/OPlCt8Ox/ViFW8jsLGlbpYzl9LWQKFq02M5VVepG3Co5zilqWNewjz1CrtaXJVZtq24DWrTuYqmj5X+LpnE0ZYWh2acd2Zmq0a/t1j/R2Mdqklva2kfBIaDOXSCFF1uME/1BiUg/W5co8IpjvquoMGrG54GAaNZuBKamaO+eoW+KFG/KFm/KGG/BPYPj+AHyGeOc03HAwUHF0VGQCDJuhFj/z/DEaZaDkgSK+Fhqb34tZ4Hi6Mnn2Eu8oec8BE8bqkETnGGbu9qk7fy+1nCV/OFSUFqJVlFDH34+BCeAjXLw8XsMAzEFhnD6WKJM0yTSWr9leEU/Wjp1AYVYDplileCdWjUJQZUN96ZZCKmdU8AfcVAhWxTbKJi87OPTn8VW7BOzKsqTUMthjtfcKNLWJVZPMe+ohjNs+9TwlCyxl8HW6ukLi9El7qvptkHH6wEbe/vCKHGjvJqH+qgaGNm/7Rx/Gr3dMfnCykRvUDY0SnhHwvwxeKPIRNsooO5jdCJQw4vWJrZFmsLdMCehg1WljcCWCvv2k67TluS3Szsi/t5O2jC3l7B2RMRhIXqQvc4XGooNV6fiyJ0ZqCPbhaeq9l7BQaair3grfmnW4pzkmAVMNvdzZ5g+I+67DnJkuis56S0/28sNSfvKegHXxvd6OZGNEWWS3x/ZGfYB7wS3dU9lvWbFvHOY8pxnp7t9nLaayS6i0bT4SHoEOp+OovghYuhMfC8zlD3HPdLuUdN0ZB0d5/tO9iHnTbo7waX6SlvVyfMW9c1THyIgcvE8whPm03vC9lofL8qZLV9wzhYlVNMg68W7aDElIVKpUYCHdtUS5sDpAGWM9VMzWdEp/YAuvvyfNi/ffWvwd8/PIxTxbN5tV94XMXXpZE/jaA2PB+hmQKvYcaH6J1OomMfxz8aIs3Ni2vXNYvkqezWm72Yg97VilNp5uW2ivhKkK/SbIhoH2F0krPwJ5KBdUx1BeVAUSna6xj5laqoKR0e1JGnnQZ67erR3lRQM64QhbdhcSOCSHAG6SJU2u8KUgBPuk8N7SnKnSGillm2QrbAQkeLwQEmlKU9heBThjhgOyiq+/vZDFZACGdl5dAfm8QA8xMHGAMm1FI5Lhrjv46A8w2YsZXi/wMAAP//+HZPcg=="
+	return "eJzsXVGT2ziOfvevYPXLJVc9rntOXW3VbDK7m9tMpivJ7DxcXXloCba5LZEakuqO59dfgSIlWSIl2abcnbRvUrdJuw18AEEQBEHwB3IP+zfkvlyD5KBBLQjRTGfwhtz8s/7hzYKQFFQiWaGZ4G/IXxaEENL8AslBS5bgtyVkQBW8IVu6IESB1oxv1RvyvzdKZTe35GandXHzf/jZTki9SgTfsO0bsqGZggUhGwZZqt4YBj8QTnPowMMP9L5ADlKUhf2JBx7+ec83QuYUURPKU6I01UxpligiNqQQqSI55XQLKVnvW3yWloJDUxN0kGjBFMgHkPUnPlQDyDoK/PHuPakItnTp/jvUKSGH2Ny/2/Ak/FGC0ksJSpQygYNfckjvYf8oZNr5bAAv/vlUUYaUeGl3AahyPSeGEPkejEQU8QEQQ5a8SrJSaZC3hqkqaAK3tXZeD+J6ALmOB+sfX77ckR7JLs9EpBFVYXj2SPZ5cg1cr5BRPN5uGCwGw4L0WHSxpHK/kiWPB+M30DuQRO/A8SClAkVSuSddRl0w94yn8ZD8k/EUHZulPsg5EXkhOHAdj/1bR5LsKE8zxrdtpQyi6XrNM5GgOzUkyUa4kZngJh5AKiYimoYlWKPoi9mFYDQHMh4EN0l8hLvMc9A7kcbjbSamh2hPaKF0PK61xF2qjm0hRQJKeTn6DNG30rbpJUW5VJD0Pnc0U1Gus0PL8wjy9u5XoiARPFVBTjnkQu5xWWcpcL1c75ugqP1/Fd9M8K3nwyokekNCXz5A9Vf8JcI4cTwthjGID0zqkmaXRGhZjgHcpGopCuDLRJRcHwvtgPXHMl+DRI+LBMmGZVD/gpAqCEFpKjWkEYzmc2UwRDGegHEx1rgdj4WP/yPVyS6a+cMDcK2Wiv0J1XAv12VyD3r5n0HhxPrfkPh0X32wmj4Ev6EoFQSCCEjKlJZsXaJ3QKvw21AYuyrzY23iKHP9XOZoMI8NbmWAq1PAxjThNqIxCJ6wZcxpT3Dc+OeTXacJskBYaNMtaAsfKgmqEFxBNJN+GlsOasQIN2DeJr4AmuwqYW9d3GH+sm42I7ftDdOt3b7gvrgOBpeLLgKPSi40RdyoTp4fM02MBocWLmRRCx+AJGOoQxfW+EAEAQSZG1qKUGXJeznbX4s2AeLqz6OyNrO0lCZFsyxPsq0Dti74czTR5HOcKDlLpBiLr9pIzldBHwuvlSIKqH44DcwFvVDtbzKqgSf7A5dzS3ZMabGVNCcVpjD+pJQSp8P5inzPNxnb7vS4KSE1WXLO+HY5hw0Tmmj2AObbxDLyo3KIQCfpshoEL6IgmgCSJklph1YRqg0XL3tapkwvzdIZhb2h54sSDhlKQIEhjcjTkewyd4wxx0QZP9ji+h3gwKLb0m5NL0pG1oTjK81yf5CSUg1DyuiHBp+RIOkRrLVRlJMXgxFOuDEtFd2CRxFT1hLz3d6nQ4CGqB4IKWRXa9OIjzFoM+Eq+Cuje7WJGm7/97Y2O9T7WyHBKp9THly/DvBSLhIhQQ1qZhDyRLgIsFSQjrCsgYkUlkWiB3GphGaQrjaZoKFfdLFkATLpxkMnyoDGTRWhjib+2+49tNA0I1ykQGiWiYRqus4AvzcobMZypr89aVPYMA5pBb9OWzau8JWQAxohbENKbr4L6esleb/pfB1/x3ysCJVAcqYUZolxC4K/+Duq+XdzUvc7HtXBqvqB9Ttgv7YWeodRCQ5ESgQneke1AXRL9I6540jyyLKMrBs2wDWTkO2XC++Iia1aTHUiI/r+ILa4X9mIxTSf4zDQB8oyFGsRspiQRxvyZo56aPM10Ss4SxwiM9EOjX5qYUlCC5owvR/f4rnffAn6qTzPdN2gK34JekE5j1ALQ8egZlGML7g9QjEThf5i7KCZLUGBGmAbCXAhXMhqCqSAdc4BCVn5IDko3gOCsDWELMGRq0dmERLtHBsbmjWXnXxdOxw5Z5kv7H9uKqkUEdznNMCfZQT8cwv9kUFwwAKefxw8ReYzQmFrEGPRsEXx3ALi9vDJ3jH59zaFP33+PDyBHeRHIe+xlhH0d66R3ypBsXhzumt7nvM8JMqF5vwipK2CbmFDy6yTQ5002hNEb1KnyIgEODkwOf23kBdDZLgFcTlMUgi9UYupkyw0wRw5t1sIyvZ9zNxPQmhThaL2SkNuTXX6VumlRLJ+PTWR7Qvfaputtl9Hdms1op7gdvB8BV1kG/mrZwPpAOBiIEWWgaxuDpx13PS2JmbvIcQp/79kWfkly8kvXZ8aty7VcOuRdLzw/8fj9ZHmUMcvruLHy/dPwSPyfc83kioty0SXEvrEn3cJbp0+kmYHZj0fHlrgOStRBVYZMn4t030ZZbpYKZzTrxEQfKj3E09WKFyXC7i9Tl0obEqHS86+EihEsgsZuMPkqUI7Z+ba4pmow2w8ravKIVq0rtfdkrUU98BJKh4xysM6aV0qs+bc2rXATH6P7++Djlm5Vlc9Wtiu/KpTu9YTABf70xDHVLns1Lq1CtxGBuB0/BctiuuMSrsoDmdMryzuTDkvWG5by4Y8Xbmk+WY8GWYwtYjonsKQvNXc8ayoquFczl0SHRHf/Gbuyrjj2Xlfioua0oFAMW3JSYg57z9KKCHaco8jCbhBqGp0zw9s/iEece+8dzEL2VFldj2WU12la+MdIckagLsf95RSi+zbkTVylHzDOFM7SOeQAS8ICXlvZEkFh+pAh2FNESmk2EoM3Mwso4r/h64kSgTH2F+a3MWwCk6VmqZpDEfyW82Npnj5pOT6VEQpFHoXFZItW68onwpL4oEbqKjAmssDlvox4BwwzMwtE8xfJVrIhQ/VKdMaHliCYxt1lUGsNeW++/JkLhpAJd8BzfRuHxVRTdVUGx4JKbZqjmRfIQ8c0U3HcHdwaHakOhyWDGgKcsnUKqfYOqPDtEKzFiIDyhcDYPoJqN92TTeGpJ9dZVxpivtdpiyImsKiC7J739A/OwbwfNlBu8eLvexZn90TOw3rT4yTx/P8LXDcPFVdadwtD+vEDzgw4/HRIBo+J2SOwxYasIvBQXiLdl5xIRISIdNqQW78F2bTqp8VVGqWlBmV9g4vrngiMT449SA039Q0LxZT/JbPazlKGyaVXllWvJMcPf1axhcHEOU0PJw4aHViM3CLNaOzA8roKB6HJgfVO/SvMGj4qheTEfxc0bGWAGmdld6yB+AedSSi2K+08CFw2CRQ1WnTEc5ZD6L7ZChNBef493rMnMj9y76oSw+GOXoS+CGjH+a4g+ayL5FQCIkNuqqy+S7z4QlUkzn4dEgXRzSQ2UiRk8cdS3ZGOQYZYarxjF5Int3aGWg+YviBsuIVg4lYHJIcNE2ppos+liNH7GdLiVClRMKQH3lkejdgNMPj5nehYXR+ag29REJvQAYd1qjiO07LMMAEyeBMaQC5cVl5IvQzzOHvlqw1iU1jDF4UcZl/nMzT9B+Ly9iQxD1lNQkMf/JIx2ajO3Zc2W4E8UD9y7ZVaiukZufFUrI0HvtfOfujBGLa4bANw95fogXEk+FxMBRkm1XG+H1EMJ8+oB+XoBAN33pNxPFn/EFkD5CuPBjn8k6Opw1kF2OexWGlBYtvOXgOYYm66eSB5SDE7c6GvNtdTQYYx3UebYc1wHS++eooH6H6uBP21/fvRng7vngBPDso8fXPiwGOuBXLQGN7UZKxDST7JIOhup41HAQKQ5OiPlVb9mLQszTUjkQR96OQ97hXk63iV1lyjB0aDF6ENsfY4eBXYkjaNr1rZceRlR3fV3+zaGULRQbbyUY5MlvuREo+1DP7J1xsXXgofBV8U+xcQsY659snnIgdKq+qKMA53bLyihFu+kz/46mtcTz4YhrYMEixxuKBkX45HoQXPI171z5+m6L13vncmGCMa1RCFtk0HFmyBv2I50gV1KMtow0vtmUEMR5jGG2AT2wYQXmOsgvM5q0UAI+xQKKpPu6Ak7sPP/3d7O6QPEHytunR7WSf2waZMpVQmQZaAZ1qFDZjXhPHRDkCX/ggFCKd7PnH3LSpyJvVS5vUF/7LhqI4BOg3KLrCOkFZpZbNF+zmEz91B71Tp25fmtgzN5pIk2Z6X57nsAJEGtHpjqGK3me1Ui2I2vOEUHQK26wC2zG73nlgvXPoZ9BH4M9ill4Z2nYWUYDnYIehMesZ1qlyW2ON6kRMuGRORnf0AQitZwQanKI8XYuvCx+YeuMazffbTHdU8WqUJt6yHHADpSGIA76yeWFUDEZQzDHYLRi2DCfbuwFfBEAEe+r5hnhkY/c2nOyoD+6PNJqGQFRN9fGdOmkbCkuQUsiZcZKKSQS0Myxvfa3WBd+R1raG8mzr24AQMRa3gARPuMANj9rJq5sTmB9eR/Q7lwHH0uopiqSGMsHXdqLXdqJx24maIoZvvZOoA+TNfIeHJjQkL63dwbVx1ymNu64tkK4tkEZbIF0b+kxs6MNBY1ZkMdVmQvbi6MmvQbG+DxP8BAmwB1MCjgfAGJXv8NK40XF1gLChCZgmy72fYqkkF9r1s7qtO79VFZ/YsltokggpIdHkgWYlkN//6/dB1VT7ttN1M1Xur3aH+EQiO3H1925gXyTlKmdavzwb+/KENuZEvXY3O7a72d+ujc3GGpv1VNQE/S+8p5npafa3l9nOrInVSk9jMx+sUDpyLlzPpaV5gyjU1tzBsTnHxVSDCBmDo8dybMA5j5ENrA/jDMaYtBkNTdGJAzV1xh8xqPjnPaqXbI5fQaauIi9ckRPWmaPc3otUon818lcx+dU07RQCT86/xUOIaw7hrBzCIOhL7+4XISAvb989iPvSO+KFD8SLOP17NqddPWDf+rt5L+qtPFxcW2VMnRcD7CN52NIJb4rg646tX7aEkQS2Aek8HjL+Jaaa7zztGyIzndjON/uHHPuEme8sb4hMvKPJNvDrm0Lf/JtCwy5j7JGRb843TN1Sf7eTOix056B99f2ftFeKeeydtw/C/54fGzrPGSxCKvv+q1gqS6r73+MomL6oAXtyAmCdxGrGQokK1uSyjdVl8ISLNmq9SPF1vxgzlQGGrc53hpbt/+3WlrMyT9dHR+I8OnLJh2KujSGeuDHE9cmP65Mf1yc/rk9+XJ/8uD75cX3y4/rkx/XJj+uTH/0nP7Crw+SVfmRDgDcfq72f3QFi94H+/m9SzFBmcHjh9ATjOYD7ec+TO4T1CUnXXt4aSXv9PQJUzBkXANisskfguqCNBmCP2mpQGlv2Yd49oXmOPQei2kHV2cRyIS02oXjsVKQxjWMI7gQLGUF6QXP5OCDIdJtxcqlkB2mZHTT98LuvAdfVylzV9KKkrS6ZbvEsY+fyCq4Z33M6Lvz81UnsPlcGhdO1T9nx7Hf4jsWzT9nxLKTAgsRZuPpoO74SVJlFFPZHrSEvtKWLmQA3hzsVlHN1W57+9PJlXsFpXNjA4zfXRO01UXtN1F4TtddE7TVRe03UXhO110TtNVF7TdQ+60Rt/dhrtOXePhfsGnvO8K7x9W3m69vM17eZQ28z2516t9ftOVO6AJ7iXMYuylFXGTcSlgHmVvoy41SvBmSwo2ghAVM2GDmax57zi7rqRqYGB7E4xh33MUKdsIoeIL3r4/ORHEAQ0wKKHpopSGiVnosBxVbWNjAcbedik6zEtBpRgmyoDKKrIT3R/qWVKLVQztjJ2FSrWYC8qdURuSPtlBtX1pNpbM98rgQxbbwvxlF4Hc66nn8x5tMH8rqtC/w1vSinUie/tDfQPp2lXlZVQife6rajqr+sDAswomW/OIYReVXHpY+U4eNUt0SDzBmnw1cvgKb7IEp/fn8iygahYWITZgNITHWpCoLBTmdbkOeDqfgE+ggNPOMdbfzw7f2Kvom2mxnD2k8L4Ju21VCSVzX8t/gIgxndt5Kq3Qchir/S5F5sNrfkJylN8467MstuvYzrj+13XuO9vMZMkE9eZKCxfVzDknIu9KeSGw64B/jll5//ybIM0tdmUCF8hQ6faVo1DFZzaxX5+VWLj0Yx3hbWQJ8mMqqpEXq58Il6TB+CMd9hbkwtQ7fvK7qhq2MTNFXLaToeGDbVrc8gIJf7uhAkyw5S0mfoIHmPgc5VeSghFC+bNCa+vThn4NR5piBqNy5Pj7sZMnf1r4vdYU6k4P8W68XYqE0MNypqUYINz858yC8dAHxrcYxtoM9m4KXjmCSCVwtIsj+ZT0OCFCJjyd7LqXp2zxvQBo0uEMhWpMwuva5N6BtJw5qplSrx5coU0lPLEtpFCJYXVh746XbfWOon7gaSdgdsfzI3Wly2rrrq0oaAq5Tl4kWA69vKWUA0HKh0pFzDkCX3sufwdSb2SHmUfQo0zRgPcx6zuXeWQM2abswe3EpkkLhsKCaONpRlrZGY8pfhf/b/UstGIcekvD7HL7aKA98Zep9BX94zui9JKDKWUOX9YleqEcmC0lkmHilDMh3X4sFjUkfEo03qteZUqwUv9jeCBCGmoJiEdCaAlvpBjvgoeMMbyFjaM1yOhVbyyw1vi9cYTAcvhSIT+xz4WVO+FQo1BKPM+YKW6vQFdnD6tpBWXHzJAYfDqXMGN9LCUQ8a4xtxpBcZm6JnZS7eNRgbc3PTtkb9ShWQhNM84xMhFsb+NAiCKvnlYPnmZxhYkfYisFlAVXz6gByQnZDsT9wZZ4VIaamFaRsjF2OzYABGy/r/UVO/E+mPNfUh57EGPUvIcMykDcHuMXRItsBtSdli4hIwAgZdf0PUPlxoOmaavUsIICajpMgOB7CBOaOfCyGyLD0jPsXt2VRl7/MBxU6AjX/e2gPcxuE55dSv7uaU022jc+/kOM5Lnw73XTiIOh1uzvgMUD+IR5Cdrk4e2KaHWkI5NlPEZkVHwKZfZ4D9a1HEh+0gayq3oGNNu0/2VgUpNcvYn8bvWBZ1z7ugHkenXFEuW3Rn0PQXg5TQB5DYFgoTxC2Gt6F+YU36D79hf4gTNSiKvUhwUWlsavI4geyXQjLVQyN4yjxinGxIn01I3DeZhpMir7Qs4ZZsaKZM/9WS33PxyF8faVbYV3WlxcowCA6Df+GeOBLthFtLFqYMcyz13oBOdqY2oIqLqiaZYQPCj7EPYZU+fCrUCc2SEksibNu8aiXwK7KL3DbGmx/6ASxEn9CiaFajnHGW4y0CSXL61fzVudSFD7/NQtLs9ExcH/MHTECa61IdZZv/STsuH12LcUamURB+TWmaF607KP1DyV94tm9ew3rcAcfAh6ZUU9QJcBzT1FBlpmgSsw5kI0VumDdXEZaLrkamnGf4d+ADWmkFcP4TDt+0nif8xogXKTpvhHgGrw96XP/JjvCueRKiXvy7ZwMTfNywmzh9jfEeZARhdFLKc8CoWAzDUGWSAKQzIzFclNqUWR+NQxJ8ouO8LQ9aaO0W1JG24juCGXk9ZKJucCIhLEI1edyxZFdPp9DRzwEuV6twUWR+rh1Mno4OsYax4YBBPRbEHDuedv0Lau10W8fxTATfsG2JC2wLqjkZbfsr8upzL0fnABZU0iyDjKl8JiW2ODx7Lbaxis0U/YnHwwLGmJoztE1aWOa+fcqY2jwr8dhqfITa2quyW5GrfbB4xCuU2AG9v1g26O4ZT+dDh9Ttva8QMvIKtktyg/UT/yPWN6+DSJlaBTJo0SD/8uiq8GpG5NUN7rFubsmN2WXd3GKYfPPfXHD4SwetQ+qtdIxljvbe8en2aH0UzL/tOFg8Aoq0O9ab16dGTFHR2tBpKlQHkR82hPEP9bRDPCTlCfX9mwffUJ9ohGNWM3zOe9YgfESR2zXCU9MaDbqS26qUwcMk/7HlMSgP+PhV3ICqEkerAi/dlRJmVN7PhtOdZXSyFlOm7i8B9x1T92eDFaVeic0KMc8I9ZdS/7JBvCfjLFh6CZ3evX93kkrnKF9uPQQxX8Xwl93hixNNKTOWFFYvUphoAx+Qag5/MSDHvWj4QmP/bdoRIU6Pez+6Z7fqt177nGaqd26PUagAeO7q4e4QNpl2O36uhri+xnzmOD6VoGacrXT1UPcKpMMPqsYbavPc27EamCpheywHs0/1gMwKx7xWYzktuhAKkIopDVw/iKw82KH5NT0tfGvIkopuk7nEPDJWa/2AKwn8ECPGOyfJ+68KHpJYeonXg+Qz/OAA+Xg4Sl42vntyxwphaBCaJELijXI8l2nGxMtVaYEngqsko0qdyv1zRYQYInWqp2dPB/W+nX8uusC6dplklOWzGWeS0Wdsonf/ejtgn5V+Vucw+CvjKaTkYWwq2MPglbWaM2aEbeMDjWdaxp8VqDdDwE+bmqT8KhfpyRx+NCQIkljONL/+n7vr2W3ehuH3PIWPG1DkEXZoi2LF1iFLtl0TJVZaD67t2UnXvP1AWbT8h7Sk2E6y4Lt8RRLyx58pmZJIih1fi7+e5txwol+ft3AfObTKWUcZSUWU9bHQuyME8EB08Log1XruAvgp1uuMLj0cGTYPt3mHA0QCpi6QXmKB9EL3HZnPf7zGdkcL3bCNDx39yvAiWCttFN6HLlpEmaVhGBX5UfGyPYbNdCvv4VlDtUjD50ryo5J86/mrFOSOstZJ5IBaqy4WrgiNAmYrSDNpsHNMgz3rvdhF+QazWi7h6ksy2Vblb6hMIRXxMfiJ+Q+hG48s5rBy+Xc06OYAHGbJmh6zIWHK/CnVCJFYRJ09dy9g02OXfsncJP4QjHnO4vI7k7u2c1jY87oMspnyUzZtMAYYboPOtNIE+iFFfPg4zS+ZtKx19q+FEdh0p6tvOr1La7ABRGCYAjZsrq6FUlpgUPTP0c4hFQKcUXSdMUJ+oaHyFUr24VHtV3W+Mfyx/kHnQGcyD5Yl/L7iuOncbRiu/sBrwnpCB2zMG24seNRbTs9zBhxXRVLHGYutjC/wbPfHOD6hNiubiA5zAf45pgcx2tRSkznK5DI88OLCLCwQ+B3stwZYbZZ8EJQaykwBONX+EHmoDoIKaN2D62nkjlQ/JApuGsqGuET7RWcVdQt1pcUpkw/BBkzdgK0bmLw3pGLS8DPsU+J0P0mAI7IsjuDQwtSRtsRwf3b/g1hh4ol2cqThoqWNMlCGeMhK4+B33nSbRG7nxEHHa3KQeSLi4HVRuby2n1Ypv8sfrMewDIUFz7+t+CFQqYyy0RQyG0JxKsL1VsRw18oQWn9NRRg8ajnontwu1JAhjoZ1ZKDwKHmHg+9BLqIkcOhRAeyzDfEJVPMzJaf13qFn/G5yL0mVkgGTYeMHqAGWxHJ/jMcL7FHiaJF9HwlE8NKDEoOWihJTKvKDurWkfA+utAXt6A8BYWzTkk2T5rfUaJBXxVBnrTYmjk9N4FeFpxXeTPIkXmPZofX4A7za+oMDh8DM6mBqJ6ytQ87PMp3YFysPrIG9DR9Ez3MA1josa5+V0Q/WdUquH51dPc5roOGjvSxPv6IiSrlkfo+MACPJRH11FDSAvDxvXxON7zwwLEspun2e0h+eEvEZ7QQsmPXbTR87FySQ8rP1NlJHVYPOat8gNUdhkHtxjA+GG9g4h3pbrYUEMigeaTx2S1Si7swby/uVMNP9dZwRUIkjyXB4Ej/N+P6ZbECouhWv2So52zPo6jQ3upH6fBJBbdZB84ACpqEu/RzZdSjqt51P+wD1SW0YmZLJuHbhNgV1JUnBfqV3D8eD4fo/SIB9SnOpKU9Egm3sZ70oRZJy+a2OQB1BAkDYjWFUTpToOp0TUVlAHqzZ8ko9mC1vm9AJprTBCDovijsnY7lauVEBV/JAMak8dL5yX4zAHUXwrofg3omZTLyXsUIx88buVSAOigJGE4L5FH+n+cUQKW0kLsQzar6wTj28doDeZUXH0WxYsi9IXdSA4QYLiiLTg21C+wTf5ih8iWKpA1OV9l7ZbW/zbj9GvlOKKsPtHBHNSu+eHrCZZ6ZuRLY79JpQNtlZc/VYdUN0r6xhpiwaDbd0goVaLc44EyIoCSomecSM7zg+YUeT/wQjWSMMmH0u5eRgXnIpXcDQl+SMjaaMXx3g/A/cOErSUBaz/wYA5gFMVQ=="
 }
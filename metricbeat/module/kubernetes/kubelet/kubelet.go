@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kubelet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/kubernetes/util"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	defaultScheme = "https"
+	defaultPath   = "/metrics"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+	}.Build()
+
+	mapping = &prometheus.MetricsMapping{
+		Metrics: map[string]prometheus.MetricMap{
+			"process_cpu_seconds_total":     prometheus.Metric("process.cpu.sec"),
+			"process_resident_memory_bytes": prometheus.Metric("process.memory.resident.bytes"),
+			"process_open_fds":              prometheus.Metric("process.fds.open.count"),
+			"process_start_time_seconds":    prometheus.Metric("process.started.sec"),
+
+			"kubelet_pleg_relist_duration_seconds": prometheus.Metric("pleg.relist.duration.us", prometheus.OpMultiplyBuckets(1000000)),
+			"kubelet_pleg_relist_interval_seconds": prometheus.Metric("pleg.relist.interval.us", prometheus.OpMultiplyBuckets(1000000)),
+			"kubelet_pleg_last_seen_seconds":       prometheus.Metric("pleg.last_seen.sec"),
+			"kubelet_pleg_discard_events":          prometheus.Metric("pleg.discarded.count"),
+
+			"kubelet_pod_start_duration_seconds":  prometheus.Metric("pod.start.duration.us", prometheus.OpMultiplyBuckets(1000000)),
+			"kubelet_pod_worker_duration_seconds": prometheus.Metric("pod.worker.duration.us", prometheus.OpMultiplyBuckets(1000000)),
+			"kubelet_running_pods":                prometheus.Metric("pod.running.count"),
+			"kubelet_running_containers": prometheus.Metric("container", prometheus.OpFilterMap(
+				"container_state", map[string]string{
+					"created": "created.count",
+					"exited":  "exited.count",
+					"running": "running.count",
+				},
+			)),
+
+			"kubelet_runtime_operations_total":            prometheus.Metric("runtime.operations.count"),
+			"kubelet_runtime_operations_errors_total":     prometheus.Metric("runtime.operations.errors.count"),
+			"kubelet_runtime_operations_duration_seconds": prometheus.Metric("runtime.operations.duration.us", prometheus.OpMultiplyBuckets(1000000), opRoundBuckets{}),
+		},
+
+		Labels: map[string]prometheus.LabelMap{
+			"operation_type": prometheus.KeyLabel("operation.type"),
+		},
+	}
+)
+
+// opRoundBuckets rounds the upper bounds of histogram buckets to integers.
+// Runtime operations use exponential buckets whose bounds in microseconds have
+// decimals, which can't be used as field names.
+type opRoundBuckets struct{}
+
+// Process rounds the numeric bucket labels of the given histogram
+func (o opRoundBuckets) Process(field string, value interface{}, labels mapstr.M) (string, interface{}, mapstr.M) {
+	histogram, ok := value.(mapstr.M)
+	if !ok {
+		return field, value, labels
+	}
+	bucket, ok := histogram["bucket"].(mapstr.M)
+	if !ok {
+		return field, value, labels
+	}
+	rounded := mapstr.M{}
+	for k, v := range bucket {
+		if f, err := strconv.ParseFloat(k, 64); err == nil {
+			k = strconv.FormatFloat(math.Round(f), 'f', -1, 64)
+		}
+		rounded[k] = v
+	}
+	histogram["bucket"] = rounded
+	return field, histogram, labels
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("kubernetes", "kubelet", New,
+		mb.WithHostParser(hostParser))
+}
+
+// MetricSet reports the pod lifecycle metrics exposed by the kubelet: PLEG
+// relisting, pod start and worker latencies and container runtime operations.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheusClient   prometheus.Prometheus
+	prometheusMappings *prometheus.MetricsMapping
+	clusterMeta        mapstr.M
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The kubernetes kubelet metricset is beta.")
+
+	pc, err := prometheus.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{
+		BaseMetricSet:      base,
+		prometheusClient:   pc,
+		prometheusMappings: mapping,
+		clusterMeta:        util.AddClusterECSMeta(base),
+	}, nil
+}
+
+// Fetch gathers information from the kubelet and reports events with this information.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	events, err := m.prometheusClient.GetProcessedMetrics(m.prometheusMappings)
+	if err != nil {
+		return fmt.Errorf("error getting metrics: %w", err)
+	}
+
+	for _, e := range events {
+		event := mb.TransformMapStrToEvent("kubernetes", e, nil)
+		if len(m.clusterMeta) != 0 {
+			event.RootFields.DeepUpdate(m.clusterMeta)
+		}
+		isOpen := reporter.Event(event)
+		if !isOpen {
+			return nil
+		}
+	}
+
+	return nil
+}
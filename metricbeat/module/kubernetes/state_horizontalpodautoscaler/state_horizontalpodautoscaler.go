@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package state_horizontalpodautoscaler
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	p "github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	k8smod "github.com/elastic/beats/v7/metricbeat/module/kubernetes"
+	"github.com/elastic/beats/v7/metricbeat/module/kubernetes/util"
+	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
+)
+
+var mapping = &p.MetricsMapping{
+	Metrics: map[string]p.MetricMap{
+		"kube_horizontalpodautoscaler_metadata_generation":     p.Metric("generation"),
+		"kube_horizontalpodautoscaler_spec_min_replicas":       p.Metric("replicas.min"),
+		"kube_horizontalpodautoscaler_spec_max_replicas":       p.Metric("replicas.max"),
+		"kube_horizontalpodautoscaler_status_current_replicas": p.Metric("replicas.current"),
+		"kube_horizontalpodautoscaler_status_desired_replicas": p.Metric("replicas.desired"),
+		"kube_horizontalpodautoscaler_spec_target_metric": p.Metric("target", p.OpFilterMap(
+			"metric_name", map[string]string{
+				"cpu":    "cpu",
+				"memory": "memory",
+			},
+		), p.OpFilterMap(
+			"metric_target_type", map[string]string{
+				"utilization": "utilization",
+			},
+		)),
+		"kube_horizontalpodautoscaler_status_condition": p.LabelMetric("condition", "status", p.OpFilterMap(
+			"condition", map[string]string{
+				"AbleToScale":    "able_to_scale",
+				"ScalingActive":  "scaling_active",
+				"ScalingLimited": "scaling_limited",
+			},
+		)),
+	},
+	Labels: map[string]p.LabelMap{
+		"horizontalpodautoscaler": p.KeyLabel("name"),
+		"namespace":               p.KeyLabel(mb.ModuleDataKey + ".namespace"),
+	},
+}
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("kubernetes", "state_horizontalpodautoscaler", New,
+		mb.WithHostParser(p.HostParser),
+	)
+}
+
+// MetricSet reports HorizontalPodAutoscaler metrics from kube-state-metrics.
+//
+// kube-state-metrics does not expose the last time an autoscaler scaled its
+// target, so when metadata is enabled it is read from the API server.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus p.Prometheus
+	mod        k8smod.Module
+	client     k8sclient.Interface
+	namespace  string
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The kubernetes state_horizontalpodautoscaler metricset is beta.")
+
+	prometheus, err := p.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	mod, ok := base.Module().(k8smod.Module)
+	if !ok {
+		return nil, fmt.Errorf("must be child of kubernetes module")
+	}
+
+	ms := &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+		mod:           mod,
+	}
+
+	if config, err := util.GetValidatedConfig(base); err == nil {
+		client, err := kubernetes.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
+		if err != nil {
+			base.Logger().Warnf("Last scale time of autoscalers won't be reported, error creating Kubernetes client: %v", err)
+		} else {
+			ms.client = client
+			ms.namespace = config.Namespace
+		}
+	}
+	return ms, nil
+}
+
+// Fetch gathers the autoscaler metrics from kube-state-metrics and reports
+// one event per HorizontalPodAutoscaler.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) {
+	families, err := m.mod.GetStateMetricsFamilies(m.prometheus)
+	if err != nil {
+		m.Logger().Error(err)
+		reporter.Error(err)
+		return
+	}
+	events, err := m.prometheus.ProcessMetrics(families, mapping)
+	if err != nil {
+		m.Logger().Error(err)
+		reporter.Error(err)
+		return
+	}
+
+	lastScale := m.lastScaleTimes()
+	for _, event := range events {
+		e, err := util.CreateEvent(event, "kubernetes.horizontalpodautoscaler")
+		if err != nil {
+			m.Logger().Error(err)
+		}
+
+		if len(lastScale) > 0 {
+			namespace, _ := e.ModuleFields.GetValue("namespace")
+			name, _ := e.MetricSetFields.GetValue("name")
+			if sec, found := lastScale[fmt.Sprintf("%v/%v", namespace, name)]; found {
+				e.MetricSetFields.Put("last_scale.sec", sec)
+			}
+		}
+
+		if reported := reporter.Event(e); !reported {
+			m.Logger().Debug("error trying to emit event")
+			return
+		}
+	}
+}
+
+// lastScaleTimes returns the unix time of the last scale operation of each
+// autoscaler, indexed by namespace/name.
+func (m *MetricSet) lastScaleTimes() map[string]int64 {
+	if m.client == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.Module().Config().Timeout)
+	defer cancel()
+	list, err := m.client.AutoscalingV1().HorizontalPodAutoscalers(m.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		m.Logger().Debugf("Error listing HorizontalPodAutoscalers: %v", err)
+		return nil
+	}
+
+	times := make(map[string]int64, len(list.Items))
+	for _, hpa := range list.Items {
+		if hpa.Status.LastScaleTime == nil {
+			continue
+		}
+		times[hpa.Namespace+"/"+hpa.Name] = hpa.Status.LastScaleTime.Unix()
+	}
+	return times
+}
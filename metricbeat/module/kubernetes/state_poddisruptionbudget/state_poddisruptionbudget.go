@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package state_poddisruptionbudget
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	p "github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	k8smod "github.com/elastic/beats/v7/metricbeat/module/kubernetes"
+	"github.com/elastic/beats/v7/metricbeat/module/kubernetes/util"
+)
+
+var mapping = &p.MetricsMapping{
+	Metrics: map[string]p.MetricMap{
+		"kube_poddisruptionbudget_created":                        p.Metric("created.sec"),
+		"kube_poddisruptionbudget_status_current_healthy":         p.Metric("pods.healthy.current"),
+		"kube_poddisruptionbudget_status_desired_healthy":         p.Metric("pods.healthy.desired"),
+		"kube_poddisruptionbudget_status_expected_pods":           p.Metric("pods.expected"),
+		"kube_poddisruptionbudget_status_pod_disruptions_allowed": p.Metric("disruptions.allowed"),
+		"kube_poddisruptionbudget_status_observed_generation":     p.Metric("generation.observed"),
+	},
+	Labels: map[string]p.LabelMap{
+		"poddisruptionbudget": p.KeyLabel("name"),
+		"namespace":           p.KeyLabel(mb.ModuleDataKey + ".namespace"),
+	},
+}
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("kubernetes", "state_poddisruptionbudget", New,
+		mb.WithHostParser(p.HostParser),
+	)
+}
+
+// MetricSet reports PodDisruptionBudget metrics from kube-state-metrics
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus p.Prometheus
+	mod        k8smod.Module
+}
+
+// New create a new instance of the MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The kubernetes state_poddisruptionbudget metricset is beta.")
+
+	prometheus, err := p.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	mod, ok := base.Module().(k8smod.Module)
+	if !ok {
+		return nil, fmt.Errorf("must be child of kubernetes module")
+	}
+	return &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+		mod:           mod,
+	}, nil
+}
+
+// Fetch gathers the disruption budget metrics from kube-state-metrics and
+// reports one event per PodDisruptionBudget.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) {
+	families, err := m.mod.GetStateMetricsFamilies(m.prometheus)
+	if err != nil {
+		m.Logger().Error(err)
+		reporter.Error(err)
+		return
+	}
+	events, err := m.prometheus.ProcessMetrics(families, mapping)
+	if err != nil {
+		m.Logger().Error(err)
+		reporter.Error(err)
+		return
+	}
+
+	for _, event := range events {
+		e, err := util.CreateEvent(event, "kubernetes.poddisruptionbudget")
+		if err != nil {
+			m.Logger().Error(err)
+		}
+
+		if reported := reporter.Event(e); !reported {
+			m.Logger().Debug("error trying to emit event")
+			return
+		}
+	}
+}
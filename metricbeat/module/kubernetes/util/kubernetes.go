@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	kubernetes2 "github.com/elastic/beats/v7/libbeat/autodiscover/providers/kubernetes"
+	"github.com/elastic/beats/v7/libbeat/common/k8swatcher"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-autodiscover/kubernetes"
 	"github.com/elastic/elastic-agent-autodiscover/kubernetes/metadata"
@@ -299,7 +300,7 @@ func NewContainerMetadataEnricher(
 }
 
 func getResourceMetadataWatchers(config *kubernetesConfig, resource kubernetes.Resource, nodeScope bool) (kubernetes.Watcher, kubernetes.Watcher, kubernetes.Watcher) {
-	client, err := kubernetes.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
+	client, err := k8swatcher.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
 	if err != nil {
 		logp.Err("Error creating Kubernetes client: %s", err)
 		return nil, nil, nil
@@ -329,19 +330,19 @@ func getResourceMetadataWatchers(config *kubernetesConfig, resource kubernetes.R
 
 	log.Debugf("Initializing a new Kubernetes watcher using host: %v", config.Node)
 
-	watcher, err := kubernetes.NewNamedWatcher("resource_metadata_enricher", client, resource, options, nil)
+	watcher, err := k8swatcher.NewNamedWatcher("resource_metadata_enricher", client, resource, options, nil)
 	if err != nil {
 		logp.Err("Error initializing Kubernetes watcher: %s", err)
 		return nil, nil, nil
 	}
 
-	nodeWatcher, err := kubernetes.NewNamedWatcher("resource_metadata_enricher_node", client, &kubernetes.Node{}, options, nil)
+	nodeWatcher, err := k8swatcher.NewNamedWatcher("resource_metadata_enricher_node", client, &kubernetes.Node{}, options, nil)
 	if err != nil {
 		logp.Err("Error creating watcher for %T due to error %+v", &kubernetes.Node{}, err)
 		return watcher, nil, nil
 	}
 
-	namespaceWatcher, err := kubernetes.NewNamedWatcher("resource_metadata_enricher_namespace", client, &kubernetes.Namespace{}, kubernetes.WatchOptions{
+	namespaceWatcher, err := k8swatcher.NewNamedWatcher("resource_metadata_enricher_namespace", client, &kubernetes.Namespace{}, kubernetes.WatchOptions{
 		SyncTimeout: config.SyncPeriod,
 	}, nil)
 	if err != nil {
@@ -586,7 +587,7 @@ func AddClusterECSMeta(base mb.BaseMetricSet) mapstr.M {
 		logp.Info("could not retrieve validated config")
 		return mapstr.M{}
 	}
-	client, err := kubernetes.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
+	client, err := k8swatcher.GetKubernetesClient(config.KubeConfig, config.KubeClientOptions)
 	if err != nil {
 		logp.Err("fail to get kubernetes client: %s", err)
 		return mapstr.M{}
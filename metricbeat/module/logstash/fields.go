@@ -32,5 +32,5 @@ func init() {
 // AssetLogstash returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/logstash.
 func AssetLogstash() string {
-	return "eJzEWU2TozYQvftXdPmcpSqHXHxI5ZCkMqmkktNeUilKA23QrkCsPpxMfn0K2zAIfSAZzSwHH4B+73WrabXaH+AzvpyA8UYqItsDgKKK4QmOv91vHQ8ANcpK0EFR3p/g+wMAwPQYOl5rhgcAgQyJxBM05AAgUSnaN/IEfx2lZMdv4NgqNRz/Hp+1XKiy4v2ZNic4EyZH+zNFVsvTFf0D9KTDV12lVETJ6yMA9TKMLILr4X5nabo0V7RDqUg3zE8ma8IokYu7A1HtCY4/zBZHC+zTpbNgliJcQpb2HXbGfR+GD2eJ1SIZSi2xLmlfPr8olNarPk9Nj6cAFz2vsbhGufh06YoOuyKCxNDTkX/fXI6XY1Kih3EJx3c6yhhdU/lFhAV4YSdevGCv5K4EoX0GsTcdxQprouBa5eNYg00ktRZkLBVZF+HOGcBe14xda6E1rTOonu9ZeBPRBYWkPMfKz/fWkBPVQAdktEc7S3uUCmvLgO/L52rQ2Qoe46QuyQUFadBbW1zAIfAlwbffrauzie2rWxBcEy6LatDFUn3hY5qVvJuQsI53C8iKaE4f13I+nkHVoElVqUJL0mDZk57n3KRGv66qihge/xcS9jHkp4F7luUXzRUpO1oJp6PbziY4XEQRTupGgKAin+tb7i9Jet09oyj5uURGhrGJGVBQXvs8jYtHSkzGe0W6jGXvWqpWcKUY1t6EfUPtSQrsuF9b6Vf7rxV3n4x5VxS8QnkrSwOKCnsVeVpwSQmhTYxfNGq8NzRlxfUuQg+Y8wCFyQeoqWUojO4lTSMWLhSLoXW1bA9wzDiefjDeeWREKlpJJKJqi4ppqVC4IvEZX/7hwvZtlLfZQTnO1tO1sjcP2NPlU5+D/Hbshg6VoJUskrXAquq/LlO2bd1q08G7LGvTVcZtG8+GXKYeo0aTYvwZEVZvdLS5HWpOoIRePw0s0Xj9wqUCC3Tf6UKiuNAKrYNEBrnz2OijA9s9aYFgfmzw/frxd3jqzzwxq9yew2ZqbeoZL5frYJREf06HO9Np93EjhBcuUvyfNwp4+tH7lbuP+4983HYihPFCmBA/JDKJGO+bIJo92ItTuqUWUgd+kbot3I3BXQSsdwpm2voiEZF3P12RnfkFkatuzdciHIvWN15PfcU72jf3MMC1HUNRBFXZI7ncsv7QquGpss6UKRTetj2Xtp/vNEnaNgeMESoD00LTeledsaeIJrh/H4nZi7ZxnJO8fP655xfb2Fv4ED/5iyeMIYWoiaDJ2RJ2Ls+Me4caK9g3Qc2jNTh6M5FyLG3cSM7kjdrV/HkZ50CME5A+b0vwBOLmZWkuQaRbsHOOZqra9BMen3rtYHpkUBVBFzdWMoFqrp+ZXdxC/9uYAKv/b16vqLZo/xblOUmngVwnWW9eczxjNzdhVLEZX91ECgUA1kEoJf0PMzf6Y4+fFXyz5Yes6+ZvkyO0wiPNYwTuokVTtPJGM18UcooH+6vwnpAS4aaKVeLQYoeCsNJbZSD6G1kdKtJIVp7uTqeVmAjYzXoLj0Yiwu3bpz9o2ZYP5NBWH2qoSaOK+lPEVHNdl/8DAAD//1SoQj4="
+	return "eJzsW8+vm8wV3fNXXHn9gpSq2XhRVdVLlFe16Vuli6rCY7iGyYMZMj/sOH99NcDYGA8w2Pj1W/BsffpkmHPOnXsG7lzIB3jD4xpynkpFZBYAKKpyXMPqH81PqwAgQRkLWirK2Rr+EgAA2MNQ8ETnGAAIzJFIXENKAgCJSlGWyjX8ZyVlvnqCVaZUufqvOZZxoaKYsx1N17AjuTTjdxTzRK4r9A/ASIFnXZFURMnqEIA6loZFcF02v7SHtocrWqBUpLDnnUeTnBKLZz4lUdkaVn89jVhdgf3YF60B1yJcQtrjC2yP78fow2ljZUjKSEtMIsqi7VFhO5bhSO1fHbGd4JDxBMNqlsMf+yIssAg9SC70FOTXw+X0clglujQpNDoKmudUBr4ihgX0wlpe3CM7ObQ/uUMGoWwGsbWOkDInBddqPo4umCVJtCDmUjFrEhrOAWxLb0fflQutaTKD6tNvV3iWaI9CUs7m5OpCWqqSlphTdrFwaiKGUmFyNYDLu+YwLvXF730YfThtrJyTJCJ7FCTFq5OGgIfA2wQfP3WvzuO5GM8Jl2Fc6rCtPuxjOil5NyHDOt5tQjpElj92pfN2B8WlJnGsQi1JihEj7MLdfrENx1WpCn14WpoeYuZ4J6OfmisSFTQWzkDnSOQp4NCL0KozAD18w6GPhd8mYbrYooj4LsKclKaIKVFQnvRF6jcfU+bE/BZOl2EDqOoNlQmuVI5Jr2EfqH2Sgut5N8Plefz7ah+XYQWXgsco68tSiSJGpoIxaf1ShtAs40+NGpsiKoq5vouwB8xy2SHVBgqDvlXWXVXdkiGkyc0aMXShXDFkrpLtBo4TjmWwZ08OHnMiFY0lEhFnYZxrqVC4ZuINjwcurmMzU3B1cpsZwLm3tp/O+MsNNsCw+jnIK9dIKFAJGstwspa2nss0XZzSp64Psg17kY7htHSHdhw3Pvg0kEsVuAZ17Xq2bMalCs1/DELnjIKm9aZmDUro7tGBFJnvVy4VXIHet7uQKPY0xtA9+i65p7bRdwe2u9My7I8Rvr9//ye8sB2f6Cp35MPu8NJjvq7Q2+TlgKddSTsnzt593AjDifMU/1pTwMtz4NLe7tONJ28sDddGGMYbwpzQJLKfmijnLB1Eu27s+SkdUzu54eepe2rjzgO2twvmNxMevvtclUtOf/lm/aq/5hGYtz7zfWExLyhLm2mAqrZDEQau8/tbcnPL+pdWKZ8qa0dzhQKTB2v70tBM0jbQBPRW2VMd+nvWx3GOLqLvfcTnXjSOYzG4fEh87v7FOPYYfpuj3SrqPdmH0IfUryNo/2rOjOS7aJdzovxgH4I6j9bB1pv/TPum1q8l57GYHcCjQHNaxbPfNiESz37ZtJB8w3L3c/z7aBPjvKPrdQfTeIfoJjoLP9QIOv/VQAnX2xz7oZo9s2sinM9v/LJt4We4RfXspKeBVJ2s4FZ/j/nasvS03SZkuItoTh1FGpqANlg1CZGkv3HmQt88OJ8VfLTknzdv/WWyh9abikcPXIu5R6FojI+fhTnFO1YFZfPA2StWhGWGBQqSRzQZhR5bI51NxTSSTqR326kjxgPWKph/JjzCrpd+qWUW3eChsTr0Qs00Kq+HIgN5ubLcLueHYGztDexOv+T8YDvewHegMjy/7GZZJBCWmENUQJnrlDJ3d3yLyrc/bqEDV8iua0ffNWPQZ2P+GpgY+3lthMLLc9hLPrm37k3/1eShyYudM6jfJWzc1q9q5Eoxg7rPlgFenq1KoRkz7Rer9gnijLAUJXBm+h7iaAzDSRIGLtG1v7xtMSLytUKrZm/XdvoWzbICxUP4xpV5fdOoO01w8/rVH8WCdRAvz09AZKX1QFVWBbWhyQZ4NRq4gBQZCqIwge3xtIz7DeJ4OjOvZEPwBDsuAH+RoswRNqngb5swmFhezifJID0BZ2j8uqGs1GrzBJu6Abgxk7jhWplfw8AlsHPBvcud7YvvEyCJMxBYcmESSCSYTErgexQgzcIhOZjNGxwoS/hhuj1NrNVuU6dZ6bx190cyrW9dVpJjzhIQGCPd1440jq1m/HSrsSuuG8tQPO2YYi2Ee6/pczu3KObJd/QxKijTCufA+tRgyTnAPs6LFmVcizmQ/vTnCupuVXSHxtY3wFiIevG+u7WbpsfZ27WOxdyLuWc0d303endzS2TK+rqWsPh68fWMvq62wNGWxG+lQCm1wEcY+1vV9zbObQqPbc7jN0zA7NhbT4kVr6xdiTLlIdjXtRePLx6/2eMHLt5QmH/7Vxeq8fGxHrdX5oZYwlbLo304c3b7YvDF4LMaXCua099VO+oRBn+tX1QnabVpN2vyog45uV2Wpmhx2V00x5peUN1kqhoTzf+6Oz7LYlgWg/9iaB4FmPddzQPqKBX8oLLeJ493L4u/GeB2zV4tCktfyzHL7iBhe1ysvVh7dms//AXYxdyLuR9u7v9nS5ywui/f1CFLNb5U43dX4/VrDlGJor5AP8LV/65KbqipKlufym8Udd0NlAFpeuKmAq+7iIvRF6N7G/1/AwDv/owC"
 }
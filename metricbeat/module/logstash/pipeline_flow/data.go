@@ -0,0 +1,203 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline_flow
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/version"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/elastic"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/logstash"
+)
+
+// FlowMetricsAvailableVersion is the version of Logstash since when the node
+// stats API reports flow metrics
+var FlowMetricsAvailableVersion = version.MustNew("8.5.0")
+
+var (
+	// flowWindows are the rates reported for every flow metric. The
+	// last_* windows only appear once the pipeline has been running for
+	// at least that long.
+	flowWindows = []string{
+		"current",
+		"last_1_minute",
+		"last_5_minutes",
+		"last_15_minutes",
+		"last_1_hour",
+		"last_24_hours",
+		"lifetime",
+	}
+
+	pipelineFlowMetrics = []string{
+		"input_throughput",
+		"filter_throughput",
+		"output_throughput",
+		"queue_backpressure",
+		"worker_concurrency",
+		"worker_utilization",
+		"queue_persisted_growth_bytes",
+		"queue_persisted_growth_events",
+	}
+
+	pluginFlowMetrics = []string{
+		"throughput",
+		"worker_utilization",
+		"worker_millis_per_event",
+	}
+
+	// pluginTypes maps the plugin sections of the API response to the
+	// type reported in the events. Codecs have no flow metrics.
+	pluginTypes = map[string]string{
+		"inputs":  "input",
+		"filters": "filter",
+		"outputs": "output",
+	}
+)
+
+type flow map[string]map[string]interface{}
+
+type plugin struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Flow flow   `json:"flow"`
+}
+
+type pipelineStats struct {
+	Hash        string              `json:"hash"`
+	EphemeralID string              `json:"ephemeral_id"`
+	Flow        flow                `json:"flow"`
+	Plugins     map[string][]plugin `json:"plugins"`
+}
+
+type response struct {
+	ID         string `json:"id"`
+	Host       string `json:"host"`
+	Version    string `json:"version"`
+	Monitoring struct {
+		ClusterID string `json:"cluster_uuid"`
+	} `json:"monitoring"`
+	Pipelines map[string]pipelineStats `json:"pipelines"`
+}
+
+func eventsMapping(r mb.ReporterV2, content []byte) error {
+	var data response
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("could not parse node pipeline stats response: %w", err)
+	}
+
+	logstashVersion, err := version.New(data.Version)
+	if err != nil {
+		return fmt.Errorf("could not parse Logstash version %q: %w", data.Version, err)
+	}
+	if !elastic.IsFeatureAvailable(logstashVersion, FlowMetricsAvailableVersion) {
+		return fmt.Errorf("the pipeline_flow metricset is only supported with Logstash >= %v. You are currently running Logstash %v",
+			FlowMetricsAvailableVersion, logstashVersion)
+	}
+
+	ids := make([]string, 0, len(data.Pipelines))
+	for id := range data.Pipelines {
+		// Skip internal pipelines, like the monitoring one
+		if id == "" || id[0] == '.' {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		stats := data.Pipelines[id]
+		pipeline := mapstr.M{
+			"id":           id,
+			"hash":         stats.Hash,
+			"ephemeral_id": stats.EphemeralID,
+		}
+
+		r.Event(data.newEvent(mapstr.M{
+			"pipeline": pipeline,
+			"flow":     stats.Flow.fields(pipelineFlowMetrics),
+		}))
+
+		for _, section := range []string{"inputs", "filters", "outputs"} {
+			for _, p := range stats.Plugins[section] {
+				r.Event(data.newEvent(mapstr.M{
+					"pipeline": pipeline.Clone(),
+					"plugin": mapstr.M{
+						"id":   p.ID,
+						"name": p.Name,
+						"type": pluginTypes[section],
+					},
+					"flow": p.Flow.fields(pluginFlowMetrics),
+				}))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (data *response) newEvent(fields mapstr.M) mb.Event {
+	event := mb.Event{
+		RootFields: mapstr.M{
+			"service": mapstr.M{
+				"id":       data.ID,
+				"hostname": data.Host,
+				"version":  data.Version,
+				"name":     logstash.ModuleName,
+			},
+		},
+		ModuleFields:    mapstr.M{},
+		MetricSetFields: fields,
+	}
+
+	if clusterUUID := data.Monitoring.ClusterID; clusterUUID != "" {
+		_, _ = event.ModuleFields.Put("cluster.id", clusterUUID)
+		_, _ = event.ModuleFields.Put("elasticsearch.cluster.id", clusterUUID)
+	}
+
+	return event
+}
+
+// fields returns the numeric windows of the given flow metrics. Metrics
+// without any numeric window, like those of plugins that are not yet
+// measured, are left out.
+func (f flow) fields(metrics []string) mapstr.M {
+	fields := mapstr.M{}
+	for _, metric := range metrics {
+		windows, found := f[metric]
+		if !found {
+			continue
+		}
+
+		values := mapstr.M{}
+		for _, window := range flowWindows {
+			if value, ok := windows[window].(float64); ok {
+				values[window] = value
+			}
+		}
+
+		if len(values) > 0 {
+			fields[metric] = values
+		}
+	}
+	return fields
+}
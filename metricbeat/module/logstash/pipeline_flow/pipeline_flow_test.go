@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package pipeline_flow
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const fixture = "./_meta/test/node_stats_pipelines.8110.json"
+
+func TestEventsMapping(t *testing.T) {
+	content, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content)
+	require.NoError(t, err)
+
+	// One event for the main pipeline and one per plugin, internal
+	// pipelines and codecs are skipped.
+	events := reporter.GetEvents()
+	require.Len(t, events, 5)
+
+	pipeline := events[0]
+	require.Equal(t, "main", getValue(t, pipeline.MetricSetFields, "pipeline.id"))
+	require.Equal(t, 1.734, getValue(t, pipeline.MetricSetFields, "flow.queue_backpressure.current"))
+	require.Equal(t, 61.25, getValue(t, pipeline.MetricSetFields, "flow.worker_utilization.lifetime"))
+	require.Equal(t, "tJfcjHMvQ1u8DmWn1YJmQw", getValue(t, pipeline.ModuleFields, "cluster.id"))
+	require.Equal(t, "8.11.0", getValue(t, pipeline.RootFields, "service.version"))
+
+	_, err = pipeline.MetricSetFields.GetValue("plugin")
+	require.Error(t, err)
+	_, err = pipeline.MetricSetFields.GetValue("flow.input_throughput.last_1_hour")
+	require.Error(t, err, "windows missing from the response must not be reported")
+
+	grok := events[2]
+	require.Equal(t, "main", getValue(t, grok.MetricSetFields, "pipeline.id"))
+	require.Equal(t, "grok_apache", getValue(t, grok.MetricSetFields, "plugin.id"))
+	require.Equal(t, "grok", getValue(t, grok.MetricSetFields, "plugin.name"))
+	require.Equal(t, "filter", getValue(t, grok.MetricSetFields, "plugin.type"))
+	require.Equal(t, 81.2, getValue(t, grok.MetricSetFields, "flow.worker_utilization.current"))
+	require.Equal(t, 31.7, getValue(t, grok.MetricSetFields, "flow.worker_millis_per_event.current"))
+
+	input := events[1]
+	require.Equal(t, "input", getValue(t, input.MetricSetFields, "plugin.type"))
+	require.Equal(t, 102.4, getValue(t, input.MetricSetFields, "flow.throughput.current"))
+
+	output := events[4]
+	require.Equal(t, "output", getValue(t, output.MetricSetFields, "plugin.type"))
+	require.Equal(t, "es_output", getValue(t, output.MetricSetFields, "plugin.id"))
+}
+
+func TestEventsMappingUnsupportedVersion(t *testing.T) {
+	reporter := &mbtest.CapturingReporterV2{}
+	err := eventsMapping(reporter, []byte(`{"version": "8.4.3", "pipelines": {"main": {}}}`))
+	require.ErrorContains(t, err, "only supported with Logstash >= 8.5.0")
+	require.Empty(t, reporter.GetEvents())
+}
+
+func TestData(t *testing.T) {
+	content, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != pipelineStatsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "logstash",
+		"metricsets": []string{"pipeline_flow"},
+		"hosts":      []string{server.URL},
+	}
+
+	ms := mbtest.NewReportingMetricSetV2Error(t, config)
+	err = mbtest.WriteEventsReporterV2ErrorCond(ms, t, "", func(e mapstr.M) bool {
+		v, err := e.GetValue("logstash.pipeline_flow.plugin.type")
+		return err == nil && v == "filter"
+	})
+	require.NoError(t, err)
+}
+
+func getValue(t *testing.T, m mapstr.M, key string) interface{} {
+	t.Helper()
+	v, err := m.GetValue(key)
+	require.NoError(t, err, key)
+	return v
+}
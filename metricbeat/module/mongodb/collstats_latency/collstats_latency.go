@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package collstats_latency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/mongodb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("mongodb", "collstats_latency", New,
+		mb.WithHostParser(mongodb.ParseURL),
+	)
+}
+
+// Metricset type defines all fields of the Metricset
+type Metricset struct {
+	*mongodb.Metricset
+
+	include []match.Matcher
+	exclude []match.Matcher
+
+	histograms *histogramCache
+}
+
+type config struct {
+	// Include and Exclude are regular expressions matched against the
+	// namespace of the collections, in the form `database.collection`.
+	Include []match.Matcher `config:"collstats_latency.include"`
+	Exclude []match.Matcher `config:"collstats_latency.exclude"`
+}
+
+// New creates a new instance of the Metricset
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The mongodb collstats_latency metricset is beta.")
+
+	var c config
+	if err := base.Module().UnpackConfig(&c); err != nil {
+		return nil, fmt.Errorf("could not read config: %w", err)
+	}
+
+	ms, err := mongodb.NewMetricset(base)
+	if err != nil {
+		return nil, fmt.Errorf("could not create mongodb metricset: %w", err)
+	}
+
+	return &Metricset{
+		Metricset:  ms,
+		include:    c.Include,
+		exclude:    c.Exclude,
+		histograms: newHistogramCache(),
+	}, nil
+}
+
+// Fetch reports the latency statistics of every selected collection, using
+// the `$collStats` aggregation stage.
+func (m *Metricset) Fetch(reporter mb.ReporterV2) error {
+	client, err := mongodb.NewClient(m.Metricset.Config, m.Module().Config().Timeout, 0)
+	if err != nil {
+		return fmt.Errorf("could not create mongodb client: %w", err)
+	}
+
+	defer func() {
+		if disconnectErr := client.Disconnect(context.Background()); disconnectErr != nil {
+			m.Logger().Warn("client disconnection did not happen gracefully")
+		}
+	}()
+
+	ctx := context.Background()
+	dbNames, err := client.ListDatabaseNames(ctx, bson.D{})
+	if err != nil {
+		return fmt.Errorf("could not get a list of databases: %w", err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, dbName := range dbNames {
+		db := client.Database(dbName)
+		// Views don't support $collStats latency statistics.
+		collections, err := db.ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+		if err != nil {
+			reporter.Error(fmt.Errorf("could not get a list of collections of database '%s': %w", dbName, err))
+			continue
+		}
+
+		for _, collection := range collections {
+			namespace := dbName + "." + collection
+			if !m.selected(collection, namespace) {
+				continue
+			}
+			seen[namespace] = struct{}{}
+
+			stats, err := fetchLatencyStats(ctx, db.Collection(collection))
+			if err != nil {
+				reporter.Error(fmt.Errorf("could not get latency stats of collection '%s': %w", namespace, err))
+				continue
+			}
+
+			event := eventMapping(dbName, collection, stats, m.histograms)
+			if !reporter.Event(mb.Event{MetricSetFields: event}) {
+				return nil
+			}
+		}
+	}
+	m.histograms.retain(seen)
+
+	return nil
+}
+
+// selected returns true if the collection must be monitored. System
+// collections are never monitored.
+func (m *Metricset) selected(collection, namespace string) bool {
+	if strings.HasPrefix(collection, "system.") {
+		return false
+	}
+	if len(m.include) > 0 && !matchAny(m.include, namespace) {
+		return false
+	}
+	return !matchAny(m.exclude, namespace)
+}
+
+func matchAny(matchers []match.Matcher, s string) bool {
+	for _, m := range matchers {
+		if m.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchLatencyStats(ctx context.Context, collection *mongo.Collection) (latencyStats, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$collStats", Value: bson.D{
+			{Key: "latencyStats", Value: bson.D{{Key: "histograms", Value: true}}},
+		}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return latencyStats{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		LatencyStats latencyStats `bson:"latencyStats"`
+	}
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return latencyStats{}, err
+		}
+		return latencyStats{}, fmt.Errorf("no results from $collStats")
+	}
+	if err := cursor.Decode(&result); err != nil {
+		return latencyStats{}, fmt.Errorf("could not decode mongo response: %w", err)
+	}
+	return result.LatencyStats, nil
+}
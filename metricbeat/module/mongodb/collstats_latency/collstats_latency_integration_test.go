@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build integration
+// +build integration
+
+package collstats_latency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/elastic/beats/v7/libbeat/tests/compose"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/mongodb"
+)
+
+func TestFetch(t *testing.T) {
+	service := compose.EnsureUp(t, "mongodb")
+	createCollection(t, service.Host())
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+	require.Len(t, events, 1)
+
+	event := events[0].MetricSetFields
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event)
+	assert.Equal(t, "beats_test", event["db"])
+	assert.Equal(t, "latency", event["collection"])
+
+	writes, err := event.GetValue("writes.ops")
+	require.NoError(t, err)
+	assert.True(t, writes.(int64) > 0)
+}
+
+func TestData(t *testing.T) {
+	service := compose.EnsureUp(t, "mongodb")
+	createCollection(t, service.Host())
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("error trying to create data.json file:", err)
+	}
+}
+
+func createCollection(t *testing.T, host string) {
+	client, err := mongodb.NewClient(mongodb.ModuleConfig{Hosts: []string{host}}, 10*time.Second, 0)
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	_, err = client.Database("beats_test").Collection("latency").InsertOne(context.Background(), bson.D{{Key: "test", Value: true}})
+	require.NoError(t, err)
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":                    "mongodb",
+		"metricsets":                []string{"collstats_latency"},
+		"hosts":                     []string{host},
+		"collstats_latency.include": []string{`^beats_test\.`},
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package collstats_latency
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func loadStats(t *testing.T) latencyStats {
+	t.Helper()
+
+	content, err := os.ReadFile("./_meta/test/collstats.json")
+	require.NoError(t, err)
+
+	var result struct {
+		LatencyStats latencyStats `bson:"latencyStats"`
+	}
+	require.NoError(t, bson.UnmarshalExtJSON(content, false, &result))
+	return result.LatencyStats
+}
+
+func TestEventMapping(t *testing.T) {
+	stats := loadStats(t)
+	histograms := newHistogramCache()
+
+	event := eventMapping("shop", "orders", stats, histograms)
+	assert.Equal(t, "shop.orders", event["name"])
+	assert.Equal(t, mapstr.M{
+		"ops":     int64(16),
+		"latency": mapstr.M{"us": int64(1450)},
+		"avg":     mapstr.M{"us": 90.625},
+	}, event["reads"], "no histogram is reported on the first fetch")
+	assert.Equal(t, mapstr.M{
+		"ops":     int64(0),
+		"latency": mapstr.M{"us": int64(0)},
+	}, event["commands"])
+
+	stats.Reads.Ops += 5
+	stats.Reads.Histogram[0].Count += 1
+	stats.Reads.Histogram[2].Count += 4
+
+	event = eventMapping("shop", "orders", stats, histograms)
+	histogram, err := event.GetValue("reads.histogram")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"values": []float64{16, 128},
+		"counts": []int64{1, 4},
+	}, histogram)
+
+	_, err = event.GetValue("writes.histogram")
+	assert.Error(t, err, "no histogram without new operations")
+}
+
+func TestHistogramReset(t *testing.T) {
+	histograms := newHistogramCache()
+	histograms.delta("shop.orders/reads", []bucket{{Micros: 16, Count: 10}})
+
+	histogram, ok := histograms.delta("shop.orders/reads", []bucket{{Micros: 16, Count: 2}, {Micros: 32, Count: 1}})
+	require.True(t, ok)
+	assert.Equal(t, mapstr.M{
+		"values": []float64{16, 32},
+		"counts": []int64{2, 1},
+	}, histogram)
+
+	histograms.retain(map[string]struct{}{})
+	_, ok = histograms.delta("shop.orders/reads", []bucket{{Micros: 16, Count: 3}})
+	assert.False(t, ok, "histograms of removed collections are forgotten")
+}
+
+func TestSelected(t *testing.T) {
+	m := &Metricset{
+		include: []match.Matcher{match.MustCompile(`^shop\.`), match.MustCompile(`^billing\.invoices$`)},
+		exclude: []match.Matcher{match.MustCompile(`\.tmp_`)},
+	}
+
+	assert.True(t, m.selected("orders", "shop.orders"))
+	assert.True(t, m.selected("invoices", "billing.invoices"))
+	assert.False(t, m.selected("payments", "billing.payments"))
+	assert.False(t, m.selected("tmp_import", "shop.tmp_import"))
+	assert.False(t, m.selected("system.profile", "shop.system.profile"))
+
+	all := &Metricset{}
+	assert.True(t, all.selected("payments", "billing.payments"))
+}
@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package collstats_latency
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// latencyStats is the latencyStats document returned by $collStats.
+type latencyStats struct {
+	Reads        operationLatency `bson:"reads"`
+	Writes       operationLatency `bson:"writes"`
+	Commands     operationLatency `bson:"commands"`
+	Transactions operationLatency `bson:"transactions"`
+}
+
+// operationLatency contains the cumulative latency and number of operations
+// of a type, and their distribution in buckets.
+type operationLatency struct {
+	Latency   int64    `bson:"latency"`
+	Ops       int64    `bson:"ops"`
+	Histogram []bucket `bson:"histogram"`
+}
+
+// bucket is a bucket of the latency distribution, Micros is its lower bound.
+type bucket struct {
+	Micros int64 `bson:"micros"`
+	Count  int64 `bson:"count"`
+}
+
+func eventMapping(db, collection string, stats latencyStats, histograms *histogramCache) mapstr.M {
+	namespace := db + "." + collection
+	event := mapstr.M{
+		"db":         db,
+		"collection": collection,
+		"name":       namespace,
+	}
+
+	operations := map[string]operationLatency{
+		"reads":        stats.Reads,
+		"writes":       stats.Writes,
+		"commands":     stats.Commands,
+		"transactions": stats.Transactions,
+	}
+	for name, op := range operations {
+		fields := mapstr.M{
+			"ops": op.Ops,
+			"latency": mapstr.M{
+				"us": op.Latency,
+			},
+		}
+		if op.Ops > 0 {
+			fields.Put("avg.us", float64(op.Latency)/float64(op.Ops))
+		}
+		if histogram, ok := histograms.delta(namespace+"/"+name, op.Histogram); ok {
+			fields["histogram"] = histogram
+		}
+		event[name] = fields
+	}
+	return event
+}
+
+// histogramCache keeps the last histogram of each collection and operation,
+// to report the operations observed since the previous fetch. MongoDB
+// histograms are cumulative since the server started.
+type histogramCache struct {
+	sync.Mutex
+	last map[string]map[int64]int64
+}
+
+func newHistogramCache() *histogramCache {
+	return &histogramCache{last: map[string]map[int64]int64{}}
+}
+
+// delta returns the histogram of the operations since the previous call for
+// the same key, in the format of the Elasticsearch histogram field type. It
+// returns false on the first call for a key, or if there were no operations.
+func (c *histogramCache) delta(key string, buckets []bucket) (mapstr.M, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	current := make(map[int64]int64, len(buckets))
+	for _, b := range buckets {
+		current[b.Micros] = b.Count
+	}
+	previous, found := c.last[key]
+	c.last[key] = current
+	if !found {
+		return nil, false
+	}
+
+	// Counters are reset if the server restarts.
+	reset := false
+	for micros, count := range previous {
+		if current[micros] < count {
+			reset = true
+			break
+		}
+	}
+
+	values := make([]float64, 0, len(buckets))
+	counts := make([]int64, 0, len(buckets))
+	for _, b := range buckets {
+		count := b.Count
+		if !reset {
+			count -= previous[b.Micros]
+		}
+		if count <= 0 {
+			continue
+		}
+		values = append(values, float64(b.Micros))
+		counts = append(counts, count)
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+	return mapstr.M{
+		"values": values,
+		"counts": counts,
+	}, true
+}
+
+// retain removes the histograms of collections not in namespaces.
+func (c *histogramCache) retain(namespaces map[string]struct{}) {
+	c.Lock()
+	defer c.Unlock()
+
+	for key := range c.last {
+		namespace := key
+		if i := strings.LastIndexByte(key, '/'); i >= 0 {
+			namespace = key[:i]
+		}
+		if _, found := namespaces[namespace]; !found {
+			delete(c.last, key)
+		}
+	}
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetMongodb returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mongodb.
 func AssetMongodb() string {
-	return "eJzsXV+TI7dxf79PgVIeJFXt8cpOKg9Xjqoky46V0lmKdC4/pFKz4EyThBYDjAEMefSnT6EBzGCGmD/8u3vM8iGxbsnGrxuNRqPR3XhLnmD/npRSrGWxfEOIYYbDe/LFB/sv33/3xRtCCtC5YpVhUrwn37whhJAPYBTLNckl55AbKMhKyZL4HxENagtKL94QojdSmSyXYsXW78mKcg1vCFHAgWp4T9bUfgeMYWKt35P/+UJr/sX/viFkxYAX+j2O9pYIWkKM0n7MvrIElKwr/y8JoAjWoyod6IX/QzxCPIrlSRtqdPOX1Fgj48VjegExKYilybSxYushsZ+uRMKnjzHG2QiiC/IJ9jupit7fRqDaz/fU0CXVgKRbWMlxW5YuN/4fWzHNQGD/7yXHLpdMUBxcrkgRREFFEU/fDFxGGsoXhpWwqHUSIJdifRy6j5Ym2VFmVwixtMlKKsJl/qQJE6RkuZIacimKjj71UeWyFuaimERdLkFZkVkwCJHAFoTRE2KyX08i6a8vMrACYmIKaDEg8lEWZ7CJrFqBB+lbwdvx5kj/AGNqAi6B8K/NNDTQZsxFDG+nmIFbyhAHPFaIDuX1pdiCO0Kl/1GDYqAvvfat4FQthBWcH2Lemg94LrnqWxEFKPAJ8tpAMSGcNZhSqiENO0s4VD8FpbJDkLxW2i5SuZspqIDtOoKyHGtCAyyqn6ynFMDanWZCdExoUOYaknOUrfAE7Egh87q0ej5Pah7WdYQWsPhR5i3AuirooBE7S1BI2crpSBl5RFeWkRtlnowUlHJ7FRkVwOEUGXlEV5YRopspo1yWJbV4ryAlZy5RTMHHDMPNE1cD7koCO0B1aODbw8fZRyRPglRKbllh7aQgcgtqy2Bn4VBSUWVYXnOq3NGvQbggHzdMNzPcIcs0KaU2JJciByWgIDtmNvhTspW8thYZqTfEzjqF0e06k8vfMs3+CYvl3sBslVlJVVLznnR/NHHkSlNnwsAa1DgRy+91Yc48kSZ/u2IcrouOiQI+3WCIOZTTx9q6zOCTsVbqRApy+RvkJ/9aG6no+sqzIDTSz8rlokyryzRQ1GRUmC0oPRSIOOU0WdLfpJp3vBimwcRJNMLvnQpkKwWQcabTdv4U5kRdnsnaoGYMEIqwJTWkEZmLh03uJmnzPLLH/NrG3MyGGqJgZQ0pMRs8Kyh0oXysx+5G4LaGcOZ6dBHHR+uKGirybtSpceSZcOwxKR6IoU9AKOFSPhFqyMaYSr9/966QuV74AOYil+W7koqa8ncKVqBA5PDO77rvXPDUIq/1u3/xoVT8r8WhnMa2prCNz1agCU/hF6ikMppIgfKzckt5DYdH+I8b8EDRz/HBz8bLoAqQoEWt046InR+g+YZsKa/B7vE0vfsjg26mHVhL2PTiVQf+DaGa7IBz+/8RSfPVFWUciuC6STEWT+kztviD/1/fLDwZvbEH0+4I7ozYfDWMiEJGX8aqzKFMRwZz7M4Zq+F/fLQpw8J0poGvBm1CStfG6Ma0nTiSX5kwOb0I6PEkGhdvvVawpqYfc74bBpc140Vmrdi9cmgd6Kx/YrkzDoVwR4SskvLumR2LAn/mzBXL+568wjpkIr/b+VsxkQJwF7ytwWR5WWScCchkdb9KahnlVJsMlBo5zd0DlzI1xN2wZw9o98xfRRUtwcDd6uhGanPXvqm75rpb7nRWUn3H+om7xHCA/t74TIaU7odJpk0Wwk9368Uhl+m7irvhsWJJ+vfBm5Irxu/WrVFQcQ0ms+6NWrK7XYYxny7Af++cboAqswR6t95OYNSl5WSV1CyRKH037LrLqTtXXjeX98rdbkONLve1YvfC4ZsUDZeB+WYue5MlA8JQJrTLKlKwpqpgYh3yPN1NMhUFqbvpRWTGZZphJRSZrK+R5fwxdRmKmP39+IZuwSEgsjZEM5G7u1m30q3nkYPWlkPVyQvrcyErON7qzeBgSPJ2vMDKIap5uipkZllPi55M6+ssBoifhnYCYuguV80KXCIV8v13/12D2i9+wv9cCPnRISQaDDGSVArTGokHnmK94xOLUxfjicw9ujEfJyfobIN2BsjOEgiVW0yTkjLUtpBinnM2I4V0IC/hjLQLzFlp0x0JzXERWgNTyoKtWO6qlypqDChxIN8pk+MSZIf97MsanDZH2I87bEVcaOwZkIWBx2p6TK3Sq+m60MLAZLlvyjwmfJfbg/TjTheGDF+ynLxe7IqxInLJL0iaOA8VU2A2YI1mDrhtuGIfWYHy9X+ikxrWXqofu6SQcLajzCzKq9RUNeKnpayFCdk9LpGcc+YTyZ1Nszy51B6yoZroyjJXgVpJVVoxrMH8SLX5E8qqkUYKN3E7lJObT7EmX7EFLMjua7JWQA0oO6ggv5uo5XLSuVZBV0o/B9h0LFErlpytGBSXYc9vydea/X5WF050F3jEYs+xo5pY70nX3IRFsfN4idko0BvJC+tfxCKbWM3NaJdayH+RvNAu1wOUxh1YwxYU5UgTl7Ovf7FboTWG+5jnzna+oaLgoEmtrcLjVFMerXykeOwq1zkVGRVFJlUxcsNxWS0O1Xc+zdGaOqKl3a38l/yfciqEbJa58+KlMhHPThZUuLz1KX3OpVhxdphefhM+QXg9iFengzOjNHOfuUzHy20yIRcWjyHY+8DK16lgkyxK9F4bKE/RKgFFiNDf1ETimIQZKHXAQYpaxQWozVp7W3EqCGwpr2lqjzzkqHEQbspT65aczlFStRRU3Pvel9Yrn95LFHDrSFlTbBUsGjGcxYdTg+Mv06oCqjB3m3IenIGQ1q4fsDqOmI3U4BYcVSC+NKQEZ1GwvBzJ2ZPn0YZyYPmNi2qGuEg3LX1LFZO1jltt2F2jL7mA5tRAQdiSRg+pQxxNDRIPhGf7LEe3Y+jcTuYci/skret1GXq51R8+JIojiVlYFyKl8w0U9XDskcyaKDJjsuJRBZi8HBtyJhekkw+6k6rfMONMmvAp57Vm21RY+wyyFmiWvN05k+hlKa4o47VK3n0fQTVyMOpBWpezBUxklZJrBXp6hVxYpS8/Ay9cpxsjwgGqYUN/JDUFtNhfhtRKwRibMzW3FpqtBeVQZK44flSJJ8m1W8vU3jhJSm9qbAmWFXKXukdoSS2l5EDT3+mpcMbsrr2i+ZDoHMV0E6mWGq0qnprGSzoy1mOx4wSPxV0ThINtc96InRpZcbk+1aOhxkBZGZ0ZmS0hlyVkLoBE1ZDGzpzJJTX55gzzODOg35MdCqMjwXBzZU9lnjPr+s/0b8NnrrEeOt50+Z4wbTNZJwPHHi95JwUoCM2V1Brd/5DhNshn9zoyHcK8Pi+Toc1EVBNXp78RPFgvg2skjmKdaLqOunvqz5XT1wiyn7ThI/WyXq1OSKqdgTFEzNwI+hBb0vzovciJlrXKwf+SLGElFcQzYgmBMKGPFXU6mp6QxCl24Qm3HUPIju7xbKxo/hStfPfFs853N9CCcM3QuQsOUmZiFj8t6pKOdLe4BvySfmJlXWJXgBBF9kBdf5aoWDuXGGowoddnYM+t3wcMwTBNhMRLlxVb14ou+UHyRJfjm3IbJivmNpcC23WE/x6frNaTZ4ZRntklcz0/IgzjV+ZQita89VBWQzfEZL4r4HKBsuBrnE9s5Aw3I/Np+GBzMU/OD4GtEpb7+RG8edNyK83vb8Y+9YYWkzvB+PL1vfdu7Rv6YSNb+0B2G5ZvsBGFgn/UoI0LHNKiwPxNyv1lWd+XSOeVtR+qsYlV1z7MVAHymfmbh3L9nJxLO+9MuUi7r+aJLncOeXuZXmTjPYwszxkrU0EBSk8Enq/vD7vrNL9EQBOPZ3hnrRRwSY8vGDj+cP5YKXi7ApNvHu3eugZrQ0BB4z1bHDq6ekIHx13oESaMJL98+8HqGiutN9udIrNRsl5vqnQ+35ytoZD5rc1qy6plHQrHZQmlVPtw4eaTb5zgnNzuwfIdsD7MbhRoSF+Z9vm7mInM9bkm0h2wLYvdVp+9Te4UjlvXeKgojVxRdeNL76OUd+Zsfk667GQRDuleGst9Eyjzh/pEV9wLqAF5kb7BQz/y9NBbCl2ZnSiHNykh+JaMi6YZ4EIDVfnmTUoSp/QEXNb5E5gMPm1ora+XKZpMVotid/kG8ifrfG0A7zawRBXz8mRtsKMKZioRXTNDl3xPOFVru2nmUhWEci6H1Kp1aZxffyMG47gkzphv/E23lHG65AnsI2m8Pm/l6tjH0KVYmnoPw/Tz+M/Oi2ni+03G7sp7uD726LsSYts/w/3SHDhlzcyLf/a0JY/D+fNRq4GQuNowOuIZU+tBXxX/4RoHsqT5k51tUTQXMK7nd+waH8FWp86yH9JKK9fRPUNb2oOtqaOrIh9ZiyU/1pMTDzaXWhTulDQBsr0c9FBDtINhLYFisA2qVSwXazC/tL/7QazkV18fnb/I/gkLbzmON1rpTrGzZUIGNnJdUWcdijgS5+eZCTfciAW2PNVjPSGux06alUbAcW7gID+DjK2Y0u6BCW1omfKrz7YNgXawyzikz6oHqjgDbb6OTLq/Gkis8UEuOL01E3ZEzwOn5nwOdkwUcncl01ywle8yTJZgdgAimggqCsfNCP4pNxVMJi76KNdH3w+43d8bizuJBWvSEzXbDk7iD3ML8OLSIDwR09x6SXg4lHGZLLq3IW5VNAz8CuY/wbjOzqEJ8WRRBWr1xfz9posPM9fx9n+Ie2E3AUi7J1WS+TMVdhrG8+RDVMLrrgO1sb6nlXBUm+FKk+3hEwSm/xurwZJQ17CdmX1/V/bH1WGD7i/YP0sJhIwOVLqG0uhS6bNfuPvVz5L9SAHsT36TtRKU9wgfbzg4vZhj9j1wum9MLR0qM6wUK6nau9iwsSah2ju1Hkq+mn6/4NOpDorViEQlwwxukePDHcaZLjsPMZ+ayx1oM8hhxAsb7iDwMnhZUW3SvCQVbAO0UFL2H2E4XcvSu7pH+GXI3HGeBQLu3wVxug7r5B407jTOh2fxgNcXpJEnzvLg6ktqrN9FL6WwP4eTavRgBqFLWQ/VGblBmiMb861VjlRRL6rFRh48pkIms35nzc9f7K5Fi0JhhukqXoaTqJxpuQ6un/pma/h8GxQCpTQcJT0Lzo9MuwNsGIykBzsElXaDLyolHSNjibEOUZ0TTVNjiP7YHPVn4FGQyy0oJta3mLt2tGbNjk9iBO9a0cdGXAGRP78+tmM/Ji1HXArxJORO3EKCHuSXTm4NVo9gLtCbyRKDsXPRYV+ouvr9LeTo8xvZP+frYgPv5poYRp4SIFVLZkDdQn5+qCmZBURXF1nAM3xmvdECfSxQ0+epVHGTxdiFNGxrJedLmj/d0Iy1ttaPPaXhDcbb7wYzEdZiA5SbzU18od5ugNbWD0/+g6wo13OAXl2WzVAHOhjZ1jnXcSODhtQ5H0T1dxmhDiT66vGvtI69E0k5o/1JrqjZuFAyy2GR/vWE+H7wvRXC2OknfX2Q+Ehg4T47EXOfEVcPIewg70CN/Cy1ZksOrnDDPcyHt/6aSJV8G67tM5ZO1xnUv7nCc4T7CThpEFzmlGeJ09Spgf8fLUEf6df+RcH2/rBpFOixToQXqdagjF4oWNecphvRHC+o9vVmT9ePg/folZJFnbeI3cJKCy/A21ElDlunnw/P0z0XXqkvD63UZ8Oq9UEt3vm4LNFzgdk9zx6BLrUwI30LlI+BmMTadtm7WKTp+2532Pjirm0FyEQusf1dBIDQxONdPi2KcX/RhOnO4Y3S1CxMx6Q8mits2b3WphFr/r6kh9yn3/jWpli4l6TraTKR87qA7mWo3gDnRIPGjY78UQrNCnCNd1wRoEy9DkrIY5Nx9ogXH0XhXtTFwrpPprnhKqipD/poRdoeqFxBnj2B1gJzSNpMuaQWxXJOU82pCJk7w3xh5sdIGca5vH3rUo7x9MV5mhU/eveG+wg96Yol9Jh2naVzLvVkFiF8MoqmXoA6PQ7NqcGeeL7jY45zdewS3gCtslrT9UWfxJ7NBOkkOmFVakj7sfNp0SWSnprFP1p49pPg+0jFpSB/E+zTux+ZqEdzDNeQrWjNb9Yi0I5I3IihHSJWcZGC6ae4WIv8TNfpheh/jc9wWx3v948MFFOW06TeJCOhPTNWjyENpsWXpmMyCM0N24KvMJhqk8vlkvKMy7xfrHqJdFpLtn37PGZz7oLo2ys8CiSLjSdz9mdl66MmtHn5uZJtXn7TsL6ZK0xt8v3q8XqsMWgb8KL9UeZPQwUJWMjONMGKLL4nVh22lGMKkAyLz6mDPwSNyMfvlxm2jBqRz3CJy2wBJasCcdyCLCGnPk+a4vynmR8vW+nM+cB3ZtVozK7QmCh59MztKDOhYTsm6ln9xkS9B7eB1aXv+d5OxUIBLdCVtfrR+Qumbih9kATbF4OncDNBJEUQOjcGHvg+SMUy1pcM1ogOT3+v/+vnxZvLuBlirvEb0Qpn3vm91YL0HhEUweuObu2pZ6ebMKRDF99mI9dw+BpB/Hlxa7e3brHBldsDHR8dPzFqTY4ikepAIi93PR7J2myOnm8VzuZofJKiON3TxU7637bPYnh/zXk21hAAzTfO/v/B0v/mwflkwen5QykL+CYhd8t9FWKh0e9dRNT5DA+Nh/EQ1a48kBIMxVHsMh7oN9Wh71G45hMPZPdAfsHf/t2XaSjQlQLtjtlUQfHQdjq0HpBp/+KLzPFfmu+M1ADgRCwcbwuao6PrrhIWDhXRG7lzp9lUdQ+KZkc18b8twlVL20nfkjkUQGfg9hmA+aPqANddUCAQ/Bf33pru9gtPdpUNTlBDkuxAAdkAL1xvqtBgHK+XZ7JR60Me8rqsOcXVY78T9TJrPdeutxJzMjFqAbTAXe7YaRuUVqB4dLWcWxeDJ79zXhHr6uZQu5WZnYO6xFIlFycT++WSxP5+HrFoWZ0psIjSmdKKKJ0pqojSJeRU64sIqdYXkVCtLyKeWp8rm551OVNEPWpnSqpH7UyB9aidILeG0nDY99UQvhrCV0P4agj/fxjC9lT0agovRezVFM6j9GoKh8m8msKbm8ISDM2sY/hqCS9F7NUSzqP0agmHybxawptbwlT/I/JqBV+t4KsVfLWCd2sF36TIpd8feOakRiaeNZmxbXIWnk4wiq5WLH9okhsfsD0M24ZUCJcdnExYi7ad2rx8vvCO13fMmcXVlXua9nOtmqcRuhMwM8tfVnrBqQGRHxazn6z0PzUtdRrSzVVqkwGIj53vNjL1nAxmuvZfw/VJBKGdkFEsP3YdKaBF4Hf4sbgz5gfnJpflkgkoPPf7/rXymOpYfFdrB3qgOZ1MlyibyXdCamOFPoXT10tPvEn+kiXsAd5KxCMZYyfL2Leoe8lSbiDeSs5hwFPkO2gXBx6UPj0pqtsJtrGEkXpY072vZud4t/6BBnW7x+jtYN1nTfzO0yZ6O+OdpBkMeicHfFiX8LmRG7EWnp4fZKgLPkl3kiFs0n+NmqgUR26wm02W64l9I97cYDfjzb82dCPmDt82ui533oLeiLvGXjOt67aMrS3luQCHg3Y9dGE7KN67uGXvNvv+rKx8K6RZBj9JN2UzX5bBj7ictP2XWGU3tf0Rc3O2gQtO4U23gYjNOTvCBdm87Y4Q8Tlrc7ggo7fdHCJGZ+0TScJDPtvMfcKVgF5qf8DGB66lZlyg71+ywmrlUNHhMZ/Wv2CZSqk/f5L+xMwGFPn3fyNSkX/9/QMpoAL3+I8UviDCULUGQ6jKN8xAbmoFWITQFB0kKUePeHnGc1lWjE88w9nGSzQrQJhFubySbrYhwl++/eBKW2FNXT33Vx+++/ohKnxLVXQnCU/ytWXK1JRfha2WqyQ7chVGb9WzZWt4J5zkqaRVBcUtZsqN5OEnmUzNVLrQ6ztfjxLaCtUatKf81o+zYhz0gwtLNm9rc/YEHNuGLwfsk/3LUP1yt4GAXJG9rFUUMEhf54S/T05CtmNmk/kO7S9jRlxjisYWpKyv++DUraQKDeaZWKfr2TD0li1p/qRd0XDa219KyYEe2UXso6qB7DautbcCrBLrP8VPQ5OuZvvxhbEWvAKj9oPQ/ftyGYg1E5DqJ+awn/SGx7dEG290XWGeK2PzhUnR4x5tax5EQzya8X1zxxQUmWHrgc5LJ2yevxpqmDYs19EW+nc7zkc7zCA+95ls+SNFKC03igpNky2PxhmYwcQgI/Gg0V7CcPdbqzMeIscFsJD10NuVE/ers+tS/xqXUAelcYHviLkpjvq4x5oH3Qo9y5/A6LZFyBzcodkG/vRm2N1u0YE99ZY0LZ5LN/De6STVQNTPqhkO+3GKgaifUy9i0COHPJonD43XsXs4GpZGY7sg2LI82jybb5xq/Er6iZV1OZheQOaIeyrNYCb/9vPB4fFs47t1o/itm/NiwP8atbGK2xF196vB+YriQkyZ/Ytkq3kgAiE67nzZ/Ay+rAprbFdzs5WNQ7qVja9iH4UUN4obQw3vQ02v7z5atA0DAYTr4fWjTuINWE/JoD3VeLqNnm5cq6B1c55tveJT7SZappeyQN0tP76RGi/VOW7YS2Gh1SpnZcIqaNZsshUJ6e5k2YpxyF7U1IT9zCKbsZ2teK03pwM/Wtg43mQXn25S0M3AzWua1KzInCaPo9fBZkebD20v8htC24s8CS0ZiGjfuM5QGYa6Z58fzQ9PaHdChEb6aXb9JiX2vnT9Wb1zcSg42e3vuZIqSn7EaE3ojPPhw7c/b393ZuRjeE2eG/wLHWzDm6s+B2u4900T2dwctNYPzCBYfPreL9dGqIMM4uHnsPv9JThMXVvFrfDJV6X+2jEf3W2Et2c1dttNEtZViD8wsfYvGQf9/bqnTN81vZJcM8yDKKb70KWWvDa+5/ODPc0e9oEmj14dHvFM9ki3gCHIUj8ONVT1fZLJEoyd6dAc2iX6zukOjSNcb378AK6zk5NrkGSQIalA+b0CtiBGXmbFp6yvB7WJlEcdVFttemg1Ca8we7tbkqyR8smymcuy4mDGHFeqTbZigtkFNsjgQN7CrHbWJvlMd0BW9PmZsOxt4P9KBr0d4K0CjvfQUXAfQxYuyxTflyD/NYSHdF5UdNa9qoAqlw1/kN+eMPEHFLsmH8Fg5+4WMwFht4+D2/zpQHhZXuf6uNe2MA54DzwaHF0LD+ZZhu/ifHv02GZPbSkf1nb/s9vcRIYwQve2K+b6kOMkWVwwJ3LsLsKMxFJ3PEroF8A7HqsDR276k0SRDILua8Wp8rB2R7lHEa4khGgEgmajEzRL6/x48g0zOmMic1OZaDl+CeTNq1IubR4HdTZJ5hjoK8huY497NOrjizkeG+CJ5CFH05FhovsrJgrM8CG0cZuFLIDUwjomlGyAbvdkOEzFpW/Jm1uH0FrTVa0wSaVgdC2kZiOBbqCK77Ob2Tv0cgOXvlrLerJBY5ewkr6Vvc43UNR8INZ1or6Pv9F6Rmjqh4P3gvE41O6NvZym5iJ8wthtqWKy1qTaUO0Cs9Hu5oOygwYgSXFYSPOiYsWA70cueNA99AMxp9inVwX98Ucp3yjf6VaqorT9/C2cEJBRa3WC4955zgWIxPXjSLrvugfHJkK0CqqMy3W2rFcrUM8iJ+fcWyRUee/eX4VOmNjw+bWknIMKL6w11skfbYKexW7fdEDJbrlNjs2zSYXmpqac75szZU8m5M9s8CaVEL3XBrB4GQrn+hawZTm4FbWi1m3NqSB0tYLcnCCg2Cd5NhlFooncDbqK5v4gVyf+/BmDoFcSlYKSVlml2JYayLYMds8oKQRTuceIqv1bKd66heZTBV2e1yBZC14vLr7a/E7+jGKxDkhAMQdq15d7GcDHXL1BwgMu4Jv/CwAA//+2umBX"
+	return "eJzsfW2v4zaS7nf9CiL3ApMAJ2rM3Iv7oTE3QCcZ7MwiPcl2ejEfFgsdWirbzJFIDUnZ7fn1i+KLRMvUi30s92nnoBuYSdsuPk+xqvhWLH5LnuDwllSCb0SxSgjRTJfwlnz1Hv/lx++/SggpQOWS1ZoJ/pZ8lxBCyHvQkuWK5KIsIddQkLUUFXE/IgrkDqRKE0LUVkid5YKv2eYtWdNSQUKIhBKogrdkQ/E7oDXjG/WW/NdXSpVf/XdCyJpBWai3prVvCacVhCjxjz7UKECKpnb/EgGKfz2qyoJO3QdhC2EryElpqlX7SaytkfbCNp2CmOAEZTKlUW09JIT0NUJIHGOIs1WE/2NBPsFhL2TR+2wEKv79kWq6ogqMojtY0XY7Stdr/4dOTTMQ4Feu2Xa1Ypzix0SsSeFVQXlB8rNwaaFpmWpWQdqoKMBS8M156D6iTLKnDD2EoGyyFpKUIn9ShHFSsVwKBbnghUpHUOWi4fqqmHhTrUCiyhCMgUhgB1wHOKKA8OtRJH3/GvKAUJgEWgyofJTiDJr49yMq3GsfFY/tzdH+CcZYB1wD4d/bbmihzeiLEN5eMg231KFp8Fwlmh/dQIsduDNM+p8NSAZqQImD4CaAGeOTDeeoONfEuML6eGLquhRNpyIPBT5B3mgoJpSzAV0JCUsoh6onb1TYBMkbqdBJxX6mojy2ZRSFjBWhHhZVT1B0YHGkmVAd4wqkXkJzVjIqj8OeFCJvKrTzeVpzsJZRmsfiWpnngE1dUA1LKMpIRj2dqSOHaGEd2Vbm6UhCJXaL6KiAEi7RkUO0sI4Mupk6ykVVUezTBbRkw6VRk59j+ubmqasFt5DCTlCdBviTRVJWUg08PyRTk7kRGD9ZEeEKSayJ3gIRNUgzMzdOR4P5+IP//1CQPdNb8/XH/43/+Csu3h4J3WwkbMyvUfIGosutFegXtOD6bOus5ZZXf6cVqJrm4Pu04/VAqCKPfsGVdh88xoHhBFfNXjlM4PJG5yfOnamlZy5BRN0HNeqPM7Ad+2UPHlGM52Ds3e6zoHXLIy/tI3Q+mjZLALUr1HJQnYN4H8ajXkiA7jZj4AvRrEq4DP67HUi6gYUJbJnSYiNpFcFgOYx9YwaNH5nSkq0av42ht0eU8D+HadUSdkw0iqxB59sJWp6SWSgt4Y5GcAD0pfljH9+gfaSDEG/rkLMBv1iPXILBS3DJEV4X+aSfuS3glU50APal+eUpwkEzSQdB3tYzz4D8Yn1zGQ4vwTtHmV3kn1pSrqhZwCzho4H4APRL89M4ykGjSQeB3tZXz4T9Yv11OR4vwWcn2c32W8+qWD37NNiJILUUO1aAIpQTsQO5Y7BH4JTUVGqWNyXFjWy+Ee1mTEo+bplqN7OOxDJFKqE0yQXPQXK/E4I/JTtRNrj5bKS3wmI7ILMPnOluk4nVb5li/4J0dRia/Edcbi1kRfVbcvyjaCPdJkBcOuMaNiDbz6JCkO+yMGfuBUV/u2YlLIuO8QI+3aCJOZKjv+ZNlcEnjRuyF0oQq98gv/jXSgtc9C+rIq6M/KxapdVqWn5UhrFkYzCYUDO0FxibNUyN8hX9Tcik9+EMZEcyGL9Ihv+9NYFsLQGykil9NXK8qS6AFUoYtIwBQVMW4uW61J9kimU8PI+MMb92m+d6SzWRsMbtcTPm5Y2U5rTIpbXgaGT2Y2l7vPxohp3iEU/dNOX58XyjPbNk3NIzO/CaPgGhpBTiiVBNtlrX6u2bN4XIVepytdJcVG8qyhtavpGwBgk8hzduYv3Gbqch8ka9+V8ua8z8V3qqp1ife606gSqJ9VPMgEYUiX8/QC2kVkRwo79GQfSA5HQq9HELDqiZNrk8L/99QqXdcEPUKn7mggsOoPmW7GjZAGF4fhwd/fGv62kLFgXrXmrOyVEObrnvoSzxf/EH3VfXlJVQuC8erxtO2nW9k3rx6Z/d//sudWLUVuz7LeCZmiLtV32LRslmLoMmk57TmKU7p62W/3hrU4GFqUxBuT75fMzWxuSGsq06ol+ZCDmdDKOQ80X4n/szM7hXgquGlUWGUexeGeIEOuuvWO6MIed2iZDVQtw92dOj/rshV6zuu/MKnJDx/G77b814ca/cNqCzvCqyknHIRH2/RopES6p0BlIKedcsxeae6eEC7Z751VTSCjTIk2/dCcmtUPqu56Y2o/du2amsouqO7dOMEsMb9PfGM7qldD8kmdKZ334q7ppl/KzibjjWjG/ulpsUePpxr/Qk1KUCneH0Rq5Y8XvgiSv+Rt070y1QqVdA9b0TtTeQslooFrmrcDd07eHUnRuv7ct7ZbffUq2qQyPZvTBMYjLsZdMk9uMLTkJ/EFxTxpU5qSR4oUoWeF7srrSiP9gz5eY4vWhMay1bVkGRiUYns9jOxuwPYk8OQw1mdz6+pTsw9RQKIhp9mv5WS5GDUj4NLh1kIWo4P+rNYDCkeWzPU0kvtFUuMqQeV/2k+mcS8N3QdUAIvbu1J4wU8uP3/9GAPKQ/m/9MucBLk6ZrQBMtTOIeJjE44OkowZpxDsVNyT3aNh8nO+hid78CSK984wK+SA2evVNmrM3fps9LNuO27EBewsXB5oPNWenSHQnNjRNigKlEwdYsN7knpKZagzw/t9neBS6SM/V9YcDxNJRvNx0EZrfGPgMy3/AwNAm6kfwzQPMNk9WhrWgxiNLOXW4P0rXbqS+JoRs9ZLnYXzBRCVWE/gzEnN/geKWb9gJ1LSEHM2D3rhr51CafGtYdqp/rUkZwhrVS0kotqn5a4b13n91jEqxYWTKXuW2HdeRkU3vIliqiaiRXg8S0NVTDBvRPVOm/GF11CeLRls0IZej5FGvyNUshJftvyEYC1SCxUU7+mM7RzvhR/nXtc4CmHXQpqiVnawbFdei5IXmp3u9oYUNu8DoGHlDsTewoJtBJUE2pvVPsHV6itxLUVpQFzi9ClXWEkxjrtrUkRvYCR/6rwIxBYyAgFSYZEwV4Mbk0Mo07u1IfOBRiMDyEnI+G8y3lRQmKNAoN3nQ1LbtvW4nnernKKc8oLzIhC5ALdXTfin2hIZfmiKGOKIGjlfuS+yinnIvWzU3gw68FnK0uKCdmLzgdpGmsypQrLNlpevlNeAJ3dhB6p4XT4U5i4FHMIbOZjkJeOxfWLABNmUfUr2mrSxYl6qA0VJdYFQdMyDM79NEyTEsp3bRJmIZKeRykaGRYa6v1tW/rknICmJJLY2PkKaN2gnBTTt205HJGSYwWbmu6uXcSo/EMu3LpvXghiaJna2EcOGjRr8VPte5+m4ZfpnUNVJrcbVqWfjLg09rVgykERPRWKMzFphqTsvkfNKkALcOEWicOC5OeHSgH3G9cVTO73KtMcLKj0txiC2rm4KjR15xHc6q6YSYhGz8kRb80xWiqkSO1YQWrLJcwMImf5TUxkTj1uo68HO2nVNcRhrCuJErlWyia4b3HeR01p7PCVjnovBprciaLUGax2gv5lIx883yZ8CkvG8V2kAx+9RKxCDSLnu48U+h1JeKhQiOjZ99nSPXS/tlAA8vHAsazWoqNBKVubdLX74EXbtNepCoB6uFAf6Y0LDV0SAa+dJ4ovBx4uSQvpeGKbTgtocjMsKCSZ4nrhhaQzxSlto2pfp4VYs9HRa2EKIHyUWnOhDO8Ji3XNIfot4euK/el0bouD8m5rnfeRAZnLLTuZixi3S3bVLfeCCc1oi7F5tIZDW4cV7VWmRbZCnJRQWY3kKg8PK8nV1Tn22eExxl6i+jOKONIg/7kCldljhkuZmbOb+foMmQ9tLyZqbyzqA8te5zmrRagIBSrVSgz/fcZbiqdZIK7QvEtzOW5TG5tRnY1ke3BnQie+Mugj0zVeZnFcybHWF8ZYCFk12lpMoRz1azXFyTVzsDod8xsC+oUWzT8qAPPiRKNzMH9kqxgjRWdgx5BksC1L9lNrY3GOySyik2d4K5iCNnTA25Taknzp8Dz7RfT5DwPnuO917QCf8wQ2kGrZcZn8elQV3SkusUS8Cv6iVVNRbBRv4vsgNr6LMFl7VyYrQbtnzXx9Kz/PpgtGKYIbljiPiPbNJKuShhnfFO2vrNCtrngWKshqMw71lkeNuNMM1pm6DJLOLAdC30zBJtxB2AX+0NVD50Qz1CzF4PLLigyP9d4vrCRNdyIlN6scLkeUL4JU2JidZi/gzevW25l+f3B2KXe0GJyJEhH8btnBsYpDPXC5XND12wQax/IfsvyLe55Egn/bEBpe9xEi8Lkb9LSHZb15xIuo2WwaapMEavj+DDTBKbMYO6INcsczlDn0BzmVK+TsF/Q5BL7nUm70+5u8wSHO6fcXuYs0qMbc88ZnimhAKkmNp6XZYKOYk6ZvIuAIg5PmgzhriWUghbJucHkjJDugshjLeFbUxj6EcfWDWAMAQnteTPi6FK27KmZO9AjjGtBPrx7j7bGKpzNHneR3krRbLZ1oy8dGrDa0Gi3XT+sdlSROiBbLfD8SMiDP3BzyTdWcafvD8xl+dIi3wn1YbrBRkP8yLTP72ohMlfPDZE2bQgpHr9q0hvkLmHs2ToPubHphofeZxnvzN78kmzZ6sIv0p02Vod2o8wt6iMPAF3BDBYxfJU/0/Af+jtPDz1XONbZhXpIYkpwJRnTthhgqoDKfJvENBFzjCHT8w2smvwJdAaftrRR8bF+VM3PSVYL9u7yLeT4DBj6GHI1V1RNXh5mpGFFFfRASlTDNF2VB1JSucFBMxeywC1NMWRWnqif19+IYLgvaXrMvXFGd5SVuLNxil0Ng3d5K4tjH0MXo9QhTmKwtS5nG+oE1l7Ebme63sskuL1HV5UQ/03r0oWzgVXWzLz4z5625HDYtWdQasAnrrZE00EqNcUZ9KL4T30cyIrmT9jbvPDTePe8WTg1PoOWp4MR9GRL60o1QzvZg6WpXQDHzUy3s5Ym42blgZuFTRJT/gVOYYRNgewOBx1Uv9vBFGaPSgY7b1rFKt2A/tD97m98Lb7+5ly3wR3S1EUOKK5TKXa2ToYGcvvaVqPsbMYvil0/M25LF6fjnBr1WejEqbQKDnMDB/kMElszqexbmkrTqj6X3pze8LJ9XDZNuqx6oLJkoPQ33eaFPxro+LRiB1mU9NYkStpywGcEns1gz3gh9ksg3wIp2NpVGSYr0HsAHnQE7kwYNiP4BwzJg8e7/dd9IO+jqwfs1R1E3Eks5k565M62ezji9IO5F/DCq0HoMXgRT9j7p1qE12RNBq0b8NysFgn8CvrfQNvKzr4I8QQbvAZagZo9YkzF5raKD9PLzPb/FtbCbjcg8U2HWjC3psKzQbuefAiu8KJ2zcsNeDuLh+boriYrskLLxdsIGi1YEGoLtjN96I/KbrmaJj14rSLcAfsXqQGHHTUQSBp1lT79wp6vfpH0AwNAvr+JRnJa9gSfHzhKupntZhN6+BFKemhDLXU3Vjo+bhVTS1ZRebB7wxqvOtUH7FRKhpKvpty7op/O7VI/QSma6O2tmb3+4+kIY0MX9kPIU5ViD0oPMgy4MP7CuayxsF2USxIjtAVaSCGqq1nZKc7Aqv7gM3fszMIYWeAQ2NWkpBvvJ/dgcZcxH+7FE66Mf+Fch70viRI2oXT+7GOCyi9+pRo8mEHoSjTteN0fxk0j7ZKNudIqZ5qoU1WKlUQH+28o63dW//wVRy1aFJic71XtWp1EZUPLMrh+7oetQTStQRgtqWXg/ISbumLdWR+JN3YKKj4NvqqWVIiMwRxUz9lNk2OIfmiX+jPw4FbtDvDA8RZ917XW+ux4Jwbwltp9bNXlEbn162PX9mM0cnQoG/7ExZ7fQoMO5B+s8bdYHYK5QG+mS7PHPBedqQvV1H+6hR5dfiP713xbbOHd3BJ9y1MKpHKF12duoT/X1JTOPKLFVebxDCIpbuSgj9jQ40yTKm7ijMeQBsFIUZZ45HILLfUt3Lc9ZeEtxsWVdinChm+Blnp7+AyjgYm2rnny/8malmoO0MV12TblASd9ML2N7qFlwkijPnXObaK6swx/D2TySC+2DPDgxt6JpCWjIW78U1O9xfmn3LEc0vivJ9T3N3PhIQffdhoF5jaJzwTmfpVG9twnUIU3Gby+vTTyi1CK4em/ubihTD60OfVXRLgneIs4jaaOp+sM2t9c5TV17OpXHASeh5VZZDV16cb/TyjQ7fTjYSmmIHTnh159vohGByqKDg/gpcb7TBt80TgK8XxFdQ+IO7muHXOOXktRNHmHGO0ZZFx5Ht6eSn5aOv358Jzc58Kr1PWhVerZsBoF8uq4UOhzgeGYh49qq6ujayWfAzGKtauyd7Wdph+Pq8OGB3duWDELiFxU+HEAADcLT6S5tChWuoMmFOev0EZ7YXpPyqE5+XykZ2bQbk9O214KqWkRQ+7Sb1xpU3NxLyrXpfcwnpdNAepYp1soS6JAmYGO/CC4YoWpA+jGEiJir4MS8thmnD2ag48CK5pKd7Huk25PuAqqmyod1GYrZQF99hTacMwC6VqMW1Go57jUnHKfuTPMy2R+jFzDeC63d/YlRXRnvBYepeJa99bjzH2+nRyrxdeYtpWl81KoySxC+KQljb0AdXF0+KWkGjegfcXH3PjEuS68BVpnjcL03KHknllHA1dJdDK3Un3aD/YnooskPbXO7yd9Ubk/8/IQmLjg5D85+/TmJ8abIBmvr5AaH49fU7wmd64uzuLaOSO2SGyLvhyiucVFCqaeuvNjnNrSTdwR3a/NM9xo492PjiXGIqcWSfTSny3PjN+2MpjCUmphyCA012wH7oZBp9IkptdNKVa0zEqRP13LA4J0WhTbvX0e0pzrEB1SG69wwpw2KvK9CSuYZQfOErq8fCxr0eblM573+gozXny9ehzd/S0y8y2r2p9E/pQm0bZMnMP3xs2NrPJA8JrgjpY46mFERMLeHNwiaEQ/brzMTMmoaIPDPXqmgjonCSzatFuQFeTU5UnjQ/VD5If7+6TPB74z2d+zKQ1FgFNyWH/IF2zHLkZ+NlHvwXS5aipX873rihSvS5qpLC96n5jUDalOkmD7anASbqaIqAp85UbPoTx4rVB+qhmEPNL9HTengy+Jm4E8SM4Ts1E4c3X9b+WQbkYEhZ91B6f21NE5ThhSvopvO5AraKfsX4bv9vwW+8iNgZbH0TzRscUONUYq5IlG0glqn88fz6Q2m9Hn88LZjMY7yRNBr1TJXDebwPyuvUji52t2ZoOBAGi+NTGA/Bnlf/dgZgXtpOfPlSjgu4jekX3t90KD39sdUTtneGhnGA/B3ZUHUoGm+Ilx44F6U0fyHQojWj6Q/QP5YH77D3dNQ4KqJSgkqLZUQvHQVTrEm4m6+8RdMjf/0n6na/4EB1JTqdVNSnMz0bVnWalFRdRW7DH0xm/34O/JHmvJ5654gjtq6Srpo5h0vOHuGYD5rSoP1x5Q4D9ZEPa9NXVcLzxaVdZPglqRZI/397dQGhq0LTCOUWgujUadcsibqimp8R6kGtQy62au7bh1wmSi1QJogf9wdrcNastLPPGLoSHFu7X1i2QoMA0NoWMjlRftOtuR7O+0zgyAcWH7awr7cE1h/3iesMCt5NUk7a8m6cPVJF1DT426ipIadRUNNeoq6mnUc3XTiy7yqtL2V5X24arSLtBbK8lNC14D4WsgfA2Er4HwdxsIu1XRayh8DYWvofA1FP5uQyFuCmW4K/QaCV8j4WskfI2Ev9tIGKt/9BoFX6PgaxR8jYL3GwWTmLj4+wMXH4ReJ6mR8c+azNgVOfNPJ2hJ12uWP7TJjVgyKAe286kQTLVbrukgLdHol8/LnPG6ijmzWC1c07Sfa+Wb63VAmxPcCkpiYEWtUqyUxvPTy+wXG/3PPnuCtKLbo1SvP4IH1GS/FbHnZEyma/81XJdE4MsJ4f2zc/0I008838MS/WP6JhfViuGDxq6h/rFyOoFvqWuD/WyfXqaLTxnBx+i5e0jH7xW6FE53X3qYgEk1eckadgBvpeJ+6s01dOxK1L1kLbcQb6Vn3+Al+k1iDDAuuuyLq4XFd8eVYNtIGJgHhu5DDefGNcbxXtkSeo4MOLax0KjbkadL9LbBOyrTB/SjHPB0kJt5buRG1PzT84OE2tHIgI/KnSRkivTDjRjZxm7WWbYm9o242cZuxs29NnQjcqdvGy3LzkXQG7Fr4zVTqumusbUh8RoMkxhNjOu+ChsUS0d23xJWVPvConynJNfuuCVG5cZi5ssK+AHLydh/iQ32ud009gfk5gwDV+zCmw4DAc05I8IVad52RAh4zhocrkj0toNDQHTWOBEV3PntFNkkxtheAU1iZC8YH0zhA1tSM7yg716yMreV/Y0Oh7m/eTNvhFjFUuqf30l/YXoLkvy//4sFZ/7Pnx5IATXYx38EdxciND79owm+QMM05LqRYC4htJcOopKDR7wc8VxUNSsnnuH0fCVgRQOu02q1AO3jLcIP796bbcEKNtTsO5Kv33//zUNw8S12ozsqeJLXjknd0HIRWh2rKB2x9q135tnRGh4JJzlVtK6huEVP2ZYc/CjJWE+dIsc/37v7KL6sUKPwGSkj+VvXzpqVuE+sj97WLtkTlKZs+CpuA+aTofvLbVD0z1ofRCNbpGTgaVv/+WQnZFjPLHMV2l9Gj9gCJm0siEVf+8d0HW4mO/hYYDWJUTVbbxlWllP20nDfbC3FlRAl0DOriH2UDeDLomaLCR/TPx6LzWkB9UW62uHHXYxF8PjEz2EQuntfLgO+YRxi9cTGSt1NYH9HlHZB117Ms9fY3MWk4HEPP0o5NMShaQVGoe/xtd5Msw3Iaw2e+DIHU5rlylWlRqD/wHY+YjOD+OYNmbngjmimJeWKRksejROYQWKQSNhoMJYwU4dmI6MhdZjVUVegA6Siia0YJ118JqXj4lOdLt3Gd0BuilEfd1uX4zOiZ/gKo+pKhMzB7YttmJ/eDLtp9Rj2OFg8+/lctoFtX2Ya+MvPaxmI4FzDwN98VrsIQafJEMyc5lu4WdwzrZmr0aZcEOyYi4Ht4zTmG5cGv4p+YlVTDaYXzFL3VJrBGV3y3uKxpMy0Lh3Fj9OcFwP+16CMlZ8d4GTzeLwa7K+OVcGkPrxIWu0DEQaimWr7a/MzeKEJ474yLS6ldbZnmyZtsQrzpPtZSM1AcWOo/n2oaf/uozWxAYob43WtTuL1WC/JoL00eKIugVB8uojgkzp+PdvNii+NmyYyvRQHtaf8SPCokF46ysDo5sXEmM6qjLD2mbzWZ6OlSEI+Ff2U4RZD9qK6xo9niGzGcLYuG7W9HPjZyjbtBUvycXTYKTcEN16Ppw8OXxm/HTZsbT60A89vCO3A8yi0JIate+M6M8YwVD07FprP2813O59t5XGzRaiFC9JmJqGFqX2Z2rqF5p8idVrFcX1P3Cfye0eu0JqvjPP+/btfdn985s7HsE+O9t2cfnMVbP2bqy4Ha7j2TbuzuaUqLtGCNQ/zO3dtlTpI0Cy50moJhrFjq7AUPvm6Ut9Y8sHZhrMUUKbablSwqv3+A27RmQKJxNvvNz1j8nvT2hXDpDxe+HSlRNloV/MZ62JF6kCTR2cOj2ZN9kh3gKaVVepxqKCqq5NMVqA1yLY4NJrtvOrQpoXl+sc1YCs7Wb16TXodYrqgVS+BHfCRl1nx6HBBqO1OeVBBtbOmh86SEIdD3IbCqFgtxBPSxPM0PBQfp5atGWdqG51kD77TMJPgO6Kjz3R7ZEWfTwc1ieHtNv6TGNDnB/SugW8lYJLtUZY0uofLMsXInJJ/H8JDjl5UtNG9roFKWwfPBYXREH8i8TjkG1/FWBJgJsBxS6o4dziwz08vZeFB1O82/rpFoRah5sNj4cE8S/9d09/m3W6mTZk9uaNlOkjT/ew2J5F+G+H4tCtkfco4Kha1cCljbA0yLcxVd7OUUC+Au1lWe0a2+6NCjRgDum8Vl+ojFxWWboy8HXQtJQQtEBPS2s0lJBO3+VG86JgZ4xn+FGIlx6/6qhQidY3agUfkZqOvwFSPEtqXs9tCk1id8RQ//vnBiWHde9vmV4wXJsOH0HbazEUBpOH4QAUlW6C7Aznapvof5q4wN3kYhv7/TpELwDG+C0z7XZnWgUiBREmL1NtPLzglMNIytK0rf0tIH67fc+LYd9UWpSRvC0EIb6qHgL1Y1Rnan1w0sQ4oU7Bj82v+DuQ3PaWc1kLtZ8FY7Vg7KWUf2wN3g62sdb1o7/M9Wh/R5dd7ypfh0JUbszHdMd2SsztTMG6Iyh8IoZTT+R3Bl8xp3gE8HLEOUp0XSwi7ivZbtJYnkfzsPfGnpZxiSa/K9iOhlBTKx13x4YnS6/WeI4T0oOCcLNxv2rmwcun9uQx5uTcFDHE7C40P7Bvr9s1uQDfsVXC6iHvMhIKoe9kKXXCx+fN2JGs55A5rk3eS0CbbWSn7ZkGZKDf/8nqoUItMMjtOMeUd7aj/prqTqlQcY8/p8DJ3CDA71WFdHNsmPQdNkK1YMSKtue1fAKjUJKthVEBTyA3SPdfTjMoLEP4YVIGP5BsfzJl6bnB4bEWk0mQ8sGqdHzfutAF2OWFL8ryqw2Lycfvtb5sw+YqwaBeEfuJTU73Vcn9j4nNSrzpwRQL++xgA+xrw1Q=="
 }
@@ -32,5 +32,5 @@ func init() {
 // AssetMysql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mysql.
 func AssetMysql() string {
-	return "eJzkXc1z2ziyv+ev6JrLZKoc7Vz2sKl6W+X1OLuuip2s7ey8d+K0yJaIMQgwAChZ89e/QoNfkkiJ+qCS3dUlsUQBv240+hvQO3ih1XvIVvarfAPghJP0Hn64Xz398+MPbwASsrERuRNavYe/vgEA4M/AklmQAevQFRYyckbEFmItJcWOEpgZnYVHJ28AbKqNi2KtZmL+HmYoLb0BMCQJLb2HOb4BmAmSiX3Pc7wDhRk1uPzLrXL/qNFFXr7TAc6/fuNv/QaxVg6FsuBSqhG6FB0syRDoqf90DWo9xNeCzGpS/tkG1gY3R0kGo8CC+tMuoP5VEzslh633e4hgQtZmGE7QdMVPlGvjV4vpAa3g7zzipDXNJnVtCjHP5Wrtkz7q9lDiX9d+sApUmHWy8VAXljYerTVtfVhBSnQxlV0f78HlX//QS9AzR4pJFkGwjZfjpRGO3llygRlCzUEX7p2evdMmIQNvczQoJUnxB/oZgGYzEQtS8eqnhrxdFMmRKWooWKIFq8FKvQSnA0Gl/DTPCJdCKuap5wF9VfpHG6QrMCYBQuMZtLl04fUvlAVZiKW2ZPwcP4OhWfgvwtwQOjIwx9zvgiWRCmBQJTBD28JhB/BuKVSil6Nw73pBBucEibAOVUw1XOaMdYxY6qX/b6xVXBhDyslVzSVmXQ8NFf6YjDvX5roh48RMxEEGT9pkCeU2qgj/5txlRsLCy1UQ1RgVTAlyba2YtjguFFRbEd7m2pFyAiUkNDdEoGewsVGH7E6hEnqNrPijnw9Sq/lxXHhOCVSRTcl4dKScEWQ9GV53x2vryTgG4XVkFjiORqlWrcHsDCqLsf+SBUMxiYXXmKmQBNj+FAzl0hNDffu63hOysI7M2bZFGO60DeHdlkgkY4iAdihbDC2ph4z8OzYVOcQpqjlZSDHPSVEyQApGkteboORacEuYtcwG9EMQbrpM6xhfaLXUpovhA2A+haX24pkKW7M01lmuFSk3gWevRoS9gmVKzts5D17phEBYryWc/zLC58e7++vH/wNt4OHTQ1T92Qy0R5J1lonz6Xce7fv3ntZ2vfc3Ahu8J6ULZiy7Tadb9+Pl+Gjb3pAyyLprpTgU6hSBbhHfg/5uxqIajKGw8OnDh6tGeFO0oLSDFblmcna81CpsB9reDVtC5O2SsJDhylvZxFtdDZmwIXYrDBukCdykFL/wkGSMNiD1HGbaQG50TgYSgXOlrRPxPoVPi009cPQegduFhQ9HbQ1aiHhzr+5brCGIAOCjsC5EbF++3P3yI2smlJLXzIaJqxi0U4muExieDt+NUfn1NvS7XtfAUCgnJKx0AYY4kPGfChPC6cQvUkzW9hpj2FDV/VrjNE2d6mXgDPstCmWYrRLW2389wWejnY613CNFM6mXUew2HZ+jRemDj0putHJGyyO1bY6F3dr8cBZ96z3HmSmVrGeWyAis8LrM8036SOrDxy9P/4Cn5+vnL0+subxWYwe68sUqDR2AVlvdc5ITDabN9PbrToFms+lX3l5BqpeQFXEacg4SFx7B3OsnH9v5gDnRy0M9hAAqUv1OwmmOt2PPKzAu916N8MarZEWQwozQFiZEFgqVthRrlQzZM4bixQi4H8kVpsz+NE7Yh5vo8/WXp1ughdfn6/agcsqvQKhYFolfDZdqS+uP2TV3pv36oqR4Ici0rZ2PBRqBU0k22J5YF373svZnj0srgkRTMEaGLDkPzawCt1kpFUEc1rJsu3QQqX7lfFF+foeMqpjkd31UuyidirCDVXvY5LeKpa8Fed0SeHTlHWJ2gK4qRc0Kp/GOWi7gPsw63gpWj9bZD36RbE6xj5vPE/lNZxFOtXGjaKGN2I95sR5SN6ldRhEyu0HDrj0XIm6hgF4pLnbwHTayT9EMhSwMfUv6PARKNhIejmxffAVbMdY3Ql8L/KkWoUvWB8Jcl3k/S4+kww5pbwP9WlDR5ZXAPp4OBAytRMJboXwE5lCRLuxPIEnNXVopFSaG4ezg7xb0CBd96Pb4XQcQ8FhDqzBjiCkT0AsydRpuiE/WbU2glUvXyoqEDE7lCiSaOScsUMHPk5+9j6LCNqrNVBkVhOR+k08HtJxi750O2dStAA01uTzvNC6FlDAnRcZ7RQhScxzfdiNdarRzUqj5QWuV4evIoubtV4avIiuyXvE6bJWGkCXUJcgSakyyGs2VS1xdQsVu5otxZaugBO0qC/VOr4hfYG5QFRKNcAPdx/447GzK17uG/zHK17PsO1W+TzW0buU7NBg+TfEKlXA9o0cNeh9ZkVtq88LvFvM0LxwIaw/k6LdUkY0Q/EepyLORdYEU2V2VGAt9G3VU8+HpvsxSBP25J84yhEl3H8dReehfW3WTsg9I2DAJa+s4ptxx0Cr2FvwqV+NcUeCv7PE8katGPrX2V4ySe9iyfi1Prfa+OCkccm2c8R0gi9OVGzGas+IPOhFs268415o/tnTwaa0P6DC6DAv9VE1ZfIiaeaGLuGF+msOBXYhrh4NjibwQuiD9U+EOxDiWktmpYyp88JY9V6fbm3dIc8w3UDXbPK3rBWRm2mQbLUvdGmVXx2VrnFa/ZdVraUhiWVT1tq/1cGgZCK2xwbNeA9LX/Lmr+TLkn7n5kzL/311K8ZFibRIL9dNV+toWWYZG/FEmD+OUMuSwPhFz2koH9KvgfarzxwxfJ973NpMlCvfjSYJxX/qjfqTg0Fe+W0NNnZ5HxzkL/9i2l1XD8w7exBKpfmRJtxu3huzZg0EHy1SUFa/AR66kcfLfG0RuF/CT9eP5WqByQtLkL38+jVfe0f3Ln13qpdHPLGTDrVoYvNiqeHXFXXIirmpZ/YawSzgaXI5euz5cw/W5tTlKsesZteYJ68GJdWhOY8lDrfG25KV/clzMzya/VYvJafJbQXM4lRQJHfnhjuyWqPQDjwV3f/rE2KzXCdxceOBu19PfaUfDRF9SZUgSJMjKzuiwP6TaovspSJ4feuesvQ8cMecz87hzxLXG0knPpGeIGx+wkTmeq6wloQWucC9TCpXFdXkIQsmPGRYYSiZ1H1yV8ihltv5mJwCehW2kERmaUsom8PDl40fOWm+OEr6hdPXgnbJknOWtESqnCeDcW2MHdw+/3P5v9HB9fwv/wyPu9qwmM3JxeiaNglICjwc6p9ANZeGv8POWR8I1272+yDGnQnjk3yqnhFzAQxbiwjqdVYF301NU2KoDf91B2XUMpLdV83Cf6kzHVyZdbJsP9abKCuq5Qs7rsiB7WnJBik6bdO7e8rIbsK+mTDEWZUdIQASJ8DpCuFQXjg9zhL4Rao1UdvrJIQn48lvjE1rWktE5ynJvXHU1d+Wytw+vDWvgFHrTiztSaPb2IBqjTT+Pji5UDDG6OZGJMEkM2S4EcHBiduNwA5MWZE/H3FFbnROwhCZOvXzNtKl5ruaVKN59hhIXddZVWjuc046XQp8UhlGilLZJer79CXQwrFJYR8o/kmvT0zO0dmxDdR7bOBz77pR4t1IwNGv3ANbtmCXlZXdp2DVXYIs4DeVkbh7x3whNpgiKujq2SzJSQ5iANoCqOkOXUabNymNJxHrfShefzlUM2c+Itk4sM9wZvkbtR6XIROUqYZzSjoLS6ZS7OF8a7Nr+o1C/KkV46if1/xpct7/bCC3J7ojgcIBDJXjQvgy4/L40kGspD9uh/vX2Qynn1ZGS2vFruvcUxWQtGiFXpXeLlQJrmcwgLL9zY/7kpz3Wx8vUZQyPtf0HxgZZnacnb1Kt9TQybkh9bIkqgUz0Ke4hZintbu6CE2W+G+8e3cOEXARMP8/2nvE6Dsw2BD/8NoC1hMS3cVR0Tirq2hvDMAzCsQ8LDJNQGLIWW4DWhYP3kXeMPN2B77ZcIqn1S5H3iAkMldxzgAwTnApTL8jMpF6eF2m/LfGvJqx3IiN7BThzZADLtETQ9T4k9a6p1JaSK6/YmbAqiOa+cFRQKLadpJxZQThs0Sej4VVVOFx7MGHXWk1C6q8RevjT1ltR9V2726BMhZJ6k28jWRQGNkmEfYkKe66zqHtmG2miXdWuo7MHf/ODce7g4JxBT78Ef1WbDN2Ihbm1Xc+zbJT/UcoqqTHSsY59NB5JRlUC3U9AbQPZpz+bRDyH4Y6TCd4Ao5xPXw8VeJqK8iF5H77v4wLAwjyHIOs5nDsCtuYk7mB0plA+LhkdWznPHmTb+bBuqR9+uqh7+E5hGclAuSwP5in4KmNbKD/dTFxoovFIam6Ikbi61FLtSYueiXGCy01Rt1Y/+2zc1DLqEs1kYdOo7GEdZb9m+BrxGd2R9YL3dC8jaRfZodYZwmx8RTC6EgiRUbcCPY9BCMJ7mbVPSNKOJu5zWdxfbj/ePt/WFdrQf87J8yIfdL+T3b4z7Pwo7x6ebh+fj0bZmwo+L8qn24+3N8ejLPKefq/zovzy+ZfrA1e83UEgTt5b3bCarsLy0HCr7h6Ko+EemOoMe3l/ANnOby6FS4UC67Qhvl5kbjCzV1CEQ/F+1H8WZEOVoRpyAneuyaBzpwbcfLqPPt89/B204f8/PV8/3z09393U5zH2ealfq3m+KdtqbmlV3nlZfqsKNFuFpemqCjk5beSZcTyPWcgaDq+vYQ+zrzZYXf19/xx9frz9fP1423rn5uOnp9urZn3un6PH26fb56Hrk6JK5LkueNt/eqPjDq6d0jBAIraloq6X3ny6v797bi3fAD10IcvDCc7yAhi9tJDigmBKpEoA1RVEbM4HwKbXQHMkdfwyEvrqbJGKTbkb3Jo8z7QBwjjlah8XrnnDtIC9/QlmhWLn9Krs2Q0nmaVchZqhhfLw9JTmIoTDfheSSrjbK47Jhvp+lRPe6p3qZVFmTCTUBeSvWtuaY4UlC8i6AucEpOZC0Y8W9FLBfSGdePeIak7wSJiAyHLJ7A01Tb6ehEkNxA+5rMdQjmYMGb7euDWFqrkgT9GG9PlSvwt/hN3e6ssbdLwZRzgbPKSSNROm9zT+OPXyRkx47rJUIRRgecNo1c0w9CznC20eKhyXAENs3Xk/ckcFel0GU7SUgFaAHtFA7LL/LoQLga+Pnb7Q2ioMJEB19+BfmACPghdBKKZj3+2OsK42ui5FuTAFHoXQhT2WCtN5ucAFidjaBDPx6j1EbTcai/aREX1/IsUHbNAh52aGqHMt5RRHc0g6kHu7tGlpPTnl2TBenYCpMUxDYmhcUK7FOMcCD6REYkz+kwrSIfCj72dFPBLghWB37hByLpQraG+HMGW5uflyQnbKhlyca8SFsYa01FCsTTJL6WQ6ZiDYaIWOrpJdLt4ebvS7d223sruRZY8i3dFmw2FbqBIL5TTcKaV/+dtWxLYNJixPZ63zZDzV2AdCKmPOMRBV4exhgMJ2GwVQOfQAQHWvSTGbkYnyrt8GGVVskyLLt3/P5jhO9PkAz+xz6bnxgbUPsVWroZb9gFib0jVDvvmeZFL5AiX7AoPAM+gKnBHzOZl2Js7xCQLOzHgdE7UYGjGN6CKbFo4vptWm9zGllzvdp+YeS0y+Nd+WaDIo8h42ed543eH5whrakgs/ijHnq46MIZtrFS6I1X7w8ndegG0k819kdDC3mTOe2yHj3cNsfmoos/taf/b3fg7g8+6+z/Y9Ib0u7oAmxcEtis1VxZsdS70bojpmxxcvo8M9/n/7zLdxffH8WFTFW78pEair9jxjKoW0l+JBUsNj/BdJTcPRPdz7N5GXbUk5XTZgs2GixwEYg55uT5pRlDTVx3dPocpQfxPyeCT5ac+2MhJdT3vj2GSUM5+NkkzY+DtUAPUVFNPCrtpH31atKhFKqcN9QRxfJ5lQwjrvfiyIe/dTwqQ5G+gjQanjFwtl3h4TzPnZFG06KNHZambxtuLClqndnd/ldfLPjc13lsr2VhkuaHsw7YMAF5A9zu1x/NPvnYbbazF5x1A5UeP5oqrG2cHSwt+Pyh9W+bcmuXVY3xbTcA+/k6v6R2OqE/opLrz1DvuUi3bBPd99A2Mf5/oT2mNy7QeDKtHZDy2GeJ0lnMCyySLwcDAxlVn9FnZDz0WMMshAhWOYl9r9c6AXVBT9mSu4GM/KPoVy08S6kGVbDDphZ6vGM1rTyKgSSDGpawmJMBT77cKPJ8K+HCA7Xv3viujHYIatf7luOyfC5qhHkyQrhZlnnlxtxOdVbB6OmG2F3TxojgYzcmTa4wzm1BKFiy7mZT5okwUyQwc19KvX8HuJldfTqNYJ/JqSqr6hiJKm+qRNeQqBfwpoTmU/FsSSsDT37CfhAoXEqaSrapwQPViwOqO1wCRU2vkafazOcoYD6+31nXlnKi3btaqOB/7X1g0n5U8akg3Xig3TJ8ymaKcqPFduauOaSQuJDnWXPqf5/wMAAP//nuXUEw=="
+	return "eJzcXd1u4ziyvvdTEHMzPUDaOzcLnG3gDJBJu2cCdCe9SXr2nCuFlsoWNxSpJim7PU+/KP5ItC1Z8m96dhJg2rHM+upjsVgsFum35AVW70ix0l/5iBDDDId35IdPq8d/fvxhREgGOlWsNEyKd+SXESGE2PeIBrUARbShptKkAKNYqkkqOYfUQEZmShbu0fGIEJ1LZZJUihmbvyMzyjWMCFHAgWp4R+Z0RMiMAc/0OyvjLRG0gAYX/phViY8qWZX+Ly3g8PfZfuqZpFIYyoQmJocaocmpIUtQQOQU312DWjfxtQK1GvuXMbAY3JxyUDRxFNTvtgElJFJ2CoZGf+9QAn+f1yQMV2i6sk/4vsHesvoQKchvtsWgWZt2sYa0LPlq7Z0u7Xo0wd9rbCwYjJMa4+jCEuORUsLWmwFSJqspb3u7Bxf+/i6XRM4MCKsyc4at0I6Xihl4q8HYd1ZMzImszFs5eytVBoq8KaminANnf1IcJQRmM5YyEOnqp0a9XRrx0do7J9eo0WBJNdGSaC6XxEinkLef5hlmcpKzeY4cwFchf9RuuODTDDICVHEGarPr3M8flFegScqlBoUyfiYKZu6flMwVUAOKzGlJpmCWAMKBoSIjM6ojHJFpdHK3ZCKTy7Owd70ARedAMqYNFSnUcC0z2ljEXC5BGxyVaaUUCMNXNUuWug4dAv4UlBm1IT9gcN2AMmzGUmeDRw2yDEqdBMVfnV1LJFmgXTlTTakgUyCl1JpNI8aZIGEokjelNCAMo5xkMFcARM7IxkAdMjqZyOBbotmf3TxwKeaHsfCUAxFVMQWF6EAYxUATJqzvTtf60+IYhNeAWlB+1l5rMBtFhaYpfkgTBSmwBXrMnHEgNH6XKCg5KgNd4zpokPJKG1CjNvCHDAvX3HEDIpVilrCsk9QjTEAayiNCvfakAKRY56wkaU7FHDTJaVmCgKyDvxjvmez1xjm5CK6HWdusQz8E4WbIFP5zVvoCq6VU2WEwH23b1jxzpgMoksqilAKEGZMndCNMX5FlDgbnOQQvZAaEafQSBj9MyeeH20/XD/9PpCJ393dJeNk0VIsftemYyqJgp/PvtrXvP3paG/UYbzgaMJKSlSXWhk0d3O0xux9uxwfP7Y0qg2Z3KYRdCo3a4LebeA/6W7Rp8JMh0+T+w4erxnhzqomQhqzANMJt4CVWSLzJYXs0bBkRzktMk4KucJbNcNaVpGAanSCbV8pOSGNyk0P6YmWDUlIRLudkJhUplSxBkYzRuZDasDQS0EoTLPSpxgiZLDT5cNDQgAVLN8dqX2cNQUQI+ci0cUvQL19u3/+osSso57bPtBMc1qCtTjT+mfin3WdTKrC/FfxbrntgUgnDOFnJiiiwCxl8lym3nM6wk1LQunMyjplBjwPnYeYxl0vHDBMGlKDc+jcIxjr545F8VtLIVPIOpAHljMtlkhp+KlP6gKuSGymMktwbz74mVdJKQ9bJ3DH+FiPHmfJOFsliBRDNMJJGNjmupD58/PL4O3l8un768oiTX4ExtQ2gQywWPLQDGoY6Monuw6iY9Pi/W0GknTZxjtZXJJdLUlRpbvtMc7pABHOcS3FthwvmTC73jRAcqEToM0wASJ+xkZcjrgRhMIahgQpnhQVQXSm3shBUSA2pFLEddIJXkC7OgPsBTKV89qcJwj7cJJ+vvzxOCCzQn6/PByEovyJMpLzKsDdMLjWsP6bXwpn454vg7AVIIXEp5oKPBVWMTjloN/ekssLRa72/jbikAJJJcJORAkw8wALTT5Zt65QqZw5rWbYdfCK874PP75CoQBKO+qQOUUYDqeqhCYeKhq8VoG9xNneFAbENgK6Co0bRUaAXhYB9mGVKT+az77CTdAkprptPs/KbzhI6lcroC6z9LBdx+Byndi0Kl9l1HnbtObfiZoLAN0irHbzHumGGIZlRxisFr6kfQoBsI+FhQEfjrFsHa3OvhL42+GNnhDZbHwhz3eZx3umw9F3WHgP9WkHVFpX0cjoQcJxIeMMErsAMFSAr/RPhIOYmD07FKmPh7OB3C3pCF13oeuKuPRR4qKEFzNStKTMicVMspOGGxGRtHbWeS5dCswwUxWQnp2puExZUkJ/HP5MCKE6l1DTTlF8VYCJ6FeXTCdU2xd4pjqK/gRWhCppcHgaNS8Y5mYMAhVERJVzadXwcRppcSWM4E/O9+qqg33b21fGmhvNXQb+xoio6zWu/XhqiFhOXUIuJc6oVVMJupqtLuNjIuQapYVFC9apwG7gYMryQuaKi4lQxMzB8zM7vfDE0/K9xvkjZd+p8H2to7c536GL4OMfLRIbODzrcIMbIAsxSKkySKVnN87LCpLOu4C/jIhsjONKXfF8u8mRqXSBFdhsSY65uo17VfHj85LMUzn92oAwIFdBsNRqOrgfZv6J9E18HxDDXQLOV9dZpCqVBinFHsxeaCzVa0bU55z5sNuJ5BBNaPm4FaBftW+/2mGkPxNbZL4rUAiUuKexybZiq6aIyxjtdmTOu5nBn8UiwASj6zVEbxgP6/CHywUf1dkYNTS5DIYpqtsWHuJkXuEgYhmL2B3Yh1vYHZ4fPhdBZWWTKzJ4Yz+VkdvqYgI+8sZGrkfHgHVIc8wquZpvTAMZ6jSQ8waQY9fmVg+oxN6Vs1GSubV+F6gjc7/NFs7/h59fclUU1Hu12UUFJrAURwE83kcdIfOMBvQV2RSpdUc5XLaonvlLyedyKdcFguV00cwTWsHDBhgnLsLxrxkCt4W2H4jpifEowjy7qwR3dAMBL2YUgl9qcDsOdX1/QLFOg9R44StlReNgyaHtAfJbKRGIJZ9qAwE0guyGUcoZ95ssRMG26E1hbJH1ML20Pxytit2me7+8+3t5Nnq/I88Pk5v6PycPt3W/46v7Dh/DG5OHh/uEZV3LPX+4eJtc3v1//+nHyvFMBJfkJ8T9IvgX/2dchOWCPk5v7u/f4ckyuF5Rx3Jjzyxrncv5n/PNOxAtQet1dHgnaifXNrqM/FGP3NtFUSg5U7IfwSVXgd+ECMv9PzDDhNhzTaxU07eDizYAxE0lb9ubQYXVXz9mxlFBoZyWREoTdz8XKHM5SQzIwbpCRFKtz9ADY9kHIzova5qdz3LWaYpGvl2l7IGAfglXJpU4WlLOMGibm58RMUBYWsqZ5qDfGcgQHOd6ouiLTyuuGm7hWvzlVU6wvq8/CDNENCmlgzOpZ9RLWtL50qydRTHIZuw2wpAyZxjX9tD4AsPcgblPUt/WK+jX2eBK9rIsaY/Wb1OfWSyo2ZwLj4OAQvBPD3ZoQzNfqnkIvJTmf0vTllfRq9sERCG6D0/QlnHI6TMnG8TiveTkfiH6ipBrdCcLv8t7tsL13GHM6Hxf6RJCfWNEUwTpMttjYd4LvFh5bGT6FaVQ/vSOsles0rB2FmoKQWfVjLOYES5JIwThnobCK3Au+wlWeq3UIM7Q1Qd/oVXfvBopKUDOpio3DK+1rwB3EPEftRKu8cOpOAae+vBYxRg/H6z0mtk7RdC07d637XCWSPQYIBf5zV/8+QIp1eaR+OhQy6aooqGJ/+jKSNIeC2lrnjM2hY2GySVgX0hjtjwX9NsZdGDXGCeTHrQd3WOqWNp/8zgS25OocvVlF2tSFWn7awseybnicajPWAKIbWdae0F9DZkcN+iUbJaAROB5tTaWtl8P5xxaOo7BuPF8riuW6MP7H34/jCrc8/vF3k5MSFEpmzeqhMQY0W5Gu7OgrWRqqGjvhtRpHg8vAN9OH63M0OLzZdbRac2IzYmMsXT6OksYbb9lLt3C6mJ/MfsNhg+PsN0AzOMElTCbYnB48Ylv9g22L3P7tntjG0CfYY2Z7jnY5/TfsKJ1vg7Or2bhpZyutj+xeobbq/Whbs6h3Su184ACZT5bj1haDOMv5uENon8Ad81f4uaONzVlZvqqQarekWeZ+0l+3BzdIrC9T1mAgG9cnosLmt7fZ+pOtAKoQ6ZSKFVR5KxuTuy8fP9r6pc1WHC4hw4O3QoMy2rp26xewLmmOs7Eht3fvJ/+X3F1/mpD/tS128mw/OZ6BSfOjBnTjUfAQh22P4JEXmxPV5Bfy82hTNG6ErkZ9I+OQfLRt+TlKXFg8WLtQaSOLsAXbLIAqfyx7M0AZj7qHZuehvXY9duE90UUG4zba5nSHDrEevpZ27b0uZXoUwt9rX5p71Majy5NuevT9LPOXUVcdRLMDFCVi26qLIcUDEP6oBiIiGUbtS2ZyPL2Hx/ptPiCHqCV/5osPKcXynzq/or6qmBoDRYmTqwyyQ8geX2PSgXwDdVeadG+j6bMGe6Sum6M2eYNI6pIbyy4BVOK3FFof7OmpLSDr/eJUc7YnU3tvQjgxroGqNEf78plB7C186U3x9jPxuOLigjYdXAHKpdBnlbIoKee6KX958xPugaChuS0RfAT3XXYjDwfhToK9zQT6nIKCWXwarD6Y5zX3KQE3aq6IxmNftrDYHiPAT2DMbgglAtrO7no1cqwVwg0MKsJtKgUUUq1wkGZs/QRDG0+nKovrJyL2ib7WqaDfkvhRzjBb4kIlmuabCd/4v+M1N2m5VLS8lPYrb8JTFEo4myq6Pv9uI9TA21cE57PgQePS4cJxqUgpOd9vhOLPmw/uuEx9uUAd+DXnuATg0VqqGF/56JYGB9YQ7I0FV06QjX+qBY7a+EzRpkZtNJ584tGaHzfrPD7ilKpxR9HhJjmuLTHLVLAuxz1kWsrbj/nsb1LrhtOOd7wTilPkImC6Oeu97eMwMNsQNPtzbXWwLtwu/Y4zmV8OtAhZgkjaxsYwDINw9GEZaKGD+qLHOLB5u/+IervFsvZdxKV8qUo97oW403JPAdIJOBYmnqHCY+inRdrVv1vbNqzAE7t0hmd1qU9LOF+PS1IMTfF2s+yKUK9YWETbo9RUkErYEApvdFqF3ZKdwkOtm4kbY3rt0IHFkTRGT/629ackfLYhbtTG75QJLuejoaNlJ4tdoyPIssDGGdMvSaVhtGdHdojukXYmQbvqHg9kj5BfsYjSJjj0eE9qw/b21gMEB2BBzRlLNNdGvZWysduOyTGf1Gj06lTlkAP+fToeqEbYP+9XIIB3Mf3JLOLJNXeYTdjhlu1L5d5UOTFB8w5+1nDZmx8vAMzJ2QdZqPM6P7amomwwOlUJsV3rdHpsXk4Psg3OcPE7GoiqA1F7863GcuCA6hsxpijd9GRn0019duo0VK9NcTN2IUHnUylIyYDTFWTfRVr0RMQxu92U+JFwbmn2eMNZu2jGK50n/jSjHg2Uso8EzIdhxBsnxc4hB4Pf1nZPbmkXGaHaKKDF2cWc3wlgv0DW7kA7hewjwBtva9Mn7/sMOBg4EV/dM+77ycfJ06TeoXVlbTZ5Hh9j6UTpvNTZUd7ePU4eng5G2ZkKPi3Kx8nHyc3hKKuyo97rtCi/fH5/vWePB4T+M6OB8HqgrcOqS8O0vz4q2ne3+x14YS5rbjPzN8mBbv0kbhUzvIBc4tZYqeRc0UJfkcrdI4et/rMCjaw1TY7JrWky6LZSg9zcf0o+3979htl6/Deeyr99fLq9qU/m90WpX4OcV6WtZksKvoo/FRdq+42l6SosOW3aCMk4nGNrZA3D633YQfbVBtXh9aen5PPD5PP1wyT6y83H+8fJVdM/n56Sh8nj5Glo/+RUZBzUZbx6623MO61hgEVsW0W9X3pz/+nT7VPUfR1kvMLMg+WFfg/bHnNpTkI4AOG8BNrNENjwzemc4NU8Z0LvBwgTqfKjwazZM2acgdrTOpzjqMJs+RqwNz+RWSV85bk72ePutMLTrXbPUBN/jdYU5swth3EU+jJ3rCnQdh+xzgn7bOsAigqlEiaYuVjf1oxVGjSh1ldgGSqIOROAlxAvBflUccPePuD98uQBt6VZUXJLr9vTRFKdqk75AYqWCkqqzmHD1xv3Z0KQRcqcalfiuJRv3Qt/gKGpyxsAHZd7nbjb/NEg4F1+KZY8Y6q1wrqXtoHU7TITK9tvVeAVuP67JkI1Q1TKsFOFF9i8Xua8Cii8k9Teqy0tTELxyB6ZUtx4kXiV7wusBmLn9MLsb4Gvj8m8wFovDFRAwLfvQAFEYTuBCTzt3HvPf6xBqWDx+hogCiYrfagWamuJemEltgbBjH3DKFzqjcKiPjWS78+k8LW9MwdzMzs0qbVoP7LYq8EA9B3IcbrcnGmxa/zZMOuiHKamYHyAIpouoJRMmO9AE05TvAG0hrQP/OT76RFE4s6QGrmnOhfKFdQqGOnTE36Gw9nBxZ8DwNrk8kWxurTUUKwBJxNCZtNRG8gTLQSDJNVWVbIrxOthozu8qyV27CL09MKG5A3GcdmGHh+/LsxIciuEfP/r1optG4zrHsjOgSe0vSckv+Y8B6KwnN0PkBtuZ6HINz0AUAAzrWYzUEnZ9i2RZzXbrCpK/72jRzOxLb9xIzZ75W8vwmLwMDFaN20PnrnQjNrvQANeXw3g6XMEESToihjF5nNQcSbO2Ass5Mz7mCQiNLE6UpPovDL2K0qk6nxMyGXTRzt445Jmr83bkqqCVGUHTZilRN+BvNjZBC+8sV+PiBSnUinQJVbhizk2RkX4xk9ip3zLPytgb7YtM8i2y3h3kG2fGkp2V+nP7rExkOfuMRJDwJC045Hezh7c4aHTzcZ9flb/7gERjtlhTyPOnZyuKcWUWV1YK/81a1vahTFvMXkj7dR4kNXYNkbdmv23WU3DaA97fxF72baU420j1sYWTEB2MX3aI2mLwutUH989RisF8AoqzfDLd0/VM5ya9HV6xks+mSb4hYqvoEYP/OYKimmlV/HRt1V8XxbHG3nCDT00w1vWtcHwYwG2dj8HmjVnAzElhTtDeL+TlUszWtpnc6rzQYnOhjc7V1yMOLN2E207Z+4iFeR1pxI9uwwXnHto3gXhEraH+rv1T4cJ+lgSn3trodpEDfIiQuHsYGuxn0/8V2z+pVWODuvraqox42JvFgpfHxpO6Od0gbO3G6d2084thnwByZ7MKfEarP2gqMhk8UNECPosZhj1RRbObAYrE6bVV9CFyznD28tQlXp63wk8gG5d8l/UUXTVv16SM1+n4AdNKivuy2KoYXq2ai42icbTlS2eyXFTB3MINMP4FFIcLvZxrPbew3bQ/e9a0Z+DDH+XSGtOBPF0eZJsJWiBBsdXG+vzsDbHD7Ysu22jJVW0ADz2FrUzmCm8NSi5WJR5h6d/rJo2ya1DJVULKe6b80PU07jWMfkX3n3kPyEAsmb3CU/52VMI9kth5+DrsUiKtw17v45xEg23Tl6FdmzcjsVxsoC1hYnbacd2kCntyxhoVKWA0mcYTOW+XCtUPNj/67rgJFz1rd21YsMCD0tTstMV9vTPoL5ZH8q+bzL8StjODhqP/jMAK3fW1g=="
 }
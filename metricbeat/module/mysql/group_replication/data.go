@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package group_replication
+
+import (
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// timestampLayout is the format of the TIMESTAMP(6) columns in
+// performance_schema.
+const timestampLayout = "2006-01-02 15:04:05.999999"
+
+var (
+	// Schema for replication_group_members, role and version are only
+	// available since MySQL 8.0.
+	memberSchema = s.Schema{
+		"channel": c.Str("channel_name"),
+		"member": s.Object{
+			"id":      c.Str("member_id"),
+			"host":    c.Str("member_host"),
+			"port":    c.Int("member_port", s.Optional),
+			"state":   c.Str("member_state"),
+			"role":    c.Str("member_role", s.Optional),
+			"version": c.Str("member_version", s.Optional),
+		},
+	}
+
+	// Schema for replication_group_member_stats, stats of the remote
+	// members are only available since MySQL 8.0.
+	statsSchema = s.Schema{
+		"view_id": c.Str("view_id", s.Optional),
+		"transactions": s.Object{
+			"in_queue":        c.Int("count_transactions_in_queue"),
+			"checked":         c.Int("count_transactions_checked"),
+			"rows_validating": c.Int("count_transactions_rows_validating"),
+			"remote": s.Object{
+				"in_applier_queue": c.Int("count_transactions_remote_in_applier_queue", s.Optional),
+				"applied":          c.Int("count_transactions_remote_applied", s.Optional),
+			},
+			"local": s.Object{
+				"proposed": c.Int("count_transactions_local_proposed", s.Optional),
+				"rollback": c.Int("count_transactions_local_rollback", s.Optional),
+			},
+		},
+		"conflicts": s.Object{
+			"detected": c.Int("count_conflicts_detected"),
+		},
+	}
+)
+
+// eventsMapping builds an event per group member, including its stats. The
+// applier lag is only known for the member the metricset is connected to.
+func eventsMapping(members, stats, workers []map[string]string, serverUUID string) []mapstr.M {
+	statsByMember := make(map[string]map[string]string, len(stats))
+	for _, memberStats := range stats {
+		statsByMember[memberStats["member_id"]] = memberStats
+	}
+
+	events := make([]mapstr.M, 0, len(members))
+	for _, member := range members {
+		id := member["member_id"]
+		if id == "" {
+			// Group Replication is not running, the table contains a
+			// single row without member.
+			continue
+		}
+
+		event, _ := memberSchema.Apply(toInterfaceMap(member))
+		local := id == serverUUID
+		event.Put("member.local", local)
+
+		if memberStats, found := statsByMember[id]; found {
+			data, _ := statsSchema.Apply(toInterfaceMap(memberStats))
+			removeEmpty(data)
+			event.DeepUpdate(data)
+		}
+
+		if local {
+			if lag, ok := applierLag(workers); ok {
+				event.Put("applier.lag.ms", lag.Milliseconds())
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// applierLag returns the time between the commit in the original member and
+// the end of the apply in this member, for the last transaction applied by
+// the group replication applier workers.
+func applierLag(workers []map[string]string) (time.Duration, bool) {
+	var lag time.Duration
+	found := false
+	for _, worker := range workers {
+		committed, err := time.Parse(timestampLayout, worker["last_applied_transaction_original_commit_timestamp"])
+		if err != nil || committed.Year() <= 1970 {
+			continue
+		}
+		applied, err := time.Parse(timestampLayout, worker["last_applied_transaction_end_apply_timestamp"])
+		if err != nil || applied.Before(committed) {
+			continue
+		}
+		if d := applied.Sub(committed); !found || d > lag {
+			lag = d
+		}
+		found = true
+	}
+	return lag, found
+}
+
+// removeEmpty removes the objects left empty by optional fields that are not
+// available in older versions.
+func removeEmpty(m mapstr.M) {
+	for k, v := range m {
+		if child, ok := v.(mapstr.M); ok {
+			removeEmpty(child)
+			if len(child) == 0 {
+				delete(m, k)
+			}
+		}
+	}
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
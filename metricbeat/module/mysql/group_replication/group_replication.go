@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+/*
+Package group_replication fetches the state of the members of a MySQL Group
+Replication group, as used by InnoDB Cluster.
+
+For more information on the tables it uses, see:
+https://dev.mysql.com/doc/refman/8.0/en/group-replication-monitoring.html
+*/
+package group_replication
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+)
+
+const (
+	membersQuery = "SELECT * FROM performance_schema.replication_group_members"
+	statsQuery   = "SELECT * FROM performance_schema.replication_group_member_stats"
+	workersQuery = "SELECT * FROM performance_schema.replication_applier_status_by_worker WHERE CHANNEL_NAME = 'group_replication_applier'"
+	serverQuery  = "SELECT @@server_uuid AS server_uuid"
+
+	// errNoSuchTable is returned by servers without the Group Replication
+	// tables, like MariaDB.
+	errNoSuchTable = 1146
+)
+
+// init registers the MetricSet with the central registry.
+func init() {
+	mb.Registry.MustAddMetricSet("mysql", "group_replication", New,
+		mb.WithHostParser(mysql.ParseDSN),
+	)
+}
+
+// MetricSet for fetching the state of the Group Replication members.
+type MetricSet struct {
+	mb.BaseMetricSet
+	db *sql.DB
+}
+
+// New creates and returns a new MetricSet instance.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The group_replication metricset is beta.")
+	return &MetricSet{BaseMetricSet: base}, nil
+}
+
+// Fetch reports an event for each member of the group the server belongs
+// to. No events are reported if Group Replication is not running.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	if m.db == nil {
+		var err error
+		m.db, err = mysql.NewDB(m.HostData().URI)
+		if err != nil {
+			return errors.Wrap(err, "group-replication fetch failed")
+		}
+	}
+
+	members, err := loadRows(m.db, membersQuery)
+	if err != nil {
+		var mysqlErr *mysqldriver.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errNoSuchTable {
+			return errors.Wrap(err, "Group Replication is not available in this server")
+		}
+		return errors.Wrap(err, "failed to load group members")
+	}
+	stats, err := loadRows(m.db, statsQuery)
+	if err != nil {
+		return errors.Wrap(err, "failed to load group member stats")
+	}
+	workers, err := loadRows(m.db, workersQuery)
+	if err != nil {
+		return errors.Wrap(err, "failed to load group replication applier status")
+	}
+	server, err := loadRows(m.db, serverQuery)
+	if err != nil {
+		return errors.Wrap(err, "failed to load server uuid")
+	}
+	var serverUUID string
+	if len(server) > 0 {
+		serverUUID = server[0]["server_uuid"]
+	}
+
+	for _, event := range eventsMapping(members, stats, workers, serverUUID) {
+		if !reporter.Event(mb.Event{MetricSetFields: event}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// loadRows runs a query and returns its rows as maps indexed by the lower
+// case column names. NULL values are not included.
+func loadRows(db *sql.DB, query string) ([]map[string]string, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if values[i].Valid {
+				row[strings.ToLower(column)] = values[i].String
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// Close closes the database connection and prevents future queries.
+func (m *MetricSet) Close() error {
+	if m.db == nil {
+		return nil
+	}
+	return errors.Wrap(m.db.Close(), "failed to close mysql database client")
+}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build integration
+// +build integration
+
+package group_replication
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/tests/compose"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+)
+
+// TestFetch checks that the queries work with a server without Group
+// Replication, in which case no events are reported.
+func TestFetch(t *testing.T) {
+	service := compose.EnsureUp(t, "mysql")
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) == 1 && strings.Contains(errs[0].Error(), "Group Replication is not available") {
+		t.Skip(errs[0])
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 errors, had %d. %v\n", len(errs), errs)
+	}
+	assert.Empty(t, events)
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "mysql",
+		"metricsets": []string{"group_replication"},
+		"hosts":      []string{mysql.GetMySQLEnvDSN(host)},
+	}
+}
@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package group_replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	testMembers = []map[string]string{
+		{
+			"channel_name":   "group_replication_applier",
+			"member_id":      "8a94f357-aab4-11df-86ab-c80aa9429562",
+			"member_host":    "mysql-1",
+			"member_port":    "3306",
+			"member_state":   "ONLINE",
+			"member_role":    "PRIMARY",
+			"member_version": "8.0.31",
+		},
+		{
+			"channel_name":   "group_replication_applier",
+			"member_id":      "8a94f357-aab4-11df-86ab-c80aa9429563",
+			"member_host":    "mysql-2",
+			"member_port":    "3306",
+			"member_state":   "RECOVERING",
+			"member_role":    "SECONDARY",
+			"member_version": "8.0.31",
+		},
+	}
+
+	testStats = []map[string]string{
+		{
+			"channel_name":                               "group_replication_applier",
+			"view_id":                                    "16657609285405662:3",
+			"member_id":                                  "8a94f357-aab4-11df-86ab-c80aa9429562",
+			"count_transactions_in_queue":                "0",
+			"count_transactions_checked":                 "1520",
+			"count_conflicts_detected":                   "2",
+			"count_transactions_rows_validating":         "35",
+			"count_transactions_remote_in_applier_queue": "0",
+			"count_transactions_remote_applied":          "5",
+			"count_transactions_local_proposed":          "1515",
+			"count_transactions_local_rollback":          "2",
+		},
+		{
+			"channel_name":                               "group_replication_applier",
+			"view_id":                                    "16657609285405662:3",
+			"member_id":                                  "8a94f357-aab4-11df-86ab-c80aa9429563",
+			"count_transactions_in_queue":                "12",
+			"count_transactions_checked":                 "1480",
+			"count_conflicts_detected":                   "0",
+			"count_transactions_rows_validating":         "35",
+			"count_transactions_remote_in_applier_queue": "40",
+			"count_transactions_remote_applied":          "1440",
+			"count_transactions_local_proposed":          "0",
+			"count_transactions_local_rollback":          "0",
+		},
+	}
+)
+
+func TestEventsMapping(t *testing.T) {
+	workers := []map[string]string{
+		{
+			"worker_id": "1",
+			"last_applied_transaction_original_commit_timestamp": "2022-10-14 10:00:00.100000",
+			"last_applied_transaction_end_apply_timestamp":       "2022-10-14 10:00:00.350000",
+		},
+		{
+			"worker_id": "2",
+			"last_applied_transaction_original_commit_timestamp": "0000-00-00 00:00:00.000000",
+			"last_applied_transaction_end_apply_timestamp":       "0000-00-00 00:00:00.000000",
+		},
+	}
+
+	events := eventsMapping(testMembers, testStats, workers, "8a94f357-aab4-11df-86ab-c80aa9429563")
+	require.Len(t, events, 2)
+
+	primary := events[0]
+	assert.Equal(t, mapstr.M{
+		"id":      "8a94f357-aab4-11df-86ab-c80aa9429562",
+		"host":    "mysql-1",
+		"port":    int64(3306),
+		"state":   "ONLINE",
+		"role":    "PRIMARY",
+		"version": "8.0.31",
+		"local":   false,
+	}, primary["member"])
+	assert.Equal(t, int64(2), primary["conflicts"].(mapstr.M)["detected"])
+	_, err := primary.GetValue("applier.lag.ms")
+	assert.Error(t, err, "applier lag is only reported for the local member")
+
+	secondary := events[1]
+	for key, expected := range map[string]interface{}{
+		"member.local":                         true,
+		"member.state":                         "RECOVERING",
+		"transactions.in_queue":                int64(12),
+		"transactions.remote.in_applier_queue": int64(40),
+		"transactions.remote.applied":          int64(1440),
+		"applier.lag.ms":                       int64(250),
+		"view_id":                              "16657609285405662:3",
+	} {
+		value, err := secondary.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, value, key)
+		}
+	}
+}
+
+func TestEventsMappingNotRunning(t *testing.T) {
+	members := []map[string]string{
+		{
+			"channel_name": "group_replication_applier",
+			"member_id":    "",
+			"member_host":  "",
+			"member_state": "OFFLINE",
+		},
+	}
+	assert.Empty(t, eventsMapping(members, nil, nil, "8a94f357-aab4-11df-86ab-c80aa9429562"))
+}
+
+func TestEventsMappingMySQL57(t *testing.T) {
+	// MySQL 5.7 doesn't report role and version, and only has stats of the
+	// local member.
+	members := []map[string]string{
+		{
+			"channel_name": "group_replication_applier",
+			"member_id":    "8a94f357-aab4-11df-86ab-c80aa9429562",
+			"member_host":  "mysql-1",
+			"member_port":  "3306",
+			"member_state": "ONLINE",
+		},
+	}
+	stats := []map[string]string{
+		{
+			"channel_name":                       "group_replication_applier",
+			"member_id":                          "8a94f357-aab4-11df-86ab-c80aa9429562",
+			"count_transactions_in_queue":        "0",
+			"count_transactions_checked":         "10",
+			"count_conflicts_detected":           "0",
+			"count_transactions_rows_validating": "0",
+		},
+	}
+
+	events := eventsMapping(members, stats, nil, "8a94f357-aab4-11df-86ab-c80aa9429562")
+	require.Len(t, events, 1)
+	assert.NotContains(t, events[0]["member"], "role")
+	assert.Equal(t, mapstr.M{"in_queue": int64(0), "checked": int64(10), "rows_validating": int64(0)}, events[0]["transactions"])
+}
@@ -6,7 +6,9 @@
 
 # NATS 2.X
 if [ -x /opt/nats/nats-server ]; then
-    if [[ -z "${ROUTES}" ]]; then
+    if [[ -n "${JETSTREAM}" ]]; then
+        (/opt/nats/nats-server --jetstream --store_dir /tmp/jetstream --http_port 8222 --port 4222) &
+    elif [[ -z "${ROUTES}" ]]; then
         (/opt/nats/nats-server --cluster nats://0.0.0.0:6222 --http_port 8222 --port 4222) &
     else
         (/opt/nats/nats-server --cluster nats://0.0.0.0:6222 --http_port 8222 --port 4222 --routes nats://nats:6222) &
@@ -32,5 +32,5 @@ func init() {
 // AssetNats returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/nats.
 func AssetNats() string {
-	return "eJzsmkuP4zYMx+/5FEROLdAN9ljkUGDRXvbQOXSmp6LIKjITC2tJXorKbPbTF/Ij44ccO5lkHkV8jBPyZ5IS/5TzAb7ifglGsJsBsOIMlzC/+/RwP58BJOgkqZyVNUv4bQYAxTfhT5v4DGcAhBkKh0vYihmAQ2Zltm4J/8ydy+a/wDxlzuf/zgA2CrPELQsbH8AIjQev4eJ9HqyQ9Xn1ScR3uL6EH30BaQ0LZRw4FqwcK+mAU8HwiIRAKBLYkNVw9+SiSdCkcEg7pIVKDndqnK+4f7TU/HwAKlwPKVam4PMfQ05Yaey5SQRjy0dOKAVjsoRfFx8XH6f5vy99Bw9gN6CRSUmQhCJ8uwckrTEoW7diWRhx+nudhqIsnmyGwghPUGG4xo/aJVNf3eS0y6QVtGP5GcGt8xQshiBxivFANBLn1wdzLkqhDOMW6QwKr9dIgaPlBJQBTpUbI8vRJMpsV+s9Y5wss2bbubGxpAUvIfajk5Ar76WhMpbjyD7vLIBJrIkn0TM5CTdHUjbppBmUA5/DTw7lz1FKlWS4eiOggeUYatdXfAVPpBDaesMhmcpIq0N6E8Gi+7SRpdpk0uic2Paq62gEJwAegRz0WCPFqn2U59hSeR5v32INaj1fJ6HW89a+9YQeIN9JQg+8bYv9FuvefI9lyyK7VnuTnggNZ3sQktUOQWYKDfcjRtZzXx+dHavC3EXD9HbVAKG2jCvVVUPPVEqlWVAJWAOPqZJp0aTKyD5RYQJsj6oUp35M7qdnCZECKb6r5pbi2+rZyQoWawHZLdpGd+5u2ZXXM3ZylU9wdxMD76d33MTASID+Z2Kgtz29ud52VQlAuFWOkTDpRuLQWlnwBeOzE3TR6Lzi6FqdKo2NrRr14iXPAipVF/xa2oMPizSk+u7Twz3kZCW6+L4mLQ1AXqDQMrtVUmSlkyJ8TR4gbxwMSCiZ+yiVkyLDZLXJrOhuyHUIcySJpnv3hCDK3Lci6I5GsFiqq/h8ARdTVoWXbF+eIWLSU+3Qk59Xy2pz+4h4uimgmwK6KaATeV5KATVBXWYfw77lvEa6zq4VXMDBRePswZqiFUS5Uub4rHZmpQV7tew49MSyj59YbYTfVl1pNI44ARMOE/43j46bb9JixTKA2UT1pKL3x0AnwnaAIVGOSa19cRZmDWhrFFsKBfj3X5/vB2wcexDo6vUfg18bWVsnPhb0qjhVHHRKhdF8tuEot08eX5+9oDgVfSfo9ckDxKngzq/d65MXFKeik7XdPvvy5AFiCHzs+PWZs2L7wPXdzNTVYfrwmfSTLHZIvQ5ymXZb2gabYznpFgfW7YBmyvHg0LCbPq+eODEE2+dxacEyvRIXK40OROkjzPQb600SxBiIFl98RBUyxcXgWfpFJi3nMwaHXL16wNLpEZxU8YoEx5GuOTwHp3WyQBDCGos3AWHh4g6rvwCN4W+EsZ4XWny/bEy1+K6011DaL9VfAuv9VCCx6xZa9Xch69dZN9hTpp8dktjiEM9/AQAA//8pwp3x"
+	return "eJzsnEGP27oRgO/+FYM97QM2i/RW7KFA8NKiKZogyG5PReFHS2ObbylSISk7zq8vRiIlWSYtyWvZ+x4WCHJY25yPM8PhzIjUO3jG3QNIZs0MwHIr8AFuvnx4eryZAaRoEs1zy5V8gL/NAKD8JnxWaSFwBqBRIDP4ACs2AzBoLZcr8wD/vTFG3NzBzdra/OZ/M4AlR5Gah3KMdyBZhrVU+pPd5TSKVkXu/hKQTf9+ox/9BomSlnFpwFhmubE8MWDXzMIWNYJGlsJSqwy+NCLaBG0Kg3qD+p6n9Sce5xl3W6Xbf49A0b+nNbqh4NPHmBDLM2z9qhKTMtv+Y4q5xoRZTB/gr/fv798Pk/9YSgCSAGoJGVrNE0g0Mvr2AVCipMRk76OQFXqE/urNQFZpjUmOQTNwGN4EAF2XAQgbp41K/+99ELdPD663E41ISrJrbEEHhZtiUQ9nghRcWlyhPoGiyBaoiWNPCHAJds3b6gyS5ShTLlfzxc5imEwouep8sFQ6Y/YBQj8aheykVwPRHIYgF3lnAQxiTQu978ODcXPUXKUdMwM3UORwazD5JUjJU4HzVwJKLMdQu7LCK3ggBctUIS0Zk8tEZWTelNn2Mo0t1TZThsaw1YF3HdXgAMAjkFGJHink7b08x5bKy3gPR/SgqrDTGFQVdqVeu0FryD+IQWve/RE9ZLOSzazPoNfeY62yTMxCyjzD9pYUWqO0YgcssXyDkAiO0h5q7He0xmpk2Vn0RVks/AvtYznkMTUt0A5VFCVmK6V3QV2dlJD86kb0CYnjrNIABNygtHegJNIXKN01d1BpCZQmfzBFhjoIW367IzCszwGcjSZdmrvlaU3b+XZIg22wDLNDHV5iAX8uBUNhMIXFrvGOO1J3eNxGmUqzFV4B+rGSfBK1xtJa6f21Ve5BSCGnzeD6Bjh5DixJaJMzY9kHsH2pw6wXAltu1w0eoGQLgWkUbs3mzBicmE5jLjhFz9RFLwNMpnX8MnD77cM/nqpAb36JwrKc34c2q3PAPtG4rX2rUeGHr58gYUKYo1yotdLTajGAVPU9NNpCS0yBSSg5oqRO+5NixmR4htrqk1LEpVwgtW0wvBCgAIYp7TRMCL8KomyxeDV5sOM/6+bIcfQBgS9RcslX9xn7Mb/eBsR+8KzIXNJRLZdmHSVM0sY6bi7X24r8ZBzBi2dD4+A85To6l3BSOxD3I9eYWFL7dk2d2Ya0FGyAWxOqSVucojAW43ihTHaoLtEyP/5d6e/NFlQpNmOydH+7bqNXu6VfI26AgIRYFtzT3Byq/oGzpH9f2g3PKG9DJZClqC/L5aqKplgkCGpZuKKoNtVRcsN/HtdnZGmOgP77jxwTymOaTKFir2u2wbQuKTL3ZeY2MfdBLmZgqYRQW6/kI1Y/BK7MdHnkcl0yjdTDtYpiB1YJb2QGnvygsXA8hPQAfnZFOqXde0EtIKSvGI6EgL5lNkCFe8srNP+DCmUajg9VZdLigAXSQjRgVRSpfHaFaRSp89xsBM8TPSRrwWyZiUrr7pfF4ndMrJlGUY9u9DYcNyC4sShpmSrZB6jRogw8hTgT4Tc/PORK8GS3715wK3jGraFsyqJGY6lHtVX6+XuBBf7SB9+XV71MuT5l2uWdVQG3Sy6QUKscsZdTFtncR6TZyPA3gDQQ9irBhXbNh4a9D5XS1SnLLZ+QysOyi2oFtT0gvoN3fyn/VMjSW/oXHc0hM6vL4Nc1zzPm1u/oLyGPZfvnQjetgs1z+vT/NOITSpsRvGxV49a67qiZS5BMKoOJkqm5g/fj5pBykzCdThNGvlZxj+W54JjCdo1tdjp7kqxpRpbiNgVDuFUipeAicRuPLNSrx3uvkAnU3wSVQFXfTKAH8CRnPkPd29OVCPl+z0yWXBs7N/h97GyG0OL3AmXSfghGhKVIjz9K7eUv59ZMlQy9GFCwSytTsJegTq7Kk/Eot+jNMs8RBlI6NycTC17aaMwUBYbC8TkpnQoNOGHVuT7CzHiailrfg6B9ajI/Xuycg9uLMgMB4+2CeJE6EKrV1TrO8spbVgPrxzbrkZbGdRpZI2zRzOKtTXSxNpFftrOha/C0RlFEzOtoFUXgGoyoy54ZxK31NhPl16kpN4Eo3sV6WTXU6+hm1Tgj+ll0ZnUhcD6dZ32sJIAc42GeDgXfoJ5XvaZp+L4qLjt1fulesF3zZL2vWGOZtgY0Jsg3g04quomw5HnSSXxInqXaCkxXmKG0neZczX8rlcQ76suA0oA/KFhy29vuIvot43Y2MoSPcV63a5IY2nA0MAmsM6sFLlV5vcP5BVmAeRt0uwZ9k6JGhxtngnn5ZkdTszhZO2DWYpZbtyfU9Cc1asitqpP4F5mDgzX18f+OhfqYaXtmu0lXwrdSRHQBcGkso+PGGpTmKy6ZiLu/MxmmUdQXZOX/bheItSRKVtrEJ+bo/ueRkrzXOwbOwZ23dcFxv04/ylft7Beg8w/mRrBR5T6vTipHhj26Y4+AO+gXlFK53c1ibBSJl0IFjnedwSP/yVdremRDl+v4qlCFqf2ztc7LI6hvLvrmohEXpf7VtPtSU+459zSt+EmHSsfuToSssT/anxW5PMiwZhuEBaJskho3B6ks7NC255AenQBlTlPrOy+EAE2OauiccSWxTFv8tI4iXtQl9jN6r87hG+1VGnMvjKmRKm5IajUQ+VhrzsP/iZpzR+wR6na9tecmaM/V1KrY283Cy2/IzbDyJl053Fkv0b3eu+IaM2VxztMgVXiRDaCqhgWegpKtdkml2YYK024k8FxuQ5gHTm1GnW+UtpyACsmEGZS25zVWrrT1AaTrtI1cnoel5uMF8nyAODkbunsNkBi41fyabxbXkFGJHil2RGHq8w0R3sMR366K/wmvih+Ep7Atr7i3TXpBXOOKTrhSVt7RROwm8wv1s2H6rNq54otNXELb91KTDLP7kDf3QoZ+NIDQZZb+0lVh3Km/8lJ+rlWCxgRBE7oWNJWjCbXiCROVkFJ9bR7QhTTdI9Y1V150Bq9UZxImMJ0vhWI2osIcdXKYZY9QYpIXexo0njhIWi7VeZMRDjb5KFWWUsTOP2c8eKdDm6jKEyezajt8BCS9ZUBvGdBbBvRKM6A2qBFqG72rEKUdFSpIRN2nMq02kJLlVhDkopcYntPTaDyfdtR7YrWPj/Q2jd/n3dSoH3EApkete73NexYD341htlELzYOf94EOhO0Al4dbNV8UZAfqTmRKcqs0rer/fPv0OAv8vGcie3qnLPVn9GtHvPWEaR168ZrOSCjpMNpzi2u5gafM4PrsJcVY9A3T1ycniLHg1Bq8PnlJMRZdK2VnwW9ckJwgYuBtHXspZtYXXwbXinvD/nFqaveqtZhSGgouDeqDHSRq1lEQ1digcqwq3fKG/L5C6W5rkIuKhg1Ow1WNfRpXxixd8JqEi7oIBlglg15UulSFrO5Psj2+IFnCkjXeR3vpZ6m0TCEsvei5fr5aCj2Cs+Z2rg+PIUxfPJNQb6zyUdMCKXJoWri48RdJ+vCXTKrC0gtszqvTzJ2tq8YH916xxW4oENusZsETH6pYCBzPwzZYXo6O8Px/AJTdN9E="
 }
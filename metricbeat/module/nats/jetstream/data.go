@@ -0,0 +1,247 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jetstream
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	categoryStats    = "stats"
+	categoryStream   = "stream"
+	categoryConsumer = "consumer"
+)
+
+var (
+	statsSchema = s.Schema{
+		"memory":  c.Int("memory"),
+		"storage": c.Int("storage"),
+		"reserved": s.Object{
+			"memory":  c.Int("reserved_memory", s.Optional),
+			"storage": c.Int("reserved_storage", s.Optional),
+		},
+		"accounts":  c.Int("accounts"),
+		"ha_assets": c.Int("ha_assets", s.Optional),
+		"api": c.Dict("api", s.Schema{
+			"total":  c.Int("total"),
+			"errors": c.Int("errors"),
+		}),
+		"streams":   c.Int("streams"),
+		"consumers": c.Int("consumers"),
+		"messages":  c.Int("messages"),
+		"bytes":     c.Int("bytes"),
+		"config": c.Dict("config", s.Schema{
+			"max_memory":  c.Int("max_memory"),
+			"max_storage": c.Int("max_storage"),
+			"store_dir":   c.Str("store_dir", s.Optional),
+		}, c.DictOptional),
+		"cluster": c.Dict("meta_cluster", s.Schema{
+			"name":   c.Str("name", s.Optional),
+			"leader": c.Str("leader", s.Optional),
+			"size":   c.Int("cluster_size", s.Optional),
+		}, c.DictOptional),
+	}
+	streamSchema = s.Schema{
+		"name":    c.Str("name"),
+		"created": c.Str("created"),
+		"config": c.Dict("config", s.Schema{
+			"subjects":      c.Ifc("subjects", s.Optional),
+			"retention":     c.Str("retention"),
+			"storage":       c.Str("storage"),
+			"num_replicas":  c.Int("num_replicas"),
+			"max_consumers": c.Int("max_consumers"),
+			"max_msgs":      c.Int("max_msgs"),
+			"max_bytes":     c.Int("max_bytes"),
+			"max_age":       c.Int("max_age"),
+			"discard":       c.Str("discard", s.Optional),
+		}, c.DictOptional),
+		"state": c.Dict("state", s.Schema{
+			"messages":       c.Int("messages"),
+			"bytes":          c.Int("bytes"),
+			"first_seq":      c.Int("first_seq"),
+			"first_ts":       c.Str("first_ts"),
+			"last_seq":       c.Int("last_seq"),
+			"last_ts":        c.Str("last_ts"),
+			"num_subjects":   c.Int("num_subjects", s.Optional),
+			"num_deleted":    c.Int("num_deleted", s.Optional),
+			"consumer_count": c.Int("consumer_count"),
+		}),
+		"cluster": c.Dict("cluster", s.Schema{
+			"name":   c.Str("name", s.Optional),
+			"leader": c.Str("leader", s.Optional),
+		}, c.DictOptional),
+	}
+	consumerSchema = s.Schema{
+		"name":    c.Str("name"),
+		"created": c.Str("created"),
+		"config": c.Dict("config", s.Schema{
+			"durable_name":    c.Str("durable_name", s.Optional),
+			"deliver_policy":  c.Str("deliver_policy", s.Optional),
+			"ack_policy":      c.Str("ack_policy", s.Optional),
+			"ack_wait":        c.Int("ack_wait", s.Optional),
+			"max_deliver":     c.Int("max_deliver", s.Optional),
+			"max_ack_pending": c.Int("max_ack_pending", s.Optional),
+			"replay_policy":   c.Str("replay_policy", s.Optional),
+		}, c.DictOptional),
+		"delivered": c.Dict("delivered", s.Schema{
+			"consumer_seq": c.Int("consumer_seq"),
+			"stream_seq":   c.Int("stream_seq"),
+			"last_active":  c.Str("last_active", s.Optional),
+		}),
+		"ack_floor": c.Dict("ack_floor", s.Schema{
+			"consumer_seq": c.Int("consumer_seq"),
+			"stream_seq":   c.Int("stream_seq"),
+			"last_active":  c.Str("last_active", s.Optional),
+		}),
+		"num_ack_pending": c.Int("num_ack_pending"),
+		"num_redelivered": c.Int("num_redelivered"),
+		"num_waiting":     c.Int("num_waiting"),
+		"num_pending":     c.Int("num_pending"),
+		"cluster": c.Dict("cluster", s.Schema{
+			"name":   c.Str("name", s.Optional),
+			"leader": c.Str("leader", s.Optional),
+		}, c.DictOptional),
+	}
+)
+
+// JetStream stores the information returned by the /jsz monitoring endpoint
+type JetStream struct {
+	Now      time.Time `json:"now"`
+	ServerID string    `json:"server_id"`
+	Accounts []struct {
+		Name    string                   `json:"name"`
+		Streams []map[string]interface{} `json:"stream_detail,omitempty"`
+	} `json:"account_details,omitempty"`
+}
+
+// eventsMapping maps the JetStream server, stream and consumer metrics
+func eventsMapping(r mb.ReporterV2, content []byte, config config) error {
+	var jsz JetStream
+	if err := json.Unmarshal(content, &jsz); err != nil {
+		return errors.Wrap(err, "failure parsing NATS JetStream API response")
+	}
+
+	moduleFields := mapstr.M{
+		"server": mapstr.M{
+			"id": jsz.ServerID,
+		},
+	}
+
+	if config.JetStream.Stats.Enabled {
+		var inInterface map[string]interface{}
+		if err := json.Unmarshal(content, &inInterface); err != nil {
+			return errors.Wrap(err, "failure parsing NATS JetStream API response")
+		}
+		fields, err := statsSchema.Apply(inInterface)
+		if err != nil {
+			return errors.Wrap(err, "failure applying jetstream stats schema")
+		}
+		addReplicas(fields, inInterface["meta_cluster"])
+		if !r.Event(newEvent(jsz, moduleFields, categoryStats, fields)) {
+			return nil
+		}
+	}
+
+	for _, account := range jsz.Accounts {
+		for _, stream := range account.Streams {
+			streamName, _ := stream["name"].(string)
+			if config.JetStream.Stream.Enabled {
+				fields, err := streamSchema.Apply(stream)
+				if err != nil {
+					r.Error(errors.Wrap(err, "error mapping jetstream stream event"))
+				} else {
+					fields["account"] = account.Name
+					addReplicas(fields, stream["cluster"])
+					if !r.Event(newEvent(jsz, moduleFields, categoryStream, fields)) {
+						return nil
+					}
+				}
+			}
+
+			if !config.JetStream.Consumer.Enabled {
+				continue
+			}
+			consumers, _ := stream["consumer_detail"].([]interface{})
+			for _, consumer := range consumers {
+				consumer, ok := consumer.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				fields, err := consumerSchema.Apply(consumer)
+				if err != nil {
+					r.Error(errors.Wrap(err, "error mapping jetstream consumer event"))
+					continue
+				}
+				fields["account"] = account.Name
+				fields["stream"] = streamName
+				addReplicas(fields, consumer["cluster"])
+				if !r.Event(newEvent(jsz, moduleFields, categoryConsumer, fields)) {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addReplicas adds the number of RAFT peers following the leader of a
+// cluster group, and how many of them are up to date with it.
+func addReplicas(fields mapstr.M, cluster interface{}) {
+	group, ok := cluster.(map[string]interface{})
+	if !ok {
+		return
+	}
+	replicas, ok := group["replicas"].([]interface{})
+	if !ok {
+		return
+	}
+
+	count, current := 0, 0
+	for _, replica := range replicas {
+		replica, ok := replica.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		count++
+		if isCurrent, _ := replica["current"].(bool); isCurrent {
+			current++
+		}
+	}
+
+	fields.Put("cluster.replicas.count", count)
+	fields.Put("cluster.replicas.current", current)
+}
+
+func newEvent(jsz JetStream, moduleFields mapstr.M, category string, fields mapstr.M) mb.Event {
+	return mb.Event{
+		MetricSetFields: mapstr.M{
+			"category": category,
+			category:   fields,
+		},
+		ModuleFields: moduleFields.Clone(),
+		Timestamp:    jsz.Now,
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jetstream
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/jsz"
+	// Details of accounts, streams and consumers are only included when requested
+	queryParams = "accounts=true&streams=true&consumers=true&config=true"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+		QueryParams:   queryParams,
+		PathConfigKey: "jetstream.metrics_path",
+	}.Build()
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("nats", "jetstream", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+type categoryConfig struct {
+	Enabled bool `config:"enabled"`
+}
+
+type config struct {
+	JetStream struct {
+		Stats    categoryConfig `config:"stats"`
+		Stream   categoryConfig `config:"stream"`
+		Consumer categoryConfig `config:"consumer"`
+	} `config:"jetstream"`
+}
+
+func defaultConfig() config {
+	var c config
+	c.JetStream.Stats.Enabled = true
+	c.JetStream.Stream.Enabled = true
+	c.JetStream.Consumer.Enabled = true
+	return c
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http   *helper.HTTP
+	config config
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The nats jetstream metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		config:        config,
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return errors.Wrap(err, "error in fetch")
+	}
+	err = eventsMapping(r, content, m.config)
+	if err != nil {
+		return errors.Wrap(err, "error in mapping")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jetstream
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventMapping(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/jsz.json")
+	require.NoError(t, err)
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content, defaultConfig())
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 3)
+
+	for _, event := range events {
+		serverID, _ := event.ModuleFields.GetValue("server.id")
+		assert.Equal(t, "NCLI7NMPXJOUPJ5O4SM3ZMWROGXG2FDFBTXUI5KGU6ZBN75JAMBLLOMJ", serverID)
+		assert.Equal(t, "2023-05-08T13:03:17.503697Z", event.Timestamp.Format("2006-01-02T15:04:05.999999Z"))
+	}
+
+	stats := events[0].MetricSetFields
+	assert.Equal(t, categoryStats, stats["category"])
+	assertValues(t, stats, map[string]interface{}{
+		"stats.storage":                  int64(2397),
+		"stats.api.errors":               int64(2),
+		"stats.messages":                 int64(21),
+		"stats.config.max_storage":       int64(53687091200),
+		"stats.cluster.leader":           "nats-1",
+		"stats.cluster.size":             int64(3),
+		"stats.cluster.replicas.count":   2,
+		"stats.cluster.replicas.current": 1,
+	})
+
+	stream := events[1].MetricSetFields
+	assert.Equal(t, categoryStream, stream["category"])
+	assertValues(t, stream, map[string]interface{}{
+		"stream.name":                     "ORDERS",
+		"stream.account":                  "$G",
+		"stream.config.num_replicas":      int64(3),
+		"stream.state.messages":           int64(21),
+		"stream.state.bytes":              int64(2397),
+		"stream.state.consumer_count":     int64(1),
+		"stream.cluster.leader":           "nats-1",
+		"stream.cluster.replicas.current": 2,
+	})
+
+	consumer := events[2].MetricSetFields
+	assert.Equal(t, categoryConsumer, consumer["category"])
+	assertValues(t, consumer, map[string]interface{}{
+		"consumer.name":                   "processor",
+		"consumer.stream":                 "ORDERS",
+		"consumer.account":                "$G",
+		"consumer.num_ack_pending":        int64(3),
+		"consumer.num_redelivered":        int64(2),
+		"consumer.delivered.stream_seq":   int64(20),
+		"consumer.ack_floor.consumer_seq": int64(15),
+		"consumer.cluster.leader":         "nats-1",
+	})
+}
+
+func TestEventMappingCategories(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/jsz.json")
+	require.NoError(t, err)
+
+	config := defaultConfig()
+	config.JetStream.Stats.Enabled = false
+	config.JetStream.Stream.Enabled = false
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content, config)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, categoryConsumer, events[0].MetricSetFields["category"])
+}
+
+func TestFetchEventContent(t *testing.T) {
+	absPath, _ := filepath.Abs("./_meta/test")
+
+	response, _ := ioutil.ReadFile(absPath + "/jsz.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Header().Set("Content-Type", "application/json;")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "nats",
+		"metricsets": []string{"jetstream"},
+		"hosts":      []string{server.URL},
+	}
+	reporter := &mbtest.CapturingReporterV2{}
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	metricSet.Fetch(reporter)
+
+	e := mbtest.StandardizeEvent(metricSet, reporter.GetEvents()[0])
+	t.Logf("%s/%s event: %+v", metricSet.Module().Name(), metricSet.Name(), e.Fields.StringToPrint())
+}
+
+func assertValues(t *testing.T, fields mapstr.M, expected map[string]interface{}) {
+	t.Helper()
+	for key, value := range expected {
+		actual, err := fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, actual, key)
+		}
+	}
+}
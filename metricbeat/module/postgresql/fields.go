@@ -32,5 +32,5 @@ func init() {
 // AssetPostgresql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/postgresql.
 func AssetPostgresql() string {
-	return "eJzUWk+P47oNv8+nIN7l7RbZoL3OoUCxr0AX6O7bh92ix4CR6ViILHklOZn00xeU5D+xncwkY8+2Oc0kFvkTKZE/kv4Aezo9QmWc31lyP9QDgJde0SP88jV++e2Pf/7yAJCRE1ZWXhr9CH99AAD4TN5K4UAYpUh4yiC3poRuHTiyB7Ju/QDgCmP9Rhidy90j5KgcPQBYUoSOHmGHDwC5JJW5xyD8A2gsaQCNP/5U8fPW1FX6ZgIaf3o4yoh0nX7r6+nrQuHlQfpT+8OUtisa+fO7JsiMqEvSHiqyyQZQWSPIuRUb4ij1DqTOjS2RZbAZkO3nDfiCQNTWkvZnchtsYHLwBfqewFoUgA6cR0+AOmvWw4+a7GkNH1v/bE9nMsPvjKXabXj1plGy7j127qLmMzRh34wZetyio7WR2dkDjTmV0bvBD1csGqz66be4cWqlgy+kgy2KPekMJB9DreM2vVlfB8b/TiLb0+lo7BD1M+C+YEkzoKtms9bXeDSgMVqHZFpz7ciul/AVCwZldjvKQOpwul+EZcI/N/jgDq1YVUqKcBk3r1PekxTv6cD3LwAjlCTt15hllpy7Dcqnr5DWNYCitDsxFMb52+3xD+N8kNNiaJVHuSuOV5YqY2NUAgRLnCkIfvvyDZQx+7rixfHxDW/pKk6WNNPx/f7xK7A40HW5JRud2DOkdFA7Dpq5sSBMWda68fdR+iLYdiQ02XoFxsKHv4DMAeFfWj6BM2JPSShd8EVavOEd3biXU9X5ICWFxu2cH53cKgqWcoCWAGtvDijqugSFtRYF2VX/y6Oxe7KrkR5ldlKgYpe2h78TMPVrkgQVWlSKVPsFw+N0q4fhCOBopedHkiNam4qCxL4yUodfnUfr62oFR1SWBMkDf3tkwqEzsiFBHlFFYeuRkr8/edJOGu2gxBNY2knnySZ8LvoYs0zyNlC1IT4Y8br/ArLJU5qhv9WzsiQ4FqTjXU5kAI6RB/C1WoFc03rVPDQZCEZi+blIWKa34i1qxyzB6DfYzq/toe3p7e9xGmSgNYvBa2+SOkVSRlHhue2N5UseKZl0oM0QSmJ01HOQQufHsqb3GCRvRIF6N81kXrvJCJ1xBFhR0wUwR5RejuJsxLE1RhHqG6HYmth+IxLVWj6pBKMBQRmxv2Km23R/TEfOHIhDUzLEkEd10fOAqo7hs2PDI6EAf0r+foTvBfX3RE8k6rAXTIR9crXM1HhtYwVORQiajt0lL0sc5va+KJC6f6lGkiXbtffACra1v3SS+dO55oYNDVDAO9wGSvCe8UjX3R8nS6nQMndJ6yZBnAGmJ0GVB6PbFBjEcWEW9sff9JUL5BQ8KRc1kLVmIl3wTnJ0vkJfQF7rRpRSVx3NSz6crZkWnUmHW0XZ0B4td+JLYlHsm9JNkuPfm3Vxn8+RveClG28oPfnhpfjVQcnMj7NuV31+6oXBFC/DolBBjuRydewGUbYzXCNSA99M4wuur1m4W4H03eIxQelCa+BzHNei2GsxbUOHYRn+rGH+jdJDWBcZsIxH71oQew7APcSviLSOXRTBcIg4FlIUQzgjEC1t2UWO9JpuyDePXjovhQPcmtq3yiPFS5SuzfeubXP0uxaRbg/d2vQsGpjjWHN756Jjkm7tREFZrUYR4d664kssJ0wOreS+vnguCzwQbIk0VGRzY8tLx7OP1NKPmpxfAGkreSakXpbk1sFf63JYJUW4uTJ445X7bjwqwNLUOsYkJjIJpIsYXRVKtxj0OXRylOb6sgU3kprOJB+9Y0GWIJcqlUm8Ac88yXCg3a9YcCmVko6E0dmFMmBsCHfS4v/ZDoy/sEbL/8S2wg3G2NZ5Ttate0aZ/fQmHa27stryLvp+uI5tgrjOj2p7mg6KL8C2yWulZgcYzuaFQO28qSrKACEAYHM6gRq2FNgTyPH5KTDrXRgDJepTu42re0xJavlzIS0JzsehEXWVKQ2gbXK+Agt5oIUSTOhNQ1hAegfmqCEoD1wT3mljS1RqyOLggh8L1JkKXjaOAkPoKr9Ga2aYS0Zd072Y91dthEoZgUukpcaBrYbLxZ/bWHI0ax+A6WNDpFwsKhLFOXJ4DFQzKB1TqmY68BpK9bsmsOYYZkuNvG6q1Hzz4SizPrbzKVA7+ZlkVBMob6dSP2/2E5ovfw6E1xVoKWNvmNqKS/25nzwNWgGVlT/dAjjchI3JN0nkArkzCe5VK+MJQjtPe65X6NbClOUoOcwQKXs62lq3Z/UzohoxXAwXZ3itUYpt8HMRMwq+sHipv7VVRuy5AsD5oyyzuaQAWMEI7VVIxRLeDnmxjytE3DxkNlQM8tSQ25gjQKAoODxOtbrRh7kT8xMMjS3QxFQX7QnehZ0arVigUHVGDgrZNY66lwtGgs81s1heYCqyGJoY7uQ8lb+6QKTTf/Hp91ctyrsPnr5UMtwx6+J8FmsCFtwkkIgsmXh76oLB8ASsphpxLyD/vQ1dLQZftSOW/FY7subIt9DXVi9RhpsjX8EovUnfodP3ohsZwOXkRbEUtiT8TmhSO7KLtC8YWyP9TnB1lS3CYQO2JPxOaBkpWgxaEn47NGF0rqRYoJ5vcAjUgjgvZjUxF2k1xuGsJWEOZE8N3pHEZ2gLlZWxaE/r0OiYP4k18lMjRVh69gzA30Z1PowEoSUQptZh/mhph5ZLvPAeyLGITYbzJZz2RlIbOO9ovVtz4rRxzsY1oyuk3r1fhRH6uYIwvjS7DSvYTNkNwJG/3OzujL49+dmMPmyIhUzQa94NLTh2weWzwy65wwXPeDEwkeSCJeycEWYhCc7PFhvJkJGPZcLL4sX/TpU+KtfbeeFr6/WzF0LjGIr/Ci+qTdfxE2+HnkmV+mDSqzTNC6FBbvc6qKhqqB3u4iuhPlyFwLhueB+0G5i+bq7yli8Ydg2sYBWLkbBPTKLf/H3V/psittYwnPqezWPns1eHpfXnNb2enm4cPPwRB6vDdediBSq1QDZtm9XRtO2kw9ZXjcscZ9Yso6+z9QtHL1mcr2W4obNPgzpY/SKmqflqrbux+nMAS6lnhPdZalnW5awA8WlOgPg0O0DCiya8/dx9JtRzonM+y+gwH76vpqpVTFHOo87QZpDRQXZZq9d96CN94QgxQi+pNPa0jo3TGbtOw+uTOrOhhRC7NbEflMZ3z5r4HOeMDbsXAA09rvuAZtJ6OVuN+QKsSeGdcBO5fzu4gynuS+EqI1AteFqD/Fcf1ohywbM6hnnPUY0wlz2pY6R3HtQIdtlzOgZ75zHlMnNJ/7P8V7s/gFzWoCOc0/b8bwAAAP//1pQyXQ=="
+	return "eJzUXEuP3DYSvs+vKOQSe9EWNsCe5rBAkCywBpJ4EnuRYw9bqm4RQ5EySXWP9tcvig+9+y1N1vAcPN1S1VcPsl7kfIAXrB+hVMbuNJqv4gHAcivwEb578h9+/v2X7x4AMjSp5qXlSj7CPx8AAH5Fq3lqIFVCYGoxg61WBbTvgUG9R22SBwCTK23XqZJbvnuELRMGHwA0CmQGH2HHHgC2HEVmHh3xDyBZgQNo9IWtS3peq6oMn0xAo58OjsIjTcJ3XT5dXiy1fM9t3Xwxxe0ER/r5JBEylVYFSgsl6qADKLVK0ZgVKeLA5Q643CpdMFIoqYGR/qwCmyOkldYobY9uxAZqCzZntkOwSnNgBoxlFoHJLL4PXyvUdQI/NfbZdEUD/z1hKXdrensdmURFAQxNBDCtwq4aM2bZhhlMFM96D0R1CiV3gy9OaJR+Pn382QuODXWwOTewYekLygw4uaGU3g2tSk4Do18HPDyyF6wPSmfXgfuNFTgDunI2bT1514CotBbJNOfKoE6WsBURBqF2O8yAS6suxTJhnytscANXVpaCp24xru9j3qHk1+nA9heASQVHaROWZRqNuQ7KxycI70VAntqNGHJl7PX6+LcyFmRHKS1zT3dF+5XGUmn6bFMDA40UKRB+/u0zCKVeqpIE8I+vSaSTOInSTO775acnIHIgq2KD2huxo0huoDK0aW6VhlQVRSWjvQ/c5s6+I6JB1ytQGj78AHwLDP4j+SsYlb5gIIpHbBFeXtMWdaUsddnaIASFQC2hOG34RqDTlAGmEVhl1Z6lVVWAYJVMc9Sr7ocHpV9Qr0Z8hNrxlAnQ2Dp/S2Dq20AJSqaZECiaDwgehVs53I4ADppbeicYIgiygjTH9KVUXLpvjWXaVuUKDkxoTJHv6dMDJRwyQ+0C5IEJT6yvcPr3r1eL0nAlDRSsBo07bizqgM94G7Ms46RzJuIq8ko8bT+HbMCQXnOB6VrL8gLhkKN0/haTATj4PICW1Qp4gskqPjS5EYzI0nM+YZkWxWomDWUJSr6BON83Ttvh25VxGqRLaxaD16wkUQNpYo8+j+rrXmla5JRUIS1uqYZQQkaHHQMJZuyY1rSMjvI6zZnc4SJCOgZOJgfLczqi8APjlo/2WY9jo5RAJq+Eoisk/XXjFKmx1XxgCUoCA6HSlxNquo73T8Hl1B5pawqKGOZR7e65Z6Ly22ebDY+IAvwt2PsRvuTYlQlfMa1IfcBCwj75Ns/E+N2oBQpFDCQe2kVeFExmx0kBl93FPKLMSa+dB1awqewxT6af1jRXCDRAAe/YxqUE7wkPjyUN/YcXXDBNuUt4bxJEDzC+plhaULIJgY4cFWbGMc6xxzxllcFx1KF/TAJqrSbCBelzy4wtmc1hW8lISoiThqZXPvTemSadccM2ArOhPprciRaJZulLLN04Gvo+vufl7Pjt5CpxVrpulXzBVzssLr43UFDmR1G3rT4/drbBsF+6l1wFOaJL1bEZ7LKt4iJJCbQylc2pviadmBVw2748ItvZWl0+R/uaJ3tqT1vjfliGn1XMn4xbcO855fpdrOcHo03sHIBbEj/y7ZD8eTC0RRxynuZgJ/eQ5GEIYLPzOdI93ZDPllluLHWJ2EZVtmHuU7yQ0jXxPngIt72uhU+3h2aNPYsIMzjHXZ2LNpM0iUlzzCqB2Ux1xW++nFBbaCh3MlfyeWYhZ3uEDaKk1hH1h465Zxepxq8VGrsA0obyTEgtL9Akzl5JMaxsycsfYSsUu3LJfVGWCWCFqihsb4G4RJDGYzQlrYGw6dPWSbu02nbAjagGnyTXO+SoEbZc+DjvvNZS0qYg4+ZlRbtswYXgBlMlM3OpIkwt029ZD4Q/10ry/2J2pTI21XZLneGOUmb33sCjMVdWaZKiw/IMtonEdX5Um3p6U7wA23pbidgWnw8gLR9zZKM2VpUlZsDAASB1mpRJ2KDLnoCP/SdnWSOrVQoKJutGjJMyhiA1u4BDC2RcY0rx2DWiAteLoK23tARmB+gt0EBxKrQqJizArQF1kOCYu1wT3kmaJQhRT1b0YzvmTGa0im2uDLp0pa38ItdMUS7peY3Iku7w/UkdMSFUypYIS4EDNBymjUVpp1lrNGjnLJGZbRMp4wvlkOIcaHt0qaZjmjwMEcXpwD0p1SeJoNWBEoSGXjtVip98OPCsi60/BWomP5MZVaRxVyr1181+XPPl7y7hNTnTmIFGoyqdHuvP/cXToBVgUdr6GsBuJazVdh1ImplU3VligXCnDOti9gJF8aZRdmprk6SqKLidHWaXR1PrdrTeS1Q9hqPbRQ+vVkKQcv9axISCBiTsWH9rQ60vqgBYNjtSyuYCAyAGI7QnIeVLWNvFxS4ut+NuXWSjbj/L6pjc+hgBKUtzXIFRI7IuMaa5E+UnzLVoQSKlukzX8M5JqqQggqmoMjSQ87Zx1B4uGBHucyayhEeVqBkV2mBqY7H43riCIvzmn35/UqOUFThLHysZrleti2e+JiDCMYB4ZEHFm7rdDIYesJpqxF2Q/HcEOlkM3iURUX4ribQ60Cq0lZZLlOHUE4vUY/jmOEZ/AtwWLbUdlsEWiN8IjUuDepH2BWGL1G8EV5XZIjksEYdA/EZoGQpcDFogfj00OuIleLpAPR9xpEymSO2zrEJqOzQc/XBWY0qzmxAJJobxp/FbLEqlma4T2gXnl6KhHxopqcazPgA/jup8GBGijkxKPTCaP2rcMU0lniGeh9w3GfqvUNgbUY1w3mGySyhwahe0FNWMJudy937lRuh9BkRcqN2aGKyn9AZg0B5vdjfAkk1tZ1P6sCFG+uz0IgbqMBMmOO47ZJIbTDAi2CdBJokmWELPGbLMBd+ZNNy6dUMZMrS+TBi58SSk/58q/WEIrXNg5WEI66ohiEZW0CrqnoAhRFVj1HDo4uKpR4fSij7s/L42QlnjnCc+fWAienYyVdVv0F5a12slZiyW/2hhO8p9dazgudS8YLp+ppr+2Vgms039nEwiOzCRlMq4gzmzbiPxRMCfP/4CkYE7weaYgOEyxThf1mEqivDnj7+4SWUATa006lQ0Bz0iJQiHlI5UpSSVZha9ROsS9dpgOtfIgETaoaTSJJxaiWiAt9BX4SAz9fM74pYa91xVIf07AR9TpbO5rOE39XA4T21Jz6RC4tCKksAf+LXiGk33GPgP/yA3Euxod53Qbku+IFLq2EPJdgi8YDucB/KSMbPnIeQUjtk9YF15bJacXRBmCjoumnbive8chFEFPRQbyTS+KBAI0h2SESdcUKSI1jU9olhuqO5Hb3dAX3CMQcgptQm4iRNmM0B+w1lyv5/QNcXRoSd8otZRezPGT1fcSZ0DE2uu1pa7lIAbQOnO+JwT9S2mxV5S4hQl9aZ7GznfIBV0m/AlyWAXW4yDiSuvZl8nkXxvKtDNCqdBUZa3EKJONhn4nIDAJbWz97g8CtejbQcSdFyPajZGijNVQfnzxwAmvKDRMi7J6L6aoln1llPlRtMkbo4fG6VEM1nuSo7LDyEcSQ9HLE5jWfaSTtRlSGOd9OfhLDc5uw3UmWs8M2HrcIkfBQArqEzFhKjDgfvKXR0gJKdxh1ss0zd+7kA9vg0UOV4C58jlnzvwjC4GBY4rP2lhe8YFxUIfRqjRETGcjR8D7AteCLraK6cOod+hxM/tgfTuLrICwV8Qnk3sNviaOWU2zavy+QzEWqbruXGG02FUIXYO0XeM/swo03imMl9ZlJYzQb+4Dx34r5XSVXEJ9lJzpftXd++x+VMgN4DsZvQbpHwmpZMzkroApi/neX8QbJeQzWat28gRHD0XJGu0FFxsk0hciouS3NBrWAzYqCKrb8E4YyZMmS8KVrqFjfZAo/etqFy72+Gng0y0q1MK4Rsk9I1Ulm9jIGiuCQak1C5ves3cjjPns2I6BAubwvHA7GZTuPe/CVNESW8yBWWirF7YFp7JlbGlg+5bsEIj4+VmEMoumGcOa4wTICZuYdwBons1l8hTEMxrw1MmfOAOt2aPBT9SSymqHZfzYfpU2bKy4MlS6Is3d8/UgEmc8MwH5edA8RoUzShtiYuI6HhQGtqwOQHlREU8G462EPYZyfEL5B1I6yVLWkP3JZRsR1fnlpQvz3GpTCiSb3ZUt878AIPOcmn0E5s4ijmBlM4YcF1gtnaBZE377rLhoOEY0ccmB2mb9RZF8jDCHG863jO3HP0pGxoh1u5/rjyfPoE88XdtelS53Kuw4cY/ZePotn/IJi0rqAzNSCi8WNenpBZo/wxzj+joL9m0Vz0nB54XH2N+yz+N0h69dxmMZpTK4PheW4vuzf7STkDhBiq6knBsrbin5tNXi6Wx5ym+Fl+vzHR+J7wweq9PllIdM3t3s71m47i0d7R0dVK5dDprJjDDAeXkOcP6pMZpWboVOvtkooXVTRLDhEJXUrYXgs8BLLicEd6vXPKiKmYFyF7nBMheZweI7KgKr/e7X5HJOdEZm2W4nw/fkyorEWoCqrOYziDDPW+jVjsr6yG9sKZxzyYFFkrXib+jMuN5+eHyCXdK3OFnf87cn2QPacVZFfdxznjV4AKgxO1GoBnXluMbYg0Mb4QbWkVvBzf2pq6E6yr/Bb3V0b/bWR2VJX11DPMWV3VUFvbUMdIbHdURWthPx2BvdFMq0pe0P9G/2/xEZGGFjnBO6/N/AwDo8kST"
 }
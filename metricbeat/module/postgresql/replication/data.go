@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	"strconv"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Based on: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-WAL-VIEW
+var statWALSchema = s.Schema{
+	"records":      c.Int("wal_records"),
+	"fpi":          c.Int("wal_fpi"),
+	"bytes":        c.Int("wal_bytes"),
+	"buffers_full": c.Int("wal_buffers_full"),
+	"write":        c.Int("wal_write", s.Optional),
+	"sync":         c.Int("wal_sync", s.Optional),
+	"times": s.Object{
+		"write": s.Object{"ms": c.Float("wal_write_time", s.Optional)},
+		"sync":  s.Object{"ms": c.Float("wal_sync_time", s.Optional)},
+	},
+	"stats_reset": c.Time(time.RFC3339Nano, "stats_reset", s.Optional),
+}
+
+// Based on: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-REPLICATION-VIEW
+var standbySchema = s.Schema{
+	"standby": s.Object{
+		"pid": c.Int("pid"),
+		"user": s.Object{
+			"id":   c.Int("usesysid", s.Optional),
+			"name": c.Str("usename", s.Optional),
+		},
+		"application_name": c.Str("application_name", s.Optional),
+		"client": s.Object{
+			"address":  c.Str("client_addr", s.Optional),
+			"hostname": c.Str("client_hostname", s.Optional),
+			"port":     c.Int("client_port", s.Optional),
+		},
+		"state":         c.Str("state", s.Optional),
+		"sync_state":    c.Str("sync_state", s.Optional),
+		"sync_priority": c.Int("sync_priority", s.Optional),
+		"lag": s.Object{
+			"send": s.Object{
+				"bytes": c.Int("send_lag_bytes", s.Optional),
+			},
+			"write": s.Object{
+				"bytes": c.Int("write_lag_bytes", s.Optional),
+				"ms":    c.Float("write_lag_ms", s.Optional),
+			},
+			"flush": s.Object{
+				"bytes": c.Int("flush_lag_bytes", s.Optional),
+				"ms":    c.Float("flush_lag_ms", s.Optional),
+			},
+			"replay": s.Object{
+				"bytes": c.Int("replay_lag_bytes", s.Optional),
+				"ms":    c.Float("replay_lag_ms", s.Optional),
+			},
+		},
+	},
+}
+
+// Based on: https://www.postgresql.org/docs/current/view-pg-replication-slots.html
+var slotSchema = s.Schema{
+	"slot": s.Object{
+		"name":       c.Str("slot_name"),
+		"type":       c.Str("slot_type"),
+		"plugin":     c.Str("plugin", s.Optional),
+		"database":   c.Str("database", s.Optional),
+		"temporary":  c.Bool("temporary", s.Optional),
+		"active":     c.Bool("active"),
+		"active_pid": c.Int("active_pid", s.Optional),
+		"retained": s.Object{
+			"bytes": c.Int("retained_bytes", s.Optional),
+		},
+		"confirmed_flush_lag": s.Object{
+			"bytes": c.Int("confirmed_flush_lag_bytes", s.Optional),
+		},
+	},
+}
+
+// withoutNulls removes the empty values from a result, QueryStats reports
+// NULL values as empty strings, that cannot be converted by the schemas.
+func withoutNulls(result map[string]interface{}) map[string]interface{} {
+	for k, v := range result {
+		if v == "" {
+			delete(result, k)
+		}
+	}
+	return result
+}
+
+// serverEvent builds the event with the replication status of the server.
+func serverEvent(status, position, statWAL map[string]interface{}, standbys, slots []map[string]interface{}, rate *walRate, now time.Time) mb.Event {
+	role := "primary"
+	if inRecovery, _ := strconv.ParseBool(status["in_recovery"].(string)); inRecovery {
+		role = "standby"
+	}
+
+	inactive := 0
+	for _, slot := range slots {
+		if active, _ := strconv.ParseBool(slot["active"].(string)); !active {
+			inactive++
+		}
+	}
+
+	wal := mapstr.M{}
+	if statWAL != nil {
+		wal, _ = statWALSchema.Apply(withoutNulls(statWAL))
+	}
+	if p, err := strconv.ParseInt(position["position"].(string), 10, 64); err == nil {
+		wal.Put("position.bytes", p)
+		if bytesPerSec, ok := rate.update(p, now); ok {
+			wal.Put("rate.bytes_per_sec", bytesPerSec)
+		}
+	}
+
+	return mb.Event{
+		MetricSetFields: mapstr.M{
+			"role": role,
+			"wal":  wal,
+			"standbys": mapstr.M{
+				"count": len(standbys),
+			},
+			"slots": mapstr.M{
+				"count":    len(slots),
+				"inactive": inactive,
+			},
+		},
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestWALRate(t *testing.T) {
+	var rate walRate
+	now := time.Now()
+
+	_, ok := rate.update(1000, now)
+	assert.False(t, ok, "no rate on first update")
+
+	bytesPerSec, ok := rate.update(21000, now.Add(10*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, 2000.0, bytesPerSec)
+
+	_, ok = rate.update(500, now.Add(20*time.Second))
+	assert.False(t, ok, "no rate when the position goes backwards")
+}
+
+func TestServerEvent(t *testing.T) {
+	status := map[string]interface{}{"server_version_num": "140005", "in_recovery": "f"}
+	position := map[string]interface{}{"position": "50331648"}
+	statWAL := map[string]interface{}{
+		"wal_records":      "1200",
+		"wal_fpi":          "30",
+		"wal_bytes":        "4194304",
+		"wal_buffers_full": "0",
+		"wal_write":        "90",
+		"wal_sync":         "85",
+		"wal_write_time":   "0",
+		"wal_sync_time":    "0",
+		"stats_reset":      "2022-10-01T12:00:00.000000Z",
+	}
+	slots := []map[string]interface{}{
+		{"slot_name": "standby_1", "slot_type": "physical", "active": "t"},
+		{"slot_name": "orphan", "slot_type": "logical", "active": "f"},
+	}
+	standbys := []map[string]interface{}{{"pid": "123"}}
+
+	var rate walRate
+	now := time.Now()
+	rate.update(50331648-1048576, now.Add(-time.Second))
+
+	event := serverEvent(status, position, statWAL, standbys, slots, &rate, now)
+	fields := event.MetricSetFields
+
+	assert.Equal(t, "primary", fields["role"])
+	assert.Equal(t, mapstr.M{"count": 1}, fields["standbys"])
+	assert.Equal(t, mapstr.M{"count": 2, "inactive": 1}, fields["slots"])
+
+	wal := fields["wal"].(mapstr.M)
+	assert.Equal(t, int64(4194304), wal["bytes"])
+	assert.Equal(t, int64(50331648), wal["position"].(mapstr.M)["bytes"])
+	assert.Equal(t, 1048576.0, wal["rate"].(mapstr.M)["bytes_per_sec"])
+}
+
+func TestStandbySchema(t *testing.T) {
+	data, err := standbySchema.Apply(withoutNulls(map[string]interface{}{
+		"pid":              "123",
+		"usesysid":         "10",
+		"usename":          "replicator",
+		"application_name": "walreceiver",
+		"client_addr":      "172.18.0.3",
+		"client_hostname":  "",
+		"client_port":      "51234",
+		"state":            "streaming",
+		"sync_state":       "async",
+		"sync_priority":    "0",
+		"send_lag_bytes":   "0",
+		"write_lag_bytes":  "128",
+		"flush_lag_bytes":  "256",
+		"replay_lag_bytes": "1024",
+		"write_lag_ms":     "0.52",
+		"flush_lag_ms":     "1.1",
+		"replay_lag_ms":    "",
+	}))
+	require.NoError(t, err)
+
+	lag, err := data.GetValue("standby.lag")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"send":   mapstr.M{"bytes": int64(0)},
+		"write":  mapstr.M{"bytes": int64(128), "ms": 0.52},
+		"flush":  mapstr.M{"bytes": int64(256), "ms": 1.1},
+		"replay": mapstr.M{"bytes": int64(1024)},
+	}, lag)
+}
@@ -0,0 +1,191 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
+)
+
+const (
+	// minServerVersion is the first version with the pg_wal_* functions
+	// and the lag columns in pg_stat_replication.
+	minServerVersion = 100000
+	// statWALServerVersion is the first version with pg_stat_wal.
+	statWALServerVersion = 140000
+
+	statusQuery = `SELECT current_setting('server_version_num') AS server_version_num, pg_is_in_recovery() AS in_recovery`
+
+	// currentLSN is the last WAL position written by a primary, or received
+	// by a standby.
+	currentLSN = `CASE WHEN pg_is_in_recovery() THEN COALESCE(pg_last_wal_receive_lsn(), pg_last_wal_replay_lsn()) ELSE pg_current_wal_lsn() END`
+
+	walPositionQuery = `SELECT pg_wal_lsn_diff(` + currentLSN + `, '0/0') AS position`
+
+	statWALQuery = `SELECT * FROM pg_stat_wal`
+
+	standbysQuery = `SELECT pid, usesysid, usename, application_name, client_addr, client_hostname, client_port,
+  state, sync_state, sync_priority,
+  pg_wal_lsn_diff(current.lsn, sent_lsn) AS send_lag_bytes,
+  pg_wal_lsn_diff(current.lsn, write_lsn) AS write_lag_bytes,
+  pg_wal_lsn_diff(current.lsn, flush_lsn) AS flush_lag_bytes,
+  pg_wal_lsn_diff(current.lsn, replay_lsn) AS replay_lag_bytes,
+  EXTRACT(EPOCH FROM write_lag) * 1000 AS write_lag_ms,
+  EXTRACT(EPOCH FROM flush_lag) * 1000 AS flush_lag_ms,
+  EXTRACT(EPOCH FROM replay_lag) * 1000 AS replay_lag_ms
+FROM pg_stat_replication, (SELECT ` + currentLSN + ` AS lsn) AS current`
+
+	slotsQuery = `SELECT slot_name, plugin, slot_type, database, temporary, active, active_pid,
+  pg_wal_lsn_diff(current.lsn, restart_lsn) AS retained_bytes,
+  pg_wal_lsn_diff(current.lsn, confirmed_flush_lsn) AS confirmed_flush_lag_bytes
+FROM pg_replication_slots, (SELECT ` + currentLSN + ` AS lsn) AS current`
+)
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("postgresql", "replication", New,
+		mb.WithHostParser(postgresql.ParseURL),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*postgresql.MetricSet
+
+	rate walRate
+}
+
+// New create a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The postgresql replication metricset is beta.")
+
+	ms, err := postgresql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch reports an event with the replication status of the server, and one
+// event per connected standby and per replication slot.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx := context.Background()
+
+	status, err := m.queryOne(ctx, statusQuery)
+	if err != nil {
+		return err
+	}
+	version, err := strconv.Atoi(status["server_version_num"].(string))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse server version")
+	}
+	if version < minServerVersion {
+		return fmt.Errorf("replication metricset requires PostgreSQL 10 or later, found version %d", version)
+	}
+
+	position, err := m.queryOne(ctx, walPositionQuery)
+	if err != nil {
+		return err
+	}
+
+	var statWAL map[string]interface{}
+	if version >= statWALServerVersion {
+		statWAL, err = m.queryOne(ctx, statWALQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	standbys, err := m.QueryStats(ctx, standbysQuery)
+	if err != nil {
+		return errors.Wrap(err, "error in QueryStats for pg_stat_replication")
+	}
+
+	slots, err := m.QueryStats(ctx, slotsQuery)
+	if err != nil {
+		return errors.Wrap(err, "error in QueryStats for pg_replication_slots")
+	}
+
+	server := serverEvent(status, position, statWAL, standbys, slots, &m.rate, time.Now())
+	if !reporter.Event(server) {
+		return nil
+	}
+	for _, standby := range standbys {
+		data, _ := standbySchema.Apply(withoutNulls(standby))
+		if !reporter.Event(mb.Event{MetricSetFields: data}) {
+			return nil
+		}
+	}
+	for _, slot := range slots {
+		data, _ := slotSchema.Apply(withoutNulls(slot))
+		if !reporter.Event(mb.Event{MetricSetFields: data}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *MetricSet) queryOne(ctx context.Context, query string) (map[string]interface{}, error) {
+	results, err := m.QueryStats(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in QueryStats")
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("No results from the query: %s", query)
+	}
+	return results[0], nil
+}
+
+// walRate calculates the rate of WAL generation, or reception in standbys,
+// from the WAL position between fetches.
+type walRate struct {
+	sync.Mutex
+
+	position int64
+	time     time.Time
+}
+
+// update stores the current position and returns the bytes per second since
+// the previous one. It returns false if there is no previous position, or
+// the position went backwards.
+func (r *walRate) update(position int64, now time.Time) (float64, bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	prevPosition, prevTime := r.position, r.time
+	r.position, r.time = position, now
+
+	if prevTime.IsZero() || position < prevPosition {
+		return 0, false
+	}
+	elapsed := now.Sub(prevTime).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(position-prevPosition) / elapsed, true
+}
@@ -92,7 +92,11 @@ func MetricSetBuilder(namespace string, genFactory PromEventsGeneratorFactory) f
 		if err := base.Module().UnpackConfig(&config); err != nil {
 			return nil, err
 		}
-		prometheus, err := p.NewPrometheusClient(base)
+		var clientOptions []p.ClientOption
+		if config.NativeHistograms {
+			clientOptions = append(clientOptions, p.WithNativeHistograms())
+		}
+		prometheus, err := p.NewPrometheusClient(base, clientOptions...)
 		if err != nil {
 			return nil, err
 		}
@@ -19,6 +19,10 @@ package collector
 
 type metricsetConfig struct {
 	MetricsFilters MetricFilters `config:"metrics_filters" yaml:"metrics_filters,omitempty"`
+
+	// NativeHistograms negotiates the protobuf exposition format to also
+	// retrieve native histograms
+	NativeHistograms bool `config:"native_histograms" yaml:"native_histograms,omitempty"`
 }
 
 type MetricFilters struct {
@@ -30,6 +34,7 @@ var defaultConfig = metricsetConfig{
 	MetricsFilters: MetricFilters{
 		IncludeMetrics: nil,
 		ExcludeMetrics: nil},
+	NativeHistograms: true,
 }
 
 func (c *metricsetConfig) Validate() error {
@@ -32,5 +32,5 @@ func init() {
 // AssetPrometheus returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/prometheus.
 func AssetPrometheus() string {
-	return "eJzMkk1u20AMhfc6xYO6C5wcQIueoEBbdFkUxlh6sqaZv5JUDN++kGU5im2gf5tyyTckP77hI555bFAkR9rAUSvAvAU2qD9dknUFdNRWfDGfU4P3FQB8MWcKbcUVduglRzi8VoGpK9kne6oAHbLYts2p9/sGvQvKChAGOmWDvZve0MynvTb4WquGeoN6MCv1twroPUOnzWnuI5KLvKKewo5l6iV5LOfMumyKd/goHQVe4WPJYi4ZBgo3CG7HoDj4EBCdtQN6L2ob2EAI1eCE6PK4C7z0W1Dm4qeHi7DA5N13trZKz4ntrD7zeMjSreQ7Ni+xcjbSxLfnqTcws/rnNFe7vVG30ZXi0/78tH6o/xL6hvbHSDn+b6wvLoynXx+DLbc9yZ8/rPjfXu+drW52Wp/mL2BODZav5NqHe2NvL30BEcZs3B7EG/+FZ+6DU58F69WXs21KeaH8NuvPAAAA///rUkpn"
+	return "eJzMlc2O00AMx+95ir/CBVbdfYAcOMEBafkSiAtC1TRxmmHnC9vZ0rdHSZNutsmy7QEJtSd7xv75P7ZzjTvaF0gcPWlDrWSAWnVUIP90NOYZUJGUbJPaGAq8zgDgixoVSMkmUYWao4fBwy1QqFK0QW8yQJrIui5jqO22QG2cUAYwOTJCBbamO0OqNmylwPdcxOUr5I1qyn9kQG3JVVL0ea8RjKcT6s6h+9TF4timwTK91v1e4CNXxLAC61NkNUHRENMKzmzICXbWOXijZYPasugK2hCYRGGYUMV24+gYb0Q5XL65OjpGmLj5SaVOzAfD+uC9o/0ucjVxL8g8/ibKelK25ZB1BuNJTWXU/Fuc90MWvOxqWaEhl2BChTZYfYVY98LNoWvjrbMksKE/QfcUdAUjEAqKzR5MPiqtd2x1rjT9Jp+cYZnVNn33Z9jfjkFGzIOglzLN++vkGdiWj1zAU0I/A9z9PxhPj3kPrEMt2JCLYSvQuIhzb1xLizQnPX0WzLcu2kgzIizmVetJ1Pi0nNvohZm/Wk/YNRQepcbOCJjKyBVVixwLI/qXuThjNs5gve1znsq0grRl03V8Z1U2JeHdm+wUeOjJi6d49pwT79qblGzYDkfzqzw7q5jZGM9of7XE+/+Nte/5bnm3TsdPVFfK59vjjeuTj9BCVbOaLtg0fYBBM6GpDktp5wtlBHli/1zMc4iDfo+NWA+6DLIJ8T3x2ax/BgDGflJ8"
 }
@@ -23,6 +23,10 @@ type Config struct {
 	Host string                  `config:"host"`
 	Port int                     `config:"port"`
 	TLS  *tlscommon.ServerConfig `config:"ssl"`
+
+	// IncludeMetadata adds the metadata sent by Prometheus (type, help and
+	// unit) of the metric families to the events
+	IncludeMetadata bool `config:"include_metadata"`
 }
 
 func defaultConfig() Config {
@@ -0,0 +1,256 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_write
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// traceIDLabel is the exemplar label conventionally used to hold the trace ID
+const traceIDLabel = "trace_id"
+
+// metadataCache keeps the metadata of metric families. Prometheus sends it
+// periodically, in write requests without samples.
+type metadataCache struct {
+	sync.RWMutex
+	families map[string]mapstr.M
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{families: map[string]mapstr.M{}}
+}
+
+func (c *metadataCache) update(metadata []prompb.MetricMetadata) {
+	if len(metadata) == 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	for _, m := range metadata {
+		if m.MetricFamilyName == "" {
+			continue
+		}
+		meta := mapstr.M{
+			"type": strings.ToLower(m.Type.String()),
+		}
+		if m.Help != "" {
+			meta["help"] = m.Help
+		}
+		if m.Unit != "" {
+			meta["unit"] = m.Unit
+		}
+		c.families[m.MetricFamilyName] = meta
+	}
+}
+
+// get returns the family name and metadata of the given metric, checking
+// also the names of the family without the suffixes of its series.
+func (c *metadataCache) get(name string) (string, mapstr.M, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if meta, found := c.families[name]; found {
+		return name, meta, true
+	}
+	for _, suffix := range []string{"_bucket", "_sum", "_count", "_total", "_created"} {
+		family := strings.TrimSuffix(name, suffix)
+		if family == name {
+			continue
+		}
+		if meta, found := c.families[family]; found {
+			return family, meta, true
+		}
+	}
+	return "", nil, false
+}
+
+// seriesLabels returns the name and the labels of a time series, as they
+// are stored in events by the events generators.
+func seriesLabels(ts *prompb.TimeSeries) (string, mapstr.M) {
+	var name string
+	labels := mapstr.M{}
+	for _, l := range ts.Labels {
+		if l.Name == model.MetricNameLabel {
+			name = l.Value
+			continue
+		}
+		labels[l.Name] = model.LabelValue(l.Value)
+	}
+	return name, labels
+}
+
+// eventsIndex locates the events generated for a time series by their labels
+type eventsIndex struct {
+	events      map[string]mb.Event
+	byTimestamp map[string]string
+	byLabels    map[string]string
+}
+
+func newEventsIndex(events map[string]mb.Event) *eventsIndex {
+	idx := &eventsIndex{
+		events:      events,
+		byTimestamp: make(map[string]string, len(events)),
+		byLabels:    make(map[string]string, len(events)),
+	}
+	for k, e := range events {
+		idx.add(k, e)
+	}
+	return idx
+}
+
+func (idx *eventsIndex) add(k string, e mb.Event) {
+	labels, _ := e.ModuleFields["labels"].(mapstr.M)
+	key := labels.String()
+	idx.byTimestamp[key+e.Timestamp.String()] = k
+	if last, found := idx.byLabels[key]; !found || e.Timestamp.After(idx.events[last].Timestamp) {
+		idx.byLabels[key] = k
+	}
+}
+
+// keys returns the label sets to look for the events of a series. Histogram
+// buckets can be grouped in events without their `le` label.
+func (idx *eventsIndex) keys(labels mapstr.M) []string {
+	keys := []string{labels.String()}
+	if _, found := labels["le"]; found {
+		withoutLE := labels.Clone()
+		withoutLE.Delete("le")
+		keys = append(keys, withoutLE.String())
+	}
+	return keys
+}
+
+// find returns the key of the event with the sample of the given timestamp
+func (idx *eventsIndex) find(labels mapstr.M, timestamp model.Time) (string, bool) {
+	for _, key := range idx.keys(labels) {
+		if k, found := idx.byTimestamp[key+timestamp.Time().String()]; found {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// latest returns the key of the most recent event with the given labels
+func (idx *eventsIndex) latest(labels mapstr.M) (string, bool) {
+	for _, key := range idx.keys(labels) {
+		if k, found := idx.byLabels[key]; found {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// addMetadata adds the metadata of the metric families in the request to the
+// events generated for their samples.
+func addMetadata(req *prompb.WriteRequest, events map[string]mb.Event, cache *metadataCache) {
+	idx := newEventsIndex(events)
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name, labels := seriesLabels(ts)
+		family, meta, found := cache.get(name)
+		if !found {
+			continue
+		}
+		for _, s := range ts.Samples {
+			k, found := idx.find(labels, model.Time(s.Timestamp))
+			if !found {
+				continue
+			}
+			events[k].ModuleFields.Put("metadata."+family, meta.Clone())
+		}
+	}
+}
+
+// addExemplars adds the exemplars in the request to the latest event generated
+// for the same series. Prometheus sends exemplars and samples in different
+// series, so events are created for exemplars of series without samples in the
+// request.
+func addExemplars(req *prompb.WriteRequest, events map[string]mb.Event) {
+	idx := newEventsIndex(events)
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		if len(ts.Exemplars) == 0 {
+			continue
+		}
+
+		name, labels := seriesLabels(ts)
+		k, found := idx.latest(labels)
+		if !found {
+			k = "exemplars" + labels.String()
+			e := mb.Event{
+				ModuleFields: mapstr.M{},
+				Timestamp:    model.Time(ts.Exemplars[len(ts.Exemplars)-1].Timestamp).Time(),
+			}
+			if len(labels) > 0 {
+				e.ModuleFields["labels"] = labels
+			}
+			events[k] = e
+			idx.add(k, e)
+		}
+
+		e := events[k]
+		exemplars, _ := e.ModuleFields["exemplars"].([]mapstr.M)
+		for _, ex := range ts.Exemplars {
+			exemplar := mapstr.M{
+				"metric":    name,
+				"value":     ex.Value,
+				"timestamp": model.Time(ex.Timestamp).Time(),
+			}
+			if len(ex.Labels) > 0 {
+				exLabels := mapstr.M{}
+				for _, l := range ex.Labels {
+					exLabels[l.Name] = l.Value
+				}
+				exemplar["labels"] = exLabels
+			}
+			exemplars = append(exemplars, exemplar)
+		}
+		e.ModuleFields["exemplars"] = exemplars
+
+		// Set the trace ID of the event when all its exemplars share it
+		if traceID, ok := commonTraceID(exemplars); ok {
+			if e.RootFields == nil {
+				e.RootFields = mapstr.M{}
+			}
+			e.RootFields.Put("trace.id", traceID)
+		} else if e.RootFields != nil {
+			e.RootFields.Delete("trace.id")
+		}
+		events[k] = e
+	}
+}
+
+func commonTraceID(exemplars []mapstr.M) (string, bool) {
+	var traceID string
+	for _, exemplar := range exemplars {
+		v, _ := exemplar.GetValue("labels." + traceIDLabel)
+		id, _ := v.(string)
+		if id == "" || (traceID != "" && id != traceID) {
+			return "", false
+		}
+		traceID = id
+	}
+	return traceID, traceID != ""
+}
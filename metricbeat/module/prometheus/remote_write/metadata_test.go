@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_write
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestAddExemplars(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_request_duration_seconds_bucket"},
+					{Name: "handler", Value: "/api"},
+					{Name: "le", Value: "0.5"},
+				},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_request_duration_seconds_bucket"},
+					{Name: "handler", Value: "/api"},
+					{Name: "le", Value: "0.5"},
+				},
+				Exemplars: []prompb.Exemplar{{
+					Labels:    []prompb.Label{{Name: "trace_id", Value: "abc123"}},
+					Value:     0.42,
+					Timestamp: 900,
+				}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "jobs_processed_total"},
+					{Name: "queue", Value: "default"},
+				},
+				Exemplars: []prompb.Exemplar{{
+					Labels:    []prompb.Label{{Name: "trace_id", Value: "def456"}},
+					Value:     1,
+					Timestamp: 2000,
+				}},
+			},
+		},
+	}
+
+	g := remoteWriteEventGenerator{}
+	events := g.GenerateEvents(protoToSamples(req))
+	require.Len(t, events, 1)
+
+	addExemplars(req, events)
+	require.Len(t, events, 2)
+
+	labels := mapstr.M{
+		"handler": model.LabelValue("/api"),
+		"le":      model.LabelValue("0.5"),
+	}
+	e := events[labels.String()+model.Time(1000).Time().String()]
+	assert.Equal(t, []mapstr.M{{
+		"metric":    "http_request_duration_seconds_bucket",
+		"value":     0.42,
+		"timestamp": model.Time(900).Time(),
+		"labels":    mapstr.M{"trace_id": "abc123"},
+	}}, e.ModuleFields["exemplars"])
+	traceID, _ := e.RootFields.GetValue("trace.id")
+	assert.Equal(t, "abc123", traceID)
+
+	// Exemplars of series without samples get their own event
+	labels = mapstr.M{"queue": model.LabelValue("default")}
+	e, found := events["exemplars"+labels.String()]
+	require.True(t, found)
+	assert.Equal(t, labels, e.ModuleFields["labels"])
+	assert.Equal(t, time.Unix(2, 0), e.Timestamp)
+	traceID, _ = e.RootFields.GetValue("trace.id")
+	assert.Equal(t, "def456", traceID)
+}
+
+func TestAddMetadata(t *testing.T) {
+	cache := newMetadataCache()
+
+	// Metadata is sent in its own request
+	cache.update([]prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricMetadata_HISTOGRAM,
+			MetricFamilyName: "http_request_duration_seconds",
+			Help:             "Duration of HTTP requests.",
+			Unit:             "seconds",
+		},
+		{
+			Type:             prompb.MetricMetadata_GAUGE,
+			MetricFamilyName: "up",
+		},
+	})
+
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_request_duration_seconds_count"},
+					{Name: "handler", Value: "/api"},
+				},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "up"},
+					{Name: "handler", Value: "/api"},
+				},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "unknown_metric"},
+				},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+			},
+		},
+	}
+
+	g := remoteWriteEventGenerator{}
+	events := g.GenerateEvents(protoToSamples(req))
+	addMetadata(req, events, cache)
+
+	labels := mapstr.M{"handler": model.LabelValue("/api")}
+	e := events[labels.String()+model.Time(1000).Time().String()]
+	assert.Equal(t, mapstr.M{
+		"http_request_duration_seconds": mapstr.M{
+			"type": "histogram",
+			"help": "Duration of HTTP requests.",
+			"unit": "seconds",
+		},
+		"up": mapstr.M{
+			"type": "gauge",
+		},
+	}, e.ModuleFields["metadata"])
+
+	e = events[mapstr.M{}.String()+model.Time(1000).Time().String()]
+	assert.NotContains(t, e.ModuleFields, "metadata")
+}
@@ -60,6 +60,7 @@ type MetricSet struct {
 	events          chan mb.Event
 	promEventsGen   RemoteWriteEventsGenerator
 	eventGenStarted bool
+	metadata        *metadataCache
 }
 
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
@@ -80,6 +81,9 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		promEventsGen:   promEventsGen,
 		eventGenStarted: false,
 	}
+	if config.IncludeMetadata {
+		m.metadata = newMetadataCache()
+	}
 
 	svc, err := httpserver.NewHttpServerWithHandler(base, m.handleFunc)
 	if err != nil {
@@ -110,6 +114,9 @@ func MetricSetBuilder(genFactory RemoteWriteEventsGeneratorFactory) func(base mb
 			promEventsGen:   promEventsGen,
 			eventGenStarted: false,
 		}
+		if config.IncludeMetadata {
+			m.metadata = newMetadataCache()
+		}
 		svc, err := httpserver.NewHttpServerWithHandler(base, m.handleFunc)
 		if err != nil {
 			return nil, err
@@ -172,6 +179,11 @@ func (m *MetricSet) handleFunc(writer http.ResponseWriter, req *http.Request) {
 
 	samples := protoToSamples(&protoReq)
 	events := m.promEventsGen.GenerateEvents(samples)
+	addExemplars(&protoReq, events)
+	if m.metadata != nil {
+		m.metadata.update(protoReq.Metadata)
+		addMetadata(&protoReq, events, m.metadata)
+	}
 
 	for _, e := range events {
 		select {
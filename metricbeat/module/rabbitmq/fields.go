@@ -32,5 +32,5 @@ func init() {
 // AssetRabbitmq returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/rabbitmq.
 func AssetRabbitmq() string {
-	return "eJzsW1+PG7kNf99PQezL5oDNIH3dhwLXvev1HjbINbn2oSgWtER7VGukiaixd1r0uxeUxv9n1vbuOLm28UOA2DPk70dSFElp38Kc2jsIOJmYWH2+AogmWrqD6z+nrx5+ub4C0MQqmDoa7+7g91cAAKufofK6sXQFEMgSMt3BDK8AmGI0bsZ38LdrZnt9C9dljPX1368Apoas5rsk5y04rGgHgXxiW4uk4Ju6+6YHw66kbWmL0nNcf7sSN6d26YPe+r5XaP78xYTYoAWRlKTC0sQSnHdvv/94//PPoEoMqCIFBmKFNWlABuPgvjjAo7xzpETNAahtjkcg9UrZNfvqs2+YbTDy784Pw/Y5Akg+n0rK5vFTiCVtgXyJwZ5z4gYoWoO890uNsdyEUdH3cmVmATOPGJp9GxzhOUo8bNNrmMKZ7OSVosd9ryL2K1NIkPphOq/7o+UEJ8i7o+N97zU9g5cjxhHD+34Tzklyv1JVonNk982R9VrvZi9YU001oSCraiUcvNtbYc+CeazwaUQ8FT6Zqqn6cKG1fkn6VHzTgBWNiO6hQ1ZTqAyzmVgCNv9MGQmzNnhjHEzaSPwdRA+OZj4ajN0aVtaQi1zsSZ76UGG8y+/1MhHEI2bStu7Jov02HEyNL9L8kcKCQhKaU/kkonGkYWEQAi0oMMEP7z/egg9gIsPPHwC1DsQMZrr9BEzRWAmFAEtk0IZxYkn3k6iJQjEukw90ER7ORyD3HBUf+lmcH86dM0TiM2YbUWGy2TPqUM0pPirfuFgwubHUvl8nkqyBQYSfmkR2UAVSZBa0HyvjIVspeBG6mpw2BxjGA9fJPxWbV/Gy7kwKzvPmNqaLObPDda4v8+7wWAe/MJp0Xz3zioSVqi+uSZmpIb1dPe+WOCs09CTb7oxe00f0yPitdRGfG2peXWH/jzcQugmyI/USnHhvCd15CP9aUixlrYS04W38wE1YmAXJmk57UyCOGPYrphUubKJ/1GRpoA5/Fbbt4LAWJgRZk05qK4xGobUtLEty4HxKGBSkcxrYuY2LFBzay0BdLTUwvNZ0C6agAhQ6sbIwMIFUtC3UzcQaLklLkTppAbvk81/QQC5LDyoQiie2iW+g93KoiBlnxEVH/dG4Im0DI2V/uBdhklVWmraMfG3ctVga3Rpu55sU/jiXXRWVWokIvonGzfq90cdEU0RjuQhDLenUetyneowR/MkvoWpUuRtgndq3xkEGXCJD/k1LawRMyjst+UTeqyQLyVboIjBWtRWuKUIXaE9k6Jv4BZ3lm3idWroDb73WU8Lja7jKN3FMXw1MbM4uD/beP7800IbnxTQQFYet88uj4wfDcxCpwDUqglVHf37bvsFnTWXiqCg/eOMiYIRlaTq/izpAi6HKe9bMg59Oz4c91UX0cWCnOh/pH42l9TM+MOACjZWKYmBsowvZRUfS/iuThukehH7FM1W4pho102yagp/uwdeUN79h/YGURVORHjVWfrrP3oaN+LODwvhCrPhYotOWCl+Te8QYqapjgYtZUY0FNoVL1iIWc4CLGUSzV0qcAmtMRx6iykoGjRUI9eUMI9KPGiZBGDUzYsSsepUTXxJGCdaYvtnA2qywYeXivUuoT1FxHAATzceNi6RfxB4NiKR7dOpJ9QnEW6cuQbx16jhx0T0+cVF9nPgymEgXYJ7kHqWetY+eBbLy16SBDGx0p2RgR7xSUfVlSrKKKh/aMYoygZyGC2MifsjwRO5AmbtW74gNFqmijU8XqpEekhKIAR1jGk5yZ8sSFwQTIiddixgrdf8o3crnxgTS2e8sXXbC+ByJgNVvioM2Og0COi6nUeFZwdEHehx9O91wWXfH+wTSZjsNvuqCPD0GCc8xuOMv+xPwitJITkx6IuBXTJ0PAa4uEvRqqoNXo/ZbDwcH6D8Gi26WNBHz0BJPQEbsvN4P6pdc0/CA5bunfBgryW1wKB+IQVMklQa5TkOTzo1h0nYg+zGlQXBhnKan4h++CQ7tRQM5kPJB837c5nl0ggEdjON4L5gfVjB308EWyuPovq4V+/GFxhXpoZEQfb+gIOlmeEHCEk00biYQRXsvLPZqTvELjWakIpE1Csid4oGs0aEaMW8cgErlyTEc416RSSlb3h84EakPSu5XBKzoyhIPZ6z7cXj2kHVfwLcD2G8HsP+fB7D0pGzDZnFRrIY3euBNOjUqkcEvHYXH2ujvvl1+HUgKSeROVijgvbTF4uSb0Dhn3OzmFiZNhApbCYjrf3HrlHGzW3jgWTrU+/c1mOmuP+SZMnhn2LhZAb/I96sWAQOB9SqdJnsH4rhIIbmDc3MmT6gmBHLRtqD90q3jkcv8v1gCJvgNC/4beehmYMWEWVOlO6AVPj3WwfhgYnuxwn+lACwtyHLa1De2iR64qYcv5CnvuKko8IXqs438I+qbaKzhFMtFrU4Gshqq1BTU4f2zY1VIyN37KiJle4Y3E4pLaSjfFe9S+/C74t13OU52Qi5VUNGDqSrSBiNJ6JA1klLXTWr0WxThU2kYFDoJLEucos+JfInn9XM5YE1lJGAnLTiKSx/m8sCMOOMNUAeaUlRlPvQ9cjid6slRPfyxqXL5jbrNTZZDNXd+aUnPSG8s8CYbTFMdy4HEuAfzEqfnO2fnW4gueLEhmebSQ5Bs/+i7rVOCL1/46b2GPoDx4gZXB1cxMu7LGX83Gi/thT7Lpz1MCq+WIuyAOQv5l/PN0AK+4NUfCmw4khv3GPeTZJHtDXKtZkOqQ58zwZu02aNdYsuykN7lPTSgY3FlfooHk1flQ3vexH74JOAouT+kM/4UfGms3+0aaaMQRrtTB0Bmr0wqfVINsyZ9C8Yp26S73hxRzW+hJKxTKl/dLQSOoVGxCUPTxDS6lqU8bvWw70DZlnk7IA9H1OlaTDKB4VWbY5wiMBFSdzO07hKFPIz/Ohy2BldnkVhX6aVfkD06PViPBCYU8bSpwoHkrzdAyFC+TRCepfetv9ztL3PQDDeYyjdWS76/SYtLfpDC+iZSqIyTnDnQ4I38l3plnkLuw/7RpLGDgItGZWi6dVgZdVNc/ScAAP//Lecs9w=="
+	return "eJzsXEuPG7nxv8+nKMzFNjBueK9z+AP7n33EBzvetTc5BIFAkaVu7rDJNostjRLkuwfFfkpqPadbs0kMGIYttap+9WC9WNJbeMT1PXgxn+uQf70BCDoYvIfbX+NLH365vQFQSNLrImhn7+H/bgAAmrchd6o0eAPg0aAgvIdU3AAQhqBtSvfwt1sic3sHt1kIxe3fbwAWGo2i+0jnLViR4wYCfjmsC6bkXVnUrwxg2KTUp7bMHIX21YbcI65Xzqve64NEqz9/0T6UwgBTihhhpUMG1tm3339+eP8eZCa8kAE9AZIUBSoQBNrCQ7KDRzprUbL+ejx2ZTwCaZDKptoBhhXTB8N/b7yxXz9HAPGfLxlGIcEtIGTYA3mJwg4ZsQMqjBa09U4hQta5UTL04VynXjCyewi+xPPkHMUf+uKVhP5M6fgjyYD5niXYb4Q+CjQM0zqFZ8JsjcCfHR3vR6fwAF4KIozo3g+dO0fKw0xlJqxFs62Oiq9xNj2PaTxTZT5Hz6eqIQ7Obp2wg2BmuXgaEU8unnRe5kO4hDFuhepUfAsvchwR3YcaWYE+10R6bhBI/wNZd6LiBq+1hfk6IL2B4MBi6oIWoT7D0mi0gTbRAiycz0W4rz43KAnbdzxX+7IuBqLosA73hsaLOH9Gv0QfUx2TBzcPQltUsNQCPC7RE8IPHz/fgfOgA8H7TyCU8kgEetF/AhZCG3YFDytBoDSJuUE1LESB6JNxJfmEk8hhXQC0h0RxPozkzrUxCufDAbWNyPATHmYn5COGmXSlDQmhHYvtxzaQVBwImPipQWQDlUeJeolqMmQNg4vQFWiVtulk4Gr6p2JzMkxrzsjgPGv2MU1mzBrXubasssOs8G6pFaqheuYZAStWX1Sg1AuNqgdmq8Rp0OATp/cUn9NHDND4o3URX0ss8VsDcbCBUKXnjLTFqPKGuXMGhT0P4V8zDBmfYR8TXmcHKv1SL5HPdMxNHikIH2gYlyiDmyk0GCbA1ncOY2COUHFSkW0ugpbCmDWsMrRgXQwY6KGkfZlb24DeCjMN1OaogaaW0x3oBBOQwrKWWQLtUQazhqKcG00ZKi5S52sQdfD5D2ggV5kD6VGwJfqCd9AHZciRSKRISS36TNskpoFBwc6P/vDAxDiqNJx6Sr7V9pY1LWwLt7YNGyaIR86qQsqGhHclz7SSkyVRGIQ2lPh9LenCOBHOlAj+5FaQlzLbdLCa7VttoQKcCeKIaVNUUHCSQems4nDJn8s5CnEqtAFI5IVhWaOHLoU5UUJXhisay5Xhlg25a63nWorleAlTuTKMaas9E5uzy4Otz59fGihNj8nCIya7rfPl3vGDpkdgqkCFkAhNR39+297hMzrXYVSUn5y2AUSAVaZruzM7EEb4vMpZqQO3WJwPe6GS4IIwIyH9SRtsn3GeQCyFNlxRJPvYcxYdiftvhAoWWxCGGacysWU+aqTpmoKfH8AVWCW/PfVMKhOP0gidoxrVV35+qGZL0JE/2ym0S1iLs0xYZTBxBdqZCAHzIiRimSb5WGCju1RcWGMWxDKFoHM8G9aYhtxFVTHZqyyPQk2nGKZ+VDH80Kh+9IMIPKYTqo2Jl7gRExj1kHWwuhO2nzlbbwr2TPcEAIT4OK5fRPGZ7FGH4IfGF52pniL42sopBF9beVxw5j2+4Mz6uOArrwNOIHmke1T0+NT4USCSfVYYiBTGN0oke8wqOebXKclyzJ1fj1GUMWQui0ZF/KGCx3RbWybD7C2SFgmXmEl4mqhG+hCZQPDCkojDSap1mYklwhzRctfCyordv+Bu5WupParK7sRddsR4SAgv8j+UDEqrOAerZTlNFEoTCs7jbPR02snSdsfbAjBPWHiX104eH4OI5xjc8Y/9CXiZaUDL3nEi4GdMnfcvEgxyKryTo/ZbH3Yu0H/0Rtg0ckKifUec3x6z8/q4lz/HmpL2aL5+ynkaHYd0HgkUBpQ8PhRWQRnvjWG+rkEOY4qD4ERbhU/J767k4eqkjuxROq9o228jDIgwoIZxHO+E8aGBuRkOeiiPo3tZLQ7j86VNIsaREH2/RM/hZv+BhJXQPPFlQzP3QVjk+K73SqMZbuV4is83QxVjOohqxLixA4ppH8Ux7opM3P1iksPMymKn5L5c3siropjcbHPa9sOzh6zbBL5dwH67gP3fvIDFJ2lK0stJsWrq+MDreMfH92NuZdHPCq3eDGPbugo5y3//C5ZfOShEkhtRIYGPPE/kW/ZXvrRW2/TVHczLALlY84387T9pbaW26R18oDRe6v3rFnSPBF+H8zOZd1YT39TBL2ynpkUQHsE4GW+TnQV2kMBbyk4hVQ0mPyFL79HyxblyK9v6I2XV/0IGIsIvifG/4odeXSNLsdpCb5kzinwHzsb1TmkEkZZ38LV0vsz5sFPwKPJhaMKnZR7XU3PxNCu8dl6H9Ug5brcnaRiAwSXv9nK90ZktOKCy2L8rKJ2lMkdPE5WOHf0j7MugjaZ4zJJCngykmfcU6OXuatwRmD9xbuTNrdrsXDnA6zmGFffm75J3sbP5Lnn3pnLhTq2aILY7wYHOc1S8msxejUZztG/75+B6IsKXTBNvkfCRM7zCGjJh4bvkHR+19rl4UuJQDRX3UxbDyvlHJpQisYLYAwuPCwwyq+6jh7XbwKhK3VEt/JnPAXcGQq2jlkor5KN1K4OK9xdaDbyuFKawCNmbk2BOcbG/ca3fQzThzkVUzUSnqmFS6593j7BxPh7ouXoNiU7COLnC2y2PLdzTKX/TG6e2wpDmY3rlmnCNATbAnIX8erbZZNwJN52RCvSkKfC63JgG+sLBrp8gWzadUDX6KhK8jnWIMCuxJj5I72LPHofnvEhcFaS0N3jx5P+8y4T9lxRHhft/5sPmqi9E6qwREwXbY3MgAoLISU5OqmqdWqHvQFtpSl5z54pLPt5BhqKIobxZewQKvpSh9PsGnfGCgGMITWpATsvU2W5oes5IKhVoqlM0acs7R4HF82HfueMPVrOzF5KhN1O7WIiqLk0MCoV+vKo4zlN4IMBOwp5TMeiKZOZadxiHcOXIWqCJojB3bdTC5AWCwmgpLkbprNF2qklqzWQIXNcl1QgOgfViEZKAPh8J4UPVmMGvYhGA6Q4hvGNH1JaXhomDkAX++tW6cQs0h76U0QduXJoYQWEWR9cjifCeaTWwmTqgDX7dhPoomXFp+0QEfQbW+qBOhrYFWMEejAsXwBZFYTSqa8Gu2W1eEXBLj5dIIF2e66ndZEuCOn5wyuRNc8jF77GBbz7TxImTBCArCsrclUWoUnu1iiCgwXAcca1uIwJauR5vy+YL99U6Lug3PTEzimh19Ur/iHJtArk2Rle1Zk/XDWrK3BLN0XF+O6OfYxA3J+HdofxyE/0KyreR/sGR/reB7+bAt3Ka/RNf6UqjuMt5FUtKfoPHSa846WvLncKLTFwb2D/qeA/A4IKWFTS1tiLXsoerUyaPYacIA5/jgLf9Noun2Bm1M7o7LjOd5yRRp7UKCuTCihR5/AuFKVNtXySSfOxHkQOj6la8RKuRYv17hTbwV2PbPqFlwqeYf4ug+8rsMVQeF+jRyhFV86lCwy1NS53v51Vs+hWqskr/2tk7HssKuz6GsifPdGbsmOzo9Qx8e7+BeBG++D3CUfHxLyXM9iasi0B+X/9cRIMzjueejXHMX3NwPjwTXfOvySedHaOTEU0+weyGLC3TCaeXDY/otgvt88mUPiBNy7LJOnPvHtGfjvSKxuiwXsMY6L3z1zRFnNZUv51zKrYrKr9mOaHqm1IooXLeIpxNWEb0+TSvNSBOrSxa0HzvO9X+SgeKoOJzx3Pc1GD93/YJnqGZdTUMdnbn7eqMHCjfmgeT+EEd1jMum0saMXnWhHtbGX0Rj8B6ocLofHTXLIvOR3ftouhChFcpiU7F5lHpQCPB6RKBzEr7SBtI4kIHBb5CrH+oCOa4cJ6jKq88VUjiUkfqhd17idNid4sF4Via/HMk1mgvTmzrnLB5a32Gbit8MyPSkTB26m3TVbODw7DQqgZ/3WRzKz6aOPU/piodWpFaPifCuW6pRuV4ldq/BwCNiFeK"
 }
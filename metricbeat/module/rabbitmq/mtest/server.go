@@ -48,13 +48,16 @@ func Server(t *testing.T, c ServerConfig) *httptest.Server {
 		file string
 		body []byte
 	}{
-		c.ManagementPathPrefix + "/api/connections":               {file: "connection_sample_response.json"},
-		c.ManagementPathPrefix + "/api/exchanges":                 {file: "exchange_sample_response.json"},
-		c.ManagementPathPrefix + "/api/nodes":                     {file: "nodes_sample_response.json"},
-		c.ManagementPathPrefix + "/api/nodes/rabbit@e2b1ae6390fd": {file: "node_sample_response.json"},
-		c.ManagementPathPrefix + "/api/overview":                  {file: "overview_sample_response.json"},
-		c.ManagementPathPrefix + "/api/queues":                    {file: "queue_sample_response.json"},
-		c.ManagementPathPrefix + "/api/shovels":                   {file: "shovel_sample_response.json"},
+		c.ManagementPathPrefix + "/api/connections":                   {file: "connection_sample_response.json"},
+		c.ManagementPathPrefix + "/api/exchanges":                     {file: "exchange_sample_response.json"},
+		c.ManagementPathPrefix + "/api/nodes":                         {file: "nodes_sample_response.json"},
+		c.ManagementPathPrefix + "/api/nodes/rabbit@e2b1ae6390fd":     {file: "node_sample_response.json"},
+		c.ManagementPathPrefix + "/api/overview":                      {file: "overview_sample_response.json"},
+		c.ManagementPathPrefix + "/api/queues":                        {file: "queue_sample_response.json"},
+		c.ManagementPathPrefix + "/api/queues/quorum///orders/status": {file: "quorum_status_sample_response.json"},
+		c.ManagementPathPrefix + "/api/shovels":                       {file: "shovel_sample_response.json"},
+		c.ManagementPathPrefix + "/api/stream/consumers":              {file: "stream_consumers_sample_response.json"},
+		c.ManagementPathPrefix + "/api/stream/publishers":             {file: "stream_publishers_sample_response.json"},
 	}
 
 	for k := range responses {
@@ -29,10 +29,13 @@ import (
 	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
 )
 
+const quorumQueueType = "quorum"
+
 var (
 	schema = s.Schema{
 		"name":        c.Str("name"),
 		"vhost":       c.Str("vhost"),
+		"type":        c.Str("type", s.Optional),
 		"durable":     c.Bool("durable"),
 		"auto_delete": c.Bool("auto_delete"),
 		"exclusive":   c.Bool("exclusive"),
@@ -73,6 +76,9 @@ var (
 		"memory": s.Object{
 			"bytes": c.Int("memory"),
 		},
+		"quorum": s.Object{
+			"leader": c.Str("leader", s.Optional),
+		},
 		"disk": s.Object{
 			"reads": s.Object{
 				"count": c.Int("disk_reads", s.Optional),
@@ -82,9 +88,26 @@ var (
 			},
 		},
 	}
+
+	raftSchema = s.Schema{
+		"term": c.Int("term", s.Optional),
+		"log": s.Object{
+			"last_index":     c.Int("last_log_index", s.Optional),
+			"last_written":   c.Int("last_written", s.Optional),
+			"last_applied":   c.Int("last_applied", s.Optional),
+			"commit_index":   c.Int("commit_index", s.Optional),
+			"snapshot_index": c.Int("snapshot_index", s.Optional),
+		},
+		"commit_latency": s.Object{
+			"ms": c.Int("commit_latency", s.Optional),
+		},
+	}
 )
 
-func eventsMapping(content []byte, r mb.ReporterV2) error {
+// quorumStatusFunc adds the status of a quorum queue to its fields
+type quorumStatusFunc func(fields mapstr.M, vhost, name string) error
+
+func eventsMapping(content []byte, r mb.ReporterV2, quorumStatus quorumStatusFunc) error {
 	var queues []map[string]interface{}
 	err := json.Unmarshal(content, &queues)
 	if err != nil {
@@ -93,6 +116,13 @@ func eventsMapping(content []byte, r mb.ReporterV2) error {
 
 	for _, queue := range queues {
 		evt := eventMapping(queue)
+		if quorumStatus != nil && queue["type"] == quorumQueueType {
+			vhost, _ := queue["vhost"].(string)
+			name, _ := queue["name"].(string)
+			if err := quorumStatus(evt.MetricSetFields, vhost, name); err != nil {
+				r.Error(err)
+			}
+		}
 		r.Event(evt)
 	}
 
@@ -113,9 +143,37 @@ func eventMapping(queue map[string]interface{}) mb.Event {
 		fields.Delete("node")
 	}
 
+	if queue["type"] == quorumQueueType {
+		if members, ok := queue["members"].([]interface{}); ok {
+			fields.Put("quorum.members.count", len(members))
+		}
+		if online, ok := queue["online"].([]interface{}); ok {
+			fields.Put("quorum.members.online.count", len(online))
+		}
+	} else {
+		fields.Delete("quorum")
+	}
+
 	event := mb.Event{
 		MetricSetFields: fields,
 		ModuleFields:    moduleFields,
 	}
 	return event
 }
+
+// quorumStatusMapping adds the Raft state reported by the leader among the
+// members of a quorum queue
+func quorumStatusMapping(fields mapstr.M, members []map[string]interface{}) error {
+	for _, member := range members {
+		if member["raft_state"] != "leader" {
+			continue
+		}
+		raft, err := raftSchema.Apply(member)
+		if err != nil {
+			return errors.Wrap(err, "error in mapping quorum status")
+		}
+		fields.Put("quorum.raft", raft)
+		return nil
+	}
+	return errors.New("no leader found in quorum status")
+}
@@ -18,10 +18,14 @@
 package queue
 
 import (
+	"encoding/json"
+	"net/url"
+
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/module/rabbitmq"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 func init() {
@@ -34,15 +38,22 @@ func init() {
 // MetricSet for fetching RabbitMQ queues metrics.
 type MetricSet struct {
 	*rabbitmq.MetricSet
+	config  Config
+	baseURI string
 }
 
 // New creates new instance of MetricSet
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
 	ms, err := rabbitmq.NewMetricSet(base, rabbitmq.QueuesPath)
 	if err != nil {
 		return nil, err
 	}
-	return &MetricSet{ms}, nil
+	return &MetricSet{ms, config, ms.HTTP.GetURI()}, nil
 }
 
 // Fetch fetches queue data
@@ -53,5 +64,29 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		return errors.Wrap(err, "error in fetch")
 	}
 
-	return eventsMapping(content, r)
+	var quorumStatus quorumStatusFunc
+	if m.config.QuorumStatus {
+		quorumStatus = m.addQuorumStatus
+	}
+	return eventsMapping(content, r, quorumStatus)
+}
+
+// addQuorumStatus fetches the status of the members of a quorum queue and
+// adds the Raft state of its leader
+func (m *MetricSet) addQuorumStatus(fields mapstr.M, vhost, name string) error {
+	m.HTTP.SetURI(m.baseURI + "/quorum/" + url.PathEscape(vhost) + "/" + url.PathEscape(name) + "/status")
+	defer m.HTTP.SetURI(m.baseURI)
+
+	content, err := m.HTTP.FetchContent()
+	if err != nil {
+		return errors.Wrapf(err, "error in fetch of quorum queue %s", name)
+	}
+
+	var members []map[string]interface{}
+	err = json.Unmarshal(content, &members)
+	if err != nil {
+		return errors.Wrapf(err, "error in mapping of quorum queue %s", name)
+	}
+
+	return quorumStatusMapping(fields, members)
 }
@@ -86,6 +86,47 @@ func TestFetchEventContents(t *testing.T) {
 	assert.EqualValues(t, 121, writes["count"])
 }
 
+func TestFetchQuorumQueue(t *testing.T) {
+	server := mtest.Server(t, mtest.DefaultServerConfig)
+	defer server.Close()
+
+	reporter := &mbtest.CapturingReporterV2{}
+
+	config := getConfig(server.URL)
+	config["queue.quorum_status.enabled"] = true
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	err := metricSet.Fetch(reporter)
+	assert.NoError(t, err)
+	assert.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	assert.Len(t, events, 2)
+
+	_, err = events[0].MetricSetFields.GetValue("quorum")
+	assert.Error(t, err, "classic queues shouldn't contain quorum fields")
+
+	event := events[1].MetricSetFields
+	assert.EqualValues(t, "orders", event["name"])
+	assert.EqualValues(t, "quorum", event["type"])
+
+	quorum := event["quorum"].(mapstr.M)
+	assert.EqualValues(t, "rabbit@rabbitmq-0", quorum["leader"])
+
+	members := quorum["members"].(mapstr.M)
+	online := members["online"].(mapstr.M)
+	assert.EqualValues(t, 3, members["count"])
+	assert.EqualValues(t, 2, online["count"])
+
+	raft := quorum["raft"].(mapstr.M)
+	log := raft["log"].(mapstr.M)
+	commitLatency := raft["commit_latency"].(mapstr.M)
+	assert.EqualValues(t, 4, raft["term"])
+	assert.EqualValues(t, 1852, log["last_index"])
+	assert.EqualValues(t, 1851, log["commit_index"])
+	assert.EqualValues(t, 1024, log["snapshot_index"])
+	assert.EqualValues(t, 2, commitLatency["ms"])
+}
+
 func TestData(t *testing.T) {
 	server := mtest.Server(t, mtest.DefaultServerConfig)
 	defer server.Close()
@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	connectionSchema = s.Schema{
+		"name":      c.Str("name"),
+		"user":      c.Str("user", s.Optional),
+		"peer_host": c.Str("peer_host", s.Optional),
+		"peer_port": c.Int("peer_port", s.Optional),
+	}
+
+	publisherSchema = s.Schema{
+		"node":       c.Str("node"),
+		"id":         c.Int("publisher_id"),
+		"reference":  c.Str("reference", s.Optional),
+		"connection": c.Dict("connection_details", connectionSchema, c.DictOptional),
+		"published": s.Object{
+			"count": c.Int("published"),
+			"details": c.Dict("published_details", s.Schema{
+				"rate": c.Float("rate"),
+			}, c.DictOptional),
+		},
+		"confirmed": s.Object{
+			"count": c.Int("confirmed"),
+			"details": c.Dict("confirmed_details", s.Schema{
+				"rate": c.Float("rate"),
+			}, c.DictOptional),
+		},
+		"errored": s.Object{
+			"count": c.Int("errored"),
+			"details": c.Dict("errored_details", s.Schema{
+				"rate": c.Float("rate"),
+			}, c.DictOptional),
+		},
+	}
+
+	consumerSchema = s.Schema{
+		"node":            c.Str("node"),
+		"subscription_id": c.Int("subscription_id"),
+		"active":          c.Bool("active", s.Optional),
+		"activity_status": c.Str("activity_status", s.Optional),
+		"connection":      c.Dict("connection_details", connectionSchema, c.DictOptional),
+		"credits":         c.Int("credits"),
+		"offset":          c.Int("offset"),
+		"offset_lag":      c.Int("offset_lag"),
+		"consumed": s.Object{
+			"count": c.Int("consumed"),
+			"details": c.Dict("consumed_details", s.Schema{
+				"rate": c.Float("rate"),
+			}, c.DictOptional),
+		},
+	}
+)
+
+func eventsMapping(content []byte, schema s.Schema, role string, r mb.ReporterV2) error {
+	var items []map[string]interface{}
+	err := json.Unmarshal(content, &items)
+	if err != nil {
+		return fmt.Errorf("error in mapping: %w", err)
+	}
+
+	for _, item := range items {
+		evt := eventMapping(item, schema, role)
+		if !r.Event(evt) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func eventMapping(item map[string]interface{}, schema s.Schema, role string) mb.Event {
+	fields, _ := schema.Apply(item)
+
+	moduleFields := mapstr.M{}
+	if v, err := fields.GetValue("node"); err == nil {
+		_, _ = moduleFields.Put("node.name", v)
+		_ = fields.Delete("node")
+	}
+
+	metricSetFields := mapstr.M{
+		role: fields,
+	}
+	if queue, ok := item["queue"].(map[string]interface{}); ok {
+		if v, ok := queue["name"].(string); ok {
+			metricSetFields["name"] = v
+		}
+		if v, ok := queue["vhost"].(string); ok {
+			moduleFields["vhost"] = v
+		}
+	}
+
+	event := mb.Event{
+		MetricSetFields: metricSetFields,
+		ModuleFields:    moduleFields,
+	}
+	return event
+}
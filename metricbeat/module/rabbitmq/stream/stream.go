@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/rabbitmq"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("rabbitmq", "stream", New,
+		mb.WithHostParser(rabbitmq.HostParser),
+	)
+}
+
+// MetricSet for fetching RabbitMQ stream publishers and consumers metrics.
+type MetricSet struct {
+	*rabbitmq.MetricSet
+	baseURI string
+}
+
+// New creates new instance of MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The rabbitmq stream metricset is beta.")
+
+	ms, err := rabbitmq.NewMetricSet(base, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the metric set: %w", err)
+	}
+	return &MetricSet{ms, ms.HTTP.GetURI()}, nil
+}
+
+// Fetch fetches stream publishers and consumers data
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	publishers, err := m.fetchPath(rabbitmq.StreamPublishersPath)
+	if err != nil {
+		return fmt.Errorf("error in fetch of stream publishers: %w", err)
+	}
+	if err := eventsMapping(publishers, publisherSchema, "publisher", report); err != nil {
+		return err
+	}
+
+	consumers, err := m.fetchPath(rabbitmq.StreamConsumersPath)
+	if err != nil {
+		return fmt.Errorf("error in fetch of stream consumers: %w", err)
+	}
+	return eventsMapping(consumers, consumerSchema, "consumer", report)
+}
+
+func (m *MetricSet) fetchPath(path string) ([]byte, error) {
+	m.HTTP.SetURI(m.baseURI + path)
+	defer m.HTTP.SetURI(m.baseURI)
+	return m.HTTP.FetchContent()
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/rabbitmq/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchEventContents(t *testing.T) {
+	server := mtest.Server(t, mtest.DefaultServerConfig)
+	defer server.Close()
+
+	reporter := &mbtest.CapturingReporterV2{}
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	err := metricSet.Fetch(reporter)
+	assert.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 2)
+
+	e := mbtest.StandardizeEvent(metricSet, events[0])
+	t.Logf("%s/%s event: %+v", metricSet.Module().Name(), metricSet.Name(), e.Fields.StringToPrint())
+
+	assertValue(t, e.Fields, "rabbitmq.stream.name", "invoices")
+	assertValue(t, e.Fields, "rabbitmq.vhost", "/")
+	assertValue(t, e.Fields, "rabbitmq.node.name", "rabbit@rabbitmq-0")
+	assertValue(t, e.Fields, "rabbitmq.stream.publisher.reference", "invoices-producer")
+	assertValue(t, e.Fields, "rabbitmq.stream.publisher.published.count", 12803)
+	assertValue(t, e.Fields, "rabbitmq.stream.publisher.confirmed.count", 12800)
+	assertValue(t, e.Fields, "rabbitmq.stream.publisher.errored.count", 3)
+	assertValue(t, e.Fields, "rabbitmq.stream.publisher.published.details.rate", 160.0)
+	assertValue(t, e.Fields, "rabbitmq.stream.publisher.connection.user", "guest")
+
+	e = mbtest.StandardizeEvent(metricSet, events[1])
+	assertValue(t, e.Fields, "rabbitmq.stream.name", "invoices")
+	assertValue(t, e.Fields, "rabbitmq.stream.consumer.subscription_id", 1)
+	assertValue(t, e.Fields, "rabbitmq.stream.consumer.active", true)
+	assertValue(t, e.Fields, "rabbitmq.stream.consumer.consumed.count", 12710)
+	assertValue(t, e.Fields, "rabbitmq.stream.consumer.offset", 12709)
+	assertValue(t, e.Fields, "rabbitmq.stream.consumer.offset_lag", 93)
+	assertValue(t, e.Fields, "rabbitmq.stream.consumer.credits", 9)
+}
+
+func TestData(t *testing.T) {
+	server := mtest.Server(t, mtest.DefaultServerConfig)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	err := mbtest.WriteEventsReporterV2ErrorCond(ms, t, "", func(e mapstr.M) bool {
+		hasConsumer, _ := e.HasKey("rabbitmq.stream.consumer")
+		return hasConsumer
+	})
+	if err != nil {
+		t.Fatal("error creating data.json file:", err)
+	}
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	v, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.EqualValues(t, expected, v, key)
+	}
+}
+
+func getConfig(url string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "rabbitmq",
+		"metricsets": []string{"stream"},
+		"hosts":      []string{url},
+	}
+}
@@ -29,6 +29,9 @@ const (
 	OverviewPath    = "/api/overview"
 	QueuesPath      = "/api/queues"
 	ShovelsPath     = "/api/shovels"
+
+	StreamConsumersPath  = "/api/stream/consumers"
+	StreamPublishersPath = "/api/stream/publishers"
 )
 
 const (
@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cluster
+
+import (
+	"sync"
+
+	rd "github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/redis"
+)
+
+var hostParser = parse.URLHostParserBuilder{DefaultScheme: "redis"}.Build()
+
+func init() {
+	mb.Registry.MustAddMetricSet("redis", "cluster", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet for fetching the topology of a Redis Cluster.
+type MetricSet struct {
+	*redis.MetricSet
+	fetchInfo bool
+
+	// pools keeps a connection pool per cluster member when collecting INFO
+	// from all of them.
+	poolsMu sync.Mutex
+	pools   map[string]*redis.Pool
+}
+
+// New creates new instance of MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The redis cluster metricset is beta.")
+
+	config := struct {
+		FetchInfo bool `config:"cluster.fetch_info"`
+	}{
+		FetchInfo: true,
+	}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, errors.Wrap(err, "failed to read configuration for 'cluster' metricset")
+	}
+
+	ms, err := redis.NewMetricSet(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create 'cluster' metricset")
+	}
+	return &MetricSet{
+		MetricSet: ms,
+		fetchInfo: config.FetchInfo,
+		pools:     map[string]*redis.Pool{},
+	}, nil
+}
+
+// Fetch discovers the members of the cluster with CLUSTER NODES, and reports
+// an event for the cluster and one per node. If enabled, INFO is collected
+// from every reachable member.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	conn := m.Connection()
+	defer func() {
+		if err := conn.Close(); err != nil {
+			m.Logger().Debug(errors.Wrapf(err, "failed to release connection"))
+		}
+	}()
+
+	out, err := rd.String(conn.Do("CLUSTER", "INFO"))
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch cluster info")
+	}
+	info := redis.ParseRedisInfo(out)
+
+	out, err = rd.String(conn.Do("CLUSTER", "NODES"))
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch cluster nodes")
+	}
+	nodes, err := parseClusterNodes(out)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse cluster nodes")
+	}
+
+	event, err := clusterEvent(info, nodes)
+	if err != nil {
+		return errors.Wrap(err, "failed to map cluster info")
+	}
+	if !r.Event(event) {
+		return nil
+	}
+
+	infos := make([]map[string]string, len(nodes))
+	errs := make([]error, len(nodes))
+	if m.fetchInfo {
+		m.fetchNodesInfo(conn, nodes, infos, errs)
+	}
+
+	for i, node := range nodes {
+		if !r.Event(nodeEvent(node, infos[i], errs[i])) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// fetchNodesInfo runs INFO concurrently in all reachable nodes, storing the
+// results in infos and errs, in the same order as nodes.
+func (m *MetricSet) fetchNodesInfo(conn rd.Conn, nodes []clusterNode, infos []map[string]string, errs []error) {
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		if !node.reachable() {
+			continue
+		}
+		if node.hasFlag("myself") {
+			infos[i], errs[i] = redis.FetchRedisInfo("default", conn)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			nodeConn := m.nodePool(address).Get()
+			defer nodeConn.Close()
+			infos[i], errs[i] = redis.FetchRedisInfo("default", nodeConn)
+			if errs[i] != nil {
+				errs[i] = errors.Wrapf(errs[i], "failed to fetch info from cluster node %s", address)
+			}
+		}(i, node.Address)
+	}
+	wg.Wait()
+
+	m.prunePools(nodes)
+}
+
+func (m *MetricSet) nodePool(address string) *redis.Pool {
+	m.poolsMu.Lock()
+	defer m.poolsMu.Unlock()
+
+	pool, found := m.pools[address]
+	if !found {
+		pool = m.NodePool(address)
+		m.pools[address] = pool
+	}
+	return pool
+}
+
+// prunePools closes the pools of nodes that are not part of the cluster
+// anymore.
+func (m *MetricSet) prunePools(nodes []clusterNode) {
+	current := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		current[node.Address] = struct{}{}
+	}
+
+	m.poolsMu.Lock()
+	defer m.poolsMu.Unlock()
+	for address, pool := range m.pools {
+		if _, found := current[address]; !found {
+			pool.Close()
+			delete(m.pools, address)
+		}
+	}
+}
+
+// Close closes the connections to the configured host and all cluster members.
+func (m *MetricSet) Close() error {
+	m.poolsMu.Lock()
+	for address, pool := range m.pools {
+		pool.Close()
+		delete(m.pools, address)
+	}
+	m.poolsMu.Unlock()
+
+	return m.MetricSet.Close()
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+// +build !integration
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/module/redis"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const clusterNodesOutput = `07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004,redis-4 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected
+67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002,redis-2 master - 0 1426238316232 2 connected 5461-10922
+292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003,redis-3 master - 0 1426238318243 3 connected 10923-16383
+6ec23923021cf3ffec47632106199cb7f496ce01 127.0.0.1:30005@31005,redis-5 slave 67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 0 1426238316232 5 connected
+824fe116063bc5fcf9f4ffd895bc17aee7731ac3 127.0.0.1:30006@31006,redis-6 slave,fail 292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 0 1426238317741 6 disconnected
+e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001,redis-1 myself,master - 0 0 1 connected 0-5460 [5460->-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1] [5461-<-292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f]
+`
+
+const clusterInfoOutput = "cluster_enabled:1\r\ncluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:16384\r\ncluster_slots_pfail:0\r\ncluster_slots_fail:0\r\ncluster_known_nodes:6\r\ncluster_size:3\r\ncluster_current_epoch:6\r\ncluster_my_epoch:1\r\n"
+
+func TestParseClusterNodes(t *testing.T) {
+	nodes, err := parseClusterNodes(clusterNodesOutput)
+	require.NoError(t, err)
+	require.Len(t, nodes, 6)
+
+	replica := nodes[0]
+	assert.Equal(t, "07c37dfeb235213a872192d90877d0cd55635b91", replica.ID)
+	assert.Equal(t, "127.0.0.1:30004", replica.Address)
+	assert.Equal(t, 31004, replica.BusPort)
+	assert.Equal(t, "redis-4", replica.Hostname)
+	assert.Equal(t, "replica", replica.role())
+	assert.Equal(t, "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", replica.MasterID)
+	assert.Equal(t, int64(1426238317239), replica.PongRecv)
+	assert.Equal(t, 0, replica.Slots)
+	assert.True(t, replica.reachable())
+
+	assert.Equal(t, 5462, nodes[1].Slots)
+	assert.Empty(t, nodes[1].MasterID)
+	assert.False(t, nodes[4].reachable())
+	assert.Equal(t, "disconnected", nodes[4].LinkState)
+
+	myself := nodes[5]
+	assert.True(t, myself.hasFlag("myself"))
+	assert.Equal(t, "master", myself.role())
+	assert.Equal(t, 5461, myself.Slots)
+	assert.Equal(t, 1, myself.Migrating)
+	assert.Equal(t, 1, myself.Importing)
+}
+
+func TestParseClusterNodesLegacyAddress(t *testing.T) {
+	// Redis 3.x doesn't report the cluster bus port.
+	nodes, err := parseClusterNodes("e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001 myself,master - 0 0 1 connected 0-16383\n")
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, "127.0.0.1:30001", nodes[0].Address)
+	assert.Equal(t, 0, nodes[0].BusPort)
+	assert.Equal(t, 16384, nodes[0].Slots)
+}
+
+func TestParseClusterNodesInvalid(t *testing.T) {
+	for _, out := range []string{
+		"e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001 master",
+		"e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001 master - 0 0 1 connected 10-5",
+		"e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@bus master - 0 0 1 connected",
+	} {
+		_, err := parseClusterNodes(out)
+		assert.Error(t, err, out)
+	}
+}
+
+func TestClusterEvent(t *testing.T) {
+	nodes, err := parseClusterNodes(clusterNodesOutput)
+	require.NoError(t, err)
+
+	event, err := clusterEvent(redis.ParseRedisInfo(clusterInfoOutput), nodes)
+	require.NoError(t, err)
+
+	assert.Equal(t, mapstr.M{
+		"state": "ok",
+		"slots": mapstr.M{
+			"assigned":  int64(16384),
+			"ok":        int64(16384),
+			"pfail":     int64(0),
+			"fail":      int64(0),
+			"migrating": 1,
+			"importing": 1,
+			"coverage":  mapstr.M{"pct": 1.0},
+		},
+		"known_nodes":   int64(6),
+		"size":          int64(3),
+		"current_epoch": int64(6),
+		"nodes": mapstr.M{
+			"masters":  3,
+			"replicas": 3,
+			"failing":  1,
+		},
+	}, event.MetricSetFields)
+}
+
+func TestNodeEvent(t *testing.T) {
+	nodes, err := parseClusterNodes(clusterNodesOutput)
+	require.NoError(t, err)
+
+	info := redis.ParseRedisInfo("# Server\r\nredis_version:7.0.5\r\nuptime_in_seconds:120\r\n# Clients\r\nconnected_clients:3\r\n# Memory\r\nused_memory:1048576\r\n# Stats\r\ninstantaneous_ops_per_sec:42\r\n# Replication\r\nmaster_repl_offset:1000\r\n# Keyspace\r\ndb0:keys=10,expires=1,avg_ttl=0\r\ndb1:keys=5,expires=0,avg_ttl=0\r\n")
+	event := nodeEvent(nodes[5], info, nil)
+	assert.NoError(t, event.Error)
+
+	fields := event.MetricSetFields
+	assertField(t, fields, "node.id", "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca")
+	assertField(t, fields, "node.role", "master")
+	assertField(t, fields, "node.myself", true)
+	assertField(t, fields, "node.hostname", "redis-1")
+	assertField(t, fields, "node.slots.count", 5461)
+	assertField(t, fields, "node.info.version", "7.0.5")
+	assertField(t, fields, "node.info.memory.used.bytes", int64(1048576))
+	assertField(t, fields, "node.info.ops_per_sec", int64(42))
+	assertField(t, fields, "node.info.keys", int64(15))
+	_, err = fields.GetValue("node.master_id")
+	assert.Error(t, err)
+
+	failed := nodeEvent(nodes[0], nil, errors.New("connection refused"))
+	assert.Error(t, failed.Error)
+	assertField(t, failed.MetricSetFields, "node.master_id", "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca")
+	_, err = failed.MetricSetFields.GetValue("node.info")
+	assert.Error(t, err)
+}
+
+func assertField(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
@@ -0,0 +1,170 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cluster
+
+import (
+	"strconv"
+	"strings"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// clusterSlots is the number of hash slots of a Redis Cluster.
+const clusterSlots = 16384
+
+var (
+	clusterInfoSchema = s.Schema{
+		"state": c.Str("cluster_state"),
+		"slots": s.Object{
+			"assigned": c.Int("cluster_slots_assigned"),
+			"ok":       c.Int("cluster_slots_ok"),
+			"pfail":    c.Int("cluster_slots_pfail"),
+			"fail":     c.Int("cluster_slots_fail"),
+		},
+		"known_nodes":   c.Int("cluster_known_nodes"),
+		"size":          c.Int("cluster_size"),
+		"current_epoch": c.Int("cluster_current_epoch"),
+	}
+
+	nodeInfoSchema = s.Schema{
+		"version": c.Str("redis_version", s.Optional),
+		"uptime": s.Object{
+			"sec": c.Int("uptime_in_seconds", s.Optional),
+		},
+		"memory": s.Object{
+			"used": s.Object{
+				"bytes": c.Int("used_memory", s.Optional),
+			},
+		},
+		"clients": s.Object{
+			"connected": c.Int("connected_clients", s.Optional),
+		},
+		"ops_per_sec": c.Int("instantaneous_ops_per_sec", s.Optional),
+		"replication": s.Object{
+			"offset": c.Int("master_repl_offset", s.Optional),
+		},
+	}
+)
+
+// clusterEvent builds the cluster summary event from CLUSTER INFO and the
+// known nodes.
+func clusterEvent(info map[string]string, nodes []clusterNode) (mb.Event, error) {
+	fields, err := clusterInfoSchema.Apply(toInterfaceMap(info))
+	if err != nil {
+		return mb.Event{}, err
+	}
+
+	var masters, replicas, failing, migrating, importing int
+	for _, node := range nodes {
+		switch node.role() {
+		case "master":
+			masters++
+		case "replica":
+			replicas++
+		}
+		if node.hasFlag("fail") || node.hasFlag("fail?") {
+			failing++
+		}
+		migrating += node.Migrating
+		importing += node.Importing
+	}
+	fields.Put("nodes.masters", masters)
+	fields.Put("nodes.replicas", replicas)
+	fields.Put("nodes.failing", failing)
+	fields.Put("slots.migrating", migrating)
+	fields.Put("slots.importing", importing)
+	if ok, err := strconv.Atoi(info["cluster_slots_ok"]); err == nil {
+		fields.Put("slots.coverage.pct", float64(ok)/clusterSlots)
+	}
+
+	return mb.Event{MetricSetFields: fields}, nil
+}
+
+// nodeEvent builds the event of a cluster member. info contains the result
+// of INFO in the node, it is nil if it wasn't collected.
+func nodeEvent(node clusterNode, info map[string]string, infoErr error) mb.Event {
+	fields := mapstr.M{
+		"id":            node.ID,
+		"address":       node.Address,
+		"role":          node.role(),
+		"flags":         node.Flags,
+		"myself":        node.hasFlag("myself"),
+		"link_state":    node.LinkState,
+		"config_epoch":  node.ConfigEpoch,
+		"ping_sent":     node.PingSent,
+		"pong_received": node.PongRecv,
+		"slots": mapstr.M{
+			"count":     node.Slots,
+			"migrating": node.Migrating,
+			"importing": node.Importing,
+		},
+	}
+	if node.BusPort > 0 {
+		fields["bus_port"] = node.BusPort
+	}
+	if node.Hostname != "" {
+		fields["hostname"] = node.Hostname
+	}
+	if node.MasterID != "" {
+		fields["master_id"] = node.MasterID
+	}
+
+	event := mb.Event{
+		MetricSetFields: mapstr.M{"node": fields},
+		Error:           infoErr,
+	}
+	if info != nil {
+		nodeInfo, _ := nodeInfoSchema.Apply(toInterfaceMap(info))
+		nodeInfo["keys"] = countKeys(info)
+		fields["info"] = nodeInfo
+	}
+	return event
+}
+
+// countKeys sums the keys of all databases in the keyspace section of INFO.
+func countKeys(info map[string]string) int64 {
+	var total int64
+	for k, v := range info {
+		if !strings.HasPrefix(k, "db") {
+			continue
+		}
+		if _, err := strconv.Atoi(k[2:]); err != nil {
+			continue
+		}
+		// keys=1,expires=0,avg_ttl=0
+		for _, kv := range strings.Split(v, ",") {
+			if keys := strings.TrimPrefix(kv, "keys="); keys != kv {
+				if n, err := strconv.ParseInt(keys, 10, 64); err == nil {
+					total += n
+				}
+			}
+		}
+	}
+	return total
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
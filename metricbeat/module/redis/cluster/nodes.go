@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// clusterNode is a member of a Redis Cluster as reported by CLUSTER NODES.
+type clusterNode struct {
+	ID          string
+	Address     string
+	BusPort     int
+	Hostname    string
+	Flags       []string
+	MasterID    string
+	PingSent    int64
+	PongRecv    int64
+	ConfigEpoch int64
+	LinkState   string
+
+	// Slots is the number of slots served by the node, Migrating and
+	// Importing the number of slots being moved from or to it.
+	Slots     int
+	Migrating int
+	Importing int
+}
+
+func (n clusterNode) hasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (n clusterNode) role() string {
+	switch {
+	case n.hasFlag("master"):
+		return "master"
+	case n.hasFlag("slave"):
+		return "replica"
+	}
+	return "unknown"
+}
+
+// reachable returns false for nodes that cannot be queried, because they
+// are failing or their address is still unknown.
+func (n clusterNode) reachable() bool {
+	return !n.hasFlag("fail") && !n.hasFlag("noaddr") && !n.hasFlag("handshake")
+}
+
+// parseClusterNodes parses the output of CLUSTER NODES, one node per line:
+// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+func parseClusterNodes(out string) ([]clusterNode, error) {
+	var nodes []clusterNode
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		node, err := parseClusterNode(line)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func parseClusterNode(line string) (clusterNode, error) {
+	parts := strings.Fields(line)
+	if len(parts) < 8 {
+		return clusterNode{}, fmt.Errorf("unexpected CLUSTER NODES line '%s'", line)
+	}
+
+	node := clusterNode{
+		ID:        parts[0],
+		Flags:     strings.Split(parts[2], ","),
+		LinkState: parts[7],
+	}
+	if parts[3] != "-" {
+		node.MasterID = parts[3]
+	}
+
+	address := parts[1]
+	if i := strings.IndexByte(address, ','); i >= 0 {
+		node.Hostname = address[i+1:]
+		address = address[:i]
+	}
+	if i := strings.IndexByte(address, '@'); i >= 0 {
+		busPort, err := strconv.Atoi(address[i+1:])
+		if err != nil {
+			return clusterNode{}, fmt.Errorf("invalid cluster bus port in '%s': %w", parts[1], err)
+		}
+		node.BusPort = busPort
+		address = address[:i]
+	}
+	node.Address = address
+
+	var err error
+	if node.PingSent, err = strconv.ParseInt(parts[4], 10, 64); err != nil {
+		return clusterNode{}, fmt.Errorf("invalid ping-sent in '%s': %w", line, err)
+	}
+	if node.PongRecv, err = strconv.ParseInt(parts[5], 10, 64); err != nil {
+		return clusterNode{}, fmt.Errorf("invalid pong-recv in '%s': %w", line, err)
+	}
+	if node.ConfigEpoch, err = strconv.ParseInt(parts[6], 10, 64); err != nil {
+		return clusterNode{}, fmt.Errorf("invalid config-epoch in '%s': %w", line, err)
+	}
+
+	for _, slot := range parts[8:] {
+		switch {
+		case strings.Contains(slot, "->-"):
+			// [slot->-importing-node-id]
+			node.Migrating++
+		case strings.Contains(slot, "-<-"):
+			// [slot-<-migrating-node-id]
+			node.Importing++
+		default:
+			count, err := countSlots(slot)
+			if err != nil {
+				return clusterNode{}, err
+			}
+			node.Slots += count
+		}
+	}
+	return node, nil
+}
+
+// countSlots returns the number of slots in a single slot or a slot range.
+func countSlots(slots string) (int, error) {
+	from, to := slots, slots
+	if i := strings.IndexByte(slots, '-'); i >= 0 {
+		from, to = slots[:i], slots[i+1:]
+	}
+	start, err := strconv.Atoi(from)
+	if err != nil {
+		return 0, fmt.Errorf("invalid slot range '%s': %w", slots, err)
+	}
+	end, err := strconv.Atoi(to)
+	if err != nil {
+		return 0, fmt.Errorf("invalid slot range '%s': %w", slots, err)
+	}
+	if end < start {
+		return 0, fmt.Errorf("invalid slot range '%s'", slots)
+	}
+	return end - start + 1, nil
+}
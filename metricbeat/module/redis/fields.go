@@ -32,5 +32,5 @@ func init() {
 // AssetRedis returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/redis.
 func AssetRedis() string {
-	return "eJzsXN+P2zbyf89fMdjvQ7dFVvlegbuHRVEgP3tB02Sxm+DQJy1FjWzWFKmSlDfuX38gKcmyLEry2nK2wPkhwdoS5zPD+UkOeQUr3FyDwpTpZwCGGY7XcHFr/754BpCipooVhklxDT8/AwBwv0GORjGqgUrOkRpMIVMy9z9GzwAUciQar2FBngFkDHmqr937VyBIjlua9mM2hX1UybKovukhbD/37q17oFIYwoQGs0RgIpMqJ/ZZICIFbYhh2lh4u6Dspw2lDccO0nzZh2gAlUNmB5gOTKEplcAUko179P79x3ef7Ot5TkQatYbelWT96bLRZoVyhsLond9CHI1wtZ1wP6hjQUedZ/rA7ACSQjgl2XuihsWlWPT8OILMfj6WeYIKZFYjrIgxKTRc4lfKy5SJxc7XTis0J2vU33d52aLOyddYlqYoTZyUWYZqBvQfpFigNuDpAGfaAMmlxVsq5djZ42oYMROzAn7FFg6wIwOezChiuJTC2zv8M/r/AZEnXNLVWdREQ4HCKYa1TU/YqQnhHC5ffbj5dPMcXt1u//tw8+Xu3y3oAcsrtdmT+9GW5wZte5NDDRAFSfiAXBMpORLxONG+FymjxKD1ecQ4b9YBrmsAY+IrytOK7vXNF++xDpRXqTGN9Kb72haRpoRjGmdcEvM4qd1ttMHcIaRS6DLfxgKPXaNaowrbSo0xpkvGU4V9s3cGsAmhKzs/IoVCSYpa44CHcqBLPeCajgf7RaM6Vq4W4jkEG8Q6LNZe68kxl2pzWgPyYz4u6jtBrgkv8VB/7v3cNSQbg302OEGwn6UhHETj9d1QQDiX1lU5Me+khQH4Sod9wHzgP3ZgO7/qJ2TLAbGqjKJWGFmgIsaGMO2N9ZJEq4iAQs1Sl7mhAc3+woHw61gukKy+Ac83SFa1urWNYcos8ZJ8A8RfNKY14moSPpQEUCyYwBHEKTFEY5/TmN0wlujUAJiotEtmDnsX0lCW+c3M+jcvbs5yZgbz4KiQnNGuN9xCXOHmQaq+jGgCirdr5hJb8ETASDur8LBEUSuEQ2gzH4WELoOZTxt1psgiR2F8hmeNWQbhHxFybu3AkKB5sN7DamPsMcdKa1emtr6bCDY0o3OrwyunwIfyEmSKUMPWGKdouYuYjlUpBOsFf4K8+R0nC2A+ebZ+m2UVAPAAGvFaNfK/TGDBxwcZTrCO0vzK/hoqE9S6eTbuy8RhMEeBgTSjh0ZvhQNjeggTdBGmicd+XjYROmBCsKdxTwG117wJkOuE4gmAvq1zmwmwp3lXGPWwB8B7t2PEYZKH+VQ4s5BrLlw6FhqtUQ6tzyPbZurHxWoxPRVhNrB3x+qt5wpUmmmDgnYdxKlWRQ6t6Lgk6TnDoU1LLU2bohJIy7yAjHG08VCKq4Xsx/J/8Fm+kZDLNcJ9Bfne5mj1H1G1GnXvMgSSpiDNElXNnpcNJG6qfO11qQ1RBgzL8TkYV1q6CXzu3qkt4zlEUfT9eERUaXJwFJxSQSm5Zinq3W2HRJYGbt+8GlAnmBhlOdEm1mSNEV0SsUAda9Y/Gkwxq4km01q59VTBUfXKQbRxejERt53AmeG+LSRdXiXEFoeWnDYkLyx6h1WXlKLWWcndnFhQYX1p85AsHANMxIWSC4W96xIwwRIPYKVrkaTBPGKBe7D9DBhiymHY4dz0ANh3jk5d1jqxN7irVRLZL5k+1HYOI4302OCRlgOEJ/P2phplhDtb3GukUqTD4bpitNq5eeK81grXy28GRAxUeLCzG1lsYiniB8VMrZrsr6eQhPeuzVi4V1JcObh1peN21dJSWXFs9eDVm5CWh6szmZ05Fr389M7HomNCUXhDC+b1gRY9l4uFy1Cqsnyn8BypnbzSfWM3bpmooLRtaKJPr5nQdIlp+U1mgYgADw+Mc0gQGmwg61xh330wDVTmBUfTs1zbx/HfJSAE5ndaTKiZ/ZsFhQDPu3FhiF/fyxA9kVBwZ8NAxWObtU5jx0h031Hcb5h/9U3ONB6eBv6uWhlZT8z/Uo7JKUdbIE/b2A4xMstC7SufACsWft2I5dZKgiLeYcDWq08EfYMapABODGrXdahMWYBUtRuZNjmZ3ggaVZ1eZ1uncFSb/rI/ZKKddW07Ot6/+AR/lti7g9oFnyInm8dvbEwNq55KBZ3KUpiQAWzjZsEZ7QvpRyxPhoYcW5lUkoe3o4/a8bqVvPENTGhDbD55SYmwaeZFTrRBdfHcauaF6yi9CPUIQl9DbOy7UIPQT9LwWBODDrEgPKuqXC6i4O7UseC6+XxLl2riPZueIZgBF3eeFqPeXoo+fgItuV1mMqa0ie1oscyyR/SITOwB8ZoLnsYJcC+ZNnygV+7xaO9C3Sr2y4mog/C9GKJZRe07Dr/TTWBuAw5Q7uDzhdu8GlGpQlnY9PZhyehyB+j7NxqIQiCUYtG31d6BzJlYhXP2E3jmTp7OxAouy+JFKh/E96PgbArOZFwVxDFZhHttRjzIQF17kJuuoHR3N5iNxIRWqxtmWXEwqjA2dxheZjrBnl3T++3wVi6FabDUXZ3g6yQXdyYh5ph55/cU2i4tGEgwkwobjlprRtMYeuqK5pTMKCJ0hsplplWNR+Du94+vD1lLdtM8ryvd95y1/TviTYY2grFQTCpmwu2Bx6Gsh9/LG4kGApSIlKXWaDKpICOMy/WAQXvETMcKSSoFD4OeY/u9EqvrY0yvdsj3VgU+1p2qILhzo3XP5sGEgmCNSvfbigdDOCN9bqIgZum5YBSj8Cg5W3izuAajegu5NmvjtrNgJtZL8o8jQ+U0QilTA7p/KkpJyXgas/Cpo1MRymV6bPE3TkSGI9KwLkkdZSXnZ9AhougyTthAj+XJJF5ywwqOX5lYxKRg82stpfGYSZ+KVnXeZkhzh2e8GiAqekc49ayrUpzDyAwt4kKqowL7OJWyCLTonJDG8q95x+eqjCmX9OBTNIeRoVJkbBFn7OiVr5GI3tO1fWQD4jGHyN0JaIUU2fq4g8L92Vvn2Fj7EHNNNHzuZBfiH0cfeX8ERE900hKku2lAx/XxwnMg9SSbE41TcAo0kTts/rhDJlNBonmQalUda68Xm8IzbVH5M/tngVVdD7CPKwjQlxyGCJSljmSh4wJV3L+Nf8ql5u4MQ4GqqjgnYvUXGKySYs7T17ZKq4T7na9tQNmKbIvWCvvXVy/6JBaQsb8q4qzA/QbtOPJwcbkRNJSenqIS3hq/JQIKLUHtF7ICWx870AqiDCM8kkcF00kA63VNqGhWYEHhnyXqnqS6FyiqOW7d2EWaomATcAYBr3CjI/xaMDXLVRtdt7/CDThqftkG1z2X03TBrdns18VUNBxBSEsEIyEnX9vnN6eJsiAUo+VQ2XUKuK3OcS7lqiwq0ep6FyQnTEDqD6aSgZObDeScaT3z1mNGGMf0QMDhwqxMdJm4wwcC+RzIf+Ey2dHdokxe6DKBmqb3XPVdSGXSjBhW6Ap1QYxB1fPcjKgrmhNAh4sZ14ARZ1Kt4nKe9GG/hdH1fGQu8Wl3L+aMKtltYQyvUbg6G2Pqzl/HWtIVzmKju865omORC7fA+9v7X25ffn4LRakKqadsv7vAGHsHrWOjCF1hGlvTmR29s8+KokPvUGwa8HBJCrcCn3AEKbg7+26zEPdFdaXWOIe7B61n953utgKb5bU62QpUmVTVJRPVuWu3sdw5e11lshNZOYNPJYlUNm71MeX6pLaXyASOkx/G0go38ewz5PVuSQw8oKqB800L+sCG8z7eM0xDB7FesaJ4lOS3Vi8fuFxErvmqd72lB/YI5Nd2LOeVuHzYOtORg6d1FXf6hZ9q5NZFiAcuAl38EFHCububsvs5rSd0ZPwEV1d4NOjxK9LSzeelkKZZdBm4WOfih8gGz7lQ+xOpr2++uBOH3bukNDYzOorQrRJY1sNQjyxeyRoVWeDupVe2eN2T7iDYWujxmdShplfpxfZCw39FP14p+uPw5Ps8+FxYq6x7HGmrJHjWBXPI1acr3LRuPt0/8uVqutYrh19pav/tdUX9K9wjIvsVN27EkQvV9nZVjiD5RbA/SwTmsyqzZNrVxZf/sam5jRhWZvBTXZz9fP2TxfDz2IWTFtFp5WJfd3eLLeWDu1zs/vPvN297bqbtxcNRLMzyRNHrgxusLgucuFrlO8fc3eNp5cmZNvp5Rd19o41iYqGfAyUqZYJwZjb+BzR6TKo+9Y6M6S6LPZaTu6rlxsh67D4bdDN/rCG6QYbuIXYWWQnUPTzvZcSnNKJfK8Tg7tBgGcPQBURNarhexKebxpdV7DKGj9DtKdYeS7STclaLJ80qzhRNfiyU/wYAAP//elnqRA=="
+	return "eJzknV9v5LiRwN/7UxC+h3gDjzbJ3QWHQbDBzHgma+zsjGHP4JAnNSVVdzPNJrUkZVv59IciKbVaFiX1H7W9OMRAdvoP61dFslhVItlvyBrKt0RBxvSMEMMMh7fk4g7/fTEjJAOdKpYbJsVb8tOMEELse2QDRrFUk1RyDqmBjCyU3Lg3oxkhCjhQDW/Jks4IWTDgmX5rv/+GCLqBrUz8nylz/KiSRe5f6RCMf3P7rTlJpTCUCU3MCggTC6k2FCEJFRnRhhqmDeLtQhGyi9LESXmhDaj69S6oHjD8m/s2WnhG5pLLZUnkglBvvg/uk1eEaqIglwotmJT28/MPn7/ff/t4R26+fPo6txrVL335ev3xHtvfbKjIaqUIaVg8AUMbr7cVbiqNloKddyq111A+SpW13utRHv/usTlUE9Xwxrgic7meE7YglHOiuTSaUAVEg3qA7IrMF5TxOZFmBeqRadiq1E2MDXQStztqBO/PVK880i50064hGzapqNZsKaBtri0cl2LZ8eYAH/59KTYJKDSqt50XRYwklAiZhSzW5JPrM5C5HiVJaalwalJju1pIQ7CXmViOYM3xoy+AywSZ3356d/N5bh3IGLO+IOl+oBu2VNQwsTwDbQJMLL1EP0yFnd1jByvboD88J6yTWK1he+Km8gEUXUKUpyZIrFPKIYsXXNKuD7n16y3JQaUgzGG63eEKWHnfP//1P//nv8hq6+Dq2bgdTSug3Kz8qApoWmm5FvJRxPaTsy4FO7pkAHnbFbZVJ4EkZdMPXxEmUl5k2En12F/hyreia+gd/hW4Zv+GkxNvKK4SHgkNioDUEFyBDZEC7FwYIEsLpUCYGHKZrk6E+MG1SWybrZV4ACfctwesrB9kIcy2b5OSKMnBxjJu1EWz1gwYWGGdxdt8vaYagRnu1YCxmkgKcs5SOi2TFzIayq+zkzJZFrLgdLmEDCPYKoZTLpr7+zwAWkFiA7MuugNG2q9QYe34DZyD8ADCENaIsHPfvfuOPhaO7LoD5bE2lRmQm+uAuZoENMsUaD0NxjvXeGVE7B6bmcxZ/hYtNx8BmBQ6xs8GCQ8febdSmVYHk6QYMxtWUhv8ryDUUWb72bdOqBCyEOk2hXMWxJxHlCMw0TdOg3iHXnenW+fOy7nZ6r3LmP51X4unmgk31xWm98I2WW56vxGM6JImmiKfsOldS3K2BjLflBr4Yr417BWZa04fYO5z279vHeMoO9v2gkokUnKg4jAlvqkCyEKqWgn7H76UA9ZVplIIV88xcgQtZ2Idd1URTmT2nYoCCsN8oqZnou0Trsi81sDZPWN6+8oIjVIpFmzZGZSdwpd9sM0XmI1JsRumjRzkORPLWIOYwtP+yjhnGlIpMk0KwZ6IYRsgjyvwlnbhJS8JTQ17AAtDHqkmCHRF/oR1HkyewCf+JAdho3f84BiPnUuxjBWkwB4gewENOQbwCGG1qkBGgHcVpvoDm5HEz4tUuwNlOIppYqYYmHd+YsC2I2n76xcjhvnYssWUwK0Shi0i74neV8SYHr0uaDBh5Bj0Glss5CxEe8QgvmmU6FuPDCo68sjMyrLOXeHbF7mvnPupiuvRAky6irHmP8cFCwRNeHOG7jcfHkBpJkVvL4VXsD06ytX9vbiRLr8JWuToiiMNaS/r8SPquxXUJMSCo3eZI2E3sJGqjAoNWZSU5lldYTRzVRjra2SkXr9aJnwKIVPaeNyyRx+knIEwOqoDikPV2nty1xJrhvpLvcQy13EOKp5+2DRZ7bzVJFcyBa193u1G0EhuH/ij0EguFhqmXrPutgKJE3jAHF1DqSfm3JoZheHMxMdqyJlRQxOqIRAmVIwtD9/t2Xtg5tjA+AewCkyhxHa27fr2zueXy7FPL/1UmHVZumutGjCx89CuUatCY5KFYXaAevxC7wAYIGvNL0foXQKTQpNLeKrK5M2X7fpqk1H9QxSk3tCnWBYmL0ycFIvFzjPwU9F/lmIJ2hAnh3CmDaEbDLN9FbxDq35iJiYFfs+WFtiKIU7MIDG5lMLtayD/Hf2px+QJl+n6LMNE1xkYzk0n2A4TdBuX7z/ffr29Iu/vtv/3+fb7/c8N9FkXv4/FZl3sR8w822jTm+w7AX0wGLTrUdWTG5HhkgT+WRr63BZ4RzQ668JM82LWhXew6T7cfncea0972QBNl/rQ55YjrHZfagMbS5hKoYvNdi1w1rPZoYoGGeN0xXimQLwMbELTNfaPyOq4Rg9AFxrUhLDfNahj7YqI5zBskLXfrLMucJdczLpAD55Ars3DVn07iR4oL2Bffz6U3Yww7DdpKCei9vq2qd0kZ2f7WwBfaf0C8NvFyjbi/KrFbWhgi4sgqgEjc8DiqVgS7TzLJY3WET4q0CzD9ViDsQ/9e5Zfq3IOdP0COt8CXVfDrTkZxvQSL5qh8bmIv2MG54l9J3wuKAGxZKKRYHQSY1LSnbxNTf1tBXYYYIrkRpdPi9pIQQU29OnFprUvVnC2YSZsYiTMJWdpGUQ86unLxweWIiVxQvDZS6F9sdwPCEuIdTgFNF0FI58m9ULR5QYE5ohSRDiZwyXHI5Yctx0qAfMIIOz8iR1zrLS2aWrjtZGwoR6deji8x6/urUtQKffwJs4AuyJiOlaFEN216hPEzfjolDAXPGPqgY/FLQDJYMe8OIwcWt1OWAW3Pkg1zcjfLRbKRhg1TBR3ReL9MUpfmNEhozPDGRyHY8biSPPg37t6hQ5MoR3w3W59QWoL4pezXuQqoHgF0HdVbDMCe5x3Hfawe+B92pnEYZHdiH32OaeRKy0g62mtUkFpfR7b1l0/bFZkei3GrLF325p1cef4TEwbECnMxjrM/aoi0ayl6EBGxyXNzrkcYl6DMjEToiQrNjlZMA64HkrxZim7Wf6DfJPXkmzkA5C5R55jjFb9I/LVKHeGiGaZO2VD/NvONiSxXeVyr0ttqDJ2S8QVMTa1tB14Zb9TzYwrEkXRDzVR0IwqS4ImDK2CIwx4q+QDwx2oO48dElkYcnf9vmc4jV1lcQ9IrOkDROmKYt061qy7tVHTaoRKrcqtk0qs1O2uFBwXI7mxAyfG/Yhbl97gA6fMjhdt6CZHerQe0UWKtZxFwW2fIFTdUq8OyRI/GzER50ouA1tex8zEPVRpz0haMw/MwGfYqLsN/4p+7HBsugc27o0r6rQWRW+5fZVEitHUx+4uqBaPzO9tO06362qHXL92jZ0JYxT1T25eua7VgOvU124prpvqVTqVeRlLET8qZqqh+fwwzN5KnyA66KzNIO4bKd5Y3CrTsU83s0LhtNyOg/fXLbvUkmYhY1C5mIWUnmYtevf1k1uLjlmK/Are22eT+ECk53K5RMNXaflO4tmLrcD24ku7cVTCozTn0EifXimhsa5VvEgvUBHQ4ZFxThIgNRuRVazw3H3gs1W5yTk0D8v1afx7WRAC/TtuTaiU/Z0tCgGdd9eFPn3dXobolSwF91ii9zo2VWtt7OjVLFlWur10/NXVOfX3e3V4HfztYeU3D7/7+qlupVeL/+chhws5mgZ53ZNtn0mGxqt85StQBfE9jauVBE28owDmq6+EvqYmUhCO+4oMVmSUKXI8uOTdSN1Er24LXYo08ju9DtVv7zqFlVrvL/uXTDAMbu7ouPnxK/mtgAJGwGfAaQnZxPDXToqT6Q7DhCZAx1bkWRdUV/YwQNPYbDwblxOc96QoE9pQjCcvUyowzLxwhzIvrnBkXtgdpRehPYJNXL83ErLYfkfP9uzZEdyNslkljLSEBfFwqHK5jFoPHU8H147nG2OpEt7x0DOEGXBxvZBDTmuEEm6L0bPFEwdKlz6tRSWkzIIpbWJsLQ5u8D/W/N+ax4mbe/qP4F4xbTiICWjvuyyMu1UIE2Opg/jODNGkpnY7Of+g64W5CRyQ3OJzidu0I8IPhSLHZyWPK5audix7c+0uKKNpCnnXXv4Wcn0EutDTeOZWnI6HoC+L/MdMPoofZq3PPoPDhIjJ2CfEMV3Kfa06Iq/dy017lPbTDSYMKHSEtrphVl6DIQVxHR8oMw3VZ0bQ13u/mzcU4B7bUqTo132eZNedUcQcFs756UO74wiHvu0L2whBGJLAQiqoNWrUjMYp9NoHmk3jjKJCL0DZyNTneJTc//PLhzGJXaW37eZpXelzz1nNfyu8jtAGGHPFpGKmnIiyav5Z3Eg1oSSlImMZ3t+AV07g/Rd4U9oAMW5GA5pJwctppnLg8bs3q93HmL3ZET/rpLVr3awL74CE4N621nX6eighCJ9fdrainHXeUJVTs3JasBSicCv+CD6OA6MKGLB5/XaQd8lMrFf0z0HgcUtl/XavoIwpU04uKSkYz46/GKd+Oyho8/zarNMLkfrAsSR1tCg4P8MYoipdxQkzenJjbApuWM7hCe9coTmbXOAyTeOhKX0qWf68Td/I7e9x30CUs+wMva4KcY5JZtL86HvM6reDUtxtDtPKWP172va5KuIUz5NOK8ZfyITl0qCgYzq/ktO1a/uICh8eYjpo86GvmeFjiOgUFyH11HS2x8YaQjsuPepH/NfRR94PQHRCt4g9rO42iri+jeIMpO0LMEZwCjCRPWwe3MN7Ekgwj1KtiZVUF5uiWevDO1TuzP5ZsPz1AM+5goAu5TBUgCx01H/dyWjSwdSy94qTkay2C+J1kusJz91iluaN+weX2xC8lrtBi4Pgl/c/dlksYOPCnB3cPgobQR5UwdYoAuHpscPC2rgeGigED6OWItWukBV49LGDllNlGOWRXE8OWNU1iZfpYYmC3wrQZiQoKDU5aQaCjeAMAq+h1BE85UxBNgVsy+2voSRWmr/b8QFEjzUdHB6whGxSX+VlYIqiSVYAVr039Kl5frNuoZc2pylEq7606xS4jZ3jXMp1kTfvOsIi04YyQTJ3MJWqchh5w/BSgUmhsbgF2Z7AQfK8SHSR2MMHAvgU5P/gMtkZu3mR/KiLhFQynefyN9zoIqlb1EPUOTUGlDgrtZc5AjpI7zZgxAup1nHRtRgej/98CyOKxIL+elt9xkGzYamSvnpdtxQk99dUxqk9fx1rvFnI6Mmds5eD5AJ1IL/e/OPu3bePJC9ULpsTLkhuF8bYukzQsVEU70SKA5eonZYehRAv0dJbirKGJ5c0txX4BHdFCF6i18QoBLdj+xX9h30PWk/uO+1tBRjlNXay5aDwwYo9u+V5bMG7ffbaR7IjVTmDT6WJ+1WsLqXsPqntJTKB4+T7qbSGMp68h9y4W1FDHkFV4LxsoEO2B+8ZuqFFrNcszw+yfKWF5vIRN4B03UQcxB77cyPY+NaZbgE6Saos7vSFH99y4yLEPYtAF3+MUsq5vnj2iR4jHeQJrRjXwf4Kj5oeniAtUFFyiT/hVRVdei7Wufgj3vmaTkXtTqTilVdYPW3fJaWhIteDhLZKgKqHUY9MXqn7VajdS68weX1m3V7YyujxmYZDJY9YeY0LDf8a/eWNSv/S3/kuDj4Xq4+6h0krwjWUs6E53kMxX0PZuPn0+ZEv9JXHXWna8WMlfRXuAZP9AqXVfMvUKZRlpxP5XbDfCiAs878zwTS2Qy7/t7rcG21G/lYlZz+9/RsC/tTorE5ENMLpINEu2CL+xo1e+Z8dm3/75+3HjptpO3k4iKU51Y92fbaNVWmBNdd2/QUOGKRpa0+8N1Vfeen2FW1wE4u+IilVGROUM1O6N8A0L33t1MIGvRAZw0+kyb3fcmNk1fasLbPq+WMnos3t++4hthcCeIPaD097GfEpJ9EvnpjYW1/Ygg3+eBt9WMan68Z3fu0yhg/I7UjWDhXaCjmZ2Om8AQ6fRB6I8n8DAA7k8Y4="
 }
@@ -33,6 +33,11 @@ import (
 type MetricSet struct {
 	mb.BaseMetricSet
 	pool *Pool
+
+	password    string
+	network     string
+	maxConn     int
+	idleTimeout time.Duration
 }
 
 // NewMetricSet creates the base for Redis metricsets
@@ -61,6 +66,10 @@ func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
 		BaseMetricSet: base,
 		pool: CreatePool(base.Host(), password, config.Network, dbNumber,
 			config.MaxConn, config.IdleTimeout, base.Module().Config().Timeout),
+		password:    password,
+		network:     config.Network,
+		maxConn:     config.MaxConn,
+		idleTimeout: config.IdleTimeout,
 	}, nil
 }
 
@@ -69,6 +78,14 @@ func (m *MetricSet) Connection() rd.Conn {
 	return m.pool.Get()
 }
 
+// NodePool creates a connection pool for another node of the same deployment,
+// like a member of a Redis Cluster, using the settings of this metricset.
+// Redis Cluster only supports the database 0.
+func (m *MetricSet) NodePool(host string) *Pool {
+	return CreatePool(host, m.password, m.network, 0,
+		m.maxConn, m.idleTimeout, m.Module().Config().Timeout)
+}
+
 // Close redis connections
 func (m *MetricSet) Close() error {
 	return m.pool.Close()
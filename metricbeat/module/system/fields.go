@@ -32,5 +32,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJzsfXtvG7my5//5FEQWB+PclRU7M5M7138skEnO7BqbjI04OecAi4VMdZckHrPJHpItWfPpL/joN/sltWR5YONi7oktFX9VLBarisXiOXqA7RWSW6kgeoWQIorCFXp9Z37x+hVCIchAkFgRzq7Q/3qFEEL2j0gqrBKJIlCCBHKCKHkA9PH2O8IsRBFEXGxRIvESJkitsEJYAAo4pRAoCNFC8AipFSAeg8CKsKVDMX2FkFxxoWYBZwuyvEJKJPAKIQEUsIQrtMSvEFoQoKG8MoDOEcMRXKFY8ACkNL9DSG1j/WHBk9j9xsOL/rm1X0s5mbo/FEcojqL5huy36TgPsN1wERZ+3zCa/vm2ghSsJTdFv3GB4BFHsZG/SBgjbPl6Whs9iJNpHKja+DLAFMLZgnJc/OOCiwirKxSDCICpAfDsF/ASEF+YaVUkAiRjYArNt2bqMhYIC8D8hmKpEKyBqWmFIpFojWkCiEjENChK/oQwpcSSaA4iHSngAqRRI6KQwGwJskTN6M4FUhxd+gUkFRZqpgHX5BSWJ69DCobnzQpYid8NNtMmFIT18a3mP8EcuSVXBMqDIIkJhIgwFGH9H/uZs68fvryZltZOZgLQkKVzb792jwLOFCZMIsoDTB21vitKz3dNWMXRO2ThUJxrOgUoWpUcAi1jhLWiLimY8bTEMIoSqoj5XsH6pD9lg5PNVoWJIiMkLP06ZYVytqz8oYUb/aOhf9So7MLIUZU++T/QbaYB0gtIcYVpRRdRlz6iVp3sgf6bHhXhQJE1eMxGabq9sBMJ4viou6weYQYYkjEOoGFKShwoEjzIcTRCg8MRT5jaE5hT81MU7gMIBnQIFyMKuFPCA9AxEsDpSZgzRPnmPBaEC6K26SYBsg83R5P0rihJSE9Q5gZVD+DHU+QegPgGE3WCsmRIA0NnnKGQyIc3/fg4po0Yhk/8cXpCliDWJNDRmHa/V5iFVP9jhUW40QEcYQqESGLVuR7FH8cT/WioJV+o5zQvGu9uHD713OyAXMFT+LI9zBJha04TprDYWhPgHN01ESrB1HxjsyLUxsirbaxFIrmoDWYCy4K8uFqBSLdALqa1L3xYY0LxnALijG715vmdkcdegjymXXxmAop4CHRmQy+vhOrJnh5CMuGoppwGdehGQ8IWoQaIPhOWPPbChkupjfGQ4Qh2xLX60wvItyB7wDEheZAIoTUooDx42A2WpjNrCMt3llW82koSYGpRXn/S0FxWISLLlXL/hUcIEgU2yRAb5RaAQzlBK2ABoEh/Qa0wqw3CGaRJjakmOyXhPQowQ3KFhV0kEkeVzzhW73cTVMrT6MIyYskkZoXVCq+QyHtVRTEkKRTESS0vpQFJhYt7zS55HpOgHNF2+tKfsQDpAiJjFLlUU/thzs7zDGqNXr5ZSbQhlKIVXgPCKMKPJEoil4XlC3R/eXHxN/Qfdrh7Q7tGrJCpLdLFVCvyFin8oLUxz+0yxREOArMT2O1+XSfqwaKh7Jzkeg7ZInTD6slGOamR3fLELHQzaUWRZ0coSwFYgTCGw8qteHYwQWSBfqyRdRl1AQgr9P7ibxraROuVVa7MjsTJNJXmvdWeOaDLXxon56+VVfpr5W2eb0bkr5KAeEaB5Euo7OHwJeAcJ556olOoHoI0h+8SWbbNjnodUjCKc33zT22FmpyS33PPqJd/oj2pkxTB0JOjk2Vk6EZ/mozstdufJkv9t/wTxb/Dvn+anIy++T8rNnf1AE6TyefqBpyaNPt4AZM0ESJ9JWsmuPbwXvEYvtUS7s+lWOSUyyyeR2HCCZ7vn/S5+FOfTu6+Iz418l03uZfjwKJMtJ4S/qoqiiHHD5pE4fxB/xNd32QFqT0r4dOf4WcUA48Is9pzGeLL4dNt2DOnhx3KBoLg8c9WUwg/SDdCdsZqKs8jvEWMKzQ3pdFrEtptHFOaC71G0+XoOxgSgMOpOfAYcfEYT6ngYZhjQxRwPUNaZWQSaA1fJJRuO/BtBFFwcIBmlB0RGgnOt6r/iVrqCvq+tAN4Q8bAKMNGN+5o0h5xkepQqOIHSggUF46SO/QlTtMYwlImkZaM+RSS5E/jh/58+a7XDD69gDQOBWwcGaXEeoqpRrVbbEatKldAWoW2g2AiQnVMEHAWyrzGXZsVs2J7TeyTQbRrttNZPDRAP8aQ633w+u2NLG/iTSB5PKbzUsWocWjHJRZ8KUDKusMATAkeb/fxGHLfxN2eqdMc7gXglOhsTtSo/l2GVhPWQqrDLVSXPHm8n+N1OCdamtheUeH21hTnNLPLP1381/vaLC8IhdJFKbSTa5iTqRWo5H8ao04lY/pIG4fxAk3oXpC34tquJywWZE0oLCG0CQjC7DBTL/QQ1iSAkQvdyp5q+c7l/dsQ1m/1Xy/vvYj0uAeAomlUocCj+ul+iq4ZkjwCFGAJ5gbbPwkL+UaimzsbL5nymbRM4z5hmdDvEZYIm33aarfem5mdXcKZvYSp9DbANxCiM3icInhUIBimBpZ8458WE9XNYk4a3MmdZWEIa5tvaNfmxj8lZrWMZdQqNp8wHoJMy6/smpzosDRYZSLH2uOdE2aFyhcW0AQtOA1ByAmS24gS9iAnJki3Ot2g8Nwg83Ozs1Qd0WoxWcHKGLn7ES0EPKnhaDcQGt1sVAUoOaqafKoDJJeaJzSvJLiP6qMX5NYurUTCcYMsPeBAeE/vJFRAt+X0MzWUep/eyx1wKmUpFTyC4ip1WSO8XApY4ixtpP14s4IrhaD5V/eudN01cfB7vpTydSPRgicsbFk+eyzpI1pw9Ds3xtRtyG38aIdywNLyq09duiCNEuSiRSEPZDXj6Zta1G7iW+Xdhb6Gs77qULbXGhNbWWhVgHpFPhlAYw46APps/vEQWtt6ZoDGNJFGpm/q4SnlONzHRn28/W5oILwGgZewp1V5ffl6qKXXfyJsOVvgQHFxhS4vLoZZ+88F+MaZzvqVRIQlCvxr+PXPp4T0Z4e1weC8vjwptJceuH7cpibgqXTCowsoJNkhQb+z/jo7TzUVfoUZg6Mn064GrdqTJ/MhP0eHvcVTs862K89ePqQlUUsnuX4/I6SSjhbbmH3NNVFqn8GjxjTf9RbbC9YRw2YbBOZH7cajcnOeBVzF5lshB5v5ISygSZh9OODMHrvMt6k7GeBgZbtw1YaeJ4sFCInOJGQBsRMNDlSC6bTihvhDCz1A74ZJe6vURzNcyjBntUj+5APTXtpnJ2C3oKKO5IOhlnfzg9CEQXp6u0KNVpfeu2zREdxmx1BBnoWFcq2QAGexpbHXIdGabm7rzkFtwN2Xc+uOheZfecbKzZD3KqX+qX4ShRADC7MY9ebOJj7NzeAQFCZUTlBsbDUKVhA8ZNmCwkK7b1AJ9PSBnhO33y5dK0QkCjANEmpSGnOsp6Ugi/Lhcjnd/QWi/MTMJEPexoIHbyOICFvwSV0W+oeL4oDma0VwJobKLV9m6ciiTD1LoKcI6gGi/rlh6ObuX4gYRjGSSVS10qkOEebapaUqdJMlFybu+/BHfWG7WeSZWriv91WLBvOG+pg41GnmUM9Qtn5YV1ulXWVFG1y1bM02LxawII9X6PX/M2z9/6oPWE4qac0zVHLfSrtTRCoSSHuGCGF6hKhxlHqflrNPQ9MzT5xcyJnpq0pPZdaNdzYM71NZxPyUf7h4T2+lJo2Cz+oQQW24eNgrunE0CuGN+02xorLU4jP9u6mCXVQyeMcrpgS1GhjwmkCyCr5PZSVP1FEPeKrlYbLU9NcLkbAnRSggALIu9uxtFGSMgwcYtV4mB+No9xTY4ZCIDElPwRA2BSG4OIxYLGlX920REbbsMVfHwiSBhd2ICJuGgsdx/8h2ECLCAh6ZMgk3d6YvyAZ0vGCH7SGxQwLkiVrydoCVbuCYbvC2vpteaNfpExYbwoyX/OvdJzSHACcSnEOsHXABMRcqzwE219BX9qOZTKII98i6ZZvFHBTut199cTuSLTBjS+0dLimfY5qZduPtE7Xtuf+QePof3uni839DzSnomLDrW3usC6KhaXQw5mjfPnYMl4RjDvf9U/dwM0oUjDzmZ6KgfWASRKPO4scvHk6zgsvSUwhoJ6/L0Sh4XXH+UgIOscKTYo/1SfHhh0rndzSu14UpwVWLEWO1yvieer4akaXAllH3okR9xOrbDmj/EqyB7zwU0cQNvdi62a9/sw/38R4DYqHdmR3HXe4+bv2rfUYMopASNvJMLxJKUcCjCLPwXJO30ZHi9rGI4ssME5eAN5uDJ1mIxTKJTBZSQowFdjuctxaBLBkXMMNzvoYr9O7ip1/8dk+C2GFB2eYlu62mYLPrtOo9krDlLCTC3APZ7jA6sHV/Y2t/OdtTA4CtieBMzxxaY0F0nC+btcD289OG1HdxBhfuKqLfBMCvd58mNh9qTe3NHfqX33CUWyei8bJhH2+/n8sYArIgQTENFufXLofmuBovv6OubEzPhIXnJmrp8Zq2W/FVsLaFgXFdD4Q2a4mowdoUon1ex2iPsxdNsu5uuYSeOF806CGhJA7NnnmtCuGCJBGhWLiMq3fYv+lRMkEWBwiJjCne5vGC4nFqstPbwPWLn37hNjSyeFYS9jzVlFMe88mmjOn86aZSy8+qiFs6T6Aj2wV/R4oqYKsTh8RrDx1bp7dFnr4XscrowrrrOwRd/5eyUFvpC+p/XP3FfHfoftS133XtV556XnSMI5lMA9IuCZ73vlZYFk8O7bFp5Uj7I48iotDHFRZLQGfKU0aSUcbWXUljOszwEoQexdhMoswZqkm7u0AmRfImawjscq+2hIvIbk0VUj7ZMZIW8leQJNRL6w4UuiN/wrRiLQa/s9Y1I2mbc+f0IgnKaNmk4dJEVVqntwft9hSdjz/T8PyJNMGMHfqYSWTjrSR/xLND6c1vhEL2GS6cL5jmVez2rDXFiNvmG4ksBA0LT78UW0yhd2kXT4xtHHkM9W72Q7a/yiWssgykod97z6MkImoq+aJ5eey3I2vSdpT0mL8DeuY9eUmWosIC7QAzNAcUrLRbFVY9OqwQZluz/3aJYoVrQe1YotCkDyWKAm0tCtO5Zw5I4LQdm+C84Qpj4Ft4Oy/JNK+vF5DBI/PmPXYk0wvFXDAy+yqWD7byJ4LyM47pj/tWdr1LQH6iUXOm9L5rCckVibUhxTWCjLNzLQ5H2QhQQmkAXnh+gebvLwyN22t5N9SRQeshYOS06fqTcTC0JnFzx81yIxGWkgfEpMM2RK3sdqrF7I9hrk30J3QEw35Q9rKXpnr9ySZlXCeMlLqhZvhOq8y8VPG85ei2KKIYq9XhhKSpp3VHTo+qHQ3cr2Uyt/HUD9LeFrR3rAeJzIx2DKE5urM1CEn4/ruJo2OuBTjI2RJLLxZUT0uawdUSa6jVoNQQNU9nECf5RCEZrCBMKNhXbN1NfgMXy4fsRrJb5F6aH+x30s2DMyU4pc7sbniWWM6GEnKCPv52Z6zb129+ovrvUmEWWjBpvye6RQtMRE7KGcFYcC1pwhmmtBrlOemYOxwuNElj27TWNp2wrDB0A2S5UlP09VsBhpeuAExdoFwBJUHJwhsk3jSA11lGec/H8gQYIbsSehQmwjSjQ0uyBqYdY8LDhrXGbJaPhSAyXv/xblIkTbTNF8vU4EV6r3dJLWfUm/MsQZzgIFCmkQQOQ6InYqIRnecyKe4MS85MyPaPpt5KjnbDzoC6dgfUw/ih6oq5/pTyW9X2VgANtncnCP5Fq39ud7HBjdR8trmVSXOvp5XLJqNU49HCXL9rpdk286iU08dLmNaaWdThNRjxFoQSrd+5WIgwxDDjrnFUT1D+YLmMqila7gWrr3Cas8fjgClkbMu4egAEccDJ0xudOWtJ2y8Pm0TvW091cLuKrYSup6iOOJV+eJ0407cbDjapzm3ZZUob346qg9tVgAV0vUV1xEn1wWu1+8FCTp1jkbTb/5bp7LvFmXHMHuwmNyKBSGfXuIErvkEClgnFQoeWjaQs9z8UO1Np/0eA5IkIQCK54gkNTXAPmFIeYNVY0OiTyR8JV/jwIvlWyZc3CsY6nJj6r+0YSKk7j4u+pEhY6kdql8xONTrDEoWwIDZ30izlonI0XcL0Sc/kOw8tuw/MlLUvQbgjAnPK4M5wQDvmmQNlH7ssOOaNREvN5tLMS0ms08LhejpY6Lz4ZknGiROKzWFFiTQ9hN8hrfRkuSqmdFrFK9QJr9dsXTbLt2G9mrOMoQtVqKlImMlXnoIwzFk4Z0uQykTJhCU8kW7NNRImrJLnKy9i++amX2pDfHunNYcWU96ryZkac/Nkjak0Rqe0YPSiKJuYZuOml7YRBVAcy94aYllXK8GVohAeXQhaV2TTrM7tJWCHDZ0ZJonnKc/0p9hoUXFj29M6drWCrRPQ4wonpqWTaUq+aLVLBXOntbo0QzapTgQye2Ff81+V+OG30OzY2bAQJracD51Vluibwj6aT0iHh+GbqIkWU/G2gHPF1u8GCabRgz6GYApe9Why8Wem8jJykDLxnsCi4TmGW0cNnaXG0FhcYPrPb+pdSoo/fVMPkrf4CX0Q11DfpW5IuoU4r04hClgq26LVzoH+oFTYpFk5y3KAKbctI3axV2Tx8qIjMEG9gxPUqwag+OOfUL4GgS4vUFfMV2Tj/Ymy8X4YGz9enCgfP14MY6TpsnmdjY4cwZ5cWOt3p1dVan76Re6LpCXQGnXpU2oOWklIe658JEmUUIUZ8EQ2nIQ4wb0YglNh48UQdLHRwxB4lvZvCaUNS7tGrHDwioOWOrdeh6/uKDXtNO65o5b+vBxxZT9PfMTlau2Pk9IrPgpZcLZL7dG08S8ewnf43IPYbDYfvao5B/CbcVpSPPNqQKWWU5sQzXP6jWHz1p5FPx2uJpUbDvZOQ8KUq7QkEi0SFqSlDOalItfzNHUPbEE+1rGr1g1T2OBesxuW82k/fxum8ZlEiiqcqa85SYp42HdSC/jGntTs/kVqTOrXsia+iSzfivCO1Fvkh1DWcfhqVs8hE9d1Cji6ap3ZuzNvhutY56HgbrPxrWk2ipd8hujZAKkfQrvG4mdf/dK0vaVxqP3abfpTun67/26eqWYta5bpKmcIcLAyH63s6i35bCK7t/XWq01omMdqrzilxca2v8KL03ogp3W4cxpBNLXlPE1XTVAfs9p1X2UA48X2ya5Mar5trFs8Sy8Y9T0nyBmO8OPpML2CrJyz2Kp2bM7tLYpT5DqvRbAuXbnVquY27TaxILXX4PIf0/n0jbv4Vy80NlmuwlFWIvtu6lp6C0xocvgCg/INIneUV7nJaC+TnFXm9A3a1NpS5D8CTAPpIerCN0dWlpQ9vkFqJUCuVpz6LWkR54osV08DVI88BOnxjU529fWxC2cneHPXfqRq3bqCF0r8BVKCxDGEWRrabgQU1tCU1+t7vEb5piXhN7yEMFfVJrkWR9f6MurwBQXsM36EH0cdPlerPqNzHo06OjdX1waMPnsgPU5YhkLQRLXO9kaiN5JRUWiCekerIeht6+Xm1PzAFaTtxtPr5SWn0DQ6N3177RNSzl9qpDemH5UJ61R9yNpdfx141YVVEkpL0LivsE7e7UzL6JzCVYXWfi3iIM6mltrz8L7k5vn4X3Jzch5YD8gnFHdUra5Z2Y0Uz2rL30Qo9UikmfcXn9PDyIvP+eJzPgOf0wfj4VQzju684WCJx4fTzzxWRdCZgGykOlwyJ+8i8kVFPm1uXyPhndzBh9NMPlbnbczso6Y9U0F8kqaiZCN06PDt423+0Fat49kQRk/VNBRtQpVjj41opLmP9TRieg52wgmrKqeaweiS0s7xYyat0zQa1Ylsvn/lDRZaWbcVC9VHCsoMtx7kl1i2LxjmLfSub1q6S1Qg9FDSEYH0QGTf55thxv2vGJWxHWcBfWCcbSOeyDzZYo7wTAm6fU/Q3FE5FxAAU3R7bkzQ2eev35u1hhKpSv3po3gh0ZlcRRC98fWk7C+8BaHH3o1+IxTO5zh4KL396oTz+ev3jN0duDKyPjI/t3rXNAOPPUcrAgKLYEUCTGdWVLPT2i+K1TBZ0jGF7VzK7K2SgvG0G0LzDc1RxCU3pymtPOfUW26NJMvy3E1u6Uunz8eSZm+zFs1FaeU1p/CqK3InST2B2WyWlN+gemW0g3ZEOI4hPC2O78ifha7G5xYicv9PI5XNpnhcmxPjJcwWOKEdOd0DXIbXNgJnrUjLIbsSZLkEYZK/cdtZj4E+UB/+zcXsGfBtgHYwjl5/0Z96bf8p0UqrEMsbvboMiX39mG5Nw1fF23IC9vFo84KM6fgXkmIr1J4aJWeNuagDNJjQA5r/mi4TvPBmur1mgdNHzXbggyft5dWHYoQnhch1X1baGuD3YuUY26KrKNQrRGAm7fNfaJUswcjlzQQx3nyyNa7jKqSc6ZFPRmq/Vx6e5QuEM0F65TXsbsQGxyfD6112wr/j7CUM1iRQ5hH9U2HqSyFHHWDGuLI9yQKKSQRhL05TLuf0gfhs+IBbAL9SHhSfun4p/h+7+H+H2n97wfFUNNam1qsvXhtbswAhbApU23DjJmBK0dwoVagXX+PgqOUEa5CYCD/OBd5cANdvb9LHgDkz7by0tF13Bkr3YNz0W4L8HQrXSse8fsMpCbbNzzDsawgcgH+808bgS/rsqoCY4kCPb2zNi3U4jnXwsTBu/jwGcW71VM+3ewywdwJ9ZxQ1QqPm4vdkqtOUHBrJ82uJdby+OC8tsV464bx0wumDa+cmV8frb5fdiH5Zwi9LeF8+/hqLMvcB7PNkMokiXLrjr4iioDm0+eK7+ge8C7TFd3UksodaKm8Wgcze3nQPr624LHmpAvSmrAct9gf1Lc02CTdKtMPtrsZlOWwDl8ga3kKnaMvYSEjyrFzlsdJeWEhYuze9PxDTQHAICkkB4kOIJCU8DI3icQz+Fy/3AmPpDsLyJ4/mZPwZsmQHIQkBjy8STbQJBbpWP0i0BrFFCaPkAahLXRJln2TDcQxYoHliWsMY59w0jMYUSaISlyIhCkV46w6l/Kxt8AN46vH3Zy8lfK4DnkZxoxtGt2jBE/tYO6chCPem6f+1R2fusbeGx1n1KAeBfljYMRYPB1hlluxY0CeIi/QlMxAKE/Mimn1zrIGvhD0wvqkePY7AWMZLoUf2ysb2OrxOaMh+cBl9JQistV8rEJEpIj9ctRKAaz5KZcP/5v9Qo1de7qjFE6bMcVGhfZwb1nrerpdUhUjXU53+fbRV2H5shevEdJttow5hc9rTpJprGrzj+Kk8HFE036LrtzfTmo8mcCmhOdjvqnx/uBel/+vVA38as0u9cd7W0CatmvZkrGrd+/cY15T1ELV1dqIHAt+Vvz0AfFuZp35DS7cMwC+BLQtm2LRqHA/FR3eJXhNHlvgEEYvl64frTwgLgbf2QZQwYSFmCvm9AyIf0nrYkSxfwfS5Iiw7SMv4h/TwzQiFSTI914g0hq0NkzkUH18khmzYLRJ7IW/88d1Fv87xzfqqnx20JLVLTfN20moUYfNktcAbg8JukdKL0tjbcTWnUCdhiBeVZsVpaOrq0OXFu5/O51sFKYQ2eHp9HsBXcvhchO0g2towYXJmetwOtJl9AlGxXf69Kdtx5qBwvz2rmCNIHxK2o8kjbFrmHkthm6ozWrzTjcOZ0bZ9RtNUUGljahtz7+HSvXDAkMl8fy5lMj8fxuRMElZLzNoxwzqY2oCm9FHhKE4HpOZQx7rPK8yWMHXvhKdQTLWb3XswC1PPcGIjCf1/SqIkLrePLqKGRwhmAQ/3ktPd9f/++H8+f0KaTv4wv0P4g0SRDkqqT32jUkBClC093n/OivOl6dYb79VHXQMLuZjFAiRUjf2g0UMwFaZDUGTPk+0Wujhrk70mXXun36+13bFLECfTlueEBwQQt9/7vRtc7t/acYW2//ila6+16wDVwc3Jy9SUj+41auFeoT3L6T0xhT0B1IaLh0YcvUpXHJH8RZSmrkC9SlWOfXHEllM1lEkXUMU4eIDdi9QH43LjdSHjidoXmnfYpnrr4rh7TZRnEopj1n0rroHtE/Z/+3jrqMjcwbNb236HKiER0ByUYkpqt/9jrFbZwpk2fT8iS1vkdoWUSBo6k+dtTCJSe36oLwL9uX0GpzzAdEqqpsIOX/s1POIopnCFLv/r3fRi+m56ibhA7y4uLq8uPv36y9WHX//+6eqXn398f3V1Ocyt/6xxoOtbhMNQgJSuYD/ADM21/4Kub9c/6cGub9fvsw/14S3mwr9ve1Q84+9d9QXCXvD1UB2YBERcwQkI/KsBMrLEHXdHEbljoL/MV1wOceAyYP/5/vzd5eX55eV/nv/4fso2U/eXacBrWd8OzLffviIBARehd9MX6ZxM0bXSLjqfK2yell4TjASsQcj69nx9iyjnD40lnRUxgKLhLKaJnHE2xJ3O5LEz+9oLhsUCAlfXGZ/b9GHITRRwBt8+f3qTesZOFnrS7HVZzgBFvF7jR/Ec6BT9xkWKbGIIaGr/89KE3a8XnE/nWEyXnGK2nHKxnL7W8n1d/EUtV/4tu8bFBQpBgYiIS65b8ijgEUhXvs0QRHMIQwhRwONtlhTFqvYEqPnCSqn46u3bOJlTEshksSCPBkdvXZ6BELXrbXsknv6uybkPzVM27Zu42ZwYDXTqhlwrjg7EaV1GXKtl7trjmr85aItLyQQ8ijDbFYQnCbMbiiikZNDC65g283Kb4w2VSLfigEc/hm5JwCMEibkbtI88zOs+g1XC/63hAzem1DqGXiSUzgaoQtkHbq5NujN/R56/71uaxBeIx8Ay/5nkBUkuQbCXB40bCg86kxN1Rf5g9Jgx61FXJ6EzJ9EalhOmYAm+u8A9bihoYEaGzegKTieRClpPdvfGkg1hnB9/2kwFfg9zx3nREdjuc9Px6k6zQLpi7x4C+1Ju/VcMJdOEzwTNsbR1tHlqBlMdPmj3zF4qNndtbUItNpcSyJ8wRR+5ECBj8yCh4umbQBJMUc9bbTHfyq18y0C9JfH6p7cqiGcRRK6GI38znjNbwTFtvrNYX2loWLanWaHaZxf1TABxEa9w+6X15pnuidYgtmvdTZIbFkKt8unUNsu3lYMmGzI2A6k96ZZ7P7tyAHwaWpudqcIDqT0CIlfekpKRAeZngIVhB0kzoFzCbIMbOz0fBG0FobYRsxzJzHsYVsatSHQasDMgfVDLLZvJ5kr5o4FOcfTFLCBYnwJmjaMP5gVhZk6qqaCjg86ADEFdzf88Gep3fVBTLNUMB74TmKOCTnH0waxtzVF2kG6TR9jShzgL0sJR3dfvn/4i7qtm5And1yQ8Rfe1fXZRT/f12M5fE+qW/5GtjrhyLXFwluDekrgvt2Z0vRnYMlUV+ymXS9jzqC2xCZJp5K9m8BwNpMsn/Wrlz4TFiZqlH4oIpcRfPtCjmPXmLuWVsBKpeqlYIkHITtnvUCj2mS+XEJ5nz1CDlISzagK5TcYN6bSdS3zznhEOjHdUCbWbhnuM+4EVj0YoXxJtuapDtLSn2JPnT78m0lVxGup9JOA5hN0Thf56ViNU0IaGCfDViuwzB5ny9S1NKR9PeJHMOadQyw90ItFfM1cvAmuZcHoy1CqRfUrF/DOSvthWKfprwRDwsbWiMBvWQIeeUfKSfxzWNqud68lXgATnCt32swl2jmYDj1w7t9APpWNBdyadP3VWAZT/j/8OAAD//+0wZoU="
+	return "eJzsfWtvIzey9nf/CmIWi9gLuTOe3QT7zocXmPUgCwGZ2Bh7sgscHNhUNyVx3U12SLZk5dcfFJvsK/smteR21vAgmbGt4lMXFovFKvISPZHdRyR3UpHoDCFFVUg+ond3+hvvzhAKiPQFjRXl7CP6/2cIIZT+EEmFVSJRRJSgvpyhkD4RdH37DWEWoIhEXOxQIvGKzJBaY4WwIMjnYUh8RQK0FDxCak0Qj4nAirKVQeGdISTXXKgHn7MlXX1ESiTkDCFBQoIl+YhW+AyhJSVhID9qQJeI4Yh8RLHgPpFSfw8htYvhlwVPYvMdBy/w5zb9mOXEMz8ojlAcBfgm2XftOE9kt+UiKHy/YTT4c78mFqwWI/HQT1wg8oyjWMtfJIxRtnrn1Ub348SLfVUgl44vfRyS4GEZclz84ZKLCKuPKCbCJ0wNgJd+AK8I4kutVkUjgmRMmEKLHVJFFijzif5OiKVCZEOYypHD1/2aSrTBYUIQlYgBqJD+TgJLiSXRggg7ks8FkdqMqEICsxWxOjVMge28R4qjK7eApMJCPQDgwudSOQVl5XVIAUig7ZqwEr9brNUmFAnq46eW/wI6MlOuCJT7fhJTEiDKUIThP+nvnH/99OXCK82dzAUMmjqP6ccekc+ZwpRJFHIfh4Za3xkF+q4Jqzh6hywMikugU4ACpmQQoCUXCIOhrkLwQkJLDKMoCRXVnzOQc31WHQ5CbiaKjNDi/M9ZCTlbVX7Qwg38AejXgCqdGDmq0m/+Cd1mFiCdgBRXOKzYYqc9tttkD/T3MCrCvqIb4nAbJXU7YSeSiNOj7vJ6lGlgSMbYJ14PDhT1n6STh8EWASsGjnjC1IHAjJlPUbhPRDASDuFiRAF3SngAOkZ9Mj3z5QyFfHsZC8oFVTu7SBDZh5uTSXpflDQIJyhzjSr7WDPw0xlyD0B8i6maoCwZAmDonDMUUPl00Y+P04l2KD7x2/SELInYUB92YxB+rzELQvjHGotgCxs4yhQRIolV53wUv53OqkdDLflSvSa9AN79OHxp3eyBXBEcTk8zlCHKNjxMmMJil7oAE+huqFAJDvUntmsapnvk9S4GkUguaoNtsSzJi6s1EXYJ5MKrfeDTBtMQL0KCOAt3iDP0jdHnXoI8mQG8PgFFPCDhQ7r1ckqonuzpISQQi6ZsN3XoBiDhFCFoEP1MWfKcfbANG47IUZDhiOyJa/27E5BrQvaAA3tE5CdCwBTzQ+4/7QcL6DzQYFxZxeudpJDmAOpo/hmgmaxCRFdrZf5LnomfKJImGWJt3ILgQM7QmkDCLIJPqzVmtUE4Izap4QFZjwaPyMcMyTU4e/AiEkeV3zGsPu4nKMvT6MICWLnE5p874WWqi5ODkkJ+nNTyUqA9SLjKw/I8wNOYvtOV/owFkWZDBPpec6k8vRoxzi7zDGqNXr5YSbSlYYjWeEMQRhF+plESmSwsX6LHq/fv/4z+ou1WPmraNWKFTG2RLg7BkHdI4Sewxjy3yxRH2Pf1SpCGYpuqn0IuLAClwSl3J7leQ7YI3bB6slHOamR3PNETHQRXoC/zI5SVIFgRAd9gqdyKZwczRJforzWyWsf6BAYr9OP7PwM0OJYxie3Mj8SJZ6X5mFrPgqCrvzcqx6rAfP6VZ5X+WHmb15sR+aMkIP7QG/z/gq3y24ZznA3nC51C9RAkxIJEopRtvaLOg5Bow5nf/Au8UEa2RP9P6Jc8MuoVn0AkNfUgJfu8kw2zxk+WkaEL/TQZOWi1n6huei/5E8W/x7o/TU5GX/xfFZv7RgDTZPK1hgFTk2afKGBmS9akq2RNb64dvGd/gT9/Qve1hPtrKRY55VHB0FX8ZNgOWphPJ8Hea+3pIO2xfJ4M3Ogr4ksj33eROxnuSa9bViZQX0L5QccPQKJw/gD/RPObrCC1ZyX8/mcUA48Is9pzGeCr4erW7MGQOWgnKkkExeOfrVoI32l7oDg0yzOcalCJIrxDjCu00KXRGxqkyzgOw1zoNZomR9/BEByEePrAw8nNfpNHR0qFCAMGkcjnkOEHk5GJDxUByyQMdx34toIqcnSAepQ9EQJz3mKniOwL0IaCrg/tAV6T0TDKsOHMRp/Ip0dctDoUqsSBkviKC0PJHPpSY2kMYSmTCHSnfwtJ+ruOQ3+4+tBLgy8vINCxImwcGVliPcVUo9otNtCCV2kBaRXaHoKJaAh7Ap+zQJrlzbgVGL1r4QUZkJeDqIfvwkj5sQG6MQYcVvT59zcFgG0geSyPiBFwQBwbC74SRBYwWQiEKcHj3SERQx6bmO6ZOs3hUYApswjJw4KqMUWUEUZAGIRUh5vDePn9fo7X4JxB3QdOW1S49uQx52Hml//2/v/9eFZlY0lDUmqU2kvRjzmZWoFK/qMx6lQypp3CH3/hgBAs3boX5A0lIQwlLBZ0Q0OyIkF65kBZOoznhB6QDfXJyIVuGUYgW+m5fPw+IJvv4adXj05EMO4RoADZKhTyrP726KE5Q5JHBPlYElAN+hdlAd9KdHOnDTYt5bFlGo8Jy4T+iLCEQhxoAlTcrM0sjZsoZ2kTpoJlgG9JgM7Js4fIsyKC4VBv0uWF5xSCrsB+iDllalxZaMLg8zXtmm7cKtGzpa9td4Go+HzKeABHg2mFTDonZ7At9deZyDFEvAvKUqHyZQpohpY8DIiQMyR3UUjZk5zpTXpq0w0GzzUyOa5UDdFqMVnBy2i5uxEtBSF9hXsMx9HuIADdw6gGUApUgby1AZpLzRiCG5IOG/qiOVBieqyi3NqllUhy2k0WDDgQ3ssHCRXQOdb6XyzypYR1+qyKeUg4YEwqpVSICIqz1GSN8GolyApnaSPIYOgZXCkEzT96YASxf+Lgl3wq5fNGoiVPWOA5x9ImfcCUPqEHR79wfVRvFuQ2fiCgbLDa5vlYNZ+6dInURpCLFgU877RvU22Hi2+Vdxf6DlO3X6mmYPDqRKsChBn5YgBh8C6ALp9/OoQaHDrXQOMwkVqmhdDNolwdVkv/z/wmhVnhDpctVWvENjSg+FJGFHGBBPcj+LvT6yyI6ut3KAvI81lPkXaIaw60wCGAGwBWzHquS+rdowfjhWHfGP0NSuMDwhRdUiIKSHQJNvr2bf4ZTAL98uv88/wT+uftN+nGNe5u6FbwIPGVpl0A5R56Q1jAxXiD/6rplWSRWhKCmzqihiUi9qm3SOSoTSq313O0SCSaf+4UQqJoSH/Xa8XJSxjudOsPX2ZVC+lCZvCiLZbAxc50HcFOKj1KbzAlc03NRDky99Tk+kj5I8AWpFbBSEwKuZU97Z1PF/fCaGboTluyCjhlYP4N1olh+GBBORm+nyAi2EN+L2e5TlvVvTpUQpqrKdQlkT53TKBvj4SSJm7xupB3YLvPSRcwzSB9HZCVIESi63REN7SYb4nwAoG33hYrNRquW6CLgC6z5/0Wlx6nDU1II6qOAUcTroqpBc4SM0/G5CVMDka1SJeYlUAXM9mQjbf9dSlUJyemSMIrTqNTTfMvtRmTFArrDLJZWqJhM1dw+pLXdqR3zBWUZNkKOQ4OiXShWAVoILwhAhrvnIFs7+3zu6t3Tnm2WAn8iLLVwxLDSelHaPEbJt2fC/BBS/nFfBFliSKeG+kPU0L6g8EqG8BeTQrtlQOuGzeUkXovZRMlzClgFNCsGqZfUWudnR+mwE6mgTE4upoES1dj8aR/6V1fvz5iu/pZFUq62Bzinx9TErVzUxOEjXBmerIkfmGz0KHBl9ojdMA64fmQVkqhpnRZ2ChkJwvFW2YDTqQu+aPMD5Mg+2Wfs7QMa7GzeVMf++v0utna0ItkuSRConNJbJrVBkzYh1JVr5Jvc8pJDxCcQlJaStd6OMswZxZ49utOkFM6gellfakCnHCr/qQHkk+amhUaCAMMQqdTvSrHFedR+XFFpC41tuq/ywZ6MFNgqCDPwkSZKySI8dhwtQSUIoCl62tpFkRtibkYwsw7Fuh/5UezRkPOO0PgT/U3UUBiwoLsMObmLj3hj+AyjIAoTEM5Q7EOr5G/Jv5TdixWmGiPXrfQX+hEw4jb7ZfmCgoufBz6SajP7hYY1FKQRbmKslzX8YVEeWmYPvX7HrZI30ckomzJZ3VZwBcXxQH1x4rg9GFB7vkyT0eXZepZpYhFUJ0N6dcNQzd3/0ZUM4qRTKKql7Y2RJm5F9ia0E12ijYznye/1Se20SLPzMJ8vK9ZNLi3Xi6u2831NJK6u8O1WdrAi+VDbnHc2+fFgizp80f07n+05/7fd2ctkPXiqanksRWEU1QquEBbpxhIYGvlAIdVrb7k31qzUU9lJFfA1RV0nWLamlO0nJm+ptQ05rEB6wzuMLwv5REznzUM7kRnatIoeAucEbXl4umsa2a2DP9oaBS2N+Y7xdah0l329ue6UW1ZOaoevvsZeMKYVegRtR644b1fO8D3aSHiiTrZZqi829UEkCy9buGESNmLIhTEJ3RTzPw6UYIgY+w/EdUb6CAwhnZPgR0PiciQ9BQMZR4RgovjiCUlbRocU0SUrTogga5OhUkSFnQjoswLBI9jEhwFEWU+j+C41+pOH6ptCewX0mF7SOyYAHmiVrwdYOXZGxxu8a6qP4TeQ+j0GYstZToU/8fdZ7QgPk4kMQExBOCCxFyoPAfY3CxqBWCc64NMogj3yLo1lem0SOWLWZF0cAhYFEerkC9wmLl2He1Tteu5/tDY+4tTXXzxH+KrYQqb36b1i0RI52DKH3O0++uO4ZJgzOG+fe4e7iGEDrNxx/yZKtI+MPWjMRmdX39xcGoHgyyGTA57P+jW0ICWmzAsNUqc397NL9IyLdlQroLlU3q1pv40lMVhCg3FJk4TRPJE+OTQijhf7828MSuf8oqnlLj7yDX9We3E1YEtxmo9HrpbrNZlfLWOLcG5qvzKmhKBhb/e7c9MnDiZqBpUHxasYXEGRyXl8dzqLiKB5p/aD9vg9IDUUniFFQKzVGnTUd2u3Uy0MVJk5up9w66u986u3+6upwzqcjBszuwZb4D4hgh09R56kaHD12vl78dXyt+PPfn76/tXyuBf3/fksCkJ1RozDkRsEk5gahq0rsuKqC94I0QLDxruT+kNwlBfNU6DsNkZIEmjJFSYEZ7I4k0Pb77hzTe8+Yaj+obGoop2rzAgZKlmOtsn9HSjFjcfb87pzTm9OacjOadJBy7m3PItdnmLXd5ilxeNXUp3LLY7hQFxy/zGO+s3kacbs8xv3hzSm0N6c0gnckiTjlfmN295lrc8y1ue5fR5FgvLtB2edXmDFgSPhkahtst8B67WwAFWeIauixdtQDGA2aqY7znPEA+v7cIhxeWjXITgLC/vEnV8NKIruDgZrF+JhDhHhPpRMt6J4H3eHQrqU9V7896JhDHKVu88J5qYBnuyX/9kH+7jAwbE8PDtvuOu9h93tdeIfgSXzxPnoHtrGpZhuF8vwiy4BPK68hYKkKXCQpV7hdM2Hzh1V46WBCxWSaR7HSSJscCmjsZ5tQ9dMXhIFy/4hnxEH97/7e9OluEKyz0mFHxs39nkb4OBo1m1QiUONFwGVOhrlXd7jE7Ypn9JR1rn8XCgBRC2oYIz0BzaYEGhmlg2W4GnPwSO1HUPdd6Swpm+aOIfd59naddF6mpv7tC/3Y7Dj5Pj1Nxf3367lDHx6ZL6xWL7Qqe7d9YvzOt8S6ZzBe1QSMvDDgUdtD8yUwWrNwKeLpA7EtrshWEAmzYqSApdTtp6jL9oknUVqDv06hV2dYdcPRkqV6VX3tZY7IrsGE6zhuIkDvSaOVeFokRJIxpiYSpqnMP+GUbJBFkcIKAyDvEur0pUPLYu2z6uYeoTO4Xb8C7Uq5KwrpGqMwpf5VLQwrvahqKrpxq8ElVIYLaqlYUbpqGv6z0or/yCdlXEpkZ0Cn7B/cBTFXA64Y6JV4/Qrt4WeYL3eHDcjJ+jC+qh7xB0gGlr3+e2Qtxi3ZMFdcDemQvXIWfB6a0sQ9ejrvWua71yXI/ZqeG2dokhMtYWYB8dMjutorjXWBb7E9PmzErj7DWP4Aqh6zUWK4LOC02z2XzIKGNVvDAqwgyviEBrrB9OieA2s8AWjaYbGYvkwnoO01JqLoqgskkruXyFlM5elFMJ+SuRNICpdUcUuqO/E6/iLRxy576fxPByCqTsMM0O7M6/fvpy0akRPxGweUIm6EWSKH2r/yzvyG2V1vTWoMEiauRP1zC/kCXosQMXM0npFb0i3mWwny+ro/gJOlvs73BhYkGbV0k7NcBS9HzV34R1Ot80LB3Pj+nNg947mP3E2M6Rx4QN1VZJDuWWlWVZBhIev8ouYzPK8BrB6LvSPHhf7iBILQbCl8reyMaQ6oaeRU9OkpahKm0fM2ir99cQVgUV9uFoDrOdXn+7RLHGIjiSKID0sURRoA2i0FXyC4IEtq+bQlW9d+bi23dNvL2npO0eggmkeZX5W3imTp8vC5eCwtmInpQoIiCMun7Mp+wEhsx71jdVC6Zg3U0JyTWNYXEoJlLTLyiABXEYylqAMnMb+sxWy6+UXNBuwRs41WmzKbnzJwOsaf5ZBxjQoc/1cVPKjYTHtrhPcXaXsw5wQMx10cLXXHdawNuSkn2nELZU55+zC/yK1DU1zbe9y8JJFS9aGkSLInK0l4woJKBu+0oa2k3Mt2WySPdT30mkg7z0yZJBItOjnUJohu7DhghJ+eGriaEDjshCzqaYvb7MzLCMXDO4OGkE5HIoA9Tpx0muKCThSqQEsoew4TMP42i4uvbCXAhuJrmT5qf0M3bx4PCIFJx0gf7UlmeJ5WwoeP7l+qc7HR58vXdbB/xcKgwX4wAY+3xiuENLTEVOyjjBWHCQNOUMh2F1l2eko2+KM1sTu7e1N/pYhWXXz2wJXa2Vh77eF2A46QqCQ7NRroCS0JyLsytHnWkArNqWpfyFFTPBQMjmoi4UJAKCeIxWdEN0DxflgeckN9f9SShhQdboJdGvH2ZF0vAYKBEr6/AiWOtNUss4dSdpvS75cYJ9X+l3mXAQUJgUM0B0mSuquDKsONPH/L82PVXYvjJ0rg59nF9txlR7/lqhdfrevSC4J23W8jfUBzdSc/nmViYh49FErt0p1XhMYW4+tNJs03wRl96reKyJTKcTb0Eo0eaD2QtRhhhm9gy8Jyj3Zrn7dubesHriaMkejwOmkLEt4+oBkIgjKg8WOn3WYquKhimRiKOKrYSuH5xTqtINrxOnSacfT6kmbNlHpQbbMQVYQNcXzimV6oJ31obRX0rPBBa1e/h7q7PvEqfHyeqlKpVHkEFEa75FgqySEAvYWjaSSrn/rvjQI8Q/9sIBieSaJ2EAyQ4I30Lu49LBQadMfku4wscXyX0lX94omKxespFUFs5jYxs6lhQJs3EkhGSpqtE5liggS5rmThpJloyj6apXl/R0vvPYsvvE9OVZcI6QHhHoa3LMGQ6BwDwLoLL7K2xg3kg0z2aYoKsmVq9wuG4HC0wU30jWjxMjFP3KGooSqcA4P8AVTWu6WhdTOq3iFWrC89WIqCUwbZqvVO4xUYXyBDydGpFJCANidBiISKV3yZQlPJFmzjUSpqyS5ytP4jXekCYv11NMOg43VnNsMeVPHxpXA1NUbHAotdMpTRiYFGUX00hWT20tChLiuPS2TA/W1VpwpUISnFwIYCuySasLSExk2OAAVb+dM2ukW3y3WHHt2+1tWWpNdilV8rzGiX4hEXJrfNnqlwruDqZ4SUOQ3VkTKpBeCy/2lPjRJ2Z+nA3ChsXOXF9UmaIXhXU0V0gj2WZFzRAuJP4WO6NTufkwSDDsJQVTiKpHk4t35vpAy2VVB+QYspa3c+sMtceFdwY5uzCyPjD10NAY1x9xDXVTF0x3S5zNAVpuW0bsYq93U8ywzUm/GoBG0eQ9jOWml15stPa+vCAbeW9LLzbaW1xekI9CC0svRto6WXp7uRG40DjQHVzpZd1Pe/Tf0QZ3hKnf2QBXnfmVdrg3R/DmCP5LHEEJl6aMfoI+GffUPmvCZI6yzpqQNE3r0vDmKBXSNpBaMUdf3tmwWfh2xHXKIy5Tay/P9rTDnlzel4ury8G2PdvNah+yiLs1ezREl239Gr2rOQfwm3FaMjzYI+FKLSd4dBzmshmmt/Ys+nS4mhXz8Yud6WlImDKVllDjkjDfljIgzGzDcBYepAX5GNJLYBv6WT+o074uvY7eKrR0B9R+/jbM4jOJFE04M18YCUU8IMPxja3UrP/C6MzRljVzKbLcFeEcqS9LRzHWcfhqNs8MQw8u7dHWyUzrPO2duRhuYwbq2Nq4b9JGsclniJ31Z+Uo1jUWP4faF9COk1bWnG23zvbbvgbYYx2oZc0yW+UMEeyvtUYrq3ojWV3q2BmitbY2DYxYzcPTptgYKvPegtajBa3Dg9OIRJ4urmnsWOrlVrv6VQYwXnyk1ZRJLXaNdYvntsHoYjDDEX6eDtNrkpVzZqyTYHTO9TScJNd5LYJed40QLI/oPL9tAg6yG0nq9xUvTOOfXckLUoMsV+EoK5F9F3WwmyWmYXL8AoNyB5E5yqt0MmpFovOKTi/QtnYtRf4lYLnofYSpSfPtiY3Fsse3cPBF5HrNw6ATJ1QyvAxQGHkI0tM7HQM0ws9dODvB6177duRNMUENVt3ACyX+AilB4T2n7ABKu2wUkg1pyuu1xQ5FRkK+bfydHvKvMZKbapNci6ODvYw6fMEA+4wf4edRh8/Nqs/onEejjs55NGz0hycahqNDAKJEDEACC8moKIAgCRwI6n9x4YlIJLdTiwOhhTR91Ni2lxtXpnGmzynrd071FtHGS430xoyj5HbiMWQeTRmZwcarLqxScNlI+HBhTT7stGV0xuCqQmtvizhKsCm3ryX6ktvXE3/J7eQisB6QJ7TvqHpdPbMbKZ7Xpr/eodR3Is28v8WcbzHnW8z5OmNOF4ynqWYczXnD0RKPBcanGjVWRdCZgGykOlwykw8R+bIin7awr5HwXuHg0zSTj09HzD4C7Qd4LHqKrsKIQUMD6vod6kWyXBIhHTeeDWF0qq4hY5kENY4dPqKR5iHeU9vDa/ATRlhVOdUcRpeU9t4/ZtKaptOoKrK5/8q5WWhlPS2+qD6FPuAgv8RyQFSaWTNMzG9abpeoQOhhpCMC6YEI+1Dy+IAZZ5OZQJ8YZ7sI2hWzZIs+wtPNJxpv2qNyKQiUsoS7Sx2WnP/89Vuz1YRUqtL99FG8lOhcriMSXbjupOwvPDh8PLHw4BrFywX2n3Lt58L5+eu3jN09uNKyPjE/t7Bq6oHH1pF9iJ76OHxIZ+zDtNaLYjVM1rubvZ+f+szsrZKC80wXhOYOzVHEJbfTlFaec+ott0aSZXnuJzfKXpsnpczhLkozr5FsbUZmvzlEUi/gNpsl5XaoThntYR0RhiTitDiGS6DzwPQyhYjM/wCpbHbFjUT3kk6MV+RhiZNQ7S2XfZvhwbdiu1MxEbiNtJWgqxUROvkbt531aOgD7eE/XDy8Ar4j/B8uOhhH777Ab71L/wnXtcdwn3F20avJkGBfJbrJAC58VfzMSTG94hAOrc0LMvrGv4AWr0LtIV+QrHyg7GRi1QPq/8JtEoqbWZW3WcCNowlcP7EHHzxRL8IITwo710NZabsA/9Sur3FZNBWF4BkEZjJ9/gutkxUBicgLKChv0gVq9Jb7rRlCygcYeTJSy41EE4O/4EyQTnkN4hfUMBle77IT/j21lzCyob6Cp7KmFjpr5+9jBje46DvJ/BDTiAS9OLVcLsKn2qPR3aevJaD/CLn/hOY3b8X/xyr+d9+L3cqLbqCZjMWmqfU8i6hJpr55SQSEZorrihwdJkDn40IbVQCTr3Fw1HKCNUhMlO8tpD0FMP/+Bt4ZSG/P0dd5gbTN7QxheADj0E0lSf4OhblKB1azmIfUdzx23Ph6/EBHYAD8+gGcgXmNYIYEiUPsw/ja1/yRvcP/sXduvY3jVhx/96cg5qUtmihxZnZ2m7fMZNsGnXaCuTw7tEQ7RGRRFakk3k9f/HmRZYmSJUt2giKLYDFIbJ4fD2+Hh4c8r2l28OlwXP85Xuo2/RTtbZMBdnag701RK6jDLHe0SgVcvCyJe7iiQUL7aG5+qeOAT2Id712ctyex3p7EensSq+FJrHEeuTre+3Zx/DaE34bwSEP4/2NQOgS7MwhkvlrRrTv+iisk9r+1/uLv9Q94B2iL7WqLcHv/4vxusztxuTdt4rV7Ibes1IxhUYZQOmkfmm0abtRoC7pvX7bB1rhc1ng3HLZiI5FsvHJOYX1YeBSz0UFQaC8KGTOWHkIlruB+NErgDGx8GFNuL5Y/xGrOx28hU2wvkojR8VWCQpsoyI36kySPDBGGScwfWGxdl1yZlGw4qaQZmef6aRhYEHj4JuQ0JpKr3LpIuCIruraHUv6qPdEH5onHH149V/ApNjyN6iZfkbpwIXKTrF3EyJulkxeSf5mjM5vsTTbjHwT9sNgpzR4OMMpMsWOhnyAngs1kxjKEkiCkxOQca6hXnjwk4ikZv2JFXUpvZOM+rK5piBQjSG2oPfoq4+wRdm2GAztL5MfF7TNas1EqC/4P/4carfKtSn1GCKxEFUrPx1mxaBfqktNMupnZ7etoq7L9bKXrxPG6WEYtYdAoX7uaWTSOfKcPWyg81DdnX4NJVWhGeTTE7qp8v78Vhf9PfNX1H3K0oODnP3TzrKFxWgVeqRjOuRxPrg7r4Wpt54kOBL4rfwMAECSOtjBXCbdV4JUv10k4A7ZIxqP4bC/Ro3BiCj8h3LB8u7q5JjTL6BpzSMaiPIloooiXDhEaLh520nEw7GArTX02CMsIaZF/SAtfCy81EtYByaWe2NqY9KH4SEwllehiIyujRTyC/Vk0vnx70W+nfD2+6mcHLU7trUfz9urVCGIDY0afNIVZIqWXUs+34/acjZJM4eVOgzuROq6OTM8vPpziBMIhtOFhfLLoUHwiKSNqGxu+MxjV6yTcQetIJcsqc5d/bSpWnDlTdNIJuuwjsEnlrDR5hEULM3VSWqbqFd3IiQWNZrq3DZGGUqxZ10HmYHFuLewhMp8Pr6XM56fdJeKDM8mT0C8zqsPUBOrQR0VXqRMY60MdlEzCe5osWUButlCw8Nm1B0EG1jJEImMTCceVJHm6/Xx0mZo9s3AWimiQnr7f/OPzP78gdXzENon5LSFSn2NTYjc6XoqMSUUzJUeaPvz3ujY6Q3inTlJk5SKONFdiRRWuYMRrmLZmFEdEN6je0CP6AJTlO13uvysYuE9wbenAQ2ieEsmXCQAoWcQ01U4eSxCQb+y/OUd+Oyfn4v0v2AvFdCtqsaKkPFb7tpNfS6bMrd6GNFliUdbXiXZ9smeKnOPkTuY6vdrdCbljz1ydotXvwH4HTYtc3fn5bUiCJxSpV2ezL19A4XlaI30yKfSVLE5JTIoQm32ARV60POHKhL4PnzPK8wXKrT/8WJf6yJII0dYZk2zvFkZ7RkxHOPehKPRUKdu/Ntak2tXOLYBUShFyZA81adNLjVMpxbcYlrmQ7q4lnXXj3FAjxEPDnfJWO8k2mrj9Cnd3+fauRoHgL9EJ10/EB7qzDpJamv9QYo+G2bAkTD2J7MEjqrlz1EFMIUU/a3yVqqlDlIn4sS8uaWlNr4yVqFLcy1HH47LydpGJXA1F84ptivcvy22rSz+pNpi7JHNSFSjhk1KTXT20ZUnElXdTirt8klnDkwW+zUFnd1TEM9bsFKExr70+gSWyGDhB0/dXfGmCLC+JyvLNiPJCLOiKx+s9CUA6RDjS1MYBr04VKPaS1H5tDY1LMv3bRXAeXARTGBcX5+fTy/PrT79dXn36/fryt1/ef7y8nFa+2tK8+PkCDnJzS2gU4STcXhhB4uc5Q2aRm9vHDxB2c/v4sfhQUUxL3ZDd0ls7Txcv6ndxsQ8+RG06pJcpYyuh2CtQ+DcNMrLGbe2OonJbge46x3GZl8pvwBVgv348vZhOT6fTX0/ffwySp8D+JQjFKujHfPvjG25MiCzyLvqZa5OA3CB/LhFzHBqxiDxy5ADHuVJ1tBM0YSzEQ552UwNTcTTDBeiZSNg++ti7+rCC2WKBGVfHFaenxn0dCb0L/TP78eX6L84ytrpAo5mHWZH0fSXqMaYxnbM4IH/f7IOwxWYEpf11CrOCvFsIEcxpFixFTJNlILJl8A76fVf+RbUyes9grhGKjERMsWzF7eGOKZ6EAvtXva2mCWGrOYsiFpFQpGtXDxRQLVh/4V6p9PLsLM3nMQ9lvljwZ81RfLitEaGWGcsykfVowR2d83cUZ5tw7qppcjIXbaJ7oO1uxD4Fs9Gbl9g6F4KUR17W5jWu+Zu9ljhXTChWK5rsC+FxAu5HsYpinrDxmk1nDrR1I1tFt3KwZ7anJuCXyvXdtCH6QE6GoHeX8H+rv+BGl+4O0Qgbn/XoCk6osV6bY+O+678Tz9+HhsYhWx0uVDv72V7bwQRiHVyDLGjaEPhSJe7Qka90P04SrA+i5ljwQZRB2rflyN2/ZL676DugHJjWYTPdhgNP57DWyILBLIUIbfzIiQ9DhemY7YId2P5tsyPrU7NCdu29Oyjs39tPT5a3ks7hc0LmFH8WSck1TWNsjWCe6cvI+p6gdajhF0TyP1hAPossYzKFzwoXpWxOKsl0UNkZZswzuZZnCVNnPH38cKbCFM8I2Rgi+kh5jIkUgSw6ZisgjUqsj7T2Vu2on92t29bCZUCRpfe0uhPu2tIdafFzZR35ppGsWFzmDFPXtM36ba1B0xwydgXcfLJb793mlQPwAa1tnqni4bRmHnN5XztoPgDg5gy6JLaXNsNYSDZ7olwdk7ZCiDlitiGZEd8JxzY3jnFeBXYB0oVarpOZZMmLQzuOrswZCx9fAzM4ujAveKLbpOoKOjp0AdKHuur/eTHqiy7UOJCd0fDhpaEdRxdmzDVHWUHakS2Gj9iR5lE66Wro7GCCgfPzeoti0s24eYXm68/rFzVf8+g1mq8/r8cwX49t/DVRt/zDoZqooUmVr6rGFqI7U8Td9tOg9m0QBMOYrmI+ZX0JwSBHQZQbB0mwkl2PBtzwcV+t/Jknaa5m7kMrHsfcHz6wo2Xg5v363dWVJ1tFBZNqReAHkjt1v0eg4hexXLLotEiDzqTkIqk6kNt0zKPx3IrQyubNEgvjlSoZVePJvUrKRyOxWPIkqotoeTxpYJ2vP+XShhZrn2MXDXgOYQdS4OtOcrk3eMX7Y0UGEFw5cZ1DUxyKObWpFGhI5kLEjCZ9SfA1ffUnNDMTtTLaNeIxhQa2iMsYuBW+1coQirF7Rak1ihjImhQnP2Y0YlnXubaD9EwIRW67zQmmjWY9j1x3QKA7lI8F7Zl0cfu7CjQhhBBCCJn8bwC54t/l"
 }
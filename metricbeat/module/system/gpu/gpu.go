@@ -0,0 +1,260 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gpu
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	backendAuto   = "auto"
+	backendNvidia = "nvidia"
+	backendROCm   = "rocm"
+
+	vendorNvidia = "nvidia"
+	vendorAMD    = "amd"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("system", "gpu", New)
+}
+
+// Config for the gpu metricset
+type Config struct {
+	// Backend used to query the GPUs, `nvidia`, `rocm`, or `auto` to use the
+	// first one whose command line tool is available.
+	Backend   string `config:"gpu.backend"`
+	NvidiaSMI string `config:"gpu.nvidia_smi.path"`
+	ROCmSMI   string `config:"gpu.rocm_smi.path"`
+	Processes bool   `config:"gpu.processes.enabled"`
+}
+
+var defaultConfig = Config{
+	Backend:   backendAuto,
+	NvidiaSMI: "nvidia-smi",
+	ROCmSMI:   "rocm-smi",
+	Processes: true,
+}
+
+// collector retrieves the metrics of the GPUs of a vendor
+type collector interface {
+	// devices returns the metricset fields of each GPU
+	devices(ctx context.Context) ([]mapstr.M, error)
+
+	// processes returns the processes using the GPUs
+	processes(ctx context.Context, devices []mapstr.M) ([]process, error)
+}
+
+// process is a process using the memory of a GPU
+type process struct {
+	pid        int
+	name       string
+	memoryUsed uint64
+	// device is the fields identifying the GPU used by the process, if known
+	device mapstr.M
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	collector collector
+	processes bool
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The system gpu metricset is beta.")
+
+	config := defaultConfig
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	c, err := newCollector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		collector:     c,
+		processes:     config.Processes,
+	}, nil
+}
+
+func newCollector(config Config) (collector, error) {
+	switch config.Backend {
+	case backendNvidia:
+		path, err := exec.LookPath(config.NvidiaSMI)
+		if err != nil {
+			return nil, errors.Wrap(err, "nvidia-smi not found")
+		}
+		return &nvidiaCollector{path: path}, nil
+	case backendROCm:
+		path, err := exec.LookPath(config.ROCmSMI)
+		if err != nil {
+			return nil, errors.Wrap(err, "rocm-smi not found")
+		}
+		return &rocmCollector{path: path}, nil
+	case backendAuto:
+		if path, err := exec.LookPath(config.NvidiaSMI); err == nil {
+			return &nvidiaCollector{path: path}, nil
+		}
+		if path, err := exec.LookPath(config.ROCmSMI); err == nil {
+			return &rocmCollector{path: path}, nil
+		}
+		return nil, errors.Errorf("neither %s nor %s were found, at least one of them is needed to collect GPU metrics", config.NvidiaSMI, config.ROCmSMI)
+	default:
+		return nil, errors.Errorf("unknown gpu.backend '%s', valid values are %s, %s and %s", config.Backend, backendAuto, backendNvidia, backendROCm)
+	}
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(ctx context.Context, report mb.ReporterV2) error {
+	if timeout := m.Module().Config().Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	devices, err := m.collector.devices(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error getting GPU metrics")
+	}
+	for _, device := range devices {
+		if !report.Event(mb.Event{MetricSetFields: device}) {
+			return nil
+		}
+	}
+
+	if !m.processes {
+		return nil
+	}
+
+	processes, err := m.collector.processes(ctx, devices)
+	if err != nil {
+		return errors.Wrap(err, "error getting GPU processes")
+	}
+	for _, p := range processes {
+		fields := mapstr.M{}
+		if p.device != nil {
+			fields = p.device.Clone()
+		}
+		fields.Put("process.memory.used.bytes", p.memoryUsed)
+
+		rootFields := mapstr.M{"process": mapstr.M{"pid": p.pid}}
+		if p.name != "" {
+			rootFields.Put("process.name", p.name)
+		}
+		if !report.Event(mb.Event{MetricSetFields: fields, RootFields: rootFields}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// deviceIdentity returns the fields that identify a GPU in the events of its
+// processes.
+func deviceIdentity(device mapstr.M) mapstr.M {
+	identity := mapstr.M{}
+	for _, key := range []string{"index", "id", "name", "vendor"} {
+		if v, ok := device[key]; ok {
+			identity[key] = v
+		}
+	}
+	return identity
+}
+
+// runCommand runs a command line tool and returns its standard output
+func runCommand(ctx context.Context, path string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", path, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// addMemoryUsedPct adds the share of the memory of a GPU that is used
+func addMemoryUsedPct(device mapstr.M) {
+	total, _ := device.GetValue("memory.total.bytes")
+	used, _ := device.GetValue("memory.used.bytes")
+	totalBytes, ok := total.(uint64)
+	if !ok || totalBytes == 0 {
+		return
+	}
+	if usedBytes, ok := used.(uint64); ok {
+		device.Put("memory.used.pct", float64(usedBytes)/float64(totalBytes))
+	}
+}
+
+func toString(value string) (interface{}, error) {
+	return value, nil
+}
+
+func toInt(value string) (interface{}, error) {
+	return strconv.Atoi(value)
+}
+
+func toFloat(value string) (interface{}, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+// toPct converts a percentage in the 0-100 range to the 0-1 range used in
+// percentage fields
+func toPct(value string) (interface{}, error) {
+	pct, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return pct / 100, nil
+}
+
+// toBytes returns a conversion to bytes of values in the given unit
+func toBytes(unit uint64) func(string) (interface{}, error) {
+	return func(value string) (interface{}, error) {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return uint64(v * float64(unit)), nil
+	}
+}
@@ -0,0 +1,190 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gpu
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestParseNvidiaDevices(t *testing.T) {
+	devices, err := parseNvidiaDevices(readTestdata(t, "nvidia-smi-gpu.csv"))
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	device := devices[0]
+	assertValue(t, device, "index", 0)
+	assertValue(t, device, "id", "GPU-8a3a0e6e-5cb6-5f3d-1e2b-2c4f0d7f8a11")
+	assertValue(t, device, "name", "NVIDIA A100-SXM4-40GB")
+	assertValue(t, device, "vendor", "nvidia")
+	assertValue(t, device, "pci.bus_id", "00000000:07:00.0")
+	assertValue(t, device, "utilization.pct", 0.87)
+	assertValue(t, device, "memory.utilization.pct", 0.42)
+	assertValue(t, device, "memory.total.bytes", uint64(40960*mebibyte))
+	assertValue(t, device, "memory.used.bytes", uint64(31540*mebibyte))
+	assertValue(t, device, "memory.free.bytes", uint64(9420*mebibyte))
+	assertValue(t, device, "memory.used.pct", 31540.0/40960.0)
+	assertValue(t, device, "temperature.celsius", 64.0)
+	assertValue(t, device, "power.draw.watts", 287.51)
+	assertValue(t, device, "power.limit.watts", 400.0)
+
+	_, err = device.GetValue("fan.speed.pct")
+	assert.Error(t, err, "unsupported properties shouldn't be reported")
+}
+
+func TestParseNvidiaProcesses(t *testing.T) {
+	devices, err := parseNvidiaDevices(readTestdata(t, "nvidia-smi-gpu.csv"))
+	require.NoError(t, err)
+
+	processes, err := parseNvidiaProcesses(readTestdata(t, "nvidia-smi-apps.csv"), devices)
+	require.NoError(t, err)
+	require.Len(t, processes, 2)
+
+	assert.Equal(t, 41235, processes[0].pid)
+	assert.Equal(t, "/usr/bin/python3", processes[0].name)
+	assert.Equal(t, uint64(31200*mebibyte), processes[0].memoryUsed)
+	assert.Equal(t, mapstr.M{
+		"index":  0,
+		"id":     "GPU-8a3a0e6e-5cb6-5f3d-1e2b-2c4f0d7f8a11",
+		"name":   "NVIDIA A100-SXM4-40GB",
+		"vendor": "nvidia",
+	}, processes[0].device)
+
+	assert.Equal(t, 41872, processes[1].pid)
+	assert.Zero(t, processes[1].memoryUsed)
+}
+
+func TestParseROCmDevices(t *testing.T) {
+	devices, err := parseROCmDevices(readTestdata(t, "rocm-smi-devices.json"))
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	device := devices[0]
+	assertValue(t, device, "index", 0)
+	assertValue(t, device, "id", "0x2a4b8c1d9e3f5a60")
+	assertValue(t, device, "name", "Instinct MI210")
+	assertValue(t, device, "vendor", "amd")
+	assertValue(t, device, "pci.bus_id", "0000:03:00.0")
+	assertValue(t, device, "utilization.pct", 0.35)
+	assertValue(t, device, "memory.utilization.pct", 0.12)
+	assertValue(t, device, "memory.total.bytes", uint64(68702699520))
+	assertValue(t, device, "memory.used.bytes", uint64(17175674880))
+	assertValue(t, device, "memory.free.bytes", uint64(68702699520-17175674880))
+	assertValue(t, device, "temperature.celsius", 48.0)
+	assertValue(t, device, "power.draw.watts", 154.0)
+	assertValue(t, device, "power.limit.watts", 300.0)
+
+	assertValue(t, devices[1], "index", 1)
+}
+
+func TestParseROCmProcesses(t *testing.T) {
+	processes, err := parseROCmProcesses(readTestdata(t, "rocm-smi-pids.json"))
+	require.NoError(t, err)
+	require.Len(t, processes, 2)
+
+	assert.Equal(t, 1984, processes[0].pid)
+	assert.Equal(t, "rocm-bandwidth", processes[0].name)
+	assert.Equal(t, 25632, processes[1].pid)
+	assert.Equal(t, uint64(17163091968), processes[1].memoryUsed)
+}
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2WithContext(t, getFakeNvidiaConfig(t))
+	err := mbtest.WriteEventsReporterV2WithContext(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2WithContext(t, getFakeNvidiaConfig(t))
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 4)
+
+	assertValue(t, events[1].MetricSetFields, "index", 1)
+	assertValue(t, events[2].MetricSetFields, "process.memory.used.bytes", uint64(31200*mebibyte))
+	assertValue(t, events[2].RootFields, "process.pid", 41235)
+	assertValue(t, events[2].RootFields, "process.name", "/usr/bin/python3")
+}
+
+func TestNewWithoutTools(t *testing.T) {
+	config := defaultConfig
+	config.NvidiaSMI = filepath.Join(t.TempDir(), "nvidia-smi")
+	config.ROCmSMI = filepath.Join(t.TempDir(), "rocm-smi")
+
+	_, err := newCollector(config)
+	assert.Error(t, err)
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("_meta/testdata", name))
+	require.NoError(t, err)
+	return content
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	v, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, v, key)
+	}
+}
+
+// getFakeNvidiaConfig returns the configuration of a metricset that uses a
+// fake nvidia-smi that replies with the test data
+func getFakeNvidiaConfig(t *testing.T) map[string]interface{} {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake nvidia-smi is a shell script")
+	}
+
+	testdata, err := filepath.Abs("_meta/testdata")
+	require.NoError(t, err)
+	script := filepath.Join(t.TempDir(), "nvidia-smi")
+	err = os.WriteFile(script, []byte(`#!/bin/sh
+case "$1" in
+  --query-gpu=*) cat "`+testdata+`/nvidia-smi-gpu.csv" ;;
+  --query-compute-apps=*) cat "`+testdata+`/nvidia-smi-apps.csv" ;;
+  *) exit 1 ;;
+esac
+`), 0o755)
+	require.NoError(t, err)
+
+	config := getConfig()
+	config["gpu.nvidia_smi.path"] = script
+	return config
+}
+
+func getConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"module":      "system",
+		"metricsets":  []string{"gpu"},
+		"gpu.backend": backendNvidia,
+	}
+}
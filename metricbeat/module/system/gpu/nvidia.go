@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gpu
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const mebibyte = 1024 * 1024
+
+// nvidiaQueryFields are the properties queried to nvidia-smi, which reads
+// them from NVML, and the fields where they are stored
+var nvidiaQueryFields = []struct {
+	property string
+	field    string
+	convert  func(string) (interface{}, error)
+}{
+	{"index", "index", toInt},
+	{"uuid", "id", toString},
+	{"name", "name", toString},
+	{"pci.bus_id", "pci.bus_id", toString},
+	{"utilization.gpu", "utilization.pct", toPct},
+	{"utilization.memory", "memory.utilization.pct", toPct},
+	{"memory.total", "memory.total.bytes", toBytes(mebibyte)},
+	{"memory.used", "memory.used.bytes", toBytes(mebibyte)},
+	{"memory.free", "memory.free.bytes", toBytes(mebibyte)},
+	{"temperature.gpu", "temperature.celsius", toFloat},
+	{"power.draw", "power.draw.watts", toFloat},
+	{"power.limit", "power.limit.watts", toFloat},
+	{"fan.speed", "fan.speed.pct", toPct},
+}
+
+type nvidiaCollector struct {
+	path string
+}
+
+func (c *nvidiaCollector) devices(ctx context.Context) ([]mapstr.M, error) {
+	properties := make([]string, len(nvidiaQueryFields))
+	for i, f := range nvidiaQueryFields {
+		properties[i] = f.property
+	}
+
+	output, err := runCommand(ctx, c.path, "--query-gpu="+strings.Join(properties, ","), "--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, err
+	}
+	return parseNvidiaDevices(output)
+}
+
+func parseNvidiaDevices(output []byte) ([]mapstr.M, error) {
+	records, err := readCSV(output, len(nvidiaQueryFields))
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing nvidia-smi output")
+	}
+
+	devices := make([]mapstr.M, 0, len(records))
+	for _, record := range records {
+		device := mapstr.M{"vendor": vendorNvidia}
+		for i, f := range nvidiaQueryFields {
+			if !available(record[i]) {
+				continue
+			}
+			value, err := f.convert(record[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing %s", f.property)
+			}
+			device.Put(f.field, value)
+		}
+		addMemoryUsedPct(device)
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func (c *nvidiaCollector) processes(ctx context.Context, devices []mapstr.M) ([]process, error) {
+	output, err := runCommand(ctx, c.path, "--query-compute-apps=gpu_uuid,pid,process_name,used_memory", "--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, err
+	}
+	return parseNvidiaProcesses(output, devices)
+}
+
+func parseNvidiaProcesses(output []byte, devices []mapstr.M) ([]process, error) {
+	records, err := readCSV(output, 4)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing nvidia-smi output")
+	}
+
+	byUUID := make(map[string]mapstr.M, len(devices))
+	for _, device := range devices {
+		if id, ok := device["id"].(string); ok {
+			byUUID[id] = deviceIdentity(device)
+		}
+	}
+
+	processes := make([]process, 0, len(records))
+	for _, record := range records {
+		pid, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing pid")
+		}
+		p := process{
+			pid:    pid,
+			name:   record[2],
+			device: byUUID[record[0]],
+		}
+		if available(record[3]) {
+			used, err := toBytes(mebibyte)(record[3])
+			if err != nil {
+				return nil, errors.Wrap(err, "error parsing used_memory")
+			}
+			p.memoryUsed = used.(uint64)
+		}
+		processes = append(processes, p)
+	}
+	return processes, nil
+}
+
+// readCSV reads the output of nvidia-smi in CSV format, checking that every
+// record has the expected number of fields
+func readCSV(output []byte, fields int) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(output))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = fields
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+	}
+	return records, nil
+}
+
+// available returns false for the values reported by nvidia-smi for the
+// properties that are not supported by a GPU
+func available(value string) bool {
+	return value != "" && !strings.HasPrefix(value, "[") && value != "N/A"
+}
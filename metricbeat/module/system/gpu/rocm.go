@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gpu
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// rocmFields are the keys of the JSON output of rocm-smi and the fields where
+// they are stored, several keys are listed for the fields whose key changed
+// between versions
+var rocmFields = []struct {
+	keys    []string
+	field   string
+	convert func(string) (interface{}, error)
+}{
+	{[]string{"Unique ID"}, "id", toString},
+	{[]string{"Card series", "Card model"}, "name", toString},
+	{[]string{"PCI Bus"}, "pci.bus_id", toString},
+	{[]string{"GPU use (%)"}, "utilization.pct", toPct},
+	{[]string{"GPU memory use (%)", "GPU Memory Allocated (VRAM%)"}, "memory.utilization.pct", toPct},
+	{[]string{"VRAM Total Memory (B)"}, "memory.total.bytes", toBytes(1)},
+	{[]string{"VRAM Total Used Memory (B)"}, "memory.used.bytes", toBytes(1)},
+	{[]string{"Temperature (Sensor edge) (C)", "Temperature (Sensor junction) (C)"}, "temperature.celsius", toFloat},
+	{[]string{"Average Graphics Package Power (W)", "Current Socket Graphics Package Power (W)"}, "power.draw.watts", toFloat},
+	{[]string{"Max Graphics Package Power (W)"}, "power.limit.watts", toFloat},
+	{[]string{"Fan speed (%)"}, "fan.speed.pct", toPct},
+}
+
+type rocmCollector struct {
+	path string
+}
+
+func (c *rocmCollector) devices(ctx context.Context) ([]mapstr.M, error) {
+	output, err := runCommand(ctx, c.path,
+		"--showuniqueid", "--showproductname", "--showbus", "--showuse", "--showmemuse",
+		"--showmeminfo", "vram", "--showtemp", "--showpower", "--showmaxpower", "--showfan",
+		"--json")
+	if err != nil {
+		return nil, err
+	}
+	return parseROCmDevices(output)
+}
+
+func parseROCmDevices(output []byte) ([]mapstr.M, error) {
+	var cards map[string]map[string]string
+	if err := json.Unmarshal(output, &cards); err != nil {
+		return nil, errors.Wrap(err, "error parsing rocm-smi output")
+	}
+
+	devices := make([]mapstr.M, 0, len(cards))
+	for card, values := range cards {
+		index, err := strconv.Atoi(strings.TrimPrefix(card, "card"))
+		if err != nil {
+			// Not a GPU, like the "system" object with driver information
+			continue
+		}
+
+		device := mapstr.M{"index": index, "vendor": vendorAMD}
+		for _, f := range rocmFields {
+			for _, key := range f.keys {
+				value, found := values[key]
+				if !found || !available(value) {
+					continue
+				}
+				v, err := f.convert(value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "error parsing '%s' of %s", key, card)
+				}
+				device.Put(f.field, v)
+				break
+			}
+		}
+		if total, err := device.GetValue("memory.total.bytes"); err == nil {
+			if used, err := device.GetValue("memory.used.bytes"); err == nil {
+				device.Put("memory.free.bytes", total.(uint64)-used.(uint64))
+			}
+		}
+		addMemoryUsedPct(device)
+		devices = append(devices, device)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i]["index"].(int) < devices[j]["index"].(int)
+	})
+	return devices, nil
+}
+
+func (c *rocmCollector) processes(ctx context.Context, devices []mapstr.M) ([]process, error) {
+	output, err := runCommand(ctx, c.path, "--showpids", "--json")
+	if err != nil {
+		return nil, err
+	}
+	return parseROCmProcesses(output)
+}
+
+// parseROCmProcesses parses the processes reported by rocm-smi, in the form
+// "PID<pid>": "<name>, <number of GPUs>, <VRAM used>, <SDMA used>, <CU occupancy>".
+// rocm-smi doesn't report which GPUs are used by each process.
+func parseROCmProcesses(output []byte) ([]process, error) {
+	var result map[string]map[string]string
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, errors.Wrap(err, "error parsing rocm-smi output")
+	}
+
+	var processes []process
+	for key, value := range result["system"] {
+		if !strings.HasPrefix(key, "PID") {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimPrefix(key, "PID"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing pid of '%s'", key)
+		}
+		parts := strings.Split(value, ",")
+		if len(parts) < 3 {
+			return nil, errors.Errorf("unexpected format of process %d: '%s'", pid, value)
+		}
+		used, err := strconv.ParseUint(strings.TrimSpace(parts[2]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing VRAM used by process %d", pid)
+		}
+		processes = append(processes, process{
+			pid:        pid,
+			name:       strings.TrimSpace(parts[0]),
+			memoryUsed: used,
+			device:     mapstr.M{"vendor": vendorAMD},
+		})
+	}
+
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].pid < processes[j].pid
+	})
+	return processes, nil
+}
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package pressure
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgcommon"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// resources that report pressure stall information
+var resources = []string{"cpu", "memory", "io"}
+
+// containerIDRegexp matches the container IDs in the names of the cgroups
+// created by the container runtimes, like docker-<id>.scope.
+var containerIDRegexp = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("system", "pressure", New)
+}
+
+// Config for the pressure metricset
+type Config struct {
+	// Cgroups is a list of glob patterns, relative to the root of the
+	// cgroup v2 hierarchy, of the cgroups whose pressure is also reported.
+	Cgroups []string `config:"pressure.cgroups"`
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod     resolve.Resolver
+	cgroups []string
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The system pressure metricset is beta.")
+
+	config := Config{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	for _, pattern := range config.Cgroups {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid cgroup pattern '%s'", pattern)
+		}
+	}
+
+	sys := base.Module().(resolve.Resolver)
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		mod:           sys,
+		cgroups:       config.Cgroups,
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	fields, err := getPressure(m.mod.ResolveHostFS("/proc/pressure"), "")
+	if err != nil {
+		return errors.Wrap(err, "error getting pressure stall information")
+	}
+	if !report.Event(mb.Event{MetricSetFields: fields}) {
+		return nil
+	}
+
+	if len(m.cgroups) == 0 {
+		return nil
+	}
+
+	root := m.mod.ResolveHostFS("/sys/fs/cgroup")
+	paths, err := matchCgroups(root, m.cgroups)
+	if err != nil {
+		return errors.Wrap(err, "error listing cgroups")
+	}
+	for _, path := range paths {
+		fields, err := getPressure(filepath.Join(root, path), ".pressure")
+		if err != nil {
+			// The cgroup may have been removed since it was listed.
+			m.Logger().Debugf("error getting pressure of cgroup %s: %v", path, err)
+			continue
+		}
+
+		id := filepath.Base(path)
+		fields.Put("cgroup.id", id)
+		fields.Put("cgroup.path", path)
+
+		event := mb.Event{MetricSetFields: fields}
+		if containerID := containerIDRegexp.FindString(id); containerID != "" {
+			event.RootFields = mapstr.M{"container": mapstr.M{"id": containerID}}
+		}
+		if !report.Event(event) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// getPressure reads the pressure of every resource from the files in
+// the given directory, named after the resource and the given suffix.
+func getPressure(dir, suffix string) (mapstr.M, error) {
+	fields := mapstr.M{}
+	for _, resource := range resources {
+		pressure, err := cgcommon.GetPressure(filepath.Join(dir, resource+suffix))
+		if os.IsNotExist(err) {
+			// Memory and IO pressure are not reported by the root
+			// cgroup, and controllers can be disabled in any other one.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		stalls := mapstr.M{}
+		for stall, data := range pressure {
+			stalls[stall] = mapstr.M{
+				"10":    mapstr.M{"pct": data.Ten.Pct},
+				"60":    mapstr.M{"pct": data.Sixty.Pct},
+				"300":   mapstr.M{"pct": data.ThreeHundred.Pct},
+				"total": data.Total.ValueOr(0),
+			}
+		}
+		fields[resource] = stalls
+	}
+	if len(fields) == 0 {
+		return nil, errors.Errorf("no pressure stall information found in %s", dir)
+	}
+	return fields, nil
+}
+
+// matchCgroups returns the paths of the cgroups matching any of the patterns,
+// relative to the root of the hierarchy.
+func matchCgroups(root string, patterns []string) ([]string, error) {
+	seen := map[string]bool{}
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			path := "/" + strings.TrimPrefix(strings.TrimPrefix(match, root), "/")
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+// +build linux
+
+package pressure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	fields := events[0].MetricSetFields
+	assertValue(t, fields, "cpu.some.10.pct", 1.53)
+	assertValue(t, fields, "cpu.some.300.pct", 0.43)
+	assertValue(t, fields, "cpu.some.total", uint64(8776907952))
+	assertValue(t, fields, "memory.full.60.pct", 0.03)
+	assertValue(t, fields, "io.full.total", uint64(251732216))
+}
+
+func TestFetchCgroups(t *testing.T) {
+	config := getConfig()
+	config["pressure.cgroups"] = []string{"/system.slice/*.scope", "system.slice/*"}
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	id := "1c8fa019edd4b9d4b2856f4932c55929c5c118c808ed5faee9a135ca6e84b039"
+	container := events[1]
+	assertValue(t, container.MetricSetFields, "cgroup.id", "docker-"+id+".scope")
+	assertValue(t, container.MetricSetFields, "cgroup.path", "/system.slice/docker-"+id+".scope")
+	assertValue(t, container.MetricSetFields, "cpu.full.10.pct", 11.9)
+	assertValue(t, container.MetricSetFields, "io.some.total", uint64(1154482))
+	assertValue(t, container.RootFields, "container.id", id)
+
+	service := events[2]
+	assertValue(t, service.MetricSetFields, "cgroup.path", "/system.slice/ssh.service")
+	assertValue(t, service.MetricSetFields, "cpu.some.total", uint64(2765))
+	assert.Nil(t, service.RootFields)
+	_, err := service.MetricSetFields.GetValue("io")
+	assert.Error(t, err, "missing resources shouldn't be reported")
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	v, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, v, key)
+	}
+}
+
+func getConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "system",
+		"metricsets": []string{"pressure"},
+		"hostfs":     "./_meta/testdata",
+	}
+}
@@ -35,6 +35,10 @@ type Properties struct {
 	ExecMainCode   int32
 	ExecMainStatus int32
 	ExecMainPID    uint32
+	// NRestarts is the number of automatic restarts, available since systemd 235,
+	// it is nil if the property isn't reported.
+	NRestarts *uint32
+	Result    string
 	// accounting
 	CPUAccounting    bool
 	MemoryAccounting bool
@@ -55,6 +59,7 @@ type Properties struct {
 	ActiveExitTimestamp    uint64
 	// Meta
 	FragmentPath string
+	ControlGroup string
 	// UnitFileState
 	UnitFileState  string
 	UnitFilePreset string
@@ -81,6 +86,18 @@ func formProperties(unit dbus.UnitStatus, props Properties) (mb.Event, error) {
 		},
 	}
 
+	if props.NRestarts != nil {
+		msData["restarts"] = *props.NRestarts
+	}
+
+	if props.Result != "" {
+		msData["result"] = props.Result
+	}
+
+	if props.ControlGroup != "" {
+		msData["cgroup"] = mapstr.M{"path": props.ControlGroup}
+	}
+
 	//most of the properties values are context-dependent.
 	//If things aren't running/haven't run/etc than a lot of the values should be ignored.
 
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/mitchellh/mapstructure"
 	"github.com/stretchr/testify/assert"
 
 	_ "github.com/elastic/beats/v7/metricbeat/module/system"
@@ -52,6 +53,7 @@ func TestFormProps(t *testing.T) {
 		ActiveState: "active",
 		SubState:    "running",
 	}
+	restarts := uint32(3)
 	testprops := Properties{
 		ExecMainPID:          0,
 		ExecMainStatus:       0,
@@ -62,6 +64,9 @@ func TestFormProps(t *testing.T) {
 		IPIngressBytes:       50,
 		IPEgressPackets:      100,
 		IPIngressPackets:     50,
+		NRestarts:            &restarts,
+		Result:               "exit-code",
+		ControlGroup:         "/system.slice/test.service",
 	}
 	event, err := formProperties(testUnit, testprops)
 	assert.NoError(t, err)
@@ -85,10 +90,36 @@ func TestFormProps(t *testing.T) {
 	}
 
 	assert.NotEmpty(t, event.MetricSetFields["resources"])
+	assert.Equal(t, uint32(3), event.MetricSetFields["restarts"])
+	assert.Equal(t, "exit-code", event.MetricSetFields["result"])
+	assert.Equal(t, mapstr.M{"path": "/system.slice/test.service"}, event.MetricSetFields["cgroup"])
 	assert.Equal(t, event.MetricSetFields["state_since"], testEvent["state_since"])
 	assert.NotEmpty(t, event.RootFields)
 }
 
+func TestFormPropsRestarts(t *testing.T) {
+	testUnit := dbus.UnitStatus{
+		Name:        "test.service",
+		LoadState:   "loaded",
+		ActiveState: "inactive",
+		SubState:    "dead",
+	}
+
+	// Systemd versions older than 235 don't report NRestarts.
+	var props Properties
+	err := mapstructure.Decode(map[string]interface{}{"Result": "success"}, &props)
+	assert.NoError(t, err)
+	event, err := formProperties(testUnit, props)
+	assert.NoError(t, err)
+	assert.NotContains(t, event.MetricSetFields, "restarts")
+
+	err = mapstructure.Decode(map[string]interface{}{"NRestarts": uint32(0)}, &props)
+	assert.NoError(t, err)
+	event, err = formProperties(testUnit, props)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), event.MetricSetFields["restarts"])
+}
+
 func TestFilterEmpty(t *testing.T) {
 
 	filtersBad := []string{
@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package datastorecluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/vsphere"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("vsphere", "datastorecluster", New,
+		mb.WithHostParser(vsphere.HostParser),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet.
+type MetricSet struct {
+	*vsphere.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The vsphere datastorecluster metricset is beta.")
+
+	ms, err := vsphere.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes one event per datastore cluster (storage pod).
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client, err := govmomi.NewClient(ctx, m.HostURL, m.Insecure)
+	if err != nil {
+		return errors.Wrap(err, "error in NewClient")
+	}
+
+	defer func() {
+		if err := client.Logout(ctx); err != nil {
+			m.Logger().Debug(errors.Wrap(err, "error trying to logout from vshphere"))
+		}
+	}()
+
+	c := client.Client
+
+	// Create a view of StoragePod objects
+	mgr := view.NewManager(c)
+
+	v, err := mgr.CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"StoragePod"}, true)
+	if err != nil {
+		return errors.Wrap(err, "error in CreateContainerView")
+	}
+
+	defer func() {
+		if err := v.Destroy(ctx); err != nil {
+			m.Logger().Debug(errors.Wrap(err, "error trying to destroy view from vshphere"))
+		}
+	}()
+
+	var pods []mo.StoragePod
+	if err = v.Retrieve(ctx, []string{"StoragePod"}, []string{"name", "summary", "podStorageDrsEntry", "childEntity"}, &pods); err != nil {
+		return errors.Wrap(err, "error in Retrieve")
+	}
+
+	for _, pod := range pods {
+		reporter.Event(mb.Event{
+			MetricSetFields: eventMapping(pod),
+		})
+	}
+
+	return nil
+}
+
+func eventMapping(pod mo.StoragePod) mapstr.M {
+	event := mapstr.M{
+		"name": pod.Name,
+		"datastores": mapstr.M{
+			"count": len(pod.ChildEntity),
+		},
+	}
+
+	// The summary is not set while the datastore cluster has no datastores.
+	if s := pod.Summary; s != nil {
+		var usedSpacePercent float64
+		if s.Capacity > 0 {
+			usedSpacePercent = float64(s.Capacity-s.FreeSpace) / float64(s.Capacity)
+		}
+
+		event.Put("capacity", mapstr.M{
+			"total": mapstr.M{
+				"bytes": s.Capacity,
+			},
+			"free": mapstr.M{
+				"bytes": s.FreeSpace,
+			},
+			"used": mapstr.M{
+				"bytes": s.Capacity - s.FreeSpace,
+				"pct":   usedSpacePercent,
+			},
+		})
+	}
+
+	if entry := pod.PodStorageDrsEntry; entry != nil {
+		config := entry.StorageDrsConfig.PodConfig
+		event.Put("sdrs", mapstr.M{
+			"enabled":          config.Enabled,
+			"automation_level": config.DefaultVmBehavior,
+			"io_load_balance": mapstr.M{
+				"enabled": config.IoLoadBalanceEnabled,
+			},
+			"recommendations": mapstr.M{
+				"count": len(entry.Recommendation),
+			},
+			"faults": mapstr.M{
+				"count": len(entry.DrsFault),
+			},
+		})
+	}
+
+	return event
+}
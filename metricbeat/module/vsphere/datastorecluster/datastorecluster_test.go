@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package datastorecluster
+
+import (
+	"context"
+	"testing"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestFetchEventContents(t *testing.T) {
+	model := simulator.VPX()
+	if err := model.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := model.Service.NewServer()
+	defer ts.Close()
+
+	createStoragePod(t, ts, "pod0")
+
+	f := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(ts))
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+
+	require.Len(t, events, 1)
+
+	event := events[0].MetricSetFields
+
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event.StringToPrint())
+
+	assert.EqualValues(t, "pod0", event["name"])
+	assert.EqualValues(t, 0, event["datastores"].(mapstr.M)["count"])
+}
+
+func TestEventMapping(t *testing.T) {
+	var pod mo.StoragePod
+	pod.Name = "pod1"
+	pod.ChildEntity = []types.ManagedObjectReference{{Type: "Datastore", Value: "ds1"}, {Type: "Datastore", Value: "ds2"}}
+	pod.Summary = &types.StoragePodSummary{
+		Name:      "pod1",
+		Capacity:  1000,
+		FreeSpace: 250,
+	}
+	pod.PodStorageDrsEntry = &types.PodStorageDrsEntry{
+		StorageDrsConfig: types.StorageDrsConfigInfo{
+			PodConfig: types.StorageDrsPodConfigInfo{
+				Enabled:              true,
+				IoLoadBalanceEnabled: false,
+				DefaultVmBehavior:    "automated",
+			},
+		},
+		Recommendation: []types.ClusterRecommendation{{Key: "1"}},
+	}
+
+	expected := mapstr.M{
+		"name": "pod1",
+		"datastores": mapstr.M{
+			"count": 2,
+		},
+		"capacity": mapstr.M{
+			"total": mapstr.M{"bytes": int64(1000)},
+			"free":  mapstr.M{"bytes": int64(250)},
+			"used": mapstr.M{
+				"bytes": int64(750),
+				"pct":   0.75,
+			},
+		},
+		"sdrs": mapstr.M{
+			"enabled":          true,
+			"automation_level": "automated",
+			"io_load_balance": mapstr.M{
+				"enabled": false,
+			},
+			"recommendations": mapstr.M{
+				"count": 1,
+			},
+			"faults": mapstr.M{
+				"count": 0,
+			},
+		},
+	}
+
+	assert.Equal(t, expected, eventMapping(pod))
+}
+
+func createStoragePod(t *testing.T, ts *simulator.Server, name string) {
+	ctx := context.Background()
+
+	client, err := govmomi.NewClient(ctx, ts.URL, true)
+	require.NoError(t, err)
+	defer client.Logout(ctx)
+
+	finder := find.NewFinder(client.Client, false)
+	dc, err := finder.DefaultDatacenter(ctx)
+	require.NoError(t, err)
+	finder.SetDatacenter(dc)
+
+	folders, err := dc.Folders(ctx)
+	require.NoError(t, err)
+
+	_, err = folders.DatastoreFolder.CreateStoragePod(ctx, name)
+	require.NoError(t, err)
+}
+
+func getConfig(ts *simulator.Server) map[string]interface{} {
+	urlSimulator := ts.URL.Scheme + "://" + ts.URL.Host + ts.URL.Path
+
+	return map[string]interface{}{
+		"module":     "vsphere",
+		"metricsets": []string{"datastorecluster"},
+		"hosts":      []string{urlSimulator},
+		"username":   "user",
+		"password":   "pass",
+		"insecure":   true,
+	}
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetVsphere returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/vsphere.
 func AssetVsphere() string {
-	return "eJzsl8+OmzAQxu95itHedx+AQ6Vqq20vaSttt9fIgSG4MQyyh6zo01e2IeKP002KaS/1ASnYfN8PzHxD7uGIbQInUxeocQPAkhUmcHd6dmfuNgAZmlTLmiVVCbzbAAB0s1BS1ih7mUaFwmACB7EByCWqzCRu6T1UosShhR3c1naxpqbuzgRcxkJDsUywMExnubDkRdluKiAyvo9+TDGGKPY4muhJjti+ks4mc7/hseNDzzTX7Q1zY/XjWT5JhaY1jCXMhHvPVNQildw+MLFQD/uW0QQJFFWH2+y/WUVwikA5cIHBjbEjJ10KTmBuP+PMNWJUzCeNGJ2yMZhFpXwxmK1DWaccZDSpUJjtckViuuAa1hp1ihVfS9stP8/OgqEgw0syYXL9v46DT2T4chKkdeM3pyx+xnyBHr++gKxgO1Ed2vociOfrU+AKY1fY8XxdWb9hW2JJeq1i3Tpxax+SfrtKO7i1cjkS3jpxvBSuQn4lfdzZX2GwPyraz14WxrLn7yCpuRGqFGkhq0XfLxeVbk8tG3sPcnqfS4NrIjgys4eVsrJvJpMsXyujv/ttgK3fh8txTRHfsanplxq1YFkd4Nl/yv1vGus1jfcnIZXYq5s6x6FBw6v1D8rhozVYnNSO1VXoiqg+HaK0vPiPddT4oj1X9wrGhx32weWsaWOYyp3vFEFC2v/A2T8Bf3K3IM4enTEEjP9qr/4VAAD//9YITTs="
+	return "eJzsmU9v4zYQxe/+FIM9tcCuWhTYiw8Ftlls00OyRdO0R4OWRhYbiiOQI6fOpy9IUf4jS3ZcUU4PxRoLWLLe+2kovqGYD/CEmzmsbVWgwRkAS1Y4h3frB3/k3QwgQ5saWbEkPYcfZwAA4SyUlNXKXWZQobA4h5WYAeQSVWbn/qcfQIsS9y3cP95U7seG6ioc6XE5FNoXywQLy7SV65cclA2nekQO7wOgH2Mfxf1/cKIlecLNM5msc+4Ej/t8bpmOdVvD3Dr9eJZfpEK7sYwlHAm3nqmoRCp5kzCxUMlyw2g7Qu7aOSjSq8vsf3eK4BWBcuACewfGfXIypeA5HNsfceYGMSrmF4MYnbK2mEWlfLSYTUNZpdxRaAbcpkJhtsgVCf4XrBWaFDW/ljb8fHt21oXeCqSqtoxm1uW9IB92czGIwTfuq1ghVJR9CyWykalN+uJjifzWAdJCDwbJtlY2SanW3Atx+TN4X5dLNG5EdwYg9eH4tnS9YG+UNr1M/9nUGU97zfSJRHvlFDpD3U2jfW6bGZugFkuF3RncIC+JFAp9Ge2fBXKBBh5CDn3+7QGkheBz2SzzhKJmKoWzWyhco4oYRJiLWvEB6s4NvFtb8LU0XAsFpUgLqdG+h1Jod4BMew1mwzchaaFIZIulUEKnOF3Zf/nuKzgnaJykXo2rvsGUyhJ15kdgqhiuUGcOdX8kOs7DiH4QpyLbJ2qMZl2IgiyPaeKd6996fX9Lloc7clrVzWqrLF4iFdvn282vjy4b7oqXQdtmYR/Pt1nWv8LYr9Tj+fqOeca2xJLMVP3vzou7cvdJn294AW6qpU8kvGlWOmPhNPIzmaeF+2bjTdr7RhYOZVvT0LxC7xqTVYNKl6eWi71EZvFq4INLZsNmznGirGyXCZ0s3wHE9f0jrEbumnEYjmuy05l+rdAI9m272Zv5v2lM1zQ+rYVUbhF3zjukn+8cqxotT9Y/KIefncHopPasBU2LeksRSJunKX5ZDxpftLr6Dhgfdr8PjmdNa8tULppO0bm6IaTlX3j0Ut0cXIyIsxtvHFpUL9rb9Gor9KgO/fDpvn2ti7X/F+SSo4AfVYebc7t/191k84Xbvhlf/hxfcYctFuoVtteiol5nb+1VyKc21lwrm2o/wmu3+zhhXp6AKFAoLjYTYRisyPgVoIDg1FTPsuC6f0T9DSS1vh7ZHhGQ35vkQugWuBcyk/ZpqhGsio2VqVCNCaRKyNL/ScyX7gTOVBXrAD1LLqjmUB+Q1tYDk9PfwIRD2QP2CqqUyoo06smm4M4AfKvYbat6TMrPzk2DdqPTpFnD2OiAQReW6CZAY1YY0vIFs1M8MTvBT04LFOYMTIcMA4l6ogmQXqF1Tkkp/o4EeCtXhXt52YnDWqgaQ3Q0M3JoVN9DbqiE74EJfvj4cfbPAG0QCWY="
 }
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vsan
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// healthStats aggregates the health of the hosts and disks of a cluster.
+type healthStats struct {
+	healthyHosts   int
+	unhealthyHosts int
+	disks          int
+	unhealthyDisks int
+	components     int64
+}
+
+// physicalDisk is the part of the QueryPhysicalVsanDisks response used by
+// this metricset. The response is a JSON object indexed by disk UUID.
+type physicalDisk struct {
+	LsomObjectsCount int64 `json:"lsom_objects_count"`
+	DiskHealth       struct {
+		HealthFlags int64 `json:"healthFlags"`
+	} `json:"disk_health"`
+}
+
+func (s *healthStats) addHost(status types.VsanHostClusterStatus) {
+	if status.Health == "healthy" {
+		s.healthyHosts++
+	} else {
+		s.unhealthyHosts++
+	}
+}
+
+func (s *healthStats) addDisks(content string) error {
+	var disks map[string]physicalDisk
+	if err := json.Unmarshal([]byte(content), &disks); err != nil {
+		return errors.Wrap(err, "error decoding physical disks")
+	}
+
+	for _, disk := range disks {
+		s.disks++
+		if disk.DiskHealth.HealthFlags != 0 {
+			s.unhealthyDisks++
+		}
+		s.components += disk.LsomObjectsCount
+	}
+	return nil
+}
+
+// resyncStats summarizes the objects that vSAN is resynchronizing.
+type resyncStats struct {
+	objects     int
+	bytesToSync int64
+}
+
+// parseSyncingObjects parses the response of QuerySyncingVsanObjects. Objects
+// are indexed by UUID under dom_objects, and each of their components being
+// resynchronized reports the pending bytes in a bytesToSync attribute.
+func parseSyncingObjects(content string) (resyncStats, error) {
+	var resp struct {
+		DomObjects map[string]interface{} `json:"dom_objects"`
+	}
+	if err := json.Unmarshal([]byte(content), &resp); err != nil {
+		return resyncStats{}, errors.Wrap(err, "error decoding syncing objects")
+	}
+
+	stats := resyncStats{objects: len(resp.DomObjects)}
+	for _, obj := range resp.DomObjects {
+		walk(obj, func(key string, value float64) {
+			if key == "bytesToSync" {
+				stats.bytesToSync += int64(value)
+			}
+		})
+	}
+	return stats, nil
+}
+
+// maxCongestion returns the highest congestion value found in the LSOM
+// statistics of a host.
+func maxCongestion(content string) (int64, error) {
+	var stats interface{}
+	if err := json.Unmarshal([]byte(content), &stats); err != nil {
+		return 0, errors.Wrap(err, "error decoding statistics")
+	}
+
+	var max int64
+	walk(stats, func(key string, value float64) {
+		if strings.Contains(strings.ToLower(key), "congestion") && int64(value) > max {
+			max = int64(value)
+		}
+	})
+	return max, nil
+}
+
+// walk calls fn for every numeric value found in a decoded JSON document.
+func walk(v interface{}, fn func(key string, value float64)) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if n, ok := value.(float64); ok {
+				fn(key, n)
+				continue
+			}
+			walk(value, fn)
+		}
+	case []interface{}:
+		for _, value := range v {
+			walk(value, fn)
+		}
+	}
+}
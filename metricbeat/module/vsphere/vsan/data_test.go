@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vsan
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func readTestFile(t *testing.T, name string) string {
+	content, err := ioutil.ReadFile("./_meta/testdata/" + name)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestHealthStats(t *testing.T) {
+	var stats healthStats
+	stats.addHost(types.VsanHostClusterStatus{Health: "healthy"})
+	stats.addHost(types.VsanHostClusterStatus{Health: "unknown"})
+	require.NoError(t, stats.addDisks(readTestFile(t, "physical_disks.json")))
+
+	assert.Equal(t, healthStats{
+		healthyHosts:   1,
+		unhealthyHosts: 1,
+		disks:          3,
+		unhealthyDisks: 1,
+		components:     59,
+	}, stats)
+
+	assert.Error(t, stats.addDisks("not json"))
+}
+
+func TestParseSyncingObjects(t *testing.T) {
+	stats, err := parseSyncingObjects(readTestFile(t, "syncing_objects.json"))
+	require.NoError(t, err)
+	assert.Equal(t, resyncStats{objects: 2, bytesToSync: 1610612736}, stats)
+
+	stats, err = parseSyncingObjects(`{"dom_objects": {}}`)
+	require.NoError(t, err)
+	assert.Equal(t, resyncStats{}, stats)
+}
+
+func TestMaxCongestion(t *testing.T) {
+	value, err := maxCongestion(readTestFile(t, "lsom_statistics.json"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(31), value)
+}
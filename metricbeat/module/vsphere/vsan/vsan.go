@@ -0,0 +1,257 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vsan
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/vsphere"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// diskProperties are the properties requested for each physical vSAN disk.
+var diskProperties = []string{"uuid", "isSsd", "lsom_objects_count", "disk_health"}
+
+func init() {
+	mb.Registry.MustAddMetricSet("vsphere", "vsan", New,
+		mb.WithHostParser(vsphere.HostParser),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet.
+type MetricSet struct {
+	*vsphere.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The vsphere vsan metricset is beta.")
+
+	ms, err := vsphere.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes one event per cluster with vSAN enabled.
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client, err := govmomi.NewClient(ctx, m.HostURL, m.Insecure)
+	if err != nil {
+		return errors.Wrap(err, "error in NewClient")
+	}
+
+	defer func() {
+		if err := client.Logout(ctx); err != nil {
+			m.Logger().Debug(errors.Wrap(err, "error trying to logout from vshphere"))
+		}
+	}()
+
+	c := client.Client
+
+	// Create a view of ClusterComputeResource objects
+	mgr := view.NewManager(c)
+
+	v, err := mgr.CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"ClusterComputeResource"}, true)
+	if err != nil {
+		return errors.Wrap(err, "error in CreateContainerView")
+	}
+
+	defer func() {
+		if err := v.Destroy(ctx); err != nil {
+			m.Logger().Debug(errors.Wrap(err, "error trying to destroy view from vshphere"))
+		}
+	}()
+
+	var clusters []mo.ClusterComputeResource
+	if err = v.Retrieve(ctx, []string{"ClusterComputeResource"}, []string{"name", "configurationEx", "host", "datastore"}, &clusters); err != nil {
+		return errors.Wrap(err, "error in Retrieve")
+	}
+
+	for _, cl := range clusters {
+		if !vsanEnabled(cl) {
+			continue
+		}
+
+		event, err := m.clusterEvent(ctx, c, cl)
+		if err != nil {
+			reporter.Error(errors.Wrapf(err, "error getting vSAN metrics of cluster %s", cl.Name))
+			continue
+		}
+
+		reporter.Event(mb.Event{
+			MetricSetFields: event,
+		})
+	}
+
+	return nil
+}
+
+func vsanEnabled(cl mo.ClusterComputeResource) bool {
+	config, ok := cl.ConfigurationEx.(*types.ClusterConfigInfoEx)
+	if !ok || config.VsanConfigInfo == nil || config.VsanConfigInfo.Enabled == nil {
+		return false
+	}
+	return *config.VsanConfigInfo.Enabled
+}
+
+func (m *MetricSet) clusterEvent(ctx context.Context, c *vim25.Client, cl mo.ClusterComputeResource) (mapstr.M, error) {
+	pc := property.DefaultCollector(c)
+
+	event := mapstr.M{
+		"cluster": mapstr.M{
+			"name": cl.Name,
+		},
+	}
+
+	if len(cl.Datastore) > 0 {
+		var dst []mo.Datastore
+		if err := pc.Retrieve(ctx, cl.Datastore, []string{"summary"}, &dst); err != nil {
+			return nil, errors.Wrap(err, "error retrieving datastores")
+		}
+		for _, ds := range dst {
+			// Each vSAN cluster has a single vSAN datastore.
+			if ds.Summary.Type != "vsan" {
+				continue
+			}
+			event.Put("capacity", capacityFields(ds.Summary.Capacity, ds.Summary.FreeSpace))
+			break
+		}
+	}
+
+	var hosts []mo.HostSystem
+	if len(cl.Host) > 0 {
+		if err := pc.Retrieve(ctx, cl.Host, []string{"name", "configManager"}, &hosts); err != nil {
+			return nil, errors.Wrap(err, "error retrieving hosts")
+		}
+	}
+
+	var (
+		stats       healthStats
+		resync      resyncStats
+		resyncFound bool
+		congestion  int64
+	)
+	for _, hs := range hosts {
+		if vsanSystem := hs.ConfigManager.VsanSystem; vsanSystem != nil {
+			res, err := methods.QueryHostStatus(ctx, c, &types.QueryHostStatus{This: *vsanSystem})
+			if err != nil {
+				m.Logger().Debugf("error querying vSAN status of host %s: %v", hs.Name, err)
+			} else {
+				stats.addHost(res.Returnval)
+			}
+		}
+
+		internal := hs.ConfigManager.VsanInternalSystem
+		if internal == nil {
+			continue
+		}
+
+		disks, err := methods.QueryPhysicalVsanDisks(ctx, c, &types.QueryPhysicalVsanDisks{
+			This:  *internal,
+			Props: diskProperties,
+		})
+		if err != nil {
+			m.Logger().Debugf("error querying vSAN disks of host %s: %v", hs.Name, err)
+		} else if err := stats.addDisks(disks.Returnval); err != nil {
+			m.Logger().Debugf("error parsing vSAN disks of host %s: %v", hs.Name, err)
+		}
+
+		stat, err := methods.QueryVsanStatistics(ctx, c, &types.QueryVsanStatistics{
+			This:   *internal,
+			Labels: []string{"lsom"},
+		})
+		if err != nil {
+			m.Logger().Debugf("error querying vSAN statistics of host %s: %v", hs.Name, err)
+		} else if value, err := maxCongestion(stat.Returnval); err != nil {
+			m.Logger().Debugf("error parsing vSAN statistics of host %s: %v", hs.Name, err)
+		} else if value > congestion {
+			congestion = value
+		}
+
+		// Syncing objects are reported for the whole cluster, so it is
+		// enough to get them from the first host that answers.
+		if !resyncFound {
+			res, err := methods.QuerySyncingVsanObjects(ctx, c, &types.QuerySyncingVsanObjects{This: *internal})
+			if err != nil {
+				m.Logger().Debugf("error querying vSAN syncing objects of host %s: %v", hs.Name, err)
+			} else if resync, err = parseSyncingObjects(res.Returnval); err != nil {
+				m.Logger().Debugf("error parsing vSAN syncing objects of host %s: %v", hs.Name, err)
+			} else {
+				resyncFound = true
+			}
+		}
+	}
+
+	event.Put("hosts", mapstr.M{
+		"count":     len(hosts),
+		"healthy":   stats.healthyHosts,
+		"unhealthy": stats.unhealthyHosts,
+	})
+	event.Put("disks", mapstr.M{
+		"count":     stats.disks,
+		"healthy":   stats.disks - stats.unhealthyDisks,
+		"unhealthy": stats.unhealthyDisks,
+	})
+	event.Put("components.count", stats.components)
+	event.Put("congestion.max", congestion)
+	if resyncFound {
+		event.Put("resync", mapstr.M{
+			"objects": resync.objects,
+			"bytes":   resync.bytesToSync,
+		})
+	}
+
+	return event, nil
+}
+
+func capacityFields(capacity, free int64) mapstr.M {
+	var usedSpacePercent float64
+	if capacity > 0 {
+		usedSpacePercent = float64(capacity-free) / float64(capacity)
+	}
+
+	return mapstr.M{
+		"total": mapstr.M{
+			"bytes": capacity,
+		},
+		"free": mapstr.M{
+			"bytes": free,
+		},
+		"used": mapstr.M{
+			"bytes": capacity - free,
+			"pct":   usedSpacePercent,
+		},
+	}
+}
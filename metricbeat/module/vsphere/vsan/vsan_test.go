@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vsan
+
+import (
+	"testing"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestFetchEventContents(t *testing.T) {
+	model := simulator.VPX()
+	if err := model.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := model.Service.NewServer()
+	defer ts.Close()
+
+	f := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(ts))
+
+	// Clusters created by the simulator don't have vSAN enabled.
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	require.Empty(t, errs)
+	assert.Empty(t, events)
+
+	cluster := simulator.Map.Any("ClusterComputeResource").(*simulator.ClusterComputeResource)
+	cluster.ConfigurationEx.(*types.ClusterConfigInfoEx).VsanConfigInfo = &types.VsanClusterConfigInfo{
+		Enabled: types.NewBool(true),
+	}
+
+	events, errs = mbtest.ReportingFetchV2WithContext(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	event := events[0].MetricSetFields
+
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event.StringToPrint())
+
+	name, err := event.GetValue("cluster.name")
+	require.NoError(t, err)
+	assert.Equal(t, cluster.Name, name)
+
+	hosts, err := event.GetValue("hosts.count")
+	require.NoError(t, err)
+	assert.EqualValues(t, len(cluster.Host), hosts)
+}
+
+func getConfig(ts *simulator.Server) map[string]interface{} {
+	urlSimulator := ts.URL.Scheme + "://" + ts.URL.Host + ts.URL.Path
+
+	return map[string]interface{}{
+		"module":     "vsphere",
+		"metricsets": []string{"vsan"},
+		"hosts":      []string{urlSimulator},
+		"username":   "user",
+		"password":   "pass",
+		"insecure":   true,
+	}
+}
@@ -32,6 +32,7 @@ import (
 	_ "github.com/elastic/beats/v7/packetbeat/protos/mysql"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/nfs"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/pgsql"
+	_ "github.com/elastic/beats/v7/packetbeat/protos/quic"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/redis"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/sip"
 	_ "github.com/elastic/beats/v7/packetbeat/protos/thrift"
@@ -18,6 +18,7 @@
 package pgsql
 
 import (
+	"encoding/hex"
 	"errors"
 	"strings"
 
@@ -67,6 +68,7 @@ func (pgsql *pgsqlPlugin) parseMessageStart(s *pgsqlStream) (bool, bool) {
 
 		// In case of Commands: StartupMessage, SSLRequest, CancelRequest that don't have
 		// their type in the first byte
+		s.isFrontend = true
 
 		// check buffer available
 		if len(s.data[s.parseOffset:]) <= length {
@@ -127,8 +129,15 @@ func (pgsql *pgsqlPlugin) parseCommand(s *pgsqlStream) (bool, bool) {
 
 	pgsql.detailf("Pgsql type %c, length=%d", typ, length)
 
+	if s.isFrontend && isExtendedQueryRequestType(typ) {
+		// the frontend messages of the extended query protocol share
+		// their types with backend messages
+		return pgsql.parseExtReq(s)
+	}
+
 	switch typ {
 	case 'Q':
+		s.isFrontend = true
 		return pgsql.parseSimpleQuery(s, length)
 	case 'T':
 		return pgsql.parseRowDescription(s, length)
@@ -140,10 +149,10 @@ func (pgsql *pgsqlPlugin) parseCommand(s *pgsqlStream) (bool, bool) {
 		return pgsql.parseReadyForQuery(s, length)
 	case 'E':
 		return pgsql.parseErrorResponse(s, length)
-	case 'P':
-		return pgsql.parseExtReq(s, length)
-	case '1':
-		return pgsql.parseExtResp(s, length)
+	case 'P', 'B':
+		return pgsql.parseExtReq(s)
+	case '1', '2', '3', 'n', 't', 'D':
+		return pgsql.parseExtResp(s)
 	default:
 		if !pgsqlValidType(typ) {
 			pgsql.detailf("invalid frame type: '%c'", typ)
@@ -178,7 +187,9 @@ func (pgsql *pgsqlPlugin) parseSimpleQuery(s *pgsqlStream, length int) (bool, bo
 func (pgsql *pgsqlPlugin) parseRowDescription(s *pgsqlStream, length int) (bool, bool) {
 	// RowDescription
 	m := s.message
-	m.start = s.parseOffset
+	if s.parseState == pgsqlStartState {
+		m.start = s.parseOffset
+	}
 	m.isRequest = false
 	m.isOK = true
 	m.toExport = true
@@ -286,39 +297,24 @@ func (pgsql *pgsqlPlugin) parseErrorResponse(s *pgsqlStream, length int) (bool,
 	return true, true
 }
 
-func (pgsql *pgsqlPlugin) parseExtReq(s *pgsqlStream, length int) (bool, bool) {
-	// Ready for query -> Parse for an extended query request
-	pgsql.detailf("Parse")
+func (pgsql *pgsqlPlugin) parseExtReq(s *pgsqlStream) (bool, bool) {
+	// Parse, Bind or any other message starting an extended query request
+	pgsql.detailf("Extended query request")
 
 	m := s.message
 	m.start = s.parseOffset
 	m.isRequest = true
+	m.isExtendedQuery = true
+	s.isFrontend = true
 
-	s.parseOffset++ // type
-	s.parseOffset += length
-	m.end = s.parseOffset
-	m.size = uint64(m.end - m.start)
-	m.toExport = true
-
-	query, err := common.ReadString(s.data[m.start+6:])
-	if err != nil {
-		pgsql.detailf("Invalid extended query request")
-		return false, false
-	}
-	m.query = query
-	pgsql.detailf("Parse in an extended query request: %s", m.query)
-
-	// Ignore SET statement
-	if strings.HasPrefix(m.query, "SET ") {
-		m.toExport = false
-	}
 	s.parseState = pgsqlExtendedQueryState
 	return pgsql.parseMessageExtendedQuery(s)
 }
 
-func (pgsql *pgsqlPlugin) parseExtResp(s *pgsqlStream, length int) (bool, bool) {
-	// Sync -> Parse completion for an extended query response
-	pgsql.detailf("ParseCompletion")
+func (pgsql *pgsqlPlugin) parseExtResp(s *pgsqlStream) (bool, bool) {
+	// ParseComplete, BindComplete, or any other message preceding the
+	// result of an Execute in an extended query response
+	pgsql.detailf("Extended query response")
 
 	m := s.message
 	m.start = s.parseOffset
@@ -326,9 +322,6 @@ func (pgsql *pgsqlPlugin) parseExtResp(s *pgsqlStream, length int) (bool, bool)
 	m.isOK = true
 	m.toExport = true
 
-	s.parseOffset++ // type
-	s.parseOffset += length
-	pgsql.detailf("Parse completion in an extended query response")
 	s.parseState = pgsqlGetDataState
 	return pgsql.parseMessageData(s)
 }
@@ -500,13 +493,51 @@ func (pgsql *pgsqlPlugin) parseMessageData(s *pgsqlStream) (bool, bool) {
 			pgsql.detailf("Rows: %s", m.rows)
 
 			return true, true
-		case '2':
-			// Parse completion -> Bind completion for an extended query response
+		case 'I', 's':
+			// EmptyQueryResponse or PortalSuspended, in place of CommandComplete
+
+			// skip type
+			s.parseOffset++
+			s.parseOffset += length
+			m.end = s.parseOffset
+			m.size = uint64(m.end - m.start)
+			s.parseState = pgsqlStartState
+
+			return true, true
+		case 'E':
+			// ErrorResponse
+
+			// skip type
+			s.parseOffset++
+			pgsql.parseError(s, s.data[s.parseOffset+4:s.parseOffset+length])
+
+			s.parseOffset += length
+			m.end = s.parseOffset
+			m.size = uint64(m.end - m.start)
+			m.isOK = false
+			m.isError = true
+			s.parseState = pgsqlStartState
+
+			return true, true
+		case 'Z':
+			// ReadyForQuery -> extended query response without an Execute
 
 			// skip type
 			s.parseOffset++
 			s.parseOffset += length
+			m.end = s.parseOffset
+			m.size = uint64(m.end - m.start)
+			m.toExport = false
 			s.parseState = pgsqlStartState
+
+			return true, true
+		case '1', '2', '3', 'n', 't', 'A', 'N', 'S':
+			// ParseComplete, BindComplete, CloseComplete, NoData,
+			// ParameterDescription, or an asynchronous message
+
+			// skip type
+			s.parseOffset++
+			s.parseOffset += length
 		case 'T':
 			return pgsql.parseRowDescription(s, length)
 		default:
@@ -536,8 +567,8 @@ func (pgsql *pgsqlPlugin) parseDataRow(s *pgsqlStream, buf []byte) error {
 			return errFieldBufferShort
 		}
 
-		// read column length (int32)
-		columnLength := readLength(buf[off:])
+		// read column length (int32), -1 for NULL values
+		columnLength := int(int32(common.BytesNtohl(buf[off:])))
 		off += 4
 
 		if columnLength > 0 && columnLength > len(buf[off:]) {
@@ -548,12 +579,13 @@ func (pgsql *pgsqlPlugin) parseDataRow(s *pgsqlStream, buf []byte) error {
 
 		// read column value (byten)
 		var columnValue []byte
-		if m.fieldsFormat[i] == 0 {
-			// field value in text format
-			if columnLength > 0 {
+		if columnLength > 0 {
+			// only keep the field values in text format, the format is
+			// unknown if the portal was not described
+			if i >= len(m.fieldsFormat) || m.fieldsFormat[i] == 0 {
 				columnValue = buf[off : off+columnLength]
-				off += columnLength
 			}
+			off += columnLength
 		}
 
 		if rowLength < pgsql.maxRowLength {
@@ -608,28 +640,20 @@ func (pgsql *pgsqlPlugin) parseMessageExtendedQuery(s *pgsqlStream) (bool, bool)
 			return true, false
 		}
 
+		buf := s.data[s.parseOffset+5 : s.parseOffset+length+1]
+
+		var err error
 		switch typ {
+		case 'P':
+			err = pgsql.parseParse(s, buf)
 		case 'B':
-			// Parse -> Bind
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
-			// TODO: pgsql.parseBind(s)
-		case 'D':
-			// Bind -> Describe
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
-			// TODO: pgsql.parseDescribe(s)
+			err = pgsql.parseBind(s, buf)
 		case 'E':
-			// Bind(or Describe) -> Execute
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
-			// TODO: pgsql.parseExecute(s)
+			err = pgsql.parseExecute(s, buf)
+		case 'C':
+			err = pgsql.parseClose(s, buf)
+		case 'D', 'H':
+			// Describe or Flush
 		case 'S':
 			// Execute -> Sync
 
@@ -638,6 +662,7 @@ func (pgsql *pgsqlPlugin) parseMessageExtendedQuery(s *pgsqlStream) (bool, bool)
 			s.parseOffset += length
 			m.end = s.parseOffset
 			m.size = uint64(m.end - m.start)
+			m.toExport = len(m.executions) > 0
 			s.parseState = pgsqlStartState
 
 			return true, true
@@ -647,11 +672,180 @@ func (pgsql *pgsqlPlugin) parseMessageExtendedQuery(s *pgsqlStream) (bool, bool)
 			s.parseState = pgsqlStartState
 			return false, false
 		}
+		if err != nil {
+			pgsql.detailf("Invalid extended query message '%c': %v", typ, err)
+			s.parseState = pgsqlStartState
+			return false, false
+		}
+
+		// skip type
+		s.parseOffset++
+		s.parseOffset += length
 	}
 
 	return true, false
 }
 
+func (pgsql *pgsqlPlugin) parseParse(s *pgsqlStream, buf []byte) error {
+	// read statement name and query (null terminated strings)
+	name, err := common.ReadString(buf)
+	if err != nil {
+		return errInvalidString
+	}
+	query, err := common.ReadString(buf[len(name)+1:])
+	if err != nil {
+		return errInvalidString
+	}
+
+	pgsql.detailf("Parse statement '%s': %s", name, query)
+	s.preparedStatements().statements[name] = query
+	return nil
+}
+
+func (pgsql *pgsqlPlugin) parseBind(s *pgsqlStream, buf []byte) error {
+	// read portal and statement names (null terminated strings)
+	portal, err := common.ReadString(buf)
+	if err != nil {
+		return errInvalidString
+	}
+	off := len(portal) + 1
+	statement, err := common.ReadString(buf[off:])
+	if err != nil {
+		return errInvalidString
+	}
+	off += len(statement) + 1
+
+	// read parameter format codes (int16 count, int16 each)
+	if len(buf) < off+2 {
+		return errFieldBufferShort
+	}
+	formatCount := readCount(buf[off:])
+	off += 2
+	if len(buf) < off+2*formatCount+2 {
+		return errFieldBufferShort
+	}
+	formats := make([]int, formatCount)
+	for i := range formats {
+		formats[i] = readCount(buf[off:])
+		off += 2
+	}
+
+	// read parameter values (int16 count, int32 length and byten each)
+	paramCount := readCount(buf[off:])
+	off += 2
+	params := make([]string, 0, paramCount)
+	for i := 0; i < paramCount; i++ {
+		if len(buf) < off+4 {
+			return errFieldBufferShort
+		}
+		paramLength := int(int32(common.BytesNtohl(buf[off:])))
+		off += 4
+		if paramLength < 0 {
+			params = append(params, "NULL")
+			continue
+		}
+		if paramLength > len(buf[off:]) {
+			return errInvalidLength
+		}
+
+		// a single format code applies to all parameters
+		format := 0
+		if formatCount == 1 {
+			format = formats[0]
+		} else if i < formatCount {
+			format = formats[i]
+		}
+		params = append(params, pgsql.formatParameter(buf[off:off+paramLength], format))
+		off += paramLength
+	}
+
+	// the result column format codes are not needed, RowDescription
+	// carries them
+
+	pgsql.detailf("Bind portal '%s' to statement '%s': %s", portal, statement, params)
+	s.preparedStatements().portals[portal] = pgsqlPortal{
+		statement: statement,
+		params:    params,
+	}
+	return nil
+}
+
+// formatParameter returns the value of a bound parameter, truncated to
+// max_row_length. Values in binary format are hex encoded.
+func (pgsql *pgsqlPlugin) formatParameter(value []byte, format int) string {
+	if len(value) > pgsql.maxRowLength {
+		value = value[:pgsql.maxRowLength]
+	}
+	if format == 0 {
+		return string(value)
+	}
+
+	param := `\x` + hex.EncodeToString(value)
+	if len(param) > pgsql.maxRowLength {
+		param = param[:pgsql.maxRowLength]
+	}
+	return param
+}
+
+func (pgsql *pgsqlPlugin) parseExecute(s *pgsqlStream, buf []byte) error {
+	// read portal name (null terminated string), the maximum number
+	// of rows (int32) follows
+	name, err := common.ReadString(buf)
+	if err != nil {
+		return errInvalidString
+	}
+
+	m := s.message
+	prepared := s.preparedStatements()
+	portal, found := prepared.portals[name]
+	if !found {
+		// the portal was bound before the capture started
+		pgsql.detailf("Execute of unknown portal '%s'", name)
+		m.executions = append(m.executions, pgsqlExecution{
+			query:   "EXECUTE",
+			unknown: true,
+		})
+		return nil
+	}
+	query, found := prepared.statements[portal.statement]
+	if !found {
+		// the statement was prepared before the capture started
+		pgsql.detailf("Execute of unknown statement '%s'", portal.statement)
+		query = strings.TrimSpace("EXECUTE " + portal.statement)
+	}
+
+	pgsql.detailf("Execute portal '%s': %s", name, query)
+	m.executions = append(m.executions, pgsqlExecution{
+		query:   query,
+		params:  portal.params,
+		unknown: !found,
+	})
+	return nil
+}
+
+func (pgsql *pgsqlPlugin) parseClose(s *pgsqlStream, buf []byte) error {
+	// read type of object to close (byte1) and its name (null terminated
+	// string)
+	if len(buf) < 1 {
+		return errInvalidString
+	}
+	name, err := common.ReadString(buf[1:])
+	if err != nil {
+		return errInvalidString
+	}
+
+	prepared := s.preparedStatements()
+	switch buf[0] {
+	case 'S':
+		pgsql.detailf("Close statement '%s'", name)
+		delete(prepared.statements, name)
+	case 'P':
+		pgsql.detailf("Close portal '%s'", name)
+		delete(prepared.portals, name)
+	}
+	return nil
+}
+
 func (pgsql *pgsqlPlugin) isSpecialCommand(data []byte) (bool, int, int) {
 	if len(data) < 8 {
 		// 8 bytes required
@@ -702,6 +896,17 @@ func pgsqlString(b []byte, sz int) (string, error) {
 	return string(b[:sz-1]), nil
 }
 
+// isExtendedQueryRequestType reports whether a frontend message of type t
+// belongs to an extended query request.
+func isExtendedQueryRequestType(t byte) bool {
+	switch t {
+	case 'B', 'C', 'D', 'E', 'H', 'P', 'S':
+		return true
+	default:
+		return false
+	}
+}
+
 func pgsqlValidType(t byte) bool {
 	switch t {
 	case '1', '2', '3',
@@ -65,21 +65,23 @@ type pgsqlMessage struct {
 	isSSLRequest  bool
 	toExport      bool
 
-	ts             time.Time
-	isRequest      bool
-	query          string
-	size           uint64
-	fields         []string
-	fieldsFormat   []byte
-	rows           [][]string
-	numberOfRows   int
-	numberOfFields int
-	isOK           bool
-	isError        bool
-	errorInfo      string
-	errorCode      string
-	errorSeverity  string
-	notes          []string
+	ts              time.Time
+	isRequest       bool
+	query           string
+	size            uint64
+	fields          []string
+	fieldsFormat    []byte
+	rows            [][]string
+	executions      []pgsqlExecution
+	numberOfRows    int
+	numberOfFields  int
+	isOK            bool
+	isError         bool
+	isExtendedQuery bool
+	errorInfo       string
+	errorCode       string
+	errorSeverity   string
+	notes           []string
 
 	direction    uint8
 	tcpTuple     common.TCPTuple
@@ -96,9 +98,15 @@ type pgsqlTransaction struct {
 	method   string
 	bytesOut uint64
 	bytesIn  uint64
+	params   []string
 	notes    []string
 	isError  bool
 
+	// batch is the extended query request the transaction was sent in
+	batch *pgsqlMessage
+	// ignore is set for transactions that must not be published
+	ignore bool
+
 	pgsql mapstr.M
 
 	requestRaw  string
@@ -112,8 +120,37 @@ type pgsqlStream struct {
 	parseState        int
 	seenSSLRequest    bool
 	expectSSLResponse bool
+	isFrontend        bool
+
+	prepared *pgsqlPreparedStatements
+	message  *pgsqlMessage
+}
+
+// pgsqlExecution is an Execute message from an extended query request,
+// resolved to the SQL text of the statement bound to its portal.
+type pgsqlExecution struct {
+	query   string
+	params  []string
+	unknown bool
+}
 
-	message *pgsqlMessage
+// pgsqlPreparedStatements holds the prepared statements and portals of a
+// connection, as created by the Parse and Bind messages of the frontend.
+type pgsqlPreparedStatements struct {
+	statements map[string]string
+	portals    map[string]pgsqlPortal
+}
+
+type pgsqlPortal struct {
+	statement string
+	params    []string
+}
+
+func newPgsqlPreparedStatements() *pgsqlPreparedStatements {
+	return &pgsqlPreparedStatements{
+		statements: map[string]string{},
+		portals:    map[string]pgsqlPortal{},
+	}
 }
 
 const (
@@ -217,6 +254,13 @@ func (stream *pgsqlStream) prepareForNewMessage() {
 	stream.message = nil
 }
 
+func (stream *pgsqlStream) preparedStatements() *pgsqlPreparedStatements {
+	if stream.prepared == nil {
+		stream.prepared = newPgsqlPreparedStatements()
+	}
+	return stream.prepared
+}
+
 // Extract the method from a SQL query
 func getQueryMethod(q string) string {
 	index := strings.Index(q, " ")
@@ -230,7 +274,8 @@ func getQueryMethod(q string) string {
 }
 
 type pgsqlPrivateData struct {
-	data [2]*pgsqlStream
+	data     [2]*pgsqlStream
+	prepared *pgsqlPreparedStatements
 }
 
 func (pgsql *pgsqlPlugin) ConnectionTimeout() time.Duration {
@@ -270,6 +315,12 @@ func (pgsql *pgsqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 
 	stream := priv.data[dir]
 
+	// prepared statements outlive the streams, which are dropped on errors
+	if priv.prepared == nil {
+		priv.prepared = newPgsqlPreparedStatements()
+	}
+	stream.prepared = priv.prepared
+
 	if priv.data[1-dir] != nil && priv.data[1-dir].seenSSLRequest {
 		stream.expectSSLResponse = true
 	}
@@ -325,7 +376,7 @@ func messageHasEnoughData(msg *pgsqlMessage) bool {
 		return false
 	}
 	if msg.isRequest {
-		return len(msg.query) > 0
+		return len(msg.query) > 0 || len(msg.executions) > 0
 	}
 	return len(msg.rows) > 0
 }
@@ -392,18 +443,25 @@ var handlePgsql = func(pgsql *pgsqlPlugin, m *pgsqlMessage, tcptuple *common.TCP
 func (pgsql *pgsqlPlugin) receivedPgsqlRequest(msg *pgsqlMessage) {
 	tuple := msg.tcpTuple
 
-	// parse the query, as it might contain a list of pgsql command
-	// separated by ';'
-	queries := pgsqlQueryParser(msg.query)
+	executions := msg.executions
+	if !msg.isExtendedQuery {
+		// parse the query, as it might contain a list of pgsql command
+		// separated by ';'
+		queries := pgsqlQueryParser(msg.query)
 
-	pgsql.debugf("Queries (%d) :%s", len(queries), queries)
+		pgsql.debugf("Queries (%d) :%s", len(queries), queries)
+
+		for _, query := range queries {
+			executions = append(executions, pgsqlExecution{query: query})
+		}
+	}
 
 	transList := pgsql.getTransaction(tuple.Hashable())
 	if transList == nil {
 		transList = []*pgsqlTransaction{}
 	}
 
-	for _, query := range queries {
+	for _, exec := range executions {
 
 		trans := &pgsqlTransaction{tuple: tuple}
 
@@ -415,13 +473,24 @@ func (pgsql *pgsqlPlugin) receivedPgsqlRequest(msg *pgsqlMessage) {
 		}
 
 		trans.pgsql = mapstr.M{}
-		trans.query = query
-		trans.method = getQueryMethod(query)
+		trans.query = exec.query
+		trans.method = getQueryMethod(exec.query)
+		trans.params = exec.params
 		trans.bytesIn = msg.size
 
 		trans.notes = msg.notes
+		if exec.unknown {
+			trans.notes = append(msg.notes[:len(msg.notes):len(msg.notes)],
+				"The actual query being used is unknown")
+		}
 
-		trans.requestRaw = query
+		if msg.isExtendedQuery {
+			trans.batch = msg
+			// Ignore SET statement
+			trans.ignore = strings.HasPrefix(exec.query, "SET ")
+		}
+
+		trans.requestRaw = exec.query
 
 		transList = append(transList, trans)
 	}
@@ -447,6 +516,15 @@ func (pgsql *pgsqlPlugin) receivedPgsqlResponse(msg *pgsqlMessage) {
 		return
 	}
 
+	if msg.isError && trans.batch != nil {
+		// The backend discards the remaining messages of an extended
+		// query request after an error, up to its Sync.
+		pgsql.discardBatch(tuple, trans.batch)
+	}
+	if trans.ignore {
+		return
+	}
+
 	trans.pgsql.Update(mapstr.M{
 		"num_rows":   msg.numberOfRows,
 		"num_fields": msg.numberOfFields,
@@ -492,6 +570,9 @@ func (pgsql *pgsqlPlugin) publishTransaction(t *pgsqlTransaction) {
 	fields["query"] = t.query
 	fields["method"] = t.method
 	fields["pgsql"] = t.pgsql
+	if len(t.params) > 0 {
+		fields["params"] = t.params
+	}
 
 	if t.isError {
 		fields["status"] = common.ERROR_STATUS
@@ -521,3 +602,18 @@ func (pgsql *pgsqlPlugin) removeTransaction(transList []*pgsqlTransaction,
 
 	return trans
 }
+
+// discardBatch removes the pending transactions of an extended query request
+// that won't get a response.
+func (pgsql *pgsqlPlugin) discardBatch(tuple common.TCPTuple, batch *pgsqlMessage) {
+	transList := pgsql.getTransaction(tuple.Hashable())
+	for len(transList) > 0 && transList[0].batch == batch {
+		pgsql.debugf("Discarding transaction of failed extended query: %s", transList[0].query)
+		transList = transList[1:]
+	}
+	if len(transList) == 0 {
+		pgsql.transactions.Delete(tuple.Hashable())
+	} else {
+		pgsql.transactions.Put(tuple.Hashable(), transList)
+	}
+}
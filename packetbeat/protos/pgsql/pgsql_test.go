@@ -21,6 +21,7 @@
 package pgsql
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"net"
 	"testing"
@@ -386,3 +387,168 @@ func Test_gap_in_response(t *testing.T) {
 		assert.Equal(t, m, "Packet loss while capturing the response")
 	}
 }
+
+// pgsqlTestMessage returns the pgsql message of type typ with the given body
+func pgsqlTestMessage(typ byte, body string) []byte {
+	msg := []byte{typ, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(msg[1:], uint32(len(body)+4))
+	return append(msg, body...)
+}
+
+func parsePgsqlMessages(pgsql *pgsqlPlugin, tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData, msgs ...[]byte,
+) protos.ProtocolData {
+	var payload []byte
+	for _, msg := range msgs {
+		payload = append(payload, msg...)
+	}
+	pkt := protos.Packet{Payload: payload}
+	return pgsql.Parse(&pkt, tcptuple, dir, private)
+}
+
+// Test an extended query using an unnamed statement, as sent by the JDBC
+// driver, from tests/pcaps/pgsql_extended_query.pcap
+func TestPgsqlParser_extendedQuery(t *testing.T) {
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+	tcptuple := testTCPTuple()
+
+	var private protos.ProtocolData
+	for _, data := range []struct {
+		dir uint8
+		msg string
+	}{
+		// SET extra_float_digits = 3
+		{0, "5000000022005345542065787472615f666c6f61745f646967697473203d2033000000420000000c0000000000000000450000000900000000015300000004"},
+		{1, "310000000432000000044300000008534554005a0000000549"},
+		// SELECT * from test where id = $1
+		{0, "500000002c0053454c454354202a2066726f6d2074657374207768657265206964203d20243100000100000017420000001600000001000100010000000400000001000044000000065000450000000900000000005300000004"},
+		{1, "3100000004320000000454000000320002696400000040010001000000170004ffffffff00006e616d650000004001000200000412ffff000000180000440000002300020000000131000000143232322020202020202020202020202020202020430000000d53454c4543542031005a0000000549"},
+	} {
+		msg, err := hex.DecodeString(data.msg)
+		if !assert.NoError(t, err) {
+			return
+		}
+		private = parsePgsqlMessages(pgsql, tcptuple, data.dir, private, msg)
+	}
+
+	// the SET statement is not published
+	assert.Len(t, store.events, 1)
+	trans := expectTransaction(t, store)
+	assert.Equal(t, "SELECT * from test where id = $1", trans["query"])
+	assert.Equal(t, "SELECT", trans["method"])
+	assert.Equal(t, []string{`\x00000001`}, trans["params"])
+	assert.Equal(t, "OK", trans["status"])
+	assert.Equal(t, mapstr.M{"num_rows": 1, "num_fields": 2}, trans["pgsql"])
+	assert.Equal(t, int64(90), trans["source"].(mapstr.M)["bytes"])
+	assert.Equal(t, int64(111), trans["destination"].(mapstr.M)["bytes"])
+}
+
+// Test the executions of a named statement prepared in an earlier request
+func TestPgsqlParser_namedStatement(t *testing.T) {
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+	tcptuple := testTCPTuple()
+
+	var private protos.ProtocolData
+	private = parsePgsqlMessages(pgsql, tcptuple, 0, private,
+		pgsqlTestMessage('P', "stmt1\x00SELECT name FROM users WHERE id = $1 AND role = $2\x00\x00\x00"),
+		pgsqlTestMessage('D', "Sstmt1\x00"),
+		pgsqlTestMessage('S', ""))
+	private = parsePgsqlMessages(pgsql, tcptuple, 1, private,
+		pgsqlTestMessage('1', ""),
+		pgsqlTestMessage('t', "\x00\x02\x00\x00\x00\x17\x00\x00\x00\x19"),
+		pgsqlTestMessage('T', "\x00\x01name\x00\x00\x00\x40\x01\x00\x02\x00\x00\x00\x19\xff\xff\xff\xff\xff\xff\x00\x00"),
+		pgsqlTestMessage('Z', "I"))
+	assert.Empty(t, store.events)
+
+	for _, params := range []struct {
+		id, value string
+	}{
+		{"42", "admin"},
+		{"43", "user"},
+	} {
+		bind := "\x00stmt1\x00\x00\x00\x00\x02" +
+			"\x00\x00\x00\x02" + params.id +
+			"\x00\x00\x00" + string(byte(len(params.value))) + params.value +
+			"\x00\x00"
+		private = parsePgsqlMessages(pgsql, tcptuple, 0, private,
+			pgsqlTestMessage('B', bind),
+			pgsqlTestMessage('E', "\x00\x00\x00\x00\x00"),
+			pgsqlTestMessage('S', ""))
+		private = parsePgsqlMessages(pgsql, tcptuple, 1, private,
+			pgsqlTestMessage('2', ""),
+			pgsqlTestMessage('D', "\x00\x01\x00\x00\x00\x03bob"),
+			pgsqlTestMessage('D', "\x00\x01\xff\xff\xff\xff"),
+			pgsqlTestMessage('C', "SELECT 2\x00"),
+			pgsqlTestMessage('Z', "I"))
+
+		trans := expectTransaction(t, store)
+		assert.Equal(t, "SELECT name FROM users WHERE id = $1 AND role = $2", trans["query"])
+		assert.Equal(t, "SELECT", trans["method"])
+		assert.Equal(t, []string{params.id, params.value}, trans["params"])
+		assert.Equal(t, "OK", trans["status"])
+		assert.Equal(t, 2, trans["pgsql"].(mapstr.M)["num_rows"])
+	}
+
+	// the statement is unknown once closed
+	private = parsePgsqlMessages(pgsql, tcptuple, 0, private,
+		pgsqlTestMessage('C', "Sstmt1\x00"),
+		pgsqlTestMessage('S', ""))
+	private = parsePgsqlMessages(pgsql, tcptuple, 1, private,
+		pgsqlTestMessage('3', ""),
+		pgsqlTestMessage('Z', "I"))
+	private = parsePgsqlMessages(pgsql, tcptuple, 0, private,
+		pgsqlTestMessage('B', "\x00stmt1\x00\x00\x00\x00\x00\x00\x00"),
+		pgsqlTestMessage('E', "\x00\x00\x00\x00\x00"),
+		pgsqlTestMessage('S', ""))
+	parsePgsqlMessages(pgsql, tcptuple, 1, private,
+		pgsqlTestMessage('E', "SERROR\x00C26000\x00Mprepared statement \"stmt1\" does not exist\x00\x00"),
+		pgsqlTestMessage('Z', "I"))
+
+	trans := expectTransaction(t, store)
+	assert.Equal(t, "EXECUTE stmt1", trans["query"])
+	assert.Equal(t, "Error", trans["status"])
+	assert.Equal(t, "The actual query being used is unknown", trans["error"].(mapstr.M)["message"])
+	assert.Equal(t, "26000", trans["pgsql"].(mapstr.M)["error_code"])
+	assert.Empty(t, store.events)
+}
+
+// Test that the executions skipped by the backend after an error in an
+// extended query request don't get the responses of the next requests
+func TestPgsqlParser_extendedQueryError(t *testing.T) {
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+	tcptuple := testTCPTuple()
+
+	var private protos.ProtocolData
+	private = parsePgsqlMessages(pgsql, tcptuple, 0, private,
+		pgsqlTestMessage('P', "\x00INSERT INTO test VALUES ($1)\x00\x00\x00"),
+		pgsqlTestMessage('B', "\x00\x00\x00\x00\x00\x01\x00\x00\x00\x01a\x00\x00"),
+		pgsqlTestMessage('E', "\x00\x00\x00\x00\x00"),
+		pgsqlTestMessage('B', "\x00\x00\x00\x00\x00\x01\x00\x00\x00\x01b\x00\x00"),
+		pgsqlTestMessage('E', "\x00\x00\x00\x00\x00"),
+		pgsqlTestMessage('S', ""))
+	private = parsePgsqlMessages(pgsql, tcptuple, 1, private,
+		pgsqlTestMessage('1', ""),
+		pgsqlTestMessage('2', ""),
+		pgsqlTestMessage('E', "SERROR\x00C23505\x00Mduplicate key value violates unique constraint\x00\x00"),
+		pgsqlTestMessage('Z', "E"))
+
+	private = parsePgsqlMessages(pgsql, tcptuple, 0, private,
+		pgsqlTestMessage('Q', "ROLLBACK\x00"))
+	parsePgsqlMessages(pgsql, tcptuple, 1, private,
+		pgsqlTestMessage('C', "ROLLBACK\x00"),
+		pgsqlTestMessage('Z', "I"))
+
+	assert.Len(t, store.events, 2)
+	trans := expectTransaction(t, store)
+	assert.Equal(t, "INSERT INTO test VALUES ($1)", trans["query"])
+	assert.Equal(t, []string{"a"}, trans["params"])
+	assert.Equal(t, "Error", trans["status"])
+	assert.Equal(t, "23505", trans["pgsql"].(mapstr.M)["error_code"])
+
+	trans = expectTransaction(t, store)
+	assert.Equal(t, "ROLLBACK", trans["query"])
+	assert.Equal(t, "OK", trans["status"])
+}
@@ -0,0 +1,298 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Frame types allowed in Initial packets.
+const (
+	framePadding          = 0x00
+	framePing             = 0x01
+	frameACK              = 0x02
+	frameACKECN           = 0x03
+	frameCrypto           = 0x06
+	frameConnectionClose  = 0x1c
+	frameApplicationClose = 0x1d
+)
+
+// TLS handshake messages and extensions used by the analyzer.
+const (
+	handshakeClientHello = 1
+	handshakeServerHello = 2
+
+	extServerName        = 0x0000
+	extALPN              = 0x0010
+	extSupportedVersions = 0x002b
+)
+
+// maxCryptoStreamLen limits the handshake data buffered per direction.
+const maxCryptoStreamLen = 64 * 1024
+
+// initialFrames are the frames of interest found in Initial packets.
+type initialFrames struct {
+	crypto []cryptoFrame
+
+	// closed is set if a CONNECTION_CLOSE frame was found.
+	closed         bool
+	closeErrorCode uint64
+	closeReason    string
+}
+
+type cryptoFrame struct {
+	offset uint64
+	data   []byte
+}
+
+// parseInitialFrames parses the frames of a decrypted Initial packet payload.
+func parseInitialFrames(payload []byte, frames *initialFrames) error {
+	for len(payload) > 0 {
+		typ, n := readVarint(payload)
+		if n == 0 {
+			return errInvalidFrame
+		}
+		payload = payload[n:]
+
+		switch typ {
+		case framePadding, framePing:
+		case frameACK, frameACKECN:
+			// largest acknowledged, ack delay, range count, first range
+			var fields [4]uint64
+			for i := range fields {
+				if fields[i], n = readVarint(payload); n == 0 {
+					return errInvalidFrame
+				}
+				payload = payload[n:]
+			}
+			// gap and length of each additional range, plus the ECN counts.
+			skip := 2 * fields[2]
+			if typ == frameACKECN {
+				skip += 3
+			}
+			for i := uint64(0); i < skip; i++ {
+				if _, n = readVarint(payload); n == 0 {
+					return errInvalidFrame
+				}
+				payload = payload[n:]
+			}
+		case frameCrypto:
+			offset, n := readVarint(payload)
+			if n == 0 {
+				return errInvalidFrame
+			}
+			payload = payload[n:]
+			length, n := readVarint(payload)
+			if n == 0 || uint64(len(payload)-n) < length {
+				return errInvalidFrame
+			}
+			payload = payload[n:]
+			frames.crypto = append(frames.crypto, cryptoFrame{offset: offset, data: payload[:length]})
+			payload = payload[length:]
+		case frameConnectionClose, frameApplicationClose:
+			code, n := readVarint(payload)
+			if n == 0 {
+				return errInvalidFrame
+			}
+			payload = payload[n:]
+			if typ == frameConnectionClose {
+				// type of the frame that triggered the error
+				if _, n = readVarint(payload); n == 0 {
+					return errInvalidFrame
+				}
+				payload = payload[n:]
+			}
+			length, n := readVarint(payload)
+			if n == 0 || uint64(len(payload)-n) < length {
+				return errInvalidFrame
+			}
+			payload = payload[n:]
+			frames.closed = true
+			frames.closeErrorCode = code
+			frames.closeReason = string(payload[:length])
+			payload = payload[length:]
+		default:
+			return fmt.Errorf("%w: unexpected frame type 0x%x in Initial packet", errInvalidFrame, typ)
+		}
+	}
+	return nil
+}
+
+// cryptoStream reassembles the CRYPTO frames of one direction. Frames can be
+// received out of order, split across several packets and retransmitted.
+type cryptoStream struct {
+	buf      []byte
+	segments [][2]uint64
+}
+
+// add adds the data of a CRYPTO frame. Data beyond maxCryptoStreamLen is
+// ignored.
+func (s *cryptoStream) add(f cryptoFrame) {
+	end := f.offset + uint64(len(f.data))
+	if end > maxCryptoStreamLen || len(f.data) == 0 {
+		return
+	}
+	if uint64(len(s.buf)) < end {
+		s.buf = append(s.buf, make([]byte, int(end)-len(s.buf))...)
+	}
+	copy(s.buf[f.offset:], f.data)
+	s.segments = append(s.segments, [2]uint64{f.offset, end})
+}
+
+// contiguous returns the data received without gaps from the start of the
+// stream.
+func (s *cryptoStream) contiguous() []byte {
+	var pos uint64
+	for progress := true; progress; {
+		progress = false
+		for _, seg := range s.segments {
+			if seg[0] <= pos && seg[1] > pos {
+				pos = seg[1]
+				progress = true
+			}
+		}
+	}
+	return s.buf[:pos]
+}
+
+// handshakeMessage returns the first handshake message of the stream if it
+// was fully received.
+func (s *cryptoStream) handshakeMessage() (typ uint8, body []byte, ok bool) {
+	data := s.contiguous()
+	if len(data) < 4 {
+		return 0, nil, false
+	}
+	length := int(data[1])<<16 | int(data[2])<<8 | int(data[3])
+	if len(data) < 4+length {
+		return 0, nil, false
+	}
+	return data[0], data[4 : 4+length], true
+}
+
+// helloMessage holds the ClientHello or ServerHello fields reported by the
+// analyzer.
+type helloMessage struct {
+	serverName   string
+	alpn         []string
+	cipherSuites []uint16
+	versions     []uint16
+}
+
+// parseHello parses the body of a ClientHello or ServerHello message.
+func parseHello(typ uint8, body []byte) (*helloMessage, error) {
+	r := reader{data: body}
+	// legacy version and random
+	r.skip(2 + 32)
+	r.skip(int(r.uint8()))
+
+	hello := &helloMessage{}
+	if typ == handshakeClientHello {
+		suites := reader{data: r.bytes(int(r.uint16()))}
+		for suites.ok() && len(suites.data) >= 2 {
+			hello.cipherSuites = append(hello.cipherSuites, suites.uint16())
+		}
+		r.skip(int(r.uint8()))
+	} else {
+		hello.cipherSuites = []uint16{r.uint16()}
+		r.skip(1)
+	}
+
+	extensions := reader{data: r.bytes(int(r.uint16()))}
+	if !r.ok() {
+		return nil, errTruncated
+	}
+	for extensions.ok() && len(extensions.data) >= 4 {
+		code := extensions.uint16()
+		ext := reader{data: extensions.bytes(int(extensions.uint16()))}
+		if !extensions.ok() {
+			return nil, errTruncated
+		}
+		switch code {
+		case extServerName:
+			list := reader{data: ext.bytes(int(ext.uint16()))}
+			for list.ok() && len(list.data) >= 3 {
+				nameType := list.uint8()
+				name := list.bytes(int(list.uint16()))
+				if nameType == 0 && list.ok() {
+					hello.serverName = string(name)
+					break
+				}
+			}
+		case extALPN:
+			list := reader{data: ext.bytes(int(ext.uint16()))}
+			for list.ok() && len(list.data) > 0 {
+				proto := list.bytes(int(list.uint8()))
+				if list.ok() {
+					hello.alpn = append(hello.alpn, string(proto))
+				}
+			}
+		case extSupportedVersions:
+			if typ == handshakeServerHello {
+				hello.versions = []uint16{ext.uint16()}
+				break
+			}
+			list := reader{data: ext.bytes(int(ext.uint8()))}
+			for list.ok() && len(list.data) >= 2 {
+				hello.versions = append(hello.versions, list.uint16())
+			}
+		}
+	}
+	return hello, nil
+}
+
+// reader reads big endian values from a byte slice. Reads past the end of the
+// data return zero values and mark the reader as failed.
+type reader struct {
+	data   []byte
+	failed bool
+}
+
+func (r *reader) ok() bool {
+	return !r.failed
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.failed || len(r.data) < n {
+		r.failed = true
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *reader) uint8() uint8 {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *reader) uint16() uint16 {
+	b := r.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
@@ -0,0 +1,334 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// QUIC versions whose Initial packets can be decrypted.
+const (
+	versionNegotiation uint32 = 0x00000000
+	version1           uint32 = 0x00000001
+	version2           uint32 = 0x6b3343cf
+	versionDraft29     uint32 = 0xff00001d
+)
+
+// Long header packet types.
+type packetType uint8
+
+const (
+	packetInitial packetType = iota
+	packet0RTT
+	packetHandshake
+	packetRetry
+	packetVersionNegotiation
+	packetShort
+)
+
+var packetTypeNames = map[packetType]string{
+	packetInitial:            "initial",
+	packet0RTT:               "0rtt",
+	packetHandshake:          "handshake",
+	packetRetry:              "retry",
+	packetVersionNegotiation: "version_negotiation",
+	packetShort:              "1rtt",
+}
+
+func (t packetType) String() string {
+	if name, found := packetTypeNames[t]; found {
+		return name
+	}
+	return "unknown"
+}
+
+var (
+	errTruncated      = errors.New("truncated QUIC packet")
+	errNotQUIC        = errors.New("not a QUIC packet")
+	errUnknownVersion = errors.New("unsupported QUIC version")
+	errDecryptFailed  = errors.New("failed to decrypt QUIC Initial packet")
+	errConnIDTooLong  = errors.New("QUIC connection ID too long")
+	errInvalidFrame   = errors.New("invalid QUIC frame")
+)
+
+// maxConnectionIDLen is the maximum length of connection IDs in QUIC version 1.
+const maxConnectionIDLen = 20
+
+// versionString returns the name of a QUIC version.
+func versionString(version uint32) string {
+	switch version {
+	case version1:
+		return "1"
+	case version2:
+		return "2"
+	}
+	if version>>8 == 0xff0000 {
+		return fmt.Sprintf("draft-%d", version&0xff)
+	}
+	return fmt.Sprintf("0x%08x", version)
+}
+
+// packetHeader is a QUIC packet header. Only the fields present in the
+// header of the packet type are set. The connection ID lengths of short
+// header packets are not encoded in the packet, the DCID of short header
+// packets is left empty by parsePacket.
+type packetHeader struct {
+	typ     packetType
+	version uint32
+	dcid    []byte
+	scid    []byte
+	token   []byte
+
+	// offset of the packet number in the packet, and length of the packet
+	// number and payload.
+	pnOffset int
+	length   int
+}
+
+// parsePacket parses the header of the first QUIC packet in data. It returns
+// the header and the length of the packet. Multiple long header packets can be
+// coalesced in a single UDP datagram, short header packets extend to the end
+// of the datagram.
+func parsePacket(data []byte) (*packetHeader, int, error) {
+	if len(data) == 0 {
+		return nil, 0, errTruncated
+	}
+
+	if data[0]&0x80 == 0 {
+		// short header, fixed bit must be set.
+		if data[0]&0x40 == 0 {
+			return nil, 0, errNotQUIC
+		}
+		return &packetHeader{typ: packetShort}, len(data), nil
+	}
+
+	if len(data) < 7 {
+		return nil, 0, errTruncated
+	}
+	hdr := &packetHeader{version: binary.BigEndian.Uint32(data[1:5])}
+	off := 5
+
+	var err error
+	if hdr.dcid, off, err = readConnectionID(data, off); err != nil {
+		return nil, 0, err
+	}
+	if hdr.scid, off, err = readConnectionID(data, off); err != nil {
+		return nil, 0, err
+	}
+
+	if hdr.version == versionNegotiation {
+		hdr.typ = packetVersionNegotiation
+		return hdr, len(data), nil
+	}
+	if data[0]&0x40 == 0 {
+		return nil, 0, errNotQUIC
+	}
+
+	hdr.typ = longPacketType(hdr.version, data[0])
+	switch hdr.typ {
+	case packetRetry:
+		return hdr, len(data), nil
+	case packetInitial:
+		tokenLen, n := readVarint(data[off:])
+		if n == 0 || uint64(len(data)-off-n) < tokenLen {
+			return nil, 0, errTruncated
+		}
+		off += n
+		hdr.token = data[off : off+int(tokenLen)]
+		off += int(tokenLen)
+	}
+
+	length, n := readVarint(data[off:])
+	if n == 0 {
+		return nil, 0, errTruncated
+	}
+	off += n
+	if uint64(len(data)-off) < length {
+		return nil, 0, errTruncated
+	}
+	hdr.pnOffset = off
+	hdr.length = int(length)
+	return hdr, off + int(length), nil
+}
+
+// longPacketType returns the type of a long header packet. QUIC version 2
+// uses different type codes than version 1.
+func longPacketType(version uint32, firstByte byte) packetType {
+	typ := packetType((firstByte >> 4) & 0x03)
+	if version == version2 {
+		return (typ + 3) % 4
+	}
+	return typ
+}
+
+func readConnectionID(data []byte, off int) ([]byte, int, error) {
+	if off >= len(data) {
+		return nil, 0, errTruncated
+	}
+	l := int(data[off])
+	off++
+	if l > maxConnectionIDLen {
+		return nil, 0, errConnIDTooLong
+	}
+	if off+l > len(data) {
+		return nil, 0, errTruncated
+	}
+	return data[off : off+l], off + l, nil
+}
+
+// readVarint reads a QUIC variable-length integer. It returns the value and
+// the number of bytes read, or 0 bytes if data is too short.
+func readVarint(data []byte) (uint64, int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+	l := 1 << (data[0] >> 6)
+	if len(data) < l {
+		return 0, 0
+	}
+	v := uint64(data[0] & 0x3f)
+	for _, b := range data[1:l] {
+		v = v<<8 | uint64(b)
+	}
+	return v, l
+}
+
+// initialKeys are the keys protecting Initial packets in one direction.
+type initialKeys struct {
+	aead cipher.AEAD
+	iv   []byte
+	hp   cipher.Block
+}
+
+var (
+	initialSaltV1      = []byte{0x38, 0x76, 0x2c, 0xf7, 0xf5, 0x59, 0x34, 0xb3, 0x4d, 0x17, 0x9a, 0xe6, 0xa4, 0xc8, 0x0c, 0xad, 0xcc, 0xbb, 0x7f, 0x0a}
+	initialSaltV2      = []byte{0x0d, 0xed, 0xe3, 0xde, 0xf7, 0x00, 0xa6, 0xdb, 0x81, 0x93, 0x81, 0xbe, 0x6e, 0x26, 0x9d, 0xcb, 0xf9, 0xbd, 0x2e, 0xd9}
+	initialSaltDraft29 = []byte{0xaf, 0xbf, 0xec, 0x28, 0x99, 0x93, 0xd2, 0x4c, 0x9e, 0x97, 0x86, 0xf1, 0x9c, 0x61, 0x11, 0xe0, 0x43, 0x90, 0xa8, 0x99}
+)
+
+// newInitialKeys derives the client and server Initial keys from the
+// destination connection ID of the first Initial packet sent by the client,
+// as described in RFC 9001 section 5.2 and RFC 9369 section 3.3.
+func newInitialKeys(version uint32, dcid []byte) (client, server *initialKeys, err error) {
+	var salt []byte
+	labelPrefix := "quic "
+	switch version {
+	case version1:
+		salt = initialSaltV1
+	case version2:
+		salt = initialSaltV2
+		labelPrefix = "quicv2 "
+	case versionDraft29:
+		salt = initialSaltDraft29
+	default:
+		return nil, nil, errUnknownVersion
+	}
+
+	initialSecret := hkdf.Extract(crypto.SHA256.New, dcid, salt)
+	client, err = makeInitialKeys(expandLabel(initialSecret, "client in", 32), labelPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	server, err = makeInitialKeys(expandLabel(initialSecret, "server in", 32), labelPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, server, nil
+}
+
+func makeInitialKeys(secret []byte, labelPrefix string) (*initialKeys, error) {
+	block, err := aes.NewCipher(expandLabel(secret, labelPrefix+"key", 16))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	hp, err := aes.NewCipher(expandLabel(secret, labelPrefix+"hp", 16))
+	if err != nil {
+		return nil, err
+	}
+	return &initialKeys{
+		aead: aead,
+		iv:   expandLabel(secret, labelPrefix+"iv", 12),
+		hp:   hp,
+	}, nil
+}
+
+// expandLabel implements HKDF-Expand-Label of TLS 1.3 with an empty context.
+func expandLabel(secret []byte, label string, length int) []byte {
+	fullLabel := "tls13 " + label
+	info := make([]byte, 2, 4+len(fullLabel))
+	binary.BigEndian.PutUint16(info, uint16(length))
+	info = append(info, byte(len(fullLabel)))
+	info = append(info, fullLabel...)
+	info = append(info, 0)
+
+	out := make([]byte, length)
+	if _, err := hkdf.Expand(crypto.SHA256.New, secret, info).Read(out); err != nil {
+		// Can not happen, the requested lengths are always valid.
+		panic(err)
+	}
+	return out
+}
+
+// decrypt removes the header protection of the long header packet and
+// decrypts its payload. packet must contain exactly the packet described by
+// hdr, it is not modified.
+func (k *initialKeys) decrypt(packet []byte, hdr *packetHeader) ([]byte, error) {
+	const sampleLen = 16
+	sampleOffset := hdr.pnOffset + 4
+	if sampleOffset+sampleLen > len(packet) {
+		return nil, errTruncated
+	}
+
+	mask := make([]byte, aes.BlockSize)
+	k.hp.Encrypt(mask, packet[sampleOffset:sampleOffset+sampleLen])
+
+	header := make([]byte, hdr.pnOffset+4)
+	copy(header, packet)
+	header[0] ^= mask[0] & 0x0f
+	pnLen := int(header[0]&0x03) + 1
+
+	var pn uint64
+	for i := 0; i < pnLen; i++ {
+		header[hdr.pnOffset+i] ^= mask[1+i]
+		pn = pn<<8 | uint64(header[hdr.pnOffset+i])
+	}
+	header = header[:hdr.pnOffset+pnLen]
+
+	nonce := make([]byte, len(k.iv))
+	copy(nonce, k.iv)
+	for i := 0; i < 8; i++ {
+		nonce[len(nonce)-1-i] ^= byte(pn >> (8 * i))
+	}
+
+	payload, err := k.aead.Open(nil, nonce, packet[len(header):], header)
+	if err != nil {
+		return nil, errDecryptFailed
+	}
+	return payload, nil
+}
@@ -0,0 +1,553 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/ecs"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+var (
+	unmatchedPackets = monitoring.NewInt(nil, "quic.unmatched_packets")
+	decryptFailures  = monitoring.NewInt(nil, "quic.decrypt_failures")
+)
+
+func init() {
+	protos.Register("quic", New)
+}
+
+// quicPlugin tracks QUIC connections and reports them once idle.
+//
+// Only the Initial packets of a connection are protected with keys known to
+// an observer, they contain the TLS ClientHello and ServerHello. Subsequent
+// packets are associated to their connection by their address tuple, or by
+// their connection ID when the client migrates to a new address. Connection
+// IDs issued later in the connection are encrypted, migrating connections can
+// only be followed as long as they use the connection IDs of the handshake.
+type quicPlugin struct {
+	ports   []int
+	timeout time.Duration
+
+	// connections holds the tracked connections by their key.
+	connections *common.Cache
+
+	// ids maps the connection IDs and client to server address tuples of the
+	// tracked connections to a *connectionRef.
+	ids *common.Cache
+
+	// cidLengths holds the lengths of the connection IDs chosen by the tracked
+	// connections. Short header packets do not encode the length of their
+	// destination connection ID.
+	mu         sync.Mutex
+	cidLengths map[int]int
+
+	results protos.Reporter
+	watcher procs.ProcessesWatcher
+	log     *logp.Logger
+}
+
+// connectionRef references a connection from the ids cache. toServer is true
+// if the key identifies packets sent from the client to the server.
+type connectionRef struct {
+	conn     *connection
+	toServer bool
+}
+
+// connection is a tracked QUIC connection.
+type connection struct {
+	mu sync.Mutex
+
+	key     string
+	version uint32
+
+	// tuple is the current client to server address tuple.
+	tuple          common.HashableIPPortTuple
+	client, server common.Endpoint
+
+	originalDCID, clientCID, serverCID []byte
+
+	clientKeys, serverKeys     *initialKeys
+	clientCrypto, serverCrypto cryptoStream
+	clientHello, serverHello   *helloMessage
+
+	start, end                   time.Time
+	clientBytes, serverBytes     int64
+	clientPackets, serverPackets int64
+
+	migrations         int
+	retry              bool
+	versionNegotiation bool
+	close              *initialFrames
+	notes              []string
+}
+
+// New constructs a new QUIC protocol plugin.
+func New(testMode bool, results protos.Reporter, watcher procs.ProcessesWatcher, cfg *conf.C) (protos.Plugin, error) {
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	return newPlugin(results, watcher, &config), nil
+}
+
+func newPlugin(results protos.Reporter, watcher procs.ProcessesWatcher, config *quicConfig) *quicPlugin {
+	p := &quicPlugin{
+		ports:      config.Ports,
+		timeout:    config.TransactionTimeout,
+		cidLengths: make(map[int]int),
+		results:    results,
+		watcher:    watcher,
+		log:        logp.NewLogger("quic"),
+	}
+	p.ids = common.NewCache(p.timeout, protos.DefaultTransactionHashSize)
+	p.connections = common.NewCacheWithRemovalListener(
+		p.timeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			conn, ok := v.(*connection)
+			if !ok {
+				p.log.Error("Expired value is not a *connection.")
+				return
+			}
+			p.expireConnection(conn)
+		})
+	p.connections.StartJanitor(p.timeout)
+	p.ids.StartJanitor(p.timeout)
+	return p
+}
+
+func (p *quicPlugin) GetPorts() []int {
+	return p.ports
+}
+
+func (p *quicPlugin) ParseUDP(pkt *protos.Packet) {
+	defer logp.Recover("QUIC ParseUDP exception")
+
+	var (
+		conn       *connection
+		fromClient bool
+	)
+	// Multiple long header packets can be coalesced in a datagram.
+	for data := pkt.Payload; len(data) > 0; {
+		hdr, n, err := parsePacket(data)
+		if err != nil {
+			p.log.Debugw("Dropping datagram: failed parsing QUIC packet", "error", err)
+			break
+		}
+		packet := data[:n]
+		data = data[n:]
+
+		var c *connection
+		if hdr.typ == packetShort {
+			c, fromClient = p.lookupShort(pkt, packet)
+		} else {
+			c, fromClient = p.handleLong(pkt, hdr, packet)
+		}
+		if c == nil {
+			break
+		}
+		conn = c
+	}
+	if conn == nil {
+		unmatchedPackets.Inc()
+		return
+	}
+
+	conn.mu.Lock()
+	migrated := conn.addPacket(pkt, fromClient)
+	if migrated {
+		procTuple := p.watcher.FindProcessesTupleUDP(&pkt.Tuple)
+		conn.setEndpoints(pkt, fromClient, procTuple)
+	}
+	tuple := conn.tuple
+	conn.mu.Unlock()
+
+	if migrated {
+		p.ids.Put(tuple, &connectionRef{conn: conn, toServer: true})
+	}
+}
+
+// handleLong handles a long header packet. It returns the connection it
+// belongs to, if any, and whether it was sent by the client.
+func (p *quicPlugin) handleLong(pkt *protos.Packet, hdr *packetHeader, packet []byte) (*connection, bool) {
+	ref := p.getRef(string(hdr.dcid))
+	if ref == nil {
+		ref = p.lookupTuple(pkt)
+	}
+	if ref == nil {
+		if hdr.typ != packetInitial {
+			return nil, false
+		}
+		conn := p.newConnection(pkt, hdr, packet)
+		return conn, conn != nil
+	}
+
+	conn, fromClient := ref.conn, ref.toServer
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if !fromClient && len(hdr.scid) != 0 && conn.serverCID == nil &&
+		hdr.typ != packetRetry && hdr.typ != packetVersionNegotiation {
+		conn.serverCID = hdr.scid
+		p.addID(hdr.scid, conn, true)
+	}
+
+	switch hdr.typ {
+	case packetInitial:
+		keys := conn.clientKeys
+		if !fromClient {
+			keys = conn.serverKeys
+		}
+		if keys == nil {
+			break
+		}
+		payload, err := keys.decrypt(packet, hdr)
+		if err != nil {
+			decryptFailures.Inc()
+			p.log.Debugw("Failed decrypting QUIC Initial packet", "error", err)
+			break
+		}
+		if err := conn.handleInitial(payload, fromClient); err != nil {
+			p.log.Debugw("Failed parsing QUIC Initial packet", "error", err)
+		}
+	case packetRetry:
+		if fromClient || len(hdr.scid) == 0 {
+			break
+		}
+		// The client restarts the handshake using the connection ID chosen by
+		// the server, which the Initial keys are derived from.
+		conn.retry = true
+		conn.clientCrypto = cryptoStream{}
+		conn.clientKeys, conn.serverKeys, _ = newInitialKeys(conn.version, hdr.scid)
+		p.addID(hdr.scid, conn, true)
+	case packetVersionNegotiation:
+		if !fromClient {
+			conn.versionNegotiation = true
+		}
+	}
+	return conn, fromClient
+}
+
+// newConnection starts tracking a connection from the first Initial packet
+// of the client. It returns nil if packet can not be decrypted as an Initial
+// packet.
+func (p *quicPlugin) newConnection(pkt *protos.Packet, hdr *packetHeader, packet []byte) *connection {
+	clientKeys, serverKeys, err := newInitialKeys(hdr.version, hdr.dcid)
+	if err != nil {
+		p.log.Debugw("Dropping packet: QUIC version not supported", "version", versionString(hdr.version))
+		return nil
+	}
+	payload, err := clientKeys.decrypt(packet, hdr)
+	if err != nil {
+		decryptFailures.Inc()
+		p.log.Debugw("Dropping packet: failed decrypting QUIC Initial packet", "error", err)
+		return nil
+	}
+
+	conn := &connection{
+		key:          hex.EncodeToString(hdr.dcid) + "-" + pkt.Tuple.String(),
+		version:      hdr.version,
+		tuple:        pkt.Tuple.Hashable(),
+		originalDCID: hdr.dcid,
+		clientCID:    hdr.scid,
+		clientKeys:   clientKeys,
+		serverKeys:   serverKeys,
+		start:        pkt.Ts,
+	}
+	conn.setEndpoints(pkt, true, p.watcher.FindProcessesTupleUDP(&pkt.Tuple))
+	if err := conn.handleInitial(payload, true); err != nil {
+		p.log.Debugw("Failed parsing QUIC Initial packet", "error", err)
+	}
+
+	p.connections.Put(conn.key, conn)
+	p.ids.Put(conn.tuple, &connectionRef{conn: conn, toServer: true})
+	p.addID(hdr.dcid, conn, true)
+	p.addID(hdr.scid, conn, false)
+	return conn
+}
+
+// lookupShort returns the connection of a short header packet and whether it
+// was sent by the client.
+func (p *quicPlugin) lookupShort(pkt *protos.Packet, packet []byte) (*connection, bool) {
+	if ref := p.lookupTuple(pkt); ref != nil {
+		return ref.conn, ref.toServer
+	}
+
+	// The packet is sent from a new address, look for its connection ID.
+	p.mu.Lock()
+	lengths := make([]int, 0, len(p.cidLengths))
+	for l := range p.cidLengths {
+		lengths = append(lengths, l)
+	}
+	p.mu.Unlock()
+
+	for _, l := range lengths {
+		if len(packet) < 1+l {
+			continue
+		}
+		if ref := p.getRef(string(packet[1 : 1+l])); ref != nil {
+			return ref.conn, ref.toServer
+		}
+	}
+	return nil, false
+}
+
+// lookupTuple returns the reference of the connection using the address tuple
+// of the packet in either direction.
+func (p *quicPlugin) lookupTuple(pkt *protos.Packet) *connectionRef {
+	if ref := p.getRef(pkt.Tuple.Hashable()); ref != nil {
+		return ref
+	}
+	if ref := p.getRef(pkt.Tuple.RevHashable()); ref != nil {
+		return &connectionRef{conn: ref.conn, toServer: false}
+	}
+	return nil
+}
+
+// getRef returns the connection reference stored for key, if the connection is
+// still tracked.
+func (p *quicPlugin) getRef(key common.Key) *connectionRef {
+	if key == "" {
+		return nil
+	}
+	v := p.ids.Get(key)
+	if v == nil {
+		return nil
+	}
+	ref := v.(*connectionRef)
+	if p.connections.Get(ref.conn.key) == nil {
+		p.ids.Delete(key)
+		return nil
+	}
+	return ref
+}
+
+// addID registers a connection ID of conn. toServer is true if the
+// connection ID is used by the client to send packets to the server.
+func (p *quicPlugin) addID(id []byte, conn *connection, toServer bool) {
+	if len(id) == 0 {
+		return
+	}
+	p.ids.Put(string(id), &connectionRef{conn: conn, toServer: toServer})
+
+	p.mu.Lock()
+	p.cidLengths[len(id)]++
+	p.mu.Unlock()
+}
+
+func (p *quicPlugin) expireConnection(conn *connection) {
+	p.mu.Lock()
+	for _, id := range [][]byte{conn.originalDCID, conn.clientCID, conn.serverCID} {
+		if len(id) == 0 {
+			continue
+		}
+		if p.cidLengths[len(id)]--; p.cidLengths[len(id)] <= 0 {
+			delete(p.cidLengths, len(id))
+		}
+	}
+	p.mu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	p.publishConnection(conn)
+}
+
+// handleInitial processes the frames of a decrypted Initial packet.
+func (c *connection) handleInitial(payload []byte, fromClient bool) error {
+	var frames initialFrames
+	err := parseInitialFrames(payload, &frames)
+
+	stream, hello := &c.clientCrypto, &c.clientHello
+	if !fromClient {
+		stream, hello = &c.serverCrypto, &c.serverHello
+	}
+	for _, f := range frames.crypto {
+		stream.add(f)
+	}
+	if *hello == nil {
+		if typ, body, ok := stream.handshakeMessage(); ok {
+			if (fromClient && typ == handshakeClientHello) || (!fromClient && typ == handshakeServerHello) {
+				if *hello, err = parseHello(typ, body); err != nil {
+					c.notes = append(c.notes, fmt.Sprintf("failed parsing TLS hello: %v", err))
+				}
+			}
+		}
+	}
+	if frames.closed {
+		c.close = &frames
+	}
+	return err
+}
+
+// addPacket updates the statistics of the connection with a packet. It
+// returns true if the packet was sent on a new path.
+func (c *connection) addPacket(pkt *protos.Packet, fromClient bool) bool {
+	c.end = pkt.Ts
+	if fromClient {
+		c.clientBytes += int64(len(pkt.Payload))
+		c.clientPackets++
+	} else {
+		c.serverBytes += int64(len(pkt.Payload))
+		c.serverPackets++
+	}
+
+	tuple := pkt.Tuple.Hashable()
+	if !fromClient {
+		tuple = pkt.Tuple.RevHashable()
+	}
+	if tuple == c.tuple {
+		return false
+	}
+	c.tuple = tuple
+	c.migrations++
+	return true
+}
+
+func (c *connection) setEndpoints(pkt *protos.Packet, fromClient bool, procTuple *common.ProcessTuple) {
+	src, dst := common.MakeEndpointPair(pkt.Tuple.BaseTuple, procTuple)
+	if fromClient {
+		c.client, c.server = src, dst
+	} else {
+		c.client, c.server = dst, src
+	}
+}
+
+func (p *quicPlugin) publishConnection(c *connection) {
+	if p.results == nil {
+		return
+	}
+
+	evt, pbf := pb.NewBeatEvent(c.start)
+	pbf.SetSource(&c.client)
+	pbf.SetDestination(&c.server)
+	pbf.Source.Bytes = c.clientBytes
+	pbf.Source.Packets = c.clientPackets
+	pbf.Destination.Bytes = c.serverBytes
+	pbf.Destination.Packets = c.serverPackets
+	pbf.Event.Start = c.start
+	pbf.Event.End = c.end
+	pbf.Event.Dataset = "quic"
+	pbf.Network.Transport = "udp"
+	pbf.Network.Protocol = "quic"
+
+	fields := evt.Fields
+	fields["type"] = "quic"
+	fields["status"] = common.OK_STATUS
+
+	quic := mapstr.M{
+		"version":    versionString(c.version),
+		"migrations": c.migrations,
+		"retry":      c.retry,
+	}
+	fields["quic"] = quic
+	connectionID := mapstr.M{
+		"original_destination": hex.EncodeToString(c.originalDCID),
+	}
+	if len(c.clientCID) != 0 {
+		connectionID["client"] = hex.EncodeToString(c.clientCID)
+	}
+	if len(c.serverCID) != 0 {
+		connectionID["server"] = hex.EncodeToString(c.serverCID)
+	}
+	quic["connection_id"] = connectionID
+
+	notes := c.notes
+	switch {
+	case c.close != nil:
+		fields["status"] = common.ERROR_STATUS
+		quic["close"] = mapstr.M{
+			"error_code": c.close.closeErrorCode,
+			"reason":     c.close.closeReason,
+		}
+		notes = append(notes, fmt.Sprintf("connection closed during handshake with error code 0x%x", c.close.closeErrorCode))
+	case c.versionNegotiation:
+		fields["status"] = common.ERROR_STATUS
+		quic["version_negotiation"] = true
+		notes = append(notes, "server does not support QUIC version "+versionString(c.version))
+	case c.serverPackets == 0:
+		fields["status"] = common.ERROR_STATUS
+		notes = append(notes, "no response from server")
+	}
+	pbf.Error.Message = notes
+
+	tlsFields := ecs.Tls{
+		VersionProtocol: "tls",
+		Version:         "1.3",
+	}
+	if hello := c.clientHello; hello != nil {
+		tlsFields.ClientServerName = hello.serverName
+		pbf.Destination.Domain = hello.serverName
+		tlsFields.ClientSupportedCiphers = cipherSuiteNames(hello.cipherSuites)
+		if len(hello.alpn) != 0 {
+			quic["alpn"] = hello.alpn
+			if isHTTP3(hello.alpn) {
+				pbf.Network.Protocol = "http3"
+			}
+		}
+	}
+	if hello := c.serverHello; hello != nil && len(hello.cipherSuites) != 0 {
+		tlsFields.Cipher = tls.CipherSuiteName(hello.cipherSuites[0])
+		tlsFields.Established = c.clientPackets > 0 && c.serverPackets > 0 && c.close == nil
+	}
+	if err := pb.MarshalStruct(fields, "tls", tlsFields); err != nil {
+		p.log.Errorw("Failed adding TLS fields to QUIC event", "error", err)
+	}
+
+	p.results(evt)
+}
+
+// isHTTP3 returns true if all the ALPN protocols offered by the client are
+// HTTP/3 variants, such as h3 or h3-29.
+func isHTTP3(alpn []string) bool {
+	for _, proto := range alpn {
+		if proto != "h3" && !strings.HasPrefix(proto, "h3-") {
+			return false
+		}
+	}
+	return len(alpn) != 0
+}
+
+// cipherSuiteNames returns the names of the cipher suites, ignoring GREASE
+// values.
+func cipherSuiteNames(suites []uint16) []string {
+	names := make([]string, 0, len(suites))
+	for _, suite := range suites {
+		if suite&0x0f0f == 0x0a0a && suite>>8 == suite&0xff {
+			continue
+		}
+		names = append(names, tls.CipherSuiteName(suite))
+	}
+	return names
+}
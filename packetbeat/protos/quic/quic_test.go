@@ -0,0 +1,342 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"crypto/aes"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+)
+
+var _ protos.UDPPlugin = &quicPlugin{}
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	if _, err := publish.MarshalPacketbeatFields(&event, nil, nil); err != nil {
+		panic(err)
+	}
+	e.events = append(e.events, event)
+}
+
+var (
+	clientTuple = common.NewIPPortTuple(4, net.ParseIP("10.0.0.1"), 50000, net.ParseIP("10.0.0.2"), 443)
+	serverTuple = common.NewIPPortTuple(4, net.ParseIP("10.0.0.2"), 443, net.ParseIP("10.0.0.1"), 50000)
+	// the client migrates to a new port
+	migratedTuple = common.NewIPPortTuple(4, net.ParseIP("10.0.0.1"), 50001, net.ParseIP("10.0.0.2"), 443)
+
+	originalDCID = []byte{0x83, 0x94, 0xc8, 0xf0, 0x3e, 0x51, 0x57, 0x08}
+	clientCID    = []byte{0xc1, 0xc2, 0xc3, 0xc4}
+	serverCID    = []byte{0x51, 0x52, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58}
+)
+
+// Server Initial packet from RFC 9001 appendix A.3.
+const rfc9001ServerInitial = "cf000000010008f067a5502a4262b5004075c0d95a482cd0991cd25b0aac406a" +
+	"5816b6394100f37a1c69797554780bb38cc5a99f5ede4cf73c3ec2493a1839b3" +
+	"dbcba3f6ea46c5b7684df3548e7ddeb9c3bf9c73cc3f3bded74b562bfb19fb84" +
+	"022f8ef4cdd93795d77d06edbb7aaf2f58891850abbdca3d20398c276456cbc4" +
+	"2158407dd074ee"
+
+func TestInitialKeys(t *testing.T) {
+	// Keys from RFC 9001 appendix A.1.
+	initialSecret := "7db5df06e7a69e432496adedb00851923595221596ae2ae9fb8115c1e9ed0a44"
+	secret, err := hex.DecodeString(initialSecret)
+	require.NoError(t, err)
+
+	client := expandLabel(secret, "client in", 32)
+	assert.Equal(t, "1f369613dd76d5467730efcbe3b1a22d", hex.EncodeToString(expandLabel(client, "quic key", 16)))
+	assert.Equal(t, "fa044b2f42a3fd3b46fb255c", hex.EncodeToString(expandLabel(client, "quic iv", 12)))
+	assert.Equal(t, "9f50449e04a0e810283a1e9933adedd2", hex.EncodeToString(expandLabel(client, "quic hp", 16)))
+
+	server := expandLabel(secret, "server in", 32)
+	assert.Equal(t, "cf3a5331653c364c88f0f379b6067e37", hex.EncodeToString(expandLabel(server, "quic key", 16)))
+	assert.Equal(t, "0ac1493ca1905853b0bba03e", hex.EncodeToString(expandLabel(server, "quic iv", 12)))
+	assert.Equal(t, "c206b8d9b9f0f37644430b490eeaa314", hex.EncodeToString(expandLabel(server, "quic hp", 16)))
+}
+
+func TestDecryptServerInitial(t *testing.T) {
+	packet, err := hex.DecodeString(rfc9001ServerInitial)
+	require.NoError(t, err)
+
+	hdr, n, err := parsePacket(packet)
+	require.NoError(t, err)
+	assert.Equal(t, len(packet), n)
+	assert.Equal(t, packetInitial, hdr.typ)
+	assert.Equal(t, version1, hdr.version)
+	assert.Empty(t, hdr.dcid)
+	assert.Equal(t, "f067a5502a4262b5", hex.EncodeToString(hdr.scid))
+
+	_, serverKeys, err := newInitialKeys(hdr.version, originalDCID)
+	require.NoError(t, err)
+	payload, err := serverKeys.decrypt(packet, hdr)
+	require.NoError(t, err)
+
+	var frames initialFrames
+	require.NoError(t, parseInitialFrames(payload, &frames))
+	require.Len(t, frames.crypto, 1)
+
+	var stream cryptoStream
+	stream.add(frames.crypto[0])
+	typ, body, ok := stream.handshakeMessage()
+	require.True(t, ok)
+	assert.EqualValues(t, handshakeServerHello, typ)
+
+	hello, err := parseHello(typ, body)
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{0x1301}, hello.cipherSuites)
+	assert.Equal(t, []uint16{0x0304}, hello.versions)
+}
+
+func TestParsePacketErrors(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"empty":            {},
+		"fixed bit unset":  {0x00, 0x01, 0x02},
+		"truncated header": {0xc0, 0x00, 0x00},
+		"long dcid":        {0xc0, 0x00, 0x00, 0x00, 0x01, 0x15},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := parsePacket(data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConnection(t *testing.T) {
+	store := &eventStore{}
+	p := newPlugin(store.publish, procs.ProcessesWatcher{}, &defaultConfig)
+
+	clientKeys, serverKeys, err := newInitialKeys(version1, originalDCID)
+	require.NoError(t, err)
+
+	// The ClientHello is split in two Initial packets, received out of order.
+	clientHello := makeClientHello("example.com", []string{"h3"})
+	split := len(clientHello) / 2
+	ts := time.Now()
+	p.ParseUDP(newPacket(ts, clientTuple, makeInitial(clientKeys, originalDCID, clientCID, 1, split, clientHello[split:])))
+	p.ParseUDP(newPacket(ts, clientTuple, makeInitial(clientKeys, originalDCID, clientCID, 0, 0, clientHello[:split])))
+	p.ParseUDP(newPacket(ts, serverTuple, makeInitial(serverKeys, clientCID, serverCID, 0, 0, makeServerHello())))
+
+	// 1-RTT packets, including one sent by the client from a new address.
+	p.ParseUDP(newPacket(ts, clientTuple, makeShort(serverCID)))
+	p.ParseUDP(newPacket(ts, serverTuple, makeShort(clientCID)))
+	p.ParseUDP(newPacket(ts.Add(time.Second), migratedTuple, makeShort(serverCID)))
+
+	// Short header packet of an unknown connection.
+	otherTuple := common.NewIPPortTuple(4, net.ParseIP("10.0.0.3"), 50000, net.ParseIP("10.0.0.2"), 443)
+	p.ParseUDP(newPacket(ts, otherTuple, []byte{0x40, 0x01, 0x02}))
+
+	entries := p.connections.Entries()
+	require.Len(t, entries, 1)
+	for _, v := range entries {
+		p.expireConnection(v.(*connection))
+	}
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+
+	expected := map[string]interface{}{
+		"type":                "quic",
+		"status":              common.OK_STATUS,
+		"network.protocol":    "http3",
+		"network.transport":   "udp",
+		"source.ip":           "10.0.0.1",
+		"source.port":         int64(50001),
+		"source.packets":      int64(4),
+		"destination.ip":      "10.0.0.2",
+		"destination.port":    int64(443),
+		"destination.domain":  "example.com",
+		"destination.packets": int64(2),
+		"quic.version":        "1",
+		"quic.migrations":     1,
+		"quic.retry":          false,
+		"quic.alpn":           []string{"h3"},
+		"quic.connection_id.original_destination": hex.EncodeToString(originalDCID),
+		"quic.connection_id.client":               hex.EncodeToString(clientCID),
+		"quic.connection_id.server":               hex.EncodeToString(serverCID),
+		"tls.client.server_name":                  "example.com",
+		"tls.client.supported_ciphers":            []string{"TLS_AES_128_GCM_SHA256", "TLS_CHACHA20_POLY1305_SHA256"},
+		"tls.cipher":                              "TLS_AES_128_GCM_SHA256",
+		"tls.established":                         true,
+		"tls.version":                             "1.3",
+		"tls.version_protocol":                    "tls",
+	}
+	for k, v := range expected {
+		actual, err := fields.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.EqualValues(t, v, actual, k)
+		}
+	}
+}
+
+func TestNoResponse(t *testing.T) {
+	store := &eventStore{}
+	p := newPlugin(store.publish, procs.ProcessesWatcher{}, &defaultConfig)
+
+	clientKeys, _, err := newInitialKeys(version1, originalDCID)
+	require.NoError(t, err)
+	p.ParseUDP(newPacket(time.Now(), clientTuple, makeInitial(clientKeys, originalDCID, clientCID, 0, 0, makeClientHello("example.com", []string{"h3", "hq-interop"}))))
+
+	for _, v := range p.connections.Entries() {
+		p.expireConnection(v.(*connection))
+	}
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, common.ERROR_STATUS, fields["status"])
+	protocol, _ := fields.GetValue("network.protocol")
+	assert.Equal(t, "quic", protocol)
+	message, _ := fields.GetValue("error.message")
+	assert.Equal(t, "no response from server", message)
+}
+
+func TestUndecryptableInitial(t *testing.T) {
+	store := &eventStore{}
+	p := newPlugin(store.publish, procs.ProcessesWatcher{}, &defaultConfig)
+
+	// Keys derived from another connection ID.
+	clientKeys, _, err := newInitialKeys(version1, serverCID)
+	require.NoError(t, err)
+	p.ParseUDP(newPacket(time.Now(), clientTuple, makeInitial(clientKeys, originalDCID, clientCID, 0, 0, makeClientHello("example.com", nil))))
+
+	assert.Empty(t, p.connections.Entries())
+}
+
+func newPacket(ts time.Time, tuple common.IPPortTuple, payload []byte) *protos.Packet {
+	return &protos.Packet{
+		Ts:      ts,
+		Tuple:   tuple,
+		Payload: payload,
+	}
+}
+
+// makeInitial returns a protected version 1 Initial packet with a CRYPTO frame
+// of the handshake data at the given offset.
+func makeInitial(keys *initialKeys, dcid, scid []byte, pn uint8, offset int, data []byte) []byte {
+	payload := []byte{frameCrypto}
+	payload = appendVarint(payload, uint64(offset))
+	payload = appendVarint(payload, uint64(len(data)))
+	payload = append(payload, data...)
+	// padding
+	payload = append(payload, make([]byte, 32)...)
+
+	header := []byte{0xc0}
+	header = appendUint32(header, version1)
+	header = append(header, byte(len(dcid)))
+	header = append(header, dcid...)
+	header = append(header, byte(len(scid)))
+	header = append(header, scid...)
+	header = append(header, 0) // token length
+	header = appendUint16(header, 0x4000|uint16(1+len(payload)+keys.aead.Overhead()))
+	pnOffset := len(header)
+	header = append(header, pn)
+
+	nonce := make([]byte, len(keys.iv))
+	copy(nonce, keys.iv)
+	nonce[len(nonce)-1] ^= pn
+	packet := keys.aead.Seal(header, nonce, payload, header)
+
+	mask := make([]byte, aes.BlockSize)
+	keys.hp.Encrypt(mask, packet[pnOffset+4:pnOffset+20])
+	packet[0] ^= mask[0] & 0x0f
+	packet[pnOffset] ^= mask[1]
+	return packet
+}
+
+// makeShort returns a short header packet with random looking content.
+func makeShort(dcid []byte) []byte {
+	packet := append([]byte{0x41}, dcid...)
+	for i := 0; i < 32; i++ {
+		packet = append(packet, byte(i*7))
+	}
+	return packet
+}
+
+func makeClientHello(serverName string, alpn []string) []byte {
+	body := []byte{0x03, 0x03}
+	body = append(body, make([]byte, 32)...) // random
+	body = append(body, 0)                   // session ID
+	body = append(body, 0x00, 0x06, 0x0a, 0x0a, 0x13, 0x01, 0x13, 0x03)
+	body = append(body, 0x01, 0x00) // compression methods
+
+	var extensions []byte
+	sni := []byte{0}
+	sni = appendUint16(sni, uint16(len(serverName)))
+	sni = append(sni, serverName...)
+	extensions = appendExtension(extensions, extServerName, appendList16(nil, sni))
+	if len(alpn) != 0 {
+		var list []byte
+		for _, proto := range alpn {
+			list = append(list, byte(len(proto)))
+			list = append(list, proto...)
+		}
+		extensions = appendExtension(extensions, extALPN, appendList16(nil, list))
+	}
+	extensions = appendExtension(extensions, extSupportedVersions, []byte{0x02, 0x03, 0x04})
+	body = appendList16(body, extensions)
+
+	return makeHandshake(handshakeClientHello, body)
+}
+
+func makeServerHello() []byte {
+	body := []byte{0x03, 0x03}
+	body = append(body, make([]byte, 32)...) // random
+	body = append(body, 0)                   // session ID
+	body = append(body, 0x13, 0x01, 0x00)    // cipher suite and compression method
+	body = appendList16(body, appendExtension(nil, extSupportedVersions, []byte{0x03, 0x04}))
+	return makeHandshake(handshakeServerHello, body)
+}
+
+func makeHandshake(typ uint8, body []byte) []byte {
+	return append([]byte{typ, byte(len(body) >> 16), byte(len(body) >> 8), byte(len(body))}, body...)
+}
+
+func appendExtension(b []byte, code uint16, data []byte) []byte {
+	b = appendUint16(b, code)
+	return appendList16(b, data)
+}
+
+func appendList16(b []byte, data []byte) []byte {
+	b = appendUint16(b, uint16(len(data)))
+	return append(b, data...)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	if v < 64 {
+		return append(b, byte(v))
+	}
+	return appendUint16(b, 0x4000|uint16(v))
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
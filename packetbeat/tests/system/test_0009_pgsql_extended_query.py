@@ -16,4 +16,5 @@ class Test(BaseTest):
         assert o["method"] == "SELECT"
         assert o["query"] == "SELECT * from test where id = $1"
         assert o["source.bytes"] == 90
-        assert o["destination.bytes"] == 101
+        assert o["destination.bytes"] == 111
+        assert o["params"] == ["\\x00000001"]
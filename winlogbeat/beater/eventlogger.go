@@ -40,6 +40,7 @@ type eventLogger struct {
 	eventMeta  mapstr.EventMetadata
 	processors beat.ProcessorList
 	keepNull   bool
+	computers  *computerFilter
 	log        *logp.Logger
 }
 
@@ -51,8 +52,26 @@ type eventLoggerConfig struct {
 
 	// KeepNull determines whether published events will keep null values or omit them.
 	KeepNull bool `config:"keep_null"`
+
+	Name            string                `config:"name"`
+	Forwarded       *bool                 `config:"forwarded"`
+	SourceComputers sourceComputersConfig `config:"source_computers"`
+}
+
+// isForwarded returns true if the event log contains events forwarded from
+// other computers.
+func (c eventLoggerConfig) isForwarded() bool {
+	if c.Forwarded != nil {
+		return *c.Forwarded
+	}
+	return c.Name == "ForwardedEvents"
 }
 
+// forwardedTag is the tag added to the events of forwarded event logs. It
+// prevents the default add_host_metadata processor from replacing the host
+// fields of the source computer with those of the collector.
+const forwardedTag = "forwarded"
+
 func newEventLogger(
 	beatInfo beat.Info,
 	source eventlog.EventLog,
@@ -69,11 +88,17 @@ func newEventLogger(
 		return nil, err
 	}
 
+	if config.isForwarded() && !containsString(config.Tags, forwardedTag) {
+		config.Tags = append(config.Tags, forwardedTag)
+	}
+
+	log = log.With("id", source.Name())
 	return &eventLogger{
 		source:     source,
 		eventMeta:  config.EventMetadata,
 		processors: processors,
-		log:        log.With("id", source.Name()),
+		computers:  newComputerFilter(config.SourceComputers, log),
+		log:        log,
 	}, nil
 }
 
@@ -178,6 +203,21 @@ runLoop:
 				continue
 			}
 
+			if e.computers != nil {
+				n := len(records)
+				records = e.computers.filter(records)
+				if dropped := n - len(records); dropped > 0 {
+					addDropped(api.Name(), dropped)
+					e.log.Debugf("Dropped %d records from filtered source computers.", dropped)
+				}
+				if len(records) == 0 {
+					if stop {
+						return
+					}
+					continue
+				}
+			}
+
 			eventACKer.Add(len(records))
 			for _, lr := range records {
 				client.Publish(lr.ToEvent())
@@ -212,3 +252,12 @@ func processorsForConfig(
 
 	return procs, nil
 }
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
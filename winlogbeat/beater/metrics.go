@@ -23,11 +23,13 @@ import "expvar"
 // enable through configuration in order for the web service to be started.
 var (
 	publishedEvents = expvar.NewMap("published_events")
+	droppedEvents   = expvar.NewMap("dropped_events")
 )
 
 func initMetrics(namespace string) {
 	// Initialize metrics.
 	publishedEvents.Add(namespace, 0)
+	droppedEvents.Add(namespace, 0)
 }
 
 func addPublished(namespace string, n int) {
@@ -35,3 +37,9 @@ func addPublished(namespace string, n int) {
 	publishedEvents.Add("total", numEvents)
 	publishedEvents.Add(namespace, numEvents)
 }
+
+func addDropped(namespace string, n int) {
+	numEvents := int64(n)
+	droppedEvents.Add("total", numEvents)
+	droppedEvents.Add(namespace, numEvents)
+}
@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/time/rate"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/elastic-agent-libs/logp"
+
+	"github.com/elastic/beats/v7/winlogbeat/eventlog"
+)
+
+// sourceComputersConfig contains the controls applied to events based on the
+// computer that generated them. They are meant for forwarded event logs that
+// receive the events of many computers.
+type sourceComputersConfig struct {
+	Include   []match.Matcher `config:"include"`    // Computer names to publish events from.
+	Exclude   []match.Matcher `config:"exclude"`    // Computer names to drop events from.
+	RateLimit *eventRate      `config:"rate_limit"` // Maximum rate of events published per computer.
+}
+
+// eventRate is a number of events per unit of time, e.g. "100/s".
+type eventRate struct {
+	perSecond float64
+}
+
+// Unpack sets the rate from a string formatted as "number/unit" where unit is
+// s, m or h.
+func (r *eventRate) Unpack(str string) error {
+	value, unit, found := strings.Cut(str, "/")
+	if !found {
+		return fmt.Errorf(`rate in invalid format: %v. Must be specified as "number/unit"`, str)
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || v <= 0 {
+		return fmt.Errorf("rate's value component must be a positive number: %v", value)
+	}
+
+	switch strings.TrimSpace(unit) {
+	case "s":
+		r.perSecond = v
+	case "m":
+		r.perSecond = v / 60
+	case "h":
+		r.perSecond = v / (60 * 60)
+	default:
+		return fmt.Errorf("rate's unit component must be specified as one of: /s, /m, /h")
+	}
+	return nil
+}
+
+// computerFilter drops the events of the computers that are not included,
+// or that exceed their rate limit.
+type computerFilter struct {
+	include []match.Matcher
+	exclude []match.Matcher
+
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter // Rate limiter by computer name.
+	limited  map[string]struct{}      // Computers that exceeded their rate limit.
+
+	log *logp.Logger
+}
+
+func newComputerFilter(config sourceComputersConfig, log *logp.Logger) *computerFilter {
+	if len(config.Include) == 0 && len(config.Exclude) == 0 && config.RateLimit == nil {
+		return nil
+	}
+
+	f := &computerFilter{
+		include: config.Include,
+		exclude: config.Exclude,
+		log:     log,
+	}
+	if config.RateLimit != nil {
+		f.limit = rate.Limit(config.RateLimit.perSecond)
+		f.burst = int(math.Max(1, math.Ceil(config.RateLimit.perSecond)))
+		f.limiters = map[string]*rate.Limiter{}
+		f.limited = map[string]struct{}{}
+	}
+	return f
+}
+
+// filter returns the records that must be published. The records are
+// filtered in place.
+func (f *computerFilter) filter(records []eventlog.Record) []eventlog.Record {
+	k := 0
+	for _, r := range records {
+		if !f.allow(r.Computer) {
+			continue
+		}
+		records[k] = r
+		k++
+	}
+	return records[:k]
+}
+
+func (f *computerFilter) allow(computer string) bool {
+	if len(f.include) > 0 && !matchAny(f.include, computer) {
+		return false
+	}
+	if matchAny(f.exclude, computer) {
+		return false
+	}
+	if f.limiters == nil {
+		return true
+	}
+
+	limiter, found := f.limiters[computer]
+	if !found {
+		limiter = rate.NewLimiter(f.limit, f.burst)
+		f.limiters[computer] = limiter
+	}
+	if !limiter.Allow() {
+		if _, found := f.limited[computer]; !found {
+			f.limited[computer] = struct{}{}
+			f.log.Warnw("Source computer exceeded its rate limit. Its events over the limit will be dropped.", "computer", computer)
+		}
+		return false
+	}
+	return true
+}
+
+func matchAny(matchers []match.Matcher, s string) bool {
+	for _, m := range matchers {
+		if m.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+
+	"github.com/elastic/beats/v7/winlogbeat/checkpoint"
+	"github.com/elastic/beats/v7/winlogbeat/eventlog"
+	"github.com/elastic/beats/v7/winlogbeat/sys/winevent"
+)
+
+func TestEventRateUnpack(t *testing.T) {
+	testCases := map[string]struct {
+		in        string
+		perSecond float64
+		err       bool
+	}{
+		"per second": {in: "100/s", perSecond: 100},
+		"per minute": {in: "120/m", perSecond: 2},
+		"per hour":   {in: "3600/h", perSecond: 1},
+		"no unit":    {in: "100", err: true},
+		"bad unit":   {in: "100/d", err: true},
+		"bad value":  {in: "x/s", err: true},
+		"zero":       {in: "0/s", err: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var r eventRate
+			err := r.Unpack(tc.in)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.perSecond, r.perSecond)
+		})
+	}
+}
+
+func TestComputerFilter(t *testing.T) {
+	records := func(computers ...string) []eventlog.Record {
+		var rs []eventlog.Record
+		for _, c := range computers {
+			rs = append(rs, eventlog.Record{Event: winevent.Event{Computer: c}})
+		}
+		return rs
+	}
+	computers := func(rs []eventlog.Record) []string {
+		var cs []string
+		for _, r := range rs {
+			cs = append(cs, r.Computer)
+		}
+		return cs
+	}
+
+	testCases := map[string]struct {
+		config   string
+		in       []eventlog.Record
+		expected []string
+	}{
+		"include": {
+			config:   "source_computers.include: ['^dc[0-9]+\\.']",
+			in:       records("dc1.example.com", "ws1.example.com", "dc2.example.com"),
+			expected: []string{"dc1.example.com", "dc2.example.com"},
+		},
+		"exclude": {
+			config:   "source_computers.exclude: ['^ws']",
+			in:       records("dc1.example.com", "ws1.example.com"),
+			expected: []string{"dc1.example.com"},
+		},
+		"include and exclude": {
+			config: `
+source_computers.include: ['\.example\.com$']
+source_computers.exclude: ['^ws2\.']
+`,
+			in:       records("ws1.example.com", "ws2.example.com", "ws3.example.org"),
+			expected: []string{"ws1.example.com"},
+		},
+		"rate limit by computer": {
+			config:   "source_computers.rate_limit: 2/s",
+			in:       records("a", "a", "b", "a", "b", "b", "c"),
+			expected: []string{"a", "a", "b", "b", "c"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config, err := eventLoggerConfigFromString(tc.config)
+			require.NoError(t, err)
+
+			f := newComputerFilter(config.SourceComputers, logp.NewLogger("test"))
+			require.NotNil(t, f)
+			assert.Equal(t, tc.expected, computers(f.filter(tc.in)))
+		})
+	}
+}
+
+func TestComputerFilterDisabled(t *testing.T) {
+	config, err := eventLoggerConfigFromString("name: ForwardedEvents")
+	require.NoError(t, err)
+	assert.Nil(t, newComputerFilter(config.SourceComputers, logp.NewLogger("test")))
+}
+
+func TestForwardedTag(t *testing.T) {
+	testCases := map[string]struct {
+		config   string
+		expected []string
+	}{
+		"ForwardedEvents":    {config: "name: ForwardedEvents", expected: []string{"forwarded"}},
+		"already tagged":     {config: "{name: ForwardedEvents, tags: [forwarded]}", expected: []string{"forwarded"}},
+		"forwarded option":   {config: "{name: Custom, forwarded: true, tags: [a]}", expected: []string{"a", "forwarded"}},
+		"forwarded disabled": {config: "{name: ForwardedEvents, forwarded: false}"},
+		"not forwarded":      {config: "name: Application"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			options, err := conf.NewConfigFrom(tc.config)
+			require.NoError(t, err)
+
+			e, err := newEventLogger(beat.Info{}, stubEventLog{}, options, logp.NewLogger("test"))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, e.eventMeta.Tags)
+		})
+	}
+}
+
+type stubEventLog struct{}
+
+func (stubEventLog) Open(checkpoint.EventLogState) error { return nil }
+func (stubEventLog) Read() ([]eventlog.Record, error)    { return nil, nil }
+func (stubEventLog) Close() error                        { return nil }
+func (stubEventLog) Name() string                        { return "test" }
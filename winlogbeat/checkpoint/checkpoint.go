@@ -63,6 +63,7 @@ type EventLogState struct {
 	RecordNumber uint64    `yaml:"record_number"`
 	Timestamp    time.Time `yaml:"timestamp"`
 	Bookmark     string    `yaml:"bookmark,omitempty"`
+	File         string    `yaml:"file,omitempty"` // File being read when reading a directory of .evtx files.
 }
 
 // NewCheckpoint creates and returns a new Checkpoint. This method loads state
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eventlog
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// evtxFiles returns the paths of the .evtx files in dir sorted by name.
+func evtxFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && strings.EqualFold(filepath.Ext(e.Name()), ".evtx") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	return paths, nil
+}
+
+// nextEvtxFile returns the path of the first .evtx file in dir whose name
+// sorts after the name of current. It returns an empty string if there is no
+// such file. If current is empty the first file of dir is returned.
+func nextEvtxFile(dir, current string) (string, error) {
+	files, err := evtxFiles(dir)
+	if err != nil {
+		return "", err
+	}
+
+	currentName := filepath.Base(current)
+	for _, f := range files {
+		if current == "" || filepath.Base(f) > currentName {
+			return f, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eventlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextEvtxFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.evtx", "a.EVTX", "c.evtx", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "d.evtx"), 0o700))
+
+	files, err := evtxFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.EVTX"),
+		filepath.Join(dir, "b.evtx"),
+		filepath.Join(dir, "c.evtx"),
+	}, files)
+
+	testCases := []struct {
+		current string
+		next    string
+	}{
+		{"", "a.EVTX"},
+		{"a.EVTX", "b.evtx"},
+		{"b.evtx", "c.evtx"},
+		{"c.evtx", ""},
+		// The current file was removed.
+		{"bb.evtx", "c.evtx"},
+	}
+	for _, tc := range testCases {
+		current := tc.current
+		if current != "" {
+			current = filepath.Join(dir, current)
+		}
+		next, err := nextEvtxFile(dir, current)
+		require.NoError(t, err)
+		if tc.next == "" {
+			assert.Empty(t, next, "current=%v", tc.current)
+		} else {
+			assert.Equal(t, filepath.Join(dir, tc.next), next, "current=%v", tc.current)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -168,6 +169,7 @@ type winEventLog struct {
 	id           string                   // Identifier of this event log.
 	channelName  string                   // Name of the channel from which to read.
 	file         bool                     // Reading from file rather than channel.
+	directory    string                   // Directory of the .evtx files being read, channelName is the current file.
 	subscription win.EvtHandle            // Handle to the subscription.
 	maxRead      int                      // Maximum number returned in one Read.
 	lastRead     checkpoint.EventLogState // Record number of the last read event.
@@ -256,6 +258,15 @@ func newWinEventLog(options *conf.C) (EventLog, error) {
 		metrics:      newInputMetrics(c.Name, id),
 	}
 
+	// An absolute path to a directory reads all the .evtx files it contains,
+	// one after the other.
+	if l.file {
+		if info, err := os.Stat(c.Name); err == nil && info.IsDir() {
+			l.directory = c.Name
+			l.channelName = ""
+		}
+	}
+
 	// Forwarded events should be rendered using RenderEventXML. It is more
 	// efficient and does not attempt to use local message files for rendering
 	// the event's message.
@@ -283,6 +294,19 @@ func (l *winEventLog) Name() string {
 }
 
 func (l *winEventLog) Open(state checkpoint.EventLogState) error {
+	if l.directory != "" {
+		var err error
+		if state, err = l.resumeDirectory(state); err != nil {
+			l.metrics.logError(err)
+			return err
+		}
+		if l.channelName == "" {
+			// The directory does not contain any file yet.
+			l.subscription = win.NilHandle
+			return nil
+		}
+	}
+
 	var bookmark win.EvtHandle
 	var err error
 	if len(state.Bookmark) > 0 {
@@ -343,6 +367,50 @@ func (l *winEventLog) openFile(state checkpoint.EventLogState, bookmark win.EvtH
 	return nil
 }
 
+// resumeDirectory selects the file of the directory to read from. It is the
+// file of the given state if it still exists, otherwise the file following
+// it. The returned state must only be used if it is for the selected file.
+func (l *winEventLog) resumeDirectory(state checkpoint.EventLogState) (checkpoint.EventLogState, error) {
+	if state.File != "" && filepath.Dir(state.File) == l.directory {
+		if _, err := os.Stat(state.File); err == nil {
+			l.channelName = state.File
+			return state, nil
+		}
+	} else {
+		state.File = ""
+	}
+
+	file, err := nextEvtxFile(l.directory, state.File)
+	if err != nil {
+		return checkpoint.EventLogState{}, fmt.Errorf("failed to list .evtx files in %v: %w", l.directory, err)
+	}
+	l.channelName = file
+	return checkpoint.EventLogState{}, nil
+}
+
+// openNextFile opens the file following the one being read in the directory.
+// It returns false if there is no such file.
+func (l *winEventLog) openNextFile() (bool, error) {
+	next, err := nextEvtxFile(l.directory, l.channelName)
+	if err != nil {
+		return false, fmt.Errorf("failed to list .evtx files in %v: %w", l.directory, err)
+	}
+	if next == "" {
+		return false, nil
+	}
+
+	if l.subscription != win.NilHandle {
+		if err := win.Close(l.subscription); err != nil {
+			logp.Warn("%s failed closing handle to event log file %v: %v", l.logPrefix, l.channelName, err)
+		}
+		l.subscription = win.NilHandle
+	}
+
+	debugf("%s Reading event log file %v", l.logPrefix, next)
+	l.channelName = next
+	return true, l.openFile(checkpoint.EventLogState{}, win.NilHandle)
+}
+
 func (l *winEventLog) openChannel(bookmark win.EvtHandle) error {
 	// Using a pull subscription to receive events. See:
 	// https://msdn.microsoft.com/en-us/library/windows/desktop/aa385771(v=vs.85).aspx#pull
@@ -431,6 +499,9 @@ func (l *winEventLog) Read() ([]Record, error) {
 			RecordNumber: r.RecordID,
 			Timestamp:    r.TimeCreated.SystemTime,
 		}
+		if l.directory != "" {
+			r.Offset.File = l.channelName
+		}
 		if r.Offset.Bookmark, err = l.createBookmarkFromEvent(h); err != nil {
 			l.metrics.logError(err)
 			logp.Warn("%s failed creating bookmark: %v", l.logPrefix, err)
@@ -452,7 +523,14 @@ func (l *winEventLog) Read() ([]Record, error) {
 }
 
 func (l *winEventLog) eventHandles(maxRead int) ([]win.EvtHandle, int, error) {
-	handles, err := win.EventHandles(l.subscription, maxRead)
+	var handles []win.EvtHandle
+	var err error
+	if l.subscription == win.NilHandle {
+		// No file of the directory is being read.
+		err = win.ERROR_NO_MORE_ITEMS
+	} else {
+		handles, err = win.EventHandles(l.subscription, maxRead)
+	}
 	switch err { //nolint:errorlint // This is an errno or nil.
 	case nil:
 		if l.maxRead > maxRead {
@@ -462,6 +540,16 @@ func (l *winEventLog) eventHandles(maxRead int) ([]win.EvtHandle, int, error) {
 		return handles, maxRead, nil
 	case win.ERROR_NO_MORE_ITEMS:
 		detailf("%s No more events", l.logPrefix)
+		if l.directory != "" {
+			opened, err := l.openNextFile()
+			if err != nil {
+				l.metrics.logError(err)
+				return nil, maxRead, err
+			}
+			if opened {
+				return l.eventHandles(maxRead)
+			}
+		}
 		if l.config.NoMoreEvents == Stop {
 			return nil, maxRead, io.EOF
 		}
@@ -525,6 +613,9 @@ func (l *winEventLog) buildRecordFromXML(x []byte, recoveredErr error) Record {
 
 	if l.file {
 		r.File = l.id
+		if l.directory != "" {
+			r.File = l.channelName
+		}
 	}
 
 	if includeXML {
@@ -548,6 +639,9 @@ func (l *winEventLog) createBookmarkFromEvent(evtHandle win.EvtHandle) (string,
 func (l *winEventLog) Close() error {
 	debugf("%s Closing handle", l.logPrefix)
 	l.metrics.close()
+	if l.subscription == win.NilHandle {
+		return nil
+	}
 	return win.Close(l.subscription)
 }
 
@@ -93,7 +93,9 @@ func newWinEventLogExp(options *conf.C) (EventLog, error) {
 		log = logp.NewLogger("wineventlog").With("id", id)
 	} else {
 		queryLog := c.Name
-		if info, err := os.Stat(c.Name); err == nil && info.Mode().IsRegular() {
+		if info, err := os.Stat(c.Name); err == nil && info.IsDir() {
+			return nil, fmt.Errorf("reading the .evtx files of directory %v is not supported by the %v API", c.Name, winEventLogExpAPIName)
+		} else if err == nil && info.Mode().IsRegular() {
 			path, err := filepath.Abs(c.Name)
 			if err != nil {
 				return nil, err
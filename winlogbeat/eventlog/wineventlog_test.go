@@ -22,6 +22,7 @@ package eventlog
 
 import (
 	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
@@ -298,6 +299,65 @@ func testWindowsEventLog(t *testing.T, api string) {
 	})
 }
 
+func TestWinEventLogDirectory(t *testing.T) {
+	src, err := os.ReadFile("../sys/wineventlog/testdata/sysmon-9.01.evtx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"a.evtx", "b.evtx"} {
+		if err = os.WriteFile(filepath.Join(dir, name), src, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readAll := func(t *testing.T, state *checkpoint.EventLogState) []Record {
+		log := openLog(t, winEventLogAPIName, state, map[string]interface{}{
+			"name":           dir,
+			"no_more_events": "stop",
+		})
+		defer log.Close()
+
+		var records []Record
+		for {
+			r, err := log.Read()
+			records = append(records, r...)
+			if err == io.EOF {
+				return records
+			}
+			require.NoError(t, err)
+		}
+	}
+
+	records := readAll(t, nil)
+	require.Len(t, records, 64)
+	for i, r := range records {
+		file := filepath.Join(dir, "a.evtx")
+		if i >= 32 {
+			file = filepath.Join(dir, "b.evtx")
+		}
+		assert.Equal(t, file, r.File)
+		assert.Equal(t, file, r.Offset.File)
+		assert.Equal(t, dir, r.Offset.Name)
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		state := records[31].Offset
+		resumed := readAll(t, &state)
+		require.Len(t, resumed, 32)
+		assert.Equal(t, records[32].RecordID, resumed[0].RecordID)
+		assert.Equal(t, filepath.Join(dir, "b.evtx"), resumed[0].File)
+	})
+
+	t.Run("removed_file", func(t *testing.T) {
+		state := records[40].Offset
+		state.File = filepath.Join(dir, "aa.evtx")
+		resumed := readAll(t, &state)
+		require.Len(t, resumed, 32)
+		assert.Equal(t, filepath.Join(dir, "b.evtx"), resumed[0].File)
+	})
+}
+
 // ---- Utility Functions -----
 
 // createLog creates a new event log and returns a handle for writing events
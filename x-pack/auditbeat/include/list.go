@@ -11,6 +11,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/host"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/login"
+	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/network_connections"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/package"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/process"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/socket"
@@ -19,5 +19,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJy0WV1v2zoSffevGPSlCeAqiNsEhR8WSJtiE2y7DdYp0DebEscSNxSpS1JJ1F9/QerDkk3ZlqMroEDNkOec+eBoSH2AJyzmoAttMJ0AGGY4zuHdwg28mwBQ1JFimWFSzOFfEwCAxwQ1AlEIJkFYM+RUQ4wCFTFIISzceIkJqaQ5x2ACoJAj0TiHEA2ZQLVwPpkAfABBUpwDPqMwjsMUGc4hVjLP3O96sv1/PVsqFjPhhuoFT1i8SEWrMY92+/x060CunU7HGcBjwjRERECIQGDNOEJGTAJnGMQBrC6eibrgMrb/gsvV+bRBk8rBWEk1ZGV6JNNMChQGTEIM6DzLOEPqplBiSI0t0HAmnlbnQdsXuUZ1tCtQGGaKJaPDvXF/C7lgf+XIC2DUAq0LJmKn0moAKYBAIrUJ4N6A9ZJMs9xGmmggsLi7+TC7uoaE6GTjlNIRdhXc305LIPsfImj5w+oOOjYYVCkThA834bFaWdNago4vMyUj1Ppod7Zs2Z7eK+KO6AR1k1WvGOWGhBxtaqG1Q7stQ3gsFTNJ6qi0c4hd8Ex4jm5Kg+g8iK+AIpIUKVAWozbVTGfftv6NBSEnTzgLl7Or6w2ex6Nb5nz5fvOfb7OwCajHnEkP08fPn05h+vj501Cmq8vZKUxXl7NjmXRCZrNB5izubmazoy3RCRnorsXdzQBPWfzlcAvcmmEcw9Kr5Dg+txzHCZ5aDvXVwJRyHMPy6epydkJEri5nF8Ni4ngGR8XxHB+X19fkepApv39f7zWiMcC9OQOSU+bvAzzFt1sAW0VcatMM+gp5D179rCzACiIpDGGi7nB4+VJjYi1VSuy6oLVqu8epn22Nrdd8ZliKHeJSKZci7gyXhHOguXK8nT8ykeVmWU8RREiNkRRUd2bJ3LSnEX1LCu+MTGHEtHPKZefve/xln1/OGmCiLSHwmB1KaXoMp8TgEM4vUhqwWD6eKnqo2B+kHrJQSo5EDOFboAG2rtLAdkANh0+AFfZHCgzsT4+A7W1zhID/tlrNGr7dcU3B9ZVfFo97Bcn1WqMJNEbHZN8BTY8bHRbVZsCe6FuV4/njrkLzMTFf0E/kgPtbHwVRUcIMRiZXIxrUga1OCq+fr5fXn859IlLii+IJ3D9uvgKhVKHW6I0dyzxEW4MHOO4f9lNI7aHYrtwHWFZSt2p3q1wDCWVu3GaRmT2y2sNO9d7p1tudmt0uKxR3Enif1w/65OeiAZ3a8kJEUUVdG4UmSs4Dr5KME2NtG1VJDVopiFAYqaeQh7kw+RRemKDyRfcoGt0v7mhdKvlBIjvyu4d6TVLGi1HJS8iKXiFNiJkCxZARMYW1Qgw1PeSRZ1R6+4X9Vl0Vpp/wCZVAPh7fo2ezvNcVzX4plnVUw+1qONOI8O3rAqQO7EDL8c3GINETifFNHWCFsbeQEAFMaEM4RwpSgcJUPiOt+d/WHW7f6xxy4F737bvpqdUevOKpGo2dyDRXPRUSlJWMiMYTvjzprRknmvjQIvfx+HbiG6n2WFXFe0y2CrKvDxmTqt2A+Pg4i1CMa10F6W07yj02ypmhpqswew8Pmv056mR2FJkF85LkaUpUcQJgudCHmSs+Zlh+/e/7bn1t7qfbFEOKqwU42KLZSbq8gt7t0Y6vp/9UdwLwq3uZveMlto34drbuMWTDFY/L9W8by14yytTYhr3XkMgULTRGRnZTu33JhXzE3gbgQclYkRSMBJULIAa4jFlPP2MTctnK1VE9Xt0wuQ8k7Rsm+CngOxP56xRMwrR9Q9vNEWMkdZntPRmxc2aqFcrw/xiZYQJXDu5AM1SUpHrz/YhpyIgytnE4C7GQ1QePvIx4ppitYuWqrf7Zv5Nh/24+FIWjIgFN/u9ubdi75Tb0TBiMcXuXDKTv234Z0dpjXN9R+XBsa8D94W2iVs2GMyFN1UBWI8xo5OvBkfScE2CsSN7syLawATxIrVnI2x/fYKUTQuXLsvFHD+ZZx2jXGduNKcoPwA7DfUU+n258u6RMk5AjXU17UFdCbpgtR7nZKRExKplr14+LQgp036q5jIGJc9dm9yFGqshMG/QlQdENmYuN1X6BJrpwwxQ0Yqp7QI2ss8Qef1A4DnfmKRF3ot/qGok2yyixBvVvnZ12rnyOCvaj+7pedGpMbegL0U4AVAKCyd8BAAD//yDbzZE="
+	return "eJysWt9P47oSfu9fMTovC1I3iO6CVjwciV1WB3RZQLdF2rfGjaeJL6mdaztA9q8/Gsdp0+L+CETKSlvH/r5vZuzJ2OYzPGF1AaYyFhcDACtsjhfw19g1/DUA4GgSLQorlLyAvwcAAJMMDQLTCDZDmAvMuYEUJWpmkcOscu01JiwUL3OMBgAac2QGL2CGlg3AD7wYDAA+g2QLvAB8Rmkdh60KvIBUq7Jwv5vOAKveSotUSPe6GfCE1YvS3LcFtNNz78aBmjudjjOCSSYMJEzCDIHBXOQIBbMZHGGURhCfPDN9kquU/kWn8fFwiaa0gyFJDaQ3PVGLQkmUFmzGLJiyKHKB3HXnzLIGW6LNhXyKj6O2L0qD+mBXoLTCVlPBu3vj5gpKKf5fYl6B4AQ0r4RMnUrSAEoCg0wZG8GNBfKSWhQlRZoZYDC+vvw8OjuHjJlsCeodQaPg5mpYA9F/mOT1DzIyWrPBol4IyfLuJkz8yMb/RLDmy0KrBI052J0tWza7bxVxzUyGppGAr5iUls1ypKmFZIdxS4blqdLCZgtHZZxDaMAzy0t0XZaI1JzhK6BMFEcOXKRorO/p7NvUv7JglrMnHM2mo7Nz/ybs0Q1zvt9e/ufnaLYMaMCcwRamL9++vofpy7evXZnOTkfvYTo7HR3KZDI2GnUyZ3x9ORodbInJWEd3ja8vO3iK8KfdLfgy7WhD1+lFVkw7zC3H8Q5PTbv6quOUcnZ0m09np6N3ROTsdHTSLSaOp3NUHM/hcXl9zc47mfL79/lOI5YGuC9nxEouwnXAGurfbxJ4WyR9fJaNb5F2JnN6YgKIIVHSMiGbCid3n0IQcq70gpEDo9aozRoHIJSk2yrLwooFtl40SnMl07XmmvACeKkd79pLIYvSTpsukkllMFGSm7VeqrTtbsxcsSrYo9CYCOOccrr2foe/6Hl01oCQbQlRwOyZUnaL4ZxZ7ML5XSkLhBXi8dFDLf4gD5DNlMqRyS58Y7Qg5n4aUAW05AgJIGF/lMSIfq5hhZfNAQLuWqVmA9/8JlVDcHXl9/FkpyA1nxu0kcHkkNm3R9NkpYNQaQbsiD6p7M8f1x4txCR4fxxwcxWiYDrJhMXElhp7JGvD+p3C67fz6fnX45CIBUv64f51+QMY5xqNwWDsRBEgEkUXjpuH3RRqPSeFM/celliZVu5upWtgM1XSlgxBFbRlpc1OvWNrawnl7JVCKsjfTOBdXt/rk/vxEnRI6YXJykfdWI02yY6joJIiZ5Zs61VJA+oVJCitMkMoZ6W05RBehOTqxWxR1LtfCNAr+cUSuB/D7y3Uc7YQedUreQ3p6TXyjNkhcJwJJocw14gzw/d55Bm12fxgf1SXxwwTPqGW2Gyke+CbBBbLJ+Npdksh1v6E3I/daDgyiPDzxxiUiaih5fiGWKJ9UfppmigpMSFAM9iXU3awxwG8vQlm8uPBbfAfrx7AqOQJ7XpmUwVKOl9pvtwfKyWN3ayctnt6p5/HBNRUFLVuX1PEP8eTy+8xKA3x7c148vMujtrWrZ1f1A8Km6GG+PHux/3dXQxHUlnwTkR+7KA86tGqOQrYJ6Ti+OFa5YZQ1o1rDrZKQ6dzCubCH8r4cyPIVM6bMzE/ZLCprmDJE0vxQ3PMY+ycV0yCkMayPEdOztO4UM/IwY/92BTaPEf80DTadbLYqN17pOgjtQENdflNJ4oeyUWhPl9sWgJ5aes36p0mPrTIQzxeQp9UO6zy8e6TzUOG2KhA7ZOqXfCG+HKRoOzXOg8ZYvNrrJc9akPnMbduVo348/H81pARWJCkXCyYrt4BWA8MYZY67zMsj/+9jQZvOJr7kPcmV7oV2PvFpk6GPskssCc4PJ/uyDS7vLPHP/Q8rl+ebNKVgvfOdnMV5kr75fqHrmS2knGh+yQjwz4ZyNQCgQuNiVW6CjObDPMea2mAB61SzRZUaehSArOQq1TIMDtNyGlrrvYp5B9/okkc7fUQwb2EWyHL1yFYuhcVxi2OFBNl6n1xWOzmclwpVLP/YWK7CYwd3J5iqKqv8sxy9VIhVzBtqXA4mmGlfC1H7z4ZKLSgLFaPWiszt63kPat5XxQOisRq/r9d2ruX3IpeSIsp6sD7DvTbll/BjAkYF87CB3DGDeDu8C6j5nvXuwcKdTMehDWYzztHMrAv7S2Sl29kE2wED8oYMcvbl70Qm4xx9TJtusZbMI/WjHZXKbQw671jjeH+auF4uPLtlAtDVyw8Hg6CoBBLtWKGo2axcyZT1Ko0rh6XFR3s0t9G5CoFIY9dmb0NMdFVYdugLxnK9ZC5VUbaT9AmJ66Zg0FcmEEAkaKimlkCTNJ9OHG4PU+NeBxtjXPOjJ0mGRkUiueWcq5DsOlshLNqLcc0hr4w4wRAkjGZIo8G/w4ARPd9nA=="
 }
@@ -0,0 +1,33 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+// +build linux
+
+package network_connections
+
+import (
+	"time"
+)
+
+// config defines the metricset's configuration options.
+type config struct {
+	StatePeriod                   time.Duration `config:"state.period"`
+	NetworkConnectionsStatePeriod time.Duration `config:"network_connections.state.period"`
+	IncludeLocalhost              bool          `config:"network_connections.include_localhost"`
+}
+
+func (c *config) effectiveStatePeriod() time.Duration {
+	if c.NetworkConnectionsStatePeriod != 0 {
+		return c.NetworkConnectionsStatePeriod
+	}
+	return c.StatePeriod
+}
+
+func defaultConfig() config {
+	return config{
+		StatePeriod:      12 * time.Hour,
+		IncludeLocalhost: false,
+	}
+}
@@ -0,0 +1,566 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+// +build linux
+
+package network_connections
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/gofrs/uuid"
+	"github.com/prometheus/procfs"
+
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/flowhash"
+	sock "github.com/elastic/beats/v7/metricbeat/helper/socket"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/cache"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/gosigar/sys/linux"
+)
+
+const (
+	moduleName    = "system"
+	metricsetName = "network_connections"
+	namespace     = "system.audit.network_connections"
+
+	bucketName              = "auditbeat.network_connections.v1"
+	bucketKeyStateTimestamp = "state_timestamp"
+
+	eventTypeState = "state"
+	eventTypeEvent = "event"
+)
+
+type eventAction uint8
+
+const (
+	eventActionExistingConnection eventAction = iota
+	eventActionConnectionOpened
+	eventActionConnectionClosed
+)
+
+func (action eventAction) String() string {
+	switch action {
+	case eventActionExistingConnection:
+		return "existing_connection"
+	case eventActionConnectionOpened:
+		return "connection_opened"
+	case eventActionConnectionClosed:
+		return "connection_closed"
+	default:
+		return ""
+	}
+}
+
+func (action eventAction) Type() string {
+	switch action {
+	case eventActionExistingConnection:
+		return "info"
+	case eventActionConnectionOpened:
+		return "start"
+	case eventActionConnectionClosed:
+		return "end"
+	default:
+		return "info"
+	}
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet(moduleName, metricsetName, New,
+		mb.WithNamespace(namespace),
+	)
+}
+
+// MetricSet collects data about the TCP and UDP sockets open on the host.
+type MetricSet struct {
+	system.SystemMetricSet
+	config    config
+	log       *logp.Logger
+	cache     *cache.Cache
+	bucket    datastore.Bucket
+	lastState time.Time
+
+	procfs    procfs.FS
+	ptable    *sock.ProcTable
+	listeners *sock.ListenerTable
+	users     map[uint32]*user.User
+}
+
+// Connection represents a TCP or UDP socket and the process holding it.
+type Connection struct {
+	Family     uint8
+	Transport  string
+	LocalIP    net.IP
+	LocalPort  int
+	RemoteIP   net.IP
+	RemotePort int
+	State      linux.TCPState
+	Direction  sock.Direction
+	Inode      uint64
+
+	// Owner of the socket.
+	UID  uint32
+	User *user.User
+
+	// Process holding the socket, PID is 0 if it is unknown.
+	PID        int
+	Name       string
+	Executable string
+	Args       []string
+}
+
+// Hash creates a hash for Connection.
+func (c Connection) Hash() uint64 {
+	h := xxhash.New()
+	h.WriteString(c.Transport)
+	h.WriteString(net.JoinHostPort(c.LocalIP.String(), strconv.Itoa(c.LocalPort)))
+	h.WriteString(net.JoinHostPort(c.RemoteIP.String(), strconv.Itoa(c.RemotePort)))
+	h.WriteString(strconv.FormatUint(c.Inode, 10))
+	return h.Sum64()
+}
+
+func (c Connection) inetType() string {
+	// Sockets created as AF_INET6 can use the IPv4 stack, in which case
+	// both addresses are IPv4-mapped addresses.
+	if c.LocalIP.To4() != nil && (c.RemoteIP == nil || c.RemoteIP.To4() != nil) {
+		return "ipv4"
+	}
+	return "ipv6"
+}
+
+func (c Connection) proto() uint8 {
+	if c.Transport == "udp" {
+		return syscall.IPPROTO_UDP
+	}
+	return syscall.IPPROTO_TCP
+}
+
+func (c Connection) transportName() string {
+	if c.Transport == "udp" {
+		return "UDP"
+	}
+	return "TCP"
+}
+
+func (c Connection) isLocalhost() bool {
+	return c.LocalIP.IsLoopback() && (c.Direction == sock.Listening || c.RemoteIP.IsLoopback())
+}
+
+func (c Connection) toMapStr() mapstr.M {
+	local := mapstr.M{
+		"ip":   c.LocalIP.String(),
+		"port": c.LocalPort,
+	}
+
+	network := mapstr.M{
+		"type":      c.inetType(),
+		"transport": c.Transport,
+	}
+
+	fields := mapstr.M{
+		"network": network,
+		"related": mapstr.M{
+			"ip": []string{c.LocalIP.String()},
+		},
+	}
+
+	if c.Direction == sock.Listening {
+		fields["server"] = local
+		return fields
+	}
+
+	remote := mapstr.M{
+		"ip":   c.RemoteIP.String(),
+		"port": c.RemotePort,
+	}
+
+	src, dst := local, remote
+	if c.Direction == sock.Ingress {
+		src, dst = dst, src
+	}
+	fields["source"] = src
+	fields["client"] = src
+	fields["destination"] = dst
+	fields["server"] = dst
+
+	network["direction"] = c.Direction.String()
+	network["community_id"] = flowhash.CommunityID.Hash(flowhash.Flow{
+		SourceIP:        c.LocalIP,
+		SourcePort:      uint16(c.LocalPort),
+		DestinationIP:   c.RemoteIP,
+		DestinationPort: uint16(c.RemotePort),
+		Protocol:        c.proto(),
+	})
+	fields.Put("related.ip", []string{c.LocalIP.String(), c.RemoteIP.String()})
+
+	return fields
+}
+
+// New constructs a new MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The %v/%v dataset is beta", moduleName, metricsetName)
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %v/%v config: %w", moduleName, metricsetName, err)
+	}
+
+	fs, err := procfs.NewDefaultFS()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open procfs: %w", err)
+	}
+
+	ptable, err := sock.NewProcTable("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create process table: %w", err)
+	}
+
+	bucket, err := datastore.OpenBucket(bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open persistent datastore: %w", err)
+	}
+
+	ms := &MetricSet{
+		SystemMetricSet: system.NewSystemMetricSet(base),
+		config:          config,
+		log:             logp.NewLogger(metricsetName),
+		cache:           cache.New(),
+		bucket:          bucket,
+		procfs:          fs,
+		ptable:          ptable,
+		listeners:       sock.NewListenerTable(),
+		users:           map[uint32]*user.User{},
+	}
+
+	// Load from disk: Time when state was last sent
+	err = bucket.Load(bucketKeyStateTimestamp, func(blob []byte) error {
+		if len(blob) > 0 {
+			return ms.lastState.UnmarshalBinary(blob)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ms.lastState.IsZero() {
+		ms.log.Debugf("Last state was sent at %v. Next state update by %v.", ms.lastState, ms.lastState.Add(ms.config.effectiveStatePeriod()))
+	} else {
+		ms.log.Debug("No state timestamp found")
+	}
+
+	if !ptable.Privileged() {
+		ms.log.Warnf("Process information will only be available for processes owned by the %v user "+
+			"because Auditbeat is not running with enough privileges", os.Geteuid())
+	}
+
+	return ms, nil
+}
+
+// Close cleans up the MetricSet when it finishes.
+func (ms *MetricSet) Close() error {
+	if ms.bucket != nil {
+		return ms.bucket.Close()
+	}
+	return nil
+}
+
+// Fetch collects socket information. It is invoked periodically.
+func (ms *MetricSet) Fetch(report mb.ReporterV2) {
+	needsStateUpdate := time.Since(ms.lastState) > ms.config.effectiveStatePeriod()
+	if needsStateUpdate || ms.cache.IsEmpty() {
+		ms.log.Debugf("State update needed (needsStateUpdate=%v, cache.IsEmpty()=%v)", needsStateUpdate, ms.cache.IsEmpty())
+		err := ms.reportState(report)
+		if err != nil {
+			ms.log.Error(err)
+			report.Error(err)
+		}
+		ms.log.Debugf("Next state update by %v", ms.lastState.Add(ms.config.effectiveStatePeriod()))
+	}
+
+	err := ms.reportChanges(report)
+	if err != nil {
+		ms.log.Error(err)
+		report.Error(err)
+	}
+}
+
+// reportState reports all open sockets on the system.
+func (ms *MetricSet) reportState(report mb.ReporterV2) error {
+	// Only update lastState if this state update was regularly scheduled,
+	// i.e. not caused by an Auditbeat restart (when the cache would be empty).
+	if !ms.cache.IsEmpty() {
+		ms.lastState = time.Now()
+	}
+
+	// Refresh the user lookups with the state.
+	ms.users = map[uint32]*user.User{}
+
+	connections, err := ms.getConnections()
+	if err != nil {
+		return fmt.Errorf("failed to get connections: %w", err)
+	}
+	ms.log.Debugf("Found %v connections", len(connections))
+
+	stateID, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Errorf("error generating state ID: %w", err)
+	}
+	for _, c := range connections {
+		event := ms.connectionEvent(c, eventTypeState, eventActionExistingConnection)
+		event.RootFields.Put("event.id", stateID.String())
+		report.Event(event)
+	}
+
+	// This will initialize the cache with the current connections.
+	ms.cache.DiffAndUpdateCache(convertToCacheable(connections))
+
+	// Save time so we know when to send the state again (config.StatePeriod)
+	timeBytes, err := ms.lastState.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = ms.bucket.Store(bucketKeyStateTimestamp, timeBytes)
+	if err != nil {
+		return fmt.Errorf("error writing state timestamp to disk: %w", err)
+	}
+
+	return nil
+}
+
+// reportChanges detects and reports any sockets opened or closed on this
+// system since the last call.
+func (ms *MetricSet) reportChanges(report mb.ReporterV2) error {
+	connections, err := ms.getConnections()
+	if err != nil {
+		return fmt.Errorf("failed to get connections: %w", err)
+	}
+	ms.log.Debugf("Found %v connections", len(connections))
+
+	opened, closed := ms.cache.DiffAndUpdateCache(convertToCacheable(connections))
+
+	for _, cacheValue := range opened {
+		report.Event(ms.connectionEvent(cacheValue.(*Connection), eventTypeEvent, eventActionConnectionOpened))
+	}
+
+	for _, cacheValue := range closed {
+		report.Event(ms.connectionEvent(cacheValue.(*Connection), eventTypeEvent, eventActionConnectionClosed))
+	}
+
+	return nil
+}
+
+func (ms *MetricSet) connectionEvent(conn *Connection, eventType string, action eventAction) mb.Event {
+	event := mb.Event{
+		RootFields: conn.toMapStr(),
+		MetricSetFields: mapstr.M{
+			"state": conn.State.String(),
+			"inode": conn.Inode,
+		},
+	}
+
+	event.RootFields.DeepUpdate(mapstr.M{
+		"event": mapstr.M{
+			"kind":     eventType,
+			"category": []string{"network"},
+			"type":     []string{action.Type(), "connection"},
+			"action":   action.String(),
+		},
+		"user": mapstr.M{
+			"id": strconv.FormatUint(uint64(conn.UID), 10),
+		},
+		"message": connectionMessage(conn, action),
+	})
+
+	if conn.User != nil && conn.User.Username != "" {
+		event.RootFields.Put("user.name", conn.User.Username)
+		event.RootFields.Put("related.user", []string{conn.User.Username})
+	}
+
+	if conn.PID > 0 {
+		process := mapstr.M{
+			"pid": conn.PID,
+		}
+		putIfNotEmpty(process, "name", conn.Name)
+		putIfNotEmpty(process, "executable", conn.Executable)
+		if len(conn.Args) > 0 {
+			process["args"] = conn.Args
+		}
+		event.RootFields.Put("process", process)
+	}
+
+	return event
+}
+
+func putIfNotEmpty(m mapstr.M, key string, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func connectionMessage(conn *Connection, action eventAction) string {
+	var connString string
+	switch conn.Direction {
+	case sock.Listening:
+		connString = fmt.Sprintf("Listening %v socket %v", conn.transportName(),
+			net.JoinHostPort(conn.LocalIP.String(), strconv.Itoa(conn.LocalPort)))
+	case sock.Ingress:
+		connString = fmt.Sprintf("Inbound %v connection %v -> %v", conn.transportName(),
+			net.JoinHostPort(conn.RemoteIP.String(), strconv.Itoa(conn.RemotePort)),
+			net.JoinHostPort(conn.LocalIP.String(), strconv.Itoa(conn.LocalPort)))
+	default:
+		connString = fmt.Sprintf("Outbound %v connection %v -> %v", conn.transportName(),
+			net.JoinHostPort(conn.LocalIP.String(), strconv.Itoa(conn.LocalPort)),
+			net.JoinHostPort(conn.RemoteIP.String(), strconv.Itoa(conn.RemotePort)))
+	}
+
+	var actionString string
+	switch action {
+	case eventActionConnectionOpened:
+		actionString = "OPENED"
+	case eventActionConnectionClosed:
+		actionString = "CLOSED"
+	case eventActionExistingConnection:
+		actionString = "is OPEN"
+	}
+
+	var processString string
+	if conn.PID > 0 {
+		processString = fmt.Sprintf(" of process %v (PID: %d)", conn.Name, conn.PID)
+	}
+
+	var userString string
+	if conn.User != nil {
+		userString = fmt.Sprintf(" by user %v", conn.User.Username)
+	}
+
+	return fmt.Sprintf("%v%v%v %v", connString, processString, userString, actionString)
+}
+
+func convertToCacheable(connections []*Connection) []cache.Cacheable {
+	c := make([]cache.Cacheable, 0, len(connections))
+
+	for _, conn := range connections {
+		c = append(c, conn)
+	}
+
+	return c
+}
+
+// socketTables are the /proc/net files listing the sockets of the host's
+// network namespace.
+var socketTables = []struct {
+	family    uint8
+	transport string
+}{
+	{syscall.AF_INET, "tcp"},
+	{syscall.AF_INET6, "tcp"},
+	{syscall.AF_INET, "udp"},
+	{syscall.AF_INET6, "udp"},
+}
+
+func (ms *MetricSet) readSockets(family uint8, transport string) (procfs.NetIPSocket, error) {
+	switch {
+	case transport == "tcp" && family == syscall.AF_INET:
+		sockets, err := ms.procfs.NetTCP()
+		return procfs.NetIPSocket(sockets), err
+	case transport == "tcp":
+		sockets, err := ms.procfs.NetTCP6()
+		return procfs.NetIPSocket(sockets), err
+	case family == syscall.AF_INET:
+		sockets, err := ms.procfs.NetUDP()
+		return procfs.NetIPSocket(sockets), err
+	default:
+		sockets, err := ms.procfs.NetUDP6()
+		return procfs.NetIPSocket(sockets), err
+	}
+}
+
+func (ms *MetricSet) getConnections() ([]*Connection, error) {
+	// Refresh inode to process mapping (must be root).
+	if err := ms.ptable.Refresh(); err != nil {
+		ms.log.Debugf("Process table refresh had failures: %v", err)
+	}
+	defer ms.listeners.Reset()
+
+	var connections []*Connection
+	for _, table := range socketTables {
+		sockets, err := ms.readSockets(table.family, table.transport)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// IPv6 is disabled.
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %v sockets: %w", table.transport, err)
+		}
+
+		for _, s := range sockets {
+			// Sockets no longer held by any process, e.g. in TIME_WAIT state,
+			// have no inode.
+			if s.Inode == 0 {
+				continue
+			}
+
+			conn := &Connection{
+				Family:     table.family,
+				Transport:  table.transport,
+				LocalIP:    s.LocalAddr,
+				LocalPort:  int(s.LocalPort),
+				RemoteIP:   s.RemAddr,
+				RemotePort: int(s.RemPort),
+				State:      linux.TCPState(s.St),
+				Inode:      s.Inode,
+				UID:        uint32(s.UID),
+			}
+			if conn.State == linux.TCP_LISTEN || (conn.Transport == "udp" && conn.RemotePort == 0) {
+				conn.RemoteIP, conn.RemotePort = nil, 0
+				ms.listeners.Put(conn.proto(), conn.LocalIP, conn.LocalPort)
+			}
+			connections = append(connections, conn)
+		}
+	}
+
+	k := 0
+	for _, conn := range connections {
+		conn.Direction = ms.listeners.Direction(conn.Family, conn.proto(),
+			conn.LocalIP, conn.LocalPort, conn.RemoteIP, conn.RemotePort)
+		if !ms.config.IncludeLocalhost && conn.isLocalhost() {
+			continue
+		}
+		ms.enrichConnection(conn)
+		connections[k] = conn
+		k++
+	}
+
+	return connections[:k], nil
+}
+
+// enrichConnection enriches a connection with the process holding the socket
+// and user lookup information.
+func (ms *MetricSet) enrichConnection(conn *Connection) {
+	if proc := ms.ptable.ProcessBySocketInode(uint32(conn.Inode)); proc != nil {
+		conn.PID = proc.PID
+		conn.Name = proc.Command
+		conn.Executable = proc.Executable
+		conn.Args = proc.Args
+	}
+
+	u, found := ms.users[conn.UID]
+	if !found {
+		u, _ = user.LookupId(strconv.FormatUint(uint64(conn.UID), 10))
+		ms.users[conn.UID] = u
+	}
+	conn.User = u
+}
@@ -0,0 +1,215 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+// +build linux
+
+package network_connections
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/auditbeat/core"
+	abtest "github.com/elastic/beats/v7/auditbeat/testing"
+	sock "github.com/elastic/beats/v7/metricbeat/helper/socket"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/gosigar/sys/linux"
+)
+
+func TestData(t *testing.T) {
+	defer abtest.SetupDataDir(t)()
+
+	f := mbtest.NewReportingMetricSetV2(t, getConfig())
+
+	// Set lastState and add test connection to cache so it will be reported as closed.
+	f.(*MetricSet).lastState = time.Now()
+	c := testConnection()
+	f.(*MetricSet).cache.DiffAndUpdateCache(convertToCacheable([]*Connection{c}))
+
+	events, errs := mbtest.ReportingFetchV2(f)
+	if len(errs) > 0 {
+		t.Fatalf("received error: %+v", errs[0])
+	}
+
+	if len(events) == 0 {
+		t.Fatal("no events were generated")
+	}
+
+	fullEvent := mbtest.StandardizeEvent(f, events[len(events)-1], core.AddDatasetToEvent)
+	mbtest.WriteEventToDataJSON(t, fullEvent, "")
+}
+
+func getConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"module":   "system",
+		"datasets": []string{"network_connections"},
+
+		"network_connections.include_localhost": true,
+	}
+}
+
+func TestConnectionEvent(t *testing.T) {
+	ms := mbtest.NewReportingMetricSetV2(t, getConfig()).(*MetricSet)
+
+	event := ms.connectionEvent(testConnection(), eventTypeEvent, eventActionConnectionOpened)
+
+	expectedRootFields := map[string]interface{}{
+		"event.kind":     "event",
+		"event.category": []string{"network"},
+		"event.type":     []string{"start", "connection"},
+		"event.action":   "connection_opened",
+		"message":        "Outbound TCP connection 10.0.2.15:40192 -> 151.101.66.217:80 of process curl (PID: 4970) by user vagrant OPENED",
+
+		"source.ip":          "10.0.2.15",
+		"source.port":        40192,
+		"client.ip":          "10.0.2.15",
+		"destination.ip":     "151.101.66.217",
+		"destination.port":   80,
+		"server.port":        80,
+		"network.type":       "ipv4",
+		"network.transport":  "tcp",
+		"network.direction":  "egress",
+		"related.ip":         []string{"10.0.2.15", "151.101.66.217"},
+		"related.user":       []string{"vagrant"},
+		"process.pid":        4970,
+		"process.name":       "curl",
+		"process.executable": "/usr/bin/curl",
+		"process.args":       []string{"curl", "http://elastic.co/"},
+		"user.id":            "1000",
+		"user.name":          "vagrant",
+	}
+	for expFieldName, expFieldValue := range expectedRootFields {
+		value, err := event.RootFields.GetValue(expFieldName)
+		if assert.NoErrorf(t, err, "error for field %v (value: %v)", expFieldName, expFieldValue) {
+			assert.Equalf(t, expFieldValue, value, "Unexpected value for field %v.", expFieldName)
+		}
+	}
+	communityID, _ := event.RootFields.GetValue("network.community_id")
+	assert.NotEmpty(t, communityID)
+
+	assert.Equal(t, "ESTAB", event.MetricSetFields["state"])
+	assert.EqualValues(t, 12345, event.MetricSetFields["inode"])
+}
+
+func TestConnections(t *testing.T) {
+	defer abtest.SetupDataDir(t)()
+
+	f := mbtest.NewReportingMetricSetV2(t, getConfig())
+
+	// Initial state.
+	events, errs := mbtest.ReportingFetchV2(f)
+	require.Empty(t, errs)
+	for _, e := range events {
+		kind, _ := e.RootFields.GetValue("event.kind")
+		require.Equal(t, eventTypeState, kind)
+	}
+	f.(*MetricSet).lastState = time.Now()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, err := l.Accept()
+		if err == nil {
+			accepted <- c
+		}
+	}()
+
+	c, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	server := <-accepted
+	port := l.Addr().(*net.TCPAddr).Port
+	clientPort := c.LocalAddr().(*net.TCPAddr).Port
+
+	events, errs = mbtest.ReportingFetchV2(f)
+	require.Empty(t, errs)
+	listening := findEvent(events, "connection_opened", "server.port", port, sock.Listening)
+	if assert.NotNil(t, listening, "listening socket not found") {
+		assertOwnProcess(t, listening)
+		assert.Equal(t, "LISTEN", listening.MetricSetFields["state"])
+	}
+	ingress := findEvent(events, "connection_opened", "source.port", clientPort, sock.Ingress)
+	if assert.NotNil(t, ingress, "inbound connection not found") {
+		assertOwnProcess(t, ingress)
+		dstPort, _ := ingress.RootFields.GetValue("destination.port")
+		assert.Equal(t, port, dstPort)
+	}
+	egress := findEvent(events, "connection_opened", "source.port", clientPort, sock.Egress)
+	if assert.NotNil(t, egress, "outbound connection not found") {
+		assertOwnProcess(t, egress)
+		assert.Equal(t, "ESTAB", egress.MetricSetFields["state"])
+	}
+
+	c.Close()
+	server.Close()
+	l.Close()
+
+	events, errs = mbtest.ReportingFetchV2(f)
+	require.Empty(t, errs)
+	assert.NotNil(t, findEvent(events, "connection_closed", "server.port", port, sock.Listening), "closed listening socket not found")
+	assert.NotNil(t, findEvent(events, "connection_closed", "source.port", clientPort, sock.Egress), "closed outbound connection not found")
+}
+
+func findEvent(events []mb.Event, action, portField string, port int, direction sock.Direction) *mb.Event {
+	for i, e := range events {
+		if a, _ := e.RootFields.GetValue("event.action"); a != action {
+			continue
+		}
+		if p, _ := e.RootFields.GetValue(portField); p != port {
+			continue
+		}
+		d, err := e.RootFields.GetValue("network.direction")
+		if direction == sock.Listening {
+			if err == nil {
+				continue
+			}
+		} else if d != direction.String() {
+			continue
+		}
+		return &events[i]
+	}
+	return nil
+}
+
+func assertOwnProcess(t *testing.T, event *mb.Event) {
+	t.Helper()
+
+	pid, _ := event.RootFields.GetValue("process.pid")
+	assert.Equal(t, os.Getpid(), pid)
+	uid, _ := event.RootFields.GetValue("user.id")
+	assert.Equal(t, strconv.Itoa(os.Getuid()), uid)
+}
+
+func testConnection() *Connection {
+	return &Connection{
+		Family:     2,
+		Transport:  "tcp",
+		LocalIP:    net.ParseIP("10.0.2.15"),
+		LocalPort:  40192,
+		RemoteIP:   net.ParseIP("151.101.66.217"),
+		RemotePort: 80,
+		State:      linux.TCP_ESTABLISHED,
+		Direction:  sock.Egress,
+		Inode:      12345,
+		UID:        1000,
+		User: &user.User{
+			Uid:      "1000",
+			Username: "vagrant",
+		},
+		PID:        4970,
+		Name:       "curl",
+		Executable: "/usr/bin/curl",
+		Args:       []string{"curl", "http://elastic.co/"},
+	}
+}
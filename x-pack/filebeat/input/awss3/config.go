@@ -38,6 +38,7 @@ type config struct {
 	PathStyle           bool                 `config:"path_style"`
 	ProviderOverride    string               `config:"provider"`
 	BackupConfig        backupConfig         `config:",inline"`
+	Inventory           *inventoryConfig     `config:"inventory"`
 }
 
 func defaultConfig() config {
@@ -63,8 +64,8 @@ func (c *config) Validate() error {
 			enabled = append(enabled, configs[i])
 		}
 	}
-	if len(enabled) == 0 {
-		return errors.New("neither queue_url, bucket_arn nor non_aws_bucket_name were provided")
+	if len(enabled) == 0 && c.Inventory == nil {
+		return errors.New("neither queue_url, bucket_arn, non_aws_bucket_name nor inventory were provided")
 	} else if len(enabled) > 1 {
 		return fmt.Errorf("queue_url <%v>, bucket_arn <%v>, non_aws_bucket_name <%v> "+
 			"cannot be set at the same time", c.QueueURL, c.BucketARN, c.NonAWSBucketName)
@@ -78,6 +79,14 @@ func (c *config) Validate() error {
 		return fmt.Errorf("number_of_workers <%v> must be greater than 0", c.NumberOfWorkers)
 	}
 
+	if c.Inventory != nil && (c.BucketARN != "" || c.NonAWSBucketName != "") {
+		return errors.New("inventory cannot be used together with bucket_arn or non_aws_bucket_name")
+	}
+
+	if c.Inventory != nil && c.NumberOfWorkers <= 0 {
+		return fmt.Errorf("number_of_workers <%v> must be greater than 0", c.NumberOfWorkers)
+	}
+
 	if c.QueueURL != "" && (c.VisibilityTimeout <= 0 || c.VisibilityTimeout.Hours() > 12) {
 		return fmt.Errorf("visibility_timeout <%v> must be greater than 0 and "+
 			"less than or equal to 12h", c.VisibilityTimeout)
@@ -145,6 +154,13 @@ func (c *backupConfig) GetBucketName() string {
 	return c.NonAWSBackupToBucketName
 }
 
+// inventoryConfig defines the location of the S3 Inventory reports used to
+// backfill the objects of a bucket.
+type inventoryConfig struct {
+	BucketARN string `config:"bucket_arn" validate:"required"` // Bucket the inventory reports are delivered to.
+	Prefix    string `config:"prefix"`                         // Prefix of the manifests of the inventory report.
+}
+
 // fileSelectorConfig defines reader configuration that applies to a subset
 // of S3 objects whose URL matches the given regex.
 type fileSelectorConfig struct {
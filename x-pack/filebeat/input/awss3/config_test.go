@@ -122,7 +122,7 @@ func TestConfig(t *testing.T) {
 				"bucket_arn":          "",
 				"non_aws_bucket_name": "",
 			},
-			"neither queue_url, bucket_arn nor non_aws_bucket_name were provided",
+			"neither queue_url, bucket_arn, non_aws_bucket_name nor inventory were provided",
 			nil,
 		},
 		{
@@ -500,6 +500,64 @@ func TestConfig(t *testing.T) {
 			"backup_to_bucket_prefix cannot be the same as bucket_list_prefix, this will create an infinite loop",
 			nil,
 		},
+		{
+			"input with inventory and queueURL",
+			queueURL,
+			s3Bucket,
+			"",
+			mapstr.M{
+				"queue_url":            queueURL,
+				"number_of_workers":    5,
+				"inventory.bucket_arn": s3Bucket,
+				"inventory.prefix":     "inventory/aBucket/all/",
+			},
+			"",
+			func(queueURL, s3Bucket string, nonAWSS3Bucket string) config {
+				c := makeConfig(queueURL, "", "")
+				c.NumberOfWorkers = 5
+				c.Inventory = &inventoryConfig{
+					BucketARN: s3Bucket,
+					Prefix:    "inventory/aBucket/all/",
+				}
+				return c
+			},
+		},
+		{
+			"error on inventory with bucket_arn",
+			"",
+			s3Bucket,
+			"",
+			mapstr.M{
+				"bucket_arn":           s3Bucket,
+				"number_of_workers":    5,
+				"inventory.bucket_arn": s3Bucket,
+			},
+			"inventory cannot be used together with bucket_arn or non_aws_bucket_name",
+			nil,
+		},
+		{
+			"error on inventory with number_of_workers == 0",
+			"",
+			"",
+			"",
+			mapstr.M{
+				"inventory.bucket_arn": s3Bucket,
+			},
+			"number_of_workers <0> must be greater than 0",
+			nil,
+		},
+		{
+			"error on inventory without bucket_arn",
+			"",
+			"",
+			"",
+			mapstr.M{
+				"number_of_workers": 5,
+				"inventory.prefix":  "inventory/",
+			},
+			"string value is not set accessing 'inventory.bucket_arn'",
+			nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -107,6 +108,10 @@ func (in *s3Input) Run(inputContext v2.Context, pipeline beat.Pipeline) error {
 		return fmt.Errorf("can not start persistent store: %w", err)
 	}
 
+	// Wait for the inventory backfill once the input context is canceled.
+	var backfillWg sync.WaitGroup
+	defer backfillWg.Wait()
+
 	// Wrap input Context's cancellation Done channel a context.Context. This
 	// goroutine stops with the parent closes the Done channel.
 	ctx, cancelInputCtx := context.WithCancel(context.Background())
@@ -136,11 +141,34 @@ func (in *s3Input) Run(inputContext v2.Context, pipeline beat.Pipeline) error {
 			defer receiver.metrics.Close()
 		}
 
+		if in.config.Inventory != nil {
+			// Backfill the objects listed in the inventory report while
+			// receiving notifications about new objects.
+			backfillWg.Add(1)
+			go func() {
+				defer backfillWg.Done()
+				if err := in.runInventoryBackfill(inputContext, ctx, pipeline, persistentStore, receiver.metrics); err != nil {
+					inputContext.Logger.Errorw("Inventory backfill failed.", "error", err)
+				}
+			}()
+		}
+
 		if err := receiver.Receive(ctx); err != nil {
 			return err
 		}
 	}
 
+	if in.config.Inventory != nil && in.config.QueueURL == "" {
+		metrics := newInputMetrics(inputContext.ID, nil)
+		if in.closeMetrics {
+			defer metrics.Close()
+		}
+
+		if err := in.runInventoryBackfill(inputContext, ctx, pipeline, persistentStore, metrics); err != nil {
+			return err
+		}
+	}
+
 	if in.config.BucketARN != "" || in.config.NonAWSBucketName != "" {
 		// Create client for publishing events and receive notification of their ACKs.
 		client, err := pipeline.ConnectWith(beat.ClientConfig{
@@ -306,6 +334,82 @@ func (in *s3Input) createS3Lister(ctx v2.Context, cancelCtx context.Context, cli
 	return s3Poller, nil
 }
 
+func (in *s3Input) runInventoryBackfill(ctx v2.Context, cancelCtx context.Context, pipeline beat.Pipeline, persistentStore *statestore.Store, metrics *inputMetrics) error {
+	// Create client for publishing events and receive notification of their ACKs.
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		CloseRef:   ctx.Cancelation,
+		ACKHandler: awscommon.NewEventACKHandler(),
+		Processing: beat.ProcessingConfig{
+			// This input only produces events with basic types so normalization
+			// is not required.
+			EventNormalization: boolPtr(false),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create pipeline client: %w", err)
+	}
+	defer client.Close()
+
+	backfill, err := in.createInventoryBackfill(ctx, cancelCtx, client, persistentStore, metrics)
+	if err != nil {
+		return fmt.Errorf("failed to initialize inventory backfill: %w", err)
+	}
+
+	return backfill.Backfill(cancelCtx)
+}
+
+func (in *s3Input) createInventoryBackfill(ctx v2.Context, cancelCtx context.Context, client beat.Client, persistentStore *statestore.Store, metrics *inputMetrics) (*s3InventoryBackfill, error) {
+	bucketName := getBucketNameFromARN(in.config.Inventory.BucketARN)
+
+	// S3 Inventory delivers the reports to a bucket in the region of the
+	// source bucket, a single client is used for both.
+	awsConfig := in.awsConfig.Copy()
+	newClient := func() *s3.Client {
+		return s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+			if in.config.AWSConfig.FIPSEnabled {
+				o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+			}
+		})
+	}
+	s3Client := newClient()
+	regionName, err := getRegionForBucket(cancelCtx, s3Client, bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS region for inventory bucket: %w", err)
+	}
+	if regionName != awsConfig.Region {
+		awsConfig.Region = regionName
+		s3Client = newClient()
+	}
+
+	s3API := &awsS3API{
+		client: s3Client,
+	}
+
+	log := ctx.Logger.With("inventory_bucket", in.config.Inventory.BucketARN)
+	log.Infof("number_of_workers is set to %v.", in.config.NumberOfWorkers)
+	log.Infof("inventory.prefix is set to %v.", in.config.Inventory.Prefix)
+	log.Infof("AWS region is set to %v.", awsConfig.Region)
+
+	fileSelectors := in.config.FileSelectors
+	if len(in.config.FileSelectors) == 0 {
+		fileSelectors = []fileSelectorConfig{{ReaderConfig: in.config.ReaderConfig}}
+	}
+	s3EventHandlerFactory := newS3ObjectProcessorFactory(log.Named("s3"), metrics, s3API, fileSelectors, in.config.BackupConfig)
+	backfill := newS3InventoryBackfill(log.Named("s3_inventory"),
+		metrics,
+		s3API,
+		client,
+		s3EventHandlerFactory,
+		persistentStore,
+		in.config.Inventory.BucketARN,
+		in.config.Inventory.Prefix,
+		awsConfig.Region,
+		getProviderFromDomain(in.config.AWSConfig.Endpoint, in.config.ProviderOverride),
+		in.config.NumberOfWorkers)
+
+	return backfill, nil
+}
+
 func getRegionFromQueueURL(queueURL string, endpoint string) (string, error) {
 	// get region from queueURL
 	// Example: https://sqs.us-east-1.amazonaws.com/627959692251/test-s3-logs
@@ -0,0 +1,422 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	awsS3InventoryPrefix = "filebeat::aws-s3::inventory::"
+
+	inventoryManifestName = "manifest.json"
+)
+
+// inventoryManifest is the manifest.json file delivered with every S3
+// Inventory report. It lists the data files of the report and their schema.
+type inventoryManifest struct {
+	SourceBucket      string                  `json:"sourceBucket"`
+	DestinationBucket string                  `json:"destinationBucket"`
+	FileFormat        string                  `json:"fileFormat"`
+	FileSchema        string                  `json:"fileSchema"`
+	Files             []inventoryManifestFile `json:"files"`
+}
+
+type inventoryManifestFile struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+// inventoryCheckpoint is the progress of a backfill, persisted in the store
+// so that a restarted input resumes where it stopped. Data files are processed
+// in manifest order, Files is the number of data files fully processed and Row
+// the number of rows of the next data file that were processed.
+type inventoryCheckpoint struct {
+	Manifest string `json:"manifest" struct:"manifest"`
+	Files    int    `json:"files" struct:"files"`
+	Row      int64  `json:"row" struct:"row"`
+	Done     bool   `json:"done" struct:"done"`
+}
+
+// inventorySchema maps the columns of the inventory data files.
+type inventorySchema struct {
+	columns        int
+	key            int
+	isLatest       int
+	isDeleteMarker int
+}
+
+func newInventorySchema(fileSchema string) (inventorySchema, error) {
+	schema := inventorySchema{key: -1, isLatest: -1, isDeleteMarker: -1}
+	fields := strings.Split(fileSchema, ",")
+	for i, field := range fields {
+		switch strings.TrimSpace(field) {
+		case "Key":
+			schema.key = i
+		case "IsLatest":
+			schema.isLatest = i
+		case "IsDeleteMarker":
+			schema.isDeleteMarker = i
+		}
+	}
+	if schema.key < 0 {
+		return schema, fmt.Errorf("inventory file schema %q has no Key field", fileSchema)
+	}
+	schema.columns = len(fields)
+	return schema, nil
+}
+
+// objectKey returns the key of the object listed in the record. It returns
+// false for records of delete markers and noncurrent versions, which are not
+// collected.
+func (s inventorySchema) objectKey(record []string) (string, bool, error) {
+	if len(record) != s.columns {
+		return "", false, fmt.Errorf("inventory record has %d fields, expected %d", len(record), s.columns)
+	}
+	if s.isDeleteMarker >= 0 && record[s.isDeleteMarker] == "true" {
+		return "", false, nil
+	}
+	if s.isLatest >= 0 && record[s.isLatest] == "false" {
+		return "", false, nil
+	}
+
+	// Object keys are URL encoded in CSV inventory reports.
+	key, err := url.QueryUnescape(record[s.key])
+	if err != nil {
+		return "", false, fmt.Errorf("failed to unescape object key %q: %w", record[s.key], err)
+	}
+	return key, true, nil
+}
+
+// inventoryCursor tracks the rows of a data file processed by concurrent
+// workers. The position only moves past a row once it and all the rows before
+// it are done, so it can be checkpointed safely.
+type inventoryCursor struct {
+	mu   sync.Mutex
+	pos  int64
+	done map[int64]struct{}
+}
+
+func newInventoryCursor(pos int64) *inventoryCursor {
+	return &inventoryCursor{pos: pos, done: map[int64]struct{}{}}
+}
+
+// markDone marks the row as done and calls advance with the new position when
+// it moved. advance is called with the cursor locked so that positions are
+// reported in order.
+func (c *inventoryCursor) markDone(row int64, advance func(pos int64)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[row] = struct{}{}
+	moved := false
+	for {
+		if _, ok := c.done[c.pos]; !ok {
+			break
+		}
+		delete(c.done, c.pos)
+		c.pos++
+		moved = true
+	}
+	if moved {
+		advance(c.pos)
+	}
+}
+
+// s3InventoryBackfill collects the objects listed in the latest S3 Inventory
+// report found in a bucket. It processes the objects with a bounded number of
+// workers and checkpoints its progress in the store.
+type s3InventoryBackfill struct {
+	numberOfWorkers int
+	bucket          string
+	prefix          string
+	region          string
+	provider        string
+	workerSem       *awscommon.Sem
+	s3              s3API
+	log             *logp.Logger
+	metrics         *inputMetrics
+	client          beat.Client
+	s3ObjectHandler s3ObjectHandlerFactory
+	store           *statestore.Store
+
+	checkpointMu sync.Mutex
+	checkpoint   inventoryCheckpoint
+}
+
+func newS3InventoryBackfill(log *logp.Logger,
+	metrics *inputMetrics,
+	s3 s3API,
+	client beat.Client,
+	s3ObjectHandler s3ObjectHandlerFactory,
+	store *statestore.Store,
+	bucket string,
+	prefix string,
+	awsRegion string,
+	provider string,
+	numberOfWorkers int,
+) *s3InventoryBackfill {
+	if metrics == nil {
+		metrics = newInputMetrics("", monitoring.NewRegistry())
+	}
+	return &s3InventoryBackfill{
+		numberOfWorkers: numberOfWorkers,
+		bucket:          bucket,
+		prefix:          prefix,
+		region:          awsRegion,
+		provider:        provider,
+		workerSem:       awscommon.NewSem(numberOfWorkers),
+		s3:              s3,
+		log:             log,
+		metrics:         metrics,
+		client:          client,
+		s3ObjectHandler: s3ObjectHandler,
+		store:           store,
+	}
+}
+
+func (b *s3InventoryBackfill) checkpointKey() string {
+	return awsS3InventoryPrefix + b.bucket + "/" + b.prefix
+}
+
+func (b *s3InventoryBackfill) saveCheckpoint(update func(cp *inventoryCheckpoint)) {
+	b.checkpointMu.Lock()
+	defer b.checkpointMu.Unlock()
+
+	update(&b.checkpoint)
+	if err := b.store.Set(b.checkpointKey(), b.checkpoint); err != nil {
+		b.log.Errorw("Failed to write inventory checkpoint to the registry", "error", err)
+	}
+}
+
+// Backfill processes the objects of the inventory report. It returns nil once
+// every object was processed, or when ctx is canceled.
+func (b *s3InventoryBackfill) Backfill(ctx context.Context) error {
+	bucketName := getBucketNameFromARN(b.bucket)
+
+	if err := b.store.Get(b.checkpointKey(), &b.checkpoint); err != nil {
+		b.checkpoint = inventoryCheckpoint{}
+	}
+	if b.checkpoint.Done {
+		b.log.Infow("Inventory backfill already completed.", "manifest", b.checkpoint.Manifest)
+		return nil
+	}
+
+	if b.checkpoint.Manifest == "" {
+		manifestKey, err := b.findLatestManifest(ctx, bucketName)
+		if err != nil {
+			return err
+		}
+		b.saveCheckpoint(func(cp *inventoryCheckpoint) {
+			*cp = inventoryCheckpoint{Manifest: manifestKey}
+		})
+	}
+
+	manifest, err := b.readManifest(ctx, bucketName, b.checkpoint.Manifest)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(manifest.FileFormat, "CSV") {
+		return fmt.Errorf("unsupported inventory file format %q in %s, only CSV is supported", manifest.FileFormat, b.checkpoint.Manifest)
+	}
+	schema, err := newInventorySchema(manifest.FileSchema)
+	if err != nil {
+		return err
+	}
+
+	b.log.Infow("Starting inventory backfill.", "manifest", b.checkpoint.Manifest,
+		"source_bucket", manifest.SourceBucket, "files", len(manifest.Files),
+		"files_done", b.checkpoint.Files, "row", b.checkpoint.Row)
+
+	for i := b.checkpoint.Files; i < len(manifest.Files); i++ {
+		file := manifest.Files[i]
+		if err := b.processFile(ctx, bucketName, manifest.SourceBucket, schema, file, b.checkpoint.Row); err != nil {
+			if errors.Is(err, context.Canceled) {
+				// A canceled context is a normal shutdown.
+				return nil
+			}
+			return fmt.Errorf("failed processing inventory file %s: %w", file.Key, err)
+		}
+		b.saveCheckpoint(func(cp *inventoryCheckpoint) {
+			cp.Files = i + 1
+			cp.Row = 0
+		})
+	}
+
+	b.saveCheckpoint(func(cp *inventoryCheckpoint) {
+		cp.Done = true
+	})
+	b.log.Infow("Inventory backfill completed.", "manifest", b.checkpoint.Manifest)
+	return nil
+}
+
+// findLatestManifest returns the key of the most recent manifest under the
+// prefix. Reports are delivered to folders named after their creation time,
+// so the most recent manifest has the greatest key.
+func (b *s3InventoryBackfill) findLatestManifest(ctx context.Context, bucketName string) (string, error) {
+	var latest string
+	paginator := b.s3.ListObjectsPaginator(bucketName, b.prefix)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed listing inventory manifests: %w", err)
+		}
+		for _, object := range page.Contents {
+			key := *object.Key
+			if strings.HasSuffix(key, "/"+inventoryManifestName) && key > latest {
+				latest = key
+			}
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no inventory manifest found in bucket %s with prefix %q", bucketName, b.prefix)
+	}
+	return latest, nil
+}
+
+func (b *s3InventoryBackfill) readManifest(ctx context.Context, bucketName, key string) (*inventoryManifest, error) {
+	out, err := b.s3.GetObject(ctx, bucketName, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory manifest %s: %w", key, err)
+	}
+	defer out.Body.Close()
+
+	var manifest inventoryManifest
+	if err := json.NewDecoder(out.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode inventory manifest %s: %w", key, err)
+	}
+	return &manifest, nil
+}
+
+// processFile processes the objects listed in an inventory data file,
+// starting at the given row.
+func (b *s3InventoryBackfill) processFile(ctx context.Context, bucketName, sourceBucket string, schema inventorySchema, file inventoryManifestFile, start int64) error {
+	out, err := b.s3.GetObject(ctx, bucketName, file.Key)
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	body := bufio.NewReader(out.Body)
+	var r io.Reader = body
+	gzipped, err := isStreamGzipped(body)
+	if err != nil {
+		return err
+	}
+	if gzipped {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	cursor := newInventoryCursor(start)
+	advance := func(pos int64) {
+		b.saveCheckpoint(func(cp *inventoryCheckpoint) {
+			cp.Row = pos
+		})
+	}
+
+	workerWg := new(sync.WaitGroup)
+	defer workerWg.Wait()
+
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	for row := int64(0); ; row++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if row < start {
+			continue
+		}
+
+		b.metrics.s3ObjectsListedTotal.Inc()
+		key, ok, err := schema.objectKey(record)
+		if err != nil {
+			b.log.Errorw("Error when parsing inventory record, skipping.", "error", err, "file", file.Key, "row", row)
+		}
+		if !ok {
+			cursor.markDone(row, advance)
+			continue
+		}
+
+		handler, event := b.createS3ObjectProcessor(ctx, sourceBucket, key)
+		if handler == nil {
+			b.log.Debugw("empty s3 processor.", "object_key", key)
+			cursor.markDone(row, advance)
+			continue
+		}
+		b.metrics.s3ObjectsProcessedTotal.Inc()
+
+		if _, err := b.workerSem.AcquireContext(1, ctx); err != nil {
+			return err
+		}
+
+		workerWg.Add(1)
+		go func(row int64) {
+			defer func() {
+				workerWg.Done()
+				b.workerSem.Release(1)
+			}()
+
+			err := handler.ProcessS3Object()
+
+			// Wait for all events to be ACKed before proceeding.
+			handler.Wait()
+
+			if err != nil {
+				b.log.Warnw("Failed processing S3 object listed in inventory.", "error", err,
+					"object_key", event.S3.Object.Key, "bucket", event.S3.Bucket.Name)
+			} else {
+				b.metrics.s3ObjectsAckedTotal.Inc()
+				if err := handler.FinalizeS3Object(); err != nil {
+					b.log.Errorw("Failed to finalize S3 object", "key", event.S3.Object.Key, "error", err)
+				}
+			}
+
+			// Objects that failed are not retried, as in bucket polling mode.
+			cursor.markDone(row, advance)
+		}(row)
+	}
+
+	workerWg.Wait()
+	return ctx.Err()
+}
+
+func (b *s3InventoryBackfill) createS3ObjectProcessor(ctx context.Context, sourceBucket, key string) (s3ObjectHandler, s3EventV2) {
+	event := s3EventV2{}
+	event.AWSRegion = b.region
+	event.Provider = b.provider
+	event.S3.Bucket.Name = sourceBucket
+	event.S3.Bucket.ARN = "arn:aws:s3:::" + sourceBucket
+	event.S3.Object.Key = key
+
+	acker := awscommon.NewEventACKTracker(ctx)
+
+	return b.s3ObjectHandler.Create(ctx, b.log, b.client, acker, event), event
+}
@@ -0,0 +1,235 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/storetest"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestInventorySchema(t *testing.T) {
+	schema, err := newInventorySchema("Bucket, Key, VersionId, IsLatest, IsDeleteMarker, Size")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		record []string
+		key    string
+		ok     bool
+		err    bool
+	}{
+		{
+			name:   "latest version",
+			record: []string{"bucket", "logs/2023/01/01/app%3D1.log", "v1", "true", "false", "10"},
+			key:    "logs/2023/01/01/app=1.log",
+			ok:     true,
+		},
+		{
+			name:   "noncurrent version",
+			record: []string{"bucket", "app.log", "v0", "false", "false", "10"},
+		},
+		{
+			name:   "delete marker",
+			record: []string{"bucket", "app.log", "v2", "true", "true", ""},
+		},
+		{
+			name:   "invalid escaping",
+			record: []string{"bucket", "app%zz.log", "v1", "true", "false", "10"},
+			err:    true,
+		},
+		{
+			name:   "missing fields",
+			record: []string{"bucket", "app.log"},
+			err:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, ok, err := schema.objectKey(tc.record)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.key, key)
+		})
+	}
+
+	_, err = newInventorySchema("Bucket, Size")
+	assert.Error(t, err)
+}
+
+func TestInventoryCursor(t *testing.T) {
+	var positions []int64
+	advance := func(pos int64) { positions = append(positions, pos) }
+
+	c := newInventoryCursor(2)
+	c.markDone(3, advance)
+	c.markDone(5, advance)
+	assert.Empty(t, positions)
+
+	c.markDone(2, advance)
+	c.markDone(4, advance)
+	assert.Equal(t, []int64{4, 6}, positions)
+}
+
+func TestS3InventoryBackfill(t *testing.T) {
+	err := logp.TestingSetup()
+	assert.Nil(t, err)
+
+	const (
+		bucketARN      = "arn:aws:s3:::inventory-bucket"
+		bucket         = "inventory-bucket"
+		prefix         = "inventory/source-bucket/all/"
+		latestManifest = prefix + "2023-01-02T01-00Z/manifest.json"
+		dataFile       = prefix + "data/6b5a7ad8.csv.gz"
+		testTimeout    = 5 * time.Second
+	)
+
+	manifest := []byte(`{
+		"sourceBucket": "source-bucket",
+		"destinationBucket": "` + bucketARN + `",
+		"fileFormat": "CSV",
+		"fileSchema": "Bucket, Key, Size",
+		"files": [{"key": "` + dataFile + `", "size": 100, "MD5checksum": "f11166069f1990abeb9c97ace9cdfabc"}]
+	}`)
+
+	var data bytes.Buffer
+	gz := gzip.NewWriter(&data)
+	_, err = gz.Write([]byte("\"source-bucket\",\"log1\",\"10\"\n" +
+		"\"source-bucket\",\"log2\",\"10\"\n" +
+		"\"source-bucket\",\"log3\",\"10\"\n" +
+		"\"source-bucket\",\"log4\",\"10\"\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	run := func(t *testing.T, store *statestore.Store, wantKeys ...string) {
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+
+		ctrl, ctx := gomock.WithContext(ctx, t)
+		defer ctrl.Finish()
+		mockAPI := NewMockS3API(ctrl)
+		mockS3HandlerFactory := NewMockS3ObjectHandlerFactory(ctrl)
+		mockS3Handler := NewMockS3ObjectHandler(ctrl)
+		mockPublisher := NewMockBeatClient(ctrl)
+
+		mockAPI.EXPECT().
+			GetObject(gomock.Any(), gomock.Eq(bucket), gomock.Eq(latestManifest)).
+			Return(newS3GetObjectResponse("manifest.json", manifest, "application/json"), nil)
+		mockAPI.EXPECT().
+			GetObject(gomock.Any(), gomock.Eq(bucket), gomock.Eq(dataFile)).
+			Return(newS3GetObjectResponse(dataFile, data.Bytes(), ""), nil)
+
+		var mu sync.Mutex
+		var keys []string
+		mockS3HandlerFactory.EXPECT().
+			Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(len(wantKeys)).
+			DoAndReturn(func(_ context.Context, _ *logp.Logger, _ beat.Client, _ *awscommon.EventACKTracker, obj s3EventV2) s3ObjectHandler {
+				assert.Equal(t, "source-bucket", obj.S3.Bucket.Name)
+				assert.Equal(t, "arn:aws:s3:::source-bucket", obj.S3.Bucket.ARN)
+				mu.Lock()
+				keys = append(keys, obj.S3.Object.Key)
+				mu.Unlock()
+				return mockS3Handler
+			})
+		mockS3Handler.EXPECT().ProcessS3Object().Times(len(wantKeys)).Return(nil)
+		mockS3Handler.EXPECT().Wait().Times(len(wantKeys))
+		mockS3Handler.EXPECT().FinalizeS3Object().Times(len(wantKeys)).Return(nil)
+
+		backfill := newS3InventoryBackfill(logp.NewLogger(inputName), nil, mockAPI, mockPublisher, mockS3HandlerFactory, store, bucketARN, prefix, "region", "provider", 2)
+		require.NoError(t, backfill.Backfill(ctx))
+		assert.ElementsMatch(t, wantKeys, keys)
+		assert.Equal(t, 2, backfill.workerSem.Available())
+
+		var cp inventoryCheckpoint
+		require.NoError(t, store.Get(awsS3InventoryPrefix+bucketARN+"/"+prefix, &cp))
+		assert.Equal(t, inventoryCheckpoint{Manifest: latestManifest, Files: 1, Done: true}, cp)
+	}
+
+	newStore := func(t *testing.T) *statestore.Store {
+		storeReg := statestore.NewRegistry(storetest.NewMemoryStoreBackend())
+		store, err := storeReg.Get("test")
+		if err != nil {
+			t.Fatalf("Failed to access store: %v", err)
+		}
+		return store
+	}
+
+	t.Run("find latest manifest", func(t *testing.T) {
+		store := newStore(t)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockAPI := NewMockS3API(ctrl)
+		mockPager := NewMockS3Pager(ctrl)
+		mockAPI.EXPECT().
+			ListObjectsPaginator(gomock.Eq(bucket), gomock.Eq(prefix)).
+			Return(mockPager)
+		gomock.InOrder(
+			mockPager.EXPECT().HasMorePages().Return(true),
+			mockPager.EXPECT().NextPage(gomock.Any()).Return(&s3.ListObjectsV2Output{
+				Contents: []types.Object{
+					{Key: aws.String(prefix + "2023-01-01T01-00Z/manifest.json")},
+					{Key: aws.String(prefix + "2023-01-01T01-00Z/manifest.checksum")},
+					{Key: aws.String(latestManifest)},
+					{Key: aws.String(dataFile)},
+				},
+			}, nil),
+			mockPager.EXPECT().HasMorePages().Return(false),
+		)
+
+		b := newS3InventoryBackfill(logp.NewLogger(inputName), nil, mockAPI, nil, nil, store, bucketARN, prefix, "region", "provider", 2)
+		key, err := b.findLatestManifest(context.Background(), bucket)
+		require.NoError(t, err)
+		assert.Equal(t, latestManifest, key)
+	})
+
+	t.Run("backfill all objects", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.Set(awsS3InventoryPrefix+bucketARN+"/"+prefix, inventoryCheckpoint{Manifest: latestManifest}))
+
+		run(t, store, "log1", "log2", "log3", "log4")
+	})
+
+	t.Run("resume from checkpoint", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.Set(awsS3InventoryPrefix+bucketARN+"/"+prefix, inventoryCheckpoint{Manifest: latestManifest, Row: 2}))
+
+		run(t, store, "log3", "log4")
+	})
+
+	t.Run("completed backfill is not repeated", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.Set(awsS3InventoryPrefix+bucketARN+"/"+prefix, inventoryCheckpoint{Manifest: latestManifest, Files: 1, Done: true}))
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockAPI := NewMockS3API(ctrl)
+
+		b := newS3InventoryBackfill(logp.NewLogger(inputName), nil, mockAPI, nil, nil, store, bucketARN, prefix, "region", "provider", 2)
+		require.NoError(t, b.Backfill(context.Background()))
+	})
+}
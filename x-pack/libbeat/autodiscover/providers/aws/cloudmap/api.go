@@ -0,0 +1,196 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	signingName  = "servicediscovery"
+	targetPrefix = "Route53AutoNaming_v20170314."
+)
+
+// api is the subset of the Cloud Map API used by the provider.
+type api interface {
+	listNamespaces(ctx context.Context) ([]namespace, error)
+	listServices(ctx context.Context, namespaceID string) ([]service, error)
+	listInstances(ctx context.Context, serviceID string) ([]instance, error)
+}
+
+type namespace struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+	Type string `json:"Type"`
+}
+
+type service struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+type instance struct {
+	ID         string            `json:"Id"`
+	Attributes map[string]string `json:"Attributes"`
+}
+
+// apiClient calls the Cloud Map API of a region. The API uses the AWS JSON
+// 1.1 protocol, with requests signed with the AWS signature version 4.
+type apiClient struct {
+	url    string
+	region string
+	config awssdk.Config
+	signer *v4.Signer
+}
+
+func newAPIClient(config awssdk.Config, endpoint string, fips bool) *apiClient {
+	return &apiClient{
+		url:    endpointURL(endpoint, config.Region, fips),
+		region: config.Region,
+		config: config,
+		signer: v4.NewSigner(),
+	}
+}
+
+// endpointURL returns the URL of the Cloud Map API of a region. A custom
+// endpoint can be a domain replacing amazonaws.com, or a full URL.
+func endpointURL(endpoint, region string, fips bool) string {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return endpoint
+	}
+	if endpoint == "" {
+		endpoint = "amazonaws.com"
+	}
+	host := signingName
+	if fips {
+		host += "-fips"
+	}
+	return fmt.Sprintf("https://%s.%s.%s", host, region, endpoint)
+}
+
+func (c *apiClient) listNamespaces(ctx context.Context) ([]namespace, error) {
+	var namespaces []namespace
+	err := c.paginate(ctx, "ListNamespaces", map[string]interface{}{}, func(data []byte) error {
+		var page struct{ Namespaces []namespace }
+		err := json.Unmarshal(data, &page)
+		namespaces = append(namespaces, page.Namespaces...)
+		return err
+	})
+	return namespaces, err
+}
+
+func (c *apiClient) listServices(ctx context.Context, namespaceID string) ([]service, error) {
+	input := map[string]interface{}{
+		"Filters": []map[string]interface{}{{
+			"Name":      "NAMESPACE_ID",
+			"Values":    []string{namespaceID},
+			"Condition": "EQ",
+		}},
+	}
+	var services []service
+	err := c.paginate(ctx, "ListServices", input, func(data []byte) error {
+		var page struct{ Services []service }
+		err := json.Unmarshal(data, &page)
+		services = append(services, page.Services...)
+		return err
+	})
+	return services, err
+}
+
+func (c *apiClient) listInstances(ctx context.Context, serviceID string) ([]instance, error) {
+	input := map[string]interface{}{"ServiceId": serviceID}
+	var instances []instance
+	err := c.paginate(ctx, "ListInstances", input, func(data []byte) error {
+		var page struct{ Instances []instance }
+		err := json.Unmarshal(data, &page)
+		instances = append(instances, page.Instances...)
+		return err
+	})
+	return instances, err
+}
+
+// paginate calls an operation until all the pages of results are received.
+func (c *apiClient) paginate(ctx context.Context, operation string, input map[string]interface{}, onPage func([]byte) error) error {
+	for {
+		data, err := c.call(ctx, operation, input)
+		if err != nil {
+			return err
+		}
+		if err := onPage(data); err != nil {
+			return fmt.Errorf("failed to decode %v response: %w", operation, err)
+		}
+
+		var page struct{ NextToken string }
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("failed to decode %v response: %w", operation, err)
+		}
+		if page.NextToken == "" {
+			return nil
+		}
+		input["NextToken"] = page.NextToken
+	}
+}
+
+func (c *apiClient) call(ctx context.Context, operation string, input interface{}) ([]byte, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", targetPrefix+operation)
+
+	if c.config.Credentials != nil {
+		creds, err := c.config.Credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+		}
+		hash := sha256.Sum256(body)
+		err = c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), signingName, c.region, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign %v request: %w", operation, err)
+		}
+	}
+
+	var client awssdk.HTTPClient = http.DefaultClient
+	if c.config.HTTPClient != nil {
+		client = c.config.HTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%v request failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v response: %w", operation, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		return nil, fmt.Errorf("%v request failed with status %v: %v %v", operation, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+	return data, nil
+}
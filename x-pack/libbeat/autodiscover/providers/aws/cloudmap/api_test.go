@@ -0,0 +1,82 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointURL(t *testing.T) {
+	assert.Equal(t, "https://servicediscovery.eu-west-1.amazonaws.com", endpointURL("", "eu-west-1", false))
+	assert.Equal(t, "https://servicediscovery-fips.us-east-1.amazonaws.com", endpointURL("", "us-east-1", true))
+	assert.Equal(t, "https://servicediscovery.cn-north-1.amazonaws.com.cn", endpointURL("amazonaws.com.cn", "cn-north-1", false))
+	assert.Equal(t, "http://localhost:4566", endpointURL("http://localhost:4566", "us-east-1", false))
+}
+
+func TestAPIClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"),
+			r.Header.Get("Authorization"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/servicediscovery/aws4_request")
+
+		var input map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "Route53AutoNaming_v20170314.ListNamespaces":
+			_, _ = w.Write([]byte(`{"Namespaces": [{"Id": "ns-1", "Name": "prod.local", "Type": "DNS_PRIVATE"}]}`))
+		case "Route53AutoNaming_v20170314.ListServices":
+			assert.Equal(t, "ns-1", input["Filters"].([]interface{})[0].(map[string]interface{})["Values"].([]interface{})[0])
+			_, _ = w.Write([]byte(`{"Services": [{"Id": "srv-1", "Name": "mysql"}]}`))
+		case "Route53AutoNaming_v20170314.ListInstances":
+			assert.Equal(t, "srv-1", input["ServiceId"])
+			if input["NextToken"] == nil {
+				_, _ = w.Write([]byte(`{"Instances": [{"Id": "i-1", "Attributes": {"AWS_INSTANCE_IPV4": "10.0.0.1"}}], "NextToken": "next"}`))
+			} else {
+				assert.Equal(t, "next", input["NextToken"])
+				_, _ = w.Write([]byte(`{"Instances": [{"Id": "i-2", "Attributes": {"AWS_INSTANCE_IPV4": "10.0.0.2"}}]}`))
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type": "UnknownOperationException", "message": "unknown operation"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newAPIClient(awssdk.Config{
+		Region:      "eu-west-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, server.URL, false)
+
+	ctx := context.Background()
+	namespaces, err := client.listNamespaces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []namespace{{ID: "ns-1", Name: "prod.local", Type: "DNS_PRIVATE"}}, namespaces)
+
+	services, err := client.listServices(ctx, "ns-1")
+	require.NoError(t, err)
+	assert.Equal(t, []service{{ID: "srv-1", Name: "mysql"}}, services)
+
+	instances, err := client.listInstances(ctx, "srv-1")
+	require.NoError(t, err)
+	assert.Equal(t, []instance{
+		{ID: "i-1", Attributes: map[string]string{"AWS_INSTANCE_IPV4": "10.0.0.1"}},
+		{ID: "i-2", Attributes: map[string]string{"AWS_INSTANCE_IPV4": "10.0.0.2"}},
+	}, instances)
+
+	_, err = client.call(ctx, "DeleteNamespace", map[string]interface{}{})
+	assert.EqualError(t, err, "DeleteNamespace request failed with status 400: UnknownOperationException unknown operation")
+}
@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"strconv"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// cloudMapInstance is an instance registered in a Cloud Map service.
+type cloudMapInstance struct {
+	region    string
+	namespace namespace
+	service   service
+	instance  instance
+}
+
+// id returns the unique ID of the instance, instance IDs are only unique
+// within their service.
+func (i *cloudMapInstance) id() string {
+	return i.service.ID + "/" + i.instance.ID
+}
+
+// host returns the address of the instance, from its standard attributes.
+func (i *cloudMapInstance) host() string {
+	for _, attr := range []string{"AWS_INSTANCE_IPV4", "AWS_INSTANCE_IPV6", "AWS_INSTANCE_CNAME"} {
+		if host := i.instance.Attributes[attr]; host != "" {
+			return host
+		}
+	}
+	return ""
+}
+
+// port returns the port of the instance, or 0 if not registered.
+func (i *cloudMapInstance) port() int {
+	port, _ := strconv.Atoi(i.instance.Attributes["AWS_INSTANCE_PORT"])
+	return port
+}
+
+// toMap converts this cloudMapInstance into the form consumed as metadata in the autodiscovery process.
+func (i *cloudMapInstance) toMap() mapstr.M {
+	attributes := mapstr.M{}
+	for k, v := range i.instance.Attributes {
+		attributes[k] = v
+	}
+
+	return mapstr.M{
+		"namespace": mapstr.M{
+			"id":   i.namespace.ID,
+			"name": i.namespace.Name,
+			"type": i.namespace.Type,
+		},
+		"service": mapstr.M{
+			"id":   i.service.ID,
+			"name": i.service.Name,
+		},
+		"instance": mapstr.M{
+			"id":         i.instance.ID,
+			"attributes": attributes,
+		},
+	}
+}
+
+func (i *cloudMapInstance) toCloudMap() mapstr.M {
+	return mapstr.M{
+		"provider": "aws",
+		"region":   i.region,
+	}
+}
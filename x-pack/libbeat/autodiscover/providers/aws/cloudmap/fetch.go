@@ -0,0 +1,124 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+)
+
+// fetcher is an interface that can fetch a list of cloudMapInstance objects without pagination being necessary.
+type fetcher interface {
+	fetch(ctx context.Context) ([]*cloudMapInstance, error)
+}
+
+// apiMultiFetcher fetches results from multiple fetchers concatenating their results together
+// Useful since we have a fetcher per region, this combines them.
+type apiMultiFetcher struct {
+	fetchers []fetcher
+}
+
+func (amf *apiMultiFetcher) fetch(ctx context.Context) ([]*cloudMapInstance, error) {
+	fetchResults := make(chan []*cloudMapInstance)
+	fetchErr := make(chan error)
+
+	// Simultaneously fetch all from each region
+	for _, f := range amf.fetchers {
+		go func(f fetcher) {
+			res, err := f.fetch(ctx)
+			if err != nil {
+				fetchErr <- err
+			} else {
+				fetchResults <- res
+			}
+		}(f)
+	}
+
+	var results []*cloudMapInstance
+	var errs []error
+
+	for pending := len(amf.fetchers); pending > 0; pending-- {
+		select {
+		case r := <-fetchResults:
+			results = append(results, r...)
+		case e := <-fetchErr:
+			errs = append(errs, e)
+		}
+	}
+
+	return results, multierr.Combine(errs...)
+}
+
+// apiFetcher fetches the instances of the services of a region.
+type apiFetcher struct {
+	region     string
+	client     api
+	namespaces map[string]bool
+	services   map[string]bool
+}
+
+func newAPIFetcher(clients map[string]api, namespaces, services []string) fetcher {
+	fetchers := make([]fetcher, 0, len(clients))
+	for region, client := range clients {
+		fetchers = append(fetchers, &apiFetcher{
+			region:     region,
+			client:     client,
+			namespaces: toSet(namespaces),
+			services:   toSet(services),
+		})
+	}
+	return &apiMultiFetcher{fetchers}
+}
+
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// fetch lists the instances of the selected services, in the selected
+// namespaces. All services and namespaces are selected if no name is
+// configured.
+func (f *apiFetcher) fetch(ctx context.Context) ([]*cloudMapInstance, error) {
+	namespaces, err := f.client.listNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*cloudMapInstance
+	for _, ns := range namespaces {
+		if f.namespaces != nil && !f.namespaces[ns.Name] {
+			continue
+		}
+		services, err := f.client.listServices(ctx, ns.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range services {
+			if f.services != nil && !f.services[svc.Name] {
+				continue
+			}
+			instances, err := f.client.listInstances(ctx, svc.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, inst := range instances {
+				results = append(results, &cloudMapInstance{
+					region:    f.region,
+					namespace: ns,
+					service:   svc,
+					instance:  inst,
+				})
+			}
+		}
+	}
+	return results, nil
+}
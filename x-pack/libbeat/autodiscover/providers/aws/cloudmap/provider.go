@@ -0,0 +1,175 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/libbeat/autodiscover"
+	"github.com/elastic/beats/v7/libbeat/autodiscover/template"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	awsauto "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	_ = autodiscover.Registry.AddProvider("aws_cloudmap", AutodiscoverBuilder)
+}
+
+// config holds the settings specific to the Cloud Map provider.
+type config struct {
+	// Namespaces and Services select the services watched by name. All of
+	// them are watched if not set.
+	Namespaces []string `config:"namespaces"`
+	Services   []string `config:"services"`
+}
+
+// Provider implements autodiscover provider for the instances registered in
+// AWS Cloud Map services.
+type Provider struct {
+	config    *awsauto.Config
+	bus       bus.Bus
+	templates *template.Mapper
+	watcher   *watcher
+	uuid      uuid.UUID
+}
+
+// AutodiscoverBuilder is the main builder for this provider.
+func AutodiscoverBuilder(
+	beatName string,
+	bus bus.Bus,
+	uuid uuid.UUID,
+	c *conf.C,
+	keystore keystore.Keystore,
+) (autodiscover.Provider, error) {
+	cfgwarn.Experimental("aws_cloudmap autodiscover is experimental")
+
+	awsConfig := awsauto.DefaultConfig()
+	if err := c.Unpack(&awsConfig); err != nil {
+		return nil, err
+	}
+	var cloudMapConfig config
+	if err := c.Unpack(&cloudMapConfig); err != nil {
+		return nil, err
+	}
+
+	awsCfg, err := awscommon.InitializeAWSConfig(awsConfig.AWSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config for aws_cloudmap autodiscover provider: %w", err)
+	}
+
+	// Watch all regions if there is no region specified.
+	if awsConfig.Regions == nil {
+		// set default region to make initial aws api call
+		awsCfg.Region = "us-west-1"
+		svcEC2 := ec2.NewFromConfig(awsCfg, func(o *ec2.Options) {
+			if awsConfig.AWSConfig.FIPSEnabled {
+				o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+			}
+		})
+
+		completeRegionsList, err := awsauto.GetRegions(svcEC2)
+		if err != nil {
+			return nil, err
+		}
+
+		awsConfig.Regions = completeRegionsList
+	}
+
+	clients := make(map[string]api, len(awsConfig.Regions))
+	for _, region := range awsConfig.Regions {
+		regionCfg := awsCfg.Copy()
+		regionCfg.Region = region
+		clients[region] = newAPIClient(regionCfg, awsConfig.AWSConfig.Endpoint, awsConfig.AWSConfig.FIPSEnabled)
+	}
+
+	fetcher := newAPIFetcher(clients, cloudMapConfig.Namespaces, cloudMapConfig.Services)
+	return internalBuilder(uuid, bus, awsConfig, fetcher, keystore)
+}
+
+// internalBuilder is mainly intended for testing via mocks and stubs.
+// it can be configured to use a fetcher that doesn't actually hit the AWS API.
+func internalBuilder(uuid uuid.UUID, bus bus.Bus, config *awsauto.Config, fetcher fetcher, keystore keystore.Keystore) (*Provider, error) {
+	mapper, err := template.NewConfigMapper(config.Templates, keystore, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		config:    config,
+		bus:       bus,
+		templates: &mapper,
+		uuid:      uuid,
+	}
+
+	p.watcher = newWatcher(
+		fetcher,
+		config.Period,
+		p.onWatcherStart,
+		p.onWatcherStop,
+	)
+
+	return p, nil
+}
+
+// Start the autodiscover process.
+func (p *Provider) Start() {
+	p.watcher.start()
+}
+
+// Stop the autodiscover process.
+func (p *Provider) Stop() {
+	p.watcher.stop()
+}
+
+func (p *Provider) onWatcherStart(id string, instance *cloudMapInstance) {
+	e := bus.Event{
+		"start":    true,
+		"provider": p.uuid,
+		"id":       id,
+		"aws": mapstr.M{
+			"cloudmap": instance.toMap(),
+		},
+		"cloud": instance.toCloudMap(),
+		"meta": mapstr.M{
+			"aws": mapstr.M{
+				"cloudmap": instance.toMap(),
+			},
+			"cloud": instance.toCloudMap(),
+		},
+	}
+	if host := instance.host(); host != "" {
+		e["host"] = host
+	}
+	if port := instance.port(); port != 0 {
+		e["port"] = port
+	}
+
+	if configs := p.templates.GetConfig(e); configs != nil {
+		e["config"] = configs
+	}
+	p.bus.Publish(e)
+}
+
+func (p *Provider) onWatcherStop(id string) {
+	e := bus.Event{
+		"stop":     true,
+		"id":       id,
+		"provider": p.uuid,
+	}
+	p.bus.Publish(e)
+}
+
+func (p *Provider) String() string {
+	return "aws_cloudmap"
+}
@@ -0,0 +1,180 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsauto "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws"
+	"github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/test"
+	"github.com/elastic/elastic-agent-autodiscover/bus"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// mockAPI is a Cloud Map API returning a customizable set of services.
+type mockAPI struct {
+	mu         sync.Mutex
+	namespaces []namespace
+	services   map[string][]service
+	instances  map[string][]instance
+	err        error
+}
+
+func (m *mockAPI) listNamespaces(context.Context) ([]namespace, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.namespaces, m.err
+}
+
+func (m *mockAPI) listServices(_ context.Context, namespaceID string) ([]service, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.services[namespaceID], m.err
+}
+
+func (m *mockAPI) listInstances(_ context.Context, serviceID string) ([]instance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.instances[serviceID], m.err
+}
+
+func (m *mockAPI) setInstances(serviceID string, instances []instance) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.instances[serviceID] = instances
+}
+
+func (m *mockAPI) setError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+func newMockAPI() *mockAPI {
+	return &mockAPI{
+		namespaces: []namespace{
+			{ID: "ns-1", Name: "prod.local", Type: "DNS_PRIVATE"},
+			{ID: "ns-2", Name: "test.local", Type: "DNS_PRIVATE"},
+		},
+		services: map[string][]service{
+			"ns-1": {{ID: "srv-1", Name: "mysql"}, {ID: "srv-2", Name: "redis"}},
+			"ns-2": {{ID: "srv-3", Name: "mysql"}},
+		},
+		instances: map[string][]instance{
+			"srv-1": {{ID: "i-1", Attributes: map[string]string{"AWS_INSTANCE_IPV4": "10.0.0.1", "AWS_INSTANCE_PORT": "3306"}}},
+			"srv-2": {{ID: "i-2", Attributes: map[string]string{"AWS_INSTANCE_IPV4": "10.0.0.2"}}},
+			"srv-3": {{ID: "i-3", Attributes: map[string]string{"AWS_INSTANCE_IPV4": "10.0.1.1"}}},
+		},
+	}
+}
+
+func TestFetcherFilters(t *testing.T) {
+	fetch := func(namespaces, services []string) []string {
+		f := newAPIFetcher(map[string]api{"eu-west-1": newMockAPI()}, namespaces, services)
+		instances, err := f.fetch(context.Background())
+		require.NoError(t, err)
+		var ids []string
+		for _, i := range instances {
+			ids = append(ids, i.id())
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []string{"srv-1/i-1", "srv-2/i-2", "srv-3/i-3"}, fetch(nil, nil))
+	assert.ElementsMatch(t, []string{"srv-1/i-1", "srv-2/i-2"}, fetch([]string{"prod.local"}, nil))
+	assert.ElementsMatch(t, []string{"srv-1/i-1", "srv-3/i-3"}, fetch(nil, []string{"mysql"}))
+	assert.ElementsMatch(t, []string{"srv-1/i-1"}, fetch([]string{"prod.local"}, []string{"mysql"}))
+}
+
+func Test_internalBuilder(t *testing.T) {
+	client := newMockAPI()
+	fetcher := newAPIFetcher(map[string]api{"eu-west-1": client}, []string{"prod.local"}, []string{"mysql"})
+	log := logp.NewLogger("cloudmap")
+	pBus := bus.New(log, "test")
+
+	cfg := &awsauto.Config{
+		Regions: []string{"eu-west-1"},
+		Period:  time.Nanosecond,
+	}
+
+	uuid, _ := uuid.NewV4()
+	k, _ := keystore.NewFileKeystore("test")
+	provider, err := internalBuilder(uuid, pBus, cfg, fetcher, k)
+	require.NoError(t, err)
+
+	startListener := pBus.Subscribe("start")
+	stopListener := pBus.Subscribe("stop")
+	listenerDone := make(chan struct{})
+	defer close(listenerDone)
+
+	var events test.TestEventAccumulator
+	go func() {
+		for {
+			select {
+			case e := <-startListener.Events():
+				events.Add(e)
+			case e := <-stopListener.Events():
+				events.Add(e)
+			case <-listenerDone:
+				return
+			}
+		}
+	}()
+
+	// Let run twice to ensure that duplicates don't create two start events
+	require.NoError(t, provider.watcher.once())
+	require.NoError(t, provider.watcher.once())
+	events.WaitForNumEvents(t, 1, time.Second)
+	assert.Equal(t, 1, events.Len())
+
+	metadata := mapstr.M{
+		"namespace": mapstr.M{"id": "ns-1", "name": "prod.local", "type": "DNS_PRIVATE"},
+		"service":   mapstr.M{"id": "srv-1", "name": "mysql"},
+		"instance": mapstr.M{
+			"id":         "i-1",
+			"attributes": mapstr.M{"AWS_INSTANCE_IPV4": "10.0.0.1", "AWS_INSTANCE_PORT": "3306"},
+		},
+	}
+	cloud := mapstr.M{"provider": "aws", "region": "eu-west-1"}
+	require.Equal(t, bus.Event{
+		"id":       "srv-1/i-1",
+		"provider": uuid,
+		"start":    true,
+		"host":     "10.0.0.1",
+		"port":     3306,
+		"aws":      mapstr.M{"cloudmap": metadata},
+		"cloud":    cloud,
+		"meta": mapstr.M{
+			"aws":   mapstr.M{"cloudmap": metadata},
+			"cloud": cloud,
+		},
+	}, events.Get()[0])
+
+	client.setInstances("srv-1", nil)
+	require.NoError(t, provider.watcher.once())
+	require.NoError(t, provider.watcher.once())
+	events.WaitForNumEvents(t, 2, time.Second)
+	require.Equal(t, 2, events.Len())
+	require.Equal(t, bus.Event{
+		"stop":     true,
+		"id":       "srv-1/i-1",
+		"provider": uuid,
+	}, events.Get()[1])
+
+	// Test that in an error situation nothing changes.
+	client.setError(errors.New("oops"))
+	assert.Error(t, provider.watcher.once())
+	assert.Equal(t, 2, events.Len())
+}
@@ -0,0 +1,105 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudmap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type watcher struct {
+	// gen tracks changes we increment the 'generation' of each entry in the map.
+	gen       uint64
+	fetcher   fetcher
+	onStart   func(id string, instance *cloudMapInstance)
+	onStop    func(id string)
+	done      chan struct{}
+	ticker    *time.Ticker
+	period    time.Duration
+	instances map[string]uint64
+	logger    *logp.Logger
+}
+
+func newWatcher(
+	fetcher fetcher,
+	period time.Duration,
+	onStart func(id string, instance *cloudMapInstance),
+	onStop func(id string)) *watcher {
+	return &watcher{
+		fetcher:   fetcher,
+		onStart:   onStart,
+		onStop:    onStop,
+		done:      make(chan struct{}),
+		ticker:    time.NewTicker(period),
+		period:    period,
+		instances: map[string]uint64{},
+		logger:    logp.NewLogger("autodiscover-cloudmap-watcher"),
+	}
+}
+
+func (w *watcher) start() {
+	go w.forever()
+}
+
+func (w *watcher) stop() {
+	close(w.done)
+}
+
+func (w *watcher) forever() {
+	for {
+		select {
+		case <-w.done:
+			w.ticker.Stop()
+			return
+		case <-w.ticker.C:
+			err := w.once()
+			if err != nil {
+				w.logger.Error(fmt.Errorf("error while fetching AWS Cloud Map instances: %w", err))
+			}
+		}
+	}
+}
+
+// once executes the watch loop a single time.
+// This is mostly useful for testing.
+func (w *watcher) once() error {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), w.period)
+	defer cancelCtx() // Always cancel to avoid leak
+
+	fetched, err := w.fetcher.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	w.logger.Debugf("fetched %d cloud map instances from AWS for autodiscover", len(fetched))
+
+	oldGen := w.gen
+	w.gen++
+
+	// Increment the generation of all instances returned by the API request
+	for _, instance := range fetched {
+		id := instance.id()
+		if _, exists := w.instances[id]; !exists {
+			if w.onStart != nil {
+				w.onStart(id, instance)
+			}
+		}
+		w.instances[id] = w.gen
+	}
+
+	// Instances not seen in the API request get deleted
+	for id, entryGen := range w.instances {
+		if entryGen == oldGen {
+			if w.onStop != nil {
+				w.onStop(id)
+			}
+			delete(w.instances, id)
+		}
+	}
+
+	return nil
+}
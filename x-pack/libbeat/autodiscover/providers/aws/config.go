@@ -23,6 +23,17 @@ type Config struct {
 	// AWS Specific autodiscover fields
 	Regions   []string      `config:"regions"`
 	AWSConfig aws.ConfigAWS `config:",inline"`
+
+	// TagsFilter limits the discovered resources to the ones with all the
+	// given tags.
+	TagsFilter []Tag `config:"tags_filter"`
+}
+
+// Tag filters the discovered resources by tag. A resource matches if it has
+// the tag with any of the values, or with any value if no value is given.
+type Tag struct {
+	Key   string   `config:"key" validate:"required"`
+	Value []string `config:"value"`
 }
 
 // DefaultConfig for all aws autodiscover providers.
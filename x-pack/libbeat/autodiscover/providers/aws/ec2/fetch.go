@@ -8,6 +8,7 @@ import (
 	"context"
 	"sync"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"go.uber.org/multierr"
@@ -60,17 +61,39 @@ func (amf *apiMultiFetcher) fetch(ctx context.Context) ([]*ec2Instance, error) {
 
 // apiFetcher is a concrete implementation of fetcher that hits the real AWS API.
 type apiFetcher struct {
-	client ec2.DescribeInstancesAPIClient
+	client  ec2.DescribeInstancesAPIClient
+	filters []ec2types.Filter
 }
 
-func newAPIFetcher(clients []ec2.DescribeInstancesAPIClient) fetcher {
+func newAPIFetcher(clients []ec2.DescribeInstancesAPIClient, tags []awsauto.Tag) fetcher {
+	filters := tagsFilters(tags)
 	fetchers := make([]fetcher, len(clients))
 	for idx, client := range clients {
-		fetchers[idx] = &apiFetcher{client}
+		fetchers[idx] = &apiFetcher{client, filters}
 	}
 	return &apiMultiFetcher{fetchers}
 }
 
+// tagsFilters converts the tags filter of the configuration to EC2 API
+// filters, so the instances are filtered by the API.
+func tagsFilters(tags []awsauto.Tag) []ec2types.Filter {
+	filters := make([]ec2types.Filter, 0, len(tags))
+	for _, tag := range tags {
+		if len(tag.Value) == 0 {
+			filters = append(filters, ec2types.Filter{
+				Name:   awssdk.String("tag-key"),
+				Values: []string{tag.Key},
+			})
+			continue
+		}
+		filters = append(filters, ec2types.Filter{
+			Name:   awssdk.String("tag:" + tag.Key),
+			Values: tag.Value,
+		})
+	}
+	return filters
+}
+
 // fetch attempts to request the full list of ec2Instance objects.
 // It accomplishes this by fetching a page of EC2 instances, then one go routine
 // per listener API request. Each page of results has O(n)+1 perf since we need that
@@ -80,6 +103,9 @@ func (f *apiFetcher) fetch(ctx context.Context) ([]*ec2Instance, error) {
 	var MaxResults int32 = 50
 
 	describeInstanceInput := &ec2.DescribeInstancesInput{MaxResults: &MaxResults}
+	if len(f.filters) > 0 {
+		describeInstanceInput.Filters = f.filters
+	}
 
 	svcDescribeInstances := ec2.NewDescribeInstancesPaginator(f.client, describeInstanceInput)
 
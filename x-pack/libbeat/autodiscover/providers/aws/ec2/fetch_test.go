@@ -7,13 +7,28 @@ package ec2
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
-
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	awsauto "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws"
 )
 
 func Test_newAPIFetcher(t *testing.T) {
 	client := newMockEC2Client(0)
-	fetcher := newAPIFetcher([]ec2.DescribeInstancesAPIClient{client})
+	fetcher := newAPIFetcher([]ec2.DescribeInstancesAPIClient{client}, nil)
 	require.NotNil(t, fetcher)
 }
+
+func Test_tagsFilters(t *testing.T) {
+	filters := tagsFilters([]awsauto.Tag{
+		{Key: "service", Value: []string{"mysql", "mariadb"}},
+		{Key: "monitored"},
+	})
+	assert.Equal(t, []ec2types.Filter{
+		{Name: aws.String("tag:service"), Values: []string{"mysql", "mariadb"}},
+		{Name: aws.String("tag-key"), Values: []string{"monitored"}},
+	}, filters)
+}
@@ -92,7 +92,7 @@ func AutodiscoverBuilder(
 		}))
 	}
 
-	return internalBuilder(uuid, bus, config, newAPIFetcher(clients), keystore)
+	return internalBuilder(uuid, bus, config, newAPIFetcher(clients, config.TagsFilter), keystore)
 }
 
 // internalBuilder is mainly intended for testing via mocks and stubs.
@@ -0,0 +1,146 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package kafka registers Kafka OAUTHBEARER token providers that depend on
+// cloud provider SDKs.
+package kafka
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/elastic/beats/v7/libbeat/common/kafka"
+	"github.com/elastic/beats/v7/libbeat/version"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+const (
+	mskService = "kafka-cluster"
+	mskAction  = "kafka-cluster:Connect"
+
+	// mskTokenExpiry is the lifetime of the presigned URL used as token.
+	mskTokenExpiry = 15 * time.Minute
+	// mskTokenRefresh is how long before expiry a new token is generated.
+	mskTokenRefresh = time.Minute
+)
+
+// emptyPayloadHash is the SHA256 of an empty body, the presigned request
+// has no payload.
+var emptyPayloadHash = func() string {
+	sum := sha256.Sum256(nil)
+	return hex.EncodeToString(sum[:])
+}()
+
+func init() {
+	kafka.RegisterTokenProvider("aws_msk_iam", newMSKIAMTokenProvider)
+}
+
+type mskIAMConfig struct {
+	Region              string `config:"region"`
+	awscommon.ConfigAWS `config:",inline"`
+}
+
+// mskIAMTokenProvider generates Amazon MSK IAM authentication tokens. The
+// token is a base64 encoded presigned URL for the kafka-cluster:Connect
+// action, signed with the configured AWS credentials.
+type mskIAMTokenProvider struct {
+	region      string
+	credentials awssdk.CredentialsProvider
+	signer      *v4.Signer
+	now         func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newMSKIAMTokenProvider(cfg *config.C) (sarama.AccessTokenProvider, error) {
+	var c mskIAMConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	if c.Region != "" {
+		c.DefaultRegion = c.Region
+	}
+
+	awsConfig, err := awscommon.InitializeAWSConfig(c.ConfigAWS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AWS credentials for MSK IAM authentication: %w", err)
+	}
+	if awsConfig.Credentials == nil {
+		return nil, fmt.Errorf("no AWS credentials found for MSK IAM authentication")
+	}
+
+	return &mskIAMTokenProvider{
+		region:      awsConfig.Region,
+		credentials: awsConfig.Credentials,
+		signer:      v4.NewSigner(),
+		now:         time.Now,
+	}, nil
+}
+
+func (p *mskIAMTokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.token == "" || now.After(p.expires.Add(-mskTokenRefresh)) {
+		token, err := p.generate(context.Background(), now)
+		if err != nil {
+			return nil, err
+		}
+		p.token = token
+		p.expires = now.Add(mskTokenExpiry)
+	}
+	return &sarama.AccessToken{Token: p.token}, nil
+}
+
+func (p *mskIAMTokenProvider) generate(ctx context.Context, now time.Time) (string, error) {
+	creds, err := p.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("Action", mskAction)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(mskTokenExpiry/time.Second)))
+	endpoint := url.URL{
+		Scheme:   "https",
+		Host:     fmt.Sprintf("kafka.%s.amazonaws.com", p.region),
+		Path:     "/",
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	signed, _, err := p.signer.PresignHTTP(ctx, creds, req, emptyPayloadHash, mskService, p.region, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign MSK IAM authentication request: %w", err)
+	}
+
+	signedURL, err := url.Parse(signed)
+	if err != nil {
+		return "", err
+	}
+	query = signedURL.Query()
+	query.Set("User-Agent", "beats/"+version.GetDefaultVersion())
+	signedURL.RawQuery = query.Encode()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(signedURL.String())), nil
+}
@@ -0,0 +1,62 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestMSKIAMToken(t *testing.T) {
+	provider, err := newMSKIAMTokenProvider(config.MustNewConfigFrom(map[string]interface{}{
+		"provider":          "aws_msk_iam",
+		"region":            "eu-west-1",
+		"access_key_id":     "AKID",
+		"secret_access_key": "SECRET",
+	}))
+	require.NoError(t, err)
+
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	provider.(*mskIAMTokenProvider).now = func() time.Time { return now }
+
+	token, err := provider.Token()
+	require.NoError(t, err)
+
+	raw, err := base64.RawURLEncoding.DecodeString(token.Token)
+	require.NoError(t, err)
+	signed, err := url.Parse(string(raw))
+	require.NoError(t, err)
+
+	assert.Equal(t, "https", signed.Scheme)
+	assert.Equal(t, "kafka.eu-west-1.amazonaws.com", signed.Host)
+
+	query := signed.Query()
+	assert.Equal(t, "kafka-cluster:Connect", query.Get("Action"))
+	assert.Equal(t, "AWS4-HMAC-SHA256", query.Get("X-Amz-Algorithm"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.Equal(t, "20221001T120000Z", query.Get("X-Amz-Date"))
+	assert.Equal(t, "AKID/20221001/eu-west-1/kafka-cluster/aws4_request", query.Get("X-Amz-Credential"))
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+	assert.True(t, strings.HasPrefix(query.Get("User-Agent"), "beats/"))
+
+	// The token is reused until it is close to expiring.
+	now = now.Add(10 * time.Minute)
+	cached, err := provider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, token.Token, cached.Token)
+
+	now = now.Add(5 * time.Minute)
+	refreshed, err := provider.Token()
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Token, refreshed.Token)
+}
@@ -12,7 +12,14 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/processors/add_cloudfoundry_metadata"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/processors/add_nomad_metadata"
 
+	// register kafka OAUTHBEARER token providers
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/common/kafka"
+
+	// register keystore backends
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/secrets"
+
 	// register autodiscover providers
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/cloudmap"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/ec2"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/elb"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/nomad"
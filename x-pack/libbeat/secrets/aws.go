@@ -0,0 +1,155 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package secrets registers keystore backends for the secret managers of
+// cloud providers.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/elastic/beats/v7/libbeat/secrets"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+const awsSigningName = "secretsmanager"
+
+func init() {
+	secrets.RegisterBackend("aws_secretsmanager", newAWSBackend)
+}
+
+type awsConfig struct {
+	Region              string `config:"region"`
+	Prefix              string `config:"prefix"`
+	awscommon.ConfigAWS `config:",inline"`
+}
+
+// awsBackend reads the secrets from AWS Secrets Manager, the keys are the
+// names of the secrets with the configured prefix. The API uses the AWS JSON
+// 1.1 protocol, with requests signed with the AWS signature version 4.
+type awsBackend struct {
+	prefix string
+	url    string
+	config awssdk.Config
+	signer *v4.Signer
+}
+
+func newAWSBackend(cfg *conf.C) (secrets.Backend, error) {
+	var c awsConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	if c.Region != "" {
+		c.DefaultRegion = c.Region
+	}
+
+	awsConfig, err := awscommon.InitializeAWSConfig(c.ConfigAWS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AWS credentials: %w", err)
+	}
+	if c.Region != "" {
+		awsConfig.Region = c.Region
+	}
+
+	return &awsBackend{
+		prefix: c.Prefix,
+		url:    awsEndpointURL(c.Endpoint, awsConfig.Region, c.FIPSEnabled),
+		config: awsConfig,
+		signer: v4.NewSigner(),
+	}, nil
+}
+
+// awsEndpointURL returns the URL of the Secrets Manager API of a region. A
+// custom endpoint can be a domain replacing amazonaws.com, or a full URL.
+func awsEndpointURL(endpoint, region string, fips bool) string {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return endpoint
+	}
+	if endpoint == "" {
+		endpoint = "amazonaws.com"
+	}
+	host := awsSigningName
+	if fips {
+		host += "-fips"
+	}
+	return fmt.Sprintf("https://%s.%s.%s", host, region, endpoint)
+}
+
+func (b *awsBackend) Fetch(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": b.prefix + key})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	if b.config.Credentials != nil {
+		creds, err := b.config.Credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+		}
+		hash := sha256.Sum256(body)
+		err = b.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), awsSigningName, b.config.Region, time.Now())
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to sign GetSecretValue request: %w", err)
+		}
+	}
+
+	var client awssdk.HTTPClient = http.DefaultClient
+	if b.config.HTTPClient != nil {
+		client = b.config.HTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetSecretValue request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read GetSecretValue response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		if strings.HasSuffix(apiErr.Type, "ResourceNotFoundException") {
+			return nil, 0, keystore.ErrKeyDoesntExists
+		}
+		return nil, 0, fmt.Errorf("GetSecretValue request failed with status %v: %v %v", resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+
+	var secret struct {
+		SecretString *string
+		SecretBinary []byte
+	}
+	if err := json.Unmarshal(data, &secret); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode GetSecretValue response: %w", err)
+	}
+	if secret.SecretString != nil {
+		return []byte(*secret.SecretString), 0, nil
+	}
+	return secret.SecretBinary, 0, nil
+}
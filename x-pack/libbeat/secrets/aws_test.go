@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+func TestAWSBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/secretsmanager/aws4_request")
+
+		var input struct{ SecretId string }
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		switch input.SecretId {
+		case "beats/es_password":
+			json.NewEncoder(w).Encode(map[string]interface{}{"SecretString": "changeme"})
+		case "beats/certificate":
+			json.NewEncoder(w).Encode(map[string]interface{}{"SecretBinary": []byte("binary")})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"__type":  "ResourceNotFoundException",
+				"message": "Secrets Manager can't find the specified secret.",
+			})
+		}
+	}))
+	defer server.Close()
+
+	b, err := newAWSBackend(conf.MustNewConfigFrom(map[string]interface{}{
+		"region":            "eu-west-1",
+		"endpoint":          server.URL,
+		"access_key_id":     "AKID",
+		"secret_access_key": "SECRET",
+		"prefix":            "beats/",
+	}))
+	require.NoError(t, err)
+
+	value, _, err := b.Fetch(context.Background(), "es_password")
+	require.NoError(t, err)
+	assert.Equal(t, "changeme", string(value))
+
+	value, _, err = b.Fetch(context.Background(), "certificate")
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(value))
+
+	_, _, err = b.Fetch(context.Background(), "missing")
+	assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+}
+
+func TestAWSEndpointURL(t *testing.T) {
+	assert.Equal(t, "https://secretsmanager.us-east-1.amazonaws.com", awsEndpointURL("", "us-east-1", false))
+	assert.Equal(t, "https://secretsmanager-fips.us-east-1.amazonaws.com", awsEndpointURL("", "us-east-1", true))
+	assert.Equal(t, "https://secretsmanager.cn-north-1.amazonaws.com.cn", awsEndpointURL("amazonaws.com.cn", "cn-north-1", false))
+	assert.Equal(t, "http://localhost:4566", awsEndpointURL("http://localhost:4566", "us-east-1", false))
+}
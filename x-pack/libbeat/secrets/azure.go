@@ -0,0 +1,208 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/elastic/beats/v7/libbeat/secrets"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+const (
+	azureKeyVaultResource   = "https://vault.azure.net"
+	azureKeyVaultAPIVersion = "7.4"
+	azureIMDSTokenURL       = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+func init() {
+	secrets.RegisterBackend("azure_keyvault", newAzureBackend)
+}
+
+type azureConfig struct {
+	VaultURL      string                           `config:"vault_url" validate:"required"`
+	TenantID      string                           `config:"tenant_id"`
+	ClientID      string                           `config:"client_id"`
+	ClientSecret  string                           `config:"client_secret"`
+	AuthorityHost string                           `config:"authority_host"`
+	Prefix        string                           `config:"prefix"`
+	Transport     httpcommon.HTTPTransportSettings `config:",inline"`
+
+	// identityURL is the token endpoint of the managed identity, only
+	// modified in tests.
+	identityURL string
+}
+
+func defaultAzureConfig() azureConfig {
+	return azureConfig{
+		AuthorityHost: "https://login.microsoftonline.com",
+		Transport:     httpcommon.DefaultHTTPTransportSettings(),
+		identityURL:   azureIMDSTokenURL,
+	}
+}
+
+func (c *azureConfig) Validate() error {
+	if c.ClientSecret != "" && (c.TenantID == "" || c.ClientID == "") {
+		return errors.New("tenant_id and client_id are required when client_secret is set")
+	}
+	return nil
+}
+
+// azureBackend reads the secrets from Azure Key Vault. It authenticates with
+// the client credentials of a service principal if client_secret is set,
+// or with the managed identity of the host otherwise.
+type azureBackend struct {
+	vaultURL string
+	prefix   string
+	client   *http.Client
+}
+
+func newAzureBackend(cfg *conf.C) (secrets.Backend, error) {
+	c := defaultAzureConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	return newAzureBackendFromConfig(c)
+}
+
+func newAzureBackendFromConfig(c azureConfig) (*azureBackend, error) {
+	base, err := c.Transport.Client()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, base)
+
+	var tokenSource oauth2.TokenSource
+	if c.ClientSecret != "" {
+		credentials := clientcredentials.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			TokenURL:     strings.TrimRight(c.AuthorityHost, "/") + "/" + c.TenantID + "/oauth2/v2.0/token",
+			Scopes:       []string{azureKeyVaultResource + "/.default"},
+		}
+		tokenSource = credentials.TokenSource(ctx)
+	} else {
+		tokenSource = oauth2.ReuseTokenSource(nil, &managedIdentityTokenSource{
+			url:      c.identityURL,
+			clientID: c.ClientID,
+			client:   base,
+		})
+	}
+
+	return &azureBackend{
+		vaultURL: strings.TrimRight(c.VaultURL, "/"),
+		prefix:   c.Prefix,
+		client:   oauth2.NewClient(ctx, tokenSource),
+	}, nil
+}
+
+func (b *azureBackend) Fetch(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	name := azureSecretName(b.prefix + key)
+	u := b.vaultURL + "/secrets/" + url.PathEscape(name) + "?api-version=" + azureKeyVaultAPIVersion
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("key vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var secret struct {
+		Value string `json:"value"`
+		Error *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&secret)
+	switch {
+	case resp.StatusCode == http.StatusOK && err != nil:
+		return nil, 0, fmt.Errorf("failed to decode key vault response: %w", err)
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, 0, keystore.ErrKeyDoesntExists
+	case resp.StatusCode != http.StatusOK && secret.Error != nil:
+		return nil, 0, fmt.Errorf("failed to get secret '%s' with status %d: %s %s", name, resp.StatusCode, secret.Error.Code, secret.Error.Message)
+	case resp.StatusCode != http.StatusOK:
+		return nil, 0, fmt.Errorf("failed to get secret '%s' with status %d", name, resp.StatusCode)
+	}
+	return []byte(secret.Value), 0, nil
+}
+
+// azureSecretName replaces the characters not allowed in the names of the
+// Key Vault secrets with dashes.
+func azureSecretName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		return '-'
+	}, key)
+}
+
+// managedIdentityTokenSource gets Key Vault tokens from the Azure Instance
+// Metadata Service.
+type managedIdentityTokenSource struct {
+	url      string
+	clientID string
+	client   *http.Client
+}
+
+func (s *managedIdentityTokenSource) Token() (*oauth2.Token, error) {
+	query := url.Values{
+		"api-version": []string{"2018-02-01"},
+		"resource":    []string{azureKeyVaultResource},
+	}
+	if s.clientID != "" {
+		query.Set("client_id", s.clientID)
+	}
+	req, err := http.NewRequest(http.MethodGet, s.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("managed identity token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("managed identity token request failed with status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode managed identity token: %w", err)
+	}
+	expiresOn, err := strconv.ParseInt(token.ExpiresOn, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiration of managed identity token: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      time.Unix(expiresOn, 0),
+	}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+func TestAzureBackend(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "https://vault.azure.net/.default", r.Form.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "sp-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("/identity", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, "https://vault.azure.net", r.URL.Query().Get("resource"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "mi-token",
+			"token_type":   "Bearer",
+			"expires_on":   strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+		})
+	})
+	var token string
+	mux.HandleFunc("/secrets/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		assert.Equal(t, "7.4", r.URL.Query().Get("api-version"))
+		if r.URL.Path != "/secrets/beats-es-password" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{"code": "SecretNotFound"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"value": "changeme"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := map[string]struct {
+		config azureConfig
+		token  string
+	}{
+		"client credentials": {
+			config: azureConfig{TenantID: "tenant", ClientID: "id", ClientSecret: "secret"},
+			token:  "sp-token",
+		},
+		"managed identity": {
+			token: "mi-token",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := defaultAzureConfig()
+			c.TenantID, c.ClientID, c.ClientSecret = test.config.TenantID, test.config.ClientID, test.config.ClientSecret
+			c.VaultURL = server.URL
+			c.AuthorityHost = server.URL
+			c.Prefix = "beats."
+			c.identityURL = server.URL + "/identity"
+			token = test.token
+
+			b, err := newAzureBackendFromConfig(c)
+			require.NoError(t, err)
+
+			value, _, err := b.Fetch(context.Background(), "es_password")
+			require.NoError(t, err)
+			assert.Equal(t, "changeme", string(value))
+
+			_, _, err = b.Fetch(context.Background(), "missing")
+			assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/elastic/beats/v7/libbeat/secrets"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+func init() {
+	secrets.RegisterBackend("gcp_secretmanager", newGCPBackend)
+}
+
+type gcpConfig struct {
+	ProjectID       string `config:"project_id" validate:"required"`
+	CredentialsFile string `config:"credentials_file"`
+	CredentialsJSON string `config:"credentials_json"`
+	Version         string `config:"version"`
+	Prefix          string `config:"prefix"`
+}
+
+func defaultGCPConfig() gcpConfig {
+	return gcpConfig{Version: "latest"}
+}
+
+// gcpBackend reads the secrets from Google Cloud Secret Manager. The
+// Application Default Credentials are used if no credentials are configured.
+type gcpBackend struct {
+	config  gcpConfig
+	service *secretmanager.Service
+}
+
+func newGCPBackend(cfg *conf.C) (secrets.Backend, error) {
+	c := defaultGCPConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+
+	var opts []option.ClientOption
+	switch {
+	case c.CredentialsJSON != "":
+		opts = append(opts, option.WithCredentialsJSON([]byte(c.CredentialsJSON)))
+	case c.CredentialsFile != "":
+		opts = append(opts, option.WithCredentialsFile(c.CredentialsFile))
+	}
+	return newGCPBackendWithOptions(c, opts...)
+}
+
+func newGCPBackendWithOptions(c gcpConfig, opts ...option.ClientOption) (*gcpBackend, error) {
+	service, err := secretmanager.NewService(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Secret Manager client: %w", err)
+	}
+	return &gcpBackend{config: c, service: service}, nil
+}
+
+func (b *gcpBackend) Fetch(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	name := fmt.Sprintf("projects/%s/secrets/%s/versions/%s", b.config.ProjectID, gcpSecretID(b.config.Prefix+key), b.config.Version)
+	resp, err := b.service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return nil, 0, keystore.ErrKeyDoesntExists
+		}
+		return nil, 0, fmt.Errorf("failed to access secret '%s': %w", name, err)
+	}
+	if resp.Payload == nil {
+		return nil, 0, nil
+	}
+
+	value, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode secret '%s': %w", name, err)
+	}
+	return value, 0, nil
+}
+
+// gcpSecretID replaces the characters not allowed in the IDs of the secrets
+// with underscores.
+func gcpSecretID(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,49 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+func TestGCPBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/my-project/secrets/beats_es_password/versions/latest:access" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{"code": 404, "message": "not found", "status": "NOT_FOUND"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"payload": map[string]interface{}{"data": base64.StdEncoding.EncodeToString([]byte("changeme"))},
+		})
+	}))
+	defer server.Close()
+
+	c := defaultGCPConfig()
+	c.ProjectID = "my-project"
+	c.Prefix = "beats."
+	b, err := newGCPBackendWithOptions(c, option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	value, _, err := b.Fetch(context.Background(), "es_password")
+	require.NoError(t, err)
+	assert.Equal(t, "changeme", string(value))
+
+	_, _, err = b.Fetch(context.Background(), "missing")
+	assert.ErrorIs(t, err, keystore.ErrKeyDoesntExists)
+}
@@ -20,6 +20,11 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/billing"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/monitor"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/storage"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse/async_metrics"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse/events"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse/metrics"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse/replication_queue"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry/container"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry/counter"
@@ -29,11 +34,14 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/blkio"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/cpu"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/memory"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/snapshot"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/coredns"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/coredns/stats"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/enterprisesearch"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/enterprisesearch/health"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/enterprisesearch/stats"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/fleet_server"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/fleet_server/stats"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/billing"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/metrics"
@@ -46,6 +54,9 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/istio/mesh"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/istio/mixer"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/istio/pilot"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect/connector"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect/task"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql/performance"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql/transaction_log"
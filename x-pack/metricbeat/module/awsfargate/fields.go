@@ -19,5 +19,5 @@ func init() {
 // AssetAwsfargate returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/awsfargate.
 func AssetAwsfargate() string {
-	return "eJzsWk1z2zYQvftX7Hg6kzaJ5UumBx0yoyrOjA92PLbTHOkVuJRRkQADgFGUtv+9A5CUaH6JomyXzohHEth9b/ftggR4AgtajQGXOkA1R0NHAIabkMZwPPlyAx/Tu8dHAD5ppnhsuBRjeH8EAHC3mXcHkfSTkIDJMCRmNNjp2UOIyCjONARKRmBQL+wd9NEgkPBjyYUZHQEoCgk1jWFGBo8AAk6hr8fO1wkIjKgE1V5mFdMY5komcXanOK04lUlhkAtSoxBnFOoRk5GHEf6QApfaI6Y9FibakFpPzc0vaLWUyi/cfxCOs+kNTNOpzlcv1/mQkwcWdkCwttAbg03NCSrRx7tL6+T6srdfnwIuuDV4EmDEw1VvFBtLULK0B6ZvpDSX/UNTAJWZSkdXsNnRnjZodMVXUeUVT+8LDwDuNmbucroazP2mGM09GliSItBMYUx+Wp7FunXAnQ345e+z6Y03/XR5Ozm/PLv2Ls5uJx8mtxPv8/W59+e7f0/t2NN0bG1x51e5yPOrXLUP0pXWlleqjLYkVMJTrE/49YrHMSn5W607F7p9fK2TXjGSu+A+CcMD/qDX7OTjfekhwHRd/xvrgExJrR0aDSj8PJb6LSzvObsH+ppgqMHIQlG4GL2BV6ev4E3hNvdHzfHySXNFvpNcoh+P1e09QWYbUtsQSOWU7EKcitaVro17C8KFkEvxJPic5f7oWJzU4imXewXNdSIMjwimV5/zsh6VxteVVdH3gpSgcBQzUxmSw9AMQ/K9IJRYNyiQKkIzhpgUI1E3YksE7XWVTsY5gQzAkeIiAwc6RkZlYhUKQqroBfAAixND/oN8mK2cUkQSzUjZCTaRTCqqpLHC1nC2KKt4QzWUYt6PgEXgbHePv15pQ4MLvTQYZmzSJNhIZ1C3URmilFr47CupjPVTSioD6lw0A0k0qaHFPYu2hbatEBz8IWqnwmFfxTimz9KCusTd1cb/HvPbdYUmGudb4Q5CJyXMfWSRs/K5XnDZ7z3mA9cLOD/91PclRhGW39ravXcMz4SxJEpCNOQ7Hxr8RHExd5EJeZBW1z1t3pHrEtUAvkhAxnWFBNuKqSMNe12u89ibyAbubGWoN+BcuG1GOpL6w5pwhPbjozbbSTvT2Tn+LFGKhMnyENv2RkyKOgEXlmhS3zgjz7byJ0aa9gS3ZhiZO4bzT6Doa0La6Le2LwsUMsVdn8Mc+BK5eWbUOU7QsY2zRWClwQV8TSih9Ast47UTFze9Pk+PTWTTdlOna1KtPbB5Nd6ymPgUK2K2z43h99G7ft2yh8TXKlG8tgIftYM7Jz9FC+/PZKg93DIyJF5gG89ycejjhz5eQ2T3Pp7qaWCNfLvI1wJPogjV6nnexlH8TF3dfgDJmBTaKT9Vd3dv6HmyXmabLyTm0OoPrb6GyO6t3m0EDaTTVxpqg8pz7BFFstLmO273XLi5fTd7ykfjD53L2V9Uu6mWPvBac14Y40UYx1zMswnHr4/7Rfgal1mssoP09B8Y2+xcnHT2dGSfAheGVICsWnqbPxeiiDdvGu6xvE6dZeO2/qwApIAvXPhyWRfsLmtnk7zhmdegtDybDeV4Y8LFAOBeES6yNHdKxhq+4t/QkLeUamGFq8mM2jtMA59tXDrwyLBAhgU0mU4cAuThiMmkdj+7Y1vcCu4j8hCcE1LNu/Qhb6uzpwtd1hyd+2Z0Sje3wD16wPXNzYNuVQXwEks/Y6RIux9jrBpB8x81RzRFDvVnM9BViNDpjGYHLhcNLHLrrWdO7njnKRTz2Z0bPYZmIvw+AMVc4PecTcMxHgxfI+lhXpsuYOjFWhP8HK8gY9eV0esRFzOZVD7DmjW97dWyicTj9fx/atmXPyC4YDKy66az2VzVvpJxXPt50/0MvhuizNMGWYxsQXXFnmMjpaTa7/eANmipeftO0R1SNuAZwtWOqSpkmZiDkg9KfhFK/i8AAP//k1OOZw=="
+	return "eJzsWsFy2zYQvesrdjydSZvE9CXTgw6ZURWn44Mdj2w3RxoCVxIqEmAA0IrS9t87C5ISJZEURUuO7LGtE0nsvrf7dgECPIUpzrvAZmbE9JhZ7ABYYUPswknv6w18Tq+edAACNFyL2Aolu/CxAwBwvxx3D5EKkhCBqzBEbg3Q8OwmRGi14AZGWkVgmZnSFRYwywBlECshrdcB0BgiM9iFIVrWARgJDAPTdb5OQbII16DSDTuPsQtjrZI4u1IcVhzKlbRMSNReyIYYGo+ryGcR+6EkmxkfufF5mBiLejE0Nz/F+UzpoHB9JRzn/Rvop0MdzFau80dOVyzsgGDhpDUGSs0p07KNdxoLvcFVa78BjoQUZPB0xCIRzlujWFqCNUuPwPSA2ggl9wEqMwXO1AY2ouAby6wp2NxU+Yanj4UbAPdLM/c5XQN2sixGO2EWZqgRDNcsxiAtz2Ldkg1wUOCXf877N37/y9Vt7+LqfOBfnt/2PvVue/7d4ML/68N/Z/TsWfpsaXHn/+tFDlBetSvpSmvLX6uMuiRshKdYn/DrtYhj1Oq3UndE5VG+FknfMJK7EAFKK0YCdVsfq+mm/34u6oJ1YFwrY5wEDTAZ5LE072E2EXwC+C1hoQGrCkVBGOEdvDl7A+8Kl0XglZIh436ARmgMnOQSsz9WtxOEzDaktmGktFMy+c1E60qXiq0G4VSqmTwIPme5PbqQJZJPfAKxX1ypYWcF1GiB6j0kJmFhOIfPvcGfvdvzmqCFIhLW43Gy5oBsdsFwFmLgj0LF7G7w+td34GyvAhMSHvrXd2YroggjpefecG6xPJmhkuO1GyOlI2a7UDZoC9xL524TcTnORckcNHyJFNaARoP6AYOF7hbO34OS4Rw0xkpbDGA2QQkGLQi5wF+YlppROYK475lgRW7W59oNsINEWhGhS0U2p656KJ/Tir6nqCWGXszXs99IIsXQxqg5yrIntgSYftfpYDZGJ20iJWQGDkzMOHrbKEilo2fAAwgnC8UPDGA4dyKRSTRETcQpkVxpNFvZWsGnppJqSQU0JEAInO3m8TdzY/HoQq8sC11h5EmgSGdQt1E5RinV8HmspDLWh5RUBtS5qAaSGNTHFvcs2gRtWyHQM0epnQ0Oj1WMY/okLahJ3F1t/PSY3y4qNDFsvBXuUehkDXMbWeSsAmGmQrVbx3wSZgoXZ1/yjYFdFzEaWVAZxzLvDcPT4zyJkpDR0pV8GAgSLeTYRSYUI9xYD5aYqQJfJKDiskLaWkwNadDvapHH1kSWcMtW0Y0B1y3FdyT1B+FwhB7HRy/3cnems3P8eaI1SpsJKqb2hlzJoBYhvWAJjj4tZg6MNO0J5Ih2ZDLHcPEFNH5L0FjjXpElkyrFbWqBz5iwT4w6xwkmpjgTApKGkPAtwQTT7ZGM105c3PDgSYgs227qdBH8ThU4J6e2k0mAsUZOfa4Lv3sfOq3Qt5B4Dn6mhcVDd3Dn5EW08PZMjrWHEyOL8hm28SwXr338tY/vpY+nejqyRr5d5Dl8k0QR08WD04P08nRmocOk9r3w2Lo6vQCpGDWjIS+qu1O2Fsl6nm2+kJjXVv/a6vfS6t2+1ZF0+o2GWqHyHHt6CNdp2uJXsGQnai03e9a/S8n/Ulmq4d9YuqmW3vBrc154xo9YHAs5zgacvD1pF+EBm2Wxyr5icR8LuKnJTYUmu+vRXRDSoh4xjpXsuYoiYQ8xvfadZXpRcn0flISvQgZqZlrOnVXyblR6e5yD0j5TbSjHGyObHgHca2RT4M2TsYCvxQOz6M+UnpJwDVqvvsNU8NnGpQGPDAtkWOjIvxGHEROhx1Uibfu2uBXcZyZCcE5Qe5VQ3Hn/Twhd8XODanTamEP0gMHNzUq38l5E6WeMNBr3VRqpEYz4gV4th/KzmcZCbHZGswOXywoWufXaMyd3vHMIxdyR4b1oJmLfa+P9NIq5ZN9zNhXHeM9AIw55rS6OvlhLgp/jlWhpXvHeekIOVbLxGlat6SoZ5parSNRGYVsEVtj/26lmvnyBEJKriOZNZ9OrxBtoFccY7Iq4BaLM0xJZzPgUbQ021FppczBoqXlaUzSHlD1wMEwlCSzFtClkldhXJb8q+Vko+f8BANjchRk="
 }
@@ -37,7 +37,7 @@ func createEvent(stats *Stats) mb.Event {
 	}
 
 	regionName, clusterName := getRegionAndClusterName(stats.Container.Labels)
-	e.RootFields = createRootFields(stats, regionName)
+	e.RootFields = createRootFields(stats, regionName, stats.taskInfo.AvailabilityZone)
 	if clusterName != "" {
 		_, _ = e.MetricSetFields.Put("cluster_name", clusterName)
 	}
@@ -57,10 +57,35 @@ func createEvent(stats *Stats) mb.Event {
 		_, _ = e.MetricSetFields.Put("task_known_status", taskKnownStatus)
 	}
 
+	launchType := stats.taskInfo.LaunchType
+	if launchType != "" {
+		_, _ = e.MetricSetFields.Put("launch_type", launchType)
+	}
+
+	if taskLimit := createLimitFields(stats.taskInfo.Limits); len(taskLimit) > 0 {
+		_, _ = e.MetricSetFields.Put("task_limit", taskLimit)
+	}
+
+	if containerLimit := createLimitFields(stats.Container.Limits); len(containerLimit) > 0 {
+		_, _ = e.MetricSetFields.Put("container_limit", containerLimit)
+	}
+
 	_, _ = e.MetricSetFields.Put("identifier", generateIdentifier(stats.Container.Name, stats.Container.DockerId))
 	return e
 }
 
+// createLimitFields returns the limits that are set, zero means no limit.
+func createLimitFields(l limits) mapstr.M {
+	fields := mapstr.M{}
+	if l.CPU > 0 {
+		_, _ = fields.Put("cpu", l.CPU)
+	}
+	if l.Memory > 0 {
+		_, _ = fields.Put("memory.bytes", l.Memory*1024*1024)
+	}
+	return fields
+}
+
 func generateIdentifier(containerName string, containerID string) string {
 	return containerName + "/" + containerID
 }
@@ -80,7 +105,7 @@ func getRegionAndClusterName(labels map[string]string) (regionName string, clust
 	return regionName, clusterName
 }
 
-func createRootFields(stats *Stats, regionName string) mapstr.M {
+func createRootFields(stats *Stats, regionName string, availabilityZone string) mapstr.M {
 	rootFields := mapstr.M{
 		"container": mapstr.M{
 			"id": stats.Container.DockerId,
@@ -92,11 +117,15 @@ func createRootFields(stats *Stats, regionName string) mapstr.M {
 		},
 	}
 
-	// add cloud.region
+	// add cloud.region and cloud.availability_zone
+	cloud := mapstr.M{}
 	if regionName != "" {
-		cloud := mapstr.M{
-			"region": regionName,
-		}
+		cloud["region"] = regionName
+	}
+	if availabilityZone != "" {
+		cloud["availability_zone"] = availabilityZone
+	}
+	if len(cloud) > 0 {
 		_, err := rootFields.Put("cloud", cloud)
 		if err != nil {
 			_ = fmt.Errorf("error putting root field 'cloud': %w", err)
@@ -58,6 +58,16 @@ type TaskInfo struct {
 	Revision          string
 	TaskDesiredStatus string
 	TaskKnownStatus   string
+	AvailabilityZone  string
+	LaunchType        string
+	Limits            limits
+}
+
+// limits is a struct that represents the resource limits of a task or a container,
+// CPU is in vCPUs and Memory in MiB
+type limits struct {
+	CPU    float64 `json:"CPU"`
+	Memory int64   `json:"Memory"`
 }
 
 // Stats is a struct that represents information regarding a container
@@ -73,13 +83,16 @@ type Stats struct {
 
 // TaskMetadata is a struct that represents response body from ${ECS_CONTAINER_METADATA_URI_V4}/task
 type TaskMetadata struct {
-	Cluster       string       `json:"Cluster"`
-	TaskARN       string       `json:"TaskARN"`
-	Family        string       `json:"Family"`
-	Revision      string       `json:"Revision"`
-	DesiredStatus string       `json:"DesiredStatus"`
-	KnownStatus   string       `json:"KnownStatus"`
-	Containers    []*container `json:"Containers"`
+	Cluster          string       `json:"Cluster"`
+	TaskARN          string       `json:"TaskARN"`
+	Family           string       `json:"Family"`
+	Revision         string       `json:"Revision"`
+	DesiredStatus    string       `json:"DesiredStatus"`
+	KnownStatus      string       `json:"KnownStatus"`
+	AvailabilityZone string       `json:"AvailabilityZone"`
+	LaunchType       string       `json:"LaunchType"`
+	Limits           limits       `json:"Limits"`
+	Containers       []*container `json:"Containers"`
 }
 
 // New creates a new instance of the MetricSet. New is responsible for unpacking
@@ -192,6 +205,9 @@ func getStatsList(taskStatsOutput map[string]types.StatsJSON, taskOutput TaskMet
 		Revision:          taskOutput.Revision,
 		TaskDesiredStatus: taskOutput.DesiredStatus,
 		TaskKnownStatus:   taskOutput.KnownStatus,
+		AvailabilityZone:  taskOutput.AvailabilityZone,
+		LaunchType:        taskOutput.LaunchType,
+		Limits:            taskOutput.Limits,
 	}
 
 	for _, c := range taskOutput.Containers {
@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 var (
@@ -48,6 +50,9 @@ var (
 		"Revision": "7",
         "DesiredStatus": "RUNNING",
         "KnownStatus": "ACTIVATING",
+		"AvailabilityZone": "us-west-2a",
+		"LaunchType": "FARGATE",
+		"Limits": {"CPU": 0.5, "Memory": 1024},
 		"Containers": [{
 			"DockerId": "query-metadata-1",
 			"Name": "query-metadata",
@@ -57,7 +62,8 @@ var (
 				"com.amazonaws.ecs.container-name": "query-metadata",
 				"com.amazonaws.ecs.task-arn": "arn:aws:ecs:us-west-2:111122223333:task/default/febee046097849aba589d4435207c04a",
 				"com.amazonaws.ecs.task-definition-family": "query-metadata",
-				"com.amazonaws.ecs.task-definition-version": "7"}
+				"com.amazonaws.ecs.task-definition-version": "7"},
+			"Limits": {"CPU": 256, "Memory": 512}
 			}]
 		}`
 )
@@ -86,12 +92,16 @@ func TestGetTask(t *testing.T) {
 	assert.Equal(t, "7", taskOutput.Revision)
 	assert.Equal(t, "RUNNING", taskOutput.DesiredStatus)
 	assert.Equal(t, "ACTIVATING", taskOutput.KnownStatus)
+	assert.Equal(t, "us-west-2a", taskOutput.AvailabilityZone)
+	assert.Equal(t, "FARGATE", taskOutput.LaunchType)
+	assert.Equal(t, limits{CPU: 0.5, Memory: 1024}, taskOutput.Limits)
 
 	assert.Equal(t, 1, len(taskOutput.Containers))
 	assert.Equal(t, "query-metadata-1", taskOutput.Containers[0].DockerId)
 	assert.Equal(t, "query-metadata", taskOutput.Containers[0].Name)
 	assert.Equal(t, "mreferre/eksutils", taskOutput.Containers[0].Image)
 	assert.Equal(t, 5, len(taskOutput.Containers[0].Labels))
+	assert.Equal(t, limits{CPU: 256, Memory: 512}, taskOutput.Containers[0].Limits)
 }
 
 func TestGetStatsList(t *testing.T) {
@@ -111,6 +121,20 @@ func TestGetStatsList(t *testing.T) {
 
 	formattedStats := getStatsList(taskStatsOutput, taskOutput)
 	assert.Equal(t, 1, len(formattedStats))
+
+	event := createEvent(&formattedStats[0])
+	assert.Equal(t, "FARGATE", event.MetricSetFields["launch_type"])
+	assert.Equal(t, mapstr.M{"cpu": 0.5, "memory": mapstr.M{"bytes": int64(1073741824)}}, event.MetricSetFields["task_limit"])
+	assert.Equal(t, mapstr.M{"cpu": float64(256), "memory": mapstr.M{"bytes": int64(536870912)}}, event.MetricSetFields["container_limit"])
+
+	availabilityZone, err := event.RootFields.GetValue("cloud.availability_zone")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2a", availabilityZone)
+}
+
+func TestCreateLimitFields(t *testing.T) {
+	assert.Empty(t, createLimitFields(limits{}))
+	assert.Equal(t, mapstr.M{"memory": mapstr.M{"bytes": int64(2097152)}}, createLimitFields(limits{Memory: 2}))
 }
 
 func TestGetCPUStats(t *testing.T) {
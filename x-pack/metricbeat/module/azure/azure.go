@@ -38,7 +38,9 @@ type MetricSet struct {
 // NewMetricSet will instantiate a new azure metricset
 func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
 	metricsetName := base.Name()
-	var config Config
+	config := Config{
+		MetricDefinitionsTTL: DefaultMetricDefinitionsTTL,
+	}
 	err := base.Module().UnpackConfig(&config)
 	if err != nil {
 		return nil, errors.Wrap(err, "error unpack raw module config using UnpackConfig")
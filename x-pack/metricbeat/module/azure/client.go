@@ -7,6 +7,7 @@ package azure
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
@@ -24,6 +25,19 @@ type Client struct {
 	ResourceConfigurations ResourceConfiguration
 	Log                    *logp.Logger
 	Resources              []Resource
+
+	metricDefinitions metricDefinitionsCache
+}
+
+// metricDefinitionsCache keeps the metric definitions returned by the api per resource and namespace
+type metricDefinitionsCache struct {
+	sync.Mutex
+	entries map[string]metricDefinitionsEntry
+}
+
+type metricDefinitionsEntry struct {
+	definitions insights.MetricDefinitionCollection
+	expiration  time.Time
 }
 
 // mapResourceMetrics function type will map the configuration options to client metrics (depending on the metricset)
@@ -144,6 +158,42 @@ func (client *Client) GetMetricValues(metrics []Metric, report mb.ReporterV2) []
 	return resultedMetrics
 }
 
+// GetMetricDefinitions returns the metric definitions for the resource ID and namespace. The definitions are cached
+// for the configured metric_definitions_ttl, as they rarely change and are requested for every resource configured.
+func (client *Client) GetMetricDefinitions(resourceId string, namespace string) (insights.MetricDefinitionCollection, error) {
+	if client.Config.MetricDefinitionsTTL <= 0 {
+		return client.AzureMonitorService.GetMetricDefinitions(resourceId, namespace)
+	}
+
+	key := resourceId + "/" + namespace
+	now := time.Now()
+
+	client.metricDefinitions.Lock()
+	defer client.metricDefinitions.Unlock()
+	if entry, ok := client.metricDefinitions.entries[key]; ok && now.Before(entry.expiration) {
+		return entry.definitions, nil
+	}
+
+	definitions, err := client.AzureMonitorService.GetMetricDefinitions(resourceId, namespace)
+	if err != nil {
+		return definitions, err
+	}
+	if client.metricDefinitions.entries == nil {
+		client.metricDefinitions.entries = make(map[string]metricDefinitionsEntry)
+	}
+	// drop the definitions of resources that are no longer requested
+	for k, entry := range client.metricDefinitions.entries {
+		if !now.Before(entry.expiration) {
+			delete(client.metricDefinitions.entries, k)
+		}
+	}
+	client.metricDefinitions.entries[key] = metricDefinitionsEntry{
+		definitions: definitions,
+		expiration:  now.Add(client.Config.MetricDefinitionsTTL),
+	}
+	return definitions, nil
+}
+
 // CreateMetric function will create a client metric based on the resource and metrics configured
 func (client *Client) CreateMetric(resourceId string, subResourceId string, namespace string, metrics []string, aggregations string, dimensions []Dimension, timegrain string) Metric {
 	if subResourceId == "" {
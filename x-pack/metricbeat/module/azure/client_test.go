@@ -7,6 +7,7 @@ package azure
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
@@ -110,3 +111,50 @@ func TestGetMetricValues(t *testing.T) {
 		m.AssertExpectations(t)
 	})
 }
+
+func TestGetMetricDefinitions(t *testing.T) {
+	name := "TotalRequests"
+	definitions := insights.MetricDefinitionCollection{
+		Value: &[]insights.MetricDefinition{{Name: &insights.LocalizableString{Value: &name}}},
+	}
+	t.Run("call the api every time when the cache is disabled", func(t *testing.T) {
+		client := NewMockClient()
+		m := &MockService{}
+		m.On("GetMetricDefinitions", "123", "namespace").Return(definitions, nil).Twice()
+		client.AzureMonitorService = m
+		for i := 0; i < 2; i++ {
+			result, err := client.GetMetricDefinitions("123", "namespace")
+			assert.NoError(t, err)
+			assert.Equal(t, definitions, result)
+		}
+		m.AssertExpectations(t)
+	})
+	t.Run("return cached definitions per resource and namespace", func(t *testing.T) {
+		client := NewMockClient()
+		client.Config.MetricDefinitionsTTL = time.Hour
+		m := &MockService{}
+		m.On("GetMetricDefinitions", "123", "namespace").Return(definitions, nil).Once()
+		m.On("GetMetricDefinitions", "456", "namespace").Return(definitions, nil).Once()
+		client.AzureMonitorService = m
+		for _, id := range []string{"123", "456", "123", "456"} {
+			result, err := client.GetMetricDefinitions(id, "namespace")
+			assert.NoError(t, err)
+			assert.Equal(t, definitions, result)
+		}
+		m.AssertExpectations(t)
+	})
+	t.Run("errors are not cached", func(t *testing.T) {
+		client := NewMockClient()
+		client.Config.MetricDefinitionsTTL = time.Hour
+		m := &MockService{}
+		m.On("GetMetricDefinitions", "123", "namespace").Return(insights.MetricDefinitionCollection{}, errors.New("throttled")).Once()
+		m.On("GetMetricDefinitions", "123", "namespace").Return(definitions, nil).Once()
+		client.AzureMonitorService = m
+		_, err := client.GetMetricDefinitions("123", "namespace")
+		assert.Error(t, err)
+		result, err := client.GetMetricDefinitions("123", "namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, definitions, result)
+		m.AssertExpectations(t)
+	})
+}
@@ -15,6 +15,10 @@ import (
 const (
 	// DefaultBaseURI is the default URI used for the service Insights
 	DefaultBaseURI = "https://management.azure.com/"
+	// DefaultMetricDefinitionsTTL is the default time metric definitions are cached for
+	DefaultMetricDefinitionsTTL = time.Hour
+	// DefaultMaxDimensions is the default maximum number of dimensions discovered per metric
+	DefaultMaxDimensions = 5
 )
 
 var (
@@ -41,6 +45,8 @@ type Config struct {
 	RefreshListInterval time.Duration    `config:"refresh_list_interval"`
 	DefaultResourceType string           `config:"default_resource_type"`
 	AddCloudMetadata    bool             `config:"add_cloud_metadata"`
+	// MetricDefinitionsTTL is the time metric definitions are cached for, 0 disables the cache
+	MetricDefinitionsTTL time.Duration `config:"metric_definitions_ttl"`
 	// specific to billing
 	BillingScopeDepartment string `config:"billing_scope_department"` // retrieve usage details from department scope
 	BillingScopeAccountId  string `config:"billing_scope_account_id"` // retrieve usage details from billing account ID scope
@@ -63,6 +69,11 @@ type MetricConfig struct {
 	Aggregations []string          `config:"aggregations"`
 	Dimensions   []DimensionConfig `config:"dimensions"`
 	Timegrain    string            `config:"timegrain"`
+	// DiscoverDimensions will split the metric values by all the dimensions supported by each metric,
+	// as returned by the metric definitions api, when no dimensions are configured
+	DiscoverDimensions bool `config:"discover_dimensions"`
+	// MaxDimensions limits the number of dimensions discovered for each metric of a resource
+	MaxDimensions int `config:"max_dimensions"`
 	// namespaces can be unsupported by some resources and supported in some, this configuration option makes sure no error messages are returned if namespace is unsupported
 	// info messages will be logged instead. Same situation with metrics, some are being removed from the API, we would like to make sure that does not affect the module
 	IgnoreUnsupported bool `config:"ignore_unsupported"`
@@ -75,6 +86,13 @@ type DimensionConfig struct {
 }
 
 func (conf *Config) Validate() error {
+	for _, resource := range conf.Resources {
+		for _, metric := range resource.Metrics {
+			if metric.MaxDimensions < 0 {
+				return errors.New("max_dimensions must be greater than or equal to 0")
+			}
+		}
+	}
 	if conf.ResourceManagerEndpoint == "" {
 		conf.ResourceManagerEndpoint = DefaultBaseURI
 	}
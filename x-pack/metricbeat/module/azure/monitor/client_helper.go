@@ -5,6 +5,7 @@
 package monitor
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -24,7 +25,7 @@ func mapMetrics(client *azure.Client, resources []resources.GenericResourceExpan
 	for _, resource := range resources {
 		for _, metric := range resourceConfig.Metrics {
 			// get all metrics supported by the namespace provided
-			metricDefinitions, err := client.AzureMonitorService.GetMetricDefinitions(*resource.ID, metric.Namespace)
+			metricDefinitions, err := client.GetMetricDefinitions(*resource.ID, metric.Namespace)
 			if err != nil {
 				return nil, errors.Wrapf(err, "no metric definitions were found for resource %s and namespace %s.", *resource.ID, metric.Namespace)
 			}
@@ -56,6 +57,17 @@ func mapMetrics(client *azure.Client, resources []resources.GenericResourceExpan
 				}
 			}
 			for key, metricGroup := range metricGroups {
+				// discover the dimensions supported by the metrics, only if none were configured
+				if len(dim) == 0 && metric.DiscoverDimensions {
+					maxDimensions := metric.MaxDimensions
+					if maxDimensions == 0 {
+						maxDimensions = azure.DefaultMaxDimensions
+					}
+					for _, group := range groupByDimensions(metricGroup, maxDimensions) {
+						metrics = append(metrics, client.CreateMetric(*resource.ID, "", metric.Namespace, group.names, key, group.dimensions, metric.Timegrain))
+					}
+					continue
+				}
 				var metricNames []string
 				for _, metricName := range metricGroup {
 					metricNames = append(metricNames, *metricName.Name.Value)
@@ -67,6 +79,52 @@ func mapMetrics(client *azure.Client, resources []resources.GenericResourceExpan
 	return metrics, nil
 }
 
+// dimensionGroup contains the metric names that support the same set of dimensions
+type dimensionGroup struct {
+	names      []string
+	dimensions []azure.Dimension
+}
+
+// groupByDimensions groups the metric definitions by the dimensions they support, as all metrics requested in the same
+// api call must support the dimensions used in the filter. At most maxDimensions dimensions are used for each metric.
+func groupByDimensions(metricDefs []insights.MetricDefinition, maxDimensions int) []dimensionGroup {
+	groups := make(map[string]*dimensionGroup)
+	var keys []string
+	for _, def := range metricDefs {
+		var dimensionNames []string
+		if def.Dimensions != nil {
+			for _, dimension := range *def.Dimensions {
+				if dimension.Value != nil {
+					dimensionNames = append(dimensionNames, *dimension.Value)
+				}
+			}
+		}
+		sort.Strings(dimensionNames)
+		if len(dimensionNames) > maxDimensions {
+			dimensionNames = dimensionNames[:maxDimensions]
+		}
+
+		key := strings.Join(dimensionNames, ",")
+		group, ok := groups[key]
+		if !ok {
+			group = &dimensionGroup{}
+			for _, name := range dimensionNames {
+				group.dimensions = append(group.dimensions, azure.Dimension{Name: name, Value: "*"})
+			}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.names = append(group.names, *def.Name.Value)
+	}
+
+	sort.Strings(keys)
+	result := make([]dimensionGroup, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *groups[key])
+	}
+	return result
+}
+
 // filterMetricNames func will verify if the metric names entered are valid and will also return the corresponding list of metrics
 func filterMetricNames(resourceId string, metricConfig azure.MetricConfig, metricDefinitions []insights.MetricDefinition) ([]string, error) {
 	var supportedMetricNames []string
@@ -104,6 +104,59 @@ func TestMapMetric(t *testing.T) {
 		assert.Equal(t, metrics[0].Dimensions, []azure.Dimension{{Name: "location", Value: "West Europe"}})
 		m.AssertExpectations(t)
 	})
+	t.Run("return metrics split by the discovered dimensions", func(t *testing.T) {
+		m := &azure.MockService{}
+		m.On("GetMetricDefinitions", mock.Anything, mock.Anything).Return(insights.MetricDefinitionCollection{
+			Value: MockMetricDefinitionsWithDimensions(),
+		}, nil)
+		client.AzureMonitorService = m
+		discoverConfig := azure.MetricConfig{Namespace: "namespace", Name: []string{"*"}, DiscoverDimensions: true, MaxDimensions: 1}
+		resourceConfig.Metrics = []azure.MetricConfig{discoverConfig}
+		metrics, err := mapMetrics(client, []resources.GenericResourceExpanded{resource}, resourceConfig)
+		assert.NoError(t, err)
+
+		assert.Len(t, metrics, 2)
+		assert.Equal(t, metrics[0].Names, []string{"Capacity"})
+		assert.Equal(t, metrics[0].Dimensions, []azure.Dimension(nil))
+		assert.Equal(t, metrics[1].Names, []string{"TotalRequests", "BytesRead"})
+		assert.Equal(t, metrics[1].Dimensions, []azure.Dimension{{Name: "ApiName", Value: "*"}})
+		m.AssertExpectations(t)
+	})
+	t.Run("configured dimensions take precedence over discovery", func(t *testing.T) {
+		m := &azure.MockService{}
+		m.On("GetMetricDefinitions", mock.Anything, mock.Anything).Return(insights.MetricDefinitionCollection{
+			Value: MockMetricDefinitionsWithDimensions(),
+		}, nil)
+		client.AzureMonitorService = m
+		metricConfig.Name = []string{"*"}
+		metricConfig.Aggregations = nil
+		metricConfig.DiscoverDimensions = true
+		resourceConfig.Metrics = []azure.MetricConfig{metricConfig}
+		metrics, err := mapMetrics(client, []resources.GenericResourceExpanded{resource}, resourceConfig)
+		assert.NoError(t, err)
+
+		assert.Len(t, metrics, 1)
+		assert.Equal(t, metrics[0].Dimensions, []azure.Dimension{{Name: "location", Value: "West Europe"}})
+		m.AssertExpectations(t)
+	})
+}
+
+func MockMetricDefinitionsWithDimensions() *[]insights.MetricDefinition {
+	defs := *MockMetricDefinitions()
+	apiName := "ApiName"
+	geoType := "GeoType"
+	defs[0].Dimensions = &[]insights.LocalizableString{{Value: &geoType}, {Value: &apiName}}
+	defs[2].Dimensions = &[]insights.LocalizableString{{Value: &apiName}}
+	return &defs
+}
+
+func TestGroupByDimensions(t *testing.T) {
+	groups := groupByDimensions(*MockMetricDefinitionsWithDimensions(), azure.DefaultMaxDimensions)
+	assert.Equal(t, []dimensionGroup{
+		{names: []string{"Capacity"}},
+		{names: []string{"BytesRead"}, dimensions: []azure.Dimension{{Name: "ApiName", Value: "*"}}},
+		{names: []string{"TotalRequests"}, dimensions: []azure.Dimension{{Name: "ApiName", Value: "*"}, {Name: "GeoType", Value: "*"}}},
+	}, groups)
 }
 
 func TestFilterSConfiguredMetrics(t *testing.T) {
@@ -0,0 +1,50 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package async_metrics
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse"
+)
+
+const query = "SELECT metric AS name, value FROM system.asynchronous_metrics"
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("clickhouse", "async_metrics", New,
+		mb.WithHostParser(clickhouse.HostParser),
+	)
+}
+
+// MetricSet fetches the metrics periodically calculated in background in system.asynchronous_metrics.
+type MetricSet struct {
+	*clickhouse.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The clickhouse async_metrics metricset is beta.")
+
+	ms, err := clickhouse.NewMetricSet(base, query)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	rows, err := m.Query()
+	if err != nil {
+		return err
+	}
+
+	reporter.Event(mb.Event{MetricSetFields: clickhouse.NameValueFields(rows)})
+	return nil
+}
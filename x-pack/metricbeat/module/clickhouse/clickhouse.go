@@ -0,0 +1,160 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/"
+
+	// 64 bit integers are quoted by default in the JSON output format, ask
+	// for plain numbers so counters can be read as such.
+	defaultQueryParams = "default_format=JSON&output_format_json_quote_64bit_integers=0"
+)
+
+// HostParser parses the address of the ClickHouse HTTP interface.
+var HostParser = parse.URLHostParserBuilder{
+	DefaultScheme: defaultScheme,
+	DefaultPath:   defaultPath,
+	QueryParams:   defaultQueryParams,
+}.Build()
+
+// MetricSet can be used to build metricsets that run a query against the
+// ClickHouse HTTP interface.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+}
+
+// NewMetricSet creates a metricset that sends the given query in the body of
+// every request.
+func NewMetricSet(base mb.BaseMetricSet, query string) (*MetricSet, error) {
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	http.SetMethod("POST")
+	http.SetBody([]byte(query))
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+	}, nil
+}
+
+// Query runs the query of the metricset and returns the rows of the result.
+func (m *MetricSet) Query() ([]map[string]interface{}, error) {
+	resp, err := m.http.FetchResponse()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	// ClickHouse reports query errors with the exception text in the body.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error %d in %s: %s", resp.StatusCode, m.Name(), strings.TrimSpace(string(content)))
+	}
+
+	return parseResponse(content)
+}
+
+type response struct {
+	Data []map[string]interface{} `json:"data"`
+}
+
+func parseResponse(content []byte) ([]map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+
+	var r response
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return r.Data, nil
+}
+
+// NameValueFields builds the fields of an event from rows with a name and a
+// numeric value column, as the ones returned when querying system.metrics,
+// system.events or system.asynchronous_metrics.
+func NameValueFields(rows []map[string]interface{}) mapstr.M {
+	fields := mapstr.M{}
+	for _, row := range rows {
+		name, ok := row["name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+
+		value, ok := Number(row["value"])
+		if !ok {
+			continue
+		}
+
+		fields[ToSnakeCase(name)] = value
+	}
+	return fields
+}
+
+// Number converts a numeric value decoded from a response into an int64 if
+// possible, or a float64 otherwise.
+func Number(v interface{}) (interface{}, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return nil, false
+	}
+
+	if i, err := n.Int64(); err == nil {
+		return i, true
+	}
+	if f, err := n.Float64(); err == nil {
+		return f, true
+	}
+	return nil, false
+}
+
+// ToSnakeCase converts ClickHouse metric names like TCPConnection or
+// jemalloc.background_thread.num_runs into snake case names that follow the
+// Elastic naming conventions and don't create nested objects.
+func ToSnakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '-' || r == ' ':
+			r = '_'
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		if r == '_' && strings.HasSuffix(b.String(), "_") {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Query":                                "query",
+		"TCPConnection":                        "tcp_connection",
+		"BackgroundMergesAndMutationsPoolTask": "background_merges_and_mutations_pool_task",
+		"OSUserTimeCPU0":                       "os_user_time_cpu0",
+		"jemalloc.background_thread.num_runs":  "jemalloc_background_thread_num_runs",
+		"ReplicasMaxQueueSize":                 "replicas_max_queue_size",
+		"NumberOfDatabases":                    "number_of_databases",
+	}
+
+	for name, expected := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, ToSnakeCase(name))
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	content := []byte(`{
+		"meta": [{"name": "name", "type": "String"}, {"name": "value", "type": "UInt64"}],
+		"data": [
+			{"name": "Query", "value": 18446744073709551615},
+			{"name": "Merge", "value": 2},
+			{"name": "jemalloc.allocated", "value": 1.5},
+			{"name": "", "value": 1},
+			{"name": "Broken", "value": "n/a"}
+		],
+		"rows": 5
+	}`)
+
+	rows, err := parseResponse(content)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	fields := NameValueFields(rows)
+	assert.Equal(t, mapstr.M{
+		"query":              float64(18446744073709551615),
+		"merge":              int64(2),
+		"jemalloc_allocated": 1.5,
+	}, fields)
+}
+
+func TestParseResponseError(t *testing.T) {
+	_, err := parseResponse([]byte("Code: 60. DB::Exception: Table system.foo doesn't exist"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse"
+)
+
+func TestData(t *testing.T) {
+	mux := clickhouse.CreateTestMuxer("../_meta/testdata/metrics.json")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, clickhouse.GetConfig([]string{"metrics"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := clickhouse.CreateTestMuxer("../_meta/testdata/metrics.json")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := clickhouse.GetConfig([]string{"metrics"}, server.URL)
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+
+	assert.NotEmpty(t, events)
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+}
+
+func TestFetchQueryError(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		body = string(content)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Code: 516. DB::Exception: default: Authentication failed. (AUTHENTICATION_FAILED)\n"))
+	}))
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, clickhouse.GetConfig([]string{"metrics"}, server.URL))
+
+	_, errs := mbtest.ReportingFetchV2Error(metricSet)
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), "HTTP error 401")
+		assert.Contains(t, errs[0].Error(), "Authentication failed")
+	}
+	assert.Equal(t, query, body)
+}
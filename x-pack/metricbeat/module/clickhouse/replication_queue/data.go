@@ -0,0 +1,37 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package replication_queue
+
+import (
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var schema = s.Schema{
+	"database": c.Str("database"),
+	"table":    c.Str("table"),
+	"tasks": s.Object{
+		"count":       c.Int("tasks"),
+		"executing":   c.Int("executing"),
+		"get_part":    c.Int("get_part"),
+		"merge_parts": c.Int("merge_parts"),
+		"mutate_part": c.Int("mutate_part"),
+		"with_errors": c.Int("with_errors"),
+	},
+	"tries":     s.Object{"count": c.Int("tries")},
+	"postponed": s.Object{"count": c.Int("postponed")},
+	"oldest_task": s.Object{
+		"age": s.Object{"sec": c.Int("oldest_task_age")},
+	},
+}
+
+func eventMapping(row map[string]interface{}) (mb.Event, error) {
+	fields, err := schema.Apply(row, s.FailOnRequired)
+	if err != nil {
+		return mb.Event{}, err
+	}
+	return mb.Event{MetricSetFields: fields}, nil
+}
@@ -0,0 +1,73 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package replication_queue
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse"
+)
+
+// query summarizes the replication queue of each replicated table.
+const query = `SELECT
+	database,
+	table,
+	count() AS tasks,
+	countIf(is_currently_executing) AS executing,
+	countIf(type = 'GET_PART') AS get_part,
+	countIf(type = 'MERGE_PARTS') AS merge_parts,
+	countIf(type = 'MUTATE_PART') AS mutate_part,
+	countIf(last_exception != '') AS with_errors,
+	sum(num_tries) AS tries,
+	sum(num_postponed) AS postponed,
+	dateDiff('second', min(create_time), now()) AS oldest_task_age
+FROM system.replication_queue
+GROUP BY database, table`
+
+// init registers the MetricSet with the central registry.
+// The New method will be called after the setup of the module and before starting to fetch data
+func init() {
+	mb.Registry.MustAddMetricSet("clickhouse", "replication_queue", New,
+		mb.WithHostParser(clickhouse.HostParser),
+	)
+}
+
+// MetricSet fetches the status of the replication queue of replicated tables.
+type MetricSet struct {
+	*clickhouse.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The clickhouse replication_queue metricset is beta.")
+
+	ms, err := clickhouse.NewMetricSet(base, query)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	rows, err := m.Query()
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		event, err := eventMapping(row)
+		if err != nil {
+			m.Logger().Errorf("error mapping replication queue of %v.%v: %v", row["database"], row["table"], err)
+			continue
+		}
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package replication_queue
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/clickhouse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	mux := clickhouse.CreateTestMuxer("../_meta/testdata/replication_queue.json")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, clickhouse.GetConfig([]string{"replication_queue"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := clickhouse.CreateTestMuxer("../_meta/testdata/replication_queue.json")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := clickhouse.GetConfig([]string{"replication_queue"}, server.URL)
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+
+	assert.Len(t, events, 2)
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+
+	event := events[0].MetricSetFields
+	assert.Equal(t, mapstr.M{
+		"database": "default",
+		"table":    "events",
+		"tasks": mapstr.M{
+			"count":       int64(7),
+			"executing":   int64(2),
+			"get_part":    int64(5),
+			"merge_parts": int64(2),
+			"mutate_part": int64(0),
+			"with_errors": int64(1),
+		},
+		"tries":     mapstr.M{"count": int64(19)},
+		"postponed": mapstr.M{"count": int64(4)},
+		"oldest_task": mapstr.M{
+			"age": mapstr.M{"sec": int64(65)},
+		},
+	}, event)
+}
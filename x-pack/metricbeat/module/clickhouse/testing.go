@@ -0,0 +1,36 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package clickhouse
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// CreateTestMuxer returns a handler that replies to any query with the content
+// of the given file.
+func CreateTestMuxer(file string) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile(file)
+		_, err := w.Write(input)
+		if err != nil {
+			fmt.Println("error writing response on mock server")
+		}
+	}))
+
+	return mux
+}
+
+// GetConfig returns the configuration to use the given metricsets in tests.
+func GetConfig(metricsets []string, host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "clickhouse",
+		"metricsets": metricsets,
+		"hosts":      []string{host},
+	}
+}
@@ -19,5 +19,5 @@ func init() {
 // AssetContainerd returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/containerd.
 func AssetContainerd() string {
-	return "eJzUmE1v8zYMx+/5FEQvAwY0u+cwYC1QoBi6Fdt6LmSZTrXoxdBL0/TTP5Bsp44t20qeBnF0KAq/kD/Sf5JSbmGDuxVQJS1hEnW+ALDMclzBzf3+4s0CQCNHYnAFGVqyAMjRUM1Ky5Rcwe8LAICvF8BYYg1QxTlSizkUWolDLwVDnptVePEWJBHYwfDL7kpcwVorV9ZXIm79epSF0oL4y0Bk5Z8Zy6gBkilnW6Z/MaCdlEyuvy6aZW2pTdUm839NSSju7zRwG9xt1R54BLGTob7FxlfGN0z1/LST4Ff3eyR4v+OKbuDxt79BoNWM7qOORd4myvGdHYQ+Fv4EhF9/EYGgCsgCUMR641gj6ZqOZyPB6R+UOuE48XL0dg3kTnsV2DcEzopA5P/fq6JjIpahNqwqTe9ew8uVXEduTiCHXDmRofZsJ0G3ZLWzeDRgVVXDLycEcOdfDfDHsTfcW81sXHs/KYJg+OpUcBr1XGTg6S3K05RgnBBE787XEPzkuE5V+KauStRhAl6tOkKTaD7CUTLpDVFauskRmjao/3HSMoFw//wSm5tDc3hsnpqdsSiWVlnCo2rOlct4t+lNpPE/bw2cQV3tgIKPQB3wTYnSApNgkCqZH8TwReYMWac32ynVb1BL7EY4bnLMbNu0jAkNxhPYrAQ9+uVT92eIoEoLSCJVnb3BkH3+rzngF6+f9HBjEr6qeG1dNUcFvCypHQzaUMIxfy24IrGHmmZZoqYoY08k0D9XL3to3xJDDPtKl94DZ5+YQ7YL/VLu54R/iCodadOHNTu3CH1cTNZwEM5NaXEuRyt1pmGGJv4tQdLSLX9dRmupClJl/2M0B9WN14lqSwjRU/bqy0dp35gJgL3hLVCog33etx2Be5bHxvRW6Q2Ta4M2opNJjYzrYyJxT4GzIQCDtrFD1hgf3dp0v/Lgpm1sw5a0y6jSCBoNy/22wvMZ9jlARqhl7/jAeM/Z+Qgrn1AwjpWROBol9C2+3zkHVPA2hsPkJXLVeJ3M1nG7wwnfL6En1EoKvxd229jU9lKQjwscVZ7IR0Md8jHcfC89XOpK4FzRcMitqYcaCSTv686X3IP2MpHegjC+pMpF05OU5QSgB8I4BCeoh1E4E2yY4oxabCWqgogXbvQgdnLl1oei6yvdBjy5gi9VBjWonVs11FzFrIviUJ4jVWG2pKv8k2vi3y0pr7AiKuzZ10PAnF01BKp510Jblp1KWPwIAAD//wv4XH8="
+	return "eJzUms2O2zYQx+96ikEuBYqNevehQLJFgEWQdJF2zwFNjW3WFCmQ1DrK0xdDibItUR827KyNGEFgRjO/+XNm+CG/hy1WC+BaOSYUmiwBcMJJXMC7x/bLdwmAQYnM4gKW6FgCkKHlRhROaLWAPxMAgP0DYB1zFriWErnDDFZG58deVgJlZhf+wfegWI4dDBpwVYELWBtdFs03Ebf0eVIrbXJGNMBU7V9YJ7gFttSlOzD9mwVTKiXUev+lTRtLh1SHZPS3LRjHdiTAbbHa6RZ4BLGjUN9i8LWUW6EPHuuLANCfjxneP0rNt/D0x9+QozOCt1HHIj8kyvBVHIU+Fv4EBH2+shxBr2DpgSLWg2ODrGs6rsYMpx84L/NSMkpHsmshKw1lgdsgSLHyRPTvNis6JmIKHcLqwvbGAq/Uah0ZnECmz9cyX6IhtrOg93jLyuHJgHVVDT88I4CP5NfDn8YeuHdGOLxGEnjDd5cF51HfShoQvUN1Gn5At2WeM1NdIxcoPa1fOe4zK6ip6wKNXwHvNjtoFtpJOClNkm4gvCiTLn43R+Yt1N9K5USO8Pj8Els3h9bhWEYEOFtZh3nqtGMyiQmd6XIpMTlJxn/JGpQWjZew9uGpPb4tUDkQCixyrTKbRslKy9bzm+1U1m/RKOxGOG5yzOyhaRVLtAkBZwoZ/pB0n30EtSygmNKNeskQF+l/zwG/UP7MDzeWwncVr2uq5qSA04K7iNU6aMuZxOz7SmrmRpplgYajcslZ9M/1wwRNLdHH0Fa6osVaip+YwbLy/VK16wT9J64NDgdY1+ytRUgdTKimoYA/N82LMx0MlCr1RsMktMsEyYsy/T2N1lKdrXr5H0Y1qAe+T1TbjBBpKnr1RQuR2wjrAZMudY65PtrnXewI3LMca0UBY6fNVqi1RRfJk8kcGc+PCeG+eM5AABZdsMPWmEZpjbXJzE3b2IZtAqzeZdQygkErMtpWEJ8VPwfIGHfiFT8Jib+OsPYJKyGx3tTG0TjjG/xlUN7bGI5Qb6GVUDPVOm13OOH7hYyFTKL7uo7T4W1CwMnZj97YiE5TWs1gps8X9iNQez3SQb63XlyaSpBSc3/h0VAPNZL5+7rriXvUXibkXTEhU65LdbbKM4A+MSHBO0EzjCJFLtwbyNUsE16oGiJNYnzRg9jZldsciu6vdAP47Ap+qzJoQN2tVUPDtbrpomggG9lGqsLuWHGpmvhnx4o7rIga++brwWPeXDV4qtuuhcO07FRC0mW0ihV2o10yVQ7z7k2DuXqqHrovg4/ucTNYf3t+hA/PT+mM812slLpBuN413AXeVxLzgQNwG+YgZ4qt0R4NplG2LVaXY/qMVUBqdu/BOd0BZ8AsGK2d39M3d8HL6lj1S2zyp9raUO5eO/H/EnbbXKGUdn95EjR6AKXpNpzLMqP3PML/WiHP6e1UBgUzqPotfB+VUDo7PayT3ujULqLw8XmLdbvheZtg2XMEp82VDe5/CvFAp5N2PNL+pnLjrNVkhoj1IUL1Q0gHUeoSugLLXshOldoHKG3JpKzaOR7+WUofuE3WqzLvSyKCLXJ/6mDVKOirwN1VGeml4XutZOVd7UHT5P8BANu8JUM="
 }
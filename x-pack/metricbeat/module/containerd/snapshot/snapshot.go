@@ -0,0 +1,209 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	snapshotsapi "github.com/containerd/containerd/api/services/snapshots/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// namespaceHeader is the gRPC metadata key used by containerd to scope
+// requests to a namespace.
+const namespaceHeader = "containerd-namespace"
+
+var hostParser = parse.URLHostParserBuilder{DefaultScheme: "unix"}.Build()
+
+func init() {
+	mb.Registry.MustAddMetricSet("containerd", "snapshot", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+type config struct {
+	Namespaces   []string      `config:"snapshot.namespaces"`
+	Snapshotters []string      `config:"snapshot.snapshotters"`
+	Timeout      time.Duration `config:"timeout"`
+}
+
+func defaultConfig() config {
+	return config{
+		Snapshotters: []string{"overlayfs"},
+		Timeout:      10 * time.Second,
+	}
+}
+
+// metricset collects snapshot usage through the containerd gRPC API, so it
+// doesn't need the containerd metrics endpoint to be enabled.
+type metricset struct {
+	mb.BaseMetricSet
+	config config
+	conn   *grpc.ClientConn
+
+	namespaces namespacesapi.NamespacesClient
+	containers containersapi.ContainersClient
+	snapshots  snapshotsapi.SnapshotsClient
+}
+
+// New creates a new instance of the snapshot MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The containerd snapshot metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(base.HostData().URI,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to containerd: %w", err)
+	}
+
+	return &metricset{
+		BaseMetricSet: base,
+		config:        config,
+		conn:          conn,
+		namespaces:    namespacesapi.NewNamespacesClient(conn),
+		containers:    containersapi.NewContainersClient(conn),
+		snapshots:     snapshotsapi.NewSnapshotsClient(conn),
+	}, nil
+}
+
+// Fetch reports one event per container with the disk usage of its active
+// snapshot, and one summary event per namespace and snapshotter.
+func (m *metricset) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+
+	namespaces := m.config.Namespaces
+	if len(namespaces) == 0 {
+		resp, err := m.namespaces.List(ctx, &namespacesapi.ListNamespacesRequest{})
+		if err != nil {
+			return fmt.Errorf("error listing containerd namespaces: %w", err)
+		}
+		for _, ns := range resp.Namespaces {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	for _, ns := range namespaces {
+		nsCtx := metadata.AppendToOutgoingContext(ctx, namespaceHeader, ns)
+
+		if err := m.fetchContainers(nsCtx, ns, r); err != nil {
+			return err
+		}
+		for _, snapshotter := range m.config.Snapshotters {
+			if err := m.fetchSummary(nsCtx, ns, snapshotter, r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the connection with containerd.
+func (m *metricset) Close() error {
+	return m.conn.Close()
+}
+
+func (m *metricset) fetchContainers(ctx context.Context, ns string, r mb.ReporterV2) error {
+	resp, err := m.containers.List(ctx, &containersapi.ListContainersRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing containers in namespace %s: %w", ns, err)
+	}
+
+	for _, c := range resp.Containers {
+		if c.Snapshotter == "" || c.SnapshotKey == "" {
+			continue
+		}
+		usage, err := m.snapshots.Usage(ctx, &snapshotsapi.UsageRequest{
+			Snapshotter: c.Snapshotter,
+			Key:         c.SnapshotKey,
+		})
+		if err != nil {
+			m.Logger().Debugf("error getting usage of snapshot %s for container %s: %v", c.SnapshotKey, c.ID, err)
+			continue
+		}
+
+		r.Event(mb.Event{
+			RootFields: mapstr.M{
+				"container": mapstr.M{
+					"id": c.ID,
+				},
+			},
+			ModuleFields: mapstr.M{
+				"namespace": ns,
+			},
+			MetricSetFields: mapstr.M{
+				"snapshotter": c.Snapshotter,
+				"key":         c.SnapshotKey,
+				"usage": mapstr.M{
+					"bytes":  usage.Size_,
+					"inodes": usage.Inodes,
+				},
+			},
+		})
+	}
+	return nil
+}
+
+func (m *metricset) fetchSummary(ctx context.Context, ns, snapshotter string, r mb.ReporterV2) error {
+	stream, err := m.snapshots.List(ctx, &snapshotsapi.ListSnapshotsRequest{Snapshotter: snapshotter})
+	if err != nil {
+		return fmt.Errorf("error listing %s snapshots in namespace %s: %w", snapshotter, ns, err)
+	}
+
+	var total, active, committed, view int64
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error listing %s snapshots in namespace %s: %w", snapshotter, ns, err)
+		}
+		for _, info := range resp.Info {
+			total++
+			switch info.Kind {
+			case snapshotsapi.KindActive:
+				active++
+			case snapshotsapi.KindCommitted:
+				committed++
+			case snapshotsapi.KindView:
+				view++
+			}
+		}
+	}
+
+	r.Event(mb.Event{
+		ModuleFields: mapstr.M{
+			"namespace": ns,
+		},
+		MetricSetFields: mapstr.M{
+			"snapshotter": snapshotter,
+			"count": mapstr.M{
+				"total":     total,
+				"active":    active,
+				"committed": committed,
+				"view":      view,
+			},
+		},
+	})
+	return nil
+}
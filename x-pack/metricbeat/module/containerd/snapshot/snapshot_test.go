@@ -0,0 +1,156 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+// +build !integration
+
+package snapshot
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	snapshotsapi "github.com/containerd/containerd/api/services/snapshots/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeNamespaces struct {
+	namespacesapi.UnimplementedNamespacesServer
+}
+
+func (fakeNamespaces) List(context.Context, *namespacesapi.ListNamespacesRequest) (*namespacesapi.ListNamespacesResponse, error) {
+	return &namespacesapi.ListNamespacesResponse{
+		Namespaces: []namespacesapi.Namespace{{Name: "k8s.io"}},
+	}, nil
+}
+
+type fakeContainers struct {
+	containersapi.UnimplementedContainersServer
+}
+
+func (fakeContainers) List(ctx context.Context, _ *containersapi.ListContainersRequest) (*containersapi.ListContainersResponse, error) {
+	if namespace(ctx) != "k8s.io" {
+		return &containersapi.ListContainersResponse{}, nil
+	}
+	return &containersapi.ListContainersResponse{
+		Containers: []containersapi.Container{
+			{ID: "c1", Snapshotter: "overlayfs", SnapshotKey: "c1"},
+			{ID: "c2", Snapshotter: "overlayfs", SnapshotKey: "missing"},
+			{ID: "c3"},
+		},
+	}, nil
+}
+
+type fakeSnapshots struct {
+	snapshotsapi.UnimplementedSnapshotsServer
+}
+
+func (fakeSnapshots) Usage(_ context.Context, req *snapshotsapi.UsageRequest) (*snapshotsapi.UsageResponse, error) {
+	if req.Key != "c1" {
+		return nil, status.Errorf(codes.NotFound, "snapshot %s not found", req.Key)
+	}
+	return &snapshotsapi.UsageResponse{Size_: 4096, Inodes: 12}, nil
+}
+
+func (fakeSnapshots) List(req *snapshotsapi.ListSnapshotsRequest, stream snapshotsapi.Snapshots_ListServer) error {
+	if req.Snapshotter != "overlayfs" {
+		return status.Errorf(codes.InvalidArgument, "unknown snapshotter %s", req.Snapshotter)
+	}
+	return stream.Send(&snapshotsapi.ListSnapshotsResponse{
+		Info: []snapshotsapi.Info{
+			{Name: "sha256:1", Kind: snapshotsapi.KindCommitted},
+			{Name: "sha256:2", Kind: snapshotsapi.KindCommitted},
+			{Name: "c1", Kind: snapshotsapi.KindActive},
+			{Name: "v1", Kind: snapshotsapi.KindView},
+		},
+	})
+}
+
+func namespace(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(namespaceHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func startServer(t *testing.T) string {
+	socket := filepath.Join(t.TempDir(), "containerd.sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	namespacesapi.RegisterNamespacesServer(s, &fakeNamespaces{})
+	containersapi.RegisterContainersServer(s, &fakeContainers{})
+	snapshotsapi.RegisterSnapshotsServer(s, &fakeSnapshots{})
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	return "unix://" + socket
+}
+
+func TestFetch(t *testing.T) {
+	config := map[string]interface{}{
+		"module":     "containerd",
+		"metricsets": []string{"snapshot"},
+		"hosts":      []string{startServer(t)},
+	}
+
+	f := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	containerEvent := events[0]
+	assert.Equal(t, "c1", containerEvent.RootFields["container"].(mapstr.M)["id"])
+	assert.Equal(t, mapstr.M{"namespace": "k8s.io"}, containerEvent.ModuleFields)
+	assert.Equal(t, mapstr.M{
+		"snapshotter": "overlayfs",
+		"key":         "c1",
+		"usage": mapstr.M{
+			"bytes":  int64(4096),
+			"inodes": int64(12),
+		},
+	}, containerEvent.MetricSetFields)
+
+	summaryEvent := events[1]
+	assert.Equal(t, mapstr.M{
+		"snapshotter": "overlayfs",
+		"count": mapstr.M{
+			"total":     int64(4),
+			"active":    int64(1),
+			"committed": int64(2),
+			"view":      int64(1),
+		},
+	}, summaryEvent.MetricSetFields)
+}
+
+func TestFetchUnknownSnapshotter(t *testing.T) {
+	config := map[string]interface{}{
+		"module":                "containerd",
+		"metricsets":            []string{"snapshot"},
+		"hosts":                 []string{startServer(t)},
+		"snapshot.namespaces":   []string{"default"},
+		"snapshot.snapshotters": []string{"zfs"},
+	}
+
+	f := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	assert.Empty(t, events)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "error listing zfs snapshots in namespace default")
+}
@@ -0,0 +1,150 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var (
+	serverSchema = s.Schema{
+		"connections": c.Dict("http_server", s.Schema{
+			"opened": c.Int("tcp_open", s.Optional),
+			"closed": c.Int("tcp_close", s.Optional),
+		}, c.DictOptional),
+
+		"agents": c.Dict("agents", s.Schema{
+			"enrolled":  c.Int("enrolled", s.Optional),
+			"online":    c.Int("online", s.Optional),
+			"offline":   c.Int("offline", s.Optional),
+			"unhealthy": c.Int("unhealthy", s.Optional),
+			"updating":  c.Int("updating", s.Optional),
+		}, c.DictOptional),
+
+		"coordinator": c.Dict("coordinator", s.Schema{
+			"state":    c.Str("state"),
+			"leader":   c.Bool("leader", s.Optional),
+			"policies": c.Int("policies", s.Optional),
+		}, c.DictOptional),
+	}
+
+	routeSchema = s.Schema{
+		"requests": s.Object{
+			"active":       c.Int("active", s.Optional),
+			"total":        c.Int("total"),
+			"failed":       c.Int("fail", s.Optional),
+			"dropped":      c.Int("drop", s.Optional),
+			"rate_limited": c.Int("limit_rate", s.Optional),
+			"max_limited":  c.Int("limit_max", s.Optional),
+		},
+		"body": s.Object{
+			"in":  s.Object{"bytes": c.Int("body_in", s.Optional)},
+			"out": s.Object{"bytes": c.Int("body_out", s.Optional)},
+		},
+		"latency": c.Dict("latency", s.Schema{
+			"p50": s.Object{"ms": c.Float("p50", s.Optional)},
+			"p95": s.Object{"ms": c.Float("p95", s.Optional)},
+			"p99": s.Object{"ms": c.Float("p99", s.Optional)},
+			"max": s.Object{"ms": c.Float("max", s.Optional)},
+		}, c.DictOptional),
+	}
+)
+
+func eventsMapping(r mb.ReporterV2, content []byte, rates *rateTracker, now time.Time) error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("failure parsing Fleet Server stats response: %w", err)
+	}
+
+	var errs multierror.Errors
+
+	serverFields, err := serverSchema.Apply(data)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failure applying server schema: %w", err))
+	} else {
+		r.Event(mb.Event{MetricSetFields: serverFields})
+	}
+
+	routes := getRoutes(data)
+	names := make([]string, 0, len(routes))
+	for name := range routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		routeFields, err := routeSchema.Apply(routes[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failure applying schema of route %s: %w", name, err))
+			continue
+		}
+
+		routeFields.Put("route", name)
+		if total, err := routeFields.GetValue("requests.total"); err == nil {
+			if rate, ok := rates.rate(name, total.(int64), now); ok {
+				routeFields.Put("requests.rate", rate)
+			}
+		}
+
+		r.Event(mb.Event{MetricSetFields: routeFields})
+	}
+
+	return errs.Err()
+}
+
+// getRoutes returns the stats of each API route, found under http_server.routes.
+func getRoutes(data map[string]interface{}) map[string]map[string]interface{} {
+	server, _ := data["http_server"].(map[string]interface{})
+	raw, _ := server["routes"].(map[string]interface{})
+
+	routes := make(map[string]map[string]interface{}, len(raw))
+	for name, stats := range raw {
+		if stats, ok := stats.(map[string]interface{}); ok {
+			routes[name] = stats
+		}
+	}
+	return routes
+}
+
+// rateTracker keeps the previous value of the request counters of each
+// route, to report them as rates per second.
+type rateTracker struct {
+	samples map[string]sample
+}
+
+type sample struct {
+	value int64
+	at    time.Time
+}
+
+func newRateTracker() *rateTracker {
+	return &rateTracker{samples: map[string]sample{}}
+}
+
+// rate returns the per second rate of the counter since its previous value.
+// No rate is available on the first sample or after the counter is reset,
+// for example when Fleet Server restarts.
+func (t *rateTracker) rate(key string, value int64, now time.Time) (float64, bool) {
+	prev, found := t.samples[key]
+	t.samples[key] = sample{value: value, at: now}
+	if !found || value < prev.value {
+		return 0, false
+	}
+
+	elapsed := now.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(value-prev.value) / elapsed, true
+}
@@ -0,0 +1,77 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/stats"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+		PathConfigKey: "stats.metrics_path",
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("fleet_server", "stats", New,
+		mb.WithHostParser(hostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http  *helper.HTTP
+	rates *rateTracker
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The Fleet Server stats metricset is currently in beta.")
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		rates:         newRateTracker(),
+	}, nil
+}
+
+// Fetch makes a GET request to the Fleet Server monitoring endpoint (see
+// defaultPath) and reports one event for the server and one for each API route.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in fetch: %w", err)
+	}
+
+	if err = eventsMapping(r, content, m.rates, time.Now()); err != nil {
+		return fmt.Errorf("error in mapping: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventsMapping(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/stats.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content, newRateTracker(), time.Now())
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 5)
+
+	server := events[0].MetricSetFields
+	assertValue(t, server, "agents.enrolled", int64(1031))
+	assertValue(t, server, "agents.offline", int64(21))
+	assertValue(t, server, "connections.opened", int64(15234))
+	assertValue(t, server, "coordinator.state", "running")
+	assertValue(t, server, "coordinator.leader", true)
+
+	// Routes are reported in order
+	checkin := events[3].MetricSetFields
+	assertValue(t, checkin, "route", "checkin")
+	assertValue(t, checkin, "requests.total", int64(482310))
+	assertValue(t, checkin, "requests.rate_limited", int64(120))
+	assertValue(t, checkin, "latency.p99.ms", 1207.3)
+	assertValue(t, checkin, "body.out.bytes", int64(30284512))
+
+	// No rate is reported on the first fetch
+	_, err = checkin.GetValue("requests.rate")
+	assert.Error(t, err)
+
+	// Routes without latency stats are still reported
+	acks := events[1].MetricSetFields
+	assertValue(t, acks, "route", "acks")
+	_, err = acks.GetValue("latency")
+	assert.Error(t, err)
+}
+
+func TestEventsMappingRates(t *testing.T) {
+	rates := newRateTracker()
+	now := time.Now()
+
+	fetch := func(total int, at time.Time) mapstr.M {
+		content := []byte(`{"http_server": {"routes": {"checkin": {"total": ` + strconv.Itoa(total) + `}}}}`)
+		reporter := &mbtest.CapturingReporterV2{}
+		require.NoError(t, eventsMapping(reporter, content, rates, at))
+		require.Len(t, reporter.GetEvents(), 2)
+		return reporter.GetEvents()[1].MetricSetFields
+	}
+
+	fields := fetch(1000, now)
+	_, err := fields.GetValue("requests.rate")
+	assert.Error(t, err)
+
+	fields = fetch(1500, now.Add(10*time.Second))
+	assertValue(t, fields, "requests.rate", float64(50))
+
+	// Counters going backwards mean Fleet Server was restarted
+	fields = fetch(20, now.Add(20*time.Second))
+	_, err = fields.GetValue("requests.rate")
+	assert.Error(t, err)
+
+	fields = fetch(40, now.Add(30*time.Second))
+	assertValue(t, fields, "requests.rate", float64(2))
+}
+
+func TestEventsMappingInvalid(t *testing.T) {
+	reporter := &mbtest.CapturingReporterV2{}
+	err := eventsMapping(reporter, []byte(`{"http_server": {"routes": {"checkin": {"active": 2}}}}`), newRateTracker(), time.Now())
+	assert.Error(t, err, "routes without a total must be reported as errors")
+	assert.Len(t, reporter.GetEvents(), 1)
+
+	err = eventsMapping(reporter, []byte("{"), newRateTracker(), time.Now())
+	assert.Error(t, err)
+}
+
+func TestData(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/stats.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != defaultPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "fleet_server",
+		"metricsets": []string{"stats"},
+		"hosts":      []string{server.URL},
+	}
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	err = mbtest.WriteEventsReporterV2ErrorCond(metricSet, t, "", func(e mapstr.M) bool {
+		v, err := e.GetValue("fleet_server.stats.route")
+		return err == nil && v == "checkin"
+	})
+	require.NoError(t, err)
+}
+
+func assertValue(t *testing.T, m mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	v, err := m.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, v, key)
+	}
+}
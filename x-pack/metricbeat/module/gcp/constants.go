@@ -27,6 +27,7 @@ const (
 	ServiceDataproc       = "dataproc"
 	ServiceCloudSQL       = "cloudsql"
 	ServiceRedis          = "redis"
+	ServiceCloudRun       = "cloudrun"
 )
 
 // Paths within the GCP monitoring.TimeSeries response, if converted to JSON, where you can find each ECS field required for the output event
@@ -88,6 +89,7 @@ const (
 	CloudSQLResourceLabel = "resource.labels.region"
 	DataprocResourceLabel = "resource.label.region"
 	RedisResourceLabel    = "resource.label.region"
+	CloudRunResourceLabel = "resource.label.location"
 )
 
 // AlignersMapToGCP map contains available perSeriesAligner
@@ -19,5 +19,5 @@ func init() {
 // AssetGcp returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/gcp.
 func AssetGcp() string {
-	return "eJzsXVtz2ziWfs+vQPVLki23eqd3ax9SW1PldqZ7UhP3uMZOV+0TGwSPJEQgwOBiR/3rt3AjQYmSKF7kuGucl9gige9ccG44gL5HG9i+QytSvUJIU83gHXr9ixArBuiGCVOgO4b1Usjy9SuEJDDACt6hHDR+hVABikhaaSr4O/TXVwgh9MvNHSpFYRi8QmhJgRXqnfvge8RxCXEq+6O3lf1dChP/kj6fvsNwDkzVf46vivwzEJ38uQNP/PG4ONVCUr5CJWhJidofeRdCCsMokIv/aH10EIr98X/M/BMb2D4JWXQOXILGBdZ4rsEtqbOMrbZKQznL0BKUMJLAZIPHgb+rGeL/fXdar1rjFsLkTrs7Ps1KXFWUr8Kj37UGP6Kdt0Ed9RprJEEbyaFASylK1FqM13cf0BcDcrvYIyunjFG+OjRfa5if/LNRNZJ3dld4mzHpWkWdiyWiIUJ5jrzaF12X1FtYb4TS7lmFKCfMFIAkrAzD8gpp/PUK4eKzUboErq8Q5gWSwvDCsh2kFHLRgYfyR0EJZKXgej0EU2SZhEpIjdw4XRNVUjhtoMWQWe782+jDeySWSK8hijXOmwMTfKWQFl2Ta6Ex65h3yQTWh2d9sK/VM+FSGK73FYyIsjIaeinYjX92RgVbUglPmLFFIUVVQbHItxpUB+2WX4dJ/8CJKC3V7nUUBkP51jE/TtJj/qzCZANaZcRx7xEz06X7PdGEwc7AQ7nSmBNYkMosJCiQj1BkREhQB8HsWbIdOL+aMgdpNdGNg+KwSHAHZ23XadDTOP8paEbhFWSalrBQQAaA+mQHQEshEWYsAKMcKSCCF6rX9Itqx3P0m/nBSkBiYn+LZGPGBMEaCnRz98mbb6oQMVIC12xrkRkFkWF9mFRQtVlIwEM1+sbqn4XnNdqO5B2JHbjXxJmoxqlxDcEO6RF8+CcSFUhsHzgqJIfiSVIN09Bvh9LAkRb9GOCmnpgDbsz+LCihFHK7yK1uCb6QuMwU/QMGQrFa6/xCMOwWlZ/BKqfVyt9uF+hhTVWw1laBBWdbhB8xZThnfrX9dhvCE++CLEPty/AjWuKSsm231z1MklFQDCTp1sNvVpkd6/moUU+4yigfqK9WPnuScWuG8oBqZUBpv4ipVkg8cWTnRKrCBJ6FWmH0lOTGRepIbCjW4jno5aCfhNwsKF9JUGoqM0yAPsaY3oIJ05yDJEQFC2eZhiOKwcUoTDAhcxTY3x5BDgQxMV/OhmOqI/HM8cmbECsEMMGGoTVWKAfgSBrOKV8dVVkPIHNmfhCMvzFcWRtqh0GKcgIRxxNWSGksNRRXSZy1QPe4rBgUCB5BbtH//GfzyfVSg0TKfk756goVWGO7ULnQ6JEqGlepqezC/MuPzat7OYd9tZKC9Eo63oeHZ8g6kiyIGaVBLtbFUi0sPC4K6FK9g4zfi/kLaiNIL3le68Pf3/9870j61U7gzRmWEPXBCp8WgHCN6DRcpYW00TfBFSZUbzvc75FY+CDuOFyN2leFaqSCRxhWgX756QykRlNG/3Ah0yiw1utUIAlwbbOHGqifZieS6IHP8DVgptfbLGeCbOaQfz0F8lNEgduneon8s8gXS0wZFDOg+yzyoJNr/AjIz2Ml3VMfLbho2eZEl66Y89Apk5c2KrkA95ydr+ezRrEnzjqfmE/QTcoyRtwN0PmEvot0mOgbpHMqQCdXB6vBFku+qCsRmQ+qs8beTWw6/+/6X7/WKaRqSiB9QFbVHLYSE00fwQPDVcXsQ5a5PRARwTWmHOQcuBygZIbTcILsDuT7Q7yzRZRKzA7dxw87ODa4KTHHqxn5Y+Ob2zDHbnTTX/0r8DsAUzHQqn0IC5qk/ItNS688967qal+Y2i7YHNDSsCVlrCnaKrKGwrBeVDxSqQ1moW47PcPD+E3Z1ErgtC8kwsb6dmhfuT3M2NPbcmuqtFhJXJ7ivstFvKcUYmO5G2BAk7C6Z+x/jLIph7OdCmH7Wkz63SN1auC3URSivk4bBjxhuiwLCiNPhaGz0O48g6qsGlLrxFb00XoJjTX0dWTPJ73UzZ0tw/rlCSXZMOUZ5Jlwo5dU060mm6n023r7OT49axpcCGJK4HpRgGX7CFP10DJQyhACSi0Nq6dAfooD3rMG4rZM5oRhJ1Bebb8YkBQUEhIxITamOgXOb2jMic7N0FFBWW0Oqs3r31cb+D3GJ95VlGkjwpN1XhJXdSvCzR2615hsCkkfQbpuhPC26ybYb69ZCune+uUff3s9oR42W9IhtHJ7e9apZaP3F29MaRh28eTN3SfkxnP5Q73b6HYbgnOvEfSrjZ2kgdGS6pHbtha2R+pGi1uVzUSj8aVlmen2Ui1uj7jvPmrYC3CMQgRzLjSCrwSgQH9BWAXhtT+w77tZpith/vjfZ3AwRJGTbc030g4jdyjnzNXaU6TOoy4Nuedsve+qjA3ZVxKwduV+zHcUJ9WaMOEz6A1UayhBYpaFiqVfhwN3Xz4Kghmqx6yroH7tUe53ZYZaiX2skW/Tog2jzoDX1YCnBev1aSzUsBM7RvZh67zbMUwKcKZVX6YEvDA/0dt4Bj5WNpRZYsP0wK3NxktUrmnKDqWuUC7FBjgqxBN3rmJbwRUq8WchXTdlSXl3E+UewHEr+7ZVVhmkjTML4FIOLCj1n9qHBZaOMK5BX6axpTZJxnL8wrKZhtpR3DV2vZJ+1/wZtPdoP8JJTaUlJMmMV8p9+lq9CWfJgIvC92KGjQOcM5gsGk7GTCLjsH7czOOxzmMNdpH3NwYXWso1Ly6aZyeiG5xi18hdg8xIXfPN8rwj/xqnZftxaKIRE4ejvu0q1bipsVPXFpMtJQztW/1ZQlrz8gNanKybqClh+0aqgf22O/pxGeAe8cCWTIf4kJZMrRkzZFceaFqWG4g2xCjjF15i3EJsNyPGCzikkQFqn3yrY7rLRv8pd8cspyYYCQuobnOezuCOD6Zn1NDYnRv7izMvx4HduQ1OvsPYun85gbvXtzsCvwKu58KuJeYqNPpMDL+iReYLF8MPjJT4K7rzhxD/eT9SVy2eEYc92ltfsT+kkoKAUvHQx1iQWYGhFPzZtpGc7Bk8AovNsx7QqJA3EjVx5h3bWnZxDkjHK1HsWYvx9uyUnahEMXKdpbitlZgec4d9mAb2o2CmnCJebBC7M24hpaiP4YSeDTvlpRx4Qt4IXf+1i650rR4n6QSuWSI1P3hHdJaDtZUp+GMR2vEq4k5pPBwprOUdMIQjWhc5HMIELnLMMCd97z34KHCBfoqvzHg6fa11pRY5JhvgRTauTN52gMmJKVxv+Ya2lL8/PNz9cO/4gjxjrCgFCjg6lbMb6bB4Z8dVR2zh0Hi+rYG4AxgdYPsAVJXgamhedpyXfujAzBrrGyERwWQNby0v4asGyTFz+N/cv+1LwKV0gDAKXCsL9TwOzyz6c8FcSsyH1kzgYxdE9l+LqAXjjkEehOmQ1Zr2cHP3w6f3d9Hn72ANetpgjl5hycTTAv0spB3A/aYQ1a8VchJu7rywQWnTM4+UloBLd6q2H/HZuOOXbSa0zmBOx4YThIw76ntcjAFNajp6UTKv6ALFM8tuMO3d6Cmff9V9+PhThy69WY6Qxdt+5Mwsi27CTi+SGuVlFkkKc16uX3oJJJR13t1EqkwpllVSfN0uCBPK3d/DORB/lmlY+SS9w6ceK7b3SkAaZEm5u8HGJZd2fd7ff0Qexkmco1bibqW04dpvt4mOGhUqPn0AjdPSw4gaOf52ex4iDk8XkCNxmdswIYoK+AQQb8JxqeSYo9E23XSno9qwpTCrtTM9J7G2EwCGNXBCj2zgdhzb6HFoo0XJtc39taS5SdNsP/UWEcyIYY7bTjWe1sDT5hp/Z4LlRKwYWNJiRSQaN2vGWPLx/m0ctbV2cyvtdNKH1yE6Psit2s9LWFFh//Nv/o3jn28deCnc2yt5OhbtsmwlNLq++UfLwAnueRV55Jh2mFFLKbi2emUtitSH9xxm48u/Hh5QCVgZaTkiJAJM1om1QTnoJwAeCcS8OGVr6hDhJS+aSER3hjvbkkKnufqyl1Ifvs6w0F4201pVs0swLc11pdbPyTh3OS3Sklb+GGhg5FVjtWLM1oqRrClrsa4zYz6V5Dwj7S3DfJDEejnFwkBbNVzmdzqCfX43pEoh9BoKR/YbylGp3jbkp274tXKMUBqTzZX3ViXlRkMrk2V4CzJkIRVWoVpZm22/HtI9kUNbF+5+9/2dju4djSE3+h66tL0yuTJ5r8HvTH5v8hn3YxTHlVoL7Tw6E6tRO5/O57lrNOozKUrhldtpdh3ShT/UXU/aA5DvM8nybeYjzosC3DsR4qUSkBxDTwRf0lVmqgJP0iZD4l18fuBwRh+RNeYrUFde4n49JdcDbCvw14CDMuw4u7kps8iN0Zn4GLmnQBKxzwxpuKQFK0DpCDnDq2H3HV6vwFnHsKn7NmqoHz7CH8LQfYAJWy8O9XxGm7yee4HJJhIy4ZqqdQOTDRdPDIqVX0rXze/11l1rrRXAqNvJt1OfRD+LjTW8hbqm5Q1ebBZ4gcKk9QdvgzxSYCeBbzVkRAzepW0x3Z9oa+78SOK9plnKNWHQ0F+iRXwEfTFCY5R2i5zCfllL3CTaKYgR9jklpQBcZAy0BjnnKqhMzqhae8bbOZGfE2lRUdKipSfwUhSZXbp2MEY5zIn+aS0UoDiTS7q87B3gW1HQ5faabN7HByZY14fIy9KrhqYidJ+CaHDTJTWBjMb1PfSD3sn8noBtoJDU0WeIXoIWxCsH09lf22Wi1gh4UQnKrVsz2vVqbUG3/EgvOgyv55qOjkt4hhBcRF9vNaghYa5gqIus3XBjUiJGx6HTEjQuhgrUOu2YX1bdSnhIB0eoYAc9c4vtAG0Ty6syjGVJ4DuLV0kI6elPXBcORgUsKaex4NP1qoKkmvFDU85oUfnDaR8avnekN7um91/4VDrQ03M5iHNK007wZxDj9CJ0nBknO7WeBZdaI6w1lFU3LvSJM7oBR4C68kVT+45rJJWIlhWDErj2mUUhwHeO51iTtb9Aso4r0L3wKUp9wQhn2+a2O8Gh9cLC33ScTCat4H2PkfteOasdor6sMX3X3bKBqwqwRKVhmlbM3/nYWbpuMbrtjafNmbvjohERxE7oMGvN8lzw47yPApjDlNphj+r7aVxcz5lGxh3jtsnTIqKwMXZohR6ROfq2PJu9XMTL38fp7qwZ/PP4/A42zqCycZK2E0FPVK8RF/x7q8vbFldpMUy32+TMqRE7RF1KDf6XiAL+OkgZzmXeJWsz7fW1X+4YV6E5Rdmzqfw+oX7DcQIi6/aZGYkKx16GoI1J56wO/1AdZ7yndwXd8dX9cSX9pJjvqueuxN/d2uDgvpStVY82mhsr0MPN1xN0X7zf6b0IVfzaLjsArprhQLw9iPglVMPCTsQgdf8260VjKPrWso8xtCjgxcy1kfa6eDERb8KfOeoPnitnlkYCpm/dZ3uYz++shy2MvQ6Ii/Qt7voTTDaontiSU1LGaH19wYO3DXU3LFXNV5PERnPXEdteJMqdJMf1kko32poU23najrdDN61CmHf1aoRWUVz3pJxV3XseNrs6W8Tf8Ns1TlIiRXQtRzW/ps3fA/fqwNytVsP7cGfcfL2GuKIjD9O/B6ZxYxXc12xgVw9NmGHVzn5Sgl6Lws0dIzXfGm1zzy5NwEav/1hgwrIcKyiyIErsvlNkGsR1BuW00ovNmQYeFCfcTLKSmGsokJ8bKcGAbVFh3AIJT17ffOyMkhsyGv80EP0nFb7Z7vrmY/otPR33ZB9GEtioKiB0SUlmkZVGj/HrO1yNvTclLlIGxRkPcmqiG5Z20OzcrbR7M9E0ujrBJUudsDu/4nkayPGeSy+doaeKd65Y8oMpVIFEuSEb0C2w9ffYMqxU62of903psQefE/dVjqjA2ytHjLs8KD4nofJHSLEOrV3hkh7fo/+IWTy3KYy/EbrAnYeoWneSujAki/7z5WjedBerxrAJM1bLMVxb942J8v8DAAD//4wfFgE="
+	return "eJzsXVuPGzeyfp9fQeTF9mLSOclZnAfjYIHxOPEaa3sHmXGA86SluksSLTbZ4WXGyq8/KF76InVLrb5o4sXCeYit7uJXF1YVi0X292QLu9dknRZXhBhmOLwmL95JueZAbrm0Gbnj1Kykyl9cEaKAA9XwmizB0CtCMtCpYoVhUrwmf7sihJB3t3ckl5nlcEXIigHP9Gv3w/dE0BziUPjH7Ar8u5I2/kv9+fo7nC6B6/Kf46ty+QVSU/vnFjzxj8clmJGKiTXJwSiW6kPK+xDqMKwGlfyl8VMnFPzP/+PCP7GF3ZNUWSvhHAzNqKFzEUdWZ6Gtd9pAPgtpBVpalcJkxCPh70qB+D/fXZ2k3aCbSbvk0P7rIqdFwcQ6PPrdX77rZ50fgzmaDTVEgbFKQEZWSuakMRlv7t6T3y2oXXLA1pJxzsS6a7wGmTf+2WgatXf2ZzghXXO1fbJENKnUXiK13whpV8wB1lupjXtWEyZSbjMgCtaWU3VNDP16TWj2xWqTgzDXhIqMKGlFhmIHpaRKWvAw8ShZCotcCrMZgimKTEEhlSGOTttAhZLOVlg2ZJQ7/zZ5/5bIFTEbIMvmuEvgUqw1MbJtcCMN5Q3qftwVl9R0j/qAr5Uj0VxaYZKrfeIpmo6y4qqDSsPCfrViBuuqwCj43YI2SYpYG09EaiinvR8acD/ZfAkKxRxoaaKAphsUNgpewSPTTIqE/PzV2aCuHnTTlCogQprqrZ20iqRSGMoEKMKENlSkoMlLSNYJsYJas5GK/QFZRUoq8rQBQXL6leU2J6KEVb3PAjTIXiXHpLHg1IBIGejkkXILrXI57Tg3TBu5VjQ/Jr63TBvFlhb/VhMi8RB2hAmSo0FpSKXIumTbyk0pwiSKYBI1t2nGKRK+Mm2uyVLJLQiSySdBljuiDTVwCiDOGbrksIgkF4blkGhI9zB1hI4DuG8CQYJ0CF2vFaypgYzQVEmtCeW81cTkqiFYFH+Q/CkWcsil2iXWMM7+oKjOC1jPbcmCH57UhidZ3bTO4zu5OmQ0L6yBqw4gDad165+dwXFFNCum4IlynmRKFgVkyXJnQF8dyvnArBuo34tU5uio3OskEEOrRWHEQXqMvyhougWjF26Ctej9LDSB2Bl4oiKTtLCJAg3qEbJFKlWrC+ucRJ0zXgF6Hk+WSOHmyAazi2A3cfxT0Kym64653Q/UZyRAVlIFS0Zg1RztNXxSpGbAyA9oEYqm0VGjCCjnMnVu5fbus3eCTJPUKgXCcOe8rYYosAjlGMqM6W2igA616Fu0P4TnXsdoEdJfJNxr4IUsxplxCQFJegTv/0lkAcq5paNKwleSJ8UMTMM/kjIgiJH9BIDPw8QScDT7iyDEkSXalhSJovlCsz9gIBS0WpfNhnQUUYVQwbxV/vYxIQ8bpoO3JkwTKfiO0EfKfAjF2fbbxxDkfeKMAsWX4Seyojnju+RMlqyGbCBLHz38apYhrefjRj/RYsHEQHtF/Rxoxs0ZJgKqNSa4fhIzowmmVTgm0QVN4Vm4ldZMyW6cpI7FimMjn4NfAeZJqm3CxFqB1tO4IQUpsMdYiUAwYZhzkISsoCWHPwtRTC5GYYIJhaMBRfUIaiCIieVyNhxbHMlnjg9epVghgQk+jGyoJksAQZQVgon1UZP1ABbOzQ+C8TOnBfpQJEM0EylEHE9U4wJOGciu62shck/zgkNG4BHUjvzPf1W/3KwMKKLxdybW1wTrsThRcYmPi4s4S22BE/PHn6pXr/Y5xFcLJdOrDtyNRcfb8HDwDVOuOipEKbfagEo22UonCE/IDNpMr1Pweyy8FxnDDBI9NNSKFn9/+8u9Y+kTDlAVSoI94GRgGRBaIjoNVxupMPtOaUFTZnYt4fdILtyJO5IrUftadolUiggDDejdmzOQ1hazo8BikC1ApSAMrh5KoH6YvUyiBz4rNkC52ewWSy7T7Rz6L4cgfoiocHwqYjkK9ItcJivKOGQzoPsil8EmN/QRiB8Hlzk97RHBBfuYFV19xpyHTttljlnJBaTn/Hw5HjrFnjjL9cR8iq6WLGPUXQGdT+n7SIepvkI6pwHsYx1nBjuqRFJWIhZ+DbGo/N3ErvP/bn79FDN3pqsSSB+QRTGHr8SazCN4YLQoODoFFG4PRGUpdA5cDlBthNNwgu461vv9tdZEVNcYku4Th510MLnJqaDrGeWD+c3HMMZ+dhPBnEZagN+3nEqAD5syLYiSCxszLg1+9+a6rPaFodFvL4GsLF8xzquirU43gF0cfbh4ZMpYykPddnqBB/pV2RQ1cBwXxkIs/XNA0r5yO/uuBkofRwqRUsotSjfAgGrB6p5BFrGhJPhOTSi+Fhf97pFyaeA3fzVhTnORYHZaBJkNYeHCvLvIoAs0Q4b5y5o9gji6pXYQyJ5Pe/Uwd7YOy5cn1GRJ8zn0WQ7eU6sRPO764NINrjpGaKyCf4lPz7oMzmRqsWskyQDFPiI2PDQclLZpClqvLC+HIH4InRwHgiXTWWHgANq7HmwcYrhnqgiXcmuLU+CwwDmvkNwINRgRwnrbaTYv/rXewr/iZrAPFcFmfGx7AqJTRYtYIsTOv3tD022mGJbHsIcqvI3xr6UpEMs8+Na7f/z8YkI7jMyFwUG5XU8MaovR+4u3NrecunwSt/QcPbewLXcbra6Ce4mgX23sJA+c5cyM3LZF2I4CcdTiVmU10Gh89bLMdHupiNsj7ruPGvYCnAsnKRVYYYSvKUBGfiRUB+U1f8D33SjTlTB/+usZEgxZ5GRb85W2A+UW45y5WnuK1XnMpWL3nK33fZMhSyBrBRSFYDZU7BlO3WrCgM9gN1BsIAdF+SIURv08HLj78kGmlJOSZlkFdTTRjzmyR9g8E2uU27RoA9UZ8KJ/nxist6exUMNO7Bjdh63z9sAwKcCZZn1Yi3+TceLHn86TY4GpzIpa3tade97uIpIijpQ+6M9EStckp1+wp0pkJGdCql4Ax83sYItxIg+xxpkVcKkAFoz63zqGBZGOcK7BXqbxpbhIpmr8xMKVht4zXNy7D/QhewbrPdqPcNJSsVRRLWb8SvCQv0Zvwlk6wJqyy4TDxgF27UyWDddo1jLjMH/cyOOxzuMN9pH3dwZT2ddPf+0ni4uus2uqG7zELpG7BpmRtuaP+IiW9VcN6gB8h3lozSImTkcdtYbFTY2dIU96sVLQVm/ug/0XBfWalyeIBsLbmZoStrOTgbj37eMywD3iYVbiER/gCs15U1vGDKsrD7RelhuINuQo4ydezbmF3G5GjBcISCMT1D7rrZbhLpv916U7ZjpVyUiYQGWbc51FKSYwghFzaUYLjd25sb944SgPzLRrOCufutdTXYN70Lc7Aj/2Ac+F3SgqdGj0mRh+wbKFL1wMDGBYZaFfyZ0/Ov3P+5G2inhGHPZobn3F/hDczAet46GPsSAXGYVcisunt3Xdc3gEHptnPaBRKW9kauKVd4B8gHPAcryQ2YG3GO/PTvmJQmYj51kdN3qJ6TG3+IdpYD9KbvMp8sUKsTvjFpYU5TGc0LOBQ14qgNfYG2Hrn9r4qs/V4yydwDVLpuaZbsnOloC+sg7+WIZ2vIpYS9VMdaSw1HfAEI5ozarxKFcuabaknIq0720tHyTNyJv4SuxSmKExYWNMoZMlHigS2SIWXQdOt0YArJ2YorV7NFx/xt8fHu5+uHdyIV4wqEpJAg6d9EY6LN/ZC9URWzg0vtyVQPDnNrB9AOpCCj10XXZclp50EGaJ9aVUJMU7Q16hLOGrASUod/hf3r/qy8ClbCDlDARerCLPlPDMqj8XzKXU3IYLhRfk2AaR/3cSrSCeQJwYpjPA0tIebu9++Pz2Lsb8PazBTivMMSqsuHxKyC9SkYfbO/c3bJZ8gb2SVpjqzgsp6j3zRBsFNHeniPsxH2++mMRyGmcwpxPDCUaYmE+NAY2RZ3Iyr+qYuITuBvPejp6J+Wfd+w9vWmzp5WqELl71Y2dmXbQzdnqSMNE0mZknSR3mvFK/9BSocdYGzaTFQmu+KJT8uktSLrW7v0cISLuukjpvOVOjFdt7FRADKmfC3WDjFpfom+7vPxAP4yTOUTPxU6c9/PaxZqNWh4pPH0BMzIOo0uNvH89DJODpAnpMFQxWoixATADxNhyXqqaDtAaXm3gwaw+2kna9ca7nJNbmAuDYxXydxzZ6HNpocHLTvDktOMl4IV9KeYp1m9gW724drDXX+DsT0J/FigFKOZ5iCYwQdGO89nNZpSoPxsQn8RlONZIz7gBmmR13SquM8wrWTKK3+4/8xsnP1Tu+GemVwmhIcF9ka2nIze0/Gg4ON9hRVlFGzui6BbVSUhi0K/TCypjLy+XXhweSA9VWoUSkInj1aM3b4BmTJ2xWCgziLbcnfE2ZInzLkyYy0b7CnW1KkdNS/banUh+5zjDRvm2hwdcLC62+1lXGPKfg3JXaxChW+GOgQZDXldeKOVsjR8K1T0N0rSvmDt6ZeH7eG465k0Um9goDTdNw6+3TGezzhyGdS2k2kDm2X+KdzfpVxX6YBg7sC4284hHudHvto1XOBN6WW1/JcrrDegiuq0hBdahWlm7bB7NKMN93bl24r1Ic7nS072h0b5103+i7vwsSNVTYpbbLXsTv7PLeLmfcj9GCFnojjdvo4HI9cHkYrnffGfDXaATd56BxlwzdnuvAznDbnlaD9gDk+0wWy93CZ+wXBXhwIsSrPCA5hj6VYsXWC1tkdJI2mTTexecJhzP6JN1QsQZ97TXuw0p5mt6N4Q6uKNB4VuYYYGHzRZRGp5PouxI/IdbeQGpqnxnScE1LnuFJmEB/QdddjTHHkd6sgbysmlpeRQv15CP8IQI9BFgT68Whni9ouyzHTmi6jYxMOKcCRU1ouhXyiUO29lPppvp7zOKacy0Djgf+d27ok+hn8bFWNFCXvLykyTahCQmDRrXoV0EfdWAnge8MLPB7KwOhN4Tur0av7vyo5XtVs5RrwmChv8TI+Aj53UpD61f5n8R+WU9cLbTrIEb45zorGd5BzsEYUHPOgsIuOdOYsuHd4Hjhsh+TGFmwtMFLT+C5zBY4dZEBzgTMif5pIzWQOJIr+vgo7AB/lBlb7W7S7dv4wATzuou9RanqCRk95CCM1phSE+goCGZW6K3C7wkYM5ZaHX2G7CVYQbxysD76C5wmekNAZIVk+JGopTWuO28HphFHevFhRTnWdHxcIjKE5CLGepzlkM2dDLWxtZ9uTMrE6Dx0WobG5VCBWysO2JxDV+1G2GWDI0zQiourrYO3ifVVWM4XtcR3lqhSY6RnPHFdOJRksGKCxYJP26saatWMH6pyRoPLH07H0PDdkd7imj5+1YU0JnI5iHNqEwf4d1Dj9Cp0khmnO72ZBZfeEGoM5EU7LvJZcLYFx4DGK1bxf/XGN5IqwrCtHG/jC983k+A7x5fUpBtcRamoVDyJIv0SJbDhuoLiLQbYrwWNF3Ax1hxMYTnS9xi5r2Hqa6JleVlj/V3sj8fuL6CK5JYbVoTvzumTgm5G42nXzG1u+6AyVAdzHth5a5bngh8XfTTAHK4UyR6199O4RFnTmhBXKc+4Y9x0eUZGFNhzFFqhG/DPWzn6tjxcvVwkyt/H4e7QDdYMqdXjfzvBokWMM5hsHKQZRMgTMxsipPgebXnXkCrLhtl2k505LaIc6rI5w/+mMoO/DTKGc4V3kuxs8+uw3HFcrGM5ezaTP2TUb31OwGTsZ5iTqXDsZQhaKy4Q8NuXmXqCSO8KuuOr++FrpwNL+rVivqueuxJ/a37o4X4rW6sebXQ3mMF1N19P0H2x/7XsUMUv/bID4Co1DsSrTsShnrKX0l6wrNJm7Xv5bdiJGGTuf8560RiO/myrjzG8aGwrnTNG78+LcoyZUp1BSc4J+Uwf6aNUYohvk0U3pj97zPaie/5gPWxi1C3IdUBcpG9xP57QdBs6NPEDD9jHxzhnwdP5GlH1iPv+Vflpktho7jrym5MEzRm/SxPk1Nhoq5bYromp5e3QTasJFW29Gi6dcCe6AvmzqnvPI2ZXZ4v4K3ljuMxZqmQMLUctv+TN3wN31TF2o9XwPtwZN1+vIS1YMs5zvQVuaOUV3Gc2KOdNN4Bmh7/kYDYyc5KImZpL9AmuPdssgVqz+SOhKV8sKZ4xDKqk7psi0yAO7Ifzad5hOdcgguGEm0nWigrsWPBjEy058B3JLF4vEp+8uf2gj7NRxaeB6D/r8GW7m9sPtWi378C6Sl1eoEGMuoCUrVi6QAHn1oyJ63tSjb03Oc3qAoojdkpqohuW9tDs3a20fzPRNLY6wSVLrbBbP/E8DeR4z6W3h4Ga379iyRPT+CFGsrR4xrgBNl6EmXKq4xLUxUyMT+UaVeJnnZFCRnfXjhlkvnxOQQHuWBk1obUrXNLje/QfKY/nNqU1Tj4Z3R2TgD+ygnayiPHz27G8BhMDcXstxrQJLzaOegzX1v3JVPn/AwCdcpCN"
 }
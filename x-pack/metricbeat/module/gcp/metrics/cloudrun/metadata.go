@@ -0,0 +1,59 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudrun
+
+import (
+	"context"
+
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp"
+)
+
+const (
+	// serviceName is the value reported in cloud.service.name for Cloud Run events.
+	serviceName = "cloudrun"
+
+	locationLabel = "location"
+)
+
+// NewMetadataService returns the specific Metadata service for a GCP Cloud Run resource.
+func NewMetadataService() gcp.MetadataService {
+	return &metadataCollector{}
+}
+
+type metadataCollector struct{}
+
+// Metadata implements googlecloud.MetadataCollector to the known set of labels from a Cloud Run TimeSeries single point of data.
+// Cloud Run revisions are regional, so the location resource label is mapped into cloud.region.
+func (s *metadataCollector) Metadata(ctx context.Context, resp *monitoringpb.TimeSeries) (gcp.MetadataCollectorData, error) {
+	stackdriverLabels := gcp.NewStackdriverMetadataServiceForTimeSeries(resp)
+
+	metadataCollectorData, err := stackdriverLabels.Metadata(ctx, resp)
+	if err != nil {
+		return gcp.MetadataCollectorData{}, err
+	}
+
+	_, _ = metadataCollectorData.ECS.Put(gcp.ECSCloud+".service.name", serviceName)
+
+	if region := s.region(resp); region != "" {
+		_, _ = metadataCollectorData.ECS.Put(gcp.ECSCloud+"."+gcp.ECSCloudRegion, region)
+	}
+
+	return metadataCollectorData, nil
+}
+
+// ID returns the Stackdriver canonical ID, which already includes the service and revision resource labels.
+func (s *metadataCollector) ID(ctx context.Context, in *gcp.MetadataCollectorInputData) (string, error) {
+	return gcp.NewStackdriverMetadataServiceForTimeSeries(in.TimeSeries).ID(ctx, in)
+}
+
+func (s *metadataCollector) region(ts *monitoringpb.TimeSeries) string {
+	if ts.Resource != nil && ts.Resource.Labels != nil {
+		return ts.Resource.Labels[locationLabel]
+	}
+
+	return ""
+}
@@ -0,0 +1,84 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/googleapis/api/metric"
+	"google.golang.org/genproto/googleapis/api/monitoredres"
+	monitoring "google.golang.org/genproto/googleapis/monitoring/v3"
+	"gotest.tools/assert"
+)
+
+var fake = &monitoring.TimeSeries{
+	Resource: &monitoredres.MonitoredResource{
+		Type: "cloud_run_revision",
+		Labels: map[string]string{
+			"configuration_name": "hello",
+			"location":           "us-central1",
+			"project_id":         "elastic-metricbeat",
+			"revision_name":      "hello-00002-zik",
+			"service_name":       "hello",
+		},
+	},
+	Metric: &metric.Metric{
+		Labels: map[string]string{
+			"response_code":       "200",
+			"response_code_class": "2xx",
+		},
+		Type: "run.googleapis.com/request_count",
+	},
+	MetricKind: metric.MetricDescriptor_DELTA,
+	ValueType:  metric.MetricDescriptor_INT64,
+	Points: []*monitoring.Point{{
+		Value: &monitoring.TypedValue{
+			Value: &monitoring.TypedValue_Int64Value{Int64Value: 12},
+		},
+		Interval: &monitoring.TimeInterval{
+			StartTime: &timestamp.Timestamp{
+				Seconds: 1569932640,
+			},
+			EndTime: &timestamp.Timestamp{
+				Seconds: 1569932700,
+			},
+		},
+	}},
+}
+
+func TestRegion(t *testing.T) {
+	m := &metadataCollector{}
+	assert.Equal(t, "us-central1", m.region(fake))
+	assert.Equal(t, "", m.region(&monitoring.TimeSeries{}))
+}
+
+func TestMetadata(t *testing.T) {
+	m := NewMetadataService()
+
+	metadata, err := m.Metadata(context.Background(), fake)
+	assert.NilError(t, err)
+
+	region, err := metadata.ECS.GetValue("cloud.region")
+	assert.NilError(t, err)
+	assert.Equal(t, "us-central1", region)
+
+	service, err := metadata.ECS.GetValue("cloud.service.name")
+	assert.NilError(t, err)
+	assert.Equal(t, "cloudrun", service)
+
+	account, err := metadata.ECS.GetValue("cloud.account.id")
+	assert.NilError(t, err)
+	assert.Equal(t, "elastic-metricbeat", account)
+
+	serviceName, err := metadata.Labels.GetValue("resource.service_name")
+	assert.NilError(t, err)
+	assert.Equal(t, "hello", serviceName)
+
+	revisionName, err := metadata.Labels.GetValue("resource.revision_name")
+	assert.NilError(t, err)
+	assert.Equal(t, "hello-00002-zik", revisionName)
+}
@@ -6,6 +6,7 @@ package metrics
 
 import (
 	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/metrics/cloudrun"
 	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/metrics/cloudsql"
 	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/metrics/compute"
 )
@@ -18,6 +19,8 @@ func NewMetadataServiceForConfig(c config, serviceName string) (gcp.MetadataServ
 		return compute.NewMetadataService(c.ProjectID, c.Zone, c.Region, c.Regions, c.opt...)
 	case gcp.ServiceCloudSQL:
 		return cloudsql.NewMetadataService(c.ProjectID, c.Zone, c.Region, c.Regions, c.opt...)
+	case gcp.ServiceCloudRun:
+		return cloudrun.NewMetadataService(), nil
 	default:
 		return nil, nil
 	}
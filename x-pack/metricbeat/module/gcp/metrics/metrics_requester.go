@@ -146,6 +146,8 @@ func getServiceLabelFor(serviceName string) string {
 		return gcp.CloudSQLResourceLabel
 	case gcp.ServiceRedis:
 		return gcp.RedisResourceLabel
+	case gcp.ServiceCloudRun:
+		return gcp.CloudRunResourceLabel
 	default:
 		return gcp.DefaultResourceLabel
 	}
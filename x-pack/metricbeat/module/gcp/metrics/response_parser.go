@@ -249,6 +249,13 @@ var reMapping = map[string]string{
 	"cluster.job.duration.value":                     "cluster.job.duration.value",
 	"cluster.operation.completion_time.value":        "cluster.operation.completion_time.value",
 	"cluster.operation.duration.value":               "cluster.operation.duration.value",
+
+	// gcp.cloudrun metricset
+	"request_count.value":                    "request.count",
+	"request_latencies.value":                "request_latencies.value",
+	"container.instance_count.value":         "container.instance.count",
+	"container.billable_instance_time.value": "container.billable_instance_time.sec",
+	"container.memory.utilizations.value":    "container.memory.utilizations.value",
 }
 
 func remap(l *logp.Logger, s string) string {
@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package connector
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	mux := kafka_connect.CreateTestMuxer()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, kafka_connect.GetConfig([]string{"connector"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := kafka_connect.CreateTestMuxer()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := kafka_connect.GetConfig([]string{"connector"}, server.URL)
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+
+	assert.Len(t, events, 2)
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+
+	connectors := map[string]mapstr.M{}
+	for _, event := range events {
+		name, _ := event.MetricSetFields.GetValue("name")
+		connectors[name.(string)] = event.MetricSetFields
+	}
+
+	assert.Equal(t, mapstr.M{
+		"name":      "local-file-sink",
+		"type":      "sink",
+		"class":     "org.apache.kafka.connect.file.FileStreamSinkConnector",
+		"state":     "RUNNING",
+		"worker_id": "10.0.0.12:8083",
+		"tasks": mapstr.M{
+			"count":      2,
+			"running":    1,
+			"paused":     0,
+			"failed":     1,
+			"unassigned": 0,
+			"restarting": 0,
+		},
+	}, connectors["local-file-sink"])
+
+	state, _ := connectors["local-file-source"].GetValue("state")
+	assert.Equal(t, "PAUSED", state)
+	paused, _ := connectors["local-file-source"].GetValue("tasks.paused")
+	assert.Equal(t, 1, paused)
+}
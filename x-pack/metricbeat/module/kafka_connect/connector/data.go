@@ -0,0 +1,47 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package connector
+
+import (
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// taskStates are the states a task can be reported in.
+var taskStates = []string{"running", "paused", "failed", "unassigned", "restarting"}
+
+func eventMapping(name string, connector kafka_connect.Connector) mb.Event {
+	tasks := mapstr.M{
+		"count": len(connector.Status.Tasks),
+	}
+	for _, state := range taskStates {
+		tasks[state] = 0
+	}
+	for _, task := range connector.Status.Tasks {
+		state := strings.ToLower(task.State.State)
+		if count, ok := tasks[state].(int); ok {
+			tasks[state] = count + 1
+		}
+	}
+
+	fields := mapstr.M{
+		"name":      name,
+		"type":      connector.Type(),
+		"state":     connector.Status.Connector.State,
+		"worker_id": connector.Status.Connector.WorkerID,
+		"tasks":     tasks,
+	}
+	if class := connector.Class(); class != "" {
+		fields["class"] = class
+	}
+	if trace := connector.Status.Connector.Trace; trace != "" {
+		fields["trace"] = trace
+	}
+
+	return mb.Event{MetricSetFields: fields}
+}
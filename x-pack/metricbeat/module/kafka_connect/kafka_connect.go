@@ -0,0 +1,90 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package kafka_connect
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/connectors"
+
+	// Expanding the list of connectors returns the status and configuration
+	// of all of them in a single request. Available since Kafka 2.3.
+	defaultQueryParams = "expand=status&expand=info"
+)
+
+// HostParser parses the address of the Kafka Connect REST API.
+var HostParser = parse.URLHostParserBuilder{
+	DefaultScheme: defaultScheme,
+	DefaultPath:   defaultPath,
+	QueryParams:   defaultQueryParams,
+}.Build()
+
+// Connector is an entry of the expanded list of connectors.
+type Connector struct {
+	Info   ConnectorInfo   `json:"info"`
+	Status ConnectorStatus `json:"status"`
+}
+
+// ConnectorInfo contains the configuration of a connector.
+type ConnectorInfo struct {
+	Name   string            `json:"name"`
+	Config map[string]string `json:"config"`
+	Type   string            `json:"type"`
+}
+
+// ConnectorStatus contains the state of a connector and its tasks.
+type ConnectorStatus struct {
+	Name      string      `json:"name"`
+	Connector State       `json:"connector"`
+	Tasks     []TaskState `json:"tasks"`
+	Type      string      `json:"type"`
+}
+
+// State is the state of a connector or a task in a worker.
+type State struct {
+	State    string `json:"state"`
+	WorkerID string `json:"worker_id"`
+	Trace    string `json:"trace,omitempty"`
+}
+
+// TaskState is the state of a task of a connector.
+type TaskState struct {
+	ID int `json:"id"`
+	State
+}
+
+// Class returns the class of the connector, as configured in connector.class.
+func (c Connector) Class() string {
+	return c.Info.Config["connector.class"]
+}
+
+// Type returns the type of the connector, sink or source.
+func (c Connector) Type() string {
+	if c.Status.Type != "" {
+		return c.Status.Type
+	}
+	return c.Info.Type
+}
+
+// FetchConnectors requests the status of all the connectors of the cluster.
+func FetchConnectors(http *helper.HTTP) (map[string]Connector, error) {
+	content, err := http.FetchContent()
+	if err != nil {
+		return nil, err
+	}
+
+	var connectors map[string]Connector
+	if err := json.Unmarshal(content, &connectors); err != nil {
+		return nil, fmt.Errorf("error decoding connectors: %w", err)
+	}
+	return connectors, nil
+}
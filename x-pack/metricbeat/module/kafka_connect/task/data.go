@@ -0,0 +1,32 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package task
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func eventsMapping(name string, connector kafka_connect.Connector) []mb.Event {
+	events := make([]mb.Event, 0, len(connector.Status.Tasks))
+	for _, task := range connector.Status.Tasks {
+		fields := mapstr.M{
+			"id":        task.ID,
+			"state":     task.State.State,
+			"worker_id": task.WorkerID,
+			"connector": mapstr.M{
+				"name": name,
+				"type": connector.Type(),
+			},
+		}
+		if task.Trace != "" {
+			fields["trace"] = task.Trace
+		}
+
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+	return events
+}
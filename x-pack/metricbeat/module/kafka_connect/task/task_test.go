@@ -0,0 +1,60 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package task
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/kafka_connect"
+)
+
+func TestData(t *testing.T) {
+	mux := kafka_connect.CreateTestMuxer()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, kafka_connect.GetConfig([]string{"task"}, server.URL))
+	if err := mbtest.WriteEventsReporterV2Error(f, t, ""); err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := kafka_connect.CreateTestMuxer()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := kafka_connect.GetConfig([]string{"task"}, server.URL)
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+
+	assert.Len(t, events, 3)
+	mbtest.TestMetricsetFieldsDocumented(t, metricSet, events)
+
+	var failed int
+	for _, event := range events {
+		state, _ := event.MetricSetFields.GetValue("state")
+		if state != "FAILED" {
+			continue
+		}
+		failed++
+
+		connector, _ := event.MetricSetFields.GetValue("connector.name")
+		assert.Equal(t, "local-file-sink", connector)
+		id, _ := event.MetricSetFields.GetValue("id")
+		assert.Equal(t, 1, id)
+		trace, _ := event.MetricSetFields.GetValue("trace")
+		assert.Contains(t, trace, "ConnectException")
+	}
+	assert.Equal(t, 1, failed)
+}
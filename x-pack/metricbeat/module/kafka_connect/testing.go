@@ -0,0 +1,33 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package kafka_connect
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+func CreateTestMuxer() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.Handle("/connectors", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input, _ := ioutil.ReadFile("../_meta/testdata/connectors.json")
+		_, err := w.Write(input)
+		if err != nil {
+			fmt.Println("error writing response on mock server")
+		}
+	}))
+
+	return mux
+}
+
+func GetConfig(metricsets []string, host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "kafka_connect",
+		"metricsets": metricsets,
+		"hosts":      []string{host},
+	}
+}
@@ -19,5 +19,5 @@ func init() {
 // AssetSql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/sql.
 func AssetSql() string {
-	return "eJy0k0Fu8jAQhfc5xRPLX4IDZPEvqi4REuIAlWM/wMXJgD2mze0rEoJSpa1AVb18npnvy0Se48C2RDqFAlCvgSVmm/VyVgCRgSaxREU1BeCYbPRH9dKU+F8AwGa9RC0uB2JLtXsm1NTobcI2Sg3TVTijpjKJBbD1DC6VXfMcjak5wC9H2yNL7KLk4zUZ1497XPRnxls8tB7Yvkl0o/wL6eE8dzOQEx1UwHfarITuiVNmbBcTahf/Drq+jBhYHddKCLQ6LG5KHS6aXDN6u/g3MZDqlVZHcR+89LdOchV4n96qZ9z+4lWO7nutpNE3u4etHtraSpr59fNxNiHzLrNKJNA0f6v21EN+1Pr8kj4CAAD//07X+ko="
+	return "eJy0k8Fu4jAQhu95il8cV4IHyGEPqz0iJMTeV479Ay52HOwxbd6+IsFVKtqKqKp8++2Z75uJssSJfY10dhUgVhxrLHbb9aICIh1VYo2GoirAMOloO7GhrfG7AoDddg0fTHbEnqKPTPCUaHXCPgYPNbwwSlSjEitgb+lMqofiJVrlWeDXI33HGocYcndLpu+nNSbaC+NbXEpP7J9DNJP8A+ly/g49kBMNJIAv1FkIORLnzNiv7qhD/D3o9tqisAauDs5RS1ncPbVctNkzWr36NWk4jh2aJ2qZxGPwf7w1ITeOj+ltRkZhFjmaz7WSRNseZlvN2tomtMvb+Lgol/mQWROCo2p/Vu3PCJmhJdYzifLdbDGj5MHv+K8wvvR6/4e/DgCk3SUk"
 }
@@ -7,6 +7,8 @@ package query
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -32,10 +34,24 @@ func init() {
 	)
 }
 
+// represents the types values of a query can be converted to
+const (
+	numericValueType   = "numeric"
+	boolValueType      = "bool"
+	timestampValueType = "timestamp"
+)
+
 // Single query
 type query struct {
 	Query          string `config:"query" validate:"nonzero,required"`
 	ResponseFormat string `config:"response_format" validate:"nonzero,required"`
+
+	// ValueType is the type the values returned by the query are converted to.
+	ValueType string `config:"value_type"`
+
+	// MergeKey is the column used to merge the rows of table queries into a
+	// single event per value of the column, when results are merged.
+	MergeKey string `config:"merge_key"`
 }
 
 // Metricset configuration
@@ -90,10 +106,27 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		b.Config.ResponseFormat = variableResponseFormat
 	}
 
-	for _, q := range b.Config.Queries {
+	for i, q := range b.Config.Queries {
 		if q.ResponseFormat != variableResponseFormat && q.ResponseFormat != tableResponseFormat {
 			return nil, fmt.Errorf("invalid sql_response_format value: %s", q.ResponseFormat)
 		}
+
+		switch q.ValueType {
+		case "", numericValueType, boolValueType, timestampValueType:
+		default:
+			return nil, fmt.Errorf("invalid value_type value: %s", q.ValueType)
+		}
+
+		if q.MergeKey != "" {
+			if !b.Config.MergeResults {
+				return nil, fmt.Errorf("merge_key can only be used when merge_results is enabled")
+			}
+			if q.ResponseFormat != tableResponseFormat {
+				return nil, fmt.Errorf("merge_key can only be used with queries in %s response format", tableResponseFormat)
+			}
+			// Column names are returned in lowercase.
+			b.Config.Queries[i].MergeKey = strings.ToLower(q.MergeKey)
+		}
 	}
 
 	if b.Config.Query == "" && len(b.Config.Queries) == 0 {
@@ -125,7 +158,7 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 		queries = append(queries, one_query)
 	}
 
-	merged := mapstr.M{}
+	merged := newMergedResults(m.Logger())
 
 	for _, q := range queries {
 		if q.ResponseFormat == tableResponseFormat {
@@ -136,17 +169,17 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 			}
 
 			for _, ms := range mss {
+				convertValues(ms, q.ValueType, q.MergeKey, m.Logger())
+
 				if m.Config.MergeResults {
-					if len(mss) > 1 {
+					if q.MergeKey != "" {
+						if err := merged.addKeyed(ms, q.MergeKey); err != nil {
+							return fmt.Errorf("can not merge query results: %w", err)
+						}
+					} else if len(mss) > 1 {
 						return fmt.Errorf("can not merge query resulting with more than one rows: %s", q)
 					} else {
-						for k, v := range ms {
-							_, ok := merged[k]
-							if ok {
-								m.Logger().Warn("overwriting duplicate metrics: ", k)
-							}
-							merged[k] = v
-						}
+						merged.add(ms)
 					}
 				} else {
 					// Report immediately for non-merged cases.
@@ -159,15 +192,10 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 			if err != nil {
 				return fmt.Errorf("fetch variable mode failed: %w", err)
 			}
+			convertValues(ms, q.ValueType, "", m.Logger())
 
 			if m.Config.MergeResults {
-				for k, v := range ms {
-					_, ok := merged[k]
-					if ok {
-						m.Logger().Warn("overwriting duplicate metrics: ", k)
-					}
-					merged[k] = v
-				}
+				merged.add(ms)
 			} else {
 				// Report immediately for non-merged cases.
 				m.reportEvent(ms, reporter, q.Query)
@@ -176,7 +204,9 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 	}
 	if m.Config.MergeResults {
 		// Report here for merged case.
-		m.reportEvent(merged, reporter, "")
+		for _, ms := range merged.events() {
+			m.reportEvent(ms, reporter, "")
+		}
 	}
 
 	return nil
@@ -230,6 +260,7 @@ func inferTypeFromMetrics(ms mapstr.M) mapstr.M {
 	numericMetrics := mapstr.M{}
 	stringMetrics := mapstr.M{}
 	boolMetrics := mapstr.M{}
+	timestampMetrics := mapstr.M{}
 
 	for k, v := range ms {
 		switch v.(type) {
@@ -239,6 +270,8 @@ func inferTypeFromMetrics(ms mapstr.M) mapstr.M {
 			stringMetrics[k] = v
 		case bool:
 			boolMetrics[k] = v
+		case time.Time:
+			timestampMetrics[k] = v
 		case nil:
 		//Ignore because a nil has no data type and thus cannot be indexed
 		default:
@@ -258,6 +291,10 @@ func inferTypeFromMetrics(ms mapstr.M) mapstr.M {
 		ret["bool"] = boolMetrics
 	}
 
+	if len(timestampMetrics) > 0 {
+		ret["timestamp"] = timestampMetrics
+	}
+
 	return ret
 }
 
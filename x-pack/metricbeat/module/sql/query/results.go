@@ -0,0 +1,165 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Layouts used to parse timestamps returned as strings.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// convertValues converts the values of a query result to the given type. The
+// value of the skip column is not converted. Values that cannot be converted
+// are removed from the result.
+func convertValues(ms mapstr.M, valueType string, skip string, logger *logp.Logger) {
+	if valueType == "" {
+		return
+	}
+
+	for k, v := range ms {
+		if k == skip || v == nil {
+			continue
+		}
+
+		converted, err := convertValue(v, valueType)
+		if err != nil {
+			logger.Debugf("dropping value of %s: %v", k, err)
+			delete(ms, k)
+			continue
+		}
+		ms[k] = converted
+	}
+}
+
+func convertValue(v interface{}, valueType string) (interface{}, error) {
+	switch valueType {
+	case numericValueType:
+		switch v := v.(type) {
+		case float64:
+			return v, nil
+		case bool:
+			if v {
+				return float64(1), nil
+			}
+			return float64(0), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not numeric", v)
+			}
+			return f, nil
+		}
+	case boolValueType:
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case float64:
+			return v != 0, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "1", "t", "true", "y", "yes", "on":
+				return true, nil
+			case "0", "f", "false", "n", "no", "off":
+				return false, nil
+			}
+			return nil, fmt.Errorf("'%s' is not a boolean", v)
+		}
+	case timestampValueType:
+		switch v := v.(type) {
+		case time.Time:
+			return v, nil
+		case float64:
+			// Numeric timestamps are considered seconds since epoch.
+			sec, dec := int64(v), v-float64(int64(v))
+			return time.Unix(sec, int64(dec*float64(time.Second))).UTC(), nil
+		case string:
+			for _, layout := range timestampLayouts {
+				if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+					return t.UTC(), nil
+				}
+			}
+			return nil, fmt.Errorf("'%s' is not a timestamp", v)
+		}
+	}
+	return nil, fmt.Errorf("unsupported value %v for %s type", v, valueType)
+}
+
+// mergedResults merges the results of multiple queries. Results of queries
+// without merge key are merged in a single event, results of queries with
+// merge key are merged in an event per value of the key.
+type mergedResults struct {
+	logger *logp.Logger
+
+	merged mapstr.M
+	keyed  map[string]mapstr.M
+	keys   []string
+}
+
+func newMergedResults(logger *logp.Logger) *mergedResults {
+	return &mergedResults{
+		logger: logger,
+		merged: mapstr.M{},
+		keyed:  map[string]mapstr.M{},
+	}
+}
+
+// add merges a result in the event of the results without merge key.
+func (r *mergedResults) add(ms mapstr.M) {
+	r.mergeInto(r.merged, ms, "")
+}
+
+// addKeyed merges a result in the event of the value of its key column.
+func (r *mergedResults) addKeyed(ms mapstr.M, key string) error {
+	value, found := ms[key]
+	if !found || value == nil {
+		return fmt.Errorf("merge key %s not found in query results", key)
+	}
+
+	k := fmt.Sprint(value)
+	event, found := r.keyed[k]
+	if !found {
+		event = mapstr.M{}
+		r.keyed[k] = event
+		r.keys = append(r.keys, k)
+	}
+	r.mergeInto(event, ms, key)
+	return nil
+}
+
+func (r *mergedResults) mergeInto(dst, src mapstr.M, key string) {
+	for k, v := range src {
+		_, ok := dst[k]
+		if ok && k != key {
+			r.logger.Warn("overwriting duplicate metrics: ", k)
+		}
+		dst[k] = v
+	}
+}
+
+// events returns the merged events. If there are results merged by key, an
+// event is returned for each key, and one more only if there are also other
+// results.
+func (r *mergedResults) events() []mapstr.M {
+	var events []mapstr.M
+	if len(r.merged) > 0 || len(r.keyed) == 0 {
+		events = append(events, r.merged)
+	}
+	for _, k := range r.keys {
+		events = append(events, r.keyed[k])
+	}
+	return events
+}
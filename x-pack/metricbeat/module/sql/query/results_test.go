@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestConvertValues(t *testing.T) {
+	logger := logp.NewLogger("test")
+
+	cases := []struct {
+		valueType string
+		values    mapstr.M
+		expected  mapstr.M
+	}{
+		{
+			valueType: "",
+			values:    mapstr.M{"a": "1", "b": 2.0},
+			expected:  mapstr.M{"a": "1", "b": 2.0},
+		},
+		{
+			valueType: numericValueType,
+			values:    mapstr.M{"a": "1.5", "b": 2.0, "c": true, "d": "foo", "e": nil, "key": "10"},
+			expected:  mapstr.M{"a": 1.5, "b": 2.0, "c": 1.0, "e": nil, "key": "10"},
+		},
+		{
+			valueType: boolValueType,
+			values:    mapstr.M{"a": "ON", "b": 0.0, "c": true, "d": "foo", "e": "off"},
+			expected:  mapstr.M{"a": true, "b": false, "c": true, "e": false},
+		},
+		{
+			valueType: timestampValueType,
+			values: mapstr.M{
+				"a": "2020-06-07T20:50:12.632975Z",
+				"b": "2020-06-07 20:50:12",
+				"c": 1591563012.0,
+				"d": "foo",
+			},
+			expected: mapstr.M{
+				"a": time.Date(2020, 6, 7, 20, 50, 12, 632975000, time.UTC),
+				"b": time.Date(2020, 6, 7, 20, 50, 12, 0, time.UTC),
+				"c": time.Date(2020, 6, 7, 20, 50, 12, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.valueType, func(t *testing.T) {
+			convertValues(c.values, c.valueType, "key", logger)
+			assert.Equal(t, c.expected, c.values)
+		})
+	}
+}
+
+func TestMergedResults(t *testing.T) {
+	logger := logp.NewLogger("test")
+
+	t.Run("without merge key", func(t *testing.T) {
+		merged := newMergedResults(logger)
+		merged.add(mapstr.M{"a": 1.0})
+		merged.add(mapstr.M{"b": 2.0})
+
+		assert.Equal(t, []mapstr.M{{"a": 1.0, "b": 2.0}}, merged.events())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		merged := newMergedResults(logger)
+		assert.Equal(t, []mapstr.M{{}}, merged.events())
+	})
+
+	t.Run("with merge key", func(t *testing.T) {
+		merged := newMergedResults(logger)
+		assert.NoError(t, merged.addKeyed(mapstr.M{"datname": "db1", "size": 10.0}, "datname"))
+		assert.NoError(t, merged.addKeyed(mapstr.M{"datname": "db2", "size": 20.0}, "datname"))
+		assert.NoError(t, merged.addKeyed(mapstr.M{"datname": "db1", "connections": 3.0}, "datname"))
+		assert.NoError(t, merged.addKeyed(mapstr.M{"db": "db2", "connections": 5.0}, "db"))
+		merged.add(mapstr.M{"version": "14"})
+
+		assert.Equal(t, []mapstr.M{
+			{"version": "14"},
+			{"datname": "db1", "size": 10.0, "connections": 3.0},
+			{"datname": "db2", "db": "db2", "size": 20.0, "connections": 5.0},
+		}, merged.events())
+	})
+
+	t.Run("missing merge key", func(t *testing.T) {
+		merged := newMergedResults(logger)
+		assert.Error(t, merged.addKeyed(mapstr.M{"size": 10.0}, "datname"))
+	})
+}
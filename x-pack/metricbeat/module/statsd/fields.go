@@ -19,5 +19,5 @@ func init() {
 // AssetStatsd returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/statsd.
 func AssetStatsd() string {
-	return "eJyUkTtqxTAQRXut4qLGYHAWoCKbyAKMIo2NEv2QxoV3H/wL4uFXeMp7z4gzaMAvrQqVNVcrAHbsSUF+7YEUgKVqisvsUlT4FABwlAjJLp4EUMiTrqQwawFMjrytaicHRB2oeX8bXvPGlrTkM2lX2rWu/zBpidz9N9d2+v4hw018BOPR+hTn+24MOmcX5xOUGykb9Obaa86rdyEq9U62fyo6+aTflK+m/TPNQFycaS3bX/oLAAD//wYwi2Y="
+	return "eJzElcGK2zwQx+9+ij+5LIRsHsCHDz7YQ6FQCtt7pJUnjmpFY0byBr99kewsSnDbLBiKL2Jm9J+fZzTSMzoaa4SoY2gqINroqMbmNRs2FdBQMGL7aNnX+K8CgMmJMzeDowoQcqQD1Wh1BRwtuSbUOfIZXp+p0E/GOPYpVnjoZ0u5pdxG7+Tjh3VpJ7BIeP1euM2wL5PUDkI9S6QGlxN5qIlrn51BwQaQ12+Omn0hc09XEuZ63XiulB2NF5amunH9iTV9P5Ic+Ih4ogl5v5j2xCGm1XqZv8yKCBRh/d8AdNsKtTqpHToa1+P4SiOGQA0iT0cknS6dOpbLEZZxerEsNq7I8X1WvGnGDsqznLVTYIFyfFHLPIEHMXRI+ddDes2iWSGP1QPnRDuSuDLG/0lzorirDYmwqB3URYu3vk1L6488VSsMxlAIRcU+qkXybg0dzIlMt864z5LIkuuP/bqz961o5g34fjF5urSGsF76dEMOYRFgB8XdXUeN2GjNdQYG33m+ePUv76nf1OwK8bTdGx58fCrEJgJ++0mmfGAmQx6YGo59u+w7nHXfW9/OgZsUuake+of57cxAJGEJdvtZ0KNjHR8j3X4O80xRrCkpy7f+1wCkwTlK"
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/metricbeat/helper/server"
+	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -22,6 +23,11 @@ var errInvalidPacket = errors.New("invalid statsd packet")
 type metricProcessor struct {
 	registry      *registry
 	reservoirSize int
+
+	// reportEvents enables the reporting of DogStatsD events and service
+	// checks, pending holds the ones received since the last report.
+	reportEvents bool
+	pending      []mb.Event
 }
 
 type statsdMetric struct {
@@ -30,6 +36,28 @@ type statsdMetric struct {
 	sampleRate string
 	value      string
 	tags       map[string]string
+
+	// event is set for DogStatsD events and service checks.
+	event *statsdEvent
+}
+
+// statsdEvent is a DogStatsD event or service check.
+type statsdEvent struct {
+	timestamp time.Time
+	message   string
+	fields    mapstr.M
+}
+
+const (
+	metricTypeEvent        = "_e"
+	metricTypeServiceCheck = "_sc"
+)
+
+var serviceCheckStatuses = map[string]string{
+	"0": "ok",
+	"1": "warning",
+	"2": "critical",
+	"3": "unknown",
 }
 
 func splitTags(rawTags []byte, kvSep []byte) map[string]string {
@@ -86,12 +114,133 @@ func parseSingle(b []byte) (statsdMetric, error) {
 	return s, nil
 }
 
+// parseEventFields parses the optional fields of DogStatsD events and service
+// checks, in the format <key>:<value>, tags being in the format #<k>:<v>,<k>:<v>.
+// Fields not in known are ignored.
+func parseEventFields(s *statsdMetric, parts [][]byte, known map[byte]string) error {
+	for _, part := range parts {
+		if len(part) > 0 && part[0] == '#' {
+			s.tags = splitTags(part[1:], []byte(":"))
+			continue
+		}
+		if len(part) < 2 || part[1] != ':' {
+			return errInvalidPacket
+		}
+		value := string(part[2:])
+		if part[0] == 'd' {
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errInvalidPacket
+			}
+			s.event.timestamp = time.Unix(ts, 0).UTC()
+			continue
+		}
+		if field, found := known[part[0]]; found {
+			s.event.fields[field] = value
+		}
+	}
+	return nil
+}
+
+func parseEvent(b []byte) (statsdMetric, error) {
+	// format: _e{<title length>,<text length>}:<title>|<text>[|d:<timestamp>][|h:<hostname>]
+	//   [|k:<aggregation key>][|p:<priority>][|s:<source type>][|t:<alert type>][|#<k>:<v>,<k>:<v>]
+	s := statsdMetric{metricType: metricTypeEvent}
+
+	header := bytes.SplitN(b[len("_e{"):], []byte("}:"), 2)
+	if len(header) != 2 {
+		return s, errInvalidPacket
+	}
+	lengths := bytes.SplitN(header[0], []byte(","), 2)
+	if len(lengths) != 2 {
+		return s, errInvalidPacket
+	}
+	titleLen, err := strconv.Atoi(string(lengths[0]))
+	if err != nil {
+		return s, errInvalidPacket
+	}
+	textLen, err := strconv.Atoi(string(lengths[1]))
+	if err != nil {
+		return s, errInvalidPacket
+	}
+
+	body := header[1]
+	if titleLen < 0 || textLen < 0 || len(body) < titleLen+1+textLen || body[titleLen] != '|' {
+		return s, errInvalidPacket
+	}
+	s.name = string(body[:titleLen])
+	text := body[titleLen+1 : titleLen+1+textLen]
+	rest := body[titleLen+1+textLen:]
+
+	s.event = &statsdEvent{
+		message: string(bytes.ReplaceAll(text, []byte(`\n`), []byte("\n"))),
+		fields:  mapstr.M{"title": s.name},
+	}
+	if len(rest) > 0 {
+		if rest[0] != '|' {
+			return s, errInvalidPacket
+		}
+		err := parseEventFields(&s, bytes.Split(rest[1:], []byte("|")), map[byte]string{
+			'h': "hostname",
+			'k': "aggregation_key",
+			'p': "priority",
+			's': "source_type",
+			't': "alert_type",
+		})
+		if err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+func parseServiceCheck(b []byte) (statsdMetric, error) {
+	// format: _sc|<name>|<status>[|d:<timestamp>][|h:<hostname>][|#<k>:<v>,<k>:<v>][|m:<message>]
+	s := statsdMetric{metricType: metricTypeServiceCheck}
+
+	// The message is always the last field and can contain pipes.
+	var message []byte
+	if i := bytes.Index(b, []byte("|m:")); i >= 0 {
+		message = b[i+len("|m:"):]
+		b = b[:i]
+	}
+
+	parts := bytes.Split(b, []byte("|"))
+	if len(parts) < 3 || len(parts[1]) == 0 {
+		return s, errInvalidPacket
+	}
+	status, found := serviceCheckStatuses[string(parts[2])]
+	if !found {
+		return s, errInvalidPacket
+	}
+
+	s.name = string(parts[1])
+	s.event = &statsdEvent{
+		message: string(bytes.ReplaceAll(message, []byte(`\n`), []byte("\n"))),
+		fields: mapstr.M{
+			"name":   s.name,
+			"status": status,
+		},
+	}
+	err := parseEventFields(&s, parts[3:], map[byte]string{
+		'h': "hostname",
+	})
+	return s, err
+}
+
 // parse will parse a statsd metric into its components
 func parse(b []byte) ([]statsdMetric, error) {
 	metrics := []statsdMetric{}
 	for _, rawMetric := range bytes.Split(b, []byte("\n")) {
 		if len(rawMetric) > 0 {
-			metric, err := parseSingle(rawMetric)
+			parseFunc := parseSingle
+			switch {
+			case bytes.HasPrefix(rawMetric, []byte("_e{")):
+				parseFunc = parseEvent
+			case bytes.HasPrefix(rawMetric, []byte("_sc|")):
+				parseFunc = parseServiceCheck
+			}
+			metric, err := parseFunc(rawMetric)
 			if err != nil {
 				return metrics, err
 			}
@@ -149,7 +298,57 @@ func newMetricProcessor(ttl time.Duration) *metricProcessor {
 	}
 }
 
+// processEvent keeps DogStatsD events and service checks to be reported as
+// separate events, or drops them if reporting them is not enabled.
+func (p *metricProcessor) processEvent(m statsdMetric) {
+	if !p.reportEvents {
+		logger.Debugf("dropping DogStatsD %s `%s`, reporting of events is not enabled", m.metricType, m.name)
+		return
+	}
+
+	key := "event"
+	if m.metricType == metricTypeServiceCheck {
+		key = "service_check"
+	}
+
+	rootFields := mapstr.M{}
+	if m.event.message != "" {
+		rootFields["message"] = m.event.message
+	}
+	if len(m.tags) > 0 {
+		labels := mapstr.M{}
+		for k, v := range m.tags {
+			labels[k] = v
+		}
+		rootFields["labels"] = labels
+	}
+
+	timestamp := m.event.timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	p.pending = append(p.pending, mb.Event{
+		Timestamp:       timestamp,
+		MetricSetFields: mapstr.M{key: m.event.fields},
+		RootFields:      rootFields,
+	})
+}
+
+// PopEvents returns the DogStatsD events and service checks received since the
+// last call.
+func (p *metricProcessor) PopEvents() []mb.Event {
+	events := p.pending
+	p.pending = nil
+	return events
+}
+
 func (p *metricProcessor) processSingle(m statsdMetric) error {
+	if m.event != nil {
+		p.processEvent(m)
+		return nil
+	}
+
 	if len(m.value) < 1 {
 		return nil
 	}
@@ -206,6 +405,13 @@ func (p *metricProcessor) processSingle(m statsdMetric) error {
 			return errors.Wrapf(err, "failed to process histogram `%s` with value `%s`", m.name, m.value)
 		}
 		c.Update(v)
+	case "d":
+		c := p.registry.GetOrNewDistribution(m.name, m.tags)
+		v, err := strconv.ParseFloat(m.value, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to process distribution `%s` with value `%s`", m.name, m.value)
+		}
+		c.SampledUpdate(v, sampleRate)
 	case "s":
 		c := p.registry.GetOrNewSet(m.name, m.tags)
 		c.Add(m.value)
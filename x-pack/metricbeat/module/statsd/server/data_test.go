@@ -6,6 +6,7 @@ package server
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -991,7 +992,89 @@ func TestParseMetrics(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "distribution1:2.5|d|@0.5|#k1:v1",
+			expected: []statsdMetric{
+				{
+					name:       "distribution1",
+					metricType: "d",
+					value:      "2.5",
+					sampleRate: "0.5",
+					tags: map[string]string{
+						"k1": "v1",
+					},
+				},
+			},
+		},
+		/// DogStatsD events and service checks
+		{
+			input: "_e{5,10}:title|some\\ntext|d:1650000000|h:host1|p:low|t:warning|#k1:v1",
+			expected: []statsdMetric{
+				{
+					name:       "title",
+					metricType: "_e",
+					tags: map[string]string{
+						"k1": "v1",
+					},
+					event: &statsdEvent{
+						timestamp: time.Unix(1650000000, 0).UTC(),
+						message:   "some\ntext",
+						fields: mapstr.M{
+							"title":      "title",
+							"hostname":   "host1",
+							"priority":   "low",
+							"alert_type": "warning",
+						},
+					},
+				},
+			},
+		},
+		{
+			input: "_e{7,9}:a|title|some|text",
+			expected: []statsdMetric{
+				{
+					name:       "a|title",
+					metricType: "_e",
+					event: &statsdEvent{
+						message: "some|text",
+						fields: mapstr.M{
+							"title": "a|title",
+						},
+					},
+				},
+			},
+		},
+		{
+			input: "_sc|check1|2|h:host1|#k1:v1|m:failed | details",
+			expected: []statsdMetric{
+				{
+					name:       "check1",
+					metricType: "_sc",
+					tags: map[string]string{
+						"k1": "v1",
+					},
+					event: &statsdEvent{
+						message: "failed | details",
+						fields: mapstr.M{
+							"name":     "check1",
+							"status":   "critical",
+							"hostname": "host1",
+						},
+					},
+				},
+			},
+		},
 		/// errors
+		{
+			input:    "_e{5,20}:title|text",
+			expected: []statsdMetric{},
+			err:      errInvalidPacket,
+		},
+		{
+			input:    "_sc|check1|5",
+			expected: []statsdMetric{},
+			err:      errInvalidPacket,
+		},
 		{
 			input:    "meter1-1.4|m",
 			expected: []statsdMetric{},
@@ -1318,3 +1401,113 @@ func BenchmarkIngest(b *testing.B) {
 	}
 
 }
+
+func TestDistribution(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{"module": "statsd"}).(*MetricSet)
+	testData := []string{
+		"metric01:1|d",
+		"metric01:2|d",
+		"metric01:3|d",
+		"metric01:4|d|@0.5",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	assert.Len(t, events, 1)
+
+	assert.Equal(t, mapstr.M{
+		"metric01": map[string]interface{}{
+			"count":  int64(5),
+			"min":    1.0,
+			"max":    4.0,
+			"sum":    14.0,
+			"mean":   2.8,
+			"median": 2.5,
+			"p75":    3.25,
+			"p95":    3.85,
+			"p99":    3.97,
+			"p99_9":  3.997,
+		},
+	}, roundMetrics(events[0].MetricSetFields))
+
+	// reset
+	events = ms.getEvents()
+	assert.Len(t, events, 1)
+
+	assert.Equal(t, mapstr.M{
+		"metric01": map[string]interface{}{"count": int64(0)},
+	}, events[0].MetricSetFields)
+}
+
+// roundMetrics rounds float values of the metrics to avoid precision issues
+// in comparisons.
+func roundMetrics(fields mapstr.M) mapstr.M {
+	for _, v := range fields {
+		values, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, value := range values {
+			if f, ok := value.(float64); ok {
+				values[k] = math.Round(f*1000) / 1000
+			}
+		}
+	}
+	return fields
+}
+
+func TestEventsDisabled(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{"module": "statsd"}).(*MetricSet)
+	testData := []string{
+		"_e{5,4}:title|text",
+		"_sc|check1|0",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	assert.Len(t, events, 0)
+}
+
+func TestEvents(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{
+		"module":        "statsd",
+		"statsd.events": true,
+	}).(*MetricSet)
+	testData := []string{
+		"_e{5,4}:title|text|d:1650000000|t:error|#k1:v1\n_sc|check1|1|m:slow",
+		"metric01:1|c",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	require.Len(t, events, 3)
+
+	assert.Equal(t, time.Unix(1650000000, 0).UTC(), events[1].Timestamp)
+	assert.Equal(t, mapstr.M{
+		"event": mapstr.M{
+			"title":      "title",
+			"alert_type": "error",
+		},
+	}, events[1].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"message": "text",
+		"labels":  mapstr.M{"k1": "v1"},
+	}, events[1].RootFields)
+
+	assert.Equal(t, mapstr.M{
+		"service_check": mapstr.M{
+			"name":   "check1",
+			"status": "warning",
+		},
+	}, events[2].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"message": "slow",
+	}, events[2].RootFields)
+
+	// events are reported only once
+	events = ms.getEvents()
+	assert.Len(t, events, 1)
+}
@@ -5,6 +5,8 @@
 package server
 
 import (
+	"math"
+	"sort"
 	"time"
 
 	"github.com/rcrowley/go-metrics"
@@ -68,6 +70,44 @@ func (d *deltaGaugeMetric) Value() float64 {
 	return d.value
 }
 
+// distributionMetric aggregates the values of a DogStatsD distribution until
+// they are reported.
+type distributionMetric struct {
+	values []float64
+	count  float64
+	sum    float64
+}
+
+// SampledUpdate adds a sampled value to the distribution
+func (d *distributionMetric) SampledUpdate(val float64, sampleRate float64) {
+	d.values = append(d.values, val)
+	d.count += 1 / sampleRate
+	d.sum += val / sampleRate
+}
+
+func (d *distributionMetric) Reset() {
+	d.values = nil
+	d.count = 0
+	d.sum = 0
+}
+
+// Percentiles returns the percentiles of the values added since the last reset.
+func (d *distributionMetric) Percentiles(ps []float64) []float64 {
+	sorted := make([]float64, len(d.values))
+	copy(sorted, d.values)
+	sort.Float64s(sorted)
+
+	values := make([]float64, len(ps))
+	for i, p := range ps {
+		// Linear interpolation between closest ranks
+		pos := p * float64(len(sorted)-1)
+		lower := int(math.Floor(pos))
+		upper := int(math.Ceil(pos))
+		values[i] = sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+	}
+	return values
+}
+
 // SamplingTimer is a timer that supports sampling
 type samplingTimer struct {
 	metrics.Timer
@@ -223,6 +263,21 @@ func (r *registry) getMetric(metric interface{}) map[string]interface{} {
 		values["5m_rate"] = t.Rate5()
 		values["15m_rate"] = t.Rate15()
 		values["mean_rate"] = t.RateMean()
+	case *distributionMetric:
+		values["count"] = int64(m.count)
+		if len(m.values) > 0 {
+			ps := m.Percentiles([]float64{0, 0.5, 0.75, 0.95, 0.99, 0.999, 1})
+			values["min"] = ps[0]
+			values["max"] = ps[6]
+			values["sum"] = m.sum
+			values["mean"] = m.sum / m.count
+			values["median"] = ps[1]
+			values["p75"] = ps[2]
+			values["p95"] = ps[3]
+			values["p99"] = ps[4]
+			values["p99_9"] = ps[5]
+		}
+		m.Reset()
 	case *setMetric:
 		values["count"] = m.Count()
 		m.Reset()
@@ -363,6 +418,16 @@ func (r *registry) GetOrNewHistogram(name string, tags map[string]string) metric
 	return r.GetOrNewHistogram(name, tags)
 }
 
+func (r *registry) GetOrNewDistribution(name string, tags map[string]string) *distributionMetric {
+	distribution, ok := r.getOrNew(name, tags, func() interface{} { return &distributionMetric{} }).(*distributionMetric)
+	if ok {
+		return distribution
+	}
+
+	r.clearTypeChanged(name, tags)
+	return r.GetOrNewDistribution(name, tags)
+}
+
 func (r *registry) GetOrNewSet(name string, tags map[string]string) *setMetric {
 	setmetric, ok := r.getOrNew(name, tags, func() interface{} { return newSetMetric() }).(*setMetric)
 	if ok {
@@ -56,6 +56,7 @@ func newErrInvalidMapping(metricLabels []string, attrLabels []Label) error {
 type Config struct {
 	TTL      time.Duration   `config:"ttl"`
 	Mappings []StatsdMapping `config:"statsd.mappings"`
+	Events   bool            `config:"statsd.events"`
 }
 
 func defaultConfig() Config {
@@ -92,6 +93,7 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	}
 
 	processor := newMetricProcessor(config.TTL)
+	processor.reportEvents = config.Events
 
 	mappings, err := buildMappings(config.Mappings)
 	if err != nil {
@@ -189,6 +191,12 @@ func (m *MetricSet) getEvents() []*mb.Event {
 			Namespace:       m.Module().Name(),
 		}
 	}
+
+	for _, event := range m.processor.PopEvents() {
+		event := event
+		event.Namespace = m.Module().Name()
+		events = append(events, &event)
+	}
 	return events
 }
 